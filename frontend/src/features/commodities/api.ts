@@ -313,6 +313,7 @@ export type CommodityEventKind =
   | "back_from_service"
   | "service_updated"
   | "deleted"
+  | "merged"
 
 export interface CommodityEventActor {
   id: string
@@ -0,0 +1,323 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// Backup-signing keyring action names. The CLI service path
+// (services/admin) emits the identical strings.
+const (
+	// AuditActionAdminBackupKeyAdd is the audit-row Action emitted when a
+	// public key is added to the backup-signing keyring.
+	AuditActionAdminBackupKeyAdd = "admin.backup_key_add"
+	// AuditActionAdminBackupKeyRevoke is the audit-row Action emitted when a
+	// trusted backup-signing key is revoked.
+	AuditActionAdminBackupKeyRevoke = "admin.backup_key_revoke"
+)
+
+// JSON:API error codes returned by the backup-keyring endpoints.
+const (
+	// AdminBackupKeyInvalidCode signals "the request body does not describe
+	// a usable key": an unparseable public key, a missing label, or an
+	// expiry in the past. Maps to a 422.
+	AdminBackupKeyInvalidCode = "admin.backup_key.invalid"
+	// AdminBackupKeyAlreadyTrustedCode signals "a key with this fingerprint
+	// is already in the keyring" (revoked keys included). Maps to a 409.
+	AdminBackupKeyAlreadyTrustedCode = "admin.backup_key.already_trusted"
+	// AdminBackupKeyNotFoundCode signals "no key with the {fingerprint} path
+	// segment is in the keyring". Maps to a 404.
+	AdminBackupKeyNotFoundCode = "admin.backup_key.not_found"
+)
+
+// BackupKeyAddRequest is the request body for POST /admin/backup-keys.
+type BackupKeyAddRequest struct {
+	// PublicKey is the Ed25519 public key to trust, as a PEM "PUBLIC KEY"
+	// block, standard base64 or hex (the forms GET /backup/public-key and
+	// `inventario backup resign --verify-key` use).
+	PublicKey string `json:"public_key"`
+	// Label is the operator's name for the key (max 200 chars).
+	Label string `json:"label" maxLength:"200"`
+	// ExpiresAt optionally stops trusting the key at that time. Must be in
+	// the future.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// BackupKeyRevokeRequest is the request body for
+// POST /admin/backup-keys/{fingerprint}/revoke. An empty body is accepted.
+type BackupKeyRevokeRequest struct {
+	// Reason is the optional operator-supplied note for the revocation (max
+	// 500 chars).
+	Reason string `json:"reason,omitempty" maxLength:"500"`
+}
+
+// TrustedBackupKeyView is the JSON:API attributes block returned by the
+// backup-keyring endpoints.
+type TrustedBackupKeyView struct {
+	Fingerprint  string     `json:"fingerprint"`
+	PublicKey    string     `json:"public_key"`
+	Label        string     `json:"label"`
+	Active       bool       `json:"active"`
+	AddedAt      time.Time  `json:"added_at"`
+	AddedBy      *string    `json:"added_by,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy    *string    `json:"revoked_by,omitempty"`
+	RevokeReason *string    `json:"revoke_reason,omitempty"`
+}
+
+// TrustedBackupKeyResource is the JSON:API resource block. `type` is
+// "trusted_backup_key" and `id` is the key fingerprint.
+type TrustedBackupKeyResource struct {
+	Type       string               `json:"type"`
+	ID         string               `json:"id"`
+	Attributes TrustedBackupKeyView `json:"attributes"`
+}
+
+// TrustedBackupKeyEnvelope is the single-resource JSON:API envelope returned
+// by add / revoke.
+type TrustedBackupKeyEnvelope struct {
+	Data TrustedBackupKeyResource `json:"data"`
+}
+
+// TrustedBackupKeyListEnvelope is the list JSON:API envelope returned by
+// GET /admin/backup-keys.
+type TrustedBackupKeyListEnvelope struct {
+	Data []TrustedBackupKeyResource `json:"data"`
+}
+
+// adminBackupKeysAPI backs the /admin/backup-keys routes. Like
+// adminWorkersAPI it holds the FactorySet directly: the keyring is a
+// platform-operator control with no tenant scope.
+type adminBackupKeysAPI struct {
+	factorySet   *registry.FactorySet
+	auditService services.AuditLogger
+}
+
+// listBackupKeys returns every key in the backup-signing keyring.
+//
+// @Summary List trusted backup-signing keys (admin)
+// @Description Returns every key in the backup-signing keyring, revoked and expired ones included, in the order they were added. `active` is false for a revoked or expired key. The server's own signing key is always accepted and is not listed. Resource `type` is "trusted_backup_key"; `id` is the key fingerprint.
+// @Tags admin
+// @Produce json-api
+// @Success 200 {object} TrustedBackupKeyListEnvelope "OK"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Account disabled"
+// @Router /admin/backup-keys [get]
+func (api *adminBackupKeysAPI) listBackupKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := api.factorySet.TrustedBackupKeyRegistry.List(r.Context())
+	if err != nil {
+		slog.Error("admin listBackupKeys: failed to list trusted backup keys", "error", err)
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	resources := make([]TrustedBackupKeyResource, 0, len(keys))
+	for _, k := range keys {
+		resources = append(resources, trustedBackupKeyResource(k, now))
+	}
+	api.writeEnvelope(w, http.StatusOK, TrustedBackupKeyListEnvelope{Data: resources})
+}
+
+// addBackupKey adds a public key to the backup-signing keyring so imports
+// and restores accept archives signed with it.
+//
+// @Summary Trust a backup-signing key (admin)
+// @Description Adds an Ed25519 public key (PEM, base64 or hex) to the backup-signing keyring. Imports and restores then accept `.inb` archives signed with it, e.g. from a sibling instance or from before a key rotation. Platform admins only.
+// @Description An unparseable key, a missing label or a past `expires_at` returns 422 with `admin.backup_key.invalid`; a key already in the keyring (even revoked) returns 409 with `admin.backup_key.already_trusted`.
+// @Tags admin
+// @Accept json
+// @Produce json-api
+// @Param data body BackupKeyAddRequest true "Key to trust"
+// @Success 201 {object} TrustedBackupKeyEnvelope "Created"
+// @Failure 400 {object} jsonapi.Errors "Bad Request - invalid body"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Forbidden - platform admin required"
+// @Failure 409 {object} jsonapi.Errors "Conflict - key already in the keyring"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - invalid key, label or expiry"
+// @Router /admin/backup-keys [post]
+func (api *adminBackupKeysAPI) addBackupKey(w http.ResponseWriter, r *http.Request) {
+	actor := appctx.AdminActorFromContext(r.Context())
+	if actor == nil {
+		_ = unauthorizedError(w, r, ErrMissingUserContext)
+		return
+	}
+
+	var req BackupKeyAddRequest
+	if !decodeStrictJSON(w, r, &req, false) {
+		return
+	}
+	key, err := services.NewTrustedBackupKey(req.PublicKey, req.Label, req.ExpiresAt, time.Now())
+	if err != nil {
+		_ = codedUnprocessableEntityError(w, r, err, AdminBackupKeyInvalidCode)
+		return
+	}
+	key.AddedBy = nullableString(actor.ID)
+
+	added, err := api.factorySet.TrustedBackupKeyRegistry.Add(r.Context(), key)
+	switch {
+	case errors.Is(err, registry.ErrAlreadyExists):
+		api.logBackupKeyOutcome(r, AuditActionAdminBackupKeyAdd, actor.ID, key.Fingerprint, key.Label, false, err.Error())
+		_ = codedConflictError(w, r, errors.New("key is already in the keyring"), AdminBackupKeyAlreadyTrustedCode, nil)
+		return
+	case err != nil:
+		slog.Error("admin addBackupKey: failed to add trusted backup key", "fingerprint", key.Fingerprint, "error", err)
+		api.logBackupKeyOutcome(r, AuditActionAdminBackupKeyAdd, actor.ID, key.Fingerprint, key.Label, false, err.Error())
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	api.writeEnvelope(w, http.StatusCreated, TrustedBackupKeyEnvelope{Data: trustedBackupKeyResource(added, time.Now())})
+	// Audit AFTER render so a writer failure lands as Success=false.
+	api.logBackupKeyOutcome(r, AuditActionAdminBackupKeyAdd, actor.ID, added.Fingerprint, added.Label, true, "")
+}
+
+// revokeBackupKey revokes the key named in the {fingerprint} path segment.
+//
+// @Summary Revoke a trusted backup-signing key (admin)
+// @Description Stops accepting archives signed with the key. The key stays in the keyring as a record; revoking it again is a no-op that keeps the original revocation. Platform admins only. An unknown fingerprint returns 404 with `admin.backup_key.not_found`.
+// @Tags admin
+// @Accept json
+// @Produce json-api
+// @Param fingerprint path string true "Key fingerprint (hex SHA-256 of the public key)"
+// @Param data body BackupKeyRevokeRequest false "Optional revoke request (reason)"
+// @Success 200 {object} TrustedBackupKeyEnvelope "OK"
+// @Failure 400 {object} jsonapi.Errors "Bad Request - invalid body"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Forbidden - platform admin required"
+// @Failure 404 {object} jsonapi.Errors "Not Found - unknown fingerprint"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - reason too long"
+// @Router /admin/backup-keys/{fingerprint}/revoke [post]
+func (api *adminBackupKeysAPI) revokeBackupKey(w http.ResponseWriter, r *http.Request) {
+	actor := appctx.AdminActorFromContext(r.Context())
+	if actor == nil {
+		_ = unauthorizedError(w, r, ErrMissingUserContext)
+		return
+	}
+
+	fingerprint := strings.ToLower(strings.TrimSpace(chi.URLParam(r, "fingerprint")))
+
+	var req BackupKeyRevokeRequest
+	if !decodeStrictJSON(w, r, &req, true) {
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(req.Reason) > adminWorkerReasonMaxLen {
+		_ = codedUnprocessableEntityError(w, r, errors.New("reason is too long"), AdminWorkerReasonTooLongCode)
+		return
+	}
+
+	revoked, err := api.factorySet.TrustedBackupKeyRegistry.Revoke(r.Context(), fingerprint, actor.ID, req.Reason)
+	switch {
+	case errors.Is(err, registry.ErrNotFound):
+		_ = codedNotFoundError(w, r, errors.New("unknown backup key"), AdminBackupKeyNotFoundCode)
+		return
+	case err != nil:
+		slog.Error("admin revokeBackupKey: failed to revoke trusted backup key", "fingerprint", fingerprint, "error", err)
+		api.logBackupKeyOutcome(r, AuditActionAdminBackupKeyRevoke, actor.ID, fingerprint, req.Reason, false, err.Error())
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	api.writeEnvelope(w, http.StatusOK, TrustedBackupKeyEnvelope{Data: trustedBackupKeyResource(revoked, time.Now())})
+	api.logBackupKeyOutcome(r, AuditActionAdminBackupKeyRevoke, actor.ID, fingerprint, req.Reason, true, "")
+}
+
+// decodeStrictJSON decodes the request body into v, rejecting unknown fields
+// and trailing tokens. allowEmpty accepts a missing or empty body (v keeps
+// its zero value). Writes a 400 and returns false on failure.
+func decodeStrictJSON(w http.ResponseWriter, r *http.Request, v any, allowEmpty bool) bool {
+	if r.Body == nil {
+		if allowEmpty {
+			return true
+		}
+		_ = badRequest(w, r, errors.New("request body is required"))
+		return false
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if allowEmpty && errors.Is(err, io.EOF) {
+			return true
+		}
+		_ = badRequest(w, r, err)
+		return false
+	}
+	if !decoderAtEOF(dec) {
+		_ = badRequest(w, r, errors.New("invalid JSON body — trailing tokens"))
+		return false
+	}
+	return true
+}
+
+// writeEnvelope encodes v as a JSON:API response on w.
+func (api *adminBackupKeysAPI) writeEnvelope(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("admin backup keys: failed to encode response", "error", err)
+	}
+}
+
+// logBackupKeyOutcome writes the admin.backup_key_add /
+// admin.backup_key_revoke audit row. reason carries the label for an add and
+// the operator's note for a revoke. Nil-safe when AuditService was not wired
+// in.
+func (api *adminBackupKeysAPI) logBackupKeyOutcome(
+	r *http.Request,
+	action, actorID, fingerprint, reason string,
+	success bool,
+	errMsg string,
+) {
+	if api.auditService == nil {
+		return
+	}
+	ev := services.AdminEvent{
+		Action:      action,
+		ActorID:     nullableString(actorID),
+		SubjectType: stringPtr("backup_key"),
+		SubjectID:   nullableString(fingerprint),
+		Success:     success,
+		Request:     r,
+		Reason:      reason,
+	}
+	if errMsg != "" {
+		ev.ErrMsg = new(errMsg)
+	}
+	api.auditService.LogAdmin(r.Context(), ev)
+}
+
+// trustedBackupKeyResource builds the JSON:API resource for key k, with
+// `active` evaluated at now.
+func trustedBackupKeyResource(k *models.TrustedBackupKey, now time.Time) TrustedBackupKeyResource {
+	return TrustedBackupKeyResource{
+		Type: "trusted_backup_key",
+		ID:   k.Fingerprint,
+		Attributes: TrustedBackupKeyView{
+			Fingerprint:  k.Fingerprint,
+			PublicKey:    k.PublicKey,
+			Label:        k.Label,
+			Active:       k.IsActive(now),
+			AddedAt:      k.AddedAt,
+			AddedBy:      k.AddedBy,
+			ExpiresAt:    k.ExpiresAt,
+			RevokedAt:    k.RevokedAt,
+			RevokedBy:    k.RevokedBy,
+			RevokeReason: k.RevokeReason,
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package apiserver_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/internal/backupsign"
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// Backup-signing keyring admin endpoint tests. Same back-office harness as
+// admin_workers_test.go (newAdminEnv / doAdminJSONRequest).
+
+func newTrustedTestSigner(b byte) *backupsign.Signer {
+	seed := make([]byte, backupsign.SeedSize)
+	for i := range seed {
+		seed[i] = b
+	}
+	return must.Must(backupsign.NewSigner(seed))
+}
+
+func TestAdminBackupKeys_AddListRevoke(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	staging := newTrustedTestSigner(0x41)
+	pem := string(must.Must(staging.PublicKeyPEM()))
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodPost, "/api/v1/admin/backup-keys",
+		env.adminToken, map[string]any{"public_key": pem, "label": "staging"})
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.type"), "trusted_backup_key")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.id"), staging.Fingerprint())
+	// Stored in canonical base64 whatever form was submitted.
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.public_key"), staging.PublicKeyBase64())
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.active"), true)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.added_by"), env.admin.ID)
+
+	list := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/backup-keys", env.adminToken, nil)
+	c.Assert(list.Code, qt.Equals, http.StatusOK)
+	c.Assert(list.Body.Bytes(), checkers.JSONPathMatches("$.data", qt.HasLen), 1)
+
+	revoke := doAdminJSONRequest(t, env.handler, http.MethodPost,
+		"/api/v1/admin/backup-keys/"+staging.Fingerprint()+"/revoke",
+		env.adminToken, map[string]any{"reason": "staging decommissioned"})
+	c.Assert(revoke.Code, qt.Equals, http.StatusOK)
+	c.Assert(revoke.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.active"), false)
+	c.Assert(revoke.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.revoke_reason"), "staging decommissioned")
+
+	// Both writes are audited against the key fingerprint.
+	rows := must.Must(env.params.FactorySet.AuditLogRegistry.List(context.Background()))
+	seen := map[string]*models.AuditLog{}
+	for _, row := range rows {
+		seen[row.Action] = row
+	}
+	for _, action := range []string{apiserver.AuditActionAdminBackupKeyAdd, apiserver.AuditActionAdminBackupKeyRevoke} {
+		row := seen[action]
+		c.Assert(row, qt.IsNotNil, qt.Commentf("missing %s audit row", action))
+		c.Assert(row.Success, qt.IsTrue)
+		c.Assert(*row.UserID, qt.Equals, env.admin.ID)
+		c.Assert(*row.EntityID, qt.Equals, staging.Fingerprint())
+	}
+}
+
+func TestAdminBackupKeys_AddRejectsDuplicateAndInvalid(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	staging := newTrustedTestSigner(0x42)
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodPost, "/api/v1/admin/backup-keys",
+		env.adminToken, map[string]any{"public_key": staging.PublicKeyBase64(), "label": "staging"})
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated)
+
+	tests := []struct {
+		name string
+		body map[string]any
+		code int
+		want string
+	}{
+		{"duplicate", map[string]any{"public_key": staging.PublicKeyBase64(), "label": "again"}, http.StatusConflict, apiserver.AdminBackupKeyAlreadyTrustedCode},
+		{"garbage key", map[string]any{"public_key": "not a key", "label": "x"}, http.StatusUnprocessableEntity, apiserver.AdminBackupKeyInvalidCode},
+		{"missing label", map[string]any{"public_key": newTrustedTestSigner(0x43).PublicKeyBase64()}, http.StatusUnprocessableEntity, apiserver.AdminBackupKeyInvalidCode},
+		{"past expiry", map[string]any{
+			"public_key": newTrustedTestSigner(0x44).PublicKeyBase64(), "label": "x",
+			"expires_at": time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}, http.StatusUnprocessableEntity, apiserver.AdminBackupKeyInvalidCode},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			rr := doAdminJSONRequest(t, env.handler, http.MethodPost, "/api/v1/admin/backup-keys", env.adminToken, tt.body)
+			c.Assert(rr.Code, qt.Equals, tt.code)
+			assertErrorCode(t, c, rr.Body.Bytes(), tt.want)
+		})
+	}
+}
+
+func TestAdminBackupKeys_RevokeUnknownNotFound(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodPost,
+		"/api/v1/admin/backup-keys/deadbeef/revoke", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusNotFound)
+	assertErrorCode(t, c, rr.Body.Bytes(), apiserver.AdminBackupKeyNotFoundCode)
+}
+
+func TestAdminBackupKeys_SupportAgentCanListButNotAdd(t *testing.T) {
+	c := qt.New(t)
+	params, _, _ := newParams()
+	_, supportToken := withBackofficeOperator(t, params, models.BackofficeRoleSupportAgent)
+	handler := apiserver.APIServer(params, &mockRestoreWorker{})
+
+	list := doAdminJSONRequest(t, handler, http.MethodGet, "/api/v1/admin/backup-keys", supportToken, nil)
+	c.Assert(list.Code, qt.Equals, http.StatusOK)
+
+	rr := doAdminJSONRequest(t, handler, http.MethodPost, "/api/v1/admin/backup-keys",
+		supportToken, map[string]any{"public_key": newTrustedTestSigner(0x45).PublicKeyBase64(), "label": "x"})
+	c.Assert(rr.Code, qt.Equals, http.StatusForbidden)
+	assertErrorCode(t, c, rr.Body.Bytes(), apiserver.AdminRoleRequiredCode)
+}
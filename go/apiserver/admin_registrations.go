@@ -0,0 +1,298 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// Registration approval action names.
+const (
+	// AuditActionAdminRegistrationApprove is the audit-row Action emitted
+	// when an operator approves a pending registration.
+	AuditActionAdminRegistrationApprove = "admin.registration_approve"
+	// AuditActionAdminRegistrationReject is the audit-row Action emitted
+	// when an operator rejects a pending registration.
+	AuditActionAdminRegistrationReject = "admin.registration_reject"
+)
+
+// JSON:API error codes returned by the registration approval endpoints.
+const (
+	// AdminRegistrationInvalidStatusCode signals a `status` filter other
+	// than pending, approved, rejected or all. Maps to a 422.
+	AdminRegistrationInvalidStatusCode = "admin.registration.invalid_status"
+	// AdminRegistrationAlreadyDecidedCode signals that the request was
+	// approved or rejected before this call. Maps to a 409.
+	AdminRegistrationAlreadyDecidedCode = "admin.registration.already_decided"
+	// AdminRegistrationReasonRequiredCode signals a rejection with a
+	// missing or blank reason. Maps to a 422.
+	AdminRegistrationReasonRequiredCode = "admin.registration.reason_required"
+	// AdminRegistrationReasonTooLongCode signals a reason longer than
+	// adminBlockReasonMaxLen characters. Maps to a 422.
+	AdminRegistrationReasonTooLongCode = "admin.registration.reason_too_long"
+)
+
+// AdminRegistrationDecisionRequest is the request body for
+// POST /admin/registrations/{requestID}/approve and .../reject. Reason
+// is optional on approve and required on reject; it is mailed to the
+// applicant either way.
+type AdminRegistrationDecisionRequest struct {
+	Reason string `json:"reason,omitempty" example:"Confirmed with the household owner"`
+}
+
+// RegistrationRequestView is the JSON:API attributes block of a
+// registration approval request.
+type RegistrationRequestView struct {
+	TenantID  string                           `json:"tenant_id"`
+	UserID    string                           `json:"user_id"`
+	Email     string                           `json:"email"`
+	Name      string                           `json:"name"`
+	Status    models.RegistrationRequestStatus `json:"status" swaggertype:"string" enums:"pending,approved,rejected"`
+	Reason    *string                          `json:"reason,omitempty"`
+	DecidedBy *string                          `json:"decided_by,omitempty"`
+	DecidedAt *time.Time                       `json:"decided_at,omitempty"`
+	CreatedAt time.Time                        `json:"created_at"`
+}
+
+// RegistrationRequestResource is the JSON:API resource block. `type` is
+// "registration_request" and `id` is the request id.
+type RegistrationRequestResource struct {
+	Type       string                  `json:"type"`
+	ID         string                  `json:"id"`
+	Attributes RegistrationRequestView `json:"attributes"`
+}
+
+// RegistrationRequestEnvelope is the single-resource JSON:API envelope
+// returned by the approve and reject endpoints.
+type RegistrationRequestEnvelope struct {
+	Data RegistrationRequestResource `json:"data"`
+}
+
+// RegistrationRequestListEnvelope is the list JSON:API envelope returned
+// by GET /admin/registrations.
+type RegistrationRequestListEnvelope struct {
+	Data []RegistrationRequestResource `json:"data"`
+}
+
+// adminRegistrationsAPI backs the registration approval queue. Like
+// adminScanBudgetsAPI it crosses tenants; the decisions themselves run
+// through RegistrationApprovalService so the CLI takes the same path.
+type adminRegistrationsAPI struct {
+	approvals    *services.RegistrationApprovalService
+	auditService services.AuditLogger
+}
+
+// listRegistrations returns the registration approval queue.
+//
+// @Summary List registration requests (admin)
+// @Description Returns the self-service registrations of tenants in approval mode, oldest first. `status` is pending (default), approved, rejected or all; anything else returns 422 with `admin.registration.invalid_status`. `tenant_id` narrows the list to one tenant. Resource `type` is "registration_request".
+// @Tags admin
+// @Produce json-api
+// @Param status query string false "Status filter" Enums(pending, approved, rejected, all)
+// @Param tenant_id query string false "Tenant ID"
+// @Success 200 {object} RegistrationRequestListEnvelope "OK"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Account disabled"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - invalid status"
+// @Router /admin/registrations [get]
+func (api *adminRegistrationsAPI) listRegistrations(w http.ResponseWriter, r *http.Request) {
+	filter := registry.RegistrationRequestFilter{
+		TenantID: strings.TrimSpace(r.URL.Query().Get("tenant_id")),
+		Status:   models.RegistrationRequestStatusPending,
+	}
+	switch raw := strings.TrimSpace(r.URL.Query().Get("status")); raw {
+	case "":
+	case "all":
+		filter.Status = ""
+	default:
+		filter.Status = models.RegistrationRequestStatus(raw)
+		if err := filter.Status.Validate(); err != nil {
+			_ = codedUnprocessableEntityError(w, r, errors.New("status must be pending, approved, rejected or all"), AdminRegistrationInvalidStatusCode)
+			return
+		}
+	}
+
+	requests, err := api.approvals.List(r.Context(), filter)
+	if err != nil {
+		slog.Error("admin listRegistrations: failed to list registration requests", "error", err)
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	resources := make([]RegistrationRequestResource, 0, len(requests))
+	for _, req := range requests {
+		resources = append(resources, registrationRequestResource(req))
+	}
+	api.writeEnvelope(w, http.StatusOK, RegistrationRequestListEnvelope{Data: resources})
+}
+
+// approveRegistration approves a pending registration.
+//
+// @Summary Approve a registration (admin)
+// @Description Approves a pending registration: the user is activated and told by email. The optional `reason` is included in the email. Returns 409 with `admin.registration.already_decided` when the request was decided before, and 422 with `admin.registration.reason_too_long` for a reason over 500 characters.
+// @Tags admin
+// @Accept json
+// @Produce json-api
+// @Param requestID path string true "Registration request ID"
+// @Param data body AdminRegistrationDecisionRequest false "Decision"
+// @Success 200 {object} RegistrationRequestEnvelope "OK"
+// @Failure 400 {object} jsonapi.Errors "Bad Request - invalid body"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Account disabled"
+// @Failure 404 {object} jsonapi.Errors "Not Found - unknown request"
+// @Failure 409 {object} jsonapi.Errors "Conflict - already decided"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - reason too long"
+// @Router /admin/registrations/{requestID}/approve [post]
+func (api *adminRegistrationsAPI) approveRegistration(w http.ResponseWriter, r *http.Request) {
+	api.decide(w, r, true)
+}
+
+// rejectRegistration rejects a pending registration.
+//
+// @Summary Reject a registration (admin)
+// @Description Rejects a pending registration: the user stays inactive and is sent the `reason` by email. Returns 409 with `admin.registration.already_decided` when the request was decided before, and 422 with `admin.registration.reason_required` (missing or blank reason) or `admin.registration.reason_too_long` (over 500 characters).
+// @Tags admin
+// @Accept json
+// @Produce json-api
+// @Param requestID path string true "Registration request ID"
+// @Param data body AdminRegistrationDecisionRequest true "Decision"
+// @Success 200 {object} RegistrationRequestEnvelope "OK"
+// @Failure 400 {object} jsonapi.Errors "Bad Request - invalid body"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Account disabled"
+// @Failure 404 {object} jsonapi.Errors "Not Found - unknown request"
+// @Failure 409 {object} jsonapi.Errors "Conflict - already decided"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - reason missing or too long"
+// @Router /admin/registrations/{requestID}/reject [post]
+func (api *adminRegistrationsAPI) rejectRegistration(w http.ResponseWriter, r *http.Request) {
+	api.decide(w, r, false)
+}
+
+// decide validates the body and approves or rejects the request.
+//
+//revive:disable-next-line:flag-parameter
+func (api *adminRegistrationsAPI) decide(w http.ResponseWriter, r *http.Request, approve bool) {
+	actor := appctx.AdminActorFromContext(r.Context())
+	if actor == nil {
+		_ = unauthorizedError(w, r, ErrMissingUserContext)
+		return
+	}
+
+	var body AdminRegistrationDecisionRequest
+	if !decodeStrictJSON(w, r, &body, approve) {
+		return
+	}
+	body.Reason = strings.TrimSpace(body.Reason)
+	if !approve && body.Reason == "" {
+		_ = codedUnprocessableEntityError(w, r, errors.New("reason is required"), AdminRegistrationReasonRequiredCode)
+		return
+	}
+	if utf8.RuneCountInString(body.Reason) > adminBlockReasonMaxLen {
+		_ = codedUnprocessableEntityError(w, r, errors.New("reason is too long"), AdminRegistrationReasonTooLongCode)
+		return
+	}
+
+	requestID := chi.URLParam(r, "requestID")
+	action := AuditActionAdminRegistrationReject
+	var (
+		req *models.RegistrationRequest
+		err error
+	)
+	if approve {
+		action = AuditActionAdminRegistrationApprove
+		req, err = api.approvals.Approve(r.Context(), requestID, &body.Reason, nullableString(actor.ID))
+	} else {
+		req, err = api.approvals.Reject(r.Context(), requestID, body.Reason, nullableString(actor.ID))
+	}
+	switch {
+	case errors.Is(err, registry.ErrNotFound):
+		_ = renderEntityError(w, r, err)
+		return
+	case errors.Is(err, services.ErrRegistrationAlreadyDecided):
+		_ = codedConflictError(w, r, errors.New("registration request was already decided"), AdminRegistrationAlreadyDecidedCode, nil)
+		return
+	case err != nil:
+		slog.Error("admin decide registration: failed", "request_id", requestID, "approve", approve, "error", err)
+		// Approve returns the decided request alongside an activation
+		// failure; record it so the audit trail shows what happened.
+		if req != nil {
+			api.logDecision(r, action, actor.ID, req, body.Reason, false, err.Error())
+		}
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	api.writeEnvelope(w, http.StatusOK, RegistrationRequestEnvelope{Data: registrationRequestResource(req)})
+	api.logDecision(r, action, actor.ID, req, body.Reason, true, "")
+}
+
+// registrationRequestResource builds the JSON:API resource for req.
+func registrationRequestResource(req *models.RegistrationRequest) RegistrationRequestResource {
+	return RegistrationRequestResource{
+		Type: "registration_request",
+		ID:   req.ID,
+		Attributes: RegistrationRequestView{
+			TenantID:  req.TenantID,
+			UserID:    req.UserID,
+			Email:     req.Email,
+			Name:      req.Name,
+			Status:    req.Status,
+			Reason:    req.Reason,
+			DecidedBy: req.DecidedBy,
+			DecidedAt: req.DecidedAt,
+			CreatedAt: req.CreatedAt,
+		},
+	}
+}
+
+// writeEnvelope encodes v as a JSON:API response on w.
+func (api *adminRegistrationsAPI) writeEnvelope(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("admin registrations: failed to encode response", "error", err)
+	}
+}
+
+// logDecision writes the admin.registration_approve /
+// admin.registration_reject audit row. The subject is the applicant's
+// user; the request id rides in Extra. Nil-safe when AuditService was
+// not wired in.
+func (api *adminRegistrationsAPI) logDecision(
+	r *http.Request,
+	action, actorID string,
+	req *models.RegistrationRequest,
+	reason string,
+	success bool,
+	errMsg string,
+) {
+	if api.auditService == nil {
+		return
+	}
+	ev := services.AdminEvent{
+		Action:      action,
+		ActorID:     nullableString(actorID),
+		TenantID:    nullableString(req.TenantID),
+		SubjectType: stringPtr("user"),
+		SubjectID:   nullableString(req.UserID),
+		Success:     success,
+		Request:     r,
+		Reason:      reason,
+		Extra:       map[string]any{"registration_request_id": req.ID},
+	}
+	if errMsg != "" {
+		ev.ErrMsg = new(errMsg)
+	}
+	api.auditService.LogAdmin(r.Context(), ev)
+}
@@ -0,0 +1,185 @@
+package apiserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// Registration approval queue endpoint tests. The /admin/registrations
+// surface is gated by RequireBackofficeAuth like the rest of the admin
+// CRUD subtree; see admin_users_test.go for the shared harness.
+
+// seedRegistrationRequest creates an inactive user in env.tenantID and
+// queues its registration request.
+func seedRegistrationRequest(c *qt.C, env adminTestEnv, email string) (*models.User, *models.RegistrationRequest) {
+	c.Helper()
+	user := createTestUserDirect(c, env.params, env.tenantID, email, false, false)
+	req := must.Must(env.params.FactorySet.RegistrationRequestRegistry.Create(context.Background(), models.RegistrationRequest{
+		TenantID: user.TenantID,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Name:     user.Name,
+	}))
+	return user, req
+}
+
+func TestAdminListRegistrations_DefaultsToPending(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	_, pending := seedRegistrationRequest(c, env, "pending@example.com")
+	_, decided := seedRegistrationRequest(c, env, "decided@example.com")
+	must.Must(env.params.FactorySet.RegistrationRequestRegistry.Decide(context.Background(),
+		decided.ID, models.RegistrationRequestStatusRejected, new("spam"), nil))
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/registrations", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathMatches("$.data", qt.HasLen), 1)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data[0].type"), "registration_request")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data[0].id"), pending.ID)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data[0].attributes.email"), "pending@example.com")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data[0].attributes.status"), "pending")
+
+	rr = doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/registrations?status=all", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathMatches("$.data", qt.HasLen), 2)
+
+	rr = doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/registrations?tenant_id=other-tenant", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathMatches("$.data", qt.HasLen), 0)
+}
+
+func TestAdminListRegistrations_InvalidStatus(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/registrations?status=bogus", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity)
+	assertErrorCode(t, c, rr.Body.Bytes(), apiserver.AdminRegistrationInvalidStatusCode)
+}
+
+func TestAdminListRegistrations_DeniesUnauthenticated(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/registrations", "", nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusUnauthorized)
+}
+
+func TestAdminApproveRegistration_ActivatesUserAndAudits(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	user, req := seedRegistrationRequest(c, env, "applicant@example.com")
+
+	// Reason is optional on approve — an empty body must still approve.
+	rr := doAdminJSONRequest(t, env.handler, http.MethodPost,
+		"/api/v1/admin/registrations/"+req.ID+"/approve", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.status"), "approved")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.decided_by"), env.admin.ID)
+
+	stored := must.Must(env.params.FactorySet.UserRegistry.Get(context.Background(), user.ID))
+	c.Assert(stored.IsActive, qt.IsTrue)
+
+	row := findAuditRow(c, env.params, apiserver.AuditActionAdminRegistrationApprove)
+	c.Assert(row, qt.IsNotNil)
+	c.Assert(row.Success, qt.IsTrue)
+	c.Assert(*row.UserID, qt.Equals, env.admin.ID)
+	c.Assert(*row.EntityID, qt.Equals, user.ID)
+	var bc map[string]any
+	c.Assert(json.Unmarshal([]byte(row.UserAgent), &bc), qt.IsNil)
+	c.Assert(bc["registration_request_id"], qt.Equals, req.ID)
+
+	// A second decision conflicts.
+	rr = doAdminJSONRequest(t, env.handler, http.MethodPost,
+		"/api/v1/admin/registrations/"+req.ID+"/reject", env.adminToken, map[string]any{"reason": "too late"})
+	c.Assert(rr.Code, qt.Equals, http.StatusConflict)
+	assertErrorCode(t, c, rr.Body.Bytes(), apiserver.AdminRegistrationAlreadyDecidedCode)
+}
+
+func TestAdminRejectRegistration_KeepsUserInactive(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	user, req := seedRegistrationRequest(c, env, "applicant@example.com")
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodPost,
+		"/api/v1/admin/registrations/"+req.ID+"/reject", env.adminToken, map[string]any{"reason": "unknown applicant"})
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.status"), "rejected")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.reason"), "unknown applicant")
+
+	stored := must.Must(env.params.FactorySet.UserRegistry.Get(context.Background(), user.ID))
+	c.Assert(stored.IsActive, qt.IsFalse)
+
+	row := findAuditRow(c, env.params, apiserver.AuditActionAdminRegistrationReject)
+	c.Assert(row, qt.IsNotNil)
+	c.Assert(row.Success, qt.IsTrue)
+	var bc map[string]any
+	c.Assert(json.Unmarshal([]byte(row.UserAgent), &bc), qt.IsNil)
+	c.Assert(bc["reason"], qt.Equals, "unknown applicant")
+}
+
+func TestAdminRejectRegistration_Validation(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	_, req := seedRegistrationRequest(c, env, "applicant@example.com")
+
+	tests := []struct {
+		name     string
+		id       string
+		body     any
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:     "missing reason",
+			id:       req.ID,
+			body:     map[string]any{"reason": "   "},
+			wantCode: http.StatusUnprocessableEntity,
+			wantErr:  apiserver.AdminRegistrationReasonRequiredCode,
+		},
+		{
+			name:     "reason too long",
+			id:       req.ID,
+			body:     map[string]any{"reason": strings.Repeat("x", 501)},
+			wantCode: http.StatusUnprocessableEntity,
+			wantErr:  apiserver.AdminRegistrationReasonTooLongCode,
+		},
+		{
+			name:     "unknown field",
+			id:       req.ID,
+			body:     map[string]any{"reason": "spam", "force": true},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown request",
+			id:       "missing",
+			body:     map[string]any{"reason": "spam"},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			rr := doAdminJSONRequest(t, env.handler, http.MethodPost,
+				"/api/v1/admin/registrations/"+tt.id+"/reject", env.adminToken, tt.body)
+			c.Assert(rr.Code, qt.Equals, tt.wantCode)
+			if tt.wantErr != "" {
+				assertErrorCode(t, c, rr.Body.Bytes(), tt.wantErr)
+			}
+		})
+	}
+
+	// Nothing above decided the request.
+	stored := must.Must(env.params.FactorySet.RegistrationRequestRegistry.Get(context.Background(), req.ID))
+	c.Assert(stored.IsPending(), qt.IsTrue)
+}
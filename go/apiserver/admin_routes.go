@@ -122,6 +122,12 @@ type AdminParams struct {
 	// plane, gated by RequireBackofficeAuth like every other admin route.
 	// May be nil; the handler then reports zero-valued debug info.
 	DebugInfo *debug.Info
+
+	// RegistrationApprovalService backs the registration approval queue.
+	// When nil, Admin() builds one without an email service or login
+	// URL (tests, memory-mode bootstrap): decisions still work, nobody
+	// is mailed.
+	RegistrationApprovalService *services.RegistrationApprovalService
 }
 
 // Admin returns the router configurator for /api/v1/admin/*. Mounted
@@ -209,6 +215,28 @@ func Admin(params AdminParams) func(r chi.Router) {
 		factorySet:   params.FactorySet,
 		auditService: params.AuditService,
 	}
+	// Backup-signing keyring: keys trusted for import/restore besides the
+	// server's own. Same FactorySet-direct posture as the workers API.
+	backupKeysAPI := &adminBackupKeysAPI{
+		factorySet:   params.FactorySet,
+		auditService: params.AuditService,
+	}
+	// AI vision usage report and spend caps. Same FactorySet-direct
+	// posture as the workers API.
+	scanBudgetsAPI := &adminScanBudgetsAPI{
+		factorySet:   params.FactorySet,
+		auditService: params.AuditService,
+	}
+	// Registration approval queue. The service may send mail, so it comes
+	// from the caller; see AdminParams.RegistrationApprovalService.
+	approvals := params.RegistrationApprovalService
+	if approvals == nil {
+		approvals = services.NewRegistrationApprovalService(params.FactorySet, nil, "")
+	}
+	registrationsAPI := &adminRegistrationsAPI{
+		approvals:    approvals,
+		auditService: params.AuditService,
+	}
 	// #2113 L-4: GET /admin/debug — moved off the tenant surface onto the
 	// back-office plane. DebugInfo may be nil; the handler then encodes the
 	// zero value (same as the legacy /debug behaviour with a nil info).
@@ -278,7 +306,7 @@ func Admin(params AdminParams) func(r chi.Router) {
 		// tokens (and vice versa).
 		r.Group(func(r chi.Router) {
 			r.Use(backofficeAuth)
-			adminBackofficeRoutes(r, tenantsAPI, usersAPI, groupsAPI, groupMembersAPI, impersonationAPI, workersAPI, debugAPIInst)
+			adminBackofficeRoutes(r, tenantsAPI, usersAPI, groupsAPI, groupMembersAPI, impersonationAPI, workersAPI, backupKeysAPI, scanBudgetsAPI, registrationsAPI, debugAPIInst)
 		})
 	}
 }
@@ -304,6 +332,9 @@ func adminBackofficeRoutes(
 	groupMembersAPI *adminGroupMembersAPI,
 	impersonationAPI *adminImpersonationAPI,
 	workersAPI *adminWorkersAPI,
+	backupKeysAPI *adminBackupKeysAPI,
+	scanBudgetsAPI *adminScanBudgetsAPI,
+	registrationsAPI *adminRegistrationsAPI,
 	debugAPIInst *debugAPI,
 ) {
 	r.Get("/_ping", adminPing)
@@ -357,6 +388,33 @@ func adminBackofficeRoutes(
 	r.Post("/workers/{workerType}/pause", workersAPI.pauseWorker)
 	r.Post("/workers/{workerType}/resume", workersAPI.resumeWorker)
 
+	// Backup-signing keyring. Listing is open to every back-office role;
+	// trusting or revoking a key decides which archives may be restored
+	// into any tenant, so it is platform_admin only. Each write
+	// audit-logs via the shared AuditService.
+	r.Get("/backup-keys", backupKeysAPI.listBackupKeys)
+	r.With(RequirePlatformAdmin).Post("/backup-keys", backupKeysAPI.addBackupKey)
+	r.With(RequirePlatformAdmin).Post("/backup-keys/{fingerprint}/revoke", backupKeysAPI.revokeBackupKey)
+
+	// AI vision cost controls. The usage report and the budget list are
+	// open to every back-office role; setting or removing a budget
+	// changes what tenants may spend, so it is platform_admin only. Each
+	// write audit-logs via the shared AuditService.
+	r.Get("/scan-usage", scanBudgetsAPI.scanUsage)
+	r.Get("/scan-budgets", scanBudgetsAPI.listScanBudgets)
+	r.With(RequirePlatformAdmin).Put("/tenants/{tenantID}/scan-budget", scanBudgetsAPI.setTenantScanBudget)
+	r.With(RequirePlatformAdmin).Delete("/tenants/{tenantID}/scan-budget", scanBudgetsAPI.deleteTenantScanBudget)
+	r.With(RequirePlatformAdmin).Put("/groups/{groupID}/scan-budget", scanBudgetsAPI.setGroupScanBudget)
+	r.With(RequirePlatformAdmin).Delete("/groups/{groupID}/scan-budget", scanBudgetsAPI.deleteGroupScanBudget)
+
+	// Registration approval queue for approval-mode tenants. Open to
+	// every back-office role, like block/unblock: deciding a pending
+	// account is routine support work. Each decision audit-logs via the
+	// shared AuditService.
+	r.Get("/registrations", registrationsAPI.listRegistrations)
+	r.Post("/registrations/{requestID}/approve", registrationsAPI.approveRegistration)
+	r.Post("/registrations/{requestID}/reject", registrationsAPI.rejectRegistration)
+
 	// #1785 Phase 5: impersonation-start is gated on platform_admin —
 	// support_agent (the read-mostly persona) cannot borrow a tenant
 	// identity. The nested-impersonation guard in the handler is
@@ -0,0 +1,435 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// AI vision budget action names.
+const (
+	// AuditActionAdminScanBudgetSet is the audit-row Action emitted when a
+	// tenant or group AI vision budget is created or changed.
+	AuditActionAdminScanBudgetSet = "admin.scan_budget_set"
+	// AuditActionAdminScanBudgetDelete is the audit-row Action emitted when
+	// a tenant or group AI vision budget is removed.
+	AuditActionAdminScanBudgetDelete = "admin.scan_budget_delete"
+)
+
+// JSON:API error codes returned by the AI vision usage and budget
+// endpoints.
+const (
+	// AdminScanUsageInvalidMonthCode signals a `month` query parameter
+	// that is not YYYY-MM. Maps to a 422.
+	AdminScanUsageInvalidMonthCode = "admin.scan_usage.invalid_month"
+	// AdminScanBudgetInvalidCode signals a missing or negative
+	// monthly_limit_usd. Maps to a 422.
+	AdminScanBudgetInvalidCode = "admin.scan_budget.invalid"
+	// AdminScanBudgetNotFoundCode signals "no budget is set for this
+	// tenant or group". Maps to a 404.
+	AdminScanBudgetNotFoundCode = "admin.scan_budget.not_found"
+)
+
+// ScanBudgetSetRequest is the request body for
+// PUT /admin/tenants/{tenantID}/scan-budget and
+// PUT /admin/groups/{groupID}/scan-budget.
+type ScanBudgetSetRequest struct {
+	// MonthlyLimitUSD is the spend cap per calendar month (UTC), in USD.
+	// Zero blocks every scan.
+	MonthlyLimitUSD *decimal.Decimal `json:"monthly_limit_usd" swaggertype:"string" example:"25.00"`
+}
+
+// ScanBudgetView is the JSON:API attributes block returned by the budget
+// endpoints. SpentUSD is the current month's spend against the budget.
+type ScanBudgetView struct {
+	TenantID        string          `json:"tenant_id"`
+	GroupID         string          `json:"group_id,omitempty"`
+	MonthlyLimitUSD decimal.Decimal `json:"monthly_limit_usd" swaggertype:"string"`
+	SpentUSD        decimal.Decimal `json:"spent_usd" swaggertype:"string"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	UpdatedBy       *string         `json:"updated_by,omitempty"`
+}
+
+// ScanBudgetResource is the JSON:API resource block. `type` is
+// "commodity_scan_budget" and `id` is the budget row id.
+type ScanBudgetResource struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id"`
+	Attributes ScanBudgetView `json:"attributes"`
+}
+
+// ScanBudgetEnvelope is the single-resource JSON:API envelope returned by
+// the PUT endpoints.
+type ScanBudgetEnvelope struct {
+	Data ScanBudgetResource `json:"data"`
+}
+
+// ScanBudgetListEnvelope is the list JSON:API envelope returned by
+// GET /admin/scan-budgets.
+type ScanBudgetListEnvelope struct {
+	Data []ScanBudgetResource `json:"data"`
+}
+
+// ScanUsageView is one row of the AI vision usage report.
+type ScanUsageView struct {
+	TenantID   string          `json:"tenant_id"`
+	GroupID    string          `json:"group_id,omitempty"`
+	Provider   string          `json:"provider"`
+	Model      string          `json:"model"`
+	Scans      int             `json:"scans"`
+	TokensUsed int64           `json:"tokens_used"`
+	CostUSD    decimal.Decimal `json:"cost_usd" swaggertype:"string"`
+}
+
+// ScanUsageResource is the JSON:API resource block of a usage row. `type`
+// is "commodity_scan_usage"; `id` joins tenant, group, provider and model.
+type ScanUsageResource struct {
+	Type       string        `json:"type"`
+	ID         string        `json:"id"`
+	Attributes ScanUsageView `json:"attributes"`
+}
+
+// ScanUsageMeta carries the report month and its totals.
+type ScanUsageMeta struct {
+	Month        string          `json:"month"`
+	Scans        int             `json:"scans"`
+	TokensUsed   int64           `json:"tokens_used"`
+	TotalCostUSD decimal.Decimal `json:"total_cost_usd" swaggertype:"string"`
+}
+
+// ScanUsageEnvelope is the JSON:API envelope returned by
+// GET /admin/scan-usage.
+type ScanUsageEnvelope struct {
+	Data []ScanUsageResource `json:"data"`
+	Meta ScanUsageMeta       `json:"meta"`
+}
+
+// adminScanBudgetsAPI backs the AI vision usage report and the budget
+// routes. Like adminWorkersAPI it holds the FactorySet directly: the
+// report crosses tenants and budgets are a platform-operator control.
+type adminScanBudgetsAPI struct {
+	factorySet   *registry.FactorySet
+	auditService services.AuditLogger
+}
+
+// scanUsage reports the AI vision calls of one calendar month.
+//
+// @Summary AI vision usage report (admin)
+// @Description Aggregates the AI vision provider calls of one calendar month (UTC) per tenant, group, provider and model: call count, tokens and cost in USD from the configured price table. A call answered by a fallback provider is reported under that provider. `month` is YYYY-MM and defaults to the current month; anything else returns 422 with `admin.scan_usage.invalid_month`.
+// @Tags admin
+// @Produce json-api
+// @Param month query string false "Report month (YYYY-MM)"
+// @Success 200 {object} ScanUsageEnvelope "OK"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Account disabled"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - invalid month"
+// @Router /admin/scan-usage [get]
+func (api *adminScanBudgetsAPI) scanUsage(w http.ResponseWriter, r *http.Request) {
+	monthStart := currentMonthStart(time.Now())
+	if raw := r.URL.Query().Get("month"); raw != "" {
+		parsed, err := time.Parse("2006-01", raw)
+		if err != nil {
+			_ = codedUnprocessableEntityError(w, r, errors.New("month must be YYYY-MM"), AdminScanUsageInvalidMonthCode)
+			return
+		}
+		monthStart = parsed
+	}
+
+	rows, err := api.factorySet.CommodityScanAuditRegistry.Usage(r.Context(), monthStart, monthStart.AddDate(0, 1, 0))
+	if err != nil {
+		slog.Error("admin scanUsage: failed to aggregate scan usage", "error", err)
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	envelope := ScanUsageEnvelope{
+		Data: make([]ScanUsageResource, 0, len(rows)),
+		Meta: ScanUsageMeta{Month: monthStart.Format("2006-01"), TotalCostUSD: decimal.Zero},
+	}
+	for _, row := range rows {
+		envelope.Data = append(envelope.Data, ScanUsageResource{
+			Type: "commodity_scan_usage",
+			ID:   row.TenantID + "/" + row.GroupID + "/" + row.Provider + "/" + row.Model,
+			Attributes: ScanUsageView{
+				TenantID:   row.TenantID,
+				GroupID:    row.GroupID,
+				Provider:   row.Provider,
+				Model:      row.Model,
+				Scans:      row.Scans,
+				TokensUsed: row.TokensUsed,
+				CostUSD:    row.CostUSD,
+			},
+		})
+		envelope.Meta.Scans += row.Scans
+		envelope.Meta.TokensUsed += row.TokensUsed
+		envelope.Meta.TotalCostUSD = envelope.Meta.TotalCostUSD.Add(row.CostUSD)
+	}
+	api.writeEnvelope(w, http.StatusOK, envelope)
+}
+
+// listScanBudgets returns every AI vision budget with its spend so far
+// this month.
+//
+// @Summary List AI vision budgets (admin)
+// @Description Returns every tenant and group AI vision budget with `spent_usd`, the current calendar month's (UTC) spend it is checked against. A tenant-wide budget has no `group_id`. Resource `type` is "commodity_scan_budget".
+// @Tags admin
+// @Produce json-api
+// @Success 200 {object} ScanBudgetListEnvelope "OK"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Account disabled"
+// @Router /admin/scan-budgets [get]
+func (api *adminScanBudgetsAPI) listScanBudgets(w http.ResponseWriter, r *http.Request) {
+	budgets, err := api.factorySet.CommodityScanBudgetRegistry.List(r.Context())
+	if err != nil {
+		slog.Error("admin listScanBudgets: failed to list scan budgets", "error", err)
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	resources := make([]ScanBudgetResource, 0, len(budgets))
+	for _, b := range budgets {
+		res, err := api.budgetResource(r, b)
+		if err != nil {
+			slog.Error("admin listScanBudgets: failed to sum scan spend", "tenant_id", b.TenantID, "group_id", b.GroupID, "error", err)
+			_ = internalServerError(w, r, err)
+			return
+		}
+		resources = append(resources, res)
+	}
+	api.writeEnvelope(w, http.StatusOK, ScanBudgetListEnvelope{Data: resources})
+}
+
+// setTenantScanBudget sets the tenant-wide AI vision budget.
+//
+// @Summary Set a tenant AI vision budget (admin)
+// @Description Creates or replaces the monthly AI vision spend cap (USD) of the tenant. Once the calendar month's spend reaches it, scans return 429 `commodity_scan.budget_exceeded`. Platform admins only. A missing or negative limit returns 422 with `admin.scan_budget.invalid`.
+// @Tags admin
+// @Accept json
+// @Produce json-api
+// @Param tenantID path string true "Tenant ID"
+// @Param data body ScanBudgetSetRequest true "Budget"
+// @Success 200 {object} ScanBudgetEnvelope "OK"
+// @Failure 400 {object} jsonapi.Errors "Bad Request - invalid body"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Forbidden - platform admin required"
+// @Failure 404 {object} jsonapi.Errors "Not Found - unknown tenant"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - invalid limit"
+// @Router /admin/tenants/{tenantID}/scan-budget [put]
+func (api *adminScanBudgetsAPI) setTenantScanBudget(w http.ResponseWriter, r *http.Request) {
+	tenant, err := api.factorySet.TenantRegistry.GetAdmin(r.Context(), chi.URLParam(r, "tenantID"))
+	if err != nil {
+		_ = renderEntityError(w, r, err)
+		return
+	}
+	api.setScanBudget(w, r, tenant.Tenant.ID, "")
+}
+
+// setGroupScanBudget sets a group AI vision budget.
+//
+// @Summary Set a group AI vision budget (admin)
+// @Description Creates or replaces the monthly AI vision spend cap (USD) of one location group. It applies on top of the tenant budget: a scan in the group must fit both. Platform admins only. A missing or negative limit returns 422 with `admin.scan_budget.invalid`.
+// @Tags admin
+// @Accept json
+// @Produce json-api
+// @Param groupID path string true "Group ID"
+// @Param data body ScanBudgetSetRequest true "Budget"
+// @Success 200 {object} ScanBudgetEnvelope "OK"
+// @Failure 400 {object} jsonapi.Errors "Bad Request - invalid body"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Forbidden - platform admin required"
+// @Failure 404 {object} jsonapi.Errors "Not Found - unknown group"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity - invalid limit"
+// @Router /admin/groups/{groupID}/scan-budget [put]
+func (api *adminScanBudgetsAPI) setGroupScanBudget(w http.ResponseWriter, r *http.Request) {
+	group, err := api.factorySet.LocationGroupRegistry.GetAdmin(r.Context(), chi.URLParam(r, "groupID"))
+	if err != nil {
+		_ = renderEntityError(w, r, err)
+		return
+	}
+	api.setScanBudget(w, r, group.Group.TenantID, group.Group.ID)
+}
+
+// deleteTenantScanBudget removes the tenant-wide AI vision budget.
+//
+// @Summary Remove a tenant AI vision budget (admin)
+// @Description Removes the tenant-wide monthly AI vision spend cap; group budgets stay. Platform admins only. Returns 404 with `admin.scan_budget.not_found` when none is set.
+// @Tags admin
+// @Param tenantID path string true "Tenant ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Forbidden - platform admin required"
+// @Failure 404 {object} jsonapi.Errors "Not Found - no budget set"
+// @Router /admin/tenants/{tenantID}/scan-budget [delete]
+func (api *adminScanBudgetsAPI) deleteTenantScanBudget(w http.ResponseWriter, r *http.Request) {
+	api.deleteScanBudget(w, r, chi.URLParam(r, "tenantID"), "")
+}
+
+// deleteGroupScanBudget removes a group AI vision budget.
+//
+// @Summary Remove a group AI vision budget (admin)
+// @Description Removes the monthly AI vision spend cap of one location group; the tenant budget stays. Platform admins only. Returns 404 with `admin.scan_budget.not_found` when none is set.
+// @Tags admin
+// @Param groupID path string true "Group ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} jsonapi.Errors "Unauthorized - back-office authentication required"
+// @Failure 403 {object} jsonapi.Errors "Forbidden - platform admin required"
+// @Failure 404 {object} jsonapi.Errors "Not Found - unknown group or no budget set"
+// @Router /admin/groups/{groupID}/scan-budget [delete]
+func (api *adminScanBudgetsAPI) deleteGroupScanBudget(w http.ResponseWriter, r *http.Request) {
+	group, err := api.factorySet.LocationGroupRegistry.GetAdmin(r.Context(), chi.URLParam(r, "groupID"))
+	if err != nil {
+		_ = renderEntityError(w, r, err)
+		return
+	}
+	api.deleteScanBudget(w, r, group.Group.TenantID, group.Group.ID)
+}
+
+// setScanBudget decodes the request and upserts the (tenantID, groupID)
+// budget.
+func (api *adminScanBudgetsAPI) setScanBudget(w http.ResponseWriter, r *http.Request, tenantID, groupID string) {
+	actor := appctx.AdminActorFromContext(r.Context())
+	if actor == nil {
+		_ = unauthorizedError(w, r, ErrMissingUserContext)
+		return
+	}
+
+	var req ScanBudgetSetRequest
+	if !decodeStrictJSON(w, r, &req, false) {
+		return
+	}
+	if req.MonthlyLimitUSD == nil || req.MonthlyLimitUSD.IsNegative() {
+		_ = codedUnprocessableEntityError(w, r, errors.New("monthly_limit_usd must be zero or more"), AdminScanBudgetInvalidCode)
+		return
+	}
+
+	limit := req.MonthlyLimitUSD.Round(2)
+	stored, err := api.factorySet.CommodityScanBudgetRegistry.Set(r.Context(), models.CommodityScanBudget{
+		TenantID:        tenantID,
+		GroupID:         groupID,
+		MonthlyLimitUSD: limit,
+		UpdatedBy:       nullableString(actor.ID),
+	})
+	if err != nil {
+		slog.Error("admin setScanBudget: failed to set scan budget", "tenant_id", tenantID, "group_id", groupID, "error", err)
+		api.logScanBudgetOutcome(r, AuditActionAdminScanBudgetSet, actor.ID, tenantID, groupID, limit.String(), false, err.Error())
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	res, err := api.budgetResource(r, stored)
+	if err != nil {
+		slog.Error("admin setScanBudget: failed to sum scan spend", "tenant_id", tenantID, "group_id", groupID, "error", err)
+		_ = internalServerError(w, r, err)
+		return
+	}
+	api.writeEnvelope(w, http.StatusOK, ScanBudgetEnvelope{Data: res})
+	// Audit AFTER render so a writer failure lands as Success=false.
+	api.logScanBudgetOutcome(r, AuditActionAdminScanBudgetSet, actor.ID, tenantID, groupID, limit.String(), true, "")
+}
+
+// deleteScanBudget removes the (tenantID, groupID) budget.
+func (api *adminScanBudgetsAPI) deleteScanBudget(w http.ResponseWriter, r *http.Request, tenantID, groupID string) {
+	actor := appctx.AdminActorFromContext(r.Context())
+	if actor == nil {
+		_ = unauthorizedError(w, r, ErrMissingUserContext)
+		return
+	}
+
+	err := api.factorySet.CommodityScanBudgetRegistry.Delete(r.Context(), tenantID, groupID)
+	switch {
+	case errors.Is(err, registry.ErrNotFound), errors.Is(err, registry.ErrFieldRequired):
+		_ = codedNotFoundError(w, r, errors.New("no scan budget is set"), AdminScanBudgetNotFoundCode)
+		return
+	case err != nil:
+		slog.Error("admin deleteScanBudget: failed to delete scan budget", "tenant_id", tenantID, "group_id", groupID, "error", err)
+		api.logScanBudgetOutcome(r, AuditActionAdminScanBudgetDelete, actor.ID, tenantID, groupID, "", false, err.Error())
+		_ = internalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	api.logScanBudgetOutcome(r, AuditActionAdminScanBudgetDelete, actor.ID, tenantID, groupID, "", true, "")
+}
+
+// budgetResource builds the JSON:API resource for b with the current
+// month's spend.
+func (api *adminScanBudgetsAPI) budgetResource(r *http.Request, b *models.CommodityScanBudget) (ScanBudgetResource, error) {
+	spent, err := api.factorySet.CommodityScanAuditRegistry.SumCostSince(r.Context(), b.TenantID, b.GroupID, currentMonthStart(time.Now()))
+	if err != nil {
+		return ScanBudgetResource{}, err
+	}
+	return ScanBudgetResource{
+		Type: "commodity_scan_budget",
+		ID:   b.ID,
+		Attributes: ScanBudgetView{
+			TenantID:        b.TenantID,
+			GroupID:         b.GroupID,
+			MonthlyLimitUSD: b.MonthlyLimitUSD,
+			SpentUSD:        spent,
+			UpdatedAt:       b.UpdatedAt,
+			UpdatedBy:       b.UpdatedBy,
+		},
+	}, nil
+}
+
+// writeEnvelope encodes v as a JSON:API response on w.
+func (api *adminScanBudgetsAPI) writeEnvelope(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("admin scan budgets: failed to encode response", "error", err)
+	}
+}
+
+// logScanBudgetOutcome writes the admin.scan_budget_set /
+// admin.scan_budget_delete audit row. The subject is the group for a
+// group budget and the tenant otherwise; limit is the new cap on a set
+// and "" on a delete. Nil-safe when AuditService was
+// not wired in.
+func (api *adminScanBudgetsAPI) logScanBudgetOutcome(
+	r *http.Request,
+	action, actorID, tenantID, groupID, limit string,
+	success bool,
+	errMsg string,
+) {
+	if api.auditService == nil {
+		return
+	}
+	subjectType, subjectID := "tenant", tenantID
+	if groupID != "" {
+		subjectType, subjectID = "group", groupID
+	}
+	ev := services.AdminEvent{
+		Action:      action,
+		ActorID:     nullableString(actorID),
+		TenantID:    nullableString(tenantID),
+		SubjectType: stringPtr(subjectType),
+		SubjectID:   nullableString(subjectID),
+		Success:     success,
+		Request:     r,
+	}
+	if limit != "" {
+		ev.Extra = map[string]any{"monthly_limit_usd": limit}
+	}
+	if errMsg != "" {
+		ev.ErrMsg = new(errMsg)
+	}
+	api.auditService.LogAdmin(r.Context(), ev)
+}
+
+// currentMonthStart returns the first instant of now's calendar month in
+// UTC, the window budgets are checked over.
+func currentMonthStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
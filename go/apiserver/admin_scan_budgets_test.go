@@ -0,0 +1,153 @@
+package apiserver_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// AI vision usage and budget admin endpoint tests. Same back-office
+// harness as admin_backup_keys_test.go (newAdminEnv / doAdminJSONRequest).
+
+func recordScanCost(c *qt.C, params apiserver.Params, tenantID, userID, groupID, cost string) {
+	c.Helper()
+	audit := models.CommodityScanAudit{
+		GroupID:    groupID,
+		Provider:   "anthropic",
+		Model:      "claude-sonnet-4-6",
+		Status:     models.CommodityScanStatusOK,
+		TokensUsed: 1200,
+		CostUSD:    decimal.RequireFromString(cost),
+	}
+	audit.TenantID = tenantID
+	audit.UserID = userID
+	must.Must(params.FactorySet.CommodityScanAuditRegistry.Record(context.Background(), audit))
+}
+
+func TestAdminScanBudgets_SetListDelete(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	recordScanCost(c, env.params, env.tenantID, "user-1", "", "1.25")
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodPut,
+		"/api/v1/admin/tenants/"+env.tenantID+"/scan-budget", env.adminToken,
+		map[string]any{"monthly_limit_usd": "10.005"})
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.type"), "commodity_scan_budget")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.tenant_id"), env.tenantID)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.monthly_limit_usd"), "10.01")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.spent_usd"), "1.25")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.updated_by"), env.admin.ID)
+
+	list := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/scan-budgets", env.adminToken, nil)
+	c.Assert(list.Code, qt.Equals, http.StatusOK)
+	c.Assert(list.Body.Bytes(), checkers.JSONPathEquals("$.data[0].attributes.monthly_limit_usd"), "10.01")
+
+	del := doAdminJSONRequest(t, env.handler, http.MethodDelete,
+		"/api/v1/admin/tenants/"+env.tenantID+"/scan-budget", env.adminToken, nil)
+	c.Assert(del.Code, qt.Equals, http.StatusNoContent)
+
+	again := doAdminJSONRequest(t, env.handler, http.MethodDelete,
+		"/api/v1/admin/tenants/"+env.tenantID+"/scan-budget", env.adminToken, nil)
+	c.Assert(again.Code, qt.Equals, http.StatusNotFound)
+	assertErrorCode(t, c, again.Body.Bytes(), apiserver.AdminScanBudgetNotFoundCode)
+
+	rows := must.Must(env.params.FactorySet.AuditLogRegistry.List(context.Background()))
+	var sets, deletes int
+	for _, row := range rows {
+		switch row.Action {
+		case apiserver.AuditActionAdminScanBudgetSet:
+			sets++
+		case apiserver.AuditActionAdminScanBudgetDelete:
+			deletes++
+		}
+	}
+	c.Assert(sets, qt.Equals, 1)
+	c.Assert(deletes, qt.Equals, 1)
+}
+
+func TestAdminScanBudgets_SetGroupBudget(t *testing.T) {
+	c := qt.New(t)
+	params, _, group := newParams()
+	_, token := WithBackofficeAdmin(t, params)
+	handler := apiserver.APIServer(params, &mockRestoreWorker{})
+
+	rr := doAdminJSONRequest(t, handler, http.MethodPut,
+		"/api/v1/admin/groups/"+group.ID+"/scan-budget", token,
+		map[string]any{"monthly_limit_usd": "5"})
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.tenant_id"), group.TenantID)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.group_id"), group.ID)
+}
+
+func TestAdminScanBudgets_SetUnknownTenant(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodPut,
+		"/api/v1/admin/tenants/no-such-tenant/scan-budget", env.adminToken,
+		map[string]any{"monthly_limit_usd": "5"})
+	c.Assert(rr.Code, qt.Equals, http.StatusNotFound)
+}
+
+func TestAdminScanBudgets_SetInvalidLimit(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+
+	for _, body := range []map[string]any{{}, {"monthly_limit_usd": "-1"}} {
+		rr := doAdminJSONRequest(t, env.handler, http.MethodPut,
+			"/api/v1/admin/tenants/"+env.tenantID+"/scan-budget", env.adminToken, body)
+		c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("body=%v", body))
+		assertErrorCode(t, c, rr.Body.Bytes(), apiserver.AdminScanBudgetInvalidCode)
+	}
+}
+
+func TestAdminScanUsage_Report(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+	recordScanCost(c, env.params, env.tenantID, "user-1", "group-1", "0.5")
+	recordScanCost(c, env.params, env.tenantID, "user-2", "group-1", "0.25")
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/scan-usage", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data[0].type"), "commodity_scan_usage")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data[0].attributes.group_id"), "group-1")
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data[0].attributes.scans"), float64(2))
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.meta.total_cost_usd"), "0.75")
+
+	past := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/scan-usage?month=2001-01", env.adminToken, nil)
+	c.Assert(past.Code, qt.Equals, http.StatusOK)
+	c.Assert(past.Body.Bytes(), checkers.JSONPathEquals("$.meta.scans"), float64(0))
+}
+
+func TestAdminScanUsage_InvalidMonth(t *testing.T) {
+	c := qt.New(t)
+	env := newAdminEnv(c)
+
+	rr := doAdminJSONRequest(t, env.handler, http.MethodGet, "/api/v1/admin/scan-usage?month=May", env.adminToken, nil)
+	c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity)
+	assertErrorCode(t, c, rr.Body.Bytes(), apiserver.AdminScanUsageInvalidMonthCode)
+}
+
+func TestAdminScanBudgets_SupportAgentCanListButNotSet(t *testing.T) {
+	c := qt.New(t)
+	params, user, _ := newParams()
+	_, supportToken := withBackofficeOperator(t, params, models.BackofficeRoleSupportAgent)
+	handler := apiserver.APIServer(params, &mockRestoreWorker{})
+
+	list := doAdminJSONRequest(t, handler, http.MethodGet, "/api/v1/admin/scan-budgets", supportToken, nil)
+	c.Assert(list.Code, qt.Equals, http.StatusOK)
+
+	rr := doAdminJSONRequest(t, handler, http.MethodPut, "/api/v1/admin/tenants/"+user.TenantID+"/scan-budget",
+		supportToken, map[string]any{"monthly_limit_usd": "5"})
+	c.Assert(rr.Code, qt.Equals, http.StatusForbidden)
+	assertErrorCode(t, c, rr.Body.Bytes(), apiserver.AdminRoleRequiredCode)
+}
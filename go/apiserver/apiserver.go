@@ -26,6 +26,9 @@ import (
 	_ "gocloud.dev/blob/s3blob"  // register s3blob driver
 
 	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/backup/replication"
+	"github.com/denisvmedia/inventario/changefeed"
+	changefeedinmemory "github.com/denisvmedia/inventario/changefeed/inmemory"
 	"github.com/denisvmedia/inventario/csrf"
 	"github.com/denisvmedia/inventario/debug"
 	_ "github.com/denisvmedia/inventario/docs" // register swagger docs
@@ -33,6 +36,7 @@ import (
 	_ "github.com/denisvmedia/inventario/internal/fileblob" // register the in-memory + file blob drivers
 	"github.com/denisvmedia/inventario/internal/metrics"
 	"github.com/denisvmedia/inventario/internal/observability/sentry"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/jsonapi"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -177,6 +181,7 @@ type Params struct {
 	JWTSecret                  []byte                             // JWT secret for user authentication
 	FileSigningKey             []byte                             // File signing key for secure file URLs
 	BackupSigner               *backupsign.Signer                 // Ed25519 signer for .inb backup archives (#534)
+	BackupReplicator           *replication.Replicator            // Off-site backup replication target; nil when replication is disabled
 	FileURLExpiration          time.Duration                      // File URL expiration duration
 	ThumbnailConfig            services.ThumbnailGenerationConfig // Thumbnail generation configuration
 	TokenBlacklister           services.TokenBlacklister          // Token blacklist service (Redis or in-memory)
@@ -184,6 +189,7 @@ type Params struct {
 	GlobalRateLimiter          services.GlobalRateLimiter         // Global API rate limiter (Redis or in-memory)
 	GlobalRateTrustedProxyNets []*net.IPNet                       // Trusted proxies for extracting real client IP in global limiter
 	CSRFService                csrf.Service                       // CSRF token service (Redis or in-memory)
+	ChangeFeed                 changefeed.Broker                  // Live group change stream broker (Redis or in-memory)
 	CORSConfig                 CORSConfig                         // CORS configuration for API routes
 	TenantResolver             TenantResolver                     // resolves host → tenant; nil = single-tenant (HostTenantResolver with no BaseDomain)
 	// TestTenantHeaderEnabled is the TEST-ONLY gate for the #1851 cross-tenant
@@ -235,6 +241,12 @@ type Params struct {
 	// is rejected by Validate().
 	ImpersonationTTL time.Duration
 
+	// IdempotencyKeyTTL is how long the response to a POST carrying an
+	// Idempotency-Key header is replayed to retries. Operators tune it via
+	// INVENTARIO_RUN_IDEMPOTENCY_KEY_TTL; zero falls back to 24h. A negative
+	// value is rejected by Validate().
+	IdempotencyKeyTTL time.Duration
+
 	// ImpersonationStore records the server-side return slots for active
 	// impersonation sessions (#1750). When nil, APIServer() falls back to
 	// an in-memory store — fine for single-replica deployments and tests.
@@ -327,6 +339,7 @@ func (p *Params) Validate() error {
 		// 30-min default), but a negative duration would mint already-expired
 		// sessions — reject it. Not Required, so zero passes the check.
 		validation.Field(&p.ImpersonationTTL, validation.Min(time.Duration(0))),
+		validation.Field(&p.IdempotencyKeyTTL, validation.Min(time.Duration(0))),
 	)
 
 	if err := validation.ValidateStruct(p, fields...); err != nil {
@@ -424,8 +437,8 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 
 	// Set a timeout value on the request context (ctx), that will signal
 	// through ctx.Done() that the request has timed out and further
-	// processing should be stopped.
-	r.Use(middleware.Timeout(60 * time.Second))
+	// processing should be stopped. The live change stream is exempt.
+	r.Use(requestTimeout(60 * time.Second))
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	// RED metrics (#843): registered BEFORE Recoverer so it WRAPS it — the
@@ -435,6 +448,11 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 	// 2xx. It still sits inside chi routing, so RoutePattern resolves; it
 	// self-skips "/metrics".
 	r.Use(metrics.HTTPMiddleware)
+	// Request spans: also outside Recoverer so a recovered panic ends the span
+	// as a 500. It continues an incoming traceparent and names the span after
+	// the chi route once routing is done. Spans go nowhere until tracing is
+	// configured (see the tracing package).
+	r.Use(tracing.Middleware())
 	r.Use(middleware.Recoverer)
 	// Sentry panic capture (#844): registered AFTER Recoverer so it sits INSIDE
 	// it. With Repanic, it reports the panic to Sentry and re-panics; Recoverer
@@ -525,6 +543,9 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 	// service can't update users.default_group_id after CreateGroup /
 	// AcceptInvite / RemoveMember.
 	groupService.SetUserRegistry(params.FactorySet.UserRegistry)
+	// Per-location member restrictions: loaded by the slug resolver and
+	// managed under /groups/{groupID}/members/{memberUserID}/scopes.
+	groupService.SetLocationScopeRegistries(params.FactorySet.GroupMemberLocationScopeRegistry, params.FactorySet.LocationRegistryFactory)
 
 	// The impersonation return-slot store (#1750) MUST be a single shared
 	// instance: Admin()'s impersonation endpoints record/restore slots and
@@ -539,10 +560,31 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 		impersonationStore = services.NewInMemoryImpersonationStore()
 	}
 
+	// Registration approval queue: POST /register submits to it and the
+	// back-office routes decide. The approval email links to the login
+	// page when a public URL is configured.
+	registrationLoginURL := ""
+	if params.PublicURL != "" {
+		if u, err := buildPublicURL(params.PublicURL, "/login", nil); err == nil {
+			registrationLoginURL = u
+		}
+	}
+	registrationApprovals := services.NewRegistrationApprovalService(params.FactorySet, emailSvc, registrationLoginURL)
+
+	// Resolve the change feed broker: default to in-memory if not provided.
+	changeFeedBroker := params.ChangeFeed
+	if changeFeedBroker == nil {
+		changeFeedBroker = changefeedinmemory.New()
+	}
+	changeFeed := services.NewChangeFeed(changeFeedBroker, params.FactorySet)
+
 	r.Route("/api/v1", func(r chi.Router) {
 		// Resolve tenant from request host and place it in context for all handlers,
 		// including public ones (login, registration, password reset).
 		r.Use(PublicTenantMiddleware(tenantResolver, params.FactorySet.TenantRegistry))
+		// Every write path may publish to the live change stream, including
+		// the unauthenticated borrower loan links.
+		r.Use(ChangeFeedMiddleware(changeFeed))
 
 		// Auth routes have dedicated per-endpoint rate limiters (login, registration,
 		// password-reset); applying the global per-IP limit here would lock users out
@@ -637,6 +679,7 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 				AuditService:         auditSvc,
 				RateLimiter:          rateLimiter,
 				GroupService:         groupService,
+				ApprovalService:      registrationApprovals,
 				PublicBaseURL:        params.PublicURL,
 			}))
 			r.Group(PasswordReset(PasswordResetParams{
@@ -691,6 +734,14 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 			// is wrapped with the userMiddlewares chain inside the Invites
 			// router itself.
 			r.Route("/invites", Invites(groupService, userMiddlewares))
+			// Borrower self-service loan links: the signed,
+			// expiring sig/exp pair on the URL stands in for a session,
+			// so these stay outside the JWT / RLS chain like /invites.
+			r.Route("/public/loans", CommodityLoansPublic(params))
+			// Calendar subscription feeds: the secret token in the .ics
+			// URL is the credential, since calendar clients cannot send
+			// a session.
+			r.Route("/public/calendar", CalendarFeedsPublic(params))
 		})
 
 		// Protected routes (authentication required).
@@ -732,7 +783,8 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 			// logout rather than POST /admin/impersonation/end (#1750).
 			ImpersonationStore: impersonationStore,
 			// #2113 L-4: GET /admin/debug now lives on the back-office plane.
-			DebugInfo: params.DebugInfo,
+			DebugInfo:                   params.DebugInfo,
+			RegistrationApprovalService: registrationApprovals,
 		}))
 		// The former /api/v1/users admin CRUD was removed together with the
 		// tenant-level `users.role` column. Per-group user management lives
@@ -746,6 +798,9 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 			RefreshTokenRegistry: params.FactorySet.RefreshTokenRegistry,
 			LoginEventRegistry:   params.FactorySet.LoginEventRegistry,
 		}))
+		// Per-user calendar subscription feeds; the .ics download itself
+		// is mounted under /public/calendar above.
+		r.With(userMiddlewares...).Route("/users/me/calendar-feeds", CalendarFeeds(params))
 		// In-app feedback / contact support (#1387). Auth-required and
 		// per-user rate-limited (5/hour). The handler returns a typed 503
 		// (feedback.not_configured) when SupportEmail is empty, so it stays
@@ -772,7 +827,13 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 		// — GET/HEAD/OPTIONS bypass the role check.
 		structuralWriteGate := requireGroupRoleForWrite(groupService, models.GroupRoleAdmin)
 		contentWriteGate := requireGroupRoleForWrite(groupService, models.GroupRoleUser)
+		// Idempotency-Key replay for POSTs (creates, exports, uploads, scans).
+		// Runs after the auth/group chain so keys are scoped to the user and
+		// the fingerprinted path names the group. The JSON-only content-type
+		// guard keeps multipart out of this tree, hence no spool cap here.
+		idempotency := IdempotencyMiddleware(params.FactorySet.IdempotencyKeyRegistry, params.IdempotencyKeyTTL, 0)
 		r.With(groupScopedMiddlewares...).Route("/g/{groupSlug}", func(r chi.Router) {
+			r.Use(idempotency)
 			r.With(structuralWriteGate).Route("/locations", Locations(params))
 			r.With(structuralWriteGate).Route("/areas", Areas(params))
 			// Commodity write paths are guarded by requireGroupNotMigrating
@@ -783,14 +844,25 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 				requireGroupNotMigrating(GroupMigrationLockOptions{FeatureEnabled: params.FeatureCurrencyMigration}),
 				contentWriteGate,
 			).Route("/commodities", Commodities(params))
+			r.With(
+				requireGroupNotMigrating(GroupMigrationLockOptions{FeatureEnabled: params.FeatureCurrencyMigration}),
+				contentWriteGate,
+				requireUnscopedMember,
+			).Route("/transfers", GroupTransfers(params))
 			r.With(contentWriteGate).Route("/files", Files(params))
 			r.With(contentWriteGate).Route("/tags", Tags(params))
 			r.With(contentWriteGate).Route("/loans", GroupLoans(params))
 			r.With(contentWriteGate).Route("/services", GroupServices(params))
 			r.With(contentWriteGate).Route("/maintenance", GroupMaintenance(params))
-			r.With(structuralWriteGate).Route("/exports", Exports(params, restoreStatus))
+			r.With(contentWriteGate).Route("/invoice-extractions", InvoiceExtractions(params))
+			r.With(contentWriteGate).Route("/saved-views", SavedViews(groupService))
+			// Group-wide resources are closed to location-scoped members:
+			// they read or rewrite every location at once.
+			r.With(structuralWriteGate, requireUnscopedMember).Route("/exports", Exports(params, restoreStatus))
+			r.With(structuralWriteGate, requireUnscopedMember).Route("/backup-schedule", BackupSchedule())
 			r.Route("/settings", Settings())
 			r.Route("/commodities/values", Values())
+			r.Route("/stats", Stats())
 			r.Route("/upload-slots", UploadSlots(params.FactorySet))
 			r.Route("/search", Search(params.EntityService))
 			// Currency-migration endpoints are always mounted so swagger
@@ -798,7 +870,7 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 			// returns 404 when params.FeatureCurrencyMigration is false,
 			// keeping the surface inert in production (#202 §8) until
 			// the operator flips the flag on.
-			r.Route("/currency-migrations", CurrencyMigrations(params, groupService, auditSvc))
+			r.With(requireUnscopedMember).Route("/currency-migrations", CurrencyMigrations(params, groupService, auditSvc))
 			r.Route("/storage-usage", StorageUsage())
 			r.Route("/plan", GroupPlan())
 			r.Route("/notifications", GroupNotifications(params.FactorySet))
@@ -808,7 +880,9 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 		// GroupSlugResolverMiddleware runs BEFORE RegistrySetMiddleware so the
 		// registry set is built with group context.
 		groupUploadMiddlewares := createGroupAwareMiddlewaresForUploads(params.JWTSecret, params.FactorySet.UserRegistry, params.FactorySet, blacklist, csrfSvc, groupService)
-		r.With(groupUploadMiddlewares...).Route("/g/{groupSlug}/uploads", Uploads(params))
+		r.With(append(groupUploadMiddlewares,
+			IdempotencyMiddleware(params.FactorySet.IdempotencyKeyRegistry, params.IdempotencyKeyTTL, params.MaxUploadBytes),
+		)...).Route("/g/{groupSlug}/uploads", Uploads(params))
 
 		// AI vision photo-scan endpoint (#1720). Bypasses the default
 		// JSON:API content-type guards because it accepts
@@ -818,11 +892,19 @@ func APIServer(params Params, restoreStatus RestoreStatusQuerier) http.Handler {
 		// applies, since this is effectively a "prepare an Add Item"
 		// affordance.
 		contentWriteGateScan := requireGroupRoleForWrite(groupService, models.GroupRoleUser)
-		r.With(append(groupUploadMiddlewares, contentWriteGateScan)...).Route(
+		r.With(append(groupUploadMiddlewares,
+			contentWriteGateScan,
+			IdempotencyMiddleware(params.FactorySet.IdempotencyKeyRegistry, params.IdempotencyKeyTTL, params.CommodityScanMaxBodyBytes),
+		)...).Route(
 			"/g/{groupSlug}/commodities/scan",
 			CommodityScan(params.CommodityScanService, params.CommodityScanMaxBodyBytes, params.CommodityScanMaxPhotoBytes),
 		)
 
+		// Live change stream (Server-Sent Events). Uses the upload chain
+		// because EventSource-style clients send Accept: text/event-stream,
+		// which the JSON:API content-type guards would reject.
+		r.With(groupUploadMiddlewares...).Route("/g/{groupSlug}/events", EventsStream(changeFeedBroker))
+
 		// File downloads use signed URL validation instead of JWT authentication
 		fileSigningService := services.NewFileSigningService(params.FileSigningKey, params.FileURLExpiration)
 		signedURLMiddleware := SignedURLMiddleware(
@@ -2,6 +2,7 @@ package apiserver
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/go-chi/render"
 
 	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/changefeed"
 	"github.com/denisvmedia/inventario/jsonapi"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -79,6 +81,7 @@ func (api *areasAPI) listAreas(w http.ResponseWriter, r *http.Request) {
 // @Param groupSlug path string true "Group slug"
 // @Param areaID path string true "Area ID"
 // @Success 200 {object} jsonapi.AreaResponse "OK"
+// @Header 200 {string} ETag "Entity version, for If-Match on update and delete"
 // @Router /g/{groupSlug}/areas/{areaID} [get].
 func (api *areasAPI) getArea(w http.ResponseWriter, r *http.Request) { //revive:disable-line:get-return
 	area := areaFromContext(r.Context())
@@ -87,6 +90,7 @@ func (api *areasAPI) getArea(w http.ResponseWriter, r *http.Request) { //revive:
 		return
 	}
 
+	setETag(w, area)
 	resp := jsonapi.NewAreaResponse(area)
 	if err := render.Render(w, r, resp); err != nil {
 		internalServerError(w, r, err)
@@ -140,7 +144,9 @@ func (api *areasAPI) createArea(w http.ResponseWriter, r *http.Request) {
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(ctx).AreaChanged(ctx, changefeed.ActionCreated, createdArea)
 
+	setETag(w, createdArea)
 	resp := jsonapi.NewAreaResponse(createdArea).WithStatusCode(http.StatusCreated)
 	if err := render.Render(w, r, resp); err != nil {
 		internalServerError(w, r, err)
@@ -157,8 +163,10 @@ func (api *areasAPI) createArea(w http.ResponseWriter, r *http.Request) {
 // @Param groupSlug path string true "Group slug"
 // @Param areaID path string true "Area ID"
 // @Param strategy query string false "Non-empty-area strategy: cascade deletes the commodities, unlink keeps them area-less. Omit to reject a non-empty area." Enums(cascade, unlink)
+// @Param If-Match header string false "ETag from GET; the delete is refused when the area has changed since"
 // @Success 204 "No content"
 // @Failure 404 {object} jsonapi.Errors "Area not found"
+// @Failure 412 {object} jsonapi.AreaResponse "Area changed since the If-Match ETag; body is the current area"
 // @Failure 422 {object} jsonapi.Errors "Non-empty area (no strategy) or unknown strategy"
 // @Router /g/{groupSlug}/areas/{areaID} [delete].
 func (api *areasAPI) deleteArea(w http.ResponseWriter, r *http.Request) {
@@ -168,6 +176,12 @@ func (api *areasAPI) deleteArea(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, area)
+	if !ok {
+		preconditionFailed(w, r, area, jsonapi.NewAreaResponse(area).WithStatusCode(http.StatusPreconditionFailed))
+		return
+	}
+
 	// #2137: a non-empty area is deleted according to the chosen strategy:
 	//   absent  → DeleteArea (non-recursive; #2119 — empty only, 422 on
 	//             ErrCannotDelete for a non-empty area; drops the area's files)
@@ -181,10 +195,25 @@ func (api *areasAPI) deleteArea(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := deleteFn(r.Context(), area.ID); err != nil {
+	// The row is deleted only while it still carries the If-Match version.
+	ctx := registry.WithExpectedVersion(r.Context(), area.ID, expectedVersion)
+	err = deleteFn(ctx, area.ID)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if registrySet := RegistrySetFromContext(ctx); registrySet != nil {
+			if current, getErr := registrySet.AreaRegistry.Get(ctx, area.ID); getErr == nil {
+				area = current
+			}
+		}
+		preconditionFailed(w, r, area, jsonapi.NewAreaResponse(area).WithStatusCode(http.StatusPreconditionFailed))
+		return
+	}
+	if err != nil {
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(ctx).AreaChanged(ctx, changefeed.ActionDeleted, area)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -218,8 +247,10 @@ func resolveAreaDeleteStrategy(svc *services.EntityService, strategy string) (en
 // @Param groupSlug path string true "Group slug"
 // @Param areaID path string true "Area ID"
 // @Param area body jsonapi.AreaRequest true "Area object"
+// @Param If-Match header string false "ETag from GET; the update is refused when the area has changed since"
 // @Success 200 {object} jsonapi.AreaResponse "OK"
 // @Failure 404 {object} jsonapi.Errors "Area not found"
+// @Failure 412 {object} jsonapi.AreaResponse "Area changed since the If-Match ETag; body is the current area"
 // @Failure 422 {object} jsonapi.Errors "User-side request problem"
 // @Router /g/{groupSlug}/areas/{areaID} [put].
 func (api *areasAPI) updateArea(w http.ResponseWriter, r *http.Request) {
@@ -240,12 +271,19 @@ func (api *areasAPI) updateArea(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, area)
+	if !ok {
+		preconditionFailed(w, r, area, jsonapi.NewAreaResponse(area).WithStatusCode(http.StatusPreconditionFailed))
+		return
+	}
+
 	// Preserve tenant_id and user_id from the existing area
 	// This ensures the foreign key constraints are satisfied during updates
 	updateData := *input.Data.Attributes
 	if updateData.TenantID == "" {
 		updateData.TenantID = area.TenantID
 	}
+	updateData.Version = expectedVersion
 
 	// Get user-aware settings registry from context
 	registrySet := RegistrySetFromContext(r.Context())
@@ -258,11 +296,22 @@ func (api *areasAPI) updateArea(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	areaReg := registrySet.AreaRegistry
 	newArea, err := areaReg.Update(ctx, updateData)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if current, getErr := areaReg.Get(ctx, area.ID); getErr == nil {
+			area = current
+		}
+		preconditionFailed(w, r, area, jsonapi.NewAreaResponse(area).WithStatusCode(http.StatusPreconditionFailed))
+		return
+	}
 	if err != nil {
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(ctx).AreaChanged(ctx, changefeed.ActionUpdated, newArea)
 
+	setETag(w, newArea)
 	resp := jsonapi.NewAreaResponse(newArea).WithStatusCode(http.StatusOK)
 	if err := render.Render(w, r, resp); err != nil {
 		internalServerError(w, r, err)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -505,6 +506,89 @@ func TestAreaUpdate(t *testing.T) {
 	c.Assert(body, checkers.JSONPathEquals("$.data.attributes.location_id"), area.LocationID)
 }
 
+func TestAreaUpdate_IfMatch(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	ctx := createTestUserContext(testUser.ID, testUser.TenantID)
+	registrySet := must.Must(params.FactorySet.CreateUserRegistrySet(ctx))
+	expectedAreas := must.Must(registrySet.AreaRegistry.List(context.Background()))
+	area := expectedAreas[0]
+
+	mockRestoreWorker := &mockRestoreWorker{hasRunningRestores: false}
+	handler := apiserver.APIServer(params, mockRestoreWorker)
+
+	doPUT := func(name, ifMatch string) *httptest.ResponseRecorder {
+		obj := &jsonapi.AreaRequest{
+			Data: &jsonapi.AreaData{
+				ID:   area.ID,
+				Type: "areas",
+				Attributes: models.WithID(area.ID, &models.Area{
+					Name:       name,
+					LocationID: area.LocationID,
+				}),
+			},
+		}
+		req, err := http.NewRequest("PUT", "/api/v1/g/"+testGroup.Slug+"/areas/"+area.ID, bytes.NewReader(must.Must(json.Marshal(obj))))
+		c.Assert(err, qt.IsNil)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		addTestUserAuthHeader(req, testUser.ID)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	req, err := http.NewRequest("GET", "/api/v1/g/"+testGroup.Slug+"/areas/"+area.ID, nil)
+	c.Assert(err, qt.IsNil)
+	addTestUserAuthHeader(req, testUser.ID)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	etag := rr.Header().Get("ETag")
+	c.Assert(etag, qt.Not(qt.Equals), "")
+
+	rr = doPUT("First Writer", etag)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("Body: %s", rr.Body.String()))
+	c.Assert(rr.Header().Get("ETag"), qt.Not(qt.Equals), etag)
+
+	// Second writer still holds the original ETag.
+	rr = doPUT("Second Writer", etag)
+	c.Assert(rr.Code, qt.Equals, http.StatusPreconditionFailed, qt.Commentf("Body: %s", rr.Body.String()))
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.attributes.name"), "First Writer")
+
+	stored := must.Must(registrySet.AreaRegistry.Get(context.Background(), area.ID))
+	c.Assert(stored.Name, qt.Equals, "First Writer")
+	c.Assert(rr.Header().Get("ETag"), qt.Equals, fmt.Sprintf("%q", fmt.Sprint(stored.Version)))
+}
+
+func TestAreaDelete_IfMatchStale(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParamsAreaRegistryOnly()
+	ctx := createTestUserContext(testUser.ID, testUser.TenantID)
+	registrySet := must.Must(params.FactorySet.CreateUserRegistrySet(ctx))
+	expectedAreas := must.Must(registrySet.AreaRegistry.List(context.Background()))
+	area := expectedAreas[0]
+
+	req, err := http.NewRequest("DELETE", "/api/v1/g/"+testGroup.Slug+"/areas/"+area.ID, nil)
+	c.Assert(err, qt.IsNil)
+	req.Header.Set("If-Match", `"999"`)
+	addTestUserAuthHeader(req, testUser.ID)
+
+	rr := httptest.NewRecorder()
+
+	mockRestoreWorker := &mockRestoreWorker{hasRunningRestores: false}
+	handler := apiserver.APIServer(params, mockRestoreWorker)
+	handler.ServeHTTP(rr, req)
+
+	c.Assert(rr.Code, qt.Equals, http.StatusPreconditionFailed)
+	c.Assert(rr.Body.Bytes(), checkers.JSONPathEquals("$.data.id"), area.ID)
+
+	_, err = registrySet.AreaRegistry.Get(context.Background(), area.ID)
+	c.Assert(err, qt.IsNil)
+}
+
 func TestAreaGet_InvalidID(t *testing.T) {
 	c := qt.New(t)
 
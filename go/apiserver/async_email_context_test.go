@@ -134,6 +134,25 @@ func (m *blockingEmailService) SendMaintenanceReminderEmail(_ context.Context, _
 	return nil
 }
 
+func (m *blockingEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+
+func (m *blockingEmailService) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+func (*blockingEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*blockingEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*blockingEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return nil
+}
+
 func (m *blockingEmailService) SendFeedbackEmail(_ context.Context, _, _, _, _, _, _, _ string, _ []string) error {
 	return nil
 }
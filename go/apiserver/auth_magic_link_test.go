@@ -89,6 +89,25 @@ func (m *recordingMagicLinkEmailService) SendMaintenanceReminderEmail(_ context.
 	return nil
 }
 
+func (m *recordingMagicLinkEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+
+func (m *recordingMagicLinkEmailService) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+func (*recordingMagicLinkEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingMagicLinkEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingMagicLinkEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return nil
+}
+
 func (m *recordingMagicLinkEmailService) SendFeedbackEmail(_ context.Context, _, _, _, _, _, _, _ string, _ []string) error {
 	return nil
 }
@@ -233,6 +233,25 @@ func (m *mockEmailServiceForAuth) SendMaintenanceReminderEmail(_ context.Context
 	return nil
 }
 
+func (m *mockEmailServiceForAuth) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+
+func (m *mockEmailServiceForAuth) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+func (*mockEmailServiceForAuth) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*mockEmailServiceForAuth) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*mockEmailServiceForAuth) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return nil
+}
+
 func (m *mockEmailServiceForAuth) SendFeedbackEmail(_ context.Context, _, _, _, _, _, _, _ string, _ []string) error {
 	return nil
 }
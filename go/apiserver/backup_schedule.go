@@ -0,0 +1,164 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+type backupScheduleAPI struct{}
+
+// getBackupSchedule returns the group's backup schedule.
+//
+// @Summary Get the group backup schedule
+// @Description Get the group's automatic backup schedule, including the next run time and the outcome of the last run.
+// @Tags backup_schedules
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Success 200 {object} jsonapi.BackupScheduleResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "The group has no backup schedule"
+// @Router /g/{groupSlug}/backup-schedule [get].
+func (*backupScheduleAPI) getBackupSchedule(w http.ResponseWriter, r *http.Request) {
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+	schedule, err := regSet.BackupScheduleRegistry.GetForGroup(r.Context())
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewBackupScheduleResponse(schedule)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// putBackupSchedule creates the group's backup schedule or replaces its
+// configuration. Saving always recomputes the next run from now; the
+// last-run state of an existing schedule is kept.
+//
+// @Summary Create or replace the group backup schedule
+// @Description Configure automatic full-database backups for the group: frequency, UTC time of day, whether file data is included and the daily/weekly/monthly retention. Scheduled exports are pruned to the retention after each successful run; manual exports are never pruned.
+// @Tags backup_schedules
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param schedule body jsonapi.BackupScheduleRequest true "Backup schedule attributes"
+// @Success 200 {object} jsonapi.BackupScheduleResponse "Schedule replaced"
+// @Success 201 {object} jsonapi.BackupScheduleResponse "Schedule created"
+// @Failure 422 {object} jsonapi.Errors "Invalid schedule"
+// @Router /g/{groupSlug}/backup-schedule [put].
+func (*backupScheduleAPI) putBackupSchedule(w http.ResponseWriter, r *http.Request) {
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var input jsonapi.BackupScheduleRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	existing, err := regSet.BackupScheduleRegistry.GetForGroup(r.Context())
+	if err != nil && !errors.Is(err, registry.ErrNotFound) {
+		renderEntityError(w, r, err)
+		return
+	}
+	var schedule models.BackupSchedule
+	if existing != nil {
+		schedule = *existing
+	}
+
+	attrs := input.Data.Attributes
+	schedule.Enabled = attrs.Enabled
+	schedule.Frequency = attrs.Frequency
+	schedule.TimeOfDay = attrs.TimeOfDay
+	schedule.Weekday = attrs.Weekday
+	schedule.DayOfMonth = attrs.DayOfMonth
+	if schedule.DayOfMonth == 0 {
+		// Only monthly schedules use it; don't make daily and weekly
+		// clients send a placeholder.
+		schedule.DayOfMonth = 1
+	}
+	schedule.IncludeFileData = attrs.IncludeFileData
+	schedule.KeepDaily = attrs.KeepDaily
+	schedule.KeepWeekly = attrs.KeepWeekly
+	schedule.KeepMonthly = attrs.KeepMonthly
+	if err := schedule.ValidateWithContext(r.Context()); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	schedule.NextRunAt = schedule.NextRunAfter(time.Now())
+
+	if existing == nil {
+		created, err := regSet.BackupScheduleRegistry.Create(r.Context(), schedule)
+		if err != nil {
+			renderEntityError(w, r, err)
+			return
+		}
+		if err := render.Render(w, r, jsonapi.NewBackupScheduleResponse(created).WithStatusCode(http.StatusCreated)); err != nil {
+			internalServerError(w, r, err)
+		}
+		return
+	}
+
+	updated, err := regSet.BackupScheduleRegistry.Update(r.Context(), schedule)
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewBackupScheduleResponse(updated)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// deleteBackupSchedule removes the group's backup schedule. Exports it
+// already produced are kept and become ordinary exports.
+//
+// @Summary Delete the group backup schedule
+// @Description Stop automatic backups for the group. Existing scheduled exports are kept.
+// @Tags backup_schedules
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Success 204 "No Content"
+// @Failure 404 {object} jsonapi.Errors "The group has no backup schedule"
+// @Router /g/{groupSlug}/backup-schedule [delete].
+func (*backupScheduleAPI) deleteBackupSchedule(w http.ResponseWriter, r *http.Request) {
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+	schedule, err := regSet.BackupScheduleRegistry.GetForGroup(r.Context())
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	if err := regSet.BackupScheduleRegistry.Delete(r.Context(), schedule.ID); err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BackupSchedule returns the chi sub-router for /backup-schedule.
+func BackupSchedule() func(r chi.Router) {
+	api := &backupScheduleAPI{}
+	return func(r chi.Router) {
+		r.Get("/", api.getBackupSchedule)
+		r.Put("/", api.putBackupSchedule)
+		r.Delete("/", api.deleteBackupSchedule)
+	}
+}
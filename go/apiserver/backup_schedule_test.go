@@ -0,0 +1,61 @@
+package apiserver_test
+
+import (
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/internal/checkers"
+)
+
+func TestBackupScheduleAPI_Lifecycle(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	url := "/api/v1/g/" + testGroup.Slug + "/backup-schedule"
+
+	rr := serveSavedViews(params, testUser.ID, http.MethodGet, url, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusNotFound, qt.Commentf("body=%s", rr.Body.String()))
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodPut, url,
+		`{"data":{"type":"backup_schedules","attributes":{"enabled":true,"frequency":"weekly","time_of_day":"02:30","weekday":6,"include_file_data":true,"keep_daily":7,"keep_weekly":4}}}`)
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.type"), "backup_schedules")
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.frequency"), "weekly")
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.day_of_month"), float64(1))
+	c.Check(rr.Body.String(), checkers.JSONPathMatches("$.data.attributes.next_run_at", qt.Matches), `^\d{4}-\d{2}-\d{2}T02:30:00Z$`)
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodPut, url,
+		`{"data":{"type":"backup_schedules","attributes":{"enabled":false,"frequency":"monthly","time_of_day":"03:00","day_of_month":15,"keep_monthly":6}}}`)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.enabled"), false)
+	c.Check(rr.Body.String(), checkers.JSONPathMatches("$.data.attributes.next_run_at", qt.Matches), `^\d{4}-\d{2}-15T03:00:00Z$`)
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodGet, url, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.frequency"), "monthly")
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodDelete, url, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusNoContent, qt.Commentf("body=%s", rr.Body.String()))
+	rr = serveSavedViews(params, testUser.ID, http.MethodGet, url, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusNotFound, qt.Commentf("body=%s", rr.Body.String()))
+}
+
+func TestBackupScheduleAPI_RejectsInvalidSchedules(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	url := "/api/v1/g/" + testGroup.Slug + "/backup-schedule"
+
+	for _, attrs := range []string{
+		`{"frequency":"hourly","time_of_day":"03:00","keep_daily":7}`,
+		`{"frequency":"daily","time_of_day":"3am","keep_daily":7}`,
+		`{"frequency":"monthly","time_of_day":"03:00","day_of_month":31,"keep_daily":7}`,
+		`{"frequency":"daily","time_of_day":"03:00"}`,
+	} {
+		rr := serveSavedViews(params, testUser.ID, http.MethodPut, url,
+			`{"data":{"type":"backup_schedules","attributes":`+attrs+`}}`)
+		c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("attrs=%s body=%s", attrs, rr.Body.String()))
+	}
+}
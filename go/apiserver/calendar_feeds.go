@@ -0,0 +1,328 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// calendarFeedCacheMaxAge is the Cache-Control max-age on the .ics body.
+// Calendar clients poll on their own schedule (Google: hours); this only
+// keeps an eager client from re-rendering the feed on every refresh.
+const calendarFeedCacheMaxAge = "max-age=900"
+
+// CalendarFeedView is the FE-facing shape of a calendar feed. URL is only
+// set on create and rotate — the raw token is not stored, so it cannot be
+// shown again afterwards.
+type CalendarFeedView struct {
+	ID         string     `json:"id"`
+	GroupID    *string    `json:"group_id"`
+	Name       string     `json:"name"`
+	Categories []string   `json:"categories"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	URL        string     `json:"url,omitempty"`
+}
+
+// CalendarFeedsListResponse is the envelope for GET /users/me/calendar-feeds.
+type CalendarFeedsListResponse struct {
+	Feeds []CalendarFeedView `json:"feeds"`
+}
+
+// CalendarFeedCreateRequest is the body of POST /users/me/calendar-feeds.
+// Omit group_id for a feed spanning every group the user belongs to;
+// omit categories (or send []) to publish all of them.
+type CalendarFeedCreateRequest struct {
+	GroupID    *string  `json:"group_id"`
+	Name       string   `json:"name"`
+	Categories []string `json:"categories"`
+}
+
+// CalendarFeedUpdateRequest is the body of PATCH /users/me/calendar-feeds/{id}.
+// The group scope is fixed at creation; create a new feed to change it.
+type CalendarFeedUpdateRequest struct {
+	Name       string   `json:"name"`
+	Categories []string `json:"categories"`
+}
+
+type calendarFeedsAPI struct {
+	feedService *services.CalendarFeedService
+	publicURL   string
+}
+
+// CalendarFeeds registers the authenticated /users/me/calendar-feeds
+// routes. The caller applies the user middleware chain.
+func CalendarFeeds(params Params) func(r chi.Router) {
+	api := newCalendarFeedsAPI(params)
+	return func(r chi.Router) {
+		r.Get("/", api.listFeeds)
+		r.Post("/", api.createFeed)
+		r.Patch("/{id}", api.updateFeed)
+		r.Post("/{id}/rotate", api.rotateFeed)
+		r.Delete("/{id}", api.revokeFeed)
+	}
+}
+
+// CalendarFeedsPublic returns the router for the unauthenticated feed
+// download mounted at /public/calendar. The token in the path is the
+// only credential, like the signed links under /public/loans.
+func CalendarFeedsPublic(params Params) func(r chi.Router) {
+	api := newCalendarFeedsAPI(params)
+	return func(r chi.Router) {
+		r.Get("/{token}.ics", api.getFeedICS)
+	}
+}
+
+func newCalendarFeedsAPI(params Params) *calendarFeedsAPI {
+	return &calendarFeedsAPI{
+		feedService: services.NewCalendarFeedService(params.FactorySet, calendarCommodityURLBuilder(params.PublicURL)),
+		publicURL:   params.PublicURL,
+	}
+}
+
+// calendarCommodityURLBuilder builds the commodity deep link attached to
+// feed events, or returns nil when no public URL is configured.
+func calendarCommodityURLBuilder(publicURL string) func(groupSlug, commodityID string) string {
+	if strings.TrimSpace(publicURL) == "" {
+		return nil
+	}
+	return func(groupSlug, commodityID string) string {
+		link, err := buildPublicURL(publicURL, "/g/"+groupSlug+"/commodities/"+commodityID, nil)
+		if err != nil {
+			return ""
+		}
+		return link
+	}
+}
+
+// listFeeds returns the user's calendar feeds.
+// @Summary List calendar feeds
+// @Description Returns the authenticated user's calendar subscription feeds. Feed URLs are not included; they are shown once on create and rotate.
+// @Tags users-me
+// @Produce json
+// @Success 200 {object} CalendarFeedsListResponse "OK"
+// @Failure 401 {string} string "Unauthorized"
+// @Router /users/me/calendar-feeds [get]
+func (api *calendarFeedsAPI) listFeeds(w http.ResponseWriter, r *http.Request) {
+	user := appctx.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	feeds, err := api.feedService.List(r.Context(), user)
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	views := make([]CalendarFeedView, 0, len(feeds))
+	for _, f := range feeds {
+		views = append(views, newCalendarFeedView(f, ""))
+	}
+	writeJSON(w, http.StatusOK, CalendarFeedsListResponse{Feeds: views})
+}
+
+// createFeed creates a calendar feed and returns its subscription URL.
+// @Summary Create a calendar feed
+// @Description Creates an iCalendar subscription feed of warranty expirations, loan due dates, service returns and maintenance due dates. The response carries the subscription URL, which is shown only once.
+// @Tags users-me
+// @Accept json
+// @Produce json
+// @Param request body CalendarFeedCreateRequest true "Feed settings"
+// @Success 201 {object} CalendarFeedView "Created"
+// @Failure 400 {object} jsonapi.Errors "Malformed body"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} jsonapi.Errors "Not a member of the group"
+// @Failure 422 {object} jsonapi.Errors "Unknown category or invalid name"
+// @Router /users/me/calendar-feeds [post]
+func (api *calendarFeedsAPI) createFeed(w http.ResponseWriter, r *http.Request) {
+	user := appctx.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var req CalendarFeedCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	feed, token, err := api.feedService.Create(r.Context(), user, services.CalendarFeedInput{
+		GroupID:    req.GroupID,
+		Name:       strings.TrimSpace(req.Name),
+		Categories: req.Categories,
+	})
+	if err != nil {
+		api.renderFeedError(w, r, err)
+		return
+	}
+	api.writeFeedWithURL(w, r, http.StatusCreated, feed, token)
+}
+
+// updateFeed renames a feed or changes its categories.
+// @Summary Update a calendar feed
+// @Description Changes a feed's name and categories. The subscription URL is unchanged.
+// @Tags users-me
+// @Accept json
+// @Produce json
+// @Param id path string true "Feed ID"
+// @Param request body CalendarFeedUpdateRequest true "Feed settings"
+// @Success 200 {object} CalendarFeedView "OK"
+// @Failure 400 {object} jsonapi.Errors "Malformed body"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {object} jsonapi.Errors "Feed not found"
+// @Failure 422 {object} jsonapi.Errors "Unknown category or invalid name"
+// @Router /users/me/calendar-feeds/{id} [patch]
+func (api *calendarFeedsAPI) updateFeed(w http.ResponseWriter, r *http.Request) {
+	user := appctx.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var req CalendarFeedUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	feed, err := api.feedService.Update(r.Context(), user, chi.URLParam(r, "id"), services.CalendarFeedInput{
+		Name:       strings.TrimSpace(req.Name),
+		Categories: req.Categories,
+	})
+	if err != nil {
+		api.renderFeedError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newCalendarFeedView(feed, ""))
+}
+
+// rotateFeed replaces the feed's token.
+// @Summary Rotate a calendar feed URL
+// @Description Replaces the feed's secret token. The old URL stops working immediately; the new one is returned once.
+// @Tags users-me
+// @Produce json
+// @Param id path string true "Feed ID"
+// @Success 200 {object} CalendarFeedView "OK"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {object} jsonapi.Errors "Feed not found"
+// @Router /users/me/calendar-feeds/{id}/rotate [post]
+func (api *calendarFeedsAPI) rotateFeed(w http.ResponseWriter, r *http.Request) {
+	user := appctx.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	feed, token, err := api.feedService.Rotate(r.Context(), user, chi.URLParam(r, "id"), time.Now().UTC())
+	if err != nil {
+		api.renderFeedError(w, r, err)
+		return
+	}
+	api.writeFeedWithURL(w, r, http.StatusOK, feed, token)
+}
+
+// revokeFeed deletes a feed.
+// @Summary Revoke a calendar feed
+// @Description Deletes the feed. Its URL stops working immediately.
+// @Tags users-me
+// @Param id path string true "Feed ID"
+// @Success 204 "No Content"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {object} jsonapi.Errors "Feed not found"
+// @Router /users/me/calendar-feeds/{id} [delete]
+func (api *calendarFeedsAPI) revokeFeed(w http.ResponseWriter, r *http.Request) {
+	user := appctx.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := api.feedService.Revoke(r.Context(), user, chi.URLParam(r, "id")); err != nil {
+		api.renderFeedError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getFeedICS serves the feed as an iCalendar document.
+// @Summary Download a calendar feed
+// @Description Unauthenticated. The token in the path is the feed's secret; calendar clients subscribe to this URL. Unknown, rotated and revoked tokens return 404.
+// @Tags users-me
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 404 {string} string "Not Found"
+// @Router /public/calendar/{token}.ics [get]
+func (api *calendarFeedsAPI) getFeedICS(w http.ResponseWriter, r *http.Request) {
+	cal, err := api.feedService.Render(r.Context(), chi.URLParam(r, "token"), time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to render calendar feed", "error", err)
+		http.Error(w, "Failed to render calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="inventario.ics"`)
+	w.Header().Set("Cache-Control", "private, "+calendarFeedCacheMaxAge)
+	w.WriteHeader(http.StatusOK)
+	_, _ = cal.WriteTo(w)
+}
+
+func (api *calendarFeedsAPI) writeFeedWithURL(w http.ResponseWriter, r *http.Request, status int, feed *models.CalendarFeed, token string) {
+	feedURL, err := buildPublicURL(api.publicURL, "/api/v1/public/calendar/"+token+".ics", nil)
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	writeJSON(w, status, newCalendarFeedView(feed, feedURL))
+}
+
+// renderFeedError maps the feed service's user-side failures: a group
+// the user does not belong to is 403, an unknown category or a model
+// validation failure 422, everything else goes through renderEntityError.
+func (*calendarFeedsAPI) renderFeedError(w http.ResponseWriter, r *http.Request, err error) {
+	var verrs validation.Errors
+	switch {
+	case errors.Is(err, services.ErrCalendarFeedGroupAccess):
+		codedForbiddenError(w, r, err, "calendar_feed.group_access")
+	case errors.Is(err, services.ErrCalendarFeedUnknownCategory) || errors.As(err, &verrs):
+		unprocessableEntityError(w, r, err)
+	default:
+		renderEntityError(w, r, err)
+	}
+}
+
+func newCalendarFeedView(f *models.CalendarFeed, feedURL string) CalendarFeedView {
+	categories := []string(f.Categories)
+	if categories == nil {
+		categories = []string{}
+	}
+	return CalendarFeedView{
+		ID:         f.ID,
+		GroupID:    f.GroupID,
+		Name:       f.Name,
+		Categories: categories,
+		CreatedAt:  f.CreatedAt,
+		RotatedAt:  f.RotatedAt,
+		LastUsedAt: f.LastUsedAt,
+		URL:        feedURL,
+	}
+}
@@ -0,0 +1,100 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// TestCalendarFeed_SubscribeRotateRevoke walks a feed's lifecycle: the
+// URL returned on create serves the .ics without a session, rotating
+// kills the old URL, and revoking kills the new one.
+func TestCalendarFeed_SubscribeRotateRevoke(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	params.PublicURL = "https://inventario.example"
+	registrySet := getRegistrySetFromParams(params, testUser)
+	areas := must.Must(registrySet.AreaRegistry.List(context.Background()))
+	c.Assert(areas, qt.Not(qt.HasLen), 0)
+	expires := models.Date("2027-03-01")
+	commodity := must.Must(registrySet.CommodityRegistry.Create(context.Background(), models.Commodity{
+		Name:              "Dishwasher",
+		ShortName:         "dishwasher",
+		AreaID:            new(areas[0].ID),
+		Status:            models.CommodityStatusInUse,
+		Type:              models.CommodityTypeWhiteGoods,
+		Count:             1,
+		WarrantyExpiresAt: &expires,
+	}))
+
+	handler := apiserver.APIServer(params, &mockRestoreWorker{})
+	do := func(method, path, body string, authed bool) *httptest.ResponseRecorder {
+		req := must.Must(http.NewRequest(method, path, bytes.NewBufferString(body)))
+		req.Header.Set("Content-Type", "application/json")
+		if authed {
+			addTestUserAuthHeader(req, testUser.ID)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	feedPath := func(view apiserver.CalendarFeedView) string {
+		u := must.Must(url.Parse(view.URL))
+		c.Assert(u.Host, qt.Equals, "inventario.example")
+		return u.Path
+	}
+
+	rr := do(http.MethodPost, "/api/v1/users/me/calendar-feeds", `{"name":"Phone","categories":["bogus"]}`, true)
+	c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("body=%s", rr.Body.String()))
+
+	rr = do(http.MethodPost, "/api/v1/users/me/calendar-feeds",
+		`{"name":"Phone","group_id":"`+testGroup.ID+`","categories":["warranty_expiry"]}`, true)
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	var created apiserver.CalendarFeedView
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &created), qt.IsNil)
+	firstPath := feedPath(created)
+
+	rr = do(http.MethodGet, firstPath, "", false)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Assert(rr.Header().Get("Content-Type"), qt.Equals, "text/calendar; charset=utf-8")
+	// Long content lines are folded (CRLF + space); unfold before matching.
+	ics := strings.ReplaceAll(rr.Body.String(), "\r\n ", "")
+	c.Assert(ics, qt.Contains, "UID:warranty-"+commodity.ID+"@inventario\r\n")
+	c.Assert(ics, qt.Contains, "DTSTART;VALUE=DATE:20270301\r\n")
+	c.Assert(ics, qt.Contains, "SUMMARY:Warranty expires: Dishwasher\r\n")
+	c.Assert(ics, qt.Contains,
+		"URL:https://inventario.example/g/"+testGroup.Slug+"/commodities/"+commodity.ID+"\r\n")
+
+	rr = do(http.MethodGet, "/api/v1/users/me/calendar-feeds", "", true)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	var list apiserver.CalendarFeedsListResponse
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &list), qt.IsNil)
+	c.Assert(list.Feeds, qt.HasLen, 1)
+	c.Assert(list.Feeds[0].URL, qt.Equals, "")
+	c.Assert(list.Feeds[0].LastUsedAt, qt.IsNotNil)
+
+	rr = do(http.MethodPost, "/api/v1/users/me/calendar-feeds/"+created.ID+"/rotate", "", true)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	var rotated apiserver.CalendarFeedView
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &rotated), qt.IsNil)
+	secondPath := feedPath(rotated)
+	c.Assert(secondPath, qt.Not(qt.Equals), firstPath)
+	c.Assert(do(http.MethodGet, firstPath, "", false).Code, qt.Equals, http.StatusNotFound)
+	c.Assert(do(http.MethodGet, secondPath, "", false).Code, qt.Equals, http.StatusOK)
+
+	rr = do(http.MethodDelete, "/api/v1/users/me/calendar-feeds/"+created.ID, "", true)
+	c.Assert(rr.Code, qt.Equals, http.StatusNoContent)
+	c.Assert(do(http.MethodGet, secondPath, "", false).Code, qt.Equals, http.StatusNotFound)
+}
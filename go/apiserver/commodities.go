@@ -13,6 +13,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/changefeed"
 	"github.com/denisvmedia/inventario/internal/validationctx"
 	"github.com/denisvmedia/inventario/jsonapi"
 	"github.com/denisvmedia/inventario/models"
@@ -25,6 +26,7 @@ type commoditiesAPI struct {
 	tagService    *services.TagService
 	coverService  *services.CommodityCoverService
 	eventService  *services.CommodityEventService
+	mergeService  *services.CommodityMergeService
 	factorySet    *registry.FactorySet
 }
 
@@ -47,7 +49,11 @@ type commoditiesAPI struct {
 // @Param warranty_status query []string false "Filter by computed warranty status (active, expiring, expired, none); repeat to OR" collectionFormat(multi)
 // @Param warranty_expires_before query string false "Restrict to commodities whose warranty expires strictly before YYYY-MM-DD"
 // @Param lent_out query bool false "Filter by current loan state: true = only currently lent (open loan), false = only currently not-lent"
+// @Param tag query []string false "Filter by tag slug; repeat to OR" collectionFormat(multi)
+// @Param view_id query string false "Apply a saved view's filters; explicit query parameters override the view's"
 // @Success 200 {object} jsonapi.CommoditiesResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Saved view not found"
+// @Failure 422 {object} jsonapi.Errors "Saved view references a deleted area or tag (code saved_view.stale_reference)"
 // @Router /g/{groupSlug}/commodities [get].
 func (api *commoditiesAPI) listCommodities(w http.ResponseWriter, r *http.Request) {
 	// Get user-aware settings registry from context
@@ -58,29 +64,18 @@ func (api *commoditiesAPI) listCommodities(w http.ResponseWriter, r *http.Reques
 	}
 	commodityReg := regSet.CommodityRegistry
 
-	q := r.URL.Query()
+	q, err := applySavedView(r.Context(), regSet, r.URL.Query())
+	if err != nil {
+		renderSavedViewError(w, r, err)
+		return
+	}
 	page, perPage := parsePagination(q.Get("page"), q.Get("per_page"))
 	offset := (page - 1) * perPage
 
 	opts := parseCommodityListOptions(q)
-
-	// Pre-resolve the open-loan commodity ID set for the lent_out filter
-	// — but ONLY for backends that don't already resolve LentOut natively
-	// (postgres joins commodity_loans inline via an EXISTS subquery and
-	// implements registry.NativeLentOutFilterer). Skipping the pre-fetch
-	// on postgres saves the extra count+list queries on every filtered
-	// commodities request; the memory backend (without the marker) still
-	// needs the pre-resolved set to evaluate membership without reaching
-	// back into CommodityLoanRegistry.
-	if opts.LentOut != nil && regSet.CommodityLoanRegistry != nil {
-		if _, native := commodityReg.(registry.NativeLentOutFilterer); !native {
-			ids, err := listOpenLoanCommodityIDs(r.Context(), regSet.CommodityLoanRegistry)
-			if err != nil {
-				internalServerError(w, r, err)
-				return
-			}
-			opts.OpenLoanCommodityIDs = ids
-		}
+	if err := resolveOpenLoanFilter(r.Context(), regSet, &opts); err != nil {
+		internalServerError(w, r, err)
+		return
 	}
 
 	commodities, total, err := commodityReg.ListPaginated(r.Context(), offset, perPage, opts)
@@ -157,6 +152,13 @@ func parseCommodityListOptions(q url.Values) registry.CommodityListOptions {
 		}
 		opts.Statuses = append(opts.Statuses, models.CommodityStatus(s))
 	}
+	for _, t := range q["tag"] {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		opts.Tags = append(opts.Tags, t)
+	}
 	if sort := strings.TrimSpace(q.Get("sort")); sort != "" {
 		desc := strings.HasPrefix(sort, "-")
 		field := strings.TrimPrefix(sort, "-")
@@ -194,6 +196,29 @@ func parseCommodityListOptions(q url.Values) registry.CommodityListOptions {
 	return opts
 }
 
+// resolveOpenLoanFilter pre-resolves the open-loan commodity ID set for
+// the lent_out filter — but ONLY for backends that don't already resolve
+// LentOut natively (postgres joins commodity_loans inline via an EXISTS
+// subquery and implements registry.NativeLentOutFilterer). Skipping the
+// pre-fetch on postgres saves the extra count+list queries on every
+// filtered commodities request; the memory backend (without the marker)
+// still needs the pre-resolved set to evaluate membership without
+// reaching back into CommodityLoanRegistry.
+func resolveOpenLoanFilter(ctx context.Context, regSet *registry.Set, opts *registry.CommodityListOptions) error {
+	if opts.LentOut == nil || regSet.CommodityLoanRegistry == nil {
+		return nil
+	}
+	if _, native := regSet.CommodityRegistry.(registry.NativeLentOutFilterer); native {
+		return nil
+	}
+	ids, err := listOpenLoanCommodityIDs(ctx, regSet.CommodityLoanRegistry)
+	if err != nil {
+		return err
+	}
+	opts.OpenLoanCommodityIDs = ids
+	return nil
+}
+
 // listOpenLoanCommodityIDs collects every commodity ID in the current
 // group that has at least one open loan (a commodity_loans row with
 // `returned_at IS NULL`). Pages through CommodityLoanRegistry until the
@@ -242,6 +267,7 @@ func listOpenLoanCommodityIDs(ctx context.Context, loanReg registry.CommodityLoa
 // @Param groupSlug path string true "Group slug"
 // @Param commodityID path string true "Commodity ID"
 // @Success 200 {object} jsonapi.CommodityResponse "OK"
+// @Header 200 {string} ETag "Entity version, for If-Match on update and delete"
 // @Router /g/{groupSlug}/commodities/{commodityID} [get].
 func (api *commoditiesAPI) getCommodity(w http.ResponseWriter, r *http.Request) { //revive:disable-line:get-return
 	commodity := commodityFromContext(r.Context())
@@ -255,12 +281,23 @@ func (api *commoditiesAPI) getCommodity(w http.ResponseWriter, r *http.Request)
 		resp = resp.WithCover(cover)
 	}
 
+	setETag(w, commodity)
 	if err := render.Render(w, r, resp); err != nil {
 		internalServerError(w, r, err)
 		return
 	}
 }
 
+// commodityPreconditionFailed answers 412 with the commodity's current
+// representation, cover included, as GET would render it.
+func (api *commoditiesAPI) commodityPreconditionFailed(w http.ResponseWriter, r *http.Request, commodity *models.Commodity) {
+	resp := jsonapi.NewCommodityResponse(commodity).WithStatusCode(http.StatusPreconditionFailed)
+	if cover := api.resolveCoverForOne(r, commodity); cover != nil {
+		resp = resp.WithCover(cover)
+	}
+	preconditionFailed(w, r, commodity, resp)
+}
+
 // resolveCoverForOne is the single-commodity counterpart to
 // resolveCoversForList. Returns nil when the commodity has no usable
 // photo, or the user context is missing, or signing fails — every path
@@ -363,6 +400,7 @@ func (api *commoditiesAPI) createCommodity(w http.ResponseWriter, r *http.Reques
 	// a failed event must not 500 a successful create.
 	api.eventService.EmitCreated(r.Context(), createdCommodity)
 
+	setETag(w, createdCommodity)
 	resp := jsonapi.NewCommodityResponse(createdCommodity).WithStatusCode(http.StatusCreated)
 
 	if err := render.Render(w, r, resp); err != nil {
@@ -379,8 +417,10 @@ func (api *commoditiesAPI) createCommodity(w http.ResponseWriter, r *http.Reques
 // @Produce  json-api
 // @Param groupSlug path string true "Group slug"
 // @Param commodityID path string true "Commodity ID"
+// @Param If-Match header string false "ETag from GET; the delete is refused when the commodity has changed since"
 // @Success 204 "No content"
 // @Failure 404 {object} jsonapi.Errors "Commodity not found"
+// @Failure 412 {object} jsonapi.CommodityResponse "Commodity changed since the If-Match ETag; body is the current commodity"
 // @Router /g/{groupSlug}/commodities/{commodityID} [delete].
 func (api *commoditiesAPI) deleteCommodity(w http.ResponseWriter, r *http.Request) {
 	commodity := commodityFromContext(r.Context())
@@ -389,6 +429,12 @@ func (api *commoditiesAPI) deleteCommodity(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, commodity)
+	if !ok {
+		api.commodityPreconditionFailed(w, r, commodity)
+		return
+	}
+
 	// #1450: emit the audit row BEFORE the delete so the FK CASCADE on
 	// commodity_events doesn't drop the new event the same instant we
 	// write it. Within the same request the timeline is observable; the
@@ -402,14 +448,32 @@ func (api *commoditiesAPI) deleteCommodity(w http.ResponseWriter, r *http.Reques
 	// emitting after the delete reintroduces the FK CASCADE problem
 	// above. The next call attempt will produce a fresh "deleted" event
 	// and the FK CASCADE wipes both on the eventual successful delete —
-	// the timeline self-heals on retry.
+	// the timeline self-heals on retry. The same holds for an If-Match
+	// delete that loses the race below.
 	api.eventService.EmitDeleted(r.Context(), commodity)
 
-	err := api.entityService.DeleteCommodityRecursive(r.Context(), commodity.ID)
+	// The row is deleted only while it still carries the If-Match version.
+	ctx := registry.WithExpectedVersion(r.Context(), commodity.ID, expectedVersion)
+	err := api.entityService.DeleteCommodityRecursive(ctx, commodity.ID)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if registrySet := RegistrySetFromContext(ctx); registrySet != nil {
+			if current, getErr := registrySet.CommodityRegistry.Get(ctx, commodity.ID); getErr == nil {
+				commodity = current
+			}
+		}
+		api.commodityPreconditionFailed(w, r, commodity)
+		return
+	}
 	if err != nil {
 		renderEntityError(w, r, err)
 		return
 	}
+	// Unlike the timeline row, the live-stream notification goes out only
+	// once the commodity is really gone, so clients refetching on it never
+	// see the row again.
+	services.ChangeFeedFromContext(ctx).CommodityChanged(ctx, changefeed.ActionDeleted, "", commodity)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -449,8 +513,10 @@ func validateForwardStatusTransition(prev, next models.CommodityStatus, statusDa
 // @Param groupSlug path string true "Group slug"
 // @Param commodityID path string true "Commodity ID"
 // @Param commodity body jsonapi.CommodityRequest true "Commodity object"
+// @Param If-Match header string false "ETag from GET; the update is refused when the commodity has changed since"
 // @Success 200 {object} jsonapi.CommodityResponse "OK"
 // @Failure 404 {object} jsonapi.Errors "Commodity not found"
+// @Failure 412 {object} jsonapi.CommodityResponse "Commodity changed since the If-Match ETag; body is the current commodity"
 // @Failure 422 {object} jsonapi.Errors "User-side request problem"
 // @Router /g/{groupSlug}/commodities/{commodityID} [put].
 func (api *commoditiesAPI) updateCommodity(w http.ResponseWriter, r *http.Request) {
@@ -485,6 +551,12 @@ func (api *commoditiesAPI) updateCommodity(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, commodity)
+	if !ok {
+		api.commodityPreconditionFailed(w, r, commodity)
+		return
+	}
+
 	input.Data.Attributes.ID = input.Data.ID
 
 	// Preserve tenant_id and user_id from the existing commodity
@@ -493,6 +565,7 @@ func (api *commoditiesAPI) updateCommodity(w http.ResponseWriter, r *http.Reques
 	if updateData.TenantID == "" {
 		updateData.TenantID = commodity.TenantID
 	}
+	updateData.Version = expectedVersion
 
 	if len(updateData.Tags) > 0 {
 		slugs, terr := api.tagService.NormalizeAndEnsureSlugs(r.Context(), models.TagKindCommodity, []string(updateData.Tags))
@@ -540,6 +613,15 @@ func (api *commoditiesAPI) updateCommodity(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	commodityReg := registrySet.CommodityRegistry
 	updatedCommodity, err := commodityReg.Update(ctx, updateData)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if current, getErr := registrySet.CommodityRegistry.Get(r.Context(), commodity.ID); getErr == nil {
+			commodity = current
+		}
+		api.commodityPreconditionFailed(w, r, commodity)
+		return
+	}
 	if err != nil {
 		renderEntityError(w, r, err)
 		return
@@ -551,6 +633,7 @@ func (api *commoditiesAPI) updateCommodity(w http.ResponseWriter, r *http.Reques
 	// rules.
 	api.eventService.EmitUpdated(ctx, commodity, updatedCommodity)
 
+	setETag(w, updatedCommodity)
 	resp := jsonapi.NewCommodityResponse(updatedCommodity).WithStatusCode(http.StatusOK)
 
 	if err := render.Render(w, r, resp); err != nil {
@@ -584,8 +667,10 @@ func (api *commoditiesAPI) updateCommodity(w http.ResponseWriter, r *http.Reques
 // @Param groupSlug path string true "Group slug"
 // @Param commodityID path string true "Commodity ID"
 // @Param body body jsonapi.CommodityCoverRequest true "Cover photo file id (null to clear)"
+// @Param If-Match header string false "ETag from GET; the change is refused when the commodity has changed since"
 // @Success 200 {object} jsonapi.CommodityResponse "OK"
 // @Failure 404 {object} jsonapi.Errors "Commodity or file not found"
+// @Failure 412 {object} jsonapi.CommodityResponse "Commodity changed since the If-Match ETag; body is the current commodity"
 // @Failure 422 {object} jsonapi.Errors "User-side request problem"
 // @Router /g/{groupSlug}/commodities/{commodityID}/cover [patch].
 func (api *commoditiesAPI) setCommodityCover(w http.ResponseWriter, r *http.Request) {
@@ -614,6 +699,12 @@ func (api *commoditiesAPI) setCommodityCover(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, commodity)
+	if !ok {
+		api.commodityPreconditionFailed(w, r, commodity)
+		return
+	}
+
 	// `null` and "" both clear the override. Treat any all-whitespace
 	// input as empty, too — the BE validator on the model expects exact
 	// matches and the FE never sends padded ids on purpose.
@@ -644,6 +735,7 @@ func (api *commoditiesAPI) setCommodityCover(w http.ResponseWriter, r *http.Requ
 	}
 
 	updateData := *commodity
+	updateData.Version = expectedVersion
 	if desired == "" {
 		updateData.CoverFileID = nil
 	} else {
@@ -652,6 +744,15 @@ func (api *commoditiesAPI) setCommodityCover(w http.ResponseWriter, r *http.Requ
 	}
 
 	updated, err := registrySet.CommodityRegistry.Update(r.Context(), updateData)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if current, getErr := registrySet.CommodityRegistry.Get(r.Context(), commodity.ID); getErr == nil {
+			commodity = current
+		}
+		api.commodityPreconditionFailed(w, r, commodity)
+		return
+	}
 	if err != nil {
 		renderEntityError(w, r, err)
 		return
@@ -666,6 +767,7 @@ func (api *commoditiesAPI) setCommodityCover(w http.ResponseWriter, r *http.Requ
 	if cover := api.resolveCoverForOne(r, updated); cover != nil {
 		resp = resp.WithCover(cover)
 	}
+	setETag(w, updated)
 
 	if err := render.Render(w, r, resp); err != nil {
 		internalServerError(w, r, err)
@@ -767,13 +869,15 @@ func (api *commoditiesAPI) bulkDeleteCommodities(w http.ResponseWriter, r *http.
 		// Get-failure is non-fatal: if the row is already gone, the
 		// delete below will surface the canonical error and we just
 		// skip the audit row for that id.
-		if before, getErr := registrySet.CommodityRegistry.Get(r.Context(), id); getErr == nil {
+		before, getErr := registrySet.CommodityRegistry.Get(r.Context(), id)
+		if getErr == nil {
 			api.eventService.EmitDeleted(r.Context(), before)
 		}
 		if err := api.entityService.DeleteCommodityRecursive(r.Context(), id); err != nil {
 			failed = append(failed, jsonapi.BulkResultFail{ID: id, Error: err.Error()})
 			continue
 		}
+		services.ChangeFeedFromContext(r.Context()).CommodityChanged(r.Context(), changefeed.ActionDeleted, "", before)
 		succeeded = append(succeeded, id)
 	}
 
@@ -849,6 +953,7 @@ func Commodities(params Params) func(r chi.Router) {
 		tagService:    services.NewTagService(params.FactorySet),
 		coverService:  services.NewCommodityCoverService(fileSigningService),
 		eventService:  services.NewCommodityEventService(params.FactorySet),
+		mergeService:  services.NewCommodityMergeService(params.FactorySet),
 		factorySet:    params.FactorySet,
 	}
 
@@ -857,19 +962,23 @@ func Commodities(params Params) func(r chi.Router) {
 		// Bulk endpoints (#1330 PR 5.5). Mounted before `/{commodityID}`
 		// so chi's static-vs-param-route matcher routes `/bulk-delete`
 		// and `/bulk-move` here rather than treating those slugs as a
-		// commodity id.
+		// commodity id. Same for the group-wide `/duplicates` list.
 		r.Post("/bulk-delete", api.bulkDeleteCommodities) // POST /commodities/bulk-delete
 		r.Post("/bulk-move", api.bulkMoveCommodities)     // POST /commodities/bulk-move
+		r.Get("/duplicates", api.listGroupDuplicates)     // GET /commodities/duplicates
 		r.Route("/{commodityID}", func(r chi.Router) {
 			r.Use(commodityCtx())
-			r.Get("/", api.getCommodity)                          // GET /commodities/123
-			r.Put("/", api.updateCommodity)                       // PUT /commodities/123
-			r.Delete("/", api.deleteCommodity)                    // DELETE /commodities/123
-			r.Patch("/cover", api.setCommodityCover)              // PATCH /commodities/123/cover
-			r.Route("/loans", CommodityLoans(params))             // /commodities/123/loans (#1452)
-			r.Route("/services", CommodityServices(params))       // /commodities/123/services (#1508)
-			r.Route("/supplies", CommoditySupplyLinks(params))    // /commodities/123/supplies (#1369)
-			r.Route("/maintenance", CommodityMaintenance(params)) // /commodities/123/maintenance (#1368)
+			r.Get("/", api.getCommodity)                               // GET /commodities/123
+			r.Put("/", api.updateCommodity)                            // PUT /commodities/123
+			r.Delete("/", api.deleteCommodity)                         // DELETE /commodities/123
+			r.Patch("/cover", api.setCommodityCover)                   // PATCH /commodities/123/cover
+			r.Get("/duplicates", api.listCommodityDuplicates)          // GET /commodities/123/duplicates
+			r.Post("/merge", api.mergeCommodity)                       // POST /commodities/123/merge
+			r.Route("/loans", CommodityLoans(params))                  // /commodities/123/loans (#1452)
+			r.Route("/services", CommodityServices(params))            // /commodities/123/services (#1508)
+			r.Route("/supplies", CommoditySupplyLinks(params))         // /commodities/123/supplies (#1369)
+			r.Route("/maintenance", CommodityMaintenance(params))      // /commodities/123/maintenance (#1368)
+			r.Route("/meter-readings", CommodityMeterReadings(params)) // /commodities/123/meter-readings
 			// #1450: append-only audit timeline.
 			r.With(paginate).Get("/events", api.listCommodityEvents) // GET /commodities/123/events
 
@@ -0,0 +1,199 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+const (
+	// defaultDuplicatePairsLimit caps GET /commodities/duplicates when the
+	// caller doesn't pass ?limit=. The list is a review queue, not an
+	// export: a user working through it never needs thousands of rows.
+	defaultDuplicatePairsLimit = 50
+	maxDuplicatePairsLimit     = 200
+)
+
+// parseDuplicateThreshold decodes ?threshold=. Empty means
+// registry.DefaultDuplicateThreshold; anything outside (0, 1] is a 422 —
+// 0 would pair every row with every other one.
+func parseDuplicateThreshold(raw string) (float64, error) {
+	if raw == "" {
+		return registry.DefaultDuplicateThreshold, nil
+	}
+	threshold, err := strconv.ParseFloat(raw, 64)
+	if err != nil || threshold <= 0 || threshold > 1 {
+		return 0, validationError("threshold", "must be a number greater than 0 and at most 1")
+	}
+	return threshold, nil
+}
+
+// parseDuplicatePairsLimit decodes ?limit= for the group-wide list,
+// clamping to maxDuplicatePairsLimit.
+func parseDuplicatePairsLimit(raw string) (int, error) {
+	if raw == "" {
+		return defaultDuplicatePairsLimit, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		return 0, validationError("limit", "must be a positive integer")
+	}
+	return min(limit, maxDuplicatePairsLimit), nil
+}
+
+// listCommodityDuplicates returns the possible duplicates of one commodity.
+// @Summary List possible duplicates of a commodity
+// @Description Returns commodities that look like duplicates of the given one:
+// @Description similar name / short name (trigram similarity at or above
+// @Description `threshold`), a shared serial or part number, or the same
+// @Description purchase date and original price. Each row explains the match in `meta`.
+// @Tags commodities
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Commodity ID"
+// @Param threshold query number false "Name similarity threshold in (0, 1] (default 0.5)"
+// @Success 200 {object} jsonapi.CommodityDuplicatesResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Commodity not found"
+// @Failure 422 {object} jsonapi.Errors "Invalid threshold"
+// @Router /g/{groupSlug}/commodities/{commodityID}/duplicates [get].
+func (api *commoditiesAPI) listCommodityDuplicates(w http.ResponseWriter, r *http.Request) {
+	commodity := commodityFromContext(r.Context())
+	if commodity == nil {
+		unprocessableEntityError(w, r, errors.New("commodity not found in context"))
+		return
+	}
+
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	threshold, err := parseDuplicateThreshold(r.URL.Query().Get("threshold"))
+	if err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	similar, err := regSet.CommodityRegistry.FindSimilar(r.Context(), commodity.ID, threshold)
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, jsonapi.NewCommodityDuplicatesResponse(similar, threshold)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// listGroupDuplicates returns every possible duplicate pair in the group.
+// @Summary List possible duplicate commodities in the group
+// @Description Returns pairs of commodities that look like duplicates of each
+// @Description other, strongest matches first. Same signals as
+// @Description GET /commodities/{commodityID}/duplicates.
+// @Tags commodities
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param threshold query number false "Name similarity threshold in (0, 1] (default 0.5)"
+// @Param limit query int false "Maximum number of pairs (default 50, max 200)"
+// @Success 200 {object} jsonapi.CommodityDuplicatePairsResponse "OK"
+// @Failure 422 {object} jsonapi.Errors "Invalid threshold or limit"
+// @Router /g/{groupSlug}/commodities/duplicates [get].
+func (api *commoditiesAPI) listGroupDuplicates(w http.ResponseWriter, r *http.Request) {
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	q := r.URL.Query()
+	threshold, err := parseDuplicateThreshold(q.Get("threshold"))
+	if err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	limit, err := parseDuplicatePairsLimit(q.Get("limit"))
+	if err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	pairs, err := regSet.CommodityRegistry.FindDuplicates(r.Context(), threshold, limit)
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, jsonapi.NewCommodityDuplicatePairsResponse(pairs, threshold)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// mergeCommodity folds a duplicate into the commodity in the path.
+// @Summary Merge a duplicate into a commodity
+// @Description Moves the duplicate's files, tags, event history, loans, service
+// @Description records, supply links and maintenance schedules onto the commodity
+// @Description in the path, then deletes the duplicate. The surviving commodity's
+// @Description own fields are kept; it only adopts the duplicate's cover photo
+// @Description when it has none.
+// @Tags commodities
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Surviving commodity ID"
+// @Param body body jsonapi.CommodityMergeRequest true "Duplicate to merge"
+// @Success 200 {object} jsonapi.CommodityMergeResponse "Merged"
+// @Failure 404 {object} jsonapi.Errors "Commodity not found"
+// @Failure 409 {object} jsonapi.Errors "Both commodities have an open loan or service"
+// @Failure 422 {object} jsonapi.Errors "Invalid request"
+// @Router /g/{groupSlug}/commodities/{commodityID}/merge [post].
+func (api *commoditiesAPI) mergeCommodity(w http.ResponseWriter, r *http.Request) {
+	commodity := commodityFromContext(r.Context())
+	if commodity == nil {
+		unprocessableEntityError(w, r, errors.New("commodity not found in context"))
+		return
+	}
+
+	var input jsonapi.CommodityMergeRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	result, err := api.mergeService.Merge(r.Context(), commodity.ID, input.Data.Attributes.DuplicateID)
+	switch {
+	case errors.Is(err, services.ErrMergeSameCommodity):
+		codedUnprocessableEntityError(w, r, err, "commodity.merge_same")
+		return
+	case errors.Is(err, services.ErrMergeHoldingConflict):
+		codedConflictError(w, r, err, "commodity.merge_holding_conflict", nil)
+		return
+	case err != nil:
+		renderEntityError(w, r, err)
+		return
+	}
+
+	resp := jsonapi.NewCommodityMergeResponse(result.Survivor, jsonapi.CommodityMergeMeta{
+		MergedID:             result.MergedID,
+		Files:                result.Files,
+		Events:               result.Events,
+		Loans:                result.Loans,
+		Services:             result.Services,
+		SupplyLinks:          result.SupplyLinks,
+		MaintenanceSchedules: result.MaintenanceSchedules,
+		MeterReadings:        result.MeterReadings,
+		InvoiceExtractions:   result.InvoiceExtractions,
+		TagsAdded:            result.TagsAdded,
+	})
+	if err := render.Render(w, r, resp); err != nil {
+		internalServerError(w, r, err)
+	}
+}
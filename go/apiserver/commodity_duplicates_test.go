@@ -0,0 +1,222 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// seedDuplicatePair creates two commodities that share a serial number in
+// the fixture's first area and returns them (survivor, duplicate).
+func seedDuplicatePair(c *qt.C, registrySet *registry.Set) (survivor, duplicate *models.Commodity) {
+	c.Helper()
+	all := must.Must(registrySet.CommodityRegistry.List(context.Background()))
+	c.Assert(len(all) >= 1, qt.IsTrue)
+	areaID := all[0].AreaID
+
+	mk := func(name string, tags []string) *models.Commodity {
+		return must.Must(registrySet.CommodityRegistry.Create(context.Background(), models.Commodity{
+			Name:         name,
+			AreaID:       areaID,
+			Type:         models.CommodityTypeElectronics,
+			Status:       models.CommodityStatusInUse,
+			Count:        1,
+			SerialNumber: "DUP-SERIAL-1",
+			Tags:         tags,
+		}))
+	}
+	return mk("Espresso machine", []string{"kitchen"}), mk("Espresso maker", []string{"kitchen", "gift"})
+}
+
+func serveDuplicates(params apiserver.Params, userID, method, url, body string) *httptest.ResponseRecorder {
+	req := must.Must(http.NewRequest(method, url, bytes.NewBufferString(body)))
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	addTestUserAuthHeader(req, userID)
+	rr := httptest.NewRecorder()
+	apiserver.APIServer(params, &mockRestoreWorker{}).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCommodityDuplicates_PerCommodityAndGroup(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	registrySet := getRegistrySetFromParams(params, testUser)
+	survivor, duplicate := seedDuplicatePair(c, registrySet)
+
+	rr := serveDuplicates(params, testUser.ID, http.MethodGet,
+		"/api/v1/g/"+testGroup.Slug+"/commodities/"+survivor.ID+"/duplicates", "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data[0].id"), duplicate.ID)
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data[0].meta.reasons[1]"), "serial_number")
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.threshold"), 0.5)
+
+	rr = serveDuplicates(params, testUser.ID, http.MethodGet,
+		"/api/v1/g/"+testGroup.Slug+"/commodities/duplicates", "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data[0].type"), "commodity_duplicate_pairs")
+
+	rr = serveDuplicates(params, testUser.ID, http.MethodGet,
+		"/api/v1/g/"+testGroup.Slug+"/commodities/duplicates?threshold=0", "")
+	c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("body=%s", rr.Body.String()))
+}
+
+func TestCommodityMerge(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	registrySet := getRegistrySetFromParams(params, testUser)
+	ctx := createTestUserContext(testUser.ID, testUser.TenantID)
+	survivor, duplicate := seedDuplicatePair(c, registrySet)
+
+	file := must.Must(registrySet.FileRegistry.Create(context.Background(), models.FileEntity{
+		Type:             models.FileTypeImage,
+		Category:         models.FileCategoryImages,
+		LinkedEntityType: "commodity",
+		LinkedEntityID:   duplicate.ID,
+		LinkedEntityMeta: "images",
+		File: &models.File{
+			Path:         "dup-photo",
+			OriginalPath: "dup-photo.jpg",
+			Ext:          ".jpg",
+			MIMEType:     "image/jpeg",
+		},
+	}))
+	loan := must.Must(registrySet.CommodityLoanRegistry.Create(ctx, models.CommodityLoan{
+		CommodityID:  duplicate.ID,
+		BorrowerName: "Alex",
+		LentAt:       "2026-01-10",
+	}))
+	extraction := must.Must(registrySet.InvoiceExtractionRegistry.Create(ctx, models.InvoiceExtraction{
+		FileID:      file.ID,
+		CommodityID: duplicate.ID,
+		Status:      models.InvoiceExtractionStatusPending,
+		Vendor:      "Coffee Shop",
+	}))
+
+	body := `{"data":{"type":"commodity_merges","attributes":{"duplicate_id":"` + duplicate.ID + `"}}}`
+	rr := serveDuplicates(params, testUser.ID, http.MethodPost,
+		"/api/v1/g/"+testGroup.Slug+"/commodities/"+survivor.ID+"/merge", body)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.id"), survivor.ID)
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.merged_id"), duplicate.ID)
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.files"), float64(1))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.loans"), float64(1))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.invoice_extractions"), float64(1))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.tags_added[0]"), "gift")
+
+	_, err := registrySet.CommodityRegistry.Get(context.Background(), duplicate.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	updated := must.Must(registrySet.CommodityRegistry.Get(context.Background(), survivor.ID))
+	c.Check([]string(updated.Tags), qt.DeepEquals, []string{"kitchen", "gift"})
+
+	movedFile := must.Must(registrySet.FileRegistry.Get(context.Background(), file.ID))
+	c.Check(movedFile.LinkedEntityID, qt.Equals, survivor.ID)
+
+	movedLoan := must.Must(registrySet.CommodityLoanRegistry.Get(context.Background(), loan.ID))
+	c.Check(movedLoan.CommodityID, qt.Equals, survivor.ID)
+
+	// The pending proposal survives the duplicate's delete instead of
+	// cascading away with it.
+	movedExtraction := must.Must(registrySet.InvoiceExtractionRegistry.Get(ctx, extraction.ID))
+	c.Check(movedExtraction.CommodityID, qt.Equals, survivor.ID)
+	c.Check(movedExtraction.Status, qt.Equals, models.InvoiceExtractionStatusPending)
+
+	events, _ := must.Must2(registrySet.CommodityEventRegistry.ListByCommodity(context.Background(), survivor.ID, 0, 100,
+		registry.CommodityEventListOptions{Kinds: []models.CommodityEventKind{models.CommodityEventKindMerged}}))
+	c.Assert(events, qt.HasLen, 1)
+	c.Check(events[0].Before["id"], qt.Equals, duplicate.ID)
+	c.Check(events[0].After["invoice_extractions"], qt.Equals, 1)
+}
+
+// TestCommodityMerge_ResumesAfterPartialMove seeds the state a failed merge
+// leaves behind — one file and one maintenance log already on the survivor,
+// the log's schedule still on the duplicate — and checks that retrying the
+// merge finishes the job.
+func TestCommodityMerge_ResumesAfterPartialMove(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	registrySet := getRegistrySetFromParams(params, testUser)
+	ctx := createTestUserContext(testUser.ID, testUser.TenantID)
+	survivor, duplicate := seedDuplicatePair(c, registrySet)
+
+	movedFile := must.Must(registrySet.FileRegistry.Create(context.Background(), models.FileEntity{
+		Type:             models.FileTypeImage,
+		Category:         models.FileCategoryImages,
+		LinkedEntityType: "commodity",
+		LinkedEntityID:   survivor.ID,
+		LinkedEntityMeta: "images",
+		File:             &models.File{Path: "moved", OriginalPath: "moved.jpg", Ext: ".jpg", MIMEType: "image/jpeg"},
+	}))
+	schedule := must.Must(registrySet.MaintenanceScheduleRegistry.Create(ctx, models.MaintenanceSchedule{
+		CommodityID:  duplicate.ID,
+		Title:        "Descale",
+		IntervalDays: 30,
+		NextDueAt:    "2026-12-01",
+		Enabled:      true,
+	}))
+	log := must.Must(registrySet.MaintenanceLogRegistry.Create(ctx, models.MaintenanceLog{
+		ScheduleID:  schedule.ID,
+		CommodityID: survivor.ID,
+		DoneAt:      "2026-11-01",
+	}))
+
+	body := `{"data":{"type":"commodity_merges","attributes":{"duplicate_id":"` + duplicate.ID + `"}}}`
+	rr := serveDuplicates(params, testUser.ID, http.MethodPost,
+		"/api/v1/g/"+testGroup.Slug+"/commodities/"+survivor.ID+"/merge", body)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.files"), float64(0))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.maintenance_schedules"), float64(1))
+
+	_, err := registrySet.CommodityRegistry.Get(context.Background(), duplicate.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	c.Check(must.Must(registrySet.FileRegistry.Get(context.Background(), movedFile.ID)).LinkedEntityID, qt.Equals, survivor.ID)
+	c.Check(must.Must(registrySet.MaintenanceScheduleRegistry.Get(ctx, schedule.ID)).CommodityID, qt.Equals, survivor.ID)
+	c.Check(must.Must(registrySet.MaintenanceLogRegistry.Get(ctx, log.ID)).CommodityID, qt.Equals, survivor.ID)
+}
+
+func TestCommodityMerge_Rejections(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	registrySet := getRegistrySetFromParams(params, testUser)
+	ctx := createTestUserContext(testUser.ID, testUser.TenantID)
+	survivor, duplicate := seedDuplicatePair(c, registrySet)
+
+	mergeURL := "/api/v1/g/" + testGroup.Slug + "/commodities/" + survivor.ID + "/merge"
+	mergeBody := func(id string) string {
+		return `{"data":{"type":"commodity_merges","attributes":{"duplicate_id":"` + id + `"}}}`
+	}
+
+	rr := serveDuplicates(params, testUser.ID, http.MethodPost, mergeURL, mergeBody(survivor.ID))
+	c.Check(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("body=%s", rr.Body.String()))
+
+	rr = serveDuplicates(params, testUser.ID, http.MethodPost, mergeURL, mergeBody("does-not-exist"))
+	c.Check(rr.Code, qt.Equals, http.StatusNotFound, qt.Commentf("body=%s", rr.Body.String()))
+
+	for _, id := range []string{survivor.ID, duplicate.ID} {
+		must.Must(registrySet.CommodityLoanRegistry.Create(ctx, models.CommodityLoan{
+			CommodityID:  id,
+			BorrowerName: "Sam",
+			LentAt:       "2026-01-10",
+		}))
+	}
+	rr = serveDuplicates(params, testUser.ID, http.MethodPost, mergeURL, mergeBody(duplicate.ID))
+	c.Assert(rr.Code, qt.Equals, http.StatusConflict, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "commodity.merge_holding_conflict")
+
+	_, err := registrySet.CommodityRegistry.Get(context.Background(), duplicate.ID)
+	c.Assert(err, qt.IsNil)
+}
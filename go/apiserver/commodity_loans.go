@@ -9,6 +9,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 
+	"github.com/denisvmedia/inventario/changefeed"
 	"github.com/denisvmedia/inventario/jsonapi"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -58,6 +59,8 @@ func loanCtx() func(http.Handler) http.Handler {
 type commodityLoansAPI struct {
 	factorySet  *registry.FactorySet
 	loanService *services.CommodityLoanService
+	linkSigner  *services.LoanLinkSigner
+	publicURL   string
 }
 
 // listCommodityLoans returns all loans (open + closed) for the
@@ -118,6 +121,7 @@ func (api *commodityLoansAPI) createCommodityLoan(w http.ResponseWriter, r *http
 		CommodityID:     commodityID,
 		BorrowerName:    input.Data.Attributes.BorrowerName,
 		BorrowerContact: input.Data.Attributes.BorrowerContact,
+		BorrowerEmail:   input.Data.Attributes.BorrowerEmail,
 		BorrowerNote:    input.Data.Attributes.BorrowerNote,
 		LentAt:          input.Data.Attributes.LentAt,
 		DueBackAt:       input.Data.Attributes.DueBackAt,
@@ -151,7 +155,7 @@ func (api *commodityLoansAPI) createCommodityLoan(w http.ResponseWriter, r *http
 // updateCommodityLoan patches a loan's mutable fields.
 //
 // @Summary Update a loan
-// @Description Patch borrower name/contact/note and due_back_at. Sending due_back_at as JSON null clears it (open-ended loan); omitting the key leaves it unchanged.
+// @Description Patch borrower name/contact/email/note and due_back_at. Sending due_back_at as JSON null clears it (open-ended loan); omitting the key leaves it unchanged.
 // @Tags commodity_loans
 // @Accept json-api
 // @Produce json-api
@@ -185,6 +189,7 @@ func (api *commodityLoansAPI) updateCommodityLoan(w http.ResponseWriter, r *http
 	updated, err := api.loanService.UpdateLoan(r.Context(), loan.ID, services.LoanUpdate{
 		BorrowerName:    input.Data.Attributes.BorrowerName,
 		BorrowerContact: input.Data.Attributes.BorrowerContact,
+		BorrowerEmail:   input.Data.Attributes.BorrowerEmail,
 		BorrowerNote:    input.Data.Attributes.BorrowerNote,
 		DueBackAt:       input.Data.Attributes.DueBackAt,
 		ClearDueBackAt:  input.Data.Attributes.ClearDueBackAt,
@@ -255,6 +260,101 @@ func (api *commodityLoansAPI) returnCommodityLoan(w http.ResponseWriter, r *http
 	}
 }
 
+// approveLoanRequest accepts the borrower's pending request: a return
+// confirmation closes the loan as of the day it was submitted, an
+// extension moves due_back_at to the requested date.
+//
+// @Summary Approve a borrower request
+// @Description Accept the pending borrower request (return confirmation or extension). 409 when nothing is pending or the loan is closed.
+// @Tags commodity_loans
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Commodity ID"
+// @Param loanID path string true "Loan ID"
+// @Success 200 {object} jsonapi.CommodityLoanResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Loan not found"
+// @Failure 409 {object} jsonapi.Errors "No pending request"
+// @Router /g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/request/approve [post].
+func (api *commodityLoansAPI) approveLoanRequest(w http.ResponseWriter, r *http.Request) {
+	api.reviewLoanRequest(w, r, api.loanService.ApproveBorrowerRequest)
+}
+
+// rejectLoanRequest discards the borrower's pending request and leaves
+// the loan untouched.
+//
+// @Summary Reject a borrower request
+// @Description Discard the pending borrower request. 409 when nothing is pending or the loan is closed.
+// @Tags commodity_loans
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Commodity ID"
+// @Param loanID path string true "Loan ID"
+// @Success 200 {object} jsonapi.CommodityLoanResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Loan not found"
+// @Failure 409 {object} jsonapi.Errors "No pending request"
+// @Router /g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/request/reject [post].
+func (api *commodityLoansAPI) rejectLoanRequest(w http.ResponseWriter, r *http.Request) {
+	api.reviewLoanRequest(w, r, api.loanService.RejectBorrowerRequest)
+}
+
+func (api *commodityLoansAPI) reviewLoanRequest(w http.ResponseWriter, r *http.Request, review func(context.Context, string) (*models.CommodityLoan, error)) {
+	loan := loanFromContext(r.Context())
+	if loan == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+	updated, err := review(r.Context(), loan.ID)
+	if err != nil {
+		if errors.Is(err, services.ErrNoPendingLoanRequest) || errors.Is(err, services.ErrLoanAlreadyReturned) {
+			conflictError(w, r, err, err)
+			return
+		}
+		renderEntityError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewCommodityLoanResponse(updated)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// createLoanBorrowerLink mints a signed, expiring link the owner can
+// hand to the borrower directly. The reminder worker embeds the same
+// kind of link in its courtesy emails.
+//
+// @Summary Create a borrower link
+// @Description Mint a signed, expiring link the borrower can use to confirm a return or request an extension without an account. 409 when the loan is closed.
+// @Tags commodity_loans
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Commodity ID"
+// @Param loanID path string true "Loan ID"
+// @Success 200 {object} jsonapi.LoanBorrowerLinkResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Loan not found"
+// @Failure 409 {object} jsonapi.Errors "Loan already returned"
+// @Router /g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/borrower-link [post].
+func (api *commodityLoansAPI) createLoanBorrowerLink(w http.ResponseWriter, r *http.Request) {
+	loan := loanFromContext(r.Context())
+	if loan == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+	if !loan.IsOpen() {
+		conflictError(w, r, services.ErrLoanAlreadyReturned, services.ErrLoanAlreadyReturned)
+		return
+	}
+	link, expiresAt, err := api.linkSigner.BuildURL(api.publicURL, loan.ID)
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewLoanBorrowerLinkResponse(loan.ID, link, expiresAt)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
 // deleteCommodityLoan permanently removes a loan row. Used to undo a
 // mistaken Lend (the FE shows a "delete" affordance only on rows the
 // user just created, to keep the audit trail clean for the rest).
@@ -285,6 +385,7 @@ func (api *commodityLoansAPI) deleteCommodityLoan(w http.ResponseWriter, r *http
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(r.Context()).LoanChanged(r.Context(), changefeed.ActionDeleted, "", loan)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -418,6 +519,8 @@ func CommodityLoans(params Params) func(r chi.Router) {
 	api := &commodityLoansAPI{
 		factorySet:  params.FactorySet,
 		loanService: services.NewCommodityLoanService(params.FactorySet),
+		linkSigner:  services.NewLoanLinkSigner(params.FileSigningKey, 0),
+		publicURL:   params.PublicURL,
 	}
 	return func(r chi.Router) {
 		r.Get("/", api.listCommodityLoans)
@@ -427,6 +530,9 @@ func CommodityLoans(params Params) func(r chi.Router) {
 			r.Patch("/", api.updateCommodityLoan)
 			r.Delete("/", api.deleteCommodityLoan)
 			r.Post("/return", api.returnCommodityLoan)
+			r.Post("/request/approve", api.approveLoanRequest)
+			r.Post("/request/reject", api.rejectLoanRequest)
+			r.Post("/borrower-link", api.createLoanBorrowerLink)
 		})
 	}
 }
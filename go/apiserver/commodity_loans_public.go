@@ -0,0 +1,144 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+type commodityLoansPublicAPI struct {
+	loanService *services.CommodityLoanService
+	linkSigner  *services.LoanLinkSigner
+}
+
+// getPublicLoan returns the borrower-visible view of a loan reached
+// through a signed loan link.
+//
+// @Summary Get a loan via a borrower link
+// @Description Unauthenticated. The sig / exp pair comes from the signed link the owner shared or the reminder email carried.
+// @Tags commodity_loans
+// @Accept json-api
+// @Produce json-api
+// @Param loanID path string true "Loan ID"
+// @Param sig query string true "Link signature"
+// @Param exp query string true "Link expiry (unix seconds)"
+// @Success 200 {object} jsonapi.PublicLoanResponse "OK"
+// @Failure 403 {object} jsonapi.Errors "Invalid or expired link"
+// @Router /public/loans/{loanID} [get].
+func (api *commodityLoansPublicAPI) getPublicLoan(w http.ResponseWriter, r *http.Request) {
+	loanID := chi.URLParam(r, "loanID")
+	if !api.validateLink(w, r, loanID) {
+		return
+	}
+	loan, commodityName, err := api.loanService.GetLoanForBorrower(r.Context(), loanID)
+	if err != nil {
+		api.renderLookupError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewPublicLoanResponse(loan, commodityName)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// submitPublicLoanRequest records the borrower's return confirmation or
+// extension request. The owner approves or rejects it from the loan's
+// Lend tab; nothing on the loan changes until then.
+//
+// @Summary Submit a borrower request via a borrower link
+// @Description Unauthenticated. Record a return confirmation or an extension request for the owner to review. A newer request replaces an unreviewed one.
+// @Tags commodity_loans
+// @Accept json-api
+// @Produce json-api
+// @Param loanID path string true "Loan ID"
+// @Param sig query string true "Link signature"
+// @Param exp query string true "Link expiry (unix seconds)"
+// @Param request body jsonapi.LoanBorrowerRequestRequest true "Borrower request"
+// @Success 200 {object} jsonapi.PublicLoanResponse "OK"
+// @Failure 403 {object} jsonapi.Errors "Invalid or expired link"
+// @Failure 409 {object} jsonapi.Errors "Loan already returned"
+// @Failure 422 {object} jsonapi.Errors "User-side request problem"
+// @Router /public/loans/{loanID}/requests [post].
+func (api *commodityLoansPublicAPI) submitPublicLoanRequest(w http.ResponseWriter, r *http.Request) {
+	loanID := chi.URLParam(r, "loanID")
+	if !api.validateLink(w, r, loanID) {
+		return
+	}
+
+	var input jsonapi.LoanBorrowerRequestRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	_, err := api.loanService.SubmitBorrowerRequest(r.Context(), loanID, services.BorrowerLoanRequest{
+		Kind:      input.Data.Attributes.Kind,
+		DueBackAt: input.Data.Attributes.DueBackAt,
+		Note:      input.Data.Attributes.Note,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrLoanAlreadyReturned) {
+			conflictError(w, r, err, err)
+			return
+		}
+		api.renderLookupError(w, r, err)
+		return
+	}
+
+	loan, commodityName, err := api.loanService.GetLoanForBorrower(r.Context(), loanID)
+	if err != nil {
+		api.renderLookupError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewPublicLoanResponse(loan, commodityName)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// validateLink checks the sig / exp query pair and renders the typed
+// 403 on failure. Returns false when the request has been answered.
+func (api *commodityLoansPublicAPI) validateLink(w http.ResponseWriter, r *http.Request, loanID string) bool {
+	q := r.URL.Query()
+	err := api.linkSigner.Validate(loanID, q.Get("sig"), q.Get("exp"))
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, services.ErrLoanLinkExpired):
+		codedForbiddenError(w, r, err, "loan_link.expired")
+	default:
+		codedForbiddenError(w, r, err, "loan_link.invalid")
+	}
+	return false
+}
+
+// renderLookupError answers a correctly signed link whose loan has since
+// been deleted with the same 403 as a bad signature, so the public
+// surface never confirms which loan IDs exist.
+func (*commodityLoansPublicAPI) renderLookupError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, registry.ErrNotFound) {
+		codedForbiddenError(w, r, services.ErrLoanLinkInvalid, "loan_link.invalid")
+		return
+	}
+	renderEntityError(w, r, err)
+}
+
+// CommodityLoansPublic returns the chi router for the borrower-facing,
+// unauthenticated loan surface mounted at /public/loans. Every request
+// carries a signed, expiring link (see services.LoanLinkSigner) in
+// place of a session; the handlers run on service registries because
+// there is no user or group context to scope RLS by.
+func CommodityLoansPublic(params Params) func(r chi.Router) {
+	api := &commodityLoansPublicAPI{
+		loanService: services.NewCommodityLoanService(params.FactorySet),
+		linkSigner:  services.NewLoanLinkSigner(params.FileSigningKey, 0),
+	}
+	return func(r chi.Router) {
+		r.Get("/{loanID}", api.getPublicLoan)
+		r.Post("/{loanID}/requests", api.submitPublicLoanRequest)
+	}
+}
@@ -0,0 +1,178 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// TestPublicLoanLink_ReturnFlow walks the borrower self-service loop:
+// the owner mints a link, the borrower reads the loan and confirms the
+// return without a session, and the owner's approval closes the loan.
+func TestPublicLoanLink_ReturnFlow(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	params.PublicURL = "https://inventario.example"
+	registrySet := getRegistrySetFromParams(params, testUser)
+	areas := must.Must(registrySet.AreaRegistry.List(context.Background()))
+	c.Assert(areas, qt.Not(qt.HasLen), 0)
+	commodity := must.Must(registrySet.CommodityRegistry.Create(context.Background(), models.Commodity{
+		Name:                  "Ladder",
+		ShortName:             "ladder",
+		AreaID:                new(areas[0].ID),
+		Status:                models.CommodityStatusInUse,
+		Type:                  models.CommodityTypeOther,
+		Count:                 1,
+		OriginalPrice:         must.Must(decimal.NewFromString("50.00")),
+		OriginalPriceCurrency: models.Currency("USD"),
+	}))
+
+	handler := apiserver.APIServer(params, &mockRestoreWorker{})
+	loansPath := "/api/v1/g/" + testGroup.Slug + "/commodities/" + commodity.ID + "/loans"
+	do := func(method, path, body string, authed bool) *httptest.ResponseRecorder {
+		var reader *bytes.Buffer
+		if body != "" {
+			reader = bytes.NewBufferString(body)
+		} else {
+			reader = &bytes.Buffer{}
+		}
+		req := must.Must(http.NewRequest(method, path, reader))
+		req.Header.Set("Content-Type", "application/vnd.api+json")
+		if authed {
+			addTestUserAuthHeader(req, testUser.ID)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := do(http.MethodPost, loansPath,
+		`{"data":{"type":"commodity_loans","attributes":{"borrower_name":"Pavel","borrower_email":"pavel@example.com","lent_at":"2026-05-01"}}}`, true)
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	var created jsonapi.CommodityLoanResponse
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &created), qt.IsNil)
+	loanID := created.Data.ID
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.borrower_email"), "pavel@example.com")
+
+	rr = do(http.MethodPost, loansPath+"/"+loanID+"/borrower-link", "", true)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	var linkResp jsonapi.LoanBorrowerLinkResponse
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &linkResp), qt.IsNil)
+	link := must.Must(url.Parse(linkResp.Data.Attributes.URL))
+	c.Assert(link.Host, qt.Equals, "inventario.example")
+	c.Assert(link.Path, qt.Equals, "/loan-response/"+loanID)
+	publicPath := "/api/v1/public/loans/" + loanID
+	query := "?" + link.RawQuery
+
+	rr = do(http.MethodGet, publicPath+query, "", false)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.commodity_name"), "Ladder")
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.borrower_name"), "Pavel")
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.returned"), false)
+
+	tampered := url.Values{"sig": {"AAAA"}, "exp": {link.Query().Get("exp")}}
+	rr = do(http.MethodGet, publicPath+"?"+tampered.Encode(), "", false)
+	c.Assert(rr.Code, qt.Equals, http.StatusForbidden)
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "loan_link.invalid")
+
+	rr = do(http.MethodPost, publicPath+"/requests"+query,
+		`{"data":{"type":"loan_requests","attributes":{"kind":"return","note":"Left it on the porch"}}}`, false)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.pending_request_kind"), "return")
+
+	rr = do(http.MethodPost, loansPath+"/"+loanID+"/request/approve", "", true)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	var approved jsonapi.CommodityLoanResponse
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &approved), qt.IsNil)
+	c.Assert(approved.Data.Attributes.ReturnedAt, qt.IsNotNil)
+
+	rr = do(http.MethodPost, loansPath+"/"+loanID+"/request/reject", "", true)
+	c.Assert(rr.Code, qt.Equals, http.StatusConflict)
+
+	rr = do(http.MethodPost, publicPath+"/requests"+query,
+		`{"data":{"type":"loan_requests","attributes":{"kind":"return"}}}`, false)
+	c.Assert(rr.Code, qt.Equals, http.StatusConflict)
+}
+
+// TestPublicLoanLink_ExtensionValidation pins that an extension
+// request must carry a date and that the date must move the loan
+// forward.
+func TestPublicLoanLink_ExtensionValidation(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	registrySet := getRegistrySetFromParams(params, testUser)
+	areas := must.Must(registrySet.AreaRegistry.List(context.Background()))
+	commodity := must.Must(registrySet.CommodityRegistry.Create(context.Background(), models.Commodity{
+		Name:                  "Tent",
+		ShortName:             "tent",
+		AreaID:                new(areas[0].ID),
+		Status:                models.CommodityStatusInUse,
+		Type:                  models.CommodityTypeOther,
+		Count:                 1,
+		OriginalPrice:         must.Must(decimal.NewFromString("80.00")),
+		OriginalPriceCurrency: models.Currency("USD"),
+	}))
+	handler := apiserver.APIServer(params, &mockRestoreWorker{})
+
+	req := must.Must(http.NewRequest(http.MethodPost,
+		"/api/v1/g/"+testGroup.Slug+"/commodities/"+commodity.ID+"/loans",
+		bytes.NewBufferString(`{"data":{"type":"commodity_loans","attributes":{"borrower_name":"Ivan","lent_at":"2026-05-01","due_back_at":"2099-01-01"}}}`)))
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	addTestUserAuthHeader(req, testUser.ID)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	var created jsonapi.CommodityLoanResponse
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &created), qt.IsNil)
+
+	link := must.Must(url.Parse(must.Must(buildTestLoanLink(params, created.Data.ID))))
+	path := "/api/v1/public/loans/" + created.Data.ID + "/requests?" + link.RawQuery
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "missing date",
+			body: `{"data":{"type":"loan_requests","attributes":{"kind":"extension"}}}`,
+		},
+		{
+			name: "not after current due date",
+			body: `{"data":{"type":"loan_requests","attributes":{"kind":"extension","due_back_at":"2098-12-31"}}}`,
+		},
+		{
+			name: "unknown kind",
+			body: `{"data":{"type":"loan_requests","attributes":{"kind":"keep_forever"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			req := must.Must(http.NewRequest(http.MethodPost, path, bytes.NewBufferString(tt.body)))
+			req.Header.Set("Content-Type", "application/vnd.api+json")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("body=%s", rr.Body.String()))
+		})
+	}
+}
+
+func buildTestLoanLink(params apiserver.Params, loanID string) (string, error) {
+	link, _, err := services.NewLoanLinkSigner(params.FileSigningKey, 0).BuildURL("https://inventario.example", loanID)
+	return link, err
+}
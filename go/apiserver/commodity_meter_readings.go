@@ -0,0 +1,132 @@
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services"
+)
+
+type commodityMeterReadingsAPI struct {
+	service *services.MaintenanceScheduleService
+	clock   func() time.Time
+}
+
+func newCommodityMeterReadingsAPI(params Params) *commodityMeterReadingsAPI {
+	return &commodityMeterReadingsAPI{
+		service: services.NewMaintenanceScheduleService(params.FactorySet),
+		clock:   time.Now,
+	}
+}
+
+// list returns the meter readings of the commodity in the URL, oldest
+// first. ?unit= narrows the list to one meter.
+//
+// @Summary List meter readings for a commodity
+// @Description Usage meter history of the commodity in the URL, oldest first.
+// @Tags commodity_meter_readings
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Commodity ID"
+// @Param unit query string false "Restrict to one meter unit" Enums(hours, km, mi, cycles)
+// @Success 200 {object} jsonapi.CommodityMeterReadingsResponse "OK"
+// @Failure 422 {object} jsonapi.Errors "Unknown unit"
+// @Router /g/{groupSlug}/commodities/{commodityID}/meter-readings [get].
+func (api *commodityMeterReadingsAPI) list(w http.ResponseWriter, r *http.Request) {
+	unit := models.MeterUnit(r.URL.Query().Get("unit"))
+	if err := unit.Validate(); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	readings, err := api.service.ListMeterReadings(r.Context(), chi.URLParam(r, "commodityID"), unit)
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewCommodityMeterReadingsResponse(readings)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// create records a meter reading and re-estimates the due dates of the
+// commodity's usage-based maintenance schedules in that unit.
+//
+// @Summary Record a meter reading
+// @Description Record a usage meter reading (hours, km, mi, cycles) for the commodity in the URL.
+// @Tags commodity_meter_readings
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Commodity ID"
+// @Param reading body jsonapi.CommodityMeterReadingRequest true "Meter reading attributes"
+// @Success 201 {object} jsonapi.CommodityMeterReadingResponse "Reading recorded"
+// @Failure 422 {object} jsonapi.Errors "User-side request problem"
+// @Router /g/{groupSlug}/commodities/{commodityID}/meter-readings [post].
+func (api *commodityMeterReadingsAPI) create(w http.ResponseWriter, r *http.Request) {
+	var input jsonapi.CommodityMeterReadingRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	now := api.clock()
+	readAt := input.Data.Attributes.ReadAt
+	if readAt == "" {
+		readAt = models.Date(now.UTC().Format("2006-01-02"))
+	}
+
+	created, err := api.service.RecordMeterReading(r.Context(), models.CommodityMeterReading{
+		CommodityID: chi.URLParam(r, "commodityID"),
+		Unit:        input.Data.Attributes.Unit,
+		Value:       input.Data.Attributes.Value,
+		ReadAt:      readAt,
+		Notes:       input.Data.Attributes.Notes,
+	}, now)
+	if err != nil {
+		renderMaintenanceError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, jsonapi.NewCommodityMeterReadingResponse(created).WithStatusCode(http.StatusCreated)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// remove deletes a mistyped meter reading.
+//
+// @Summary Delete a meter reading
+// @Description Hard-delete a meter reading; usage schedules are re-estimated.
+// @Tags commodity_meter_readings
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param commodityID path string true "Commodity ID"
+// @Param readingID path string true "Meter reading ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} jsonapi.Errors "Reading not found"
+// @Router /g/{groupSlug}/commodities/{commodityID}/meter-readings/{readingID} [delete].
+func (api *commodityMeterReadingsAPI) remove(w http.ResponseWriter, r *http.Request) {
+	err := api.service.DeleteMeterReading(r.Context(), chi.URLParam(r, "commodityID"), chi.URLParam(r, "readingID"), api.clock())
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CommodityMeterReadings returns the chi sub-router mounted under the
+// per-commodity prefix `/commodities/{commodityID}/meter-readings`.
+func CommodityMeterReadings(params Params) func(r chi.Router) {
+	api := newCommodityMeterReadingsAPI(params)
+	return func(r chi.Router) {
+		r.Get("/", api.list)
+		r.Post("/", api.create)
+		r.Delete("/{readingID}", api.remove)
+	}
+}
@@ -30,6 +30,7 @@ const (
 	commodityScanProviderTimeoutCode  = "commodity_scan.provider_timeout"
 	commodityScanProviderErrorCode    = "commodity_scan.provider_error"
 	commodityScanNoPhotosCode         = "commodity_scan.no_photos"
+	commodityScanBudgetExceededCode   = "commodity_scan.budget_exceeded"
 )
 
 // scanFormField is the multipart form field name the FE uses for each
@@ -193,7 +194,7 @@ func (api *commodityScanAPI) handlePublicScan(w http.ResponseWriter, r *http.Req
 // @Failure 413 {object} jsonapi.Errors "File too large"
 // @Failure 415 {object} jsonapi.Errors "Unsupported MIME type"
 // @Failure 422 {object} jsonapi.Errors "Too many files / no files"
-// @Failure 429 {object} jsonapi.Errors "Rate limited"
+// @Failure 429 {object} jsonapi.Errors "Rate limited / monthly AI budget exceeded"
 // @Failure 502 {object} jsonapi.Errors "Provider unavailable / parse error"
 // @Failure 503 {object} jsonapi.Errors "Provider disabled"
 // @Failure 504 {object} jsonapi.Errors "Provider timed out"
@@ -246,6 +247,9 @@ func (api *commodityScanAPI) handleScan(w http.ResponseWriter, r *http.Request)
 	}
 
 	in.PreferredCurrencyCode = preferredCurrencyFromContext(r.Context())
+	if group := appctx.GroupFromContext(r.Context()); group != nil {
+		in.GroupID = group.ID
+	}
 
 	// The constructor permits a nil service so the route can stay
 	// mounted in a "feature gated off" deployment; calling Scan on it
@@ -468,6 +472,8 @@ func mapCommodityScanError(err error) (jsonapi.Error, bool) {
 	switch {
 	case errors.Is(err, services.ErrScanRateLimited):
 		return scanError(err, http.StatusTooManyRequests, "Too Many Requests", commodityScanRateLimitedCode), true
+	case errors.Is(err, services.ErrScanBudgetExceeded):
+		return scanError(err, http.StatusTooManyRequests, "Too Many Requests", commodityScanBudgetExceededCode), true
 	case errors.Is(err, services.ErrScanTooManyPhotos):
 		return scanError(err, http.StatusUnprocessableEntity, "Unprocessable Entity", commodityScanTooManyPhotosCode), true
 	case errors.Is(err, services.ErrScanPhotoTooLarge):
@@ -25,9 +25,13 @@ var defaultAllowedHeaders = []string{
 	"X-CSRF-Token",
 	"X-Auth-Check",
 	"X-Request-ID",
+	"If-Match",
+	"Idempotency-Key",
 }
 
 var defaultExposedHeaders = []string{
+	"ETag",
+	"Idempotent-Replayed",
 	"X-CSRF-Token",
 	"X-RateLimit-Limit",
 	"X-RateLimit-Remaining",
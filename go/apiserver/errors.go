@@ -44,6 +44,20 @@ var (
 	// exfiltration. The handler answers 422 directly via
 	// unprocessableEntityError, so it does not need a toJSONAPIError mapping.
 	errImportSourceForeignTenant = errx.NewSentinel("import source path must be within your tenant namespace")
+	// errBackupReplicationDisabled is returned by the import-replica
+	// endpoint when no backup replication target is configured. Rendered
+	// as a coded 404 so the FE can hide the action.
+	errBackupReplicationDisabled = errx.NewSentinel("backup replication is not configured")
+	// errSavedViewStaleReference is returned when a saved view's filters
+	// name an area or tag that no longer exists in the group. Handlers
+	// answer 422 directly via codedUnprocessableEntityError with JSON:API
+	// code saved_view.stale_reference, so it needs no toJSONAPIError
+	// mapping.
+	errSavedViewStaleReference = errx.NewSentinel("saved view references an area or tag that no longer exists")
+	// errSavedViewNotCreator is returned when a member who neither created
+	// a saved view nor administers the group tries to change or delete it.
+	// It answers 403 with JSON:API code saved_view.not_creator.
+	errSavedViewNotCreator = errx.NewSentinel("only the creator or a group admin can modify this saved view")
 	// ErrNotSystemAdmin is returned by RequireSystemAdmin when the caller's
 	// user is not flagged as a system administrator. Surfaces as a 403 with
 	// JSON:API code "admin.forbidden" so the FE can render specific copy
@@ -425,10 +439,48 @@ func adminSentinelJSONAPIError(err error) (jsonapi.Error, bool) {
 	}
 }
 
+// locationScopeSentinelJSONAPIError maps the member location-scope
+// sentinels. A write outside a restricted member's locations is a 403
+// (the member is known, the row is off-limits); a malformed scope
+// assignment is a 422. The dotted codes let the FE tell them apart from
+// the generic role-gate 403 and validation 422.
+func locationScopeSentinelJSONAPIError(err error) (jsonapi.Error, bool) {
+	switch {
+	case errors.Is(err, registry.ErrLocationScopeDenied):
+		return jsonapi.Error{
+			Err:            err,
+			UserError:      errormarshal.Marshal(err),
+			HTTPStatusCode: http.StatusForbidden,
+			StatusText:     "Forbidden",
+			Code:           "group.location_scope_denied",
+		}, true
+	case errors.Is(err, services.ErrScopeRoleNotAllowed),
+		errors.Is(err, services.ErrScopeRoleAboveMembership),
+		errors.Is(err, services.ErrScopeLocationNotInGroup):
+		code := "group.scope_role_not_allowed"
+		switch {
+		case errors.Is(err, services.ErrScopeRoleAboveMembership):
+			code = "group.scope_role_above_membership"
+		case errors.Is(err, services.ErrScopeLocationNotInGroup):
+			code = "group.scope_location_not_in_group"
+		}
+		return jsonapi.Error{
+			Err:            err,
+			UserError:      errormarshal.Marshal(err),
+			HTTPStatusCode: http.StatusUnprocessableEntity,
+			StatusText:     "Unprocessable Entity",
+			Code:           code,
+		}, true
+	default:
+		return jsonapi.Error{}, false
+	}
+}
+
 // preSwitchSentinelJSONAPIError dispatches to the extracted sentinel-family
-// helpers (admin guards, account-deletion) before the toJSONAPIError switch.
-// Collapsing them into a single early-return call keeps toJSONAPIError under
-// the gocyclo budget. ok=false when err matches none of them.
+// helpers (admin guards, account-deletion, location scopes) before the
+// toJSONAPIError switch. Collapsing them into a single early-return call
+// keeps toJSONAPIError under the gocyclo budget. ok=false when err matches
+// none of them.
 func preSwitchSentinelJSONAPIError(err error) (jsonapi.Error, bool) {
 	if jsErr, ok := adminSentinelJSONAPIError(err); ok {
 		return jsErr, true
@@ -436,6 +488,20 @@ func preSwitchSentinelJSONAPIError(err error) (jsonapi.Error, bool) {
 	if jsErr, ok := accountDeletionSentinelJSONAPIError(err); ok {
 		return jsErr, true
 	}
+	if jsErr, ok := locationScopeSentinelJSONAPIError(err); ok {
+		return jsErr, true
+	}
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Handlers answering If-Match render the current entity themselves;
+		// this covers version conflicts surfacing through service paths.
+		return jsonapi.Error{
+			Err:            err,
+			UserError:      errormarshal.Marshal(err),
+			HTTPStatusCode: http.StatusPreconditionFailed,
+			StatusText:     "Precondition Failed",
+			Code:           "entity.version_conflict",
+		}, true
+	}
 	return jsonapi.Error{}, false
 }
 
@@ -489,13 +555,15 @@ func toJSONAPIError(err error) jsonapi.Error {
 		// is a business-rule violation: 422, not 500.
 		return NewUnprocessableEntityError(err)
 	case errors.Is(err, services.ErrCommodityNotTrackable),
-		errors.Is(err, services.ErrClosedLoanFieldImmutable):
+		errors.Is(err, services.ErrClosedLoanFieldImmutable),
+		errors.Is(err, services.ErrInvalidLoanRequest):
 		// #1554: a bundle commodity (count > 1) cannot carry a per-
 		// instance event (lend / service / warranty) — the FE renders
 		// the "split into separate items" hint the create-form banner
 		// uses. #1511: due_back_at / returned_at are frozen on closed
-		// loans (date-of-record after the loan ends). Both are
-		// business-rule violations: 422, not 500.
+		// loans (date-of-record after the loan ends). A borrower
+		// extension must land after both today and the current due
+		// date. All are business-rule violations: 422, not 500.
 		return NewUnprocessableEntityError(err)
 	case errors.Is(err, services.ErrInvalidConfirmation),
 		errors.Is(err, services.ErrInvalidPassword):
@@ -0,0 +1,57 @@
+package apiserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// Optimistic concurrency for the member-editable entities (commodities,
+// areas, locations, files). GET serves the entity's models.Versioned version
+// as a strong ETag; PUT / PATCH / DELETE honour If-Match against it and
+// answer 412 Precondition Failed with the current representation, so the
+// client can rebase its edit without another round-trip. Requests without
+// If-Match keep the historical last-write-wins behaviour.
+
+// entityETag formats the entity's version as a strong ETag.
+func entityETag(entity models.Versioned) string {
+	return strconv.Quote(strconv.Itoa(entity.GetVersion()))
+}
+
+// setETag stamps the entity's ETag on the response.
+func setETag(w http.ResponseWriter, entity models.Versioned) {
+	w.Header().Set("ETag", entityETag(entity))
+}
+
+// ifMatchVersion evaluates the request's If-Match header against current,
+// the entity as loaded by the route middleware. ok=false means the
+// precondition failed. expected is the version the registry Update (or a
+// Delete under registry.WithExpectedVersion) must still find: current's
+// version when one of the listed tags matched, 0 (no expectation) when the
+// header is absent or "*" — the entity is known to exist at this point.
+func ifMatchVersion(r *http.Request, current models.Versioned) (expected int, ok bool) {
+	header := strings.TrimSpace(strings.Join(r.Header.Values("If-Match"), ","))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	want := entityETag(current)
+	for tag := range strings.SplitSeq(header, ",") {
+		if strings.TrimSpace(tag) == want {
+			return current.GetVersion(), true
+		}
+	}
+	return 0, false
+}
+
+// preconditionFailed answers 412 with current's representation (resp, built
+// with WithStatusCode(http.StatusPreconditionFailed)) and ETag.
+func preconditionFailed(w http.ResponseWriter, r *http.Request, current models.Versioned, resp render.Renderer) {
+	setETag(w, current)
+	if err := render.Render(w, r, resp); err != nil {
+		internalServerError(w, r, err)
+	}
+}
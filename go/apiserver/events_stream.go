@@ -0,0 +1,179 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/changefeed"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services"
+)
+
+const (
+	// eventsStreamHeartbeat keeps idle connections alive through proxies
+	// that drop silent connections (typically after 60s).
+	eventsStreamHeartbeat = 25 * time.Second
+	// eventsStreamMaxDuration caps one stream connection. The JWT is only
+	// checked when the stream opens, so recycling the connection makes a
+	// revoked session or a changed membership take effect; the client
+	// reconnects with its Last-Event-ID and misses nothing.
+	eventsStreamMaxDuration = 15 * time.Minute
+	// eventsStreamRetryMillis is the reconnect delay sent to clients.
+	eventsStreamRetryMillis = 3000
+)
+
+// eventsStreamPathSuffix identifies the stream route for requestTimeout.
+const eventsStreamPathSuffix = "/events/stream"
+
+type eventsStreamAPI struct {
+	broker    changefeed.Broker
+	heartbeat time.Duration
+}
+
+// streamEvents streams the group's changes as Server-Sent Events.
+// @Summary Stream live group changes
+// @Description Server-Sent Events stream of commodity, area, location, file and loan changes in the group. Each message carries the event ID, an event name of the form `<entity>.<action>` and a JSON data line; clients refetch the changed row. Resume after a disconnect by sending the last received ID in the Last-Event-ID header (or the last_event_id query parameter). A `reset` event means the ID could not be resumed and cached state should be refetched. Location-scoped members only receive events for their locations.
+// @Tags events
+// @Produce text/event-stream
+// @Param groupSlug path string true "Group slug"
+// @Param Last-Event-ID header string false "Resume after this event ID"
+// @Param last_event_id query string false "Resume after this event ID, for clients that cannot set headers"
+// @Success 200 {object} changefeed.Event "Event stream"
+// @Failure 404 {object} jsonapi.Errors "Group not found"
+// @Router /g/{groupSlug}/events/stream [get].
+func (api *eventsStreamAPI) streamEvents(w http.ResponseWriter, r *http.Request) {
+	group := appctx.GroupFromContext(r.Context())
+	if group == nil {
+		http.Error(w, "Group context required", http.StatusInternalServerError)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), eventsStreamMaxDuration)
+	defer cancel()
+
+	sub, err := api.broker.Subscribe(ctx, group.ID, strings.TrimSpace(lastEventID))
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	defer sub.Close()
+
+	scope := appctx.LocationScopeFromContext(r.Context())
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Stop nginx-style proxies from buffering the stream.
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventsStreamRetryMillis); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(api.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, open := <-sub.Events():
+			if !open {
+				// Broker closed a lagging subscription; the client
+				// reconnects and resumes from history.
+				return
+			}
+			if !eventVisible(scope, event) {
+				continue
+			}
+			if err := writeSSEEvent(w, event); err != nil {
+				slog.DebugContext(ctx, "events stream: write failed", "err", err, "group_id", group.ID)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// eventVisible applies the member's location scope. Events without a
+// location are hidden from scoped members, matching the registries.
+func eventVisible(scope models.LocationScope, event changefeed.Event) bool {
+	if !scope.Restricted() || event.Action == changefeed.ActionReset {
+		return true
+	}
+	return scope.Allows(event.LocationID, models.GroupRoleViewer)
+}
+
+// writeSSEEvent writes one event in text/event-stream framing. A reset
+// event carries an empty id so the browser forgets the stale Last-Event-ID.
+func writeSSEEvent(w http.ResponseWriter, event changefeed.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Name(), data)
+	return err
+}
+
+// ChangeFeedMiddleware puts the change feed on the request context so the
+// write paths (handlers and the commodity timeline service) can publish.
+func ChangeFeedMiddleware(feed *services.ChangeFeed) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(services.WithChangeFeed(r.Context(), feed)))
+		})
+	}
+}
+
+// requestTimeout is middleware.Timeout for every route except the live
+// change stream, which is meant to outlive it and is bounded by
+// eventsStreamMaxDuration instead.
+func requestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	withTimeout := middleware.Timeout(timeout)
+	return func(next http.Handler) http.Handler {
+		timed := withTimeout(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, eventsStreamPathSuffix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timed.ServeHTTP(w, r)
+		})
+	}
+}
+
+func EventsStream(broker changefeed.Broker) func(r chi.Router) {
+	api := &eventsStreamAPI{
+		broker:    broker,
+		heartbeat: eventsStreamHeartbeat,
+	}
+	return func(r chi.Router) {
+		r.Get("/stream", api.streamEvents) // GET /events/stream
+	}
+}
@@ -0,0 +1,154 @@
+package apiserver_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/changefeed"
+	changefeedinmemory "github.com/denisvmedia/inventario/changefeed/inmemory"
+	"github.com/denisvmedia/inventario/models"
+)
+
+type sseMessage struct {
+	id    string
+	name  string
+	event changefeed.Event
+}
+
+// openEventStream connects to the group's SSE stream and returns a channel
+// of decoded messages. Comment and retry-only blocks are skipped.
+func openEventStream(c *qt.C, serverURL, groupSlug, userID, lastEventID string) <-chan sseMessage {
+	c.Helper()
+
+	ctx, cancel := context.WithTimeout(c.Context(), 5*time.Second)
+	c.Cleanup(cancel)
+	req := must.Must(http.NewRequestWithContext(ctx, http.MethodGet, serverURL+"/api/v1/g/"+groupSlug+"/events/stream", nil))
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	addTestUserAuthHeader(req, userID)
+
+	resp, err := http.DefaultClient.Do(req)
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(func() { _ = resp.Body.Close() })
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), qt.Equals, "text/event-stream")
+
+	out := make(chan sseMessage, 16)
+	go func() {
+		defer close(out)
+		var msg sseMessage
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if msg.name != "" {
+					out <- msg
+				}
+				msg = sseMessage{}
+			case strings.HasPrefix(line, "id: "):
+				msg.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				msg.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &msg.event)
+			}
+		}
+	}()
+	return out
+}
+
+func nextSSEMessage(c *qt.C, messages <-chan sseMessage) sseMessage {
+	c.Helper()
+	select {
+	case msg, ok := <-messages:
+		c.Assert(ok, qt.IsTrue, qt.Commentf("stream closed"))
+		return msg
+	case <-time.After(3 * time.Second):
+		c.Fatal("timed out waiting for an SSE event")
+		return sseMessage{}
+	}
+}
+
+func TestEventsStream_DeliversWrites(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	params.ChangeFeed = changefeedinmemory.New()
+	server := httptest.NewServer(apiserver.APIServer(params, &mockRestoreWorker{}))
+	defer server.Close()
+
+	messages := openEventStream(c, server.URL, testGroup.Slug, testUser.ID, "")
+
+	locations := must.Must(getRegistrySetFromParams(params, testUser).LocationRegistry.List(c.Context()))
+	req := must.Must(http.NewRequestWithContext(c.Context(), http.MethodDelete, server.URL+"/api/v1/g/"+testGroup.Slug+"/locations/"+locations[1].ID, nil))
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	addTestUserAuthHeader(req, testUser.ID)
+	resp := must.Must(http.DefaultClient.Do(req))
+	_ = resp.Body.Close()
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusNoContent)
+
+	msg := nextSSEMessage(c, messages)
+	c.Assert(msg.name, qt.Equals, "location.deleted")
+	c.Assert(msg.id, qt.Not(qt.Equals), "")
+	c.Assert(msg.event.EntityID, qt.Equals, locations[1].ID)
+	c.Assert(msg.event.GroupID, qt.Equals, testGroup.ID)
+	c.Assert(msg.event.ActorUserID, qt.Equals, testUser.ID)
+}
+
+func TestEventsStream_ResumesAndAppliesLocationScope(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	broker := changefeedinmemory.New()
+	params.ChangeFeed = broker
+	server := httptest.NewServer(apiserver.APIServer(params, &mockRestoreWorker{}))
+	defer server.Close()
+
+	publish := func(entityID, locationID string) changefeed.Event {
+		return must.Must(broker.Publish(c.Context(), changefeed.Event{
+			GroupID:    testGroup.ID,
+			Entity:     changefeed.EntityCommodity,
+			Action:     changefeed.ActionUpdated,
+			EntityID:   entityID,
+			LocationID: locationID,
+		}))
+	}
+	first := publish("c-1", "loc-a")
+	publish("c-2", "loc-b")
+	publish("c-3", "loc-a")
+
+	c.Run("resume replays after Last-Event-ID", func(c *qt.C) {
+		messages := openEventStream(c, server.URL, testGroup.Slug, testUser.ID, first.ID)
+		c.Assert(nextSSEMessage(c, messages).event.EntityID, qt.Equals, "c-2")
+		c.Assert(nextSSEMessage(c, messages).event.EntityID, qt.Equals, "c-3")
+	})
+
+	c.Run("unknown Last-Event-ID resets", func(c *qt.C) {
+		messages := openEventStream(c, server.URL, testGroup.Slug, testUser.ID, "not-an-id")
+		msg := nextSSEMessage(c, messages)
+		c.Assert(msg.name, qt.Equals, "reset")
+		c.Assert(msg.id, qt.Equals, "")
+	})
+
+	c.Run("scoped member only sees their locations", func(c *qt.C) {
+		_, err := params.FactorySet.GroupMemberLocationScopeRegistry.ReplaceForMember(c.Context(), testUser.TenantID, testGroup.ID, testUser.ID,
+			[]models.GroupMemberLocationScope{{LocationID: "loc-a", Role: models.GroupRoleViewer}})
+		c.Assert(err, qt.IsNil)
+
+		messages := openEventStream(c, server.URL, testGroup.Slug, testUser.ID, first.ID)
+		c.Assert(nextSSEMessage(c, messages).event.EntityID, qt.Equals, "c-3")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/jsonapi"
 	"github.com/denisvmedia/inventario/models"
 )
@@ -147,7 +148,11 @@ func (api *exportRestoresAPI) apiGetExportRestore(w http.ResponseWriter, r *http
 
 // createExportRestore creates a new restore operation for an export.
 // @Summary Create export restore operation
-// @Description create a new restore operation for an export
+// @Description create a new restore operation for an export. With options.dry_run set
+// @Description nothing is written: the operation ends in the preview status with the
+// @Description planned changes and conflicts in its preview attribute. options.selection
+// @Description restricts the restore to the listed location, area and commodity UUIDs
+// @Description (with their subtrees and files); missing parents are created.
 // @Tags exports
 // @Accept json-api
 // @Produce json-api
@@ -157,6 +162,7 @@ func (api *exportRestoresAPI) apiGetExportRestore(w http.ResponseWriter, r *http
 // @Success 201 {object} jsonapi.RestoreOperationResponse "Created"
 // @Failure 400 {object} jsonapi.Errors "Bad request"
 // @Failure 404 {object} jsonapi.Errors "Not found"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable entity"
 // @Router /g/{groupSlug}/exports/{id}/restores [post].
 func (api *exportRestoresAPI) createExportRestore(w http.ResponseWriter, r *http.Request) {
 	// Get user-aware settings registry from context
@@ -190,7 +196,7 @@ func (api *exportRestoresAPI) createExportRestore(w http.ResponseWriter, r *http
 
 	data := &jsonapi.RestoreOperationCreateRequest{}
 	if err := render.Bind(r, data); err != nil {
-		renderEntityError(w, r, err)
+		unprocessableEntityError(w, r, err)
 		return
 	}
 
@@ -247,6 +253,7 @@ func (api *exportRestoresAPI) createExportRestore(w http.ResponseWriter, r *http
 	// ListByExport(exportID) returns []; the FE never sees the restore
 	// it just created.
 	restoreOperation.ExportID = exportID
+	restoreOperation.TraceParent = tracing.TraceParent(r.Context())
 	createdRestoreOperation, err := restoreOpReg.Create(r.Context(), restoreOperation)
 	if err != nil {
 		renderEntityError(w, r, err)
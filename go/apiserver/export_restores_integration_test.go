@@ -381,3 +381,161 @@ func TestRestoreOperationCreatedWithPendingStatus(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(restoreOp.Status, qt.Equals, models.RestoreStatusPending)
 }
+
+func TestCreateExportRestore_Selection(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection *models.RestoreSelection
+		wantCode  int
+	}{
+		{
+			name:      "selected area is accepted",
+			selection: &models.RestoreSelection{AreaIDs: []string{"area-uuid"}},
+			wantCode:  http.StatusCreated,
+		},
+		{
+			name:      "empty selection is rejected",
+			selection: &models.RestoreSelection{},
+			wantCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "blank id is rejected",
+			selection: &models.RestoreSelection{CommodityIDs: []string{""}},
+			wantCode:  http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+
+			factorySet, testUser := newTestFactorySet()
+			ctx := appctx.WithUser(context.Background(), testUser)
+			exportRegistry := factorySet.ExportRegistryFactory.MustCreateUserRegistry(ctx)
+			createdExport, err := exportRegistry.Create(context.Background(), models.Export{
+				Description: "Test Export",
+				Status:      models.ExportStatusCompleted,
+				FilePath:    "test-export.inb",
+				CreatedDate: models.PNow(),
+			})
+			c.Assert(err, qt.IsNil)
+
+			r := chi.NewRouter()
+			r.Use(apiserver.JWTMiddleware(testJWTSecret, factorySet.UserRegistry, nil))
+			r.Use(apiserver.RegistrySetMiddleware(factorySet))
+			params := apiserver.Params{
+				FactorySet:     factorySet,
+				UploadLocation: "memory://",
+				JWTSecret:      testJWTSecret,
+			}
+			r.Route("/api/v1/exports", apiserver.Exports(params, &mockRestoreWorker{hasRunningRestores: false}))
+
+			data, err := json.Marshal(&jsonapi.RestoreOperationCreateRequest{
+				Data: &jsonapi.RestoreOperationCreateRequestData{
+					Type: "restores",
+					Attributes: &models.RestoreOperation{
+						Description: "Restore the kitchen",
+						Options: models.RestoreOptions{
+							Strategy:  "merge_add",
+							Selection: tt.selection,
+						},
+					},
+				},
+			})
+			c.Assert(err, qt.IsNil)
+
+			req, err := http.NewRequest("POST", "/api/v1/exports/"+createdExport.ID+"/restores", bytes.NewReader(data))
+			c.Assert(err, qt.IsNil)
+			req.Header.Set("Content-Type", "application/json")
+			addTestUserAuthHeader(req, testUser.ID)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			c.Assert(rr.Code, qt.Equals, tt.wantCode, qt.Commentf("body: %s", rr.Body.String()))
+			if tt.wantCode != http.StatusCreated {
+				return
+			}
+
+			var response struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			c.Assert(json.Unmarshal(rr.Body.Bytes(), &response), qt.IsNil)
+			restoreOp, err := factorySet.RestoreOperationRegistryFactory.MustCreateUserRegistry(ctx).Get(context.Background(), response.Data.ID)
+			c.Assert(err, qt.IsNil)
+			c.Assert(restoreOp.Options.Selection, qt.DeepEquals, tt.selection)
+		})
+	}
+}
+
+func TestGetExportRestore_ReturnsPreviewReport(t *testing.T) {
+	c := qt.New(t)
+
+	factorySet, testUser := newTestFactorySet()
+	ctx := appctx.WithUser(context.Background(), testUser)
+	exportRegistry := factorySet.ExportRegistryFactory.MustCreateUserRegistry(ctx)
+	createdExport, err := exportRegistry.Create(ctx, models.Export{
+		Description: "Test Export",
+		Status:      models.ExportStatusCompleted,
+		FilePath:    "test-export.inb",
+		CreatedDate: models.PNow(),
+	})
+	c.Assert(err, qt.IsNil)
+
+	restoreOpRegistry := factorySet.RestoreOperationRegistryFactory.MustCreateUserRegistry(ctx)
+	preview, err := restoreOpRegistry.Create(ctx, models.RestoreOperation{
+		ExportID:    createdExport.ID,
+		Description: "Preview",
+		Status:      models.RestoreStatusPreview,
+		Options:     models.RestoreOptions{Strategy: "merge_update", DryRun: true},
+		Preview: &models.RestorePreview{
+			Locations: []models.RestorePreviewLocation{{
+				ID:     "loc-uuid",
+				Name:   "Home",
+				Action: models.RestorePreviewActionUpdate,
+				Areas: []models.RestorePreviewArea{{
+					ID:          "area-uuid",
+					Name:        "Kitchen",
+					Action:      models.RestorePreviewActionCreate,
+					Commodities: models.RestorePreviewCounts{Create: 2},
+				}},
+			}},
+			Files: models.RestorePreviewFiles{Upload: 1, UploadBytes: 2048},
+			Conflicts: []models.RestoreConflict{{
+				Kind:       models.RestoreConflictMissingArea,
+				EntityType: "commodity",
+				EntityID:   "commodity-uuid",
+			}},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	r := chi.NewRouter()
+	r.Use(apiserver.JWTMiddleware(testJWTSecret, factorySet.UserRegistry, nil))
+	r.Use(apiserver.RegistrySetMiddleware(factorySet))
+	params := apiserver.Params{
+		FactorySet:     factorySet,
+		UploadLocation: "memory://",
+		JWTSecret:      testJWTSecret,
+	}
+	r.Route("/api/v1/exports", apiserver.Exports(params, &mockRestoreWorker{}))
+
+	req, err := http.NewRequest("GET", "/api/v1/exports/"+createdExport.ID+"/restores/"+preview.ID, nil)
+	c.Assert(err, qt.IsNil)
+	addTestUserAuthHeader(req, testUser.ID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body: %s", rr.Body.String()))
+
+	var resp jsonapi.RestoreOperationResponse
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &resp), qt.IsNil)
+	got := resp.Data.Attributes
+	c.Assert(got.Status, qt.Equals, models.RestoreStatusPreview)
+	c.Assert(got.Preview, qt.IsNotNil)
+	c.Assert(got.Preview.Locations, qt.HasLen, 1)
+	c.Assert(got.Preview.Locations[0].Areas[0].Commodities, qt.Equals, models.RestorePreviewCounts{Create: 2})
+	c.Assert(got.Preview.Files, qt.Equals, models.RestorePreviewFiles{Upload: 1, UploadBytes: 2048})
+	c.Assert(got.Preview.Conflicts, qt.HasLen, 1)
+	c.Assert(got.Preview.Conflicts[0].Kind, qt.Equals, models.RestoreConflictMissingArea)
+}
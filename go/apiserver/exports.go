@@ -11,11 +11,14 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
 	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
 
 	"github.com/denisvmedia/inventario/apiserver/internal/downloadutils"
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/backup/export"
+	"github.com/denisvmedia/inventario/backup/replication"
 	"github.com/denisvmedia/inventario/internal/blobkeys"
 	"github.com/denisvmedia/inventario/internal/filekit"
 	"github.com/denisvmedia/inventario/internal/mimekit"
@@ -38,6 +41,7 @@ type exportsAPI struct {
 	uploadLocation     string
 	entityService      *services.EntityService
 	fileSigningService *services.FileSigningService
+	replicator         *replication.Replicator
 }
 
 // listExports lists all exports.
@@ -121,7 +125,9 @@ func (api *exportsAPI) apiGetExport(w http.ResponseWriter, r *http.Request) {
 // @Produce json-api
 // @Param groupSlug path string true "Group slug"
 // @Param export body jsonapi.ExportCreateRequest true "Export"
+// @Param view_id query string false "Export the commodities matched by a saved view as a selected_items export; selected_items in the body is ignored"
 // @Success 201 {object} jsonapi.ExportResponse "Created"
+// @Failure 404 {object} jsonapi.Errors "Saved view not found"
 // @Failure 422 {object} jsonapi.Errors "Unprocessable Entity"
 // @Router /g/{groupSlug}/exports [post].
 func (api *exportsAPI) createExport(w http.ResponseWriter, r *http.Request) {
@@ -139,7 +145,27 @@ func (api *exportsAPI) createExport(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var request jsonapi.ExportCreateRequest
-	if err := render.Bind(r, &request); err != nil {
+	if r.URL.Query().Get("view_id") != "" {
+		// The view supplies the selection, so it has to be in place before
+		// Bind validates that a selected_items export is non-empty.
+		if err := render.Decode(r, &request); err != nil {
+			unprocessableEntityError(w, r, err)
+			return
+		}
+		items, err := savedViewExportItems(r.Context(), registrySet, r.URL.Query())
+		if err != nil {
+			renderSavedViewError(w, r, err)
+			return
+		}
+		if request.Data != nil && request.Data.Attributes != nil {
+			request.Data.Attributes.Type = models.ExportTypeSelectedItems
+			request.Data.Attributes.SelectedItems = items
+		}
+		if err := request.Bind(r); err != nil {
+			unprocessableEntityError(w, r, err)
+			return
+		}
+	} else if err := render.Bind(r, &request); err != nil {
 		unprocessableEntityError(w, r, err)
 		return
 	}
@@ -491,6 +517,101 @@ func (api *exportsAPI) importExport(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// importReplica imports an export from its copy in the backup replication target
+// @Summary Import backup archive from its off-site replica
+// @Description Copy a replicated `.inb` archive back from the backup replication target and create an export record for it, which can then be restored like any imported backup. The URL must point into the configured replication target and into the caller's tenant. When one of the group's exports records this replica, the copy must match its recorded SHA-256; the archive signature is verified by the import worker either way.
+// @Tags exports
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param data body jsonapi.ImportReplicaRequest true "Import replica request data"
+// @Success 201 {object} jsonapi.ExportResponse "Created"
+// @Failure 404 {object} jsonapi.Errors "Replication is not configured or the replica does not exist"
+// @Failure 422 {object} jsonapi.Errors "Unprocessable Entity"
+// @Router /g/{groupSlug}/exports/import-replica [post].
+func (api *exportsAPI) importReplica(w http.ResponseWriter, r *http.Request) {
+	if api.replicator == nil {
+		codedNotFoundError(w, r, errBackupReplicationDisabled, "exports.replication_disabled")
+		return
+	}
+
+	registrySet := RegistrySetFromContext(r.Context())
+	if registrySet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var data jsonapi.ImportReplicaRequest
+	if err := render.Bind(r, &data); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	user := GetUserFromRequest(r)
+	if user == nil {
+		http.Error(w, "User context required", http.StatusInternalServerError)
+		return
+	}
+
+	key, err := api.replicator.ResolveObjectURL(data.Data.Attributes.ReplicaURL)
+	if err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	// Same tenant guard as importExport: replicas keep their upload-bucket
+	// key, so the tenant prefix identifies whose backup this is.
+	prefix := blobkeys.TenantPrefix(user.TenantID)
+	if prefix == "" || !strings.HasPrefix(key, prefix) {
+		unprocessableEntityError(w, r, errImportSourceForeignTenant)
+		return
+	}
+
+	// A replica the group still has a record of must match the digest taken
+	// when it was replicated. Without a record (e.g. the database itself is
+	// being recovered) the archive signature is the integrity check.
+	var expectedSHA256 string
+	exports, err := registrySet.ExportRegistry.List(r.Context())
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	objectURL := api.replicator.ObjectURL(key)
+	for _, e := range exports {
+		if e.ReplicaURL == objectURL && e.ReplicaSHA256 != "" {
+			expectedSHA256 = e.ReplicaSHA256
+			break
+		}
+	}
+
+	dest := blobkeys.BuildRestoreUploadKey(user.TenantID, uuid.New().String(), path.Base(key))
+	if _, err := api.replicator.Fetch(r.Context(), key, dest, expectedSHA256); err != nil {
+		switch {
+		case gcerrors.Code(err) == gcerrors.NotFound:
+			codedNotFoundError(w, r, err, "exports.replica_not_found")
+		case errors.Is(err, replication.ErrReplicaMismatch):
+			unprocessableEntityError(w, r, err)
+		default:
+			internalServerError(w, r, err)
+		}
+		return
+	}
+
+	importedExport := models.NewImportedExport(data.Data.Attributes.Description, dest)
+	if importedExport.TenantID == "" {
+		importedExport.TenantID = user.TenantID
+	}
+	createdExport, err := registrySet.ExportRegistry.Create(r.Context(), importedExport)
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, jsonapi.NewExportResponse(createdExport).WithStatusCode(http.StatusCreated)); err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+}
+
 func (api *exportsAPI) getDownloadFile(ctx context.Context, filePath string) (io.ReadCloser, error) {
 	b, err := blob.OpenBucket(ctx, api.uploadLocation)
 	if err != nil {
@@ -537,12 +658,14 @@ func Exports(params Params, restoreStatus RestoreStatusQuerier) func(r chi.Route
 		uploadLocation:     params.UploadLocation,
 		entityService:      params.EntityService,
 		fileSigningService: services.NewFileSigningService(params.FileSigningKey, params.FileURLExpiration),
+		replicator:         params.BackupReplicator,
 	}
 
 	return func(r chi.Router) {
 		r.Get("/", api.listExports)
 		r.Post("/", api.createExport)
 		r.Post("/import", api.importExport)
+		r.Post("/import-replica", api.importReplica)
 
 		r.Route("/{id}", func(r chi.Router) {
 			r.Use(exportCtx())
@@ -0,0 +1,194 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-extras/go-kit/must"
+	"gocloud.dev/blob"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/backup/replication"
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+)
+
+func newFileBucketURL(c *qt.C) string {
+	dir := c.TempDir()
+	if runtime.GOOS == "windows" {
+		return "file:///" + dir + "?create_dir=1"
+	}
+	return "file://" + dir + "?create_dir=1"
+}
+
+// TestImportReplica covers POST /exports/import-replica: the replica URL
+// must point into the configured target and the caller's tenant, a replica
+// the group has a record of must match its recorded SHA-256, and a
+// successful import copies the archive into the caller's restore-upload
+// namespace and creates a pending imported export.
+func TestImportReplica(t *testing.T) {
+	const (
+		tenantID = "caller-tenant-id"
+		ownKey   = "t/caller-tenant-id/exports/backup_full_database_20260513.inb"
+	)
+	archive := []byte("signed backup archive")
+	archiveSum := sha256.Sum256(archive)
+
+	cases := []struct {
+		name         string
+		disabled     bool
+		replicaURL   func(r *replication.Replicator) string
+		recordedSHA  string
+		wantStatus   int
+		wantImported bool
+	}{
+		{
+			name:       "replication not configured",
+			disabled:   true,
+			replicaURL: func(*replication.Replicator) string { return "s3://backups/" + ownKey },
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "URL outside the replication target",
+			replicaURL: func(*replication.Replicator) string { return "s3://elsewhere/" + ownKey },
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "another tenant's replica",
+			replicaURL: func(r *replication.Replicator) string {
+				return r.ObjectURL("t/victim-tenant-id/exports/backup_full_database_20260513.inb")
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "missing replica",
+			replicaURL: func(r *replication.Replicator) string { return r.ObjectURL("t/caller-tenant-id/exports/missing.inb") },
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:        "replica does not match the recorded digest",
+			replicaURL:  func(r *replication.Replicator) string { return r.ObjectURL(ownKey) },
+			recordedSHA: strings.Repeat("0", 64),
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "replica matching the recorded digest",
+			replicaURL:   func(r *replication.Replicator) string { return r.ObjectURL(ownKey) },
+			recordedSHA:  hex.EncodeToString(archiveSum[:]),
+			wantStatus:   http.StatusCreated,
+			wantImported: true,
+		},
+		{
+			name:         "replica without a record",
+			replicaURL:   func(r *replication.Replicator) string { return r.ObjectURL(ownKey) },
+			wantStatus:   http.StatusCreated,
+			wantImported: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := qt.New(t)
+			ctx := context.Background()
+			uploads := newFileBucketURL(c)
+			target := newFileBucketURL(c)
+
+			targetBucket := must.Must(blob.OpenBucket(ctx, target))
+			c.Assert(targetBucket.WriteAll(ctx, ownKey, archive, nil), qt.IsNil)
+			c.Assert(targetBucket.WriteAll(ctx, "t/victim-tenant-id/exports/backup_full_database_20260513.inb", archive, nil), qt.IsNil)
+			c.Assert(targetBucket.Close(), qt.IsNil)
+
+			replicator := must.Must(replication.NewReplicator(uploads, target))
+
+			factorySet := memory.NewFactorySet()
+			testUser := models.User{
+				TenantAwareEntityID: models.TenantAwareEntityID{TenantID: tenantID},
+				Email:               "test+import-replica@example.com",
+				Name:                "Test User",
+				IsActive:            true,
+			}
+			must.Assert(testUser.SetPassword("Password123"))
+			createdUser := must.Must(factorySet.UserRegistry.Create(ctx, testUser))
+
+			if tc.recordedSHA != "" {
+				userCtx := appctx.WithUser(ctx, createdUser)
+				_, err := factorySet.ExportRegistryFactory.MustCreateUserRegistry(userCtx).Create(userCtx, models.Export{
+					TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{TenantID: tenantID},
+					Type:                     models.ExportTypeFullDatabase,
+					Status:                   models.ExportStatusCompleted,
+					FilePath:                 ownKey,
+					ReplicationStatus:        models.ExportReplicationStatusReplicated,
+					ReplicaURL:               replicator.ObjectURL(ownKey),
+					ReplicaSHA256:            tc.recordedSHA,
+				})
+				c.Assert(err, qt.IsNil)
+			}
+
+			r := chi.NewRouter()
+			r.Use(render.SetContentType(render.ContentTypeJSON))
+			r.Use(apiserver.JWTMiddleware(testJWTSecret, factorySet.UserRegistry, nil))
+			r.Use(apiserver.RegistrySetMiddleware(factorySet))
+			params := apiserver.Params{
+				FactorySet:     factorySet,
+				UploadLocation: uploads,
+				EntityService:  services.NewEntityService(factorySet, uploads),
+				JWTSecret:      testJWTSecret,
+			}
+			if !tc.disabled {
+				params.BackupReplicator = replicator
+			}
+			r.Route("/exports", apiserver.Exports(params, &mockRestoreWorker{}))
+
+			payload := must.Must(json.Marshal(jsonapi.ImportReplicaRequest{
+				Data: &jsonapi.ImportReplicaRequestData{
+					Type: "exports",
+					Attributes: &jsonapi.ImportReplicaAttributes{
+						Description: "Recovered from replica",
+						ReplicaURL:  tc.replicaURL(replicator),
+					},
+				},
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/exports/import-replica", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			addTestUserAuthHeader(req, createdUser.ID)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			c.Assert(w.Code, qt.Equals, tc.wantStatus, qt.Commentf("body: %s", w.Body.String()))
+			if !tc.wantImported {
+				return
+			}
+
+			var resp struct {
+				Data struct {
+					Attributes models.Export `json:"attributes"`
+				} `json:"data"`
+			}
+			c.Assert(json.Unmarshal(w.Body.Bytes(), &resp), qt.IsNil)
+			imported := resp.Data.Attributes
+			c.Assert(imported.Imported, qt.IsTrue)
+			c.Assert(imported.Status, qt.Equals, models.ExportStatusPending)
+			c.Assert(imported.FilePath, qt.Matches, `t/caller-tenant-id/restores/.*backup_full_database_20260513\.inb`)
+
+			uploadBucket := must.Must(blob.OpenBucket(ctx, uploads))
+			defer uploadBucket.Close()
+			copied, err := uploadBucket.ReadAll(ctx, imported.FilePath)
+			c.Assert(err, qt.IsNil)
+			c.Assert(copied, qt.DeepEquals, archive)
+		})
+	}
+}
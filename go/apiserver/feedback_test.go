@@ -85,6 +85,25 @@ func (*capturingFeedbackEmailService) SendMaintenanceReminderEmail(_ context.Con
 	return nil
 }
 
+func (*capturingFeedbackEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+
+func (*capturingFeedbackEmailService) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+func (*capturingFeedbackEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*capturingFeedbackEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*capturingFeedbackEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return nil
+}
+
 // newFeedbackTestRouter mounts the Feedback route group with a stubbed
 // user-context middleware so the test exercises the same handler tree
 // production uses — only the auth middleware is swapped out.
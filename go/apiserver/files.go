@@ -1,6 +1,7 @@
 package apiserver
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -21,6 +22,7 @@ import (
 	"github.com/denisvmedia/inventario/apiserver/internal/downloadutils"
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/assets"
+	"github.com/denisvmedia/inventario/changefeed"
 	"github.com/denisvmedia/inventario/internal/filekit"
 	"github.com/denisvmedia/inventario/internal/mimekit"
 	"github.com/denisvmedia/inventario/internal/textutils"
@@ -364,7 +366,9 @@ func (api *filesAPI) createFile(w http.ResponseWriter, r *http.Request) {
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(r.Context()).FileChanged(r.Context(), changefeed.ActionCreated, createdFile)
 
+	setETag(w, createdFile)
 	response := jsonapi.NewFileResponse(createdFile).WithStatusCode(http.StatusCreated)
 	if err := render.Render(w, r, response); err != nil {
 		internalServerError(w, r, err)
@@ -381,6 +385,7 @@ func (api *filesAPI) createFile(w http.ResponseWriter, r *http.Request) {
 // @Param groupSlug path string true "Group slug"
 // @Param fileID path string true "File ID"
 // @Success 200 {object} jsonapi.FileResponse "OK"
+// @Header 200 {string} ETag "Entity version, for If-Match on update and delete"
 // @Failure 404 {object} jsonapi.Errors "File not found"
 // @Router /g/{groupSlug}/files/{fileID} [get].
 func (api *filesAPI) apiGetFile(w http.ResponseWriter, r *http.Request) {
@@ -404,6 +409,7 @@ func (api *filesAPI) apiGetFile(w http.ResponseWriter, r *http.Request) {
 	// Generate signed URLs for the file
 	signedUrls := api.generateSignedURLsForFiles(r, []*models.FileEntity{file})
 
+	setETag(w, file)
 	response := jsonapi.NewFileResponseWithSignedUrls(file, signedUrls)
 	if err := render.Render(w, r, response); err != nil {
 		internalServerError(w, r, err)
@@ -420,8 +426,10 @@ func (api *filesAPI) apiGetFile(w http.ResponseWriter, r *http.Request) {
 // @Param groupSlug path string true "Group slug"
 // @Param fileID path string true "File ID"
 // @Param file body jsonapi.FileUpdateRequest true "File update data"
+// @Param If-Match header string false "ETag from GET; the update is refused when the file has changed since"
 // @Success 200 {object} jsonapi.FileResponse "OK"
 // @Failure 404 {object} jsonapi.Errors "File not found"
+// @Failure 412 {object} jsonapi.FileResponse "File changed since the If-Match ETag; body is the current file"
 // @Failure 422 {object} jsonapi.Errors "Validation error"
 // @Router /g/{groupSlug}/files/{fileID} [put].
 func (api *filesAPI) updateFile(w http.ResponseWriter, r *http.Request) {
@@ -453,6 +461,13 @@ func (api *filesAPI) updateFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, file)
+	if !ok {
+		preconditionFailed(w, r, file, jsonapi.NewFileResponse(file).WithStatusCode(http.StatusPreconditionFailed))
+		return
+	}
+	file.Version = expectedVersion
+
 	// Check if this is an export file and prevent changing entity linking
 	if file.LinkedEntityType == "export" {
 		// For export files, only allow updating title, description, tags, and path
@@ -509,11 +524,22 @@ func (api *filesAPI) updateFile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	updatedFile, err := fileReg.Update(r.Context(), *file)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if current, getErr := fileReg.Get(r.Context(), fileID); getErr == nil {
+			file = current
+		}
+		preconditionFailed(w, r, file, jsonapi.NewFileResponse(file).WithStatusCode(http.StatusPreconditionFailed))
+		return
+	}
 	if err != nil {
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(r.Context()).FileChanged(r.Context(), changefeed.ActionUpdated, updatedFile)
 
+	setETag(w, updatedFile)
 	response := jsonapi.NewFileResponse(updatedFile)
 	if err := render.Render(w, r, response); err != nil {
 		internalServerError(w, r, err)
@@ -529,14 +555,52 @@ func (api *filesAPI) updateFile(w http.ResponseWriter, r *http.Request) {
 // @Produce json-api
 // @Param groupSlug path string true "Group slug"
 // @Param fileID path string true "File ID"
+// @Param If-Match header string false "ETag from GET; the delete is refused when the file has changed since"
 // @Success 204 "No Content"
 // @Failure 404 {object} jsonapi.Errors "File not found"
+// @Failure 412 {object} jsonapi.FileResponse "File changed since the If-Match ETag; body is the current file"
 // @Router /g/{groupSlug}/files/{fileID} [delete].
 func (api *filesAPI) deleteFile(w http.ResponseWriter, r *http.Request) {
 	fileID := chi.URLParam(r, "fileID")
 
+	// Files have no loader middleware, so the row is only read when the
+	// client asked for a precondition.
+	ctx := r.Context()
+	var fileReg registry.FileRegistry
+	if r.Header.Get("If-Match") != "" {
+		registrySet := RegistrySetFromContext(ctx)
+		if registrySet == nil {
+			http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+			return
+		}
+		fileReg = registrySet.FileRegistry
+		file, err := fileReg.Get(ctx, fileID)
+		if err != nil {
+			renderEntityError(w, r, err)
+			return
+		}
+		expectedVersion, ok := ifMatchVersion(r, file)
+		if !ok {
+			preconditionFailed(w, r, file, jsonapi.NewFileResponse(file).WithStatusCode(http.StatusPreconditionFailed))
+			return
+		}
+		// The row is deleted only while it still carries that version.
+		ctx = registry.WithExpectedVersion(ctx, fileID, expectedVersion)
+	}
+
 	// Use file service to delete both physical file and database record
-	err := api.fileService.DeleteFileWithPhysical(r.Context(), fileID)
+	err := api.deleteFileAndNotify(ctx, fileID)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		file, getErr := fileReg.Get(ctx, fileID)
+		if getErr != nil {
+			renderEntityError(w, r, getErr)
+			return
+		}
+		preconditionFailed(w, r, file, jsonapi.NewFileResponse(file).WithStatusCode(http.StatusPreconditionFailed))
+		return
+	}
 	if err != nil {
 		renderEntityError(w, r, err)
 		return
@@ -680,7 +744,7 @@ func (api *filesAPI) bulkDeleteFiles(w http.ResponseWriter, r *http.Request) {
 	succeeded := make([]string, 0, len(input.Data.Attributes.IDs))
 	failed := make([]jsonapi.BulkResultFail, 0)
 	for _, id := range input.Data.Attributes.IDs {
-		if err := api.fileService.DeleteFileWithPhysical(r.Context(), id); err != nil {
+		if err := api.deleteFileAndNotify(r.Context(), id); err != nil {
 			failed = append(failed, jsonapi.BulkResultFail{ID: id, Error: err.Error()})
 			continue
 		}
@@ -693,6 +757,22 @@ func (api *filesAPI) bulkDeleteFiles(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// deleteFileAndNotify removes the file row and blob and announces the deletion on
+// the change feed. The row is read first because the event needs its link
+// to resolve the location; a failed read leaves the delete to report the
+// canonical error.
+func (api *filesAPI) deleteFileAndNotify(ctx context.Context, fileID string) error {
+	var before *models.FileEntity
+	if registrySet := RegistrySetFromContext(ctx); registrySet != nil {
+		before, _ = registrySet.FileRegistry.Get(ctx, fileID)
+	}
+	if err := api.fileService.DeleteFileWithPhysical(ctx, fileID); err != nil {
+		return err
+	}
+	services.ChangeFeedFromContext(ctx).FileChanged(ctx, changefeed.ActionDeleted, before)
+	return nil
+}
+
 func Files(params Params) func(r chi.Router) {
 	fileSigningService := services.NewFileSigningService(params.FileSigningKey, params.FileURLExpiration)
 	api := &filesAPI{
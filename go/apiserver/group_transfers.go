@@ -0,0 +1,112 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/internal/currency"
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+type groupTransfersAPI struct {
+	service *services.GroupTransferService
+}
+
+// GroupTransfers mounts the transfer endpoint of a group. The role check
+// on the target group lives in the service: the route's write gate only
+// covers the group in the path.
+func GroupTransfers(params Params) func(r chi.Router) {
+	api := &groupTransfersAPI{
+		service: services.NewGroupTransferService(params.FactorySet),
+	}
+	return func(r chi.Router) {
+		r.Post("/", api.transfer)
+	}
+}
+
+// transfer moves locations, areas or commodities into another group.
+// @Summary Transfer inventory to another group
+// @Description Moves the selected locations (with their areas and commodities),
+// @Description areas or commodities from the group in the path to another group of
+// @Description the same tenant, together with their files, tags, loans, services,
+// @Description maintenance and event history. The caller needs at least the user
+// @Description role in both groups. Prices are converted with exchange_rate when the
+// @Description group currencies differ. A "transferred" event is logged in both groups.
+// @Tags groups
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Source group slug"
+// @Param body body jsonapi.GroupTransferRequest true "What to transfer and where"
+// @Success 200 {object} jsonapi.GroupTransferResponse "Transferred"
+// @Failure 403 {object} jsonapi.Errors "Not a user of both groups"
+// @Failure 404 {object} jsonapi.Errors "Group, location, area or commodity not found"
+// @Failure 422 {object} jsonapi.Errors "Invalid request"
+// @Failure 423 {object} jsonapi.Errors "A group is locked by a currency migration"
+// @Router /g/{groupSlug}/transfers [post].
+func (api *groupTransfersAPI) transfer(w http.ResponseWriter, r *http.Request) {
+	var input jsonapi.GroupTransferRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	attrs := input.Data.Attributes
+
+	result, err := api.service.Transfer(r.Context(), services.GroupTransferRequest{
+		TargetGroupID:    attrs.TargetGroupID,
+		LocationIDs:      attrs.LocationIDs,
+		AreaIDs:          attrs.AreaIDs,
+		TargetLocationID: attrs.TargetLocationID,
+		CommodityIDs:     attrs.CommodityIDs,
+		TargetAreaID:     attrs.TargetAreaID,
+		ExchangeRate:     attrs.ExchangeRate,
+	})
+	switch {
+	case errors.Is(err, services.ErrTransferForbidden):
+		codedForbiddenError(w, r, err, "group_transfer.forbidden")
+		return
+	case errors.Is(err, services.ErrTransferSameGroup):
+		codedUnprocessableEntityError(w, r, err, "group_transfer.same_group")
+		return
+	case errors.Is(err, services.ErrTransferNothingSelected):
+		codedUnprocessableEntityError(w, r, err, "group_transfer.nothing_selected")
+		return
+	case errors.Is(err, services.ErrTransferTargetLocationRequired):
+		codedUnprocessableEntityError(w, r, err, "group_transfer.target_location_required")
+		return
+	case errors.Is(err, services.ErrTransferTargetAreaRequired):
+		codedUnprocessableEntityError(w, r, err, "group_transfer.target_area_required")
+		return
+	case errors.Is(err, services.ErrTransferExchangeRateRequired):
+		codedUnprocessableEntityError(w, r, err, "group_transfer.exchange_rate_required")
+		return
+	case errors.Is(err, currency.ErrInvalidExchangeRate):
+		codedUnprocessableEntityError(w, r, err, "group_transfer.invalid_exchange_rate")
+		return
+	case errors.Is(err, registry.ErrMigrationInFlight):
+		lockedError(w, r, err, codeCurrencyMigrationLocked, nil)
+		return
+	case err != nil:
+		renderEntityError(w, r, err)
+		return
+	}
+
+	meta := jsonapi.GroupTransferMeta{
+		Locations:   result.Locations,
+		Areas:       result.Areas,
+		Commodities: result.Commodities,
+		Files:       result.Files,
+		TagsCreated: result.TagsCreated,
+		Converted:   result.Converted,
+	}
+	if result.Converted {
+		meta.ExchangeRate = &result.ExchangeRate
+	}
+	if err := render.Render(w, r, jsonapi.NewGroupTransferResponse(result.TargetGroup, meta)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
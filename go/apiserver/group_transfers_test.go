@@ -0,0 +1,72 @@
+package apiserver_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/models"
+)
+
+func TestGroupTransfer_Location(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	registrySet := getRegistrySetFromParams(params, testUser)
+	location := must.Must(registrySet.LocationRegistry.List(context.Background()))[0]
+	target := createTestGroupForUser(params.FactorySet, testUser.TenantID, testUser.ID)
+
+	body := `{"data":{"type":"group_transfers","attributes":{"target_group_id":"` + target.ID +
+		`","location_ids":["` + location.ID + `"]}}}`
+	rr := serveDuplicates(params, testUser.ID, http.MethodPost, "/api/v1/g/"+testGroup.Slug+"/transfers", body)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.id"), target.ID)
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.locations"), float64(1))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.converted"), false)
+
+	moved := must.Must(params.FactorySet.LocationRegistryFactory.CreateServiceRegistry().Get(context.Background(), location.ID))
+	c.Assert(moved.GroupID, qt.Equals, target.ID)
+}
+
+func TestGroupTransfer_Rejections(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	registrySet := getRegistrySetFromParams(params, testUser)
+	location := must.Must(registrySet.LocationRegistry.List(context.Background()))[0]
+	transfersURL := "/api/v1/g/" + testGroup.Slug + "/transfers"
+	transferBody := func(targetID string) string {
+		return `{"data":{"type":"group_transfers","attributes":{"target_group_id":"` + targetID +
+			`","location_ids":["` + location.ID + `"]}}}`
+	}
+
+	rr := serveDuplicates(params, testUser.ID, http.MethodPost, transfersURL, transferBody(testGroup.ID))
+	c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "group_transfer.same_group")
+
+	rr = serveDuplicates(params, testUser.ID, http.MethodPost, transfersURL, transferBody("does-not-exist"))
+	c.Assert(rr.Code, qt.Equals, http.StatusNotFound, qt.Commentf("body=%s", rr.Body.String()))
+
+	// A viewer of the target group may not write into it.
+	viewOnly := must.Must(params.FactorySet.LocationGroupRegistry.Create(context.Background(), models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: testUser.TenantID},
+		Name:                "View Only",
+		Slug:                must.Must(models.GenerateGroupSlug()),
+		Status:              models.LocationGroupStatusActive,
+		CreatedBy:           testUser.ID,
+		GroupCurrency:       models.Currency("USD"),
+	}))
+	must.Must(params.FactorySet.GroupMembershipRegistry.Create(context.Background(), models.GroupMembership{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: testUser.TenantID},
+		GroupID:             viewOnly.ID,
+		MemberUserID:        testUser.ID,
+		Role:                models.GroupRoleViewer,
+	}))
+	rr = serveDuplicates(params, testUser.ID, http.MethodPost, transfersURL, transferBody(viewOnly.ID))
+	c.Assert(rr.Code, qt.Equals, http.StatusForbidden, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "group_transfer.forbidden")
+}
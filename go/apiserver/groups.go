@@ -106,11 +106,23 @@ func GroupSlugResolverMiddleware(groupService *services.GroupService) func(http.
 				return
 			}
 
+			scope, err := groupService.GetMemberLocationScope(r.Context(), group.TenantID, group.ID, user.ID)
+			if err != nil {
+				slog.Error("GroupSlugResolverMiddleware: GetMemberLocationScope failed", "group_id", group.ID, "user_id", user.ID, "error", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
 			// Store the group in both the apiserver-local key (used by
 			// group handlers) and the appctx key (read by registry factories
 			// at RegistrySetMiddleware time to wire group_id into transactions).
+			// A restricted member's location scope rides along the same way
+			// so the registries and the RLS setup narrow every query.
 			ctx := context.WithValue(r.Context(), groupCtxKey, group)
 			ctx = appctx.WithGroup(ctx, group)
+			if scope.Restricted() {
+				ctx = appctx.WithLocationScope(ctx, scope)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -222,7 +234,7 @@ func requireGroupRole(groupService *services.GroupService, minRole models.GroupR
 				return
 			}
 
-			ok, _, err := groupService.HasRoleAtLeast(r.Context(), group.ID, user.ID, minRole)
+			ok, role, err := groupService.HasRoleAtLeast(r.Context(), group.ID, user.ID, minRole)
 			if err != nil {
 				// Treat infrastructure failures as 500 — same posture as
 				// GroupSlugResolverMiddleware. Masking a DB outage as a
@@ -236,6 +248,13 @@ func requireGroupRole(groupService *services.GroupService, minRole models.GroupR
 				http.Error(w, "Internal server error", http.StatusInternalServerError)
 				return
 			}
+			// A location-scoped member's per-location roles replace the
+			// membership role. The gate admits them when any scoped
+			// location grants minRole; the registries then reject writes
+			// to the other locations.
+			if scope := appctx.LocationScopeFromContext(r.Context()); scope.Restricted() && role != "" {
+				ok = scope.MaxRole().AtLeast(minRole)
+			}
 			if !ok {
 				http.Error(w, forbiddenMessageForRole(minRole), http.StatusForbidden)
 				return
@@ -268,6 +287,20 @@ func requireGroupRoleForWrite(groupService *services.GroupService, minRole model
 	}
 }
 
+// requireUnscopedMember rejects members restricted to specific locations
+// from group-wide resources (exports, backups, transfers, currency
+// migrations). Those operate on every location at once, so a location
+// scope cannot be applied to them row by row.
+func requireUnscopedMember(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if appctx.LocationScopeFromContext(r.Context()).Restricted() {
+			http.Error(w, "Not available to location-scoped members", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func forbiddenMessageForRole(minRole models.GroupRole) string {
 	switch minRole {
 	case models.GroupRoleOwner:
@@ -311,6 +344,9 @@ func Groups(params Params, groupService *services.GroupService, auditService ser
 				r.Patch("/", api.updateGroup)
 				r.Delete("/members/{memberUserID}", api.removeMember)
 				r.Patch("/members/{memberUserID}", api.updateMemberRole)
+				r.Get("/members/{memberUserID}/scopes", api.getMemberScopes)
+				r.Put("/members/{memberUserID}/scopes", api.setMemberScopes)
+				r.Delete("/members/{memberUserID}/scopes", api.clearMemberScopes)
 				r.Post("/invites", api.createInvite)
 				r.Get("/invites", api.listInvites)
 				r.Delete("/invites/{inviteID}", api.revokeInvite)
@@ -720,6 +756,134 @@ func (api *groupsAPI) updateMemberRole(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getMemberScopes returns the locations a member is restricted to.
+// @Summary Get member location scopes
+// @Description Returns the per-location roles that restrict a member to specific locations. An empty list means the member sees the whole group at their membership role. Requires group admin role.
+// @Tags groups
+// @Accept json-api
+// @Produce json-api
+// @Param groupID path string true "Group ID"
+// @Param memberUserID path string true "User ID of the member"
+// @Success 200 {object} jsonapi.MemberLocationScopesResponse "OK"
+// @Failure 403 {string} string "Forbidden - not a group admin"
+// @Failure 404 {object} jsonapi.Errors "Member not found"
+// @Router /groups/{groupID}/members/{memberUserID}/scopes [get].
+func (api *groupsAPI) getMemberScopes(w http.ResponseWriter, r *http.Request) {
+	group := groupFromContext(r.Context())
+	if group == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+
+	memberUserID := chi.URLParam(r, "memberUserID")
+	if memberUserID == "" {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+
+	scopes, err := api.groupService.ListMemberLocationScopes(r.Context(), group.ID, memberUserID)
+	if err != nil {
+		renderMemberScopeError(w, r, err)
+		return
+	}
+
+	resp := jsonapi.NewMemberLocationScopesResponse(group.ID, memberUserID, scopes)
+	if err := render.Render(w, r, resp); err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+}
+
+// setMemberScopes replaces the locations a member is restricted to.
+// @Summary Set member location scopes
+// @Description Restricts a viewer or user member to the listed locations, each with its own role (viewer, user or admin). The list replaces any previous restriction; an empty list lifts it. Admins and owners cannot be restricted. Requires group admin role.
+// @Tags groups
+// @Accept json-api
+// @Produce json-api
+// @Param groupID path string true "Group ID"
+// @Param memberUserID path string true "User ID of the member"
+// @Param data body jsonapi.MemberLocationScopesRequest true "Location scopes"
+// @Success 200 {object} jsonapi.MemberLocationScopesResponse "OK"
+// @Failure 403 {string} string "Forbidden - not a group admin"
+// @Failure 404 {object} jsonapi.Errors "Member not found"
+// @Failure 422 {object} jsonapi.Errors "Invalid scope, location outside the group, or member is an admin"
+// @Router /groups/{groupID}/members/{memberUserID}/scopes [put].
+func (api *groupsAPI) setMemberScopes(w http.ResponseWriter, r *http.Request) {
+	group := groupFromContext(r.Context())
+	if group == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+
+	memberUserID := chi.URLParam(r, "memberUserID")
+	if memberUserID == "" {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+
+	var input jsonapi.MemberLocationScopesRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+
+	scopes, err := api.groupService.SetMemberLocationScopes(r.Context(), group.ID, memberUserID, input.ToModels())
+	if err != nil {
+		renderMemberScopeError(w, r, err)
+		return
+	}
+
+	resp := jsonapi.NewMemberLocationScopesResponse(group.ID, memberUserID, scopes)
+	if err := render.Render(w, r, resp); err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+}
+
+// clearMemberScopes lifts a member's location restriction.
+// @Summary Clear member location scopes
+// @Description Removes every location restriction of a member so they see the whole group at their membership role. Requires group admin role.
+// @Tags groups
+// @Accept json-api
+// @Produce json-api
+// @Param groupID path string true "Group ID"
+// @Param memberUserID path string true "User ID of the member"
+// @Success 204 "No Content"
+// @Failure 403 {string} string "Forbidden - not a group admin"
+// @Failure 404 {object} jsonapi.Errors "Member not found"
+// @Router /groups/{groupID}/members/{memberUserID}/scopes [delete].
+func (api *groupsAPI) clearMemberScopes(w http.ResponseWriter, r *http.Request) {
+	group := groupFromContext(r.Context())
+	if group == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+
+	memberUserID := chi.URLParam(r, "memberUserID")
+	if memberUserID == "" {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+
+	if err := api.groupService.ClearMemberLocationScopes(r.Context(), group.ID, memberUserID); err != nil {
+		renderMemberScopeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// renderMemberScopeError renders a scope-endpoint error, reporting an
+// unknown member as a plain 404 rather than the 500 an unmapped
+// ErrNotGroupMember would produce.
+func renderMemberScopeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, services.ErrNotGroupMember) {
+		renderEntityError(w, r, registry.ErrNotFound)
+		return
+	}
+	renderEntityError(w, r, err)
+}
+
 // leaveGroup allows the current user to leave a group.
 // @Summary Leave group
 // @Description Removes the current user from a location group. Cannot leave if you are the last admin.
@@ -0,0 +1,353 @@
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/go-extras/errx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+const (
+	// idempotencyKeyHeader carries the client-chosen key on a POST.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks a response served from the store
+	// rather than produced by the handler.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencyKeyMaxLength bounds the header value; clients typically
+	// send a UUID.
+	idempotencyKeyMaxLength = 255
+	// defaultIdempotencyKeyTTL is how long a stored response is replayed
+	// when Params.IdempotencyKeyTTL is zero.
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+	// idempotencyReservationTTL bounds an in-flight reservation. A process
+	// that dies mid-request leaves the key locked at most this long.
+	idempotencyReservationTTL = 5 * time.Minute
+	// idempotencyMaxResponseBytes caps the stored response body. A larger
+	// response is served normally but not stored, so a retry re-runs.
+	idempotencyMaxResponseBytes = 1 * 1024 * 1024 // 1 MiB
+	// idempotencyMultipartOverhead is the slack on top of a per-file upload
+	// cap for multipart boundaries and part headers.
+	idempotencyMultipartOverhead = 64 * 1024
+	// idempotencyMaxRequestBytes caps the non-multipart body buffered in
+	// memory for hashing. A larger body bypasses the middleware like an
+	// oversized multipart one does.
+	idempotencyMaxRequestBytes = 1 * 1024 * 1024 // 1 MiB
+)
+
+var (
+	// ErrIdempotencyKeyInvalid is returned when the header value is too long.
+	ErrIdempotencyKeyInvalid = errx.NewSentinel("idempotency key is invalid")
+	// ErrIdempotencyKeyReused is returned when a key is replayed with a
+	// different method, path or payload than the request that first used it.
+	ErrIdempotencyKeyReused = errx.NewSentinel("idempotency key was already used for a different request")
+	// ErrIdempotencyKeyInProgress is returned while the request that first
+	// used the key is still running.
+	ErrIdempotencyKeyInProgress = errx.NewSentinel("a request with this idempotency key is still in progress")
+)
+
+// IdempotencyMiddleware makes POST requests that carry an Idempotency-Key
+// header safe to retry. The first request reserves the key for the caller,
+// runs the handler and stores its status and body; a retry with the same
+// key gets the stored response back (marked Idempotent-Replayed: true)
+// without running the handler again.
+//
+// The key is scoped per user. The request fingerprint covers the method,
+// path, query and payload; multipart payloads are hashed part by part, so
+// a client that regenerates the boundary on retry still matches. Reusing
+// a key for a different request renders 422 (idempotency.key_reused), and
+// a retry racing the still-running first request renders 409
+// (idempotency.in_progress).
+//
+// Only settled outcomes are stored. 5xx, auth rejections (401, 403) and
+// the retryable 4xx statuses (408, 409, 423, 425, 429) release the key so
+// the retry runs for real.
+//
+// maxBodyBytes bounds how much of a multipart body is spooled to disk for
+// hashing (<= 0 spools without a bound). A larger body bypasses the
+// middleware and reaches the handler untouched, which rejects it with its
+// own 413. Non-multipart bodies are buffered in memory up to
+// idempotencyMaxRequestBytes.
+//
+// Passing a nil registry disables the middleware; this is intended only
+// for test environments.
+func IdempotencyMiddleware(reg registry.IdempotencyKeyRegistry, ttl time.Duration, maxBodyBytes int64) func(http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyKeyTTL
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if reg == nil || r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user := appctx.UserFromContext(r.Context())
+			if user == nil {
+				// No authenticated user — nothing to scope the key to.
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > idempotencyKeyMaxLength {
+				_ = badRequest(w, r, ErrIdempotencyKeyInvalid)
+				return
+			}
+
+			body, err := spoolIdempotentBody(r, maxBodyBytes)
+			if err != nil {
+				slog.Error("Failed to read request body for idempotency check", "error", err)
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+			defer body.cleanup()
+			if body.truncated {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			requestHash, err := body.fingerprint(r)
+			if err != nil {
+				slog.Error("Failed to fingerprint request for idempotency check", "error", err)
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+			if err := body.rewind(); err != nil {
+				_ = internalServerError(w, r, err)
+				return
+			}
+
+			rec, created, err := reg.Reserve(r.Context(), models.IdempotencyKey{
+				TenantID:    user.TenantID,
+				UserID:      user.ID,
+				Key:         key,
+				RequestHash: requestHash,
+				ExpiresAt:   time.Now().Add(idempotencyReservationTTL),
+			})
+			if err != nil {
+				_ = internalServerError(w, r, err)
+				return
+			}
+
+			if !created {
+				switch {
+				case rec.RequestHash != requestHash:
+					_ = codedUnprocessableEntityError(w, r, ErrIdempotencyKeyReused, "idempotency.key_reused")
+				case !rec.IsCompleted():
+					_ = codedConflictError(w, r, ErrIdempotencyKeyInProgress, "idempotency.in_progress", nil)
+				default:
+					replayIdempotentResponse(w, rec)
+				}
+				return
+			}
+
+			captured := &cappedBuffer{limit: idempotencyMaxResponseBytes}
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(captured)
+			next.ServeHTTP(ww, r)
+
+			// The client may already be gone; the outcome must still be
+			// recorded so its retry sees it.
+			ctx := context.WithoutCancel(r.Context())
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if !isStorableIdempotentStatus(status) || captured.overflow {
+				if err := reg.Release(ctx, rec.ID); err != nil {
+					slog.Error("Failed to release idempotency key", "error", err, "user_id", user.ID)
+				}
+				return
+			}
+			err = reg.Complete(ctx, rec.ID, status, ww.Header().Get("Content-Type"), captured.Bytes(), time.Now().Add(ttl))
+			if err != nil {
+				slog.Error("Failed to store idempotent response", "error", err, "user_id", user.ID)
+			}
+		})
+	}
+}
+
+// isStorableIdempotentStatus reports whether a response settles the
+// request. Server errors, auth rejections (the caller's role may change
+// before the retry) and the 4xx statuses that mean "try again later" are
+// not stored.
+func isStorableIdempotentStatus(status int) bool {
+	if status >= http.StatusInternalServerError {
+		return false
+	}
+	switch status {
+	case http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusRequestTimeout,
+		http.StatusConflict,
+		http.StatusLocked,
+		http.StatusTooEarly,
+		http.StatusTooManyRequests:
+		return false
+	}
+	return true
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, rec *models.IdempotencyKey) {
+	if rec.ContentType != "" {
+		w.Header().Set("Content-Type", rec.ContentType)
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(rec.StatusCode)
+	_, _ = w.Write(rec.ResponseBody)
+}
+
+// cappedBuffer collects up to limit bytes of the response and records
+// whether more were written. Writes never fail so the client response is
+// unaffected.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.overflow || b.Len()+len(p) > b.limit {
+		b.overflow = true
+		b.Reset()
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// idempotentBody is the spooled request body: in memory for regular
+// payloads, in a temp file for multipart ones. r.Body reads from the spool
+// (followed by whatever was left unread when truncated).
+type idempotentBody struct {
+	src       io.ReadSeeker
+	file      *os.File
+	multipart bool
+	truncated bool
+}
+
+func spoolIdempotentBody(r *http.Request, maxBodyBytes int64) (*idempotentBody, error) {
+	orig := r.Body
+	b := &idempotentBody{
+		multipart: strings.HasPrefix(strings.ToLower(r.Header.Get("Content-Type")), "multipart/"),
+	}
+
+	if !b.multipart {
+		data, err := io.ReadAll(io.LimitReader(orig, idempotencyMaxRequestBytes+1))
+		if err != nil {
+			return nil, err
+		}
+		b.truncated = len(data) > idempotencyMaxRequestBytes
+		b.src = bytes.NewReader(data)
+	} else {
+		f, err := os.CreateTemp("", "inventario-idempotency-*")
+		if err != nil {
+			return nil, err
+		}
+		b.file = f
+		var src io.Reader = orig
+		limit := int64(-1)
+		if maxBodyBytes > 0 {
+			limit = maxBodyBytes + idempotencyMultipartOverhead
+			src = io.LimitReader(orig, limit+1)
+		}
+		n, err := io.Copy(f, src)
+		if err != nil {
+			b.cleanup()
+			return nil, err
+		}
+		b.truncated = limit >= 0 && n > limit
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			b.cleanup()
+			return nil, err
+		}
+		b.src = f
+	}
+
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(b.src, orig), orig}
+	return b, nil
+}
+
+func (b *idempotentBody) rewind() error {
+	_, err := b.src.Seek(0, io.SeekStart)
+	return err
+}
+
+func (b *idempotentBody) cleanup() {
+	if b.file == nil {
+		return
+	}
+	_ = b.file.Close()
+	_ = os.Remove(b.file.Name())
+}
+
+// fingerprint hashes the request identity and payload. Each field is
+// length-prefixed so adjacent values cannot run into each other.
+func (b *idempotentBody) fingerprint(r *http.Request) (string, error) {
+	h := sha256.New()
+	writeHashField(h, []byte(r.Method))
+	writeHashField(h, []byte(r.URL.Path))
+	writeHashField(h, []byte(r.URL.RawQuery))
+
+	if err := b.rewind(); err != nil {
+		return "", err
+	}
+	if !b.multipart {
+		data, err := io.ReadAll(b.src)
+		if err != nil {
+			return "", err
+		}
+		writeHashField(h, data)
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", err
+	}
+	mr := multipart.NewReader(b.src, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		writeHashField(h, []byte(part.FormName()))
+		writeHashField(h, []byte(part.FileName()))
+		writeHashField(h, []byte(part.Header.Get("Content-Type")))
+		n, err := io.Copy(h, part)
+		if err != nil {
+			return "", err
+		}
+		_ = binary.Write(h, binary.BigEndian, n)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeHashField(h hash.Hash, v []byte) {
+	_ = binary.Write(h, binary.BigEndian, uint64(len(v)))
+	_, _ = h.Write(v)
+}
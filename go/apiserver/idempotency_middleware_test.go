@@ -0,0 +1,184 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func TestIdempotencyKey_ReplaysCreate(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	ctx := createTestUserContext(testUser.ID, testUser.TenantID)
+	registrySet := must.Must(params.FactorySet.CreateUserRegistrySet(ctx))
+	location := must.Must(registrySet.LocationRegistry.List(context.Background()))[0]
+	areasBefore := must.Must(registrySet.AreaRegistry.Count(context.Background()))
+
+	handler := apiserver.APIServer(params, &mockRestoreWorker{hasRunningRestores: false})
+	doPOST := func(key, name string) *httptest.ResponseRecorder {
+		obj := &jsonapi.AreaRequest{
+			Data: &jsonapi.AreaData{
+				Type:       "areas",
+				Attributes: &models.Area{Name: name, LocationID: location.ID},
+			},
+		}
+		req, err := http.NewRequest("POST", "/api/v1/g/"+testGroup.Slug+"/areas", bytes.NewReader(must.Must(json.Marshal(obj))))
+		c.Assert(err, qt.IsNil)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		addTestUserAuthHeader(req, testUser.ID)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	first := doPOST("key-1", "Garage")
+	c.Assert(first.Code, qt.Equals, http.StatusCreated, qt.Commentf("Body: %s", first.Body.String()))
+	c.Assert(first.Header().Get("Idempotent-Replayed"), qt.Equals, "")
+
+	retry := doPOST("key-1", "Garage")
+	c.Assert(retry.Code, qt.Equals, http.StatusCreated)
+	c.Assert(retry.Header().Get("Idempotent-Replayed"), qt.Equals, "true")
+	c.Assert(retry.Header().Get("Content-Type"), qt.Equals, first.Header().Get("Content-Type"))
+	c.Assert(retry.Body.String(), qt.Equals, first.Body.String())
+
+	areasAfter := must.Must(registrySet.AreaRegistry.Count(context.Background()))
+	c.Assert(areasAfter, qt.Equals, areasBefore+1)
+
+	// Same key, different payload.
+	reused := doPOST("key-1", "Attic")
+	c.Assert(reused.Code, qt.Equals, http.StatusUnprocessableEntity)
+	c.Assert(reused.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "idempotency.key_reused")
+
+	// Without the header every POST runs.
+	c.Assert(doPOST("", "Shed").Code, qt.Equals, http.StatusCreated)
+	c.Assert(doPOST("", "Shed").Code, qt.Equals, http.StatusCreated)
+	c.Assert(must.Must(registrySet.AreaRegistry.Count(context.Background())), qt.Equals, areasBefore+3)
+}
+
+func TestIdempotencyMiddleware_ReleasesOnServerError(t *testing.T) {
+	c := qt.New(t)
+
+	var calls int
+	status := http.StatusInternalServerError
+	handler := apiserver.IdempotencyMiddleware(memory.NewIdempotencyKeyRegistry(), time.Hour, 0)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls++
+			w.WriteHeader(status)
+		}),
+	)
+	user := &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{EntityID: models.EntityID{ID: "user-1"}, TenantID: "tenant-1"},
+	}
+
+	do := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/things", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Idempotency-Key", "key-1")
+		req = req.WithContext(appctx.WithUser(req.Context(), user))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	c.Assert(do(), qt.Equals, http.StatusInternalServerError)
+	status = http.StatusCreated
+	c.Assert(do(), qt.Equals, http.StatusCreated)
+	c.Assert(do(), qt.Equals, http.StatusCreated)
+	c.Assert(calls, qt.Equals, 2)
+}
+
+func TestIdempotencyMiddleware_MultipartIgnoresBoundary(t *testing.T) {
+	c := qt.New(t)
+
+	var calls int
+	var lastBody []byte
+	handler := apiserver.IdempotencyMiddleware(memory.NewIdempotencyKeyRegistry(), time.Hour, 1<<20)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			lastBody = must.Must(io.ReadAll(r.Body))
+			w.WriteHeader(http.StatusCreated)
+		}),
+	)
+	user := &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{EntityID: models.EntityID{ID: "user-1"}, TenantID: "tenant-1"},
+	}
+
+	do := func(boundary, content string) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		c.Assert(mw.SetBoundary(boundary), qt.IsNil)
+		part := must.Must(mw.CreateFormFile("file", "receipt.txt"))
+		_, _ = part.Write([]byte(content))
+		c.Assert(mw.Close(), qt.IsNil)
+		payload := buf.Bytes()
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("Idempotency-Key", "upload-1")
+		req = req.WithContext(appctx.WithUser(req.Context(), user))
+		before := calls
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if calls > before {
+			// The handler saw the full, unmodified body.
+			c.Assert(lastBody, qt.DeepEquals, payload)
+		}
+		return rr
+	}
+
+	c.Assert(do("boundary-a", "hello").Code, qt.Equals, http.StatusCreated)
+	replay := do("boundary-b", "hello")
+	c.Assert(replay.Code, qt.Equals, http.StatusCreated)
+	c.Assert(replay.Header().Get("Idempotent-Replayed"), qt.Equals, "true")
+	c.Assert(do("boundary-c", "changed").Code, qt.Equals, http.StatusUnprocessableEntity)
+	c.Assert(calls, qt.Equals, 1)
+}
+
+// TestIdempotencyMiddleware_OversizedBodyBypasses checks that a regular body
+// above the in-memory cap reaches the handler whole and is never stored, so
+// each retry runs for real.
+func TestIdempotencyMiddleware_OversizedBodyBypasses(t *testing.T) {
+	c := qt.New(t)
+
+	payload := bytes.Repeat([]byte("x"), 1<<20+1)
+	var calls int
+	handler := apiserver.IdempotencyMiddleware(memory.NewIdempotencyKeyRegistry(), time.Hour, 0)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			c.Assert(must.Must(io.ReadAll(r.Body)), qt.DeepEquals, payload)
+			w.WriteHeader(http.StatusCreated)
+		}),
+	)
+	user := &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{EntityID: models.EntityID{ID: "user-1"}, TenantID: "tenant-1"},
+	}
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/things", bytes.NewReader(payload))
+		req.Header.Set("Idempotency-Key", "key-1")
+		req = req.WithContext(appctx.WithUser(req.Context(), user))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		c.Assert(rr.Code, qt.Equals, http.StatusCreated)
+		c.Assert(rr.Header().Get("Idempotent-Replayed"), qt.Equals, "")
+	}
+	c.Assert(calls, qt.Equals, 2)
+}
@@ -0,0 +1,207 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+type invoiceExtractionsAPI struct {
+	service *services.InvoiceExtractionService
+	clock   func() time.Time
+}
+
+func newInvoiceExtractionsAPI(params Params) *invoiceExtractionsAPI {
+	// The review endpoints never call the provider; the scan service and
+	// upload location only matter to the background job.
+	return &invoiceExtractionsAPI{
+		service: services.NewInvoiceExtractionService(params.FactorySet, params.CommodityScanService, params.UploadLocation, services.InvoiceExtractionConfig{}),
+		clock:   time.Now,
+	}
+}
+
+// list returns the review queue of the current group, oldest first.
+//
+// @Summary List invoice extractions
+// @Description Invoice extractions of the current group, oldest first. Defaults to the pending review queue; ?status= selects another status, ?status=all returns every row.
+// @Tags invoice_extractions
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param status query string false "Filter by status (pending, applied, rejected, no_changes, failed or all)" default(pending)
+// @Success 200 {object} jsonapi.InvoiceExtractionsResponse "OK"
+// @Failure 422 {object} jsonapi.Errors "Unknown status"
+// @Router /g/{groupSlug}/invoice-extractions [get].
+func (api *invoiceExtractionsAPI) list(w http.ResponseWriter, r *http.Request) {
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	status := models.InvoiceExtractionStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		status = models.InvoiceExtractionStatusPending
+	case "all":
+		status = ""
+	default:
+		if err := status.Validate(); err != nil {
+			unprocessableEntityError(w, r, err)
+			return
+		}
+	}
+
+	extractions, err := api.service.List(r.Context(), status)
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+
+	commoditiesByID := make(map[string]*models.Commodity, len(extractions))
+	for _, e := range extractions {
+		if _, ok := commoditiesByID[e.CommodityID]; ok {
+			continue
+		}
+		c, cerr := regSet.CommodityRegistry.Get(r.Context(), e.CommodityID)
+		if cerr != nil {
+			if errors.Is(cerr, registry.ErrNotFound) {
+				commoditiesByID[e.CommodityID] = nil
+				continue
+			}
+			renderEntityError(w, r, cerr)
+			return
+		}
+		commoditiesByID[e.CommodityID] = c
+	}
+
+	if err := render.Render(w, r, jsonapi.NewInvoiceExtractionsResponse(extractions, commoditiesByID)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// get returns one extraction.
+//
+// @Summary Get an invoice extraction
+// @Description One invoice extraction with its proposed changes and line items.
+// @Tags invoice_extractions
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param extractionID path string true "Invoice extraction ID"
+// @Success 200 {object} jsonapi.InvoiceExtractionResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Extraction not found"
+// @Router /g/{groupSlug}/invoice-extractions/{extractionID} [get].
+func (api *invoiceExtractionsAPI) get(w http.ResponseWriter, r *http.Request) {
+	extraction, err := api.service.Get(r.Context(), chi.URLParam(r, "extractionID"))
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewInvoiceExtractionResponse(extraction)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// approve applies the proposed changes to the commodity.
+//
+// @Summary Approve an invoice extraction
+// @Description Write the proposed changes (all of them, or the listed fields) to the linked commodity and mark the extraction applied.
+// @Tags invoice_extractions
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param extractionID path string true "Invoice extraction ID"
+// @Param payload body jsonapi.InvoiceExtractionApproveRequest false "Optional subset of fields to apply"
+// @Success 200 {object} jsonapi.InvoiceExtractionResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Extraction or commodity not found"
+// @Failure 409 {object} jsonapi.Errors "Extraction is not pending review"
+// @Failure 422 {object} jsonapi.Errors "Unknown field or the updated commodity is invalid"
+// @Router /g/{groupSlug}/invoice-extractions/{extractionID}/approve [post].
+func (api *invoiceExtractionsAPI) approve(w http.ResponseWriter, r *http.Request) {
+	var input jsonapi.InvoiceExtractionApproveRequest
+	// Same optional-body handling as the maintenance done endpoint.
+	hasBody := r.Body != nil && r.Body != http.NoBody &&
+		(r.ContentLength > 0 || len(r.TransferEncoding) > 0)
+	if hasBody {
+		if err := render.Bind(r, &input); err != nil {
+			unprocessableEntityError(w, r, err)
+			return
+		}
+	}
+	var fields []string
+	if input.Data != nil {
+		fields = input.Data.Attributes.Fields
+	}
+
+	updated, err := api.service.Approve(r.Context(), chi.URLParam(r, "extractionID"), fields, api.clock())
+	if err != nil {
+		renderInvoiceExtractionError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewInvoiceExtractionResponse(updated)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// reject dismisses the proposal.
+//
+// @Summary Reject an invoice extraction
+// @Description Dismiss the proposal; the commodity is left untouched.
+// @Tags invoice_extractions
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param extractionID path string true "Invoice extraction ID"
+// @Success 200 {object} jsonapi.InvoiceExtractionResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Extraction not found"
+// @Failure 409 {object} jsonapi.Errors "Extraction is not pending review"
+// @Router /g/{groupSlug}/invoice-extractions/{extractionID}/reject [post].
+func (api *invoiceExtractionsAPI) reject(w http.ResponseWriter, r *http.Request) {
+	updated, err := api.service.Reject(r.Context(), chi.URLParam(r, "extractionID"), api.clock())
+	if err != nil {
+		renderInvoiceExtractionError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewInvoiceExtractionResponse(updated)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// renderInvoiceExtractionError maps a second review to 409, an unknown
+// field or an invalid resulting commodity to 422, and everything else
+// through renderEntityError.
+func renderInvoiceExtractionError(w http.ResponseWriter, r *http.Request, err error) {
+	var verrs validation.Errors
+	switch {
+	case errors.Is(err, services.ErrInvoiceExtractionNotPending):
+		conflictError(w, r, err, err)
+	case errors.Is(err, services.ErrInvoiceExtractionUnknownField), errors.As(err, &verrs):
+		unprocessableEntityError(w, r, err)
+	default:
+		renderEntityError(w, r, err)
+	}
+}
+
+// InvoiceExtractions returns the chi sub-router for the group-scoped
+// invoice extraction review queue.
+func InvoiceExtractions(params Params) func(r chi.Router) {
+	api := newInvoiceExtractionsAPI(params)
+	return func(r chi.Router) {
+		r.Get("/", api.list)
+		r.Route("/{extractionID}", func(r chi.Router) {
+			r.Get("/", api.get)
+			r.Post("/approve", api.approve)
+			r.Post("/reject", api.reject)
+		})
+	}
+}
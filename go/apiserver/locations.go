@@ -1,6 +1,7 @@
 package apiserver
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -8,7 +9,10 @@ import (
 	"github.com/go-chi/render"
 
 	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/changefeed"
 	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
 	"github.com/denisvmedia/inventario/services"
 )
 
@@ -63,6 +67,7 @@ func (api *locationsAPI) listLocations(w http.ResponseWriter, r *http.Request) {
 // @Param groupSlug path string true "Group slug"
 // @Param locationID path string true "Location ID"
 // @Success 200 {object} jsonapi.LocationResponse "OK"
+// @Header 200 {string} ETag "Entity version, for If-Match on update and delete"
 // @Router /g/{groupSlug}/locations/{locationID} [get].
 func (api *locationsAPI) getLocation(w http.ResponseWriter, r *http.Request) { //revive:disable-line:get-return
 	// Get user-aware registry from context
@@ -90,6 +95,7 @@ func (api *locationsAPI) getLocation(w http.ResponseWriter, r *http.Request) { /
 		Areas:    areas,
 	}
 
+	setETag(w, location)
 	if err := render.Render(w, r, jsonapi.NewLocationResponse(respLocation)); err != nil {
 		internalServerError(w, r, err)
 		return
@@ -142,6 +148,7 @@ func (api *locationsAPI) createLocation(w http.ResponseWriter, r *http.Request)
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(ctx).LocationChanged(ctx, changefeed.ActionCreated, createdLocation)
 
 	areas, err := locationReg.GetAreas(ctx, createdLocation.ID)
 	if err != nil {
@@ -154,6 +161,7 @@ func (api *locationsAPI) createLocation(w http.ResponseWriter, r *http.Request)
 		Areas:    areas,
 	}
 
+	setETag(w, createdLocation)
 	resp := jsonapi.NewLocationResponse(respLocation).WithStatusCode(http.StatusCreated)
 	if err := render.Render(w, r, resp); err != nil {
 		internalServerError(w, r, err)
@@ -170,8 +178,10 @@ func (api *locationsAPI) createLocation(w http.ResponseWriter, r *http.Request)
 // @Param groupSlug path string true "Group slug"
 // @Param locationID path string true "Location ID"
 // @Param strategy query string false "Non-empty-location strategy: cascade deletes the commodities, unlink keeps them area-less. Omit to reject a non-empty location." Enums(cascade, unlink)
+// @Param If-Match header string false "ETag from GET; the delete is refused when the location has changed since"
 // @Success 204 "No content"
 // @Failure 404 {object} jsonapi.Errors "Location not found"
+// @Failure 412 {object} jsonapi.LocationResponse "Location changed since the If-Match ETag; body is the current location"
 // @Failure 422 {object} jsonapi.Errors "Non-empty location (no strategy) or unknown strategy"
 // @Router /g/{groupSlug}/locations/{locationID} [delete].
 func (api *locationsAPI) deleteLocation(w http.ResponseWriter, r *http.Request) {
@@ -181,6 +191,12 @@ func (api *locationsAPI) deleteLocation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, location)
+	if !ok {
+		locationPreconditionFailed(w, r, location)
+		return
+	}
+
 	// #2137: a non-empty location is deleted according to the chosen strategy:
 	//   absent  → DeleteLocation (non-recursive; #2119 — empty only, 422 on
 	//             ErrCannotDelete; drops the location's files)
@@ -194,13 +210,50 @@ func (api *locationsAPI) deleteLocation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := deleteFn(r.Context(), location.ID); err != nil {
+	// The row is deleted only while it still carries the If-Match version.
+	ctx := registry.WithExpectedVersion(r.Context(), location.ID, expectedVersion)
+	err = deleteFn(ctx, location.ID)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if registrySet := RegistrySetFromContext(ctx); registrySet != nil {
+			if current, getErr := registrySet.LocationRegistry.Get(ctx, location.ID); getErr == nil {
+				location = current
+			}
+		}
+		locationPreconditionFailed(w, r, location)
+		return
+	}
+	if err != nil {
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(ctx).LocationChanged(ctx, changefeed.ActionDeleted, location)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// locationPreconditionFailed answers 412 with the location's current
+// representation, areas included, as GET would render it.
+func locationPreconditionFailed(w http.ResponseWriter, r *http.Request, location *models.Location) {
+	registrySet := RegistrySetFromContext(r.Context())
+	if registrySet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	areas, err := registrySet.LocationRegistry.GetAreas(r.Context(), location.ID)
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+
+	respLocation := &jsonapi.Location{
+		Location: location,
+		Areas:    areas,
+	}
+	preconditionFailed(w, r, location, jsonapi.NewLocationResponse(respLocation).WithStatusCode(http.StatusPreconditionFailed))
+}
+
 // resolveLocationDeleteStrategy maps a `?strategy=` value to the matching
 // EntityService delete method (#2137). An empty value keeps the historical
 // non-recursive DeleteLocation behaviour; an unrecognised value yields an error
@@ -227,8 +280,10 @@ func resolveLocationDeleteStrategy(svc *services.EntityService, strategy string)
 // @Param groupSlug path string true "Group slug"
 // @Param locationID path string true "Location ID"
 // @Param location body jsonapi.LocationRequest true "Location object"
+// @Param If-Match header string false "ETag from GET; the update is refused when the location has changed since"
 // @Success 200 {object} jsonapi.LocationResponse "OK"
 // @Failure 404 {object} jsonapi.Errors "Location not found"
+// @Failure 412 {object} jsonapi.LocationResponse "Location changed since the If-Match ETag; body is the current location"
 // @Failure 422 {object} jsonapi.Errors "User-side request problem"
 // @Router /g/{groupSlug}/locations/{locationID} [put].
 func (api *locationsAPI) updateLocation(w http.ResponseWriter, r *http.Request) {
@@ -256,21 +311,38 @@ func (api *locationsAPI) updateLocation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	expectedVersion, ok := ifMatchVersion(r, location)
+	if !ok {
+		locationPreconditionFailed(w, r, location)
+		return
+	}
+
 	// Preserve tenant_id and user_id from the existing location
 	// This ensures the foreign key constraints are satisfied during updates
 	updateData := *input.Data.Attributes
 	if updateData.TenantID == "" {
 		updateData.TenantID = location.TenantID
 	}
+	updateData.Version = expectedVersion
 
 	// Use WithCurrentUser to ensure proper user context and validation
 	ctx := r.Context()
 	locationReg := registrySet.LocationRegistry
 	newLocation, err := locationReg.Update(ctx, updateData)
+	if errors.Is(err, registry.ErrVersionConflict) {
+		// Lost the race after the If-Match check: answer with the row the
+		// other writer left behind.
+		if current, getErr := locationReg.Get(ctx, location.ID); getErr == nil {
+			location = current
+		}
+		locationPreconditionFailed(w, r, location)
+		return
+	}
 	if err != nil {
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(ctx).LocationChanged(ctx, changefeed.ActionUpdated, newLocation)
 
 	areas, err := locationReg.GetAreas(r.Context(), location.ID)
 	if err != nil {
@@ -283,6 +355,7 @@ func (api *locationsAPI) updateLocation(w http.ResponseWriter, r *http.Request)
 		Areas:    areas,
 	}
 
+	setETag(w, newLocation)
 	resp := jsonapi.NewLocationResponse(respLocation).WithStatusCode(http.StatusOK)
 	if err := render.Render(w, r, resp); err != nil {
 		internalServerError(w, r, err)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/jellydator/validation"
 
 	"github.com/denisvmedia/inventario/jsonapi"
 	"github.com/denisvmedia/inventario/models"
@@ -128,22 +129,23 @@ func (api *maintenanceSchedulesAPI) createForCommodity(w http.ResponseWriter, r
 	}
 
 	schedule := models.MaintenanceSchedule{
-		CommodityID:  commodityID,
-		Title:        input.Data.Attributes.Title,
-		IntervalDays: input.Data.Attributes.IntervalDays,
-		NextDueAt:    input.Data.Attributes.NextDueAt,
-		LastDoneAt:   input.Data.Attributes.LastDoneAt,
-		Notes:        input.Data.Attributes.Notes,
-		Enabled:      enabled,
+		CommodityID:   commodityID,
+		Title:         input.Data.Attributes.Title,
+		Recurrence:    input.Data.Attributes.Recurrence,
+		IntervalDays:  input.Data.Attributes.IntervalDays,
+		CalendarRule:  input.Data.Attributes.CalendarRule,
+		MeterUnit:     input.Data.Attributes.MeterUnit,
+		MeterInterval: input.Data.Attributes.MeterInterval,
+		NextDueMeter:  input.Data.Attributes.NextDueMeter,
+		NextDueAt:     input.Data.Attributes.NextDueAt,
+		LastDoneAt:    input.Data.Attributes.LastDoneAt,
+		Notes:         input.Data.Attributes.Notes,
+		Enabled:       enabled,
 	}
 
 	created, err := api.service.Create(r.Context(), schedule, api.clock())
 	if err != nil {
-		if errors.Is(err, services.ErrCommodityNotTrackable) {
-			unprocessableEntityError(w, r, err)
-			return
-		}
-		renderEntityError(w, r, err)
+		renderMaintenanceError(w, r, err)
 		return
 	}
 
@@ -155,7 +157,7 @@ func (api *maintenanceSchedulesAPI) createForCommodity(w http.ResponseWriter, r
 // update patches a schedule's mutable fields.
 //
 // @Summary Update a maintenance schedule
-// @Description Patch title / interval / next_due_at / last_done_at / notes / enabled.
+// @Description Patch title / recurrence / interval / calendar rule / meter settings / next_due_at / last_done_at / notes / enabled.
 // @Tags maintenance_schedules
 // @Accept json-api
 // @Produce json-api
@@ -180,15 +182,20 @@ func (api *maintenanceSchedulesAPI) update(w http.ResponseWriter, r *http.Reques
 	}
 
 	updated, err := api.service.Update(r.Context(), schedule.ID, services.MaintenanceScheduleUpdate{
-		Title:        input.Data.Attributes.Title,
-		IntervalDays: input.Data.Attributes.IntervalDays,
-		NextDueAt:    input.Data.Attributes.NextDueAt,
-		LastDoneAt:   input.Data.Attributes.LastDoneAt,
-		Notes:        input.Data.Attributes.Notes,
-		Enabled:      input.Data.Attributes.Enabled,
-	})
+		Title:         input.Data.Attributes.Title,
+		Recurrence:    input.Data.Attributes.Recurrence,
+		IntervalDays:  input.Data.Attributes.IntervalDays,
+		CalendarRule:  input.Data.Attributes.CalendarRule,
+		MeterUnit:     input.Data.Attributes.MeterUnit,
+		MeterInterval: input.Data.Attributes.MeterInterval,
+		NextDueMeter:  input.Data.Attributes.NextDueMeter,
+		NextDueAt:     input.Data.Attributes.NextDueAt,
+		LastDoneAt:    input.Data.Attributes.LastDoneAt,
+		Notes:         input.Data.Attributes.Notes,
+		Enabled:       input.Data.Attributes.Enabled,
+	}, api.clock())
 	if err != nil {
-		renderEntityError(w, r, err)
+		renderMaintenanceError(w, r, err)
 		return
 	}
 
@@ -197,19 +204,21 @@ func (api *maintenanceSchedulesAPI) update(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// markDone advances a schedule's next_due_at by interval_days from the
-// supplied (or default-today) date and records last_done_at.
+// markDone completes a schedule on the supplied (or default-today)
+// date: it appends a maintenance log entry, records last_done_at and
+// advances next_due_at (and next_due_meter for usage schedules).
 //
 // @Summary Mark a maintenance schedule as done
-// @Description Advance next_due_at by interval_days and record last_done_at.
+// @Description Log a completion (notes, cost, meter value, attached files), record last_done_at and advance the schedule per its recurrence.
 // @Tags maintenance_schedules
 // @Accept json-api
 // @Produce json-api
 // @Param groupSlug path string true "Group slug"
 // @Param scheduleID path string true "Maintenance schedule ID"
-// @Param payload body jsonapi.MaintenanceScheduleDoneRequest false "Optional explicit done_at"
+// @Param payload body jsonapi.MaintenanceScheduleDoneRequest false "Optional done_at and completion details"
 // @Success 200 {object} jsonapi.MaintenanceScheduleResponse "OK"
 // @Failure 404 {object} jsonapi.Errors "Schedule not found"
+// @Failure 422 {object} jsonapi.Errors "User-side request problem"
 // @Router /g/{groupSlug}/maintenance/{scheduleID}/done [post].
 func (api *maintenanceSchedulesAPI) markDone(w http.ResponseWriter, r *http.Request) {
 	schedule := maintenanceScheduleFromContext(r.Context())
@@ -232,14 +241,22 @@ func (api *maintenanceSchedulesAPI) markDone(w http.ResponseWriter, r *http.Requ
 			return
 		}
 	}
-	var doneAt models.PDate
+	var completion services.MaintenanceCompletion
 	if input.Data != nil {
-		doneAt = input.Data.Attributes.DoneAt
+		attrs := input.Data.Attributes
+		completion = services.MaintenanceCompletion{
+			DoneAt:       attrs.DoneAt,
+			MeterValue:   attrs.MeterValue,
+			Notes:        attrs.Notes,
+			CostAmount:   attrs.CostAmount,
+			CostCurrency: attrs.CostCurrency,
+			FileIDs:      attrs.FileIDs,
+		}
 	}
 
-	updated, err := api.service.MarkDone(r.Context(), schedule.ID, doneAt, api.clock())
+	updated, _, err := api.service.Complete(r.Context(), schedule.ID, completion, api.clock())
 	if err != nil {
-		renderEntityError(w, r, err)
+		renderMaintenanceError(w, r, err)
 		return
 	}
 
@@ -278,6 +295,73 @@ func (api *maintenanceSchedulesAPI) remove(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// listLogs returns the completion history of a schedule, newest first.
+//
+// @Summary List maintenance log entries
+// @Description Completion history of the schedule, newest first.
+// @Tags maintenance_schedules
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param scheduleID path string true "Maintenance schedule ID"
+// @Success 200 {object} jsonapi.MaintenanceLogsResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Schedule not found"
+// @Router /g/{groupSlug}/maintenance/{scheduleID}/logs [get].
+func (api *maintenanceSchedulesAPI) listLogs(w http.ResponseWriter, r *http.Request) {
+	schedule := maintenanceScheduleFromContext(r.Context())
+	if schedule == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+	logs, err := api.service.ListLogs(r.Context(), schedule.ID)
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewMaintenanceLogsResponse(logs)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// removeLog deletes a single log entry of the schedule. The schedule's
+// due state is left alone — the entry is history, not a driver.
+//
+// @Summary Delete a maintenance log entry
+// @Description Hard-delete one completion entry of the schedule.
+// @Tags maintenance_schedules
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param scheduleID path string true "Maintenance schedule ID"
+// @Param logID path string true "Maintenance log ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} jsonapi.Errors "Schedule or log entry not found"
+// @Router /g/{groupSlug}/maintenance/{scheduleID}/logs/{logID} [delete].
+func (api *maintenanceSchedulesAPI) removeLog(w http.ResponseWriter, r *http.Request) {
+	schedule := maintenanceScheduleFromContext(r.Context())
+	if schedule == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+	if err := api.service.DeleteLog(r.Context(), schedule.ID, chi.URLParam(r, "logID")); err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// renderMaintenanceError maps the maintenance service's user-side
+// failures (non-trackable commodity, model validation) to 422 and
+// everything else through renderEntityError.
+func renderMaintenanceError(w http.ResponseWriter, r *http.Request, err error) {
+	var verrs validation.Errors
+	if errors.Is(err, services.ErrCommodityNotTrackable) || errors.As(err, &verrs) {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	renderEntityError(w, r, err)
+}
+
 // listGroup returns the group-wide upcoming maintenance list, ordered
 // by next_due_at ascending. Supports ?due_before=YYYY-MM-DD and
 // ?enabled_only=true filters.
@@ -350,7 +434,8 @@ func CommodityMaintenance(params Params) func(r chi.Router) {
 }
 
 // GroupMaintenance returns the chi sub-router for the group-wide
-// /maintenance surface — list, plus per-row PATCH / DELETE / done.
+// /maintenance surface — list, plus per-row PATCH / DELETE / done and
+// the completion log.
 func GroupMaintenance(params Params) func(r chi.Router) {
 	api := newMaintenanceSchedulesAPI(params)
 	return func(r chi.Router) {
@@ -360,6 +445,8 @@ func GroupMaintenance(params Params) func(r chi.Router) {
 			r.Patch("/", api.update)
 			r.Delete("/", api.remove)
 			r.Post("/done", api.markDone)
+			r.Get("/logs", api.listLogs)
+			r.Delete("/logs/{logID}", api.removeLog)
 		})
 	}
 }
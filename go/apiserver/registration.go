@@ -26,6 +26,7 @@ type RegistrationAPI struct {
 	auditService         services.AuditLogger
 	rateLimiter          services.AuthRateLimiter
 	groupService         *services.GroupService
+	approvalService      *services.RegistrationApprovalService
 	publicBaseURL        string
 }
 
@@ -42,6 +43,10 @@ type RegistrationParams struct {
 	// deployments that have no groups yet — the invite-token branch simply
 	// becomes unavailable.
 	GroupService *services.GroupService
+	// ApprovalService, when set, queues approval-mode registrations for
+	// the back office and notifies the operators. Leave nil and the
+	// pending user can only be activated by hand.
+	ApprovalService *services.RegistrationApprovalService
 	// PublicBaseURL, when set, is used to build absolute verification links.
 	// Example: https://inventario.example.com
 	PublicBaseURL string
@@ -82,6 +87,7 @@ func Registration(params RegistrationParams) func(r chi.Router) {
 		auditService:         params.AuditService,
 		rateLimiter:          params.RateLimiter,
 		groupService:         params.GroupService,
+		approvalService:      params.ApprovalService,
 		publicBaseURL:        strings.TrimSpace(params.PublicBaseURL),
 	}
 	return func(r chi.Router) {
@@ -111,7 +117,8 @@ func resolveRegistrationMode(ctx context.Context) models.RegistrationMode {
 //     If the invite carries `invitee_email`, the registration `email` must
 //     match it (trim + case-insensitive); mismatch → 400 (#1221).
 //   - closed    → 403 Forbidden; registration is disabled.
-//   - approval  → account created (inactive); admin must activate; no verification email sent.
+//   - approval  → account created (inactive) and queued for the back office,
+//     whose operators are notified; no verification email sent.
 //   - open      → account created (inactive); verification email sent; activates on token click.
 //
 // @Summary Register a new user
@@ -228,8 +235,8 @@ func (api *RegistrationAPI) handleRegister(w http.ResponseWriter, r *http.Reques
 		// Send email verification only in open mode.
 		api.sendVerification(r, created)
 	default:
-		// Approval mode: log that the account is pending admin approval.
-		slog.Info("User registered, pending admin approval", "user_id", created.ID, "email", created.Email)
+		// Approval mode: queue the account for an operator decision.
+		api.submitForApproval(r, created)
 	}
 
 	api.logAuth(r, "register", &created.ID, true, "")
@@ -494,6 +501,21 @@ func (api *RegistrationAPI) sendVerification(r *http.Request, user *models.User)
 	}()
 }
 
+// submitForApproval queues an approval-mode registration. A failure is
+// logged, not returned: the user exists either way and the response must
+// not differ from the duplicate branch (enumeration).
+func (api *RegistrationAPI) submitForApproval(r *http.Request, user *models.User) {
+	if api.approvalService == nil {
+		slog.Info("User registered, pending admin approval", "user_id", user.ID, "email", user.Email)
+		return
+	}
+	if _, err := api.approvalService.Submit(context.WithoutCancel(r.Context()), user); err != nil {
+		slog.Error("Failed to queue registration for approval", "user_id", user.ID, "error", err)
+		return
+	}
+	slog.Info("User registered, queued for admin approval", "user_id", user.ID, "email", user.Email)
+}
+
 func (api *RegistrationAPI) sendWelcome(user *models.User) {
 	if api.emailService == nil || user == nil {
 		return
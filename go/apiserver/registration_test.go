@@ -248,6 +248,34 @@ func TestHandleRegister_ApprovalModeCreatesPendingUserNoEmail(t *testing.T) {
 	assertNoVerificationEmail(t, emailSvc)
 }
 
+func TestHandleRegister_ApprovalModeQueuesRequest(t *testing.T) {
+	c := qt.New(t)
+	userReg := &registrationUserRegistry{mockUserRegistryForAuth: &mockUserRegistryForAuth{users: map[string]*models.User{}}}
+	factorySet := memory.NewFactorySet()
+	r := newRegistrationRouter(apiserver.RegistrationParams{
+		UserRegistry:         userReg,
+		VerificationRegistry: memory.NewEmailVerificationRegistry(),
+		RateLimiter:          services.NewInMemoryAuthRateLimiter(),
+		ApprovalService:      services.NewRegistrationApprovalService(factorySet, nil, ""),
+	}, models.RegistrationModeApproval)
+
+	w := postRegister(c, r, map[string]string{
+		"email":    "pending@example.com",
+		"name":     "Pending",
+		"password": "Password123",
+	})
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+
+	queued, err := factorySet.RegistrationRequestRegistry.List(context.Background(), registry.RegistrationRequestFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(queued, qt.HasLen, 1)
+	c.Assert(queued[0].Email, qt.Equals, "pending@example.com")
+	c.Assert(queued[0].IsPending(), qt.IsTrue)
+	for _, u := range userReg.users {
+		c.Assert(queued[0].UserID, qt.Equals, u.ID)
+	}
+}
+
 // ---- handleVerifyEmail -----------------------------------------------------
 
 func TestHandleVerifyEmail_MissingTokenReturns400(t *testing.T) {
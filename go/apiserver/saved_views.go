@@ -0,0 +1,400 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+const savedViewCtxKey ctxValueKey = "saved_view"
+
+// savedViewStaleReferenceCode is the JSON:API error code answered when a
+// saved view's filters name an area or tag that has since been deleted.
+const savedViewStaleReferenceCode = "saved_view.stale_reference"
+
+// savedViewNotCreatorCode is the JSON:API error code answered when a
+// member tries to change or delete a view they neither created nor
+// administer.
+const savedViewNotCreatorCode = "saved_view.not_creator"
+
+func savedViewFromContext(ctx context.Context) *models.SavedView {
+	view, ok := ctx.Value(savedViewCtxKey).(*models.SavedView)
+	if !ok {
+		return nil
+	}
+	return view
+}
+
+// savedViewCtx loads the view referenced by the {viewID} URL param into
+// the request context. Another member's private view answers 404, the
+// same as a view in another group.
+func savedViewCtx() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			regSet := RegistrySetFromContext(r.Context())
+			if regSet == nil {
+				http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+				return
+			}
+			view, err := regSet.SavedViewRegistry.Get(r.Context(), chi.URLParam(r, "viewID"))
+			if err != nil {
+				renderEntityError(w, r, err)
+				return
+			}
+			ctx := context.WithValue(r.Context(), savedViewCtxKey, view)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+type savedViewsAPI struct {
+	groupService *services.GroupService
+}
+
+// canModify reports whether the caller may change or delete view: its
+// creator always may, otherwise only an unrestricted group admin or
+// owner. A shared view is visible to every member, but that does not
+// make it theirs to rewrite.
+func (api *savedViewsAPI) canModify(r *http.Request, view *models.SavedView) (bool, error) {
+	user := GetUserFromRequest(r)
+	if user == nil {
+		return false, nil
+	}
+	if view.CreatedByUserID == user.ID {
+		return true, nil
+	}
+	if appctx.LocationScopeFromContext(r.Context()).Restricted() {
+		return false, nil
+	}
+	group := groupFromContext(r.Context())
+	if group == nil {
+		return false, nil
+	}
+	ok, _, err := api.groupService.HasRoleAtLeast(r.Context(), group.ID, user.ID, models.GroupRoleAdmin)
+	return ok, err
+}
+
+// requireModify answers 403 (or 500 on a role lookup failure) and
+// returns false when the caller may not change view.
+func (api *savedViewsAPI) requireModify(w http.ResponseWriter, r *http.Request, view *models.SavedView) bool {
+	ok, err := api.canModify(r, view)
+	if err != nil {
+		internalServerError(w, r, err)
+		return false
+	}
+	if !ok {
+		_ = codedForbiddenError(w, r, errSavedViewNotCreator, savedViewNotCreatorCode)
+		return false
+	}
+	return true
+}
+
+// listSavedViews returns the group's shared views plus the caller's own
+// private ones, ordered by name.
+//
+// @Summary List saved views
+// @Description Saved commodity list views visible to the caller: every group-scoped view plus the caller's private ones.
+// @Tags saved_views
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Success 200 {object} jsonapi.SavedViewsResponse "OK"
+// @Router /g/{groupSlug}/saved-views [get].
+func (*savedViewsAPI) listSavedViews(w http.ResponseWriter, r *http.Request) {
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+	views, err := regSet.SavedViewRegistry.List(r.Context())
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewSavedViewsResponse(views)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// getSavedView returns a single saved view.
+//
+// @Summary Get a saved view
+// @Description Get a saved commodity list view by ID.
+// @Tags saved_views
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param viewID path string true "Saved view ID"
+// @Success 200 {object} jsonapi.SavedViewResponse "OK"
+// @Failure 404 {object} jsonapi.Errors "Saved view not found"
+// @Router /g/{groupSlug}/saved-views/{viewID} [get].
+func (*savedViewsAPI) getSavedView(w http.ResponseWriter, r *http.Request) {
+	view := savedViewFromContext(r.Context())
+	if view == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+	if err := render.Render(w, r, jsonapi.NewSavedViewResponse(view)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// createSavedView saves a new view. Areas and tags named by the filters
+// must exist in the group.
+//
+// @Summary Create a saved view
+// @Description Save a named set of commodity list filters and columns, private to the caller or shared with the group.
+// @Tags saved_views
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param view body jsonapi.SavedViewRequest true "Saved view attributes"
+// @Success 201 {object} jsonapi.SavedViewResponse "Saved view created"
+// @Failure 422 {object} jsonapi.Errors "Invalid view or stale area/tag reference (code saved_view.stale_reference)"
+// @Router /g/{groupSlug}/saved-views [post].
+func (*savedViewsAPI) createSavedView(w http.ResponseWriter, r *http.Request) {
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var input jsonapi.SavedViewRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	attrs := input.Data.Attributes
+	if err := validateSavedViewReferences(r.Context(), regSet, attrs.Filters); err != nil {
+		renderSavedViewError(w, r, err)
+		return
+	}
+
+	created, err := regSet.SavedViewRegistry.Create(r.Context(), models.SavedView{
+		Name:    attrs.Name,
+		Scope:   attrs.Scope,
+		Filters: attrs.Filters,
+		Columns: attrs.Columns,
+	})
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, jsonapi.NewSavedViewResponse(created).WithStatusCode(http.StatusCreated)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// updateSavedView replaces a view's name, scope, filters and columns.
+// Only the view's creator or a group admin may update it.
+//
+// @Summary Update a saved view
+// @Description Replace a saved view's name, scope, filters and columns.
+// @Tags saved_views
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param viewID path string true "Saved view ID"
+// @Param view body jsonapi.SavedViewRequest true "Saved view attributes"
+// @Success 200 {object} jsonapi.SavedViewResponse "OK"
+// @Failure 403 {object} jsonapi.Errors "Not the view's creator or a group admin (code saved_view.not_creator)"
+// @Failure 404 {object} jsonapi.Errors "Saved view not found"
+// @Failure 422 {object} jsonapi.Errors "Invalid view or stale area/tag reference (code saved_view.stale_reference)"
+// @Router /g/{groupSlug}/saved-views/{viewID} [put].
+func (api *savedViewsAPI) updateSavedView(w http.ResponseWriter, r *http.Request) {
+	view := savedViewFromContext(r.Context())
+	if view == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+	if !api.requireModify(w, r, view) {
+		return
+	}
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var input jsonapi.SavedViewRequest
+	if err := render.Bind(r, &input); err != nil {
+		unprocessableEntityError(w, r, err)
+		return
+	}
+	attrs := input.Data.Attributes
+	if err := validateSavedViewReferences(r.Context(), regSet, attrs.Filters); err != nil {
+		renderSavedViewError(w, r, err)
+		return
+	}
+
+	updated := *view
+	updated.Name = attrs.Name
+	updated.Scope = attrs.Scope
+	updated.Filters = attrs.Filters
+	updated.Columns = attrs.Columns
+	saved, err := regSet.SavedViewRegistry.Update(r.Context(), updated)
+	if err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, jsonapi.NewSavedViewResponse(saved)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
+
+// deleteSavedView removes a saved view. Only the view's creator or a
+// group admin may delete it.
+//
+// @Summary Delete a saved view
+// @Description Delete a saved commodity list view.
+// @Tags saved_views
+// @Accept json-api
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param viewID path string true "Saved view ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} jsonapi.Errors "Not the view's creator or a group admin (code saved_view.not_creator)"
+// @Failure 404 {object} jsonapi.Errors "Saved view not found"
+// @Router /g/{groupSlug}/saved-views/{viewID} [delete].
+func (api *savedViewsAPI) deleteSavedView(w http.ResponseWriter, r *http.Request) {
+	view := savedViewFromContext(r.Context())
+	if view == nil {
+		unprocessableEntityError(w, r, nil)
+		return
+	}
+	if !api.requireModify(w, r, view) {
+		return
+	}
+	regSet := RegistrySetFromContext(r.Context())
+	if regSet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+	if err := regSet.SavedViewRegistry.Delete(r.Context(), view.ID); err != nil {
+		renderEntityError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateSavedViewReferences checks that the area and tags named by the
+// filters still exist in the current group. Returns
+// errSavedViewStaleReference for a dangling reference.
+func validateSavedViewReferences(ctx context.Context, regSet *registry.Set, filters models.SavedViewFilters) error {
+	if filters.AreaID != "" {
+		if _, err := regSet.AreaRegistry.Get(ctx, filters.AreaID); err != nil {
+			if errors.Is(err, registry.ErrNotFound) {
+				return errxtrace.Wrap("area not found", errSavedViewStaleReference, errx.Attrs("area_id", filters.AreaID))
+			}
+			return errxtrace.Wrap("failed to get area", err)
+		}
+	}
+	for _, slug := range filters.Tags {
+		if _, err := regSet.TagRegistry.GetBySlug(ctx, models.TagKindCommodity, slug); err != nil {
+			if errors.Is(err, registry.ErrNotFound) {
+				return errxtrace.Wrap("tag not found", errSavedViewStaleReference, errx.Attrs("tag", slug))
+			}
+			return errxtrace.Wrap("failed to get tag", err)
+		}
+	}
+	return nil
+}
+
+// applySavedView resolves the `view_id` query parameter, if present, into
+// the commodity list query it stands for. The view's filters are laid
+// down first and any parameter the request sets explicitly replaces the
+// view's value, so a client can open a view and still narrow or re-sort
+// it. The view's references are re-validated on every use because areas
+// and tags can be deleted after the view is saved.
+func applySavedView(ctx context.Context, regSet *registry.Set, q url.Values) (url.Values, error) {
+	viewID := q.Get("view_id")
+	if viewID == "" {
+		return q, nil
+	}
+	view, err := regSet.SavedViewRegistry.Get(ctx, viewID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get saved view", err, errx.Attrs("view_id", viewID))
+	}
+	if err := validateSavedViewReferences(ctx, regSet, view.Filters); err != nil {
+		return nil, err
+	}
+
+	merged := view.Filters.QueryValues()
+	for key, values := range q {
+		if key == "view_id" {
+			continue
+		}
+		merged[key] = values
+	}
+	return merged, nil
+}
+
+// savedViewExportLimit caps how many matched commodities are handed to a
+// selected_items export. One over the export's own 1000-item limit, so an
+// oversized view fails validation instead of being silently truncated.
+const savedViewExportLimit = 1001
+
+// savedViewExportItems resolves the view named by `view_id` into the
+// selected_items list of an export. Names and areas are filled in later
+// by the export service.
+func savedViewExportItems(ctx context.Context, regSet *registry.Set, q url.Values) ([]models.ExportSelectedItem, error) {
+	merged, err := applySavedView(ctx, regSet, q)
+	if err != nil {
+		return nil, err
+	}
+	opts := parseCommodityListOptions(merged)
+	if err := resolveOpenLoanFilter(ctx, regSet, &opts); err != nil {
+		return nil, err
+	}
+	commodities, _, err := regSet.CommodityRegistry.ListPaginated(ctx, 0, savedViewExportLimit, opts)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list saved view commodities", err)
+	}
+	items := make([]models.ExportSelectedItem, 0, len(commodities))
+	for _, c := range commodities {
+		items = append(items, models.ExportSelectedItem{
+			ID:   c.ID,
+			Type: models.ExportSelectedItemTypeCommodity,
+		})
+	}
+	return items, nil
+}
+
+// renderSavedViewError answers a stale area/tag reference with a coded
+// 422 and everything else through renderEntityError.
+func renderSavedViewError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errSavedViewStaleReference) {
+		_ = codedUnprocessableEntityError(w, r, err, savedViewStaleReferenceCode)
+		return
+	}
+	renderEntityError(w, r, err)
+}
+
+// SavedViews returns the chi sub-router for /saved-views.
+func SavedViews(groupService *services.GroupService) func(r chi.Router) {
+	api := &savedViewsAPI{groupService: groupService}
+	return func(r chi.Router) {
+		r.Get("/", api.listSavedViews)
+		r.Post("/", api.createSavedView)
+		r.Route("/{viewID}", func(r chi.Router) {
+			r.Use(savedViewCtx())
+			r.Get("/", api.getSavedView)
+			r.Put("/", api.updateSavedView)
+			r.Delete("/", api.deleteSavedView)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/internal/checkers"
+	"github.com/denisvmedia/inventario/models"
+)
+
+func serveSavedViews(params apiserver.Params, userID, method, url, body string) *httptest.ResponseRecorder {
+	req := must.Must(http.NewRequest(method, url, bytes.NewBufferString(body)))
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	addTestUserAuthHeader(req, userID)
+	rr := httptest.NewRecorder()
+	apiserver.APIServer(params, &mockRestoreWorker{}).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestSavedViewsAPI_ApplyToListStatsAndExport(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	base := "/api/v1/g/" + testGroup.Slug
+
+	rr := serveSavedViews(params, testUser.ID, http.MethodPost, base+"/saved-views",
+		`{"data":{"type":"saved_views","attributes":{"name":"Electronics","scope":"group","filters":{"types":["electronics"]},"columns":["name","current_price"]}}}`)
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.type"), "saved_views")
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.scope"), "group")
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &created), qt.IsNil)
+	viewID := created.Data.ID
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodGet, base+"/commodities?view_id="+viewID, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.commodities"), float64(1))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data[0].attributes.name"), "Commodity 2")
+
+	// Explicit query parameters override the view's.
+	rr = serveSavedViews(params, testUser.ID, http.MethodGet, base+"/commodities?view_id="+viewID+"&type=furniture", "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data[0].attributes.name"), "Commodity 1")
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodGet, base+"/stats?view_id="+viewID, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.total_count"), float64(1))
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodPost, base+"/exports?view_id="+viewID,
+		`{"data":{"type":"exports","attributes":{"type":"full_database","description":"Electronics"}}}`)
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.type"), "selected_items")
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.selected_items[0].name"), "Commodity 2")
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodPatch, base+"/settings/appearance.default_items_view", `"`+viewID+`"`)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	rr = serveSavedViews(params, testUser.ID, http.MethodPatch, base+"/settings/appearance.default_items_view", `"no-such-view"`)
+	c.Assert(rr.Code, qt.Equals, http.StatusBadRequest, qt.Commentf("body=%s", rr.Body.String()))
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodDelete, base+"/saved-views/"+viewID, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusNoContent, qt.Commentf("body=%s", rr.Body.String()))
+	rr = serveSavedViews(params, testUser.ID, http.MethodGet, base+"/commodities?view_id="+viewID, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusNotFound, qt.Commentf("body=%s", rr.Body.String()))
+}
+
+func TestSavedViewsAPI_StaleReferences(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	base := "/api/v1/g/" + testGroup.Slug
+
+	for _, filters := range []string{`{"area_id":"no-such-area"}`, `{"tags":["no-such-tag"]}`} {
+		rr := serveSavedViews(params, testUser.ID, http.MethodPost, base+"/saved-views",
+			`{"data":{"type":"saved_views","attributes":{"name":"Stale","filters":`+filters+`}}}`)
+		c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("filters=%s body=%s", filters, rr.Body.String()))
+		c.Check(rr.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "saved_view.stale_reference")
+	}
+
+	for _, attrs := range []string{`{"name":""}`, `{"name":"Bad","filters":{"warranty_statuses":["bogus"]}}`} {
+		rr := serveSavedViews(params, testUser.ID, http.MethodPost, base+"/saved-views",
+			`{"data":{"type":"saved_views","attributes":`+attrs+`}}`)
+		c.Assert(rr.Code, qt.Equals, http.StatusUnprocessableEntity, qt.Commentf("attrs=%s body=%s", attrs, rr.Body.String()))
+	}
+
+	rr := serveSavedViews(params, testUser.ID, http.MethodGet, base+"/saved-views", "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.meta.views"), float64(0))
+}
+
+func TestSavedViewsAPI_OnlyCreatorOrAdminModifies(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+	base := "/api/v1/g/" + testGroup.Slug
+	member := createTestUserDirect(c, params, testUser.TenantID, "member@example.com", true, false)
+	addMembershipRow(c, params, testUser.TenantID, testGroup.ID, member.ID, models.GroupRoleUser)
+	admin := createTestUserDirect(c, params, testUser.TenantID, "admin@example.com", true, false)
+	addMembershipRow(c, params, testUser.TenantID, testGroup.ID, admin.ID, models.GroupRoleAdmin)
+
+	rr := serveSavedViews(params, testUser.ID, http.MethodPost, base+"/saved-views",
+		`{"data":{"type":"saved_views","attributes":{"name":"Shared","scope":"group","filters":{}}}}`)
+	c.Assert(rr.Code, qt.Equals, http.StatusCreated, qt.Commentf("body=%s", rr.Body.String()))
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &created), qt.IsNil)
+	viewURL := base + "/saved-views/" + created.Data.ID
+	rename := `{"data":{"type":"saved_views","attributes":{"name":"Renamed","scope":"group","filters":{}}}}`
+
+	// A plain member sees the shared view but may not change it.
+	rr = serveSavedViews(params, member.ID, http.MethodGet, viewURL, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	rr = serveSavedViews(params, member.ID, http.MethodPut, viewURL, rename)
+	c.Assert(rr.Code, qt.Equals, http.StatusForbidden, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "saved_view.not_creator")
+	rr = serveSavedViews(params, member.ID, http.MethodDelete, viewURL, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusForbidden, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.errors[0].code"), "saved_view.not_creator")
+
+	rr = serveSavedViews(params, testUser.ID, http.MethodGet, viewURL, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK)
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.name"), "Shared")
+
+	// A group admin may.
+	rr = serveSavedViews(params, admin.ID, http.MethodPut, viewURL, rename)
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.name"), "Renamed")
+	rr = serveSavedViews(params, admin.ID, http.MethodDelete, viewURL, "")
+	c.Assert(rr.Code, qt.Equals, http.StatusNoContent, qt.Commentf("body=%s", rr.Body.String()))
+}
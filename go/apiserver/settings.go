@@ -15,6 +15,7 @@ import (
 var (
 	errRegistrySetNotFound       = errors.New("registry set not found in context")
 	errPatchSettingValueRequired = errors.New("patch setting value is required")
+	errInvalidDefaultItemsView   = errors.New("default items view must be grid, list or the ID of a saved view")
 )
 
 type settingsAPI struct {
@@ -170,7 +171,7 @@ func (api *settingsAPI) updateSettings(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param groupSlug path string true "Group slug"
-// @Param   field path string true "Setting field path (e.g., uiconfig.theme)"
+// @Param   field path string true "Setting field path (e.g., uiconfig.theme). appearance.default_items_view takes grid, list or a saved view ID"
 // @Param   value body PatchSettingRequest true "Setting value envelope with required value."
 // @Success 200 {object} models.SettingsObject "OK"
 // @Failure 400 {string} string "Bad Request"
@@ -205,6 +206,17 @@ func (api *settingsAPI) patchSetting(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if field == string(models.SettingNameAppearanceDefaultItemsView) {
+		if err := validateDefaultItemsView(r.Context(), registrySet, value); err != nil {
+			if errors.Is(err, errInvalidDefaultItemsView) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Patch the setting. Registry returns ErrInvalidSettingName for unknown
 	// fields — surface those as 400, not 500.
 	if err := settingsRegistry.Patch(r.Context(), field, value); err != nil {
@@ -233,6 +245,26 @@ func (api *settingsAPI) patchSetting(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// validateDefaultItemsView accepts the built-in grid / list modes or the
+// ID of a saved view the caller can see in the current group.
+func validateDefaultItemsView(ctx context.Context, registrySet *registry.Set, value any) error {
+	view, ok := value.(string)
+	if !ok {
+		return errInvalidDefaultItemsView
+	}
+	switch view {
+	case "grid", "list":
+		return nil
+	}
+	if _, err := registrySet.SavedViewRegistry.Get(ctx, view); err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return errInvalidDefaultItemsView
+		}
+		return err
+	}
+	return nil
+}
+
 func decodePatchSettingValue(rawValue json.RawMessage) (any, error) {
 	var envelope map[string]json.RawMessage
 	if err := json.Unmarshal(rawValue, &envelope); err == nil && hasPatchSettingEnvelopeShape(envelope) {
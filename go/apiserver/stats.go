@@ -0,0 +1,103 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/valuation"
+	"github.com/denisvmedia/inventario/jsonapi"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// Stats mounts GET /g/{groupSlug}/stats. The route is group-scoped via
+// the parent middleware chain — RegistrySet on the context is already
+// filtered to the active group.
+func Stats() func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", handleStats)
+	}
+}
+
+// handleStats returns the dashboard aggregates for the group.
+// @Summary Get commodity statistics for a group
+// @Description Returns commodity counts and values broken down by status, type,
+// @Description location, area, tag, purchase year and warranty status. Drafts are
+// @Description excluded; only in-use commodities carry value, in the group currency,
+// @Description so total_value matches GET /commodities/values.
+// @Tags commodities
+// @Produce json-api
+// @Param groupSlug path string true "Group slug"
+// @Param view_id query string false "Report on the commodities matched by a saved view"
+// @Success 200 {object} jsonapi.StatsResponse "OK"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {object} jsonapi.Errors "Saved view not found"
+// @Failure 422 {object} jsonapi.Errors "Saved view references a deleted area or tag (code saved_view.stale_reference)"
+// @Failure 500 {object} jsonapi.Errors "Internal Server Error"
+// @Router /g/{groupSlug}/stats [get].
+func handleStats(w http.ResponseWriter, r *http.Request) {
+	registrySet := RegistrySetFromContext(r.Context())
+	if registrySet == nil {
+		http.Error(w, "Registry set not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	// Same currency resolution as the values endpoint, so the two can't
+	// disagree on what a commodity is worth.
+	currency, err := valuation.NewValuator(r.Context(), registrySet).GetGroupCurrency()
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+
+	statsOpts := registry.CommodityStatsOptions{GroupCurrency: currency}
+	if r.URL.Query().Get("view_id") != "" {
+		q, err := applySavedView(r.Context(), registrySet, r.URL.Query())
+		if err != nil {
+			renderSavedViewError(w, r, err)
+			return
+		}
+		filter := parseCommodityListOptions(q)
+		if err := resolveOpenLoanFilter(r.Context(), registrySet, &filter); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		statsOpts.Filter = &filter
+	}
+
+	stats, err := registrySet.CommodityRegistry.AggregateStats(r.Context(), statsOpts)
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+
+	locations, err := registrySet.LocationRegistry.List(r.Context())
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	areas, err := registrySet.AreaRegistry.List(r.Context())
+	if err != nil {
+		internalServerError(w, r, err)
+		return
+	}
+	// Location and area ids are UUIDs, so one lookup serves both breakdowns.
+	names := make(map[string]string, len(locations)+len(areas))
+	for _, l := range locations {
+		names[l.ID] = l.Name
+	}
+	for _, a := range areas {
+		names[a.ID] = a.Name
+	}
+
+	groupID := ""
+	if group := appctx.GroupFromContext(r.Context()); group != nil {
+		groupID = group.ID
+	}
+
+	if err := render.Render(w, r, jsonapi.NewStatsResponse(groupID, currency, stats, names)); err != nil {
+		internalServerError(w, r, err)
+	}
+}
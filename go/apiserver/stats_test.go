@@ -0,0 +1,74 @@
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/internal/checkers"
+)
+
+func TestStatsAPI_GetStats(t *testing.T) {
+	c := qt.New(t)
+
+	params, testUser, testGroup := newParams()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := must.Must(http.NewRequest(http.MethodGet, "/api/v1/g/"+testGroup.Slug+path, nil))
+		addTestUserAuthHeader(req, testUser.ID)
+		rr := httptest.NewRecorder()
+		apiserver.APIServer(params, &mockRestoreWorker{}).ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/stats")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	body := rr.Body.String()
+	c.Check(body, checkers.JSONPathEquals("$.data.type"), "stats")
+	c.Check(body, checkers.JSONPathEquals("$.data.id"), testGroup.ID)
+	c.Check(body, checkers.JSONPathEquals("$.data.attributes.currency"), string(testGroup.GroupCurrency))
+
+	var stats struct {
+		Data struct {
+			Attributes struct {
+				TotalCount int    `json:"total_count"`
+				TotalValue string `json:"total_value"`
+				ByStatus   []struct {
+					Key   string `json:"key"`
+					Count int    `json:"count"`
+				} `json:"by_status"`
+				ByArea []struct {
+					Key  string `json:"key"`
+					Name string `json:"name"`
+				} `json:"by_area"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	c.Assert(json.Unmarshal(rr.Body.Bytes(), &stats), qt.IsNil)
+	attrs := stats.Data.Attributes
+
+	// The status breakdown partitions the non-draft commodities.
+	sum := 0
+	for _, b := range attrs.ByStatus {
+		sum += b.Count
+	}
+	c.Assert(attrs.TotalCount > 0, qt.IsTrue)
+	c.Assert(sum, qt.Equals, attrs.TotalCount)
+
+	// Assigned areas carry their display name.
+	for _, b := range attrs.ByArea {
+		if b.Key != "" {
+			c.Check(b.Name, qt.Not(qt.Equals), "", qt.Commentf("area %s", b.Key))
+		}
+	}
+
+	// Values follow valuation.Valuator, so the total matches /commodities/values.
+	rr = get("/commodities/values")
+	c.Assert(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("body=%s", rr.Body.String()))
+	c.Check(rr.Body.String(), checkers.JSONPathEquals("$.data.attributes.global_total"), attrs.TotalValue)
+}
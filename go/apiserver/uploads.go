@@ -19,6 +19,7 @@ import (
 
 	"github.com/denisvmedia/inventario/apiserver/middleware"
 	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/changefeed"
 	"github.com/denisvmedia/inventario/internal/blobkeys"
 	"github.com/denisvmedia/inventario/internal/filekit"
 	"github.com/denisvmedia/inventario/internal/mimekit"
@@ -171,6 +172,7 @@ func (api *uploadsAPI) handleFileUpload(w http.ResponseWriter, r *http.Request)
 		renderEntityError(w, r, err)
 		return
 	}
+	services.ChangeFeedFromContext(r.Context()).FileChanged(r.Context(), changefeed.ActionCreated, createdFile)
 
 	// Generate thumbnail inline for image files
 	api.generateThumbnailInline(r.Context(), createdFile, user.ID)
@@ -33,3 +33,21 @@ func GroupIDFromContext(ctx context.Context) string {
 	}
 	return group.ID
 }
+
+const (
+	locationScopeCtxKey contextKey = "location_scope"
+)
+
+// WithLocationScope adds the current member's location scope to the
+// context. Registries and the Postgres RLS setup read it to narrow a
+// restricted member to their scoped locations.
+func WithLocationScope(ctx context.Context, scope models.LocationScope) context.Context {
+	return context.WithValue(ctx, locationScopeCtxKey, scope)
+}
+
+// LocationScopeFromContext extracts the location scope from the context.
+// Returns nil (unrestricted) if none is present.
+func LocationScopeFromContext(ctx context.Context) models.LocationScope {
+	scope, _ := ctx.Value(locationScopeCtxKey).(models.LocationScope)
+	return scope
+}
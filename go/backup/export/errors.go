@@ -6,4 +6,8 @@ import (
 
 var (
 	ErrUnsupportedExportType = errx.NewSentinel("unsupported export type")
+
+	// errScheduleOwnerUnavailable marks a backup schedule whose owner or
+	// group was deleted or deactivated; the scheduler disables it.
+	errScheduleOwnerUnavailable = errx.NewSentinel("the schedule was disabled; re-create it to resume backups")
 )
@@ -82,6 +82,7 @@ func (s *ExportService) generateExport(ctx context.Context, export models.Export
 
 	// 2. Sign the streaming digest (never the buffered payload).
 	sig := s.signer.SignDigest(digest)
+	stats.KeyFingerprint = s.signer.Fingerprint()
 
 	// 3. Rewind the temp file and stream it into the container in blob storage.
 	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
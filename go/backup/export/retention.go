@@ -0,0 +1,62 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// SelectExportsToPrune applies grandfather-father-son retention to the
+// completed exports of one backup schedule and returns the ones that fall
+// outside it. Walking newest first, the newest export of each of the last
+// keepDaily distinct days, keepWeekly ISO weeks and keepMonthly months is
+// kept; an export kept by any tier survives. Periods are UTC. Exports
+// without a created date are never pruned.
+func SelectExportsToPrune(exports []*models.Export, keepDaily, keepWeekly, keepMonthly int) []*models.Export {
+	dated := make([]*models.Export, 0, len(exports))
+	for _, e := range exports {
+		if e.CreatedDate != nil {
+			dated = append(dated, e)
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].CreatedDate.ToTime().After(dated[j].CreatedDate.ToTime())
+	})
+
+	kept := make(map[string]bool, len(dated))
+	tiers := []struct {
+		keep   int
+		period func(time.Time) string
+	}{
+		{keepDaily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{keepWeekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{keepMonthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	for _, tier := range tiers {
+		seen := make(map[string]bool, tier.keep)
+		for _, e := range dated {
+			if len(seen) >= tier.keep {
+				break
+			}
+			key := tier.period(e.CreatedDate.ToTime().UTC())
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			kept[e.ID] = true
+		}
+	}
+
+	var prune []*models.Export
+	for _, e := range dated {
+		if !kept[e.ID] {
+			prune = append(prune, e)
+		}
+	}
+	return prune
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+func TestSelectExportsToPrune(t *testing.T) {
+	at := func(id string, ts time.Time) *models.Export {
+		return &models.Export{
+			TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{EntityID: models.EntityID{ID: id}},
+			CreatedDate:              models.NewPTimestamp(ts),
+		}
+	}
+	ids := func(exports []*models.Export) []string {
+		out := make([]string, 0, len(exports))
+		for _, e := range exports {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+	// 2026-05-13 is a Wednesday; daily backups at 03:00 for three weeks,
+	// plus a second backup on the newest day.
+	newest := time.Date(2026, 5, 13, 3, 0, 0, 0, time.UTC)
+	var exports []*models.Export
+	for i := range 21 {
+		day := newest.AddDate(0, 0, -i)
+		exports = append(exports, at(day.Format("01-02"), day))
+	}
+	exports = append(exports, at("05-13-late", newest.Add(6*time.Hour)))
+
+	cases := []struct {
+		name                   string
+		daily, weekly, monthly int
+		wantKept               []string
+	}{
+		{
+			name:     "daily only keeps the newest of each day",
+			daily:    3,
+			wantKept: []string{"05-13-late", "05-12", "05-11"},
+		},
+		{
+			name:     "weekly keeps the newest of each ISO week",
+			weekly:   3,
+			wantKept: []string{"05-13-late", "05-10", "05-03"},
+		},
+		{
+			name:     "monthly reaches into the previous month",
+			monthly:  2,
+			wantKept: []string{"05-13-late", "04-30"},
+		},
+		{
+			name:     "tiers overlap instead of adding up",
+			daily:    2,
+			weekly:   2,
+			monthly:  1,
+			wantKept: []string{"05-13-late", "05-12", "05-10"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := qt.New(t)
+			pruned := SelectExportsToPrune(exports, tc.daily, tc.weekly, tc.monthly)
+			c.Assert(len(pruned), qt.Equals, len(exports)-len(tc.wantKept))
+			prunedIDs := ids(pruned)
+			for _, id := range tc.wantKept {
+				c.Assert(prunedIDs, qt.Not(qt.Contains), id)
+			}
+		})
+	}
+}
+
+func TestSelectExportsToPrune_IgnoresUndatedExports(t *testing.T) {
+	c := qt.New(t)
+	undated := &models.Export{TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{EntityID: models.EntityID{ID: "undated"}}}
+	c.Assert(SelectExportsToPrune([]*models.Export{undated}, 0, 0, 0), qt.HasLen, 0)
+}
@@ -0,0 +1,485 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services/notifications"
+)
+
+const defaultBackupSchedulerInterval = 5 * time.Minute
+
+// Prometheus counters for the backup scheduler. `_runs_total` counts
+// exports enqueued; `_failures_total` counts runs that failed either at
+// enqueue time or later in the export worker; `_pruned_total` counts
+// exports removed by retention.
+var (
+	backupScheduleRunsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inventario_backup_schedule_runs_total",
+		Help: "Number of scheduled backup exports enqueued.",
+	})
+	backupScheduleFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inventario_backup_schedule_failures_total",
+		Help: "Number of scheduled backups that failed to enqueue or to complete.",
+	})
+	backupSchedulePrunedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inventario_backup_schedule_pruned_total",
+		Help: "Number of scheduled backup exports deleted by retention.",
+	})
+)
+
+// ExportDeleter removes an export together with its stored file. Satisfied
+// by *services.EntityService; declared locally for the same reason as
+// PauseChecker.
+type ExportDeleter interface {
+	DeleteExportWithFile(ctx context.Context, id string) error
+}
+
+// BackupFailureNotifier tells a schedule owner that a scheduled backup did
+// not complete. Satisfied by services.EmailService.
+type BackupFailureNotifier interface {
+	SendBackupFailureEmail(ctx context.Context, to, name, groupName, reason, exportsURL string) error
+}
+
+// BackupSchedulerStats summarises one RunOnce sweep.
+type BackupSchedulerStats struct {
+	Enqueued int
+	Failed   int
+	Pruned   int
+}
+
+// BackupScheduler runs group backup schedules. Each sweep lists the
+// schedules that are due or whose last export has not been settled yet,
+// and for every one of them:
+//
+//  1. settles the previous run once the export worker has finished it —
+//     a failed export emails the schedule owner, a completed one applies
+//     the schedule's retention to the exports it produced;
+//  2. when due, enqueues a new full-database export as the schedule owner
+//     and advances next_run_at. A slot whose previous export is still in
+//     flight is skipped rather than stacking a second export behind it.
+//
+// A schedule whose owner or group has been deleted or deactivated is
+// disabled instead, and the group's admins are emailed.
+//
+// Manual exports are never considered by retention: only rows carrying
+// the schedule's backup_schedule_id are pruned.
+type BackupScheduler struct {
+	factorySet        *registry.FactorySet
+	deleter           ExportDeleter
+	notifier          BackupFailureNotifier
+	exportsURLBuilder func(groupSlug string) string
+	prefs             *notifications.Service
+	interval          time.Duration
+	clock             func() time.Time
+	pause             PauseChecker
+	stopCh            chan struct{}
+	stopOnce          sync.Once
+	wg                sync.WaitGroup
+}
+
+// BackupSchedulerOption customises a BackupScheduler.
+type BackupSchedulerOption func(*backupSchedulerOptions)
+
+type backupSchedulerOptions struct {
+	interval          time.Duration
+	clock             func() time.Time
+	pause             PauseChecker
+	notifier          BackupFailureNotifier
+	exportsURLBuilder func(groupSlug string) string
+	prefs             *notifications.Service
+}
+
+// WithBackupSchedulerInterval overrides the default tick cadence. Non-
+// positive values are ignored.
+func WithBackupSchedulerInterval(d time.Duration) BackupSchedulerOption {
+	return func(o *backupSchedulerOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+// WithBackupSchedulerClock overrides the now-source used for due checks.
+func WithBackupSchedulerClock(now func() time.Time) BackupSchedulerOption {
+	return func(o *backupSchedulerOptions) {
+		if now != nil {
+			o.clock = now
+		}
+	}
+}
+
+// WithBackupSchedulerPauseController wires the soft-pause controller so
+// the scheduler skips its sweep while the backup-scheduler worker type is
+// paused. A nil checker leaves the scheduler unpaused.
+func WithBackupSchedulerPauseController(pc PauseChecker) BackupSchedulerOption {
+	return func(o *backupSchedulerOptions) {
+		if pc != nil {
+			o.pause = pc
+		}
+	}
+}
+
+// WithBackupFailureNotifier wires the failure email. Without one, failures
+// are only recorded on the schedule and logged.
+func WithBackupFailureNotifier(n BackupFailureNotifier, exportsURLBuilder func(groupSlug string) string) BackupSchedulerOption {
+	return func(o *backupSchedulerOptions) {
+		o.notifier = n
+		o.exportsURLBuilder = exportsURLBuilder
+	}
+}
+
+// WithBackupSchedulerPreferences attaches the notification preferences
+// service so the failure email is rendered in the owner's UI language.
+// The email itself is not opt-out: a silently failing backup schedule is
+// exactly what it exists to surface.
+func WithBackupSchedulerPreferences(prefs *notifications.Service) BackupSchedulerOption {
+	return func(o *backupSchedulerOptions) {
+		o.prefs = prefs
+	}
+}
+
+// NewBackupScheduler constructs the scheduler. Default cadence: five
+// minutes, which bounds how late a run can start after its slot.
+func NewBackupScheduler(factorySet *registry.FactorySet, deleter ExportDeleter, opts ...BackupSchedulerOption) *BackupScheduler {
+	options := backupSchedulerOptions{
+		interval: defaultBackupSchedulerInterval,
+		clock:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &BackupScheduler{
+		factorySet:        factorySet,
+		deleter:           deleter,
+		notifier:          options.notifier,
+		exportsURLBuilder: options.exportsURLBuilder,
+		prefs:             options.prefs,
+		interval:          options.interval,
+		clock:             options.clock,
+		pause:             options.pause,
+		stopCh:            make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine. No-op when no factory set is configured.
+func (s *BackupScheduler) Start(ctx context.Context) {
+	if s.factorySet == nil {
+		slog.Warn("BackupScheduler: no factory set configured, skipping startup")
+		return
+	}
+	s.wg.Go(func() {
+		s.run(ctx)
+	})
+	slog.Info("Backup scheduler started", "interval", s.interval)
+}
+
+// Stop signals the scheduler and waits for the goroutine to exit.
+func (s *BackupScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+	slog.Info("Backup scheduler stopped")
+}
+
+func (s *BackupScheduler) run(ctx context.Context) {
+	s.tick(ctx)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *BackupScheduler) tick(ctx context.Context) {
+	if s.pause != nil && s.pause.IsPaused(models.WorkerTypeBackupScheduler) {
+		return
+	}
+
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeBackupScheduler))
+	defer span.End()
+
+	stats, err := s.RunOnce(ctx, s.clock())
+	if err != nil {
+		slog.Error("Backup schedule sweep failed", "error", err)
+		return
+	}
+	backupScheduleRunsTotal.Add(float64(stats.Enqueued))
+	backupScheduleFailuresTotal.Add(float64(stats.Failed))
+	backupSchedulePrunedTotal.Add(float64(stats.Pruned))
+	if stats.Enqueued+stats.Failed+stats.Pruned > 0 {
+		slog.Info("Backup schedule sweep completed",
+			"enqueued", stats.Enqueued,
+			"failed", stats.Failed,
+			"pruned", stats.Pruned,
+		)
+	}
+}
+
+// RunOnce runs one sweep pinned to `now`. A non-nil error is only returned
+// when listing the pending schedules fails; per-schedule errors are logged
+// and retried on the next sweep.
+func (s *BackupScheduler) RunOnce(ctx context.Context, now time.Time) (BackupSchedulerStats, error) {
+	var stats BackupSchedulerStats
+	if s.factorySet == nil || s.factorySet.BackupScheduleRegistryFactory == nil {
+		return stats, errxtrace.Wrap("backup scheduler: missing BackupScheduleRegistryFactory", registry.ErrFieldRequired)
+	}
+	scheduleReg := s.factorySet.BackupScheduleRegistryFactory.CreateServiceRegistry()
+	schedules, err := scheduleReg.ListPending(ctx, now)
+	if err != nil {
+		return stats, errxtrace.Wrap("backup scheduler: list pending", err)
+	}
+	for _, schedule := range schedules {
+		if err := s.processOne(ctx, scheduleReg, schedule, now, &stats); err != nil {
+			slog.Error("backup schedule run failed",
+				"schedule_id", schedule.ID,
+				"group_id", schedule.GroupID,
+				"error", err,
+			)
+		}
+	}
+	return stats, nil
+}
+
+// scheduleOwner is the identity a schedule's exports run under.
+type scheduleOwner struct {
+	ctx   context.Context
+	user  *models.User
+	group *models.LocationGroup
+}
+
+func (s *BackupScheduler) processOne(ctx context.Context, scheduleReg registry.BackupScheduleRegistry, schedule *models.BackupSchedule, now time.Time, stats *BackupSchedulerStats) error {
+	owner, err := s.resolveOwner(ctx, schedule)
+	if errors.Is(err, errScheduleOwnerUnavailable) {
+		return s.disable(ctx, scheduleReg, schedule, err, stats)
+	}
+	if err != nil {
+		return err
+	}
+
+	inFlight := false
+	if schedule.LastExportID != "" && schedule.LastExportID != schedule.SettledExportID {
+		inFlight, err = s.settle(owner, schedule, stats)
+		if err != nil {
+			return err
+		}
+	}
+
+	if schedule.IsDue(now) {
+		schedule.NextRunAt = schedule.NextRunAfter(now)
+		if inFlight {
+			slog.Warn("backup schedule slot skipped: previous export still running",
+				"schedule_id", schedule.ID,
+				"export_id", schedule.LastExportID,
+			)
+		} else {
+			s.enqueue(owner, schedule, now, stats)
+		}
+	}
+
+	if _, err := scheduleReg.Update(ctx, *schedule); err != nil {
+		return errxtrace.Wrap("failed to update backup schedule", err)
+	}
+	return nil
+}
+
+// disable turns off a schedule whose owner or group is gone for good. The
+// schedule would otherwise stay due and be retried, silently, on every
+// sweep. The reason is kept on the schedule and the group's admins are
+// emailed so one of them can re-create it.
+func (s *BackupScheduler) disable(ctx context.Context, scheduleReg registry.BackupScheduleRegistry, schedule *models.BackupSchedule, cause error, stats *BackupSchedulerStats) error {
+	stats.Failed++
+	schedule.Enabled = false
+	schedule.SettledExportID = schedule.LastExportID
+	schedule.LastError = cause.Error()
+	slog.Warn("backup schedule disabled: owner unavailable",
+		"schedule_id", schedule.ID,
+		"group_id", schedule.GroupID,
+		"error", cause,
+	)
+	if _, err := scheduleReg.Update(ctx, *schedule); err != nil {
+		return errxtrace.Wrap("failed to update backup schedule", err)
+	}
+	s.notifyAdmins(ctx, schedule, cause.Error())
+	return nil
+}
+
+// resolveOwner loads the schedule's creator and group and returns a
+// context impersonating them, the same way the export worker does. A
+// deleted or deactivated owner or group is reported as
+// errScheduleOwnerUnavailable; any other error is transient.
+func (s *BackupScheduler) resolveOwner(ctx context.Context, schedule *models.BackupSchedule) (*scheduleOwner, error) {
+	user, err := s.factorySet.UserRegistry.Get(ctx, schedule.CreatedByUserID)
+	switch {
+	case errors.Is(err, registry.ErrNotFound), errors.Is(err, registry.ErrDeleted):
+		return nil, errxtrace.Wrap("schedule owner no longer exists", errScheduleOwnerUnavailable)
+	case err != nil:
+		return nil, errxtrace.Wrap("failed to get schedule owner", err)
+	case !user.IsActive:
+		return nil, errxtrace.Wrap("schedule owner is deactivated", errScheduleOwnerUnavailable)
+	}
+	group, err := s.factorySet.LocationGroupRegistry.Get(ctx, schedule.GroupID)
+	switch {
+	case errors.Is(err, registry.ErrNotFound), errors.Is(err, registry.ErrDeleted):
+		return nil, errxtrace.Wrap("schedule group no longer exists", errScheduleOwnerUnavailable)
+	case err != nil:
+		return nil, errxtrace.Wrap("failed to get schedule group", err)
+	case !group.IsActive():
+		return nil, errxtrace.Wrap("schedule group is not active", errScheduleOwnerUnavailable)
+	}
+	ownerCtx, err := registry.MemberContext(ctx, s.factorySet.GroupMemberLocationScopeRegistry, user, group)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduleOwner{ctx: ownerCtx, user: user, group: group}, nil
+}
+
+// settle inspects the schedule's last export. It reports inFlight while the
+// export worker has not finished it yet.
+func (s *BackupScheduler) settle(owner *scheduleOwner, schedule *models.BackupSchedule, stats *BackupSchedulerStats) (inFlight bool, err error) {
+	exp, err := s.factorySet.ExportRegistryFactory.CreateServiceRegistry().Get(owner.ctx, schedule.LastExportID)
+	switch {
+	case errors.Is(err, registry.ErrNotFound), errors.Is(err, registry.ErrDeleted):
+		// Deleted by a member before it was settled; nothing to report.
+		schedule.SettledExportID = schedule.LastExportID
+		return false, nil
+	case err != nil:
+		return false, errxtrace.Wrap("failed to get scheduled export", err)
+	}
+
+	switch exp.Status {
+	case models.ExportStatusFailed:
+		stats.Failed++
+		schedule.LastError = exp.ErrorMessage
+		s.notifyFailure(owner, exp.ErrorMessage)
+	case models.ExportStatusCompleted:
+		schedule.LastError = ""
+		pruned, err := s.applyRetention(owner, schedule)
+		stats.Pruned += pruned
+		if err != nil {
+			return false, err
+		}
+	default:
+		return true, nil
+	}
+	schedule.SettledExportID = schedule.LastExportID
+	return false, nil
+}
+
+func (s *BackupScheduler) enqueue(owner *scheduleOwner, schedule *models.BackupSchedule, now time.Time, stats *BackupSchedulerStats) {
+	schedule.LastRunAt = &now
+	registrySet, err := s.factorySet.CreateUserRegistrySet(owner.ctx)
+	if err == nil {
+		var created models.Export
+		created, err = CreateScheduledExport(owner.ctx, registrySet, schedule)
+		if err == nil {
+			stats.Enqueued++
+			schedule.LastExportID = created.ID
+			schedule.LastError = ""
+			return
+		}
+	}
+	stats.Failed++
+	schedule.LastError = err.Error()
+	slog.Error("failed to enqueue scheduled backup", "schedule_id", schedule.ID, "error", err)
+	s.notifyFailure(owner, err.Error())
+}
+
+// applyRetention deletes the schedule's completed exports that fall outside
+// its retention. Deletion runs as the owner so file cleanup follows the
+// same path as a manual delete.
+func (s *BackupScheduler) applyRetention(owner *scheduleOwner, schedule *models.BackupSchedule) (int, error) {
+	exportReg, err := s.factorySet.ExportRegistryFactory.CreateUserRegistry(owner.ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to create export registry", err)
+	}
+	exports, err := exportReg.List(owner.ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to list exports", err)
+	}
+	var produced []*models.Export
+	for _, e := range exports {
+		if e.BackupScheduleID != nil && *e.BackupScheduleID == schedule.ID && e.Status == models.ExportStatusCompleted {
+			produced = append(produced, e)
+		}
+	}
+	pruned := 0
+	for _, e := range SelectExportsToPrune(produced, schedule.KeepDaily, schedule.KeepWeekly, schedule.KeepMonthly) {
+		if err := s.deleter.DeleteExportWithFile(owner.ctx, e.ID); err != nil {
+			return pruned, errxtrace.Wrap("failed to prune scheduled export", err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func (s *BackupScheduler) notifyFailure(owner *scheduleOwner, reason string) {
+	if s.notifier == nil {
+		return
+	}
+	s.sendFailure(owner.ctx, owner.user, owner.group, reason)
+}
+
+// notifyAdmins emails every active admin and owner of the schedule's group.
+// Nobody is told when the group itself is gone.
+func (s *BackupScheduler) notifyAdmins(ctx context.Context, schedule *models.BackupSchedule, reason string) {
+	if s.notifier == nil {
+		return
+	}
+	group, err := s.factorySet.LocationGroupRegistry.Get(ctx, schedule.GroupID)
+	if err != nil || !group.IsActive() {
+		return
+	}
+	memberships, err := s.factorySet.GroupMembershipRegistry.ListByGroup(ctx, group.ID)
+	if err != nil {
+		slog.Error("failed to list group admins for backup failure email", "group_id", group.ID, "error", err)
+		return
+	}
+	for _, m := range memberships {
+		if !m.Role.AtLeast(models.GroupRoleAdmin) {
+			continue
+		}
+		user, err := s.factorySet.UserRegistry.Get(ctx, m.MemberUserID)
+		if err != nil || !user.IsActive {
+			continue
+		}
+		adminCtx, err := registry.MemberContext(ctx, s.factorySet.GroupMemberLocationScopeRegistry, user, group)
+		if err != nil {
+			slog.Error("failed to build admin context for backup failure email", "group_id", group.ID, "user_id", user.ID, "error", err)
+			continue
+		}
+		s.sendFailure(adminCtx, user, group, reason)
+	}
+}
+
+func (s *BackupScheduler) sendFailure(ctx context.Context, user *models.User, group *models.LocationGroup, reason string) {
+	exportsURL := ""
+	if s.exportsURLBuilder != nil {
+		exportsURL = s.exportsURLBuilder(group.Slug)
+	}
+	if s.prefs != nil {
+		ctx = appctx.WithEmailLanguage(ctx, s.prefs.Language(ctx, user))
+	}
+	if err := s.notifier.SendBackupFailureEmail(ctx, user.Email, user.Name, group.Name, reason, exportsURL); err != nil {
+		slog.Error("failed to send backup failure email", "group_id", group.ID, "error", err)
+	}
+}
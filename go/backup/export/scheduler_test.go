@@ -0,0 +1,241 @@
+package export
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+type recordingExportDeleter struct {
+	factorySet *registry.FactorySet
+	deleted    []string
+}
+
+func (d *recordingExportDeleter) DeleteExportWithFile(ctx context.Context, id string) error {
+	d.deleted = append(d.deleted, id)
+	return d.factorySet.ExportRegistryFactory.CreateServiceRegistry().Delete(ctx, id)
+}
+
+type recordedBackupFailure struct {
+	to, groupName, reason, exportsURL string
+}
+
+type recordingBackupFailureNotifier struct {
+	mu    sync.Mutex
+	calls []recordedBackupFailure
+}
+
+func (n *recordingBackupFailureNotifier) SendBackupFailureEmail(_ context.Context, to, _, groupName, reason, exportsURL string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls = append(n.calls, recordedBackupFailure{to: to, groupName: groupName, reason: reason, exportsURL: exportsURL})
+	return nil
+}
+
+func TestBackupScheduler_RunOnce(t *testing.T) {
+	c := qt.New(t)
+	factorySet := newTestFactorySet()
+	ctx := newTestContext()
+	now := time.Date(2026, 5, 13, 3, 1, 0, 0, time.UTC)
+
+	scheduleReg := factorySet.BackupScheduleRegistryFactory.MustCreateUserRegistry(ctx)
+	schedule, err := scheduleReg.Create(ctx, models.BackupSchedule{
+		Enabled:         true,
+		Frequency:       models.BackupFrequencyDaily,
+		TimeOfDay:       "03:00",
+		DayOfMonth:      1,
+		IncludeFileData: true,
+		KeepDaily:       2,
+		NextRunAt:       now.Add(-time.Minute),
+	})
+	c.Assert(err, qt.IsNil)
+
+	deleter := &recordingExportDeleter{factorySet: factorySet}
+	notifier := &recordingBackupFailureNotifier{}
+	scheduler := NewBackupScheduler(factorySet, deleter,
+		WithBackupFailureNotifier(notifier, func(slug string) string { return "https://inventario.test/g/" + slug + "/exports" }),
+	)
+	exportReg := factorySet.ExportRegistryFactory.CreateServiceRegistry()
+
+	// Due: a full-database export is enqueued as the schedule owner.
+	stats, err := scheduler.RunOnce(context.Background(), now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{Enqueued: 1})
+	schedule, err = scheduleReg.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schedule.NextRunAt, qt.Equals, time.Date(2026, 5, 14, 3, 0, 0, 0, time.UTC))
+	c.Assert(schedule.LastExportID, qt.Not(qt.Equals), "")
+	firstRun, err := exportReg.Get(context.Background(), schedule.LastExportID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(firstRun.Type, qt.Equals, models.ExportTypeFullDatabase)
+	c.Assert(firstRun.IncludeFileData, qt.IsTrue)
+	c.Assert(firstRun.BackupScheduleID, qt.DeepEquals, new(schedule.ID))
+	c.Assert(firstRun.CreatedByUserID, qt.Equals, testUserID)
+	c.Assert(firstRun.Status, qt.Equals, models.ExportStatusPending)
+
+	// Still pending: nothing to settle, nothing due.
+	stats, err = scheduler.RunOnce(context.Background(), now.Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{})
+
+	// Failed: the owner is emailed and the error is kept on the schedule.
+	firstRun.Status = models.ExportStatusFailed
+	firstRun.ErrorMessage = "disk full"
+	_, err = exportReg.Update(context.Background(), *firstRun)
+	c.Assert(err, qt.IsNil)
+	stats, err = scheduler.RunOnce(context.Background(), now.Add(2*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{Failed: 1})
+	c.Assert(notifier.calls, qt.HasLen, 1)
+	c.Assert(notifier.calls[0].to, qt.Equals, "test@example.com")
+	c.Assert(notifier.calls[0].groupName, qt.Equals, "Test Group")
+	c.Assert(notifier.calls[0].reason, qt.Equals, "disk full")
+	c.Assert(notifier.calls[0].exportsURL, qt.Matches, `https://inventario\.test/g/.+/exports`)
+	schedule, err = scheduleReg.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schedule.LastError, qt.Equals, "disk full")
+	c.Assert(schedule.SettledExportID, qt.Equals, firstRun.ID)
+
+	// Next slot: enqueue again, then complete it; retention keeps the two
+	// newest days of scheduled exports and never touches manual ones.
+	expCreate := factorySet.ExportRegistryFactory.MustCreateUserRegistry(ctx)
+	seed := func(days int, scheduleID string) *models.Export {
+		created, err := expCreate.Create(ctx, models.Export{
+			Type:             models.ExportTypeFullDatabase,
+			Status:           models.ExportStatusCompleted,
+			BackupScheduleID: new(scheduleID),
+			CreatedDate:      models.NewPTimestamp(now.AddDate(0, 0, -days)),
+		})
+		c.Assert(err, qt.IsNil)
+		return created
+	}
+	older := seed(2, schedule.ID)
+	oldest := seed(3, schedule.ID)
+	manual := seed(10, "")
+
+	next := time.Date(2026, 5, 14, 3, 1, 0, 0, time.UTC)
+	stats, err = scheduler.RunOnce(context.Background(), next)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{Enqueued: 1})
+	schedule, err = scheduleReg.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	secondRun, err := exportReg.Get(context.Background(), schedule.LastExportID)
+	c.Assert(err, qt.IsNil)
+	secondRun.Status = models.ExportStatusCompleted
+	secondRun.CreatedDate = models.NewPTimestamp(next)
+	_, err = exportReg.Update(context.Background(), *secondRun)
+	c.Assert(err, qt.IsNil)
+
+	stats, err = scheduler.RunOnce(context.Background(), next.Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{Pruned: 1})
+	c.Assert(deleter.deleted, qt.DeepEquals, []string{oldest.ID})
+	for _, id := range []string{secondRun.ID, older.ID, manual.ID} {
+		_, err := exportReg.Get(context.Background(), id)
+		c.Assert(err, qt.IsNil, qt.Commentf("export %s", id))
+	}
+	schedule, err = scheduleReg.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schedule.LastError, qt.Equals, "")
+	c.Assert(schedule.SettledExportID, qt.Equals, secondRun.ID)
+}
+
+func TestBackupScheduler_SkipsSlotWhilePreviousRunInFlight(t *testing.T) {
+	c := qt.New(t)
+	factorySet := newTestFactorySet()
+	ctx := newTestContext()
+	now := time.Date(2026, 5, 13, 3, 1, 0, 0, time.UTC)
+
+	scheduleReg := factorySet.BackupScheduleRegistryFactory.MustCreateUserRegistry(ctx)
+	schedule, err := scheduleReg.Create(ctx, models.BackupSchedule{
+		Enabled:    true,
+		Frequency:  models.BackupFrequencyDaily,
+		TimeOfDay:  "03:00",
+		DayOfMonth: 1,
+		KeepDaily:  7,
+		NextRunAt:  now.Add(-time.Minute),
+	})
+	c.Assert(err, qt.IsNil)
+	scheduler := NewBackupScheduler(factorySet, &recordingExportDeleter{factorySet: factorySet})
+
+	_, err = scheduler.RunOnce(context.Background(), now)
+	c.Assert(err, qt.IsNil)
+	schedule, err = scheduleReg.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	inFlight := schedule.LastExportID
+
+	stats, err := scheduler.RunOnce(context.Background(), now.AddDate(0, 0, 1))
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{})
+	schedule, err = scheduleReg.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schedule.LastExportID, qt.Equals, inFlight)
+	c.Assert(schedule.NextRunAt, qt.Equals, time.Date(2026, 5, 15, 3, 0, 0, 0, time.UTC))
+}
+
+func TestBackupScheduler_DisablesScheduleOfDeletedOwner(t *testing.T) {
+	c := qt.New(t)
+	factorySet := newTestFactorySet()
+	ctx := newTestContext()
+	now := time.Date(2026, 5, 13, 3, 1, 0, 0, time.UTC)
+
+	admin, err := factorySet.UserRegistry.Create(context.Background(), models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: "test-tenant"},
+		Email:               "admin@example.com",
+		Name:                "Admin User",
+		IsActive:            true,
+	})
+	c.Assert(err, qt.IsNil)
+	for _, m := range []struct {
+		userID string
+		role   models.GroupRole
+	}{{testUserID, models.GroupRoleUser}, {admin.ID, models.GroupRoleAdmin}} {
+		_, err := factorySet.GroupMembershipRegistry.Create(context.Background(), models.GroupMembership{
+			TenantAwareEntityID: models.TenantAwareEntityID{TenantID: "test-tenant"},
+			GroupID:             testGroupID,
+			MemberUserID:        m.userID,
+			Role:                m.role,
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	scheduleReg := factorySet.BackupScheduleRegistryFactory.MustCreateUserRegistry(ctx)
+	schedule, err := scheduleReg.Create(ctx, models.BackupSchedule{
+		Enabled:    true,
+		Frequency:  models.BackupFrequencyDaily,
+		TimeOfDay:  "03:00",
+		DayOfMonth: 1,
+		KeepDaily:  7,
+		NextRunAt:  now.Add(-time.Minute),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(factorySet.UserRegistry.Delete(context.Background(), testUserID), qt.IsNil)
+
+	notifier := &recordingBackupFailureNotifier{}
+	scheduler := NewBackupScheduler(factorySet, &recordingExportDeleter{factorySet: factorySet},
+		WithBackupFailureNotifier(notifier, nil),
+	)
+
+	stats, err := scheduler.RunOnce(context.Background(), now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{Failed: 1})
+	schedule, err = scheduleReg.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schedule.Enabled, qt.IsFalse)
+	c.Assert(schedule.LastError, qt.Matches, `schedule owner no longer exists: .*`)
+	c.Assert(notifier.calls, qt.HasLen, 1)
+	c.Assert(notifier.calls[0].to, qt.Equals, "admin@example.com")
+	c.Assert(notifier.calls[0].reason, qt.Equals, schedule.LastError)
+
+	// Disabled: later sweeps no longer pick it up.
+	stats, err = scheduler.RunOnce(context.Background(), now.AddDate(0, 0, 1))
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, BackupSchedulerStats{})
+	c.Assert(notifier.calls, qt.HasLen, 1)
+}
@@ -9,10 +9,12 @@ import (
 	"time"
 
 	errxtrace "github.com/go-extras/errx/stacktrace"
+	"go.opentelemetry.io/otel/attribute"
 	"gocloud.dev/blob"
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/internal/backupsign"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -73,13 +75,18 @@ func NewExportService(factorySet *registry.FactorySet, uploadLocation string, si
 // format-agnostic: the per-build generateExport produces either a signed `.inb`
 // archive (default) or a legacy XML bundle, and createExportFileEntity stamps
 // the matching Ext/MIME/LinkedEntityMeta via the per-build exportFileMeta.
-func (s *ExportService) ProcessExport(ctx context.Context, exportID string) error {
+func (s *ExportService) ProcessExport(ctx context.Context, exportID string) (err error) {
 	// Get the export request
 	export, err := s.factorySet.ExportRegistryFactory.CreateServiceRegistry().Get(ctx, exportID)
 	if err != nil {
 		return errxtrace.Wrap("failed to get export", err)
 	}
 
+	ctx, span := tracing.StartJob(ctx, "export.process", export.TraceParent,
+		attribute.String("inventario.export.id", export.ID),
+		attribute.String("inventario.export.type", string(export.Type)))
+	defer func() { tracing.End(span, err) }()
+
 	// Skip processing for imported exports - they are already completed
 	if export.Type == models.ExportTypeImported {
 		return nil
@@ -99,8 +106,10 @@ func (s *ExportService) ProcessExport(ctx context.Context, exportID string) erro
 		return errxtrace.Wrap("failed to get export group", err)
 	}
 
-	ctx = appctx.WithUser(ctx, user)
-	ctx = appctx.WithGroup(ctx, group)
+	ctx, err = registry.MemberContext(ctx, s.factorySet.GroupMemberLocationScopeRegistry, user, group)
+	if err != nil {
+		return err
+	}
 
 	// Update status to in_progress
 	export.Status = models.ExportStatusInProgress
@@ -150,6 +159,7 @@ func (s *ExportService) ProcessExport(ctx context.Context, exportID string) erro
 	export.ManualCount = stats.ManualCount
 	export.FileCount = stats.FileCount
 	export.BinaryDataSize = stats.BinaryDataSize
+	export.SignatureKeyFingerprint = stats.KeyFingerprint
 	export.FileSize = artifactSize
 
 	// Update status to completed using user context
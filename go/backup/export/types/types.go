@@ -18,4 +18,7 @@ type ExportStats struct {
 	// the export's own backup-bundle FileEntity (linked_entity_type="export").
 	FileCount      int
 	BinaryDataSize int64
+	// KeyFingerprint is the fingerprint of the key the archive was signed
+	// with; empty for the unsigned legacy XML format.
+	KeyFingerprint string
 }
@@ -6,6 +6,7 @@ import (
 
 	errxtrace "github.com/go-extras/errx/stacktrace"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -39,17 +40,44 @@ func exportTypeLabel(t models.ExportType) string {
 // as local time, which is ambiguous for users in non-UTC timezones (and
 // inconsistent with the actual persisted value, which is always UTC).
 func defaultExportDescription(e *models.Export) string {
+	return describeExport("Backup", e)
+}
+
+// describeExport renders "{prefix} · {Type label} · {Created at UTC}".
+func describeExport(prefix string, e *models.Export) string {
 	created := "—"
 	if e.CreatedDate != nil {
 		created = e.CreatedDate.ToTime().UTC().Format("2006-01-02 15:04") + " UTC"
 	}
-	return "Backup · " + exportTypeLabel(e.Type) + " · " + created
+	return prefix + " · " + exportTypeLabel(e.Type) + " · " + created
 }
 
 // CreateExportFromUserInput creates a new export record from user input.
 // The export record is created with status "pending" and is ready for processing.
 // It will be processed by the ExportWorker in the background.
 func CreateExportFromUserInput(ctx context.Context, registrySet *registry.Set, input *models.Export) (models.Export, error) {
+	return createExport(ctx, registrySet, input, nil)
+}
+
+// CreateScheduledExport enqueues the full-database export for one run of a
+// group's backup schedule. It goes through the same path as a user-submitted
+// export, so ctx must carry the schedule owner and group; the schedule link
+// and description are stamped afterwards because NewExportFromUserInput
+// drops every non-user-input field.
+func CreateScheduledExport(ctx context.Context, registrySet *registry.Set, schedule *models.BackupSchedule) (models.Export, error) {
+	input := &models.Export{
+		Type:            models.ExportTypeFullDatabase,
+		IncludeFileData: schedule.IncludeFileData,
+	}
+	return createExport(ctx, registrySet, input, func(e *models.Export) {
+		e.BackupScheduleID = new(schedule.ID)
+		e.Description = describeExport("Scheduled backup", e)
+	})
+}
+
+// createExport is the shared create path. stamp, when set, runs after the
+// user-input copy and before the default description is synthesised.
+func createExport(ctx context.Context, registrySet *registry.Set, input *models.Export, stamp func(*models.Export)) (models.Export, error) {
 	// Normalise whitespace-only descriptions to "" BEFORE validation so the
 	// length(0, 500) cap doesn't reject a 500+ char blob of spaces — the
 	// service's intent is "treat blank as missing and synthesise a default",
@@ -66,6 +94,9 @@ func CreateExportFromUserInput(ctx context.Context, registrySet *registry.Set, i
 	}
 
 	export := models.NewExportFromUserInput(input)
+	if stamp != nil {
+		stamp(&export)
+	}
 
 	// Synthesise a default description when the user leaves it blank, so the
 	// list row never renders as an empty line. Done after NewExportFromUserInput
@@ -96,6 +127,8 @@ func CreateExportFromUserInput(ctx context.Context, registrySet *registry.Set, i
 		}
 	}
 
+	export.TraceParent = tracing.TraceParent(ctx)
+
 	exportReg := registrySet.ExportRegistry
 
 	// Create the export
@@ -8,6 +8,7 @@ import (
 
 	"golang.org/x/sync/semaphore"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -161,6 +162,9 @@ func (w *ExportWorker) processPendingExports(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeExport))
+	defer span.End()
+
 	reg := w.factorySet.ExportRegistryFactory.CreateServiceRegistry()
 	exports, err := reg.List(ctx)
 	if err != nil {
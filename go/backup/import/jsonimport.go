@@ -16,6 +16,7 @@ import (
 	"github.com/denisvmedia/inventario/internal/backupsign"
 	"github.com/denisvmedia/inventario/internal/inb"
 	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services"
 )
 
 // importFileMeta returns the FileEntity stamping for an imported `.inb` backup
@@ -44,12 +45,17 @@ type manifestDoc struct {
 }
 
 // parseImportMetadata verifies the uploaded `.inb` archive's signature against
-// the server's own key, then reads manifest.json for the statistics used to
-// stamp the import's export record (#534). A bad/missing signature or a
-// non-`.inb` upload (e.g. legacy XML) fails hard — there is no bypass.
-func (s *ImportService) parseImportMetadata(_ context.Context, reader io.Reader) (importStats, error) {
-	if s.signer == nil {
-		return importStats{}, errx.NewSentinel("backup signer is required to import an .inb archive")
+// the backup keyring (the server's own key plus the trusted keys), then reads
+// manifest.json for the statistics used to stamp the import's export record
+// (#534). A bad/missing signature or a non-`.inb` upload (e.g. legacy XML)
+// fails hard — there is no bypass.
+func (s *ImportService) parseImportMetadata(ctx context.Context, reader io.Reader) (importStats, error) {
+	keyring, err := services.BackupKeyring(ctx, s.factorySet, s.signer)
+	if err != nil {
+		return importStats{}, err
+	}
+	if keyring.Len() == 0 {
+		return importStats{}, errx.NewSentinel("backup signer or a trusted backup key is required to import an .inb archive")
 	}
 
 	sig, payload, err := inb.ReadContainer(reader, inb.DefaultLimits())
@@ -75,7 +81,8 @@ func (s *ImportService) parseImportMetadata(_ context.Context, reader io.Reader)
 	}
 
 	// Verify BEFORE inflate.
-	if err := s.signer.VerifyDigest(digest.Sum(nil), sig); err != nil {
+	fingerprint, err := keyring.VerifyDigest(digest.Sum(nil), sig)
+	if err != nil {
 		return importStats{}, errxtrace.Wrap("backup signature verification failed; refusing to import", err)
 	}
 
@@ -97,6 +104,7 @@ func (s *ImportService) parseImportMetadata(_ context.Context, reader io.Reader)
 		ManualCount:    manifest.Statistics.ManualCount,
 		FileCount:      manifest.Statistics.FileCount,
 		BinaryDataSize: manifest.Statistics.TotalFileSize,
+		KeyFingerprint: fingerprint,
 	}, nil
 }
 
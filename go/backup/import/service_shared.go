@@ -10,7 +10,6 @@ import (
 	errxtrace "github.com/go-extras/errx/stacktrace"
 	"gocloud.dev/blob"
 
-	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/internal/backupsign"
 	"github.com/denisvmedia/inventario/internal/blobkeys"
 	"github.com/denisvmedia/inventario/models"
@@ -21,7 +20,8 @@ import (
 // uploaded backup files.
 //
 // The signer is consumed by the default `.inb` import path (which verifies the
-// archive signature and reads its manifest); the legacy XML path ignores it.
+// archive signature against it and the trusted backup keys, then reads its
+// manifest); the legacy XML path ignores it.
 // The constructor signature is identical across both builds.
 type ImportService struct {
 	factorySet     *registry.FactorySet
@@ -50,6 +50,9 @@ type importStats struct {
 	ManualCount    int
 	FileCount      int
 	BinaryDataSize int64
+	// KeyFingerprint is the fingerprint of the keyring entry the archive's
+	// signature verified under; empty for formats that are not signed.
+	KeyFingerprint string
 }
 
 // ProcessImport processes an uploaded backup file and updates the export record
@@ -119,6 +122,7 @@ func (s *ImportService) ProcessImport(ctx context.Context, exportID, sourceFileP
 	exportRecord.FileCount = stats.FileCount
 	exportRecord.BinaryDataSize = stats.BinaryDataSize
 	exportRecord.IncludeFileData = stats.BinaryDataSize > 0
+	exportRecord.SignatureKeyFingerprint = stats.KeyFingerprint
 
 	if _, err = expReg.Update(ctx, *exportRecord); err != nil {
 		return fmt.Errorf("import was successful, but failed to update export record")
@@ -176,8 +180,10 @@ func (s *ImportService) createImportFileEntity(ctx context.Context, export *mode
 	if err != nil {
 		return nil, errxtrace.Wrap("failed to get import group", err)
 	}
-	ctx = appctx.WithUser(ctx, user)
-	ctx = appctx.WithGroup(ctx, group)
+	ctx, err = registry.MemberContext(ctx, s.factorySet.GroupMemberLocationScopeRegistry, user, group)
+	if err != nil {
+		return nil, err
+	}
 
 	fileReg, err := s.factorySet.FileRegistryFactory.CreateUserRegistry(ctx)
 	if err != nil {
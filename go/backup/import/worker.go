@@ -8,6 +8,7 @@ import (
 
 	"golang.org/x/sync/semaphore"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -160,6 +161,9 @@ func (w *ImportWorker) processPendingImports(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeImport))
+	defer span.End()
+
 	expReg := w.factorySet.ExportRegistryFactory.CreateServiceRegistry()
 	exports, err := expReg.List(ctx)
 	if err != nil {
@@ -784,9 +784,9 @@ func TestINBRestore_EntityFilesUnderMergeStrategies(t *testing.T) {
 
 // TestINBRestore_DryRunDoesNotPersistEntityFiles: a dry-run consumes the files
 // member and its byte members (so the missing-member check stays satisfied) but
-// creates no row. Note it DOES count "references unmapped location" errors, which
-// is the pre-existing dry-run behaviour for every linked file — nothing is
-// persisted, so there is no ID mapping to resolve a link against.
+// creates no row. The linked location is not persisted either, but the dry-run
+// maps its UUID to itself, so the file still resolves its link and is reported
+// as an upload in the preview.
 func TestINBRestore_DryRunDoesNotPersistEntityFiles(t *testing.T) {
 	c := qt.New(t)
 	signer := testSigner(c)
@@ -806,7 +806,9 @@ func TestINBRestore_DryRunDoesNotPersistEntityFiles(t *testing.T) {
 		DryRun:   true,
 	})
 	c.Assert(err, qt.IsNil)
-	c.Assert(final.Status, qt.Equals, models.RestoreStatusCompleted)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusPreview)
+	c.Assert(final.ErrorCount, qt.Equals, 0)
+	c.Assert(final.Preview.Files.Upload, qt.Equals, 2)
 
 	// No new file rows.
 	c.Assert(must.Must(fileReg.List(f.ctx)), qt.HasLen, before)
@@ -0,0 +1,143 @@
+//go:build !legacy_xml_backup
+
+package backup_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"gocloud.dev/blob"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// TestINBRestorePreview_MergeUpdateReportsChangesAndConflicts: a merge_update
+// dry-run reports the archived subtree as updates, flags the commodity edited
+// since the backup as a content conflict, and writes nothing.
+func TestINBRestorePreview_MergeUpdateReportsChangesAndConflicts(t *testing.T) {
+	c := qt.New(t)
+	signer := testSigner(c)
+	f := newInbFixture(c)
+	f.attachCommodityFile(c, "images", 512)
+	blobKey, _ := f.runExport(c, signer)
+
+	comReg := must.Must(f.fs.CommodityRegistryFactory.CreateUserRegistry(f.ctx))
+	com := must.Must(comReg.List(f.ctx))[0]
+	com.Name = "Renamed TV"
+	com.Count = 2
+	must.Must(comReg.Update(f.ctx, *com))
+	f.addUnassignedCommodity(c, "Added after backup")
+
+	final, err := restoreInbWithOptions(c, f, signer, blobKey, models.RestoreOptions{
+		Strategy: string(types.RestoreStrategyMergeUpdate),
+		DryRun:   true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusPreview)
+	c.Assert(final.Preview, qt.IsNotNil)
+
+	preview := final.Preview
+	c.Assert(preview.Locations, qt.HasLen, 1)
+	c.Assert(preview.Locations[0].Name, qt.Equals, "Home")
+	c.Assert(preview.Locations[0].Action, qt.Equals, models.RestorePreviewActionUpdate)
+	c.Assert(preview.Locations[0].Areas, qt.HasLen, 1)
+	c.Assert(preview.Locations[0].Areas[0].Action, qt.Equals, models.RestorePreviewActionUpdate)
+	c.Assert(preview.Locations[0].Areas[0].Commodities, qt.Equals, models.RestorePreviewCounts{Update: 1})
+	c.Assert(preview.Unassigned, qt.Equals, models.RestorePreviewCounts{})
+	c.Assert(preview.Files, qt.Equals, models.RestorePreviewFiles{Upload: 1, UploadBytes: 512})
+
+	c.Assert(preview.Conflicts, qt.HasLen, 1)
+	conflict := preview.Conflicts[0]
+	c.Assert(conflict.Kind, qt.Equals, models.RestoreConflictContentMismatch)
+	c.Assert(conflict.EntityType, qt.Equals, "commodity")
+	c.Assert(conflict.EntityID, qt.Equals, com.UUID)
+	c.Assert(conflict.Detail, qt.Equals, "differs in: name, count")
+
+	// Nothing was written.
+	c.Assert(f.commodityByUUID(c, com.UUID).Name, qt.Equals, "Renamed TV")
+}
+
+// TestINBRestorePreview_FullReplaceReportsDeletes: full_replace lists what it
+// would remove — data the archive does not carry — and leaves it in place.
+func TestINBRestorePreview_FullReplaceReportsDeletes(t *testing.T) {
+	c := qt.New(t)
+	signer := testSigner(c)
+	f := newInbFixture(c)
+	blobKey, _ := f.runExport(c, signer)
+
+	locReg := must.Must(f.fs.LocationRegistryFactory.CreateUserRegistry(f.ctx))
+	garage := must.Must(locReg.Create(f.ctx, models.Location{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{TenantID: "tenant-a", GroupID: f.group.ID, CreatedByUserID: f.user.ID},
+		Name:                     "Garage",
+	}))
+	f.addUnassignedCommodity(c, "Added after backup")
+
+	final, err := restoreInbWithOptions(c, f, signer, blobKey, models.RestoreOptions{
+		Strategy: string(types.RestoreStrategyFullReplace),
+		DryRun:   true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusPreview)
+
+	preview := final.Preview
+	c.Assert(preview.Locations, qt.HasLen, 2)
+	c.Assert(preview.Locations[0].Name, qt.Equals, "Home")
+	c.Assert(preview.Locations[0].Action, qt.Equals, models.RestorePreviewActionUpdate)
+	c.Assert(preview.Locations[0].Areas[0].Commodities, qt.Equals, models.RestorePreviewCounts{Update: 1})
+	c.Assert(preview.Locations[1].ID, qt.Equals, garage.UUID)
+	c.Assert(preview.Locations[1].Action, qt.Equals, models.RestorePreviewActionDelete)
+	c.Assert(preview.Unassigned, qt.Equals, models.RestorePreviewCounts{Delete: 1})
+	c.Assert(preview.Conflicts, qt.HasLen, 0)
+
+	_, err = locReg.Get(f.ctx, garage.ID)
+	c.Assert(err, qt.IsNil)
+}
+
+// TestINBRestorePreview_ReportsMissingAreaAndCurrencyMismatch covers the two
+// archive-side conflicts: a commodity whose area exists nowhere, and one whose
+// prices were recorded in a main currency other than the group's.
+func TestINBRestorePreview_ReportsMissingAreaAndCurrencyMismatch(t *testing.T) {
+	c := qt.New(t)
+	signer := testSigner(c)
+	f := newInbFixture(c)
+
+	doc := inbDocForFixture(c, f, func(com *types.INBCommodity) {
+		com.AcquisitionPrice = "100"
+		com.AcquisitionCurrency = "EUR"
+	})
+	orphan := doc.Commodities[0]
+	orphan.ID = "orphan-commodity"
+	orphan.Name = "Orphan"
+	orphan.AreaID = "missing-area"
+	orphan.AcquisitionPrice = ""
+	orphan.AcquisitionCurrency = ""
+	doc.Commodities = append(doc.Commodities, orphan)
+	archive := signedArchiveFromDoc(c, signer, doc)
+
+	bucket := must.Must(blob.OpenBucket(f.ctx, inbUploadLocation))
+	key := "t/tenant-a/restores/preview-conflicts.inb"
+	c.Assert(bucket.WriteAll(f.ctx, key, archive, nil), qt.IsNil)
+	bucket.Close()
+
+	final, err := restoreInbWithOptions(c, f, signer, key, models.RestoreOptions{
+		Strategy: string(types.RestoreStrategyMergeAdd),
+		DryRun:   true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusPreview)
+
+	preview := final.Preview
+	c.Assert(preview.Locations[0].Action, qt.Equals, models.RestorePreviewActionSkip)
+	c.Assert(preview.Locations[0].Areas[0].Commodities, qt.Equals, models.RestorePreviewCounts{Skip: 1})
+
+	kinds := map[models.RestoreConflictKind]string{}
+	for _, conflict := range preview.Conflicts {
+		kinds[conflict.Kind] = conflict.EntityID
+	}
+	c.Assert(kinds, qt.DeepEquals, map[models.RestoreConflictKind]string{
+		models.RestoreConflictCurrencyMismatch: doc.Commodities[0].ID,
+		models.RestoreConflictMissingArea:      "orphan-commodity",
+	})
+}
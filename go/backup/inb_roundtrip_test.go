@@ -709,7 +709,9 @@ func TestINBRestore_DryRunWithFilesDoesNotErrorOnMembers(t *testing.T) {
 		DryRun:   true,
 	})
 	c.Assert(err, qt.IsNil)
-	c.Assert(final.Status, qt.Equals, models.RestoreStatusCompleted)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusPreview)
+	c.Assert(final.Preview, qt.IsNotNil)
+	c.Assert(final.Preview.Files.Upload, qt.Equals, 1)
 }
 
 // TestINBRestore_MissingFileMemberFails proves the positive case: an archive
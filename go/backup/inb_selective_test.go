@@ -0,0 +1,235 @@
+//go:build !legacy_xml_backup
+
+package backup_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// selectiveFixture extends the base fixture with a second area ("Kitchen",
+// holding one commodity and an area file) in the same location, so a
+// selection has something to leave out.
+type selectiveFixture struct {
+	*inbFixture
+	locUUID     string
+	areaUUID    string
+	tvUUID      string
+	kitchenID   string
+	kitchenUUID string
+	kettleUUID  string
+	areaFile    string
+}
+
+func newSelectiveFixture(c *qt.C) *selectiveFixture {
+	f := newInbFixture(c)
+	ids := models.TenantGroupAwareEntityID{TenantID: "tenant-a", GroupID: f.group.ID, CreatedByUserID: f.user.ID}
+
+	locReg := must.Must(f.fs.LocationRegistryFactory.CreateUserRegistry(f.ctx))
+	areaReg := must.Must(f.fs.AreaRegistryFactory.CreateUserRegistry(f.ctx))
+	comReg := must.Must(f.fs.CommodityRegistryFactory.CreateUserRegistry(f.ctx))
+
+	kitchen := must.Must(areaReg.Create(f.ctx, models.Area{
+		TenantGroupAwareEntityID: ids,
+		Name:                     "Kitchen",
+		LocationID:               f.locID,
+	}))
+	kettle := must.Must(comReg.Create(f.ctx, models.Commodity{
+		TenantGroupAwareEntityID: ids,
+		Name:                     "Kettle",
+		ShortName:                "kettle",
+		Type:                     models.CommodityTypeElectronics,
+		AreaID:                   new(kitchen.ID),
+		Count:                    1,
+		Status:                   models.CommodityStatusInUse,
+		OriginalPriceCurrency:    "USD",
+		Draft:                    true,
+	}))
+
+	sf := &selectiveFixture{
+		inbFixture:  f,
+		locUUID:     must.Must(locReg.Get(f.ctx, f.locID)).UUID,
+		areaUUID:    must.Must(areaReg.Get(f.ctx, f.areaID)).UUID,
+		kitchenID:   kitchen.ID,
+		kitchenUUID: kitchen.UUID,
+		kettleUUID:  kettle.UUID,
+	}
+	for _, com := range must.Must(comReg.List(f.ctx)) {
+		if com.Name == "TV" {
+			sf.tvUUID = com.UUID
+		}
+	}
+	sf.areaFile = f.attachEntityFile(c, "area", kitchen.ID, "images", "kitchen", ".jpg", "image/jpeg", 256)
+	return sf
+}
+
+// renameCommodity renames a commodity in place, so a test can tell whether a
+// restore touched it.
+func (f *inbFixture) renameCommodity(c *qt.C, uuid, name string) {
+	comReg := must.Must(f.fs.CommodityRegistryFactory.CreateUserRegistry(f.ctx))
+	com := f.commodityByUUID(c, uuid)
+	com.Name = name
+	must.Must(comReg.Update(f.ctx, *com))
+}
+
+// hasCommodity reports whether a commodity with the given UUID exists.
+func (f *inbFixture) hasCommodity(c *qt.C, uuid string) bool {
+	comReg := must.Must(f.fs.CommodityRegistryFactory.CreateUserRegistry(f.ctx))
+	for _, com := range must.Must(comReg.List(f.ctx)) {
+		if com.UUID == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+// TestINBRestore_SelectionRestoresDeletedArea is the motivating case: an area
+// deleted by mistake is restored on its own — with its commodities and files
+// — under its still-existing location, while the rest of the group keeps its
+// current state even under merge_update.
+func TestINBRestore_SelectionRestoresDeletedArea(t *testing.T) {
+	c := qt.New(t)
+	signer := testSigner(c)
+	f := newSelectiveFixture(c)
+	blobKey, _ := f.runExport(c, signer)
+
+	entityService := services.NewEntityService(f.fs, inbUploadLocation)
+	c.Assert(entityService.DeleteAreaRecursive(f.ctx, f.kitchenID), qt.IsNil)
+	f.renameCommodity(c, f.tvUUID, "Renamed TV")
+
+	final, err := restoreInbWithOptions(c, f.inbFixture, signer, blobKey, models.RestoreOptions{
+		Strategy:  string(types.RestoreStrategyMergeUpdate),
+		Selection: &models.RestoreSelection{AreaIDs: []string{f.kitchenUUID}},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusCompleted)
+	c.Assert(final.ErrorCount, qt.Equals, 0)
+	c.Assert(final.LocationCount, qt.Equals, 0)
+	c.Assert(final.AreaCount, qt.Equals, 1)
+	c.Assert(final.CommodityCount, qt.Equals, 1)
+
+	c.Assert(f.areaByUUID(c, f.kitchenUUID).LocationID, qt.Equals, f.locID)
+	c.Assert(f.commodityByUUID(c, f.kettleUUID).Name, qt.Equals, "Kettle")
+	c.Assert(f.countFilesWithUUID(c, f.areaFile), qt.Equals, 1)
+	c.Assert(f.commodityByUUID(c, f.tvUUID).Name, qt.Equals, "Renamed TV")
+
+	locReg := must.Must(f.fs.LocationRegistryFactory.CreateUserRegistry(f.ctx))
+	c.Assert(must.Must(locReg.List(f.ctx)), qt.HasLen, 1)
+}
+
+// TestINBRestore_SelectionCreatesMissingParents: a selected commodity whose
+// location and area are gone brings both back, but none of their other
+// children.
+func TestINBRestore_SelectionCreatesMissingParents(t *testing.T) {
+	c := qt.New(t)
+	signer := testSigner(c)
+	f := newSelectiveFixture(c)
+	blobKey, _ := f.runExport(c, signer)
+
+	entityService := services.NewEntityService(f.fs, inbUploadLocation)
+	c.Assert(entityService.DeleteLocationRecursive(f.ctx, f.locID), qt.IsNil)
+
+	final, err := restoreInbWithOptions(c, f.inbFixture, signer, blobKey, models.RestoreOptions{
+		Strategy:  string(types.RestoreStrategyMergeAdd),
+		Selection: &models.RestoreSelection{CommodityIDs: []string{f.tvUUID}},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusCompleted)
+	c.Assert(final.ErrorCount, qt.Equals, 0)
+
+	area := f.areaByUUID(c, f.areaUUID)
+	c.Assert(area.LocationID, qt.Equals, f.locationByUUID(c, f.locUUID).ID)
+	c.Assert(*f.commodityByUUID(c, f.tvUUID).AreaID, qt.Equals, area.ID)
+	c.Assert(f.hasCommodity(c, f.kettleUUID), qt.IsFalse)
+	c.Assert(f.countFilesWithUUID(c, f.areaFile), qt.Equals, 0)
+
+	areaReg := must.Must(f.fs.AreaRegistryFactory.CreateUserRegistry(f.ctx))
+	c.Assert(must.Must(areaReg.List(f.ctx)), qt.HasLen, 1)
+}
+
+// TestINBRestore_SelectionFullReplace: full_replace with a selection replaces
+// only the selected subtree — a commodity added to it after the backup goes,
+// edits inside it are reverted — and previews exactly that.
+func TestINBRestore_SelectionFullReplace(t *testing.T) {
+	c := qt.New(t)
+	signer := testSigner(c)
+	f := newSelectiveFixture(c)
+	blobKey, _ := f.runExport(c, signer)
+
+	f.renameCommodity(c, f.kettleUUID, "Renamed Kettle")
+	f.renameCommodity(c, f.tvUUID, "Renamed TV")
+	comReg := must.Must(f.fs.CommodityRegistryFactory.CreateUserRegistry(f.ctx))
+	toaster := must.Must(comReg.Create(f.ctx, models.Commodity{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{TenantID: "tenant-a", GroupID: f.group.ID, CreatedByUserID: f.user.ID},
+		Name:                     "Toaster",
+		ShortName:                "toaster",
+		Type:                     models.CommodityTypeElectronics,
+		AreaID:                   new(f.kitchenID),
+		Count:                    1,
+		Status:                   models.CommodityStatusInUse,
+		OriginalPriceCurrency:    "USD",
+		Draft:                    true,
+	}))
+	looseUUID := f.addUnassignedCommodity(c, "Added after backup")
+
+	opts := models.RestoreOptions{
+		Strategy:  string(types.RestoreStrategyFullReplace),
+		DryRun:    true,
+		Selection: &models.RestoreSelection{AreaIDs: []string{f.kitchenUUID}},
+	}
+	final, err := restoreInbWithOptions(c, f.inbFixture, signer, blobKey, opts)
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusPreview)
+	preview := final.Preview
+	c.Assert(preview.Locations, qt.HasLen, 1)
+	c.Assert(preview.Locations[0].Action, qt.Equals, models.RestorePreviewActionSkip)
+	c.Assert(preview.Locations[0].Areas, qt.HasLen, 1)
+	c.Assert(preview.Locations[0].Areas[0].ID, qt.Equals, f.kitchenUUID)
+	c.Assert(preview.Locations[0].Areas[0].Action, qt.Equals, models.RestorePreviewActionUpdate)
+	c.Assert(preview.Locations[0].Areas[0].Commodities, qt.Equals, models.RestorePreviewCounts{Update: 1, Delete: 1})
+	c.Assert(preview.Unassigned, qt.Equals, models.RestorePreviewCounts{})
+	c.Assert(preview.Files, qt.Equals, models.RestorePreviewFiles{Upload: 1, UploadBytes: 256})
+
+	opts.DryRun = false
+	final, err = restoreInbWithOptions(c, f.inbFixture, signer, blobKey, opts)
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusCompleted)
+	c.Assert(final.ErrorCount, qt.Equals, 0)
+
+	c.Assert(f.commodityByUUID(c, f.kettleUUID).Name, qt.Equals, "Kettle")
+	c.Assert(f.hasCommodity(c, toaster.UUID), qt.IsFalse)
+	c.Assert(f.countFilesWithUUID(c, f.areaFile), qt.Equals, 1)
+	c.Assert(f.commodityByUUID(c, f.tvUUID).Name, qt.Equals, "Renamed TV")
+	c.Assert(f.hasCommodity(c, looseUUID), qt.IsTrue)
+}
+
+// TestINBRestore_SelectionUnknownIDIsCounted: a selected UUID the archive
+// does not carry is counted as an error; the rest of the selection still
+// restores.
+func TestINBRestore_SelectionUnknownIDIsCounted(t *testing.T) {
+	c := qt.New(t)
+	signer := testSigner(c)
+	f := newSelectiveFixture(c)
+	blobKey, _ := f.runExport(c, signer)
+
+	entityService := services.NewEntityService(f.fs, inbUploadLocation)
+	c.Assert(entityService.DeleteAreaRecursive(f.ctx, f.kitchenID), qt.IsNil)
+
+	final, err := restoreInbWithOptions(c, f.inbFixture, signer, blobKey, models.RestoreOptions{
+		Strategy: string(types.RestoreStrategyMergeAdd),
+		Selection: &models.RestoreSelection{
+			AreaIDs:      []string{f.kitchenUUID},
+			CommodityIDs: []string{"no-such-commodity"},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(final.Status, qt.Equals, models.RestoreStatusCompleted)
+	c.Assert(final.ErrorCount, qt.Equals, 1)
+	c.Assert(f.hasCommodity(c, f.kettleUUID), qt.IsTrue)
+}
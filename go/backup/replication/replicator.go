@@ -0,0 +1,203 @@
+// Package replication copies completed backup exports to an off-site blob
+// bucket and brings replicated archives back for a restore.
+package replication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"gocloud.dev/blob"
+)
+
+var (
+	// ErrInvalidTargetURL is returned by NewReplicator for a target that is
+	// not a bucket URL.
+	ErrInvalidTargetURL = errx.NewSentinel("invalid backup replication URL")
+	// ErrReplicaMismatch is returned when the copy read back from the
+	// replication target does not match what was written.
+	ErrReplicaMismatch = errx.NewSentinel("replica does not match the source archive")
+	// ErrForeignReplicaURL is returned by ResolveObjectURL for a URL that
+	// does not point into the configured replication target.
+	ErrForeignReplicaURL = errx.NewSentinel("URL does not point into the backup replication target")
+)
+
+// Result describes one object copied between buckets.
+type Result struct {
+	// URL is the object's URL in the replication target.
+	URL    string
+	SHA256 string
+	Size   int64
+}
+
+// Replicator moves export archives between the upload bucket and the
+// replication target. Both locations are gocloud bucket URLs (s3://,
+// azblob://, gs://, file://); an object keeps its upload-bucket key in the
+// target, so the tenant prefix travels with it.
+type Replicator struct {
+	uploadLocation string
+	targetLocation string
+	target         *url.URL
+}
+
+// NewReplicator validates the target URL and returns a Replicator.
+func NewReplicator(uploadLocation, targetLocation string) (*Replicator, error) {
+	target, err := url.Parse(targetLocation)
+	if err != nil {
+		return nil, errxtrace.Classify(ErrInvalidTargetURL, errx.Attrs("reason", err.Error()))
+	}
+	if target.Scheme == "" {
+		return nil, errxtrace.Classify(ErrInvalidTargetURL, errx.Attrs("reason", "missing scheme"))
+	}
+	return &Replicator{
+		uploadLocation: uploadLocation,
+		targetLocation: targetLocation,
+		target:         target,
+	}, nil
+}
+
+// ObjectURL returns the URL identifying key inside the replication target:
+// the target URL with the key appended to its path.
+func (r *Replicator) ObjectURL(key string) string {
+	u := *r.target
+	u.Path = strings.TrimSuffix(r.target.Path, "/") + "/" + key
+	u.RawPath = ""
+	return u.String()
+}
+
+// ResolveObjectURL is the inverse of ObjectURL. It accepts only URLs in the
+// configured target (same scheme, host, query and a path below the target
+// path) and returns the object key.
+func (r *Replicator) ResolveObjectURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", errxtrace.Classify(ErrForeignReplicaURL, errx.Attrs("reason", err.Error()))
+	}
+	if u.Scheme != r.target.Scheme || u.Host != r.target.Host || u.Query().Encode() != r.target.Query().Encode() {
+		return "", errxtrace.Classify(ErrForeignReplicaURL, errx.Attrs("reason", "different bucket"))
+	}
+	prefix := strings.TrimSuffix(r.target.Path, "/") + "/"
+	key, ok := strings.CutPrefix(u.Path, prefix)
+	if !ok || key == "" || path.Clean(key) != key || strings.HasPrefix(key, "../") {
+		return "", errxtrace.Classify(ErrForeignReplicaURL, errx.Attrs("reason", "invalid object path"))
+	}
+	return key, nil
+}
+
+// Replicate copies key from the upload bucket into the replication target
+// under the same key, then reads the copy back and verifies that its
+// SHA-256 and size match the source.
+func (r *Replicator) Replicate(ctx context.Context, key string) (Result, error) {
+	written, err := r.copy(ctx, r.uploadLocation, key, r.targetLocation, key)
+	if err != nil {
+		return Result{}, err
+	}
+
+	target, err := blob.OpenBucket(ctx, r.targetLocation)
+	if err != nil {
+		return Result{}, errxtrace.Wrap("failed to open replication bucket", err)
+	}
+	defer target.Close()
+
+	reader, err := target.NewReader(ctx, key, nil)
+	if err != nil {
+		return Result{}, errxtrace.Wrap("failed to read back replica", err)
+	}
+	defer reader.Close()
+	readBack, err := digest(reader)
+	if err != nil {
+		return Result{}, errxtrace.Wrap("failed to read back replica", err)
+	}
+	if readBack != written {
+		return Result{}, errxtrace.Classify(ErrReplicaMismatch,
+			errx.Attrs("source_sha256", written.SHA256, "replica_sha256", readBack.SHA256))
+	}
+
+	written.URL = r.ObjectURL(key)
+	return written, nil
+}
+
+// Fetch copies key from the replication target into the upload bucket at
+// destKey and returns the digest of the bytes copied. When expectedSHA256
+// is set and the copy does not match it, the copy is removed and
+// ErrReplicaMismatch is returned.
+func (r *Replicator) Fetch(ctx context.Context, key, destKey, expectedSHA256 string) (Result, error) {
+	result, err := r.copy(ctx, r.targetLocation, key, r.uploadLocation, destKey)
+	if err != nil {
+		return Result{}, err
+	}
+	if expectedSHA256 != "" && result.SHA256 != expectedSHA256 {
+		if err := r.removeUpload(ctx, destKey); err != nil {
+			slog.Error("Failed to remove mismatched replica copy", "key", destKey, "error", err)
+		}
+		return Result{}, errxtrace.Classify(ErrReplicaMismatch,
+			errx.Attrs("expected_sha256", expectedSHA256, "replica_sha256", result.SHA256))
+	}
+	result.URL = r.ObjectURL(key)
+	return result, nil
+}
+
+func (r *Replicator) removeUpload(ctx context.Context, key string) error {
+	b, err := blob.OpenBucket(ctx, r.uploadLocation)
+	if err != nil {
+		return errxtrace.Wrap("failed to open upload bucket", err)
+	}
+	defer b.Close()
+	return b.Delete(ctx, key)
+}
+
+func (*Replicator) copy(ctx context.Context, srcLocation, srcKey, dstLocation, dstKey string) (Result, error) {
+	src, err := blob.OpenBucket(ctx, srcLocation)
+	if err != nil {
+		return Result{}, errxtrace.Wrap("failed to open source bucket", err)
+	}
+	defer src.Close()
+
+	dst, err := blob.OpenBucket(ctx, dstLocation)
+	if err != nil {
+		return Result{}, errxtrace.Wrap("failed to open destination bucket", err)
+	}
+	defer dst.Close()
+
+	reader, err := src.NewReader(ctx, srcKey, nil)
+	if err != nil {
+		return Result{}, errxtrace.Wrap("failed to open source object", err)
+	}
+	defer reader.Close()
+
+	// Cancelling the context before Close aborts the write, so a failed
+	// copy never leaves a truncated object behind.
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	writer, err := dst.NewWriter(writeCtx, dstKey, &blob.WriterOptions{ContentType: reader.ContentType()})
+	if err != nil {
+		return Result{}, errxtrace.Wrap("failed to open destination object", err)
+	}
+
+	result, err := digest(io.TeeReader(reader, writer))
+	if err != nil {
+		cancel()
+		_ = writer.Close()
+		return Result{}, errxtrace.Wrap("failed to copy object", err)
+	}
+	if err := writer.Close(); err != nil {
+		return Result{}, errxtrace.Wrap("failed to finish writing object", err)
+	}
+	return result, nil
+}
+
+func digest(r io.Reader) (Result, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{SHA256: hex.EncodeToString(h.Sum(nil)), Size: n}, nil
+}
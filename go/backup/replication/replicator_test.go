@@ -0,0 +1,164 @@
+package replication_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"gocloud.dev/blob"
+
+	"github.com/denisvmedia/inventario/backup/replication"
+	_ "github.com/denisvmedia/inventario/internal/fileblob" // register the file:// driver
+)
+
+func newBucketURL(c *qt.C) string {
+	dir := c.TempDir()
+	if runtime.GOOS == "windows" {
+		return "file:///" + dir + "?create_dir=1"
+	}
+	return "file://" + dir + "?create_dir=1"
+}
+
+func writeBlob(c *qt.C, location, key string, data []byte) {
+	c.Helper()
+	ctx := context.Background()
+	b, err := blob.OpenBucket(ctx, location)
+	c.Assert(err, qt.IsNil)
+	defer b.Close()
+	c.Assert(b.WriteAll(ctx, key, data, nil), qt.IsNil)
+}
+
+func readBlob(c *qt.C, location, key string) []byte {
+	c.Helper()
+	ctx := context.Background()
+	b, err := blob.OpenBucket(ctx, location)
+	c.Assert(err, qt.IsNil)
+	defer b.Close()
+	data, err := b.ReadAll(ctx, key)
+	c.Assert(err, qt.IsNil)
+	return data
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestReplicator_ReplicateAndFetch(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	uploads := newBucketURL(c)
+	target := newBucketURL(c)
+	data := []byte("signed backup archive")
+	key := "t/tenant-1/exports/backup_full_database_20260513.inb"
+	writeBlob(c, uploads, key, data)
+
+	r, err := replication.NewReplicator(uploads, target)
+	c.Assert(err, qt.IsNil)
+
+	result, err := r.Replicate(ctx, key)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.SHA256, qt.Equals, sha256Hex(data))
+	c.Assert(result.Size, qt.Equals, int64(len(data)))
+	c.Assert(result.URL, qt.Equals, r.ObjectURL(key))
+	c.Assert(readBlob(c, target, key), qt.DeepEquals, data)
+
+	fetched, err := r.Fetch(ctx, key, "t/tenant-1/restores/upload/copy.inb", result.SHA256)
+	c.Assert(err, qt.IsNil)
+	c.Assert(fetched.SHA256, qt.Equals, result.SHA256)
+	c.Assert(readBlob(c, uploads, "t/tenant-1/restores/upload/copy.inb"), qt.DeepEquals, data)
+}
+
+func TestReplicator_FetchRejectsTamperedReplica(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	uploads := newBucketURL(c)
+	target := newBucketURL(c)
+	key := "t/tenant-1/exports/a.inb"
+	writeBlob(c, target, key, []byte("tampered"))
+
+	r, err := replication.NewReplicator(uploads, target)
+	c.Assert(err, qt.IsNil)
+
+	dest := "t/tenant-1/restores/upload/a.inb"
+	_, err = r.Fetch(ctx, key, dest, sha256Hex([]byte("original")))
+	c.Assert(err, qt.ErrorIs, replication.ErrReplicaMismatch)
+
+	b, err := blob.OpenBucket(ctx, uploads)
+	c.Assert(err, qt.IsNil)
+	defer b.Close()
+	exists, err := b.Exists(ctx, dest)
+	c.Assert(err, qt.IsNil)
+	c.Assert(exists, qt.IsFalse)
+}
+
+func TestReplicator_ReplicateMissingSource(t *testing.T) {
+	c := qt.New(t)
+	r, err := replication.NewReplicator(newBucketURL(c), newBucketURL(c))
+	c.Assert(err, qt.IsNil)
+
+	_, err = r.Replicate(context.Background(), "t/tenant-1/exports/missing.inb")
+	c.Assert(err, qt.ErrorMatches, ".*failed to open source object.*")
+}
+
+func TestNewReplicator_RejectsURLWithoutScheme(t *testing.T) {
+	c := qt.New(t)
+	_, err := replication.NewReplicator("file:///uploads", "/var/backups")
+	c.Assert(err, qt.ErrorIs, replication.ErrInvalidTargetURL)
+}
+
+func TestReplicator_ObjectURLRoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		target string
+		key    string
+		want   string
+	}{
+		{
+			name:   "bucket root",
+			target: "s3://backups?region=eu-west-1",
+			key:    "t/tenant-1/exports/a.inb",
+			want:   "s3://backups/t/tenant-1/exports/a.inb?region=eu-west-1",
+		},
+		{
+			name:   "directory",
+			target: "file:///var/backups/",
+			key:    "t/tenant-1/exports/a.inb",
+			want:   "file:///var/backups/t/tenant-1/exports/a.inb",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := qt.New(t)
+			r, err := replication.NewReplicator("mem://", tc.target)
+			c.Assert(err, qt.IsNil)
+			c.Assert(r.ObjectURL(tc.key), qt.Equals, tc.want)
+			key, err := r.ResolveObjectURL(tc.want)
+			c.Assert(err, qt.IsNil)
+			c.Assert(key, qt.Equals, tc.key)
+		})
+	}
+}
+
+func TestReplicator_ResolveObjectURLRejectsForeignURLs(t *testing.T) {
+	r, err := replication.NewReplicator("mem://", "s3://backups/inventario?region=eu-west-1")
+	qt.Assert(t, err, qt.IsNil)
+
+	for _, raw := range []string{
+		"s3://other/inventario/t/x/exports/a.inb?region=eu-west-1",
+		"gs://backups/inventario/t/x/exports/a.inb?region=eu-west-1",
+		"s3://backups/inventario/t/x/exports/a.inb?region=us-east-1",
+		"s3://backups/elsewhere/t/x/exports/a.inb?region=eu-west-1",
+		"s3://backups/inventario/?region=eu-west-1",
+		"s3://backups/inventario/t/x/../../secret?region=eu-west-1",
+	} {
+		t.Run(raw, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := r.ResolveObjectURL(raw)
+			c.Assert(err, qt.ErrorIs, replication.ErrForeignReplicaURL)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/denisvmedia/inventario/internal/blobkeys"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+const (
+	defaultWorkerInterval = 10 * time.Minute
+	// MaxAttempts bounds how many times one export is tried before the
+	// worker gives up on it; the last error stays on the export row.
+	MaxAttempts = 5
+	// batchSize bounds the exports copied per sweep so a large backlog
+	// (e.g. right after replication is first enabled) is spread over
+	// several sweeps.
+	batchSize = 20
+)
+
+var (
+	backupReplicationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inventario_backup_replications_total",
+		Help: "Number of exports copied to the backup replication target and verified.",
+	})
+	backupReplicationFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inventario_backup_replication_failures_total",
+		Help: "Number of failed attempts to replicate an export.",
+	})
+)
+
+// PauseChecker reports whether a worker type is soft-paused. Declared
+// locally for the same reason as export.PauseChecker.
+type PauseChecker interface {
+	IsPaused(models.WorkerType) bool
+}
+
+// Stats summarises one RunOnce sweep.
+type Stats struct {
+	Replicated int
+	Failed     int
+}
+
+// Worker copies completed exports to the replication target. Each
+// attempt is recorded on the export row: a verified copy marks it
+// replicated with the replica URL and SHA-256, a failure marks it failed
+// with the error and is retried on later sweeps up to MaxAttempts.
+type Worker struct {
+	factorySet *registry.FactorySet
+	replicator *Replicator
+	interval   time.Duration
+	clock      func() time.Time
+	pause      PauseChecker
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+}
+
+// WorkerOption customises a Worker.
+type WorkerOption func(*workerOptions)
+
+type workerOptions struct {
+	interval time.Duration
+	clock    func() time.Time
+	pause    PauseChecker
+}
+
+// WithWorkerInterval overrides the default tick cadence. Non-positive
+// values are ignored.
+func WithWorkerInterval(d time.Duration) WorkerOption {
+	return func(o *workerOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+// WithWorkerClock overrides the now-source stamped on replicated exports.
+func WithWorkerClock(now func() time.Time) WorkerOption {
+	return func(o *workerOptions) {
+		if now != nil {
+			o.clock = now
+		}
+	}
+}
+
+// WithWorkerPauseController wires the soft-pause controller so the worker
+// skips its sweep while the backup-replication worker type is paused. A
+// nil checker leaves the worker unpaused.
+func WithWorkerPauseController(pc PauseChecker) WorkerOption {
+	return func(o *workerOptions) {
+		if pc != nil {
+			o.pause = pc
+		}
+	}
+}
+
+// NewWorker constructs the replication worker. Default cadence: ten
+// minutes.
+func NewWorker(factorySet *registry.FactorySet, replicator *Replicator, opts ...WorkerOption) *Worker {
+	options := workerOptions{
+		interval: defaultWorkerInterval,
+		clock:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Worker{
+		factorySet: factorySet,
+		replicator: replicator,
+		interval:   options.interval,
+		clock:      options.clock,
+		pause:      options.pause,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine. No-op when no factory set is configured.
+func (w *Worker) Start(ctx context.Context) {
+	if w.factorySet == nil {
+		slog.Warn("Backup replication worker: no factory set configured, skipping startup")
+		return
+	}
+	w.wg.Go(func() {
+		w.run(ctx)
+	})
+	slog.Info("Backup replication worker started", "interval", w.interval)
+}
+
+// Stop signals the worker and waits for the goroutine to exit.
+func (w *Worker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+	})
+	w.wg.Wait()
+	slog.Info("Backup replication worker stopped")
+}
+
+func (w *Worker) run(ctx context.Context) {
+	w.tick(ctx)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *Worker) tick(ctx context.Context) {
+	if w.pause != nil && w.pause.IsPaused(models.WorkerTypeBackupReplication) {
+		return
+	}
+
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeBackupReplication))
+	defer span.End()
+
+	stats, err := w.RunOnce(ctx)
+	if err != nil {
+		slog.Error("Backup replication sweep failed", "error", err)
+		return
+	}
+	backupReplicationsTotal.Add(float64(stats.Replicated))
+	backupReplicationFailuresTotal.Add(float64(stats.Failed))
+	if stats.Replicated+stats.Failed > 0 {
+		slog.Info("Backup replication sweep completed",
+			"replicated", stats.Replicated,
+			"failed", stats.Failed,
+		)
+	}
+}
+
+// RunOnce replicates one batch of pending exports. A non-nil error is
+// only returned when listing them fails; per-export failures are recorded
+// on the export and counted in Stats.Failed.
+func (w *Worker) RunOnce(ctx context.Context) (Stats, error) {
+	var stats Stats
+	if w.factorySet == nil || w.factorySet.ExportRegistryFactory == nil {
+		return stats, errxtrace.Wrap("backup replication: missing ExportRegistryFactory", registry.ErrFieldRequired)
+	}
+	expReg := w.factorySet.ExportRegistryFactory.CreateServiceRegistry()
+	exports, err := expReg.ListPendingReplication(ctx, MaxAttempts, batchSize)
+	if err != nil {
+		return stats, errxtrace.Wrap("backup replication: list pending", err)
+	}
+	for _, export := range exports {
+		if ctx.Err() != nil {
+			break
+		}
+		ok, err := w.replicateOne(ctx, expReg, export)
+		if err != nil {
+			slog.Error("Failed to record backup replication",
+				"export_id", export.ID,
+				"error", err,
+			)
+			continue
+		}
+		if ok {
+			stats.Replicated++
+		} else {
+			stats.Failed++
+		}
+	}
+	return stats, nil
+}
+
+// replicateOne copies one export and records the outcome. It reports
+// whether the copy succeeded; the error is about recording the outcome.
+func (w *Worker) replicateOne(ctx context.Context, expReg registry.ExportRegistry, export *models.Export) (bool, error) {
+	result, replErr := w.replicate(ctx, export)
+
+	// Re-read the row: the copy can take a while and the export may have
+	// been edited or deleted meanwhile.
+	current, err := expReg.Get(ctx, export.ID)
+	if errors.Is(err, registry.ErrNotFound) || errors.Is(err, registry.ErrDeleted) {
+		return replErr == nil, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	current.ReplicationAttempts++
+	if replErr != nil {
+		slog.Warn("Backup replication failed",
+			"export_id", export.ID,
+			"attempt", current.ReplicationAttempts,
+			"error", replErr,
+		)
+		current.ReplicationStatus = models.ExportReplicationStatusFailed
+		current.ReplicationError = replErr.Error()
+	} else {
+		current.ReplicationStatus = models.ExportReplicationStatusReplicated
+		current.ReplicaURL = result.URL
+		current.ReplicaSHA256 = result.SHA256
+		current.ReplicatedAt = models.NewPTimestamp(w.clock())
+		current.ReplicationError = ""
+	}
+	if _, err := expReg.Update(ctx, *current); err != nil {
+		return false, errxtrace.Wrap("failed to update export replication status", err)
+	}
+	return replErr == nil, nil
+}
+
+func (w *Worker) replicate(ctx context.Context, export *models.Export) (Result, error) {
+	// The worker reads without RLS; refuse a key outside the export's own
+	// tenant namespace, as the restore processor does.
+	prefix := blobkeys.TenantPrefix(export.TenantID)
+	if prefix == "" || !strings.HasPrefix(export.FilePath, prefix) {
+		return Result{}, errors.New("export file path is outside the tenant namespace")
+	}
+	return w.replicator.Replicate(ctx, export.FilePath)
+}
@@ -0,0 +1,90 @@
+package replication_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/backup/replication"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func TestWorker_RunOnce(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	uploads := newBucketURL(c)
+	target := newBucketURL(c)
+	now := time.Date(2026, 5, 13, 3, 0, 0, 0, time.UTC)
+
+	factorySet := memory.NewFactorySet()
+	expReg := factorySet.ExportRegistryFactory.CreateServiceRegistry()
+	seed := func(filePath string) *models.Export {
+		created, err := expReg.Create(ctx, models.Export{
+			TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{TenantID: "tenant-1", GroupID: "group-1"},
+			Type:                     models.ExportTypeFullDatabase,
+			Status:                   models.ExportStatusCompleted,
+			FilePath:                 filePath,
+			CreatedDate:              models.NewPTimestamp(now),
+			CompletedDate:            models.NewPTimestamp(now),
+		})
+		c.Assert(err, qt.IsNil)
+		return created
+	}
+	data := []byte("archive")
+	writeBlob(c, uploads, "t/tenant-1/exports/ok.inb", data)
+	ok := seed("t/tenant-1/exports/ok.inb")
+	missing := seed("t/tenant-1/exports/missing.inb")
+	writeBlob(c, uploads, "t/tenant-2/exports/foreign.inb", data)
+	foreign := seed("t/tenant-2/exports/foreign.inb")
+
+	replicator, err := replication.NewReplicator(uploads, target)
+	c.Assert(err, qt.IsNil)
+	worker := replication.NewWorker(factorySet, replicator, replication.WithWorkerClock(func() time.Time { return now }))
+
+	stats, err := worker.RunOnce(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, replication.Stats{Replicated: 1, Failed: 2})
+
+	got, err := expReg.Get(ctx, ok.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ReplicationStatus, qt.Equals, models.ExportReplicationStatusReplicated)
+	c.Assert(got.ReplicaURL, qt.Equals, replicator.ObjectURL("t/tenant-1/exports/ok.inb"))
+	c.Assert(got.ReplicaSHA256, qt.Equals, sha256Hex(data))
+	c.Assert(got.ReplicatedAt.ToTime().Equal(now), qt.IsTrue)
+	c.Assert(got.ReplicationAttempts, qt.Equals, 1)
+	c.Assert(readBlob(c, target, "t/tenant-1/exports/ok.inb"), qt.DeepEquals, data)
+
+	got, err = expReg.Get(ctx, missing.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ReplicationStatus, qt.Equals, models.ExportReplicationStatusFailed)
+	c.Assert(got.ReplicationError, qt.Not(qt.Equals), "")
+	c.Assert(got.ReplicaURL, qt.Equals, "")
+
+	got, err = expReg.Get(ctx, foreign.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ReplicationStatus, qt.Equals, models.ExportReplicationStatusFailed)
+	c.Assert(got.ReplicationError, qt.Matches, ".*outside the tenant namespace.*")
+
+	// A failed export is retried until MaxAttempts, then left alone; a
+	// replicated one is never picked up again.
+	writeBlob(c, uploads, "t/tenant-1/exports/missing.inb", data)
+	stats, err = worker.RunOnce(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, replication.Stats{Replicated: 1, Failed: 1})
+	got, err = expReg.Get(ctx, missing.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ReplicationStatus, qt.Equals, models.ExportReplicationStatusReplicated)
+	c.Assert(got.ReplicationError, qt.Equals, "")
+	c.Assert(got.ReplicationAttempts, qt.Equals, 2)
+
+	for range replication.MaxAttempts {
+		_, err = worker.RunOnce(ctx)
+		c.Assert(err, qt.IsNil)
+	}
+	got, err = expReg.Get(ctx, foreign.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ReplicationAttempts, qt.Equals, replication.MaxAttempts)
+}
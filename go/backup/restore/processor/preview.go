@@ -0,0 +1,464 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/internal/validationctx"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// restorePreviewer builds the models.RestorePreview of a dry-run. The walker
+// reports each archived entity once its strategy handler accepted it; the
+// previewer decides the action from the group's data as it was before the
+// restore (current), which for merge strategies is the same snapshot the
+// handlers consult.
+//
+// full_replace reports an entity present on both sides as an update (it is
+// recreated under the same UUID) and everything the archive does not carry
+// as a delete — under a selection, only inside the selected subtrees. The
+// merge strategies never delete.
+//
+// All methods are no-ops on a nil previewer, so the walker can report
+// unconditionally.
+type restorePreviewer struct {
+	strategy      types.RestoreStrategy
+	groupCurrency string
+	current       *types.ExistingEntities
+	selection     *restoreSelection
+
+	// Archived entities in archive order, keyed by UUID.
+	locationOrder []string
+	locations     map[string]*models.RestorePreviewLocation
+	areaOrder     map[string][]string // location UUID → area UUIDs
+	areas         map[string]*models.RestorePreviewArea
+	commodities   map[string]bool
+	files         map[string]bool
+
+	unassigned models.RestorePreviewCounts
+	fileStats  models.RestorePreviewFiles
+	conflicts  []models.RestoreConflict
+}
+
+// newRestorePreviewer snapshots the group's current data. The merge
+// strategies and selective restores already loaded it into existing; a plain
+// full_replace leaves existing empty, so it is loaded here.
+func (l *RestoreOperationProcessor) newRestorePreviewer(
+	ctx context.Context,
+	options types.RestoreOptions,
+	existing *types.ExistingEntities,
+) (*restorePreviewer, error) {
+	current := existing
+	if options.Strategy == types.RestoreStrategyFullReplace && options.Selection == nil {
+		current = &types.ExistingEntities{}
+		if err := l.loadExistingEntities(ctx, current); err != nil {
+			return nil, errxtrace.Wrap("failed to load existing entities for preview", err)
+		}
+	}
+	groupCurrency, _ := validationctx.GroupCurrencyFromContext(ctx)
+	return &restorePreviewer{
+		strategy:      options.Strategy,
+		groupCurrency: groupCurrency,
+		current:       current,
+		selection:     l.selection,
+		locations:     map[string]*models.RestorePreviewLocation{},
+		areaOrder:     map[string][]string{},
+		areas:         map[string]*models.RestorePreviewArea{},
+		commodities:   map[string]bool{},
+		files:         map[string]bool{},
+	}, nil
+}
+
+func (p *restorePreviewer) action(exists bool) models.RestorePreviewAction {
+	switch {
+	case !exists:
+		return models.RestorePreviewActionCreate
+	case p.strategy == types.RestoreStrategyMergeAdd:
+		return models.RestorePreviewActionSkip
+	default:
+		return models.RestorePreviewActionUpdate
+	}
+}
+
+// checksContent reports whether a same-UUID entity with different content is
+// a conflict. Under full_replace the group's copy is deleted anyway.
+func (p *restorePreviewer) checksContent() bool {
+	return p.strategy != types.RestoreStrategyFullReplace
+}
+
+func (p *restorePreviewer) location(loc *types.INBLocation) {
+	if p == nil {
+		return
+	}
+	existing := p.current.Locations[loc.ID]
+	p.addLocation(loc.ID, loc.Name, p.action(existing != nil))
+	if existing == nil || !p.checksContent() {
+		return
+	}
+	var diff []string
+	if existing.Name != loc.Name {
+		diff = append(diff, "name")
+	}
+	if existing.Address != loc.Address {
+		diff = append(diff, "address")
+	}
+	p.contentConflict("location", loc.ID, loc.Name, diff)
+}
+
+// parentLocation records a location restored only as the parent of a
+// selected descendant: created when missing, otherwise untouched.
+func (p *restorePreviewer) parentLocation(loc *types.INBLocation, exists bool) {
+	if p == nil {
+		return
+	}
+	p.addLocation(loc.ID, loc.Name, parentAction(exists))
+}
+
+// parentArea is parentLocation for an area.
+func (p *restorePreviewer) parentArea(a *types.INBArea, exists bool) {
+	if p == nil {
+		return
+	}
+	p.addArea(a.LocationID, a.ID, a.Name, parentAction(exists))
+}
+
+func parentAction(exists bool) models.RestorePreviewAction {
+	if exists {
+		return models.RestorePreviewActionSkip
+	}
+	return models.RestorePreviewActionCreate
+}
+
+func (p *restorePreviewer) area(a *types.INBArea) {
+	if p == nil {
+		return
+	}
+	existing := p.current.Areas[a.ID]
+	p.addArea(a.LocationID, a.ID, a.Name, p.action(existing != nil))
+	if existing == nil || !p.checksContent() {
+		return
+	}
+	var diff []string
+	if existing.Name != a.Name {
+		diff = append(diff, "name")
+	}
+	if p.locationUUID(existing.LocationID) != a.LocationID {
+		diff = append(diff, "location")
+	}
+	p.contentConflict("area", a.ID, a.Name, diff)
+}
+
+func (p *restorePreviewer) areaMissingLocation(a *types.INBArea) {
+	if p == nil {
+		return
+	}
+	p.conflicts = append(p.conflicts, models.RestoreConflict{
+		Kind:       models.RestoreConflictMissingLocation,
+		EntityType: "area",
+		EntityID:   a.ID,
+		Name:       a.Name,
+		Detail:     fmt.Sprintf("location %s is neither in the archive nor in the group", a.LocationID),
+	})
+}
+
+// commodity records an accepted commodity. archived is the converted model,
+// whose AreaID is already the destination DB id; c.AreaID is the archived
+// area UUID ("" for an area-less commodity).
+func (p *restorePreviewer) commodity(c *types.INBCommodity, archived *models.Commodity) {
+	if p == nil {
+		return
+	}
+	p.commodities[c.ID] = true
+	existing := p.current.Commodities[c.ID]
+	action := p.action(existing != nil)
+	if c.AreaID == "" {
+		p.unassigned.Add(action)
+	} else {
+		p.areaEntry(c.AreaID).Commodities.Add(action)
+	}
+
+	if p.groupCurrency != "" && c.AcquisitionCurrency != "" && c.AcquisitionCurrency != p.groupCurrency {
+		p.conflicts = append(p.conflicts, models.RestoreConflict{
+			Kind:       models.RestoreConflictCurrencyMismatch,
+			EntityType: "commodity",
+			EntityID:   c.ID,
+			Name:       c.Name,
+			Detail:     fmt.Sprintf("prices were recorded in %s, the group currency is %s", c.AcquisitionCurrency, p.groupCurrency),
+		})
+	}
+
+	if existing == nil || !p.checksContent() {
+		return
+	}
+	p.contentConflict("commodity", c.ID, c.Name, p.commodityDiff(existing, archived, c.AreaID))
+}
+
+func (p *restorePreviewer) commodityMissingArea(c *types.INBCommodity) {
+	if p == nil {
+		return
+	}
+	p.conflicts = append(p.conflicts, models.RestoreConflict{
+		Kind:       models.RestoreConflictMissingArea,
+		EntityType: "commodity",
+		EntityID:   c.ID,
+		Name:       c.Name,
+		Detail:     fmt.Sprintf("area %s is neither in the archive nor in the group", c.AreaID),
+	})
+}
+
+// file records a file member: uploaded when the strategy writes it, skipped
+// otherwise.
+func (p *restorePreviewer) file(uuid string, upload bool, size int64) {
+	if p == nil {
+		return
+	}
+	p.files[uuid] = true
+	if !upload {
+		p.fileStats.Skip++
+		return
+	}
+	p.fileStats.Upload++
+	p.fileStats.UploadBytes += size
+}
+
+func (p *restorePreviewer) commodityDiff(existing, archived *models.Commodity, areaUUID string) []string {
+	var diff []string
+	if existing.Name != archived.Name {
+		diff = append(diff, "name")
+	}
+	if existing.ShortName != archived.ShortName {
+		diff = append(diff, "short_name")
+	}
+	if existing.Type != archived.Type {
+		diff = append(diff, "type")
+	}
+	if existing.Count != archived.Count {
+		diff = append(diff, "count")
+	}
+	if existing.Status != archived.Status {
+		diff = append(diff, "status")
+	}
+	if existing.SerialNumber != archived.SerialNumber {
+		diff = append(diff, "serial_number")
+	}
+	if !existing.OriginalPrice.Equal(archived.OriginalPrice) || existing.OriginalPriceCurrency != archived.OriginalPriceCurrency {
+		diff = append(diff, "original_price")
+	}
+	if !existing.CurrentPrice.Equal(archived.CurrentPrice) {
+		diff = append(diff, "current_price")
+	}
+	if existing.Comments != archived.Comments {
+		diff = append(diff, "comments")
+	}
+	existingArea := ""
+	if existing.AreaID != nil {
+		existingArea = p.areaUUID(*existing.AreaID)
+	}
+	if existingArea != areaUUID {
+		diff = append(diff, "area")
+	}
+	return diff
+}
+
+func (p *restorePreviewer) contentConflict(entityType, uuid, name string, diff []string) {
+	if len(diff) == 0 {
+		return
+	}
+	p.conflicts = append(p.conflicts, models.RestoreConflict{
+		Kind:       models.RestoreConflictContentMismatch,
+		EntityType: entityType,
+		EntityID:   uuid,
+		Name:       name,
+		Detail:     "differs in: " + strings.Join(diff, ", "),
+	})
+}
+
+func (p *restorePreviewer) addLocation(uuid, name string, action models.RestorePreviewAction) {
+	if _, ok := p.locations[uuid]; ok {
+		return
+	}
+	p.locationOrder = append(p.locationOrder, uuid)
+	p.locations[uuid] = &models.RestorePreviewLocation{ID: uuid, Name: name, Action: action}
+}
+
+func (p *restorePreviewer) addArea(locationUUID, uuid, name string, action models.RestorePreviewAction) {
+	if _, ok := p.areas[uuid]; ok {
+		return
+	}
+	if _, ok := p.locations[locationUUID]; !ok {
+		// Parent not in the archive: list the group's own location as
+		// untouched so the area still has a home in the report.
+		p.addLocation(locationUUID, p.locationName(locationUUID), models.RestorePreviewActionSkip)
+	}
+	p.areaOrder[locationUUID] = append(p.areaOrder[locationUUID], uuid)
+	p.areas[uuid] = &models.RestorePreviewArea{ID: uuid, Name: name, Action: action}
+}
+
+// areaEntry returns the report entry of an area a commodity is placed in. A
+// group area the archive does not carry is listed as untouched.
+func (p *restorePreviewer) areaEntry(uuid string) *models.RestorePreviewArea {
+	if entry, ok := p.areas[uuid]; ok {
+		return entry
+	}
+	existing := p.current.Areas[uuid]
+	locationUUID, name := "", ""
+	if existing != nil {
+		locationUUID, name = p.locationUUID(existing.LocationID), existing.Name
+	}
+	p.addArea(locationUUID, uuid, name, models.RestorePreviewActionSkip)
+	return p.areas[uuid]
+}
+
+func (p *restorePreviewer) locationName(uuid string) string {
+	if existing := p.current.Locations[uuid]; existing != nil {
+		return existing.Name
+	}
+	return ""
+}
+
+// locationUUID maps a current location DB id to its UUID.
+func (p *restorePreviewer) locationUUID(id string) string {
+	for uuid, loc := range p.current.Locations {
+		if loc.ID == id {
+			return uuid
+		}
+	}
+	return ""
+}
+
+// areaUUID maps a current area DB id to its UUID.
+func (p *restorePreviewer) areaUUID(id string) string {
+	for uuid, area := range p.current.Areas {
+		if area.ID == id {
+			return uuid
+		}
+	}
+	return ""
+}
+
+// commodityUUID maps a current commodity DB id to its UUID.
+func (p *restorePreviewer) commodityUUID(id string) string {
+	for uuid, commodity := range p.current.Commodities {
+		if commodity.ID == id {
+			return uuid
+		}
+	}
+	return ""
+}
+
+// Under a selection, full_replace only recreates the included subtrees, so
+// only current entities inside them can be deleted. Locations never are: a
+// selected location is always in the archive.
+
+func (p *restorePreviewer) areaInScope(area *models.Area) bool {
+	return p.selection == nil || p.selection.includedLocations[p.locationUUID(area.LocationID)]
+}
+
+func (p *restorePreviewer) commodityInScope(commodity *models.Commodity) bool {
+	if p.selection == nil {
+		return true
+	}
+	if commodity.AreaID == nil {
+		return false
+	}
+	areaUUID := p.areaUUID(*commodity.AreaID)
+	if p.selection.includedAreas[areaUUID] {
+		return true
+	}
+	area, ok := p.current.Areas[areaUUID]
+	return ok && p.areaInScope(area)
+}
+
+func (p *restorePreviewer) fileInScope(file *models.FileEntity) bool {
+	if p.selection == nil {
+		return true
+	}
+	switch file.LinkedEntityType {
+	case "location":
+		return p.selection.includedLocations[p.locationUUID(file.LinkedEntityID)]
+	case "area":
+		areaUUID := p.areaUUID(file.LinkedEntityID)
+		if p.selection.includedAreas[areaUUID] {
+			return true
+		}
+		area, ok := p.current.Areas[areaUUID]
+		return ok && p.areaInScope(area)
+	case "commodity":
+		commodityUUID := p.commodityUUID(file.LinkedEntityID)
+		if p.selection.includedCommodities[commodityUUID] {
+			return true
+		}
+		commodity, ok := p.current.Commodities[commodityUUID]
+		return ok && p.commodityInScope(commodity)
+	}
+	return false
+}
+
+// addDeletes lists what full_replace removes: every current entity the
+// archive does not carry, limited to the selected subtrees.
+func (p *restorePreviewer) addDeletes() {
+	if p.selection == nil {
+		for _, uuid := range slices.Sorted(maps.Keys(p.current.Locations)) {
+			if _, ok := p.locations[uuid]; !ok {
+				p.addLocation(uuid, p.current.Locations[uuid].Name, models.RestorePreviewActionDelete)
+			}
+		}
+	}
+	for _, uuid := range slices.Sorted(maps.Keys(p.current.Areas)) {
+		if _, ok := p.areas[uuid]; !ok && p.areaInScope(p.current.Areas[uuid]) {
+			area := p.current.Areas[uuid]
+			p.addArea(p.locationUUID(area.LocationID), uuid, area.Name, models.RestorePreviewActionDelete)
+		}
+	}
+	for _, uuid := range slices.Sorted(maps.Keys(p.current.Commodities)) {
+		commodity := p.current.Commodities[uuid]
+		if p.commodities[uuid] || !p.commodityInScope(commodity) {
+			continue
+		}
+		if commodity.AreaID == nil {
+			p.unassigned.Add(models.RestorePreviewActionDelete)
+			continue
+		}
+		p.areaEntry(p.areaUUID(*commodity.AreaID)).Commodities.Add(models.RestorePreviewActionDelete)
+	}
+	for uuid, file := range p.current.Files {
+		if !p.files[uuid] && p.fileInScope(file) {
+			p.fileStats.Delete++
+		}
+	}
+}
+
+// report assembles the preview. Deletes are appended after the archived
+// entities, ordered by UUID.
+func (p *restorePreviewer) report() *models.RestorePreview {
+	if p == nil {
+		return nil
+	}
+	if p.strategy == types.RestoreStrategyFullReplace {
+		p.addDeletes()
+	}
+	preview := &models.RestorePreview{
+		Locations:  make([]models.RestorePreviewLocation, 0, len(p.locationOrder)),
+		Unassigned: p.unassigned,
+		Files:      p.fileStats,
+		Conflicts:  p.conflicts,
+	}
+	if preview.Conflicts == nil {
+		preview.Conflicts = []models.RestoreConflict{}
+	}
+	for _, locUUID := range p.locationOrder {
+		loc := *p.locations[locUUID]
+		loc.Areas = make([]models.RestorePreviewArea, 0, len(p.areaOrder[locUUID]))
+		for _, areaUUID := range p.areaOrder[locUUID] {
+			loc.Areas = append(loc.Areas, *p.areas[areaUUID])
+		}
+		preview.Locations = append(preview.Locations, loc)
+	}
+	return preview
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/denisvmedia/inventario/internal/validationctx"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
 )
 
 // inbMemberLimits bounds the inner tar walk so a hostile archive can't exhaust
@@ -78,15 +79,20 @@ var (
 )
 
 // decodeAndRestore is the default `.inb` decode entry point (#534). It verifies
-// the archive signature against the server's own key BEFORE inflating, then
+// the archive signature against the backup keyring (the server's own key plus
+// the trusted keys) BEFORE inflating, then
 // walks the inner tar applying each entity through the shared model-level
 // strategy handlers. A bad/missing signature, a non-`.inb` upload (e.g. legacy
 // XML), or any framing violation fails the restore hard — there is no bypass.
 func (l *RestoreOperationProcessor) decodeAndRestore(ctx context.Context, reader io.Reader, options types.RestoreOptions) (*types.RestoreStats, error) {
 	stats := &types.RestoreStats{}
 
-	if l.signer == nil {
-		return stats, errx.NewSentinel("backup signer is required to restore an .inb archive")
+	keyring, err := services.BackupKeyring(ctx, l.factorySet, l.signer)
+	if err != nil {
+		return stats, err
+	}
+	if keyring.Len() == 0 {
+		return stats, errx.NewSentinel("backup signer or a trusted backup key is required to restore an .inb archive")
 	}
 
 	// 1. Read the container framing: signature first, then the bounded payload
@@ -114,9 +120,13 @@ func (l *RestoreOperationProcessor) decodeAndRestore(ctx context.Context, reader
 	}
 
 	// 3. Verify the signature BEFORE inflating. Hard fail on mismatch.
-	if err := l.signer.VerifyDigest(digest.Sum(nil), sig); err != nil {
+	fingerprint, err := keyring.VerifyDigest(digest.Sum(nil), sig)
+	if err != nil {
 		return stats, errxtrace.Wrap("backup signature verification failed; refusing to restore", err)
 	}
+	if l.signer == nil || fingerprint != l.signer.Fingerprint() {
+		slog.Info("Restoring backup signed with a trusted key", "restore_operation_id", l.restoreOperationID, "key_fingerprint", fingerprint)
+	}
 
 	// 4. Rewind and inflate. The signature is now trusted, so it is safe to
 	//    decompress the payload.
@@ -140,6 +150,12 @@ func (l *RestoreOperationProcessor) decodeAndRestore(ctx context.Context, reader
 	if err != nil {
 		return stats, err
 	}
+	l.selection = newRestoreSelection(options.Selection)
+	if options.DryRun {
+		if l.preview, err = l.newRestorePreviewer(prep.ctx, options, prep.existing); err != nil {
+			return stats, err
+		}
+	}
 
 	if err := l.applyInbPayload(prep.ctx, tmp, stats, prep.existing, prep.idMapping, options); err != nil {
 		return stats, err
@@ -246,6 +262,8 @@ func (l *RestoreOperationProcessor) applyInbPayload(
 		existing:  existing,
 		idMapping: idMapping,
 		options:   options,
+		preview:   l.preview,
+		selection: l.selection,
 		fileRefs:  map[string]inbPendingFile{},
 	}
 
@@ -307,6 +325,13 @@ func (l *RestoreOperationProcessor) applyInbPayload(
 		return errx.Classify(ErrMissingFileMembers, errx.Attrs("count", len(missing), "members", missing))
 	}
 
+	// A selected UUID the archive never carried is reported, not fatal: the
+	// rest of the selection has been restored.
+	for _, missing := range walker.selection.unmatched() {
+		stats.ErrorCount++
+		stats.Errors = append(stats.Errors, fmt.Sprintf("selected %s is not in the backup", missing))
+	}
+
 	// Patch cover-photo cross-references last: only now is idMapping.Files fully
 	// populated, so a cover that points at one of the just-restored files can be
 	// resolved to its new DB id.
@@ -359,9 +384,13 @@ func (w *inbWalker) applyPendingCovers() error {
 }
 
 // inbPendingFile records the metadata a file member needs once its bytes arrive.
+//
+// excluded marks a reference outside the restore selection: its member is
+// consumed but nothing is restored.
 type inbPendingFile struct {
-	ref  types.INBFileRef
-	link inbFileLink
+	ref      types.INBFileRef
+	link     inbFileLink
+	excluded bool
 }
 
 // inbFileLink is a file's ARCHIVE-side link: the linked entity's immutable UUID
@@ -399,6 +428,13 @@ type inbWalker struct {
 	idMapping *types.IDMapping
 	options   types.RestoreOptions
 
+	// preview records the planned changes of a dry-run; nil otherwise.
+	preview *restorePreviewer
+
+	// selection narrows the walk to the selected subtrees; nil restores
+	// everything.
+	selection *restoreSelection
+
 	// bucket is the destination blob bucket, opened once for the whole walk
 	// (nil in dry-run or when no upload location is configured — file bytes are
 	// then drained, not written).
@@ -465,25 +501,36 @@ func (w *inbWalker) handleLocationMember(hdr *tar.Header, r io.Reader) error {
 	return w.applyLocationDoc(&doc)
 }
 
-// applyLocationDoc recreates one location's full subtree.
+// applyLocationDoc recreates one location's full subtree, or under a
+// selection the selected part of it.
 func (w *inbWalker) applyLocationDoc(doc *types.INBLocationDoc) error {
 	l := w.proc
 
+	locationRole, areaRoles := w.selection.planLocationDoc(doc)
+	if locationRole == roleExcluded {
+		for i := range doc.Commodities {
+			w.excludeFileRefs(&doc.Commodities[i])
+		}
+		return nil
+	}
+
 	location := doc.Location.ConvertToLocation()
 	if err := location.ValidateWithContext(w.ctx); err != nil {
 		return errxtrace.Wrap("invalid location", err, errx.Attrs("location_id", doc.Location.ID))
 	}
 	step := locationStep(doc.Location.Name)
 	l.createRestoreStep(w.ctx, step, models.RestoreStepResultInProgress, "")
-	existingLoc := w.existing.Locations[doc.Location.ID]
-	if err := l.applyStrategyForLocationModel(w.ctx, location, existingLoc, doc.Location.ID, doc.Location.Name, w.stats, w.existing, w.idMapping, w.options); err != nil {
+	if err := w.applyLocation(doc, location, locationRole); err != nil {
 		l.updateRestoreStep(w.ctx, step, models.RestoreStepResultError, err.Error())
 		return err
 	}
 	l.updateRestoreStep(w.ctx, step, models.RestoreStepResultSuccess, "Completed")
 
 	for i := range doc.Areas {
-		if err := w.applyArea(&doc.Areas[i]); err != nil {
+		if areaRoles[doc.Areas[i].ID] == roleExcluded {
+			continue
+		}
+		if err := w.applyArea(&doc.Areas[i], areaRoles[doc.Areas[i].ID]); err != nil {
 			if errors.Is(err, ErrMalformedEntity) {
 				return err
 			}
@@ -492,6 +539,10 @@ func (w *inbWalker) applyLocationDoc(doc *types.INBLocationDoc) error {
 		}
 	}
 	for i := range doc.Commodities {
+		if !w.selection.includesCommodity(&doc.Commodities[i]) {
+			w.excludeFileRefs(&doc.Commodities[i])
+			continue
+		}
 		if err := w.applyCommodity(&doc.Commodities[i]); err != nil {
 			// A malformed field (unparseable price/timestamp) is archive
 			// corruption — abort the whole restore. Other per-item errors stay
@@ -529,6 +580,10 @@ func (w *inbWalker) handleUnassignedMember(hdr *tar.Header, r io.Reader) error {
 // loop; a malformed field aborts the whole restore.
 func (w *inbWalker) applyUnassignedDoc(doc *types.INBUnassignedDoc) error {
 	for i := range doc.Commodities {
+		if !w.selection.includesCommodity(&doc.Commodities[i]) {
+			w.excludeFileRefs(&doc.Commodities[i])
+			continue
+		}
 		if err := w.applyUnassignedCommodity(&doc.Commodities[i]); err != nil {
 			if errors.Is(err, ErrMalformedEntity) {
 				return err
@@ -540,11 +595,36 @@ func (w *inbWalker) applyUnassignedDoc(doc *types.INBUnassignedDoc) error {
 	return nil
 }
 
+// applyLocation applies the location of a document per its selection role.
+func (w *inbWalker) applyLocation(doc *types.INBLocationDoc, location *models.Location, role selectionRole) error {
+	l := w.proc
+	if role == roleParent {
+		_, exists := w.idMapping.Locations[doc.Location.ID]
+		w.preview.parentLocation(&doc.Location, exists)
+		if exists {
+			return nil
+		}
+		return l.createLocation(w.ctx, location, doc.Location.ID, doc.Location.Name, w.stats, w.existing, w.idMapping, w.options)
+	}
+
+	if err := w.clearForSelectiveReplace("location", doc.Location.ID); err != nil {
+		return err
+	}
+	existingLoc := w.existing.Locations[doc.Location.ID]
+	if err := l.applyStrategyForLocationModel(w.ctx, location, existingLoc, doc.Location.ID, doc.Location.Name, w.stats, w.existing, w.idMapping, w.options); err != nil {
+		return err
+	}
+	w.preview.location(&doc.Location)
+	return nil
+}
+
 // applyArea recreates one area, resolving its parent location UUID → DB ID.
-func (w *inbWalker) applyArea(a *types.INBArea) error {
+// An area in the parent role is only created when missing.
+func (w *inbWalker) applyArea(a *types.INBArea, role selectionRole) error {
 	l := w.proc
 	actualLocationID, ok := w.idMapping.Locations[a.LocationID]
 	if !ok || actualLocationID == "" {
+		w.preview.areaMissingLocation(a)
 		return fmt.Errorf("area %s references unmapped location %s", a.ID, a.LocationID)
 	}
 	area := a.ConvertToArea()
@@ -552,8 +632,60 @@ func (w *inbWalker) applyArea(a *types.INBArea) error {
 	if err := area.ValidateWithContext(w.ctx); err != nil {
 		return errxtrace.Wrap("invalid area", err, errx.Attrs("area_id", a.ID))
 	}
+	if role == roleParent {
+		_, exists := w.idMapping.Areas[a.ID]
+		w.preview.parentArea(a, exists)
+		if exists {
+			return nil
+		}
+		return l.createArea(w.ctx, area, a.ID, w.stats, w.existing, w.idMapping, w.options)
+	}
+
+	if err := w.clearForSelectiveReplace("area", a.ID); err != nil {
+		return err
+	}
 	existingArea := w.existing.Areas[a.ID]
-	return l.applyStrategyForAreaModel(w.ctx, area, existingArea, a.ID, w.stats, w.existing, w.idMapping, w.options)
+	if err := l.applyStrategyForAreaModel(w.ctx, area, existingArea, a.ID, w.stats, w.existing, w.idMapping, w.options); err != nil {
+		return err
+	}
+	w.preview.area(a)
+	return nil
+}
+
+// clearForSelectiveReplace removes the group's copy of an included entity
+// (with everything under it) ahead of a selective full_replace, which then
+// recreates it from the archive. Without a selection full_replace cleared the
+// whole group up front, and the merge strategies never delete.
+func (w *inbWalker) clearForSelectiveReplace(entityType, uuid string) error {
+	if w.selection == nil || w.options.Strategy != types.RestoreStrategyFullReplace || w.options.DryRun {
+		return nil
+	}
+	es := w.proc.entityService
+	var err error
+	switch entityType {
+	case "location":
+		if existing := w.existing.Locations[uuid]; existing != nil {
+			err = es.DeleteLocationRecursive(w.ctx, existing.ID)
+			delete(w.existing.Locations, uuid)
+			delete(w.idMapping.Locations, uuid)
+		}
+	case "area":
+		if existing := w.existing.Areas[uuid]; existing != nil {
+			err = es.DeleteAreaRecursive(w.ctx, existing.ID)
+			delete(w.existing.Areas, uuid)
+			delete(w.idMapping.Areas, uuid)
+		}
+	case "commodity":
+		if existing := w.existing.Commodities[uuid]; existing != nil {
+			err = es.DeleteCommodityRecursive(w.ctx, existing.ID)
+			delete(w.existing.Commodities, uuid)
+			delete(w.idMapping.Commodities, uuid)
+		}
+	}
+	if err != nil && !errors.Is(err, registry.ErrNotFound) {
+		return errxtrace.Wrap("failed to clear selected entity for replace", err, errx.Attrs("entity_type", entityType, "uuid", uuid))
+	}
+	return nil
 }
 
 // applyCommodity recreates one area-bound commodity (resolving its area UUID →
@@ -561,6 +693,7 @@ func (w *inbWalker) applyArea(a *types.INBArea) error {
 func (w *inbWalker) applyCommodity(c *types.INBCommodity) error {
 	actualAreaID, ok := w.idMapping.Areas[c.AreaID]
 	if !ok || actualAreaID == "" {
+		w.preview.commodityMissingArea(c)
 		return fmt.Errorf("commodity %s references unmapped area %s", c.ID, c.AreaID)
 	}
 	return w.applyCommodityModel(c, &actualAreaID)
@@ -630,10 +763,14 @@ func (w *inbWalker) applyCommodityModel(c *types.INBCommodity, actualAreaID *str
 		createCtx = registry.WithRestoreAcquisition(createCtx, *price, *currency)
 	}
 
+	if err := w.clearForSelectiveReplace("commodity", c.ID); err != nil {
+		return err
+	}
 	existingCommodity := w.existing.Commodities[c.ID]
 	if err := l.applyStrategyForCommodityModel(createCtx, commodity, existingCommodity, c.ID, w.stats, w.existing, w.idMapping, w.options); err != nil {
 		return err
 	}
+	w.preview.commodity(c, commodity)
 
 	// Cover-photo cross-reference (#1451) is patched only after the commodity's
 	// files are restored (their new DB ids aren't known yet), and only for a
@@ -680,11 +817,24 @@ func (w *inbWalker) queueCoverPatch(c *types.INBCommodity) {
 
 // registerFileRefs indexes a commodity's file references by their archive path.
 func (w *inbWalker) registerFileRefs(commodityUUID, bucket string, refs []types.INBFileRef) {
+	w.indexCommodityFileRefs(commodityUUID, bucket, refs, false)
+}
+
+// excludeFileRefs indexes the file references of a commodity outside the
+// restore selection, so their members are consumed without restoring them.
+func (w *inbWalker) excludeFileRefs(c *types.INBCommodity) {
+	w.indexCommodityFileRefs(c.ID, "images", c.Images, true)
+	w.indexCommodityFileRefs(c.ID, "invoices", c.Invoices, true)
+	w.indexCommodityFileRefs(c.ID, "manuals", c.Manuals, true)
+}
+
+func (w *inbWalker) indexCommodityFileRefs(commodityUUID, bucket string, refs []types.INBFileRef, excluded bool) {
 	for _, ref := range refs {
 		key := blobkeys.SanitizeArchivePath(ref.Path)
 		w.fileRefs[key] = inbPendingFile{
-			ref:  ref,
-			link: inbFileLink{linkedType: "commodity", entityUUID: commodityUUID, meta: bucket},
+			ref:      ref,
+			link:     inbFileLink{linkedType: "commodity", entityUUID: commodityUUID, meta: bucket},
+			excluded: excluded,
 		}
 	}
 }
@@ -726,6 +876,7 @@ func (w *inbWalker) registerEntityFileRefs(refs []types.INBEntityFileRef) {
 				fileType:   ref.Type,
 				category:   ref.Category,
 			},
+			excluded: !w.selection.includesEntityFile(&ref),
 		}
 	}
 }
@@ -776,6 +927,10 @@ func (w *inbWalker) handleFileMember(hdr *tar.Header, r io.Reader) error {
 	// refs whose member never appeared in the archive (e.g. dry-run, where the
 	// commodity isn't persisted, still delivers and consumes the member here).
 	delete(w.fileRefs, hdr.Name)
+	if pending.excluded {
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}
 
 	// Resolve the archived link (commodity / location / area / standalone) to a
 	// destination DB id. A link whose entity never landed is dropped with a
@@ -831,6 +986,7 @@ func (w *inbWalker) handleFileMember(hdr *tar.Header, r io.Reader) error {
 	action := decideFileStrategyAction(w.options.Strategy, w.existing.Files[pending.ref.ID])
 
 	if action == fileActionSkip {
+		w.preview.file(pending.ref.ID, false, hdr.Size)
 		return w.skipFileMember(r, hdr.Size, blobKey)
 	}
 
@@ -862,6 +1018,7 @@ func (w *inbWalker) handleFileMember(hdr *tar.Header, r io.Reader) error {
 	}
 
 	w.deleteSupersededBlob(staleBlobKey, blobKey)
+	w.preview.file(pending.ref.ID, true, written)
 
 	w.incBucketStat(pending.link)
 	return nil
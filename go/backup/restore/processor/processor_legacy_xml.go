@@ -43,6 +43,10 @@ var (
 	// ErrDataStreamTruncated is returned when a legacy XML <data> stream ends
 	// before its closing </data> tag, i.e. the export was truncated.
 	ErrDataStreamTruncated = errx.NewSentinel("malformed export: <data> stream ended before </data>")
+
+	// ErrSelectionUnsupported is returned for a selective restore of a legacy
+	// XML export, which only restores whole.
+	ErrSelectionUnsupported = errx.NewSentinel("selective restore is not supported for legacy XML exports")
 )
 
 // decodeAndRestore is the legacy XML decode entry point. It streams the XML
@@ -67,6 +71,9 @@ func (l *RestoreOperationProcessor) restoreFromXML(
 	options types.RestoreOptions,
 ) (*types.RestoreStats, error) {
 	stats := &types.RestoreStats{}
+	if options.Selection != nil {
+		return stats, ErrSelectionUnsupported
+	}
 
 	prep, err := l.prepareRestore(ctx, options)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-extras/errx"
 	errxtrace "github.com/go-extras/errx/stacktrace"
+	"go.opentelemetry.io/otel/attribute"
 	"gocloud.dev/blob"
 
 	"github.com/denisvmedia/inventario/appctx"
@@ -17,6 +18,7 @@ import (
 	"github.com/denisvmedia/inventario/backup/restore/types"
 	"github.com/denisvmedia/inventario/internal/backupsign"
 	"github.com/denisvmedia/inventario/internal/blobkeys"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/internal/validationctx"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -43,6 +45,12 @@ type RestoreOperationProcessor struct {
 	// keyed by their immutable UUID. Populated once on the first call to
 	// validateCommodityOwnershipInDB.
 	commodityUUIDMap map[string]*models.Commodity
+
+	// preview collects the planned changes of a dry-run; nil otherwise.
+	preview *restorePreviewer
+
+	// selection narrows a selective restore; nil otherwise.
+	selection *restoreSelection
 }
 
 // NewRestoreOperationProcessor builds a processor. The signer is consumed by the
@@ -80,13 +88,18 @@ func (l *RestoreOperationProcessor) fileService() *services.FileService {
 // Process drives the full restore lifecycle: status/step bookkeeping, blob open,
 // then the per-build decodeAndRestore (XML or `.inb`). The decode/verify/apply
 // body differs per format; everything around it is format-agnostic.
-func (l *RestoreOperationProcessor) Process(ctx context.Context) error {
+func (l *RestoreOperationProcessor) Process(ctx context.Context) (err error) {
 	restoreOperationRegistry := l.factorySet.RestoreOperationRegistryFactory.CreateServiceRegistry()
 	restoreOperation, err := restoreOperationRegistry.Get(ctx, l.restoreOperationID)
 	if err != nil {
 		return l.markRestoreFailed(ctx, err, "failed to get restore operation")
 	}
 
+	ctx, span := tracing.StartJob(ctx, "restore.process", restoreOperation.TraceParent,
+		attribute.String("inventario.restore.id", restoreOperation.ID),
+		attribute.Bool("inventario.restore.dry_run", restoreOperation.Options.DryRun))
+	defer func() { tracing.End(span, err) }()
+
 	exportReg := l.factorySet.ExportRegistryFactory.CreateServiceRegistry()
 	export, err := exportReg.Get(ctx, restoreOperation.ExportID)
 	if err != nil {
@@ -110,7 +123,11 @@ func (l *RestoreOperationProcessor) Process(ctx context.Context) error {
 		if gerr != nil {
 			return l.markRestoreFailed(ctx, gerr, "failed to get restore group")
 		}
-		ctx = appctx.WithGroup(ctx, group)
+		memberCtx, serr := registry.MemberContext(ctx, l.factorySet.GroupMemberLocationScopeRegistry, user, group)
+		if serr != nil {
+			return l.markRestoreFailed(ctx, serr, "failed to get restore member scope")
+		}
+		ctx = memberCtx
 	}
 
 	restoreOperation.Status = models.RestoreStatusRunning
@@ -153,6 +170,7 @@ func (l *RestoreOperationProcessor) Process(ctx context.Context) error {
 		Strategy:        types.RestoreStrategy(restoreOperation.Options.Strategy),
 		IncludeFileData: restoreOperation.Options.IncludeFileData,
 		DryRun:          restoreOperation.Options.DryRun,
+		Selection:       restoreOperation.Options.Selection,
 	}
 
 	l.updateRestoreStep(ctx, "Reading backup file", models.RestoreStepResultSuccess, "")
@@ -162,7 +180,13 @@ func (l *RestoreOperationProcessor) Process(ctx context.Context) error {
 		return l.markRestoreFailed(ctx, err, "restore failed")
 	}
 
+	// A dry-run wrote nothing: it ends in the preview state with the planned
+	// changes attached instead of as a completed restore.
 	restoreOperation.Status = models.RestoreStatusCompleted
+	if restoreOptions.DryRun {
+		restoreOperation.Status = models.RestoreStatusPreview
+		restoreOperation.Preview = l.preview.report()
+	}
 	restoreOperation.CompletedDate = models.PNow()
 	restoreOperation.LocationCount = stats.LocationCount
 	restoreOperation.AreaCount = stats.AreaCount
@@ -178,7 +202,11 @@ func (l *RestoreOperationProcessor) Process(ctx context.Context) error {
 		return l.markRestoreFailed(ctx, err, "failed to update restore completion status")
 	}
 
-	l.createRestoreStep(ctx, "Restore completed successfully", models.RestoreStepResultSuccess,
+	stepName := "Restore completed successfully"
+	if restoreOptions.DryRun {
+		stepName = "Restore preview completed"
+	}
+	l.createRestoreStep(ctx, stepName, models.RestoreStepResultSuccess,
 		fmt.Sprintf("Processed %d locations, %d areas, %d commodities with %d errors",
 			stats.LocationCount, stats.AreaCount, stats.CommodityCount, stats.ErrorCount))
 
@@ -218,7 +246,9 @@ func (l *RestoreOperationProcessor) prepareRestore(
 		Files:       make(map[string]string),
 	}
 
-	if options.Strategy != types.RestoreStrategyFullReplace {
+	// A selective restore consults the group's data under every strategy:
+	// missing parents are created, existing ones reused.
+	if options.Strategy != types.RestoreStrategyFullReplace || options.Selection != nil {
 		if err := l.loadExistingEntities(ctx, existingEntities); err != nil {
 			return nil, errxtrace.Wrap("failed to load existing entities", err)
 		}
@@ -241,7 +271,9 @@ func (l *RestoreOperationProcessor) prepareRestore(
 		existingEntities.Files = make(map[string]*models.FileEntity)
 	}
 
-	if options.Strategy == types.RestoreStrategyFullReplace && !options.DryRun {
+	// A selective full_replace replaces only the selected subtrees, one at a
+	// time as the walker reaches them.
+	if options.Strategy == types.RestoreStrategyFullReplace && !options.DryRun && options.Selection == nil {
 		if err := l.clearExistingData(ctx); err != nil {
 			return nil, errxtrace.Wrap("failed to clear existing data", err)
 		}
@@ -606,6 +638,10 @@ func (l *RestoreOperationProcessor) createLocation(
 		existing.Locations[originalID] = created
 		idMapping.Locations[originalID] = created.ID
 		l.trackCreatedEntity(created.ID)
+	} else {
+		// Nothing is written in a dry-run: map the UUID to itself so the
+		// entities nested under it still resolve their parent.
+		idMapping.Locations[originalID] = originalID
 	}
 	stats.CreatedCount++
 	stats.LocationCount++
@@ -689,6 +725,8 @@ func (l *RestoreOperationProcessor) createArea(
 		existing.Areas[originalID] = created
 		idMapping.Areas[originalID] = created.ID
 		l.trackCreatedEntity(created.ID)
+	} else {
+		idMapping.Areas[originalID] = originalID
 	}
 	stats.CreatedCount++
 	stats.AreaCount++
@@ -787,6 +825,8 @@ func (l *RestoreOperationProcessor) createCommodity(
 		existing.Commodities[originalID] = created
 		idMapping.Commodities[originalID] = created.ID
 		l.trackCreatedEntity(created.ID)
+	} else {
+		idMapping.Commodities[originalID] = originalID
 	}
 	stats.CreatedCount++
 	stats.CommodityCount++
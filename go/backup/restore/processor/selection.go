@@ -0,0 +1,152 @@
+package processor
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// selectionRole is the part an archived entity plays in a selective restore.
+// The zero value is roleIncluded, so a restore without a selection (a nil
+// restoreSelection, nil role maps) includes everything.
+type selectionRole int
+
+const (
+	// roleIncluded: restored per the strategy.
+	roleIncluded selectionRole = iota
+	// roleParent: not selected, but a selected descendant needs it. Created
+	// when missing, otherwise left as it is.
+	roleParent
+	// roleExcluded: not restored.
+	roleExcluded
+)
+
+// restoreSelection narrows a restore to the subtrees a RestoreSelection
+// names. The included* sets record what the walk actually reached: a
+// selected location includes all its areas, an included area all its
+// commodities. All methods treat a nil selection as "everything".
+type restoreSelection struct {
+	locations   map[string]bool
+	areas       map[string]bool
+	commodities map[string]bool
+
+	includedLocations   map[string]bool
+	includedAreas       map[string]bool
+	includedCommodities map[string]bool
+}
+
+func newRestoreSelection(sel *models.RestoreSelection) *restoreSelection {
+	if sel == nil {
+		return nil
+	}
+	set := func(ids []string) map[string]bool {
+		m := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			m[id] = true
+		}
+		return m
+	}
+	return &restoreSelection{
+		locations:           set(sel.LocationIDs),
+		areas:               set(sel.AreaIDs),
+		commodities:         set(sel.CommodityIDs),
+		includedLocations:   map[string]bool{},
+		includedAreas:       map[string]bool{},
+		includedCommodities: map[string]bool{},
+	}
+}
+
+// planLocationDoc decides the role of a location document's location and
+// areas, and records the included ones.
+func (s *restoreSelection) planLocationDoc(doc *types.INBLocationDoc) (selectionRole, map[string]selectionRole) {
+	if s == nil {
+		return roleIncluded, nil
+	}
+	locationIncluded := s.locations[doc.Location.ID]
+	areaRoles := make(map[string]selectionRole, len(doc.Areas))
+	needed := locationIncluded
+	for i := range doc.Areas {
+		areaID := doc.Areas[i].ID
+		switch {
+		case locationIncluded || s.areas[areaID]:
+			areaRoles[areaID] = roleIncluded
+			s.includedAreas[areaID] = true
+		case s.selectsCommodityIn(doc, areaID):
+			areaRoles[areaID] = roleParent
+		default:
+			areaRoles[areaID] = roleExcluded
+			continue
+		}
+		needed = true
+	}
+	switch {
+	case locationIncluded:
+		s.includedLocations[doc.Location.ID] = true
+		return roleIncluded, areaRoles
+	case needed:
+		return roleParent, areaRoles
+	default:
+		return roleExcluded, areaRoles
+	}
+}
+
+func (s *restoreSelection) selectsCommodityIn(doc *types.INBLocationDoc, areaID string) bool {
+	for i := range doc.Commodities {
+		if doc.Commodities[i].AreaID == areaID && s.commodities[doc.Commodities[i].ID] {
+			return true
+		}
+	}
+	return false
+}
+
+// includesCommodity reports whether a commodity is restored: selected
+// itself or inside an included area. Call after planLocationDoc.
+func (s *restoreSelection) includesCommodity(c *types.INBCommodity) bool {
+	if s == nil {
+		return true
+	}
+	if s.commodities[c.ID] || (c.AreaID != "" && s.includedAreas[c.AreaID]) {
+		s.includedCommodities[c.ID] = true
+		return true
+	}
+	return false
+}
+
+// includesEntityFile reports whether a non-commodity file is restored: its
+// location or area must be included. Standalone files belong to no subtree.
+func (s *restoreSelection) includesEntityFile(ref *types.INBEntityFileRef) bool {
+	if s == nil {
+		return true
+	}
+	switch ref.LinkedEntityType {
+	case "location":
+		return s.includedLocations[ref.LinkedEntityID]
+	case "area":
+		return s.includedAreas[ref.LinkedEntityID]
+	case "commodity":
+		return s.includedCommodities[ref.LinkedEntityID]
+	}
+	return false
+}
+
+// unmatched lists the selected UUIDs the archive does not carry.
+func (s *restoreSelection) unmatched() []string {
+	if s == nil {
+		return nil
+	}
+	var missing []string
+	collect := func(kind string, selected, included map[string]bool) {
+		for _, id := range slices.Sorted(maps.Keys(selected)) {
+			if !included[id] {
+				missing = append(missing, fmt.Sprintf("%s %s", kind, id))
+			}
+		}
+	}
+	collect("location", s.locations, s.includedLocations)
+	collect("area", s.areas, s.includedAreas)
+	collect("commodity", s.commodities, s.includedCommodities)
+	return missing
+}
@@ -14,6 +14,9 @@ type RestoreOptions struct {
 	Strategy        RestoreStrategy `json:"strategy"`
 	IncludeFileData bool            `json:"include_file_data"`
 	DryRun          bool            `json:"dry_run"`
+	// Selection narrows the restore to chosen archive subtrees; nil restores
+	// everything.
+	Selection *models.RestoreSelection `json:"selection,omitempty"`
 }
 
 // RestoreStrategy defines how to handle existing data during restore
@@ -8,6 +8,7 @@ import (
 
 	"golang.org/x/sync/semaphore"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -175,6 +176,9 @@ func (w *RestoreWorker) processPendingRestores(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeRestore))
+	defer span.End()
+
 	restoreOperations, err := w.registrySet.RestoreOperationRegistry.List(ctx)
 	if err != nil {
 		slog.Error("Failed to get restore operations", "error", err)
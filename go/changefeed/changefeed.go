@@ -0,0 +1,134 @@
+// Package changefeed defines the per-group change stream contract shared by
+// the SSE endpoint (GET /g/{slug}/events/stream) and the write paths that
+// feed it.
+//
+// Architecture:
+//   - The Broker interface fans published events out to every subscriber of
+//     the same group and keeps a bounded per-group history for resume.
+//   - Implementations live in the sub-packages changefeed/inmemory
+//     (single node) and changefeed/redis (multiple apiserver replicas).
+//   - Higher-level wiring (backend selection, resolving the location an
+//     event belongs to) is handled by the services layer.
+//
+// Events are notifications, not replicas of the rows: they carry the entity
+// kind, its ID and the location it lives in, and clients refetch what they
+// display. Keeping row data out of the stream means it never needs its own
+// authorization model beyond the group membership and location scope checks
+// the SSE handler already applies.
+package changefeed
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// HistorySize is the number of most recent events each backend keeps per
+	// group for Last-Event-ID resume. A client that falls further behind
+	// receives a reset event and refetches.
+	HistorySize = 500
+
+	// SubscriberBuffer is the number of undelivered events a subscription
+	// holds before it is closed as too slow. The client reconnects with its
+	// Last-Event-ID and the gap is filled from history.
+	SubscriberBuffer = 64
+)
+
+// Entity names the kind of row an event is about.
+type Entity string
+
+const (
+	EntityCommodity Entity = "commodity"
+	EntityArea      Entity = "area"
+	EntityLocation  Entity = "location"
+	EntityFile      Entity = "file"
+	EntityLoan      Entity = "loan"
+)
+
+// Action names what happened to the row.
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionDeleted Action = "deleted"
+	// ActionReset is delivered first when a Last-Event-ID can no longer be
+	// resumed (unknown ID or trimmed from history). Clients should discard
+	// their cached state and refetch.
+	ActionReset Action = "reset"
+)
+
+// Event is one change notification. ID is assigned by the broker on Publish
+// and is opaque to clients; it is only meaningful as a Last-Event-ID for the
+// backend that issued it.
+type Event struct {
+	ID       string `json:"id"`
+	GroupID  string `json:"group_id"`
+	Entity   Entity `json:"entity,omitempty"`
+	Action   Action `json:"action"`
+	EntityID string `json:"entity_id,omitempty"`
+	// LocationID is the location the row belongs to, used to filter the
+	// stream for location-scoped members. Empty when the row is not tied to
+	// a location (e.g. a standalone file).
+	LocationID string `json:"location_id,omitempty"`
+	// Kind carries the commodity timeline kind (moved, price_changed, ...)
+	// when the event was fed from a CommodityEvent write.
+	Kind        string    `json:"kind,omitempty"`
+	ActorUserID string    `json:"actor_user_id,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// Name is the SSE event name: "<entity>.<action>", or "reset".
+func (e Event) Name() string {
+	if e.Action == ActionReset {
+		return string(ActionReset)
+	}
+	return string(e.Entity) + "." + string(e.Action)
+}
+
+// ResetEvent returns the event that tells a resuming client its
+// Last-Event-ID could not be honoured.
+func ResetEvent(groupID string) Event {
+	return Event{GroupID: groupID, Action: ActionReset, OccurredAt: time.Now().UTC()}
+}
+
+// Broker publishes group change events and streams them to subscribers.
+type Broker interface {
+	// Publish assigns the event an ID, records it in the group's history and
+	// delivers it to the group's subscribers (on every replica for shared
+	// backends). Returns the event as stored.
+	Publish(ctx context.Context, event Event) (Event, error)
+
+	// Subscribe starts a subscription to the group's events. When
+	// lastEventID is non-empty the events recorded after it are delivered
+	// first, or a reset event when that is no longer possible. The
+	// subscription ends when ctx is done, when Close is called or when the
+	// subscriber falls SubscriberBuffer events behind.
+	Subscribe(ctx context.Context, groupID, lastEventID string) (*Subscription, error)
+}
+
+// Subscription is a live feed of one group's events.
+type Subscription struct {
+	events    <-chan Event
+	closeOnce sync.Once
+	close     func()
+}
+
+// NewSubscription wraps a backend's event channel. closeFn releases the
+// backend resources and must make the channel close eventually; it is
+// called at most once.
+func NewSubscription(events <-chan Event, closeFn func()) *Subscription {
+	return &Subscription{events: events, close: closeFn}
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription ends.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close ends the subscription. Safe to call more than once.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(s.close)
+}
@@ -0,0 +1,134 @@
+package inmemory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/denisvmedia/inventario/changefeed"
+)
+
+// Broker implements changefeed.Broker with per-group history slices and
+// buffered subscriber channels.
+//
+// Event IDs come from a single process-wide sequence, so they are strictly
+// increasing within every group and a Last-Event-ID is simply the sequence
+// number to continue after.
+type Broker struct {
+	mu     sync.Mutex
+	seq    uint64
+	groups map[string]*group
+}
+
+type group struct {
+	// history holds the last changefeed.HistorySize events, oldest first.
+	history []changefeed.Event
+	// trimmed is the sequence number of the newest event evicted from
+	// history; resuming from anything older would skip events.
+	trimmed uint64
+	subs    map[chan changefeed.Event]struct{}
+}
+
+var _ changefeed.Broker = (*Broker)(nil)
+
+// New creates a new in-memory broker.
+func New() *Broker {
+	return &Broker{groups: make(map[string]*group)}
+}
+
+// Publish records the event in the group's history and hands it to every
+// subscriber. A subscriber whose buffer is full is closed rather than
+// blocking the writer; it resumes from history on reconnect.
+func (b *Broker) Publish(_ context.Context, event changefeed.Event) (changefeed.Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event.ID = strconv.FormatUint(b.seq, 10)
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	g := b.group(event.GroupID)
+	g.history = append(g.history, event)
+	if len(g.history) > changefeed.HistorySize {
+		evicted := g.history[0]
+		g.trimmed, _ = strconv.ParseUint(evicted.ID, 10, 64)
+		g.history = g.history[1:]
+	}
+
+	for ch := range g.subs {
+		select {
+		case ch <- event:
+		default:
+			delete(g.subs, ch)
+			close(ch)
+		}
+	}
+	return event, nil
+}
+
+// Subscribe registers a subscriber for the group. Replay and registration
+// happen under the same lock, so no event published in between is missed
+// or delivered twice.
+func (b *Broker) Subscribe(ctx context.Context, groupID, lastEventID string) (*changefeed.Subscription, error) {
+	b.mu.Lock()
+	g := b.group(groupID)
+	replay, ok := b.since(g, lastEventID)
+	ch := make(chan changefeed.Event, len(replay)+changefeed.SubscriberBuffer)
+	if ok {
+		for _, event := range replay {
+			ch <- event
+		}
+	} else {
+		ch <- changefeed.ResetEvent(groupID)
+	}
+	g.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := g.subs[ch]; ok {
+			delete(g.subs, ch)
+			close(ch)
+		}
+	}
+	stop := context.AfterFunc(ctx, unsubscribe)
+	return changefeed.NewSubscription(ch, func() {
+		stop()
+		unsubscribe()
+	}), nil
+}
+
+// group returns the group's state, creating it on first access. Must be
+// called with b.mu held.
+func (b *Broker) group(groupID string) *group {
+	g, ok := b.groups[groupID]
+	if !ok {
+		g = &group{subs: make(map[chan changefeed.Event]struct{})}
+		b.groups[groupID] = g
+	}
+	return g
+}
+
+// since returns the group's events recorded after lastEventID. ok is false
+// when the ID is malformed, from the future (e.g. issued before a restart)
+// or older than the retained history. Must be called with b.mu held.
+func (b *Broker) since(g *group, lastEventID string) (events []changefeed.Event, ok bool) {
+	if lastEventID == "" {
+		return nil, true
+	}
+	last, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil || last > b.seq || last < g.trimmed {
+		return nil, false
+	}
+	for i, event := range g.history {
+		seq, _ := strconv.ParseUint(event.ID, 10, 64)
+		if seq > last {
+			return append([]changefeed.Event(nil), g.history[i:]...), true
+		}
+	}
+	return nil, true
+}
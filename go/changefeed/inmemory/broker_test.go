@@ -0,0 +1,146 @@
+package inmemory_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/changefeed"
+	"github.com/denisvmedia/inventario/changefeed/inmemory"
+)
+
+// Compile-time interface check.
+var _ changefeed.Broker = (*inmemory.Broker)(nil)
+
+func publish(c *qt.C, b *inmemory.Broker, groupID, entityID string) changefeed.Event {
+	c.Helper()
+	event, err := b.Publish(context.Background(), changefeed.Event{
+		GroupID:  groupID,
+		Entity:   changefeed.EntityCommodity,
+		Action:   changefeed.ActionUpdated,
+		EntityID: entityID,
+	})
+	c.Assert(err, qt.IsNil)
+	return event
+}
+
+func receive(c *qt.C, sub *changefeed.Subscription) changefeed.Event {
+	c.Helper()
+	select {
+	case event, ok := <-sub.Events():
+		c.Assert(ok, qt.IsTrue, qt.Commentf("subscription closed"))
+		return event
+	case <-time.After(time.Second):
+		c.Fatal("timed out waiting for an event")
+		return changefeed.Event{}
+	}
+}
+
+func TestBroker_PublishFansOutPerGroup(t *testing.T) {
+	c := qt.New(t)
+	b := inmemory.New()
+
+	subA1, err := b.Subscribe(context.Background(), "group-a", "")
+	c.Assert(err, qt.IsNil)
+	defer subA1.Close()
+	subA2, err := b.Subscribe(context.Background(), "group-a", "")
+	c.Assert(err, qt.IsNil)
+	defer subA2.Close()
+	subB, err := b.Subscribe(context.Background(), "group-b", "")
+	c.Assert(err, qt.IsNil)
+	defer subB.Close()
+
+	published := publish(c, b, "group-a", "c-1")
+	c.Assert(published.ID, qt.Not(qt.Equals), "")
+	c.Assert(published.OccurredAt.IsZero(), qt.IsFalse)
+
+	c.Assert(receive(c, subA1).EntityID, qt.Equals, "c-1")
+	c.Assert(receive(c, subA2).EntityID, qt.Equals, "c-1")
+	c.Assert(subB.Events(), qt.HasLen, 0)
+}
+
+func TestBroker_SubscribeResumesAfterLastEventID(t *testing.T) {
+	c := qt.New(t)
+	b := inmemory.New()
+
+	first := publish(c, b, "group-a", "c-1")
+	publish(c, b, "group-b", "other")
+	publish(c, b, "group-a", "c-2")
+
+	sub, err := b.Subscribe(context.Background(), "group-a", first.ID)
+	c.Assert(err, qt.IsNil)
+	defer sub.Close()
+
+	c.Assert(receive(c, sub).EntityID, qt.Equals, "c-2")
+	publish(c, b, "group-a", "c-3")
+	c.Assert(receive(c, sub).EntityID, qt.Equals, "c-3")
+}
+
+func TestBroker_SubscribeResetsOnUnknownID(t *testing.T) {
+	b := inmemory.New()
+	c := qt.New(t)
+	for range changefeed.HistorySize + 1 {
+		publish(c, b, "group-a", "c")
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+	}{
+		{name: "malformed", lastEventID: "not-a-number"},
+		{name: "from the future", lastEventID: strconv.Itoa(changefeed.HistorySize + 100)},
+		{name: "trimmed from history", lastEventID: "0"},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			sub, err := b.Subscribe(context.Background(), "group-a", tt.lastEventID)
+			c.Assert(err, qt.IsNil)
+			defer sub.Close()
+
+			event := receive(c, sub)
+			c.Assert(event.Action, qt.Equals, changefeed.ActionReset)
+			c.Assert(event.GroupID, qt.Equals, "group-a")
+			c.Assert(sub.Events(), qt.HasLen, 0)
+		})
+	}
+}
+
+func TestBroker_SlowSubscriberIsClosed(t *testing.T) {
+	c := qt.New(t)
+	b := inmemory.New()
+
+	sub, err := b.Subscribe(context.Background(), "group-a", "")
+	c.Assert(err, qt.IsNil)
+	defer sub.Close()
+
+	for range changefeed.SubscriberBuffer + 1 {
+		publish(c, b, "group-a", "c")
+	}
+
+	received := 0
+	for range sub.Events() {
+		received++
+	}
+	c.Assert(received, qt.Equals, changefeed.SubscriberBuffer)
+}
+
+func TestBroker_ContextCancelUnsubscribes(t *testing.T) {
+	c := qt.New(t)
+	b := inmemory.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := b.Subscribe(ctx, "group-a", "")
+	c.Assert(err, qt.IsNil)
+	cancel()
+
+	select {
+	case _, ok := <-sub.Events():
+		c.Assert(ok, qt.IsFalse)
+	case <-time.After(time.Second):
+		c.Fatal("subscription not closed after context cancellation")
+	}
+	sub.Close()
+}
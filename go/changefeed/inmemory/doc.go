@@ -0,0 +1,6 @@
+// Package inmemory provides a process-local changefeed.Broker implementation.
+//
+// It is intended for development and single-process deployments. Events are
+// not shared across instances and the resume history is lost on restart. Use
+// changefeed/redis when running more than one apiserver replica.
+package inmemory
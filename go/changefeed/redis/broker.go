@@ -0,0 +1,254 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	redisv9 "github.com/redis/go-redis/v9"
+
+	"github.com/denisvmedia/inventario/changefeed"
+)
+
+// historyTTL bounds how long a quiet group's stream is kept. Every Publish
+// refreshes it, so only groups with no writes for this long lose their
+// resume history.
+const historyTTL = 24 * time.Hour
+
+// eventField is the stream entry field holding the JSON-encoded event.
+const eventField = "event"
+
+// Broker implements changefeed.Broker using Redis streams for history and
+// pub/sub for fan-out.
+//
+// Use this in production when running more than one server instance.
+type Broker struct {
+	client *redisv9.Client
+}
+
+var _ changefeed.Broker = (*Broker)(nil)
+
+// New creates a new Redis-backed broker from an existing client.
+func New(client *redisv9.Client) *Broker {
+	return &Broker{client: client}
+}
+
+// NewFromURL creates a Redis-backed broker from a connection URL.
+//
+// A PING connectivity check is performed at construction time; failures are
+// logged as warnings but the broker is still returned. The change stream is
+// a convenience on top of the CRUD API, so a Redis outage must not take the
+// API offline — publishes fail (and are logged by the caller) until Redis is
+// back.
+func NewFromURL(redisURL string) (*Broker, error) {
+	opts, err := redisv9.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	client := redisv9.NewClient(opts)
+	if pingErr := client.Ping(context.Background()).Err(); pingErr != nil {
+		slog.Warn("Redis change feed unreachable at startup; live updates are unavailable until Redis becomes available",
+			"error", pingErr)
+	}
+	return New(client), nil
+}
+
+// streamKey returns the key of the group's history stream.
+func streamKey(groupID string) string { return fmt.Sprintf("changefeed:stream:%s", groupID) }
+
+// channel returns the group's pub/sub channel.
+func channel(groupID string) string { return fmt.Sprintf("changefeed:events:%s", groupID) }
+
+// Publish appends the event to the group's stream (trimmed to
+// changefeed.HistorySize), takes the stream entry ID as the event ID and
+// publishes the event to the group's channel.
+func (b *Broker) Publish(ctx context.Context, event changefeed.Event) (changefeed.Event, error) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	event.ID = ""
+	stored, err := json.Marshal(event)
+	if err != nil {
+		return event, fmt.Errorf("failed to encode change event: %w", err)
+	}
+
+	key := streamKey(event.GroupID)
+	id, err := b.client.XAdd(ctx, &redisv9.XAddArgs{
+		Stream: key,
+		MaxLen: changefeed.HistorySize,
+		Values: map[string]any{eventField: stored},
+	}).Result()
+	if err != nil {
+		return event, fmt.Errorf("failed to append change event: %w", err)
+	}
+	event.ID = id
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return event, fmt.Errorf("failed to encode change event: %w", err)
+	}
+	pipe := b.client.Pipeline()
+	pipe.Expire(ctx, key, historyTTL)
+	pipe.Publish(ctx, channel(event.GroupID), payload)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return event, fmt.Errorf("failed to publish change event: %w", err)
+	}
+	return event, nil
+}
+
+// Subscribe subscribes to the group's channel first and only then reads the
+// resume history, so an event published in between arrives on both paths;
+// the forwarding goroutine drops anything not newer than what it already
+// delivered.
+func (b *Broker) Subscribe(ctx context.Context, groupID, lastEventID string) (*changefeed.Subscription, error) {
+	pubsub := b.client.Subscribe(ctx, channel(groupID))
+	// Receive waits for the subscription confirmation; without it a publish
+	// racing the history read could be missed.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to change feed: %w", err)
+	}
+
+	replay, ok, err := b.since(ctx, groupID, lastEventID)
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	ch := make(chan changefeed.Event, changefeed.SubscriberBuffer)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		defer pubsub.Close()
+
+		send := func(event changefeed.Event) bool {
+			select {
+			case ch <- event:
+				return true
+			case <-done:
+				return false
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		cursor := lastEventID
+		if !ok {
+			cursor = ""
+			if !send(changefeed.ResetEvent(groupID)) {
+				return
+			}
+		}
+		for _, event := range replay {
+			if !send(event) {
+				return
+			}
+			cursor = event.ID
+		}
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case msg, open := <-messages:
+				if !open {
+					return
+				}
+				var event changefeed.Event
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					slog.Warn("change feed: dropping undecodable event", "group_id", groupID, "error", err)
+					continue
+				}
+				if cursor != "" && !after(event.ID, cursor) {
+					continue
+				}
+				// A subscriber that stopped draining is closed rather than
+				// letting go-redis drop messages silently; the client
+				// resumes from the stream.
+				select {
+				case ch <- event:
+				default:
+					return
+				}
+				cursor = event.ID
+			}
+		}
+	}()
+
+	return changefeed.NewSubscription(ch, func() { close(done) }), nil
+}
+
+// since returns the group's events recorded after lastEventID. ok is false
+// when the ID is malformed or no longer in the stream (trimmed or expired),
+// since events after it may be gone too.
+func (b *Broker) since(ctx context.Context, groupID, lastEventID string) (events []changefeed.Event, ok bool, err error) {
+	if lastEventID == "" {
+		return nil, true, nil
+	}
+	if _, _, valid := parseID(lastEventID); !valid {
+		return nil, false, nil
+	}
+
+	key := streamKey(groupID)
+	anchor, err := b.client.XRange(ctx, key, lastEventID, lastEventID).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read change feed history: %w", err)
+	}
+	if len(anchor) == 0 {
+		return nil, false, nil
+	}
+
+	entries, err := b.client.XRange(ctx, key, "("+lastEventID, "+").Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read change feed history: %w", err)
+	}
+	events = make([]changefeed.Event, 0, len(entries))
+	for _, entry := range entries {
+		raw, _ := entry.Values[eventField].(string)
+		var event changefeed.Event
+		if err := json.Unmarshal([]byte(raw), &event); err != nil {
+			slog.Warn("change feed: skipping undecodable history entry", "group_id", groupID, "id", entry.ID, "error", err)
+			continue
+		}
+		event.ID = entry.ID
+		events = append(events, event)
+	}
+	return events, true, nil
+}
+
+// after reports whether stream ID a is newer than b.
+func after(a, b string) bool {
+	aMs, aSeq, aOK := parseID(a)
+	bMs, bSeq, bOK := parseID(b)
+	if !aOK || !bOK {
+		return true
+	}
+	if aMs != bMs {
+		return aMs > bMs
+	}
+	return aSeq > bSeq
+}
+
+// parseID splits a Redis stream ID ("<ms>-<seq>").
+func parseID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
@@ -0,0 +1,114 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/changefeed"
+	changefeedredis "github.com/denisvmedia/inventario/changefeed/redis"
+)
+
+// Compile-time interface check.
+var _ changefeed.Broker = (*changefeedredis.Broker)(nil)
+
+// newTestBroker starts a miniredis instance and returns a Broker backed by
+// it. The caller must call mr.Close() when done (typically via defer).
+func newTestBroker(t *testing.T) (*changefeedredis.Broker, *miniredis.Miniredis) {
+	t.Helper()
+	c := qt.New(t)
+
+	mr, err := miniredis.Run()
+	c.Assert(err, qt.IsNil)
+
+	b, err := changefeedredis.NewFromURL(fmt.Sprintf("redis://%s/0", mr.Addr()))
+	c.Assert(err, qt.IsNil)
+
+	return b, mr
+}
+
+func publish(c *qt.C, b *changefeedredis.Broker, groupID, entityID string) changefeed.Event {
+	c.Helper()
+	event, err := b.Publish(context.Background(), changefeed.Event{
+		GroupID:  groupID,
+		Entity:   changefeed.EntityArea,
+		Action:   changefeed.ActionCreated,
+		EntityID: entityID,
+	})
+	c.Assert(err, qt.IsNil)
+	return event
+}
+
+func receive(c *qt.C, sub *changefeed.Subscription) changefeed.Event {
+	c.Helper()
+	select {
+	case event, ok := <-sub.Events():
+		c.Assert(ok, qt.IsTrue, qt.Commentf("subscription closed"))
+		return event
+	case <-time.After(2 * time.Second):
+		c.Fatal("timed out waiting for an event")
+		return changefeed.Event{}
+	}
+}
+
+func TestNewFromURL_InvalidURL(t *testing.T) {
+	c := qt.New(t)
+	_, err := changefeedredis.NewFromURL("://bad-url")
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestBroker_PublishDeliversToSubscriber(t *testing.T) {
+	c := qt.New(t)
+	b, mr := newTestBroker(t)
+	defer mr.Close()
+
+	sub, err := b.Subscribe(context.Background(), "group-a", "")
+	c.Assert(err, qt.IsNil)
+	defer sub.Close()
+
+	published := publish(c, b, "group-a", "area-1")
+	c.Assert(published.ID, qt.Not(qt.Equals), "")
+
+	event := receive(c, sub)
+	c.Assert(event.ID, qt.Equals, published.ID)
+	c.Assert(event.EntityID, qt.Equals, "area-1")
+	c.Assert(event.Name(), qt.Equals, "area.created")
+}
+
+func TestBroker_SubscribeResumesAfterLastEventID(t *testing.T) {
+	c := qt.New(t)
+	b, mr := newTestBroker(t)
+	defer mr.Close()
+
+	first := publish(c, b, "group-a", "area-1")
+	publish(c, b, "group-a", "area-2")
+
+	sub, err := b.Subscribe(context.Background(), "group-a", first.ID)
+	c.Assert(err, qt.IsNil)
+	defer sub.Close()
+
+	c.Assert(receive(c, sub).EntityID, qt.Equals, "area-2")
+	publish(c, b, "group-a", "area-3")
+	c.Assert(receive(c, sub).EntityID, qt.Equals, "area-3")
+}
+
+func TestBroker_SubscribeResetsOnUnknownID(t *testing.T) {
+	c := qt.New(t)
+	b, mr := newTestBroker(t)
+	defer mr.Close()
+
+	publish(c, b, "group-a", "area-1")
+
+	for _, lastEventID := range []string{"garbage", "1-0"} {
+		sub, err := b.Subscribe(context.Background(), "group-a", lastEventID)
+		c.Assert(err, qt.IsNil)
+
+		event := receive(c, sub)
+		c.Assert(event.Action, qt.Equals, changefeed.ActionReset, qt.Commentf("last event id %q", lastEventID))
+		sub.Close()
+	}
+}
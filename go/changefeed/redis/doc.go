@@ -0,0 +1,10 @@
+// Package redis provides a Redis-backed changefeed.Broker implementation
+// suitable for multi-instance deployments.
+//
+// Each group has a Redis stream holding its recent events (capped at
+// changefeed.HistorySize) and a pub/sub channel for live delivery. Publish
+// appends to the stream, which assigns the event ID, then publishes the
+// event on the channel; every replica with a subscriber for the group
+// receives it. Resume reads the stream after the client's Last-Event-ID, so
+// a client may reconnect to a different replica than the one it left.
+package redis
@@ -0,0 +1,310 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-extras/errx"
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/internal/backupsign"
+	"github.com/denisvmedia/inventario/internal/inb"
+)
+
+// Per-member caps for the JSON documents the offline commands decode. They
+// mirror the restore processor's limits: far above any realistic document, low
+// enough that a hostile member cannot make io.ReadAll exhaust memory.
+const (
+	maxManifestBytes   int64 = 4 << 20  // 4 MiB
+	maxJSONMemberBytes int64 = 32 << 20 // 32 MiB for location / unassigned / files documents
+)
+
+// supportedInbMajor is the highest manifest format MAJOR version these commands
+// understand. Kept in step with the restore processor's maxSupportedInbMajor.
+const supportedInbMajor = 2
+
+// errNoVerifyKey is returned when neither --public-key nor a signing key is
+// available to check an archive's signature.
+var errNoVerifyKey = errx.NewSentinel("no key to verify the archive with; pass --public-key, --backup-signing-key or set " + backupSigningKeyEnv)
+
+// archive is an `.inb` file whose payload has been spooled to a temp file and
+// digested. Spooling keeps the payload off the heap and lets a command inflate
+// it more than once (extract plans in one pass and writes in a second) without
+// re-reading the outer container.
+type archive struct {
+	payload *os.File
+	sig     []byte
+	digest  []byte
+}
+
+// openArchive reads the outer container at path and spools payload.tar.gz to a
+// temp file while digesting it. Nothing is inflated; call verify before walk.
+func openArchive(path string) (*archive, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer in.Close()
+
+	sig, payload, err := inb.ReadContainer(in, inb.DefaultLimits())
+	if err != nil {
+		return nil, fmt.Errorf("not a valid signed .inb archive: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "inventario-backup-*.payload")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp payload: %w", err)
+	}
+	a := &archive{payload: tmp, sig: sig}
+
+	digest := backupsign.NewDigest()
+	if _, err := io.Copy(io.MultiWriter(tmp, digest), payload); err != nil {
+		_ = a.Close()
+		return nil, fmt.Errorf("failed to spool payload: %w", err)
+	}
+	a.digest = digest.Sum(nil)
+	return a, nil
+}
+
+// Close removes the spooled payload.
+func (a *archive) Close() error {
+	name := a.payload.Name()
+	_ = a.payload.Close()
+	return os.Remove(name)
+}
+
+// verify checks the detached signature against pub.
+func (a *archive) verify(pub ed25519.PublicKey) error {
+	return backupsign.VerifyDigestWithPublicKey(pub, a.digest, a.sig)
+}
+
+// walk inflates the payload from the start and calls fn for every regular
+// inner-tar member. Members fn does not read are skipped without inflating more
+// than tar needs to advance past them. Non-regular members are ignored, the same
+// as the restore walker.
+func (a *archive) walk(fn func(hdr *tar.Header, r io.Reader) error) error {
+	if _, err := a.payload.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind payload: %w", err)
+	}
+	gzr, err := gzip.NewReader(a.payload)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip reader: %w", err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read inner tar: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// memberKind classifies an inner-tar member the same way the restore walker
+// dispatches it (backup/restore/processor inbWalker.handleMember).
+type memberKind int
+
+const (
+	memberUnknown memberKind = iota
+	memberManifest
+	memberLocation
+	memberUnassigned
+	memberFilesIndex
+	memberFileBytes
+)
+
+func classifyMember(name string) memberKind {
+	switch {
+	case name == types.INBManifestMember:
+		return memberManifest
+	case name == types.INBFilesMember:
+		// Lives under files/, so it must be matched before the byte members.
+		return memberFilesIndex
+	case strings.HasPrefix(name, "files/"):
+		return memberFileBytes
+	case name == types.INBUnassignedMember:
+		return memberUnassigned
+	case strings.HasSuffix(name, ".json"):
+		return memberLocation
+	default:
+		return memberUnknown
+	}
+}
+
+// decodeJSONMember reads a JSON member of at most limit bytes into v.
+func decodeJSONMember(hdr *tar.Header, r io.Reader, limit int64, v any) error {
+	if hdr.Size < 0 || hdr.Size > limit {
+		return fmt.Errorf("member %s is %d bytes, over the %d byte limit", hdr.Name, hdr.Size, limit)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, hdr.Size))
+	if err != nil {
+		return fmt.Errorf("failed to read member %s: %w", hdr.Name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode member %s: %w", hdr.Name, err)
+	}
+	return nil
+}
+
+// contents is everything the offline commands learn from one metadata pass over
+// the payload: the decoded JSON documents plus the name and size of every file
+// member. File bytes are never read.
+type contents struct {
+	manifest      *types.INBManifest
+	locations     map[string]*types.INBLocationDoc // keyed by member name
+	locationOrder []string
+	unassigned    *types.INBUnassignedDoc
+	files         *types.INBFilesDoc
+	fileMembers   map[string]int64
+	fileOrder     []string
+	unknown       []string
+}
+
+// scan performs the metadata pass.
+func (a *archive) scan() (*contents, error) {
+	c := &contents{
+		locations:   make(map[string]*types.INBLocationDoc),
+		fileMembers: make(map[string]int64),
+	}
+	err := a.walk(func(hdr *tar.Header, r io.Reader) error {
+		switch classifyMember(hdr.Name) {
+		case memberManifest:
+			var m types.INBManifest
+			if err := decodeJSONMember(hdr, r, maxManifestBytes, &m); err != nil {
+				return err
+			}
+			c.manifest = &m
+		case memberLocation:
+			var doc types.INBLocationDoc
+			if err := decodeJSONMember(hdr, r, maxJSONMemberBytes, &doc); err != nil {
+				return err
+			}
+			if _, dup := c.locations[hdr.Name]; !dup {
+				c.locationOrder = append(c.locationOrder, hdr.Name)
+			}
+			c.locations[hdr.Name] = &doc
+		case memberUnassigned:
+			var doc types.INBUnassignedDoc
+			if err := decodeJSONMember(hdr, r, maxJSONMemberBytes, &doc); err != nil {
+				return err
+			}
+			c.unassigned = &doc
+		case memberFilesIndex:
+			var doc types.INBFilesDoc
+			if err := decodeJSONMember(hdr, r, maxJSONMemberBytes, &doc); err != nil {
+				return err
+			}
+			c.files = &doc
+		case memberFileBytes:
+			if _, dup := c.fileMembers[hdr.Name]; !dup {
+				c.fileOrder = append(c.fileOrder, hdr.Name)
+			}
+			c.fileMembers[hdr.Name] = hdr.Size
+		default:
+			c.unknown = append(c.unknown, hdr.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// commodityFileRefs returns the attachment references of commodities, bucket by
+// bucket per commodity, in document order.
+func commodityFileRefs(commodities []types.INBCommodity) []types.INBFileRef {
+	var refs []types.INBFileRef
+	for i := range commodities {
+		com := &commodities[i]
+		refs = append(refs, com.Images...)
+		refs = append(refs, com.Invoices...)
+		refs = append(refs, com.Manuals...)
+	}
+	return refs
+}
+
+// keyOptions are the signature-verification flags shared by verify, inspect and
+// extract.
+type keyOptions struct {
+	publicKey  string
+	signingKey string
+	noVerify   bool
+}
+
+// register adds the key flags to cmd. allowSkip adds --no-verify (verify itself
+// never offers it).
+func (o *keyOptions) register(cmd *cobra.Command, allowSkip bool) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.publicKey, "public-key", "", "verify against this public key (PEM/base64/hex, or a file path)")
+	flags.StringVar(&o.signingKey, "backup-signing-key", "", "verify against the public half of this Ed25519 seed (64 hex chars or 32 raw bytes); falls back to "+backupSigningKeyEnv)
+	if allowSkip {
+		flags.BoolVar(&o.noVerify, "no-verify", false, "skip the signature check (the archive contents are then untrusted)")
+	}
+}
+
+// resolve returns the public key to verify with: --public-key first, then the
+// public half of the signing key from --backup-signing-key or the environment.
+func (o *keyOptions) resolve() (ed25519.PublicKey, error) {
+	if o.publicKey != "" {
+		return loadVerifyKey(o.publicKey)
+	}
+	if o.signingKey == "" && os.Getenv(backupSigningKeyEnv) == "" {
+		return nil, errNoVerifyKey
+	}
+	signer, err := loadSigner(o.signingKey)
+	if err != nil {
+		return nil, err
+	}
+	return signer.PublicKey(), nil
+}
+
+// openVerified opens the archive and, unless --no-verify was given, checks its
+// signature before anything is inflated.
+func (o *keyOptions) openVerified(out io.Writer, path string) (*archive, error) {
+	var pub ed25519.PublicKey
+	if !o.noVerify {
+		var err error
+		if pub, err = o.resolve(); err != nil {
+			return nil, err
+		}
+	}
+	a, err := openArchive(path)
+	if err != nil {
+		return nil, err
+	}
+	if o.noVerify {
+		fmt.Fprintln(out, "warning: signature NOT verified (--no-verify)")
+		return a, nil
+	}
+	if err := a.verify(pub); err != nil {
+		_ = a.Close()
+		return nil, fmt.Errorf("signature does not verify against key %s: %w", fingerprint(pub), err)
+	}
+	return a, nil
+}
+
+// fingerprint mirrors backupsign.Signer.Fingerprint for a bare public key.
+func fingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,7 @@
 // Package backup is the parent command group for signed `.inb` backup archive
 // tooling (issue #534). Its subcommands operate directly on `.inb` files on
-// disk and need only the backup signing key — never a database connection.
+// disk and need only the backup signing key (or, to read an archive, its public
+// key) — never a database connection.
 package backup
 
 import (
@@ -15,16 +16,23 @@ func New() *cobra.Command {
 		Long: `Parent command for working with signed .inb backup archives.
 
 Subcommands operate on .inb files directly and require only the backup signing
-key (--backup-signing-key / INVENTARIO_RUN_BACKUP_SIGNING_KEY) — they do not
-connect to a database.
+key (--backup-signing-key / INVENTARIO_RUN_BACKUP_SIGNING_KEY) or, for the
+read-only verify / inspect / extract, the matching public key (--public-key) —
+they do not connect to a database.
 
 Examples:
   inventario backup public-key
-  inventario backup resign old.inb -o new.inb`,
+  inventario backup resign old.inb -o new.inb
+  inventario backup verify backup.inb --public-key backup-key.pem
+  inventario backup inspect backup.inb --public-key backup-key.pem
+  inventario backup extract backup.inb --location garage -o ./garage`,
 		Args: cobra.NoArgs,
 	}
 
 	cmd.AddCommand(newResignCmd())
 	cmd.AddCommand(newPublicKeyCmd())
+	cmd.AddCommand(newVerifyCmd())
+	cmd.AddCommand(newInspectCmd())
+	cmd.AddCommand(newExtractCmd())
 	return cmd
 }
@@ -0,0 +1,231 @@
+package backup
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/internal/textutils"
+)
+
+// extractOptions holds the parsed flags for `inventario backup extract`.
+type extractOptions struct {
+	keyOptions
+	location string
+	output   string
+}
+
+// newExtractCmd builds the `extract` subcommand: it unpacks an `.inb` archive's
+// JSON documents and file bytes into a plain directory, so a single location
+// can be recovered by hand without a database or a restore.
+func newExtractCmd() *cobra.Command {
+	opts := &extractOptions{}
+	cmd := &cobra.Command{
+		Use:   "extract <file.inb> -o <dir>",
+		Short: "Extract an .inb archive's documents and files to a directory",
+		Long: `Extract the contents of an .inb backup archive to a directory without a
+database.
+
+Members keep their archive paths under the output directory: manifest.json, the
+location documents, and the file bytes under files/.
+
+--location narrows the extraction to one location, matched by its UUID, its
+name, or its slug (the cleaned name used in the location document's member
+name). Only that location's document and commodity files are written, plus the
+location- and area-linked files (with a files/_index.json listing just those).
+Without --location everything is extracted.
+
+Existing files in the output directory are never overwritten.
+
+The signature is verified first, with the same key options as "backup verify";
+pass --no-verify to extract from an archive whose key you do not have.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExtract(cmd, args[0], opts)
+		},
+	}
+	opts.register(cmd, true)
+	flags := cmd.Flags()
+	flags.StringVar(&opts.location, "location", "", "extract only this location (UUID, name or slug)")
+	flags.StringVarP(&opts.output, "output", "o", "", "directory to extract into (created if missing)")
+	_ = cmd.MarkFlagRequired("output")
+	return cmd
+}
+
+func runExtract(cmd *cobra.Command, path string, opts *extractOptions) error {
+	out := cmd.OutOrStdout()
+
+	a, err := opts.openVerified(out, path)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	// Pass 1: decode the documents and decide which members to write.
+	c, err := a.scan()
+	if err != nil {
+		return err
+	}
+	plan, err := planExtract(c, opts.location)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(opts.output, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Pass 2: copy the selected members out verbatim.
+	written := 0
+	err = a.walk(func(hdr *tar.Header, r io.Reader) error {
+		if !plan.members[hdr.Name] {
+			return nil
+		}
+		if err := writeMember(opts.output, hdr.Name, r); err != nil {
+			return err
+		}
+		written++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if plan.filesIndex != nil {
+		data, err := json.MarshalIndent(plan.filesIndex, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode files index: %w", err)
+		}
+		if err := writeMember(opts.output, types.INBFilesMember, strings.NewReader(string(data))); err != nil {
+			return err
+		}
+		written++
+	}
+
+	fmt.Fprintf(out, "extracted %d members to %s\n", written, opts.output)
+	return nil
+}
+
+// extractPlan is the outcome of the planning pass: the inner-tar members to copy
+// verbatim, and — for a single-location extract — a filtered files index to
+// write in place of the archive's own.
+type extractPlan struct {
+	members    map[string]bool
+	filesIndex *types.INBFilesDoc
+}
+
+// planExtract selects the members to extract. An empty selector selects every
+// member; otherwise exactly one location must match it.
+func planExtract(c *contents, selector string) (*extractPlan, error) {
+	plan := &extractPlan{members: make(map[string]bool)}
+
+	if selector == "" {
+		plan.members[types.INBManifestMember] = c.manifest != nil
+		for _, member := range c.locationOrder {
+			plan.members[member] = true
+		}
+		plan.members[types.INBUnassignedMember] = c.unassigned != nil
+		plan.members[types.INBFilesMember] = c.files != nil
+		for _, member := range c.fileOrder {
+			plan.members[member] = true
+		}
+		for _, member := range c.unknown {
+			plan.members[member] = true
+		}
+		return plan, nil
+	}
+
+	member, err := matchLocation(c, selector)
+	if err != nil {
+		return nil, err
+	}
+	doc := c.locations[member]
+
+	plan.members[types.INBManifestMember] = c.manifest != nil
+	plan.members[member] = true
+	for _, ref := range commodityFileRefs(doc.Commodities) {
+		plan.members[ref.Path] = true
+	}
+
+	if c.files != nil {
+		linked := map[string]bool{"location:" + doc.Location.ID: true}
+		for _, area := range doc.Areas {
+			linked["area:"+area.ID] = true
+		}
+		var index types.INBFilesDoc
+		for _, ref := range c.files.Files {
+			if !linked[ref.LinkedEntityType+":"+ref.LinkedEntityID] {
+				continue
+			}
+			index.Files = append(index.Files, ref)
+			plan.members[ref.Path] = true
+		}
+		if len(index.Files) > 0 {
+			plan.filesIndex = &index
+		}
+	}
+	return plan, nil
+}
+
+// matchLocation resolves selector to a single location document member, trying
+// the UUID first, then the exact name, then the slug.
+func matchLocation(c *contents, selector string) (string, error) {
+	for _, match := range []func(loc types.INBLocation) bool{
+		func(loc types.INBLocation) bool { return loc.ID == selector },
+		func(loc types.INBLocation) bool { return loc.Name == selector },
+		func(loc types.INBLocation) bool { return textutils.CleanFilename(loc.Name) == selector },
+	} {
+		var found []string
+		for _, member := range c.locationOrder {
+			if match(c.locations[member].Location) {
+				found = append(found, member)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			ids := make([]string, 0, len(found))
+			for _, member := range found {
+				ids = append(ids, c.locations[member].Location.ID)
+			}
+			return "", fmt.Errorf("location %q is ambiguous; use one of the UUIDs: %s", selector, strings.Join(ids, ", "))
+		}
+	}
+	return "", fmt.Errorf("no location matches %q", selector)
+}
+
+// writeMember writes one member under dir at its archive path. Member names come
+// from the archive, so anything that would land outside dir is refused, and an
+// existing file is never overwritten.
+func writeMember(dir, name string, r io.Reader) error {
+	rel := filepath.FromSlash(name)
+	if !filepath.IsLocal(rel) {
+		return fmt.Errorf("refusing to extract member %s: path escapes the output directory", name)
+	}
+	target := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", target, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", target, err)
+	}
+	return nil
+}
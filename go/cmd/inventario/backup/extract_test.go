@@ -0,0 +1,87 @@
+package backup_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/internal/backupsign"
+)
+
+func TestExtract_SingleLocation(t *testing.T) {
+	for _, selector := range []string{"loc-1", "Garage"} {
+		t.Run(selector, func(t *testing.T) {
+			c := qt.New(t)
+			signer := must.Must(backupsign.NewSigner(seed(0x21)))
+			manifest, members := fixtureArchive()
+			path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+			dir := filepath.Join(c.TempDir(), "out")
+
+			out, err := runBackup("extract", path, "--public-key", signer.PublicKeyBase64(), "--location", selector, "-o", dir)
+			c.Assert(err, qt.IsNil, qt.Commentf("output=%s", out))
+
+			c.Assert(string(must.Must(os.ReadFile(filepath.Join(dir, filepath.FromSlash(garageFile))))), qt.Equals, "drill")
+			c.Assert(string(must.Must(os.ReadFile(filepath.Join(dir, filepath.FromSlash(garageAreaDoc))))), qt.Equals, "plan!")
+			var doc types.INBLocationDoc
+			c.Assert(json.Unmarshal(must.Must(os.ReadFile(filepath.Join(dir, garageMember))), &doc), qt.IsNil)
+			c.Assert(doc.Location.ID, qt.Equals, "loc-1")
+			var index types.INBFilesDoc
+			c.Assert(json.Unmarshal(must.Must(os.ReadFile(filepath.Join(dir, filepath.FromSlash(types.INBFilesMember)))), &index), qt.IsNil)
+			c.Assert(index.Files, qt.HasLen, 1)
+
+			// The other location stays in the archive.
+			_, err = os.Stat(filepath.Join(dir, atticMember))
+			c.Assert(os.IsNotExist(err), qt.IsTrue)
+			_, err = os.Stat(filepath.Join(dir, filepath.FromSlash(atticFile)))
+			c.Assert(os.IsNotExist(err), qt.IsTrue)
+		})
+	}
+}
+
+func TestExtract_UnknownLocation(t *testing.T) {
+	c := qt.New(t)
+	signer := must.Must(backupsign.NewSigner(seed(0x24)))
+	manifest, members := fixtureArchive()
+	path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+
+	_, err := runBackup("extract", path, "--public-key", signer.PublicKeyBase64(), "--location", "Cellar", "-o", c.TempDir())
+	c.Assert(err, qt.ErrorMatches, `no location matches "Cellar"`)
+}
+
+func TestExtract_Everything(t *testing.T) {
+	c := qt.New(t)
+	signer := must.Must(backupsign.NewSigner(seed(0x22)))
+	manifest, members := fixtureArchive()
+	path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+	dir := c.TempDir()
+
+	out, err := runBackup("extract", path, "--public-key", signer.PublicKeyBase64(), "-o", dir)
+	c.Assert(err, qt.IsNil, qt.Commentf("output=%s", out))
+	c.Assert(out, qt.Contains, "extracted 7 members")
+	for _, m := range members {
+		c.Assert(string(must.Must(os.ReadFile(filepath.Join(dir, filepath.FromSlash(m.name))))), qt.Equals, string(m.data))
+	}
+
+	// A second run never overwrites what is already there.
+	_, err = runBackup("extract", path, "--public-key", signer.PublicKeyBase64(), "-o", dir)
+	c.Assert(err, qt.ErrorMatches, "failed to create .*: .*file exists")
+}
+
+func TestExtract_RefusesEscapingMember(t *testing.T) {
+	c := qt.New(t)
+	signer := must.Must(backupsign.NewSigner(seed(0x23)))
+	manifest, members := fixtureArchive()
+	members = append(members, member{name: "files/../../escape.txt", data: []byte("x")})
+	path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+	dir := filepath.Join(c.TempDir(), "out")
+
+	_, err := runBackup("extract", path, "--public-key", signer.PublicKeyBase64(), "-o", dir)
+	c.Assert(err, qt.ErrorMatches, "refusing to extract member files/../../escape.txt: .*")
+	_, err = os.Stat(filepath.Join(dir, "..", "escape.txt"))
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+}
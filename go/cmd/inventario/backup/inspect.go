@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+)
+
+// newInspectCmd builds the `inspect` subcommand: a read-only listing of what an
+// `.inb` archive holds.
+func newInspectCmd() *cobra.Command {
+	opts := &keyOptions{}
+	cmd := &cobra.Command{
+		Use:   "inspect <file.inb>",
+		Short: "Print an .inb archive's manifest, location index and file listing",
+		Long: `Print what an .inb backup archive holds without restoring it.
+
+Shows the manifest (format version, export date and type, signer, statistics),
+the location index with per-location area, commodity and file counts, and every
+file member with its size.
+
+The signature is verified first, with the same key options as "backup verify";
+pass --no-verify to look inside an archive whose key you do not have.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(cmd, args[0], opts)
+		},
+	}
+	opts.register(cmd, true)
+	return cmd
+}
+
+func runInspect(cmd *cobra.Command, path string, opts *keyOptions) error {
+	out := cmd.OutOrStdout()
+
+	a, err := opts.openVerified(out, path)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	c, err := a.scan()
+	if err != nil {
+		return err
+	}
+	if c.manifest == nil {
+		return fmt.Errorf("archive has no %s", types.INBManifestMember)
+	}
+	printManifest(out, c.manifest)
+	printLocations(out, c)
+	printFiles(out, c)
+	return nil
+}
+
+func printManifest(out io.Writer, m *types.INBManifest) {
+	s := m.Statistics
+	fmt.Fprintf(out, "format:      %s %s (%s)\n", m.Format, m.Version, m.Compression)
+	fmt.Fprintf(out, "exported:    %s (%s)\n", m.ExportDate, m.ExportType)
+	fmt.Fprintf(out, "signed by:   %s %s\n", m.Signature.Algorithm, m.Signature.Fingerprint)
+	fmt.Fprintf(out, "locations:   %d\n", s.LocationCount)
+	fmt.Fprintf(out, "areas:       %d\n", s.AreaCount)
+	fmt.Fprintf(out, "commodities: %d\n", s.CommodityCount)
+	fmt.Fprintf(out, "files:       %d (%d images, %d invoices, %d manuals), %d bytes\n",
+		s.FileCount, s.ImageCount, s.InvoiceCount, s.ManualCount, s.TotalFileSize)
+}
+
+func printLocations(out io.Writer, c *contents) {
+	fmt.Fprintln(out)
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOCATION\tID\tAREAS\tCOMMODITIES\tFILES\tDOCUMENT")
+	for _, loc := range c.manifest.Locations {
+		doc, ok := c.locations[loc.File]
+		if !ok {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t%s (missing)\n", loc.Name, loc.ID, loc.File)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\n",
+			loc.Name, loc.ID, len(doc.Areas), len(doc.Commodities), len(commodityFileRefs(doc.Commodities)), loc.File)
+	}
+	if c.unassigned != nil {
+		fmt.Fprintf(tw, "(unassigned)\t-\t-\t%d\t%d\t%s\n",
+			len(c.unassigned.Commodities), len(commodityFileRefs(c.unassigned.Commodities)), types.INBUnassignedMember)
+	}
+	if c.files != nil {
+		fmt.Fprintf(tw, "(location, area and standalone files)\t-\t-\t-\t%d\t%s\n",
+			len(c.files.Files), types.INBFilesMember)
+	}
+	_ = tw.Flush()
+}
+
+func printFiles(out io.Writer, c *contents) {
+	fmt.Fprintln(out)
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SIZE\tPATH")
+	for _, member := range c.fileOrder {
+		fmt.Fprintf(tw, "%d\t%s\n", c.fileMembers[member], member)
+	}
+	_ = tw.Flush()
+}
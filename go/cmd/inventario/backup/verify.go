@@ -0,0 +1,229 @@
+package backup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+)
+
+// newVerifyCmd builds the `verify` subcommand: an offline integrity check of an
+// `.inb` archive that needs only a public key — no database, no server.
+func newVerifyCmd() *cobra.Command {
+	opts := &keyOptions{}
+	cmd := &cobra.Command{
+		Use:   "verify <file.inb>",
+		Short: "Check an .inb archive's signature and contents offline",
+		Long: `Check an .inb backup archive without a database.
+
+The detached Ed25519 signature is checked first; nothing is inflated from an
+archive that does not verify. The payload is then walked once and every member
+is cross-checked against manifest.json:
+
+  - the manifest is present and its format version is readable by this build
+  - every location listed in the manifest index has its document, and no
+    location document is missing from the index
+  - the unassigned-commodities and non-commodity files documents match the
+    manifest's pointers to them
+  - every file reference has its bytes member, and no file member is orphaned
+  - the manifest statistics match the counts recomputed from the documents
+
+The key to verify with is taken from --public-key (PEM, base64 or hex, inline
+or a file path) or, failing that, from the public half of the signing key
+(--backup-signing-key / ` + backupSigningKeyEnv + `).
+
+Exits non-zero when the signature or any check fails.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd, args[0], opts)
+		},
+	}
+	opts.register(cmd, false)
+	return cmd
+}
+
+func runVerify(cmd *cobra.Command, path string, opts *keyOptions) error {
+	out := cmd.OutOrStdout()
+
+	pub, err := opts.resolve()
+	if err != nil {
+		return err
+	}
+	a, err := openArchive(path)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	keyFingerprint := fingerprint(pub)
+	if err := a.verify(pub); err != nil {
+		return fmt.Errorf("signature does not verify against key %s: %w", keyFingerprint, err)
+	}
+	fmt.Fprintf(out, "signature: ok (key %s)\n", keyFingerprint)
+
+	c, err := a.scan()
+	if err != nil {
+		return err
+	}
+	if c.manifest != nil && c.manifest.Signature.Fingerprint != "" && c.manifest.Signature.Fingerprint != keyFingerprint {
+		// Not a failure: `backup resign` replaces the signature but leaves the
+		// manifest's informational signer block untouched.
+		fmt.Fprintf(out, "note: manifest records signing key %s (archive re-signed since export)\n", c.manifest.Signature.Fingerprint)
+	}
+
+	for _, member := range c.unknown {
+		// Restore drains unknown members for forward compatibility, so they are
+		// reported but never fail verification.
+		fmt.Fprintf(out, "note: ignoring unknown member %s\n", member)
+	}
+
+	problems := checkContents(c)
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "contents: ok (%d locations, %d commodities, %d files)\n",
+			len(c.locationOrder), c.manifest.Statistics.CommodityCount, len(c.fileOrder))
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Fprintf(out, "problem: %s\n", p)
+	}
+	return fmt.Errorf("archive failed verification with %d problem(s)", len(problems))
+}
+
+// checkContents cross-checks the scanned documents and file members against the
+// manifest and returns one human-readable line per problem found.
+func checkContents(c *contents) []string {
+	var problems []string
+	addf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	m := c.manifest
+	if m == nil {
+		return []string{"manifest.json is missing"}
+	}
+	if major, ok := formatMajor(m.Version); ok && major > supportedInbMajor {
+		addf("format version %s is newer than this build supports (major %d)", m.Version, supportedInbMajor)
+	}
+
+	// Location index <-> location documents.
+	indexed := make(map[string]bool, len(m.Locations))
+	for _, loc := range m.Locations {
+		indexed[loc.File] = true
+		doc, ok := c.locations[loc.File]
+		switch {
+		case !ok:
+			addf("location %q (%s) is indexed but its document %s is missing", loc.Name, loc.ID, loc.File)
+		case doc.Location.ID != loc.ID:
+			addf("document %s holds location %s, but the index says %s", loc.File, doc.Location.ID, loc.ID)
+		}
+	}
+	for _, member := range c.locationOrder {
+		if !indexed[member] {
+			addf("location document %s is not in the manifest index", member)
+		}
+	}
+
+	// Optional documents the manifest points at.
+	switch {
+	case m.UnassignedFile != "" && c.unassigned == nil:
+		addf("manifest names %s but the archive does not contain it", m.UnassignedFile)
+	case m.UnassignedFile != "" && m.UnassignedFile != types.INBUnassignedMember:
+		addf("manifest names unassigned document %s, expected %s", m.UnassignedFile, types.INBUnassignedMember)
+	}
+	switch {
+	case m.FilesFile != "" && c.files == nil:
+		addf("manifest names %s but the archive does not contain it", m.FilesFile)
+	case m.FilesFile != "" && m.FilesFile != types.INBFilesMember:
+		addf("manifest names files document %s, expected %s", m.FilesFile, types.INBFilesMember)
+	}
+
+	// File references <-> file members, and the recomputed statistics.
+	var got types.INBManifestStats
+	got.LocationCount = len(c.locationOrder)
+	referenced := make(map[string]bool)
+	checkRef := func(owner string, ref types.INBFileRef) {
+		if referenced[ref.Path] {
+			addf("file %s is referenced more than once", ref.Path)
+			return
+		}
+		referenced[ref.Path] = true
+		got.FileCount++
+		size, ok := c.fileMembers[ref.Path]
+		if !ok {
+			addf("%s references %s, which is missing from the archive", owner, ref.Path)
+			return
+		}
+		got.TotalFileSize += size
+	}
+	countCommodities := func(commodities []types.INBCommodity) {
+		for i := range commodities {
+			com := &commodities[i]
+			got.CommodityCount++
+			got.ImageCount += len(com.Images)
+			got.InvoiceCount += len(com.Invoices)
+			got.ManualCount += len(com.Manuals)
+			owner := "commodity " + com.ID
+			for _, refs := range [][]types.INBFileRef{com.Images, com.Invoices, com.Manuals} {
+				for _, ref := range refs {
+					checkRef(owner, ref)
+				}
+			}
+		}
+	}
+	for _, member := range c.locationOrder {
+		doc := c.locations[member]
+		got.AreaCount += len(doc.Areas)
+		countCommodities(doc.Commodities)
+	}
+	if c.unassigned != nil {
+		countCommodities(c.unassigned.Commodities)
+	}
+	if c.files != nil {
+		for _, ref := range c.files.Files {
+			checkRef("file "+ref.ID, ref.INBFileRef)
+		}
+	}
+	for _, member := range c.fileOrder {
+		if !referenced[member] {
+			addf("file member %s is not referenced by any document", member)
+		}
+	}
+
+	want := m.Statistics
+	for _, s := range []struct {
+		name      string
+		want, got int64
+	}{
+		{"locationCount", int64(want.LocationCount), int64(got.LocationCount)},
+		{"areaCount", int64(want.AreaCount), int64(got.AreaCount)},
+		{"commodityCount", int64(want.CommodityCount), int64(got.CommodityCount)},
+		{"imageCount", int64(want.ImageCount), int64(got.ImageCount)},
+		{"invoiceCount", int64(want.InvoiceCount), int64(got.InvoiceCount)},
+		{"manualCount", int64(want.ManualCount), int64(got.ManualCount)},
+		{"fileCount", int64(want.FileCount), int64(got.FileCount)},
+		{"totalFileSize", want.TotalFileSize, got.TotalFileSize},
+	} {
+		if s.want != s.got {
+			addf("statistics.%s is %d in the manifest but %d in the archive", s.name, s.want, s.got)
+		}
+	}
+	return problems
+}
+
+// formatMajor extracts the MAJOR component of a manifest format version
+// ("2.1" → 2). ok=false for an absent or unparseable value, which — like the
+// restore processor — is treated as "no constraint".
+func formatMajor(version string) (int, bool) {
+	if version == "" {
+		return 0, false
+	}
+	majorPart, _, _ := strings.Cut(version, ".")
+	major, err := strconv.Atoi(majorPart)
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
@@ -0,0 +1,244 @@
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/backup/restore/types"
+	"github.com/denisvmedia/inventario/cmd/inventario/backup"
+	"github.com/denisvmedia/inventario/internal/backupsign"
+)
+
+// member is one inner-tar entry of a test payload.
+type member struct {
+	name string
+	data []byte
+}
+
+const (
+	garageMember  = "location-Garage-loc-1.json"
+	garageFile    = "files/Garage/com-1/images/file-1/drill.jpg"
+	garageAreaDoc = "files/_entity/area/area-1/file-2/plan.pdf"
+	atticMember   = "location-Attic-loc-2.json"
+	atticFile     = "files/Attic/com-2/manuals/file-3/lamp.pdf"
+)
+
+// fixtureArchive returns the inner-tar members of a small two-location archive
+// with one commodity file per location and one area-linked file, and the
+// manifest that describes them. Tests mutate either before packing.
+func fixtureArchive() (*types.INBManifest, []member) {
+	garage := types.INBLocationDoc{
+		Location: types.INBLocation{ID: "loc-1", Name: "Garage"},
+		Areas:    []types.INBArea{{ID: "area-1", Name: "Shelf", LocationID: "loc-1"}},
+		Commodities: []types.INBCommodity{{
+			ID: "com-1", Name: "Drill", AreaID: "area-1", Count: 1,
+			Images: []types.INBFileRef{{ID: "file-1", Path: garageFile}},
+		}},
+	}
+	attic := types.INBLocationDoc{
+		Location: types.INBLocation{ID: "loc-2", Name: "Attic"},
+		Areas:    []types.INBArea{{ID: "area-2", Name: "Corner", LocationID: "loc-2"}},
+		Commodities: []types.INBCommodity{{
+			ID: "com-2", Name: "Lamp", AreaID: "area-2", Count: 1,
+			Manuals: []types.INBFileRef{{ID: "file-3", Path: atticFile}},
+		}},
+	}
+	files := types.INBFilesDoc{Files: []types.INBEntityFileRef{{
+		INBFileRef:       types.INBFileRef{ID: "file-2", Path: garageAreaDoc},
+		LinkedEntityType: "area",
+		LinkedEntityID:   "area-1",
+	}}}
+	manifest := &types.INBManifest{
+		Version:   "2.1",
+		Format:    "inb",
+		FilesFile: types.INBFilesMember,
+		Locations: []types.INBManifestLoc{
+			{ID: "loc-1", Name: "Garage", File: garageMember},
+			{ID: "loc-2", Name: "Attic", File: atticMember},
+		},
+		Statistics: types.INBManifestStats{
+			LocationCount: 2, AreaCount: 2, CommodityCount: 2,
+			ImageCount: 1, ManualCount: 1, FileCount: 3, TotalFileSize: 15,
+		},
+	}
+	return manifest, []member{
+		{name: garageMember, data: must.Must(json.Marshal(garage))},
+		{name: garageFile, data: []byte("drill")},
+		{name: atticMember, data: must.Must(json.Marshal(attic))},
+		{name: atticFile, data: []byte("lamp!")},
+		{name: types.INBFilesMember, data: must.Must(json.Marshal(files))},
+		{name: garageAreaDoc, data: []byte("plan!")},
+	}
+}
+
+// packPayload builds payload.tar.gz with the manifest first, as the exporter
+// writes it.
+func packPayload(c *qt.C, manifest *types.INBManifest, members []member) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	all := append([]member{{name: types.INBManifestMember, data: must.Must(json.Marshal(manifest))}}, members...)
+	for _, m := range all {
+		c.Assert(tw.WriteHeader(&tar.Header{Name: m.name, Mode: 0o644, Size: int64(len(m.data)), Typeflag: tar.TypeReg}), qt.IsNil)
+		_ = must.Must(tw.Write(m.data))
+	}
+	c.Assert(tw.Close(), qt.IsNil)
+	c.Assert(gz.Close(), qt.IsNil)
+	return buf.Bytes()
+}
+
+// runBackup executes `inventario backup <args...>` and returns its output.
+func runBackup(args ...string) (string, error) {
+	cmd := backup.New()
+	cmd.SetArgs(args)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestVerify_ValidArchive(t *testing.T) {
+	c := qt.New(t)
+	signer := must.Must(backupsign.NewSigner(seed(0x11)))
+	manifest, members := fixtureArchive()
+	path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+
+	out, err := runBackup("verify", path, "--public-key", signer.PublicKeyBase64())
+	c.Assert(err, qt.IsNil, qt.Commentf("output=%s", out))
+	c.Assert(out, qt.Contains, "signature: ok (key "+signer.Fingerprint()+")")
+	c.Assert(out, qt.Contains, "contents: ok (2 locations, 2 commodities, 3 files)")
+
+	// The signing key's public half works as well as an explicit public key.
+	out, err = runBackup("verify", path, "--backup-signing-key", hexSeed(0x11))
+	c.Assert(err, qt.IsNil, qt.Commentf("output=%s", out))
+}
+
+func TestVerify_WrongKey(t *testing.T) {
+	c := qt.New(t)
+	signer := must.Must(backupsign.NewSigner(seed(0x12)))
+	other := must.Must(backupsign.NewSigner(seed(0x13)))
+	manifest, members := fixtureArchive()
+	path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+
+	_, err := runBackup("verify", path, "--public-key", other.PublicKeyBase64())
+	c.Assert(err, qt.ErrorIs, backupsign.ErrBadSignature)
+}
+
+func TestVerify_ContentProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(m *types.INBManifest, members []member) []member
+		want   string
+	}{
+		{
+			name: "missing file member",
+			mutate: func(_ *types.INBManifest, members []member) []member {
+				return without(members, atticFile)
+			},
+			want: "commodity com-2 references " + atticFile + ", which is missing from the archive",
+		},
+		{
+			name: "orphan file member",
+			mutate: func(_ *types.INBManifest, members []member) []member {
+				return append(members, member{name: "files/Garage/stray.bin", data: []byte("x")})
+			},
+			want: "file member files/Garage/stray.bin is not referenced by any document",
+		},
+		{
+			name: "missing location document",
+			mutate: func(_ *types.INBManifest, members []member) []member {
+				return without(members, atticMember, atticFile)
+			},
+			want: `location "Attic" (loc-2) is indexed but its document ` + atticMember + " is missing",
+		},
+		{
+			name: "unindexed location document",
+			mutate: func(m *types.INBManifest, members []member) []member {
+				m.Locations = m.Locations[:1]
+				return members
+			},
+			want: "location document " + atticMember + " is not in the manifest index",
+		},
+		{
+			name: "statistics mismatch",
+			mutate: func(m *types.INBManifest, members []member) []member {
+				m.Statistics.TotalFileSize = 99
+				return members
+			},
+			want: "statistics.totalFileSize is 99 in the manifest but 15 in the archive",
+		},
+		{
+			name: "files document named but absent",
+			mutate: func(_ *types.INBManifest, members []member) []member {
+				return without(members, types.INBFilesMember, garageAreaDoc)
+			},
+			want: "manifest names " + types.INBFilesMember + " but the archive does not contain it",
+		},
+		{
+			name: "newer format",
+			mutate: func(m *types.INBManifest, members []member) []member {
+				m.Version = "3.0"
+				return members
+			},
+			want: "format version 3.0 is newer than this build supports",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			signer := must.Must(backupsign.NewSigner(seed(0x14)))
+			manifest, members := fixtureArchive()
+			members = tt.mutate(manifest, members)
+			path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+
+			out, err := runBackup("verify", path, "--public-key", signer.PublicKeyBase64())
+			c.Assert(err, qt.ErrorMatches, `archive failed verification with \d+ problem\(s\)`)
+			c.Assert(out, qt.Contains, "problem: "+tt.want)
+		})
+	}
+}
+
+func TestInspect_ListsLocationsAndFiles(t *testing.T) {
+	c := qt.New(t)
+	signer := must.Must(backupsign.NewSigner(seed(0x15)))
+	manifest, members := fixtureArchive()
+	path, _ := writeArchive(c, c.TempDir(), signer, packPayload(c, manifest, members))
+
+	out, err := runBackup("inspect", path, "--public-key", signer.PublicKeyBase64())
+	c.Assert(err, qt.IsNil, qt.Commentf("output=%s", out))
+	c.Assert(out, qt.Contains, "format:      inb 2.1")
+	c.Assert(out, qt.Matches, `(?s).*Garage\s+loc-1\s+1\s+1\s+1\s+`+garageMember+`.*`)
+	c.Assert(out, qt.Matches, `(?s).*5\s+`+atticFile+`.*`)
+
+	// Without a key inspect refuses, unless verification is explicitly skipped.
+	c.Setenv("INVENTARIO_RUN_BACKUP_SIGNING_KEY", "")
+	_, err = runBackup("inspect", path)
+	c.Assert(err, qt.ErrorMatches, "no key to verify the archive with.*")
+	out, err = runBackup("inspect", path, "--no-verify")
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.Contains, "signature NOT verified")
+}
+
+// without returns members minus the named ones.
+func without(members []member, names ...string) []member {
+	drop := make(map[string]bool, len(names))
+	for _, n := range names {
+		drop[n] = true
+	}
+	var kept []member
+	for _, m := range members {
+		if !drop[m.name] {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
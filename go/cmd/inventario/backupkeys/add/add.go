@@ -0,0 +1,144 @@
+// Package add implements `inventario backup-keys add`.
+package add
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Config carries the add command's flags.
+type Config struct {
+	PublicKey string `yaml:"public_key" env:"PUBLIC_KEY"`
+	Label     string `yaml:"label" env:"LABEL"`
+	Expires   string `yaml:"expires" env:"EXPIRES"`
+}
+
+// Command is the `backup-keys add` cobra wrapper.
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New constructs the command with the supplied database config.
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	shared.TryReadSection("backup-keys.add", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "add",
+		Short: "Trust a backup-signing public key",
+		Long: `Add an Ed25519 public key to the backup-signing keyring.
+
+--public-key takes the key as a PEM "PUBLIC KEY" block, standard base64 or
+hex — inline, or the path to a file holding it. These are the forms the
+/backup/public-key endpoint and "inventario backup resign --verify-key"
+use.
+
+--expires optionally stops trusting the key at a given time (RFC 3339, or
+a date meaning midnight UTC). A key that is already in the keyring, even
+a revoked one, cannot be added again.
+
+Examples:
+  inventario backup-keys add --label staging --public-key staging.pem
+  inventario backup-keys add --label "prod 2025" --public-key <hex> --expires 2026-12-31`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.run(&c.config, dbConfig)
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	c.Cmd().Flags().StringVar(&c.config.PublicKey, "public-key", c.config.PublicKey, "Public key to trust: PEM, base64 or hex, inline or a file path (required)")
+	c.Cmd().Flags().StringVar(&c.config.Label, "label", c.config.Label, "Name for the key, e.g. the instance it belongs to (required)")
+	c.Cmd().Flags().StringVar(&c.config.Expires, "expires", c.config.Expires, "Optional expiry (RFC 3339 or YYYY-MM-DD)")
+}
+
+func (c *Command) run(cfg *Config, dbConfig *shared.DatabaseConfig) error {
+	out := c.Cmd().OutOrStdout()
+
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return errors.New("backup-keys commands are not supported for memory databases: the keyring must persist in a database shared with the server; use PostgreSQL")
+	}
+	if err := dbConfig.Validate(); err != nil {
+		return errxtrace.Wrap("database configuration error", err)
+	}
+	if strings.TrimSpace(cfg.PublicKey) == "" {
+		return errors.New("--public-key is required")
+	}
+	if strings.TrimSpace(cfg.Label) == "" {
+		return errors.New("--label is required")
+	}
+	publicKey, err := readKey(cfg.PublicKey)
+	if err != nil {
+		return err
+	}
+	expiresAt, err := parseExpires(cfg.Expires)
+	if err != nil {
+		return err
+	}
+
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	key, err := adminService.AddTrustedBackupKey(c.Cmd().Context(), publicKey, cfg.Label, expiresAt)
+	if err != nil {
+		return errxtrace.Wrap("failed to add trusted backup key", err)
+	}
+
+	fmt.Fprintf(out, "✅ Trusted backup key %q (fingerprint %s).\n", key.Label, key.Fingerprint)
+	if key.ExpiresAt != nil {
+		fmt.Fprintf(out, "   expires: %s\n", key.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
+	}
+	return nil
+}
+
+// readKey returns the key material from value, reading it from disk when
+// value names a regular file.
+func readKey(value string) (string, error) {
+	if info, statErr := os.Stat(value); statErr == nil && !info.IsDir() {
+		data, err := os.ReadFile(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to read --public-key file: %w", err)
+		}
+		return string(data), nil
+	}
+	return value, nil
+}
+
+// parseExpires parses the --expires flag; an empty value means no expiry.
+func parseExpires(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid --expires %q: use RFC 3339 (2026-12-31T00:00:00Z) or a date (2026-12-31)", value)
+}
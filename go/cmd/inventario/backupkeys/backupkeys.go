@@ -0,0 +1,64 @@
+// Package backupkeys is the CLI command group for the backup-signing
+// keyring: the Ed25519 public keys whose `.inb` signatures import and
+// restore accept in addition to the server's own signing key.
+//
+// Trusting a key lets this instance restore archives from a sibling
+// instance (e.g. staging) or archives signed with this instance's key before
+// a rotation, without re-signing them offline with `inventario backup
+// resign`. Revoked keys stay in the keyring as a record.
+//
+// All operations require a PostgreSQL DSN — the in-memory backend has no
+// persistence and is not shared with the server process.
+package backupkeys
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/inventario/backupkeys/add"
+	"github.com/denisvmedia/inventario/cmd/inventario/backupkeys/list"
+	"github.com/denisvmedia/inventario/cmd/inventario/backupkeys/revoke"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+)
+
+// New creates the parent `backup-keys` command and registers its
+// subcommands. The dbConfig flagset is supplied by the root command.
+func New(dbConfig *shared.DatabaseConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup-keys",
+		Short: "Manage the trusted backup-signing keyring",
+		Long: `Manage the keyring of trusted backup-signing keys.
+
+Imports and restores accept an .inb archive signed with the server's own
+key or with any trusted key that is neither revoked nor expired. Trust a
+sibling instance's public key to move backups between instances, or this
+instance's previous key to keep old backups restorable after a key
+rotation. The fingerprint of the key an import verified under is recorded
+on its export.
+
+Revoking a key keeps it in the keyring as a record; every add and revoke
+is written to the audit log.
+
+IMPORTANT: These commands ONLY support PostgreSQL databases.
+
+USAGE EXAMPLES:
+
+  Trust the staging instance's key (PEM, base64 or hex; inline or a file):
+    inventario backup-keys add --label staging --public-key staging.pem
+
+  Trust the pre-rotation key until the end of the year:
+    inventario backup-keys add --label "prod 2025" --public-key <hex> --expires 2026-12-31
+
+  List the keyring:
+    inventario backup-keys list
+
+  Revoke a key:
+    inventario backup-keys revoke --fingerprint <hex> --reason "staging decommissioned"`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(add.New(dbConfig).Cmd())
+	cmd.AddCommand(list.New(dbConfig).Cmd())
+	cmd.AddCommand(revoke.New(dbConfig).Cmd())
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+// Package list implements `inventario backup-keys list`.
+package list
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command is the `backup-keys list` cobra wrapper. It carries no flags.
+type Command struct {
+	command.Base
+}
+
+// New constructs the command with the supplied database config.
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "list",
+		Short: "List the trusted backup-signing keys",
+		Long: `List every key in the backup-signing keyring, revoked and expired
+ones included, in the order they were added. The server's own signing key
+is always accepted and is not listed.
+
+Examples:
+  inventario backup-keys list`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.run(dbConfig)
+		},
+	})
+
+	return c
+}
+
+func (c *Command) run(dbConfig *shared.DatabaseConfig) error {
+	out := c.Cmd().OutOrStdout()
+
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return errors.New("backup-keys commands are not supported for memory databases: the keyring must persist in a database shared with the server; use PostgreSQL")
+	}
+	if err := dbConfig.Validate(); err != nil {
+		return errxtrace.Wrap("database configuration error", err)
+	}
+
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	keys, err := adminService.ListTrustedBackupKeys(c.Cmd().Context())
+	if err != nil {
+		return errxtrace.Wrap("failed to list trusted backup keys", err)
+	}
+	if len(keys) == 0 {
+		fmt.Fprintln(out, "No trusted backup keys.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	now := time.Now()
+	fmt.Fprintln(w, "LABEL\tFINGERPRINT\tSTATE\tADDED_AT\tEXPIRES_AT")
+	for _, k := range keys {
+		state := "active"
+		switch {
+		case k.RevokedAt != nil:
+			state = "revoked"
+		case !k.IsActive(now):
+			state = "expired"
+		}
+		expiresAt := "-"
+		if k.ExpiresAt != nil {
+			expiresAt = k.ExpiresAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", k.Label, k.Fingerprint, state, k.AddedAt.Format("2006-01-02 15:04:05"), expiresAt)
+	}
+	return nil
+}
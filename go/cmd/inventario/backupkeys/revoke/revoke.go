@@ -0,0 +1,99 @@
+// Package revoke implements `inventario backup-keys revoke`.
+package revoke
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Config carries the revoke command's flags.
+type Config struct {
+	Fingerprint string `yaml:"fingerprint" env:"FINGERPRINT"`
+	Reason      string `yaml:"reason" env:"REASON"`
+}
+
+// Command is the `backup-keys revoke` cobra wrapper.
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New constructs the command with the supplied database config.
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	shared.TryReadSection("backup-keys.revoke", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke a trusted backup-signing key",
+		Long: `Stop accepting archives signed with the key named by --fingerprint.
+
+The key stays in the keyring with the revocation time, "cli" as the
+revoker and the optional reason. Revoking an already-revoked key is a
+no-op that keeps the original revocation.
+
+Examples:
+  inventario backup-keys revoke --fingerprint <hex>
+  inventario backup-keys revoke --fingerprint <hex> --reason "staging decommissioned"`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.run(&c.config, dbConfig)
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	c.Cmd().Flags().StringVar(&c.config.Fingerprint, "fingerprint", c.config.Fingerprint, "Fingerprint of the key to revoke (required)")
+	c.Cmd().Flags().StringVar(&c.config.Reason, "reason", c.config.Reason, "Optional reason recorded with the revocation")
+}
+
+func (c *Command) run(cfg *Config, dbConfig *shared.DatabaseConfig) error {
+	out := c.Cmd().OutOrStdout()
+
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return errors.New("backup-keys commands are not supported for memory databases: the keyring must persist in a database shared with the server; use PostgreSQL")
+	}
+	if err := dbConfig.Validate(); err != nil {
+		return errxtrace.Wrap("database configuration error", err)
+	}
+	fingerprint := strings.TrimSpace(cfg.Fingerprint)
+	if fingerprint == "" {
+		return errors.New("--fingerprint is required")
+	}
+
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	key, err := adminService.RevokeTrustedBackupKey(c.Cmd().Context(), fingerprint, strings.TrimSpace(cfg.Reason))
+	if err != nil {
+		return errxtrace.Wrap("failed to revoke trusted backup key", err)
+	}
+
+	revokedAt := "unknown"
+	if key.RevokedAt != nil {
+		revokedAt = key.RevokedAt.Format("2006-01-02 15:04:05 MST")
+	}
+	fmt.Fprintf(out, "🚫 Revoked backup key %q (fingerprint %s, revoked_at: %s).\n", key.Label, key.Fingerprint, revokedAt)
+	return nil
+}
@@ -25,8 +25,9 @@ This command provides database management capabilities including:
 - Schema migrations using Ptah
 - Database maintenance operations
 
-IMPORTANT: These commands ONLY support PostgreSQL databases.
-Other database types are no longer supported in this version.`,
+IMPORTANT: These commands ONLY support PostgreSQL databases, except
+"db status", which also reports the snapshot file of a memory://?snapshot=PATH
+database.`,
 		Args: cobra.NoArgs, // Disallow unknown subcommands
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
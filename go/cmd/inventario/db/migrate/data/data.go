@@ -13,6 +13,7 @@ import (
 	"github.com/denisvmedia/inventario/cmd/inventario/db/setup"
 	"github.com/denisvmedia/inventario/cmd/inventario/shared"
 	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
 )
 
 // Command represents the initial dataset setup command
@@ -116,7 +117,13 @@ func (c *Command) setupData(cfg *Config, dbConfig *shared.DatabaseConfig) error
 	fmt.Println()
 
 	// Connect to database
-	db, err := sql.Open("postgres", dsn)
+	var db *sql.DB
+	var err error
+	if sqlite.IsDSN(dsn) {
+		db, err = sqlite.Open(dsn)
+	} else {
+		db, err = sql.Open("postgres", dsn)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
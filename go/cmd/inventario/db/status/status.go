@@ -2,11 +2,21 @@ package status
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/denisvmedia/inventario/cmd/internal/command"
 	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
 	"github.com/denisvmedia/inventario/schema/migrations/migrator"
 )
 
@@ -29,9 +39,14 @@ func New(dbConfig *shared.DatabaseConfig) *Command {
 - Number of pending migrations
 - List of pending migrations (with --verbose)
 
+For a snapshot-backed memory database (memory://?snapshot=PATH) it reports
+the snapshot file instead: its age, format version and size, and the row
+count of every table with --verbose.
+
 Examples:
   inventario migrate status                # Show basic status
-  inventario migrate status --verbose      # Show detailed status with pending migrations`,
+  inventario migrate status --verbose      # Show detailed status with pending migrations
+  inventario db status --db-dsn "memory://?snapshot=./inventario.json"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return c.migrateStatusCommand(dbConfig)
 		},
@@ -54,7 +69,58 @@ func (c *Command) migrateStatusCommand(dbConfig *shared.DatabaseConfig) error {
 	verbose, _ := c.Cmd().Flags().GetBool("verbose")
 	dsn := dbConfig.DBDSN
 
+	if strings.HasPrefix(dsn, "memory://") {
+		return printSnapshotStatus(c.Cmd().OutOrStdout(), dsn, verbose, time.Now())
+	}
+
 	migr := migrator.NewWithFallback(dsn, c.config.MigrationsDir)
 
 	return migr.PrintMigrationStatus(context.Background(), verbose)
 }
+
+// printSnapshotStatus reports the snapshot file of a memory:// DSN. A DSN
+// without a snapshot path has nothing on disk to report.
+func printSnapshotStatus(w io.Writer, dsn string, verbose bool, now time.Time) error {
+	cfg, err := memory.ParseSnapshotConfig(registry.Config(dsn))
+	if err != nil {
+		return err
+	}
+	if cfg.Path == "" {
+		fmt.Fprintln(w, "Memory database without a snapshot: data is not persisted.")
+		fmt.Fprintln(w, `Add ?snapshot=PATH to the DSN to keep it across restarts.`)
+		return nil
+	}
+
+	info, err := memory.ReadSnapshotInfo(cfg.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(w, "Snapshot:  %s\n", cfg.Path)
+		fmt.Fprintln(w, "Status:    not written yet")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Snapshot:  %s\n", info.Path)
+	fmt.Fprintf(w, "Version:   %d (this build writes %d)\n", info.Version, memory.SnapshotVersion)
+	fmt.Fprintf(w, "Taken at:  %s\n", info.TakenAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "Age:       %s\n", now.Sub(info.TakenAt).Round(time.Second))
+	fmt.Fprintf(w, "Interval:  %s\n", cfg.Interval)
+	fmt.Fprintf(w, "Size:      %d bytes\n", info.Size)
+	if !verbose {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TABLE\tROWS")
+	names := make([]string, 0, len(info.Rows))
+	for name := range info.Rows {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s\t%d\n", name, info.Rows[name])
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,36 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func TestPrintSnapshotStatus(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "inventario.json")
+	dsn := "memory://?snapshot=" + path
+
+	var out bytes.Buffer
+	c.Assert(printSnapshotStatus(&out, dsn, false, time.Now()), qt.IsNil)
+	c.Assert(out.String(), qt.Contains, "not written yet")
+
+	snapshotter, err := memory.NewSnapshotter(memory.NewFactorySet(), path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(snapshotter.Save(context.Background()), qt.IsNil)
+
+	out.Reset()
+	c.Assert(printSnapshotStatus(&out, dsn, true, time.Now().Add(90*time.Second)), qt.IsNil)
+	c.Assert(out.String(), qt.Contains, "Age:       1m30s")
+	c.Assert(out.String(), qt.Contains, "commodities")
+
+	out.Reset()
+	c.Assert(printSnapshotStatus(&out, "memory://", false, time.Now()), qt.IsNil)
+	c.Assert(out.String(), qt.Contains, "not persisted")
+}
@@ -0,0 +1,122 @@
+package addmember
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group add-member command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group add-member command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.add_member", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "add-member <group-id-or-slug> <user-id-or-email>",
+		Short: "Add a user to a group",
+		Long: `Add a user to a location group with the given role.
+
+The user must belong to the group's tenant and must not already be a member.
+The per-user group membership cap applies.
+
+ROLES:
+  • viewer: Read-only access
+  • user: Can manage inventory (default)
+  • admin: Can also manage members and invites
+  • owner: Full control, including deleting the group
+
+Examples:
+  # Add a user with the default role
+  inventario groups add-member k3m9x2 jane@acme.com
+
+  # Add an admin
+  inventario groups add-member k3m9x2 jane@acme.com --role=admin`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.addMember(&c.config, dbConfig, args[0], args[1])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+
+	// Membership flags
+	c.Cmd().Flags().StringVar(&c.config.Role, "role", c.config.Role, "Member role (viewer, user, admin, owner)")
+}
+
+// addMember handles the member addition process
+func (c *Command) addMember(cfg *Config, dbConfig *shared.DatabaseConfig, groupIDOrSlug, userIDOrEmail string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group membership changes are not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	role := models.GroupRole(cfg.Role)
+	if err := role.Validate(); err != nil {
+		return fmt.Errorf("invalid role '%s'. Valid roles: viewer, user, admin, owner", cfg.Role)
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	detail, err := adminService.GetGroup(context.Background(), groupIDOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	user, err := adminService.GetUser(context.Background(), userIDOrEmail)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	fmt.Fprintf(out, "Adding %s (%s) to group %s (%s) as %s\n\n", user.Name, user.Email, detail.Group.Name, detail.Group.Slug, role)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To add the member, run the command without --dry-run")
+		return nil
+	}
+
+	if _, err := adminService.AddGroupMember(context.Background(), detail.Group.ID, user.ID, role); err != nil {
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Member added successfully!")
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package addmember
+
+// Config holds configuration for group add-member command
+type Config struct {
+	// Membership options
+	Role string `yaml:"role" env:"GROUP_ROLE" env-default:"user"`
+
+	// Command options
+	DryRun bool `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
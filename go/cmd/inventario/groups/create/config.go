@@ -0,0 +1,15 @@
+package create
+
+// Config holds configuration for group create command
+type Config struct {
+	// Group details
+	Tenant      string `yaml:"tenant" env:"GROUP_TENANT" env-default:""`
+	Owner       string `yaml:"owner" env:"GROUP_OWNER" env-default:""`
+	Name        string `yaml:"name" env:"GROUP_NAME" env-default:""`
+	Icon        string `yaml:"icon" env:"GROUP_ICON" env-default:""`
+	Description string `yaml:"description" env:"GROUP_DESCRIPTION" env-default:""`
+	Currency    string `yaml:"currency" env:"GROUP_CURRENCY" env-default:"USD"`
+
+	// Command options
+	DryRun bool `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
@@ -0,0 +1,180 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group creation command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group creation command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.create", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "create",
+		Short: "Create a new location group",
+		Long: `Create a new location group owned by an existing user.
+
+The owner becomes the group's sole owner, exactly as when a user creates a
+group in the UI, and the per-user group membership cap applies.
+
+REQUIRED FIELDS:
+  • Tenant: Tenant ID or slug the group belongs to
+  • Owner: User ID or email of the owner (must belong to the tenant)
+  • Name: Group name (1-100 characters)
+
+OPTIONAL FIELDS:
+  • Icon: One of the supported group icons
+  • Description: Short description (up to 200 characters)
+  • Currency: ISO-4217 group currency (defaults to USD; cannot be changed later)
+
+Examples:
+  # Create a group
+  inventario groups create --tenant=acme --owner=admin@acme.com --name="Home"
+
+  # Create a group with a currency
+  inventario groups create --tenant=acme --owner=admin@acme.com --name="Office" --currency=EUR
+
+  # Preview group creation
+  inventario groups create --dry-run --tenant=acme --owner=admin@acme.com --name="Home"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.createGroup(&c.config, dbConfig)
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+
+	// Group configuration flags
+	c.Cmd().Flags().StringVar(&c.config.Tenant, "tenant", c.config.Tenant, "Tenant ID or slug (required)")
+	c.Cmd().Flags().StringVar(&c.config.Owner, "owner", c.config.Owner, "Owner user ID or email (required)")
+	c.Cmd().Flags().StringVar(&c.config.Name, "name", c.config.Name, "Group name (required)")
+	c.Cmd().Flags().StringVar(&c.config.Icon, "icon", c.config.Icon, "Group icon")
+	c.Cmd().Flags().StringVar(&c.config.Description, "description", c.config.Description, "Group description")
+	c.Cmd().Flags().StringVar(&c.config.Currency, "currency", c.config.Currency, "Group currency (ISO-4217)")
+}
+
+// createGroup handles the group creation process
+func (c *Command) createGroup(cfg *Config, dbConfig *shared.DatabaseConfig) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group creation is not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	// Validate required fields
+	if strings.TrimSpace(cfg.Tenant) == "" {
+		return fmt.Errorf("--tenant is required")
+	}
+	if strings.TrimSpace(cfg.Owner) == "" {
+		return fmt.Errorf("--owner is required")
+	}
+	if strings.TrimSpace(cfg.Name) == "" {
+		return fmt.Errorf("--name is required")
+	}
+
+	fmt.Fprintln(out, "=== CREATE GROUP ===")
+	fmt.Fprintf(out, "Database: %s\n", shared.RedactDSN(dbConfig.DBDSN))
+	if cfg.DryRun {
+		fmt.Fprintln(out, "Mode: DRY RUN (no changes will be made)")
+	} else {
+		fmt.Fprintln(out, "Mode: LIVE CREATION")
+	}
+	fmt.Fprintln(out)
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	// Resolve tenant and owner up front so the summary (and a dry run) shows
+	// what the group will be attached to.
+	tenant, err := adminService.GetTenant(context.Background(), cfg.Tenant)
+	if err != nil {
+		return fmt.Errorf("failed to find tenant: %w", err)
+	}
+	owner, err := adminService.GetUser(context.Background(), cfg.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to find owner: %w", err)
+	}
+	if owner.TenantID != tenant.ID {
+		return fmt.Errorf("owner %s does not belong to tenant %s", owner.Email, tenant.Slug)
+	}
+
+	currency := strings.ToUpper(cfg.Currency)
+	if !models.Currency(currency).IsValid() {
+		return fmt.Errorf("invalid currency '%s'", cfg.Currency)
+	}
+
+	fmt.Fprintln(out, "Group to be created:")
+	fmt.Fprintf(out, "  • Name: %s\n", cfg.Name)
+	if cfg.Icon != "" {
+		fmt.Fprintf(out, "  • Icon: %s\n", cfg.Icon)
+	}
+	if cfg.Description != "" {
+		fmt.Fprintf(out, "  • Description: %s\n", cfg.Description)
+	}
+	fmt.Fprintf(out, "  • Currency: %s\n", currency)
+	fmt.Fprintf(out, "  • Tenant: %s (%s)\n", tenant.Name, tenant.Slug)
+	fmt.Fprintf(out, "  • Owner: %s (%s)\n", owner.Name, owner.Email)
+	fmt.Fprintln(out)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To perform the actual creation, run the command without --dry-run")
+		return nil
+	}
+
+	group, err := adminService.CreateGroup(context.Background(), admin.GroupCreateRequest{
+		Tenant:      tenant.ID,
+		Owner:       owner.ID,
+		Name:        cfg.Name,
+		Icon:        cfg.Icon,
+		Description: cfg.Description,
+		Currency:    currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create group: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Group created successfully!")
+	fmt.Fprintf(out, "Group ID: %s\n", group.ID)
+	fmt.Fprintf(out, "Slug: %s\n", group.Slug)
+
+	return nil
+}
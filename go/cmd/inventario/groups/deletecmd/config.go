@@ -0,0 +1,8 @@
+package deletecmd
+
+// Config holds configuration for group delete command
+type Config struct {
+	// Command options
+	Force  bool `yaml:"force" env:"FORCE" env-default:"false"`
+	DryRun bool `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
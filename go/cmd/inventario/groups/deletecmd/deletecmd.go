@@ -0,0 +1,180 @@
+package deletecmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group delete command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group delete command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.delete", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "delete <id-or-slug>",
+		Short: "Delete a location group",
+		Long: `Mark a location group for deletion.
+
+The group is set to pending_deletion: it disappears for its members right
+away and the background purge worker removes it together with all its
+locations, areas, commodities and files on its next sweep. Until then the
+deletion can be undone with 'inventario groups restore-pending-deletion'.
+
+Deleting a group that is already pending deletion is a no-op.
+
+SAFETY FEATURES:
+  • Group details display before deletion
+  • Confirmation prompts (unless --force is used)
+  • Dry-run support to preview deletion
+
+Examples:
+  # Delete group with confirmation
+  inventario groups delete k3m9x2
+
+  # Preview deletion
+  inventario groups delete k3m9x2 --dry-run
+
+  # Force deletion without confirmation
+  inventario groups delete k3m9x2 --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.deleteGroup(&c.config, dbConfig, args[0])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+
+	// Delete options
+	c.Cmd().Flags().BoolVar(&c.config.Force, "force", c.config.Force, "Skip confirmation prompts")
+}
+
+// deleteGroup handles the group deletion process
+func (c *Command) deleteGroup(cfg *Config, dbConfig *shared.DatabaseConfig, idOrSlug string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group deletion is not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	fmt.Fprintln(out, "=== DELETE GROUP ===")
+	fmt.Fprintf(out, "Database: %s\n", shared.RedactDSN(dbConfig.DBDSN))
+	fmt.Fprintf(out, "Target: %s\n", idOrSlug)
+	if cfg.DryRun {
+		fmt.Fprintln(out, "Mode: DRY RUN (no changes will be made)")
+	} else {
+		fmt.Fprintln(out, "Mode: LIVE DELETION")
+	}
+	fmt.Fprintln(out)
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	detail, err := adminService.GetGroup(context.Background(), idOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	group := detail.Group
+
+	fmt.Fprintf(out, "Found group: %s (%s)\n", group.Name, group.Slug)
+	fmt.Fprintf(out, "Status: %s\n", group.Status)
+	fmt.Fprintf(out, "Members: %d\n", detail.MemberCount)
+	if detail.Tenant != nil {
+		fmt.Fprintf(out, "Tenant: %s (%s)\n", detail.Tenant.Name, detail.Tenant.Slug)
+	}
+	fmt.Fprintln(out)
+
+	if group.Status == models.LocationGroupStatusPendingDeletion {
+		fmt.Fprintln(out, "Group is already pending deletion; nothing to do.")
+		return nil
+	}
+
+	fmt.Fprintln(out, "DELETION IMPACT:")
+	fmt.Fprintln(out, "  • The group is hidden from its members immediately")
+	fmt.Fprintln(out, "  • All its locations, areas, commodities and files are purged by the background worker")
+	fmt.Fprintln(out, "  • Until the purge runs, 'inventario groups restore-pending-deletion' can undo this")
+	fmt.Fprintln(out)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To perform the actual deletion, run the command without --dry-run")
+		return nil
+	}
+
+	// Confirmation prompt (unless forced)
+	if !cfg.Force && !c.confirmDeletion(group) {
+		fmt.Fprintln(out, "Deletion cancelled.")
+		return nil
+	}
+
+	_, alreadyPending, err := adminService.DeleteGroup(context.Background(), group.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	if alreadyPending {
+		fmt.Fprintln(out, "Group was already pending deletion.")
+		return nil
+	}
+	fmt.Fprintln(out, "✅ Group marked for deletion!")
+	fmt.Fprintf(out, "Group: %s (%s)\n", group.Name, group.Slug)
+
+	return nil
+}
+
+// confirmDeletion prompts for deletion confirmation
+func (c *Command) confirmDeletion(group *models.LocationGroup) bool {
+	fmt.Printf("⚠️  Are you sure you want to delete group '%s' (%s)? [y/N]: ", group.Name, group.Slug)
+
+	var response string
+	fmt.Scanln(&response)
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response == "y" || response == "yes" {
+		// Double confirmation for safety
+		fmt.Printf("⚠️  This will delete all data in the group. Type '%s' to confirm: ", group.Slug)
+
+		var confirmation string
+		fmt.Scanln(&confirmation)
+
+		return confirmation == group.Slug
+	}
+
+	return false
+}
@@ -0,0 +1,7 @@
+package get
+
+// Config holds configuration for group get command
+type Config struct {
+	// Output options
+	Output string `yaml:"output" env:"OUTPUT" env-default:"table"`
+}
@@ -0,0 +1,167 @@
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group get command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group get command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.get", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "get <id-or-slug>",
+		Short: "Get detailed group information",
+		Long: `Get detailed information about a specific location group by ID or slug.
+
+Slugs are unique per tenant only; if a slug matches groups in several
+tenants the command refuses and asks for the group ID instead.
+
+OUTPUT FORMATS:
+  • table: Human-readable formatted output (default)
+  • json: JSON format for scripting
+
+Examples:
+  # Get group by slug
+  inventario groups get k3m9x2
+
+  # Get group by ID
+  inventario groups get 550e8400-e29b-41d4-a716-446655440000
+
+  # Output as JSON
+  inventario groups get k3m9x2 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.getGroup(&c.config, dbConfig, args[0])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Output flags
+	c.Cmd().Flags().StringVarP(&c.config.Output, "output", "o", c.config.Output, "Output format (table, json)")
+}
+
+// getGroup handles the group retrieval process
+func (c *Command) getGroup(cfg *Config, dbConfig *shared.DatabaseConfig, idOrSlug string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group retrieval is not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	// Validate output format
+	if cfg.Output != "table" && cfg.Output != "json" {
+		return fmt.Errorf("invalid output format '%s'. Supported formats: table, json", cfg.Output)
+	}
+
+	// Validate input
+	if strings.TrimSpace(idOrSlug) == "" {
+		return fmt.Errorf("group ID or slug is required")
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	detail, err := adminService.GetGroup(context.Background(), idOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+
+	// Output results
+	switch cfg.Output {
+	case "json":
+		return c.outputJSON(detail)
+	case "table":
+		return c.outputTable(detail)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cfg.Output)
+	}
+}
+
+// outputJSON outputs group information in JSON format
+func (c *Command) outputJSON(detail *registry.AdminGroupDetail) error {
+	output := map[string]any{
+		"group":        detail.Group,
+		"member_count": detail.MemberCount,
+		"tenant":       detail.Tenant,
+	}
+
+	encoder := json.NewEncoder(c.Cmd().OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// outputTable outputs group information in table format
+func (c *Command) outputTable(detail *registry.AdminGroupDetail) error {
+	out := c.Cmd().OutOrStdout()
+	group := detail.Group
+
+	// Create table writer
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	// Print group information
+	fmt.Fprintln(w, "FIELD\tVALUE")
+	fmt.Fprintf(w, "ID\t%s\n", group.ID)
+	fmt.Fprintf(w, "Slug\t%s\n", group.Slug)
+	fmt.Fprintf(w, "Name\t%s\n", group.Name)
+	if group.Icon != "" {
+		fmt.Fprintf(w, "Icon\t%s\n", group.Icon)
+	}
+	if group.Description != "" {
+		fmt.Fprintf(w, "Description\t%s\n", group.Description)
+	}
+	fmt.Fprintf(w, "Status\t%s\n", group.Status)
+	fmt.Fprintf(w, "Currency\t%s\n", group.GroupCurrency)
+	fmt.Fprintf(w, "Members\t%d\n", detail.MemberCount)
+	if detail.Tenant != nil {
+		fmt.Fprintf(w, "Tenant\t%s (%s)\n", detail.Tenant.Name, detail.Tenant.Slug)
+	}
+	fmt.Fprintf(w, "Tenant ID\t%s\n", group.TenantID)
+	fmt.Fprintf(w, "Created By\t%s\n", group.CreatedBy)
+	fmt.Fprintf(w, "Created\t%s\n", group.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "Updated\t%s\n", group.UpdatedAt.Format("2006-01-02 15:04:05"))
+
+	// Flush table
+	w.Flush()
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package list
+
+// Config holds configuration for group list command
+type Config struct {
+	// Filtering options
+	Tenant string `yaml:"tenant" env:"GROUP_TENANT" env-default:""`
+	Status string `yaml:"status" env:"GROUP_STATUS" env-default:""`
+	Search string `yaml:"search" env:"GROUP_SEARCH" env-default:""`
+
+	// Pagination options
+	Page    int `yaml:"page" env:"PAGE" env-default:"1"`
+	PerPage int `yaml:"per_page" env:"PER_PAGE" env-default:"50"`
+
+	// Output options
+	Output string `yaml:"output" env:"OUTPUT" env-default:"table"`
+}
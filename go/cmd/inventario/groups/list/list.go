@@ -0,0 +1,237 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group list command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group list command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.list", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "list",
+		Short: "List location groups",
+		Long: `List location groups across all tenants with filtering and pagination.
+
+FILTERING:
+  • Tenant: Filter by tenant ID or slug
+  • Status: Filter by status (active, pending_deletion)
+  • Search: Search by group name or slug (case-insensitive)
+
+PAGINATION:
+  • Page: 1-based page number (default: 1)
+  • Per page: Groups per page (default: 50)
+
+OUTPUT FORMATS:
+  • table: Human-readable table format (default)
+  • json: JSON format for scripting
+
+Examples:
+  # List all groups
+  inventario groups list
+
+  # List groups of one tenant
+  inventario groups list --tenant=acme
+
+  # List groups awaiting the purge worker
+  inventario groups list --status=pending_deletion
+
+  # Output as JSON
+  inventario groups list --output=json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.listGroups(&c.config, dbConfig)
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Filtering flags
+	c.Cmd().Flags().StringVar(&c.config.Tenant, "tenant", c.config.Tenant, "Filter by tenant ID or slug")
+	c.Cmd().Flags().StringVar(&c.config.Status, "status", c.config.Status, "Filter by status (active, pending_deletion)")
+	c.Cmd().Flags().StringVar(&c.config.Search, "search", c.config.Search, "Search by group name or slug")
+
+	// Pagination flags
+	c.Cmd().Flags().IntVar(&c.config.Page, "page", c.config.Page, "Page number (1-based)")
+	c.Cmd().Flags().IntVar(&c.config.PerPage, "per-page", c.config.PerPage, "Maximum number of groups per page")
+
+	// Output flags
+	c.Cmd().Flags().StringVarP(&c.config.Output, "output", "o", c.config.Output, "Output format (table, json)")
+}
+
+// listGroups handles the group listing process
+func (c *Command) listGroups(cfg *Config, dbConfig *shared.DatabaseConfig) error {
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group listing is not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	// Validate output format
+	if cfg.Output != "table" && cfg.Output != "json" {
+		return fmt.Errorf("invalid output format '%s'. Supported formats: table, json", cfg.Output)
+	}
+
+	// Validate filters
+	if err := c.validateFilters(cfg); err != nil {
+		return err
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(c.Cmd().OutOrStdout(), "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	response, err := adminService.ListGroups(context.Background(), admin.GroupListRequest{
+		Tenant:  cfg.Tenant,
+		Status:  cfg.Status,
+		Search:  cfg.Search,
+		Page:    cfg.Page,
+		PerPage: cfg.PerPage,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	// Output results
+	switch cfg.Output {
+	case "json":
+		return c.outputJSON(response.Groups, response.TotalCount, cfg)
+	case "table":
+		return c.outputTable(response.Groups, response.TotalCount, cfg)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cfg.Output)
+	}
+}
+
+// validateFilters validates filter parameters
+func (c *Command) validateFilters(cfg *Config) error {
+	if cfg.Status != "" {
+		switch models.LocationGroupStatus(cfg.Status) {
+		case models.LocationGroupStatusActive, models.LocationGroupStatusPendingDeletion:
+		default:
+			return fmt.Errorf("invalid status value '%s'. Valid values: active, pending_deletion", cfg.Status)
+		}
+	}
+
+	// Validate pagination
+	if cfg.Page < 1 {
+		return fmt.Errorf("page must be at least 1")
+	}
+	if cfg.PerPage < 1 {
+		return fmt.Errorf("per-page must be at least 1")
+	}
+
+	return nil
+}
+
+// outputJSON outputs groups in JSON format
+func (c *Command) outputJSON(items []*registry.AdminGroupListItem, totalCount int, cfg *Config) error {
+	type GroupWithTenant struct {
+		*models.LocationGroup
+		MemberCount int            `json:"member_count"`
+		Tenant      *models.Tenant `json:"tenant"`
+	}
+
+	groups := make([]GroupWithTenant, 0, len(items))
+	for _, item := range items {
+		groups = append(groups, GroupWithTenant{
+			LocationGroup: item.Group,
+			MemberCount:   item.MemberCount,
+			Tenant:        item.Tenant,
+		})
+	}
+
+	output := map[string]any{
+		"groups":      groups,
+		"total_count": totalCount,
+		"page":        cfg.Page,
+		"per_page":    cfg.PerPage,
+	}
+
+	encoder := json.NewEncoder(c.Cmd().OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// outputTable outputs groups in table format
+func (c *Command) outputTable(items []*registry.AdminGroupListItem, totalCount int, cfg *Config) error {
+	out := c.Cmd().OutOrStdout()
+
+	if len(items) == 0 {
+		fmt.Fprintln(out, "No groups found.")
+		return nil
+	}
+
+	// Create table writer
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	// Print header
+	fmt.Fprintln(w, "ID\tSLUG\tNAME\tSTATUS\tMEMBERS\tCURRENCY\tTENANT\tCREATED")
+
+	// Print groups
+	for _, item := range items {
+		tenantName := "<unknown>"
+		if item.Tenant != nil {
+			tenantName = fmt.Sprintf("%s (%s)", item.Tenant.Name, item.Tenant.Slug)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			item.Group.ID,
+			item.Group.Slug,
+			item.Group.Name,
+			item.Group.Status,
+			item.MemberCount,
+			item.Group.GroupCurrency,
+			tenantName,
+			item.Group.CreatedAt.Format("2006-01-02 15:04"),
+		)
+	}
+
+	// Flush table
+	w.Flush()
+
+	// Print pagination info
+	fmt.Fprintf(out, "\nShowing %d of %d groups", len(items), totalCount)
+	if cfg.Page > 1 || cfg.Page*cfg.PerPage < totalCount {
+		fmt.Fprintf(out, " (page: %d, per page: %d)", cfg.Page, cfg.PerPage)
+	}
+	fmt.Fprintln(out)
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package members
+
+// Config holds configuration for group members command
+type Config struct {
+	// Output options
+	Output string `yaml:"output" env:"OUTPUT" env-default:"table"`
+}
@@ -0,0 +1,170 @@
+package members
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group members command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group members command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.members", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "members <group-id-or-slug>",
+		Short: "List the members of a group",
+		Long: `List the members of a location group with their roles.
+
+OUTPUT FORMATS:
+  • table: Human-readable table format (default)
+  • json: JSON format for scripting
+
+Examples:
+  # List members
+  inventario groups members k3m9x2
+
+  # Output as JSON
+  inventario groups members k3m9x2 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.listMembers(&c.config, dbConfig, args[0])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Output flags
+	c.Cmd().Flags().StringVarP(&c.config.Output, "output", "o", c.config.Output, "Output format (table, json)")
+}
+
+// listMembers handles the member listing process
+func (c *Command) listMembers(cfg *Config, dbConfig *shared.DatabaseConfig, idOrSlug string) error {
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group member listing is not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	// Validate output format
+	if cfg.Output != "table" && cfg.Output != "json" {
+		return fmt.Errorf("invalid output format '%s'. Supported formats: table, json", cfg.Output)
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(c.Cmd().OutOrStdout(), "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	detail, members, err := adminService.ListGroupMembers(context.Background(), idOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	// Output results
+	switch cfg.Output {
+	case "json":
+		return c.outputJSON(detail, members)
+	case "table":
+		return c.outputTable(detail, members)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cfg.Output)
+	}
+}
+
+// outputJSON outputs members in JSON format
+func (c *Command) outputJSON(detail *registry.AdminGroupDetail, members []*models.MembershipWithUser) error {
+	type Member struct {
+		*models.GroupMembership
+		User *models.User `json:"user"`
+	}
+
+	out := make([]Member, 0, len(members))
+	for _, m := range members {
+		out = append(out, Member{GroupMembership: m.Membership, User: m.User})
+	}
+
+	output := map[string]any{
+		"group":       detail.Group,
+		"members":     out,
+		"total_count": len(out),
+	}
+
+	encoder := json.NewEncoder(c.Cmd().OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// outputTable outputs members in table format
+func (c *Command) outputTable(detail *registry.AdminGroupDetail, members []*models.MembershipWithUser) error {
+	out := c.Cmd().OutOrStdout()
+
+	fmt.Fprintf(out, "Group: %s (%s)\n\n", detail.Group.Name, detail.Group.Slug)
+
+	if len(members) == 0 {
+		fmt.Fprintln(out, "No members found.")
+		return nil
+	}
+
+	// Create table writer
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	// Print header
+	fmt.Fprintln(w, "USER ID\tEMAIL\tNAME\tROLE\tJOINED")
+
+	// Print members
+	for _, m := range members {
+		email, name := "<unknown>", "<unknown>"
+		if m.User != nil {
+			email, name = m.User.Email, m.User.Name
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			m.Membership.MemberUserID,
+			email,
+			name,
+			m.Membership.Role,
+			m.Membership.JoinedAt.Format("2006-01-02 15:04"),
+		)
+	}
+
+	// Flush table
+	w.Flush()
+
+	fmt.Fprintf(out, "\nShowing %d members\n", len(members))
+
+	return nil
+}
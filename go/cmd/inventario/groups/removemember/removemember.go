@@ -0,0 +1,130 @@
+package removemember
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/services"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group remove-member command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group remove-member command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.remove_member", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "remove-member <group-id-or-slug> <user-id-or-email>",
+		Short: "Remove a user from a group",
+		Long: `Remove a member from a location group.
+
+A group always keeps at least one owner and one member: removing the last
+owner is refused (transfer ownership first), and so is removing the last
+member (delete the group instead).
+
+Examples:
+  # Remove a member with confirmation
+  inventario groups remove-member k3m9x2 jane@acme.com
+
+  # Remove without confirmation
+  inventario groups remove-member k3m9x2 jane@acme.com --force`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.removeMember(&c.config, dbConfig, args[0], args[1])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+
+	// Remove options
+	c.Cmd().Flags().BoolVar(&c.config.Force, "force", c.config.Force, "Skip confirmation prompt")
+}
+
+// removeMember handles the member removal process
+func (c *Command) removeMember(cfg *Config, dbConfig *shared.DatabaseConfig, groupIDOrSlug, userIDOrEmail string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group membership changes are not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	detail, err := adminService.GetGroup(context.Background(), groupIDOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	user, err := adminService.GetUser(context.Background(), userIDOrEmail)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	fmt.Fprintf(out, "Removing %s (%s) from group %s (%s)\n\n", user.Name, user.Email, detail.Group.Name, detail.Group.Slug)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To remove the member, run the command without --dry-run")
+		return nil
+	}
+
+	if !cfg.Force {
+		fmt.Fprintf(out, "⚠️  Remove %s from group '%s'? [y/N]: ", user.Email, detail.Group.Name)
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(out, "Removal cancelled.")
+			return nil
+		}
+	}
+
+	err = adminService.RemoveGroupMember(context.Background(), detail.Group.ID, user.ID)
+	switch {
+	case errors.Is(err, services.ErrLastOwner):
+		return fmt.Errorf("cannot remove the last owner; transfer ownership first: %w", err)
+	case errors.Is(err, services.ErrLastMember):
+		return fmt.Errorf("cannot remove the last member; delete the group instead: %w", err)
+	case err != nil:
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Member removed successfully!")
+
+	return nil
+}
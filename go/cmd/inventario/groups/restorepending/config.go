@@ -0,0 +1,8 @@
+package restorepending
+
+// Config holds configuration for group restore-pending-deletion command
+type Config struct {
+	// Command options
+	Force  bool `yaml:"force" env:"FORCE" env-default:"false"`
+	DryRun bool `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
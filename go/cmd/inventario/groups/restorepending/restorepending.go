@@ -0,0 +1,136 @@
+package restorepending
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group restore-pending-deletion command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group restore-pending-deletion command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.restore_pending_deletion", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "restore-pending-deletion <id-or-slug>",
+		Short: "Undo the deletion of a group",
+		Long: `Return a group marked for deletion to the active state.
+
+This only works while the group is still pending deletion. Once the
+background purge worker has processed it, the group and its data are gone
+and can only be brought back from a backup.
+
+Examples:
+  # Restore a group
+  inventario groups restore-pending-deletion k3m9x2
+
+  # Preview the restore
+  inventario groups restore-pending-deletion k3m9x2 --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.restoreGroup(&c.config, dbConfig, args[0])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+
+	// Restore options
+	c.Cmd().Flags().BoolVar(&c.config.Force, "force", c.config.Force, "Skip confirmation prompt")
+}
+
+// restoreGroup handles the group restore process
+func (c *Command) restoreGroup(cfg *Config, dbConfig *shared.DatabaseConfig, idOrSlug string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group restore is not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	fmt.Fprintln(out, "=== RESTORE PENDING-DELETION GROUP ===")
+	fmt.Fprintf(out, "Database: %s\n", shared.RedactDSN(dbConfig.DBDSN))
+	fmt.Fprintf(out, "Target: %s\n", idOrSlug)
+	if cfg.DryRun {
+		fmt.Fprintln(out, "Mode: DRY RUN (no changes will be made)")
+	} else {
+		fmt.Fprintln(out, "Mode: LIVE RESTORE")
+	}
+	fmt.Fprintln(out)
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	detail, err := adminService.GetGroup(context.Background(), idOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to find group (it may already have been purged): %w", err)
+	}
+	group := detail.Group
+
+	fmt.Fprintf(out, "Found group: %s (%s)\n", group.Name, group.Slug)
+	fmt.Fprintf(out, "Status: %s\n\n", group.Status)
+
+	if group.Status != models.LocationGroupStatusPendingDeletion {
+		return fmt.Errorf("group %s is not pending deletion", group.Slug)
+	}
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To perform the actual restore, run the command without --dry-run")
+		return nil
+	}
+
+	if !cfg.Force {
+		fmt.Fprintf(out, "Restore group '%s' (%s)? [y/N]: ", group.Name, group.Slug)
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(out, "Restore cancelled.")
+			return nil
+		}
+	}
+
+	if _, err := adminService.RestorePendingDeletionGroup(context.Background(), group.ID); err != nil {
+		return fmt.Errorf("failed to restore group: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Group restored successfully!")
+	fmt.Fprintf(out, "Group: %s (%s) is active again\n", group.Name, group.Slug)
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package setrole
+
+// Config holds configuration for group set-role command
+type Config struct {
+	// Command options
+	DryRun bool `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
@@ -0,0 +1,119 @@
+package setrole
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group set-role command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group set-role command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.set_role", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "set-role <group-id-or-slug> <user-id-or-email> <role>",
+		Short: "Change a member's role",
+		Long: `Change the role of an existing group member.
+
+Valid roles are viewer, user, admin and owner. Demoting the last owner is
+refused; use 'inventario groups transfer-ownership' to hand the group over.
+
+Examples:
+  # Promote a member to admin
+  inventario groups set-role k3m9x2 jane@acme.com admin
+
+  # Make a member a co-owner
+  inventario groups set-role k3m9x2 jane@acme.com owner`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.setRole(&c.config, dbConfig, args[0], args[1], args[2])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+}
+
+// setRole handles the role change process
+func (c *Command) setRole(cfg *Config, dbConfig *shared.DatabaseConfig, groupIDOrSlug, userIDOrEmail, roleArg string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group membership changes are not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	role := models.GroupRole(roleArg)
+	if err := role.Validate(); err != nil {
+		return fmt.Errorf("invalid role '%s'. Valid roles: viewer, user, admin, owner", roleArg)
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	detail, err := adminService.GetGroup(context.Background(), groupIDOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	user, err := adminService.GetUser(context.Background(), userIDOrEmail)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	fmt.Fprintf(out, "Setting role of %s (%s) in group %s (%s) to %s\n\n", user.Name, user.Email, detail.Group.Name, detail.Group.Slug, role)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To change the role, run the command without --dry-run")
+		return nil
+	}
+
+	_, err = adminService.SetGroupMemberRole(context.Background(), detail.Group.ID, user.ID, role)
+	switch {
+	case errors.Is(err, services.ErrLastOwner):
+		return fmt.Errorf("cannot demote the last owner; transfer ownership first: %w", err)
+	case err != nil:
+		return fmt.Errorf("failed to change role: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Role updated successfully!")
+
+	return nil
+}
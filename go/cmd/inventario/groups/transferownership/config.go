@@ -0,0 +1,11 @@
+package transferownership
+
+// Config holds configuration for group transfer-ownership command
+type Config struct {
+	// Role the previous owners are demoted to
+	DemoteTo string `yaml:"demote_to" env:"GROUP_DEMOTE_TO" env-default:"admin"`
+
+	// Command options
+	Force  bool `yaml:"force" env:"FORCE" env-default:"false"`
+	DryRun bool `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
@@ -0,0 +1,167 @@
+package transferownership
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group transfer-ownership command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group transfer-ownership command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.transfer_ownership", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "transfer-ownership <group-id-or-slug> <user-id-or-email>",
+		Short: "Make a member the owner of a group",
+		Long: `Hand a location group over to one of its members.
+
+The new owner must already be a member. They are promoted first and every
+other owner is then demoted to --demote-to (admin by default), so the group
+is never left without an owner. Use --demote-to=owner to keep the previous
+owners as co-owners.
+
+Examples:
+  # Transfer ownership; previous owners become admins
+  inventario groups transfer-ownership k3m9x2 jane@acme.com
+
+  # Previous owners become plain users
+  inventario groups transfer-ownership k3m9x2 jane@acme.com --demote-to=user`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.transferOwnership(&c.config, dbConfig, args[0], args[1])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+
+	// Transfer options
+	c.Cmd().Flags().StringVar(&c.config.DemoteTo, "demote-to", c.config.DemoteTo, "Role for the previous owners (viewer, user, admin, owner)")
+	c.Cmd().Flags().BoolVar(&c.config.Force, "force", c.config.Force, "Skip confirmation prompt")
+}
+
+// transferOwnership handles the ownership transfer process
+func (c *Command) transferOwnership(cfg *Config, dbConfig *shared.DatabaseConfig, groupIDOrSlug, userIDOrEmail string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group membership changes are not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	demoteTo := models.GroupRole(cfg.DemoteTo)
+	if err := demoteTo.Validate(); err != nil {
+		return fmt.Errorf("invalid --demote-to role '%s'. Valid roles: viewer, user, admin, owner", cfg.DemoteTo)
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	fmt.Fprintln(out, "=== TRANSFER GROUP OWNERSHIP ===")
+	fmt.Fprintf(out, "Database: %s\n", shared.RedactDSN(dbConfig.DBDSN))
+	if cfg.DryRun {
+		fmt.Fprintln(out, "Mode: DRY RUN (no changes will be made)")
+	} else {
+		fmt.Fprintln(out, "Mode: LIVE TRANSFER")
+	}
+	fmt.Fprintln(out)
+
+	detail, members, err := adminService.ListGroupMembers(context.Background(), groupIDOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	user, err := adminService.GetUser(context.Background(), userIDOrEmail)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	var isMember bool
+	var previousOwners []string
+	for _, m := range members {
+		if m.Membership.MemberUserID == user.ID {
+			isMember = true
+			continue
+		}
+		if m.Membership.Role == models.GroupRoleOwner {
+			label := m.Membership.MemberUserID
+			if m.User != nil {
+				label = m.User.Email
+			}
+			previousOwners = append(previousOwners, label)
+		}
+	}
+	if !isMember {
+		return fmt.Errorf("%s is not a member of group %s; add them first with 'inventario groups add-member'", user.Email, detail.Group.Slug)
+	}
+
+	fmt.Fprintf(out, "Group: %s (%s)\n", detail.Group.Name, detail.Group.Slug)
+	fmt.Fprintf(out, "New owner: %s (%s)\n", user.Name, user.Email)
+	if len(previousOwners) > 0 && demoteTo != models.GroupRoleOwner {
+		fmt.Fprintf(out, "Demoted to %s: %s\n", demoteTo, strings.Join(previousOwners, ", "))
+	}
+	fmt.Fprintln(out)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To transfer ownership, run the command without --dry-run")
+		return nil
+	}
+
+	if !cfg.Force {
+		fmt.Fprintf(out, "⚠️  Transfer ownership of '%s' to %s? [y/N]: ", detail.Group.Name, user.Email)
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(out, "Transfer cancelled.")
+			return nil
+		}
+	}
+
+	_, demoted, err := adminService.TransferGroupOwnership(context.Background(), detail.Group.ID, user.ID, demoteTo)
+	if err != nil {
+		return fmt.Errorf("failed to transfer ownership: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Ownership transferred successfully!")
+	if len(demoted) > 0 {
+		fmt.Fprintf(out, "Demoted %d previous owner(s) to %s\n", len(demoted), demoteTo)
+	}
+
+	return nil
+}
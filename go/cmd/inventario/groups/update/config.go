@@ -0,0 +1,14 @@
+package update
+
+// Config holds configuration for group update command
+type Config struct {
+	// Group fields to update. Empty / unset values are left unchanged; the
+	// command tracks which flags were explicitly provided (see update.go) so
+	// that an unset flag never overwrites an existing column.
+	Name        string `yaml:"name" env:"GROUP_NAME" env-default:""`
+	Icon        string `yaml:"icon" env:"GROUP_ICON" env-default:""`
+	Description string `yaml:"description" env:"GROUP_DESCRIPTION" env-default:""`
+
+	// Command options
+	DryRun bool `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
@@ -0,0 +1,161 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command represents the group update command
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New creates the group update command
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	// Load default configuration from struct tags
+	shared.TryReadSection("groups.update", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "update <id-or-slug>",
+		Short: "Update a location group",
+		Long: `Update the name, icon or description of a location group.
+
+Only the flags you provide are applied. The group currency cannot be changed
+here, and a group pending deletion must be restored before it can be edited.
+
+Examples:
+  # Rename a group
+  inventario groups update k3m9x2 --name="Summer House"
+
+  # Clear the description
+  inventario groups update k3m9x2 --description=""
+
+  # Preview the update
+  inventario groups update k3m9x2 --name="Summer House" --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.updateGroup(&c.config, dbConfig, args[0])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	// Dry run flag
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+
+	// Group configuration flags. Use cmd.Flags().Changed(<name>) at run time to
+	// decide which of these to apply, so an unset flag never overwrites a value.
+	c.Cmd().Flags().StringVar(&c.config.Name, "name", c.config.Name, "New group name")
+	c.Cmd().Flags().StringVar(&c.config.Icon, "icon", c.config.Icon, "New group icon (empty to clear)")
+	c.Cmd().Flags().StringVar(&c.config.Description, "description", c.config.Description, "New group description (empty to clear)")
+}
+
+// updateGroup handles the group update process
+func (c *Command) updateGroup(cfg *Config, dbConfig *shared.DatabaseConfig, idOrSlug string) error {
+	out := c.Cmd().OutOrStdout()
+
+	// Validate database configuration
+	if err := dbConfig.Validate(); err != nil {
+		return fmt.Errorf("database configuration error: %w", err)
+	}
+
+	// Check if this is a memory database and reject it
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return fmt.Errorf("group update is not supported for memory databases as they don't provide persistence. Please use a PostgreSQL database")
+	}
+
+	// Build the update request from the flags the operator actually provided.
+	req, changes := c.buildUpdateRequest(cfg)
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No changes requested. Provide one or more of --name, --icon or --description.")
+		return nil
+	}
+
+	// Create admin service
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	fmt.Fprintln(out, "=== UPDATE GROUP ===")
+	fmt.Fprintf(out, "Database: %s\n", shared.RedactDSN(dbConfig.DBDSN))
+	fmt.Fprintf(out, "Target: %s\n", idOrSlug)
+	if cfg.DryRun {
+		fmt.Fprintln(out, "Mode: DRY RUN (no changes will be made)")
+	} else {
+		fmt.Fprintln(out, "Mode: LIVE UPDATE")
+	}
+	fmt.Fprintln(out)
+
+	detail, err := adminService.GetGroup(context.Background(), idOrSlug)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	fmt.Fprintf(out, "Found group: %s (%s), status: %s\n\n", detail.Group.Name, detail.Group.Slug, detail.Group.Status)
+
+	fmt.Fprintln(out, "The following changes will be applied:")
+	for _, change := range changes {
+		fmt.Fprintf(out, "  • %s\n", change)
+	}
+	fmt.Fprintln(out)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To perform the actual update, run the command without --dry-run")
+		return nil
+	}
+
+	group, err := adminService.UpdateGroup(context.Background(), detail.Group.ID, req)
+	if err != nil {
+		return fmt.Errorf("failed to update group: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Group updated successfully!")
+	fmt.Fprintf(out, "Name: %s\n", group.Name)
+
+	return nil
+}
+
+// buildUpdateRequest assembles an admin.GroupUpdateRequest from the flags that
+// were explicitly set, and returns a human-readable list of the changes.
+func (c *Command) buildUpdateRequest(cfg *Config) (admin.GroupUpdateRequest, []string) {
+	flags := c.Cmd().Flags()
+
+	var req admin.GroupUpdateRequest
+	var changes []string
+
+	if flags.Changed("name") {
+		name := strings.TrimSpace(cfg.Name)
+		req.Name = &name
+		changes = append(changes, fmt.Sprintf("Name: %s", name))
+	}
+	if flags.Changed("icon") {
+		req.Icon = &cfg.Icon
+		changes = append(changes, fmt.Sprintf("Icon: %q", cfg.Icon))
+	}
+	if flags.Changed("description") {
+		req.Description = &cfg.Description
+		changes = append(changes, fmt.Sprintf("Description: %q", cfg.Description))
+	}
+
+	return req, changes
+}
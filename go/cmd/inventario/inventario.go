@@ -10,8 +10,10 @@ import (
 	"github.com/denisvmedia/inventario/cmd/inventario/backfill"
 	"github.com/denisvmedia/inventario/cmd/inventario/backoffice"
 	"github.com/denisvmedia/inventario/cmd/inventario/backup"
+	"github.com/denisvmedia/inventario/cmd/inventario/backupkeys"
 	"github.com/denisvmedia/inventario/cmd/inventario/db"
 	"github.com/denisvmedia/inventario/cmd/inventario/features"
+	"github.com/denisvmedia/inventario/cmd/inventario/groups"
 	"github.com/denisvmedia/inventario/cmd/inventario/initconfig"
 	"github.com/denisvmedia/inventario/cmd/inventario/run"
 	"github.com/denisvmedia/inventario/cmd/inventario/shared"
@@ -62,6 +64,7 @@ Use "inventario [command] --help" for detailed information about each command.`,
 	rootCmd.AddCommand(features.New())
 	rootCmd.AddCommand(tenants.New(&dbConfig))
 	rootCmd.AddCommand(users.New(&dbConfig))
+	rootCmd.AddCommand(groups.New(&dbConfig))
 	rootCmd.AddCommand(admin.New(&dbConfig))
 	// Top-level `workers` group (#1308) — soft-pause/resume/status of the
 	// background workers. Distinct from `run workers` (which starts the
@@ -70,6 +73,7 @@ Use "inventario [command] --help" for detailed information about each command.`,
 	rootCmd.AddCommand(workers.New(&dbConfig))
 	rootCmd.AddCommand(backfill.New(&dbConfig))
 	rootCmd.AddCommand(backup.New())
+	rootCmd.AddCommand(backupkeys.New(&dbConfig))
 	rootCmd.AddCommand(backoffice.New(&dbConfig))
 	rootCmd.AddCommand(version.New())
 	err := rootCmd.Execute()
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -8,16 +9,18 @@ import (
 	"github.com/denisvmedia/inventario/cmd/inventario/shared"
 	"github.com/denisvmedia/inventario/registry/memory"
 	"github.com/denisvmedia/inventario/registry/postgres"
+	"github.com/denisvmedia/inventario/registry/sqlite"
 )
 
 func registerDBBackends() (cleanup func() error) {
 	// Register backends with the traditional registry system
 	memory.Register()
 	postgresCleanup := postgres.Register()
+	sqliteCleanup := sqlite.Register()
 
 	// Combine cleanup functions
 	cleanup = func() error {
-		return postgresCleanup()
+		return errors.Join(postgresCleanup(), sqliteCleanup())
 	}
 
 	return cleanup
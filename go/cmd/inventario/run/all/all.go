@@ -58,9 +58,14 @@ func (c *Command) run() error {
 	if err != nil {
 		return err
 	}
+	// Flush the registry (the final memory snapshot) after everything else
+	// has stopped writing to it.
+	defer rs.CloseFactorySet()
 	defer rs.CloseReadinessRedisPinger()
 	// Flush buffered Sentry events on shutdown (#844); no-op when disabled.
 	defer rs.SentryFlush(2 * time.Second)
+	// Flush buffered OpenTelemetry spans; no-op when tracing is disabled.
+	defer rs.ShutdownTracing(5 * time.Second)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -83,6 +88,9 @@ func (c *Command) run() error {
 	stopImport := bootstrap.StartImportWorker(ctx, rs, c.cfg)
 	defer stopImport()
 
+	stopBackupReplication := bootstrap.StartBackupReplicationWorker(ctx, rs, c.cfg)
+	defer stopBackupReplication()
+
 	stopThumbnail := bootstrap.StartThumbnailWorker(ctx, rs, c.cfg)
 	defer stopThumbnail()
 
@@ -95,6 +103,9 @@ func (c *Command) run() error {
 	stopMagicLinkTokenCleanup := bootstrap.StartMagicLinkTokenCleanupWorker(ctx, rs, c.cfg)
 	defer stopMagicLinkTokenCleanup()
 
+	stopIdempotencyKeyCleanup := bootstrap.StartIdempotencyKeyCleanupWorker(ctx, rs, c.cfg)
+	defer stopIdempotencyKeyCleanup()
+
 	stopOperationSlotCleanup := bootstrap.StartOperationSlotCleanupWorker(ctx, rs, c.cfg)
 	defer stopOperationSlotCleanup()
 
@@ -115,8 +126,14 @@ func (c *Command) run() error {
 
 	stopLoanReminder := bootstrap.StartLoanReminderWorker(ctx, rs, c.cfg)
 	defer stopLoanReminder()
+	stopServiceReminder := bootstrap.StartServiceReminderWorker(ctx, rs, c.cfg)
+	defer stopServiceReminder()
 	stopMaintenanceReminder := bootstrap.StartMaintenanceReminderWorker(ctx, rs, c.cfg)
 	defer stopMaintenanceReminder()
+	stopInvoiceExtraction := bootstrap.StartInvoiceExtractionWorker(ctx, rs, c.cfg)
+	defer stopInvoiceExtraction()
+	stopBackupScheduler := bootstrap.StartBackupSchedulerWorker(ctx, rs, c.cfg)
+	defer stopBackupScheduler()
 
 	stopCurrencyMigration := bootstrap.StartCurrencyMigrationWorker(ctx, rs, c.cfg)
 	defer stopCurrencyMigration()
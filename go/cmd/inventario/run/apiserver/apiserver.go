@@ -64,9 +64,14 @@ func (c *Command) run() error {
 	if err != nil {
 		return err
 	}
+	// Flush the registry (the final memory snapshot) after everything else
+	// has stopped writing to it.
+	defer rs.CloseFactorySet()
 	defer rs.CloseReadinessRedisPinger()
 	// Flush buffered Sentry events on shutdown (#844); no-op when disabled.
 	defer rs.SentryFlush(2 * time.Second)
+	// Flush buffered OpenTelemetry spans; no-op when tracing is disabled.
+	defer rs.ShutdownTracing(5 * time.Second)
 
 	restoreStatus := restore.NewRegistryStatusQuerier(rs.FactorySet.CreateServiceRegistrySet())
 	srv, errCh := bootstrap.StartAPIServer(c.cfg, rs, restoreStatus)
@@ -15,9 +15,12 @@ import (
 	"github.com/denisvmedia/inventario/apiserver"
 	"github.com/denisvmedia/inventario/cmd/inventario/shared"
 	"github.com/denisvmedia/inventario/internal/observability/sentry"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+	"github.com/denisvmedia/inventario/internal/version"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 	"github.com/denisvmedia/inventario/schema/migrations/migrator"
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
 	"github.com/denisvmedia/inventario/services/workerpause"
 )
 
@@ -54,6 +57,11 @@ type RuntimeSetup struct {
 	// defer it unconditionally.
 	SentryFlush func(timeout time.Duration) bool
 
+	// TracingShutdown flushes buffered OpenTelemetry spans on shutdown. Like
+	// SentryFlush it is always non-nil — a no-op when the tracing exporter
+	// is "none".
+	TracingShutdown func(ctx context.Context) error
+
 	// PauseController polls the worker_control rows and exposes the
 	// soft-pause check the workers consult each tick (#1308). It is built
 	// only in worker-bearing modes (ModeAll / ModeWorkers); it stays nil in
@@ -131,6 +139,21 @@ func Build(cfg *Config, dbConfig *shared.DatabaseConfig, mode Mode) (*RuntimeSet
 		return nil, fmt.Errorf("initialising sentry: %w", err)
 	}
 
+	// Initialise OpenTelemetry tracing. An unknown exporter or a malformed
+	// endpoint fails fast; the default "none" installs only the W3C
+	// propagator.
+	tracingShutdown, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:       cfg.TracingExporter,
+		Endpoint:       cfg.TracingEndpoint,
+		SampleRatio:    cfg.TracingSampleRatio,
+		ServiceName:    "inventario",
+		ServiceVersion: version.Version,
+	})
+	if err != nil {
+		serverSetup.closeReadinessRedisPinger()
+		return nil, fmt.Errorf("initialising tracing: %w", err)
+	}
+
 	rs := &RuntimeSetup{
 		DSN:                       dsn,
 		FactorySet:                factorySet,
@@ -139,6 +162,7 @@ func Build(cfg *Config, dbConfig *shared.DatabaseConfig, mode Mode) (*RuntimeSet
 		WorkerDurations:           durations,
 		CloseReadinessRedisPinger: serverSetup.closeReadinessRedisPinger,
 		SentryFlush:               sentryFlush,
+		TracingShutdown:           tracingShutdown,
 	}
 
 	// Build the soft-pause controller only in worker-bearing modes (#1308).
@@ -154,6 +178,29 @@ func Build(cfg *Config, dbConfig *shared.DatabaseConfig, mode Mode) (*RuntimeSet
 	return rs, nil
 }
 
+// ShutdownTracing flushes buffered OpenTelemetry spans, giving the exporter
+// at most timeout. It must run after the API server and every worker have
+// stopped so their final spans are included.
+func (rs *RuntimeSetup) ShutdownTracing(timeout time.Duration) {
+	if rs.TracingShutdown == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := rs.TracingShutdown(ctx); err != nil {
+		slog.Warn("Failed to flush OpenTelemetry spans on shutdown", "error", err)
+	}
+}
+
+// CloseFactorySet flushes the registry factory on shutdown. For a
+// snapshot-backed memory:// registry this writes the final snapshot, so it
+// must run after the API server and every worker have stopped.
+func (rs *RuntimeSetup) CloseFactorySet() {
+	if err := rs.FactorySet.Close(); err != nil {
+		slog.Error("Failed to close registry", "error", err)
+	}
+}
+
 // logInventarioEnv emits every INVENTARIO_-prefixed environment variable name
 // (values are intentionally omitted to avoid leaking secrets) to aid
 // configuration troubleshooting.
@@ -191,6 +238,7 @@ func logStartupInfo(mode Mode, addr, dsn string) {
 // at a half-migrated schema, and queries fail with "column does not exist"
 // from the request path.
 //
+// SQLite runs its own embedded migrations and is checked the same way.
 // Memory and other in-process registries don't run real migrations — skip
 // the check there so dev workflows and unit tests stay friction-free.
 //
@@ -199,7 +247,7 @@ func logStartupInfo(mode Mode, addr, dsn string) {
 // failure they can retry rather than a hung pod.
 func verifySchemaMatchesBinary(dsn string) error {
 	lowered := strings.ToLower(strings.TrimSpace(dsn))
-	if !strings.HasPrefix(lowered, "postgres://") && !strings.HasPrefix(lowered, "postgresql://") {
+	if !strings.HasPrefix(lowered, "postgres://") && !strings.HasPrefix(lowered, "postgresql://") && !sqlite.IsDSN(lowered) {
 		return nil
 	}
 
@@ -254,6 +302,10 @@ func seedMemoryDBDefaultTenant(dsn string, factorySet *registry.FactorySet) {
 	if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(dsn)), "memory://") {
 		return
 	}
+	// A registry restored from a snapshot already has its tenants.
+	if existing, err := factorySet.TenantRegistry.List(context.Background()); err == nil && len(existing) > 0 {
+		return
+	}
 	slug := os.Getenv("INVENTARIO_RUN_MEMORY_TENANT_SLUG")
 	if slug == "" {
 		slug = "default"
@@ -36,13 +36,20 @@ type Config struct {
 	RefreshTokenCleanupInterval      string `yaml:"refresh_token_cleanup_interval" env:"REFRESH_TOKEN_CLEANUP_INTERVAL" env-default:""`
 	EmailVerificationCleanupInterval string `yaml:"email_verification_cleanup_interval" env:"EMAIL_VERIFICATION_CLEANUP_INTERVAL" env-default:""`
 	MagicLinkTokenCleanupInterval    string `yaml:"magic_link_token_cleanup_interval" env:"MAGIC_LINK_TOKEN_CLEANUP_INTERVAL" env-default:""`
+	IdempotencyKeyCleanupInterval    string `yaml:"idempotency_key_cleanup_interval" env:"IDEMPOTENCY_KEY_CLEANUP_INTERVAL" env-default:""`
 	OperationSlotCleanupInterval     string `yaml:"operation_slot_cleanup_interval" env:"OPERATION_SLOT_CLEANUP_INTERVAL" env-default:""`
 	GroupPurgeInterval               string `yaml:"group_purge_interval" env:"GROUP_PURGE_INTERVAL" env-default:""`
 	WarrantyReminderInterval         string `yaml:"warranty_reminder_interval" env:"WARRANTY_REMINDER_INTERVAL" env-default:""`
 	StorageQuotaReminderInterval     string `yaml:"storage_quota_reminder_interval" env:"STORAGE_QUOTA_REMINDER_INTERVAL" env-default:""`
 	LoanReminderInterval             string `yaml:"loan_reminder_interval" env:"LOAN_REMINDER_INTERVAL" env-default:""`
 	LoanReminderDueSoonDays          int    `yaml:"loan_reminder_due_soon_days" env:"LOAN_REMINDER_DUE_SOON_DAYS" env-default:"0"`
+	ServiceReminderInterval          string `yaml:"service_reminder_interval" env:"SERVICE_REMINDER_INTERVAL" env-default:""`
+	ServiceReminderDueSoonDays       int    `yaml:"service_reminder_due_soon_days" env:"SERVICE_REMINDER_DUE_SOON_DAYS" env-default:"0"`
 	MaintenanceReminderInterval      string `yaml:"maintenance_reminder_interval" env:"MAINTENANCE_REMINDER_INTERVAL" env-default:""`
+	InvoiceExtractionInterval        string `yaml:"invoice_extraction_interval" env:"INVOICE_EXTRACTION_INTERVAL" env-default:""`
+	InvoiceExtractionBatchSize       int    `yaml:"invoice_extraction_batch_size" env:"INVOICE_EXTRACTION_BATCH_SIZE" env-default:"0"`
+	BackupSchedulerInterval          string `yaml:"backup_scheduler_interval" env:"BACKUP_SCHEDULER_INTERVAL" env-default:""`
+	BackupReplicationInterval        string `yaml:"backup_replication_interval" env:"BACKUP_REPLICATION_INTERVAL" env-default:""`
 	CurrencyMigrationInterval        string `yaml:"currency_migration_interval" env:"CURRENCY_MIGRATION_INTERVAL" env-default:""`
 	BusinessMetricsInterval          string `yaml:"business_metrics_interval" env:"BUSINESS_METRICS_INTERVAL" env-default:""`
 	WorkerControlRefreshInterval     string `yaml:"worker_control_refresh_interval" env:"WORKER_CONTROL_REFRESH_INTERVAL" env-default:""`
@@ -52,6 +59,10 @@ type Config struct {
 	OrphanFileGCInterval string `yaml:"orphan_file_gc_interval" env:"ORPHAN_FILE_GC_INTERVAL" env-default:""`
 	OrphanFileGCMinAge   string `yaml:"orphan_file_gc_min_age" env:"ORPHAN_FILE_GC_MIN_AGE" env-default:""`
 	OrphanFileGCMode     string `yaml:"orphan_file_gc_mode" env:"ORPHAN_FILE_GC_MODE" env-default:""`
+	// BackupReplicationURL is the gocloud bucket URL (s3://, azblob://,
+	// gs://, file://) completed exports are copied to. Empty disables
+	// off-site replication.
+	BackupReplicationURL string `yaml:"backup_replication_url" env:"BACKUP_REPLICATION_URL" env-default:""`
 
 	JWTSecret      string `yaml:"jwt_secret" env:"JWT_SECRET" env-default:""`
 	FileSigningKey string `yaml:"file_signing_key" env:"FILE_SIGNING_KEY" env-default:""`
@@ -65,6 +76,7 @@ type Config struct {
 	BackupSigningKey              string `yaml:"backup_signing_key" env:"BACKUP_SIGNING_KEY" env-default:""`
 	FileURLExpiration             string `yaml:"file_url_expiration" env:"FILE_URL_EXPIRATION" env-default:"15m"`
 	ImpersonationTTL              string `yaml:"impersonation_ttl" env:"IMPERSONATION_TTL" env-default:"30m"`
+	IdempotencyKeyTTL             string `yaml:"idempotency_key_ttl" env:"IDEMPOTENCY_KEY_TTL" env-default:"24h"`
 	ThumbnailMaxConcurrentPerUser int    `yaml:"thumbnail_max_concurrent_per_user" env:"THUMBNAIL_MAX_CONCURRENT_PER_USER" env-default:"0"`
 	ThumbnailRateLimitPerMinute   int    `yaml:"thumbnail_rate_limit_per_minute" env:"THUMBNAIL_RATE_LIMIT_PER_MINUTE" env-default:"0"`
 	ThumbnailSlotDuration         string `yaml:"thumbnail_slot_duration" env:"THUMBNAIL_SLOT_DURATION" env-default:"30m"`
@@ -83,6 +95,7 @@ type Config struct {
 	GlobalRateLimitDisabled       bool   `yaml:"global_rate_limit_disabled" env:"GLOBAL_RATE_LIMIT_DISABLED" env-default:"false"`
 	GlobalRateTrustedProxies      string `yaml:"global_rate_trusted_proxies" env:"GLOBAL_RATE_TRUSTED_PROXIES" env-default:""`
 	CSRFRedisURL                  string `yaml:"csrf_redis_url" env:"CSRF_REDIS_URL" env-default:""`
+	ChangeFeedRedisURL            string `yaml:"change_feed_redis_url" env:"CHANGE_FEED_REDIS_URL" env-default:""`
 	AllowedOrigins                string `yaml:"allowed_origins" env:"ALLOWED_ORIGINS" env-default:""`
 	PublicURL                     string `yaml:"public_url" env:"PUBLIC_URL" env-default:""`
 
@@ -145,6 +158,15 @@ type Config struct {
 	// the provider default (4096). It is only a ceiling — small scans don't
 	// pay for the headroom.
 	AIVisionMaxTokens int `yaml:"ai_vision_max_tokens" env:"AI_VISION_MAX_TOKENS" env-default:"4096"`
+	// AIVisionFallbackProviders is a comma-separated list of providers
+	// tried in order when the primary is unavailable or times out (e.g.
+	// "openai"). AI_VISION_TIMEOUT then bounds each attempt.
+	AIVisionFallbackProviders string `yaml:"ai_vision_fallback_providers" env:"AI_VISION_FALLBACK_PROVIDERS" env-default:""`
+	// AIVisionPrices overrides or extends the built-in per-model price
+	// table used for scan cost accounting and budgets, as
+	// "model=input/output" pairs in USD per million tokens, e.g.
+	// "gpt-4o-mini=0.15/0.60".
+	AIVisionPrices string `yaml:"ai_vision_prices" env:"AI_VISION_PRICES" env-default:""`
 
 	// PublicAIVisionScanEnabled gates the unauthenticated public photo-scan
 	// endpoint (#1988) that backs the landing-page "add your first item"
@@ -195,6 +217,18 @@ type Config struct {
 	// YAML key.
 	EnableAPIDocs bool `yaml:"enable_api_docs" env:"ENABLE_API_DOCS" env-default:"true"`
 
+	// Tracing* configure OpenTelemetry distributed tracing. TracingExporter
+	// is "none" (the default: spans are not recorded), "otlp-grpc" or
+	// "otlp-http"; TracingEndpoint is the collector URL (empty falls back to
+	// the standard OTEL_EXPORTER_OTLP_* env vars, which also carry auth
+	// headers). TracingSampleRatio is the fraction of new traces kept and is
+	// clamped to [0, 1]; requests arriving with a traceparent follow the
+	// caller's decision. Like EnableAPIDocs, a YAML ratio of 0 is re-defaulted
+	// to 1 — use exporter "none" to turn tracing off.
+	TracingExporter    string  `yaml:"tracing_exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	TracingEndpoint    string  `yaml:"tracing_endpoint" env:"TRACING_ENDPOINT" env-default:""`
+	TracingSampleRatio float64 `yaml:"tracing_sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+
 	// WorkersOnly / WorkersExclude restrict which background workers run in
 	// `inventario run workers`. See the run/workers package for the accepted
 	// syntax and mutual-exclusion rules. Both fields default to empty, meaning
@@ -313,6 +347,11 @@ func (c *Config) SetDefaults() {
 		// env-default did not apply (e.g. config loaded from YAML).
 		c.ImpersonationTTL = "30m"
 	}
+	if c.IdempotencyKeyTTL == "" {
+		// Matches the IDEMPOTENCY_KEY_TTL env-default for YAML configs
+		// that omit the key.
+		c.IdempotencyKeyTTL = "24h"
+	}
 	if c.AIVisionTimeout == "" {
 		// #1720: matches AI_VISION_TIMEOUT env-default. Empty values
 		// come from YAML configs that omit the key entirely. 60s leaves
@@ -364,6 +403,9 @@ func (c *Config) setWorkerDefaults() {
 	if c.MagicLinkTokenCleanupInterval == "" {
 		c.MagicLinkTokenCleanupInterval = defaults.GetMagicLinkTokenCleanupInterval()
 	}
+	if c.IdempotencyKeyCleanupInterval == "" {
+		c.IdempotencyKeyCleanupInterval = defaults.GetIdempotencyKeyCleanupInterval()
+	}
 	if c.OperationSlotCleanupInterval == "" {
 		c.OperationSlotCleanupInterval = defaults.GetOperationSlotCleanupInterval()
 	}
@@ -384,9 +426,27 @@ func (c *Config) setWorkerDefaults() {
 		// sign by mistake otherwise silently invert the due-soon window.
 		c.LoanReminderDueSoonDays = defaults.GetLoanReminderDueSoonDays()
 	}
+	if c.ServiceReminderInterval == "" {
+		c.ServiceReminderInterval = defaults.GetServiceReminderInterval()
+	}
+	if c.ServiceReminderDueSoonDays <= 0 {
+		c.ServiceReminderDueSoonDays = defaults.GetServiceReminderDueSoonDays()
+	}
 	if c.MaintenanceReminderInterval == "" {
 		c.MaintenanceReminderInterval = defaults.GetMaintenanceReminderInterval()
 	}
+	if c.InvoiceExtractionInterval == "" {
+		c.InvoiceExtractionInterval = defaults.GetInvoiceExtractionInterval()
+	}
+	if c.InvoiceExtractionBatchSize <= 0 {
+		c.InvoiceExtractionBatchSize = defaults.GetInvoiceExtractionBatchSize()
+	}
+	if c.BackupSchedulerInterval == "" {
+		c.BackupSchedulerInterval = defaults.GetBackupSchedulerInterval()
+	}
+	if c.BackupReplicationInterval == "" {
+		c.BackupReplicationInterval = defaults.GetBackupReplicationInterval()
+	}
 	if c.CurrencyMigrationInterval == "" {
 		c.CurrencyMigrationInterval = defaults.GetCurrencyMigrationInterval()
 	}
@@ -19,12 +19,17 @@ type WorkerDurations struct {
 	RefreshTokenCleanupInterval      time.Duration
 	EmailVerificationCleanupInterval time.Duration
 	MagicLinkTokenCleanupInterval    time.Duration
+	IdempotencyKeyCleanupInterval    time.Duration
 	OperationSlotCleanupInterval     time.Duration
 	GroupPurgeInterval               time.Duration
 	WarrantyReminderInterval         time.Duration
 	StorageQuotaReminderInterval     time.Duration
 	LoanReminderInterval             time.Duration
+	ServiceReminderInterval          time.Duration
 	MaintenanceReminderInterval      time.Duration
+	InvoiceExtractionInterval        time.Duration
+	BackupSchedulerInterval          time.Duration
+	BackupReplicationInterval        time.Duration
 	CurrencyMigrationInterval        time.Duration
 	BusinessMetricsInterval          time.Duration
 	WorkerControlRefreshInterval     time.Duration
@@ -67,12 +72,17 @@ func ParseWorkerDurations(cfg *Config) (WorkerDurations, error) {
 		{"refresh-token-cleanup-interval", cfg.RefreshTokenCleanupInterval, &out.RefreshTokenCleanupInterval},
 		{"email-verification-cleanup-interval", cfg.EmailVerificationCleanupInterval, &out.EmailVerificationCleanupInterval},
 		{"magic-link-token-cleanup-interval", cfg.MagicLinkTokenCleanupInterval, &out.MagicLinkTokenCleanupInterval},
+		{"idempotency-key-cleanup-interval", cfg.IdempotencyKeyCleanupInterval, &out.IdempotencyKeyCleanupInterval},
 		{"operation-slot-cleanup-interval", cfg.OperationSlotCleanupInterval, &out.OperationSlotCleanupInterval},
 		{"group-purge-interval", cfg.GroupPurgeInterval, &out.GroupPurgeInterval},
 		{"warranty-reminder-interval", cfg.WarrantyReminderInterval, &out.WarrantyReminderInterval},
 		{"storage-quota-reminder-interval", cfg.StorageQuotaReminderInterval, &out.StorageQuotaReminderInterval},
 		{"loan-reminder-interval", cfg.LoanReminderInterval, &out.LoanReminderInterval},
+		{"service-reminder-interval", cfg.ServiceReminderInterval, &out.ServiceReminderInterval},
 		{"maintenance-reminder-interval", cfg.MaintenanceReminderInterval, &out.MaintenanceReminderInterval},
+		{"invoice-extraction-interval", cfg.InvoiceExtractionInterval, &out.InvoiceExtractionInterval},
+		{"backup-scheduler-interval", cfg.BackupSchedulerInterval, &out.BackupSchedulerInterval},
+		{"backup-replication-interval", cfg.BackupReplicationInterval, &out.BackupReplicationInterval},
 		{"currency-migration-interval", cfg.CurrencyMigrationInterval, &out.CurrencyMigrationInterval},
 		{"business-metrics-interval", cfg.BusinessMetricsInterval, &out.BusinessMetricsInterval},
 		{"orphan-file-gc-interval", cfg.OrphanFileGCInterval, &out.OrphanFileGCInterval},
@@ -19,12 +19,17 @@ func TestParseWorkerDurations_Valid(t *testing.T) {
 		RefreshTokenCleanupInterval:      "2h",
 		EmailVerificationCleanupInterval: "45m",
 		MagicLinkTokenCleanupInterval:    "40m",
+		IdempotencyKeyCleanupInterval:    "35m",
 		OperationSlotCleanupInterval:     "4m",
 		GroupPurgeInterval:               "7m",
 		WarrantyReminderInterval:         "30m",
 		StorageQuotaReminderInterval:     "20m",
 		LoanReminderInterval:             "45m",
+		ServiceReminderInterval:          "50m",
 		MaintenanceReminderInterval:      "55m",
+		InvoiceExtractionInterval:        "20m",
+		BackupSchedulerInterval:          "3m",
+		BackupReplicationInterval:        "15m",
 		CurrencyMigrationInterval:        "8s",
 		BusinessMetricsInterval:          "90s",
 		OrphanFileGCInterval:             "12h",
@@ -48,12 +53,17 @@ func TestParseWorkerDurations_Valid(t *testing.T) {
 	c.Assert(got.RefreshTokenCleanupInterval, qt.Equals, 2*time.Hour)
 	c.Assert(got.EmailVerificationCleanupInterval, qt.Equals, 45*time.Minute)
 	c.Assert(got.MagicLinkTokenCleanupInterval, qt.Equals, 40*time.Minute)
+	c.Assert(got.IdempotencyKeyCleanupInterval, qt.Equals, 35*time.Minute)
 	c.Assert(got.OperationSlotCleanupInterval, qt.Equals, 4*time.Minute)
 	c.Assert(got.GroupPurgeInterval, qt.Equals, 7*time.Minute)
 	c.Assert(got.WarrantyReminderInterval, qt.Equals, 30*time.Minute)
 	c.Assert(got.StorageQuotaReminderInterval, qt.Equals, 20*time.Minute)
 	c.Assert(got.LoanReminderInterval, qt.Equals, 45*time.Minute)
+	c.Assert(got.ServiceReminderInterval, qt.Equals, 50*time.Minute)
 	c.Assert(got.MaintenanceReminderInterval, qt.Equals, 55*time.Minute)
+	c.Assert(got.InvoiceExtractionInterval, qt.Equals, 20*time.Minute)
+	c.Assert(got.BackupSchedulerInterval, qt.Equals, 3*time.Minute)
+	c.Assert(got.BackupReplicationInterval, qt.Equals, 15*time.Minute)
 	c.Assert(got.CurrencyMigrationInterval, qt.Equals, 8*time.Second)
 	c.Assert(got.BusinessMetricsInterval, qt.Equals, 90*time.Second)
 	c.Assert(got.OrphanFileGCInterval, qt.Equals, 12*time.Hour)
@@ -29,13 +29,20 @@ func RegisterFlags(cmd *cobra.Command, cfg *Config, dbConfig *shared.DatabaseCon
 	flags.StringVar(&cfg.RefreshTokenCleanupInterval, "refresh-token-cleanup-interval", cfg.RefreshTokenCleanupInterval, "Interval between refresh token cleanup runs (e.g., 1h, 30m)")
 	flags.StringVar(&cfg.EmailVerificationCleanupInterval, "email-verification-cleanup-interval", cfg.EmailVerificationCleanupInterval, "Interval between email verification token cleanup runs (e.g., 1h, 30m)")
 	flags.StringVar(&cfg.MagicLinkTokenCleanupInterval, "magic-link-token-cleanup-interval", cfg.MagicLinkTokenCleanupInterval, "Interval between magic-link sign-in token cleanup runs (e.g., 1h, 30m)")
+	flags.StringVar(&cfg.IdempotencyKeyCleanupInterval, "idempotency-key-cleanup-interval", cfg.IdempotencyKeyCleanupInterval, "Interval between expired Idempotency-Key record cleanup runs (e.g., 1h, 30m)")
 	flags.StringVar(&cfg.OperationSlotCleanupInterval, "operation-slot-cleanup-interval", cfg.OperationSlotCleanupInterval, "Interval between expired operation-slot cleanup runs (e.g., 5m, 1m)")
 	flags.StringVar(&cfg.GroupPurgeInterval, "group-purge-interval", cfg.GroupPurgeInterval, "Interval between group purge sweeps (hard-deletes pending_deletion groups and expired unused invites; e.g., 5m, 15m)")
 	flags.StringVar(&cfg.WarrantyReminderInterval, "warranty-reminder-interval", cfg.WarrantyReminderInterval, "Interval between warranty reminder sweeps (60/30/7-day expiry emails; e.g., 1h)")
 	flags.StringVar(&cfg.StorageQuotaReminderInterval, "storage-quota-reminder-interval", cfg.StorageQuotaReminderInterval, "Interval between storage quota warning sweeps (90% threshold emails; e.g., 1h)")
 	flags.StringVar(&cfg.LoanReminderInterval, "loan-reminder-interval", cfg.LoanReminderInterval, "Interval between loan reminder sweeps (overdue + due-soon emails; e.g., 1h)")
 	flags.IntVar(&cfg.LoanReminderDueSoonDays, "loan-reminder-due-soon-days", cfg.LoanReminderDueSoonDays, "Forward-looking window in days for the due-soon loan reminder (default 7)")
+	flags.StringVar(&cfg.ServiceReminderInterval, "service-reminder-interval", cfg.ServiceReminderInterval, "Interval between service reminder sweeps (overdue + due-soon repair return emails; e.g., 1h)")
+	flags.IntVar(&cfg.ServiceReminderDueSoonDays, "service-reminder-due-soon-days", cfg.ServiceReminderDueSoonDays, "Forward-looking window in days for the due-soon service reminder (default 7)")
 	flags.StringVar(&cfg.MaintenanceReminderInterval, "maintenance-reminder-interval", cfg.MaintenanceReminderInterval, "Interval between maintenance reminder sweeps (14/7/1-day + overdue maintenance emails; e.g., 1h)")
+	flags.StringVar(&cfg.InvoiceExtractionInterval, "invoice-extraction-interval", cfg.InvoiceExtractionInterval, "Interval between invoice extraction sweeps (AI vision over attached invoices; runs only with a configured --ai-vision-provider; e.g., 15m)")
+	flags.IntVar(&cfg.InvoiceExtractionBatchSize, "invoice-extraction-batch-size", cfg.InvoiceExtractionBatchSize, "Maximum invoice files read by the AI vision provider per extraction sweep (default 20)")
+	flags.StringVar(&cfg.BackupSchedulerInterval, "backup-scheduler-interval", cfg.BackupSchedulerInterval, "Interval between scheduled-backup sweeps (enqueue due group backups, apply retention, report failures; e.g., 5m)")
+	flags.StringVar(&cfg.BackupReplicationInterval, "backup-replication-interval", cfg.BackupReplicationInterval, "Interval between off-site backup replication sweeps (e.g., 10m)")
 	flags.StringVar(&cfg.CurrencyMigrationInterval, "currency-migration-interval", cfg.CurrencyMigrationInterval, "Currency migration worker active-poll interval (when pending rows exist; idle cadence is fixed at 1m). Values like 5s, 10s.")
 	flags.StringVar(&cfg.BusinessMetricsInterval, "business-metrics-interval", cfg.BusinessMetricsInterval, "Interval between installation-wide business-metrics collection sweeps (#843; e.g., 60s)")
 	flags.StringVar(&cfg.OrphanFileGCInterval, "orphan-file-gc-interval", cfg.OrphanFileGCInterval, "Interval between orphan-file GC sweeps (#2237; e.g., 24h)")
@@ -52,8 +59,10 @@ func RegisterFlags(cmd *cobra.Command, cfg *Config, dbConfig *shared.DatabaseCon
 	flags.StringVar(&cfg.JWTSecret, "jwt-secret", cfg.JWTSecret, "JWT secret for authentication (minimum 32 characters, auto-generated if not provided)")
 	flags.StringVar(&cfg.FileSigningKey, "file-signing-key", cfg.FileSigningKey, "File signing key for secure file URLs (minimum 32 characters, auto-generated if not provided)")
 	flags.StringVar(&cfg.BackupSigningKey, "backup-signing-key", cfg.BackupSigningKey, "Ed25519 seed for signing .inb backup archives (64 hex chars or 32 raw bytes, auto-generated if not provided)")
+	flags.StringVar(&cfg.BackupReplicationURL, "backup-replication-url", cfg.BackupReplicationURL, "Bucket URL completed backups are replicated to (s3://, azblob://, gs://, file://); empty disables replication")
 	flags.StringVar(&cfg.FileURLExpiration, "file-url-expiration", cfg.FileURLExpiration, "File URL expiration duration (e.g., 15m, 1h, 30s)")
 	flags.StringVar(&cfg.ImpersonationTTL, "impersonation-ttl", cfg.ImpersonationTTL, "Admin impersonation session lifetime (e.g., 30m, 15m); values above 30m are clamped down")
+	flags.StringVar(&cfg.IdempotencyKeyTTL, "idempotency-key-ttl", cfg.IdempotencyKeyTTL, "How long a response stored under an Idempotency-Key is replayed to retries (e.g., 24h)")
 	flags.StringVar(&cfg.TokenBlacklistRedisURL, "token-blacklist-redis-url", cfg.TokenBlacklistRedisURL, "Redis URL for token blacklist (e.g., redis://localhost:6379/0); omit to use in-memory blacklist")
 	flags.StringVar(&cfg.AuthRateLimitRedisURL, "auth-rate-limit-redis-url", cfg.AuthRateLimitRedisURL, "Redis URL for auth rate limiting/lockout (e.g., redis://localhost:6379/0); omit to use in-memory limiter")
 	flags.BoolVar(&cfg.AuthRateLimitDisabled, "no-auth-rate-limit", cfg.AuthRateLimitDisabled, "Disable auth rate limiting entirely (for testing only — do not use in production)")
@@ -70,6 +79,7 @@ func RegisterFlags(cmd *cobra.Command, cfg *Config, dbConfig *shared.DatabaseCon
 	)
 	flags.StringVar(&cfg.GlobalRateTrustedProxies, "global-rate-trusted-proxies", cfg.GlobalRateTrustedProxies, "Comma-separated trusted proxy CIDRs/IPs used when resolving client IP for global rate limiting")
 	flags.StringVar(&cfg.CSRFRedisURL, "csrf-redis-url", cfg.CSRFRedisURL, "Redis URL for CSRF token storage (e.g., redis://localhost:6379/0); omit to use in-memory storage")
+	flags.StringVar(&cfg.ChangeFeedRedisURL, "change-feed-redis-url", cfg.ChangeFeedRedisURL, "Redis URL for the live group change stream (e.g., redis://localhost:6379/0); omit to use an in-memory feed (single replica only)")
 	flags.StringVar(&cfg.AllowedOrigins, "allowed-origins", cfg.AllowedOrigins, "Comma-separated list of allowed CORS origins (e.g., https://example.com)")
 	flags.StringVar(&cfg.PublicURL, "public-url", cfg.PublicURL, "Public base URL used in transactional email links (e.g., https://inventario.example.com)")
 	flags.BoolVar(&cfg.MagicLinkLoginEnabled, "magic-link-login-enabled", cfg.MagicLinkLoginEnabled, "Enable passwordless magic-link sign-in (auto-inert when the email provider is stub)")
@@ -115,9 +125,14 @@ func RegisterFlags(cmd *cobra.Command, cfg *Config, dbConfig *shared.DatabaseCon
 	flags.IntVar(&cfg.AIVisionMaxPhotoBytes, "ai-vision-max-photo-bytes", cfg.AIVisionMaxPhotoBytes, "Maximum bytes accepted per photo (defaults to 10 MiB)")
 	flags.IntVar(&cfg.AIVisionRateLimitPerHour, "ai-vision-rate-limit-per-hour", cfg.AIVisionRateLimitPerHour, "Per-user hourly scan rate limit (0 disables the limit)")
 	flags.IntVar(&cfg.AIVisionMaxTokens, "ai-vision-max-tokens", cfg.AIVisionMaxTokens, "Cap on the vision model's structured output (must hold a multi-line invoice; 0 = provider default 4096)")
+	flags.StringVar(&cfg.AIVisionFallbackProviders, "ai-vision-fallback-providers", cfg.AIVisionFallbackProviders, "Comma-separated AI vision providers tried in order when the primary is unavailable or times out (e.g. openai)")
+	flags.StringVar(&cfg.AIVisionPrices, "ai-vision-prices", cfg.AIVisionPrices, "Per-model AI vision prices as model=input/output USD per million tokens, comma-separated; overrides the built-in table")
 	flags.BoolVar(&cfg.PublicAIVisionScanEnabled, "public-ai-vision-scan-enabled", cfg.PublicAIVisionScanEnabled, "Enable the unauthenticated public photo-scan endpoint for the landing-page CTA (#1988). Default false; spends vendor tokens.")
 	flags.BoolVar(&cfg.SeedEndpointEnabled, "enable-seed-endpoint", cfg.SeedEndpointEnabled, "Mount the public, unauthenticated POST /api/v1/seed route (#2039). Default false; runs a privileged RLS-bypassing op — keep off in prod.")
 	flags.StringVar(&cfg.MetricsToken, "metrics-token", cfg.MetricsToken, "Bearer token gating GET /metrics (#2102; minimum 32 bytes recommended). Empty = open + one-time startup warning.")
+	flags.StringVar(&cfg.TracingExporter, "tracing-exporter", cfg.TracingExporter, "OpenTelemetry span exporter: none (default), otlp-grpc or otlp-http")
+	flags.StringVar(&cfg.TracingEndpoint, "tracing-endpoint", cfg.TracingEndpoint, "OTLP collector URL (e.g., http://otel-collector:4317); empty = OTEL_EXPORTER_OTLP_* env vars")
+	flags.Float64Var(&cfg.TracingSampleRatio, "tracing-sample-ratio", cfg.TracingSampleRatio, "Fraction of new traces recorded, 0..1 (default 1); incoming traceparent sampling decisions are honoured")
 	flags.BoolVar(&cfg.EnableAPIDocs, "enable-api-docs", cfg.EnableAPIDocs, "Mount the GET /swagger/* API documentation UI (#2102). Default true for dev/e2e; set false in production to hide the API surface.")
 
 	// OAuth third-party sign-in (issue #1394). Each provider requires
@@ -72,7 +72,7 @@ func NewReadinessRedisPinger(cfg *Config) *ReadinessRedisPinger {
 		url  string
 	}
 
-	deps := make([]redisDependency, 0, 4)
+	deps := make([]redisDependency, 0, 5)
 	if redisURL := strings.TrimSpace(cfg.TokenBlacklistRedisURL); redisURL != "" {
 		deps = append(deps, redisDependency{name: "token_blacklist", url: redisURL})
 	}
@@ -89,6 +89,9 @@ func NewReadinessRedisPinger(cfg *Config) *ReadinessRedisPinger {
 	if redisURL := strings.TrimSpace(cfg.CSRFRedisURL); redisURL != "" {
 		deps = append(deps, redisDependency{name: "csrf", url: redisURL})
 	}
+	if redisURL := strings.TrimSpace(cfg.ChangeFeedRedisURL); redisURL != "" {
+		deps = append(deps, redisDependency{name: "change_feed", url: redisURL})
+	}
 	if len(deps) == 0 {
 		return nil
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/jellydator/validation"
 
 	"github.com/denisvmedia/inventario/apiserver"
+	"github.com/denisvmedia/inventario/backup/replication"
 	"github.com/denisvmedia/inventario/debug"
 	"github.com/denisvmedia/inventario/internal/aivision"
 	_ "github.com/denisvmedia/inventario/internal/aivision/anthropic" // register the anthropic provider via init()
@@ -90,6 +91,17 @@ func buildServerParams(cfg *Config, factorySet *registry.FactorySet, dsn string)
 		return serverSetup{}, err
 	}
 
+	// Off-site replication is optional; a malformed target URL fails
+	// startup rather than silently leaving backups unreplicated.
+	if cfg.BackupReplicationURL != "" {
+		replicator, err := replication.NewReplicator(cfg.UploadLocation, cfg.BackupReplicationURL)
+		if err != nil {
+			slog.Error("Failed to configure backup replication", "error", err)
+			return serverSetup{}, err
+		}
+		params.BackupReplicator = replicator
+	}
+
 	// Parse file URL expiration duration.
 	fileURLExpiration, err := time.ParseDuration(cfg.FileURLExpiration)
 	if err != nil {
@@ -113,11 +125,19 @@ func buildServerParams(cfg *Config, factorySet *registry.FactorySet, dsn string)
 		return serverSetup{}, err
 	}
 
+	// Parse how long a stored Idempotency-Key response is replayed.
+	idempotencyKeyTTL, err := time.ParseDuration(cfg.IdempotencyKeyTTL)
+	if err != nil {
+		slog.Error("Failed to parse idempotency key TTL duration", "error", err, "duration", cfg.IdempotencyKeyTTL)
+		return serverSetup{}, err
+	}
+
 	params.JWTSecret = jwtSecret
 	params.FileSigningKey = fileSigningKey
 	params.BackupSigner = backupSigner
 	params.FileURLExpiration = fileURLExpiration
 	params.ImpersonationTTL = impersonationTTL
+	params.IdempotencyKeyTTL = idempotencyKeyTTL
 	params.ThumbnailConfig = services.ThumbnailGenerationConfig{
 		MaxConcurrentPerUser: cfg.ThumbnailMaxConcurrentPerUser,
 		RateLimitPerMinute:   cfg.ThumbnailRateLimitPerMinute,
@@ -149,6 +169,7 @@ func buildServerParams(cfg *Config, factorySet *registry.FactorySet, dsn string)
 	}
 
 	params.CSRFService = services.NewCSRFService(cfg.CSRFRedisURL)
+	params.ChangeFeed = services.NewChangeFeedBroker(cfg.ChangeFeedRedisURL)
 	// Assign through a typed local so that a nil *ReadinessRedisPinger is stored
 	// as a genuinely-nil apiserver.RedisPinger (avoiding the typed-nil-in-
 	// interface pitfall) and so the close closure can be omitted entirely when
@@ -257,6 +278,18 @@ func wireCommodityScan(cfg *Config, params *apiserver.Params) error {
 		return errors.New("AI_VISION_MAX_TOKENS must not be negative")
 	}
 
+	prices, err := aivision.ParsePriceTable(cfg.AIVisionPrices)
+	if err != nil {
+		return err
+	}
+
+	var fallbacks []string
+	for name := range strings.SplitSeq(cfg.AIVisionFallbackProviders, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			fallbacks = append(fallbacks, name)
+		}
+	}
+
 	provider, err := aivision.NewProvider(aivision.ProviderConfig{
 		Name:             strings.TrimSpace(cfg.AIVisionProvider),
 		AnthropicAPIKey:  cfg.AIVisionAnthropicAPIKey,
@@ -271,6 +304,10 @@ func wireCommodityScan(cfg *Config, params *apiserver.Params) error {
 		// when an operator raises it above the provider's fixed 90s fallback.
 		// +30s slack keeps the client a safety net strictly above the context.
 		HTTPClient: &http.Client{Timeout: timeout + 30*time.Second},
+		// With a fallback chain AI_VISION_TIMEOUT bounds each attempt and
+		// the service deadline below grows to cover all of them.
+		Fallbacks:      fallbacks,
+		AttemptTimeout: timeout,
 	})
 	switch {
 	case err == nil:
@@ -286,6 +323,10 @@ func wireCommodityScan(cfg *Config, params *apiserver.Params) error {
 		return err
 	}
 
+	if chain, ok := provider.(*aivision.Chain); ok {
+		timeout *= time.Duration(len(chain.Providers()))
+	}
+
 	params.CommodityScanService = services.NewCommodityScanService(
 		provider,
 		params.FactorySet.CommodityScanAuditRegistry,
@@ -294,8 +335,9 @@ func wireCommodityScan(cfg *Config, params *apiserver.Params) error {
 			MaxPhotoBytes:    cfg.AIVisionMaxPhotoBytes,
 			RateLimitPerHour: cfg.AIVisionRateLimitPerHour,
 			Timeout:          timeout,
+			Prices:           prices,
 		},
-	)
+	).WithBudgets(params.FactorySet.CommodityScanBudgetRegistry)
 	// Body cap = per-photo cap * max photos + a 1MB headroom for
 	// multipart overhead/JSON form fields. Zero when either cap is
 	// unset so the handler doesn't accidentally clamp to zero.
@@ -7,11 +7,13 @@ import (
 
 	"github.com/denisvmedia/inventario/backup/export"
 	importpkg "github.com/denisvmedia/inventario/backup/import"
+	"github.com/denisvmedia/inventario/backup/replication"
 	"github.com/denisvmedia/inventario/backup/restore"
 	"github.com/denisvmedia/inventario/internal/metrics"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 	"github.com/denisvmedia/inventario/registry/postgres"
+	"github.com/denisvmedia/inventario/registry/sqlite"
 	"github.com/denisvmedia/inventario/services"
 	"github.com/denisvmedia/inventario/services/notifications"
 )
@@ -156,6 +158,21 @@ func StartMagicLinkTokenCleanupWorker(ctx context.Context, rs *RuntimeSetup, _ *
 	return worker.Stop
 }
 
+// StartIdempotencyKeyCleanupWorker wires and starts the Idempotency-Key
+// cleanup worker (which deletes expired stored responses and abandoned
+// reservations on the configured interval) and returns its stop function.
+func StartIdempotencyKeyCleanupWorker(ctx context.Context, rs *RuntimeSetup, _ *Config) func() {
+	opts := []services.IdempotencyKeyCleanupOption{
+		services.WithIdempotencyKeyCleanupInterval(rs.WorkerDurations.IdempotencyKeyCleanupInterval),
+	}
+	if rs.PauseController != nil {
+		opts = append(opts, services.WithIdempotencyKeyCleanupPauseController(rs.PauseController))
+	}
+	worker := services.NewIdempotencyKeyCleanupWorker(rs.FactorySet.IdempotencyKeyRegistry, opts...)
+	worker.Start(ctx)
+	return worker.Stop
+}
+
 // StartOperationSlotCleanupWorker wires and starts the operation-slot cleanup
 // worker (#2122 F4), which deletes expired operation-slot rows on the
 // configured interval, and returns its stop function. It uses the service-mode
@@ -315,7 +332,8 @@ func StartWarrantyReminderWorker(ctx context.Context, rs *RuntimeSetup, cfg *Con
 // email service comes from rs.EmailLifecycle (already started by
 // StartEmailLifecycle in the housekeeping group). Per-user notification
 // preferences gate the per-recipient send via notifications.IsEnabled
-// against notifications.CategoryLoanReminder.
+// against notifications.CategoryLoanReminder; borrower courtesy
+// reminders are gated only by the loan carrying a borrower_email.
 func StartLoanReminderWorker(ctx context.Context, rs *RuntimeSetup, cfg *Config) func() {
 	urlBuilder := buildCommodityURLBuilder(cfg.PublicURL)
 	prefs := notifications.NewService(rs.FactorySet.SettingsRegistryFactory)
@@ -323,6 +341,20 @@ func StartLoanReminderWorker(ctx context.Context, rs *RuntimeSetup, cfg *Config)
 	service := services.NewLoanReminderService(rs.FactorySet, rs.EmailLifecycle.Service, urlBuilder).
 		WithPreferences(prefs).
 		WithDueSoonDays(cfg.LoanReminderDueSoonDays)
+	// Borrower reminders carry a signed loan link the API server must be
+	// able to validate, so the link is only minted from an explicitly
+	// configured signing key. An auto-generated per-process key (the
+	// getFileSigningKey fallback) would differ from the server's and
+	// every link would fail validation; without one the borrower email
+	// simply omits the link block.
+	if cfg.FileSigningKey != "" && cfg.PublicURL != "" {
+		key, err := getFileSigningKey(cfg.FileSigningKey)
+		if err != nil {
+			slog.Error("loan reminder: borrower links disabled", "error", err)
+		} else {
+			service = service.WithBorrowerLinks(services.NewLoanLinkSigner(key, 0), cfg.PublicURL)
+		}
+	}
 	opts := []services.LoanReminderOption{
 		services.WithLoanReminderInterval(rs.WorkerDurations.LoanReminderInterval),
 	}
@@ -334,6 +366,68 @@ func StartLoanReminderWorker(ctx context.Context, rs *RuntimeSetup, cfg *Config)
 	return worker.Stop
 }
 
+// StartServiceReminderWorker wires and starts the service reminder
+// worker for open commodity_services rows. Same wiring as
+// StartLoanReminderWorker; the opt-out check additionally honours the
+// per-group override via notifications.Cache.IsEnabledForGroup against
+// notifications.CategoryServiceReminder.
+func StartServiceReminderWorker(ctx context.Context, rs *RuntimeSetup, cfg *Config) func() {
+	urlBuilder := buildCommodityURLBuilder(cfg.PublicURL)
+	prefs := notifications.NewService(rs.FactorySet.SettingsRegistryFactory)
+	prefs.SetGroupPrefs(rs.FactorySet.GroupNotificationPrefRegistry)
+	service := services.NewServiceReminderService(rs.FactorySet, rs.EmailLifecycle.Service, urlBuilder).
+		WithPreferences(prefs).
+		WithDueSoonDays(cfg.ServiceReminderDueSoonDays)
+	opts := []services.ServiceReminderOption{
+		services.WithServiceReminderInterval(rs.WorkerDurations.ServiceReminderInterval),
+	}
+	if rs.PauseController != nil {
+		opts = append(opts, services.WithServiceReminderPauseController(rs.PauseController))
+	}
+	worker := services.NewServiceReminderWorker(service, opts...)
+	worker.Start(ctx)
+	return worker.Stop
+}
+
+// StartBackupSchedulerWorker wires and starts the group backup scheduler.
+// Scheduled exports are processed by the regular export worker; the
+// scheduler only enqueues them, prunes by retention through the same
+// EntityService path as a manual delete, and emails the schedule owner
+// when a run fails.
+func StartBackupSchedulerWorker(ctx context.Context, rs *RuntimeSetup, cfg *Config) func() {
+	prefs := notifications.NewService(rs.FactorySet.SettingsRegistryFactory)
+	opts := []export.BackupSchedulerOption{
+		export.WithBackupSchedulerInterval(rs.WorkerDurations.BackupSchedulerInterval),
+		export.WithBackupFailureNotifier(rs.EmailLifecycle.Service, buildExportsURLBuilder(cfg.PublicURL)),
+		export.WithBackupSchedulerPreferences(prefs),
+	}
+	if rs.PauseController != nil {
+		opts = append(opts, export.WithBackupSchedulerPauseController(rs.PauseController))
+	}
+	scheduler := export.NewBackupScheduler(rs.FactorySet, rs.Params.EntityService, opts...)
+	scheduler.Start(ctx)
+	return scheduler.Stop
+}
+
+// StartBackupReplicationWorker wires and starts the worker that copies
+// completed exports to the off-site replication target. It is a no-op
+// unless --backup-replication-url is set.
+func StartBackupReplicationWorker(ctx context.Context, rs *RuntimeSetup, _ *Config) func() {
+	if rs.Params.BackupReplicator == nil {
+		slog.Info("Backup replication worker disabled; set --backup-replication-url to enable")
+		return func() {}
+	}
+	opts := []replication.WorkerOption{
+		replication.WithWorkerInterval(rs.WorkerDurations.BackupReplicationInterval),
+	}
+	if rs.PauseController != nil {
+		opts = append(opts, replication.WithWorkerPauseController(rs.PauseController))
+	}
+	worker := replication.NewWorker(rs.FactorySet, rs.Params.BackupReplicator, opts...)
+	worker.Start(ctx)
+	return worker.Stop
+}
+
 // StartStorageQuotaReminderWorker wires and starts the storage quota
 // warning worker (#1585). Uses the configured interval from
 // rs.WorkerDurations and pulls the public URL from cfg for the two
@@ -432,12 +526,37 @@ func StartMaintenanceReminderWorker(ctx context.Context, rs *RuntimeSetup, cfg *
 	return worker.Stop
 }
 
+// StartInvoiceExtractionWorker wires and starts the invoice extraction
+// worker. It reuses the scan service built for the photo-scan endpoint,
+// so the job shares its validation, per-user rate limit and audit
+// trail. Returns a no-op stop function when no AI vision provider is
+// configured: every sweep would only record "disabled" audit rows.
+func StartInvoiceExtractionWorker(ctx context.Context, rs *RuntimeSetup, cfg *Config) func() {
+	if !rs.Params.CommodityScanService.Enabled() {
+		slog.Info("Invoice extraction worker disabled: no AI vision provider configured")
+		return func() {}
+	}
+	service := services.NewInvoiceExtractionService(rs.FactorySet, rs.Params.CommodityScanService, rs.Params.UploadLocation, services.InvoiceExtractionConfig{
+		BatchSize:    cfg.InvoiceExtractionBatchSize,
+		MaxFileBytes: cfg.AIVisionMaxPhotoBytes,
+	})
+	opts := []services.InvoiceExtractionOption{
+		services.WithInvoiceExtractionInterval(rs.WorkerDurations.InvoiceExtractionInterval),
+	}
+	if rs.PauseController != nil {
+		opts = append(opts, services.WithInvoiceExtractionPauseController(rs.PauseController))
+	}
+	worker := services.NewInvoiceExtractionWorker(service, opts...)
+	worker.Start(ctx)
+	return worker.Stop
+}
+
 // StartCurrencyMigrationWorker wires and starts the currency migration
 // worker (#1552 / #202 §4.5). Returns a no-op stop function when the
-// feature flag is off OR the active backend is not postgres — TX2 of
-// the migration lifecycle is postgres-only by design (advisory lock,
-// SET LOCAL role, transactional audit_logs insert), so a memory or
-// future non-postgres backend simply never schedules work. The
+// feature flag is off OR the active backend is neither postgres nor
+// sqlite — TX2 of the migration lifecycle needs a transactional
+// database (row locking, transactional audit_logs insert), so the
+// memory backend simply never schedules work. The
 // CurrencyMigrationRegistryFactory always exposes the registry side so
 // the apiserver endpoints stay live.
 func StartCurrencyMigrationWorker(ctx context.Context, rs *RuntimeSetup, cfg *Config) func() {
@@ -448,18 +567,21 @@ func StartCurrencyMigrationWorker(ctx context.Context, rs *RuntimeSetup, cfg *Co
 		slog.Info("Currency migration worker disabled; set INVENTARIO_RUN_FEATURE_CURRENCY_MIGRATION=true (or feature_currency_migration in config.yaml) to enable")
 		return func() {}
 	}
-	pgFactory, ok := rs.FactorySet.CurrencyMigrationRegistryFactory.(*postgres.CurrencyMigrationRegistryFactory)
-	if !ok {
+	var adapter services.CurrencyMigrationProcessor
+	switch factory := rs.FactorySet.CurrencyMigrationRegistryFactory.(type) {
+	case *postgres.CurrencyMigrationRegistryFactory:
+		adapter = &currencyMigrationProcessorAdapter{inner: factory.NewProcessor()}
+	case *sqlite.CurrencyMigrationRegistryFactory:
+		adapter = &sqliteCurrencyMigrationProcessorAdapter{inner: factory.NewProcessor()}
+	default:
 		// Memory backend or any future stub. The conversion service +
-		// audit + advisory lock surface lives in the postgres package,
-		// so a non-postgres deployment cannot run TX2 — log loudly so
-		// operators don't think the worker silently succeeded.
-		slog.Warn("Currency migration worker: skipping startup, backend is not postgres")
+		// audit surface lives in the SQL backends, so any other
+		// deployment cannot run TX2 — log loudly so operators don't
+		// think the worker silently succeeded.
+		slog.Warn("Currency migration worker: skipping startup, backend is neither postgres nor sqlite")
 		return func() {}
 	}
 
-	processor := pgFactory.NewProcessor()
-	adapter := &currencyMigrationProcessorAdapter{inner: processor}
 	opts := []services.CurrencyMigrationWorkerOption{
 		services.WithCurrencyMigrationActiveInterval(rs.WorkerDurations.CurrencyMigrationInterval),
 	}
@@ -494,6 +616,21 @@ func (a *currencyMigrationProcessorAdapter) WriteSweepFailureAuditLog(ctx contex
 	return a.inner.WriteSweepFailureAuditLog(ctx, op)
 }
 
+// sqliteCurrencyMigrationProcessorAdapter is currencyMigrationProcessorAdapter
+// for the sqlite processor.
+type sqliteCurrencyMigrationProcessorAdapter struct {
+	inner *sqlite.CurrencyMigrationProcessor
+}
+
+func (a *sqliteCurrencyMigrationProcessorAdapter) ProcessRunningMigration(ctx context.Context, op *currencyMigrationOp) (services.CurrencyMigrationProcessSummary, error) {
+	res, err := a.inner.ProcessRunningMigration(ctx, op)
+	return services.CurrencyMigrationProcessSummary(res), err
+}
+
+func (a *sqliteCurrencyMigrationProcessorAdapter) WriteSweepFailureAuditLog(ctx context.Context, op *currencyMigrationOp) error {
+	return a.inner.WriteSweepFailureAuditLog(ctx, op)
+}
+
 // buildCommodityURLBuilder returns the per-commodity URL builder
 // passed to WarrantyReminderService. Returns nil when no PublicURL is
 // configured — the email template suppresses the link block in that
@@ -511,6 +648,22 @@ func buildCommodityURLBuilder(publicURL string) func(string, string) string {
 	}
 }
 
+// buildExportsURLBuilder returns the builder for the group exports page
+// linked from the backup failure email, or nil when no public URL is
+// configured.
+func buildExportsURLBuilder(publicURL string) func(groupSlug string) string {
+	publicURL = strings.TrimRight(strings.TrimSpace(publicURL), "/")
+	if publicURL == "" {
+		return nil
+	}
+	return func(groupSlug string) string {
+		if groupSlug == "" {
+			return ""
+		}
+		return publicURL + "/g/" + groupSlug + "/exports"
+	}
+}
+
 // buildStorageQuotaURLBuilders returns the per-group files URL +
 // settings URL builders passed to StorageQuotaReminderService.
 // Returns (nil, nil) when no PublicURL is configured — the email
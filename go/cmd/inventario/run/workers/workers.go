@@ -124,9 +124,14 @@ func (c *Command) run() error {
 	if err != nil {
 		return err
 	}
+	// Flush the registry (the final memory snapshot) after everything else
+	// has stopped writing to it.
+	defer rs.CloseFactorySet()
 	defer rs.CloseReadinessRedisPinger()
 	// Flush buffered Sentry events on shutdown (#844); no-op when disabled.
 	defer rs.SentryFlush(2 * time.Second)
+	// Flush buffered OpenTelemetry spans; no-op when tracing is disabled.
+	defer rs.ShutdownTracing(5 * time.Second)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -149,12 +154,14 @@ func (c *Command) run() error {
 				return stop
 			},
 			bootstrap.StartImportWorker,
+			bootstrap.StartBackupReplicationWorker,
 		}},
 		{WorkerMedia, []starter{bootstrap.StartThumbnailWorker}},
 		{WorkerHousekeeping, []starter{
 			bootstrap.StartRefreshTokenCleanupWorker,
 			bootstrap.StartEmailVerificationCleanupWorker,
 			bootstrap.StartMagicLinkTokenCleanupWorker,
+			bootstrap.StartIdempotencyKeyCleanupWorker,
 			bootstrap.StartOperationSlotCleanupWorker,
 			bootstrap.StartLoginEventRetentionWorker,
 			bootstrap.StartGroupPurgeWorker,
@@ -162,7 +169,10 @@ func (c *Command) run() error {
 			bootstrap.StartWarrantyReminderWorker,
 			bootstrap.StartStorageQuotaReminderWorker,
 			bootstrap.StartLoanReminderWorker,
+			bootstrap.StartServiceReminderWorker,
 			bootstrap.StartMaintenanceReminderWorker,
+			bootstrap.StartInvoiceExtractionWorker,
+			bootstrap.StartBackupSchedulerWorker,
 			bootstrap.StartCurrencyMigrationWorker,
 			bootstrap.StartBusinessMetricsWorker,
 		}},
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
 )
 
 type DatabaseConfig struct {
@@ -15,9 +17,9 @@ func (d *DatabaseConfig) Validate() error {
 	if d.DBDSN == "" {
 		return errors.New("database DSN is required for this command")
 	}
-	// Validate that this is a PostgreSQL DSN
-	if !strings.HasPrefix(d.DBDSN, "postgres://") && !strings.HasPrefix(d.DBDSN, "postgresql://") {
-		return errors.New("config: bootstrap migrations only support PostgreSQL databases")
+	// Validate that this is a persistent (PostgreSQL or SQLite) DSN
+	if !strings.HasPrefix(d.DBDSN, "postgres://") && !strings.HasPrefix(d.DBDSN, "postgresql://") && !sqlite.IsDSN(d.DBDSN) {
+		return errors.New("config: bootstrap migrations only support PostgreSQL and SQLite databases")
 	}
 	return nil
 }
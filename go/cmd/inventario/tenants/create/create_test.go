@@ -113,7 +113,7 @@ func TestCommand_DatabaseValidation_HappyPath(t *testing.T) {
 			// For valid DSNs, we expect connection errors since we're not using a real database
 			// but the DSN validation should pass
 			if err != nil {
-				c.Assert(err.Error(), qt.Not(qt.Contains), "bootstrap migrations only support PostgreSQL databases")
+				c.Assert(err.Error(), qt.Not(qt.Contains), "bootstrap migrations only support PostgreSQL and SQLite databases")
 				c.Assert(err.Error(), qt.Not(qt.Contains), "tenant creation is not supported for memory databases")
 			}
 		})
@@ -129,7 +129,7 @@ func TestCommand_DatabaseValidation_ErrorPath(t *testing.T) {
 		{
 			name:     "memory dsn rejected",
 			dsn:      "memory://",
-			errorMsg: "bootstrap migrations only support PostgreSQL databases",
+			errorMsg: "bootstrap migrations only support PostgreSQL and SQLite databases",
 		},
 		{
 			name:     "empty dsn",
@@ -139,7 +139,7 @@ func TestCommand_DatabaseValidation_ErrorPath(t *testing.T) {
 		{
 			name:     "invalid dsn",
 			dsn:      "invalid://test",
-			errorMsg: "bootstrap migrations only support PostgreSQL databases",
+			errorMsg: "bootstrap migrations only support PostgreSQL and SQLite databases",
 		},
 	}
 
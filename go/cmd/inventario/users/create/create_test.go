@@ -162,7 +162,7 @@ func TestCommand_DatabaseValidation_ErrorPath(t *testing.T) {
 		{
 			name:     "memory dsn rejected",
 			dsn:      "memory://",
-			errorMsg: "bootstrap migrations only support PostgreSQL databases",
+			errorMsg: "bootstrap migrations only support PostgreSQL and SQLite databases",
 		},
 		{
 			name:     "empty dsn",
@@ -172,7 +172,7 @@ func TestCommand_DatabaseValidation_ErrorPath(t *testing.T) {
 		{
 			name:     "invalid dsn",
 			dsn:      "invalid://test",
-			errorMsg: "bootstrap migrations only support PostgreSQL databases",
+			errorMsg: "bootstrap migrations only support PostgreSQL and SQLite databases",
 		},
 	}
 
@@ -0,0 +1,8 @@
+package approve
+
+// Config holds configuration for the registrations approve command.
+type Config struct {
+	Reason string `yaml:"reason" env:"REASON"`
+	Force  bool   `yaml:"force" env:"FORCE" env-default:"false"`
+	DryRun bool   `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
@@ -0,0 +1,134 @@
+// Package list implements `inventario users registrations list`.
+package list
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// statusAll is the --status value that disables the status filter.
+const statusAll = "all"
+
+// Config carries the list command's flags.
+type Config struct {
+	Tenant string `yaml:"tenant" env:"TENANT"`
+	Status string `yaml:"status" env:"STATUS" env-default:"pending"`
+}
+
+// Command is the `users registrations list` cobra wrapper.
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New constructs the command with the supplied database config.
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{
+		config: Config{Status: string(models.RegistrationRequestStatusPending)},
+	}
+
+	shared.TryReadSection("users.registrations.list", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "list",
+		Short: "List registration requests",
+		Long: `List registration requests, oldest first.
+
+Only pending requests are listed by default. Use --status to show
+approved or rejected ones, or "all" for every status.
+
+Examples:
+  inventario users registrations list
+  inventario users registrations list --tenant acme
+  inventario users registrations list --status rejected`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.run(&c.config, dbConfig)
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	c.Cmd().Flags().StringVar(&c.config.Tenant, "tenant", c.config.Tenant, "Only list requests of this tenant (ID or slug)")
+	c.Cmd().Flags().StringVar(&c.config.Status, "status", c.config.Status, "Status to list: pending, approved, rejected, or all")
+}
+
+func (c *Command) run(cfg *Config, dbConfig *shared.DatabaseConfig) error {
+	out := c.Cmd().OutOrStdout()
+
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return errors.New("registration commands are not supported for memory databases: the queue lives in the server's database; use PostgreSQL")
+	}
+	if err := dbConfig.Validate(); err != nil {
+		return errxtrace.Wrap("database configuration error", err)
+	}
+
+	status, err := parseStatus(cfg.Status)
+	if err != nil {
+		return err
+	}
+
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	requests, err := adminService.ListRegistrations(c.Cmd().Context(), cfg.Tenant, status)
+	if err != nil {
+		return errxtrace.Wrap("failed to list registration requests", err)
+	}
+
+	if len(requests) == 0 {
+		fmt.Fprintln(out, "No registration requests found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "ID\tTENANT\tEMAIL\tNAME\tSTATUS\tCREATED_AT\tREASON")
+	for _, req := range requests {
+		reason := "-"
+		if req.Reason != nil && *req.Reason != "" {
+			reason = *req.Reason
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			req.ID, req.TenantID, req.Email, req.Name, req.Status,
+			req.CreatedAt.Format("2006-01-02 15:04:05"), reason)
+	}
+	return nil
+}
+
+// parseStatus maps the --status flag to a filter value; "all" (and an
+// empty value) disables the filter.
+func parseStatus(raw string) (models.RegistrationRequestStatus, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" || raw == statusAll {
+		return "", nil
+	}
+	status := models.RegistrationRequestStatus(raw)
+	if err := status.Validate(); err != nil {
+		return "", fmt.Errorf("invalid --status %q: must be pending, approved, rejected, or all", raw)
+	}
+	return status, nil
+}
@@ -0,0 +1,62 @@
+// Package registrations is the CLI command group for the registration
+// approval queue: tenants in RegistrationModeApproval park every
+// self-service sign-up as an inactive user plus a pending request, and an
+// operator approves (activating the user) or rejects it.
+//
+// It is the CLI twin of the back-office endpoints under
+// /api/v1/admin/registrations and writes the same audit rows. The CLI has
+// no mail transport, so decisions taken here do NOT email the applicant.
+//
+// All operations require a PostgreSQL DSN — the in-memory backend is not
+// shared with the server process that queued the requests.
+package registrations
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/cmd/inventario/users/registrations/approve"
+	"github.com/denisvmedia/inventario/cmd/inventario/users/registrations/list"
+	"github.com/denisvmedia/inventario/cmd/inventario/users/registrations/reject"
+)
+
+// New creates the `users registrations` command group and registers its
+// subcommands.
+func New(dbConfig *shared.DatabaseConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registrations",
+		Short: "Review registrations waiting for approval",
+		Long: `List, approve, and reject self-service registrations waiting in the
+approval queue of tenants that run in "approval" registration mode.
+
+Approving a request activates the user; rejecting it keeps the user
+inactive and records the reason. Both decisions are audit-logged.
+
+NOTE: the CLI has no mail transport, so the applicant is NOT emailed
+about a decision taken here. Decide from the back office to have the
+decision mailed, or let the applicant know directly.
+
+IMPORTANT: These commands ONLY support PostgreSQL databases.
+
+USAGE EXAMPLES:
+
+  List pending registrations:
+    inventario users registrations list
+
+  List every request of one tenant:
+    inventario users registrations list --tenant acme --status all
+
+  Approve a request:
+    inventario users registrations approve 550e8400-e29b-41d4-a716-446655440000
+
+  Reject a request:
+    inventario users registrations reject 550e8400-e29b-41d4-a716-446655440000 --reason "unknown applicant"`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(list.New(dbConfig).Cmd())
+	cmd.AddCommand(approve.New(dbConfig).Cmd())
+	cmd.AddCommand(reject.New(dbConfig).Cmd())
+
+	return cmd
+}
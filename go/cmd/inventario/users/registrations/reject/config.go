@@ -0,0 +1,8 @@
+package reject
+
+// Config holds configuration for the registrations reject command.
+type Config struct {
+	Reason string `yaml:"reason" env:"REASON"`
+	Force  bool   `yaml:"force" env:"FORCE" env-default:"false"`
+	DryRun bool   `yaml:"dry_run" env:"DRY_RUN" env-default:"false"`
+}
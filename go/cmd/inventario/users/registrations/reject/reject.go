@@ -0,0 +1,135 @@
+// Package reject implements `inventario users registrations reject`.
+package reject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/denisvmedia/inventario/cmd/internal/command"
+	"github.com/denisvmedia/inventario/cmd/inventario/shared"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/services/admin"
+)
+
+// Command is the `users registrations reject` cobra wrapper.
+type Command struct {
+	command.Base
+
+	config Config
+}
+
+// New constructs the command with the supplied database config.
+func New(dbConfig *shared.DatabaseConfig) *Command {
+	c := &Command{}
+
+	shared.TryReadSection("users.registrations.reject", &c.config)
+
+	c.Base = command.NewBase(&cobra.Command{
+		Use:   "reject <request-id>",
+		Short: "Reject a pending registration",
+		Long: `Reject a pending registration request. --reason is required and is
+recorded with the decision; the user stays inactive. The decision is
+audit-logged.
+
+A rejected user keeps its email address. Delete the user
+("inventario users delete") to let the address register again.
+
+The applicant is NOT emailed: the CLI has no mail transport.
+
+Examples:
+  inventario users registrations reject 550e8400-e29b-41d4-a716-446655440000 --reason "unknown applicant"
+  inventario users registrations reject 550e8400-e29b-41d4-a716-446655440000 --reason "duplicate" --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return c.run(&c.config, dbConfig, args[0])
+		},
+	})
+
+	c.registerFlags()
+
+	return c
+}
+
+func (c *Command) registerFlags() {
+	shared.RegisterDryRunFlag(c.Cmd(), &c.config.DryRun)
+	c.Cmd().Flags().BoolVar(&c.config.Force, "force", c.config.Force, "Skip confirmation prompts")
+	// `--reason` is required but enforced inside run(), like `workers pause
+	// --type`, so the message is the same however the command is invoked.
+	c.Cmd().Flags().StringVar(&c.config.Reason, "reason", c.config.Reason, "Reason for the rejection (required)")
+}
+
+func (c *Command) run(cfg *Config, dbConfig *shared.DatabaseConfig, id string) error {
+	out := c.Cmd().OutOrStdout()
+	ctx := c.Cmd().Context()
+
+	if strings.HasPrefix(dbConfig.DBDSN, "memory://") {
+		return errors.New("registration commands are not supported for memory databases: the queue lives in the server's database; use PostgreSQL")
+	}
+	if err := dbConfig.Validate(); err != nil {
+		return errxtrace.Wrap("database configuration error", err)
+	}
+	if strings.TrimSpace(cfg.Reason) == "" {
+		return errors.New("--reason is required to reject a registration")
+	}
+
+	adminService, err := admin.NewService(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := adminService.Close(); closeErr != nil {
+			fmt.Fprintf(out, "Warning: failed to close admin service: %v\n", closeErr)
+		}
+	}()
+
+	req, err := adminService.GetRegistration(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !req.IsPending() {
+		return fmt.Errorf("registration request %s was already %s", req.ID, req.Status)
+	}
+
+	fmt.Fprintf(out, "Request: %s\n", req.ID)
+	fmt.Fprintf(out, "Applicant: %s (%s)\n", req.Name, req.Email)
+	fmt.Fprintf(out, "Tenant: %s\n", req.TenantID)
+	fmt.Fprintf(out, "Submitted: %s\n", req.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(out, "Reason: %s\n", cfg.Reason)
+	fmt.Fprintln(out)
+
+	if cfg.DryRun {
+		fmt.Fprintln(out, "💡 This is a dry run. To reject the registration, run the command without --dry-run")
+		return nil
+	}
+
+	if !cfg.Force && !c.confirm(req) {
+		fmt.Fprintln(out, "Rejection cancelled.")
+		return nil
+	}
+
+	if _, err := adminService.RejectRegistration(ctx, id, cfg.Reason); err != nil {
+		if errors.Is(err, admin.ErrRegistrationAlreadyDecided) {
+			return fmt.Errorf("registration request %s was decided by someone else in the meantime", id)
+		}
+		return errxtrace.Wrap("failed to reject registration", err)
+	}
+
+	fmt.Fprintf(out, "✅ Registration rejected: %s (%s).\n", req.Name, req.Email)
+	fmt.Fprintln(out, "ℹ️  The applicant was not emailed (the CLI has no mail transport); let them know directly.")
+	return nil
+}
+
+func (c *Command) confirm(req *models.RegistrationRequest) bool {
+	out := c.Cmd().OutOrStdout()
+
+	fmt.Fprintf(out, "⚠️  Reject the registration of '%s' (%s)? [y/N]: ", req.Name, req.Email)
+	var response string
+	fmt.Scanln(&response)
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
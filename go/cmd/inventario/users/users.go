@@ -9,6 +9,7 @@ import (
 	"github.com/denisvmedia/inventario/cmd/inventario/users/get"
 	"github.com/denisvmedia/inventario/cmd/inventario/users/list"
 	"github.com/denisvmedia/inventario/cmd/inventario/users/mfareset"
+	"github.com/denisvmedia/inventario/cmd/inventario/users/registrations"
 	"github.com/denisvmedia/inventario/cmd/inventario/users/update"
 )
 
@@ -39,6 +40,9 @@ USAGE EXAMPLES:
   Preview user creation:
     inventario users create --dry-run --email="test@example.com" --tenant="acme"
 
+  Review registrations waiting for approval:
+    inventario users registrations list
+
 CONFIGURATION:
 
   The command reads database configuration from:
@@ -57,6 +61,7 @@ CONFIGURATION:
 	cmd.AddCommand(get.New(dbConfig).Cmd())
 	cmd.AddCommand(list.New(dbConfig).Cmd())
 	cmd.AddCommand(mfareset.New(dbConfig).Cmd())
+	cmd.AddCommand(registrations.New(dbConfig))
 	cmd.AddCommand(update.New(dbConfig).Cmd())
 
 	return cmd
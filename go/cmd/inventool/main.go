@@ -1,11 +1,13 @@
 package main
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 
 	"github.com/denisvmedia/inventario/cmd/inventario/shared"
 	"github.com/denisvmedia/inventario/registry/postgres"
+	"github.com/denisvmedia/inventario/registry/sqlite"
 )
 
 // @title Inventario API
@@ -23,10 +25,11 @@ import (
 func registerDBBackends() (cleanup func() error) {
 	// Register backends with the traditional registry system
 	postgresCleanup := postgres.Register()
+	sqliteCleanup := sqlite.Register()
 
 	// Combine cleanup functions
 	cleanup = func() error {
-		return postgresCleanup()
+		return errors.Join(postgresCleanup(), sqliteCleanup())
 	}
 
 	return cleanup
@@ -276,6 +276,9 @@ func cleanupTestData(c *qt.C, db *sqlx.DB) {
 		"warranty_reminders",
 		"currency_migration_audit_rows",
 		"maintenance_reminders",
+		"maintenance_logs",
+		"commodity_meter_readings",
+		"invoice_extractions",
 		"commodity_supply_links",
 		"restore_steps",
 		"thumbnail_generation_jobs",
@@ -284,11 +287,13 @@ func cleanupTestData(c *qt.C, db *sqlx.DB) {
 		"group_invites_audit",
 		"group_notification_prefs",
 		"storage_quota_reminders",
+		"saved_views",
 		"tags",
 		"login_events",
 		"email_verifications",
 		"password_resets",
 		"refresh_tokens",
+		"calendar_feeds",
 		"user_mfa_secrets",
 		"operation_slots",
 		"settings",
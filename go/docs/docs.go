@@ -54,6 +54,170 @@ const docTemplate = `{
                 }
             }
         },
+        "/admin/backup-keys": {
+            "get": {
+                "description": "Returns every key in the backup-signing keyring, revoked and expired ones included, in the order they were added. ` + "`" + `active` + "`" + ` is false for a revoked or expired key. The server's own signing key is always accepted and is not listed. Resource ` + "`" + `type` + "`" + ` is \"trusted_backup_key\"; ` + "`" + `id` + "`" + ` is the key fingerprint.",
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List trusted backup-signing keys (admin)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.TrustedBackupKeyListEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds an Ed25519 public key (PEM, base64 or hex) to the backup-signing keyring. Imports and restores then accept ` + "`" + `.inb` + "`" + ` archives signed with it, e.g. from a sibling instance or from before a key rotation. Platform admins only.\nAn unparseable key, a missing label or a past ` + "`" + `expires_at` + "`" + ` returns 422 with ` + "`" + `admin.backup_key.invalid` + "`" + `; a key already in the keyring (even revoked) returns 409 with ` + "`" + `admin.backup_key.already_trusted` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Trust a backup-signing key (admin)",
+                "parameters": [
+                    {
+                        "description": "Key to trust",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.BackupKeyAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.TrustedBackupKeyEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - platform admin required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - key already in the keyring",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - invalid key, label or expiry",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/backup-keys/{fingerprint}/revoke": {
+            "post": {
+                "description": "Stops accepting archives signed with the key. The key stays in the keyring as a record; revoking it again is a no-op that keeps the original revocation. Platform admins only. An unknown fingerprint returns 404 with ` + "`" + `admin.backup_key.not_found` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Revoke a trusted backup-signing key (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key fingerprint (hex SHA-256 of the public key)",
+                        "name": "fingerprint",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional revoke request (reason)",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.BackupKeyRevokeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.TrustedBackupKeyEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - platform admin required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - unknown fingerprint",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - reason too long",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
         "/admin/debug": {
             "get": {
                 "description": "get debug information about file storage, database driver, and operating system (back-office only)",
@@ -508,6 +672,116 @@ const docTemplate = `{
                 }
             }
         },
+        "/admin/groups/{groupID}/scan-budget": {
+            "put": {
+                "description": "Creates or replaces the monthly AI vision spend cap (USD) of one location group. It applies on top of the tenant budget: a scan in the group must fit both. Platform admins only. A missing or negative limit returns 422 with ` + "`" + `admin.scan_budget.invalid` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set a group AI vision budget (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Budget",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.ScanBudgetSetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.ScanBudgetEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - platform admin required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - unknown group",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the monthly AI vision spend cap of one location group; the tenant budget stays. Platform admins only. Returns 404 with ` + "`" + `admin.scan_budget.not_found` + "`" + ` when none is set.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a group AI vision budget (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - platform admin required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - unknown group or no budget set",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
         "/admin/impersonation/current": {
             "get": {
                 "description": "Convenience read for the FE impersonation banner. Returns ` + "`" + `active=false` + "`" + ` with no other fields when the\ncaller is not inside an impersonation session, and the target/operator/started_at/expires_at quartet when\nit is. Reachable from EITHER the operator's back-office session OR the impersonated tenant session.",
@@ -572,53 +846,105 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/tenants": {
+        "/admin/registrations": {
             "get": {
-                "description": "Returns every tenant with computed user_count and group_count. Pagination via ?page\u0026per_page; ?q matches name/slug/domain (ILIKE); ?sort=\u003cfield\u003e with optional ` + "`" + `-` + "`" + ` prefix for desc, or explicit ?order=asc|desc.",
+                "description": "Returns the self-service registrations of tenants in approval mode, oldest first. ` + "`" + `status` + "`" + ` is pending (default), approved, rejected or all; anything else returns 422 with ` + "`" + `admin.registration.invalid_status` + "`" + `. ` + "`" + `tenant_id` + "`" + ` narrows the list to one tenant. Resource ` + "`" + `type` + "`" + ` is \"registration_request\".",
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "List tenants (admin)",
+                "summary": "List registration requests (admin)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (default 50, max 100)",
-                        "name": "per_page",
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status filter",
+                        "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search term — ILIKE match on name/slug/domain",
-                        "name": "q",
+                        "description": "Tenant ID",
+                        "name": "tenant_id",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.RegistrationRequestListEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
                     },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/{requestID}/approve": {
+            "post": {
+                "description": "Approves a pending registration: the user is activated and told by email. The optional ` + "`" + `reason` + "`" + ` is included in the email. Returns 409 with ` + "`" + `admin.registration.already_decided` + "`" + ` when the request was decided before, and 422 with ` + "`" + `admin.registration.reason_too_long` + "`" + ` for a reason over 500 characters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Approve a registration (admin)",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Sort field: name|slug|created_at|status (prefix with - for desc)",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "Registration request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Sort direction override: asc|desc (wins over ` + "`" + `-` + "`" + ` prefix)",
-                        "name": "order",
-                        "in": "query"
+                        "description": "Decision",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.AdminRegistrationDecisionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.AdminTenantsResponse"
+                            "$ref": "#/definitions/apiserver.RegistrationRequestEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "401": {
@@ -632,34 +958,70 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found - unknown request",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - already decided",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - reason too long",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
                     }
                 }
             }
         },
-        "/admin/tenants/{tenantID}": {
-            "get": {
-                "description": "Returns the tenant row with computed user_count and group_count. No nested users or groups list — those live behind GET /admin/tenants/{tenantID}/users (#1746).",
+        "/admin/registrations/{requestID}/reject": {
+            "post": {
+                "description": "Rejects a pending registration: the user stays inactive and is sent the ` + "`" + `reason` + "`" + ` by email. Returns 409 with ` + "`" + `admin.registration.already_decided` + "`" + ` when the request was decided before, and 422 with ` + "`" + `admin.registration.reason_required` + "`" + ` (missing or blank reason) or ` + "`" + `admin.registration.reason_too_long` + "`" + ` (over 500 characters).",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get tenant (admin)",
+                "summary": "Reject a registration (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantID",
+                        "description": "Registration request ID",
+                        "name": "requestID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Decision",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.AdminRegistrationDecisionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.AdminTenantResponse"
+                            "$ref": "#/definitions/apiserver.RegistrationRequestEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "401": {
@@ -675,7 +1037,19 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Tenant not found",
+                        "description": "Not Found - unknown request",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - already decided",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - reason missing or too long",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -683,24 +1057,95 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/tenants/{tenantID}/users": {
+        "/admin/scan-budgets": {
             "get": {
-                "description": "Returns users in the target tenant with computed group_membership_count. Tri-state ?is_active. Pagination via ?page\u0026per_page; ?q matches email/name (ILIKE); ?sort=\u003cfield\u003e with ` + "`" + `-` + "`" + ` prefix or ?order=asc|desc.",
+                "description": "Returns every tenant and group AI vision budget with ` + "`" + `spent_usd` + "`" + `, the current calendar month's (UTC) spend it is checked against. A tenant-wide budget has no ` + "`" + `group_id` + "`" + `. Resource ` + "`" + `type` + "`" + ` is \"commodity_scan_budget\".",
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "List users for a tenant (admin)",
+                "summary": "List AI vision budgets (admin)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.ScanBudgetListEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/scan-usage": {
+            "get": {
+                "description": "Aggregates the AI vision provider calls of one calendar month (UTC) per tenant, group, provider and model: call count, tokens and cost in USD from the configured price table. A call answered by a fallback provider is reported under that provider. ` + "`" + `month` + "`" + ` is YYYY-MM and defaults to the current month; anything else returns 422 with ` + "`" + `admin.scan_usage.invalid_month` + "`" + `.",
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "AI vision usage report (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantID",
-                        "in": "path",
-                        "required": true
+                        "description": "Report month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.ScanUsageEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity - invalid month",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tenants": {
+            "get": {
+                "description": "Returns every tenant with computed user_count and group_count. Pagination via ?page\u0026per_page; ?q matches name/slug/domain (ILIKE); ?sort=\u003cfield\u003e with optional ` + "`" + `-` + "`" + ` prefix for desc, or explicit ?order=asc|desc.",
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List tenants (admin)",
+                "parameters": [
                     {
                         "type": "integer",
                         "description": "Page number (default 1)",
@@ -715,19 +1160,13 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search term — ILIKE match on email/name",
+                        "description": "Search term — ILIKE match on name/slug/domain",
                         "name": "q",
                         "in": "query"
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Tri-state active-flag filter: true (active only), false (inactive only), or omit the param entirely for no filter. Unknown values are ignored.",
-                        "name": "is_active",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Sort field: email|name|created_at|last_login_at|is_active (prefix with - for desc)",
+                        "description": "Sort field: name|slug|created_at|status (prefix with - for desc)",
                         "name": "sort",
                         "in": "query"
                     },
@@ -742,7 +1181,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.AdminUsersResponse"
+                            "$ref": "#/definitions/jsonapi.AdminTenantsResponse"
                         }
                     },
                     "401": {
@@ -756,31 +1195,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
-                    },
-                    "404": {
-                        "description": "Tenant not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
                     }
                 }
             }
         },
-        "/admin/users/{userID}": {
+        "/admin/tenants/{tenantID}": {
             "get": {
-                "description": "Returns the user detail across tenants: identity, is_active, last_login_at, group memberships (group_id, group_slug, group_name, role, joined_at), and active_session_count from unrevoked refresh tokens. No password hash.",
+                "description": "Returns the tenant row with computed user_count and group_count. No nested users or groups list — those live behind GET /admin/tenants/{tenantID}/users (#1746).",
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get user (admin)",
+                "summary": "Get tenant (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Tenant ID",
+                        "name": "tenantID",
                         "in": "path",
                         "required": true
                     }
@@ -789,7 +1222,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.AdminUserResponse"
+                            "$ref": "#/definitions/jsonapi.AdminTenantResponse"
                         }
                     },
                     "401": {
@@ -805,7 +1238,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Tenant not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -813,9 +1246,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{userID}/block": {
-            "post": {
-                "description": "Sets the user's ` + "`" + `is_active` + "`" + ` flag to false, revokes every refresh token, and bumps the JWT-blacklist staleness threshold so live access tokens are rejected on next use.\nReturns 422 with ` + "`" + `admin.block.self_blocked` + "`" + ` when the caller targets their own account, and 422 with ` + "`" + `admin.block.admin_requires_force` + "`" + ` when targeting another system admin without ` + "`" + `force=true` + "`" + `.\nBody-validation rejections surface as 422 with ` + "`" + `admin.block.reason_required` + "`" + ` (missing or blank ` + "`" + `reason` + "`" + `) or ` + "`" + `admin.block.reason_too_long` + "`" + ` (reason exceeds 500 characters).",
+        "/admin/tenants/{tenantID}/scan-budget": {
+            "put": {
+                "description": "Creates or replaces the monthly AI vision spend cap (USD) of the tenant. Once the calendar month's spend reaches it, scans return 429 ` + "`" + `commodity_scan.budget_exceeded` + "`" + `. Platform admins only. A missing or negative limit returns 422 with ` + "`" + `admin.scan_budget.invalid` + "`" + `.",
                 "consumes": [
                     "application/json"
                 ],
@@ -825,22 +1258,22 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Block (deactivate) a user account",
+                "summary": "Set a tenant AI vision budget (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Target user ID",
-                        "name": "userID",
+                        "description": "Tenant ID",
+                        "name": "tenantID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Block request",
+                        "description": "Budget",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apiserver.AdminBlockRequest"
+                            "$ref": "#/definitions/apiserver.ScanBudgetSetRequest"
                         }
                     }
                 ],
@@ -848,7 +1281,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apiserver.AdminUserEnvelope"
+                            "$ref": "#/definitions/apiserver.ScanBudgetEnvelope"
                         }
                     },
                     "400": {
@@ -864,44 +1297,284 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Account disabled",
+                        "description": "Forbidden - platform admin required",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "404": {
-                        "description": "Not Found - unknown user",
+                        "description": "Not Found - unknown tenant",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "422": {
-                        "description": "Unprocessable Entity - self-block or admin-on-admin without force",
+                        "description": "Unprocessable Entity - invalid limit",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            }
-        },
-        "/admin/users/{userID}/impersonate": {
-            "post": {
-                "description": "Issues a short-lived impersonation access token for the target tenant user on behalf of the calling back-office operator.\nThe token carries ` + "`" + `imp=true` + "`" + `, ` + "`" + `impersonator_id=\u003cbackoffice user id\u003e` + "`" + `, ` + "`" + `impersonator_type=backoffice_user` + "`" + `, and\n` + "`" + `is_system_admin=false` + "`" + `; it cannot be refreshed and cannot start a nested impersonation. The operator's own\n` + "`" + `backoffice_refresh_token` + "`" + ` cookie is captured server-side at start and restored by POST /admin/impersonation/end.\nReturns 422 with ` + "`" + `admin.impersonate.target_is_admin` + "`" + ` when the target is a system admin,\n` + "`" + `admin.impersonate.target_blocked` + "`" + ` when the target account is blocked, and ` + "`" + `admin.impersonate.nested` + "`" + `\nwhen the caller is already impersonating. Returns 429 with ` + "`" + `admin.impersonate.rate_limited` + "`" + ` when the\nper-operator start rate limit (10/hour) is exceeded.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Removes the tenant-wide monthly AI vision spend cap; group budgets stay. Platform admins only. Returns 404 with ` + "`" + `admin.scan_budget.not_found` + "`" + ` when none is set.",
                 "tags": [
                     "admin"
                 ],
-                "summary": "Start an impersonation session (back-office operator)",
+                "summary": "Remove a tenant AI vision budget (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Target tenant user ID",
-                        "name": "userID",
+                        "description": "Tenant ID",
+                        "name": "tenantID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - platform admin required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - no budget set",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tenants/{tenantID}/users": {
+            "get": {
+                "description": "Returns users in the target tenant with computed group_membership_count. Tri-state ?is_active. Pagination via ?page\u0026per_page; ?q matches email/name (ILIKE); ?sort=\u003cfield\u003e with ` + "`" + `-` + "`" + ` prefix or ?order=asc|desc.",
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List users for a tenant (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term — ILIKE match on email/name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Tri-state active-flag filter: true (active only), false (inactive only), or omit the param entirely for no filter. Unknown values are ignored.",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field: email|name|created_at|last_login_at|is_active (prefix with - for desc)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction override: asc|desc (wins over ` + "`" + `-` + "`" + ` prefix)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.AdminUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{userID}": {
+            "get": {
+                "description": "Returns the user detail across tenants: identity, is_active, last_login_at, group memberships (group_id, group_slug, group_name, role, joined_at), and active_session_count from unrevoked refresh tokens. No password hash.",
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get user (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.AdminUserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{userID}/block": {
+            "post": {
+                "description": "Sets the user's ` + "`" + `is_active` + "`" + ` flag to false, revokes every refresh token, and bumps the JWT-blacklist staleness threshold so live access tokens are rejected on next use.\nReturns 422 with ` + "`" + `admin.block.self_blocked` + "`" + ` when the caller targets their own account, and 422 with ` + "`" + `admin.block.admin_requires_force` + "`" + ` when targeting another system admin without ` + "`" + `force=true` + "`" + `.\nBody-validation rejections surface as 422 with ` + "`" + `admin.block.reason_required` + "`" + ` (missing or blank ` + "`" + `reason` + "`" + `) or ` + "`" + `admin.block.reason_too_long` + "`" + ` (reason exceeds 500 characters).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Block (deactivate) a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target user ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Block request",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.AdminBlockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.AdminUserEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - back-office authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - unknown user",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - self-block or admin-on-admin without force",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{userID}/impersonate": {
+            "post": {
+                "description": "Issues a short-lived impersonation access token for the target tenant user on behalf of the calling back-office operator.\nThe token carries ` + "`" + `imp=true` + "`" + `, ` + "`" + `impersonator_id=\u003cbackoffice user id\u003e` + "`" + `, ` + "`" + `impersonator_type=backoffice_user` + "`" + `, and\n` + "`" + `is_system_admin=false` + "`" + `; it cannot be refreshed and cannot start a nested impersonation. The operator's own\n` + "`" + `backoffice_refresh_token` + "`" + ` cookie is captured server-side at start and restored by POST /admin/impersonation/end.\nReturns 422 with ` + "`" + `admin.impersonate.target_is_admin` + "`" + ` when the target is a system admin,\n` + "`" + `admin.impersonate.target_blocked` + "`" + ` when the target account is blocked, and ` + "`" + `admin.impersonate.nested` + "`" + `\nwhen the caller is already impersonating. Returns 429 with ` + "`" + `admin.impersonate.rate_limited` + "`" + ` when the\nper-operator start rate limit (10/hour) is exceeded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Start an impersonation session (back-office operator)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target tenant user ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     },
@@ -2624,6 +3297,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.AreaResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity version, for If-Match on update and delete"
+                            }
                         }
                     }
                 }
@@ -2663,6 +3342,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/jsonapi.AreaRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the update is refused when the area has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2678,6 +3363,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
+                    "412": {
+                        "description": "Area changed since the If-Match ETag; body is the current area",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.AreaResponse"
+                        }
+                    },
                     "422": {
                         "description": "User-side request problem",
                         "schema": {
@@ -2722,6 +3413,12 @@ const docTemplate = `{
                         "description": "Non-empty-area strategy: cascade deletes the commodities, unlink keeps them area-less. Omit to reject a non-empty area.",
                         "name": "strategy",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the delete is refused when the area has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2734,10 +3431,138 @@ const docTemplate = `{
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "422": {
-                        "description": "Non-empty area (no strategy) or unknown strategy",
+                    "412": {
+                        "description": "Area changed since the If-Match ETag; body is the current area",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.AreaResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Non-empty area (no strategy) or unknown strategy",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/g/{groupSlug}/backup-schedule": {
+            "get": {
+                "description": "Get the group's automatic backup schedule, including the next run time and the outcome of the last run.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "backup_schedules"
+                ],
+                "summary": "Get the group backup schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.BackupScheduleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "The group has no backup schedule",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Configure automatic full-database backups for the group: frequency, UTC time of day, whether file data is included and the daily/weekly/monthly retention. Scheduled exports are pruned to the retention after each successful run; manual exports are never pruned.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "backup_schedules"
+                ],
+                "summary": "Create or replace the group backup schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Backup schedule attributes",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.BackupScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule replaced",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.BackupScheduleResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Schedule created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.BackupScheduleResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid schedule",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop automatic backups for the group. Existing scheduled exports are kept.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "backup_schedules"
+                ],
+                "summary": "Delete the group backup schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "The group has no backup schedule",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
@@ -2847,6 +3672,22 @@ const docTemplate = `{
                         "description": "Filter by current loan state: true = only currently lent (open loan), false = only currently not-lent",
                         "name": "lent_out",
                         "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by tag slug; repeat to OR",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Apply a saved view's filters; explicit query parameters override the view's",
+                        "name": "view_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2855,6 +3696,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/jsonapi.CommoditiesResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Saved view not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Saved view references a deleted area or tag (code saved_view.stale_reference)",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
                     }
                 }
             },
@@ -2998,6 +3851,56 @@ const docTemplate = `{
                 }
             }
         },
+        "/g/{groupSlug}/commodities/duplicates": {
+            "get": {
+                "description": "Returns pairs of commodities that look like duplicates of each\nother, strongest matches first. Same signals as\nGET /commodities/{commodityID}/duplicates.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "commodities"
+                ],
+                "summary": "List possible duplicate commodities in the group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Name similarity threshold in (0, 1] (default 0.5)",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of pairs (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CommodityDuplicatePairsResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid threshold or limit",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
         "/g/{groupSlug}/commodities/scan": {
             "post": {
                 "description": "Extract structured commodity field guesses from 1..N product photos or PDF documents. The handler does not persist any commodity — it only returns structured suggestions for the Add Item dialog to pre-fill.",
@@ -3059,7 +3962,7 @@ const docTemplate = `{
                         }
                     },
                     "429": {
-                        "description": "Rate limited",
+                        "description": "Rate limited / monthly AI budget exceeded",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -3151,6 +4054,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.CommodityResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity version, for If-Match on update and delete"
+                            }
                         }
                     }
                 }
@@ -3190,6 +4099,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/jsonapi.CommodityRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the update is refused when the commodity has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -3205,6 +4120,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
+                    "412": {
+                        "description": "Commodity changed since the If-Match ETag; body is the current commodity",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CommodityResponse"
+                        }
+                    },
                     "422": {
                         "description": "User-side request problem",
                         "schema": {
@@ -3239,6 +4160,12 @@ const docTemplate = `{
                         "name": "commodityID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the delete is refused when the commodity has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -3250,6 +4177,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
+                    },
+                    "412": {
+                        "description": "Commodity changed since the If-Match ETag; body is the current commodity",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CommodityResponse"
+                        }
                     }
                 }
             }
@@ -3290,6 +4223,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/jsonapi.CommodityCoverRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the change is refused when the commodity has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -3305,6 +4244,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
+                    "412": {
+                        "description": "Commodity changed since the If-Match ETag; body is the current commodity",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CommodityResponse"
+                        }
+                    },
                     "422": {
                         "description": "User-side request problem",
                         "schema": {
@@ -3314,6 +4259,63 @@ const docTemplate = `{
                 }
             }
         },
+        "/g/{groupSlug}/commodities/{commodityID}/duplicates": {
+            "get": {
+                "description": "Returns commodities that look like duplicates of the given one:\nsimilar name / short name (trigram similarity at or above\n` + "`" + `threshold` + "`" + `), a shared serial or part number, or the same\npurchase date and original price. Each row explains the match in ` + "`" + `meta` + "`" + `.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "commodities"
+                ],
+                "summary": "List possible duplicates of a commodity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Name similarity threshold in (0, 1] (default 0.5)",
+                        "name": "threshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CommodityDuplicatesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Commodity not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid threshold",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
         "/g/{groupSlug}/commodities/{commodityID}/events": {
             "get": {
                 "description": "Returns the append-only audit timeline for a commodity, newest first.",
@@ -3527,7 +4529,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Patch borrower name/contact/note and due_back_at. Sending due_back_at as JSON null clears it (open-ended loan); omitting the key leaves it unchanged.",
+                "description": "Patch borrower name/contact/email/note and due_back_at. Sending due_back_at as JSON null clears it (open-ended loan); omitting the key leaves it unchanged.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3592,9 +4594,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/return": {
+        "/g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/borrower-link": {
             "post": {
-                "description": "Close a loan. Defaults returned_at to today. 409 if already returned.",
+                "description": "Mint a signed, expiring link the borrower can use to confirm a return or request an extension without an account. 409 when the loan is closed.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3604,7 +4606,7 @@ const docTemplate = `{
                 "tags": [
                     "commodity_loans"
                 ],
-                "summary": "Mark a loan as returned",
+                "summary": "Create a borrower link",
                 "parameters": [
                     {
                         "type": "string",
@@ -3626,21 +4628,13 @@ const docTemplate = `{
                         "name": "loanID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Optional explicit returned_at",
-                        "name": "payload",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityLoanReturnRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityLoanResponse"
+                            "$ref": "#/definitions/jsonapi.LoanBorrowerLinkResponse"
                         }
                     },
                     "404": {
@@ -3658,9 +4652,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/maintenance": {
-            "get": {
-                "description": "All maintenance schedules for the commodity in the URL.",
+        "/g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/request/approve": {
+            "post": {
+                "description": "Accept the pending borrower request (return confirmation or extension). 409 when nothing is pending or the loan is closed.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3668,9 +4662,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "maintenance_schedules"
+                    "commodity_loans"
                 ],
-                "summary": "List maintenance schedules for a commodity",
+                "summary": "Approve a borrower request",
                 "parameters": [
                     {
                         "type": "string",
@@ -3685,19 +4679,40 @@ const docTemplate = `{
                         "name": "commodityID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "loanID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceSchedulesResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityLoanResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Loan not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "No pending request",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/request/reject": {
             "post": {
-                "description": "Create a new schedule for the commodity in the URL.",
+                "description": "Discard the pending borrower request. 409 when nothing is pending or the loan is closed.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3705,9 +4720,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "maintenance_schedules"
+                    "commodity_loans"
                 ],
-                "summary": "Create a maintenance schedule",
+                "summary": "Reject a borrower request",
                 "parameters": [
                     {
                         "type": "string",
@@ -3724,24 +4739,28 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Maintenance schedule attributes",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleRequest"
-                        }
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "loanID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Schedule created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityLoanResponse"
                         }
                     },
-                    "422": {
-                        "description": "User-side request problem",
+                    "404": {
+                        "description": "Loan not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "No pending request",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -3749,9 +4768,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/services": {
-            "get": {
-                "description": "All service rows (open + completed) for the commodity, most-recent-first.",
+        "/g/{groupSlug}/commodities/{commodityID}/loans/{loanID}/return": {
+            "post": {
+                "description": "Close a loan. Defaults returned_at to today. 409 if already returned.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3759,9 +4778,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_services"
+                    "commodity_loans"
                 ],
-                "summary": "List service rows for a commodity",
+                "summary": "Mark a loan as returned",
                 "parameters": [
                     {
                         "type": "string",
@@ -3776,69 +4795,38 @@ const docTemplate = `{
                         "name": "commodityID",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServicesResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Open a new service row. Returns 409 if one is already open or the commodity is currently lent out.",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
-                "produces": [
-                    "application/vnd.api+json"
-                ],
-                "tags": [
-                    "commodity_services"
-                ],
-                "summary": "Send a commodity for service",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
-                        "in": "path",
-                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Commodity ID",
-                        "name": "commodityID",
+                        "description": "Loan ID",
+                        "name": "loanID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Service attributes",
-                        "name": "service",
+                        "description": "Optional explicit returned_at",
+                        "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceRequest"
+                            "$ref": "#/definitions/jsonapi.CommodityLoanReturnRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Service created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityLoanResponse"
                         }
                     },
-                    "409": {
-                        "description": "Commodity already has an open holding",
+                    "404": {
+                        "description": "Loan not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "422": {
-                        "description": "User-side request problem",
+                    "409": {
+                        "description": "Loan already returned",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -3846,9 +4834,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/services/{serviceID}": {
-            "delete": {
-                "description": "Hard-delete a service row.",
+        "/g/{groupSlug}/commodities/{commodityID}/maintenance": {
+            "get": {
+                "description": "All maintenance schedules for the commodity in the URL.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3856,9 +4844,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_services"
+                    "maintenance_schedules"
                 ],
-                "summary": "Delete a service row",
+                "summary": "List maintenance schedules for a commodity",
                 "parameters": [
                     {
                         "type": "string",
@@ -3873,29 +4861,19 @@ const docTemplate = `{
                         "name": "commodityID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Service ID",
-                        "name": "serviceID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "404": {
-                        "description": "Service not found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.MaintenanceSchedulesResponse"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "Patch provider contact / reason / expected return / cost.",
+            "post": {
+                "description": "Create a new schedule for the commodity in the URL.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3903,9 +4881,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_services"
+                    "maintenance_schedules"
                 ],
-                "summary": "Update a service row",
+                "summary": "Create a maintenance schedule",
                 "parameters": [
                     {
                         "type": "string",
@@ -3922,33 +4900,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Service ID",
-                        "name": "serviceID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Service patch payload",
-                        "name": "service",
+                        "description": "Maintenance schedule attributes",
+                        "name": "schedule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceUpdateRequest"
+                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Service not found",
+                    "201": {
+                        "description": "Schedule created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleResponse"
                         }
                     },
                     "422": {
@@ -3960,9 +4925,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/services/{serviceID}/return": {
+        "/g/{groupSlug}/commodities/{commodityID}/merge": {
             "post": {
-                "description": "Close a service row. Defaults returned_at to today. 409 if already returned.",
+                "description": "Moves the duplicate's files, tags, event history, loans, service\nrecords, supply links and maintenance schedules onto the commodity\nin the path, then deletes the duplicate. The surviving commodity's\nown fields are kept; it only adopts the duplicate's cover photo\nwhen it has none.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -3970,9 +4935,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_services"
+                    "commodities"
                 ],
-                "summary": "Mark a service as returned",
+                "summary": "Merge a duplicate into a commodity",
                 "parameters": [
                     {
                         "type": "string",
@@ -3983,42 +4948,42 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Commodity ID",
+                        "description": "Surviving commodity ID",
                         "name": "commodityID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Service ID",
-                        "name": "serviceID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Optional explicit returned_at and final cost",
-                        "name": "payload",
+                        "description": "Duplicate to merge",
+                        "name": "body",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceReturnRequest"
+                            "$ref": "#/definitions/jsonapi.CommodityMergeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Merged",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityMergeResponse"
                         }
                     },
                     "404": {
-                        "description": "Service not found",
+                        "description": "Commodity not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "409": {
-                        "description": "Service already returned",
+                        "description": "Both commodities have an open loan or service",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4026,9 +4991,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/supplies": {
+        "/g/{groupSlug}/commodities/{commodityID}/meter-readings": {
             "get": {
-                "description": "All supply links for the commodity in the URL, sorted by sort_order ASC.",
+                "description": "Usage meter history of the commodity in the URL, oldest first.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4036,9 +5001,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_supply_links"
+                    "commodity_meter_readings"
                 ],
-                "summary": "List supply links for a commodity",
+                "summary": "List meter readings for a commodity",
                 "parameters": [
                     {
                         "type": "string",
@@ -4053,19 +5018,37 @@ const docTemplate = `{
                         "name": "commodityID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "hours",
+                            "km",
+                            "mi",
+                            "cycles"
+                        ],
+                        "type": "string",
+                        "description": "Restrict to one meter unit",
+                        "name": "unit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.SupplyLinksResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityMeterReadingsResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown unit",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Attach a new supply link to the commodity. commodity_id is taken from the URL.",
+                "description": "Record a usage meter reading (hours, km, mi, cycles) for the commodity in the URL.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4073,9 +5056,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_supply_links"
+                    "commodity_meter_readings"
                 ],
-                "summary": "Create a supply link",
+                "summary": "Record a meter reading",
                 "parameters": [
                     {
                         "type": "string",
@@ -4092,26 +5075,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Supply link attributes",
-                        "name": "link",
+                        "description": "Meter reading attributes",
+                        "name": "reading",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.SupplyLinkRequest"
+                            "$ref": "#/definitions/jsonapi.CommodityMeterReadingRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.SupplyLinkResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Commodity not found",
+                        "description": "Reading recorded",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.CommodityMeterReadingResponse"
                         }
                     },
                     "422": {
@@ -4123,9 +5100,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/supplies/reorder": {
-            "post": {
-                "description": "Renumber sort_order for the commodity's supply links per the supplied id list.",
+        "/g/{groupSlug}/commodities/{commodityID}/meter-readings/{readingID}": {
+            "delete": {
+                "description": "Hard-delete a meter reading; usage schedules are re-estimated.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4133,9 +5110,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_supply_links"
+                    "commodity_meter_readings"
                 ],
-                "summary": "Reorder supply links",
+                "summary": "Delete a meter reading",
                 "parameters": [
                     {
                         "type": "string",
@@ -4152,30 +5129,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Ordered supply link ids",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.SupplyLinkReorderRequest"
-                        }
+                        "type": "string",
+                        "description": "Meter reading ID",
+                        "name": "readingID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated list, sort_order applied",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.SupplyLinksResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "404": {
-                        "description": "Commodity or supply link not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "422": {
-                        "description": "User-side request problem",
+                        "description": "Reading not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4183,9 +5149,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/commodities/{commodityID}/supplies/{supplyID}": {
-            "delete": {
-                "description": "Hard-delete a supply link.",
+        "/g/{groupSlug}/commodities/{commodityID}/services": {
+            "get": {
+                "description": "All service rows (open + completed) for the commodity, most-recent-first.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4193,9 +5159,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_supply_links"
+                    "commodity_services"
                 ],
-                "summary": "Delete a supply link",
+                "summary": "List service rows for a commodity",
                 "parameters": [
                     {
                         "type": "string",
@@ -4210,29 +5176,19 @@ const docTemplate = `{
                         "name": "commodityID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Supply link ID",
-                        "name": "supplyID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "404": {
-                        "description": "Supply link not found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.CommodityServicesResponse"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "Patch label/url/notes on a supply link. Omitting a key leaves it unchanged.",
+            "post": {
+                "description": "Open a new service row. Returns 409 if one is already open or the commodity is currently lent out.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4240,9 +5196,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_supply_links"
+                    "commodity_services"
                 ],
-                "summary": "Update a supply link",
+                "summary": "Send a commodity for service",
                 "parameters": [
                     {
                         "type": "string",
@@ -4259,31 +5215,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Supply link ID",
-                        "name": "supplyID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Supply link patch",
-                        "name": "link",
+                        "description": "Service attributes",
+                        "name": "service",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.SupplyLinkUpdateRequest"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Service created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.SupplyLinkResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceResponse"
                         }
                     },
-                    "404": {
-                        "description": "Supply link not found",
+                    "409": {
+                        "description": "Commodity already has an open holding",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4297,9 +5246,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/currency-migrations": {
-            "get": {
-                "description": "Returns the group's full currency-migration history newest-first.",
+        "/g/{groupSlug}/commodities/{commodityID}/services/{serviceID}": {
+            "delete": {
+                "description": "Hard-delete a service row.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4307,9 +5256,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "currency-migrations"
+                    "commodity_services"
                 ],
-                "summary": "List currency migrations for a group",
+                "summary": "Delete a service row",
                 "parameters": [
                     {
                         "type": "string",
@@ -4317,25 +5266,36 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "serviceID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.CurrencyMigrationsResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "403": {
-                        "description": "Forbidden — non-admin",
+                    "404": {
+                        "description": "Service not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Verify preview token, perform cross-op + daily-cap checks, insert a pending migration row.",
+            "patch": {
+                "description": "Patch provider contact / reason / expected return / cost.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4343,9 +5303,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "currency-migrations"
+                    "commodity_services"
                 ],
-                "summary": "Start a currency migration",
+                "summary": "Update a service row",
                 "parameters": [
                     {
                         "type": "string",
@@ -4355,42 +5315,44 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Start request",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "serviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service patch payload",
+                        "name": "service",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CurrencyMigrationStartRequest"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.CurrencyMigrationResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden — non-admin",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceResponse"
                         }
                     },
-                    "409": {
-                        "description": "Token expired / state changed / migration in progress / restore in progress",
+                    "404": {
+                        "description": "Service not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "422": {
-                        "description": "Validation / token invalid",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "429": {
-                        "description": "Daily cap reached",
+                        "description": "User-side request problem",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4398,9 +5360,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/currency-migrations/preview": {
+        "/g/{groupSlug}/commodities/{commodityID}/services/{serviceID}/return": {
             "post": {
-                "description": "Dry-run the conversion, returning projected totals + per-row diffs + a signed preview_token.",
+                "description": "Close a service row. Defaults returned_at to today. 409 if already returned.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4408,9 +5370,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "currency-migrations"
+                    "commodity_services"
                 ],
-                "summary": "Preview a currency migration",
+                "summary": "Mark a service as returned",
                 "parameters": [
                     {
                         "type": "string",
@@ -4420,12 +5382,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Preview request",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "serviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional explicit returned_at and final cost",
+                        "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewRequest"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceReturnRequest"
                         }
                     }
                 ],
@@ -4433,17 +5408,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden — non-admin",
+                    "404": {
+                        "description": "Service not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "409": {
+                        "description": "Service already returned",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4451,9 +5426,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/currency-migrations/{id}": {
+        "/g/{groupSlug}/commodities/{commodityID}/supplies": {
             "get": {
-                "description": "Returns one currency-migration row by id.",
+                "description": "All supply links for the commodity in the URL, sorted by sort_order ASC.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4461,9 +5436,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "currency-migrations"
+                    "commodity_supply_links"
                 ],
-                "summary": "Get a currency migration",
+                "summary": "List supply links for a commodity",
                 "parameters": [
                     {
                         "type": "string",
@@ -4474,8 +5449,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Currency migration ID",
-                        "name": "id",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
                         "in": "path",
                         "required": true
                     }
@@ -4484,27 +5459,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CurrencyMigrationResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden — non-admin",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.SupplyLinksResponse"
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/exports": {
-            "get": {
-                "description": "get exports",
+            },
+            "post": {
+                "description": "Attach a new supply link to the commodity. commodity_id is taken from the URL.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4512,9 +5473,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "commodity_supply_links"
                 ],
-                "summary": "List exports",
+                "summary": "Create a supply link",
                 "parameters": [
                     {
                         "type": "string",
@@ -4523,49 +5484,20 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Include deleted exports",
-                        "name": "include_deleted",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.ExportsResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "create a new export",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
-                "produces": [
-                    "application/vnd.api+json"
-                ],
-                "tags": [
-                    "exports"
-                ],
-                "summary": "Create an export",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Export",
-                        "name": "export",
+                        "description": "Supply link attributes",
+                        "name": "link",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.ExportCreateRequest"
+                            "$ref": "#/definitions/jsonapi.SupplyLinkRequest"
                         }
                     }
                 ],
@@ -4573,11 +5505,17 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.ExportResponse"
+                            "$ref": "#/definitions/jsonapi.SupplyLinkResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Commodity not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "422": {
-                        "description": "Unprocessable Entity",
+                        "description": "User-side request problem",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4585,9 +5523,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/exports/import": {
+        "/g/{groupSlug}/commodities/{commodityID}/supplies/reorder": {
             "post": {
-                "description": "Import an uploaded signed ` + "`" + `.inb` + "`" + ` backup file and create an export record",
+                "description": "Renumber sort_order for the commodity's supply links per the supplied id list.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4595,9 +5533,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "commodity_supply_links"
                 ],
-                "summary": "Import backup archive",
+                "summary": "Reorder supply links",
                 "parameters": [
                     {
                         "type": "string",
@@ -4607,30 +5545,37 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Import request data",
-                        "name": "data",
+                        "type": "string",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ordered supply link ids",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.ImportExportRequest"
+                            "$ref": "#/definitions/jsonapi.SupplyLinkReorderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Updated list, sort_order applied",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.ExportResponse"
+                            "$ref": "#/definitions/jsonapi.SupplyLinksResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Commodity or supply link not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "422": {
-                        "description": "Unprocessable Entity",
+                        "description": "User-side request problem",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4638,9 +5583,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/exports/{id}": {
-            "get": {
-                "description": "get export by ID",
+        "/g/{groupSlug}/commodities/{commodityID}/supplies/{supplyID}": {
+            "delete": {
+                "description": "Hard-delete a supply link.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4648,9 +5593,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "commodity_supply_links"
                 ],
-                "summary": "Get an export",
+                "summary": "Delete a supply link",
                 "parameters": [
                     {
                         "type": "string",
@@ -4661,29 +5606,33 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Export ID",
-                        "name": "id",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Supply link ID",
+                        "name": "supplyID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.ExportResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Supply link not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "delete an export",
+            "patch": {
+                "description": "Patch label/url/notes on a supply link. Omitting a key leaves it unchanged.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4691,9 +5640,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "commodity_supply_links"
                 ],
-                "summary": "Delete an export",
+                "summary": "Update a supply link",
                 "parameters": [
                     {
                         "type": "string",
@@ -4704,63 +5653,43 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Export ID",
-                        "name": "id",
+                        "description": "Commodity ID",
+                        "name": "commodityID",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    }
-                }
-            }
-        },
-        "/g/{groupSlug}/exports/{id}/download": {
-            "get": {
-                "description": "Download an export XML file",
-                "consumes": [
-                    "application/octet-stream"
-                ],
-                "produces": [
-                    "application/octet-stream"
-                ],
-                "tags": [
-                    "exports"
-                ],
-                "summary": "Download an export file",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
+                        "description": "Supply link ID",
+                        "name": "supplyID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Export ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Supply link patch",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.SupplyLinkUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/jsonapi.SupplyLinkResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Supply link not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "User-side request problem",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4768,9 +5697,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/exports/{id}/restores": {
+        "/g/{groupSlug}/currency-migrations": {
             "get": {
-                "description": "get restore operations for an export",
+                "description": "Returns the group's full currency-migration history newest-first.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4778,9 +5707,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "currency-migrations"
                 ],
-                "summary": "List export restore operations",
+                "summary": "List currency migrations for a group",
                 "parameters": [
                     {
                         "type": "string",
@@ -4788,26 +5717,25 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Export ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.RestoreOperationsResponse"
+                            "$ref": "#/definitions/jsonapi.CurrencyMigrationsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden — non-admin",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
             "post": {
-                "description": "create a new restore operation for an export",
+                "description": "Verify preview token, perform cross-op + daily-cap checks, insert a pending migration row.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4815,9 +5743,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "currency-migrations"
                 ],
-                "summary": "Create export restore operation",
+                "summary": "Start a currency migration",
                 "parameters": [
                     {
                         "type": "string",
@@ -4827,19 +5755,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Export ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Restore operation data",
+                        "description": "Start request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.RestoreOperationCreateRequest"
+                            "$ref": "#/definitions/jsonapi.CurrencyMigrationStartRequest"
                         }
                     }
                 ],
@@ -4847,17 +5768,29 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.RestoreOperationResponse"
+                            "$ref": "#/definitions/jsonapi.CurrencyMigrationResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "403": {
+                        "description": "Forbidden — non-admin",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "409": {
+                        "description": "Token expired / state changed / migration in progress / restore in progress",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation / token invalid",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "429": {
+                        "description": "Daily cap reached",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -4865,9 +5798,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/exports/{id}/restores/{restoreId}": {
-            "get": {
-                "description": "get restore operation by ID for an export",
+        "/g/{groupSlug}/currency-migrations/preview": {
+            "post": {
+                "description": "Dry-run the conversion, returning projected totals + per-row diffs + a signed preview_token.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4875,9 +5808,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "currency-migrations"
                 ],
-                "summary": "Get export restore operation",
+                "summary": "Preview a currency migration",
                 "parameters": [
                     {
                         "type": "string",
@@ -4887,37 +5820,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Export ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Restore Operation ID",
-                        "name": "restoreId",
-                        "in": "path",
-                        "required": true
+                        "description": "Preview request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.RestoreOperationResponse"
+                            "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "403": {
+                        "description": "Forbidden — non-admin",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "delete a restore operation for an export",
+            }
+        },
+        "/g/{groupSlug}/currency-migrations/{id}": {
+            "get": {
+                "description": "Returns one currency-migration row by id.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -4925,9 +5861,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "exports"
+                    "currency-migrations"
                 ],
-                "summary": "Delete export restore operation",
+                "summary": "Get a currency migration",
                 "parameters": [
                     {
                         "type": "string",
@@ -4938,22 +5874,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Export ID",
+                        "description": "Currency migration ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Restore Operation ID",
-                        "name": "restoreId",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CurrencyMigrationResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden — non-admin",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
                     },
                     "404": {
                         "description": "Not found",
@@ -4964,16 +5902,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/exports/{id}/signed-url": {
+        "/g/{groupSlug}/events/stream": {
             "get": {
-                "description": "Return a secure HMAC-signed URL for downloading a completed\nexport file without putting a JWT in the URL. Minting the URL\nis side-effect-free, so this is a GET available to any group\nmember (the same audience as the export download route). The\nsigned URL targets the file-download route and is consumed by\nthe frontend export-download CTA.",
+                "description": "Server-Sent Events stream of commodity, area, location, file and loan changes in the group. Each message carries the event ID, an event name of the form ` + "`" + `\u003centity\u003e.\u003caction\u003e` + "`" + ` and a JSON data line; clients refetch the changed row. Resume after a disconnect by sending the last received ID in the Last-Event-ID header (or the last_event_id query parameter). A ` + "`" + `reset` + "`" + ` event means the ID could not be resumed and cached state should be refetched. Location-scoped members only receive events for their locations.",
                 "produces": [
-                    "application/vnd.api+json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "exports"
+                    "events"
                 ],
-                "summary": "Get signed URL for export download",
+                "summary": "Stream live group changes",
                 "parameters": [
                     {
                         "type": "string",
@@ -4984,21 +5922,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Export ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Resume after this event ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event ID, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Signed URL",
+                        "description": "Event stream",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.SignedFileURLResponse"
+                            "$ref": "#/definitions/changefeed.Event"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Group not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -5006,9 +5949,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/files": {
+        "/g/{groupSlug}/exports": {
             "get": {
-                "description": "get files with optional filtering",
+                "description": "get exports",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5016,9 +5959,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "files"
+                    "exports"
                 ],
-                "summary": "List files",
+                "summary": "List exports",
                 "parameters": [
                     {
                         "type": "string",
@@ -5028,72 +5971,9 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "image",
-                            "document",
-                            "video",
-                            "audio",
-                            "archive",
-                            "other"
-                        ],
-                        "type": "string",
-                        "description": "Filter by file type",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "images",
-                            "documents",
-                            "other"
-                        ],
-                        "type": "string",
-                        "description": "Filter by file category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search in title, description, and file paths",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by tags (comma-separated)",
-                        "name": "tags",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by a single tag (alias for ?tags=, repeatable; backs the Files page tag chips)",
-                        "name": "tag",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by linked entity type (e.g. commodity, location, area, export). Must be supplied together with linked_entity_id.",
-                        "name": "linked_entity_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by linked entity id. Must be supplied together with linked_entity_type.",
-                        "name": "linked_entity_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (1-based)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page",
-                        "name": "limit",
+                        "type": "boolean",
+                        "description": "Include deleted exports",
+                        "name": "include_deleted",
                         "in": "query"
                     }
                 ],
@@ -5101,19 +5981,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.FilesResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid query parameter",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.ExportsResponse"
                         }
                     }
                 }
             },
             "post": {
-                "description": "create a new file entity with metadata (file upload handled separately)",
+                "description": "create a new export",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5121,9 +5995,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "files"
+                    "exports"
                 ],
-                "summary": "Create a file entity",
+                "summary": "Create an export",
                 "parameters": [
                     {
                         "type": "string",
@@ -5133,24 +6007,36 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "File metadata",
-                        "name": "file",
+                        "description": "Export",
+                        "name": "export",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.FileRequest"
+                            "$ref": "#/definitions/jsonapi.ExportCreateRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export the commodities matched by a saved view as a selected_items export; selected_items in the body is ignored",
+                        "name": "view_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.FileResponse"
+                            "$ref": "#/definitions/jsonapi.ExportResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Saved view not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "422": {
-                        "description": "Validation error",
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -5158,9 +6044,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/files/bulk-delete": {
+        "/g/{groupSlug}/exports/import": {
             "post": {
-                "description": "Delete every file whose id appears in the body, including\nthe physical blob. The response lists succeeded vs.\nfailed ids so the frontend can render partial-failure UX\nwithout parsing per-id HTTP statuses.",
+                "description": "Import an uploaded signed ` + "`" + `.inb` + "`" + ` backup file and create an export record",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5168,9 +6054,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "files"
+                    "exports"
                 ],
-                "summary": "Bulk-delete files",
+                "summary": "Import backup archive",
                 "parameters": [
                     {
                         "type": "string",
@@ -5180,24 +6066,30 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "List of file IDs to delete",
-                        "name": "body",
+                        "description": "Import request data",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.BulkIDsRequest"
+                            "$ref": "#/definitions/jsonapi.ImportExportRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Per-id outcome",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.BulkResultResponse"
+                            "$ref": "#/definitions/jsonapi.ExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "422": {
-                        "description": "Bad request body",
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -5205,9 +6097,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/files/category-counts": {
-            "get": {
-                "description": "Per-category file counts, respecting the same filters as GET /files",
+        "/g/{groupSlug}/exports/import-replica": {
+            "post": {
+                "description": "Copy a replicated ` + "`" + `.inb` + "`" + ` archive back from the backup replication target and create an export record for it, which can then be restored like any imported backup. The URL must point into the configured replication target and into the caller's tenant. When one of the group's exports records this replica, the copy must match its recorded SHA-256; the archive signature is verified by the import worker either way.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5215,9 +6107,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "files"
+                    "exports"
                 ],
-                "summary": "File category counts",
+                "summary": "Import backup archive from its off-site replica",
                 "parameters": [
                     {
                         "type": "string",
@@ -5227,45 +6119,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "image",
-                            "document",
-                            "video",
-                            "audio",
-                            "archive",
-                            "other"
-                        ],
-                        "type": "string",
-                        "description": "Filter by file type",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search in title, description, and file paths",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by tags (comma-separated)",
-                        "name": "tags",
-                        "in": "query"
+                        "description": "Import replica request data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.ImportReplicaRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.FileCategoryCountsResponse"
+                            "$ref": "#/definitions/jsonapi.ExportResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Replication is not configured or the replica does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/files/{fileID}": {
+        "/g/{groupSlug}/exports/{id}": {
             "get": {
-                "description": "get file by ID",
+                "description": "get export by ID",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5273,52 +6160,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "files"
-                ],
-                "summary": "Get a file",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "File ID",
-                        "name": "fileID",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.FileResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "File not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "update file metadata",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
-                "produces": [
-                    "application/vnd.api+json"
-                ],
-                "tags": [
-                    "files"
+                    "exports"
                 ],
-                "summary": "Update a file",
+                "summary": "Get an export",
                 "parameters": [
                     {
                         "type": "string",
@@ -5329,36 +6173,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "File ID",
-                        "name": "fileID",
+                        "description": "Export ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "File update data",
-                        "name": "file",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.FileUpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.FileResponse"
+                            "$ref": "#/definitions/jsonapi.ExportResponse"
                         }
                     },
                     "404": {
-                        "description": "File not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "422": {
-                        "description": "Validation error",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -5366,7 +6195,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "delete file and its associated file",
+                "description": "delete an export",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5374,9 +6203,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "files"
+                    "exports"
                 ],
-                "summary": "Delete a file",
+                "summary": "Delete an export",
                 "parameters": [
                     {
                         "type": "string",
@@ -5387,8 +6216,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "File ID",
-                        "name": "fileID",
+                        "description": "Export ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -5398,7 +6227,7 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "404": {
-                        "description": "File not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -5406,13 +6235,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/files/{fileID}/signed-url": {
-            "post": {
-                "description": "Generate a secure signed URL for downloading a file",
+        "/g/{groupSlug}/exports/{id}/download": {
+            "get": {
+                "description": "Download an export XML file",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
                 "tags": [
-                    "files"
+                    "exports"
                 ],
-                "summary": "Generate signed URL for file download",
+                "summary": "Download an export file",
                 "parameters": [
                     {
                         "type": "string",
@@ -5423,21 +6258,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "File ID",
-                        "name": "fileID",
+                        "description": "Export ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Signed URL",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.SignedFileURLResponse"
+                            "type": "file"
                         }
                     },
                     "404": {
-                        "description": "File not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -5445,9 +6280,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/loans": {
+        "/g/{groupSlug}/exports/{id}/restores": {
             "get": {
-                "description": "List loans across the current group with optional state filter.",
+                "description": "get restore operations for an export",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5455,9 +6290,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_loans"
+                    "exports"
                 ],
-                "summary": "List group-wide loans",
+                "summary": "List export restore operations",
                 "parameters": [
                     {
                         "type": "string",
@@ -5467,46 +6302,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "all",
-                            "open",
-                            "overdue",
-                            "returned"
-                        ],
                         "type": "string",
-                        "default": "all",
-                        "description": "Filter by state",
-                        "name": "state",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (1-based)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityLoanListResponse"
+                            "$ref": "#/definitions/jsonapi.RestoreOperationsResponse"
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/loans/counts": {
-            "get": {
-                "description": "Map of commodity_id → open-loan count for a list of commodities.",
+            },
+            "post": {
+                "description": "create a new restore operation for an export. With options.dry_run set\nnothing is written: the operation ends in the preview status with the\nplanned changes and conflicts in its preview attribute. options.selection\nrestricts the restore to the listed location, area and commodity UUIDs\n(with their subtrees and files); missing parents are created.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5514,9 +6327,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_loans"
+                    "exports"
                 ],
-                "summary": "Get open-loan counts by commodity",
+                "summary": "Create export restore operation",
                 "parameters": [
                     {
                         "type": "string",
@@ -5526,30 +6339,53 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "Repeatable commodity IDs to look up",
-                        "name": "commodity_id",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Restore operation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.RestoreOperationCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityLoanCountsResponse"
+                            "$ref": "#/definitions/jsonapi.RestoreOperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/locations": {
+        "/g/{groupSlug}/exports/{id}/restores/{restoreId}": {
             "get": {
-                "description": "get locations",
+                "description": "get restore operation by ID for an export",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5557,9 +6393,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "locations"
+                    "exports"
                 ],
-                "summary": "List locations",
+                "summary": "Get export restore operation",
                 "parameters": [
                     {
                         "type": "string",
@@ -5569,29 +6405,37 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Items per page (default 50, max 100)",
-                        "name": "per_page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Restore Operation ID",
+                        "name": "restoreId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationsResponse"
+                            "$ref": "#/definitions/jsonapi.RestoreOperationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "add by location data",
+            "delete": {
+                "description": "delete a restore operation for an export",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5599,9 +6443,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "locations"
+                    "exports"
                 ],
-                "summary": "Create a new location",
+                "summary": "Delete export restore operation",
                 "parameters": [
                     {
                         "type": "string",
@@ -5610,88 +6454,44 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "description": "Location object",
-                        "name": "location",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Location created",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Location not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "422": {
-                        "description": "User-side request problem",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    }
-                }
-            }
-        },
-        "/g/{groupSlug}/locations/{locationID}": {
-            "get": {
-                "description": "get location by ID",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
-                "produces": [
-                    "application/vnd.api+json"
-                ],
-                "tags": [
-                    "locations"
-                ],
-                "summary": "Get a location",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
+                        "description": "Export ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Location ID",
-                        "name": "locationID",
+                        "description": "Restore Operation ID",
+                        "name": "restoreId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not found",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationResponse"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update by location data",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
+            }
+        },
+        "/g/{groupSlug}/exports/{id}/signed-url": {
+            "get": {
+                "description": "Return a secure HMAC-signed URL for downloading a completed\nexport file without putting a JWT in the URL. Minting the URL\nis side-effect-free, so this is a GET available to any group\nmember (the same audience as the export download route). The\nsigned URL targets the file-download route and is consumed by\nthe frontend export-download CTA.",
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "locations"
+                    "exports"
                 ],
-                "summary": "Update a location",
+                "summary": "Get signed URL for export download",
                 "parameters": [
                     {
                         "type": "string",
@@ -5702,44 +6502,31 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Location ID",
-                        "name": "locationID",
+                        "description": "Export ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Location object",
-                        "name": "location",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Signed URL",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationResponse"
+                            "$ref": "#/definitions/jsonapi.SignedFileURLResponse"
                         }
                     },
                     "404": {
-                        "description": "Location not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "422": {
-                        "description": "User-side request problem",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete by location ID",
+            }
+        },
+        "/g/{groupSlug}/files": {
+            "get": {
+                "description": "get files with optional filtering",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5747,9 +6534,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "locations"
+                    "files"
                 ],
-                "summary": "Delete a location",
+                "summary": "List files",
                 "parameters": [
                     {
                         "type": "string",
@@ -5759,73 +6546,58 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "image",
+                            "document",
+                            "video",
+                            "audio",
+                            "archive",
+                            "other"
+                        ],
                         "type": "string",
-                        "description": "Location ID",
-                        "name": "locationID",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by file type",
+                        "name": "type",
+                        "in": "query"
                     },
                     {
                         "enum": [
-                            "cascade",
-                            "unlink"
+                            "images",
+                            "documents",
+                            "other"
                         ],
                         "type": "string",
-                        "description": "Non-empty-location strategy: cascade deletes the commodities, unlink keeps them area-less. Omit to reject a non-empty location.",
-                        "name": "strategy",
+                        "description": "Filter by file category",
+                        "name": "category",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No content"
                     },
-                    "404": {
-                        "description": "Location not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Search in title, description, and file paths",
+                        "name": "search",
+                        "in": "query"
                     },
-                    "422": {
-                        "description": "Non-empty location (no strategy) or unknown strategy",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    }
-                }
-            }
-        },
-        "/g/{groupSlug}/maintenance": {
-            "get": {
-                "description": "Upcoming maintenance across the current group.",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
-                "produces": [
-                    "application/vnd.api+json"
-                ],
-                "tags": [
-                    "maintenance_schedules"
-                ],
-                "summary": "List group-wide maintenance schedules",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter to schedules whose next_due_at is on or before this date",
-                        "name": "due_before",
+                        "description": "Filter by a single tag (alias for ?tags=, repeatable; backs the Files page tag chips)",
+                        "name": "tag",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Restrict to enabled schedules",
-                        "name": "enabled_only",
+                        "type": "string",
+                        "description": "Filter by linked entity type (e.g. commodity, location, area, export). Must be supplied together with linked_entity_id.",
+                        "name": "linked_entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by linked entity id. Must be supplied together with linked_entity_type.",
+                        "name": "linked_entity_id",
                         "in": "query"
                     },
                     {
@@ -5837,9 +6609,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 50,
+                        "default": 20,
                         "description": "Items per page",
-                        "name": "per_page",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -5847,15 +6619,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleListResponse"
+                            "$ref": "#/definitions/jsonapi.FilesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/maintenance/{scheduleID}": {
-            "delete": {
-                "description": "Hard-delete a maintenance schedule row.",
+            },
+            "post": {
+                "description": "create a new file entity with metadata (file upload handled separately)",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5863,9 +6639,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "maintenance_schedules"
+                    "files"
                 ],
-                "summary": "Delete a maintenance schedule",
+                "summary": "Create a file entity",
                 "parameters": [
                     {
                         "type": "string",
@@ -5875,27 +6651,34 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Maintenance schedule ID",
-                        "name": "scheduleID",
-                        "in": "path",
-                        "required": true
+                        "description": "File metadata",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.FileRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.FileResponse"
+                        }
                     },
-                    "404": {
-                        "description": "Schedule not found",
+                    "422": {
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Patch title / interval / next_due_at / last_done_at / notes / enabled.",
+            }
+        },
+        "/g/{groupSlug}/files/bulk-delete": {
+            "post": {
+                "description": "Delete every file whose id appears in the body, including\nthe physical blob. The response lists succeeded vs.\nfailed ids so the frontend can render partial-failure UX\nwithout parsing per-id HTTP statuses.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5903,9 +6686,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "maintenance_schedules"
+                    "files"
                 ],
-                "summary": "Update a maintenance schedule",
+                "summary": "Bulk-delete files",
                 "parameters": [
                     {
                         "type": "string",
@@ -5915,37 +6698,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Maintenance schedule ID",
-                        "name": "scheduleID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Schedule patch payload",
-                        "name": "schedule",
+                        "description": "List of file IDs to delete",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleUpdateRequest"
+                            "$ref": "#/definitions/jsonapi.BulkIDsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Schedule not found",
+                        "description": "Per-id outcome",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.BulkResultResponse"
                         }
                     },
                     "422": {
-                        "description": "User-side request problem",
+                        "description": "Bad request body",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -5953,9 +6723,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/maintenance/{scheduleID}/done": {
-            "post": {
-                "description": "Advance next_due_at by interval_days and record last_done_at.",
+        "/g/{groupSlug}/files/category-counts": {
+            "get": {
+                "description": "Per-category file counts, respecting the same filters as GET /files",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -5963,9 +6733,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "maintenance_schedules"
+                    "files"
                 ],
-                "summary": "Mark a maintenance schedule as done",
+                "summary": "File category counts",
                 "parameters": [
                     {
                         "type": "string",
@@ -5975,47 +6745,104 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "image",
+                            "document",
+                            "video",
+                            "audio",
+                            "archive",
+                            "other"
+                        ],
                         "type": "string",
-                        "description": "Maintenance schedule ID",
-                        "name": "scheduleID",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by file type",
+                        "name": "type",
+                        "in": "query"
                     },
                     {
-                        "description": "Optional explicit done_at",
-                        "name": "payload",
-                        "in": "body",
+                        "type": "string",
+                        "description": "Search in title, description, and file paths",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleDoneRequest"
+                            "$ref": "#/definitions/jsonapi.FileCategoryCountsResponse"
                         }
                     }
+                }
+            }
+        },
+        "/g/{groupSlug}/files/{fileID}": {
+            "get": {
+                "description": "get file by ID",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "fileID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleResponse"
+                            "$ref": "#/definitions/jsonapi.FileResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity version, for If-Match on update and delete"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Schedule not found",
+                        "description": "File not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/notifications": {
-            "get": {
-                "description": "Returns the effective on/off for each FE toggle, resolved per-group → user-global → in-code default (issue #1648 / #1537 item 2).",
+            },
+            "put": {
+                "description": "update file metadata",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "files"
                 ],
-                "summary": "Get per-group notification preferences for the caller",
+                "summary": "Update a file",
                 "parameters": [
                     {
                         "type": "string",
@@ -6023,41 +6850,69 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "fileID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File update data",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.FileUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the update is refused when the file has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apiserver.GroupNotificationsResponse"
+                            "$ref": "#/definitions/jsonapi.FileResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "File not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "412": {
+                        "description": "File changed since the If-Match ETag; body is the current file",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.FileResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "Upserts the per-group override for each toggle present in the body. Missing keys are left untouched (issue #1648 / #1537 item 2).",
+            "delete": {
+                "description": "delete file and its associated file",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "files"
                 ],
-                "summary": "Update per-group notification preferences for the caller",
+                "summary": "Delete a file",
                 "parameters": [
                     {
                         "type": "string",
@@ -6067,53 +6922,90 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Toggles to upsert",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apiserver.GroupNotificationsPatchRequest"
-                        }
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "fileID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the delete is refused when the file has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "File not found",
                         "schema": {
-                            "$ref": "#/definitions/apiserver.GroupNotificationsResponse"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "412": {
+                        "description": "File changed since the If-Match ETag; body is the current file",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.FileResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/g/{groupSlug}/files/{fileID}/signed-url": {
+            "post": {
+                "description": "Generate a secure signed URL for downloading a file",
+                "tags": [
+                    "files"
+                ],
+                "summary": "Generate signed URL for file download",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "fileID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Signed URL",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.SignedFileURLResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "File not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/plan": {
+        "/g/{groupSlug}/invoice-extractions": {
             "get": {
-                "description": "Tenant plan (caps + gates) + current group usage (items, locations, storage). Plan resolved from tenants.plan_id; unknown ids degrade to unlimited. Powers the GroupSettings Plan card (#1389 / #1537).",
+                "description": "Invoice extractions of the current group, oldest first. Defaults to the pending review queue; ?status= selects another status, ?status=all returns every row.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "invoice_extractions"
                 ],
-                "summary": "Get the active plan + per-group usage",
+                "summary": "List invoice extractions",
                 "parameters": [
                     {
                         "type": "string",
@@ -6121,33 +7013,34 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Filter by status (pending, applied, rejected, no_changes, failed or all)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GroupPlanResult"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.InvoiceExtractionsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Unknown status",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/search": {
+        "/g/{groupSlug}/invoice-extractions/{extractionID}": {
             "get": {
-                "description": "Perform advanced search across commodities, files, and other entities",
+                "description": "One invoice extraction with its proposed changes and line items.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6155,9 +7048,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "search"
+                    "invoice_extractions"
                 ],
-                "summary": "Advanced search",
+                "summary": "Get an invoice extraction",
                 "parameters": [
                     {
                         "type": "string",
@@ -6168,68 +7061,147 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
+                        "description": "Invoice extraction ID",
+                        "name": "extractionID",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.InvoiceExtractionResponse"
+                        }
                     },
+                    "404": {
+                        "description": "Extraction not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/g/{groupSlug}/invoice-extractions/{extractionID}/approve": {
+            "post": {
+                "description": "Write the proposed changes (all of them, or the listed fields) to the linked commodity and mark the extraction applied.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "invoice_extractions"
+                ],
+                "summary": "Approve an invoice extraction",
+                "parameters": [
                     {
-                        "enum": [
-                            "commodities",
-                            "files",
-                            "areas",
-                            "locations"
-                        ],
                         "type": "string",
-                        "description": "Entity type to search",
-                        "name": "type",
-                        "in": "query"
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Maximum number of results",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Invoice extraction ID",
+                        "name": "extractionID",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Number of results to skip",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Optional subset of fields to apply",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.InvoiceExtractionApproveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.InvoiceExtractionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Extraction or commodity not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "Extraction is not pending review",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
                     },
+                    "422": {
+                        "description": "Unknown field or the updated commodity is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/g/{groupSlug}/invoice-extractions/{extractionID}/reject": {
+            "post": {
+                "description": "Dismiss the proposal; the commodity is left untouched.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "invoice_extractions"
+                ],
+                "summary": "Reject an invoice extraction",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by tags (comma-separated)",
-                        "name": "tags",
-                        "in": "query"
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "AND",
-                            "OR"
-                        ],
                         "type": "string",
-                        "default": "OR",
-                        "description": "Tag operator",
-                        "name": "operator",
-                        "in": "query"
+                        "description": "Invoice extraction ID",
+                        "name": "extractionID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Search results",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.SearchResponse"
+                            "$ref": "#/definitions/jsonapi.InvoiceExtractionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Extraction not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "Extraction is not pending review",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/services": {
+        "/g/{groupSlug}/loans": {
             "get": {
-                "description": "List service rows across the current group with optional state filter.",
+                "description": "List loans across the current group with optional state filter.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6237,9 +7209,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_services"
+                    "commodity_loans"
                 ],
-                "summary": "List group-wide services",
+                "summary": "List group-wide loans",
                 "parameters": [
                     {
                         "type": "string",
@@ -6253,7 +7225,7 @@ const docTemplate = `{
                             "all",
                             "open",
                             "overdue",
-                            "completed"
+                            "returned"
                         ],
                         "type": "string",
                         "default": "all",
@@ -6280,15 +7252,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceListResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityLoanListResponse"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/services/counts": {
+        "/g/{groupSlug}/loans/counts": {
             "get": {
-                "description": "Map of commodity_id → open-service count for a list of commodities.",
+                "description": "Map of commodity_id → open-loan count for a list of commodities.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6296,9 +7268,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodity_services"
+                    "commodity_loans"
                 ],
-                "summary": "Get open-service counts by commodity",
+                "summary": "Get open-loan counts by commodity",
                 "parameters": [
                     {
                         "type": "string",
@@ -6323,25 +7295,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityServiceCountsResponse"
+                            "$ref": "#/definitions/jsonapi.CommodityLoanCountsResponse"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/settings": {
+        "/g/{groupSlug}/locations": {
             "get": {
-                "description": "get current settings",
+                "description": "get locations",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "settings"
+                    "locations"
                 ],
-                "summary": "Get current settings",
+                "summary": "List locations",
                 "parameters": [
                     {
                         "type": "string",
@@ -6349,29 +7321,41 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 100)",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SettingsObject"
+                            "$ref": "#/definitions/jsonapi.LocationsResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "update entire settings object",
+            "post": {
+                "description": "add by location data",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "settings"
+                    "locations"
                 ],
-                "summary": "Update settings",
+                "summary": "Create a new location",
                 "parameters": [
                     {
                         "type": "string",
@@ -6381,44 +7365,50 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Settings object with documented snake_case field names",
-                        "name": "settings",
+                        "description": "Location object",
+                        "name": "location",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apiserver.SettingsUpdateRequest"
+                            "$ref": "#/definitions/jsonapi.LocationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Location created",
                         "schema": {
-                            "$ref": "#/definitions/models.SettingsObject"
+                            "$ref": "#/definitions/jsonapi.LocationResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Location not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "User-side request problem",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/settings/{field}": {
-            "patch": {
-                "description": "update a specific setting field.",
+        "/g/{groupSlug}/locations/{locationID}": {
+            "get": {
+                "description": "get location by ID",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "settings"
+                    "locations"
                 ],
-                "summary": "Patch setting",
+                "summary": "Get a location",
                 "parameters": [
                     {
                         "type": "string",
@@ -6429,47 +7419,39 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Setting field path (e.g., uiconfig.theme)",
-                        "name": "field",
+                        "description": "Location ID",
+                        "name": "locationID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Setting value envelope with required value.",
-                        "name": "value",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apiserver.PatchSettingRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SettingsObject"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.LocationResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity version, for If-Match on update and delete"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/storage-usage": {
-            "get": {
-                "description": "Returns the per-group blob byte total with a per-category breakdown and the active quota. Used by the Settings → Data \u0026 storage card.",
-                "produces": [
-                    "application/json"
+            },
+            "put": {
+                "description": "Update by location data",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "storage"
+                    "locations"
                 ],
-                "summary": "Get storage usage for a group",
+                "summary": "Update a location",
                 "parameters": [
                     {
                         "type": "string",
@@ -6477,33 +7459,59 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location object",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.LocationRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET; the update is refused when the location has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.StorageUsage"
+                            "$ref": "#/definitions/jsonapi.LocationResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Location not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "412": {
+                        "description": "Location changed since the If-Match ETag; body is the current location",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.LocationResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "User-side request problem",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/tags": {
-            "get": {
-                "description": "get tags of the given kind. Pass include=usage to attach a per-row meta.usage block. kind=commodity|file is required — item-tags and file-tags are separate entities.",
+            },
+            "delete": {
+                "description": "Delete by location ID",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6511,9 +7519,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "tags"
+                    "locations"
                 ],
-                "summary": "List tags",
+                "summary": "Delete a location",
                 "parameters": [
                     {
                         "type": "string",
@@ -6523,118 +7531,47 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "commodity",
-                            "file"
-                        ],
                         "type": "string",
-                        "description": "Tag kind (commodity = item-tags, file = file-tags)",
-                        "name": "kind",
-                        "in": "query",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "Search by label or slug",
-                        "name": "q",
-                        "in": "query"
-                    },
                     {
                         "enum": [
-                            "label",
-                            "created_at",
-                            "usage"
+                            "cascade",
+                            "unlink"
                         ],
                         "type": "string",
-                        "description": "Sort field (label, created_at, usage)",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "asc",
-                        "description": "Sort direction (asc, desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (1-based)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Items per page",
-                        "name": "per_page",
+                        "description": "Non-empty-location strategy: cascade deletes the commodities, unlink keeps them area-less. Omit to reject a non-empty location.",
+                        "name": "strategy",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "usage"
-                        ],
                         "type": "string",
-                        "description": "Comma-separated extras. 'usage' attaches per-row meta.usage.",
-                        "name": "include",
-                        "in": "query"
+                        "description": "ETag from GET; the delete is refused when the location has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.TagsResponse"
-                        }
+                    "204": {
+                        "description": "No content"
                     },
-                    "422": {
-                        "description": "Missing or invalid kind value",
+                    "404": {
+                        "description": "Location not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "add a tag with slug, label, color",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
-                "produces": [
-                    "application/vnd.api+json"
-                ],
-                "tags": [
-                    "tags"
-                ],
-                "summary": "Create a new tag",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
-                        "in": "path",
-                        "required": true
                     },
-                    {
-                        "description": "Tag object",
-                        "name": "tag",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.TagRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Tag created",
+                    "412": {
+                        "description": "Location changed since the If-Match ETag; body is the current location",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.TagResponse"
+                            "$ref": "#/definitions/jsonapi.LocationResponse"
                         }
                     },
                     "422": {
-                        "description": "User-side request problem",
+                        "description": "Non-empty location (no strategy) or unknown strategy",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -6642,9 +7579,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/tags/autocomplete": {
+        "/g/{groupSlug}/maintenance": {
             "get": {
-                "description": "Top-N tags of the given kind matching the query, ranked by usage desc + created_at desc.",
+                "description": "Upcoming maintenance across the current group.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6652,9 +7589,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "tags"
+                    "maintenance_schedules"
                 ],
-                "summary": "Autocomplete tag suggestions",
+                "summary": "List group-wide maintenance schedules",
                 "parameters": [
                     {
                         "type": "string",
@@ -6664,27 +7601,29 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "commodity",
-                            "file"
-                        ],
                         "type": "string",
-                        "description": "Tag kind (commodity = item-tags, file = file-tags)",
-                        "name": "kind",
-                        "in": "query",
-                        "required": true
+                        "description": "Filter to schedules whose next_due_at is on or before this date",
+                        "name": "due_before",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Substring match against label and slug",
-                        "name": "q",
+                        "type": "boolean",
+                        "description": "Restrict to enabled schedules",
+                        "name": "enabled_only",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Maximum suggestions returned",
-                        "name": "limit",
+                        "default": 1,
+                        "description": "Page number (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Items per page",
+                        "name": "per_page",
                         "in": "query"
                     }
                 ],
@@ -6692,21 +7631,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.TagAutocompleteResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Missing or invalid kind value",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleListResponse"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/tags/stats": {
-            "get": {
-                "description": "Returns total tags, plus tagged/untagged counts on commodities and files for the current group.",
+        "/g/{groupSlug}/maintenance/{scheduleID}": {
+            "delete": {
+                "description": "Hard-delete a maintenance schedule row.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6714,9 +7647,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "tags"
+                    "maintenance_schedules"
                 ],
-                "summary": "Tag adoption stats",
+                "summary": "Delete a maintenance schedule",
                 "parameters": [
                     {
                         "type": "string",
@@ -6724,21 +7657,29 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maintenance schedule ID",
+                        "name": "scheduleID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Schedule not found",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.TagStatsResponse"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/tags/{tagID}": {
-            "get": {
-                "description": "get tag by ID with usage breakdown",
+            },
+            "patch": {
+                "description": "Patch title / recurrence / interval / calendar rule / meter settings / next_due_at / last_done_at / notes / enabled.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6746,9 +7687,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "tags"
+                    "maintenance_schedules"
                 ],
-                "summary": "Get a tag",
+                "summary": "Update a maintenance schedule",
                 "parameters": [
                     {
                         "type": "string",
@@ -6759,29 +7700,46 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Tag ID",
-                        "name": "tagID",
+                        "description": "Maintenance schedule ID",
+                        "name": "scheduleID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Schedule patch payload",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.TagResponse"
+                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleResponse"
                         }
                     },
                     "404": {
-                        "description": "Tag not found",
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "User-side request problem",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete tag by ID. Returns 409 with usage breakdown when in use; pass ?force=true to strip references.",
+            }
+        },
+        "/g/{groupSlug}/maintenance/{scheduleID}/done": {
+            "post": {
+                "description": "Log a completion (notes, cost, meter value, attached files), record last_done_at and advance the schedule per its recurrence.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6789,9 +7747,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "tags"
+                    "maintenance_schedules"
                 ],
-                "summary": "Delete a tag",
+                "summary": "Mark a maintenance schedule as done",
                 "parameters": [
                     {
                         "type": "string",
@@ -6802,38 +7760,45 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Tag ID",
-                        "name": "tagID",
+                        "description": "Maintenance schedule ID",
+                        "name": "scheduleID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Strip JSONB references then delete",
-                        "name": "force",
-                        "in": "query"
+                        "description": "Optional done_at and completion details",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleDoneRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.MaintenanceScheduleResponse"
+                        }
                     },
                     "404": {
-                        "description": "Tag not found",
+                        "description": "Schedule not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "409": {
-                        "description": "Tag is in use; pass force=true to strip references",
+                    "422": {
+                        "description": "User-side request problem",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Patch label/color/slug. Slug change rewrites JSONB references.",
+            }
+        },
+        "/g/{groupSlug}/maintenance/{scheduleID}/logs": {
+            "get": {
+                "description": "Completion history of the schedule, newest first.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6841,9 +7806,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "tags"
+                    "maintenance_schedules"
                 ],
-                "summary": "Update a tag",
+                "summary": "List maintenance log entries",
                 "parameters": [
                     {
                         "type": "string",
@@ -6854,42 +7819,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Tag ID",
-                        "name": "tagID",
+                        "description": "Maintenance schedule ID",
+                        "name": "scheduleID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Tag patch payload",
-                        "name": "tag",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.TagUpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.TagResponse"
+                            "$ref": "#/definitions/jsonapi.MaintenanceLogsResponse"
                         }
                     },
                     "404": {
-                        "description": "Tag not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "409": {
-                        "description": "Slug already in use",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "422": {
-                        "description": "User-side request problem",
+                        "description": "Schedule not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -6897,9 +7841,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/upload-slots/check": {
-            "get": {
-                "description": "Check if user can start an upload for a specific operation",
+        "/g/{groupSlug}/maintenance/{scheduleID}/logs/{logID}": {
+            "delete": {
+                "description": "Hard-delete one completion entry of the schedule.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -6907,9 +7851,9 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "upload-slots"
+                    "maintenance_schedules"
                 ],
-                "summary": "Check upload capacity",
+                "summary": "Delete a maintenance log entry",
                 "parameters": [
                     {
                         "type": "string",
@@ -6920,34 +7864,25 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "example": "image_upload",
-                        "description": "Operation name",
-                        "name": "operation",
-                        "in": "query",
+                        "description": "Maintenance schedule ID",
+                        "name": "scheduleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maintenance log ID",
+                        "name": "logID",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Upload capacity available",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.UploadStatusResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "429": {
-                        "description": "Too many concurrent uploads",
+                    "404": {
+                        "description": "Schedule or log entry not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -6955,19 +7890,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/g/{groupSlug}/upload-slots/status": {
+        "/g/{groupSlug}/notifications": {
             "get": {
-                "description": "Get current upload status for a specific operation",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
+                "description": "Returns the effective on/off for each FE toggle, resolved per-group → user-global → in-code default (issue #1648 / #1537 item 2).",
                 "produces": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "upload-slots"
+                    "groups"
                 ],
-                "summary": "Get upload status",
+                "summary": "Get per-group notification preferences for the caller",
                 "parameters": [
                     {
                         "type": "string",
@@ -6975,51 +7907,41 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "image_upload",
-                        "description": "Operation name",
-                        "name": "operation",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Upload status retrieved successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.UploadStatusResponse"
+                            "$ref": "#/definitions/apiserver.GroupNotificationsResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/g/{groupSlug}/uploads/file": {
-            "post": {
-                "description": "Upload a single file of any type. The file is stored and a file entity is created without a linked entity.",
+            },
+            "patch": {
+                "description": "Upserts the per-group override for each toggle present in the body. Missing keys are left untouched (issue #1648 / #1537 item 2).",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "uploads"
+                    "groups"
                 ],
-                "summary": "Upload a file",
+                "summary": "Update per-group notification preferences for the caller",
                 "parameters": [
                     {
                         "type": "string",
@@ -7029,54 +7951,53 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "File to upload",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Toggles to upsert",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.GroupNotificationsPatchRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.FileResponse"
+                            "$ref": "#/definitions/apiserver.GroupNotificationsResponse"
                         }
                     },
-                    "413": {
-                        "description": "Request Entity Too Large",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/g/{groupSlug}/uploads/restores": {
-            "post": {
-                "description": "Upload a signed ` + "`" + `.inb` + "`" + ` backup file to be used for a restore operation.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/g/{groupSlug}/plan": {
+            "get": {
+                "description": "Tenant plan (caps + gates) + current group usage (items, locations, storage). Plan resolved from tenants.plan_id; unknown ids degrade to unlimited. Powers the GroupSettings Plan card (#1389 / #1537).",
                 "produces": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "uploads"
+                    "groups"
                 ],
-                "summary": "Upload restore file",
+                "summary": "Get the active plan + per-group usage",
                 "parameters": [
                     {
                         "type": "string",
@@ -7084,46 +8005,33 @@ const docTemplate = `{
                         "name": "groupSlug",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Signed .inb backup file to upload",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.UploadResponse"
-                        }
-                    },
-                    "413": {
-                        "description": "Request Entity Too Large",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/models.GroupPlanResult"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/groups": {
+        "/g/{groupSlug}/saved-views": {
             "get": {
-                "description": "Returns all active location groups the authenticated user is a member of",
+                "description": "Saved commodity list views visible to the caller: every group-scoped view plus the caller's private ones.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7131,26 +8039,29 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "saved_views"
                 ],
-                "summary": "List user's groups",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationGroupsResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                "summary": "List saved views",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.SavedViewsResponse"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Creates a new location group with a random non-guessable slug. The creator becomes the group admin.",
+                "description": "Save a named set of commodity list filters and columns, private to the caller or shared with the group.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7158,35 +8069,36 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "saved_views"
                 ],
-                "summary": "Create a group",
+                "summary": "Create a saved view",
                 "parameters": [
                     {
-                        "description": "Group data",
-                        "name": "data",
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Saved view attributes",
+                        "name": "view",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationGroupRequest"
+                            "$ref": "#/definitions/jsonapi.SavedViewRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationGroupResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Saved view created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.SavedViewResponse"
                         }
                     },
                     "422": {
-                        "description": "Validation error",
+                        "description": "Invalid view or stale area/tag reference (code saved_view.stale_reference)",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -7194,9 +8106,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/{groupID}": {
+        "/g/{groupSlug}/saved-views/{viewID}": {
             "get": {
-                "description": "Returns details of a location group. Requires group membership.",
+                "description": "Get a saved commodity list view by ID.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7204,14 +8116,21 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "saved_views"
                 ],
-                "summary": "Get group details",
+                "summary": "Get a saved view",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Saved view ID",
+                        "name": "viewID",
                         "in": "path",
                         "required": true
                     }
@@ -7220,79 +8139,83 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationGroupResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - not a group member",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.SavedViewResponse"
                         }
                     },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Saved view not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Initiates async deletion of a location group. Requires typing the group name AND the caller's current password. Requires group admin role. Failed attempts are audit-logged.",
+            "put": {
+                "description": "Replace a saved view's name, scope, filters and columns.",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "saved_views"
                 ],
-                "summary": "Delete group",
+                "summary": "Update a saved view",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Deletion confirmation (confirm_word + password)",
-                        "name": "data",
+                        "type": "string",
+                        "description": "Saved view ID",
+                        "name": "viewID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Saved view attributes",
+                        "name": "view",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupDeleteRequest"
+                            "$ref": "#/definitions/jsonapi.SavedViewRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.SavedViewResponse"
+                        }
                     },
                     "403": {
-                        "description": "Forbidden - not a group admin",
+                        "description": "Not the view's creator or a group admin (code saved_view.not_creator)",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Saved view not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "422": {
-                        "description": "Invalid confirmation word or password",
+                        "description": "Invalid view or stale area/tag reference (code saved_view.stale_reference)",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "Updates a location group's name, icon, and description. The group_currency field is set once at creation and cannot be changed here — see issue #202 for the currency-migration tool. Requires group admin role.",
+            "delete": {
+                "description": "Delete a saved commodity list view.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7300,48 +8223,37 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "saved_views"
                 ],
-                "summary": "Update group",
+                "summary": "Delete a saved view",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Group data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationGroupRequest"
-                        }
+                        "type": "string",
+                        "description": "Saved view ID",
+                        "name": "viewID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.LocationGroupResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "403": {
-                        "description": "Forbidden - not a group admin",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Group not found",
+                        "description": "Not the view's creator or a group admin (code saved_view.not_creator)",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "404": {
+                        "description": "Saved view not found",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -7349,9 +8261,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/{groupID}/invites": {
+        "/g/{groupSlug}/search": {
             "get": {
-                "description": "Returns all non-expired, unused invite links for a group. Requires group admin role.",
+                "description": "Perform advanced search across commodities, files, and other entities",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7359,87 +8271,81 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "search"
                 ],
-                "summary": "List active invites",
+                "summary": "Advanced search",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupInvitesResponse"
-                        }
                     },
-                    "403": {
-                        "description": "Forbidden - not a group admin",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Creates an invite. When the request body carries ` + "`" + `email` + "`" + `, the BE persists invitee_email on\nthe row and dispatches an email via EmailService; when empty, the invite remains a copy-paste\ntoken. ` + "`" + `role` + "`" + ` (viewer / user / admin) defaults to \"user\"; owner-by-invite is rejected — owner\nis a transfer-of-ownership operation. Requires group admin role.",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
-                "produces": [
-                    "application/vnd.api+json"
-                ],
-                "tags": [
-                    "groups"
-                ],
-                "summary": "Create invite",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
-                        "in": "path",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Optional invitee email + role",
-                        "name": "data",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupInviteCreateRequest"
-                        }
+                        "enum": [
+                            "commodities",
+                            "files",
+                            "areas",
+                            "locations"
+                        ],
+                        "type": "string",
+                        "description": "Entity type to search",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "AND",
+                            "OR"
+                        ],
+                        "type": "string",
+                        "default": "OR",
+                        "description": "Tag operator",
+                        "name": "operator",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupInviteResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - not a group admin",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "422": {
-                        "description": "Invalid email / role",
+                    "200": {
+                        "description": "Search results",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.SearchResponse"
                         }
                     }
                 }
             }
         },
-        "/groups/{groupID}/invites/{inviteID}": {
-            "delete": {
-                "description": "Deletes an unused invite link. Cannot revoke an already-used invite. Requires group admin role.",
+        "/g/{groupSlug}/services": {
+            "get": {
+                "description": "List service rows across the current group with optional state filter.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7447,47 +8353,58 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "commodity_services"
                 ],
-                "summary": "Revoke invite",
+                "summary": "List group-wide services",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Invite ID",
-                        "name": "inviteID",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "all",
+                            "open",
+                            "overdue",
+                            "completed"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Filter by state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "403": {
-                        "description": "Forbidden - not a group admin",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "422": {
-                        "description": "Cannot revoke a used invite",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceListResponse"
                         }
                     }
                 }
             }
         },
-        "/groups/{groupID}/invites/{inviteID}/resend": {
-            "post": {
-                "description": "Mints a new token and expiry on an email-flow invite, then dispatches a fresh email. Legacy token-only invites cannot be resent — create a new invite or recopy the URL. Requires group admin role.",
+        "/g/{groupSlug}/services/counts": {
+            "get": {
+                "description": "Map of commodity_id → open-service count for a list of commodities.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7495,22 +8412,26 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "commodity_services"
                 ],
-                "summary": "Resend invite",
+                "summary": "Get open-service counts by commodity",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Invite ID",
-                        "name": "inviteID",
-                        "in": "path",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Repeatable commodity IDs to look up",
+                        "name": "commodity_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -7518,102 +8439,82 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupInviteResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - not a group admin",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Invite not found",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "422": {
-                        "description": "Invite already used, belongs to another group, or has no invitee email",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.CommodityServiceCountsResponse"
                         }
                     }
                 }
             }
         },
-        "/groups/{groupID}/leave": {
-            "post": {
-                "description": "Removes the current user from a location group. Cannot leave if you are the last admin.",
+        "/g/{groupSlug}/settings": {
+            "get": {
+                "description": "get current settings",
                 "consumes": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "settings"
                 ],
-                "summary": "Leave group",
+                "summary": "Get current settings",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "403": {
-                        "description": "Forbidden - not a group member",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "422": {
-                        "description": "Cannot leave as the last admin",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/models.SettingsObject"
                         }
                     }
                 }
-            }
-        },
-        "/groups/{groupID}/members": {
-            "get": {
-                "description": "Returns all members of a location group with their roles and joined user data (id / name / email). Requires group membership.",
+            },
+            "put": {
+                "description": "update entire settings object",
                 "consumes": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "settings"
                 ],
-                "summary": "List group members",
+                "summary": "Update settings",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Settings object with documented snake_case field names",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.SettingsUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.MembershipsWithUsersResponse"
+                            "$ref": "#/definitions/models.SettingsObject"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - not a group member",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
@@ -7621,105 +8522,112 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/{groupID}/members/{memberUserID}": {
-            "delete": {
-                "description": "Removes a user from a location group. Cannot remove the last admin. Requires group admin role.",
+        "/g/{groupSlug}/settings/{field}": {
+            "patch": {
+                "description": "update a specific setting field.",
                 "consumes": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "settings"
                 ],
-                "summary": "Remove group member",
+                "summary": "Patch setting",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "User ID of the member to remove",
-                        "name": "memberUserID",
+                        "description": "Setting field path (e.g., uiconfig.theme). appearance.default_items_view takes grid, list or a saved view ID",
+                        "name": "field",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Setting value envelope with required value.",
+                        "name": "value",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.PatchSettingRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "403": {
-                        "description": "Forbidden - not a group admin",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.SettingsObject"
                         }
                     },
-                    "422": {
-                        "description": "Cannot remove the last admin",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Changes a member's role (admin/user) within a location group. Cannot demote the last admin. Requires group admin role.",
-                "consumes": [
-                    "application/vnd.api+json"
-                ],
+            }
+        },
+        "/g/{groupSlug}/stats": {
+            "get": {
+                "description": "Returns commodity counts and values broken down by status, type,\nlocation, area, tag, purchase year and warranty status. Drafts are\nexcluded; only in-use commodities carry value, in the group currency,\nso total_value matches GET /commodities/values.",
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "groups"
+                    "commodities"
                 ],
-                "summary": "Update member role",
+                "summary": "Get commodity statistics for a group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "groupID",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "User ID of the member",
-                        "name": "memberUserID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "New role",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupMembershipRoleRequest"
-                        }
+                        "description": "Report on the commodities matched by a saved view",
+                        "name": "view_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupMembershipResponse"
+                            "$ref": "#/definitions/jsonapi.StatsResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - not a group admin",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Saved view not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
                     "422": {
-                        "description": "Cannot demote the last admin",
+                        "description": "Saved view references a deleted area or tag (code saved_view.stale_reference)",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -7727,21 +8635,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/invites/{token}": {
+        "/g/{groupSlug}/storage-usage": {
             "get": {
-                "description": "Returns public information about an invite link including group name and whether it has expired or been used. Does not require authentication.",
+                "description": "Returns the per-group blob byte total with a per-category breakdown and the active quota. Used by the Settings → Data \u0026 storage card.",
                 "produces": [
-                    "application/vnd.api+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "invites"
+                    "storage"
                 ],
-                "summary": "Get invite info",
+                "summary": "Get storage usage for a group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Invite token",
-                        "name": "token",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
                     }
@@ -7750,21 +8658,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.InviteInfoResponse"
+                            "$ref": "#/definitions/services.StorageUsage"
                         }
                     },
-                    "404": {
-                        "description": "Invite not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/invites/{token}/accept": {
-            "post": {
-                "description": "Accepts a single-use invite link and joins the group with the role stored on the invite. Requires auth. If invitee_email is set, user email must match (trimmed, case-insensitive); else 422 ` + "`" + `invite.email_mismatch` + "`" + `.",
+        "/g/{groupSlug}/tags": {
+            "get": {
+                "description": "get tags of the given kind. Pass include=usage to attach a per-row meta.usage block. kind=commodity|file is required — item-tags and file-tags are separate entities.",
                 "consumes": [
                     "application/vnd.api+json"
                 ],
@@ -7772,39 +8686,130 @@ const docTemplate = `{
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "invites"
+                    "tags"
                 ],
-                "summary": "Accept invite",
+                "summary": "List tags",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Invite token",
-                        "name": "token",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "commodity",
+                            "file"
+                        ],
+                        "type": "string",
+                        "description": "Tag kind (commodity = item-tags, file = file-tags)",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by label or slug",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "label",
+                            "created_at",
+                            "usage"
+                        ],
+                        "type": "string",
+                        "description": "Sort field (label, created_at, usage)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "usage"
+                        ],
+                        "type": "string",
+                        "description": "Comma-separated extras. 'usage' attaches per-row meta.usage.",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.GroupMembershipResponse"
+                            "$ref": "#/definitions/jsonapi.TagsResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Missing or invalid kind value",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
+                    }
+                }
+            },
+            "post": {
+                "description": "add a tag with slug, label, color",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a new tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
                     },
-                    "404": {
-                        "description": "Invite not found",
+                    {
+                        "description": "Tag object",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tag created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.TagResponse"
                         }
                     },
                     "422": {
-                        "description": "Invite expired, already used, user already a member, or user email (trim + case-insensitive) does not match invitee email",
+                        "description": "User-side request problem",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -7812,79 +8817,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/commodities/scan": {
-            "post": {
-                "description": "Anonymous variant of the photo scan for the landing-page CTA (#1988). Unauthenticated, IP + global-daily rate limited, gated behind a deployment feature flag. Returns field guesses only and persists nothing.",
+        "/g/{groupSlug}/tags/autocomplete": {
+            "get": {
+                "description": "Top-N tags of the given kind matching the query, ranked by usage desc + created_at desc.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
                     "application/vnd.api+json"
                 ],
                 "tags": [
-                    "commodities"
+                    "tags"
                 ],
-                "summary": "Run an AI vision scan on uploaded photos or documents (public)",
+                "summary": "Autocomplete tag suggestions",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Product photo(s) or PDF document(s); image/jpeg|jpg|png|webp|heic|heif or application/pdf. Repeat the field to upload up to 5 files (multipart/form-data with multiple ` + "`" + `photos` + "`" + ` parts).",
-                        "name": "photos",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
                         "required": true
                     },
                     {
+                        "enum": [
+                            "commodity",
+                            "file"
+                        ],
                         "type": "string",
-                        "description": "Optional free-form hint (brand, category guess)",
-                        "name": "hint",
-                        "in": "formData"
+                        "description": "Tag kind (commodity = item-tags, file = file-tags)",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Substring match against label and slug",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum suggestions returned",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/jsonapi.CommodityScanResponse"
-                        }
-                    },
-                    "413": {
-                        "description": "File too large",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "415": {
-                        "description": "Unsupported MIME type",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
+                            "$ref": "#/definitions/jsonapi.TagAutocompleteResponse"
                         }
                     },
                     "422": {
-                        "description": "Too many files / no files",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "429": {
-                        "description": "Rate limited",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "502": {
-                        "description": "Provider unavailable / parse error",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "503": {
-                        "description": "Provider disabled",
-                        "schema": {
-                            "$ref": "#/definitions/jsonapi.Errors"
-                        }
-                    },
-                    "504": {
-                        "description": "Provider timed out",
+                        "description": "Missing or invalid kind value",
                         "schema": {
                             "$ref": "#/definitions/jsonapi.Errors"
                         }
@@ -7892,131 +8879,168 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
-            "post": {
-                "description": "Create a user. Valid invite_token: account active, no email verification (caller still POSTs /invites/{token}/accept after login). Without an invite: mode decides (open, approval, or 403 closed).",
+        "/g/{groupSlug}/tags/stats": {
+            "get": {
+                "description": "Returns total tags, plus tagged/untagged counts on commodities and files for the current group.",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "registration"
+                    "tags"
                 ],
-                "summary": "Register a new user",
+                "summary": "Tag adoption stats",
                 "parameters": [
                     {
-                        "description": "Registration data (optionally including invite_token)",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apiserver.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - registration accepted",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request - invalid body, expired/used invite, invalid password, or registration email (trim + case-insensitive) does not match invitee email",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - registrations are closed and no valid invite was supplied",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.TagStatsResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/g/{groupSlug}/tags/{tagID}": {
+            "get": {
+                "description": "get tag by ID with usage breakdown",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.TagResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable - registration mode is misconfigured or invite-based registration is not wired",
+                    "404": {
+                        "description": "Tag not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            }
-        },
-        "/resend-verification": {
-            "post": {
-                "description": "Issue a new email verification link for an unverified account. Always responds with success to prevent email enumeration.",
+            },
+            "delete": {
+                "description": "Delete tag by ID. Returns 409 with usage breakdown when in use; pass ?force=true to strip references.",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "registration"
+                    "tags"
                 ],
-                "summary": "Resend verification email",
+                "summary": "Delete a tag",
                 "parameters": [
                     {
-                        "description": "Email to resend verification to",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apiserver.ResendVerificationRequest"
-                        }
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Strip JSONB references then delete",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No content"
+                    },
+                    "404": {
+                        "description": "Tag not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Tag is in use; pass force=true to strip references",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            }
-        },
-        "/reset-password": {
-            "post": {
-                "description": "Validate a password-reset token and update the user's password. Invalidates all existing sessions.",
+            },
+            "patch": {
+                "description": "Patch label/color/slug. Slug change rewrites JSONB references.",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "auth"
+                    "tags"
                 ],
-                "summary": "Reset password using a token",
+                "summary": "Update a tag",
                 "parameters": [
                     {
-                        "description": "Reset token and new password",
-                        "name": "data",
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag patch payload",
+                        "name": "tag",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apiserver.ResetPasswordRequest"
+                            "$ref": "#/definitions/jsonapi.TagUpdateRequest"
                         }
                     }
                 ],
@@ -8024,325 +9048,3201 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/jsonapi.TagResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request - missing, invalid, or expired token",
+                    "404": {
+                        "description": "Tag not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Slug already in use",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "User-side request problem",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/seed": {
+        "/g/{groupSlug}/transfers": {
             "post": {
-                "description": "Seed the database with example data. Optionally specify user_email and tenant_slug in request body.",
+                "description": "Moves the selected locations (with their areas and commodities),\nareas or commodities from the group in the path to another group of\nthe same tenant, together with their files, tags, loans, services,\nmaintenance and event history. The caller needs at least the user\nrole in both groups. Prices are converted with exchange_rate when the\ngroup currencies differ. A \"transferred\" event is logged in both groups.",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "admin"
+                    "groups"
                 ],
-                "summary": "Seed database",
+                "summary": "Transfer inventory to another group",
                 "parameters": [
                     {
-                        "description": "Seed options (optional)",
+                        "type": "string",
+                        "description": "Source group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "What to transfer and where",
                         "name": "body",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apiserver.SeedRequest"
+                            "$ref": "#/definitions/jsonapi.GroupTransferRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Transferred",
                         "schema": {
-                            "$ref": "#/definitions/apiserver.SeedResponse"
+                            "$ref": "#/definitions/jsonapi.GroupTransferResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a user of both groups",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "404": {
+                        "description": "Group, location, area or commodity not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "423": {
+                        "description": "A group is locked by a currency migration",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/system": {
+        "/g/{groupSlug}/upload-slots/check": {
             "get": {
-                "description": "get comprehensive system information including version, runtime metrics, and settings",
+                "description": "Check if user can start an upload for a specific operation",
                 "consumes": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "system"
+                    "upload-slots"
+                ],
+                "summary": "Check upload capacity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "image_upload",
+                        "description": "Operation name",
+                        "name": "operation",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get system information",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Upload capacity available",
                         "schema": {
-                            "$ref": "#/definitions/apiserver.SystemInfo"
+                            "$ref": "#/definitions/jsonapi.UploadStatusResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/users/me/login-history": {
-            "get": {
-                "description": "Returns the authenticated user's most recent login attempts (default 100, max 500). Also returns failed_last_7d for the optional banner.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users-me"
-                ],
-                "summary": "List login history",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Cap on number of events returned (default 100, max 500)",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
-                            "$ref": "#/definitions/apiserver.LoginHistoryResponse"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many concurrent uploads",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/users/me/sessions": {
+        "/g/{groupSlug}/upload-slots/status": {
             "get": {
-                "description": "Returns the authenticated user's active refresh-token sessions, with a flag identifying the session bound to the current refresh cookie.",
+                "description": "Get current upload status for a specific operation",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "users-me"
+                    "upload-slots"
+                ],
+                "summary": "Get upload status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "image_upload",
+                        "description": "Operation name",
+                        "name": "operation",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "List active sessions",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Upload status retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/apiserver.SessionsListResponse"
+                            "$ref": "#/definitions/jsonapi.UploadStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Revoke every refresh token for the authenticated user except the one identified as current.\nResolution order for the \"current\" session: ` + "`" + `?keep_id=\u003cid\u003e` + "`" + ` (recommended; the id the FE\nrendered with ` + "`" + `is_current: true` + "`" + ` on GET /users/me/sessions) → access token ` + "`" + `rti` + "`" + ` claim\n→ refresh cookie hash. When none matches, the request is rejected with 400 (the current\nsession can't be identified, so revoking everything is refused).",
+            }
+        },
+        "/g/{groupSlug}/uploads/file": {
+            "post": {
+                "description": "Upload a single file of any type. The file is stored and a file entity is created without a linked entity.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "users-me"
+                    "uploads"
                 ],
-                "summary": "Revoke all other sessions",
+                "summary": "Upload a file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Session id to keep alive (the is_current row from GET /users/me/sessions). Optional — when omitted the BE falls back to the access token's rti claim, then the refresh cookie.",
-                        "name": "keep_id",
-                        "in": "query"
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.FileResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "413": {
+                        "description": "Request Entity Too Large",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/users/me/sessions/{id}": {
-            "delete": {
-                "description": "Mark a single refresh token as revoked. Returns 404 if the id does not belong to the authenticated user.",
+        "/g/{groupSlug}/uploads/restores": {
+            "post": {
+                "description": "Upload a signed ` + "`" + `.inb` + "`" + ` backup file to be used for a restore operation.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "users-me"
+                    "uploads"
                 ],
-                "summary": "Revoke one session",
+                "summary": "Upload restore file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Session ID",
-                        "name": "id",
+                        "description": "Group slug",
+                        "name": "groupSlug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Signed .inb backup file to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.UploadResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "413": {
+                        "description": "Request Entity Too Large",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
         },
-        "/verify-email": {
+        "/groups": {
             "get": {
-                "description": "Activate a user account using the verification token sent by email.",
+                "description": "Returns all active location groups the authenticated user is a member of",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.api+json"
                 ],
                 "tags": [
-                    "registration"
-                ],
-                "summary": "Verify email address",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Email verification token",
-                        "name": "token",
-                        "in": "query",
-                        "required": true
-                    }
+                    "groups"
                 ],
+                "summary": "List user's groups",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/jsonapi.LocationGroupsResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request - missing, invalid, or expired token",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new location group with a random non-guessable slug. The creator becomes the group admin.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Create a group",
+                "parameters": [
+                    {
+                        "description": "Group data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.LocationGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.LocationGroupResponse"
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Validation error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/jsonapi.Errors"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "/groups/{groupID}": {
+            "get": {
+                "description": "Returns details of a location group. Requires group membership.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get group details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.LocationGroupResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group member",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Initiates async deletion of a location group. Requires typing the group name AND the caller's current password. Requires group admin role. Failed attempts are audit-logged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Delete group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Deletion confirmation (confirm_word + password)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid confirmation word or password",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a location group's name, icon, and description. The group_currency field is set once at creation and cannot be changed here — see issue #202 for the currency-migration tool. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Update group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.LocationGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.LocationGroupResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupID}/invites": {
+            "get": {
+                "description": "Returns all non-expired, unused invite links for a group. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "List active invites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupInvitesResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an invite. When the request body carries ` + "`" + `email` + "`" + `, the BE persists invitee_email on\nthe row and dispatches an email via EmailService; when empty, the invite remains a copy-paste\ntoken. ` + "`" + `role` + "`" + ` (viewer / user / admin) defaults to \"user\"; owner-by-invite is rejected — owner\nis a transfer-of-ownership operation. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Create invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional invitee email + role",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupInviteCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupInviteResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid email / role",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupID}/invites/{inviteID}": {
+            "delete": {
+                "description": "Deletes an unused invite link. Cannot revoke an already-used invite. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Revoke invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invite ID",
+                        "name": "inviteID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Cannot revoke a used invite",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupID}/invites/{inviteID}/resend": {
+            "post": {
+                "description": "Mints a new token and expiry on an email-flow invite, then dispatches a fresh email. Legacy token-only invites cannot be resent — create a new invite or recopy the URL. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Resend invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invite ID",
+                        "name": "inviteID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupInviteResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Invite not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Invite already used, belongs to another group, or has no invitee email",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupID}/leave": {
+            "post": {
+                "description": "Removes the current user from a location group. Cannot leave if you are the last admin.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Leave group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group member",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Cannot leave as the last admin",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupID}/members": {
+            "get": {
+                "description": "Returns all members of a location group with their roles and joined user data (id / name / email). Requires group membership.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "List group members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.MembershipsWithUsersResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group member",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupID}/members/{memberUserID}": {
+            "delete": {
+                "description": "Removes a user from a location group. Cannot remove the last admin. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Remove group member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the member to remove",
+                        "name": "memberUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Cannot remove the last admin",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes a member's role (admin/user) within a location group. Cannot demote the last admin. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Update member role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the member",
+                        "name": "memberUserID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupMembershipRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupMembershipResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Cannot demote the last admin",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupID}/members/{memberUserID}/scopes": {
+            "get": {
+                "description": "Returns the per-location roles that restrict a member to specific locations. An empty list means the member sees the whole group at their membership role. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get member location scopes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the member",
+                        "name": "memberUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.MemberLocationScopesResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Restricts a viewer or user member to the listed locations, each with its own role (viewer, user or admin). The list replaces any previous restriction; an empty list lifts it. Admins and owners cannot be restricted. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Set member location scopes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the member",
+                        "name": "memberUserID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location scopes",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.MemberLocationScopesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.MemberLocationScopesResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid scope, location outside the group, or member is an admin",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes every location restriction of a member so they see the whole group at their membership role. Requires group admin role.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Clear member location scopes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the member",
+                        "name": "memberUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden - not a group admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/invites/{token}": {
+            "get": {
+                "description": "Returns public information about an invite link including group name and whether it has expired or been used. Does not require authentication.",
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "invites"
+                ],
+                "summary": "Get invite info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.InviteInfoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invite not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/invites/{token}/accept": {
+            "post": {
+                "description": "Accepts a single-use invite link and joins the group with the role stored on the invite. Requires auth. If invitee_email is set, user email must match (trimmed, case-insensitive); else 422 ` + "`" + `invite.email_mismatch` + "`" + `.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "invites"
+                ],
+                "summary": "Accept invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.GroupMembershipResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Invite not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Invite expired, already used, user already a member, or user email (trim + case-insensitive) does not match invitee email",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/calendar/{token}.ics": {
+            "get": {
+                "description": "Unauthenticated. The token in the path is the feed's secret; calendar clients subscribe to this URL. Unknown, rotated and revoked tokens return 404.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "Download a calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/commodities/scan": {
+            "post": {
+                "description": "Anonymous variant of the photo scan for the landing-page CTA (#1988). Unauthenticated, IP + global-daily rate limited, gated behind a deployment feature flag. Returns field guesses only and persists nothing.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "commodities"
+                ],
+                "summary": "Run an AI vision scan on uploaded photos or documents (public)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Product photo(s) or PDF document(s); image/jpeg|jpg|png|webp|heic|heif or application/pdf. Repeat the field to upload up to 5 files (multipart/form-data with multiple ` + "`" + `photos` + "`" + ` parts).",
+                        "name": "photos",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional free-form hint (brand, category guess)",
+                        "name": "hint",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.CommodityScanResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported MIME type",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Too many files / no files",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "502": {
+                        "description": "Provider unavailable / parse error",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "503": {
+                        "description": "Provider disabled",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "504": {
+                        "description": "Provider timed out",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/loans/{loanID}": {
+            "get": {
+                "description": "Unauthenticated. The sig / exp pair comes from the signed link the owner shared or the reminder email carried.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "commodity_loans"
+                ],
+                "summary": "Get a loan via a borrower link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "loanID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "sig",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link expiry (unix seconds)",
+                        "name": "exp",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.PublicLoanResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired link",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/loans/{loanID}/requests": {
+            "post": {
+                "description": "Unauthenticated. Record a return confirmation or an extension request for the owner to review. A newer request replaces an unreviewed one.",
+                "consumes": [
+                    "application/vnd.api+json"
+                ],
+                "produces": [
+                    "application/vnd.api+json"
+                ],
+                "tags": [
+                    "commodity_loans"
+                ],
+                "summary": "Submit a borrower request via a borrower link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "loanID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "sig",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link expiry (unix seconds)",
+                        "name": "exp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Borrower request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.LoanBorrowerRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.PublicLoanResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired link",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "409": {
+                        "description": "Loan already returned",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "User-side request problem",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Create a user. Valid invite_token: account active, no email verification (caller still POSTs /invites/{token}/accept after login). Without an invite: mode decides (open, approval, or 403 closed).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registration"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Registration data (optionally including invite_token)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - registration accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - invalid body, expired/used invite, invalid password, or registration email (trim + case-insensitive) does not match invitee email",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - registrations are closed and no valid invite was supplied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - registration mode is misconfigured or invite-based registration is not wired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/resend-verification": {
+            "post": {
+                "description": "Issue a new email verification link for an unverified account. Always responds with success to prevent email enumeration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registration"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "Email to resend verification to",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reset-password": {
+            "post": {
+                "description": "Validate a password-reset token and update the user's password. Invalidates all existing sessions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Reset password using a token",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - missing, invalid, or expired token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/seed": {
+            "post": {
+                "description": "Seed the database with example data. Optionally specify user_email and tenant_slug in request body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Seed database",
+                "parameters": [
+                    {
+                        "description": "Seed options (optional)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.SeedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.SeedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/system": {
+            "get": {
+                "description": "get comprehensive system information including version, runtime metrics, and settings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Get system information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.SystemInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/calendar-feeds": {
+            "get": {
+                "description": "Returns the authenticated user's calendar subscription feeds. Feed URLs are not included; they are shown once on create and rotate.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "List calendar feeds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.CalendarFeedsListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an iCalendar subscription feed of warranty expirations, loan due dates, service returns and maintenance due dates. The response carries the subscription URL, which is shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "Create a calendar feed",
+                "parameters": [
+                    {
+                        "description": "Feed settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.CalendarFeedCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.CalendarFeedView"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member of the group",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown category or invalid name",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/calendar-feeds/{id}": {
+            "delete": {
+                "description": "Deletes the feed. Its URL stops working immediately.",
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "Revoke a calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes a feed's name and categories. The subscription URL is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "Update a calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Feed settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.CalendarFeedUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.CalendarFeedView"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown category or invalid name",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/calendar-feeds/{id}/rotate": {
+            "post": {
+                "description": "Replaces the feed's secret token. The old URL stops working immediately; the new one is returned once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "Rotate a calendar feed URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.CalendarFeedView"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/jsonapi.Errors"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/login-history": {
+            "get": {
+                "description": "Returns the authenticated user's most recent login attempts (default 100, max 500). Also returns failed_last_7d for the optional banner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "List login history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cap on number of events returned (default 100, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.LoginHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions": {
+            "get": {
+                "description": "Returns the authenticated user's active refresh-token sessions, with a flag identifying the session bound to the current refresh cookie.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "List active sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apiserver.SessionsListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revoke every refresh token for the authenticated user except the one identified as current.\nResolution order for the \"current\" session: ` + "`" + `?keep_id=\u003cid\u003e` + "`" + ` (recommended; the id the FE\nrendered with ` + "`" + `is_current: true` + "`" + ` on GET /users/me/sessions) → access token ` + "`" + `rti` + "`" + ` claim\n→ refresh cookie hash. When none matches, the request is rejected with 400 (the current\nsession can't be identified, so revoking everything is refused).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "Revoke all other sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session id to keep alive (the is_current row from GET /users/me/sessions). Optional — when omitted the BE falls back to the access token's rti claim, then the refresh cookie.",
+                        "name": "keep_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions/{id}": {
+            "delete": {
+                "description": "Mark a single refresh token as revoked. Returns 404 if the id does not belong to the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-me"
+                ],
+                "summary": "Revoke one session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email": {
+            "get": {
+                "description": "Activate a user account using the verification token sent by email.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registration"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - missing, invalid, or expired token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
         "apiserver.AdminAddMemberRequest": {
             "type": "object",
-            "required": [
-                "role",
-                "userID"
-            ],
+            "required": [
+                "role",
+                "userID"
+            ],
+            "properties": {
+                "role": {
+                    "description": "Role is the group role granted to the user: viewer|user|admin|owner.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GroupRole"
+                        }
+                    ]
+                },
+                "userID": {
+                    "description": "UserID is the ID of the user being added to the group.",
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.AdminBlockRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "force": {
+                    "description": "Force overrides the \"cannot block another system admin\" guard.\nHas no effect when blocking a non-admin user.",
+                    "type": "boolean"
+                },
+                "reason": {
+                    "description": "Reason is the free-form justification for the block (max 500 chars).",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "apiserver.AdminMemberEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/apiserver.AdminMemberResource"
+                }
+            }
+        },
+        "apiserver.AdminMemberResource": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/apiserver.AdminMemberView"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.AdminMemberView": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "member_user_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/models.GroupRole"
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.AdminPingResponse": {
+            "type": "object",
+            "properties": {
+                "ok": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.AdminRegistrationDecisionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Confirmed with the household owner"
+                }
+            }
+        },
+        "apiserver.AdminUnblockRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Reason is the free-form justification for the unblock (max 500 chars).",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "apiserver.AdminUpdateMemberRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "Role is the new group role: viewer|user|admin|owner.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GroupRole"
+                        }
+                    ]
+                }
+            }
+        },
+        "apiserver.AdminUserEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/apiserver.AdminUserResource"
+                }
+            }
+        },
+        "apiserver.AdminUserResource": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/apiserver.AdminUserView"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.AdminUserView": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_system_admin": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.BackofficeLoginMFARequest": {
+            "type": "object",
+            "properties": {
+                "backup_code": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "totp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.BackofficeLoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.BackofficeLoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "token_type": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/apiserver.BackofficeProfile"
+                }
+            }
+        },
+        "apiserver.BackofficeLogoutResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.BackofficeMFARequiredResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code mirrors a stable identifier so FE clients can branch on it\ninstead of an HTTP status alone. Today only the 501 branch sets\n` + "`" + `backofficeMFANotImplementedCode` + "`" + `; future codes can be added\nwithout breaking older FE clients.",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "ExpiresIn carries the MFAToken lifetime in seconds so the FE can\ndisable the code-entry surface when the token lapses. Zero on the\n501 branch.",
+                    "type": "integer"
+                },
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "description": "MFAToken is the short-lived (5 min) step-1 token that the FE must\necho back to /login/mfa alongside the TOTP / backup code. Empty\non the 501 (not-implemented) branch.",
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.BackofficeProfile": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_login_at": {
+                    "type": "string"
+                },
+                "mfa_enforced": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.BackupKeyAddRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt optionally stops trusting the key at that time. Must be in\nthe future.",
+                    "type": "string"
+                },
+                "label": {
+                    "description": "Label is the operator's name for the key (max 200 chars).",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "public_key": {
+                    "description": "PublicKey is the Ed25519 public key to trust, as a PEM \"PUBLIC KEY\"\nblock, standard base64 or hex (the forms GET /backup/public-key and\n` + "`" + `inventario backup resign --verify-key` + "`" + ` use).",
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.BackupKeyRevokeRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Reason is the optional operator-supplied note for the revocation (max\n500 chars).",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "apiserver.BackupPublicKeyResponse": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "description": "Algorithm names the hash-then-sign construction (\"ed25519-sha256\").",
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "description": "Fingerprint is the lowercase hex SHA-256 of the raw public key, a stable\nshort identifier for the signing key (useful around key rotation).",
+                    "type": "string"
+                },
+                "public_key": {
+                    "description": "PublicKey is the PKIX-encoded PEM public key (\"PUBLIC KEY\" block) — the\nform openssl and Go's x509.ParsePKIXPublicKey expect.",
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.CalendarFeedCreateRequest": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.CalendarFeedUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.CalendarFeedView": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.CalendarFeedsListResponse": {
+            "type": "object",
+            "properties": {
+                "feeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.CalendarFeedView"
+                    }
+                }
+            }
+        },
+        "apiserver.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.DeleteAccountRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.FeatureFlags": {
+            "type": "object",
+            "properties": {
+                "currency_migration": {
+                    "description": "CurrencyMigration mirrors Params.FeatureCurrencyMigration. When\nfalse the /currency-migrations endpoints return a coded 404 and\nthe FE hides the wizard entry point + history sheet on the\ngroup-settings page.",
+                    "type": "boolean"
+                },
+                "magic_link_login": {
+                    "description": "MagicLinkLogin mirrors Params.MagicLinkLoginEnabled. The entry point\nis pre-login (a \"Email me a sign-in link\" affordance on the Login\npage), so it is exposed here on the public /feature-flags surface\nrather than the auth-gated /system endpoint. When false the FE hides\nthe affordance and the /auth/magic-link routes return 404.",
+                    "type": "boolean"
+                },
+                "public_scan": {
+                    "description": "PublicScan mirrors Params.PublicScanEnabled. The entry point is the\nanonymous landing-page \"add your first item\" CTA (#1988), so — like\nMagicLinkLogin — it is read pre-login on this public surface. When\nfalse the FE hides the CTA and the POST /public/commodities/scan\nroute is not mounted (404).",
+                    "type": "boolean"
+                }
+            }
+        },
+        "apiserver.FeedbackRequest": {
+            "type": "object",
+            "required": [
+                "message",
+                "type"
+            ],
+            "properties": {
+                "diagnostics": {
+                    "description": "Diagnostics is the FE-controlled set of debug attributes. Keys\nare surfaced verbatim — the BE does not whitelist or rewrite\nthem — but the BE caps per-line size and the number of entries.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "description": "Message is the free-form body. Required, trimmed, capped at\nfeedbackMaxMessageBytes.",
+                    "type": "string"
+                },
+                "reply_to_email": {
+                    "description": "ReplyToEmail is optional. When set the value goes into the email\nbody and (in the async sender) into the Reply-To header. Empty\nmeans \"the submitter declined to share a reply-to address\".",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is one of \"feedback\" | \"bug\" | \"feature\" | \"question\". The\nFE renders these as radio chips; the backend uses the value\nverbatim in the email subject and body. Unknown values are\nrejected with 400.",
+                    "type": "string",
+                    "enum": [
+                        "feedback",
+                        "bug",
+                        "feature",
+                        "question"
+                    ]
+                }
+            }
+        },
+        "apiserver.FeedbackResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.GroupNotificationsPatchRequest": {
+            "type": "object",
+            "properties": {
+                "warranty_expiring_alerts": {
+                    "type": "boolean"
+                },
+                "weekly_digest": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apiserver.GroupNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "warranty_expiring_alerts": {
+                    "type": "boolean"
+                },
+                "weekly_digest": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apiserver.ImpersonateRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Reason is the optional free-form justification for the\nimpersonation (max 500 chars). The maxLength struct tag surfaces\nthe cap into the generated OpenAPI schema; the handler enforces\nthe same bound at decode time (impersonationReasonMaxLen).",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "apiserver.ImpersonationOperatorView": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ImpersonationStateResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active reports whether the caller is inside an impersonation session.",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "Operator is the back-office operator who initiated the session —\nnil when Active is false. Renamed from ` + "`" + `admin_user` + "`" + ` at #1785\nPhase 5 to reflect that the operator-of-record now lives in the\nback-office identity plane.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apiserver.ImpersonationOperatorView"
+                        }
+                    ]
+                },
+                "started_at": {
+                    "description": "StartedAt / ExpiresAt bound the session — zero values when inactive.",
+                    "type": "string"
+                },
+                "target_user": {
+                    "description": "TargetUser is the impersonated tenant user — nil when Active is false.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apiserver.ImpersonationUserView"
+                        }
+                    ]
+                }
+            }
+        },
+        "apiserver.ImpersonationUserView": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.LoginEventView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "method": {
+                    "$ref": "#/definitions/models.LoginMethod"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/models.LoginOutcome"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.LoginHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.LoginEventView"
+                    }
+                },
+                "failed_last_7d": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apiserver.LoginMFARequest": {
+            "type": "object",
+            "properties": {
+                "backup_code": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "totp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "csrf_token": {
+                    "description": "CSRF token for protecting state-changing requests",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "seconds until access token expiry",
+                    "type": "integer"
+                },
+                "token_type": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "apiserver.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.MFADisableRequest": {
+            "type": "object",
+            "properties": {
+                "backup_code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "totp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.MFASetupResponse": {
+            "type": "object",
+            "properties": {
+                "qr_code_url": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.MFAState": {
+            "type": "string",
+            "enum": [
+                "none",
+                "pending",
+                "active"
+            ],
+            "x-enum-varnames": [
+                "MFAStateNone",
+                "MFAStatePending",
+                "MFAStateActive"
+            ]
+        },
+        "apiserver.MFAStatusResponse": {
+            "type": "object",
+            "properties": {
+                "backup_codes_remaining": {
+                    "type": "integer"
+                },
+                "enabled_at": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/apiserver.MFAState"
+                }
+            }
+        },
+        "apiserver.MFAVerifyRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.MFAVerifyResponse": {
+            "type": "object",
+            "properties": {
+                "backup_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apiserver.MagicLinkRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.MagicLinkVerifyRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.PatchSettingRequest": {
+            "type": "object",
+            "properties": {
+                "value": {
+                    "description": "Value is the setting value to apply and is required when using the object envelope."
+                }
+            }
+        },
+        "apiserver.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "invite_token": {
+                    "description": "InviteToken, when present and valid, allows registration even if\nRegistrationMode is closed and suppresses the email-verification step\n(the invite itself vouches for the user). The token is NOT consumed\nhere — the caller must POST /api/v1/invites/{token}/accept after\nlogging in. See issue #1219 §7 and #1285.\n\nWhen the invite carries ` + "`" + `invitee_email` + "`" + ` (the #1533 email-flow path),\nthe registration ` + "`" + `email` + "`" + ` must match it after trim + case-insensitive\nnormalization, otherwise the request is rejected with 400. A\nwhitespace-only invitee_email (only reachable via direct registry\nwrite — the JSON-API binder strips whitespace) is treated as\ninvalid and rejected. Legacy token-only invites (invitee_email ==\nnil) keep working unchanged. See #1221.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.RegistrationRequestEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/apiserver.RegistrationRequestResource"
+                }
+            }
+        },
+        "apiserver.RegistrationRequestListEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.RegistrationRequestResource"
+                    }
+                }
+            }
+        },
+        "apiserver.RegistrationRequestResource": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/apiserver.RegistrationRequestView"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.RegistrationRequestView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ]
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ResendVerificationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ScanBudgetEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/apiserver.ScanBudgetResource"
+                }
+            }
+        },
+        "apiserver.ScanBudgetListEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.ScanBudgetResource"
+                    }
+                }
+            }
+        },
+        "apiserver.ScanBudgetResource": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/apiserver.ScanBudgetView"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ScanBudgetSetRequest": {
+            "type": "object",
+            "properties": {
+                "monthly_limit_usd": {
+                    "description": "MonthlyLimitUSD is the spend cap per calendar month (UTC), in USD.\nZero blocks every scan.",
+                    "type": "string",
+                    "example": "25.00"
+                }
+            }
+        },
+        "apiserver.ScanBudgetView": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "monthly_limit_usd": {
+                    "type": "string"
+                },
+                "spent_usd": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ScanUsageEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.ScanUsageResource"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/apiserver.ScanUsageMeta"
+                }
+            }
+        },
+        "apiserver.ScanUsageMeta": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "string"
+                },
+                "scans": {
+                    "type": "integer"
+                },
+                "tokens_used": {
+                    "type": "integer"
+                },
+                "total_cost_usd": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ScanUsageResource": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/apiserver.ScanUsageView"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.ScanUsageView": {
+            "type": "object",
+            "properties": {
+                "cost_usd": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "scans": {
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "tokens_used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apiserver.SeedRequest": {
+            "type": "object",
+            "properties": {
+                "tenant_slug": {
+                    "type": "string"
+                },
+                "user_email": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.SeedResponse": {
+            "type": "object",
+            "properties": {
+                "already_seeded": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.SessionView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.SessionsListResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.SessionView"
+                    }
+                }
+            }
+        },
+        "apiserver.SettingsUpdateRequest": {
+            "type": "object",
             "properties": {
-                "role": {
-                    "description": "Role is the group role granted to the user: viewer|user|admin|owner.",
+                "default_date_format": {
+                    "description": "DefaultDateFormat is the uiconfig.default_date_format value accepted by PUT /settings.",
+                    "type": "string"
+                },
+                "show_debug_info": {
+                    "description": "ShowDebugInfo is the uiconfig.show_debug_info value accepted by PUT /settings.",
+                    "type": "boolean"
+                },
+                "theme": {
+                    "description": "Theme is the uiconfig.theme value accepted by PUT /settings.",
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.SystemInfo": {
+            "type": "object",
+            "properties": {
+                "build_date": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "database_backend": {
+                    "description": "System information",
+                    "type": "string"
+                },
+                "debug": {
+                    "description": "Debug reflects INVENTARIO_DEBUG_UI. When true the frontend shows\ncrash details (error message + stack) on its error screen. Off by\ndefault so production never exposes a stack trace to end users.",
+                    "type": "boolean"
+                },
+                "file_storage_backend": {
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "memory_usage": {
+                    "type": "string"
+                },
+                "num_cpu": {
+                    "type": "integer"
+                },
+                "num_goroutines": {
+                    "type": "integer"
+                },
+                "operating_system": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "settings": {
+                    "description": "Settings information",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.GroupRole"
+                            "$ref": "#/definitions/models.SettingsObject"
                         }
                     ]
                 },
-                "userID": {
-                    "description": "UserID is the ID of the user being added to the group.",
+                "uptime": {
+                    "description": "Runtime metrics",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version information",
                     "type": "string"
                 }
             }
         },
-        "apiserver.AdminBlockRequest": {
+        "apiserver.TrustedBackupKeyEnvelope": {
             "type": "object",
-            "required": [
-                "reason"
-            ],
             "properties": {
-                "force": {
-                    "description": "Force overrides the \"cannot block another system admin\" guard.\nHas no effect when blocking a non-admin user.",
-                    "type": "boolean"
-                },
-                "reason": {
-                    "description": "Reason is the free-form justification for the block (max 500 chars).",
-                    "type": "string",
-                    "maxLength": 500
+                "data": {
+                    "$ref": "#/definitions/apiserver.TrustedBackupKeyResource"
                 }
             }
         },
-        "apiserver.AdminMemberEnvelope": {
+        "apiserver.TrustedBackupKeyListEnvelope": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/apiserver.AdminMemberResource"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.TrustedBackupKeyResource"
+                    }
                 }
             }
         },
-        "apiserver.AdminMemberResource": {
+        "apiserver.TrustedBackupKeyResource": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/apiserver.AdminMemberView"
+                    "$ref": "#/definitions/apiserver.TrustedBackupKeyView"
                 },
                 "id": {
                     "type": "string"
@@ -8352,409 +12252,576 @@ const docTemplate = `{
                 }
             }
         },
-        "apiserver.AdminMemberView": {
+        "apiserver.TrustedBackupKeyView": {
             "type": "object",
             "properties": {
-                "group_id": {
+                "active": {
+                    "type": "boolean"
+                },
+                "added_at": {
                     "type": "string"
                 },
-                "joined_at": {
+                "added_by": {
                     "type": "string"
                 },
-                "member_user_id": {
+                "expires_at": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/models.GroupRole"
+                "fingerprint": {
+                    "type": "string"
                 },
-                "tenant_id": {
+                "label": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "revoke_reason": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "revoked_by": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.AdminPingResponse": {
+        "apiserver.WorkerControlEnvelope": {
             "type": "object",
             "properties": {
-                "ok": {
+                "data": {
+                    "$ref": "#/definitions/apiserver.WorkerControlResource"
+                }
+            }
+        },
+        "apiserver.WorkerControlListEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.WorkerControlResource"
+                    }
+                }
+            }
+        },
+        "apiserver.WorkerControlResource": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/apiserver.WorkerControlView"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apiserver.WorkerControlView": {
+            "type": "object",
+            "properties": {
+                "paused": {
                     "type": "boolean"
                 },
-                "timestamp": {
+                "paused_at": {
+                    "type": "string"
+                },
+                "paused_by": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "worker_type": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.AdminUnblockRequest": {
+        "apiserver.WorkerPauseRequest": {
             "type": "object",
-            "required": [
-                "reason"
-            ],
             "properties": {
                 "reason": {
-                    "description": "Reason is the free-form justification for the unblock (max 500 chars).",
+                    "description": "Reason is the optional operator-supplied note for the pause (max 500\nchars). Persisted into worker_control.reason and the audit breadcrumb.",
                     "type": "string",
                     "maxLength": 500
                 }
             }
         },
-        "apiserver.AdminUpdateMemberRoleRequest": {
+        "apiserver.linkedIdentitiesResponse": {
             "type": "object",
-            "required": [
-                "role"
-            ],
             "properties": {
-                "role": {
-                    "description": "Role is the new group role: viewer|user|admin|owner.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.GroupRole"
-                        }
-                    ]
+                "identities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.linkedIdentityEntry"
+                    }
                 }
             }
         },
-        "apiserver.AdminUserEnvelope": {
+        "apiserver.linkedIdentityEntry": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/apiserver.AdminUserResource"
+                "email": {
+                    "type": "string"
+                },
+                "linked_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
                 }
             }
         },
-        "apiserver.AdminUserResource": {
+        "apiserver.providerListEntry": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/apiserver.AdminUserView"
-                },
-                "id": {
+                "display_name": {
                     "type": "string"
                 },
-                "type": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.AdminUserView": {
+        "apiserver.providerListResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apiserver.providerListEntry"
+                    }
+                }
+            }
+        },
+        "changefeed.Action": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "deleted",
+                "reset"
+            ],
+            "x-enum-varnames": [
+                "ActionCreated",
+                "ActionUpdated",
+                "ActionDeleted",
+                "ActionReset"
+            ]
+        },
+        "changefeed.Entity": {
+            "type": "string",
+            "enum": [
+                "commodity",
+                "area",
+                "location",
+                "file",
+                "loan"
+            ],
+            "x-enum-varnames": [
+                "EntityCommodity",
+                "EntityArea",
+                "EntityLocation",
+                "EntityFile",
+                "EntityLoan"
+            ]
+        },
+        "changefeed.Event": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/changefeed.Action"
+                },
+                "actor_user_id": {
                     "type": "string"
                 },
-                "id": {
+                "entity": {
+                    "$ref": "#/definitions/changefeed.Entity"
+                },
+                "entity_id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "group_id": {
+                    "type": "string"
                 },
-                "is_system_admin": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
                 },
-                "name": {
+                "kind": {
+                    "description": "Kind carries the commodity timeline kind (moved, price_changed, ...)\nwhen the event was fed from a CommodityEvent write.",
+                    "type": "string"
+                },
+                "location_id": {
+                    "description": "LocationID is the location the row belongs to, used to filter the\nstream for location-scoped members. Empty when the row is not tied to\na location (e.g. a standalone file).",
                     "type": "string"
                 },
-                "tenant_id": {
+                "occurred_at": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.BackofficeLoginMFARequest": {
+        "debug.Info": {
             "type": "object",
             "properties": {
-                "backup_code": {
+                "database_driver": {
                     "type": "string"
                 },
-                "mfa_token": {
+                "error": {},
+                "file_storage_driver": {
                     "type": "string"
                 },
-                "totp_code": {
+                "operating_system": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.BackofficeLoginRequest": {
+        "jsonapi.AdminGroupDetail": {
             "type": "object",
             "properties": {
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "password": {
+                "created_by": {
                     "type": "string"
-                }
-            }
-        },
-        "apiserver.BackofficeLoginResponse": {
-            "type": "object",
-            "properties": {
-                "access_token": {
+                },
+                "currency": {
                     "type": "string"
                 },
-                "expires_in": {
+                "id": {
+                    "type": "string"
+                },
+                "member_count": {
                     "type": "integer"
                 },
-                "token_type": {
+                "name": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/apiserver.BackofficeProfile"
-                }
-            }
-        },
-        "apiserver.BackofficeLogoutResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LocationGroupStatus"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/jsonapi.AdminGroupTenantChip"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "admin_groups"
+                    ],
+                    "example": "admin_groups"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.BackofficeMFARequiredResponse": {
+        "jsonapi.AdminGroupListItem": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Code mirrors a stable identifier so FE clients can branch on it\ninstead of an HTTP status alone. Today only the 501 branch sets\n` + "`" + `backofficeMFANotImplementedCode` + "`" + `; future codes can be added\nwithout breaking older FE clients.",
+                "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "created_by": {
                     "type": "string"
                 },
-                "expires_in": {
-                    "description": "ExpiresIn carries the MFAToken lifetime in seconds so the FE can\ndisable the code-entry surface when the token lapses. Zero on the\n501 branch.",
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "member_count": {
                     "type": "integer"
                 },
-                "mfa_required": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "mfa_token": {
-                    "description": "MFAToken is the short-lived (5 min) step-1 token that the FE must\necho back to /login/mfa alongside the TOTP / backup code. Empty\non the 501 (not-implemented) branch.",
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LocationGroupStatus"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/jsonapi.AdminGroupTenantChip"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "admin_groups"
+                    ],
+                    "example": "admin_groups"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.BackofficeProfile": {
+        "jsonapi.AdminGroupMember": {
             "type": "object",
             "properties": {
-                "email": {
+                "group_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "last_login_at": {
+                "joined_at": {
                     "type": "string"
                 },
-                "mfa_enforced": {
-                    "type": "boolean"
-                },
-                "name": {
+                "member_user_id": {
                     "type": "string"
                 },
                 "role": {
-                    "type": "string"
+                    "$ref": "#/definitions/models.GroupRole"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "admin_group_members"
+                    ],
+                    "example": "admin_group_members"
+                },
+                "user": {
+                    "$ref": "#/definitions/jsonapi.AdminGroupMemberUser"
                 }
             }
         },
-        "apiserver.BackupPublicKeyResponse": {
+        "jsonapi.AdminGroupMemberUser": {
             "type": "object",
             "properties": {
-                "algorithm": {
-                    "description": "Algorithm names the hash-then-sign construction (\"ed25519-sha256\").",
+                "email": {
                     "type": "string"
                 },
-                "fingerprint": {
-                    "description": "Fingerprint is the lowercase hex SHA-256 of the raw public key, a stable\nshort identifier for the signing key (useful around key rotation).",
+                "id": {
                     "type": "string"
                 },
-                "public_key": {
-                    "description": "PublicKey is the PKIX-encoded PEM public key (\"PUBLIC KEY\" block) — the\nform openssl and Go's x509.ParsePKIXPublicKey expect.",
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.ChangePasswordRequest": {
+        "jsonapi.AdminGroupMembersResponse": {
             "type": "object",
             "properties": {
-                "current_password": {
-                    "type": "string"
-                },
-                "new_password": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.AdminGroupMember"
+                    }
                 }
             }
         },
-        "apiserver.DeleteAccountRequest": {
+        "jsonapi.AdminGroupResponse": {
             "type": "object",
             "properties": {
-                "password": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.AdminGroupDetail"
                 }
             }
         },
-        "apiserver.FeatureFlags": {
+        "jsonapi.AdminGroupTenantChip": {
             "type": "object",
             "properties": {
-                "currency_migration": {
-                    "description": "CurrencyMigration mirrors Params.FeatureCurrencyMigration. When\nfalse the /currency-migrations endpoints return a coded 404 and\nthe FE hides the wizard entry point + history sheet on the\ngroup-settings page.",
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
                 },
-                "magic_link_login": {
-                    "description": "MagicLinkLogin mirrors Params.MagicLinkLoginEnabled. The entry point\nis pre-login (a \"Email me a sign-in link\" affordance on the Login\npage), so it is exposed here on the public /feature-flags surface\nrather than the auth-gated /system endpoint. When false the FE hides\nthe affordance and the /auth/magic-link routes return 404.",
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "public_scan": {
-                    "description": "PublicScan mirrors Params.PublicScanEnabled. The entry point is the\nanonymous landing-page \"add your first item\" CTA (#1988), so — like\nMagicLinkLogin — it is read pre-login on this public surface. When\nfalse the FE hides the CTA and the POST /public/commodities/scan\nroute is not mounted (404).",
-                    "type": "boolean"
+                "slug": {
+                    "type": "string"
                 }
             }
         },
-        "apiserver.FeedbackRequest": {
+        "jsonapi.AdminGroupsResponse": {
             "type": "object",
-            "required": [
-                "message",
-                "type"
-            ],
             "properties": {
-                "diagnostics": {
-                    "description": "Diagnostics is the FE-controlled set of debug attributes. Keys\nare surfaced verbatim — the BE does not whitelist or rewrite\nthem — but the BE caps per-line size and the number of entries.",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.AdminGroupListItem"
                     }
                 },
-                "message": {
-                    "description": "Message is the free-form body. Required, trimmed, capped at\nfeedbackMaxMessageBytes.",
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.AdminListMeta"
+                }
+            }
+        },
+        "jsonapi.AdminListMeta": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "reply_to_email": {
-                    "description": "ReplyToEmail is optional. When set the value goes into the email\nbody and (in the async sender) into the Reply-To header. Empty\nmeans \"the submitter declined to share a reply-to address\".",
-                    "type": "string"
+                "per_page": {
+                    "type": "integer",
+                    "example": 50
                 },
-                "type": {
-                    "description": "Type is one of \"feedback\" | \"bug\" | \"feature\" | \"question\". The\nFE renders these as radio chips; the backend uses the value\nverbatim in the email subject and body. Unknown values are\nrejected with 400.",
-                    "type": "string",
-                    "enum": [
-                        "feedback",
-                        "bug",
-                        "feature",
-                        "question"
-                    ]
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "apiserver.FeedbackResponse": {
+        "jsonapi.AdminTenantListItem": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "group_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "plan_id": {
+                    "type": "string"
+                },
+                "registration_mode": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
                 "status": {
+                    "$ref": "#/definitions/models.TenantStatus"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "admin_tenants"
+                    ],
+                    "example": "admin_tenants"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "user_count": {
+                    "type": "integer"
                 }
             }
         },
-        "apiserver.ForgotPasswordRequest": {
+        "jsonapi.AdminTenantResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.AdminTenantListItem"
                 }
             }
         },
-        "apiserver.GroupNotificationsPatchRequest": {
+        "jsonapi.AdminTenantsResponse": {
             "type": "object",
             "properties": {
-                "warranty_expiring_alerts": {
-                    "type": "boolean"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.AdminTenantListItem"
+                    }
                 },
-                "weekly_digest": {
-                    "type": "boolean"
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.AdminListMeta"
                 }
             }
         },
-        "apiserver.GroupNotificationsResponse": {
+        "jsonapi.AdminUserDetail": {
             "type": "object",
             "properties": {
-                "warranty_expiring_alerts": {
-                    "type": "boolean"
+                "active_session_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
                 },
-                "weekly_digest": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "apiserver.ImpersonateRequest": {
-            "type": "object",
-            "properties": {
-                "reason": {
-                    "description": "Reason is the optional free-form justification for the\nimpersonation (max 500 chars). The maxLength struct tag surfaces\nthe cap into the generated OpenAPI schema; the handler enforces\nthe same bound at decode time (impersonationReasonMaxLen).",
-                    "type": "string",
-                    "maxLength": 500
-                }
-            }
-        },
-        "apiserver.ImpersonationOperatorView": {
-            "type": "object",
-            "properties": {
                 "email": {
                     "type": "string"
                 },
+                "group_memberships": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.AdminUserGroupMembership"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "role": {
-                    "type": "string"
-                }
-            }
-        },
-        "apiserver.ImpersonationStateResponse": {
-            "type": "object",
-            "properties": {
-                "active": {
-                    "description": "Active reports whether the caller is inside an impersonation session.",
+                "is_system_admin": {
                     "type": "boolean"
                 },
-                "expires_at": {
+                "last_login_at": {
                     "type": "string"
                 },
-                "operator": {
-                    "description": "Operator is the back-office operator who initiated the session —\nnil when Active is false. Renamed from ` + "`" + `admin_user` + "`" + ` at #1785\nPhase 5 to reflect that the operator-of-record now lives in the\nback-office identity plane.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/apiserver.ImpersonationOperatorView"
-                        }
-                    ]
+                "name": {
+                    "type": "string"
                 },
-                "started_at": {
-                    "description": "StartedAt / ExpiresAt bound the session — zero values when inactive.",
+                "tenant_id": {
                     "type": "string"
                 },
-                "target_user": {
-                    "description": "TargetUser is the impersonated tenant user — nil when Active is false.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/apiserver.ImpersonationUserView"
-                        }
-                    ]
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "admin_users"
+                    ],
+                    "example": "admin_users"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "apiserver.ImpersonationUserView": {
+        "jsonapi.AdminUserGroupMembership": {
             "type": "object",
             "properties": {
-                "email": {
+                "group_id": {
                     "type": "string"
                 },
-                "id": {
+                "group_name": {
                     "type": "string"
                 },
-                "name": {
+                "group_slug": {
                     "type": "string"
                 },
-                "tenant_id": {
+                "joined_at": {
                     "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/models.GroupRole"
                 }
             }
         },
-        "apiserver.LoginEventView": {
+        "jsonapi.AdminUserListItem": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -8763,159 +12830,244 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "group_membership_count": {
+                    "type": "integer"
                 },
-                "ip_address": {
+                "id": {
                     "type": "string"
                 },
-                "method": {
-                    "$ref": "#/definitions/models.LoginMethod"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "outcome": {
-                    "$ref": "#/definitions/models.LoginOutcome"
+                "last_login_at": {
+                    "type": "string"
                 },
-                "user_agent": {
+                "name": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "admin_users"
+                    ],
+                    "example": "admin_users"
                 }
             }
         },
-        "apiserver.LoginHistoryResponse": {
+        "jsonapi.AdminUserResponse": {
             "type": "object",
             "properties": {
-                "events": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.AdminUserDetail"
+                }
+            }
+        },
+        "jsonapi.AdminUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/apiserver.LoginEventView"
+                        "$ref": "#/definitions/jsonapi.AdminUserListItem"
                     }
                 },
-                "failed_last_7d": {
-                    "type": "integer"
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.AdminListMeta"
                 }
             }
         },
-        "apiserver.LoginMFARequest": {
+        "jsonapi.AreaData": {
             "type": "object",
             "properties": {
-                "backup_code": {
-                    "type": "string"
+                "attributes": {
+                    "$ref": "#/definitions/models.Area"
                 },
-                "mfa_token": {
+                "id": {
                     "type": "string"
                 },
-                "totp_code": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "areas"
+                    ],
+                    "example": "areas"
                 }
             }
         },
-        "apiserver.LoginRequest": {
+        "jsonapi.AreaRequest": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.AreaData"
+                }
+            }
+        },
+        "jsonapi.AreaResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.AreaResponseData"
+                }
+            }
+        },
+        "jsonapi.AreaResponseData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/models.Area"
                 },
-                "password": {
+                "id": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "areas"
+                    ],
+                    "example": "areas"
                 }
             }
         },
-        "apiserver.LoginResponse": {
+        "jsonapi.AreasMeta": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string"
+                "areas": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
                 },
-                "csrf_token": {
-                    "description": "CSRF token for protecting state-changing requests",
-                    "type": "string"
+                "page": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
                 },
-                "expires_in": {
-                    "description": "seconds until access token expiry",
-                    "type": "integer"
+                "per_page": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 50
                 },
-                "token_type": {
-                    "type": "string"
+                "total_pages": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
+                }
+            }
+        },
+        "jsonapi.AreasResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.AreaData"
+                    }
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.AreasMeta"
                 }
             }
         },
-        "apiserver.LogoutResponse": {
+        "jsonapi.BackupScheduleRequest": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.BackupScheduleRequestDataWrapper"
                 }
             }
         },
-        "apiserver.MFADisableRequest": {
+        "jsonapi.BackupScheduleRequestData": {
             "type": "object",
             "properties": {
-                "backup_code": {
-                    "type": "string"
+                "day_of_month": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "password": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "totp_code": {
-                    "type": "string"
+                "frequency": {
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BackupFrequency"
+                        }
+                    ],
+                    "example": "daily"
+                },
+                "include_file_data": {
+                    "type": "boolean"
+                },
+                "keep_daily": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "keep_monthly": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "keep_weekly": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "time_of_day": {
+                    "type": "string",
+                    "example": "03:00"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "example": 0
                 }
             }
         },
-        "apiserver.MFASetupResponse": {
+        "jsonapi.BackupScheduleRequestDataWrapper": {
             "type": "object",
             "properties": {
-                "qr_code_url": {
-                    "type": "string"
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.BackupScheduleRequestData"
                 },
-                "secret": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "backup_schedules"
+                    ],
+                    "example": "backup_schedules"
                 }
             }
         },
-        "apiserver.MFAState": {
-            "type": "string",
-            "enum": [
-                "none",
-                "pending",
-                "active"
-            ],
-            "x-enum-varnames": [
-                "MFAStateNone",
-                "MFAStatePending",
-                "MFAStateActive"
-            ]
-        },
-        "apiserver.MFAStatusResponse": {
+        "jsonapi.BackupScheduleResponse": {
             "type": "object",
             "properties": {
-                "backup_codes_remaining": {
-                    "type": "integer"
-                },
-                "enabled_at": {
-                    "type": "string"
-                },
-                "last_used_at": {
-                    "type": "string"
-                },
-                "state": {
-                    "$ref": "#/definitions/apiserver.MFAState"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.BackupScheduleResponseData"
                 }
             }
         },
-        "apiserver.MFAVerifyRequest": {
+        "jsonapi.BackupScheduleResponseData": {
             "type": "object",
             "properties": {
-                "code": {
+                "attributes": {
+                    "$ref": "#/definitions/models.BackupSchedule"
+                },
+                "id": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "backup_schedules"
+                    ],
+                    "example": "backup_schedules"
                 }
             }
         },
-        "apiserver.MFAVerifyResponse": {
+        "jsonapi.BulkIDsAttributes": {
             "type": "object",
             "properties": {
-                "backup_codes": {
+                "ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -8923,764 +13075,709 @@ const docTemplate = `{
                 }
             }
         },
-        "apiserver.MagicLinkRequest": {
+        "jsonapi.BulkIDsRequest": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.BulkIDsRequestData"
                 }
             }
         },
-        "apiserver.MagicLinkVerifyRequest": {
+        "jsonapi.BulkIDsRequestData": {
             "type": "object",
             "properties": {
-                "token": {
+                "attributes": {
+                    "description": "Attributes carries the actual IDs payload.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jsonapi.BulkIDsAttributes"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "Type is the entity collection to act on (e.g. \"commodities\", \"files\").",
                     "type": "string"
                 }
             }
         },
-        "apiserver.PatchSettingRequest": {
-            "type": "object",
-            "properties": {
-                "value": {
-                    "description": "Value is the setting value to apply and is required when using the object envelope."
-                }
-            }
-        },
-        "apiserver.RegisterRequest": {
+        "jsonapi.BulkMoveAttributes": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "invite_token": {
-                    "description": "InviteToken, when present and valid, allows registration even if\nRegistrationMode is closed and suppresses the email-verification step\n(the invite itself vouches for the user). The token is NOT consumed\nhere — the caller must POST /api/v1/invites/{token}/accept after\nlogging in. See issue #1219 §7 and #1285.\n\nWhen the invite carries ` + "`" + `invitee_email` + "`" + ` (the #1533 email-flow path),\nthe registration ` + "`" + `email` + "`" + ` must match it after trim + case-insensitive\nnormalization, otherwise the request is rejected with 400. A\nwhitespace-only invitee_email (only reachable via direct registry\nwrite — the JSON-API binder strips whitespace) is treated as\ninvalid and rejected. Legacy token-only invites (invitee_email ==\nnil) keep working unchanged. See #1221.",
-                    "type": "string"
-                },
-                "name": {
+                "area_id": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "apiserver.ResendVerificationRequest": {
+        "jsonapi.BulkMoveRequest": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.BulkMoveRequestData"
                 }
             }
         },
-        "apiserver.ResetPasswordRequest": {
+        "jsonapi.BulkMoveRequestData": {
             "type": "object",
             "properties": {
-                "new_password": {
-                    "type": "string"
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.BulkMoveAttributes"
                 },
-                "token": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.SeedRequest": {
+        "jsonapi.BulkResultAttrs": {
             "type": "object",
             "properties": {
-                "tenant_slug": {
-                    "type": "string"
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.BulkResultFail"
+                    }
                 },
-                "user_email": {
-                    "type": "string"
+                "succeeded": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "apiserver.SeedResponse": {
+        "jsonapi.BulkResultData": {
             "type": "object",
             "properties": {
-                "already_seeded": {
-                    "type": "boolean"
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.BulkResultAttrs"
                 },
-                "message": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "apiserver.SessionView": {
+        "jsonapi.BulkResultFail": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "expires_at": {
+                "error": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
-                },
-                "ip_address": {
-                    "type": "string"
-                },
-                "is_current": {
-                    "type": "boolean"
-                },
-                "last_used_at": {
-                    "type": "string"
-                },
-                "user_agent": {
-                    "type": "string"
-                }
-            }
-        },
-        "apiserver.SessionsListResponse": {
-            "type": "object",
-            "properties": {
-                "sessions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/apiserver.SessionView"
-                    }
                 }
             }
         },
-        "apiserver.SettingsUpdateRequest": {
+        "jsonapi.BulkResultResponse": {
             "type": "object",
             "properties": {
-                "default_date_format": {
-                    "description": "DefaultDateFormat is the uiconfig.default_date_format value accepted by PUT /settings.",
-                    "type": "string"
-                },
-                "show_debug_info": {
-                    "description": "ShowDebugInfo is the uiconfig.show_debug_info value accepted by PUT /settings.",
-                    "type": "boolean"
-                },
-                "theme": {
-                    "description": "Theme is the uiconfig.theme value accepted by PUT /settings.",
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.BulkResultData"
                 }
             }
         },
-        "apiserver.SystemInfo": {
+        "jsonapi.CommoditiesMeta": {
             "type": "object",
             "properties": {
-                "build_date": {
-                    "type": "string"
-                },
-                "commit": {
-                    "type": "string"
-                },
-                "database_backend": {
-                    "description": "System information",
-                    "type": "string"
-                },
-                "debug": {
-                    "description": "Debug reflects INVENTARIO_DEBUG_UI. When true the frontend shows\ncrash details (error message + stack) on its error screen. Off by\ndefault so production never exposes a stack trace to end users.",
-                    "type": "boolean"
-                },
-                "file_storage_backend": {
-                    "type": "string"
-                },
-                "go_version": {
-                    "type": "string"
+                "commodities": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
                 },
-                "memory_usage": {
-                    "type": "string"
+                "covers": {
+                    "description": "Covers maps commodity id → resolved cover image. Empty (omitted) for\ncommodities without an attached photo. The FE renders the largest\nthumbnail that fits the slot and falls back to the type emoji when\nthe entry is absent. Issue #1451 ships option (A) — first photo by\n` + "`" + `created_at` + "`" + ` ASC; option (B) ` + "`" + `cover_file_id` + "`" + ` override is filed as a\nfollow-up sub-issue.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/jsonapi.CommodityCover"
+                    }
                 },
-                "num_cpu": {
-                    "type": "integer"
+                "page": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
                 },
-                "num_goroutines": {
-                    "type": "integer"
+                "per_page": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 50
                 },
-                "operating_system": {
-                    "type": "string"
+                "total_pages": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1
+                }
+            }
+        },
+        "jsonapi.CommoditiesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.CommodityData"
+                    }
                 },
-                "platform": {
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommoditiesMeta"
+                }
+            }
+        },
+        "jsonapi.CommodityCover": {
+            "type": "object",
+            "properties": {
+                "file_id": {
                     "type": "string"
                 },
-                "settings": {
-                    "description": "Settings information",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.SettingsObject"
-                        }
-                    ]
-                },
-                "uptime": {
-                    "description": "Runtime metrics",
-                    "type": "string"
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "first_photo",
+                        "explicit"
+                    ],
+                    "example": "first_photo"
                 },
-                "version": {
-                    "description": "Version information",
-                    "type": "string"
+                "thumbnails": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "apiserver.WorkerControlEnvelope": {
+        "jsonapi.CommodityCoverRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/apiserver.WorkerControlResource"
+                    "$ref": "#/definitions/jsonapi.CommodityCoverRequestData"
                 }
             }
         },
-        "apiserver.WorkerControlListEnvelope": {
+        "jsonapi.CommodityCoverRequestAttributes": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/apiserver.WorkerControlResource"
-                    }
+                "file_id": {
+                    "type": "string"
                 }
             }
         },
-        "apiserver.WorkerControlResource": {
+        "jsonapi.CommodityCoverRequestData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/apiserver.WorkerControlView"
-                },
-                "id": {
-                    "type": "string"
+                    "$ref": "#/definitions/jsonapi.CommodityCoverRequestAttributes"
                 },
                 "type": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "commodity_cover"
+                    ],
+                    "example": "commodity_cover"
                 }
             }
         },
-        "apiserver.WorkerControlView": {
+        "jsonapi.CommodityData": {
             "type": "object",
             "properties": {
-                "paused": {
-                    "type": "boolean"
+                "attributes": {
+                    "$ref": "#/definitions/models.Commodity"
                 },
-                "paused_at": {
+                "id": {
                     "type": "string"
                 },
-                "paused_by": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "commodities"
+                    ],
+                    "example": "commodities"
+                }
+            }
+        },
+        "jsonapi.CommodityDuplicateData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/models.Commodity"
                 },
-                "reason": {
+                "id": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityDuplicateMeta"
                 },
-                "worker_type": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "commodities"
+                    ],
+                    "example": "commodities"
                 }
             }
         },
-        "apiserver.WorkerPauseRequest": {
+        "jsonapi.CommodityDuplicateMeta": {
             "type": "object",
             "properties": {
-                "reason": {
-                    "description": "Reason is the optional operator-supplied note for the pause (max 500\nchars). Persisted into worker_control.reason and the audit breadcrumb.",
-                    "type": "string",
-                    "maxLength": 500
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "serial_number"
+                    ]
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.82
                 }
             }
         },
-        "apiserver.linkedIdentitiesResponse": {
+        "jsonapi.CommodityDuplicatePairAttributes": {
             "type": "object",
             "properties": {
-                "identities": {
+                "a": {
+                    "$ref": "#/definitions/models.Commodity"
+                },
+                "b": {
+                    "$ref": "#/definitions/models.Commodity"
+                },
+                "reasons": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/apiserver.linkedIdentityEntry"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "serial_number"
+                    ]
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.82
                 }
             }
         },
-        "apiserver.linkedIdentityEntry": {
+        "jsonapi.CommodityDuplicatePairData": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.CommodityDuplicatePairAttributes"
                 },
-                "linked_at": {
+                "id": {
                     "type": "string"
                 },
-                "provider": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "commodity_duplicate_pairs"
+                    ],
+                    "example": "commodity_duplicate_pairs"
                 }
             }
         },
-        "apiserver.providerListEntry": {
+        "jsonapi.CommodityDuplicatePairsMeta": {
             "type": "object",
             "properties": {
-                "display_name": {
-                    "type": "string"
+                "pairs": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 3
                 },
-                "name": {
-                    "type": "string"
+                "threshold": {
+                    "type": "number",
+                    "example": 0.5
                 }
             }
         },
-        "apiserver.providerListResponse": {
+        "jsonapi.CommodityDuplicatePairsResponse": {
             "type": "object",
             "properties": {
-                "providers": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/apiserver.providerListEntry"
+                        "$ref": "#/definitions/jsonapi.CommodityDuplicatePairData"
                     }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityDuplicatePairsMeta"
                 }
             }
         },
-        "debug.Info": {
+        "jsonapi.CommodityDuplicatesMeta": {
             "type": "object",
             "properties": {
-                "database_driver": {
-                    "type": "string"
-                },
-                "error": {},
-                "file_storage_driver": {
-                    "type": "string"
+                "duplicates": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2
                 },
-                "operating_system": {
-                    "type": "string"
+                "threshold": {
+                    "type": "number",
+                    "example": 0.5
                 }
             }
         },
-        "jsonapi.AdminGroupDetail": {
+        "jsonapi.CommodityDuplicatesResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
-                "currency": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "member_count": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "slug": {
-                    "type": "string"
-                },
-                "status": {
-                    "$ref": "#/definitions/models.LocationGroupStatus"
-                },
-                "tenant": {
-                    "$ref": "#/definitions/jsonapi.AdminGroupTenantChip"
-                },
-                "tenant_id": {
-                    "type": "string"
-                },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "admin_groups"
-                    ],
-                    "example": "admin_groups"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.CommodityDuplicateData"
+                    }
                 },
-                "updated_at": {
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityDuplicatesMeta"
                 }
             }
         },
-        "jsonapi.AdminGroupListItem": {
+        "jsonapi.CommodityEventActor": {
             "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
-                "currency": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "member_count": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "slug": {
-                    "type": "string"
-                },
-                "status": {
-                    "$ref": "#/definitions/models.LocationGroupStatus"
-                },
-                "tenant": {
-                    "$ref": "#/definitions/jsonapi.AdminGroupTenantChip"
-                },
-                "tenant_id": {
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "admin_groups"
-                    ],
-                    "example": "admin_groups"
+                "id": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.AdminGroupMember": {
+        "jsonapi.CommodityEventListItem": {
             "type": "object",
             "properties": {
-                "group_id": {
+                "after": {
+                    "$ref": "#/definitions/models.CommodityEventPayload"
+                },
+                "before": {
+                    "$ref": "#/definitions/models.CommodityEventPayload"
+                },
+                "commodity_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "joined_at": {
-                    "type": "string"
+                "kind": {
+                    "$ref": "#/definitions/models.CommodityEventKind"
                 },
-                "member_user_id": {
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityEventListItemMeta"
+                },
+                "note": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/models.GroupRole"
+                "occurred_at": {
+                    "type": "string"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "admin_group_members"
+                        "commodity_events"
                     ],
-                    "example": "admin_group_members"
-                },
-                "user": {
-                    "$ref": "#/definitions/jsonapi.AdminGroupMemberUser"
+                    "example": "commodity_events"
                 }
             }
         },
-        "jsonapi.AdminGroupMemberUser": {
+        "jsonapi.CommodityEventListItemMeta": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "actor": {
+                    "$ref": "#/definitions/jsonapi.CommodityEventActor"
+                }
+            }
+        },
+        "jsonapi.CommodityEventsMeta": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
                 },
-                "name": {
-                    "type": "string"
+                "total": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
                 }
             }
         },
-        "jsonapi.AdminGroupMembersResponse": {
+        "jsonapi.CommodityEventsResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/jsonapi.AdminGroupMember"
+                        "$ref": "#/definitions/jsonapi.CommodityEventListItem"
                     }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityEventsMeta"
                 }
             }
         },
-        "jsonapi.AdminGroupResponse": {
+        "jsonapi.CommodityLoanCountsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.AdminGroupDetail"
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "jsonapi.AdminGroupTenantChip": {
+        "jsonapi.CommodityLoanListItem": {
             "type": "object",
             "properties": {
-                "id": {
+                "borrower_contact": {
+                    "description": "BorrowerContact is free-form (phone / email / @handle). No\nvalidation — the field is for the user's own reference.",
                     "type": "string"
                 },
-                "name": {
+                "borrower_email": {
+                    "description": "BorrowerEmail is the optional structured address the reminder\nworker sends courtesy reminders and the signed return link to.\nUnlike BorrowerContact it is validated — an empty value simply\nopts the loan out of borrower-facing mail.",
                     "type": "string"
                 },
-                "slug": {
+                "borrower_name": {
+                    "description": "BorrowerName is required and free-form. Capped at 200 chars to\nmatch the soft cap the FE already enforces on similar text\nfields and to leave room in DB indexes if we later add one.",
                     "type": "string"
-                }
-            }
-        },
-        "jsonapi.AdminGroupsResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.AdminGroupListItem"
-                    }
                 },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.AdminListMeta"
-                }
-            }
-        },
-        "jsonapi.AdminListMeta": {
-            "type": "object",
-            "properties": {
-                "page": {
-                    "type": "integer",
-                    "example": 1
+                "borrower_note": {
+                    "description": "BorrowerNote is a free-form aide-mémoire (\"works in the office\ndownstairs\"). Capped at 1000 chars.",
+                    "type": "string"
                 },
-                "per_page": {
-                    "type": "integer",
-                    "example": 50
+                "borrower_reminder_sent_due_soon": {
+                    "type": "boolean"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 100
+                "borrower_reminder_sent_overdue": {
+                    "description": "BorrowerReminderSentOverdue + BorrowerReminderSentDueSoon are the\nborrower-side twins of the flags above. Kept separate so a lender\nopting out of their own reminders never suppresses the borrower's\ncourtesy mail (and vice versa).",
+                    "type": "boolean"
                 },
-                "total_pages": {
-                    "type": "integer",
-                    "example": 2
-                }
-            }
-        },
-        "jsonapi.AdminTenantListItem": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                "commodity": {
+                    "$ref": "#/definitions/jsonapi.LoanCommodityRef"
+                },
+                "commodity_id": {
+                    "description": "CommodityID — the lent item. ON DELETE CASCADE is added manually\nto the generated migration: hard-deleting a commodity drops its\nloan history (no orphan rows). Soft delete is not currently a\ncommodity capability, so this is the only path that touches loans.",
                     "type": "string"
                 },
-                "domain": {
+                "created_at": {
                     "type": "string"
                 },
-                "group_count": {
-                    "type": "integer"
+                "due_back_at": {
+                    "description": "DueBackAt is the optional expected-return date. Nullable for\nopen-ended loans (\"when you're done with it\").",
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_default": {
-                    "type": "boolean"
-                },
-                "name": {
+                "lent_at": {
+                    "description": "LentAt is the date the item left. Required. Stored as TEXT in\nYYYY-MM-DD format to match the project's other date fields\n(purchase_date, registered_date, last_modified_date).",
                     "type": "string"
                 },
-                "plan_id": {
+                "pending_request_at": {
+                    "description": "PendingRequestAt is when the pending request was submitted.",
                     "type": "string"
                 },
-                "registration_mode": {
+                "pending_request_due_back_at": {
+                    "description": "PendingRequestDueBackAt is the new due date the borrower asked for\n(extension requests only).",
                     "type": "string"
                 },
-                "slug": {
+                "pending_request_kind": {
+                    "description": "PendingRequestKind is the borrower's outstanding self-service\nrequest submitted through the signed loan link (\"\" when none).\nThe owner approves or rejects it; either outcome clears the\npending_request_* columns. Only one request is tracked at a time —\na newer submission replaces an unreviewed one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LoanRequestKind"
+                        }
+                    ]
+                },
+                "pending_request_note": {
+                    "description": "PendingRequestNote is the borrower's optional message. Capped at\n1000 chars like BorrowerNote.",
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/models.TenantStatus"
+                "reminder_sent_due_soon": {
+                    "type": "boolean"
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "admin_tenants"
-                    ],
-                    "example": "admin_tenants"
+                "reminder_sent_overdue": {
+                    "description": "ReminderSentOverdue + ReminderSentDueSoon are idempotency flags\nfor the reminder worker (separate sub-issue, not exposed in the\nbase feature's UI). Columns ship with the base table so the\nworker doesn't need a second migration to land. Set by the\nworker only — zero on every Create.",
+                    "type": "boolean"
+                },
+                "returned_at": {
+                    "description": "ReturnedAt closes out the loan. Nullable until the item comes\nback. The \"open vs returned\" semantics derive from this field\nalone — there is no explicit status enum.",
+                    "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "user_count": {
-                    "type": "integer"
+                "uuid": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.AdminTenantResponse": {
+        "jsonapi.CommodityLoanListResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.AdminTenantListItem"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.CommodityLoanListItem"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityLoansMeta"
                 }
             }
         },
-        "jsonapi.AdminTenantsResponse": {
+        "jsonapi.CommodityLoanRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.AdminTenantListItem"
-                    }
-                },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.AdminListMeta"
+                    "$ref": "#/definitions/jsonapi.CommodityLoanRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.AdminUserDetail": {
+        "jsonapi.CommodityLoanRequestData": {
             "type": "object",
             "properties": {
-                "active_session_count": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
+                "borrower_contact": {
                     "type": "string"
                 },
-                "group_memberships": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.AdminUserGroupMembership"
-                    }
-                },
-                "id": {
+                "borrower_email": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "is_system_admin": {
-                    "type": "boolean"
-                },
-                "last_login_at": {
+                "borrower_name": {
                     "type": "string"
                 },
-                "name": {
+                "borrower_note": {
                     "type": "string"
                 },
-                "tenant_id": {
+                "due_back_at": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "admin_users"
-                    ],
-                    "example": "admin_users"
-                },
-                "updated_at": {
+                "lent_at": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.AdminUserGroupMembership": {
+        "jsonapi.CommodityLoanRequestDataWrapper": {
             "type": "object",
             "properties": {
-                "group_id": {
-                    "type": "string"
-                },
-                "group_name": {
-                    "type": "string"
-                },
-                "group_slug": {
-                    "type": "string"
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.CommodityLoanRequestData"
                 },
-                "joined_at": {
+                "id": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/models.GroupRole"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "commodity_loans"
+                    ],
+                    "example": "commodity_loans"
                 }
             }
         },
-        "jsonapi.AdminUserListItem": {
+        "jsonapi.CommodityLoanResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "group_membership_count": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.CommodityLoanResponseData"
+                }
+            }
+        },
+        "jsonapi.CommodityLoanResponseData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/models.CommodityLoan"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "last_login_at": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "admin_users"
+                        "commodity_loans"
                     ],
-                    "example": "admin_users"
+                    "example": "commodity_loans"
                 }
             }
         },
-        "jsonapi.AdminUserResponse": {
+        "jsonapi.CommodityLoanReturnRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.AdminUserDetail"
+                    "$ref": "#/definitions/jsonapi.CommodityLoanReturnRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.AdminUsersResponse": {
+        "jsonapi.CommodityLoanReturnRequestData": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.AdminUserListItem"
-                    }
-                },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.AdminListMeta"
+                "returned_at": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.AreaData": {
+        "jsonapi.CommodityLoanReturnRequestDataWrapper": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.Area"
-                },
-                "id": {
-                    "type": "string"
+                    "$ref": "#/definitions/jsonapi.CommodityLoanReturnRequestData"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "areas"
+                        "commodity_loans"
                     ],
-                    "example": "areas"
+                    "example": "commodity_loans"
                 }
             }
         },
-        "jsonapi.AreaRequest": {
+        "jsonapi.CommodityLoanUpdateRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.AreaData"
+                    "$ref": "#/definitions/jsonapi.CommodityLoanUpdateRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.AreaResponse": {
+        "jsonapi.CommodityLoanUpdateRequestData": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.AreaResponseData"
+                "borrower_contact": {
+                    "type": "string"
+                },
+                "borrower_email": {
+                    "type": "string"
+                },
+                "borrower_name": {
+                    "type": "string"
+                },
+                "borrower_note": {
+                    "type": "string"
+                },
+                "due_back_at": {
+                    "description": "DueBackAt: omitted leaves it unchanged; a \"YYYY-MM-DD\" string\nreplaces the value; an explicit JSON ` + "`" + `null` + "`" + ` clears the column\n(open-ended loan).",
+                    "type": "string",
+                    "x-nullable": "true"
                 }
             }
         },
-        "jsonapi.AreaResponseData": {
+        "jsonapi.CommodityLoanUpdateRequestDataWrapper": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.Area"
+                    "$ref": "#/definitions/jsonapi.CommodityLoanUpdateRequestData"
                 },
                 "id": {
                     "type": "string"
@@ -9688,94 +13785,88 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "areas"
+                        "commodity_loans"
                     ],
-                    "example": "areas"
+                    "example": "commodity_loans"
                 }
             }
         },
-        "jsonapi.AreasMeta": {
+        "jsonapi.CommodityLoansMeta": {
             "type": "object",
             "properties": {
-                "areas": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1
-                },
-                "page": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1
-                },
-                "per_page": {
+                "loans": {
                     "type": "integer",
                     "format": "int64",
-                    "example": 50
+                    "example": 10
                 },
-                "total_pages": {
+                "total": {
                     "type": "integer",
                     "format": "int64",
-                    "example": 1
+                    "example": 100
                 }
             }
         },
-        "jsonapi.AreasResponse": {
+        "jsonapi.CommodityLoansResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/jsonapi.AreaData"
+                        "$ref": "#/definitions/models.CommodityLoan"
                     }
                 },
                 "meta": {
-                    "$ref": "#/definitions/jsonapi.AreasMeta"
-                }
-            }
-        },
-        "jsonapi.BulkIDsAttributes": {
-            "type": "object",
-            "properties": {
-                "ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                }
-            }
-        },
-        "jsonapi.BulkIDsRequest": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.BulkIDsRequestData"
+                    "$ref": "#/definitions/jsonapi.CommodityLoansMeta"
                 }
             }
         },
-        "jsonapi.BulkIDsRequestData": {
+        "jsonapi.CommodityMergeAttributes": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "description": "Attributes carries the actual IDs payload.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/jsonapi.BulkIDsAttributes"
-                        }
-                    ]
-                },
-                "type": {
-                    "description": "Type is the entity collection to act on (e.g. \"commodities\", \"files\").",
+                "duplicate_id": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.BulkMoveAttributes": {
+        "jsonapi.CommodityMergeMeta": {
             "type": "object",
             "properties": {
-                "area_id": {
+                "events": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "files": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "invoice_extractions": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "loans": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "maintenance_schedules": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "merged_id": {
                     "type": "string"
                 },
-                "ids": {
+                "meter_readings": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "services": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "supply_links": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "tags_added": {
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -9783,175 +13874,168 @@ const docTemplate = `{
                 }
             }
         },
-        "jsonapi.BulkMoveRequest": {
+        "jsonapi.CommodityMergeRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.BulkMoveRequestData"
+                    "$ref": "#/definitions/jsonapi.CommodityMergeRequestData"
                 }
             }
         },
-        "jsonapi.BulkMoveRequestData": {
+        "jsonapi.CommodityMergeRequestData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.BulkMoveAttributes"
+                    "$ref": "#/definitions/jsonapi.CommodityMergeAttributes"
                 },
                 "type": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "commodity_merges"
+                    ],
+                    "example": "commodity_merges"
                 }
             }
         },
-        "jsonapi.BulkResultAttrs": {
+        "jsonapi.CommodityMergeResponse": {
             "type": "object",
             "properties": {
-                "failed": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.BulkResultFail"
-                    }
+                "data": {
+                    "$ref": "#/definitions/jsonapi.CommodityResponseData"
                 },
-                "succeeded": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityMergeMeta"
                 }
             }
         },
-        "jsonapi.BulkResultData": {
+        "jsonapi.CommodityMeterReadingRequest": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.BulkResultAttrs"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "type": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.CommodityMeterReadingRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.BulkResultFail": {
+        "jsonapi.CommodityMeterReadingRequestData": {
             "type": "object",
             "properties": {
-                "error": {
+                "notes": {
                     "type": "string"
                 },
-                "id": {
+                "read_at": {
                     "type": "string"
+                },
+                "unit": {
+                    "enum": [
+                        "hours",
+                        "km",
+                        "mi",
+                        "cycles"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeterUnit"
+                        }
+                    ]
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "jsonapi.BulkResultResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.BulkResultData"
-                }
-            }
-        },
-        "jsonapi.CommoditiesMeta": {
+        "jsonapi.CommodityMeterReadingRequestDataWrapper": {
             "type": "object",
             "properties": {
-                "commodities": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1
-                },
-                "covers": {
-                    "description": "Covers maps commodity id → resolved cover image. Empty (omitted) for\ncommodities without an attached photo. The FE renders the largest\nthumbnail that fits the slot and falls back to the type emoji when\nthe entry is absent. Issue #1451 ships option (A) — first photo by\n` + "`" + `created_at` + "`" + ` ASC; option (B) ` + "`" + `cover_file_id` + "`" + ` override is filed as a\nfollow-up sub-issue.",
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/jsonapi.CommodityCover"
-                    }
-                },
-                "page": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1
-                },
-                "per_page": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 50
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.CommodityMeterReadingRequestData"
                 },
-                "total_pages": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "commodity_meter_readings"
+                    ],
+                    "example": "commodity_meter_readings"
                 }
             }
         },
-        "jsonapi.CommoditiesResponse": {
+        "jsonapi.CommodityMeterReadingResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.CommodityData"
-                    }
-                },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.CommoditiesMeta"
+                    "$ref": "#/definitions/jsonapi.CommodityMeterReadingResponseData"
                 }
             }
         },
-        "jsonapi.CommodityCover": {
+        "jsonapi.CommodityMeterReadingResponseData": {
             "type": "object",
             "properties": {
-                "file_id": {
+                "attributes": {
+                    "$ref": "#/definitions/models.CommodityMeterReading"
+                },
+                "id": {
                     "type": "string"
                 },
-                "source": {
+                "type": {
                     "type": "string",
                     "enum": [
-                        "first_photo",
-                        "explicit"
+                        "commodity_meter_readings"
                     ],
-                    "example": "first_photo"
+                    "example": "commodity_meter_readings"
+                }
+            }
+        },
+        "jsonapi.CommodityMeterReadingsMeta": {
+            "type": "object",
+            "properties": {
+                "readings": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
                 },
-                "thumbnails": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "total": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
                 }
             }
         },
-        "jsonapi.CommodityCoverRequest": {
+        "jsonapi.CommodityMeterReadingsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityCoverRequestData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CommodityMeterReading"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityMeterReadingsMeta"
                 }
             }
         },
-        "jsonapi.CommodityCoverRequestAttributes": {
+        "jsonapi.CommodityRequest": {
             "type": "object",
             "properties": {
-                "file_id": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.CommodityData"
                 }
             }
         },
-        "jsonapi.CommodityCoverRequestData": {
+        "jsonapi.CommodityResponse": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityCoverRequestAttributes"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.CommodityResponseData"
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "commodity_cover"
-                    ],
-                    "example": "commodity_cover"
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityResponseMeta"
                 }
             }
         },
-        "jsonapi.CommodityData": {
+        "jsonapi.CommodityResponseData": {
             "type": "object",
             "properties": {
                 "attributes": {
@@ -9969,94 +14053,114 @@ const docTemplate = `{
                 }
             }
         },
-        "jsonapi.CommodityEventActor": {
+        "jsonapi.CommodityResponseMeta": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "cover": {
+                    "$ref": "#/definitions/jsonapi.CommodityCover"
                 }
             }
         },
-        "jsonapi.CommodityEventListItem": {
+        "jsonapi.CommodityScanAttributes": {
             "type": "object",
             "properties": {
-                "after": {
-                    "$ref": "#/definitions/models.CommodityEventPayload"
-                },
-                "before": {
-                    "$ref": "#/definitions/models.CommodityEventPayload"
-                },
-                "commodity_id": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/jsonapi.CommodityScanFieldGuess"
+                    }
                 },
-                "kind": {
-                    "$ref": "#/definitions/models.CommodityEventKind"
+                "items": {
+                    "description": "Items is present only when the source described more than one\ndistinct product; one entry per product, most prominent first. The\nFE renders a chooser. Empty/absent for a single product.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.CommodityScanItem"
+                    }
                 },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.CommodityEventListItemMeta"
+                "latency_ms": {
+                    "type": "integer"
                 },
-                "note": {
-                    "type": "string"
+                "used_tokens": {
+                    "type": "integer"
                 },
-                "occurred_at": {
-                    "type": "string"
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.CommodityScanWarning"
+                    }
+                }
+            }
+        },
+        "jsonapi.CommodityScanFieldGuess": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number"
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "commodity_events"
-                    ],
-                    "example": "commodity_events"
+                "value": {
+                    "description": "Value is polymorphic — see the type-level doc comment.",
+                    "type": "object"
                 }
             }
         },
-        "jsonapi.CommodityEventListItemMeta": {
+        "jsonapi.CommodityScanItem": {
             "type": "object",
             "properties": {
-                "actor": {
-                    "$ref": "#/definitions/jsonapi.CommodityEventActor"
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/jsonapi.CommodityScanFieldGuess"
+                    }
                 }
             }
         },
-        "jsonapi.CommodityEventsMeta": {
+        "jsonapi.CommodityScanResource": {
             "type": "object",
             "properties": {
-                "events": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 10
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.CommodityScanAttributes"
                 },
-                "total": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 100
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "commodity_scan"
+                    ],
+                    "example": "commodity_scan"
                 }
             }
         },
-        "jsonapi.CommodityEventsResponse": {
+        "jsonapi.CommodityScanResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.CommodityEventListItem"
-                    }
+                    "$ref": "#/definitions/jsonapi.CommodityScanResource"
+                }
+            }
+        },
+        "jsonapi.CommodityScanWarning": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "enum": [
+                        "low_confidence",
+                        "unreadable_serial",
+                        "ambiguous_price",
+                        "currency_inferred",
+                        "no_photo_text",
+                        "multiple_items"
+                    ],
+                    "example": "low_confidence"
                 },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.CommodityEventsMeta"
+                "detail": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.CommodityLoanCountsResponse": {
+        "jsonapi.CommodityServiceCountsResponse": {
             "type": "object",
             "properties": {
                 "data": {
@@ -10067,51 +14171,59 @@ const docTemplate = `{
                 }
             }
         },
-        "jsonapi.CommodityLoanListItem": {
+        "jsonapi.CommodityServiceListItem": {
             "type": "object",
             "properties": {
-                "borrower_contact": {
-                    "description": "BorrowerContact is free-form (phone / email / @handle). No\nvalidation — the field is for the user's own reference.",
-                    "type": "string"
-                },
-                "borrower_name": {
-                    "description": "BorrowerName is required and free-form. Capped at 200 chars to\nmatch the soft cap the FE already enforces on similar text\nfields and to leave room in DB indexes if we later add one.",
-                    "type": "string"
+                "commodity": {
+                    "$ref": "#/definitions/jsonapi.ServiceCommodityRef"
                 },
-                "borrower_note": {
-                    "description": "BorrowerNote is a free-form aide-mémoire (\"works in the office\ndownstairs\"). Capped at 1000 chars.",
+                "commodity_id": {
+                    "description": "CommodityID — the item being serviced. ON DELETE CASCADE is added\nmanually to the generated migration; mirrors commodity_loans.",
                     "type": "string"
                 },
-                "commodity": {
-                    "$ref": "#/definitions/jsonapi.LoanCommodityRef"
+                "cost_amount": {
+                    "description": "CostAmount is the optional repair bill. Bare decimal.Decimal —\nzero means \"no cost recorded\" (per the codebase's existing\nprice-field convention). Pair-validated with CostCurrency:\neither both unset or both set.",
+                    "type": "number"
                 },
-                "commodity_id": {
-                    "description": "CommodityID — the lent item. ON DELETE CASCADE is added manually\nto the generated migration: hard-deleting a commodity drops its\nloan history (no orphan rows). Soft delete is not currently a\ncommodity capability, so this is the only path that touches loans.",
+                "cost_currency": {
+                    "description": "CostCurrency is the ISO 4217 code for CostAmount. Empty means\n\"no cost recorded\" — see CostAmount. Stored as a plain string\n(not Currency) so the empty-string \"unset\" form doesn't trip the\nCurrency type's auto-Validatable contract; ISO 4217 is enforced\nin ValidateWithContext only when a value is supplied.",
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "due_back_at": {
-                    "description": "DueBackAt is the optional expected-return date. Nullable for\nopen-ended loans (\"when you're done with it\").",
+                "expected_return_at": {
+                    "description": "ExpectedReturnAt is the optional ETA from the workshop. Nullable\nfor open-ended estimates (\"we'll call when ready\").",
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "lent_at": {
-                    "description": "LentAt is the date the item left. Required. Stored as TEXT in\nYYYY-MM-DD format to match the project's other date fields\n(purchase_date, registered_date, last_modified_date).",
+                "provider_contact": {
+                    "description": "ProviderContact is free-form (phone / email / address). No\nvalidation — for the user's own reference.",
+                    "type": "string"
+                },
+                "provider_name": {
+                    "description": "ProviderName is required and free-form (\"Apple Authorized Service\",\n\"Bob's Repair Shop\"). Capped at 200 chars to match similar BE text\ncaps and leave room for indexes if we later add one.",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is free-form (\"screen replacement\", \"warranty diagnostics\").\nOptional, but UI-prominent. Capped at 1000 chars.",
                     "type": "string"
                 },
                 "reminder_sent_due_soon": {
                     "type": "boolean"
                 },
                 "reminder_sent_overdue": {
-                    "description": "ReminderSentOverdue + ReminderSentDueSoon are idempotency flags\nfor the reminder worker (separate sub-issue, not exposed in the\nbase feature's UI). Columns ship with the base table so the\nworker doesn't need a second migration to land. Set by the\nworker only — zero on every Create.",
+                    "description": "ReminderSentOverdue + ReminderSentDueSoon are idempotency flags\nfor the reminder worker (separate sub-issue #1509-equivalent for\nservices). Set by the worker only — zero on every Create.",
                     "type": "boolean"
                 },
                 "returned_at": {
-                    "description": "ReturnedAt closes out the loan. Nullable until the item comes\nback. The \"open vs returned\" semantics derive from this field\nalone — there is no explicit status enum.",
+                    "description": "ReturnedAt closes out the service row. Nullable until the item\ncomes back. The \"open vs returned\" semantics derive from this\nfield alone.",
+                    "type": "string"
+                },
+                "sent_at": {
+                    "description": "SentAt is the date the item left for service. Required. Stored as\nTEXT in YYYY-MM-DD format to match other date fields.",
                     "type": "string"
                 },
                 "updated_at": {
@@ -10122,53 +14234,59 @@ const docTemplate = `{
                 }
             }
         },
-        "jsonapi.CommodityLoanListResponse": {
+        "jsonapi.CommodityServiceListResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/jsonapi.CommodityLoanListItem"
+                        "$ref": "#/definitions/jsonapi.CommodityServiceListItem"
                     }
                 },
                 "meta": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoansMeta"
+                    "$ref": "#/definitions/jsonapi.CommodityServicesMeta"
                 }
             }
         },
-        "jsonapi.CommodityLoanRequest": {
+        "jsonapi.CommodityServiceRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoanRequestDataWrapper"
+                    "$ref": "#/definitions/jsonapi.CommodityServiceRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.CommodityLoanRequestData": {
+        "jsonapi.CommodityServiceRequestData": {
             "type": "object",
             "properties": {
-                "borrower_contact": {
+                "cost_amount": {
+                    "type": "number"
+                },
+                "cost_currency": {
                     "type": "string"
                 },
-                "borrower_name": {
+                "expected_return_at": {
                     "type": "string"
                 },
-                "borrower_note": {
+                "provider_contact": {
                     "type": "string"
                 },
-                "due_back_at": {
+                "provider_name": {
                     "type": "string"
                 },
-                "lent_at": {
+                "reason": {
+                    "type": "string"
+                },
+                "sent_at": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.CommodityLoanRequestDataWrapper": {
+        "jsonapi.CommodityServiceRequestDataWrapper": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoanRequestData"
+                    "$ref": "#/definitions/jsonapi.CommodityServiceRequestData"
                 },
                 "id": {
                     "type": "string"
@@ -10176,25 +14294,25 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodity_loans"
+                        "commodity_services"
                     ],
-                    "example": "commodity_loans"
+                    "example": "commodity_services"
                 }
             }
         },
-        "jsonapi.CommodityLoanResponse": {
+        "jsonapi.CommodityServiceResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoanResponseData"
+                    "$ref": "#/definitions/jsonapi.CommodityServiceResponseData"
                 }
             }
         },
-        "jsonapi.CommodityLoanResponseData": {
+        "jsonapi.CommodityServiceResponseData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.CommodityLoan"
+                    "$ref": "#/definitions/models.CommodityService"
                 },
                 "id": {
                     "type": "string"
@@ -10202,141 +14320,85 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodity_loans"
+                        "commodity_services"
                     ],
-                    "example": "commodity_loans"
+                    "example": "commodity_services"
                 }
             }
         },
-        "jsonapi.CommodityLoanReturnRequest": {
+        "jsonapi.CommodityServiceReturnRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoanReturnRequestDataWrapper"
+                    "$ref": "#/definitions/jsonapi.CommodityServiceReturnRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.CommodityLoanReturnRequestData": {
+        "jsonapi.CommodityServiceReturnRequestData": {
             "type": "object",
             "properties": {
+                "cost_amount": {
+                    "type": "number"
+                },
+                "cost_currency": {
+                    "type": "string"
+                },
                 "returned_at": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.CommodityLoanReturnRequestDataWrapper": {
+        "jsonapi.CommodityServiceReturnRequestDataWrapper": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoanReturnRequestData"
+                    "$ref": "#/definitions/jsonapi.CommodityServiceReturnRequestData"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodity_loans"
+                        "commodity_services"
                     ],
-                    "example": "commodity_loans"
+                    "example": "commodity_services"
                 }
             }
         },
-        "jsonapi.CommodityLoanUpdateRequest": {
+        "jsonapi.CommodityServiceUpdateRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoanUpdateRequestDataWrapper"
+                    "$ref": "#/definitions/jsonapi.CommodityServiceUpdateRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.CommodityLoanUpdateRequestData": {
+        "jsonapi.CommodityServiceUpdateRequestData": {
             "type": "object",
             "properties": {
-                "borrower_contact": {
-                    "type": "string"
+                "cost_amount": {
+                    "type": "number"
                 },
-                "borrower_name": {
+                "cost_currency": {
                     "type": "string"
                 },
-                "borrower_note": {
+                "expected_return_at": {
                     "type": "string"
                 },
-                "due_back_at": {
-                    "description": "DueBackAt: omitted leaves it unchanged; a \"YYYY-MM-DD\" string\nreplaces the value; an explicit JSON ` + "`" + `null` + "`" + ` clears the column\n(open-ended loan).",
-                    "type": "string",
-                    "x-nullable": "true"
-                }
-            }
-        },
-        "jsonapi.CommodityLoanUpdateRequestDataWrapper": {
-            "type": "object",
-            "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoanUpdateRequestData"
-                },
-                "id": {
+                "provider_contact": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "commodity_loans"
-                    ],
-                    "example": "commodity_loans"
-                }
-            }
-        },
-        "jsonapi.CommodityLoansMeta": {
-            "type": "object",
-            "properties": {
-                "loans": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 10
-                },
-                "total": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 100
-                }
-            }
-        },
-        "jsonapi.CommodityLoansResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.CommodityLoan"
-                    }
-                },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.CommodityLoansMeta"
-                }
-            }
-        },
-        "jsonapi.CommodityRequest": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityData"
-                }
-            }
-        },
-        "jsonapi.CommodityResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityResponseData"
+                "provider_name": {
+                    "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.CommodityResponseMeta"
+                "reason": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.CommodityResponseData": {
+        "jsonapi.CommodityServiceUpdateRequestDataWrapper": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.Commodity"
+                    "$ref": "#/definitions/jsonapi.CommodityServiceUpdateRequestData"
                 },
                 "id": {
                     "type": "string"
@@ -10344,246 +14406,187 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodities"
-                    ],
-                    "example": "commodities"
-                }
-            }
-        },
-        "jsonapi.CommodityResponseMeta": {
-            "type": "object",
-            "properties": {
-                "cover": {
-                    "$ref": "#/definitions/jsonapi.CommodityCover"
-                }
-            }
-        },
-        "jsonapi.CommodityScanAttributes": {
-            "type": "object",
-            "properties": {
-                "fields": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/jsonapi.CommodityScanFieldGuess"
-                    }
-                },
-                "items": {
-                    "description": "Items is present only when the source described more than one\ndistinct product; one entry per product, most prominent first. The\nFE renders a chooser. Empty/absent for a single product.",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.CommodityScanItem"
-                    }
-                },
-                "latency_ms": {
-                    "type": "integer"
-                },
-                "used_tokens": {
-                    "type": "integer"
-                },
-                "warnings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.CommodityScanWarning"
-                    }
-                }
-            }
-        },
-        "jsonapi.CommodityScanFieldGuess": {
-            "type": "object",
-            "properties": {
-                "confidence": {
-                    "type": "number"
-                },
-                "value": {
-                    "description": "Value is polymorphic — see the type-level doc comment.",
-                    "type": "object"
-                }
-            }
-        },
-        "jsonapi.CommodityScanItem": {
-            "type": "object",
-            "properties": {
-                "fields": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/jsonapi.CommodityScanFieldGuess"
-                    }
-                }
-            }
-        },
-        "jsonapi.CommodityScanResource": {
-            "type": "object",
-            "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityScanAttributes"
-                },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "commodity_scan"
+                        "commodity_services"
                     ],
-                    "example": "commodity_scan"
-                }
-            }
-        },
-        "jsonapi.CommodityScanResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityScanResource"
+                    "example": "commodity_services"
                 }
             }
         },
-        "jsonapi.CommodityScanWarning": {
+        "jsonapi.CommodityServicesMeta": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string",
-                    "enum": [
-                        "low_confidence",
-                        "unreadable_serial",
-                        "ambiguous_price",
-                        "currency_inferred",
-                        "no_photo_text",
-                        "multiple_items"
-                    ],
-                    "example": "low_confidence"
-                },
-                "detail": {
-                    "type": "string"
+                "services": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
                 },
-                "field": {
-                    "type": "string"
+                "total": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
                 }
             }
         },
-        "jsonapi.CommodityServiceCountsResponse": {
+        "jsonapi.CommodityServicesResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CommodityService"
                     }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.CommodityServicesMeta"
                 }
             }
         },
-        "jsonapi.CommodityServiceListItem": {
+        "jsonapi.CurrencyMigrationPreviewAttributes": {
             "type": "object",
             "properties": {
-                "commodity": {
-                    "$ref": "#/definitions/jsonapi.ServiceCommodityRef"
+                "exchange_rate": {
+                    "type": "number"
                 },
-                "commodity_id": {
-                    "description": "CommodityID — the item being serviced. ON DELETE CASCADE is added\nmanually to the generated migration; mirrors commodity_loans.",
+                "from_currency": {
                     "type": "string"
                 },
-                "cost_amount": {
-                    "description": "CostAmount is the optional repair bill. Bare decimal.Decimal —\nzero means \"no cost recorded\" (per the codebase's existing\nprice-field convention). Pair-validated with CostCurrency:\neither both unset or both set.",
+                "to_currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "jsonapi.CurrencyMigrationPreviewBody": {
+            "type": "object",
+            "properties": {
+                "acquisition_fills": {
+                    "type": "integer"
+                },
+                "commodity_count": {
+                    "type": "integer"
+                },
+                "diffs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewDiff"
+                    }
+                },
+                "exchange_rate": {
                     "type": "number"
                 },
-                "cost_currency": {
-                    "description": "CostCurrency is the ISO 4217 code for CostAmount. Empty means\n\"no cost recorded\" — see CostAmount. Stored as a plain string\n(not Currency) so the empty-string \"unset\" form doesn't trip the\nCurrency type's auto-Validatable contract; ISO 4217 is enforced\nin ValidateWithContext only when a value is supplied.",
+                "from_currency": {
                     "type": "string"
                 },
-                "created_at": {
+                "preview_expires_at": {
                     "type": "string"
                 },
-                "expected_return_at": {
-                    "description": "ExpectedReturnAt is the optional ETA from the workshop. Nullable\nfor open-ended estimates (\"we'll call when ready\").",
+                "preview_expires_in_seconds": {
+                    "type": "integer"
+                },
+                "preview_token": {
                     "type": "string"
                 },
-                "id": {
+                "state_hash": {
                     "type": "string"
                 },
-                "provider_contact": {
-                    "description": "ProviderContact is free-form (phone / email / address). No\nvalidation — for the user's own reference.",
+                "to_currency": {
                     "type": "string"
                 },
-                "provider_name": {
-                    "description": "ProviderName is required and free-form (\"Apple Authorized Service\",\n\"Bob's Repair Shop\"). Capped at 200 chars to match similar BE text\ncaps and leave room for indexes if we later add one.",
+                "total_current_after": {
+                    "type": "number"
+                },
+                "total_current_before": {
+                    "type": "number"
+                }
+            }
+        },
+        "jsonapi.CurrencyMigrationPreviewDiff": {
+            "type": "object",
+            "properties": {
+                "commodity_id": {
                     "type": "string"
                 },
-                "reason": {
-                    "description": "Reason is free-form (\"screen replacement\", \"warranty diagnostics\").\nOptional, but UI-prominent. Capped at 1000 chars.",
+                "commodity_name": {
                     "type": "string"
                 },
-                "reminder_sent_due_soon": {
-                    "type": "boolean"
+                "current_price_after": {
+                    "type": "number"
                 },
-                "reminder_sent_overdue": {
-                    "description": "ReminderSentOverdue + ReminderSentDueSoon are idempotency flags\nfor the reminder worker (separate sub-issue #1509-equivalent for\nservices). Set by the worker only — zero on every Create.",
-                    "type": "boolean"
+                "current_price_before": {
+                    "type": "number"
                 },
-                "returned_at": {
-                    "description": "ReturnedAt closes out the service row. Nullable until the item\ncomes back. The \"open vs returned\" semantics derive from this\nfield alone.",
+                "original_currency_after": {
                     "type": "string"
                 },
-                "sent_at": {
-                    "description": "SentAt is the date the item left for service. Required. Stored as\nTEXT in YYYY-MM-DD format to match other date fields.",
+                "original_currency_before": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "original_price_after": {
+                    "type": "number"
                 },
-                "uuid": {
-                    "type": "string"
+                "original_price_before": {
+                    "type": "number"
                 }
             }
         },
-        "jsonapi.CommodityServiceListResponse": {
+        "jsonapi.CurrencyMigrationPreviewRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.CommodityServiceListItem"
-                    }
+                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewRequestData"
+                }
+            }
+        },
+        "jsonapi.CurrencyMigrationPreviewRequestData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewAttributes"
                 },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.CommodityServicesMeta"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "currency-migrations"
+                    ],
+                    "example": "currency-migrations"
                 }
             }
         },
-        "jsonapi.CommodityServiceRequest": {
+        "jsonapi.CurrencyMigrationPreviewResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityServiceRequestDataWrapper"
+                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewResponseData"
                 }
             }
         },
-        "jsonapi.CommodityServiceRequestData": {
+        "jsonapi.CurrencyMigrationPreviewResponseData": {
             "type": "object",
             "properties": {
-                "cost_amount": {
-                    "type": "number"
-                },
-                "cost_currency": {
-                    "type": "string"
-                },
-                "expected_return_at": {
-                    "type": "string"
-                },
-                "provider_contact": {
-                    "type": "string"
-                },
-                "provider_name": {
-                    "type": "string"
-                },
-                "reason": {
-                    "type": "string"
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewBody"
                 },
-                "sent_at": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "currency-migration-previews"
+                    ],
+                    "example": "currency-migration-previews"
                 }
             }
         },
-        "jsonapi.CommodityServiceRequestDataWrapper": {
+        "jsonapi.CurrencyMigrationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.CurrencyMigrationResponseData"
+                }
+            }
+        },
+        "jsonapi.CurrencyMigrationResponseData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityServiceRequestData"
+                    "$ref": "#/definitions/models.CurrencyMigration"
                 },
                 "id": {
                     "type": "string"
@@ -10591,111 +14594,136 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodity_services"
+                        "currency-migrations"
                     ],
-                    "example": "commodity_services"
+                    "example": "currency-migrations"
                 }
             }
         },
-        "jsonapi.CommodityServiceResponse": {
+        "jsonapi.CurrencyMigrationStartAttributes": {
+            "type": "object",
+            "properties": {
+                "exchange_rate": {
+                    "description": "ExchangeRate is the user-typed rate (1 from = rate to). FE clamps\nto 6 decimals as a UX guard; BE validates per #202 §2 (positive,\nfinite, ≤ 1e10).",
+                    "type": "number"
+                },
+                "from_currency": {
+                    "type": "string"
+                },
+                "preview_token": {
+                    "description": "PreviewToken is the HMAC-signed token the preview endpoint\nreturned. Required; the start handler verifies the signature and\nthen re-derives the state hash to detect group drift between\npreview and commit.",
+                    "type": "string"
+                },
+                "to_currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "jsonapi.CurrencyMigrationStartRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityServiceResponseData"
+                    "$ref": "#/definitions/jsonapi.CurrencyMigrationStartRequestData"
                 }
             }
         },
-        "jsonapi.CommodityServiceResponseData": {
+        "jsonapi.CurrencyMigrationStartRequestData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.CommodityService"
-                },
-                "id": {
-                    "type": "string"
+                    "$ref": "#/definitions/jsonapi.CurrencyMigrationStartAttributes"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodity_services"
+                        "currency-migrations"
                     ],
-                    "example": "commodity_services"
+                    "example": "currency-migrations"
                 }
             }
         },
-        "jsonapi.CommodityServiceReturnRequest": {
+        "jsonapi.CurrencyMigrationsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityServiceReturnRequestDataWrapper"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.CurrencyMigrationResponseData"
+                    }
                 }
             }
         },
-        "jsonapi.CommodityServiceReturnRequestData": {
+        "jsonapi.Error": {
             "type": "object",
             "properties": {
-                "cost_amount": {
-                    "type": "number"
-                },
-                "cost_currency": {
+                "code": {
+                    "description": "Code is the application-specific error code (e.g.\n\"currency_migration.daily_cap_reached\"). Optional; when present,\nthe FE branches on this string before falling back to the generic\nHTTP status. Stable across versions.",
                     "type": "string"
                 },
-                "returned_at": {
+                "error": {
+                    "description": "user-level error message",
+                    "type": "object"
+                },
+                "meta": {
+                    "description": "Meta is a free-form JSON object the handler may attach for\nmachine-readable context (e.g. {\"retry_after_seconds\": 3600}).\nOptional; serialised only when non-empty.\n\nSwagger annotation note: ` + "`" + `swaggertype:\"object,string\"` + "`" + ` tells swag\nto emit ` + "`" + `additionalProperties: { type: string }` + "`" + `. Without an\nexplicit additionalProperties, openapi-typescript renders the\nempty ` + "`" + `{type: object}` + "`" + ` schema as ` + "`" + `Record\u003cstring, never\u003e` + "`" + ` — a\nclosed, indexable-but-empty object — which makes\n` + "`" + `meta.retry_after_seconds` + "`" + ` uncallable in TS without a cast. With\nthe override the FE gets a ` + "`" + `{ [key: string]: string }` + "`" + ` index\nsignature; consumers parse known keys (` + "`" + `retry_after_seconds:\nnumber` + "`" + `, ` + "`" + `migration_id: string` + "`" + `, ` + "`" + `status: string` + "`" + `) into their\nreal types — we control both sides of that cast.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "user-level status message",
                     "type": "string"
                 }
             }
         },
-        "jsonapi.CommodityServiceReturnRequestDataWrapper": {
+        "jsonapi.Errors": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.Error"
+                    }
+                }
+            }
+        },
+        "jsonapi.ExportCreateRequest": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.ExportCreateRequestData"
+                }
+            }
+        },
+        "jsonapi.ExportCreateRequestData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityServiceReturnRequestData"
+                    "$ref": "#/definitions/models.Export"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodity_services"
+                        "exports"
                     ],
-                    "example": "commodity_services"
+                    "example": "exports"
                 }
             }
         },
-        "jsonapi.CommodityServiceUpdateRequest": {
+        "jsonapi.ExportResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CommodityServiceUpdateRequestDataWrapper"
-                }
-            }
-        },
-        "jsonapi.CommodityServiceUpdateRequestData": {
-            "type": "object",
-            "properties": {
-                "cost_amount": {
-                    "type": "number"
-                },
-                "cost_currency": {
-                    "type": "string"
-                },
-                "expected_return_at": {
-                    "type": "string"
-                },
-                "provider_contact": {
-                    "type": "string"
-                },
-                "provider_name": {
-                    "type": "string"
-                },
-                "reason": {
-                    "type": "string"
+                    "$ref": "#/definitions/jsonapi.ExportResponseData"
                 }
             }
         },
-        "jsonapi.CommodityServiceUpdateRequestDataWrapper": {
+        "jsonapi.ExportResponseData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.CommodityServiceUpdateRequestData"
+                    "$ref": "#/definitions/models.Export"
                 },
                 "id": {
                     "type": "string"
@@ -10703,187 +14731,199 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "commodity_services"
+                        "exports"
                     ],
-                    "example": "commodity_services"
+                    "example": "exports"
                 }
             }
         },
-        "jsonapi.CommodityServicesMeta": {
+        "jsonapi.ExportsMeta": {
             "type": "object",
             "properties": {
-                "services": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 10
-                },
-                "total": {
+                "exports": {
                     "type": "integer",
                     "format": "int64",
-                    "example": 100
+                    "example": 1
                 }
             }
         },
-        "jsonapi.CommodityServicesResponse": {
+        "jsonapi.ExportsResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.CommodityService"
+                        "$ref": "#/definitions/jsonapi.ExportResponseData"
                     }
                 },
                 "meta": {
-                    "$ref": "#/definitions/jsonapi.CommodityServicesMeta"
+                    "$ref": "#/definitions/jsonapi.ExportsMeta"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationPreviewAttributes": {
+        "jsonapi.FileCategoryBytes": {
             "type": "object",
             "properties": {
-                "exchange_rate": {
-                    "type": "number"
+                "all": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1966080
                 },
-                "from_currency": {
-                    "type": "string"
+                "documents": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 786432
                 },
-                "to_currency": {
-                    "type": "string"
+                "images": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 1048576
+                },
+                "other": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 131072
                 }
             }
         },
-        "jsonapi.CurrencyMigrationPreviewBody": {
+        "jsonapi.FileCategoryCounts": {
             "type": "object",
             "properties": {
-                "acquisition_fills": {
-                    "type": "integer"
-                },
-                "commodity_count": {
-                    "type": "integer"
-                },
-                "diffs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewDiff"
-                    }
-                },
-                "exchange_rate": {
-                    "type": "number"
-                },
-                "from_currency": {
-                    "type": "string"
-                },
-                "preview_expires_at": {
-                    "type": "string"
-                },
-                "preview_expires_in_seconds": {
-                    "type": "integer"
-                },
-                "preview_token": {
-                    "type": "string"
+                "all": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 11
                 },
-                "state_hash": {
-                    "type": "string"
+                "bytes": {
+                    "$ref": "#/definitions/jsonapi.FileCategoryBytes"
                 },
-                "to_currency": {
-                    "type": "string"
+                "documents": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 6
                 },
-                "total_current_after": {
-                    "type": "number"
+                "images": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 3
                 },
-                "total_current_before": {
-                    "type": "number"
+                "other": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 2
                 }
             }
         },
-        "jsonapi.CurrencyMigrationPreviewDiff": {
+        "jsonapi.FileCategoryCountsResponse": {
             "type": "object",
             "properties": {
-                "commodity_id": {
-                    "type": "string"
-                },
-                "commodity_name": {
-                    "type": "string"
-                },
-                "current_price_after": {
-                    "type": "number"
-                },
-                "current_price_before": {
-                    "type": "number"
-                },
-                "original_currency_after": {
-                    "type": "string"
-                },
-                "original_currency_before": {
-                    "type": "string"
-                },
-                "original_price_after": {
-                    "type": "number"
-                },
-                "original_price_before": {
-                    "type": "number"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.FileCategoryCounts"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationPreviewRequest": {
+        "jsonapi.FileMeta": {
+            "type": "object",
+            "properties": {
+                "signed_urls": {
+                    "description": "Map of file ID to signed URLs and thumbnails",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/jsonapi.URLData"
+                    }
+                }
+            }
+        },
+        "jsonapi.FileRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewRequestData"
+                    "$ref": "#/definitions/jsonapi.FileRequestDataWrapper"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationPreviewRequestData": {
+        "jsonapi.FileRequestData": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewAttributes"
+                "description": {
+                    "type": "string"
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "currency-migrations"
-                    ],
-                    "example": "currency-migrations"
+                "linked_entity_id": {
+                    "description": "ID of linked entity",
+                    "type": "string"
+                },
+                "linked_entity_meta": {
+                    "description": "metadata about the link",
+                    "type": "string"
+                },
+                "linked_entity_type": {
+                    "description": "commodity, export, or empty",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationPreviewResponse": {
+        "jsonapi.FileRequestDataWrapper": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewResponseData"
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.FileRequestData"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationPreviewResponseData": {
+        "jsonapi.FileResponse": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.CurrencyMigrationPreviewBody"
+                    "$ref": "#/definitions/models.FileEntity"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meta": {
+                    "description": "Optional meta field for signed URLs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jsonapi.FileMeta"
+                        }
+                    ]
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "currency-migration-previews"
+                        "files"
                     ],
-                    "example": "currency-migration-previews"
+                    "example": "files"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationResponse": {
+        "jsonapi.FileUpdateRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.CurrencyMigrationResponseData"
+                    "$ref": "#/definitions/jsonapi.FileUpdateRequestData"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationResponseData": {
+        "jsonapi.FileUpdateRequestData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.CurrencyMigration"
+                    "$ref": "#/definitions/jsonapi.FileUpdateRequestFileData"
                 },
                 "id": {
                     "type": "string"
@@ -10891,136 +14931,156 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "currency-migrations"
+                        "files"
                     ],
-                    "example": "currency-migrations"
+                    "example": "files"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationStartAttributes": {
+        "jsonapi.FileUpdateRequestFileData": {
             "type": "object",
             "properties": {
-                "exchange_rate": {
-                    "description": "ExchangeRate is the user-typed rate (1 from = rate to). FE clamps\nto 6 decimals as a UX guard; BE validates per #202 §2 (positive,\nfinite, ≤ 1e10).",
-                    "type": "number"
+                "description": {
+                    "type": "string"
                 },
-                "from_currency": {
+                "linked_entity_id": {
+                    "description": "ID of linked entity",
                     "type": "string"
                 },
-                "preview_token": {
-                    "description": "PreviewToken is the HMAC-signed token the preview endpoint\nreturned. Required; the start handler verifies the signature and\nthen re-derives the state hash to detect group drift between\npreview and commit.",
+                "linked_entity_meta": {
+                    "description": "metadata about the link",
                     "type": "string"
                 },
-                "to_currency": {
+                "linked_entity_type": {
+                    "description": "commodity, export, or empty",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "User-editable filename",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.CurrencyMigrationStartRequest": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.CurrencyMigrationStartRequestData"
-                }
-            }
-        },
-        "jsonapi.CurrencyMigrationStartRequestData": {
+        "jsonapi.FilesMeta": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.CurrencyMigrationStartAttributes"
+                "files": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
                 },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "currency-migrations"
-                    ],
-                    "example": "currency-migrations"
+                "signed_urls": {
+                    "description": "Map of file ID to signed URLs and thumbnails",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/jsonapi.URLData"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
                 }
             }
         },
-        "jsonapi.CurrencyMigrationsResponse": {
+        "jsonapi.FilesResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/jsonapi.CurrencyMigrationResponseData"
+                        "$ref": "#/definitions/models.FileEntity"
                     }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.FilesMeta"
                 }
             }
         },
-        "jsonapi.Error": {
+        "jsonapi.GroupDeleteRequest": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Code is the application-specific error code (e.g.\n\"currency_migration.daily_cap_reached\"). Optional; when present,\nthe FE branches on this string before falling back to the generic\nHTTP status. Stable across versions.",
+                "confirm_word": {
                     "type": "string"
                 },
-                "error": {
-                    "description": "user-level error message",
-                    "type": "object"
-                },
-                "meta": {
-                    "description": "Meta is a free-form JSON object the handler may attach for\nmachine-readable context (e.g. {\"retry_after_seconds\": 3600}).\nOptional; serialised only when non-empty.\n\nSwagger annotation note: ` + "`" + `swaggertype:\"object,string\"` + "`" + ` tells swag\nto emit ` + "`" + `additionalProperties: { type: string }` + "`" + `. Without an\nexplicit additionalProperties, openapi-typescript renders the\nempty ` + "`" + `{type: object}` + "`" + ` schema as ` + "`" + `Record\u003cstring, never\u003e` + "`" + ` — a\nclosed, indexable-but-empty object — which makes\n` + "`" + `meta.retry_after_seconds` + "`" + ` uncallable in TS without a cast. With\nthe override the FE gets a ` + "`" + `{ [key: string]: string }` + "`" + ` index\nsignature; consumers parse known keys (` + "`" + `retry_after_seconds:\nnumber` + "`" + `, ` + "`" + `migration_id: string` + "`" + `, ` + "`" + `status: string` + "`" + `) into their\nreal types — we control both sides of that cast.",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "status": {
-                    "description": "user-level status message",
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.Errors": {
+        "jsonapi.GroupInviteCreateAttrs": {
             "type": "object",
             "properties": {
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/jsonapi.Error"
-                    }
+                "email": {
+                    "type": "string",
+                    "example": "colleague@example.com"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GroupRole"
+                        }
+                    ],
+                    "example": "user"
                 }
             }
         },
-        "jsonapi.ExportCreateRequest": {
+        "jsonapi.GroupInviteCreateData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.GroupInviteCreateAttrs"
+                }
+            }
+        },
+        "jsonapi.GroupInviteCreateRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.ExportCreateRequestData"
+                    "$ref": "#/definitions/jsonapi.GroupInviteCreateData"
                 }
             }
         },
-        "jsonapi.ExportCreateRequestData": {
+        "jsonapi.GroupInviteData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.Export"
+                    "$ref": "#/definitions/models.GroupInvite"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "exports"
+                        "invites"
                     ],
-                    "example": "exports"
+                    "example": "invites"
                 }
             }
         },
-        "jsonapi.ExportResponse": {
+        "jsonapi.GroupInviteResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.ExportResponseData"
+                    "$ref": "#/definitions/jsonapi.GroupInviteResponseData"
                 }
             }
         },
-        "jsonapi.ExportResponseData": {
+        "jsonapi.GroupInviteResponseData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.Export"
+                    "$ref": "#/definitions/models.GroupInvite"
                 },
                 "id": {
                     "type": "string"
@@ -11028,393 +15088,401 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "exports"
+                        "invites"
                     ],
-                    "example": "exports"
-                }
-            }
-        },
-        "jsonapi.ExportsMeta": {
-            "type": "object",
-            "properties": {
-                "exports": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1
+                    "example": "invites"
                 }
             }
         },
-        "jsonapi.ExportsResponse": {
+        "jsonapi.GroupInvitesResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/jsonapi.ExportResponseData"
+                        "$ref": "#/definitions/jsonapi.GroupInviteData"
                     }
-                },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.ExportsMeta"
                 }
             }
         },
-        "jsonapi.FileCategoryBytes": {
+        "jsonapi.GroupMembershipResponse": {
             "type": "object",
             "properties": {
-                "all": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1966080
-                },
-                "documents": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 786432
-                },
-                "images": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 1048576
-                },
-                "other": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 131072
+                "data": {
+                    "$ref": "#/definitions/jsonapi.GroupMembershipResponseData"
                 }
             }
         },
-        "jsonapi.FileCategoryCounts": {
+        "jsonapi.GroupMembershipResponseData": {
             "type": "object",
             "properties": {
-                "all": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 11
-                },
-                "bytes": {
-                    "$ref": "#/definitions/jsonapi.FileCategoryBytes"
-                },
-                "documents": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 6
+                "attributes": {
+                    "$ref": "#/definitions/models.GroupMembership"
                 },
-                "images": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 3
+                "id": {
+                    "type": "string"
                 },
-                "other": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 2
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "memberships"
+                    ],
+                    "example": "memberships"
                 }
             }
         },
-        "jsonapi.FileCategoryCountsResponse": {
+        "jsonapi.GroupMembershipRoleAttributes": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.FileCategoryCounts"
+                "role": {
+                    "$ref": "#/definitions/models.GroupRole"
                 }
             }
         },
-        "jsonapi.FileMeta": {
+        "jsonapi.GroupMembershipRoleData": {
             "type": "object",
             "properties": {
-                "signed_urls": {
-                    "description": "Map of file ID to signed URLs and thumbnails",
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/jsonapi.URLData"
-                    }
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.GroupMembershipRoleAttributes"
                 }
             }
         },
-        "jsonapi.FileRequest": {
+        "jsonapi.GroupMembershipRoleRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.FileRequestDataWrapper"
+                    "$ref": "#/definitions/jsonapi.GroupMembershipRoleData"
                 }
             }
         },
-        "jsonapi.FileRequestData": {
+        "jsonapi.GroupTransferAttributes": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "linked_entity_id": {
-                    "description": "ID of linked entity",
-                    "type": "string"
+                "area_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "linked_entity_meta": {
-                    "description": "metadata about the link",
-                    "type": "string"
+                "commodity_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "linked_entity_type": {
-                    "description": "commodity, export, or empty",
-                    "type": "string"
+                "exchange_rate": {
+                    "type": "string",
+                    "example": "0.92"
                 },
-                "tags": {
+                "location_ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "title": {
+                "target_area_id": {
                     "type": "string"
-                }
-            }
-        },
-        "jsonapi.FileRequestDataWrapper": {
-            "type": "object",
-            "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.FileRequestData"
                 },
-                "id": {
+                "target_group_id": {
                     "type": "string"
                 },
-                "type": {
+                "target_location_id": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.FileResponse": {
+        "jsonapi.GroupTransferMeta": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/models.FileEntity"
+                "areas": {
+                    "type": "integer",
+                    "format": "int64"
                 },
-                "id": {
-                    "type": "string"
+                "commodities": {
+                    "type": "integer",
+                    "format": "int64"
                 },
-                "meta": {
-                    "description": "Optional meta field for signed URLs",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/jsonapi.FileMeta"
-                        }
-                    ]
+                "converted": {
+                    "type": "boolean"
                 },
-                "type": {
+                "exchange_rate": {
                     "type": "string",
-                    "enum": [
-                        "files"
-                    ],
-                    "example": "files"
+                    "example": "0.92"
+                },
+                "files": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "locations": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "tags_created": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "jsonapi.FileUpdateRequest": {
+        "jsonapi.GroupTransferRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.FileUpdateRequestData"
+                    "$ref": "#/definitions/jsonapi.GroupTransferRequestData"
                 }
             }
         },
-        "jsonapi.FileUpdateRequestData": {
+        "jsonapi.GroupTransferRequestData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.FileUpdateRequestFileData"
-                },
-                "id": {
-                    "type": "string"
+                    "$ref": "#/definitions/jsonapi.GroupTransferAttributes"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "files"
+                        "group_transfers"
                     ],
-                    "example": "files"
+                    "example": "group_transfers"
                 }
             }
         },
-        "jsonapi.FileUpdateRequestFileData": {
+        "jsonapi.GroupTransferResponse": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "linked_entity_id": {
-                    "description": "ID of linked entity",
-                    "type": "string"
-                },
-                "linked_entity_meta": {
-                    "description": "metadata about the link",
-                    "type": "string"
-                },
-                "linked_entity_type": {
-                    "description": "commodity, export, or empty",
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.LocationGroupResponseData"
                 },
-                "path": {
-                    "description": "User-editable filename",
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.GroupTransferMeta"
+                }
+            }
+        },
+        "jsonapi.ImportExportAttributes": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "title": {
+                "source_file_path": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.FilesMeta": {
+        "jsonapi.ImportExportRequest": {
             "type": "object",
             "properties": {
-                "files": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 10
-                },
-                "signed_urls": {
-                    "description": "Map of file ID to signed URLs and thumbnails",
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/jsonapi.URLData"
-                    }
-                },
-                "total": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 100
+                "data": {
+                    "$ref": "#/definitions/jsonapi.ImportExportRequestData"
                 }
             }
         },
-        "jsonapi.FilesResponse": {
+        "jsonapi.ImportExportRequestData": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.FileEntity"
-                    }
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.ImportExportAttributes"
                 },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.FilesMeta"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "exports"
+                    ],
+                    "example": "exports"
                 }
             }
         },
-        "jsonapi.GroupDeleteRequest": {
+        "jsonapi.ImportReplicaAttributes": {
             "type": "object",
             "properties": {
-                "confirm_word": {
+                "description": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "replica_url": {
+                    "description": "ReplicaURL is the object URL reported in an export's replica_url.",
+                    "type": "string",
+                    "example": "s3://inventario-backups/t/tenant-id/exports/backup_full_database_20260513_030000.inb?region=eu-west-1"
                 }
             }
         },
-        "jsonapi.GroupInviteCreateAttrs": {
+        "jsonapi.ImportReplicaRequest": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "colleague@example.com"
-                },
-                "role": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.GroupRole"
-                        }
-                    ],
-                    "example": "user"
+                "data": {
+                    "$ref": "#/definitions/jsonapi.ImportReplicaRequestData"
                 }
             }
         },
-        "jsonapi.GroupInviteCreateData": {
+        "jsonapi.ImportReplicaRequestData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.GroupInviteCreateAttrs"
+                    "$ref": "#/definitions/jsonapi.ImportReplicaAttributes"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "exports"
+                    ],
+                    "example": "exports"
                 }
             }
         },
-        "jsonapi.GroupInviteCreateRequest": {
+        "jsonapi.InviteInfoAttr": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/jsonapi.GroupInviteCreateData"
+                "expired": {
+                    "type": "boolean"
+                },
+                "group_icon": {
+                    "type": "string"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "used": {
+                    "type": "boolean"
                 }
             }
         },
-        "jsonapi.GroupInviteData": {
+        "jsonapi.InviteInfoData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.GroupInvite"
-                },
-                "id": {
-                    "type": "string"
+                    "$ref": "#/definitions/jsonapi.InviteInfoAttr"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "invites"
+                        "invite_info"
                     ],
-                    "example": "invites"
+                    "example": "invite_info"
                 }
             }
         },
-        "jsonapi.GroupInviteResponse": {
+        "jsonapi.InviteInfoResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.GroupInviteResponseData"
+                    "$ref": "#/definitions/jsonapi.InviteInfoData"
                 }
             }
         },
-        "jsonapi.GroupInviteResponseData": {
+        "jsonapi.InvoiceExtractionApproveRequest": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.InvoiceExtractionApproveRequestDataWrapper"
+                }
+            }
+        },
+        "jsonapi.InvoiceExtractionApproveRequestData": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "description": "Fields narrows the approval to a subset of the proposed changes.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "purchase_date",
+                            "original_price",
+                            "original_price_currency",
+                            "comments"
+                        ]
+                    }
+                }
+            }
+        },
+        "jsonapi.InvoiceExtractionApproveRequestDataWrapper": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.GroupInvite"
-                },
-                "id": {
-                    "type": "string"
+                    "$ref": "#/definitions/jsonapi.InvoiceExtractionApproveRequestData"
                 },
                 "type": {
-                    "type": "string",
-                    "enum": [
-                        "invites"
-                    ],
-                    "example": "invites"
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.GroupInvitesResponse": {
+        "jsonapi.InvoiceExtractionListItem": {
             "type": "object",
             "properties": {
-                "data": {
+                "changes": {
+                    "description": "Changes is the proposed commodity update, computed against the\ncommodity as it was when the document was read.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/jsonapi.GroupInviteData"
+                        "$ref": "#/definitions/models.InvoiceExtractionChange"
                     }
+                },
+                "commodity": {
+                    "$ref": "#/definitions/jsonapi.MaintenanceCommodityRef"
+                },
+                "commodity_id": {
+                    "description": "CommodityID is the commodity the file is linked to and the target\nof the proposed changes.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "description": "ErrorCode is the commodity scan error code of a failed extraction.",
+                    "type": "string"
+                },
+                "file_id": {
+                    "description": "FileID is the invoice document that was read.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "line_items": {
+                    "description": "LineItems lists every product line on the document, so the\nreviewer can see which one the proposal was taken from.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoiceLineItem"
+                    }
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by_user_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.InvoiceExtractionStatus"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vendor": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.GroupMembershipResponse": {
+        "jsonapi.InvoiceExtractionResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.GroupMembershipResponseData"
+                    "$ref": "#/definitions/jsonapi.InvoiceExtractionResponseData"
                 }
             }
         },
-        "jsonapi.GroupMembershipResponseData": {
+        "jsonapi.InvoiceExtractionResponseData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/models.GroupMembership"
+                    "$ref": "#/definitions/models.InvoiceExtraction"
                 },
                 "id": {
                     "type": "string"
@@ -11422,107 +15490,116 @@ const docTemplate = `{
                 "type": {
                     "type": "string",
                     "enum": [
-                        "memberships"
+                        "invoice_extractions"
                     ],
-                    "example": "memberships"
-                }
-            }
-        },
-        "jsonapi.GroupMembershipRoleAttributes": {
-            "type": "object",
-            "properties": {
-                "role": {
-                    "$ref": "#/definitions/models.GroupRole"
+                    "example": "invoice_extractions"
                 }
             }
         },
-        "jsonapi.GroupMembershipRoleData": {
+        "jsonapi.InvoiceExtractionsMeta": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "$ref": "#/definitions/jsonapi.GroupMembershipRoleAttributes"
+                "extractions": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
                 }
             }
         },
-        "jsonapi.GroupMembershipRoleRequest": {
+        "jsonapi.InvoiceExtractionsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.GroupMembershipRoleData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.InvoiceExtractionListItem"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.InvoiceExtractionsMeta"
                 }
             }
         },
-        "jsonapi.ImportExportAttributes": {
+        "jsonapi.LoanBorrowerLinkAttributes": {
             "type": "object",
             "properties": {
-                "description": {
+                "expires_at": {
                     "type": "string"
                 },
-                "source_file_path": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "jsonapi.ImportExportRequest": {
+        "jsonapi.LoanBorrowerLinkResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.ImportExportRequestData"
+                    "$ref": "#/definitions/jsonapi.LoanBorrowerLinkResponseData"
                 }
             }
         },
-        "jsonapi.ImportExportRequestData": {
+        "jsonapi.LoanBorrowerLinkResponseData": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.ImportExportAttributes"
+                    "$ref": "#/definitions/jsonapi.LoanBorrowerLinkAttributes"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "exports"
+                        "loan_borrower_links"
                     ],
-                    "example": "exports"
+                    "example": "loan_borrower_links"
                 }
             }
         },
-        "jsonapi.InviteInfoAttr": {
+        "jsonapi.LoanBorrowerRequestData": {
             "type": "object",
             "properties": {
-                "expired": {
-                    "type": "boolean"
-                },
-                "group_icon": {
+                "due_back_at": {
                     "type": "string"
                 },
-                "group_name": {
-                    "type": "string"
+                "kind": {
+                    "enum": [
+                        "return",
+                        "extension"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LoanRequestKind"
+                        }
+                    ],
+                    "example": "extension"
                 },
-                "used": {
-                    "type": "boolean"
+                "note": {
+                    "type": "string"
                 }
             }
         },
-        "jsonapi.InviteInfoData": {
+        "jsonapi.LoanBorrowerRequestDataWrapper": {
             "type": "object",
             "properties": {
                 "attributes": {
-                    "$ref": "#/definitions/jsonapi.InviteInfoAttr"
+                    "$ref": "#/definitions/jsonapi.LoanBorrowerRequestData"
                 },
                 "type": {
                     "type": "string",
                     "enum": [
-                        "invite_info"
+                        "loan_requests"
                     ],
-                    "example": "invite_info"
+                    "example": "loan_requests"
                 }
             }
         },
-        "jsonapi.InviteInfoResponse": {
+        "jsonapi.LoanBorrowerRequestRequest": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/jsonapi.InviteInfoData"
+                    "$ref": "#/definitions/jsonapi.LoanBorrowerRequestDataWrapper"
                 }
             }
         },
@@ -11568,6 +15645,9 @@ const docTemplate = `{
                 },
                 "uuid": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -11804,6 +15884,35 @@ const docTemplate = `{
                 }
             }
         },
+        "jsonapi.MaintenanceLogsMeta": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
+                }
+            }
+        },
+        "jsonapi.MaintenanceLogsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MaintenanceLog"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.MaintenanceLogsMeta"
+                }
+            }
+        },
         "jsonapi.MaintenanceScheduleDoneRequest": {
             "type": "object",
             "properties": {
@@ -11815,8 +15924,28 @@ const docTemplate = `{
         "jsonapi.MaintenanceScheduleDoneRequestData": {
             "type": "object",
             "properties": {
+                "cost_amount": {
+                    "type": "number"
+                },
+                "cost_currency": {
+                    "type": "string"
+                },
                 "done_at": {
                     "type": "string"
+                },
+                "file_ids": {
+                    "description": "FileIDs attaches files already uploaded to the group.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meter_value": {
+                    "description": "MeterValue is the meter reading at completion; for usage\nschedules it is also stored as a meter reading.",
+                    "type": "number"
+                },
+                "notes": {
+                    "type": "string"
                 }
             }
         },
@@ -11834,6 +15963,14 @@ const docTemplate = `{
         "jsonapi.MaintenanceScheduleListItem": {
             "type": "object",
             "properties": {
+                "calendar_rule": {
+                    "description": "CalendarRule is the recurrence rule of calendar schedules; nil\nfor the other kinds.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MaintenanceCalendarRule"
+                        }
+                    ]
+                },
                 "commodity": {
                     "$ref": "#/definitions/jsonapi.MaintenanceCommodityRef"
                 },
@@ -11852,21 +15989,43 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "interval_days": {
-                    "description": "IntervalDays is the fixed cadence in days. v1 keeps this as a\nplain integer — cron-like recurrence is deliberately out of\nscope (#1368 options §1). Validated to be strictly positive: a\nnon-positive interval would either spam reminders (0) or make\nnext_due_at recede (negative).",
+                    "description": "IntervalDays is the fixed cadence in days for interval\nschedules, where it is validated to be strictly positive: a\nnon-positive interval would either spam reminders (0) or make\nnext_due_at recede (negative). For usage schedules it is an\noptional time cap (0 = none); calendar schedules ignore it.",
                     "type": "integer"
                 },
                 "last_done_at": {
                     "description": "LastDoneAt is the most recent date the user marked the schedule\nas done. Nullable for freshly-created rows the user has not yet\nperformed once — the FE renders \"—\" for those. The done date may\nbe in the past (the user logging a maintenance they performed\nearlier and forgot to tick off) and may differ from the previous\nnext_due_at by an arbitrary delta (life happens).",
                     "type": "string"
                 },
+                "meter_interval": {
+                    "type": "number"
+                },
+                "meter_unit": {
+                    "description": "MeterUnit, MeterInterval and NextDueMeter describe usage\nschedules: the schedule is due once the commodity's latest\nreading in MeterUnit reaches NextDueMeter, which advances by\nMeterInterval on every completion. Zero / empty for the other\nkinds.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeterUnit"
+                        }
+                    ]
+                },
                 "next_due_at": {
-                    "description": "NextDueAt is the date the next instance is due. Stored as TEXT\nin YYYY-MM-DD format to match the codebase's other date fields\n(lent_at, sent_at, warranty_expires_at). Recomputed on every\nMarkDone call as ` + "`" + `done_date + interval_days` + "`" + `.",
+                    "description": "NextDueAt is the date the next instance is due. Stored as TEXT\nin YYYY-MM-DD format to match the codebase's other date fields\n(lent_at, sent_at, warranty_expires_at). Recomputed on every\nMarkDone call from the recurrence. Usage schedules store the\nestimated date, or \"\" when no estimate is possible yet.",
                     "type": "string"
                 },
+                "next_due_meter": {
+                    "type": "number"
+                },
                 "notes": {
                     "description": "Notes is a free-form aide-mémoire (\"use NSF-53 filter, comes in\n2-packs\"). Capped at 1000 chars — same convention as the loan /\nservice note fields.",
                     "type": "string"
                 },
+                "recurrence": {
+                    "description": "Recurrence selects how NextDueAt is derived — see the type-level\ncomment. Rows created before calendar and usage recurrence\nexisted default to \"interval\".",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MaintenanceRecurrence"
+                        }
+                    ]
+                },
                 "title": {
                     "description": "Title is required and free-form (\"Replace water filter\",\n\"Descale espresso machine\"). Capped at 200 chars to match the\nsoft cap used by other text fields and leave room for indexes.",
                     "type": "string"
@@ -11904,6 +16063,9 @@ const docTemplate = `{
         "jsonapi.MaintenanceScheduleRequestData": {
             "type": "object",
             "properties": {
+                "calendar_rule": {
+                    "$ref": "#/definitions/models.MaintenanceCalendarRule"
+                },
                 "enabled": {
                     "description": "Enabled defaults to true at the BE when omitted (the model\nhas a ` + "`" + `default=\"true\"` + "`" + ` migrator tag); the request field is a\npointer so the BE can tell \"user explicitly chose true\" from\n\"user omitted the field\". The handler folds nil → true.",
                     "type": "boolean"
@@ -11914,12 +16076,45 @@ const docTemplate = `{
                 "last_done_at": {
                     "type": "string"
                 },
+                "meter_interval": {
+                    "type": "number"
+                },
+                "meter_unit": {
+                    "enum": [
+                        "hours",
+                        "km",
+                        "mi",
+                        "cycles"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeterUnit"
+                        }
+                    ]
+                },
                 "next_due_at": {
                     "type": "string"
                 },
+                "next_due_meter": {
+                    "description": "NextDueMeter defaults to the latest reading plus MeterInterval.",
+                    "type": "number"
+                },
                 "notes": {
                     "type": "string"
                 },
+                "recurrence": {
+                    "description": "Recurrence defaults to \"interval\" when omitted.",
+                    "enum": [
+                        "interval",
+                        "calendar",
+                        "usage"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MaintenanceRecurrence"
+                        }
+                    ]
+                },
                 "title": {
                     "type": "string"
                 }
@@ -11976,6 +16171,9 @@ const docTemplate = `{
         "jsonapi.MaintenanceScheduleUpdateRequestData": {
             "type": "object",
             "properties": {
+                "calendar_rule": {
+                    "$ref": "#/definitions/models.MaintenanceCalendarRule"
+                },
                 "enabled": {
                     "type": "boolean"
                 },
@@ -11985,12 +16183,43 @@ const docTemplate = `{
                 "last_done_at": {
                     "type": "string"
                 },
+                "meter_interval": {
+                    "type": "number"
+                },
+                "meter_unit": {
+                    "enum": [
+                        "hours",
+                        "km",
+                        "mi",
+                        "cycles"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeterUnit"
+                        }
+                    ]
+                },
                 "next_due_at": {
                     "type": "string"
                 },
+                "next_due_meter": {
+                    "type": "number"
+                },
                 "notes": {
                     "type": "string"
                 },
+                "recurrence": {
+                    "enum": [
+                        "interval",
+                        "calendar",
+                        "usage"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MaintenanceRecurrence"
+                        }
+                    ]
+                },
                 "title": {
                     "type": "string"
                 }
@@ -12018,24 +16247,126 @@ const docTemplate = `{
                     "format": "int64",
                     "example": 10
                 },
-                "total": {
-                    "type": "integer",
-                    "format": "int64",
-                    "example": 100
+                "total": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 100
+                }
+            }
+        },
+        "jsonapi.MaintenanceSchedulesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MaintenanceSchedule"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.MaintenanceSchedulesMeta"
+                }
+            }
+        },
+        "jsonapi.MemberLocationScopeEntry": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "type": "string",
+                    "example": "loc_123"
+                },
+                "role": {
+                    "enum": [
+                        "viewer",
+                        "user",
+                        "admin"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GroupRole"
+                        }
+                    ],
+                    "example": "viewer"
+                }
+            }
+        },
+        "jsonapi.MemberLocationScopesAttrs": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "member_user_id": {
+                    "type": "string"
+                },
+                "restricted": {
+                    "type": "boolean"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.MemberLocationScopeEntry"
+                    }
+                }
+            }
+        },
+        "jsonapi.MemberLocationScopesData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.MemberLocationScopesAttrs"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "member-location-scopes"
+                    ],
+                    "example": "member-location-scopes"
+                }
+            }
+        },
+        "jsonapi.MemberLocationScopesInputAttrs": {
+            "type": "object",
+            "properties": {
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.MemberLocationScopeEntry"
+                    }
+                }
+            }
+        },
+        "jsonapi.MemberLocationScopesRequest": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.MemberLocationScopesRequestData"
+                }
+            }
+        },
+        "jsonapi.MemberLocationScopesRequestData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.MemberLocationScopesInputAttrs"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "member-location-scopes"
+                    ],
+                    "example": "member-location-scopes"
                 }
             }
         },
-        "jsonapi.MaintenanceSchedulesResponse": {
+        "jsonapi.MemberLocationScopesResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.MaintenanceSchedule"
-                    }
-                },
-                "meta": {
-                    "$ref": "#/definitions/jsonapi.MaintenanceSchedulesMeta"
+                    "$ref": "#/definitions/jsonapi.MemberLocationScopesData"
                 }
             }
         },
@@ -12119,6 +16450,58 @@ const docTemplate = `{
                 }
             }
         },
+        "jsonapi.PublicLoanAttributes": {
+            "type": "object",
+            "properties": {
+                "borrower_name": {
+                    "type": "string"
+                },
+                "commodity_name": {
+                    "type": "string"
+                },
+                "due_back_at": {
+                    "type": "string"
+                },
+                "lent_at": {
+                    "type": "string"
+                },
+                "pending_request_due_back_at": {
+                    "type": "string"
+                },
+                "pending_request_kind": {
+                    "$ref": "#/definitions/models.LoanRequestKind"
+                },
+                "returned": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "jsonapi.PublicLoanResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.PublicLoanResponseData"
+                }
+            }
+        },
+        "jsonapi.PublicLoanResponseData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.PublicLoanAttributes"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "public_loans"
+                    ],
+                    "example": "public_loans"
+                }
+            }
+        },
         "jsonapi.RestoreOperationCreateRequest": {
             "type": "object",
             "properties": {
@@ -12179,6 +16562,111 @@ const docTemplate = `{
                 }
             }
         },
+        "jsonapi.SavedViewRequest": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.SavedViewRequestDataWrapper"
+                }
+            }
+        },
+        "jsonapi.SavedViewRequestData": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "filters": {
+                    "$ref": "#/definitions/models.SavedViewFilters"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scope": {
+                    "enum": [
+                        "private",
+                        "group"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SavedViewScope"
+                        }
+                    ],
+                    "example": "private"
+                }
+            }
+        },
+        "jsonapi.SavedViewRequestDataWrapper": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.SavedViewRequestData"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "saved_views"
+                    ],
+                    "example": "saved_views"
+                }
+            }
+        },
+        "jsonapi.SavedViewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.SavedViewResponseData"
+                }
+            }
+        },
+        "jsonapi.SavedViewResponseData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/models.SavedView"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "saved_views"
+                    ],
+                    "example": "saved_views"
+                }
+            }
+        },
+        "jsonapi.SavedViewsMeta": {
+            "type": "object",
+            "properties": {
+                "views": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
+                }
+            }
+        },
+        "jsonapi.SavedViewsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.SavedViewResponseData"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/jsonapi.SavedViewsMeta"
+                }
+            }
+        },
         "jsonapi.SearchMeta": {
             "type": "object",
             "properties": {
@@ -12241,6 +16729,112 @@ const docTemplate = `{
                 }
             }
         },
+        "jsonapi.StatsAttrs": {
+            "type": "object",
+            "properties": {
+                "by_area": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.StatsBucket"
+                    }
+                },
+                "by_location": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.StatsBucket"
+                    }
+                },
+                "by_purchase_year": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.StatsBucket"
+                    }
+                },
+                "by_status": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.StatsBucket"
+                    }
+                },
+                "by_tag": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.StatsBucket"
+                    }
+                },
+                "by_type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.StatsBucket"
+                    }
+                },
+                "by_warranty_status": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonapi.StatsBucket"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 42
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "jsonapi.StatsBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 12
+                },
+                "key": {
+                    "type": "string",
+                    "example": "in_use"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Living room"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "jsonapi.StatsData": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/jsonapi.StatsAttrs"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "stats"
+                    ],
+                    "example": "stats"
+                }
+            }
+        },
+        "jsonapi.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/jsonapi.StatsData"
+                }
+            }
+        },
         "jsonapi.SupplyLinkReorderRequest": {
             "type": "object",
             "properties": {
@@ -12834,17 +17428,93 @@ const docTemplate = `{
                     "description": "Icon is a short visual token (typically a single emoji) shown as\nthe area's avatar tile in the area grid on the location detail\nview. Empty string means \"no icon picked\" — the UI falls back to\nthe generic Package glyph.",
                     "type": "string"
                 },
-                "id": {
+                "id": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BackupFrequency": {
+            "type": "string",
+            "enum": [
+                "daily",
+                "weekly",
+                "monthly"
+            ],
+            "x-enum-varnames": [
+                "BackupFrequencyDaily",
+                "BackupFrequencyWeekly",
+                "BackupFrequencyMonthly"
+            ]
+        },
+        "models.BackupSchedule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "day_of_month": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "frequency": {
+                    "$ref": "#/definitions/models.BackupFrequency"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "include_file_data": {
+                    "type": "boolean"
+                },
+                "keep_daily": {
+                    "description": "Retention: the newest completed scheduled export of each of the\nlast KeepDaily days, KeepWeekly ISO weeks and KeepMonthly months is\nkept; every other completed scheduled export is pruned. Manual\nexports are never touched.",
+                    "type": "integer"
+                },
+                "keep_monthly": {
+                    "type": "integer"
+                },
+                "keep_weekly": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "description": "LastError is the reason the most recent run failed; cleared by the\nnext successful one.",
+                    "type": "string"
+                },
+                "last_export_id": {
+                    "description": "LastExportID is the export enqueued by the most recent run.",
                     "type": "string"
                 },
-                "location_id": {
+                "last_run_at": {
                     "type": "string"
                 },
-                "name": {
+                "next_run_at": {
+                    "description": "NextRunAt is when the scheduler enqueues the next export. Recomputed\nfrom the cadence on every save and after every run.",
+                    "type": "string"
+                },
+                "time_of_day": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 },
                 "uuid": {
                     "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
                 }
             }
         },
@@ -12953,6 +17623,9 @@ const docTemplate = `{
                 "uuid": {
                     "type": "string"
                 },
+                "version": {
+                    "type": "integer"
+                },
                 "warranty_expires_at": {
                     "description": "WarrantyExpiresAt is the date the manufacturer/seller warranty for this\ncommodity ends. Nil means \"no warranty tracked\" (status=none). Status —\nactive / expiring / expired — is computed from this date and the server\nclock, never stored, so a row \"expires\" without any write happening.",
                     "type": "string"
@@ -12975,10 +17648,16 @@ const docTemplate = `{
                 "returned",
                 "loan_updated",
                 "loan_reminder_sent",
+                "loan_request_submitted",
+                "loan_request_approved",
+                "loan_request_rejected",
                 "sent_for_service",
                 "back_from_service",
                 "service_updated",
-                "deleted"
+                "service_reminder_sent",
+                "deleted",
+                "merged",
+                "transferred"
             ],
             "x-enum-varnames": [
                 "CommodityEventKindCreated",
@@ -12991,10 +17670,16 @@ const docTemplate = `{
                 "CommodityEventKindReturned",
                 "CommodityEventKindLoanUpdated",
                 "CommodityEventKindLoanReminderSent",
+                "CommodityEventKindLoanRequestSubmitted",
+                "CommodityEventKindLoanRequestApproved",
+                "CommodityEventKindLoanRequestRejected",
                 "CommodityEventKindSentForService",
                 "CommodityEventKindBackFromService",
                 "CommodityEventKindServiceUpdated",
-                "CommodityEventKindDeleted"
+                "CommodityEventKindServiceReminderSent",
+                "CommodityEventKindDeleted",
+                "CommodityEventKindMerged",
+                "CommodityEventKindTransferred"
             ]
         },
         "models.CommodityEventPayload": {
@@ -13008,6 +17693,10 @@ const docTemplate = `{
                     "description": "BorrowerContact is free-form (phone / email / @handle). No\nvalidation — the field is for the user's own reference.",
                     "type": "string"
                 },
+                "borrower_email": {
+                    "description": "BorrowerEmail is the optional structured address the reminder\nworker sends courtesy reminders and the signed return link to.\nUnlike BorrowerContact it is validated — an empty value simply\nopts the loan out of borrower-facing mail.",
+                    "type": "string"
+                },
                 "borrower_name": {
                     "description": "BorrowerName is required and free-form. Capped at 200 chars to\nmatch the soft cap the FE already enforces on similar text\nfields and to leave room in DB indexes if we later add one.",
                     "type": "string"
@@ -13016,6 +17705,13 @@ const docTemplate = `{
                     "description": "BorrowerNote is a free-form aide-mémoire (\"works in the office\ndownstairs\"). Capped at 1000 chars.",
                     "type": "string"
                 },
+                "borrower_reminder_sent_due_soon": {
+                    "type": "boolean"
+                },
+                "borrower_reminder_sent_overdue": {
+                    "description": "BorrowerReminderSentOverdue + BorrowerReminderSentDueSoon are the\nborrower-side twins of the flags above. Kept separate so a lender\nopting out of their own reminders never suppresses the borrower's\ncourtesy mail (and vice versa).",
+                    "type": "boolean"
+                },
                 "commodity_id": {
                     "description": "CommodityID — the lent item. ON DELETE CASCADE is added manually\nto the generated migration: hard-deleting a commodity drops its\nloan history (no orphan rows). Soft delete is not currently a\ncommodity capability, so this is the only path that touches loans.",
                     "type": "string"
@@ -13034,6 +17730,26 @@ const docTemplate = `{
                     "description": "LentAt is the date the item left. Required. Stored as TEXT in\nYYYY-MM-DD format to match the project's other date fields\n(purchase_date, registered_date, last_modified_date).",
                     "type": "string"
                 },
+                "pending_request_at": {
+                    "description": "PendingRequestAt is when the pending request was submitted.",
+                    "type": "string"
+                },
+                "pending_request_due_back_at": {
+                    "description": "PendingRequestDueBackAt is the new due date the borrower asked for\n(extension requests only).",
+                    "type": "string"
+                },
+                "pending_request_kind": {
+                    "description": "PendingRequestKind is the borrower's outstanding self-service\nrequest submitted through the signed loan link (\"\" when none).\nThe owner approves or rejects it; either outcome clears the\npending_request_* columns. Only one request is tracked at a time —\na newer submission replaces an unreviewed one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LoanRequestKind"
+                        }
+                    ]
+                },
+                "pending_request_note": {
+                    "description": "PendingRequestNote is the borrower's optional message. Capped at\n1000 chars like BorrowerNote.",
+                    "type": "string"
+                },
                 "reminder_sent_due_soon": {
                     "type": "boolean"
                 },
@@ -13053,6 +17769,38 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CommodityMeterReading": {
+            "type": "object",
+            "properties": {
+                "commodity_id": {
+                    "description": "CommodityID is the metered item. ON DELETE CASCADE is added\nmanually to the generated migration, mirroring\nmaintenance_schedules.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "read_at": {
+                    "description": "ReadAt is the date the meter was read (YYYY-MM-DD).",
+                    "type": "string"
+                },
+                "unit": {
+                    "$ref": "#/definitions/models.MeterUnit"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is the absolute meter value, not a delta.",
+                    "type": "number"
+                }
+            }
+        },
         "models.CommodityService": {
             "type": "object",
             "properties": {
@@ -13236,6 +17984,10 @@ const docTemplate = `{
                 "area_count": {
                     "type": "integer"
                 },
+                "backup_schedule_id": {
+                    "description": "BackupScheduleID is set on exports enqueued by the backup scheduler;\nonly these are subject to the schedule's retention policy.",
+                    "type": "string"
+                },
                 "binary_data_size": {
                     "type": "integer"
                 },
@@ -13292,12 +18044,39 @@ const docTemplate = `{
                 "manual_count": {
                     "type": "integer"
                 },
+                "replica_sha256": {
+                    "type": "string"
+                },
+                "replica_url": {
+                    "type": "string"
+                },
+                "replicated_at": {
+                    "type": "string"
+                },
+                "replication_attempts": {
+                    "type": "integer"
+                },
+                "replication_error": {
+                    "type": "string"
+                },
+                "replication_status": {
+                    "description": "Off-site replication state, maintained by the replication worker when\na replication target is configured. ReplicaSHA256 is the digest of the\narchive as read back from the target.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ExportReplicationStatus"
+                        }
+                    ]
+                },
                 "selected_items": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/models.ExportSelectedItem"
                     }
                 },
+                "signature_key_fingerprint": {
+                    "description": "SignatureKeyFingerprint is the fingerprint of the key the archive is\nsigned with: the server's own key for a generated export, and for an\nimported one the keyring entry its signature verified under.",
+                    "type": "string"
+                },
                 "status": {
                     "$ref": "#/definitions/models.ExportStatus"
                 },
@@ -13309,6 +18088,17 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ExportReplicationStatus": {
+            "type": "string",
+            "enum": [
+                "replicated",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ExportReplicationStatusReplicated",
+                "ExportReplicationStatusFailed"
+            ]
+        },
         "models.ExportSelectedItem": {
             "type": "object",
             "properties": {
@@ -13473,6 +18263,9 @@ const docTemplate = `{
                 },
                 "uuid": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -13602,6 +18395,125 @@ const docTemplate = `{
                 "GroupRoleOwner"
             ]
         },
+        "models.InvoiceExtraction": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes is the proposed commodity update, computed against the\ncommodity as it was when the document was read.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoiceExtractionChange"
+                    }
+                },
+                "commodity_id": {
+                    "description": "CommodityID is the commodity the file is linked to and the target\nof the proposed changes.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "description": "ErrorCode is the commodity scan error code of a failed extraction.",
+                    "type": "string"
+                },
+                "file_id": {
+                    "description": "FileID is the invoice document that was read.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "line_items": {
+                    "description": "LineItems lists every product line on the document, so the\nreviewer can see which one the proposal was taken from.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoiceLineItem"
+                    }
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by_user_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.InvoiceExtractionStatus"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vendor": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvoiceExtractionChange": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "proposed": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvoiceExtractionStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "applied",
+                "rejected",
+                "no_changes",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "InvoiceExtractionStatusPending",
+                "InvoiceExtractionStatusApplied",
+                "InvoiceExtractionStatusRejected",
+                "InvoiceExtractionStatusNoChanges",
+                "InvoiceExtractionStatusFailed"
+            ]
+        },
+        "models.InvoiceLineItem": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.LoanRequestKind": {
+            "type": "string",
+            "enum": [
+                "",
+                "return",
+                "extension"
+            ],
+            "x-enum-varnames": [
+                "LoanRequestKindNone",
+                "LoanRequestKindReturn",
+                "LoanRequestKindExtension"
+            ]
+        },
         "models.Location": {
             "type": "object",
             "properties": {
@@ -13624,6 +18536,9 @@ const docTemplate = `{
                 },
                 "uuid": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -13723,36 +18638,143 @@ const docTemplate = `{
                 "LoginMethodOAuthOther"
             ]
         },
-        "models.LoginOutcome": {
+        "models.LoginOutcome": {
+            "type": "string",
+            "enum": [
+                "ok",
+                "bad_password",
+                "account_locked",
+                "account_disabled",
+                "email_not_verified",
+                "mfa_required",
+                "bad_mfa",
+                "mfa_admin_reset",
+                "identity_linked",
+                "tenant_mismatch"
+            ],
+            "x-enum-varnames": [
+                "LoginOutcomeOK",
+                "LoginOutcomeBadPassword",
+                "LoginOutcomeAccountLocked",
+                "LoginOutcomeAccountDisabled",
+                "LoginOutcomeEmailNotVerified",
+                "LoginOutcomeMFARequired",
+                "LoginOutcomeBadMFA",
+                "LoginOutcomeMFAAdminReset",
+                "LoginOutcomeIdentityLinked",
+                "LoginOutcomeTenantMismatch"
+            ]
+        },
+        "models.MaintenanceCalendarFrequency": {
+            "type": "string",
+            "enum": [
+                "monthly",
+                "yearly"
+            ],
+            "x-enum-varnames": [
+                "MaintenanceCalendarMonthly",
+                "MaintenanceCalendarYearly"
+            ]
+        },
+        "models.MaintenanceCalendarRule": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "description": "Day (1-31) is the day of the month. Mutually exclusive with\nWeekday.",
+                    "type": "integer"
+                },
+                "every": {
+                    "description": "Every is the step between occurrences in units of Frequency.\nZero is treated as 1.",
+                    "type": "integer"
+                },
+                "frequency": {
+                    "$ref": "#/definitions/models.MaintenanceCalendarFrequency"
+                },
+                "month": {
+                    "description": "Month (1-12) pins yearly rules to a month. Must be unset for\nmonthly rules.",
+                    "type": "integer"
+                },
+                "week": {
+                    "description": "Week picks which Weekday of the month: 1-4, or -1 for the last.",
+                    "type": "integer"
+                },
+                "weekday": {
+                    "description": "Weekday is a lowercase English weekday name (\"monday\").",
+                    "type": "string"
+                }
+            }
+        },
+        "models.MaintenanceLog": {
+            "type": "object",
+            "properties": {
+                "commodity_id": {
+                    "description": "CommodityID is denormalised from the schedule so the per-item\nhistory can be read without a join. ON DELETE CASCADE as above.",
+                    "type": "string"
+                },
+                "cost_amount": {
+                    "description": "CostAmount / CostCurrency — see CommodityService for the\nzero-means-unset and pair-validation conventions.",
+                    "type": "number"
+                },
+                "cost_currency": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "done_at": {
+                    "description": "DoneAt is the date the work was performed (YYYY-MM-DD).",
+                    "type": "string"
+                },
+                "file_ids": {
+                    "description": "FileIDs are the attached files (receipts, photos).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meter_value": {
+                    "description": "MeterValue is the meter reading at completion for usage-based\nschedules; nil otherwise.",
+                    "type": "number"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "description": "ScheduleID is the completed schedule. ON DELETE CASCADE is added\nmanually to the generated migration: deleting a schedule drops\nits history with it.",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MaintenanceRecurrence": {
             "type": "string",
             "enum": [
-                "ok",
-                "bad_password",
-                "account_locked",
-                "account_disabled",
-                "email_not_verified",
-                "mfa_required",
-                "bad_mfa",
-                "mfa_admin_reset",
-                "identity_linked",
-                "tenant_mismatch"
+                "interval",
+                "calendar",
+                "usage"
             ],
             "x-enum-varnames": [
-                "LoginOutcomeOK",
-                "LoginOutcomeBadPassword",
-                "LoginOutcomeAccountLocked",
-                "LoginOutcomeAccountDisabled",
-                "LoginOutcomeEmailNotVerified",
-                "LoginOutcomeMFARequired",
-                "LoginOutcomeBadMFA",
-                "LoginOutcomeMFAAdminReset",
-                "LoginOutcomeIdentityLinked",
-                "LoginOutcomeTenantMismatch"
+                "MaintenanceRecurrenceInterval",
+                "MaintenanceRecurrenceCalendar",
+                "MaintenanceRecurrenceUsage"
             ]
         },
         "models.MaintenanceSchedule": {
             "type": "object",
             "properties": {
+                "calendar_rule": {
+                    "description": "CalendarRule is the recurrence rule of calendar schedules; nil\nfor the other kinds.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MaintenanceCalendarRule"
+                        }
+                    ]
+                },
                 "commodity_id": {
                     "description": "CommodityID is the schedule's owning commodity. ON DELETE CASCADE\nis added manually to the generated migration: hard-deleting a\ncommodity drops its maintenance history (no orphan rows). Mirrors\ncommodity_loans / commodity_services.",
                     "type": "string"
@@ -13768,21 +18790,43 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "interval_days": {
-                    "description": "IntervalDays is the fixed cadence in days. v1 keeps this as a\nplain integer — cron-like recurrence is deliberately out of\nscope (#1368 options §1). Validated to be strictly positive: a\nnon-positive interval would either spam reminders (0) or make\nnext_due_at recede (negative).",
+                    "description": "IntervalDays is the fixed cadence in days for interval\nschedules, where it is validated to be strictly positive: a\nnon-positive interval would either spam reminders (0) or make\nnext_due_at recede (negative). For usage schedules it is an\noptional time cap (0 = none); calendar schedules ignore it.",
                     "type": "integer"
                 },
                 "last_done_at": {
                     "description": "LastDoneAt is the most recent date the user marked the schedule\nas done. Nullable for freshly-created rows the user has not yet\nperformed once — the FE renders \"—\" for those. The done date may\nbe in the past (the user logging a maintenance they performed\nearlier and forgot to tick off) and may differ from the previous\nnext_due_at by an arbitrary delta (life happens).",
                     "type": "string"
                 },
+                "meter_interval": {
+                    "type": "number"
+                },
+                "meter_unit": {
+                    "description": "MeterUnit, MeterInterval and NextDueMeter describe usage\nschedules: the schedule is due once the commodity's latest\nreading in MeterUnit reaches NextDueMeter, which advances by\nMeterInterval on every completion. Zero / empty for the other\nkinds.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeterUnit"
+                        }
+                    ]
+                },
                 "next_due_at": {
-                    "description": "NextDueAt is the date the next instance is due. Stored as TEXT\nin YYYY-MM-DD format to match the codebase's other date fields\n(lent_at, sent_at, warranty_expires_at). Recomputed on every\nMarkDone call as ` + "`" + `done_date + interval_days` + "`" + `.",
+                    "description": "NextDueAt is the date the next instance is due. Stored as TEXT\nin YYYY-MM-DD format to match the codebase's other date fields\n(lent_at, sent_at, warranty_expires_at). Recomputed on every\nMarkDone call from the recurrence. Usage schedules store the\nestimated date, or \"\" when no estimate is possible yet.",
                     "type": "string"
                 },
+                "next_due_meter": {
+                    "type": "number"
+                },
                 "notes": {
                     "description": "Notes is a free-form aide-mémoire (\"use NSF-53 filter, comes in\n2-packs\"). Capped at 1000 chars — same convention as the loan /\nservice note fields.",
                     "type": "string"
                 },
+                "recurrence": {
+                    "description": "Recurrence selects how NextDueAt is derived — see the type-level\ncomment. Rows created before calendar and usage recurrence\nexisted default to \"interval\".",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MaintenanceRecurrence"
+                        }
+                    ]
+                },
                 "title": {
                     "description": "Title is required and free-form (\"Replace water filter\",\n\"Descale espresso machine\"). Capped at 200 chars to match the\nsoft cap used by other text fields and leave room for indexes.",
                     "type": "string"
@@ -13795,6 +18839,21 @@ const docTemplate = `{
                 }
             }
         },
+        "models.MeterUnit": {
+            "type": "string",
+            "enum": [
+                "hours",
+                "km",
+                "mi",
+                "cycles"
+            ],
+            "x-enum-varnames": [
+                "MeterUnitHours",
+                "MeterUnitKm",
+                "MeterUnitMiles",
+                "MeterUnitCycles"
+            ]
+        },
         "models.Plan": {
             "type": "object",
             "properties": {
@@ -13844,6 +18903,41 @@ const docTemplate = `{
                 }
             }
         },
+        "models.RestoreConflict": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/models.RestoreConflictKind"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestoreConflictKind": {
+            "type": "string",
+            "enum": [
+                "content_mismatch",
+                "missing_location",
+                "missing_area",
+                "currency_mismatch"
+            ],
+            "x-enum-varnames": [
+                "RestoreConflictContentMismatch",
+                "RestoreConflictMissingLocation",
+                "RestoreConflictMissingArea",
+                "RestoreConflictCurrencyMismatch"
+            ]
+        },
         "models.RestoreOperation": {
             "type": "object",
             "properties": {
@@ -13896,6 +18990,14 @@ const docTemplate = `{
                 "options": {
                     "$ref": "#/definitions/models.RestoreOptions"
                 },
+                "preview": {
+                    "description": "Preview is the planned-change report of a dry-run; nil otherwise.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RestorePreview"
+                        }
+                    ]
+                },
                 "started_date": {
                     "type": "string"
                 },
@@ -13923,24 +19025,171 @@ const docTemplate = `{
                 "include_file_data": {
                     "type": "boolean"
                 },
+                "selection": {
+                    "description": "Selection narrows the restore to chosen subtrees of the archive; nil\nrestores everything.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RestoreSelection"
+                        }
+                    ]
+                },
                 "strategy": {
                     "type": "string"
                 }
             }
         },
+        "models.RestorePreview": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RestoreConflict"
+                    }
+                },
+                "files": {
+                    "$ref": "#/definitions/models.RestorePreviewFiles"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RestorePreviewLocation"
+                    }
+                },
+                "unassigned": {
+                    "description": "Unassigned counts the area-less commodities.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RestorePreviewCounts"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.RestorePreviewAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "skip",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "RestorePreviewActionCreate",
+                "RestorePreviewActionUpdate",
+                "RestorePreviewActionSkip",
+                "RestorePreviewActionDelete"
+            ]
+        },
+        "models.RestorePreviewArea": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/models.RestorePreviewAction"
+                },
+                "commodities": {
+                    "$ref": "#/definitions/models.RestorePreviewCounts"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestorePreviewCounts": {
+            "type": "object",
+            "properties": {
+                "create": {
+                    "type": "integer"
+                },
+                "delete": {
+                    "type": "integer"
+                },
+                "skip": {
+                    "type": "integer"
+                },
+                "update": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RestorePreviewFiles": {
+            "type": "object",
+            "properties": {
+                "delete": {
+                    "type": "integer"
+                },
+                "skip": {
+                    "type": "integer"
+                },
+                "upload": {
+                    "type": "integer"
+                },
+                "upload_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RestorePreviewLocation": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/models.RestorePreviewAction"
+                },
+                "areas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RestorePreviewArea"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestoreSelection": {
+            "type": "object",
+            "properties": {
+                "area_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "commodity_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "location_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "models.RestoreStatus": {
             "type": "string",
             "enum": [
                 "pending",
                 "running",
                 "completed",
-                "failed"
+                "failed",
+                "preview"
             ],
             "x-enum-varnames": [
                 "RestoreStatusPending",
                 "RestoreStatusRunning",
                 "RestoreStatusCompleted",
-                "RestoreStatusFailed"
+                "RestoreStatusFailed",
+                "RestoreStatusPreview"
             ]
         },
         "models.RestoreStep": {
@@ -13994,6 +19243,105 @@ const docTemplate = `{
                 "RestoreStepResultSkipped"
             ]
         },
+        "models.SavedView": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "Columns is the ordered list of list-view columns to show. The\nbackend stores it verbatim; the frontend owns the vocabulary.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filters": {
+                    "$ref": "#/definitions/models.SavedViewFilters"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scope": {
+                    "$ref": "#/definitions/models.SavedViewScope"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SavedViewFilters": {
+            "type": "object",
+            "properties": {
+                "area_id": {
+                    "type": "string"
+                },
+                "include_inactive": {
+                    "type": "boolean"
+                },
+                "lent_out": {
+                    "type": "boolean"
+                },
+                "q": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "-purchase_date"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CommodityStatus"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CommodityType"
+                    }
+                },
+                "unassigned": {
+                    "type": "boolean"
+                },
+                "warranty_expires_before": {
+                    "type": "string",
+                    "example": "2026-12-31"
+                },
+                "warranty_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "expiring"
+                    ]
+                }
+            }
+        },
+        "models.SavedViewScope": {
+            "type": "string",
+            "enum": [
+                "private",
+                "group"
+            ],
+            "x-enum-varnames": [
+                "SavedViewScopePrivate",
+                "SavedViewScopeGroup"
+            ]
+        },
         "models.SettingsObject": {
             "type": "object",
             "properties": {
@@ -14030,6 +19378,9 @@ const docTemplate = `{
                 "notificationsPriceDrop": {
                     "type": "boolean"
                 },
+                "notificationsServiceReminder": {
+                    "type": "boolean"
+                },
                 "notificationsWarrantyExpiry": {
                     "description": "Notification category toggles. Each category controls a class of\noutbound notifications (warranty expiry mailers, weekly digests,\netc.). Transactional senders — password reset, email verification —\nnever consult these and so cannot be opted out.",
                     "type": "boolean"
@@ -304,6 +304,8 @@ func parseResponse(body []byte) (*aivision.ScanResult, error) {
 		}
 		if resp.Usage != nil {
 			result.UsedTokens = resp.Usage.InputTokens + resp.Usage.OutputTokens
+			result.InputTokens = resp.Usage.InputTokens
+			result.OutputTokens = resp.Usage.OutputTokens
 		}
 		return result, nil
 	}
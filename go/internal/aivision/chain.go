@@ -0,0 +1,99 @@
+package aivision
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+)
+
+// Chain is a Provider that tries an ordered list of providers, falling
+// over to the next one when the current one is unavailable or times out.
+// Any other failure (bad credentials, unparseable response) is returned
+// as-is: a different vendor would not fix a misconfigured key, and
+// silently spending a second call on it hides the real problem.
+//
+// Name and Model report the primary provider, which is what short-
+// circuited audit rows (rate limited, disabled) should name. A
+// successful Scan stamps ScanResult.Provider / ScanResult.Model with the
+// member that actually answered.
+type Chain struct {
+	providers      []Provider
+	attemptTimeout time.Duration
+}
+
+// NewChain builds a chain over providers in fallback order. The first
+// provider is the primary. attemptTimeout bounds each attempt so a hung
+// primary leaves budget for the fallbacks; zero applies only the
+// caller's deadline.
+func NewChain(attemptTimeout time.Duration, providers ...Provider) *Chain {
+	return &Chain{providers: providers, attemptTimeout: attemptTimeout}
+}
+
+// Providers returns the chain members in fallback order.
+func (c *Chain) Providers() []Provider {
+	return c.providers
+}
+
+// Name implements Provider.
+func (c *Chain) Name() string {
+	if len(c.providers) == 0 {
+		return ""
+	}
+	return c.providers[0].Name()
+}
+
+// Model implements Provider.
+func (c *Chain) Model() string {
+	if len(c.providers) == 0 {
+		return ""
+	}
+	return c.providers[0].Model()
+}
+
+// Scan implements Provider. It returns the first successful result, or
+// the error of the last attempted provider.
+func (c *Chain) Scan(ctx context.Context, req ScanRequest) (*ScanResult, error) {
+	if len(c.providers) == 0 {
+		return nil, errxtrace.Classify(ErrProviderDisabled)
+	}
+	var lastErr error
+	for i, p := range c.providers {
+		result, err := c.attempt(ctx, p, req)
+		if err == nil {
+			if result != nil {
+				result.Provider = p.Name()
+				result.Model = p.Model()
+			}
+			return result, nil
+		}
+		lastErr = err
+		if !isFallbackError(err) || ctx.Err() != nil || i == len(c.providers)-1 {
+			break
+		}
+		next := c.providers[i+1]
+		slog.Warn("aivision provider failed; falling back",
+			"provider", p.Name(), "model", p.Model(),
+			"fallback_provider", next.Name(), "fallback_model", next.Model(),
+			"error", err)
+	}
+	return nil, lastErr
+}
+
+// attempt runs one provider under the per-attempt deadline.
+func (c *Chain) attempt(ctx context.Context, p Provider, req ScanRequest) (*ScanResult, error) {
+	if c.attemptTimeout <= 0 {
+		return p.Scan(ctx, req)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, c.attemptTimeout)
+	defer cancel()
+	return p.Scan(attemptCtx, req)
+}
+
+// isFallbackError reports whether err is worth retrying on another
+// provider.
+func isFallbackError(err error) bool {
+	return errors.Is(err, ErrProviderUnavailable) || errors.Is(err, ErrProviderTimeout)
+}
@@ -0,0 +1,113 @@
+package aivision_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/internal/aivision"
+)
+
+// stubProvider answers every Scan with a fixed result or error and
+// counts its calls.
+type stubProvider struct {
+	name, model string
+	err         error
+	delay       time.Duration
+	calls       int
+}
+
+func (p *stubProvider) Name() string  { return p.name }
+func (p *stubProvider) Model() string { return p.model }
+
+func (p *stubProvider) Scan(ctx context.Context, _ aivision.ScanRequest) (*aivision.ScanResult, error) {
+	p.calls++
+	if p.delay > 0 {
+		select {
+		case <-time.After(p.delay):
+		case <-ctx.Done():
+			return nil, aivision.ErrProviderTimeout
+		}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &aivision.ScanResult{UsedTokens: 10}, nil
+}
+
+func TestChain_PrimarySucceeds(t *testing.T) {
+	c := qt.New(t)
+
+	primary := &stubProvider{name: "anthropic", model: "a-model"}
+	fallback := &stubProvider{name: "openai", model: "o-model"}
+	chain := aivision.NewChain(0, primary, fallback)
+
+	result, err := chain.Scan(context.Background(), aivision.ScanRequest{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Provider, qt.Equals, "anthropic")
+	c.Assert(result.Model, qt.Equals, "a-model")
+	c.Assert(fallback.calls, qt.Equals, 0)
+	c.Assert(chain.Name(), qt.Equals, "anthropic")
+	c.Assert(chain.Model(), qt.Equals, "a-model")
+}
+
+func TestChain_FallsBackOnUnavailable(t *testing.T) {
+	c := qt.New(t)
+
+	primary := &stubProvider{name: "anthropic", model: "a-model", err: aivision.ErrProviderUnavailable}
+	fallback := &stubProvider{name: "openai", model: "o-model"}
+	chain := aivision.NewChain(0, primary, fallback)
+
+	result, err := chain.Scan(context.Background(), aivision.ScanRequest{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Provider, qt.Equals, "openai")
+	c.Assert(result.Model, qt.Equals, "o-model")
+	c.Assert(primary.calls, qt.Equals, 1)
+	c.Assert(fallback.calls, qt.Equals, 1)
+}
+
+func TestChain_FallsBackOnAttemptTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	primary := &stubProvider{name: "anthropic", model: "a-model", delay: time.Minute}
+	fallback := &stubProvider{name: "openai", model: "o-model"}
+	chain := aivision.NewChain(10*time.Millisecond, primary, fallback)
+
+	result, err := chain.Scan(context.Background(), aivision.ScanRequest{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Provider, qt.Equals, "openai")
+}
+
+func TestChain_DoesNotFallBackOnOtherErrors(t *testing.T) {
+	c := qt.New(t)
+
+	primary := &stubProvider{name: "anthropic", model: "a-model", err: aivision.ErrProviderAuth}
+	fallback := &stubProvider{name: "openai", model: "o-model"}
+	chain := aivision.NewChain(0, primary, fallback)
+
+	_, err := chain.Scan(context.Background(), aivision.ScanRequest{})
+	c.Assert(err, qt.ErrorIs, aivision.ErrProviderAuth)
+	c.Assert(fallback.calls, qt.Equals, 0)
+}
+
+func TestChain_ReturnsLastError(t *testing.T) {
+	c := qt.New(t)
+
+	primary := &stubProvider{name: "anthropic", err: aivision.ErrProviderUnavailable}
+	fallback := &stubProvider{name: "openai", err: aivision.ErrProviderTimeout}
+	chain := aivision.NewChain(0, primary, fallback)
+
+	_, err := chain.Scan(context.Background(), aivision.ScanRequest{})
+	c.Assert(err, qt.ErrorIs, aivision.ErrProviderTimeout)
+	c.Assert(errors.Is(err, aivision.ErrProviderUnavailable), qt.IsFalse)
+}
+
+func TestChain_Empty(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := aivision.NewChain(0).Scan(context.Background(), aivision.ScanRequest{})
+	c.Assert(err, qt.ErrorIs, aivision.ErrProviderDisabled)
+}
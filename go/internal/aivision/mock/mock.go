@@ -133,9 +133,11 @@ func looksLikeInvoice(photos []aivision.PhotoInput) bool {
 // the wire) and avoids action-at-a-distance across calls.
 func cloneScanResult(src aivision.ScanResult) aivision.ScanResult {
 	dst := aivision.ScanResult{
-		UsedTokens: src.UsedTokens,
-		LatencyMS:  src.LatencyMS,
-		Fields:     cloneFieldMap(src.Fields),
+		UsedTokens:   src.UsedTokens,
+		InputTokens:  src.InputTokens,
+		OutputTokens: src.OutputTokens,
+		LatencyMS:    src.LatencyMS,
+		Fields:       cloneFieldMap(src.Fields),
 	}
 	if src.Items != nil {
 		dst.Items = make([]aivision.ScanItem, len(src.Items))
@@ -223,6 +225,7 @@ func DefaultResult() aivision.ScanResult {
 			aivision.FieldNameWarrantyExpiresAt: {Value: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02"), Confidence: 0.45},
 			aivision.FieldNameComments:          {Value: "Black over-ear wireless headphones with active noise cancellation.", Confidence: 0.65},
 			aivision.FieldNameTags:              {Value: []string{"audio", "headphones", "wireless"}, Confidence: 0.60},
+			aivision.FieldNameVendor:            {Value: "Sample Electronics Store", Confidence: 0.40},
 		},
 		Warnings: []aivision.Warning{
 			{Code: "low_confidence", Field: aivision.FieldNamePurchaseDate, Detail: "purchase date inferred from packaging design only"},
@@ -248,6 +251,7 @@ func MultiItemResult() aivision.ScanResult {
 		aivision.FieldNamePurchaseDate:          {Value: purchased, Confidence: 0.85},
 		aivision.FieldNameComments:              {Value: "Purchased from Sample Store s.r.o.", Confidence: 0.70},
 		aivision.FieldNameTags:                  {Value: []string{"coffee", "kitchen", "appliance"}, Confidence: 0.60},
+		aivision.FieldNameVendor:                {Value: "Sample Store s.r.o.", Confidence: 0.85},
 	}
 	frother := map[string]aivision.FieldGuess{
 		aivision.FieldNameName:                  {Value: "Milk Frother", Confidence: 0.86},
@@ -258,6 +262,7 @@ func MultiItemResult() aivision.ScanResult {
 		aivision.FieldNamePurchaseDate:          {Value: purchased, Confidence: 0.85},
 		aivision.FieldNameComments:              {Value: "Purchased from Sample Store s.r.o.", Confidence: 0.70},
 		aivision.FieldNameTags:                  {Value: []string{"coffee", "kitchen"}, Confidence: 0.60},
+		aivision.FieldNameVendor:                {Value: "Sample Store s.r.o.", Confidence: 0.85},
 	}
 	return aivision.ScanResult{
 		Fields: espresso,
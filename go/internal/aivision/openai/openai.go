@@ -308,6 +308,8 @@ func parseResponse(body []byte) (*aivision.ScanResult, error) {
 	}
 	if resp.Usage != nil {
 		result.UsedTokens = resp.Usage.TotalTokens
+		result.InputTokens = resp.Usage.PromptTokens
+		result.OutputTokens = resp.Usage.CompletionTokens
 	}
 	return result, nil
 }
@@ -0,0 +1,93 @@
+package aivision
+
+import (
+	"strings"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidPriceTable is returned by ParsePriceTable for a malformed
+// price spec.
+var ErrInvalidPriceTable = errx.NewSentinel("aivision price table is invalid")
+
+// costPrecision is the number of decimal places a scan cost is rounded
+// to (micro-dollars), matching the audit column's DECIMAL(12,6).
+const costPrecision = 6
+
+var tokensPerUnit = decimal.NewFromInt(1_000_000)
+
+// ModelPrice is the vendor list price of one model in USD per million
+// tokens.
+type ModelPrice struct {
+	Input  decimal.Decimal
+	Output decimal.Decimal
+}
+
+// PriceTable maps a model id (as reported by Provider.Model) to its
+// price. Models missing from the table cost nothing, so an unpriced
+// model never blocks a scan on budget grounds.
+type PriceTable map[string]ModelPrice
+
+// DefaultPriceTable returns the list prices of the default models of the
+// in-tree providers. Operators override or extend it through
+// ParsePriceTable when they pin another model or the vendor reprices.
+func DefaultPriceTable() PriceTable {
+	return PriceTable{
+		"claude-sonnet-4-6": {Input: decimal.RequireFromString("3"), Output: decimal.RequireFromString("15")},
+		"gpt-4o":            {Input: decimal.RequireFromString("2.50"), Output: decimal.RequireFromString("10")},
+	}
+}
+
+// ParsePriceTable returns the default table overlaid with the entries of
+// spec, a comma-separated list of "model=input/output" pairs in USD per
+// million tokens, e.g. "claude-sonnet-4-6=3/15,gpt-4o-mini=0.15/0.60".
+// An empty spec yields the defaults.
+func ParsePriceTable(spec string) (PriceTable, error) {
+	table := DefaultPriceTable()
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, prices, ok := strings.Cut(entry, "=")
+		model = strings.TrimSpace(model)
+		if !ok || model == "" {
+			return nil, errxtrace.Classify(ErrInvalidPriceTable, errx.Attrs("entry", entry))
+		}
+		in, out, ok := strings.Cut(prices, "/")
+		if !ok {
+			return nil, errxtrace.Classify(ErrInvalidPriceTable, errx.Attrs("entry", entry))
+		}
+		inPrice, inErr := decimal.NewFromString(strings.TrimSpace(in))
+		outPrice, outErr := decimal.NewFromString(strings.TrimSpace(out))
+		if inErr != nil || outErr != nil || inPrice.IsNegative() || outPrice.IsNegative() {
+			return nil, errxtrace.Classify(ErrInvalidPriceTable, errx.Attrs("entry", entry))
+		}
+		table[model] = ModelPrice{Input: inPrice, Output: outPrice}
+	}
+	return table, nil
+}
+
+// Cost returns the USD cost of result when produced by model. The
+// input/output split is billed at the matching rates; a provider that
+// only reports a total is billed at the input rate, the cheaper side for
+// every priced vendor, so the estimate never overshoots.
+func (t PriceTable) Cost(model string, result *ScanResult) decimal.Decimal {
+	if result == nil {
+		return decimal.Zero
+	}
+	price, ok := t[model]
+	if !ok {
+		return decimal.Zero
+	}
+	var cost decimal.Decimal
+	if result.InputTokens > 0 || result.OutputTokens > 0 {
+		cost = price.Input.Mul(decimal.NewFromInt(int64(result.InputTokens))).
+			Add(price.Output.Mul(decimal.NewFromInt(int64(result.OutputTokens))))
+	} else {
+		cost = price.Input.Mul(decimal.NewFromInt(int64(result.UsedTokens)))
+	}
+	return cost.Div(tokensPerUnit).Round(costPrecision)
+}
@@ -0,0 +1,58 @@
+package aivision_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/internal/aivision"
+)
+
+func TestParsePriceTable_Empty(t *testing.T) {
+	c := qt.New(t)
+
+	table, err := aivision.ParsePriceTable("")
+	c.Assert(err, qt.IsNil)
+	c.Assert(table, qt.HasLen, len(aivision.DefaultPriceTable()))
+}
+
+func TestParsePriceTable_OverlaysDefaults(t *testing.T) {
+	c := qt.New(t)
+
+	table, err := aivision.ParsePriceTable(" gpt-4o = 5/20 , gpt-4o-mini=0.15/0.60")
+	c.Assert(err, qt.IsNil)
+	c.Assert(table["gpt-4o"].Input.String(), qt.Equals, "5")
+	c.Assert(table["gpt-4o"].Output.String(), qt.Equals, "20")
+	c.Assert(table["gpt-4o-mini"].Output.String(), qt.Equals, "0.6")
+	c.Assert(table["claude-sonnet-4-6"].Input.String(), qt.Equals, "3")
+}
+
+func TestParsePriceTable_Invalid(t *testing.T) {
+	for _, spec := range []string{"gpt-4o", "=1/2", "gpt-4o=1", "gpt-4o=x/2", "gpt-4o=-1/2"} {
+		t.Run(spec, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := aivision.ParsePriceTable(spec)
+			c.Assert(err, qt.ErrorIs, aivision.ErrInvalidPriceTable)
+		})
+	}
+}
+
+func TestPriceTable_Cost(t *testing.T) {
+	c := qt.New(t)
+
+	table := aivision.PriceTable{
+		"m": {Input: decimal.RequireFromString("3"), Output: decimal.RequireFromString("15")},
+	}
+
+	// 1000 in at $3/M + 200 out at $15/M = 0.003 + 0.003.
+	cost := table.Cost("m", &aivision.ScanResult{InputTokens: 1000, OutputTokens: 200})
+	c.Assert(cost.String(), qt.Equals, "0.006")
+
+	// A total-only result is billed at the input rate.
+	cost = table.Cost("m", &aivision.ScanResult{UsedTokens: 1000})
+	c.Assert(cost.String(), qt.Equals, "0.003")
+
+	c.Assert(table.Cost("unpriced", &aivision.ScanResult{UsedTokens: 1000}).IsZero(), qt.IsTrue)
+	c.Assert(table.Cost("m", nil).IsZero(), qt.IsTrue)
+}
@@ -19,7 +19,7 @@ const SystemPrompt = "You are an assistant that extracts structured product info
 	"product line. Do NOT collapse them into one and do NOT pick just one — enumerate EVERY product on the document.\n" +
 	"EXCLUDE non-product lines: subtotals, taxes/VAT, shipping/postage, discounts, vouchers, fees, deposits, and totals.\n" +
 	"For each product, from a receipt/invoice read its own purchase price, currency, and purchase date; " +
-	"put the seller/vendor/store name (there is no dedicated seller field) into \"comments\".\n" +
+	"put the seller/vendor/store name into \"vendor\" and also mention it in \"comments\".\n" +
 	"Classify \"type\" as EXACTLY one of the allowed values in the schema enum; omit it if none clearly fits.\n" +
 	"Keep \"short_name\" a concise label of at most 40 characters.\n" +
 	"Suggest 2–5 short, lowercase \"tags\" describing each product (brand, category, material, colour).\n" +
@@ -130,6 +130,7 @@ func ResponseSchema() map[string]any {
 		FieldNameWarrantyExpiresAt:     fieldGuessString,
 		FieldNameComments:              fieldGuessString,
 		FieldNameTags:                  fieldGuessStringArray,
+		FieldNameVendor:                fieldGuessString,
 	}
 
 	warningSchema := map[string]any{
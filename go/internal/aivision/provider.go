@@ -92,8 +92,9 @@ type ScanRequest struct {
 	// least 1; the caller enforces the upper bound.
 	Photos []PhotoInput
 	// HintFromUser is an optional free-form user hint (brand, category
-	// guess, model number visible elsewhere). Currently always empty;
-	// reserved for a future affordance on the Add Item dialog.
+	// guess, model number visible elsewhere). The Add Item dialog leaves
+	// it empty; the invoice extraction job names the commodity the
+	// document is attached to.
 	HintFromUser string
 	// PreferredCurrencyCode is the tenant's main currency (e.g. "USD",
 	// "EUR"). The prompt asks the model to prefer this code when a
@@ -107,7 +108,7 @@ type ScanRequest struct {
 // gating. Value's concrete type depends on the field:
 //
 //   - name, short_name, type, serial_number, comments,
-//     original_price_currency: string
+//     original_price_currency, vendor: string
 //   - original_price: float64 (decimal as string is also accepted by
 //     callers, which coerce it to a number)
 //   - urls, tags: []string
@@ -162,6 +163,16 @@ type ScanResult struct {
 	// output where the upstream API distinguishes them). Zero when
 	// unavailable.
 	UsedTokens int `json:"used_tokens,omitempty"`
+	// InputTokens and OutputTokens split UsedTokens where the upstream
+	// API reports them separately; the price table bills them at
+	// different rates. Both zero when only a total is available.
+	InputTokens  int `json:"input_tokens,omitempty"`
+	OutputTokens int `json:"output_tokens,omitempty"`
+	// Provider and Model name the provider that actually answered. A
+	// Chain sets them so the audit row records a fallback rather than
+	// the primary; a bare provider leaves them empty.
+	Provider string `json:"-"`
+	Model    string `json:"-"`
 	// LatencyMS is the wall-clock duration of the upstream call,
 	// measured server-side, used for audit and observability.
 	LatencyMS int64 `json:"latency_ms,omitempty"`
@@ -235,6 +246,10 @@ const (
 	FieldNameWarrantyExpiresAt     FieldName = "warranty_expires_at"
 	FieldNameComments              FieldName = "comments"
 	FieldNameTags                  FieldName = "tags"
+	// FieldNameVendor is the seller named on a receipt or invoice. The
+	// Add Item dialog ignores it (the prompt still mirrors the seller
+	// into comments); the invoice extraction job reads it directly.
+	FieldNameVendor FieldName = "vendor"
 )
 
 // AllFieldNames is the closed set used by tests and by the prompt
@@ -251,4 +266,5 @@ var AllFieldNames = []FieldName{
 	FieldNameWarrantyExpiresAt,
 	FieldNameComments,
 	FieldNameTags,
+	FieldNameVendor,
 }
@@ -2,6 +2,7 @@ package aivision
 
 import (
 	"net/http"
+	"time"
 
 	errxtrace "github.com/go-extras/errx/stacktrace"
 )
@@ -38,6 +39,16 @@ type ProviderConfig struct {
 	// tests to swap a RoundTripper without poking into individual
 	// provider constructors.
 	HTTPClient *http.Client
+
+	// Fallbacks lists further provider names tried in order when the
+	// selected provider is unavailable or times out. Each one is built
+	// from the same config. Empty means no fallback.
+	Fallbacks []string
+
+	// AttemptTimeout bounds each provider attempt when Fallbacks is set,
+	// so a hung primary still leaves time for the next provider. Zero
+	// leaves the caller's deadline as the only bound.
+	AttemptTimeout time.Duration
 }
 
 // providerFactory is the closed registration map of in-tree provider
@@ -63,14 +74,46 @@ func RegisterProvider(name string, ctor func(ProviderConfig) (Provider, error))
 // (ErrProviderDisabled, ErrProviderUnknown). Callers should treat
 // ErrProviderDisabled as "intentional 503 from the handler" and
 // ErrProviderUnknown as "boot-time misconfiguration".
+//
+// When cfg.Fallbacks names further providers the result is a Chain with
+// the selected provider first. A disabled primary stays disabled: the
+// fallbacks back up a working provider, they do not replace one.
 func NewProvider(cfg ProviderConfig) (Provider, error) {
-	switch cfg.Name {
+	primary, err := newSingleProvider(cfg.Name, cfg)
+	if err != nil {
+		return nil, err
+	}
+	providers := []Provider{primary}
+	for _, name := range cfg.Fallbacks {
+		if name == "" || name == "none" {
+			continue
+		}
+		p, err := newSingleProvider(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	if len(providers) == 1 {
+		return primary, nil
+	}
+	return NewChain(cfg.AttemptTimeout, providers...), nil
+}
+
+// newSingleProvider builds the provider registered under name.
+func newSingleProvider(name string, cfg ProviderConfig) (Provider, error) {
+	switch name {
 	case "", "none":
 		return nil, errxtrace.Classify(ErrProviderDisabled)
 	}
-	ctor, ok := providerFactory[cfg.Name]
+	ctor, ok := providerFactory[name]
 	if !ok {
 		return nil, errxtrace.Classify(ErrProviderUnknown)
 	}
-	return ctor(cfg)
+	cfg.Name = name
+	p, err := ctor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return traced{Provider: p}, nil
 }
@@ -82,7 +82,30 @@ func TestAllFieldNames_Closed(t *testing.T) {
 
 	expected := []string{
 		"name", "short_name", "type", "original_price", "original_price_currency",
-		"serial_number", "urls", "purchase_date", "warranty_expires_at", "comments", "tags",
+		"serial_number", "urls", "purchase_date", "warranty_expires_at", "comments", "tags", "vendor",
 	}
 	c.Assert(aivision.AllFieldNames, qt.DeepEquals, expected)
 }
+
+func TestNewProvider_FallbacksBuildChain(t *testing.T) {
+	c := qt.New(t)
+
+	provider, err := aivision.NewProvider(aivision.ProviderConfig{
+		Name:      "mock",
+		Fallbacks: []string{"", "none", "mock"},
+	})
+	c.Assert(err, qt.IsNil)
+	chain, ok := provider.(*aivision.Chain)
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(chain.Providers(), qt.HasLen, 2)
+}
+
+func TestNewProvider_UnknownFallback(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := aivision.NewProvider(aivision.ProviderConfig{
+		Name:      "mock",
+		Fallbacks: []string{"definitely-not-a-provider"},
+	})
+	c.Assert(err, qt.ErrorIs, aivision.ErrProviderUnknown)
+}
@@ -0,0 +1,38 @@
+package aivision
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+)
+
+// traced wraps a single provider so every vendor call gets its own span,
+// including each attempt of a fallback Chain. Neither the photos nor the
+// user hint are recorded.
+type traced struct {
+	Provider
+}
+
+func (t traced) Scan(ctx context.Context, req ScanRequest) (result *ScanResult, err error) {
+	ctx, span := tracing.Start(ctx, "aivision.scan",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("aivision.provider", t.Name()),
+			attribute.String("aivision.model", t.Model()),
+			attribute.Int("aivision.photos", len(req.Photos)),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	result, err = t.Provider.Scan(ctx, req)
+	if result != nil {
+		span.SetAttributes(
+			attribute.Int("aivision.input_tokens", result.InputTokens),
+			attribute.Int("aivision.output_tokens", result.OutputTokens),
+		)
+	}
+	return result, err
+}
@@ -23,9 +23,11 @@
 // algorithm identifier surfaced in the manifest and the public-key endpoint is
 // therefore Algorithm ("ed25519-sha256"), not bare "ed25519".
 //
-// Verification on restore ALWAYS uses the server's own configured key, never a
-// key read from the archive; a public key embedded in a manifest is purely
-// informational.
+// Verification on import and restore uses a Keyring: the server's own
+// configured key plus the public keys an operator has explicitly trusted (a
+// sibling instance's key, or this instance's key from before a rotation). A
+// key read from the archive is never trusted; a public key embedded in a
+// manifest is purely informational.
 package backupsign
 
 import (
@@ -163,10 +165,70 @@ func (s *Signer) PublicKeyPEM() ([]byte, error) {
 // returned by the public-key endpoint so operators can tell at a glance which
 // key signed a given backup (useful around key rotation).
 func (s *Signer) Fingerprint() string {
-	sum := sha256.Sum256(s.pub)
+	return Fingerprint(s.pub)
+}
+
+// Fingerprint returns the lowercase hex SHA-256 of a raw Ed25519 public key —
+// the same identifier (*Signer).Fingerprint reports for the server's own key,
+// so a trusted key and the manifest signer block can be compared directly.
+func Fingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
 	return hex.EncodeToString(sum[:])
 }
 
+// Keyring is the set of public keys a backup signature is accepted under: the
+// server's own key first, then any operator-trusted keys. It is immutable once
+// built and safe for concurrent use; callers build one per import/restore from
+// the current trusted-key list so a revocation takes effect on the next run.
+type Keyring struct {
+	keys         []ed25519.PublicKey
+	fingerprints []string
+}
+
+// NewKeyring builds a Keyring from the server's own signer (may be nil when
+// signing is not configured) and the additionally trusted public keys. Keys
+// of the wrong length and duplicates of an earlier key are skipped.
+func NewKeyring(own *Signer, trusted ...ed25519.PublicKey) *Keyring {
+	k := &Keyring{}
+	seen := make(map[string]bool, len(trusted)+1)
+	add := func(pub ed25519.PublicKey) {
+		if len(pub) != ed25519.PublicKeySize {
+			return
+		}
+		fp := Fingerprint(pub)
+		if seen[fp] {
+			return
+		}
+		seen[fp] = true
+		k.keys = append(k.keys, append(ed25519.PublicKey(nil), pub...))
+		k.fingerprints = append(k.fingerprints, fp)
+	}
+	if own != nil {
+		add(own.pub)
+	}
+	for _, pub := range trusted {
+		add(pub)
+	}
+	return k
+}
+
+// Len reports how many keys the Keyring holds.
+func (k *Keyring) Len() int {
+	return len(k.keys)
+}
+
+// VerifyDigest checks sig over digest against every key in the ring, in order,
+// and returns the fingerprint of the first key it verifies under. It returns
+// ErrBadSignature when no key matches (including an empty ring).
+func (k *Keyring) VerifyDigest(digest, sig []byte) (string, error) {
+	for i, pub := range k.keys {
+		if ed25519.Verify(pub, digest, sig) {
+			return k.fingerprints[i], nil
+		}
+	}
+	return "", ErrBadSignature
+}
+
 // VerifyDigestWithPublicKey verifies a detached signature over a payload digest
 // against a raw Ed25519 public key, WITHOUT needing the private key (issue
 // #534). It exists for the `inventario backup resign --verify-key` flow, where
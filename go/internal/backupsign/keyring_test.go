@@ -0,0 +1,51 @@
+package backupsign_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/internal/backupsign"
+)
+
+func TestKeyring_AcceptsOwnAndTrustedKeys(t *testing.T) {
+	c := qt.New(t)
+
+	own, err := backupsign.NewSigner(seed(0x31))
+	c.Assert(err, qt.IsNil)
+	staging, err := backupsign.NewSigner(seed(0x32))
+	c.Assert(err, qt.IsNil)
+	stranger, err := backupsign.NewSigner(seed(0x33))
+	c.Assert(err, qt.IsNil)
+
+	ring := backupsign.NewKeyring(own, staging.PublicKey(), own.PublicKey())
+	c.Assert(ring.Len(), qt.Equals, 2)
+
+	payload := []byte("payload.tar.gz bytes")
+	digest := backupsign.NewDigest()
+	_, _ = digest.Write(payload)
+	sum := digest.Sum(nil)
+
+	fp, err := ring.VerifyDigest(sum, own.SignDigest(sum))
+	c.Assert(err, qt.IsNil)
+	c.Assert(fp, qt.Equals, own.Fingerprint())
+
+	fp, err = ring.VerifyDigest(sum, staging.SignDigest(sum))
+	c.Assert(err, qt.IsNil)
+	c.Assert(fp, qt.Equals, staging.Fingerprint())
+
+	_, err = ring.VerifyDigest(sum, stranger.SignDigest(sum))
+	c.Assert(err, qt.ErrorIs, backupsign.ErrBadSignature)
+}
+
+func TestKeyring_EmptyRejectsEverything(t *testing.T) {
+	c := qt.New(t)
+
+	s, err := backupsign.NewSigner(seed(0x34))
+	c.Assert(err, qt.IsNil)
+
+	ring := backupsign.NewKeyring(nil, []byte("short"))
+	c.Assert(ring.Len(), qt.Equals, 0)
+	_, err = ring.VerifyDigest(make([]byte, backupsign.DigestSize), s.SignDigest(make([]byte, backupsign.DigestSize)))
+	c.Assert(err, qt.ErrorIs, backupsign.ErrBadSignature)
+}
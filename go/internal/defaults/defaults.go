@@ -31,13 +31,20 @@ type Workers struct {
 	RefreshTokenCleanupInterval      string // Refresh token cleanup interval (e.g., "1h")
 	EmailVerificationCleanupInterval string // Email verification token cleanup interval (e.g., "1h")
 	MagicLinkTokenCleanupInterval    string // Magic-link sign-in token cleanup interval (e.g., "1h")
+	IdempotencyKeyCleanupInterval    string // Expired Idempotency-Key record cleanup interval (e.g., "1h")
 	OperationSlotCleanupInterval     string // Operation-slot cleanup interval (e.g., "5m")
 	GroupPurgeInterval               string // Group purge worker interval (e.g., "5m")
 	WarrantyReminderInterval         string // Warranty reminder worker interval (e.g., "1h")
 	StorageQuotaReminderInterval     string // Storage quota warning worker interval (e.g., "1h")
 	LoanReminderInterval             string // Loan reminder worker interval (e.g., "1h")
 	LoanReminderDueSoonDays          int    // Forward-looking window for the loan due-soon reminder (default 7)
+	ServiceReminderInterval          string // Service reminder worker interval (e.g., "1h")
+	ServiceReminderDueSoonDays       int    // Forward-looking window for the service due-soon reminder (default 7)
 	MaintenanceReminderInterval      string // Maintenance reminder worker interval (e.g., "1h")
+	InvoiceExtractionInterval        string // Invoice extraction worker interval (e.g., "15m")
+	InvoiceExtractionBatchSize       int    // Invoice files read per extraction sweep (default 20)
+	BackupSchedulerInterval          string // Scheduled-backup sweep interval (e.g., "5m")
+	BackupReplicationInterval        string // Off-site backup replication sweep interval (e.g., "10m")
 	CurrencyMigrationInterval        string // Currency migration worker active-poll interval (e.g., "5s")
 	BusinessMetricsInterval          string // Business-metrics collector interval (e.g., "60s")
 	WorkerControlRefreshInterval     string // Worker soft-pause control poll interval (e.g., "10s")
@@ -105,13 +112,20 @@ func New() Config {
 			RefreshTokenCleanupInterval:      "1h",
 			EmailVerificationCleanupInterval: "1h",
 			MagicLinkTokenCleanupInterval:    "1h",
+			IdempotencyKeyCleanupInterval:    "1h",
 			OperationSlotCleanupInterval:     "5m",
 			GroupPurgeInterval:               "5m",
 			WarrantyReminderInterval:         "1h",
 			StorageQuotaReminderInterval:     "1h",
 			LoanReminderInterval:             "1h",
 			LoanReminderDueSoonDays:          7,
+			ServiceReminderInterval:          "1h",
+			ServiceReminderDueSoonDays:       7,
 			MaintenanceReminderInterval:      "1h",
+			InvoiceExtractionInterval:        "15m",
+			InvoiceExtractionBatchSize:       20,
+			BackupSchedulerInterval:          "5m",
+			BackupReplicationInterval:        "10m",
 			CurrencyMigrationInterval:        "5s",
 			BusinessMetricsInterval:          "60s",
 			WorkerControlRefreshInterval:     "10s",
@@ -221,6 +235,11 @@ func GetMagicLinkTokenCleanupInterval() string {
 	return defaultConfig.Workers.MagicLinkTokenCleanupInterval
 }
 
+// GetIdempotencyKeyCleanupInterval returns the default Idempotency-Key record cleanup interval
+func GetIdempotencyKeyCleanupInterval() string {
+	return defaultConfig.Workers.IdempotencyKeyCleanupInterval
+}
+
 // GetOperationSlotCleanupInterval returns the default operation-slot cleanup interval
 func GetOperationSlotCleanupInterval() string {
 	return defaultConfig.Workers.OperationSlotCleanupInterval
@@ -277,12 +296,49 @@ func GetLoanReminderDueSoonDays() int {
 	return defaultConfig.Workers.LoanReminderDueSoonDays
 }
 
+// GetServiceReminderInterval returns the default interval between
+// service reminder sweeps. Same hourly cadence as the loan reminder.
+func GetServiceReminderInterval() string {
+	return defaultConfig.Workers.ServiceReminderInterval
+}
+
+// GetServiceReminderDueSoonDays returns the default forward-looking
+// window for the service due-soon kind.
+func GetServiceReminderDueSoonDays() int {
+	return defaultConfig.Workers.ServiceReminderDueSoonDays
+}
+
 // GetMaintenanceReminderInterval returns the default interval
 // between maintenance reminder sweeps (#1368).
 func GetMaintenanceReminderInterval() string {
 	return defaultConfig.Workers.MaintenanceReminderInterval
 }
 
+// GetInvoiceExtractionInterval returns the default interval between
+// invoice extraction sweeps.
+func GetInvoiceExtractionInterval() string {
+	return defaultConfig.Workers.InvoiceExtractionInterval
+}
+
+// GetInvoiceExtractionBatchSize returns the default number of invoice
+// files handed to the AI vision provider per sweep.
+func GetInvoiceExtractionBatchSize() int {
+	return defaultConfig.Workers.InvoiceExtractionBatchSize
+}
+
+// GetBackupSchedulerInterval returns the default interval between
+// scheduled-backup sweeps. It bounds how late a run starts after its
+// slot, so it is much shorter than the reminder cadences.
+func GetBackupSchedulerInterval() string {
+	return defaultConfig.Workers.BackupSchedulerInterval
+}
+
+// GetBackupReplicationInterval returns the default interval between
+// off-site backup replication sweeps.
+func GetBackupReplicationInterval() string {
+	return defaultConfig.Workers.BackupReplicationInterval
+}
+
 // GetCurrencyMigrationInterval returns the default active-poll interval
 // for the currency migration worker. The worker switches to a 1m idle
 // cadence when no pending rows exist, so this is the latency-sensitive
@@ -0,0 +1,136 @@
+// Package ical writes minimal RFC 5545 iCalendar documents: a VCALENDAR
+// of all-day VEVENTs, which is everything the calendar subscription
+// feed needs. It deliberately covers only the properties the feed
+// emits; it is not a general-purpose iCalendar library.
+package ical
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the RFC 5545 §3.1 line length limit, excluding the
+// CRLF. Longer content lines are folded.
+const maxLineOctets = 75
+
+// Calendar is a VCALENDAR object.
+type Calendar struct {
+	// ProdID identifies the producing product (PRODID), e.g.
+	// "-//Inventario//Calendar Feed//EN".
+	ProdID string
+	// Name is shown by clients as the subscription title
+	// (X-WR-CALNAME); optional.
+	Name   string
+	Events []Event
+}
+
+// Event is an all-day VEVENT.
+type Event struct {
+	// UID must be globally unique and stable across feed refreshes so
+	// clients update the event in place instead of duplicating it.
+	UID string
+	// Date is the day of the event; only the date part is used.
+	Date time.Time
+	// Stamp is DTSTAMP, the time the event was last (re)generated.
+	Stamp       time.Time
+	Summary     string
+	Description string
+	// URL is an optional deep link back into the application.
+	URL        string
+	Categories []string
+}
+
+// WriteTo writes the calendar to w using CRLF line endings and folding.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+escapeText(c.ProdID))
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&buf, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+	for _, e := range c.Events {
+		e.write(&buf)
+	}
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.WriteTo(w)
+}
+
+// String returns the serialised calendar.
+func (c *Calendar) String() string {
+	var sb strings.Builder
+	_, _ = c.WriteTo(&sb)
+	return sb.String()
+}
+
+func (e *Event) write(buf *bytes.Buffer) {
+	writeLine(buf, "BEGIN:VEVENT")
+	writeLine(buf, "UID:"+escapeText(e.UID))
+	writeLine(buf, "DTSTAMP:"+e.Stamp.UTC().Format("20060102T150405Z"))
+	writeLine(buf, "DTSTART;VALUE=DATE:"+e.Date.Format("20060102"))
+	writeLine(buf, "DTEND;VALUE=DATE:"+e.Date.AddDate(0, 0, 1).Format("20060102"))
+	writeLine(buf, "SUMMARY:"+escapeText(e.Summary))
+	if e.Description != "" {
+		writeLine(buf, "DESCRIPTION:"+escapeText(e.Description))
+	}
+	if e.URL != "" {
+		writeLine(buf, "URL:"+e.URL)
+	}
+	if len(e.Categories) > 0 {
+		escaped := make([]string, len(e.Categories))
+		for i, cat := range e.Categories {
+			escaped[i] = escapeText(cat)
+		}
+		writeLine(buf, "CATEGORIES:"+strings.Join(escaped, ","))
+	}
+	writeLine(buf, "TRANSP:TRANSPARENT")
+	writeLine(buf, "END:VEVENT")
+}
+
+// escapeText escapes a TEXT value per RFC 5545 §3.3.11.
+func escapeText(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case ';':
+			sb.WriteString(`\;`)
+		case ',':
+			sb.WriteString(`\,`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			// Dropped: a CRLF pair is already encoded by the \n.
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// writeLine writes one content line, folding it into continuation
+// lines (CRLF + space) so no physical line exceeds maxLineOctets.
+// Folding never splits a multi-byte UTF-8 sequence.
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines lose one octet to the leading space.
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
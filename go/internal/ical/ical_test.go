@@ -0,0 +1,71 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/internal/ical"
+)
+
+func TestCalendar_String(t *testing.T) {
+	c := qt.New(t)
+
+	cal := ical.Calendar{
+		ProdID: "-//Inventario//Calendar Feed//EN",
+		Name:   "Inventario",
+		Events: []ical.Event{{
+			UID:         "warranty-1@inventario",
+			Date:        time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+			Stamp:       time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+			Summary:     "Warranty expires: Laptop; 15\", silver",
+			Description: "Line one\nLine two",
+			URL:         "https://inventario.example/g/home/commodities/1",
+			Categories:  []string{"warranty_expiry"},
+		}},
+	}
+
+	c.Assert(cal.String(), qt.Equals, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Inventario//Calendar Feed//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Inventario",
+		"BEGIN:VEVENT",
+		"UID:warranty-1@inventario",
+		"DTSTAMP:20261018T093000Z",
+		"DTSTART;VALUE=DATE:20261231",
+		"DTEND;VALUE=DATE:20270101",
+		`SUMMARY:Warranty expires: Laptop\; 15"\, silver`,
+		`DESCRIPTION:Line one\nLine two`,
+		"URL:https://inventario.example/g/home/commodities/1",
+		"CATEGORIES:warranty_expiry",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"))
+}
+
+func TestCalendar_FoldsLongLines(t *testing.T) {
+	c := qt.New(t)
+
+	cal := ical.Calendar{
+		ProdID: "-//Inventario//Calendar Feed//EN",
+		Events: []ical.Event{{
+			UID:     "x",
+			Date:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Summary: strings.Repeat("ä", 100),
+		}},
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(cal.String(), "\r\n"), "\r\n") {
+		c.Assert(len(line) <= 75, qt.IsTrue, qt.Commentf("line too long: %q", line))
+		c.Assert(strings.ToValidUTF8(line, "?"), qt.Equals, line)
+	}
+	unfolded := strings.ReplaceAll(cal.String(), "\r\n ", "")
+	c.Assert(strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("ä", 100)+"\r\n"), qt.IsTrue)
+}
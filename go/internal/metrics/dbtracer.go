@@ -2,9 +2,14 @@ package metrics
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 )
 
 // queryTraceKey is the unexported context key under which the query
@@ -17,12 +22,17 @@ type queryTraceKey struct{}
 type queryTraceData struct {
 	start time.Time
 	verb  string
+	span  trace.Span
 }
 
 // QueryTracer implements pgx.QueryTracer to record per-query latency
 // and counts into the Prometheus default registry. The SQL operation
 // (select/insert/update/...) is the only label, keeping cardinality
 // bounded — see parseSQLVerb.
+//
+// It also opens a client span per query under the span in the query's
+// context, so database time shows up inside request and worker traces.
+// The span carries the statement text but never the bound arguments.
 type QueryTracer struct{}
 
 // compile-time assertion that QueryTracer satisfies pgx.QueryTracer.
@@ -34,17 +44,28 @@ func NewQueryTracer() *QueryTracer {
 	return &QueryTracer{}
 }
 
-// TraceQueryStart stashes the start time and parsed SQL verb in the
-// returned context for retrieval in TraceQueryEnd.
+// TraceQueryStart starts the query span and stashes it with the start
+// time and parsed SQL verb in the returned context for retrieval in
+// TraceQueryEnd.
 func (*QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	verb := parseSQLVerb(data.SQL)
+	ctx, span := tracing.Start(ctx, strings.ToUpper(verb),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(verb),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
 	return context.WithValue(ctx, queryTraceKey{}, queryTraceData{
 		start: time.Now(),
-		verb:  parseSQLVerb(data.SQL),
+		verb:  verb,
+		span:  span,
 	})
 }
 
-// TraceQueryEnd observes the elapsed duration and increments the query
-// counter, partitioned by operation and outcome. If the start context
+// TraceQueryEnd observes the elapsed duration, increments the query
+// counter, partitioned by operation and outcome, and ends the span. If the start context
 // value is missing (which should not happen), it no-ops safely.
 func (*QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
 	td, ok := ctx.Value(queryTraceKey{}).(queryTraceData)
@@ -59,4 +80,5 @@ func (*QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.Tra
 
 	dbQueryDuration.WithLabelValues(td.verb).Observe(time.Since(td.start).Seconds())
 	dbQueriesTotal.WithLabelValues(td.verb, status).Inc()
+	tracing.End(td.span, data.Err)
 }
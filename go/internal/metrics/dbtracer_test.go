@@ -9,6 +9,11 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
 
 	"github.com/denisvmedia/inventario/internal/metrics"
 )
@@ -47,6 +52,28 @@ func TestQueryTracer_RecordsError(t *testing.T) {
 	c.Assert(countAfter-countBefore, qt.Equals, float64(1))
 }
 
+func TestQueryTracer_RecordsSpan(t *testing.T) {
+	c := qt.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	tracer := metrics.NewQueryTracer()
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "DELETE FROM areas WHERE id = $1",
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("boom")})
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 1)
+	c.Assert(spans[0].Name(), qt.Equals, "DELETE")
+	c.Assert(spans[0].Status().Code, qt.Equals, codes.Error)
+	c.Assert(spans[0].Attributes(), qt.Contains, semconv.DBQueryText("DELETE FROM areas WHERE id = $1"))
+}
+
 func TestQueryTracer_MissingStartContextNoOps(t *testing.T) {
 	c := qt.New(t)
 
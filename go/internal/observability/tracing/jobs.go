@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceParentHeader is the W3C trace-context carrier key.
+const traceParentHeader = "traceparent"
+
+// workerAttr names the background worker on iteration spans.
+const workerAttr = attribute.Key("inventario.worker")
+
+// TraceParent returns the W3C traceparent of the span in ctx, or "" when ctx
+// carries no sampled span. Request handlers store it on the job rows they
+// enqueue (exports, restores, thumbnails) so the worker that later picks the
+// job up can continue the same trace — see StartJob.
+func TraceParent(ctx context.Context) string {
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return ""
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier[traceParentHeader]
+}
+
+// StartJob starts the span for one background job. When traceParent (as
+// stored by TraceParent) is valid the span joins the enqueuing request's
+// trace, so a slow export reads end to end from the POST that created it;
+// the worker iteration span in ctx, if any, is kept as a link. Otherwise
+// the span is an ordinary child of ctx.
+func StartJob(ctx context.Context, name, traceParent string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...),
+	}
+	if traceParent != "" {
+		remote := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(
+			context.Background(), propagation.MapCarrier{traceParentHeader: traceParent},
+		))
+		if remote.IsValid() {
+			if local := trace.SpanContextFromContext(ctx); local.IsValid() {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: local}))
+			}
+			ctx = trace.ContextWithRemoteSpanContext(ctx, remote)
+		}
+	}
+	return Start(ctx, name, opts...)
+}
+
+// StartWorkerIteration starts the root span for one tick of a background
+// worker. worker is the worker's models.WorkerType value.
+func StartWorkerIteration(ctx context.Context, worker string) (context.Context, trace.Span) {
+	return Start(ctx, "worker "+worker,
+		trace.WithNewRoot(),
+		trace.WithAttributes(workerAttr.String(worker)),
+	)
+}
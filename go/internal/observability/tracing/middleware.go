@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// metricsRoute is never traced: a span per Prometheus scrape is pure noise.
+const metricsRoute = "/metrics"
+
+// Middleware returns a chi-aware middleware that opens a server span per
+// request, continuing the caller's trace when the request carries a
+// traceparent header.
+//
+// Like metrics.HTTPMiddleware it must be installed as root router
+// middleware (r.Use) and OUTSIDE Recoverer: the span is renamed after the
+// handler returns, once chi has resolved the matched route template (e.g.
+// "GET /api/v1/g/{groupSlug}/commodities/{commodityID}"). The concrete URL
+// path is deliberately not recorded — it can carry bearer secrets (invite
+// tokens, see the sentry package) and would explode span-name cardinality.
+func Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == metricsRoute {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method)),
+			)
+			defer span.End()
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if route := chi.RouteContext(r.Context()).RoutePattern(); route != "" {
+				span.SetName(r.Method + " " + route)
+				span.SetAttributes(semconv.HTTPRoute(route))
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
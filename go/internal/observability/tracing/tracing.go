@@ -0,0 +1,139 @@
+// Package tracing is Inventario's thin wrapper around the OpenTelemetry SDK
+// for distributed tracing. Init installs the process-global TracerProvider
+// and W3C propagator; everything else in the tree starts spans through Start,
+// Middleware, StartWorkerIteration and StartJob, or — for gocloud blob
+// operations, which gocloud.dev instruments itself — picks the provider up
+// from the otel global.
+//
+// Until Init binds an exporter the global provider is OpenTelemetry's no-op,
+// so spans cost a context lookup and nothing is recorded or sent.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer every Inventario span comes from.
+const instrumentationName = "github.com/denisvmedia/inventario"
+
+// Exporter values accepted by Config.Exporter.
+const (
+	ExporterNone     = "none"
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+)
+
+// ErrUnknownExporter is returned by Init for an unrecognised exporter name.
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// Config selects the span exporter. The zero value (and ExporterNone)
+// disables tracing.
+type Config struct {
+	// Exporter is one of ExporterNone, ExporterOTLPGRPC or ExporterOTLPHTTP.
+	Exporter string
+	// Endpoint is the collector URL, e.g. "http://otel-collector:4317" for
+	// gRPC or "https://collector.example.com/v1/traces" for HTTP. An http://
+	// scheme disables TLS. Empty falls back to the standard
+	// OTEL_EXPORTER_OTLP_(TRACES_)ENDPOINT variables, which the exporter
+	// reads along with OTEL_EXPORTER_OTLP_HEADERS and friends.
+	Endpoint string
+	// SampleRatio is the fraction of new traces recorded, in [0, 1]. Traces
+	// started upstream keep the caller's sampling decision.
+	SampleRatio float64
+	// ServiceName and ServiceVersion are stamped on the resource.
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Init installs the global TracerProvider for cfg and returns a shutdown
+// function that flushes buffered spans; callers defer it unconditionally.
+// The W3C trace-context propagator is installed in every mode so incoming
+// traceparent headers are honoured and forwarded even while tracing is off.
+//
+// Init is intended to be called EXACTLY ONCE during bootstrap, before
+// request serving begins.
+func Init(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	exporter := strings.ToLower(strings.TrimSpace(cfg.Exporter))
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case "", ExporterNone:
+		slog.Info("OpenTelemetry tracing disabled (tracing exporter is none)")
+		return noop, nil
+	case ExporterOTLPGRPC:
+		var opts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+		}
+		spanExporter, err = otlptracegrpc.New(ctx, opts...)
+	case ExporterOTLPHTTP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		spanExporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return noop, fmt.Errorf("%w: %q (want %s, %s or %s)",
+			ErrUnknownExporter, cfg.Exporter, ExporterNone, ExporterOTLPGRPC, ExporterOTLPHTTP)
+	}
+	if err != nil {
+		return noop, fmt.Errorf("creating %s exporter: %w", exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.ServiceVersion),
+	))
+	if err != nil {
+		return noop, fmt.Errorf("building tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	slog.Info("OpenTelemetry tracing enabled",
+		"exporter", exporter,
+		"endpoint", cfg.Endpoint,
+		"sample_ratio", cfg.SampleRatio)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name as a child of the span in ctx.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End records err on span (if non-nil) and ends it. It suits a deferred
+// call over a named error result:
+//
+//	ctx, span := tracing.Start(ctx, "thing.do")
+//	defer func() { tracing.End(span, err) }()
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
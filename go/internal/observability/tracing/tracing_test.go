@@ -0,0 +1,152 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+)
+
+// recordSpans installs an in-memory TracerProvider for the duration of the
+// test and returns its recorder.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func TestInit_NoneIsNoop(t *testing.T) {
+	c := qt.New(t)
+
+	for _, exporter := range []string{"", tracing.ExporterNone} {
+		shutdown, err := tracing.Init(context.Background(), tracing.Config{Exporter: exporter})
+		c.Assert(err, qt.IsNil)
+		c.Assert(shutdown(context.Background()), qt.IsNil)
+	}
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+	c := qt.New(t)
+
+	shutdown, err := tracing.Init(context.Background(), tracing.Config{Exporter: "zipkin"})
+	c.Assert(err, qt.ErrorIs, tracing.ErrUnknownExporter)
+	c.Assert(shutdown, qt.IsNotNil)
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	c := qt.New(t)
+	recorder := recordSpans(t)
+
+	_, span := tracing.Start(context.Background(), "thing.do")
+	tracing.End(span, errors.New("boom"))
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 1)
+	c.Assert(spans[0].Status().Code, qt.Equals, codes.Error)
+	c.Assert(spans[0].Status().Description, qt.Equals, "boom")
+}
+
+func TestMiddleware_NamesSpanAfterRouteAndContinuesTrace(t *testing.T) {
+	c := qt.New(t)
+	recorder := recordSpans(t)
+	_, err := tracing.Init(context.Background(), tracing.Config{})
+	c.Assert(err, qt.IsNil)
+
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware())
+	r.Get("/items/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/items/secret-token", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 1)
+	span := spans[0]
+	c.Assert(span.Name(), qt.Equals, "GET /items/{id}")
+	c.Assert(span.SpanContext().TraceID().String(), qt.Equals, traceID)
+	c.Assert(span.Status().Code, qt.Equals, codes.Error)
+	c.Assert(span.Attributes(), qt.Contains, semconv.HTTPRoute("/items/{id}"))
+	c.Assert(span.Attributes(), qt.Contains, semconv.HTTPResponseStatusCode(http.StatusInternalServerError))
+}
+
+func TestMiddleware_SkipsMetrics(t *testing.T) {
+	c := qt.New(t)
+	recorder := recordSpans(t)
+
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware())
+	r.Get("/metrics", func(http.ResponseWriter, *http.Request) {})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	c.Assert(recorder.Ended(), qt.HasLen, 0)
+}
+
+func TestStartJob_ContinuesEnqueuingTrace(t *testing.T) {
+	c := qt.New(t)
+	recorder := recordSpans(t)
+
+	requestCtx, requestSpan := tracing.Start(context.Background(), "POST /exports")
+	traceParent := tracing.TraceParent(requestCtx)
+	requestSpan.End()
+	c.Assert(traceParent, qt.Not(qt.Equals), "")
+
+	iterationCtx, iteration := tracing.StartWorkerIteration(context.Background(), "export")
+	_, job := tracing.StartJob(iterationCtx, "export.process", traceParent)
+	job.End()
+	iteration.End()
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 3)
+	jobSpan := spans[1]
+	c.Assert(jobSpan.Name(), qt.Equals, "export.process")
+	c.Assert(jobSpan.SpanContext().TraceID(), qt.Equals, requestSpan.SpanContext().TraceID())
+	c.Assert(jobSpan.Parent().SpanID(), qt.Equals, requestSpan.SpanContext().SpanID())
+	c.Assert(jobSpan.Links(), qt.HasLen, 1)
+	c.Assert(jobSpan.Links()[0].SpanContext.SpanID(), qt.Equals, iteration.SpanContext().SpanID())
+}
+
+func TestStartJob_WithoutTraceParentIsChildOfContext(t *testing.T) {
+	c := qt.New(t)
+	recorder := recordSpans(t)
+
+	iterationCtx, iteration := tracing.StartWorkerIteration(context.Background(), "thumbnail")
+	for _, traceParent := range []string{"", "not-a-traceparent"} {
+		_, job := tracing.StartJob(iterationCtx, "thumbnail.generate", traceParent)
+		job.End()
+	}
+	iteration.End()
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 3)
+	for _, job := range spans[:2] {
+		c.Assert(job.Parent().SpanID(), qt.Equals, iteration.SpanContext().SpanID())
+		c.Assert(job.Links(), qt.HasLen, 0)
+	}
+}
+
+func TestTraceParent_EmptyWithoutSpan(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(tracing.TraceParent(context.Background()), qt.Equals, "")
+}
@@ -252,24 +252,8 @@ func (v *Valuator) CalculateTotalValueByArea() (map[string]decimal.Decimal, erro
 }
 
 // getCommodityValue returns the value of a commodity based on the specified rules.
-// Returns zero decimal if the commodity has no valid price.
+// Returns zero decimal if the commodity has no valid price. The rules live in
+// registry.CommodityValue so the stats aggregates value commodities the same way.
 func getCommodityValue(commodity *models.Commodity, groupCurrency string) decimal.Decimal {
-	// If we have current price, use it (the currency is our group currency)
-	if !commodity.CurrentPrice.IsZero() {
-		return commodity.CurrentPrice
-	}
-
-	// If no current price, check if the original price is in our group currency
-	if !commodity.OriginalPrice.IsZero() && string(commodity.OriginalPriceCurrency) == groupCurrency {
-		return commodity.OriginalPrice
-	}
-
-	// If still no price detected, check if we have converted original price
-	if !commodity.ConvertedOriginalPrice.IsZero() {
-		return commodity.ConvertedOriginalPrice
-	}
-
-	// If only original price set and the currency is not our group currency,
-	// the commodity state is invalid and we should not count it
-	return decimal.NewFromInt(0)
+	return registry.CommodityValue(commodity, groupCurrency)
 }
@@ -0,0 +1,96 @@
+package jsonapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// BackupScheduleResponse is the JSON:API envelope for a group's backup
+// schedule.
+type BackupScheduleResponse struct {
+	HTTPStatusCode int                         `json:"-"`
+	Data           *BackupScheduleResponseData `json:"data"`
+}
+
+// BackupScheduleResponseData is the inner resource object.
+type BackupScheduleResponseData struct {
+	ID         string                `json:"id"`
+	Type       string                `json:"type" example:"backup_schedules" enums:"backup_schedules"`
+	Attributes models.BackupSchedule `json:"attributes"`
+}
+
+func NewBackupScheduleResponse(schedule *models.BackupSchedule) *BackupScheduleResponse {
+	return &BackupScheduleResponse{
+		Data: &BackupScheduleResponseData{
+			ID:         schedule.ID,
+			Type:       "backup_schedules",
+			Attributes: *schedule,
+		},
+	}
+}
+
+func (sr *BackupScheduleResponse) WithStatusCode(code int) *BackupScheduleResponse {
+	tmp := *sr
+	tmp.HTTPStatusCode = code
+	return &tmp
+}
+
+func (sr *BackupScheduleResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, statusCodeDef(sr.HTTPStatusCode, http.StatusOK))
+	return nil
+}
+
+// BackupScheduleRequest is the JSON:API payload for PUT /backup-schedule.
+// PUT creates the group's schedule or replaces it, so the whole
+// configuration is sent every time.
+type BackupScheduleRequest struct {
+	Data *BackupScheduleRequestDataWrapper `json:"data"`
+}
+
+type BackupScheduleRequestDataWrapper struct {
+	Type       string                     `json:"type" example:"backup_schedules" enums:"backup_schedules"`
+	Attributes *BackupScheduleRequestData `json:"attributes"`
+}
+
+// BackupScheduleRequestData carries the user-supplied fields. Range and
+// format checks live on models.BackupSchedule and run once the request is
+// applied to the stored schedule.
+type BackupScheduleRequestData struct {
+	Enabled         bool                   `json:"enabled"`
+	Frequency       models.BackupFrequency `json:"frequency" example:"daily" enums:"daily,weekly,monthly"`
+	TimeOfDay       string                 `json:"time_of_day" example:"03:00"`
+	Weekday         int                    `json:"weekday" example:"0"`
+	DayOfMonth      int                    `json:"day_of_month" example:"1"`
+	IncludeFileData bool                   `json:"include_file_data"`
+	KeepDaily       int                    `json:"keep_daily" example:"7"`
+	KeepWeekly      int                    `json:"keep_weekly" example:"4"`
+	KeepMonthly     int                    `json:"keep_monthly" example:"6"`
+}
+
+func (w *BackupScheduleRequestDataWrapper) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, w,
+		validation.Field(&w.Type, validation.Required, validation.In("backup_schedules")),
+		validation.Field(&w.Attributes, validation.Required),
+	)
+}
+
+func (sr *BackupScheduleRequest) Bind(r *http.Request) error {
+	return sr.ValidateWithContext(r.Context())
+}
+
+func (sr *BackupScheduleRequest) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, sr,
+		validation.Field(&sr.Data, validation.Required),
+	)
+}
+
+var (
+	_ render.Binder                     = (*BackupScheduleRequest)(nil)
+	_ validation.ValidatableWithContext = (*BackupScheduleRequest)(nil)
+	_ validation.ValidatableWithContext = (*BackupScheduleRequestDataWrapper)(nil)
+)
@@ -0,0 +1,194 @@
+package jsonapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// CommodityDuplicateMeta explains why a commodity was flagged as a
+// possible duplicate. Score is the best name / short-name trigram
+// similarity (0..1); Reasons lists every signal that matched.
+type CommodityDuplicateMeta struct {
+	Score   float64                    `json:"score" example:"0.82"`
+	Reasons []registry.DuplicateReason `json:"reasons" swaggertype:"array,string" example:"name,serial_number"`
+}
+
+// CommodityDuplicateData is one row of GET
+// /commodities/{id}/duplicates: the candidate commodity plus the match
+// explanation in `meta`.
+type CommodityDuplicateData struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type" example:"commodities" enums:"commodities"`
+	Attributes *models.Commodity      `json:"attributes"`
+	Meta       CommodityDuplicateMeta `json:"meta"`
+}
+
+// CommodityDuplicatesMeta is the meta block on the per-commodity list.
+type CommodityDuplicatesMeta struct {
+	Duplicates int     `json:"duplicates" example:"2" format:"int64"`
+	Threshold  float64 `json:"threshold" example:"0.5"`
+}
+
+// CommodityDuplicatesResponse is the JSON:API envelope for GET
+// /commodities/{id}/duplicates.
+type CommodityDuplicatesResponse struct {
+	Data []CommodityDuplicateData `json:"data"`
+	Meta CommodityDuplicatesMeta  `json:"meta"`
+}
+
+// NewCommodityDuplicatesResponse builds the per-commodity duplicates list.
+func NewCommodityDuplicatesResponse(similar []registry.SimilarCommodity, threshold float64) *CommodityDuplicatesResponse {
+	data := make([]CommodityDuplicateData, 0, len(similar)) // must be an empty array instead of nil due to JSON serialization
+	for _, s := range similar {
+		data = append(data, CommodityDuplicateData{
+			ID:         s.Commodity.ID,
+			Type:       "commodities",
+			Attributes: s.Commodity,
+			Meta:       CommodityDuplicateMeta{Score: s.Score, Reasons: s.Reasons},
+		})
+	}
+	return &CommodityDuplicatesResponse{
+		Data: data,
+		Meta: CommodityDuplicatesMeta{Duplicates: len(data), Threshold: threshold},
+	}
+}
+
+func (*CommodityDuplicatesResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// CommodityDuplicatePairAttributes holds both sides of a group-wide
+// duplicate pair. A and B are ordered by id.
+type CommodityDuplicatePairAttributes struct {
+	A       *models.Commodity          `json:"a"`
+	B       *models.Commodity          `json:"b"`
+	Score   float64                    `json:"score" example:"0.82"`
+	Reasons []registry.DuplicateReason `json:"reasons" swaggertype:"array,string" example:"name,serial_number"`
+}
+
+// CommodityDuplicatePairData is one row of GET /commodities/duplicates.
+// The id is the two commodity ids joined with ":" so the FE has a stable
+// key for "dismiss" / "merge" row actions.
+type CommodityDuplicatePairData struct {
+	ID         string                            `json:"id"`
+	Type       string                            `json:"type" example:"commodity_duplicate_pairs" enums:"commodity_duplicate_pairs"`
+	Attributes *CommodityDuplicatePairAttributes `json:"attributes"`
+}
+
+// CommodityDuplicatePairsMeta is the meta block on the group-wide list.
+type CommodityDuplicatePairsMeta struct {
+	Pairs     int     `json:"pairs" example:"3" format:"int64"`
+	Threshold float64 `json:"threshold" example:"0.5"`
+}
+
+// CommodityDuplicatePairsResponse is the JSON:API envelope for GET
+// /commodities/duplicates.
+type CommodityDuplicatePairsResponse struct {
+	Data []CommodityDuplicatePairData `json:"data"`
+	Meta CommodityDuplicatePairsMeta  `json:"meta"`
+}
+
+// NewCommodityDuplicatePairsResponse builds the group-wide duplicates list.
+func NewCommodityDuplicatePairsResponse(pairs []registry.DuplicatePair, threshold float64) *CommodityDuplicatePairsResponse {
+	data := make([]CommodityDuplicatePairData, 0, len(pairs)) // must be an empty array instead of nil due to JSON serialization
+	for _, p := range pairs {
+		data = append(data, CommodityDuplicatePairData{
+			ID:   p.A.ID + ":" + p.B.ID,
+			Type: "commodity_duplicate_pairs",
+			Attributes: &CommodityDuplicatePairAttributes{
+				A:       p.A,
+				B:       p.B,
+				Score:   p.Score,
+				Reasons: p.Reasons,
+			},
+		})
+	}
+	return &CommodityDuplicatePairsResponse{
+		Data: data,
+		Meta: CommodityDuplicatePairsMeta{Pairs: len(data), Threshold: threshold},
+	}
+}
+
+func (*CommodityDuplicatePairsResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// CommodityMergeRequest is the body of POST /commodities/{id}/merge. The
+// commodity in the path survives; DuplicateID is folded into it and
+// deleted.
+type CommodityMergeRequest struct {
+	Data *CommodityMergeRequestData `json:"data"`
+}
+
+// CommodityMergeRequestData is the inner body for a CommodityMergeRequest.
+type CommodityMergeRequestData struct {
+	Type       string                    `json:"type" example:"commodity_merges" enums:"commodity_merges"`
+	Attributes *CommodityMergeAttributes `json:"attributes"`
+}
+
+// CommodityMergeAttributes carries the merge payload.
+type CommodityMergeAttributes struct {
+	DuplicateID string `json:"duplicate_id"`
+}
+
+// Bind validates a CommodityMergeRequest body.
+func (r *CommodityMergeRequest) Bind(_ *http.Request) error {
+	if r.Data == nil || r.Data.Attributes == nil {
+		return errors.New("missing data.attributes")
+	}
+	if r.Data.Type != "commodity_merges" {
+		return errors.New("type must be commodity_merges")
+	}
+	if r.Data.Attributes.DuplicateID == "" {
+		return errors.New("duplicate_id is required")
+	}
+	return nil
+}
+
+// CommodityMergeMeta reports what the merge moved onto the survivor.
+type CommodityMergeMeta struct {
+	MergedID             string   `json:"merged_id"`
+	Files                int      `json:"files" format:"int64"`
+	Events               int      `json:"events" format:"int64"`
+	Loans                int      `json:"loans" format:"int64"`
+	Services             int      `json:"services" format:"int64"`
+	SupplyLinks          int      `json:"supply_links" format:"int64"`
+	MaintenanceSchedules int      `json:"maintenance_schedules" format:"int64"`
+	MeterReadings        int      `json:"meter_readings" format:"int64"`
+	InvoiceExtractions   int      `json:"invoice_extractions" format:"int64"`
+	TagsAdded            []string `json:"tags_added"`
+}
+
+// CommodityMergeResponse is the JSON:API envelope for POST
+// /commodities/{id}/merge: the surviving commodity plus the move summary.
+type CommodityMergeResponse struct {
+	Data *CommodityResponseData `json:"data"`
+	Meta CommodityMergeMeta     `json:"meta"`
+}
+
+// NewCommodityMergeResponse builds the merge response.
+func NewCommodityMergeResponse(survivor *models.Commodity, meta CommodityMergeMeta) *CommodityMergeResponse {
+	if meta.TagsAdded == nil {
+		meta.TagsAdded = []string{}
+	}
+	return &CommodityMergeResponse{
+		Data: &CommodityResponseData{
+			ID:         survivor.ID,
+			Type:       "commodities",
+			Attributes: survivor,
+		},
+		Meta: meta,
+	}
+}
+
+func (*CommodityMergeResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
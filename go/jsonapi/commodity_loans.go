@@ -91,6 +91,7 @@ type CommodityLoanRequestDataWrapper struct {
 type CommodityLoanRequestData struct {
 	BorrowerName    string       `json:"borrower_name"`
 	BorrowerContact string       `json:"borrower_contact,omitempty"`
+	BorrowerEmail   string       `json:"borrower_email,omitempty"`
 	BorrowerNote    string       `json:"borrower_note,omitempty"`
 	LentAt          models.Date  `json:"lent_at"`
 	DueBackAt       models.PDate `json:"due_back_at,omitempty"`
@@ -104,6 +105,7 @@ func (lrd *CommodityLoanRequestData) ValidateWithContext(ctx context.Context) er
 	return validation.ValidateStructWithContext(ctx, lrd,
 		validation.Field(&lrd.BorrowerName, validation.Required, validation.Length(1, 200)),
 		validation.Field(&lrd.BorrowerContact, validation.Length(0, 200)),
+		validation.Field(&lrd.BorrowerEmail, validation.Length(0, 255), validation.Match(models.EmailPattern)),
 		validation.Field(&lrd.BorrowerNote, validation.Length(0, 1000)),
 		validation.Field(&lrd.LentAt, validation.Required),
 	)
@@ -165,6 +167,7 @@ type CommodityLoanUpdateRequestDataWrapper struct {
 type CommodityLoanUpdateRequestData struct {
 	BorrowerName    *string `json:"borrower_name,omitempty"`
 	BorrowerContact *string `json:"borrower_contact,omitempty"`
+	BorrowerEmail   *string `json:"borrower_email,omitempty"`
 	BorrowerNote    *string `json:"borrower_note,omitempty"`
 	// DueBackAt: omitted leaves it unchanged; a "YYYY-MM-DD" string
 	// replaces the value; an explicit JSON `null` clears the column
@@ -207,13 +210,16 @@ func (lurd *CommodityLoanUpdateRequestData) Validate() error {
 }
 
 func (lurd *CommodityLoanUpdateRequestData) ValidateWithContext(ctx context.Context) error {
-	fields := make([]*validation.FieldRules, 0, 3)
+	fields := make([]*validation.FieldRules, 0, 4)
 	if lurd.BorrowerName != nil {
 		fields = append(fields, validation.Field(lurd.BorrowerName, validation.Length(1, 200)))
 	}
 	if lurd.BorrowerContact != nil {
 		fields = append(fields, validation.Field(lurd.BorrowerContact, validation.Length(0, 200)))
 	}
+	if lurd.BorrowerEmail != nil {
+		fields = append(fields, validation.Field(lurd.BorrowerEmail, validation.Length(0, 255), validation.Match(models.EmailPattern)))
+	}
 	if lurd.BorrowerNote != nil {
 		fields = append(fields, validation.Field(lurd.BorrowerNote, validation.Length(0, 1000)))
 	}
@@ -0,0 +1,139 @@
+package jsonapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// CommodityMeterReadingResponse is the JSON:API envelope for a single
+// meter reading.
+type CommodityMeterReadingResponse struct {
+	HTTPStatusCode int                                `json:"-"`
+	Data           *CommodityMeterReadingResponseData `json:"data"`
+}
+
+// CommodityMeterReadingResponseData is the inner resource object.
+type CommodityMeterReadingResponseData struct {
+	ID         string                       `json:"id"`
+	Type       string                       `json:"type" example:"commodity_meter_readings" enums:"commodity_meter_readings"`
+	Attributes models.CommodityMeterReading `json:"attributes"`
+}
+
+func NewCommodityMeterReadingResponse(reading *models.CommodityMeterReading) *CommodityMeterReadingResponse {
+	return &CommodityMeterReadingResponse{
+		Data: &CommodityMeterReadingResponseData{
+			ID:         reading.ID,
+			Type:       "commodity_meter_readings",
+			Attributes: *reading,
+		},
+	}
+}
+
+func (rr *CommodityMeterReadingResponse) WithStatusCode(code int) *CommodityMeterReadingResponse {
+	tmp := *rr
+	tmp.HTTPStatusCode = code
+	return &tmp
+}
+
+func (rr *CommodityMeterReadingResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, statusCodeDef(rr.HTTPStatusCode, http.StatusOK))
+	return nil
+}
+
+// CommodityMeterReadingsMeta is the count block on a list response.
+type CommodityMeterReadingsMeta struct {
+	Readings int `json:"readings" example:"10" format:"int64"`
+	Total    int `json:"total" example:"100" format:"int64"`
+}
+
+// CommodityMeterReadingsResponse is the per-commodity reading history,
+// oldest first.
+type CommodityMeterReadingsResponse struct {
+	Data []*models.CommodityMeterReading `json:"data"`
+	Meta CommodityMeterReadingsMeta      `json:"meta"`
+}
+
+func NewCommodityMeterReadingsResponse(readings []*models.CommodityMeterReading) *CommodityMeterReadingsResponse {
+	return &CommodityMeterReadingsResponse{
+		Data: readings,
+		Meta: CommodityMeterReadingsMeta{Readings: len(readings), Total: len(readings)},
+	}
+}
+
+func (*CommodityMeterReadingsResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// CommodityMeterReadingRequest is the JSON:API payload for POST
+// .../commodities/{id}/meter-readings.
+type CommodityMeterReadingRequest struct {
+	Data *CommodityMeterReadingRequestDataWrapper `json:"data"`
+}
+
+type CommodityMeterReadingRequestDataWrapper struct {
+	ID         string                           `json:"id,omitempty"`
+	Type       string                           `json:"type" example:"commodity_meter_readings" enums:"commodity_meter_readings"`
+	Attributes CommodityMeterReadingRequestData `json:"attributes"`
+}
+
+// CommodityMeterReadingRequestData carries the user-supplied fields on
+// create. ReadAt defaults to today (server clock) when omitted.
+type CommodityMeterReadingRequestData struct {
+	Unit   models.MeterUnit `json:"unit" enums:"hours,km,mi,cycles"`
+	Value  decimal.Decimal  `json:"value"`
+	ReadAt models.Date      `json:"read_at,omitempty"`
+	Notes  string           `json:"notes,omitempty"`
+}
+
+func (rrd *CommodityMeterReadingRequestData) Validate() error {
+	return models.ErrMustUseValidateWithContext
+}
+
+func (rrd *CommodityMeterReadingRequestData) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, rrd,
+		validation.Field(&rrd.Unit, validation.Required),
+		validation.Field(&rrd.Value, validation.By(func(any) error {
+			if rrd.Value.IsNegative() {
+				return validation.NewError("meter_value_negative", "value must not be negative")
+			}
+			return nil
+		})),
+		validation.Field(&rrd.ReadAt),
+		validation.Field(&rrd.Notes, validation.Length(0, 1000)),
+	)
+}
+
+func (rrdw *CommodityMeterReadingRequestDataWrapper) ValidateWithContext(ctx context.Context) error {
+	if rrdw.ID != "" {
+		return errors.New("ID field not allowed in create requests")
+	}
+	return validation.ValidateStructWithContext(ctx, rrdw,
+		validation.Field(&rrdw.Type, validation.Required, validation.In("commodity_meter_readings")),
+		validation.Field(&rrdw.Attributes, validation.Required),
+	)
+}
+
+func (rr *CommodityMeterReadingRequest) Bind(r *http.Request) error {
+	return rr.ValidateWithContext(r.Context())
+}
+
+func (rr *CommodityMeterReadingRequest) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, rr,
+		validation.Field(&rr.Data, validation.Required),
+	)
+}
+
+var (
+	_ render.Binder                     = (*CommodityMeterReadingRequest)(nil)
+	_ validation.ValidatableWithContext = (*CommodityMeterReadingRequest)(nil)
+	_ validation.ValidatableWithContext = (*CommodityMeterReadingRequestDataWrapper)(nil)
+	_ validation.ValidatableWithContext = (*CommodityMeterReadingRequestData)(nil)
+)
@@ -199,3 +199,52 @@ func (cr *ImportExportRequest) ValidateWithContext(ctx context.Context) error {
 	)
 	return validation.ValidateStructWithContext(ctx, cr, fields...)
 }
+
+// ImportReplicaAttributes holds the attributes for importing an export
+// from its off-site replica.
+type ImportReplicaAttributes struct {
+	Description string `json:"description"`
+	// ReplicaURL is the object URL reported in an export's replica_url.
+	ReplicaURL string `json:"replica_url" example:"s3://inventario-backups/t/tenant-id/exports/backup_full_database_20260513_030000.inb?region=eu-west-1"`
+}
+
+func (a *ImportReplicaAttributes) ValidateWithContext(ctx context.Context) error {
+	fields := make([]*validation.FieldRules, 0)
+	fields = append(fields,
+		validation.Field(&a.Description, validation.Required, validation.Length(1, 500)),
+		validation.Field(&a.ReplicaURL, validation.Required, validation.Length(1, 2000)),
+	)
+	return validation.ValidateStructWithContext(ctx, a, fields...)
+}
+
+// ImportReplicaRequestData is request data for importing an export from
+// its replica.
+type ImportReplicaRequestData struct {
+	Type       string                   `json:"type" example:"exports" enums:"exports"`
+	Attributes *ImportReplicaAttributes `json:"attributes"`
+}
+
+func (cd *ImportReplicaRequestData) ValidateWithContext(ctx context.Context) error {
+	fields := make([]*validation.FieldRules, 0)
+	fields = append(fields,
+		validation.Field(&cd.Type, validation.Required, validation.In("exports")),
+		validation.Field(&cd.Attributes, validation.Required),
+	)
+	return validation.ValidateStructWithContext(ctx, cd, fields...)
+}
+
+type ImportReplicaRequest struct {
+	Data *ImportReplicaRequestData `json:"data"`
+}
+
+var _ render.Binder = (*ImportReplicaRequest)(nil)
+
+func (cr *ImportReplicaRequest) Bind(r *http.Request) error {
+	if cr.Data == nil {
+		return errx.NewDisplayable("missing required data field")
+	}
+	if err := cr.Data.ValidateWithContext(r.Context()); err != nil {
+		return err
+	}
+	return cr.Data.Attributes.ValidateWithContext(r.Context())
+}
@@ -0,0 +1,91 @@
+package jsonapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// GroupTransferRequest is the body of POST /g/{groupSlug}/transfers. The
+// group in the path is the source; everything selected moves to
+// TargetGroupID.
+type GroupTransferRequest struct {
+	Data *GroupTransferRequestData `json:"data"`
+}
+
+// GroupTransferRequestData is the inner body for a GroupTransferRequest.
+type GroupTransferRequestData struct {
+	Type       string                   `json:"type" example:"group_transfers" enums:"group_transfers"`
+	Attributes *GroupTransferAttributes `json:"attributes"`
+}
+
+// GroupTransferAttributes carries the transfer payload. Locations move
+// with their areas and commodities; standalone areas need
+// target_location_id and standalone commodities need target_area_id, both
+// ids of the target group. exchange_rate (source → target currency) is
+// required only when the two groups use different currencies.
+type GroupTransferAttributes struct {
+	TargetGroupID    string           `json:"target_group_id"`
+	LocationIDs      []string         `json:"location_ids,omitempty"`
+	AreaIDs          []string         `json:"area_ids,omitempty"`
+	TargetLocationID string           `json:"target_location_id,omitempty"`
+	CommodityIDs     []string         `json:"commodity_ids,omitempty"`
+	TargetAreaID     string           `json:"target_area_id,omitempty"`
+	ExchangeRate     *decimal.Decimal `json:"exchange_rate,omitempty" swaggertype:"string" example:"0.92"`
+}
+
+// Bind validates a GroupTransferRequest body.
+func (r *GroupTransferRequest) Bind(_ *http.Request) error {
+	if r.Data == nil || r.Data.Attributes == nil {
+		return errors.New("missing data.attributes")
+	}
+	if r.Data.Type != "group_transfers" {
+		return errors.New("type must be group_transfers")
+	}
+	if r.Data.Attributes.TargetGroupID == "" {
+		return errors.New("target_group_id is required")
+	}
+	return nil
+}
+
+// GroupTransferMeta reports what the transfer moved.
+type GroupTransferMeta struct {
+	Locations    int              `json:"locations" format:"int64"`
+	Areas        int              `json:"areas" format:"int64"`
+	Commodities  int              `json:"commodities" format:"int64"`
+	Files        int              `json:"files" format:"int64"`
+	TagsCreated  []string         `json:"tags_created"`
+	Converted    bool             `json:"converted"`
+	ExchangeRate *decimal.Decimal `json:"exchange_rate,omitempty" swaggertype:"string" example:"0.92"`
+}
+
+// GroupTransferResponse is the JSON:API envelope for POST
+// /g/{groupSlug}/transfers: the target group plus the move summary.
+type GroupTransferResponse struct {
+	Data *LocationGroupResponseData `json:"data"`
+	Meta GroupTransferMeta          `json:"meta"`
+}
+
+// NewGroupTransferResponse builds the transfer response.
+func NewGroupTransferResponse(target *models.LocationGroup, meta GroupTransferMeta) *GroupTransferResponse {
+	if meta.TagsCreated == nil {
+		meta.TagsCreated = []string{}
+	}
+	return &GroupTransferResponse{
+		Data: &LocationGroupResponseData{
+			ID:         target.ID,
+			Type:       "groups",
+			Attributes: target,
+		},
+		Meta: meta,
+	}
+}
+
+func (*GroupTransferResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
@@ -342,6 +342,108 @@ func (r *GroupMembershipRoleRequest) Bind(_ *http.Request) error {
 	return r.Data.Attributes.Role.Validate()
 }
 
+// --- Member location scopes ---
+//
+// A member's location scope is rendered as one resource keyed by the
+// member's user ID. An empty scopes list means the member is
+// unrestricted and sees the whole group at their membership role.
+
+type MemberLocationScopeEntry struct {
+	LocationID string           `json:"location_id" example:"loc_123"`
+	Role       models.GroupRole `json:"role" example:"viewer" enums:"viewer,user,admin"`
+}
+
+type MemberLocationScopesAttrs struct {
+	GroupID      string                     `json:"group_id"`
+	MemberUserID string                     `json:"member_user_id"`
+	Restricted   bool                       `json:"restricted"`
+	Scopes       []MemberLocationScopeEntry `json:"scopes"`
+}
+
+type MemberLocationScopesData struct {
+	ID         string                     `json:"id"`
+	Type       string                     `json:"type" example:"member-location-scopes" enums:"member-location-scopes"`
+	Attributes *MemberLocationScopesAttrs `json:"attributes"`
+}
+
+type MemberLocationScopesResponse struct {
+	Data *MemberLocationScopesData `json:"data"`
+}
+
+func NewMemberLocationScopesResponse(groupID, memberUserID string, scopes []*models.GroupMemberLocationScope) *MemberLocationScopesResponse {
+	entries := make([]MemberLocationScopeEntry, 0, len(scopes))
+	for _, s := range scopes {
+		entries = append(entries, MemberLocationScopeEntry{
+			LocationID: s.LocationID,
+			Role:       s.Role,
+		})
+	}
+	return &MemberLocationScopesResponse{
+		Data: &MemberLocationScopesData{
+			ID:   memberUserID,
+			Type: "member-location-scopes",
+			Attributes: &MemberLocationScopesAttrs{
+				GroupID:      groupID,
+				MemberUserID: memberUserID,
+				Restricted:   len(entries) > 0,
+				Scopes:       entries,
+			},
+		},
+	}
+}
+
+func (*MemberLocationScopesResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+var _ render.Binder = (*MemberLocationScopesRequest)(nil)
+
+// MemberLocationScopesRequest replaces a member's location scope. Sending
+// an empty scopes list lifts the restriction, same as DELETE.
+type MemberLocationScopesRequest struct {
+	Data *MemberLocationScopesRequestData `json:"data"`
+}
+
+type MemberLocationScopesRequestData struct {
+	Type       string                          `json:"type" example:"member-location-scopes" enums:"member-location-scopes"`
+	Attributes *MemberLocationScopesInputAttrs `json:"attributes"`
+}
+
+type MemberLocationScopesInputAttrs struct {
+	Scopes []MemberLocationScopeEntry `json:"scopes"`
+}
+
+func (r *MemberLocationScopesRequest) Bind(_ *http.Request) error {
+	if r.Data == nil || r.Data.Attributes == nil {
+		return validation.NewError("validation_required", "data.attributes is required")
+	}
+	for _, entry := range r.Data.Attributes.Scopes {
+		if entry.LocationID == "" {
+			return validation.NewError("validation_required", "scopes[].location_id is required")
+		}
+		if err := entry.Role.Validate(); err != nil {
+			return err
+		}
+		if entry.Role == models.GroupRoleOwner {
+			return validation.NewError("validation_invalid_scope_role", "scopes[].role must be one of: viewer, user, admin")
+		}
+	}
+	return nil
+}
+
+// ToModels converts the request into scope rows for the service layer.
+func (r *MemberLocationScopesRequest) ToModels() []models.GroupMemberLocationScope {
+	out := make([]models.GroupMemberLocationScope, 0, len(r.Data.Attributes.Scopes))
+	for _, entry := range r.Data.Attributes.Scopes {
+		out = append(out, models.GroupMemberLocationScope{
+			LocationID: entry.LocationID,
+			Role:       entry.Role,
+		})
+	}
+	return out
+}
+
 // --- GroupInvite create request (#1533) ---
 //
 // Both fields are optional. When `Email` is non-empty the BE persists it
@@ -0,0 +1,117 @@
+package jsonapi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// InvoiceExtractionResponse is the JSON:API envelope for a single
+// extraction.
+type InvoiceExtractionResponse struct {
+	HTTPStatusCode int                            `json:"-"`
+	Data           *InvoiceExtractionResponseData `json:"data"`
+}
+
+// InvoiceExtractionResponseData is the inner resource object.
+type InvoiceExtractionResponseData struct {
+	ID         string                   `json:"id"`
+	Type       string                   `json:"type" example:"invoice_extractions" enums:"invoice_extractions"`
+	Attributes models.InvoiceExtraction `json:"attributes"`
+}
+
+func NewInvoiceExtractionResponse(extraction *models.InvoiceExtraction) *InvoiceExtractionResponse {
+	return &InvoiceExtractionResponse{
+		Data: &InvoiceExtractionResponseData{
+			ID:         extraction.ID,
+			Type:       "invoice_extractions",
+			Attributes: *extraction,
+		},
+	}
+}
+
+func (er *InvoiceExtractionResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, statusCodeDef(er.HTTPStatusCode, http.StatusOK))
+	return nil
+}
+
+// InvoiceExtractionsMeta is the count block on a list response.
+type InvoiceExtractionsMeta struct {
+	Extractions int `json:"extractions" example:"10" format:"int64"`
+}
+
+// InvoiceExtractionListItem is a single row of the review queue with
+// the denormalised commodity it proposes changes for, so the FE can
+// render "name: current → proposed" without a round-trip per row.
+type InvoiceExtractionListItem struct {
+	*models.InvoiceExtraction
+	Commodity *MaintenanceCommodityRef `json:"commodity,omitempty"`
+}
+
+// InvoiceExtractionsResponse is the review queue list shape.
+type InvoiceExtractionsResponse struct {
+	Data []*InvoiceExtractionListItem `json:"data"`
+	Meta InvoiceExtractionsMeta       `json:"meta"`
+}
+
+func NewInvoiceExtractionsResponse(extractions []*models.InvoiceExtraction, commoditiesByID map[string]*models.Commodity) *InvoiceExtractionsResponse {
+	items := make([]*InvoiceExtractionListItem, 0, len(extractions))
+	for _, e := range extractions {
+		item := &InvoiceExtractionListItem{InvoiceExtraction: e}
+		if c, ok := commoditiesByID[e.CommodityID]; ok && c != nil {
+			item.Commodity = &MaintenanceCommodityRef{
+				ID:        c.ID,
+				Name:      c.Name,
+				ShortName: c.ShortName,
+			}
+		}
+		items = append(items, item)
+	}
+	return &InvoiceExtractionsResponse{
+		Data: items,
+		Meta: InvoiceExtractionsMeta{Extractions: len(items)},
+	}
+}
+
+func (*InvoiceExtractionsResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// InvoiceExtractionApproveRequest is the JSON:API payload for POST
+// .../invoice-extractions/{id}/approve. The body is optional: without
+// it every proposed change is applied.
+type InvoiceExtractionApproveRequest struct {
+	Data *InvoiceExtractionApproveRequestDataWrapper `json:"data,omitempty"`
+}
+
+type InvoiceExtractionApproveRequestDataWrapper struct {
+	Type       string                              `json:"type"`
+	Attributes InvoiceExtractionApproveRequestData `json:"attributes"`
+}
+
+type InvoiceExtractionApproveRequestData struct {
+	// Fields narrows the approval to a subset of the proposed changes.
+	Fields []string `json:"fields,omitempty" enums:"purchase_date,original_price,original_price_currency,comments"`
+}
+
+func (ar *InvoiceExtractionApproveRequest) Bind(r *http.Request) error {
+	if ar.Data == nil {
+		return nil
+	}
+	return validation.ValidateStructWithContext(r.Context(), ar.Data,
+		validation.Field(&ar.Data.Type, validation.Required, validation.In("invoice_extractions")),
+		validation.Field(&ar.Data.Attributes),
+	)
+}
+
+func (ard InvoiceExtractionApproveRequestData) Validate() error {
+	return validation.ValidateStruct(&ard,
+		validation.Field(&ard.Fields, validation.Length(0, 10), validation.Each(validation.Required)),
+	)
+}
+
+var _ render.Binder = (*InvoiceExtractionApproveRequest)(nil)
@@ -0,0 +1,154 @@
+package jsonapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// PublicLoanResponse is the JSON:API envelope served to a borrower who
+// opened a signed loan link. It deliberately carries a narrow view of
+// the loan — no contact details, notes or reminder bookkeeping — since
+// anyone holding the link can read it.
+type PublicLoanResponse struct {
+	Data *PublicLoanResponseData `json:"data"`
+}
+
+type PublicLoanResponseData struct {
+	ID         string               `json:"id"`
+	Type       string               `json:"type" example:"public_loans" enums:"public_loans"`
+	Attributes PublicLoanAttributes `json:"attributes"`
+}
+
+// PublicLoanAttributes is the borrower-visible subset of a loan.
+type PublicLoanAttributes struct {
+	CommodityName           string                 `json:"commodity_name"`
+	BorrowerName            string                 `json:"borrower_name"`
+	LentAt                  models.Date            `json:"lent_at"`
+	DueBackAt               models.PDate           `json:"due_back_at"`
+	Returned                bool                   `json:"returned"`
+	PendingRequestKind      models.LoanRequestKind `json:"pending_request_kind,omitempty"`
+	PendingRequestDueBackAt models.PDate           `json:"pending_request_due_back_at,omitempty"`
+}
+
+func NewPublicLoanResponse(loan *models.CommodityLoan, commodityName string) *PublicLoanResponse {
+	return &PublicLoanResponse{
+		Data: &PublicLoanResponseData{
+			ID:   loan.ID,
+			Type: "public_loans",
+			Attributes: PublicLoanAttributes{
+				CommodityName:           commodityName,
+				BorrowerName:            loan.BorrowerName,
+				LentAt:                  loan.LentAt,
+				DueBackAt:               loan.DueBackAt,
+				Returned:                !loan.IsOpen(),
+				PendingRequestKind:      loan.PendingRequestKind,
+				PendingRequestDueBackAt: loan.PendingRequestDueBackAt,
+			},
+		},
+	}
+}
+
+func (*PublicLoanResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// LoanBorrowerRequestRequest is the JSON:API payload a borrower POSTs
+// through the signed loan link to confirm a return or ask for an
+// extension.
+type LoanBorrowerRequestRequest struct {
+	Data *LoanBorrowerRequestDataWrapper `json:"data"`
+}
+
+type LoanBorrowerRequestDataWrapper struct {
+	Type       string                  `json:"type" example:"loan_requests" enums:"loan_requests"`
+	Attributes LoanBorrowerRequestData `json:"attributes"`
+}
+
+// LoanBorrowerRequestData carries the borrower's request. DueBackAt is
+// required for kind=extension and ignored for kind=return; the date
+// rules (later than today and the current due date) live in the
+// service because they depend on the loan.
+type LoanBorrowerRequestData struct {
+	Kind      models.LoanRequestKind `json:"kind" example:"extension" enums:"return,extension"`
+	DueBackAt models.PDate           `json:"due_back_at,omitempty"`
+	Note      string                 `json:"note,omitempty"`
+}
+
+func (d *LoanBorrowerRequestData) Validate() error {
+	return models.ErrMustUseValidateWithContext
+}
+
+func (d *LoanBorrowerRequestData) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, d,
+		validation.Field(&d.Kind, validation.Required, validation.In(models.LoanRequestKindReturn, models.LoanRequestKindExtension)),
+		validation.Field(&d.DueBackAt, validation.When(d.Kind == models.LoanRequestKindExtension, validation.Required)),
+		validation.Field(&d.Note, validation.Length(0, 1000)),
+	)
+}
+
+func (w *LoanBorrowerRequestDataWrapper) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, w,
+		validation.Field(&w.Type, validation.Required, validation.In("loan_requests")),
+		validation.Field(&w.Attributes, validation.Required),
+	)
+}
+
+func (lr *LoanBorrowerRequestRequest) Bind(r *http.Request) error {
+	return lr.ValidateWithContext(r.Context())
+}
+
+func (lr *LoanBorrowerRequestRequest) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, lr,
+		validation.Field(&lr.Data, validation.Required),
+	)
+}
+
+var (
+	_ render.Binder                     = (*LoanBorrowerRequestRequest)(nil)
+	_ validation.ValidatableWithContext = (*LoanBorrowerRequestRequest)(nil)
+	_ validation.ValidatableWithContext = (*LoanBorrowerRequestDataWrapper)(nil)
+	_ validation.ValidatableWithContext = (*LoanBorrowerRequestData)(nil)
+)
+
+// LoanBorrowerLinkResponse returns a freshly minted signed loan link so
+// the owner can share it with the borrower by hand (the reminder worker
+// embeds the same kind of link in its courtesy emails).
+type LoanBorrowerLinkResponse struct {
+	Data *LoanBorrowerLinkResponseData `json:"data"`
+}
+
+type LoanBorrowerLinkResponseData struct {
+	ID         string                     `json:"id"`
+	Type       string                     `json:"type" example:"loan_borrower_links" enums:"loan_borrower_links"`
+	Attributes LoanBorrowerLinkAttributes `json:"attributes"`
+}
+
+type LoanBorrowerLinkAttributes struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewLoanBorrowerLinkResponse(loanID, url string, expiresAt time.Time) *LoanBorrowerLinkResponse {
+	return &LoanBorrowerLinkResponse{
+		Data: &LoanBorrowerLinkResponseData{
+			ID:   loanID,
+			Type: "loan_borrower_links",
+			Attributes: LoanBorrowerLinkAttributes{
+				URL:       url,
+				ExpiresAt: expiresAt.UTC(),
+			},
+		},
+	}
+}
+
+func (*LoanBorrowerLinkResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
@@ -0,0 +1,34 @@
+package jsonapi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// MaintenanceLogsMeta is the count block on a log list response.
+type MaintenanceLogsMeta struct {
+	Logs  int `json:"logs" example:"10" format:"int64"`
+	Total int `json:"total" example:"100" format:"int64"`
+}
+
+// MaintenanceLogsResponse is the per-schedule completion history, newest
+// first (the schedule is implicit in the URL).
+type MaintenanceLogsResponse struct {
+	Data []*models.MaintenanceLog `json:"data"`
+	Meta MaintenanceLogsMeta      `json:"meta"`
+}
+
+func NewMaintenanceLogsResponse(logs []*models.MaintenanceLog) *MaintenanceLogsResponse {
+	return &MaintenanceLogsResponse{
+		Data: logs,
+		Meta: MaintenanceLogsMeta{Logs: len(logs), Total: len(logs)},
+	}
+}
+
+func (*MaintenanceLogsResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-chi/render"
 	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models"
 )
@@ -136,13 +137,22 @@ type MaintenanceScheduleRequestDataWrapper struct {
 // MaintenanceScheduleRequestData carries the user-supplied fields on
 // create. NextDueAt is optional — when omitted the service defaults it
 // to `today + interval_days` so the user can just say "every 90 days"
-// without picking a start date.
+// without picking a start date (or to the first rule occurrence for
+// calendar schedules). Usage schedules ignore it: their due date is
+// estimated from the commodity's meter readings.
 type MaintenanceScheduleRequestData struct {
-	Title        string       `json:"title"`
-	IntervalDays int          `json:"interval_days"`
-	NextDueAt    models.Date  `json:"next_due_at,omitempty"`
-	LastDoneAt   models.PDate `json:"last_done_at,omitempty"`
-	Notes        string       `json:"notes,omitempty"`
+	Title string `json:"title"`
+	// Recurrence defaults to "interval" when omitted.
+	Recurrence    models.MaintenanceRecurrence    `json:"recurrence,omitempty" enums:"interval,calendar,usage"`
+	IntervalDays  int                             `json:"interval_days,omitempty"`
+	CalendarRule  *models.MaintenanceCalendarRule `json:"calendar_rule,omitempty"`
+	MeterUnit     models.MeterUnit                `json:"meter_unit,omitempty" enums:"hours,km,mi,cycles"`
+	MeterInterval decimal.Decimal                 `json:"meter_interval,omitempty"`
+	// NextDueMeter defaults to the latest reading plus MeterInterval.
+	NextDueMeter decimal.Decimal `json:"next_due_meter,omitempty"`
+	NextDueAt    models.Date     `json:"next_due_at,omitempty"`
+	LastDoneAt   models.PDate    `json:"last_done_at,omitempty"`
+	Notes        string          `json:"notes,omitempty"`
 	// Enabled defaults to true at the BE when omitted (the model
 	// has a `default="true"` migrator tag); the request field is a
 	// pointer so the BE can tell "user explicitly chose true" from
@@ -157,7 +167,18 @@ func (mrd *MaintenanceScheduleRequestData) Validate() error {
 func (mrd *MaintenanceScheduleRequestData) ValidateWithContext(ctx context.Context) error {
 	return validation.ValidateStructWithContext(ctx, mrd,
 		validation.Field(&mrd.Title, validation.Required, validation.Length(1, 200)),
-		validation.Field(&mrd.IntervalDays, validation.Required, validation.Min(1), validation.Max(36500)),
+		validation.Field(&mrd.Recurrence),
+		validation.Field(&mrd.IntervalDays, validation.Min(0), validation.Max(36500), validation.By(func(any) error {
+			// Only interval schedules need a cadence in days; the
+			// remaining cross-field rules live on the model.
+			isInterval := mrd.Recurrence == "" || mrd.Recurrence == models.MaintenanceRecurrenceInterval
+			if isInterval && mrd.IntervalDays < 1 {
+				return validation.ErrRequired
+			}
+			return nil
+		})),
+		validation.Field(&mrd.CalendarRule),
+		validation.Field(&mrd.MeterUnit),
 		validation.Field(&mrd.NextDueAt),
 		validation.Field(&mrd.LastDoneAt),
 		validation.Field(&mrd.Notes, validation.Length(0, 1000)),
@@ -205,12 +226,17 @@ type MaintenanceScheduleUpdateRequestDataWrapper struct {
 }
 
 type MaintenanceScheduleUpdateRequestData struct {
-	Title        *string      `json:"title,omitempty"`
-	IntervalDays *int         `json:"interval_days,omitempty"`
-	NextDueAt    *models.Date `json:"next_due_at,omitempty"`
-	LastDoneAt   models.PDate `json:"last_done_at,omitempty"`
-	Notes        *string      `json:"notes,omitempty"`
-	Enabled      *bool        `json:"enabled,omitempty"`
+	Title         *string                         `json:"title,omitempty"`
+	Recurrence    *models.MaintenanceRecurrence   `json:"recurrence,omitempty" enums:"interval,calendar,usage"`
+	IntervalDays  *int                            `json:"interval_days,omitempty"`
+	CalendarRule  *models.MaintenanceCalendarRule `json:"calendar_rule,omitempty"`
+	MeterUnit     *models.MeterUnit               `json:"meter_unit,omitempty" enums:"hours,km,mi,cycles"`
+	MeterInterval *decimal.Decimal                `json:"meter_interval,omitempty"`
+	NextDueMeter  *decimal.Decimal                `json:"next_due_meter,omitempty"`
+	NextDueAt     *models.Date                    `json:"next_due_at,omitempty"`
+	LastDoneAt    models.PDate                    `json:"last_done_at,omitempty"`
+	Notes         *string                         `json:"notes,omitempty"`
+	Enabled       *bool                           `json:"enabled,omitempty"`
 }
 
 func (murd *MaintenanceScheduleUpdateRequestData) Validate() error {
@@ -218,12 +244,23 @@ func (murd *MaintenanceScheduleUpdateRequestData) Validate() error {
 }
 
 func (murd *MaintenanceScheduleUpdateRequestData) ValidateWithContext(ctx context.Context) error {
-	fields := make([]*validation.FieldRules, 0, 4)
+	fields := make([]*validation.FieldRules, 0, 6)
 	if murd.Title != nil {
 		fields = append(fields, validation.Field(murd.Title, validation.Length(1, 200)))
 	}
+	if murd.Recurrence != nil {
+		fields = append(fields, validation.Field(murd.Recurrence, validation.Required))
+	}
 	if murd.IntervalDays != nil {
-		fields = append(fields, validation.Field(murd.IntervalDays, validation.Min(1), validation.Max(36500)))
+		// Zero is a valid "no time cap" for usage schedules; the model
+		// re-checks the interval-schedule minimum after the patch.
+		fields = append(fields, validation.Field(murd.IntervalDays, validation.Min(0), validation.Max(36500)))
+	}
+	if murd.CalendarRule != nil {
+		fields = append(fields, validation.Field(murd.CalendarRule))
+	}
+	if murd.MeterUnit != nil {
+		fields = append(fields, validation.Field(murd.MeterUnit, validation.Required))
 	}
 	if murd.Notes != nil {
 		fields = append(fields, validation.Field(murd.Notes, validation.Length(0, 1000)))
@@ -254,8 +291,9 @@ var (
 )
 
 // MaintenanceScheduleDoneRequest is the JSON:API payload for POST
-// .../maintenance/{id}/done. DoneAt is optional; absent means "today
-// (server clock)".
+// .../maintenance/{id}/done. Every attribute is optional: DoneAt
+// absent means "today (server clock)"; the rest end up on the
+// completion's maintenance log entry.
 type MaintenanceScheduleDoneRequest struct {
 	Data *MaintenanceScheduleDoneRequestDataWrapper `json:"data,omitempty"`
 }
@@ -267,6 +305,14 @@ type MaintenanceScheduleDoneRequestDataWrapper struct {
 
 type MaintenanceScheduleDoneRequestData struct {
 	DoneAt models.PDate `json:"done_at,omitempty"`
+	// MeterValue is the meter reading at completion; for usage
+	// schedules it is also stored as a meter reading.
+	MeterValue   *decimal.Decimal `json:"meter_value,omitempty"`
+	Notes        string           `json:"notes,omitempty"`
+	CostAmount   decimal.Decimal  `json:"cost_amount,omitempty"`
+	CostCurrency string           `json:"cost_currency,omitempty"`
+	// FileIDs attaches files already uploaded to the group.
+	FileIDs []string `json:"file_ids,omitempty"`
 }
 
 func (mdr *MaintenanceScheduleDoneRequest) Bind(r *http.Request) error {
@@ -276,6 +322,15 @@ func (mdr *MaintenanceScheduleDoneRequest) Bind(r *http.Request) error {
 	}
 	return validation.ValidateStructWithContext(r.Context(), mdr.Data,
 		validation.Field(&mdr.Data.Type, validation.Required, validation.In("maintenance_schedules")),
+		validation.Field(&mdr.Data.Attributes),
+	)
+}
+
+func (mdrd MaintenanceScheduleDoneRequestData) Validate() error {
+	return validation.ValidateStruct(&mdrd,
+		validation.Field(&mdrd.DoneAt),
+		validation.Field(&mdrd.Notes, validation.Length(0, 1000)),
+		validation.Field(&mdrd.FileIDs, validation.Length(0, 20), validation.Each(validation.Required)),
 	)
 }
 
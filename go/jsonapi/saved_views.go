@@ -0,0 +1,135 @@
+package jsonapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// SavedViewResponse is the JSON:API envelope for a single saved view.
+type SavedViewResponse struct {
+	HTTPStatusCode int                    `json:"-"`
+	Data           *SavedViewResponseData `json:"data"`
+}
+
+// SavedViewResponseData is the inner resource object.
+type SavedViewResponseData struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type" example:"saved_views" enums:"saved_views"`
+	Attributes models.SavedView `json:"attributes"`
+}
+
+func NewSavedViewResponse(view *models.SavedView) *SavedViewResponse {
+	return &SavedViewResponse{
+		Data: &SavedViewResponseData{
+			ID:         view.ID,
+			Type:       "saved_views",
+			Attributes: *view,
+		},
+	}
+}
+
+func (sr *SavedViewResponse) WithStatusCode(code int) *SavedViewResponse {
+	tmp := *sr
+	tmp.HTTPStatusCode = code
+	return &tmp
+}
+
+func (sr *SavedViewResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, statusCodeDef(sr.HTTPStatusCode, http.StatusOK))
+	return nil
+}
+
+// SavedViewsMeta is the meta block on a list response.
+type SavedViewsMeta struct {
+	Views int `json:"views" example:"10" format:"int64"`
+}
+
+// SavedViewsResponse lists the views visible to the caller: the group's
+// shared views plus the caller's own private ones.
+type SavedViewsResponse struct {
+	Data []*SavedViewResponseData `json:"data"`
+	Meta SavedViewsMeta           `json:"meta"`
+}
+
+func NewSavedViewsResponse(views []*models.SavedView) *SavedViewsResponse {
+	data := make([]*SavedViewResponseData, 0, len(views))
+	for _, v := range views {
+		data = append(data, NewSavedViewResponse(v).Data)
+	}
+	return &SavedViewsResponse{
+		Data: data,
+		Meta: SavedViewsMeta{Views: len(data)},
+	}
+}
+
+func (*SavedViewsResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// SavedViewRequest is the JSON:API payload for POST /saved-views and
+// PUT /saved-views/{viewID}. PUT replaces the whole view, so the same
+// shape serves both.
+type SavedViewRequest struct {
+	Data *SavedViewRequestDataWrapper `json:"data"`
+}
+
+type SavedViewRequestDataWrapper struct {
+	ID         string                `json:"id,omitempty"`
+	Type       string                `json:"type" example:"saved_views" enums:"saved_views"`
+	Attributes *SavedViewRequestData `json:"attributes"`
+}
+
+// SavedViewRequestData carries the user-supplied fields. Scope defaults
+// to private when omitted.
+type SavedViewRequestData struct {
+	Name    string                  `json:"name"`
+	Scope   models.SavedViewScope   `json:"scope,omitempty" example:"private" enums:"private,group"`
+	Filters models.SavedViewFilters `json:"filters"`
+	Columns []string                `json:"columns,omitempty"`
+}
+
+func (d *SavedViewRequestData) Validate() error {
+	return models.ErrMustUseValidateWithContext
+}
+
+func (d *SavedViewRequestData) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, d,
+		validation.Field(&d.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&d.Scope),
+		validation.Field(&d.Filters),
+		validation.Field(&d.Columns, validation.Length(0, 50), validation.Each(validation.Length(1, 64))),
+	)
+}
+
+func (w *SavedViewRequestDataWrapper) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, w,
+		validation.Field(&w.Type, validation.Required, validation.In("saved_views")),
+		validation.Field(&w.Attributes, validation.Required),
+	)
+}
+
+func (sr *SavedViewRequest) Bind(r *http.Request) error {
+	if sr.Data != nil && sr.Data.Attributes != nil && sr.Data.Attributes.Scope == "" {
+		sr.Data.Attributes.Scope = models.SavedViewScopePrivate
+	}
+	return sr.ValidateWithContext(r.Context())
+}
+
+func (sr *SavedViewRequest) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, sr,
+		validation.Field(&sr.Data, validation.Required),
+	)
+}
+
+var (
+	_ render.Binder                     = (*SavedViewRequest)(nil)
+	_ validation.ValidatableWithContext = (*SavedViewRequest)(nil)
+	_ validation.ValidatableWithContext = (*SavedViewRequestDataWrapper)(nil)
+	_ validation.ValidatableWithContext = (*SavedViewRequestData)(nil)
+)
@@ -0,0 +1,93 @@
+package jsonapi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// StatsBucket is one row of a dashboard breakdown. Key is the status /
+// type / location id / area id / tag / purchase year / warranty status;
+// an empty key collects the commodities with no value for the dimension.
+// Name is only filled for the location and area breakdowns, for the same
+// reason NamedTotal carries one.
+type StatsBucket struct {
+	Key   string          `json:"key" example:"in_use"`
+	Name  string          `json:"name,omitempty" example:"Living room"`
+	Count int             `json:"count" example:"12" format:"int64"`
+	Value decimal.Decimal `json:"value"`
+}
+
+// StatsAttrs is the dashboard aggregate for one group. Counts cover every
+// non-draft commodity; values only include in-use commodities, so
+// total_value equals GET /commodities/values' global_total.
+type StatsAttrs struct {
+	Currency         string          `json:"currency" example:"USD"`
+	TotalCount       int             `json:"total_count" example:"42" format:"int64"`
+	TotalValue       decimal.Decimal `json:"total_value"`
+	ByStatus         []StatsBucket   `json:"by_status"`
+	ByType           []StatsBucket   `json:"by_type"`
+	ByLocation       []StatsBucket   `json:"by_location"`
+	ByArea           []StatsBucket   `json:"by_area"`
+	ByTag            []StatsBucket   `json:"by_tag"`
+	ByPurchaseYear   []StatsBucket   `json:"by_purchase_year"`
+	ByWarrantyStatus []StatsBucket   `json:"by_warranty_status"`
+}
+
+// StatsData is the data part of a StatsResponse.
+type StatsData struct {
+	Type       string      `json:"type" example:"stats" enums:"stats"`
+	ID         string      `json:"id"`
+	Attributes *StatsAttrs `json:"attributes"`
+}
+
+// StatsResponse is the JSON:API envelope for GET /g/{groupSlug}/stats.
+type StatsResponse struct {
+	Data *StatsData `json:"data"`
+}
+
+// Render implements the render.Renderer interface for StatsResponse.
+func (*StatsResponse) Render(_ http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// NewStatsResponse converts registry stats into the API shape. names
+// resolves location and area ids to display names; unknown ids keep an
+// empty name.
+func NewStatsResponse(groupID, currency string, stats *registry.CommodityStats, names map[string]string) *StatsResponse {
+	buckets := func(dim registry.CommodityStatsDimension, named bool) []StatsBucket {
+		in := stats.Breakdowns[dim]
+		out := make([]StatsBucket, 0, len(in)) // must be an empty array instead of nil due to JSON serialization
+		for _, b := range in {
+			bucket := StatsBucket{Key: b.Key, Count: b.Count, Value: b.Value}
+			if named {
+				bucket.Name = names[b.Key]
+			}
+			out = append(out, bucket)
+		}
+		return out
+	}
+
+	return &StatsResponse{
+		Data: &StatsData{
+			Type: "stats",
+			ID:   groupID,
+			Attributes: &StatsAttrs{
+				Currency:         currency,
+				TotalCount:       stats.TotalCount,
+				TotalValue:       stats.TotalValue,
+				ByStatus:         buckets(registry.CommodityStatsByStatus, false),
+				ByType:           buckets(registry.CommodityStatsByType, false),
+				ByLocation:       buckets(registry.CommodityStatsByLocation, true),
+				ByArea:           buckets(registry.CommodityStatsByArea, true),
+				ByTag:            buckets(registry.CommodityStatsByTag, false),
+				ByPurchaseYear:   buckets(registry.CommodityStatsByPurchaseYear, false),
+				ByWarrantyStatus: buckets(registry.CommodityStatsByWarrantyStatus, false),
+			},
+		},
+	}
+}
@@ -15,13 +15,15 @@ var (
 
 // Enable RLS for multi-tenant isolation
 //migrator:schema:rls:enable table="areas" comment="Enable RLS for multi-tenant area isolation"
-//migrator:schema:rls:policy name="area_isolation" table="areas" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures areas can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="area_isolation" table="areas" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(location_id, 'viewer')" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(location_id, 'admin')" comment="Ensures areas can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations"
 //migrator:schema:rls:policy name="area_background_worker_access" table="areas" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all areas for processing"
 
 //migrator:schema:table name="areas"
 type Area struct {
 	//migrator:embedded mode="inline"
 	TenantGroupAwareEntityID
+	//migrator:embedded mode="inline"
+	EntityVersion
 	//migrator:schema:field name="name" type="TEXT" not_null="true"
 	Name string `json:"name" db:"name"`
 	//migrator:schema:field name="location_id" type="TEXT" not_null="true" foreign="locations(id)" foreign_key_name="fk_area_location"
@@ -0,0 +1,208 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/jellydator/validation"
+)
+
+var (
+	_ validation.Validatable            = (*BackupSchedule)(nil)
+	_ validation.ValidatableWithContext = (*BackupSchedule)(nil)
+	_ TenantGroupAwareIDable            = (*BackupSchedule)(nil)
+	_ validation.Validatable            = BackupFrequency("")
+)
+
+// BackupFrequency is how often a scheduled backup runs.
+type BackupFrequency string
+
+// Backup frequencies. Adding a new frequency? Don't forget to update IsValid() method.
+const (
+	BackupFrequencyDaily   BackupFrequency = "daily"
+	BackupFrequencyWeekly  BackupFrequency = "weekly"
+	BackupFrequencyMonthly BackupFrequency = "monthly"
+)
+
+func (f BackupFrequency) IsValid() bool {
+	switch f {
+	case BackupFrequencyDaily, BackupFrequencyWeekly, BackupFrequencyMonthly:
+		return true
+	}
+	return false
+}
+
+func (f BackupFrequency) Validate() error {
+	if !f.IsValid() {
+		return validation.NewError("invalid_backup_frequency", "must be one of: daily, weekly, monthly")
+	}
+	return nil
+}
+
+// BackupScheduleTimeLayout is the wire and storage format of
+// BackupSchedule.TimeOfDay.
+const BackupScheduleTimeLayout = "15:04"
+
+// BackupScheduleMaxDayOfMonth caps DayOfMonth so a monthly schedule fires
+// in every month, February included.
+const BackupScheduleMaxDayOfMonth = 28
+
+// BackupSchedule is a group's automatic backup configuration. A group has
+// at most one. The backup scheduler worker enqueues a full-database
+// export for every schedule whose next_run_at has passed, as the member
+// who configured it, and once that export settles either prunes the
+// schedule's older exports down to the GFS retention policy (completed)
+// or emails the member (failed).
+//
+// All times are UTC: TimeOfDay is "HH:MM", Weekday (0 = Sunday) only
+// matters for weekly schedules and DayOfMonth only for monthly ones.
+//
+// Enable RLS for multi-tenant isolation.
+//
+//migrator:schema:rls:enable table="backup_schedules" comment="Enable RLS for multi-tenant backup schedule isolation"
+//migrator:schema:rls:policy name="backup_schedule_isolation" table="backup_schedules" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures backup schedules can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="backup_schedule_background_worker_access" table="backup_schedules" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all backup schedules for processing"
+//migrator:schema:table name="backup_schedules"
+type BackupSchedule struct {
+	//migrator:embedded mode="inline"
+	TenantGroupAwareEntityID
+
+	//migrator:schema:field name="enabled" type="BOOLEAN" not_null="true" default="true"
+	Enabled bool `json:"enabled" db:"enabled"`
+
+	//migrator:schema:field name="frequency" type="TEXT" not_null="true"
+	Frequency BackupFrequency `json:"frequency" db:"frequency"`
+
+	//migrator:schema:field name="time_of_day" type="TEXT" not_null="true" default="03:00"
+	TimeOfDay string `json:"time_of_day" db:"time_of_day"`
+
+	//migrator:schema:field name="weekday" type="INTEGER" not_null="true" default="0"
+	Weekday int `json:"weekday" db:"weekday"`
+
+	//migrator:schema:field name="day_of_month" type="INTEGER" not_null="true" default="1"
+	DayOfMonth int `json:"day_of_month" db:"day_of_month"`
+
+	//migrator:schema:field name="include_file_data" type="BOOLEAN" not_null="true" default="false"
+	IncludeFileData bool `json:"include_file_data" db:"include_file_data"`
+
+	// Retention: the newest completed scheduled export of each of the
+	// last KeepDaily days, KeepWeekly ISO weeks and KeepMonthly months is
+	// kept; every other completed scheduled export is pruned. Manual
+	// exports are never touched.
+	//migrator:schema:field name="keep_daily" type="INTEGER" not_null="true" default="7"
+	KeepDaily int `json:"keep_daily" db:"keep_daily"`
+	//migrator:schema:field name="keep_weekly" type="INTEGER" not_null="true" default="4"
+	KeepWeekly int `json:"keep_weekly" db:"keep_weekly"`
+	//migrator:schema:field name="keep_monthly" type="INTEGER" not_null="true" default="6"
+	KeepMonthly int `json:"keep_monthly" db:"keep_monthly"`
+
+	// NextRunAt is when the scheduler enqueues the next export. Recomputed
+	// from the cadence on every save and after every run.
+	//migrator:schema:field name="next_run_at" type="TIMESTAMP" not_null="true"
+	NextRunAt time.Time `json:"next_run_at" db:"next_run_at" userinput:"false"`
+
+	//migrator:schema:field name="last_run_at" type="TIMESTAMP"
+	LastRunAt *time.Time `json:"last_run_at" db:"last_run_at" userinput:"false"`
+
+	// LastExportID is the export enqueued by the most recent run.
+	//migrator:schema:field name="last_export_id" type="TEXT"
+	LastExportID string `json:"last_export_id,omitempty" db:"last_export_id" userinput:"false"`
+
+	// SettledExportID is the last export whose outcome the scheduler has
+	// acted on (retention or failure email), so each outcome is handled
+	// once.
+	//migrator:schema:field name="settled_export_id" type="TEXT"
+	SettledExportID string `json:"-" db:"settled_export_id" userinput:"false"`
+
+	// LastError is the reason the most recent run failed; cleared by the
+	// next successful one.
+	//migrator:schema:field name="last_error" type="TEXT"
+	LastError string `json:"last_error,omitempty" db:"last_error" userinput:"false"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at" userinput:"false"`
+
+	//migrator:schema:field name="updated_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" userinput:"false"`
+}
+
+// BackupScheduleIndexes defines the postgres indexes for backup_schedules.
+type BackupScheduleIndexes struct {
+	// Unique index for the immutable UUID (deduplication key for import/restore).
+	//migrator:schema:index name="idx_backup_schedules_uuid" fields="uuid" unique="true" table="backup_schedules"
+	_ int
+
+	// One schedule per group.
+	//migrator:schema:index name="idx_backup_schedules_group" fields="group_id" unique="true" table="backup_schedules"
+	_ int
+
+	// Index for tenant-based queries.
+	//migrator:schema:index name="idx_backup_schedules_tenant_id" fields="tenant_id" table="backup_schedules"
+	_ int
+
+	// Index for the scheduler's due-schedule scan.
+	//migrator:schema:index name="idx_backup_schedules_next_run" fields="next_run_at" table="backup_schedules"
+	_ int
+}
+
+// NextRunAfter returns the first scheduled slot strictly after t.
+// TimeOfDay must be valid (see ValidateWithContext).
+func (s *BackupSchedule) NextRunAfter(t time.Time) time.Time {
+	t = t.UTC()
+	tod, err := time.Parse(BackupScheduleTimeLayout, s.TimeOfDay)
+	if err != nil {
+		tod = time.Time{}
+	}
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, tod.Hour(), tod.Minute(), 0, 0, time.UTC)
+	}
+
+	switch s.Frequency {
+	case BackupFrequencyWeekly:
+		next := at(t.Year(), t.Month(), t.Day())
+		next = next.AddDate(0, 0, (s.Weekday-int(next.Weekday())+7)%7)
+		if !next.After(t) {
+			next = next.AddDate(0, 0, 7)
+		}
+		return next
+	case BackupFrequencyMonthly:
+		next := at(t.Year(), t.Month(), s.DayOfMonth)
+		if !next.After(t) {
+			next = at(t.Year(), t.Month()+1, s.DayOfMonth)
+		}
+		return next
+	default:
+		next := at(t.Year(), t.Month(), t.Day())
+		if !next.After(t) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	}
+}
+
+// IsDue reports whether the schedule should run at now.
+func (s *BackupSchedule) IsDue(now time.Time) bool {
+	return s.Enabled && !s.NextRunAt.After(now)
+}
+
+func (*BackupSchedule) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (s *BackupSchedule) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, s,
+		validation.Field(&s.TenantGroupAwareEntityID),
+		validation.Field(&s.Frequency, validation.Required),
+		validation.Field(&s.TimeOfDay, validation.Required, validation.Date(BackupScheduleTimeLayout).Error("must be HH:MM (UTC)")),
+		validation.Field(&s.Weekday, validation.Min(0), validation.Max(6)),
+		validation.Field(&s.DayOfMonth, validation.Min(1), validation.Max(BackupScheduleMaxDayOfMonth)),
+		validation.Field(&s.KeepDaily, validation.Min(0), validation.Max(366), validation.By(func(any) error {
+			if s.KeepDaily+s.KeepWeekly+s.KeepMonthly == 0 {
+				return validation.NewError("backup_schedule_keep_nothing", "retention must keep at least one backup")
+			}
+			return nil
+		})),
+		validation.Field(&s.KeepWeekly, validation.Min(0), validation.Max(260)),
+		validation.Field(&s.KeepMonthly, validation.Min(0), validation.Max(120)),
+	)
+}
@@ -0,0 +1,116 @@
+package models_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+func TestBackupSchedule_NextRunAfter(t *testing.T) {
+	// 2026-05-13 is a Wednesday.
+	from := time.Date(2026, 5, 13, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		schedule models.BackupSchedule
+		from     time.Time
+		want     time.Time
+	}{
+		{
+			name:     "daily later today",
+			schedule: models.BackupSchedule{Frequency: models.BackupFrequencyDaily, TimeOfDay: "22:30"},
+			from:     from,
+			want:     time.Date(2026, 5, 13, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily slot passed rolls to tomorrow",
+			schedule: models.BackupSchedule{Frequency: models.BackupFrequencyDaily, TimeOfDay: "03:00"},
+			from:     from,
+			want:     time.Date(2026, 5, 14, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily exactly on the slot is not after it",
+			schedule: models.BackupSchedule{Frequency: models.BackupFrequencyDaily, TimeOfDay: "10:00"},
+			from:     from,
+			want:     time.Date(2026, 5, 14, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekly on a later weekday",
+			schedule: models.BackupSchedule{Frequency: models.BackupFrequencyWeekly, TimeOfDay: "03:00", Weekday: int(time.Saturday)},
+			from:     from,
+			want:     time.Date(2026, 5, 16, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekly same weekday slot passed",
+			schedule: models.BackupSchedule{Frequency: models.BackupFrequencyWeekly, TimeOfDay: "03:00", Weekday: int(time.Wednesday)},
+			from:     from,
+			want:     time.Date(2026, 5, 20, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "monthly this month",
+			schedule: models.BackupSchedule{Frequency: models.BackupFrequencyMonthly, TimeOfDay: "03:00", DayOfMonth: 20},
+			from:     from,
+			want:     time.Date(2026, 5, 20, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "monthly rolls over the year end",
+			schedule: models.BackupSchedule{Frequency: models.BackupFrequencyMonthly, TimeOfDay: "03:00", DayOfMonth: 1},
+			from:     time.Date(2026, 12, 5, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2027, 1, 1, 3, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := qt.New(t)
+			c.Assert(tc.schedule.NextRunAfter(tc.from), qt.Equals, tc.want)
+		})
+	}
+}
+
+func TestBackupSchedule_ValidateWithContext(t *testing.T) {
+	valid := func() models.BackupSchedule {
+		return models.BackupSchedule{
+			TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{TenantID: "t", GroupID: "g"},
+			Frequency:                models.BackupFrequencyDaily,
+			TimeOfDay:                "03:00",
+			DayOfMonth:               1,
+			KeepDaily:                7,
+		}
+	}
+	c := qt.New(t)
+	s := valid()
+	c.Assert(s.ValidateWithContext(context.Background()), qt.IsNil)
+
+	cases := []struct {
+		name string
+		mut  func(*models.BackupSchedule)
+		want string
+	}{
+		{"unknown frequency", func(s *models.BackupSchedule) { s.Frequency = "hourly" }, "frequency: must be one of"},
+		{"bad time", func(s *models.BackupSchedule) { s.TimeOfDay = "25:00" }, "time_of_day: must be HH:MM"},
+		{"weekday out of range", func(s *models.BackupSchedule) { s.Weekday = 7 }, "weekday: must be no greater than 6"},
+		{"day of month past 28", func(s *models.BackupSchedule) { s.DayOfMonth = 31 }, "day_of_month: must be no greater than 28"},
+		{"keeps nothing", func(s *models.BackupSchedule) { s.KeepDaily = 0 }, "keep_daily: retention must keep at least one backup"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := qt.New(t)
+			s := valid()
+			tc.mut(&s)
+			c.Assert(s.ValidateWithContext(context.Background()), qt.ErrorMatches, ".*"+tc.want+".*")
+		})
+	}
+}
+
+func TestBackupSchedule_IsDue(t *testing.T) {
+	c := qt.New(t)
+	now := time.Date(2026, 5, 13, 10, 0, 0, 0, time.UTC)
+	s := models.BackupSchedule{Enabled: true, NextRunAt: now}
+	c.Assert(s.IsDue(now), qt.IsTrue)
+	c.Assert(s.IsDue(now.Add(-time.Second)), qt.IsFalse)
+	s.Enabled = false
+	c.Assert(s.IsDue(now), qt.IsFalse)
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"github.com/jellydator/validation"
+)
+
+var (
+	_ validation.Validatable            = (*CalendarFeed)(nil)
+	_ validation.ValidatableWithContext = (*CalendarFeed)(nil)
+)
+
+// CalendarFeed is a user's iCalendar subscription: a secret URL that a
+// calendar client (Google Calendar, Apple Calendar, Outlook) polls for
+// warranty expirations, loan due dates, service returns and maintenance
+// due dates. The URL token is the only credential, so only its SHA-256
+// hash is stored — the raw token is shown once on create / rotate.
+//
+// A feed is scoped to one location group (GroupID set) or to every group
+// the user belongs to at the time the feed is fetched (GroupID nil).
+// Categories narrows the feed to a subset of the notification categories
+// (warranty_expiry, loan_reminder, service_reminder,
+// maintenance_reminder); empty means all of them. The service layer
+// validates the category names, since they belong to the notifications
+// package.
+//
+// Enable RLS for multi-tenant isolation
+//
+//migrator:schema:rls:enable table="calendar_feeds" comment="Enable RLS for multi-tenant calendar feed isolation"
+//migrator:schema:rls:policy name="calendar_feed_isolation" table="calendar_feeds" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND user_id = get_current_user_id() AND get_current_user_id() IS NOT NULL AND get_current_user_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND user_id = get_current_user_id() AND get_current_user_id() IS NOT NULL AND get_current_user_id() != ''" comment="Ensures calendar feeds can only be accessed and modified by the owning user within their tenant"
+//migrator:schema:rls:policy name="calendar_feed_background_worker_access" table="calendar_feeds" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to resolve calendar feed tokens for unauthenticated feed requests"
+//migrator:schema:table name="calendar_feeds"
+type CalendarFeed struct {
+	//migrator:embedded mode="inline"
+	TenantUserAwareEntityID
+
+	// GroupID scopes the feed to one group; nil means all of the user's
+	// groups. ON DELETE CASCADE: a deleted group takes its feeds along.
+	//migrator:schema:field name="group_id" type="TEXT" foreign="location_groups(id)" foreign_key_name="fk_calendar_feed_group" on_delete="CASCADE"
+	GroupID *string `json:"group_id" db:"group_id"`
+
+	// Name is an optional label so the user can tell feeds apart
+	// ("Phone", "Work laptop").
+	//migrator:schema:field name="name" type="TEXT"
+	Name string `json:"name" db:"name"`
+
+	//migrator:schema:field name="token_hash" type="VARCHAR(128)" not_null="true"
+	TokenHash string `json:"-" db:"token_hash" userinput:"false"`
+
+	//migrator:schema:field name="categories" type="JSONB"
+	Categories ValuerSlice[string] `json:"categories" db:"categories"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at" userinput:"false"`
+
+	// RotatedAt is when the token was last replaced; nil if never.
+	//migrator:schema:field name="rotated_at" type="TIMESTAMP"
+	RotatedAt *time.Time `json:"rotated_at,omitempty" db:"rotated_at" userinput:"false"`
+
+	// LastUsedAt is the last time a client fetched the feed.
+	//migrator:schema:field name="last_used_at" type="TIMESTAMP"
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at" userinput:"false"`
+}
+
+// CalendarFeedIndexes defines the postgres indexes for calendar_feeds.
+type CalendarFeedIndexes struct {
+	// Unique index for the immutable UUID (deduplication key for import/restore)
+	//migrator:schema:index name="idx_calendar_feeds_uuid" fields="uuid" unique="true" table="calendar_feeds"
+	_ int
+
+	// Index for listing a user's feeds
+	//migrator:schema:index name="idx_calendar_feeds_user_id" fields="user_id" table="calendar_feeds"
+	_ int
+
+	// Unique index for token hash lookups on feed fetches
+	//migrator:schema:index name="idx_calendar_feeds_token_hash" fields="token_hash" unique="true" table="calendar_feeds"
+	_ int
+}
+
+// GenerateCalendarFeedToken creates a cryptographically secure random
+// feed token and its SHA-256 hash. Mirrors GenerateRefreshToken: the raw
+// token goes into the subscription URL, the hash into the database.
+func GenerateCalendarFeedToken() (token string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashCalendarFeedToken(token), nil
+}
+
+// HashCalendarFeedToken computes the SHA-256 hash of a raw feed token.
+func HashCalendarFeedToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// IncludesCategory reports whether the feed publishes the category.
+func (f *CalendarFeed) IncludesCategory(category string) bool {
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, c := range f.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func (*CalendarFeed) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (f *CalendarFeed) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, f,
+		validation.Field(&f.Name, validation.Length(0, 100)),
+		validation.Field(&f.TokenHash, validation.Required),
+		validation.Field(&f.Categories, validation.Each(validation.Required)),
+	)
+}
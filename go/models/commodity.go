@@ -93,7 +93,7 @@ var (
 
 // Enable RLS for multi-tenant isolation
 //migrator:schema:rls:enable table="commodities" comment="Enable RLS for multi-tenant commodity isolation"
-//migrator:schema:rls:policy name="commodity_isolation" table="commodities" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures commodities can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="commodity_isolation" table="commodities" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND area_location_scope_allows(area_id, 'viewer')" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND area_location_scope_allows(area_id, 'user')" comment="Ensures commodities can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations"
 //migrator:schema:rls:policy name="commodity_background_worker_access" table="commodities" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all commodities for processing"
 
 // Both-or-neither invariant on the acquisition pair (#1550 / #202) is
@@ -110,6 +110,8 @@ var (
 type Commodity struct {
 	//migrator:embedded mode="inline"
 	TenantGroupAwareEntityID
+	//migrator:embedded mode="inline"
+	EntityVersion
 	//migrator:schema:field name="name" type="TEXT" not_null="true"
 	Name string `json:"name" db:"name"`
 	//migrator:schema:field name="short_name" type="TEXT"
@@ -43,9 +43,10 @@ const (
 	// the returned_at date for kind-aware FE copy.
 	CommodityEventKindReturned CommodityEventKind = "returned"
 	// CommodityEventKindLoanUpdated is emitted when a loan's mutable
-	// fields change (borrower_contact / borrower_note / due_back_at). The
-	// service skips no-op patches so this event only lands when something
-	// actually changed — same gate as EmitUpdated for commodities.
+	// fields change (borrower_contact / borrower_email / borrower_note /
+	// due_back_at). The service skips no-op patches so this event only
+	// lands when something actually changed — same gate as EmitUpdated
+	// for commodities.
 	CommodityEventKindLoanUpdated CommodityEventKind = "loan_updated"
 	// CommodityEventKindLoanReminderSent is emitted by the loan reminder
 	// worker (#1509) when an overdue or due-soon email is successfully
@@ -55,6 +56,20 @@ const (
 	// so a single (loan, kind) tuple emits at most one event over its
 	// lifetime.
 	CommodityEventKindLoanReminderSent CommodityEventKind = "loan_reminder_sent"
+	// CommodityEventKindLoanRequestSubmitted is emitted when a borrower
+	// confirms a return or asks for an extension through the signed loan
+	// link. After holds {"loan_id","borrower_name","request_kind"} plus
+	// the optional "requested_due_back_at" / "request_note".
+	CommodityEventKindLoanRequestSubmitted CommodityEventKind = "loan_request_submitted"
+	// CommodityEventKindLoanRequestApproved is emitted when the owner
+	// approves a pending borrower request. Same payload as
+	// CommodityEventKindLoanRequestSubmitted; the resulting returned /
+	// loan_updated event lands alongside it.
+	CommodityEventKindLoanRequestApproved CommodityEventKind = "loan_request_approved"
+	// CommodityEventKindLoanRequestRejected is emitted when the owner
+	// dismisses a pending borrower request. Same payload as
+	// CommodityEventKindLoanRequestSubmitted.
+	CommodityEventKindLoanRequestRejected CommodityEventKind = "loan_request_rejected"
 	// CommodityEventKindSentForService is emitted when a commodity is
 	// sent to a workshop / service center (a new commodity_services row
 	// is created with returned_at NULL). Sibling to CommodityEventKindLentOut.
@@ -69,11 +84,29 @@ const (
 	// cost_amount / cost_currency). Same no-op skip gate as
 	// CommodityEventKindLoanUpdated.
 	CommodityEventKindServiceUpdated CommodityEventKind = "service_updated"
+	// CommodityEventKindServiceReminderSent is emitted by the service
+	// reminder worker when an overdue or due-soon email is enqueued for
+	// an open service row. Same payload shape as
+	// CommodityEventKindLoanReminderSent with "service_id" in place of
+	// "loan_id".
+	CommodityEventKindServiceReminderSent CommodityEventKind = "service_reminder_sent"
 	// CommodityEventKindDeleted is emitted right before a commodity is deleted.
 	// Persisted so the event row is still in the table when the commodity row
 	// is removed in the same transaction; ON DELETE CASCADE then drops it. The
 	// row is still observable to anyone scanning during the same request.
 	CommodityEventKindDeleted CommodityEventKind = "deleted"
+	// CommodityEventKindMerged is emitted on the surviving commodity when a
+	// duplicate is merged into it. Before holds the duplicate's identifying
+	// fields (it is gone after the merge); After holds the per-kind counts
+	// of what was moved across. The duplicate's own timeline is reassigned
+	// to the survivor in the same flow, so this row marks the seam.
+	CommodityEventKindMerged CommodityEventKind = "merged"
+	// CommodityEventKindTransferred is emitted when a commodity moves to
+	// another location group. It is written twice, once in each group, so
+	// the source group keeps a trace of the item after its timeline has
+	// moved away. Before / After hold the group, area and price fields on
+	// either side of the move.
+	CommodityEventKindTransferred CommodityEventKind = "transferred"
 )
 
 // IsValid reports whether the event kind is one of the known values.
@@ -89,10 +122,16 @@ func (k CommodityEventKind) IsValid() bool {
 		CommodityEventKindReturned,
 		CommodityEventKindLoanUpdated,
 		CommodityEventKindLoanReminderSent,
+		CommodityEventKindLoanRequestSubmitted,
+		CommodityEventKindLoanRequestApproved,
+		CommodityEventKindLoanRequestRejected,
 		CommodityEventKindSentForService,
 		CommodityEventKindBackFromService,
 		CommodityEventKindServiceUpdated,
-		CommodityEventKindDeleted:
+		CommodityEventKindServiceReminderSent,
+		CommodityEventKindDeleted,
+		CommodityEventKindMerged,
+		CommodityEventKindTransferred:
 		return true
 	}
 	return false
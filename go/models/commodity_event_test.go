@@ -26,6 +26,8 @@ func TestCommodityEventKind_IsValid(t *testing.T) {
 		{models.CommodityEventKindReturned, true},
 		{models.CommodityEventKindLoanUpdated, true},
 		{models.CommodityEventKindDeleted, true},
+		{models.CommodityEventKindMerged, true},
+		{models.CommodityEventKindTransferred, true},
 		{"", false},
 		{"unknown", false},
 		{"LENT_OUT", false}, // case-sensitive — wire format is lowercase
@@ -39,7 +39,7 @@ var (
 // Enable RLS for multi-tenant isolation
 //
 //migrator:schema:rls:enable table="commodity_loans" comment="Enable RLS for multi-tenant commodity loan isolation"
-//migrator:schema:rls:policy name="commodity_loan_isolation" table="commodity_loans" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures commodity loans can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="commodity_loan_isolation" table="commodity_loans" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND commodity_location_scope_allows(commodity_id, 'viewer')" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND commodity_location_scope_allows(commodity_id, 'user')" comment="Ensures commodity loans can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations"
 //migrator:schema:rls:policy name="commodity_loan_background_worker_access" table="commodity_loans" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all commodity loans for processing"
 //migrator:schema:table name="commodity_loans"
 type CommodityLoan struct {
@@ -64,6 +64,13 @@ type CommodityLoan struct {
 	//migrator:schema:field name="borrower_contact" type="TEXT"
 	BorrowerContact string `json:"borrower_contact" db:"borrower_contact"`
 
+	// BorrowerEmail is the optional structured address the reminder
+	// worker sends courtesy reminders and the signed return link to.
+	// Unlike BorrowerContact it is validated — an empty value simply
+	// opts the loan out of borrower-facing mail.
+	//migrator:schema:field name="borrower_email" type="TEXT"
+	BorrowerEmail string `json:"borrower_email" db:"borrower_email"`
+
 	// BorrowerNote is a free-form aide-mémoire ("works in the office
 	// downstairs"). Capped at 1000 chars.
 	//migrator:schema:field name="borrower_note" type="TEXT"
@@ -97,6 +104,38 @@ type CommodityLoan struct {
 	//migrator:schema:field name="reminder_sent_due_soon" type="BOOLEAN" not_null="true" default="false"
 	ReminderSentDueSoon bool `json:"reminder_sent_due_soon" db:"reminder_sent_due_soon" userinput:"false"`
 
+	// BorrowerReminderSentOverdue + BorrowerReminderSentDueSoon are the
+	// borrower-side twins of the flags above. Kept separate so a lender
+	// opting out of their own reminders never suppresses the borrower's
+	// courtesy mail (and vice versa).
+	//migrator:schema:field name="borrower_reminder_sent_overdue" type="BOOLEAN" not_null="true" default="false"
+	BorrowerReminderSentOverdue bool `json:"borrower_reminder_sent_overdue" db:"borrower_reminder_sent_overdue" userinput:"false"`
+
+	//migrator:schema:field name="borrower_reminder_sent_due_soon" type="BOOLEAN" not_null="true" default="false"
+	BorrowerReminderSentDueSoon bool `json:"borrower_reminder_sent_due_soon" db:"borrower_reminder_sent_due_soon" userinput:"false"`
+
+	// PendingRequestKind is the borrower's outstanding self-service
+	// request submitted through the signed loan link ("" when none).
+	// The owner approves or rejects it; either outcome clears the
+	// pending_request_* columns. Only one request is tracked at a time —
+	// a newer submission replaces an unreviewed one.
+	//migrator:schema:field name="pending_request_kind" type="TEXT"
+	PendingRequestKind LoanRequestKind `json:"pending_request_kind,omitempty" db:"pending_request_kind" userinput:"false"`
+
+	// PendingRequestDueBackAt is the new due date the borrower asked for
+	// (extension requests only).
+	//migrator:schema:field name="pending_request_due_back_at" type="TEXT"
+	PendingRequestDueBackAt PDate `json:"pending_request_due_back_at,omitempty" db:"pending_request_due_back_at" userinput:"false"`
+
+	// PendingRequestNote is the borrower's optional message. Capped at
+	// 1000 chars like BorrowerNote.
+	//migrator:schema:field name="pending_request_note" type="TEXT"
+	PendingRequestNote string `json:"pending_request_note,omitempty" db:"pending_request_note" userinput:"false"`
+
+	// PendingRequestAt is when the pending request was submitted.
+	//migrator:schema:field name="pending_request_at" type="TIMESTAMP"
+	PendingRequestAt *time.Time `json:"pending_request_at,omitempty" db:"pending_request_at" userinput:"false"`
+
 	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
 	CreatedAt time.Time `json:"created_at" db:"created_at" userinput:"false"`
 
@@ -134,6 +173,52 @@ type CommodityLoanIndexes struct {
 	_ int
 }
 
+// LoanRequestKind names a borrower self-service request on a loan.
+type LoanRequestKind string
+
+const (
+	// LoanRequestKindNone means no request is pending.
+	LoanRequestKindNone LoanRequestKind = ""
+	// LoanRequestKindReturn is the borrower confirming they returned the
+	// item. Approval closes the loan.
+	LoanRequestKindReturn LoanRequestKind = "return"
+	// LoanRequestKindExtension is the borrower asking for a later due
+	// date. Approval moves due_back_at and re-arms the reminder flags.
+	LoanRequestKindExtension LoanRequestKind = "extension"
+)
+
+// IsValid reports whether the kind is one of the known values.
+func (k LoanRequestKind) IsValid() bool {
+	switch k {
+	case LoanRequestKindNone, LoanRequestKindReturn, LoanRequestKindExtension:
+		return true
+	}
+	return false
+}
+
+// Validate makes LoanRequestKind a validation.Validatable.
+func (k LoanRequestKind) Validate() error {
+	if !k.IsValid() {
+		return validation.NewError("invalid_loan_request_kind", "must be one of: return, extension")
+	}
+	return nil
+}
+
+// HasPendingRequest reports whether the borrower has an unreviewed
+// self-service request on the loan.
+func (l *CommodityLoan) HasPendingRequest() bool {
+	return l.PendingRequestKind != LoanRequestKindNone
+}
+
+// ClearPendingRequest drops the pending_request_* fields after the
+// owner has reviewed the request.
+func (l *CommodityLoan) ClearPendingRequest() {
+	l.PendingRequestKind = LoanRequestKindNone
+	l.PendingRequestDueBackAt = nil
+	l.PendingRequestNote = ""
+	l.PendingRequestAt = nil
+}
+
 // IsOpen reports whether the loan is currently active (no return logged).
 func (l *CommodityLoan) IsOpen() bool {
 	return l.ReturnedAt == nil || *l.ReturnedAt == ""
@@ -163,9 +248,13 @@ func (l *CommodityLoan) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&l.CommodityID, rules.NotEmpty),
 		validation.Field(&l.BorrowerName, rules.NotEmpty, validation.Length(1, 200)),
 		validation.Field(&l.BorrowerContact, validation.Length(0, 200)),
+		validation.Field(&l.BorrowerEmail, validation.Length(0, 255), validation.Match(EmailPattern)),
 		validation.Field(&l.BorrowerNote, validation.Length(0, 1000)),
 		validation.Field(&l.LentAt, validation.Required),
 		validation.Field(&l.DueBackAt),
 		validation.Field(&l.ReturnedAt),
+		validation.Field(&l.PendingRequestKind),
+		validation.Field(&l.PendingRequestDueBackAt),
+		validation.Field(&l.PendingRequestNote, validation.Length(0, 1000)),
 	)
 }
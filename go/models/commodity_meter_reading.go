@@ -0,0 +1,122 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models/rules"
+)
+
+var (
+	_ validation.Validatable            = (*CommodityMeterReading)(nil)
+	_ validation.ValidatableWithContext = (*CommodityMeterReading)(nil)
+	_ TenantGroupAwareIDable            = (*CommodityMeterReading)(nil)
+)
+
+// MeterUnit is the unit a usage meter counts in. A commodity may carry
+// readings in several units (a car's odometer in km and a generator's
+// run-hours counter are different meters), so every reading and every
+// usage-based maintenance schedule names its unit explicitly.
+type MeterUnit string
+
+const (
+	MeterUnitHours  MeterUnit = "hours"
+	MeterUnitKm     MeterUnit = "km"
+	MeterUnitMiles  MeterUnit = "mi"
+	MeterUnitCycles MeterUnit = "cycles"
+)
+
+func (u MeterUnit) Validate() error {
+	return validation.Validate(string(u), validation.In(
+		string(MeterUnitHours),
+		string(MeterUnitKm),
+		string(MeterUnitMiles),
+		string(MeterUnitCycles),
+	))
+}
+
+// CommodityMeterReading is a single observation of a commodity's usage
+// meter ("odometer at 48 210 km on 2026-05-02"). Readings drive
+// usage-based maintenance schedules: the schedule's next_due_meter is
+// compared with the latest reading, and the recent reading rate is used
+// to estimate the calendar date the threshold will be crossed.
+//
+// Readings are append-only history; the user may delete a mistyped
+// one, but there is no update path.
+//
+// Enable RLS for multi-tenant isolation.
+//
+//migrator:schema:rls:enable table="commodity_meter_readings" comment="Enable RLS for multi-tenant commodity meter reading isolation"
+//migrator:schema:rls:policy name="commodity_meter_reading_isolation" table="commodity_meter_readings" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures commodity meter readings can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="commodity_meter_reading_background_worker_access" table="commodity_meter_readings" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all commodity meter readings for processing"
+//migrator:schema:table name="commodity_meter_readings"
+type CommodityMeterReading struct {
+	//migrator:embedded mode="inline"
+	TenantGroupAwareEntityID
+
+	// CommodityID is the metered item. ON DELETE CASCADE is added
+	// manually to the generated migration, mirroring
+	// maintenance_schedules.
+	//migrator:schema:field name="commodity_id" type="TEXT" not_null="true" foreign="commodities(id)" foreign_key_name="fk_commodity_meter_reading_commodity" on_delete="CASCADE"
+	CommodityID string `json:"commodity_id" db:"commodity_id"`
+
+	//migrator:schema:field name="unit" type="TEXT" not_null="true"
+	Unit MeterUnit `json:"unit" db:"unit"`
+
+	// Value is the absolute meter value, not a delta.
+	//migrator:schema:field name="value" type="DECIMAL(14,2)" not_null="true"
+	Value decimal.Decimal `json:"value" db:"value"`
+
+	// ReadAt is the date the meter was read (YYYY-MM-DD).
+	//migrator:schema:field name="read_at" type="TEXT" not_null="true"
+	ReadAt Date `json:"read_at" db:"read_at"`
+
+	//migrator:schema:field name="notes" type="TEXT"
+	Notes string `json:"notes" db:"notes"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at" userinput:"false"`
+}
+
+// CommodityMeterReadingIndexes defines the postgres indexes for commodity_meter_readings.
+type CommodityMeterReadingIndexes struct {
+	// Unique index for the immutable UUID (deduplication key for import/restore).
+	//migrator:schema:index name="idx_commodity_meter_readings_uuid" fields="uuid" unique="true" table="commodity_meter_readings"
+	_ int
+
+	// Index for tenant-based queries.
+	//migrator:schema:index name="idx_commodity_meter_readings_tenant_id" fields="tenant_id" table="commodity_meter_readings"
+	_ int
+
+	// Composite index for tenant+group RLS-filtered queries.
+	//migrator:schema:index name="idx_commodity_meter_readings_tenant_group" fields="tenant_id,group_id" table="commodity_meter_readings"
+	_ int
+
+	// Composite index for the per-commodity history and the due-date
+	// estimator, which both read one meter in date order.
+	//migrator:schema:index name="idx_commodity_meter_readings_commodity" fields="commodity_id,unit,read_at" table="commodity_meter_readings"
+	_ int
+}
+
+func (*CommodityMeterReading) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (r *CommodityMeterReading) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, r,
+		validation.Field(&r.TenantGroupAwareEntityID),
+		validation.Field(&r.CommodityID, rules.NotEmpty),
+		validation.Field(&r.Unit, validation.Required),
+		validation.Field(&r.Value, validation.By(func(any) error {
+			if r.Value.IsNegative() {
+				return validation.NewError("meter_value_negative", "value must not be negative")
+			}
+			return nil
+		})),
+		validation.Field(&r.ReadAt, validation.Required),
+		validation.Field(&r.Notes, validation.Length(0, 1000)),
+	)
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 // Commodity scan audit values used by both the service that writes the
 // row and the registry tests that read it back. Keeping them as typed
@@ -30,6 +34,9 @@ const (
 	// per-user budget that's meant to bound vendor cost on real
 	// provider attempts.
 	CommodityScanStatusValidation = "validation"
+	// CommodityScanStatusBudgetExceeded signals the tenant or group
+	// monthly spend budget was exhausted before the provider was called.
+	CommodityScanStatusBudgetExceeded = "budget_exceeded"
 )
 
 // CommodityScanAudit records each invocation of the AI vision scan
@@ -52,6 +59,12 @@ type CommodityScanAudit struct {
 	//migrator:embedded mode="inline"
 	TenantUserAwareEntityID
 
+	// GroupID is the location group the scan was made for, when there is
+	// one; it scopes group budgets and the usage report. Empty for scans
+	// made outside a group. Not an FK: audit rows outlive their group.
+	//migrator:schema:field name="group_id" type="TEXT" not_null="true" default=""
+	GroupID string `json:"group_id,omitempty" db:"group_id"`
+
 	// Provider is the aivision provider name that handled the scan
 	// (e.g. "anthropic", "openai", "mock"). For rate-limit / disabled
 	// rows this is the configured provider name even though no call
@@ -94,6 +107,11 @@ type CommodityScanAudit struct {
 	//migrator:schema:field name="tokens_used" type="INTEGER" not_null="true" default="0"
 	TokensUsed int32 `json:"tokens_used" db:"tokens_used"`
 
+	// CostUSD is TokensUsed priced with the configured per-model price
+	// table. Zero for short-circuited rows and unpriced models.
+	//migrator:schema:field name="cost_usd" type="DECIMAL(12,6)" not_null="true" default="0"
+	CostUSD decimal.Decimal `json:"cost_usd" db:"cost_usd"`
+
 	// ResultJSON is the marshalled ScanResult on success. Empty on
 	// every non-OK status. Kept JSONB so cost-analytics queries can
 	// project specific fields without a downstream join.
@@ -120,4 +138,8 @@ type CommodityScanAuditIndexes struct {
 	// Index for tenant-level dashboards (cost per tenant).
 	//migrator:schema:index name="idx_commodity_scan_audits_tenant_created" fields="tenant_id,created_at" table="commodity_scan_audits"
 	_ int
+
+	// Index for the group budget check (month-to-date spend per group).
+	//migrator:schema:index name="idx_commodity_scan_audits_tenant_group_created" fields="tenant_id,group_id,created_at" table="commodity_scan_audits"
+	_ int
 }
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CommodityScanBudget caps the monthly AI vision spend of a tenant, or of
+// one location group within it. CommodityScanService sums the calendar
+// month's CostUSD from commodity_scan_audits and refuses further scans
+// once the cap is reached. A tenant-wide row has an empty GroupID; a
+// group row applies on top of it, so a scan must fit both.
+//
+// Like WorkerControl the table has NO RLS policy: budgets are set by
+// platform operators from the back office, and the scan path reads them
+// for whichever tenant is calling.
+//
+//migrator:schema:table name="commodity_scan_budgets"
+type CommodityScanBudget struct {
+	//migrator:embedded mode="inline"
+	EntityID
+
+	// TenantID is the tenant the budget applies to.
+	//migrator:schema:field name="tenant_id" type="TEXT" not_null="true"
+	TenantID string `json:"tenant_id" db:"tenant_id"`
+
+	// GroupID narrows the budget to one location group. Empty for the
+	// tenant-wide budget.
+	//migrator:schema:field name="group_id" type="TEXT" not_null="true" default=""
+	GroupID string `json:"group_id,omitempty" db:"group_id"`
+
+	// MonthlyLimitUSD is the spend cap per calendar month (UTC). Zero
+	// blocks every provider call.
+	//migrator:schema:field name="monthly_limit_usd" type="DECIMAL(12,2)" not_null="true"
+	MonthlyLimitUSD decimal.Decimal `json:"monthly_limit_usd" db:"monthly_limit_usd"`
+
+	// UpdatedAt is when the limit was last set.
+	//migrator:schema:field name="updated_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// UpdatedBy records who set the limit: the back-office operator id
+	// for an API call. Not an FK, same as WorkerControl.PausedBy.
+	//migrator:schema:field name="updated_by" type="TEXT"
+	UpdatedBy *string `json:"updated_by,omitempty" db:"updated_by"`
+}
+
+// CommodityScanBudgetIndexes defines the PostgreSQL indexes for the
+// commodity_scan_budgets table.
+type CommodityScanBudgetIndexes struct {
+	// Unique index for the immutable UUID (mirrors the convention used
+	// elsewhere).
+	//migrator:schema:index name="idx_commodity_scan_budgets_uuid" fields="uuid" unique="true" table="commodity_scan_budgets"
+	_ int
+
+	// One budget per tenant and group; backs the upsert in Set.
+	//migrator:schema:index name="idx_commodity_scan_budgets_tenant_group" fields="tenant_id,group_id" unique="true" table="commodity_scan_budgets"
+	_ int
+}
+
+// CommodityScanUsage is one row of the AI vision usage report: the
+// provider calls made for a tenant and group through one model, with
+// their summed tokens and cost.
+type CommodityScanUsage struct {
+	TenantID   string          `json:"tenant_id" db:"tenant_id"`
+	GroupID    string          `json:"group_id,omitempty" db:"group_id"`
+	Provider   string          `json:"provider" db:"provider"`
+	Model      string          `json:"model" db:"model"`
+	Scans      int             `json:"scans" db:"scans"`
+	TokensUsed int64           `json:"tokens_used" db:"tokens_used"`
+	CostUSD    decimal.Decimal `json:"cost_usd" db:"cost_usd"`
+}
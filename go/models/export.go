@@ -50,6 +50,16 @@ func (e ExportStatus) ValidateWithContext(context.Context) error {
 	return nil
 }
 
+// ExportReplicationStatus tracks an export's copy in the off-site
+// replication target. The zero value means the export has not been
+// replicated (yet).
+type ExportReplicationStatus string
+
+const (
+	ExportReplicationStatusReplicated ExportReplicationStatus = "replicated"
+	ExportReplicationStatusFailed     ExportReplicationStatus = "failed"
+)
+
 type ExportType string
 
 // Export types. Adding a new type? Don't forget to update IsValid() method.
@@ -189,6 +199,34 @@ type Export struct {
 	FileCount int `json:"file_count" db:"file_count" userinput:"false"`
 	//migrator:schema:field name="binary_data_size" type="BIGINT" default="0"
 	BinaryDataSize int64 `json:"binary_data_size" db:"binary_data_size" userinput:"false"`
+	// SignatureKeyFingerprint is the fingerprint of the key the archive is
+	// signed with: the server's own key for a generated export, and for an
+	// imported one the keyring entry its signature verified under.
+	//migrator:schema:field name="signature_key_fingerprint" type="TEXT"
+	SignatureKeyFingerprint string `json:"signature_key_fingerprint,omitempty" db:"signature_key_fingerprint" userinput:"false"`
+	// BackupScheduleID is set on exports enqueued by the backup scheduler;
+	// only these are subject to the schedule's retention policy.
+	//migrator:schema:field name="backup_schedule_id" type="TEXT" foreign="backup_schedules(id)" foreign_key_name="fk_export_backup_schedule" on_delete="SET NULL"
+	BackupScheduleID *string `json:"backup_schedule_id,omitempty" db:"backup_schedule_id" userinput:"false"`
+	// Off-site replication state, maintained by the replication worker when
+	// a replication target is configured. ReplicaSHA256 is the digest of the
+	// archive as read back from the target.
+	//migrator:schema:field name="replication_status" type="TEXT" not_null="true" default=""
+	ReplicationStatus ExportReplicationStatus `json:"replication_status,omitempty" db:"replication_status" userinput:"false"`
+	//migrator:schema:field name="replica_url" type="TEXT"
+	ReplicaURL string `json:"replica_url,omitempty" db:"replica_url" userinput:"false"`
+	//migrator:schema:field name="replica_sha256" type="TEXT"
+	ReplicaSHA256 string `json:"replica_sha256,omitempty" db:"replica_sha256" userinput:"false"`
+	//migrator:schema:field name="replicated_at" type="TIMESTAMP"
+	ReplicatedAt PTimestamp `json:"replicated_at,omitempty" db:"replicated_at" userinput:"false"`
+	//migrator:schema:field name="replication_error" type="TEXT"
+	ReplicationError string `json:"replication_error,omitempty" db:"replication_error" userinput:"false"`
+	//migrator:schema:field name="replication_attempts" type="INTEGER" not_null="true" default="0"
+	ReplicationAttempts int `json:"replication_attempts,omitempty" db:"replication_attempts" userinput:"false"`
+	// TraceParent is the W3C traceparent of the request that enqueued the
+	// export, so the worker's span joins that request's trace.
+	//migrator:schema:field name="trace_parent" type="TEXT"
+	TraceParent string `json:"-" db:"trace_parent" userinput:"false"`
 }
 
 func NewImportedExport(description, sourceFilePath string) Export {
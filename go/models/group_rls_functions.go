@@ -24,3 +24,37 @@ type GroupRLSFunctions struct {
 	//migrator:schema:function name="get_current_group_id" returns="TEXT" language="plpgsql" volatility="STABLE" body="BEGIN RETURN current_setting('app.current_group_id', true); END;" comment="Gets the current group ID from session for RLS policies"
 	_ int
 }
+
+// Location scope functions for Row-Level Security.
+//
+// A group member restricted to specific locations (see
+// GroupMemberLocationScope) carries their effective scope in the
+// transaction-local `app.current_location_scope` GUC, encoded as
+// "location_id:role,location_id:role" by LocationScope.Encode. An empty
+// or missing GUC means "unrestricted", so every existing caller keeps
+// its behaviour. The *_location_scope_allows helpers resolve a row to
+// its location (area → location, commodity → area → location, file →
+// linked entity) and compare the granted role against the minimum the
+// policy asks for. They are STABLE and run under the caller's role, so
+// the lookups they perform are themselves filtered by RLS.
+type LocationScopeRLSFunctions struct {
+	// Function to get the current location scope from the session
+	//migrator:schema:function name="get_current_location_scope" returns="TEXT" language="plpgsql" volatility="STABLE" body="BEGIN RETURN COALESCE(current_setting('app.current_location_scope', true), ''); END;" comment="Gets the current member location scope from session for RLS policies"
+	_ int
+
+	// Function to check a location against the current location scope
+	//migrator:schema:function name="location_scope_allows" params="location_id_param TEXT, min_role_param TEXT" returns="BOOLEAN" language="plpgsql" volatility="STABLE" body="DECLARE entry TEXT; roles TEXT[] := ARRAY['viewer','user','admin','owner']; BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; IF location_id_param IS NULL THEN RETURN FALSE; END IF; FOREACH entry IN ARRAY string_to_array(get_current_location_scope(), ',') LOOP IF split_part(entry, ':', 1) = location_id_param THEN RETURN COALESCE(array_position(roles, split_part(entry, ':', 2)) >= array_position(roles, min_role_param), FALSE); END IF; END LOOP; RETURN FALSE; END;" comment="Checks that the current member location scope grants at least the given role on a location"
+	_ int
+
+	// Function to check an area against the current location scope
+	//migrator:schema:function name="area_location_scope_allows" params="area_id_param TEXT, min_role_param TEXT" returns="BOOLEAN" language="plpgsql" volatility="STABLE" body="BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; RETURN location_scope_allows((SELECT location_id FROM areas WHERE id = area_id_param), min_role_param); END;" comment="Checks that the current member location scope grants at least the given role on the location of an area"
+	_ int
+
+	// Function to check a commodity against the current location scope
+	//migrator:schema:function name="commodity_location_scope_allows" params="commodity_id_param TEXT, min_role_param TEXT" returns="BOOLEAN" language="plpgsql" volatility="STABLE" body="BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; RETURN area_location_scope_allows((SELECT area_id FROM commodities WHERE id = commodity_id_param), min_role_param); END;" comment="Checks that the current member location scope grants at least the given role on the location of a commodity"
+	_ int
+
+	// Function to check a file's linked entity against the current location scope
+	//migrator:schema:function name="file_location_scope_allows" params="entity_type_param TEXT, entity_id_param TEXT, min_role_param TEXT" returns="BOOLEAN" language="plpgsql" volatility="STABLE" body="BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; CASE entity_type_param WHEN 'location' THEN RETURN location_scope_allows(entity_id_param, min_role_param); WHEN 'area' THEN RETURN area_location_scope_allows(entity_id_param, min_role_param); WHEN 'commodity' THEN RETURN commodity_location_scope_allows(entity_id_param, min_role_param); ELSE RETURN FALSE; END CASE; END;" comment="Checks that the current member location scope grants at least the given role on the location a file is linked to"
+	_ int
+}
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// IdempotencyKey records one client-supplied Idempotency-Key and the
+// response of the request that first used it. The API replays the stored
+// response when a retried POST carries the same key, so a flaky connection
+// cannot create the same commodity, loan or upload twice.
+//
+// A row starts as an in-flight reservation (StatusCode 0) with a short
+// expiry; completing it stores the response and extends ExpiresAt to the
+// configured TTL. An expired row no longer counts, and the cleanup worker
+// deletes it.
+//
+// Like RegistrationRequest the table has NO RLS policy: the middleware
+// resolves keys before the handler runs, and the cleanup worker sweeps
+// across tenants. user_id is a plain column (no FK); the user and tenant
+// purgers delete the rows.
+//
+//migrator:schema:table name="idempotency_keys"
+type IdempotencyKey struct {
+	//migrator:embedded mode="inline"
+	EntityID
+
+	//migrator:schema:field name="tenant_id" type="TEXT" not_null="true"
+	TenantID string `json:"tenant_id" db:"tenant_id"`
+
+	//migrator:schema:field name="user_id" type="TEXT" not_null="true"
+	UserID string `json:"user_id" db:"user_id"`
+
+	// Key is the Idempotency-Key header value, unique per user.
+	//migrator:schema:field name="idempotency_key" type="TEXT" not_null="true"
+	Key string `json:"idempotency_key" db:"idempotency_key"`
+
+	// RequestHash fingerprints the method, path and payload of the first
+	// request. A retry with the same key and a different hash is rejected.
+	//migrator:schema:field name="request_hash" type="TEXT" not_null="true"
+	RequestHash string `json:"request_hash" db:"request_hash"`
+
+	// StatusCode is the stored response status; 0 while the first request
+	// is still in flight.
+	//migrator:schema:field name="status_code" type="INTEGER" not_null="true" default="0"
+	StatusCode int `json:"status_code" db:"status_code"`
+
+	//migrator:schema:field name="content_type" type="TEXT" not_null="true" default="''"
+	ContentType string `json:"content_type" db:"content_type"`
+
+	//migrator:schema:field name="response_body" type="BYTEA"
+	ResponseBody []byte `json:"-" db:"response_body"`
+
+	//migrator:schema:field name="expires_at" type="TIMESTAMP" not_null="true"
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// IdempotencyKeyIndexes defines the PostgreSQL indexes for the
+// idempotency_keys table.
+type IdempotencyKeyIndexes struct {
+	// Unique index for the immutable UUID (mirrors the convention used
+	// elsewhere).
+	//migrator:schema:index name="idx_idempotency_keys_uuid" fields="uuid" unique="true" table="idempotency_keys"
+	_ int
+
+	// One record per user and key; backs the Reserve upsert.
+	//migrator:schema:index name="idx_idempotency_keys_user_key" fields="user_id,idempotency_key" unique="true" table="idempotency_keys"
+	_ int
+
+	// Backs the cleanup worker's expiry sweep.
+	//migrator:schema:index name="idx_idempotency_keys_expires_at" fields="expires_at" table="idempotency_keys"
+	_ int
+
+	// Backs the tenant purger.
+	//migrator:schema:index name="idx_idempotency_keys_tenant_id" fields="tenant_id" table="idempotency_keys"
+	_ int
+}
+
+// IsCompleted reports whether the first request finished and its response
+// is stored.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
+
+// IsExpired reports whether the record has passed its expiry time.
+func (k *IdempotencyKey) IsExpired() bool {
+	return time.Now().After(k.ExpiresAt)
+}
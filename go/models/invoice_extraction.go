@@ -0,0 +1,187 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models/rules"
+)
+
+var (
+	_ validation.Validatable            = (*InvoiceExtraction)(nil)
+	_ validation.ValidatableWithContext = (*InvoiceExtraction)(nil)
+	_ TenantGroupAwareIDable            = (*InvoiceExtraction)(nil)
+)
+
+// InvoiceExtractionStatus is the review state of an extraction.
+type InvoiceExtractionStatus string
+
+const (
+	// InvoiceExtractionStatusPending is a proposal waiting in the review
+	// queue.
+	InvoiceExtractionStatusPending InvoiceExtractionStatus = "pending"
+	// InvoiceExtractionStatusApplied means a reviewer approved the
+	// proposal and its changes were written to the commodity.
+	InvoiceExtractionStatusApplied InvoiceExtractionStatus = "applied"
+	// InvoiceExtractionStatusRejected means a reviewer dismissed the
+	// proposal; the commodity was left untouched.
+	InvoiceExtractionStatusRejected InvoiceExtractionStatus = "rejected"
+	// InvoiceExtractionStatusNoChanges means the document was read but
+	// everything it says already matches the commodity. Never queued.
+	InvoiceExtractionStatusNoChanges InvoiceExtractionStatus = "no_changes"
+	// InvoiceExtractionStatusFailed means the provider could not read
+	// the document (unsupported format, too large, unparseable reply).
+	// ErrorCode carries the scan error code.
+	InvoiceExtractionStatusFailed InvoiceExtractionStatus = "failed"
+)
+
+func (s InvoiceExtractionStatus) Validate() error {
+	return validation.Validate(string(s), validation.In(
+		string(InvoiceExtractionStatusPending),
+		string(InvoiceExtractionStatusApplied),
+		string(InvoiceExtractionStatusRejected),
+		string(InvoiceExtractionStatusNoChanges),
+		string(InvoiceExtractionStatusFailed),
+	))
+}
+
+// Commodity fields an extraction may propose a change for.
+const (
+	InvoiceExtractionFieldPurchaseDate          = "purchase_date"
+	InvoiceExtractionFieldOriginalPrice         = "original_price"
+	InvoiceExtractionFieldOriginalPriceCurrency = "original_price_currency"
+	InvoiceExtractionFieldComments              = "comments"
+)
+
+// InvoiceLineItem is one product line read off the document.
+type InvoiceLineItem struct {
+	Name     string           `json:"name"`
+	Price    *decimal.Decimal `json:"price,omitempty"`
+	Currency Currency         `json:"currency,omitempty"`
+}
+
+// InvoiceExtractionChange is one proposed commodity field update, with
+// both sides rendered as strings so the review queue can show a plain
+// before/after table.
+type InvoiceExtractionChange struct {
+	Field    string `json:"field"`
+	Current  string `json:"current"`
+	Proposed string `json:"proposed"`
+}
+
+// InvoiceExtraction is the result of running the AI vision provider over
+// an invoice or receipt already attached to a commodity. The extraction
+// job writes one row per file (the unique file_id index keeps it from
+// reading a document twice); rows with changes wait in the review queue
+// until a group member applies or rejects them.
+//
+// CreatedByUserID is the uploader of the file: the scan is attributed
+// to them in commodity_scan_audits and counts against their hourly
+// scan budget.
+//
+// Enable RLS for multi-tenant isolation.
+//
+//migrator:schema:rls:enable table="invoice_extractions" comment="Enable RLS for multi-tenant invoice extraction isolation"
+//migrator:schema:rls:policy name="invoice_extraction_isolation" table="invoice_extractions" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures invoice extractions can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="invoice_extraction_background_worker_access" table="invoice_extractions" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all invoice extractions for processing"
+//migrator:schema:table name="invoice_extractions"
+type InvoiceExtraction struct {
+	//migrator:embedded mode="inline"
+	TenantGroupAwareEntityID
+
+	// FileID is the invoice document that was read.
+	//migrator:schema:field name="file_id" type="TEXT" not_null="true" foreign="files(id)" foreign_key_name="fk_invoice_extraction_file" on_delete="CASCADE"
+	FileID string `json:"file_id" db:"file_id"`
+
+	// CommodityID is the commodity the file is linked to and the target
+	// of the proposed changes.
+	//migrator:schema:field name="commodity_id" type="TEXT" not_null="true" foreign="commodities(id)" foreign_key_name="fk_invoice_extraction_commodity" on_delete="CASCADE"
+	CommodityID string `json:"commodity_id" db:"commodity_id"`
+
+	//migrator:schema:field name="status" type="TEXT" not_null="true"
+	Status InvoiceExtractionStatus `json:"status" db:"status"`
+
+	//migrator:schema:field name="vendor" type="TEXT"
+	Vendor string `json:"vendor" db:"vendor"`
+
+	//migrator:schema:field name="purchase_date" type="TEXT"
+	PurchaseDate Date `json:"purchase_date" db:"purchase_date"`
+
+	//migrator:schema:field name="price" type="DECIMAL(15,2)"
+	Price *decimal.Decimal `json:"price,omitempty" db:"price"`
+
+	//migrator:schema:field name="currency" type="TEXT"
+	Currency Currency `json:"currency" db:"currency"`
+
+	// LineItems lists every product line on the document, so the
+	// reviewer can see which one the proposal was taken from.
+	//migrator:schema:field name="line_items" type="JSONB"
+	LineItems ValuerSlice[InvoiceLineItem] `json:"line_items" db:"line_items"`
+
+	// Changes is the proposed commodity update, computed against the
+	// commodity as it was when the document was read.
+	//migrator:schema:field name="changes" type="JSONB"
+	Changes ValuerSlice[InvoiceExtractionChange] `json:"changes" db:"changes"`
+
+	// ErrorCode is the commodity scan error code of a failed extraction.
+	//migrator:schema:field name="error_code" type="TEXT"
+	ErrorCode string `json:"error_code,omitempty" db:"error_code"`
+
+	//migrator:schema:field name="reviewed_by_user_id" type="TEXT"
+	ReviewedByUserID string `json:"reviewed_by_user_id,omitempty" db:"reviewed_by_user_id" userinput:"false"`
+
+	//migrator:schema:field name="reviewed_at" type="TIMESTAMP"
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at" userinput:"false"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at" userinput:"false"`
+}
+
+// InvoiceExtractionIndexes defines the postgres indexes for invoice_extractions.
+type InvoiceExtractionIndexes struct {
+	// Unique index for the immutable UUID (deduplication key for import/restore).
+	//migrator:schema:index name="idx_invoice_extractions_uuid" fields="uuid" unique="true" table="invoice_extractions"
+	_ int
+
+	// Index for tenant-based queries.
+	//migrator:schema:index name="idx_invoice_extractions_tenant_id" fields="tenant_id" table="invoice_extractions"
+	_ int
+
+	// Composite index for the review queue, which lists one status of
+	// one group.
+	//migrator:schema:index name="idx_invoice_extractions_tenant_group_status" fields="tenant_id,group_id,status" table="invoice_extractions"
+	_ int
+
+	// One extraction per file: the job skips files that already have a
+	// row.
+	//migrator:schema:index name="idx_invoice_extractions_file" fields="file_id" unique="true" table="invoice_extractions"
+	_ int
+}
+
+// Change returns the proposed change for field, or nil when the
+// extraction proposes none.
+func (e *InvoiceExtraction) Change(field string) *InvoiceExtractionChange {
+	for i := range e.Changes {
+		if e.Changes[i].Field == field {
+			return &e.Changes[i]
+		}
+	}
+	return nil
+}
+
+func (*InvoiceExtraction) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (e *InvoiceExtraction) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, e,
+		validation.Field(&e.TenantGroupAwareEntityID),
+		validation.Field(&e.FileID, rules.NotEmpty),
+		validation.Field(&e.CommodityID, rules.NotEmpty),
+		validation.Field(&e.Status, validation.Required),
+		validation.Field(&e.Vendor, validation.Length(0, 255)),
+	)
+}
@@ -15,13 +15,15 @@ var (
 
 // Enable RLS for multi-tenant isolation
 //migrator:schema:rls:enable table="locations" comment="Enable RLS for multi-tenant location isolation"
-//migrator:schema:rls:policy name="location_isolation" table="locations" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures locations can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="location_isolation" table="locations" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(id, 'viewer')" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(id, 'admin')" comment="Ensures locations can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations"
 //migrator:schema:rls:policy name="location_background_worker_access" table="locations" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all locations for processing"
 
 //migrator:schema:table name="locations"
 type Location struct {
 	//migrator:embedded mode="inline"
 	TenantGroupAwareEntityID
+	//migrator:embedded mode="inline"
+	EntityVersion
 	//migrator:schema:field name="name" type="TEXT" not_null="true"
 	Name string `json:"name" db:"name"`
 	//migrator:schema:field name="address" type="TEXT" not_null="true"
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jellydator/validation"
+)
+
+var (
+	_ validation.Validatable            = (*MaintenanceCalendarRule)(nil)
+	_ validation.ValidatableWithContext = (*MaintenanceCalendarRule)(nil)
+)
+
+// MaintenanceCalendarFrequency is the period a calendar rule repeats on.
+type MaintenanceCalendarFrequency string
+
+const (
+	MaintenanceCalendarMonthly MaintenanceCalendarFrequency = "monthly"
+	MaintenanceCalendarYearly  MaintenanceCalendarFrequency = "yearly"
+)
+
+// MaintenanceCalendarLastWeek selects the last matching weekday of the
+// month ("last Friday") in MaintenanceCalendarRule.Week.
+const MaintenanceCalendarLastWeek = -1
+
+var calendarWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// MaintenanceCalendarRule is the recurrence rule of a calendar-based
+// maintenance schedule. It covers the two shapes people actually use
+// for household and equipment care without pulling in a full RRULE
+// implementation:
+//
+//   - a fixed day of the period: "every 3 months on the 15th",
+//     "yearly on March 1st" (Day, plus Month for yearly rules);
+//   - the n-th weekday of the month: "every first Monday",
+//     "last Friday of October" (Weekday + Week, plus Month for yearly
+//     rules).
+//
+// Days past the end of a short month are clamped to its last day, so
+// "monthly on the 31st" lands on Feb 28/29. Stored as JSONB on
+// maintenance_schedules.calendar_rule.
+type MaintenanceCalendarRule struct {
+	Frequency MaintenanceCalendarFrequency `json:"frequency"`
+	// Every is the step between occurrences in units of Frequency.
+	// Zero is treated as 1.
+	Every int `json:"every,omitempty"`
+	// Month (1-12) pins yearly rules to a month. Must be unset for
+	// monthly rules.
+	Month int `json:"month,omitempty"`
+	// Day (1-31) is the day of the month. Mutually exclusive with
+	// Weekday.
+	Day int `json:"day,omitempty"`
+	// Weekday is a lowercase English weekday name ("monday").
+	Weekday string `json:"weekday,omitempty"`
+	// Week picks which Weekday of the month: 1-4, or -1 for the last.
+	Week int `json:"week,omitempty"`
+}
+
+// Value implements driver.Valuer so the rule can be written to a JSONB
+// column.
+func (r MaintenanceCalendarRule) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements sql.Scanner for the JSONB `calendar_rule` column.
+func (r *MaintenanceCalendarRule) Scan(value any) error {
+	if value == nil {
+		*r = MaintenanceCalendarRule{}
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return fmt.Errorf("cannot scan %T into MaintenanceCalendarRule", value)
+	}
+}
+
+func (MaintenanceCalendarRule) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (r MaintenanceCalendarRule) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, &r,
+		validation.Field(&r.Frequency, validation.Required, validation.In(MaintenanceCalendarMonthly, MaintenanceCalendarYearly)),
+		validation.Field(&r.Every, validation.Min(0), validation.Max(100)),
+		validation.Field(&r.Month, validation.By(func(any) error {
+			switch {
+			case r.Frequency == MaintenanceCalendarYearly && (r.Month < 1 || r.Month > 12):
+				return validation.NewError("calendar_rule_month_required", "month must be between 1 and 12 for yearly rules")
+			case r.Frequency == MaintenanceCalendarMonthly && r.Month != 0:
+				return validation.NewError("calendar_rule_month_not_allowed", "month must not be set for monthly rules")
+			}
+			return nil
+		})),
+		validation.Field(&r.Day, validation.Min(0), validation.Max(31), validation.By(func(any) error {
+			if (r.Day == 0) == (r.Weekday == "") {
+				return validation.NewError("calendar_rule_day_or_weekday", "exactly one of day or weekday must be set")
+			}
+			return nil
+		})),
+		validation.Field(&r.Weekday, validation.By(func(any) error {
+			if r.Weekday == "" {
+				return nil
+			}
+			if _, ok := calendarWeekdays[r.Weekday]; !ok {
+				return validation.NewError("calendar_rule_weekday", "weekday must be a lowercase English weekday name")
+			}
+			return nil
+		})),
+		validation.Field(&r.Week, validation.By(func(any) error {
+			if r.Weekday == "" {
+				if r.Week != 0 {
+					return validation.NewError("calendar_rule_week_not_allowed", "week requires weekday")
+				}
+				return nil
+			}
+			if r.Week != MaintenanceCalendarLastWeek && (r.Week < 1 || r.Week > 4) {
+				return validation.NewError("calendar_rule_week", "week must be between 1 and 4, or -1 for the last week")
+			}
+			return nil
+		})),
+	)
+}
+
+// Next returns the first occurrence of the rule strictly after the
+// given day (compared at day granularity, UTC). Periods are counted
+// from the one containing `after`, so "every 3 months on the 1st"
+// marked done on May 10 next falls on August 1st.
+func (r MaintenanceCalendarRule) Next(after time.Time) time.Time {
+	a := after.UTC()
+	day := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	step := max(r.Every, 1)
+
+	year, month := day.Year(), day.Month()
+	if r.Frequency == MaintenanceCalendarYearly {
+		month = time.Month(r.Month)
+	}
+	for {
+		candidate := r.occurrenceIn(year, month)
+		if candidate.After(day) {
+			return candidate
+		}
+		if r.Frequency == MaintenanceCalendarYearly {
+			year += step
+			continue
+		}
+		// Normalise through time.Date so month overflow rolls the year.
+		t := time.Date(year, month+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		year, month = t.Year(), t.Month()
+	}
+}
+
+// occurrenceIn returns the rule's day within the given month.
+func (r MaintenanceCalendarRule) occurrenceIn(year int, month time.Month) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	if r.Weekday == "" {
+		return time.Date(year, month, min(max(r.Day, 1), daysInMonth), 0, 0, 0, 0, time.UTC)
+	}
+
+	weekday := calendarWeekdays[r.Weekday]
+	if r.Week == MaintenanceCalendarLastWeek {
+		last := time.Date(year, month, daysInMonth, 0, 0, 0, 0, time.UTC)
+		offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -offset)
+	}
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(max(r.Week, 1)-1))
+}
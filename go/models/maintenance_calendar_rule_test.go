@@ -0,0 +1,147 @@
+package models_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+func TestMaintenanceCalendarRule_Next(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rule  models.MaintenanceCalendarRule
+		after string
+		want  string
+	}{
+		{
+			name:  "first monday of next month",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Weekday: "monday", Week: 1},
+			after: "2026-10-18",
+			want:  "2026-11-02",
+		},
+		{
+			name:  "first monday later this month",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Weekday: "monday", Week: 1},
+			after: "2026-10-04",
+			want:  "2026-10-05",
+		},
+		{
+			name:  "last friday",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Weekday: "friday", Week: models.MaintenanceCalendarLastWeek},
+			after: "2026-10-18",
+			want:  "2026-10-30",
+		},
+		{
+			name:  "occurrence day itself is skipped",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Day: 18},
+			after: "2026-10-18",
+			want:  "2026-11-18",
+		},
+		{
+			name:  "31st clamps to end of february",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Day: 31},
+			after: "2027-01-31",
+			want:  "2027-02-28",
+		},
+		{
+			name:  "every three months",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Every: 3, Day: 15},
+			after: "2026-10-18",
+			want:  "2027-01-15",
+		},
+		{
+			name:  "yearly on a fixed day",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarYearly, Month: 3, Day: 1},
+			after: "2026-10-18",
+			want:  "2027-03-01",
+		},
+		{
+			name:  "yearly on february 29 in a common year",
+			rule:  models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarYearly, Month: 2, Day: 29},
+			after: "2026-03-01",
+			want:  "2027-02-28",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := qt.New(t)
+			after, err := time.Parse("2006-01-02", tc.after)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tc.rule.Next(after).Format("2006-01-02"), qt.Equals, tc.want)
+		})
+	}
+}
+
+func TestMaintenanceCalendarRule_ValidateWithContext(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    models.MaintenanceCalendarRule
+		wantErr bool
+	}{
+		{
+			name: "monthly on a day",
+			rule: models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Day: 15},
+		},
+		{
+			name: "yearly on the last sunday of march",
+			rule: models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarYearly, Month: 3, Weekday: "sunday", Week: -1},
+		},
+		{
+			name:    "unknown frequency",
+			rule:    models.MaintenanceCalendarRule{Frequency: "weekly", Day: 1},
+			wantErr: true,
+		},
+		{
+			name:    "yearly without month",
+			rule:    models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarYearly, Day: 1},
+			wantErr: true,
+		},
+		{
+			name:    "monthly with month",
+			rule:    models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Month: 4, Day: 1},
+			wantErr: true,
+		},
+		{
+			name:    "neither day nor weekday",
+			rule:    models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly},
+			wantErr: true,
+		},
+		{
+			name:    "both day and weekday",
+			rule:    models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Day: 1, Weekday: "monday", Week: 1},
+			wantErr: true,
+		},
+		{
+			name:    "unknown weekday",
+			rule:    models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Weekday: "funday", Week: 1},
+			wantErr: true,
+		},
+		{
+			name:    "fifth week",
+			rule:    models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Weekday: "monday", Week: 5},
+			wantErr: true,
+		},
+		{
+			name:    "week without weekday",
+			rule:    models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Day: 1, Week: 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := qt.New(t)
+			err := tc.rule.ValidateWithContext(context.Background())
+			if tc.wantErr {
+				c.Assert(err, qt.IsNotNil)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/currency"
+
+	"github.com/denisvmedia/inventario/models/rules"
+)
+
+var (
+	_ validation.Validatable            = (*MaintenanceLog)(nil)
+	_ validation.ValidatableWithContext = (*MaintenanceLog)(nil)
+	_ TenantGroupAwareIDable            = (*MaintenanceLog)(nil)
+)
+
+// MaintenanceLog is one completion of a maintenance schedule — the
+// service history of an item. A row is written every time the user
+// marks a schedule done, so the schedule itself only has to carry the
+// forward-looking state (next due date / meter) while the history is
+// kept here.
+//
+// Cost follows the commodity_services convention: amount and ISO 4217
+// currency are optional but locked together. FileIDs references files
+// already uploaded to the group (receipts, photos of the work); the
+// service layer checks they exist before the row is written.
+//
+// Enable RLS for multi-tenant isolation.
+//
+//migrator:schema:rls:enable table="maintenance_logs" comment="Enable RLS for multi-tenant maintenance log isolation"
+//migrator:schema:rls:policy name="maintenance_log_isolation" table="maintenance_logs" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures maintenance logs can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="maintenance_log_background_worker_access" table="maintenance_logs" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all maintenance logs for processing"
+//migrator:schema:table name="maintenance_logs"
+type MaintenanceLog struct {
+	//migrator:embedded mode="inline"
+	TenantGroupAwareEntityID
+
+	// ScheduleID is the completed schedule. ON DELETE CASCADE is added
+	// manually to the generated migration: deleting a schedule drops
+	// its history with it.
+	//migrator:schema:field name="schedule_id" type="TEXT" not_null="true" foreign="maintenance_schedules(id)" foreign_key_name="fk_maintenance_log_schedule" on_delete="CASCADE"
+	ScheduleID string `json:"schedule_id" db:"schedule_id"`
+
+	// CommodityID is denormalised from the schedule so the per-item
+	// history can be read without a join. ON DELETE CASCADE as above.
+	//migrator:schema:field name="commodity_id" type="TEXT" not_null="true" foreign="commodities(id)" foreign_key_name="fk_maintenance_log_commodity" on_delete="CASCADE"
+	CommodityID string `json:"commodity_id" db:"commodity_id"`
+
+	// DoneAt is the date the work was performed (YYYY-MM-DD).
+	//migrator:schema:field name="done_at" type="TEXT" not_null="true"
+	DoneAt Date `json:"done_at" db:"done_at"`
+
+	// MeterValue is the meter reading at completion for usage-based
+	// schedules; nil otherwise.
+	//migrator:schema:field name="meter_value" type="DECIMAL(14,2)"
+	MeterValue *decimal.Decimal `json:"meter_value,omitempty" db:"meter_value"`
+
+	//migrator:schema:field name="notes" type="TEXT"
+	Notes string `json:"notes" db:"notes"`
+
+	// CostAmount / CostCurrency — see CommodityService for the
+	// zero-means-unset and pair-validation conventions.
+	//migrator:schema:field name="cost_amount" type="DECIMAL(14,2)"
+	CostAmount decimal.Decimal `json:"cost_amount" db:"cost_amount"`
+
+	//migrator:schema:field name="cost_currency" type="TEXT"
+	CostCurrency string `json:"cost_currency" db:"cost_currency"`
+
+	// FileIDs are the attached files (receipts, photos).
+	//migrator:schema:field name="file_ids" type="JSONB"
+	FileIDs ValuerSlice[string] `json:"file_ids" db:"file_ids"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at" userinput:"false"`
+}
+
+// MaintenanceLogIndexes defines the postgres indexes for maintenance_logs.
+type MaintenanceLogIndexes struct {
+	// Unique index for the immutable UUID (deduplication key for import/restore).
+	//migrator:schema:index name="idx_maintenance_logs_uuid" fields="uuid" unique="true" table="maintenance_logs"
+	_ int
+
+	// Index for tenant-based queries.
+	//migrator:schema:index name="idx_maintenance_logs_tenant_id" fields="tenant_id" table="maintenance_logs"
+	_ int
+
+	// Composite index for tenant+group RLS-filtered queries.
+	//migrator:schema:index name="idx_maintenance_logs_tenant_group" fields="tenant_id,group_id" table="maintenance_logs"
+	_ int
+
+	// Composite index for the per-schedule history, newest first.
+	//migrator:schema:index name="idx_maintenance_logs_schedule" fields="schedule_id,done_at" table="maintenance_logs"
+	_ int
+
+	// Index for per-commodity history reads.
+	//migrator:schema:index name="idx_maintenance_logs_commodity" fields="commodity_id,done_at" table="maintenance_logs"
+	_ int
+}
+
+// HasCost reports whether a cost has been recorded.
+func (l *MaintenanceLog) HasCost() bool {
+	return !l.CostAmount.IsZero() || l.CostCurrency != ""
+}
+
+func (*MaintenanceLog) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (l *MaintenanceLog) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, l,
+		validation.Field(&l.TenantGroupAwareEntityID),
+		validation.Field(&l.ScheduleID, rules.NotEmpty),
+		validation.Field(&l.CommodityID, rules.NotEmpty),
+		validation.Field(&l.DoneAt, validation.Required),
+		validation.Field(&l.MeterValue, validation.By(func(any) error {
+			if l.MeterValue != nil && l.MeterValue.IsNegative() {
+				return validation.NewError("meter_value_negative", "meter_value must not be negative")
+			}
+			return nil
+		})),
+		validation.Field(&l.Notes, validation.Length(0, 1000)),
+		validation.Field(&l.CostAmount, validation.By(func(any) error {
+			amountSet := !l.CostAmount.IsZero()
+			currencySet := l.CostCurrency != ""
+			if amountSet != currencySet {
+				return validation.NewError("cost_currency_pair_required",
+					"cost_amount and cost_currency must be set together")
+			}
+			if currencySet {
+				if _, err := currency.ParseISO(l.CostCurrency); err != nil {
+					return validation.NewError("cost_currency_iso_4217",
+						"cost_currency must be a valid ISO 4217 code")
+				}
+			}
+			return nil
+		})),
+		validation.Field(&l.FileIDs, validation.Length(0, 20), validation.Each(rules.NotEmpty)),
+	)
+}
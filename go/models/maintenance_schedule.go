@@ -2,9 +2,11 @@ package models
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models/rules"
 )
@@ -15,6 +17,23 @@ var (
 	_ TenantGroupAwareIDable            = (*MaintenanceSchedule)(nil)
 )
 
+// MaintenanceRecurrence is how a maintenance schedule repeats.
+type MaintenanceRecurrence string
+
+const (
+	MaintenanceRecurrenceInterval MaintenanceRecurrence = "interval"
+	MaintenanceRecurrenceCalendar MaintenanceRecurrence = "calendar"
+	MaintenanceRecurrenceUsage    MaintenanceRecurrence = "usage"
+)
+
+func (r MaintenanceRecurrence) Validate() error {
+	return validation.Validate(string(r), validation.In(
+		string(MaintenanceRecurrenceInterval),
+		string(MaintenanceRecurrenceCalendar),
+		string(MaintenanceRecurrenceUsage),
+	))
+}
+
 // MaintenanceSchedule is a per-commodity recurring care reminder
 // (#1368). The user creates a row like "Replace water filter every 180
 // days" and the reminder worker fires emails at 14 / 7 / 1 days before
@@ -23,13 +42,22 @@ var (
 //
 // The mental model intentionally mirrors warranty tracking
 // (one-shot, "when does coverage end") — see #1367 — but on a recurring
-// cadence. v1 stores a single fixed interval in days; cron-like
-// recurrence is deliberately out of scope (issue body §Options 1).
+// cadence. Recurrence selects how the cadence is expressed:
 //
-// Marking a schedule done advances next_due_at by IntervalDays from the
-// supplied done date (server clock by default). The same idempotency
-// pattern as warranty reminders is reused via the maintenance_reminders
-// table: at most one row per (schedule_id, threshold_days) tuple.
+//   - interval: a fixed number of days after the last completion;
+//   - calendar: a MaintenanceCalendarRule ("every first Monday",
+//     "yearly on March 1st");
+//   - usage: every MeterInterval units of a commodity meter (hours, km,
+//     cycles), optionally capped by IntervalDays ("every 10 000 km or
+//     12 months, whichever comes first"). NextDueAt is then an estimate
+//     derived from the commodity's meter readings and may be empty
+//     until there are enough readings to extrapolate.
+//
+// Marking a schedule done writes a MaintenanceLog row and advances
+// next_due_at (and next_due_meter for usage schedules). The same
+// idempotency pattern as warranty reminders is reused via the
+// maintenance_reminders table: at most one row per (schedule_id,
+// threshold_days) tuple.
 //
 // Enable RLS for multi-tenant isolation.
 //
@@ -54,18 +82,44 @@ type MaintenanceSchedule struct {
 	//migrator:schema:field name="title" type="TEXT" not_null="true"
 	Title string `json:"title" db:"title"`
 
-	// IntervalDays is the fixed cadence in days. v1 keeps this as a
-	// plain integer — cron-like recurrence is deliberately out of
-	// scope (#1368 options §1). Validated to be strictly positive: a
+	// Recurrence selects how NextDueAt is derived — see the type-level
+	// comment. Rows created before calendar and usage recurrence
+	// existed default to "interval".
+	//migrator:schema:field name="recurrence" type="TEXT" not_null="true" default="interval"
+	Recurrence MaintenanceRecurrence `json:"recurrence" db:"recurrence"`
+
+	// IntervalDays is the fixed cadence in days for interval
+	// schedules, where it is validated to be strictly positive: a
 	// non-positive interval would either spam reminders (0) or make
-	// next_due_at recede (negative).
+	// next_due_at recede (negative). For usage schedules it is an
+	// optional time cap (0 = none); calendar schedules ignore it.
 	//migrator:schema:field name="interval_days" type="INTEGER" not_null="true"
 	IntervalDays int `json:"interval_days" db:"interval_days"`
 
+	// CalendarRule is the recurrence rule of calendar schedules; nil
+	// for the other kinds.
+	//migrator:schema:field name="calendar_rule" type="JSONB"
+	CalendarRule *MaintenanceCalendarRule `json:"calendar_rule,omitempty" db:"calendar_rule"`
+
+	// MeterUnit, MeterInterval and NextDueMeter describe usage
+	// schedules: the schedule is due once the commodity's latest
+	// reading in MeterUnit reaches NextDueMeter, which advances by
+	// MeterInterval on every completion. Zero / empty for the other
+	// kinds.
+	//migrator:schema:field name="meter_unit" type="TEXT"
+	MeterUnit MeterUnit `json:"meter_unit,omitempty" db:"meter_unit"`
+
+	//migrator:schema:field name="meter_interval" type="DECIMAL(14,2)" not_null="true" default="0"
+	MeterInterval decimal.Decimal `json:"meter_interval" db:"meter_interval"`
+
+	//migrator:schema:field name="next_due_meter" type="DECIMAL(14,2)" not_null="true" default="0"
+	NextDueMeter decimal.Decimal `json:"next_due_meter" db:"next_due_meter"`
+
 	// NextDueAt is the date the next instance is due. Stored as TEXT
 	// in YYYY-MM-DD format to match the codebase's other date fields
 	// (lent_at, sent_at, warranty_expires_at). Recomputed on every
-	// MarkDone call as `done_date + interval_days`.
+	// MarkDone call from the recurrence. Usage schedules store the
+	// estimated date, or "" when no estimate is possible yet.
 	//migrator:schema:field name="next_due_at" type="TEXT" not_null="true"
 	NextDueAt Date `json:"next_due_at" db:"next_due_at"`
 
@@ -168,14 +222,154 @@ func (m *MaintenanceSchedule) IsOverdue(now time.Time) bool {
 	return due.Before(today)
 }
 
-// AdvanceFromDone returns the new NextDueAt computed from a done date
-// plus the row's IntervalDays. Pure function on the row — the caller
-// is responsible for writing the result back via the registry.
+// Kind returns the schedule's recurrence, treating the empty value of
+// rows that predate the column as interval.
+func (m *MaintenanceSchedule) Kind() MaintenanceRecurrence {
+	if m.Recurrence == "" {
+		return MaintenanceRecurrenceInterval
+	}
+	return m.Recurrence
+}
+
+// AdvanceFromDone returns the new NextDueAt for a completion on
+// doneDate. Pure function on the row — the caller is responsible for
+// writing the result back via the registry.
+//
+//   - interval: doneDate + IntervalDays;
+//   - calendar: the first rule occurrence after the later of doneDate
+//     and the current NextDueAt, so doing the job a few days early
+//     still moves the schedule to the following period;
+//   - usage: the time cap (doneDate + IntervalDays), or "" without a
+//     cap. The meter-based estimate is layered on top by
+//     EstimateUsage.
 func (m *MaintenanceSchedule) AdvanceFromDone(doneDate time.Time) Date {
-	d := doneDate.UTC()
-	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
-	next := day.AddDate(0, 0, m.IntervalDays)
-	return Date(next.Format("2006-01-02"))
+	day := truncateToDay(doneDate)
+	switch m.Kind() {
+	case MaintenanceRecurrenceCalendar:
+		if m.CalendarRule == nil {
+			return ""
+		}
+		after := day
+		if due := m.NextDueAt.ToTime(); m.NextDueAt != "" && due.After(after) {
+			after = due
+		}
+		return Date(m.CalendarRule.Next(after).Format("2006-01-02"))
+	case MaintenanceRecurrenceUsage:
+		if m.IntervalDays <= 0 {
+			return ""
+		}
+	}
+	return Date(day.AddDate(0, 0, m.IntervalDays).Format("2006-01-02"))
+}
+
+// usageWindowDays is how far back from the latest reading the usage
+// estimator looks when computing the meter rate. A year smooths out
+// seasonal use (a lawn mower's summer) without letting a long-gone
+// usage pattern dominate.
+const usageWindowDays = 365
+
+// MaintenanceUsageEstimate is the outcome of EstimateUsage.
+type MaintenanceUsageEstimate struct {
+	// LatestMeter / LatestReadAt describe the most recent reading in
+	// the schedule's unit; nil / "" without readings.
+	LatestMeter  *decimal.Decimal
+	LatestReadAt Date
+	// MeterReached is true once the latest reading is at or past
+	// NextDueMeter — the schedule is due regardless of dates.
+	MeterReached bool
+	// DueAt is the estimated due date: the earlier of the
+	// extrapolated meter date and the IntervalDays time cap. "" when
+	// neither is available (no usable readings and no cap).
+	DueAt Date
+}
+
+// EstimateUsage estimates when a usage schedule falls due from the
+// commodity's meter readings. Readings in other units are ignored.
+// The rate is the meter increase per day between the oldest and the
+// latest reading within usageWindowDays of the latest one; the date
+// the meter reaches NextDueMeter is extrapolated from the latest
+// reading at that rate. The time cap counts from LastDoneAt, or from
+// the row's creation (`now` for rows not yet persisted).
+func (m *MaintenanceSchedule) EstimateUsage(readings []*CommodityMeterReading, now time.Time) MaintenanceUsageEstimate {
+	var est MaintenanceUsageEstimate
+
+	var own []*CommodityMeterReading
+	for _, r := range readings {
+		if r != nil && r.Unit == m.MeterUnit && r.ReadAt != "" {
+			own = append(own, r)
+		}
+	}
+	sort.SliceStable(own, func(i, j int) bool {
+		if own[i].ReadAt != own[j].ReadAt {
+			return own[i].ReadAt < own[j].ReadAt
+		}
+		return own[i].Value.LessThan(own[j].Value)
+	})
+
+	var meterDue time.Time
+	if len(own) > 0 {
+		latest := own[len(own)-1]
+		latestAt := latest.ReadAt.ToTime()
+		est.LatestMeter = &latest.Value
+		est.LatestReadAt = latest.ReadAt
+
+		if latest.Value.GreaterThanOrEqual(m.NextDueMeter) {
+			est.MeterReached = true
+			meterDue = latestAt
+		} else if rate := usageRate(own, latestAt); rate.IsPositive() {
+			remaining := m.NextDueMeter.Sub(latest.Value)
+			days := remaining.Div(rate).Ceil().IntPart()
+			meterDue = latestAt.AddDate(0, 0, int(days))
+		}
+	}
+
+	var capDue time.Time
+	if m.IntervalDays > 0 {
+		start := now
+		switch {
+		case m.LastDoneAt != nil && *m.LastDoneAt != "":
+			start = m.LastDoneAt.ToTime()
+		case !m.CreatedAt.IsZero():
+			start = m.CreatedAt
+		}
+		capDue = truncateToDay(start).AddDate(0, 0, m.IntervalDays)
+	}
+
+	due := meterDue
+	if due.IsZero() || (!capDue.IsZero() && capDue.Before(due)) {
+		due = capDue
+	}
+	if !due.IsZero() {
+		est.DueAt = Date(due.Format("2006-01-02"))
+	}
+	return est
+}
+
+// usageRate returns the per-day meter increase across the readings
+// (sorted by date) that fall within usageWindowDays of latestAt.
+func usageRate(sorted []*CommodityMeterReading, latestAt time.Time) decimal.Decimal {
+	windowStart := latestAt.AddDate(0, 0, -usageWindowDays)
+	var first *CommodityMeterReading
+	for _, r := range sorted {
+		if !r.ReadAt.ToTime().Before(windowStart) {
+			first = r
+			break
+		}
+	}
+	latest := sorted[len(sorted)-1]
+	if first == nil || first == latest {
+		return decimal.Zero
+	}
+	days := int64(latestAt.Sub(first.ReadAt.ToTime()).Hours() / 24)
+	if days <= 0 {
+		return decimal.Zero
+	}
+	return latest.Value.Sub(first.Value).Div(decimal.NewFromInt(days))
+}
+
+func truncateToDay(t time.Time) time.Time {
+	u := t.UTC()
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
 }
 
 func (*MaintenanceSchedule) Validate() error {
@@ -191,8 +385,50 @@ func (m *MaintenanceSchedule) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&m.TenantGroupAwareEntityID),
 		validation.Field(&m.CommodityID, rules.NotEmpty),
 		validation.Field(&m.Title, rules.NotEmpty, validation.Length(1, 200)),
-		validation.Field(&m.IntervalDays, validation.Required, validation.Min(1), validation.Max(36500)),
-		validation.Field(&m.NextDueAt, validation.Required),
+		validation.Field(&m.Recurrence),
+		validation.Field(&m.IntervalDays, validation.Min(0), validation.Max(36500), validation.By(func(any) error {
+			if m.Kind() == MaintenanceRecurrenceInterval && m.IntervalDays < 1 {
+				return validation.NewError("interval_days_required", "interval_days is required for interval schedules")
+			}
+			return nil
+		})),
+		validation.Field(&m.CalendarRule, validation.By(func(any) error {
+			if m.Kind() == MaintenanceRecurrenceCalendar && m.CalendarRule == nil {
+				return validation.NewError("calendar_rule_required", "calendar_rule is required for calendar schedules")
+			}
+			if m.Kind() != MaintenanceRecurrenceCalendar && m.CalendarRule != nil {
+				return validation.NewError("calendar_rule_not_allowed", "calendar_rule is only allowed for calendar schedules")
+			}
+			return nil
+		})),
+		validation.Field(&m.MeterUnit, validation.By(func(any) error {
+			if m.Kind() == MaintenanceRecurrenceUsage && m.MeterUnit == "" {
+				return validation.NewError("meter_unit_required", "meter_unit is required for usage schedules")
+			}
+			return nil
+		})),
+		validation.Field(&m.MeterInterval, validation.By(func(any) error {
+			if m.Kind() == MaintenanceRecurrenceUsage && !m.MeterInterval.IsPositive() {
+				return validation.NewError("meter_interval_required", "meter_interval must be positive for usage schedules")
+			}
+			return nil
+		})),
+		validation.Field(&m.NextDueMeter, validation.By(func(any) error {
+			if m.NextDueMeter.IsNegative() {
+				return validation.NewError("next_due_meter_negative", "next_due_meter must not be negative")
+			}
+			return nil
+		})),
+		// Usage schedules may have no date estimate yet.
+		validation.Field(&m.NextDueAt, validation.By(func(any) error {
+			if m.NextDueAt == "" {
+				if m.Kind() == MaintenanceRecurrenceUsage {
+					return nil
+				}
+				return validation.ErrRequired
+			}
+			return m.NextDueAt.ValidateWithContext(ctx)
+		})),
 		validation.Field(&m.LastDoneAt),
 		validation.Field(&m.Notes, validation.Length(0, 1000)),
 	)
@@ -0,0 +1,107 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+func meterReading(unit models.MeterUnit, readAt string, value int64) *models.CommodityMeterReading {
+	return &models.CommodityMeterReading{Unit: unit, ReadAt: models.Date(readAt), Value: decimal.NewFromInt(value)}
+}
+
+func TestMaintenanceSchedule_EstimateUsage(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	readings := []*models.CommodityMeterReading{
+		meterReading(models.MeterUnitKm, "2026-07-01", 12000),
+		meterReading(models.MeterUnitKm, "2026-01-01", 10000),
+		// Another meter of the same item must not skew the rate.
+		meterReading(models.MeterUnitHours, "2026-07-01", 900000),
+	}
+
+	t.Run("extrapolates the recent rate", func(t *testing.T) {
+		c := qt.New(t)
+		m := models.MaintenanceSchedule{
+			Recurrence:   models.MaintenanceRecurrenceUsage,
+			MeterUnit:    models.MeterUnitKm,
+			NextDueMeter: decimal.NewFromInt(15000),
+		}
+		est := m.EstimateUsage(readings, now)
+		c.Assert(est.MeterReached, qt.IsFalse)
+		c.Assert(est.LatestMeter, qt.IsNotNil)
+		c.Assert(est.LatestMeter.String(), qt.Equals, "12000")
+		c.Assert(string(est.LatestReadAt), qt.Equals, "2026-07-01")
+		// 2000 km over 181 days; 3000 km to go is 271.5 days.
+		c.Assert(string(est.DueAt), qt.Equals, "2027-03-30")
+	})
+
+	t.Run("time cap wins when earlier", func(t *testing.T) {
+		c := qt.New(t)
+		lastDone := models.Date("2026-06-01")
+		m := models.MaintenanceSchedule{
+			Recurrence:   models.MaintenanceRecurrenceUsage,
+			MeterUnit:    models.MeterUnitKm,
+			NextDueMeter: decimal.NewFromInt(15000),
+			IntervalDays: 180,
+			LastDoneAt:   &lastDone,
+		}
+		est := m.EstimateUsage(readings, now)
+		c.Assert(string(est.DueAt), qt.Equals, "2026-11-28")
+	})
+
+	t.Run("meter reached", func(t *testing.T) {
+		c := qt.New(t)
+		m := models.MaintenanceSchedule{
+			Recurrence:   models.MaintenanceRecurrenceUsage,
+			MeterUnit:    models.MeterUnitKm,
+			NextDueMeter: decimal.NewFromInt(12000),
+		}
+		est := m.EstimateUsage(readings, now)
+		c.Assert(est.MeterReached, qt.IsTrue)
+		c.Assert(string(est.DueAt), qt.Equals, "2026-07-01")
+	})
+
+	t.Run("single reading gives no estimate", func(t *testing.T) {
+		c := qt.New(t)
+		m := models.MaintenanceSchedule{
+			Recurrence:   models.MaintenanceRecurrenceUsage,
+			MeterUnit:    models.MeterUnitKm,
+			NextDueMeter: decimal.NewFromInt(15000),
+		}
+		est := m.EstimateUsage(readings[:1], now)
+		c.Assert(est.MeterReached, qt.IsFalse)
+		c.Assert(string(est.DueAt), qt.Equals, "")
+	})
+}
+
+func TestMaintenanceSchedule_AdvanceFromDone(t *testing.T) {
+	done := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+
+	t.Run("interval", func(t *testing.T) {
+		c := qt.New(t)
+		m := models.MaintenanceSchedule{IntervalDays: 90}
+		c.Assert(string(m.AdvanceFromDone(done)), qt.Equals, "2027-01-16")
+	})
+
+	t.Run("calendar", func(t *testing.T) {
+		c := qt.New(t)
+		m := models.MaintenanceSchedule{
+			Recurrence:   models.MaintenanceRecurrenceCalendar,
+			CalendarRule: &models.MaintenanceCalendarRule{Frequency: models.MaintenanceCalendarMonthly, Weekday: "monday", Week: 1},
+			NextDueAt:    "2026-11-02",
+		}
+		// Completing early must not land on the still-pending
+		// occurrence again.
+		c.Assert(string(m.AdvanceFromDone(done)), qt.Equals, "2026-12-07")
+	})
+
+	t.Run("usage without a time cap", func(t *testing.T) {
+		c := qt.New(t)
+		m := models.MaintenanceSchedule{Recurrence: models.MaintenanceRecurrenceUsage, MeterUnit: models.MeterUnitHours}
+		c.Assert(string(m.AdvanceFromDone(done)), qt.Equals, "")
+	})
+}
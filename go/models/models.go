@@ -273,12 +273,14 @@ func (s StringSlice) Value() (driver.Value, error) {
 // Enable RLS for multi-tenant isolation
 //
 //migrator:schema:rls:enable table="files" comment="Enable RLS for multi-tenant file isolation"
-//migrator:schema:rls:policy name="file_isolation" table="files" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures files can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="file_isolation" table="files" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND file_location_scope_allows(linked_entity_type, linked_entity_id, 'viewer')" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND file_location_scope_allows(linked_entity_type, linked_entity_id, 'user')" comment="Ensures files can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations"
 //migrator:schema:rls:policy name="file_background_worker_access" table="files" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all files for processing"
 //migrator:schema:table name="files"
 type FileEntity struct {
 	//migrator:embedded mode="inline"
 	TenantGroupAwareEntityID
+	//migrator:embedded mode="inline"
+	EntityVersion
 
 	// Title is the user-defined title for the file
 	//migrator:schema:field name="title" type="TEXT"
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jellydator/validation"
+)
+
+// RegistrationRequestStatus is the review state of a self-service
+// registration in a RegistrationModeApproval tenant.
+type RegistrationRequestStatus string
+
+const (
+	// RegistrationRequestStatusPending is a registration waiting in the
+	// approval queue. The user row exists but is inactive.
+	RegistrationRequestStatusPending RegistrationRequestStatus = "pending"
+	// RegistrationRequestStatusApproved means an operator approved the
+	// registration and the user was activated.
+	RegistrationRequestStatusApproved RegistrationRequestStatus = "approved"
+	// RegistrationRequestStatusRejected means an operator turned the
+	// registration down; the user stays inactive.
+	RegistrationRequestStatusRejected RegistrationRequestStatus = "rejected"
+)
+
+func (s RegistrationRequestStatus) Validate() error {
+	return validation.Validate(string(s), validation.In(
+		string(RegistrationRequestStatusPending),
+		string(RegistrationRequestStatusApproved),
+		string(RegistrationRequestStatusRejected),
+	))
+}
+
+// RegistrationRequest is one entry of the registration approval queue.
+// POST /register creates it next to the (inactive) user when the tenant
+// runs in RegistrationModeApproval; an operator then approves or rejects
+// it from the back office or the `inventario users registrations` CLI.
+// Decided rows are kept as the decision record.
+//
+// Like CommodityScanBudget the table has NO RLS policy: the queue is read
+// and decided by platform operators across tenants. user_id is a plain
+// column (no FK); the user and tenant purgers delete the row.
+//
+//migrator:schema:table name="registration_requests"
+type RegistrationRequest struct {
+	//migrator:embedded mode="inline"
+	EntityID
+
+	// TenantID is the tenant the user registered with.
+	//migrator:schema:field name="tenant_id" type="TEXT" not_null="true"
+	TenantID string `json:"tenant_id" db:"tenant_id"`
+
+	// UserID is the inactive user the request activates.
+	//migrator:schema:field name="user_id" type="TEXT" not_null="true"
+	UserID string `json:"user_id" db:"user_id"`
+
+	// Email and Name are copied from the registration so the queue reads
+	// without a users join.
+	//migrator:schema:field name="email" type="TEXT" not_null="true"
+	Email string `json:"email" db:"email"`
+	//migrator:schema:field name="name" type="TEXT" not_null="true"
+	Name string `json:"name" db:"name"`
+
+	//migrator:schema:field name="status" type="TEXT" not_null="true" default="pending"
+	Status RegistrationRequestStatus `json:"status" db:"status"`
+
+	// Reason is the operator's note on the decision. Always set for a
+	// rejection; optional for an approval.
+	//migrator:schema:field name="reason" type="TEXT"
+	Reason *string `json:"reason,omitempty" db:"reason"`
+
+	// DecidedBy records who decided: the back-office operator id for an
+	// API call, nil for the CLI. Not an FK, same as WorkerControl.PausedBy.
+	//migrator:schema:field name="decided_by" type="TEXT"
+	DecidedBy *string `json:"decided_by,omitempty" db:"decided_by"`
+
+	//migrator:schema:field name="decided_at" type="TIMESTAMP"
+	DecidedAt *time.Time `json:"decided_at,omitempty" db:"decided_at"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RegistrationRequestIndexes defines the PostgreSQL indexes for the
+// registration_requests table.
+type RegistrationRequestIndexes struct {
+	// Unique index for the immutable UUID (mirrors the convention used
+	// elsewhere).
+	//migrator:schema:index name="idx_registration_requests_uuid" fields="uuid" unique="true" table="registration_requests"
+	_ int
+
+	// One request per user: a repeated registration with the same email
+	// is ignored before it gets here.
+	//migrator:schema:index name="idx_registration_requests_user_id" fields="user_id" unique="true" table="registration_requests"
+	_ int
+
+	// Backs the queue listing (pending first, per tenant, oldest first).
+	//migrator:schema:index name="idx_registration_requests_tenant_status_created" fields="tenant_id,status,created_at" table="registration_requests"
+	_ int
+}
+
+// IsPending reports whether the request is still waiting for a decision.
+func (r *RegistrationRequest) IsPending() bool {
+	return r.Status == RegistrationRequestStatusPending
+}
@@ -17,6 +17,10 @@ const (
 	RestoreStatusRunning   RestoreStatus = "running"
 	RestoreStatusCompleted RestoreStatus = "completed"
 	RestoreStatusFailed    RestoreStatus = "failed"
+	// RestoreStatusPreview is the terminal status of a dry-run: the archive
+	// was read and the planned changes are in RestoreOperation.Preview, but
+	// nothing was written.
+	RestoreStatusPreview RestoreStatus = "preview"
 )
 
 var (
@@ -32,6 +36,40 @@ type RestoreOptions struct {
 	Strategy        string `json:"strategy"`
 	IncludeFileData bool   `json:"include_file_data"`
 	DryRun          bool   `json:"dry_run"`
+	// Selection narrows the restore to chosen subtrees of the archive; nil
+	// restores everything.
+	Selection *RestoreSelection `json:"selection,omitempty"`
+}
+
+// RestoreSelection picks what a selective restore brings back, by the
+// immutable UUIDs the archive carries (the manifest's location index and the
+// per-location documents). A location brings its areas, an area its
+// commodities, and each entity its files. Missing parents of a selected
+// entity are created; existing ones are left as they are.
+type RestoreSelection struct {
+	LocationIDs  []string `json:"location_ids,omitempty"`
+	AreaIDs      []string `json:"area_ids,omitempty"`
+	CommodityIDs []string `json:"commodity_ids,omitempty"`
+}
+
+// IsEmpty reports whether nothing is selected.
+func (s RestoreSelection) IsEmpty() bool {
+	return len(s.LocationIDs) == 0 && len(s.AreaIDs) == 0 && len(s.CommodityIDs) == 0
+}
+
+func (s RestoreSelection) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (s RestoreSelection) ValidateWithContext(ctx context.Context) error {
+	if s.IsEmpty() {
+		return validation.NewError("invalid_restore_selection", "selection must name at least one location, area or commodity")
+	}
+	return validation.ValidateStructWithContext(ctx, &s,
+		validation.Field(&s.LocationIDs, validation.Each(validation.Required)),
+		validation.Field(&s.AreaIDs, validation.Each(validation.Required)),
+		validation.Field(&s.CommodityIDs, validation.Each(validation.Required)),
+	)
 }
 
 // Value implements driver.Valuer so RestoreOptions can be written to a
@@ -72,6 +110,7 @@ func (r RestoreOptions) ValidateWithContext(ctx context.Context) error {
 			"merge_add",
 			"merge_update",
 		)),
+		validation.Field(&r.Selection),
 	)
 
 	return validation.ValidateStructWithContext(ctx, &r, fields...)
@@ -125,6 +164,15 @@ type RestoreOperation struct {
 	//migrator:schema:field name="error_count" type="INTEGER" default="0"
 	ErrorCount int `json:"error_count" db:"error_count" userinput:"false"`
 
+	// Preview is the planned-change report of a dry-run; nil otherwise.
+	//migrator:schema:field name="preview" type="JSONB"
+	Preview *RestorePreview `json:"preview,omitempty" db:"preview" userinput:"false"`
+
+	// TraceParent is the W3C traceparent of the request that enqueued the
+	// restore, so the worker's span joins that request's trace.
+	//migrator:schema:field name="trace_parent" type="TEXT"
+	TraceParent string `json:"-" db:"trace_parent" userinput:"false"`
+
 	// Related steps (not stored in DB, loaded separately)
 	Steps []RestoreStep `json:"steps,omitempty" db:"-"`
 }
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// RestorePreviewAction is what a restore would do to a single entity.
+type RestorePreviewAction string
+
+const (
+	RestorePreviewActionCreate RestorePreviewAction = "create"
+	RestorePreviewActionUpdate RestorePreviewAction = "update"
+	RestorePreviewActionSkip   RestorePreviewAction = "skip"
+	RestorePreviewActionDelete RestorePreviewAction = "delete"
+)
+
+// RestoreConflictKind classifies a RestoreConflict.
+type RestoreConflictKind string
+
+const (
+	// RestoreConflictContentMismatch: an entity with the same UUID exists in
+	// the group with different content. merge_add keeps the group's version,
+	// merge_update overwrites it with the archive's.
+	RestoreConflictContentMismatch RestoreConflictKind = "content_mismatch"
+	// RestoreConflictMissingLocation: an archived area references a location
+	// that is neither in the archive nor in the group.
+	RestoreConflictMissingLocation RestoreConflictKind = "missing_location"
+	// RestoreConflictMissingArea: an archived commodity references an area
+	// that is neither in the archive nor in the group.
+	RestoreConflictMissingArea RestoreConflictKind = "missing_area"
+	// RestoreConflictCurrencyMismatch: an archived commodity's prices were
+	// recorded in a main currency other than the group currency.
+	RestoreConflictCurrencyMismatch RestoreConflictKind = "currency_mismatch"
+)
+
+// RestorePreviewCounts tallies the per-action outcome for a set of entities.
+type RestorePreviewCounts struct {
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Skip   int `json:"skip"`
+	Delete int `json:"delete"`
+}
+
+// Add counts one entity under action.
+func (c *RestorePreviewCounts) Add(action RestorePreviewAction) {
+	switch action {
+	case RestorePreviewActionCreate:
+		c.Create++
+	case RestorePreviewActionUpdate:
+		c.Update++
+	case RestorePreviewActionSkip:
+		c.Skip++
+	case RestorePreviewActionDelete:
+		c.Delete++
+	}
+}
+
+// RestorePreviewArea is the planned change for one area and its commodities.
+// ID is the area's immutable UUID.
+type RestorePreviewArea struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Action      RestorePreviewAction `json:"action"`
+	Commodities RestorePreviewCounts `json:"commodities"`
+}
+
+// RestorePreviewLocation is the planned change for one location and its
+// areas. ID is the location's immutable UUID.
+type RestorePreviewLocation struct {
+	ID     string               `json:"id"`
+	Name   string               `json:"name"`
+	Action RestorePreviewAction `json:"action"`
+	Areas  []RestorePreviewArea `json:"areas"`
+}
+
+// RestorePreviewFiles summarises the file changes. Upload counts the blobs a
+// restore would write and UploadBytes their total size.
+type RestorePreviewFiles struct {
+	Upload      int   `json:"upload"`
+	UploadBytes int64 `json:"upload_bytes"`
+	Skip        int   `json:"skip"`
+	Delete      int   `json:"delete"`
+}
+
+// RestoreConflict is one problem a restore would run into. EntityID is the
+// immutable UUID of the archived entity.
+type RestoreConflict struct {
+	Kind       RestoreConflictKind `json:"kind"`
+	EntityType string              `json:"entity_type"`
+	EntityID   string              `json:"entity_id"`
+	Name       string              `json:"name,omitempty"`
+	Detail     string              `json:"detail,omitempty"`
+}
+
+// RestorePreview is the report of a dry-run restore: what a restore with the
+// same options would create, update, skip and delete, grouped per location
+// and area, plus the conflicts it would run into. Nothing is written while
+// producing it.
+type RestorePreview struct {
+	Locations []RestorePreviewLocation `json:"locations"`
+	// Unassigned counts the area-less commodities.
+	Unassigned RestorePreviewCounts `json:"unassigned"`
+	Files      RestorePreviewFiles  `json:"files"`
+	Conflicts  []RestoreConflict    `json:"conflicts"`
+}
+
+// Value implements driver.Valuer so RestorePreview can be written to a JSONB
+// column.
+func (p RestorePreview) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan implements sql.Scanner for the JSONB `preview` column.
+func (p *RestorePreview) Scan(value any) error {
+	if value == nil {
+		*p = RestorePreview{}
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("cannot scan %T into RestorePreview", value)
+	}
+}
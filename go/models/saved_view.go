@@ -0,0 +1,238 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/models/rules"
+)
+
+var (
+	_ validation.Validatable            = (*SavedView)(nil)
+	_ validation.ValidatableWithContext = (*SavedView)(nil)
+	_ TenantGroupAwareIDable            = (*SavedView)(nil)
+	_ validation.Validatable            = SavedViewScope("")
+)
+
+// SavedViewScope controls who sees a saved view.
+type SavedViewScope string
+
+// Saved view scopes. Adding a new scope? Don't forget to update IsValid() method.
+const (
+	// SavedViewScopePrivate views are only visible to the member who
+	// created them.
+	SavedViewScopePrivate SavedViewScope = "private"
+	// SavedViewScopeGroup views are shared with every member of the group.
+	SavedViewScopeGroup SavedViewScope = "group"
+)
+
+func (s SavedViewScope) IsValid() bool {
+	switch s {
+	case SavedViewScopePrivate, SavedViewScopeGroup:
+		return true
+	}
+	return false
+}
+
+func (s SavedViewScope) Validate() error {
+	if !s.IsValid() {
+		return validation.NewError("invalid_saved_view_scope", "must be one of: private, group")
+	}
+	return nil
+}
+
+// SavedViewFilters is the serialized form of the commodity list filters.
+// Field names follow the GET /commodities query parameters so a view can
+// be replayed through the same parser the list endpoint uses (see
+// QueryValues) instead of growing a second interpretation of the same
+// filters. Empty fields mean "no filter", exactly like the query string.
+type SavedViewFilters struct {
+	Types                 []CommodityType   `json:"types,omitempty"`
+	Statuses              []CommodityStatus `json:"statuses,omitempty"`
+	AreaID                string            `json:"area_id,omitempty"`
+	Unassigned            bool              `json:"unassigned,omitempty"`
+	Tags                  []string          `json:"tags,omitempty"`
+	Search                string            `json:"q,omitempty"`
+	IncludeInactive       *bool             `json:"include_inactive,omitempty"`
+	Sort                  string            `json:"sort,omitempty" example:"-purchase_date"`
+	WarrantyStatuses      []string          `json:"warranty_statuses,omitempty" example:"expiring"`
+	WarrantyExpiresBefore string            `json:"warranty_expires_before,omitempty" example:"2026-12-31"`
+	LentOut               *bool             `json:"lent_out,omitempty"`
+}
+
+// Value implements driver.Valuer so SavedViewFilters can be written to a
+// JSONB column.
+func (f SavedViewFilters) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+// Scan implements sql.Scanner for the JSONB `filters` column.
+func (f *SavedViewFilters) Scan(value any) error {
+	if value == nil {
+		*f = SavedViewFilters{}
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return fmt.Errorf("cannot scan %T into SavedViewFilters", value)
+	}
+}
+
+// QueryValues renders the filters as GET /commodities query parameters.
+// Only set fields are emitted, so the result can be layered under an
+// incoming request's own parameters.
+func (f SavedViewFilters) QueryValues() url.Values {
+	q := url.Values{}
+	for _, t := range f.Types {
+		q.Add("type", string(t))
+	}
+	for _, s := range f.Statuses {
+		q.Add("status", string(s))
+	}
+	if f.AreaID != "" {
+		q.Set("area_id", f.AreaID)
+	}
+	if f.Unassigned {
+		q.Set("unassigned", "true")
+	}
+	for _, t := range f.Tags {
+		q.Add("tag", t)
+	}
+	if f.Search != "" {
+		q.Set("q", f.Search)
+	}
+	if f.IncludeInactive != nil {
+		q.Set("include_inactive", strconv.FormatBool(*f.IncludeInactive))
+	}
+	if f.Sort != "" {
+		q.Set("sort", f.Sort)
+	}
+	for _, s := range f.WarrantyStatuses {
+		q.Add("warranty_status", s)
+	}
+	if f.WarrantyExpiresBefore != "" {
+		q.Set("warranty_expires_before", f.WarrantyExpiresBefore)
+	}
+	if f.LentOut != nil {
+		q.Set("lent_out", strconv.FormatBool(*f.LentOut))
+	}
+	return q
+}
+
+func (SavedViewFilters) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (f SavedViewFilters) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, &f,
+		validation.Field(&f.Types, validation.Length(0, 20)),
+		validation.Field(&f.Statuses, validation.Length(0, 20)),
+		validation.Field(&f.AreaID, validation.Length(0, 100)),
+		validation.Field(&f.Tags, validation.Length(0, 50), validation.Each(validation.Length(1, 100))),
+		validation.Field(&f.Search, validation.Length(0, 200)),
+		validation.Field(&f.Sort, validation.Length(0, 50)),
+		validation.Field(&f.WarrantyStatuses, validation.Length(0, 10), validation.Each(validation.In(
+			string(WarrantyStatusNone),
+			string(WarrantyStatusActive),
+			string(WarrantyStatusExpiring),
+			string(WarrantyStatusExpired),
+		))),
+		validation.Field(&f.WarrantyExpiresBefore, validation.Date("2006-01-02")),
+	)
+}
+
+// SavedView is a named, reusable set of commodity list filters plus the
+// columns to show. Views are group-scoped like the commodities they
+// filter; a private view is additionally limited to the member who
+// created it. The registry enforces that split — Postgres RLS only
+// isolates by group, so private rows are filtered on created_by_user_id
+// in the registry layer.
+//
+// A view can be applied to the commodity list, to exports and to the
+// stats report by passing `view_id`; a member can also make one the
+// default list view through the `appearance.default_items_view`
+// setting. Areas and tags referenced by the filters may be deleted after
+// the view is saved, so they are re-validated every time the view is
+// applied.
+//
+// Enable RLS for multi-tenant isolation.
+//
+//migrator:schema:rls:enable table="saved_views" comment="Enable RLS for multi-tenant saved view isolation"
+//migrator:schema:rls:policy name="saved_view_isolation" table="saved_views" for="ALL" to="inventario_app" using="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" with_check="tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != ''" comment="Ensures saved views can only be accessed and modified by their tenant and group with required contexts"
+//migrator:schema:rls:policy name="saved_view_background_worker_access" table="saved_views" for="ALL" to="inventario_background_worker" using="true" with_check="true" comment="Allows background workers to access all saved views for processing"
+//migrator:schema:table name="saved_views"
+type SavedView struct {
+	//migrator:embedded mode="inline"
+	TenantGroupAwareEntityID
+
+	//migrator:schema:field name="name" type="TEXT" not_null="true"
+	Name string `json:"name" db:"name"`
+
+	//migrator:schema:field name="scope" type="TEXT" not_null="true" default="private"
+	Scope SavedViewScope `json:"scope" db:"scope"`
+
+	//migrator:schema:field name="filters" type="JSONB" not_null="true"
+	Filters SavedViewFilters `json:"filters" db:"filters"`
+
+	// Columns is the ordered list of list-view columns to show. The
+	// backend stores it verbatim; the frontend owns the vocabulary.
+	//migrator:schema:field name="columns" type="JSONB"
+	Columns ValuerSlice[string] `json:"columns" db:"columns"`
+
+	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	CreatedAt time.Time `json:"created_at" db:"created_at" userinput:"false"`
+
+	//migrator:schema:field name="updated_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" userinput:"false"`
+}
+
+// SavedViewIndexes defines the postgres indexes for saved_views.
+type SavedViewIndexes struct {
+	// Unique index for the immutable UUID (deduplication key for import/restore).
+	//migrator:schema:index name="idx_saved_views_uuid" fields="uuid" unique="true" table="saved_views"
+	_ int
+
+	// Index for tenant-based queries.
+	//migrator:schema:index name="idx_saved_views_tenant_id" fields="tenant_id" table="saved_views"
+	_ int
+
+	// Composite index for tenant+group RLS-filtered queries.
+	//migrator:schema:index name="idx_saved_views_tenant_group" fields="tenant_id,group_id" table="saved_views"
+	_ int
+
+	// Composite index for the per-member list (shared views plus the
+	// caller's own private ones).
+	//migrator:schema:index name="idx_saved_views_group_creator" fields="group_id,created_by_user_id" table="saved_views"
+	_ int
+}
+
+// IsVisibleTo reports whether userID may see the view: shared views are
+// visible to every group member, private ones only to their creator.
+func (v *SavedView) IsVisibleTo(userID string) bool {
+	return v.Scope != SavedViewScopePrivate || v.CreatedByUserID == userID
+}
+
+func (*SavedView) Validate() error {
+	return ErrMustUseValidateWithContext
+}
+
+func (v *SavedView) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, v,
+		validation.Field(&v.TenantGroupAwareEntityID),
+		validation.Field(&v.Name, rules.NotEmpty, validation.Length(1, 100)),
+		validation.Field(&v.Scope, validation.Required),
+		validation.Field(&v.Filters),
+		validation.Field(&v.Columns, validation.Length(0, 50), validation.Each(validation.Length(1, 64))),
+	)
+}
@@ -90,11 +90,13 @@ const (
 	SettingNameNotificationsWeeklyDigest        SettingName = "notifications.weekly_digest"
 	SettingNameNotificationsPriceDrop           SettingName = "notifications.price_drop"
 	SettingNameNotificationsLoanReminder        SettingName = "notifications.loan_reminder"
+	SettingNameNotificationsServiceReminder     SettingName = "notifications.service_reminder"
 	SettingNameNotificationsChannelEmail        SettingName = "notifications.channel.email"
 	SettingNameNotificationsChannelPush         SettingName = "notifications.channel.push"
 
 	// Per-user appearance preferences. `default_items_view` is consumed by
-	// the commodities list page as the initial view mode (grid / list).
+	// the commodities list page as the initial view mode (grid / list),
+	// or holds a saved view ID to open the list with that view applied.
 	// `preferred_display_currency` is a personal display-formatting hint;
 	// it is NOT used to override the per-group commodity currency on
 	// stored values (see deviations log in PR-A for the wiring scope).
@@ -130,6 +132,7 @@ type SettingsObject struct {
 	NotificationsWeeklyDigest        *bool `configfield:"notifications.weekly_digest"`
 	NotificationsPriceDrop           *bool `configfield:"notifications.price_drop"`
 	NotificationsLoanReminder        *bool `configfield:"notifications.loan_reminder"`
+	NotificationsServiceReminder     *bool `configfield:"notifications.service_reminder"`
 	// Channel toggles act as a master switch per delivery channel: when
 	// false, no category-level notification is delivered through that
 	// channel regardless of the per-category toggle.
@@ -60,6 +60,11 @@ type ThumbnailGenerationJob struct {
 	//migrator:schema:field name="processing_completed_at" type="TIMESTAMP"
 	ProcessingCompletedAt *time.Time `json:"processing_completed_at" db:"processing_completed_at"`
 
+	// TraceParent is the W3C traceparent of the request that enqueued the job,
+	// so the worker's span joins that request's trace.
+	//migrator:schema:field name="trace_parent" type="TEXT"
+	TraceParent string `json:"-" db:"trace_parent"`
+
 	// CreatedAt is when the job was created
 	//migrator:schema:field name="created_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// TrustedBackupKey is one entry of the backup-signing keyring: an Ed25519
+// public key whose signatures import and restore accept in addition to the
+// server's own key. It covers archives from a sibling instance (e.g. staging)
+// and archives signed with this instance's key before a rotation.
+//
+// Like WorkerControl the table is NOT tenant-scoped and has NO RLS policy:
+// which keys are trusted is a platform-operator decision. Rows are never
+// deleted — revoking a key stamps RevokedAt so the keyring keeps a record of
+// every key that was ever trusted and who withdrew it.
+//
+//migrator:schema:table name="trusted_backup_keys"
+type TrustedBackupKey struct {
+	//migrator:embedded mode="inline"
+	EntityID
+
+	// Fingerprint is the lowercase hex SHA-256 of the raw public key — the
+	// same value backupsign reports and the manifest signer block carries.
+	// It is the natural key the API and CLI address a key by.
+	//migrator:schema:field name="fingerprint" type="TEXT" not_null="true"
+	Fingerprint string `json:"fingerprint" db:"fingerprint"`
+
+	// PublicKey is the raw 32-byte Ed25519 public key, standard-base64
+	// encoded (the backupsign.PublicKeyBase64 form).
+	//migrator:schema:field name="public_key" type="TEXT" not_null="true"
+	PublicKey string `json:"public_key" db:"public_key"`
+
+	// Label is the operator's name for the key ("staging", "prod 2025").
+	//migrator:schema:field name="label" type="TEXT" not_null="true"
+	Label string `json:"label" db:"label"`
+
+	// AddedAt is when the key was trusted.
+	//migrator:schema:field name="added_at" type="TIMESTAMP" not_null="true" default_expr="CURRENT_TIMESTAMP"
+	AddedAt time.Time `json:"added_at" db:"added_at"`
+
+	// AddedBy records who trusted the key: the back-office operator id for
+	// an API call, or the literal "cli". Not an FK, same as
+	// WorkerControl.PausedBy.
+	//migrator:schema:field name="added_by" type="TEXT"
+	AddedBy *string `json:"added_by,omitempty" db:"added_by"`
+
+	// ExpiresAt optionally bounds how long the key is trusted. NULL means
+	// the key is trusted until revoked.
+	//migrator:schema:field name="expires_at" type="TIMESTAMP"
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+
+	// RevokedAt is set once the key is revoked; a revoked key is kept but
+	// no longer accepted.
+	//migrator:schema:field name="revoked_at" type="TIMESTAMP"
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+
+	// RevokedBy records who revoked the key, in the AddedBy format.
+	//migrator:schema:field name="revoked_by" type="TEXT"
+	RevokedBy *string `json:"revoked_by,omitempty" db:"revoked_by"`
+
+	// RevokeReason is the optional operator-supplied note for the revocation.
+	//migrator:schema:field name="revoke_reason" type="TEXT"
+	RevokeReason *string `json:"revoke_reason,omitempty" db:"revoke_reason"`
+}
+
+// IsActive reports whether signatures under the key are accepted at now: the
+// key is not revoked and, when it has an expiry, has not reached it.
+func (k *TrustedBackupKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// TrustedBackupKeyIndexes defines the PostgreSQL indexes for the
+// trusted_backup_keys table.
+type TrustedBackupKeyIndexes struct {
+	// Unique index for the immutable UUID (mirrors the convention used
+	// elsewhere).
+	//migrator:schema:index name="idx_trusted_backup_keys_uuid" fields="uuid" unique="true" table="trusted_backup_keys"
+	_ int
+
+	// Unique index on fingerprint: a key is trusted at most once, and the
+	// API/CLI look keys up by fingerprint.
+	//migrator:schema:index name="trusted_backup_keys_fingerprint_idx" fields="fingerprint" unique="true" table="trusted_backup_keys"
+	_ int
+}
@@ -0,0 +1,30 @@
+package models
+
+// Versioned is implemented by entities guarded by optimistic concurrency
+// control. The registries stamp version 1 on Create and bump it on every
+// Update; an Update whose incoming version is non-zero must match the stored
+// one or fails with registry.ErrVersionConflict. A zero version means "no
+// expectation" and always applies — internal callers that build an entity
+// from scratch (restores, imports) keep their last-write-wins behaviour.
+type Versioned interface {
+	GetVersion() int
+	SetVersion(int)
+}
+
+var _ Versioned = (*EntityVersion)(nil)
+
+// EntityVersion is embedded by entities that members edit concurrently
+// (commodities, areas, locations, files). The API serves the version as the
+// ETag and checks If-Match against it.
+type EntityVersion struct {
+	//migrator:schema:field name="version" type="INTEGER" not_null="true" default="1"
+	Version int `json:"version" db:"version" userinput:"false"`
+}
+
+func (v *EntityVersion) GetVersion() int {
+	return v.Version
+}
+
+func (v *EntityVersion) SetVersion(version int) {
+	v.Version = version
+}
@@ -29,6 +29,8 @@ const (
 	WorkerTypeEmailVerificationCleanup WorkerType = "email-verification-cleanup"
 	// WorkerTypeMagicLinkTokenCleanup pauses the magic-link token cleanup worker.
 	WorkerTypeMagicLinkTokenCleanup WorkerType = "magic-link-token-cleanup"
+	// WorkerTypeIdempotencyKeyCleanup pauses the Idempotency-Key cleanup worker.
+	WorkerTypeIdempotencyKeyCleanup WorkerType = "idempotency-key-cleanup"
 	// WorkerTypeOperationSlotCleanup pauses the operation-slot cleanup worker.
 	WorkerTypeOperationSlotCleanup WorkerType = "operation-slot-cleanup"
 	// WorkerTypeLoginEventRetention pauses the login-event retention worker.
@@ -43,8 +45,19 @@ const (
 	WorkerTypeLoanReminder WorkerType = "loan-reminder"
 	// WorkerTypeMaintenanceReminder pauses the maintenance reminder worker.
 	WorkerTypeMaintenanceReminder WorkerType = "maintenance-reminder"
+	// WorkerTypeServiceReminder pauses the service reminder worker.
+	WorkerTypeServiceReminder WorkerType = "service-reminder"
 	// WorkerTypeCurrencyMigration pauses the currency migration worker.
 	WorkerTypeCurrencyMigration WorkerType = "currency-migration"
+	// WorkerTypeBackupScheduler pauses the scheduled-backup worker (both
+	// enqueueing new exports and retention pruning).
+	WorkerTypeBackupScheduler WorkerType = "backup-scheduler"
+	// WorkerTypeBackupReplication pauses the off-site backup replication
+	// worker.
+	WorkerTypeBackupReplication WorkerType = "backup-replication"
+	// WorkerTypeInvoiceExtraction pauses the invoice extraction worker,
+	// which spends AI vision provider tokens.
+	WorkerTypeInvoiceExtraction WorkerType = "invoice-extraction"
 	// WorkerTypeOrphanFileGC pauses the orphan-file GC sweeper (#2237).
 	// This is the only DESTRUCTIVE periodic worker in the set: pausing it
 	// is the operator's emergency stop, so the constant MUST also appear
@@ -65,6 +78,7 @@ var allWorkerTypes = []WorkerType{
 	WorkerTypeRefreshTokenCleanup,
 	WorkerTypeEmailVerificationCleanup,
 	WorkerTypeMagicLinkTokenCleanup,
+	WorkerTypeIdempotencyKeyCleanup,
 	WorkerTypeOperationSlotCleanup,
 	WorkerTypeLoginEventRetention,
 	WorkerTypeGroupPurge,
@@ -72,7 +86,11 @@ var allWorkerTypes = []WorkerType{
 	WorkerTypeStorageQuotaReminder,
 	WorkerTypeLoanReminder,
 	WorkerTypeMaintenanceReminder,
+	WorkerTypeServiceReminder,
 	WorkerTypeCurrencyMigration,
+	WorkerTypeBackupScheduler,
+	WorkerTypeBackupReplication,
+	WorkerTypeInvoiceExtraction,
 	WorkerTypeOrphanFileGC,
 }
 
@@ -96,6 +114,7 @@ func (w WorkerType) IsValid() bool {
 		WorkerTypeRefreshTokenCleanup,
 		WorkerTypeEmailVerificationCleanup,
 		WorkerTypeMagicLinkTokenCleanup,
+		WorkerTypeIdempotencyKeyCleanup,
 		WorkerTypeOperationSlotCleanup,
 		WorkerTypeLoginEventRetention,
 		WorkerTypeGroupPurge,
@@ -103,7 +122,11 @@ func (w WorkerType) IsValid() bool {
 		WorkerTypeStorageQuotaReminder,
 		WorkerTypeLoanReminder,
 		WorkerTypeMaintenanceReminder,
+		WorkerTypeServiceReminder,
 		WorkerTypeCurrencyMigration,
+		WorkerTypeBackupScheduler,
+		WorkerTypeBackupReplication,
+		WorkerTypeInvoiceExtraction,
 		WorkerTypeOrphanFileGC:
 		return true
 	}
@@ -0,0 +1,136 @@
+package registry
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// CommodityStatsDimension names one breakdown of CommodityStats.
+type CommodityStatsDimension string
+
+const (
+	CommodityStatsByStatus         CommodityStatsDimension = "status"
+	CommodityStatsByType           CommodityStatsDimension = "type"
+	CommodityStatsByLocation       CommodityStatsDimension = "location"
+	CommodityStatsByArea           CommodityStatsDimension = "area"
+	CommodityStatsByTag            CommodityStatsDimension = "tag"
+	CommodityStatsByPurchaseYear   CommodityStatsDimension = "purchase_year"
+	CommodityStatsByWarrantyStatus CommodityStatsDimension = "warranty_status"
+)
+
+// CommodityStatsDimensions lists every dimension AggregateStats computes,
+// in the order the API reports them.
+var CommodityStatsDimensions = []CommodityStatsDimension{
+	CommodityStatsByStatus,
+	CommodityStatsByType,
+	CommodityStatsByLocation,
+	CommodityStatsByArea,
+	CommodityStatsByTag,
+	CommodityStatsByPurchaseYear,
+	CommodityStatsByWarrantyStatus,
+}
+
+// CommodityStatsOptions parameterises CommodityRegistry.AggregateStats.
+type CommodityStatsOptions struct {
+	// GroupCurrency is the currency values are reported in. It drives the
+	// same price fallback as valuation.Valuator (see CommodityValue).
+	GroupCurrency string
+	// Now anchors the warranty_status breakdown. Zero means time.Now();
+	// tests pin it so the expiring window is deterministic.
+	Now time.Time
+	// Filter, when non-nil, narrows the aggregated set with the same
+	// predicates as ListPaginated (sort fields are ignored). Drafts stay
+	// excluded regardless of Filter.IncludeInactive. Used to report on a
+	// saved view.
+	Filter *CommodityListOptions
+}
+
+// CommodityStatsBucket is one row of a CommodityStats breakdown.
+//
+// Key is the status / type / location id / area id / tag / four-digit
+// purchase year / warranty status. The empty key collects rows with no
+// value for the dimension (unassigned area, untagged, no purchase date).
+// A commodity with several tags counts once in every tag bucket, so the
+// tag breakdown does not sum to the totals.
+type CommodityStatsBucket struct {
+	Key   string
+	Count int
+	Value decimal.Decimal
+}
+
+// CommodityStats is the dashboard aggregate returned by
+// CommodityRegistry.AggregateStats.
+//
+// The draft rules match valuation.Valuator: drafts are excluded
+// altogether, and only in-use commodities contribute Value — sold, lost
+// or disposed items still show up in the counts (that is what the status
+// breakdown is for) but are worth 0.
+type CommodityStats struct {
+	TotalCount int
+	TotalValue decimal.Decimal
+	Breakdowns map[CommodityStatsDimension][]CommodityStatsBucket
+}
+
+// CommodityValue returns what a commodity is worth in groupCurrency for
+// valuation purposes: the current price when set, else the original price
+// when it is already in groupCurrency, else the converted original price.
+// Zero means the commodity cannot be valued. Callers decide separately
+// whether the commodity should be valued at all (drafts and non-in-use
+// rows are not).
+func CommodityValue(commodity *models.Commodity, groupCurrency string) decimal.Decimal {
+	if !commodity.CurrentPrice.IsZero() {
+		return commodity.CurrentPrice
+	}
+	if !commodity.OriginalPrice.IsZero() && string(commodity.OriginalPriceCurrency) == groupCurrency {
+		return commodity.OriginalPrice
+	}
+	if !commodity.ConvertedOriginalPrice.IsZero() {
+		return commodity.ConvertedOriginalPrice
+	}
+	return decimal.Zero
+}
+
+// PurchaseYear returns the four-digit year of a commodity's purchase date,
+// or "" when it has none. Purchase dates are stored as YYYY-MM-DD text, so
+// this is the same LEFT(purchase_date, 4) the postgres backend groups on.
+func PurchaseYear(date models.PDate) string {
+	if date == nil || len(*date) < 4 {
+		return ""
+	}
+	return string((*date)[:4])
+}
+
+// SortCommodityStatsBuckets orders a breakdown by count descending, then
+// key ascending, so both backends return identical slices.
+func SortCommodityStatsBuckets(buckets []CommodityStatsBucket) {
+	slices.SortFunc(buckets, func(a, b CommodityStatsBucket) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+}
+
+// ValidateDateRange checks the FindByDateRange bounds: both must be
+// YYYY-MM-DD and start must not be after end.
+func ValidateDateRange(startDate, endDate string) error {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return errxtrace.Wrap("invalid start date", ErrInvalidInput, errx.Attrs("start_date", startDate))
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return errxtrace.Wrap("invalid end date", ErrInvalidInput, errx.Attrs("end_date", endDate))
+	}
+	if start.After(end) {
+		return errxtrace.Wrap("start date is after end date", ErrInvalidInput)
+	}
+	return nil
+}
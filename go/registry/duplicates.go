@@ -0,0 +1,143 @@
+package registry
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// DuplicateReason names one signal that made CommodityRegistry.FindSimilar /
+// FindDuplicates flag two commodities as possible duplicates. A pair can
+// carry several reasons at once; the FE renders one chip per reason.
+type DuplicateReason string
+
+const (
+	// DuplicateReasonName means the trigram similarity of the two names is
+	// at or above the requested threshold.
+	DuplicateReasonName DuplicateReason = "name"
+	// DuplicateReasonShortName means both short names are set and their
+	// trigram similarity is at or above the requested threshold.
+	DuplicateReasonShortName DuplicateReason = "short_name"
+	// DuplicateReasonSerialNumber means the two commodities share at least
+	// one serial number (primary or extra), compared case-insensitively.
+	DuplicateReasonSerialNumber DuplicateReason = "serial_number"
+	// DuplicateReasonPartNumber means the two commodities share at least
+	// one part number, compared case-insensitively.
+	DuplicateReasonPartNumber DuplicateReason = "part_number"
+	// DuplicateReasonPurchase means both commodities were bought on the
+	// same date for the same non-zero original price in the same currency.
+	DuplicateReasonPurchase DuplicateReason = "purchase_date_price"
+)
+
+// DefaultDuplicateThreshold is the trigram similarity threshold used when a
+// caller does not pass one. Deliberately stricter than pg_trgm's 0.3
+// default: that value is tuned for search-as-you-type, where a loose match
+// is cheap to ignore, whereas every false positive here is a row the user
+// has to dismiss by hand.
+const DefaultDuplicateThreshold = 0.5
+
+// SimilarCommodity is a single FindSimilar hit.
+type SimilarCommodity struct {
+	Commodity *models.Commodity
+	// Score is the higher of the name / short-name trigram similarities
+	// (0..1). It is reported even when the match came from an exact
+	// signal only, so the FE can still show how close the names are.
+	Score   float64
+	Reasons []DuplicateReason
+}
+
+// DuplicatePair is a single FindDuplicates hit. A and B are ordered by id
+// (A.ID < B.ID) so every unordered pair is reported exactly once.
+type DuplicatePair struct {
+	A       *models.Commodity
+	B       *models.Commodity
+	Score   float64
+	Reasons []DuplicateReason
+}
+
+// DuplicateReasons evaluates the duplicate signals between a and b. The
+// trigram scores are computed by the caller (pg_trgm's similarity() on
+// postgres, an equivalent Go implementation on memory) so both backends
+// share the same decision rules for everything else. Returns nil when the
+// pair is not a possible duplicate.
+func DuplicateReasons(a, b *models.Commodity, nameScore, shortNameScore, threshold float64) []DuplicateReason {
+	var reasons []DuplicateReason
+	if nameScore >= threshold {
+		reasons = append(reasons, DuplicateReasonName)
+	}
+	if a.ShortName != "" && b.ShortName != "" && shortNameScore >= threshold {
+		reasons = append(reasons, DuplicateReasonShortName)
+	}
+	if identifiersOverlap(commoditySerials(a), commoditySerials(b)) {
+		reasons = append(reasons, DuplicateReasonSerialNumber)
+	}
+	if identifiersOverlap(a.PartNumbers, b.PartNumbers) {
+		reasons = append(reasons, DuplicateReasonPartNumber)
+	}
+	if samePurchase(a, b) {
+		reasons = append(reasons, DuplicateReasonPurchase)
+	}
+	return reasons
+}
+
+// SortSimilarCommodities orders FindSimilar results: more reasons first,
+// then higher score, then id for a stable order across backends.
+func SortSimilarCommodities(items []SimilarCommodity) {
+	slices.SortStableFunc(items, func(x, y SimilarCommodity) int {
+		return compareDuplicates(len(x.Reasons), len(y.Reasons), x.Score, y.Score, x.Commodity.GetID(), y.Commodity.GetID())
+	})
+}
+
+// SortDuplicatePairs orders FindDuplicates results with the same rules as
+// SortSimilarCommodities, tie-breaking on the (A, B) id pair.
+func SortDuplicatePairs(items []DuplicatePair) {
+	slices.SortStableFunc(items, func(x, y DuplicatePair) int {
+		return compareDuplicates(len(x.Reasons), len(y.Reasons), x.Score, y.Score, x.A.GetID()+"\x00"+x.B.GetID(), y.A.GetID()+"\x00"+y.B.GetID())
+	})
+}
+
+func compareDuplicates(xReasons, yReasons int, xScore, yScore float64, xKey, yKey string) int {
+	if xReasons != yReasons {
+		return yReasons - xReasons
+	}
+	switch {
+	case xScore > yScore:
+		return -1
+	case xScore < yScore:
+		return 1
+	}
+	return strings.Compare(xKey, yKey)
+}
+
+func commoditySerials(c *models.Commodity) []string {
+	out := make([]string, 0, len(c.ExtraSerialNumbers)+1)
+	out = append(out, c.SerialNumber)
+	out = append(out, c.ExtraSerialNumbers...)
+	return out
+}
+
+func identifiersOverlap(a, b []string) bool {
+	seen := make(map[string]struct{}, len(a))
+	for _, v := range a {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			seen[v] = struct{}{}
+		}
+	}
+	for _, v := range b {
+		if _, ok := seen[strings.ToLower(strings.TrimSpace(v))]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func samePurchase(a, b *models.Commodity) bool {
+	if a.PurchaseDate == nil || b.PurchaseDate == nil || *a.PurchaseDate == "" || *a.PurchaseDate != *b.PurchaseDate {
+		return false
+	}
+	if a.OriginalPrice.IsZero() || !a.OriginalPrice.Equal(b.OriginalPrice) {
+		return false
+	}
+	return a.OriginalPriceCurrency == b.OriginalPriceCurrency
+}
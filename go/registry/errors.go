@@ -152,4 +152,26 @@ var (
 	// decide whether to issue a 501 (MFAEnforced=true, no secret) vs.
 	// a MFA challenge (MFAEnforced=true, secret present).
 	ErrBackofficeMFASecretNotFound = errx.NewSentinel("backoffice MFA secret not found", ErrNotFound)
+
+	// ErrRegistrationAlreadyDecided is returned by
+	// RegistrationRequestRegistry.Decide when the request is no longer
+	// pending — another operator approved or rejected it first. Same
+	// no-idempotency stance as ErrLoanAlreadyReturned: the caller should
+	// refresh the queue.
+	ErrRegistrationAlreadyDecided = errx.NewSentinel("registration request already decided")
+
+	// ErrLocationScopeDenied is returned when a member restricted to a
+	// set of locations writes to a row outside them, or to one where
+	// their per-location role is too low. Reads outside the scope stay
+	// ErrNotFound so a restricted member cannot probe for IDs. The
+	// memory backend returns it from its scope filter; Postgres surfaces
+	// the RLS WITH CHECK violation (SQLSTATE 42501) as the same sentinel.
+	ErrLocationScopeDenied = errx.NewSentinel("location scope denied")
+
+	// ErrVersionConflict is returned by Update when the entity carries a
+	// non-zero models.Versioned version that no longer matches the stored
+	// row — another writer got there first — and by a Delete run under
+	// WithExpectedVersion in the same case. The apiserver maps it to 412
+	// Precondition Failed.
+	ErrVersionConflict = errx.NewSentinel("version conflict")
 )
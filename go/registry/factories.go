@@ -83,6 +83,36 @@ type MaintenanceScheduleRegistryFactory interface {
 	ServiceRegistryFactory[models.MaintenanceSchedule, MaintenanceScheduleRegistry]
 }
 
+// MaintenanceLogRegistryFactory creates MaintenanceLogRegistry instances with proper context.
+type MaintenanceLogRegistryFactory interface {
+	UserRegistryFactory[models.MaintenanceLog, MaintenanceLogRegistry]
+	ServiceRegistryFactory[models.MaintenanceLog, MaintenanceLogRegistry]
+}
+
+// CommodityMeterReadingRegistryFactory creates CommodityMeterReadingRegistry instances with proper context.
+type CommodityMeterReadingRegistryFactory interface {
+	UserRegistryFactory[models.CommodityMeterReading, CommodityMeterReadingRegistry]
+	ServiceRegistryFactory[models.CommodityMeterReading, CommodityMeterReadingRegistry]
+}
+
+// InvoiceExtractionRegistryFactory creates InvoiceExtractionRegistry instances with proper context.
+type InvoiceExtractionRegistryFactory interface {
+	UserRegistryFactory[models.InvoiceExtraction, InvoiceExtractionRegistry]
+	ServiceRegistryFactory[models.InvoiceExtraction, InvoiceExtractionRegistry]
+}
+
+// SavedViewRegistryFactory creates SavedViewRegistry instances with proper context.
+type SavedViewRegistryFactory interface {
+	UserRegistryFactory[models.SavedView, SavedViewRegistry]
+	ServiceRegistryFactory[models.SavedView, SavedViewRegistry]
+}
+
+// BackupScheduleRegistryFactory creates BackupScheduleRegistry instances with proper context.
+type BackupScheduleRegistryFactory interface {
+	UserRegistryFactory[models.BackupSchedule, BackupScheduleRegistry]
+	ServiceRegistryFactory[models.BackupSchedule, BackupScheduleRegistry]
+}
+
 // RestoreOperationRegistryFactory creates RestoreOperationRegistry instances with proper context
 type RestoreOperationRegistryFactory interface {
 	UserRegistryFactory[models.RestoreOperation, RestoreOperationRegistry]
@@ -175,13 +205,20 @@ type FactorySet struct {
 	CommodityServiceRegistryFactory       CommodityServiceRegistryFactory
 	SupplyLinkRegistryFactory             SupplyLinkRegistryFactory
 	MaintenanceScheduleRegistryFactory    MaintenanceScheduleRegistryFactory
+	MaintenanceLogRegistryFactory         MaintenanceLogRegistryFactory
+	CommodityMeterReadingRegistryFactory  CommodityMeterReadingRegistryFactory
+	InvoiceExtractionRegistryFactory      InvoiceExtractionRegistryFactory
+	SavedViewRegistryFactory              SavedViewRegistryFactory
+	BackupScheduleRegistryFactory         BackupScheduleRegistryFactory
 	ThumbnailGenerationJobRegistryFactory ThumbnailGenerationJobRegistryFactory
 	UserConcurrencySlotRegistryFactory    UserConcurrencySlotRegistryFactory
 	OperationSlotRegistryFactory          OperationSlotRegistryFactory
 	PingFn                                func(context.Context) error   // Optional health check hook for backing storage.
+	CloseFn                               func() error                  // Optional shutdown hook that flushes backing storage.
 	TenantRegistry                        TenantRegistry                // TenantRegistry doesn't need factory as it's not user-aware
 	UserRegistry                          UserRegistry                  // UserRegistry doesn't need factory as it's not user-aware
 	RefreshTokenRegistry                  RefreshTokenRegistry          // RefreshTokenRegistry doesn't need factory as it's not user-aware
+	CalendarFeedRegistry                  CalendarFeedRegistry          // CalendarFeedRegistry is service-mode: feed tokens are resolved before any user context exists
 	LoginEventRegistry                    LoginEventRegistry            // LoginEventRegistry runs under the background-worker role (write path) + app-level user_id filter (read path)
 	UserMFASecretRegistry                 UserMFASecretRegistry         // Per-user TOTP secrets (#1645); service-mode (called pre-RLS in login)
 	AuditLogRegistry                      AuditLogRegistry              // AuditLogRegistry doesn't need factory as it's not user-aware
@@ -195,6 +232,7 @@ type FactorySet struct {
 	GroupNotificationPrefRegistry         GroupNotificationPrefRegistry // Per-group notification opt-outs (#1648); tenant-scoped, user-filtered in application logic
 	GroupPurger                           GroupPurger                   // GroupPurger hard-deletes group-scoped data during purge ticks
 	TenantPurger                          TenantPurger                  // TenantPurger hard-deletes every tenant-scoped dependent row during admin tenant hard-delete (#2115)
+	GroupTransferrer                      GroupTransferrer              // GroupTransferrer moves locations, areas and commodities to another group of the tenant
 	UserPurger                            UserPurger                    // UserPurger hard-deletes a user's auth/identity rows during admin user hard-delete (#2116)
 	UserContentOwnershipChecker           UserContentOwnershipChecker   // UserContentOwnershipChecker is the read-only pre-check for self-service account deletion (#2147)
 	WarrantyReminderRegistry              WarrantyReminderRegistry      // WarrantyReminderRegistry is the worker idempotency store; service-mode only
@@ -250,6 +288,32 @@ type FactorySet struct {
 	// tenant-scoped, not user-aware, no RLS (same posture as
 	// SystemAdminGrantRegistry / AuditLogRegistry).
 	WorkerControlRegistry WorkerControlRegistry
+
+	// TrustedBackupKeyRegistry holds the backup-signing keyring consulted
+	// when verifying imported and restored archives. FactorySet only, for
+	// the same reasons as WorkerControlRegistry.
+	TrustedBackupKeyRegistry TrustedBackupKeyRegistry
+
+	// CommodityScanBudgetRegistry holds the monthly AI vision spend caps
+	// enforced by CommodityScanService. FactorySet only, for the same
+	// reasons as WorkerControlRegistry.
+	CommodityScanBudgetRegistry CommodityScanBudgetRegistry
+
+	// RegistrationRequestRegistry holds the registration approval queue.
+	// FactorySet only, for the same reasons as WorkerControlRegistry.
+	RegistrationRequestRegistry RegistrationRequestRegistry
+
+	// IdempotencyKeyRegistry stores the responses replayed for retried
+	// POSTs carrying an Idempotency-Key. FactorySet only: keys are
+	// resolved by middleware and swept by the cleanup worker across
+	// tenants.
+	IdempotencyKeyRegistry IdempotencyKeyRegistry
+
+	// GroupMemberLocationScopeRegistry holds the location restrictions of
+	// group members. It is read once per group request by the slug
+	// resolver, before the per-request Set exists, so it lives on
+	// FactorySet only.
+	GroupMemberLocationScopeRegistry GroupMemberLocationScopeRegistry
 }
 
 // Ping checks readiness of the backing registry dependency (e.g. database).
@@ -261,6 +325,15 @@ func (fs *FactorySet) Ping(ctx context.Context) error {
 	return fs.PingFn(ctx)
 }
 
+// Close flushes the backing registry on shutdown (e.g. the final memory
+// snapshot). If no close function is configured, it does nothing.
+func (fs *FactorySet) Close() error {
+	if fs == nil || fs.CloseFn == nil {
+		return nil
+	}
+	return fs.CloseFn()
+}
+
 // CreateUserRegistrySet creates a complete set of user-aware registries from factories
 func (fs *FactorySet) CreateUserRegistrySet(ctx context.Context) (*Set, error) {
 	locationRegistry, err := fs.LocationRegistryFactory.CreateUserRegistry(ctx)
@@ -323,6 +396,31 @@ func (fs *FactorySet) CreateUserRegistrySet(ctx context.Context) (*Set, error) {
 		return nil, err
 	}
 
+	maintenanceLogRegistry, err := fs.MaintenanceLogRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commodityMeterReadingRegistry, err := fs.CommodityMeterReadingRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceExtractionRegistry, err := fs.InvoiceExtractionRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	savedViewRegistry, err := fs.SavedViewRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	backupScheduleRegistry, err := fs.BackupScheduleRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	restoreOperationRegistry, err := fs.RestoreOperationRegistryFactory.CreateUserRegistry(ctx)
 	if err != nil {
 		return nil, err
@@ -368,6 +466,11 @@ func (fs *FactorySet) CreateUserRegistrySet(ctx context.Context) (*Set, error) {
 		CommodityServiceRegistry:       commodityServiceRegistry,
 		SupplyLinkRegistry:             supplyLinkRegistry,
 		MaintenanceScheduleRegistry:    maintenanceScheduleRegistry,
+		MaintenanceLogRegistry:         maintenanceLogRegistry,
+		CommodityMeterReadingRegistry:  commodityMeterReadingRegistry,
+		InvoiceExtractionRegistry:      invoiceExtractionRegistry,
+		SavedViewRegistry:              savedViewRegistry,
+		BackupScheduleRegistry:         backupScheduleRegistry,
 		ThumbnailGenerationJobRegistry: thumbnailGenerationJobRegistry,
 		UserConcurrencySlotRegistry:    userConcurrencySlotRegistry,
 		OperationSlotRegistry:          operationSlotRegistry,
@@ -413,6 +516,11 @@ func (fs *FactorySet) CreateServiceRegistrySet() *Set {
 		CommodityServiceRegistry:       fs.CommodityServiceRegistryFactory.CreateServiceRegistry(),
 		SupplyLinkRegistry:             fs.SupplyLinkRegistryFactory.CreateServiceRegistry(),
 		MaintenanceScheduleRegistry:    fs.MaintenanceScheduleRegistryFactory.CreateServiceRegistry(),
+		MaintenanceLogRegistry:         fs.MaintenanceLogRegistryFactory.CreateServiceRegistry(),
+		CommodityMeterReadingRegistry:  fs.CommodityMeterReadingRegistryFactory.CreateServiceRegistry(),
+		InvoiceExtractionRegistry:      fs.InvoiceExtractionRegistryFactory.CreateServiceRegistry(),
+		SavedViewRegistry:              fs.SavedViewRegistryFactory.CreateServiceRegistry(),
+		BackupScheduleRegistry:         fs.BackupScheduleRegistryFactory.CreateServiceRegistry(),
 		ThumbnailGenerationJobRegistry: fs.ThumbnailGenerationJobRegistryFactory.CreateServiceRegistry(),
 		UserConcurrencySlotRegistry:    fs.UserConcurrencySlotRegistryFactory.CreateServiceRegistry(),
 		OperationSlotRegistry:          fs.OperationSlotRegistryFactory.CreateServiceRegistry(),
@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"context"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// MemberContext returns ctx acting as user inside group, the way the group
+// slug resolver sets it up for a request: user, group and, for a member
+// restricted to locations, their location scope. Background code that
+// opens user registries on a member's behalf (feeds, schedulers, workers)
+// must build its context here — the registries treat a missing scope as
+// unrestricted. A failed scope lookup is returned rather than ignored so
+// callers fail closed. A nil scopes registry means scoping is not wired.
+func MemberContext(ctx context.Context, scopes GroupMemberLocationScopeRegistry, user *models.User, group *models.LocationGroup) (context.Context, error) {
+	ctx = appctx.WithUser(ctx, user)
+	ctx = appctx.WithGroup(ctx, group)
+	if scopes == nil {
+		return ctx, nil
+	}
+	rows, err := scopes.ListByMember(ctx, group.TenantID, group.ID, user.ID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to load member location scope", err)
+	}
+	if scope := models.NewLocationScope(rows); scope.Restricted() {
+		ctx = appctx.WithLocationScope(ctx, scope)
+	}
+	return ctx, nil
+}
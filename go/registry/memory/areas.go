@@ -64,6 +64,7 @@ func (f *AreaRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.
 		lock:    f.baseAreaRegistry.lock,  // Share the mutex pointer
 		userID:  user.ID,                  // Set user-specific userID
 		groupID: groupID,                  // Set group-specific groupID
+		scoper:  newLocationScoper(ctx, models.GroupRoleAdmin, locateArea),
 	}
 
 	// Create user-aware location registry
@@ -161,17 +162,19 @@ func (r *AreaRegistry) Delete(ctx context.Context, id string) error {
 		return errxtrace.Wrap("area has commodities", registry.ErrCannotDelete)
 	}
 
-	// Remove this area from its parent location's area list
-	area, err := r.Registry.Get(ctx, id)
-	if err == nil {
-		_ = r.locationRegistry.DeleteArea(ctx, area.LocationID, id)
-	}
+	area, getErr := r.Registry.Get(ctx, id)
 
-	err = r.Registry.Delete(ctx, id)
+	err := r.Registry.Delete(ctx, id)
 	if err != nil {
 		return errxtrace.Wrap("failed to delete area", err)
 	}
 
+	// Remove this area from its parent location's area list only once the
+	// row is gone, so a refused delete leaves the index intact.
+	if getErr == nil {
+		_ = r.locationRegistry.DeleteArea(ctx, area.LocationID, id)
+	}
+
 	// Clean up the area's commodity list when the area is successfully deleted
 	r.commoditiesLock.Lock()
 	delete(r.commodities, id)
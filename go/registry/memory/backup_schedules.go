@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// BackupScheduleRegistryFactory creates BackupScheduleRegistry instances
+// with proper context. Stores the base registry so all per-request
+// registries share the same backing map.
+type BackupScheduleRegistryFactory struct {
+	base *Registry[models.BackupSchedule, *models.BackupSchedule]
+}
+
+// BackupScheduleRegistry is the context-aware in-memory registry of
+// backup schedules.
+type BackupScheduleRegistry struct {
+	*Registry[models.BackupSchedule, *models.BackupSchedule]
+
+	groupID string
+}
+
+var (
+	_ registry.BackupScheduleRegistry        = (*BackupScheduleRegistry)(nil)
+	_ registry.BackupScheduleRegistryFactory = (*BackupScheduleRegistryFactory)(nil)
+)
+
+func NewBackupScheduleRegistryFactory() *BackupScheduleRegistryFactory {
+	return &BackupScheduleRegistryFactory{
+		base: NewRegistry[models.BackupSchedule, *models.BackupSchedule](),
+	}
+}
+
+func (f *BackupScheduleRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.BackupScheduleRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *BackupScheduleRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.BackupScheduleRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+
+	groupID := appctx.GroupIDFromContext(ctx)
+	userRegistry := &Registry[models.BackupSchedule, *models.BackupSchedule]{
+		items:   f.base.items,
+		lock:    f.base.lock,
+		userID:  user.ID,
+		groupID: groupID,
+	}
+
+	return &BackupScheduleRegistry{
+		Registry: userRegistry,
+		groupID:  groupID,
+	}, nil
+}
+
+func (f *BackupScheduleRegistryFactory) CreateServiceRegistry() registry.BackupScheduleRegistry {
+	serviceRegistry := &Registry[models.BackupSchedule, *models.BackupSchedule]{
+		items:  f.base.items,
+		lock:   f.base.lock,
+		userID: "",
+	}
+
+	return &BackupScheduleRegistry{
+		Registry: serviceRegistry,
+	}
+}
+
+func (r *BackupScheduleRegistry) GetForGroup(ctx context.Context) (*models.BackupSchedule, error) {
+	all, err := r.Registry.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range all {
+		if s.GroupID == r.groupID {
+			return s, nil
+		}
+	}
+	return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("group_id", r.groupID))
+}
+
+func (r *BackupScheduleRegistry) ListPending(ctx context.Context, now time.Time) ([]*models.BackupSchedule, error) {
+	all, err := r.Registry.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.BackupSchedule, 0, len(all))
+	for _, s := range all {
+		unsettled := s.LastExportID != "" && s.LastExportID != s.SettledExportID
+		if s.IsDue(now) || unsettled {
+			out = append(out, s)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].NextRunAt.Equal(out[j].NextRunAt) {
+			return out[i].NextRunAt.Before(out[j].NextRunAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (r *BackupScheduleRegistry) Create(ctx context.Context, schedule models.BackupSchedule) (*models.BackupSchedule, error) {
+	if _, err := r.GetForGroup(ctx); err == nil {
+		return nil, errxtrace.Classify(registry.ErrAlreadyExists, errx.Attrs("group_id", r.groupID))
+	}
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+	created, err := r.Registry.CreateWithUser(ctx, schedule)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create backup schedule", err)
+	}
+	return created, nil
+}
+
+func (r *BackupScheduleRegistry) Update(ctx context.Context, schedule models.BackupSchedule) (*models.BackupSchedule, error) {
+	existing, err := r.Registry.Get(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Ownership and creation time are immutable.
+	schedule.TenantGroupAwareEntityID = existing.TenantGroupAwareEntityID
+	schedule.CreatedAt = existing.CreatedAt
+	schedule.UpdatedAt = time.Now()
+	updated, err := r.Registry.Update(ctx, schedule)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update backup schedule", err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,89 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func TestBackupScheduleRegistry_OnePerGroupAndPending(t *testing.T) {
+	c := qt.New(t)
+	factorySet := memory.NewFactorySet()
+	u, err := factorySet.CreateServiceRegistrySet().UserRegistry.Create(context.Background(), models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "backup-owner"},
+			TenantID: "backup-tenant",
+		},
+		Email: "owner@example.com",
+		Name:  "Owner",
+	})
+	c.Assert(err, qt.IsNil)
+	inGroup := func(groupID string) (context.Context, registry.BackupScheduleRegistry) {
+		ctx := appctx.WithUser(context.Background(), u)
+		ctx = appctx.WithGroup(ctx, &models.LocationGroup{
+			TenantAwareEntityID: models.TenantAwareEntityID{
+				EntityID: models.EntityID{ID: groupID},
+				TenantID: "backup-tenant",
+			},
+			Slug: groupID,
+		})
+		return ctx, must.Must(factorySet.CreateUserRegistrySet(ctx)).BackupScheduleRegistry
+	}
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	ctxA, regA := inGroup("group-a")
+	_, err = regA.GetForGroup(ctxA)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	due, err := regA.Create(ctxA, models.BackupSchedule{
+		Enabled:   true,
+		Frequency: models.BackupFrequencyDaily,
+		TimeOfDay: "03:00",
+		KeepDaily: 7,
+		NextRunAt: now.Add(-time.Minute),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(due.GroupID, qt.Equals, "group-a")
+
+	_, err = regA.Create(ctxA, models.BackupSchedule{Frequency: models.BackupFrequencyDaily})
+	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
+
+	got, err := regA.GetForGroup(ctxA)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ID, qt.Equals, due.ID)
+
+	// A disabled, not-yet-due schedule is only pending while its last
+	// export is unsettled.
+	ctxB, regB := inGroup("group-b")
+	idle, err := regB.Create(ctxB, models.BackupSchedule{
+		Frequency:    models.BackupFrequencyWeekly,
+		TimeOfDay:    "03:00",
+		KeepWeekly:   4,
+		NextRunAt:    now.Add(time.Hour),
+		LastExportID: "export-1",
+	})
+	c.Assert(err, qt.IsNil)
+
+	service := factorySet.BackupScheduleRegistryFactory.CreateServiceRegistry()
+	pending, err := service.ListPending(context.Background(), now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pending, qt.HasLen, 2)
+	c.Assert(pending[0].ID, qt.Equals, due.ID)
+	c.Assert(pending[1].ID, qt.Equals, idle.ID)
+
+	idle.SettledExportID = "export-1"
+	_, err = service.Update(context.Background(), *idle)
+	c.Assert(err, qt.IsNil)
+	pending, err = service.ListPending(context.Background(), now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pending, qt.HasLen, 1)
+	c.Assert(pending[0].ID, qt.Equals, due.ID)
+}
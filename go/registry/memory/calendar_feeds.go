@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var _ registry.CalendarFeedRegistry = (*CalendarFeedRegistry)(nil)
+
+type baseCalendarFeedRegistry = Registry[models.CalendarFeed, *models.CalendarFeed]
+
+// CalendarFeedRegistry is the in-memory calendar feed store. Like
+// RefreshTokenRegistry it is not user-aware; callers filter by user.
+type CalendarFeedRegistry struct {
+	*baseCalendarFeedRegistry
+}
+
+func NewCalendarFeedRegistry() *CalendarFeedRegistry {
+	return &CalendarFeedRegistry{
+		baseCalendarFeedRegistry: NewRegistry[models.CalendarFeed, *models.CalendarFeed](),
+	}
+}
+
+func (r *CalendarFeedRegistry) Create(_ context.Context, feed models.CalendarFeed) (*models.CalendarFeed, error) {
+	if feed.TokenHash == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TokenHash"))
+	}
+	if feed.UserID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+	if feed.TenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+
+	feed.ID = uuid.New().String()
+	if feed.UUID == "" {
+		feed.UUID = uuid.New().String()
+	}
+	feed.CreatedAt = time.Now()
+
+	r.lock.Lock()
+	r.items.Set(feed.ID, &feed)
+	r.lock.Unlock()
+
+	return &feed, nil
+}
+
+// GetByTokenHash returns the feed whose token hashes to tokenHash.
+func (r *CalendarFeedRegistry) GetByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	feeds, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range feeds {
+		if f.TokenHash == tokenHash {
+			return f, nil
+		}
+	}
+	return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "CalendarFeed"))
+}
+
+// ListByUserID returns the user's feeds, oldest first.
+func (r *CalendarFeedRegistry) ListByUserID(ctx context.Context, userID string) ([]*models.CalendarFeed, error) {
+	feeds, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.CalendarFeed, 0, len(feeds))
+	for _, f := range feeds {
+		if f.UserID == userID {
+			out = append(out, f)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
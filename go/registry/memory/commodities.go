@@ -59,6 +59,7 @@ func (f *CommodityRegistryFactory) CreateUserRegistry(ctx context.Context) (regi
 		lock:    f.baseCommodityRegistry.lock,  // Share the mutex pointer
 		userID:  user.ID,                       // Set user-specific userID
 		groupID: groupID,                       // Set group-specific groupID
+		scoper:  newLocationScoper(ctx, models.GroupRoleUser, commodityLocator(f.areaRegistry)),
 	}
 
 	// Create user-aware area registry
@@ -417,6 +418,9 @@ func commodityMatches(c *models.Commodity, opts registry.CommodityListOptions, q
 	if q != "" && !commoditySearchMatches(c, q) {
 		return false
 	}
+	if len(opts.Tags) > 0 && !slices.ContainsFunc(opts.Tags, func(t string) bool { return slices.Contains(c.Tags, t) }) {
+		return false
+	}
 	if len(opts.WarrantyStatuses) > 0 {
 		st := models.ComputeWarrantyStatus(c.WarrantyExpiresAt, now)
 		if !slices.Contains(opts.WarrantyStatuses, registry.WarrantyStatusFilter(st)) {
@@ -559,10 +563,11 @@ func (r *CommodityRegistry) matchesTags(commodityTags []string, searchTags []str
 	}
 }
 
-// FindSimilar finds similar commodities using simple name comparison (simplified)
-func (r *CommodityRegistry) FindSimilar(ctx context.Context, commodityID string, threshold float64) ([]*models.Commodity, error) {
-	// Get the reference commodity
-	refCommodity, err := r.Registry.Get(ctx, commodityID)
+// FindSimilar returns the possible duplicates of commodityID. Name scores
+// use trigramSimilarity, the Go port of pg_trgm's similarity(), so the
+// results match the postgres backend for the same data.
+func (r *CommodityRegistry) FindSimilar(ctx context.Context, commodityID string, threshold float64) ([]registry.SimilarCommodity, error) {
+	ref, err := r.Registry.Get(ctx, commodityID)
 	if err != nil {
 		return nil, err
 	}
@@ -572,44 +577,65 @@ func (r *CommodityRegistry) FindSimilar(ctx context.Context, commodityID string,
 		return nil, err
 	}
 
-	var similar []*models.Commodity
-	refName := strings.ToLower(refCommodity.Name)
-
+	similar := make([]registry.SimilarCommodity, 0)
 	for _, commodity := range commodities {
-		if commodity.ID == commodityID {
+		if commodity.ID == ref.ID {
 			continue
 		}
-
-		// Simple similarity check based on common words
-		commodityName := strings.ToLower(commodity.Name)
-		if r.calculateSimpleSimilarity(refName, commodityName) > threshold {
-			similar = append(similar, commodity)
+		score, reasons := commodityDuplicateReasons(ref, commodity, threshold)
+		if len(reasons) == 0 {
+			continue
 		}
+		similar = append(similar, registry.SimilarCommodity{
+			Commodity: commodity,
+			Score:     score,
+			Reasons:   reasons,
+		})
 	}
 
+	registry.SortSimilarCommodities(similar)
 	return similar, nil
 }
 
-// calculateSimpleSimilarity calculates a simple similarity score between two strings
-func (r *CommodityRegistry) calculateSimpleSimilarity(s1, s2 string) float64 {
-	words1 := strings.Fields(s1)
-	words2 := strings.Fields(s2)
-
-	if len(words1) == 0 || len(words2) == 0 {
-		return 0.0
+// FindDuplicates compares every visible pair once. Quadratic, which is fine
+// for the in-memory backend's dev / test data sizes.
+func (r *CommodityRegistry) FindDuplicates(ctx context.Context, threshold float64, limit int) ([]registry.DuplicatePair, error) {
+	commodities, err := r.Registry.List(ctx)
+	if err != nil {
+		return nil, err
 	}
+	slices.SortFunc(commodities, func(a, b *models.Commodity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
 
-	commonWords := 0
-	for _, word1 := range words1 {
-		if slices.Contains(words2, word1) {
-			commonWords++
+	pairs := make([]registry.DuplicatePair, 0)
+	for i, a := range commodities {
+		for _, b := range commodities[i+1:] {
+			score, reasons := commodityDuplicateReasons(a, b, threshold)
+			if len(reasons) == 0 {
+				continue
+			}
+			pairs = append(pairs, registry.DuplicatePair{A: a, B: b, Score: score, Reasons: reasons})
 		}
 	}
 
-	// Simple similarity score: common words / max words
-	maxWords := max(len(words2), len(words1))
+	registry.SortDuplicatePairs(pairs)
+	if limit > 0 && len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs, nil
+}
 
-	return float64(commonWords) / float64(maxWords)
+// commodityDuplicateReasons computes the name / short-name trigram scores
+// for a pair and hands them to registry.DuplicateReasons. The returned
+// score is the higher of the two.
+func commodityDuplicateReasons(a, b *models.Commodity, threshold float64) (float64, []registry.DuplicateReason) {
+	nameScore := trigramSimilarity(a.Name, b.Name)
+	shortNameScore := 0.0
+	if a.ShortName != "" && b.ShortName != "" {
+		shortNameScore = trigramSimilarity(a.ShortName, b.ShortName)
+	}
+	return max(nameScore, shortNameScore), registry.DuplicateReasons(a, b, nameScore, shortNameScore, threshold)
 }
 
 // FullTextSearch performs simple text search on commodities (simplified)
@@ -647,70 +673,154 @@ func (r *CommodityRegistry) FullTextSearch(ctx context.Context, query string, op
 	return filtered, nil
 }
 
-// AggregateByArea aggregates commodities by area (simplified)
-func (r *CommodityRegistry) AggregateByArea(ctx context.Context, groupBy []string) ([]registry.AggregationResult, error) {
+// AggregateStats walks the visible commodities once and buckets them per
+// registry.CommodityStatsDimension. The location breakdown resolves areas
+// through the area registry, mirroring the postgres LEFT JOIN.
+func (r *CommodityRegistry) AggregateStats(ctx context.Context, opts registry.CommodityStatsOptions) (*registry.CommodityStats, error) {
 	commodities, err := r.List(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if opts.Filter != nil {
+		commodities = filterCommodities(commodities, *opts.Filter)
+	}
+	areas, err := r.areaRegistry.List(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list areas", err)
+	}
+	areaToLocation := make(map[string]string, len(areas))
+	for _, area := range areas {
+		areaToLocation[area.ID] = area.LocationID
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	stats := &registry.CommodityStats{
+		TotalValue: decimal.Zero,
+		Breakdowns: make(map[registry.CommodityStatsDimension][]registry.CommodityStatsBucket, len(registry.CommodityStatsDimensions)),
+	}
+	buckets := make(map[registry.CommodityStatsDimension]map[string]*registry.CommodityStatsBucket, len(registry.CommodityStatsDimensions))
+	for _, dim := range registry.CommodityStatsDimensions {
+		buckets[dim] = make(map[string]*registry.CommodityStatsBucket)
+	}
+	add := func(dim registry.CommodityStatsDimension, key string, value decimal.Decimal) {
+		b, ok := buckets[dim][key]
+		if !ok {
+			b = &registry.CommodityStatsBucket{Key: key, Value: decimal.Zero}
+			buckets[dim][key] = b
+		}
+		b.Count++
+		b.Value = b.Value.Add(value)
+	}
 
-	areaMap := make(map[string][]float64)
 	for _, commodity := range commodities {
 		if commodity.Draft {
 			continue
 		}
-		// Area is optional (issue #1986): unassigned commodities have no
-		// area to aggregate under, so skip them.
-		if commodity.AreaID == nil || *commodity.AreaID == "" {
-			continue
-		}
-		price, _ := commodity.OriginalPrice.Float64()
-		if !commodity.ConvertedOriginalPrice.IsZero() {
-			price, _ = commodity.ConvertedOriginalPrice.Float64()
-		}
-		areaMap[*commodity.AreaID] = append(areaMap[*commodity.AreaID], price)
-	}
+		value := commodityStatsValue(commodity, opts.GroupCurrency)
+		stats.TotalCount++
+		stats.TotalValue = stats.TotalValue.Add(value)
 
-	var results []registry.AggregationResult
-	for areaID, prices := range areaMap {
-		count := len(prices)
-		var sum, avg float64
-		for _, price := range prices {
-			sum += price
+		areaID := ""
+		if commodity.AreaID != nil {
+			areaID = *commodity.AreaID
+		}
+		add(registry.CommodityStatsByStatus, string(commodity.Status), value)
+		add(registry.CommodityStatsByType, string(commodity.Type), value)
+		add(registry.CommodityStatsByArea, areaID, value)
+		add(registry.CommodityStatsByLocation, areaToLocation[areaID], value)
+		add(registry.CommodityStatsByPurchaseYear, registry.PurchaseYear(commodity.PurchaseDate), value)
+		add(registry.CommodityStatsByWarrantyStatus, string(models.ComputeWarrantyStatus(commodity.WarrantyExpiresAt, now)), value)
+		if len(commodity.Tags) == 0 {
+			add(registry.CommodityStatsByTag, "", value)
 		}
-		if count > 0 {
-			avg = sum / float64(count)
+		for _, tag := range slices.Compact(slices.Sorted(slices.Values(commodity.Tags))) {
+			add(registry.CommodityStatsByTag, tag, value)
 		}
+	}
 
-		result := registry.AggregationResult{
-			GroupBy: map[string]any{"area_id": areaID},
-			Count:   count,
-			Avg:     map[string]float64{"price": avg},
-			Sum:     map[string]float64{"price": sum},
+	for _, dim := range registry.CommodityStatsDimensions {
+		out := make([]registry.CommodityStatsBucket, 0, len(buckets[dim]))
+		for _, b := range buckets[dim] {
+			out = append(out, *b)
 		}
-		results = append(results, result)
+		registry.SortCommodityStatsBuckets(out)
+		stats.Breakdowns[dim] = out
 	}
 
-	return results, nil
+	return stats, nil
 }
 
-// CountByStatus counts commodities by status (simplified)
-func (r *CommodityRegistry) CountByStatus(ctx context.Context) (map[string]int, error) {
+// commodityStatsValue is the value a non-draft commodity contributes to
+// the stats: its valuation when in use, zero otherwise.
+func commodityStatsValue(commodity *models.Commodity, groupCurrency string) decimal.Decimal {
+	if commodity.Status != models.CommodityStatusInUse {
+		return decimal.Zero
+	}
+	return registry.CommodityValue(commodity, groupCurrency)
+}
+
+// AggregateByArea sums the in-use valuation of non-draft commodities per area.
+func (r *CommodityRegistry) AggregateByArea(ctx context.Context, groupCurrency string) ([]registry.AggregationResult, error) {
 	commodities, err := r.List(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make(map[string]int)
+	type areaTotal struct {
+		count int
+		sum   decimal.Decimal
+	}
+	totals := make(map[string]*areaTotal)
 	for _, commodity := range commodities {
-		result[string(commodity.Status)]++
+		if commodity.Draft {
+			continue
+		}
+		// Area is optional (issue #1986): unassigned commodities have no
+		// area to aggregate under, so skip them.
+		if commodity.AreaID == nil || *commodity.AreaID == "" {
+			continue
+		}
+		t, ok := totals[*commodity.AreaID]
+		if !ok {
+			t = &areaTotal{sum: decimal.Zero}
+			totals[*commodity.AreaID] = t
+		}
+		t.count++
+		t.sum = t.sum.Add(commodityStatsValue(commodity, groupCurrency))
 	}
 
-	return result, nil
+	results := make([]registry.AggregationResult, 0, len(totals))
+	for areaID, t := range totals {
+		sum := t.sum.InexactFloat64()
+		results = append(results, registry.AggregationResult{
+			GroupBy: map[string]any{"area_id": areaID},
+			Count:   t.count,
+			Sum:     map[string]float64{"value": sum},
+			Avg:     map[string]float64{"value": t.sum.Div(decimal.NewFromInt(int64(t.count))).InexactFloat64()},
+		})
+	}
+	slices.SortFunc(results, func(a, b registry.AggregationResult) int {
+		return strings.Compare(a.GroupBy["area_id"].(string), b.GroupBy["area_id"].(string))
+	})
+
+	return results, nil
+}
+
+// CountByStatus counts non-draft commodities per status.
+func (r *CommodityRegistry) CountByStatus(ctx context.Context) (map[string]int, error) {
+	return r.countBy(ctx, func(c *models.Commodity) string { return string(c.Status) })
 }
 
-// CountByType counts commodities by type (simplified)
+// CountByType counts non-draft commodities per type.
 func (r *CommodityRegistry) CountByType(ctx context.Context) (map[string]int, error) {
+	return r.countBy(ctx, func(c *models.Commodity) string { return string(c.Type) })
+}
+
+func (r *CommodityRegistry) countBy(ctx context.Context, key func(*models.Commodity) string) (map[string]int, error) {
 	commodities, err := r.List(ctx)
 	if err != nil {
 		return nil, err
@@ -718,14 +828,18 @@ func (r *CommodityRegistry) CountByType(ctx context.Context) (map[string]int, er
 
 	result := make(map[string]int)
 	for _, commodity := range commodities {
-		result[string(commodity.Type)]++
+		if commodity.Draft {
+			continue
+		}
+		result[key(commodity)]++
 	}
 
 	return result, nil
 }
 
-// FindByPriceRange finds commodities within a price range (simplified)
-func (r *CommodityRegistry) FindByPriceRange(ctx context.Context, minPrice, maxPrice float64, currency string) ([]*models.Commodity, error) {
+// FindByPriceRange returns non-draft commodities whose original price is
+// within [minPrice, maxPrice], optionally restricted to one currency.
+func (r *CommodityRegistry) FindByPriceRange(ctx context.Context, minPrice, maxPrice decimal.Decimal, currency string) ([]*models.Commodity, error) {
 	commodities, err := r.List(ctx)
 	if err != nil {
 		return nil, err
@@ -733,55 +847,59 @@ func (r *CommodityRegistry) FindByPriceRange(ctx context.Context, minPrice, maxP
 
 	var filtered []*models.Commodity
 	for _, commodity := range commodities {
-		price, _ := commodity.OriginalPrice.Float64()
-		if !commodity.ConvertedOriginalPrice.IsZero() {
-			price, _ = commodity.ConvertedOriginalPrice.Float64()
+		if commodity.Draft {
+			continue
 		}
-
-		// Check currency if specified
 		if currency != "" && string(commodity.OriginalPriceCurrency) != currency {
 			continue
 		}
-
-		if price >= minPrice && price <= maxPrice {
-			filtered = append(filtered, commodity)
+		if commodity.OriginalPrice.LessThan(minPrice) || commodity.OriginalPrice.GreaterThan(maxPrice) {
+			continue
 		}
+		filtered = append(filtered, commodity)
 	}
 
+	slices.SortFunc(filtered, func(a, b *models.Commodity) int {
+		if c := a.OriginalPrice.Cmp(b.OriginalPrice); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
 	return filtered, nil
 }
 
-// FindByDateRange finds commodities within a date range (simplified)
+// FindByDateRange returns non-draft commodities purchased within
+// [startDate, endDate].
 func (r *CommodityRegistry) FindByDateRange(ctx context.Context, startDate, endDate string) ([]*models.Commodity, error) {
-	commodities, err := r.List(ctx)
-	if err != nil {
+	if err := registry.ValidateDateRange(startDate, endDate); err != nil {
 		return nil, err
 	}
 
-	start, err := time.Parse("2006-01-02", startDate)
-	if err != nil {
-		return nil, errxtrace.Wrap("invalid start date format", err)
-	}
-
-	end, err := time.Parse("2006-01-02", endDate)
+	commodities, err := r.List(ctx)
 	if err != nil {
-		return nil, errxtrace.Wrap("invalid end date format", err)
+		return nil, err
 	}
 
+	// Purchase dates are YYYY-MM-DD, so lexical comparison is date order
+	// — the same text comparison the postgres backend runs.
 	var filtered []*models.Commodity
 	for _, commodity := range commodities {
-		if commodity.PurchaseDate != nil {
-			purchaseDate, err := time.Parse("2006-01-02", string(*commodity.PurchaseDate))
-			if err != nil {
-				continue
-			}
-			if (purchaseDate.Equal(start) || purchaseDate.After(start)) &&
-				(purchaseDate.Equal(end) || purchaseDate.Before(end)) {
-				filtered = append(filtered, commodity)
-			}
+		if commodity.Draft {
+			continue
+		}
+		date := pdateString(commodity.PurchaseDate)
+		if date == "" || date < startDate || date > endDate {
+			continue
 		}
+		filtered = append(filtered, commodity)
 	}
 
+	slices.SortFunc(filtered, func(a, b *models.Commodity) int {
+		if c := strings.Compare(pdateString(a.PurchaseDate), pdateString(b.PurchaseDate)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
 	return filtered, nil
 }
 
@@ -158,8 +158,9 @@ func TestCommodityRegistry_Update_PreservesAcquisition(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	stored.AcquisitionPrice = &planted
 	stored.AcquisitionCurrency = &plantedCur
-	_, err = memReg.Registry.UpdateWithUser(ctx, *stored)
+	plantedRow, err := memReg.Registry.UpdateWithUser(ctx, *stored)
 	c.Assert(err, qt.IsNil)
+	stored.Version = plantedRow.Version
 
 	// User Update with a payload that tries to clear them.
 	stored.Name = "renamed"
@@ -177,6 +178,7 @@ func TestCommodityRegistry_Update_PreservesAcquisition(t *testing.T) {
 	// something else: also ignored.
 	bogus := decimal.NewFromInt(999)
 	bogusCur := models.Currency("EUR")
+	stored.Version = updated.Version
 	stored.AcquisitionPrice = &bogus
 	stored.AcquisitionCurrency = &bogusCur
 	updated2, err := set.CommodityRegistry.Update(ctx, *stored)
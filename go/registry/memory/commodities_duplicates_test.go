@@ -0,0 +1,120 @@
+package memory_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+func TestCommodityRegistry_FindSimilar(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID := newCommodityWarrantyFixture(c)
+
+	mk := func(cm models.Commodity) *models.Commodity {
+		c.Helper()
+		cm.AreaID = new(areaID)
+		cm.Status = models.CommodityStatusInUse
+		cm.Type = models.CommodityTypeOther
+		cm.Count = 1
+		out, err := regSet.CommodityRegistry.Create(ctx, cm)
+		c.Assert(err, qt.IsNil)
+		return out
+	}
+
+	ref := mk(models.Commodity{
+		Name:                  "word",
+		SerialNumber:          "SN-123",
+		PartNumbers:           []string{"PN-9"},
+		PurchaseDate:          models.ToPDate("2024-05-01"),
+		OriginalPrice:         decimal.NewFromInt(100),
+		OriginalPriceCurrency: "USD",
+	})
+	nameMatch := mk(models.Commodity{Name: "words"})
+	serialMatch := mk(models.Commodity{Name: "Unrelated", ExtraSerialNumbers: []string{" sn-123 "}})
+	partMatch := mk(models.Commodity{Name: "Other thing", PartNumbers: []string{"pn-9"}})
+	purchaseMatch := mk(models.Commodity{
+		Name:                  "Different",
+		PurchaseDate:          models.ToPDate("2024-05-01"),
+		OriginalPrice:         decimal.RequireFromString("100.00"),
+		OriginalPriceCurrency: "USD",
+	})
+	mk(models.Commodity{
+		Name:                  "Same day other price",
+		PurchaseDate:          models.ToPDate("2024-05-01"),
+		OriginalPrice:         decimal.NewFromInt(99),
+		OriginalPriceCurrency: "USD",
+	})
+	mk(models.Commodity{Name: "Kitchen table"})
+
+	similar, err := regSet.CommodityRegistry.FindSimilar(ctx, ref.ID, 0.5)
+	c.Assert(err, qt.IsNil)
+
+	got := make(map[string][]registry.DuplicateReason, len(similar))
+	for _, s := range similar {
+		got[s.Commodity.ID] = s.Reasons
+	}
+	c.Assert(got, qt.DeepEquals, map[string][]registry.DuplicateReason{
+		nameMatch.ID:     {registry.DuplicateReasonName},
+		serialMatch.ID:   {registry.DuplicateReasonSerialNumber},
+		partMatch.ID:     {registry.DuplicateReasonPartNumber},
+		purchaseMatch.ID: {registry.DuplicateReasonPurchase},
+	})
+
+	// "word" vs "words" shares 4 of 7 distinct trigrams — the same score
+	// pg_trgm's similarity() reports for this pair.
+	for _, s := range similar {
+		if s.Commodity.ID == nameMatch.ID {
+			c.Assert(s.Score, qt.Equals, 4.0/7.0)
+		}
+	}
+
+	// A stricter threshold drops the fuzzy name match but keeps the exact signals.
+	similar, err = regSet.CommodityRegistry.FindSimilar(ctx, ref.ID, 0.9)
+	c.Assert(err, qt.IsNil)
+	c.Assert(similar, qt.HasLen, 3)
+
+	_, err = regSet.CommodityRegistry.FindSimilar(ctx, "missing", 0.5)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
+
+func TestCommodityRegistry_FindDuplicates(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID := newCommodityWarrantyFixture(c)
+
+	mk := func(name, serial string) *models.Commodity {
+		c.Helper()
+		out, err := regSet.CommodityRegistry.Create(ctx, models.Commodity{
+			AreaID:       new(areaID),
+			Name:         name,
+			SerialNumber: serial,
+			Status:       models.CommodityStatusInUse,
+			Type:         models.CommodityTypeOther,
+			Count:        1,
+		})
+		c.Assert(err, qt.IsNil)
+		return out
+	}
+
+	a := mk("Sony WH-1000XM4 headphones", "X1")
+	b := mk("Sony WH-1000XM4 Headphones", "x1")
+	mk("Kitchen table", "")
+	mk("Garden hose", "")
+
+	pairs, err := regSet.CommodityRegistry.FindDuplicates(ctx, 0.5, 0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pairs, qt.HasLen, 1)
+	c.Assert(pairs[0].Reasons, qt.DeepEquals, []registry.DuplicateReason{
+		registry.DuplicateReasonName,
+		registry.DuplicateReasonSerialNumber,
+	})
+	c.Assert(pairs[0].Score, qt.Equals, 1.0)
+	ids := []string{pairs[0].A.ID, pairs[0].B.ID}
+	if a.ID > b.ID {
+		a, b = b, a
+	}
+	c.Assert(ids, qt.DeepEquals, []string{a.ID, b.ID})
+}
@@ -159,11 +159,11 @@ func TestCommodityRegistry_Update_PriceValidation(t *testing.T) {
 	updatedCommodity1.OriginalPriceCurrency = "USD"
 	updatedCommodity1.ConvertedOriginalPrice = decimal.Zero
 
-	_, err = registrySet.CommodityRegistry.Update(ctx, updatedCommodity1)
+	updated1, err := registrySet.CommodityRegistry.Update(ctx, updatedCommodity1)
 	c.Assert(err, qt.IsNil, qt.Commentf("Should allow update when original price is in group currency and converted price is zero"))
 
 	// Test case 2: Update to have original price in group currency (USD) and converted original price is not zero - should fail
-	updatedCommodity2 := *createdCommodity
+	updatedCommodity2 := *updated1
 	updatedCommodity2.OriginalPriceCurrency = "USD"
 	updatedCommodity2.ConvertedOriginalPrice = decimal.NewFromFloat(110.00) // Non-zero value
 
@@ -0,0 +1,184 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+func TestCommodityRegistry_AggregateStats(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID := newCommodityWarrantyFixture(c)
+	area, err := regSet.AreaRegistry.Get(ctx, areaID)
+	c.Assert(err, qt.IsNil)
+
+	mk := func(cm models.Commodity) {
+		c.Helper()
+		if cm.Status == "" {
+			cm.Status = models.CommodityStatusInUse
+		}
+		if cm.Type == "" {
+			cm.Type = models.CommodityTypeElectronics
+		}
+		cm.Count = 1
+		_, err := regSet.CommodityRegistry.Create(ctx, cm)
+		c.Assert(err, qt.IsNil)
+	}
+
+	mk(models.Commodity{
+		Name:                  "TV",
+		AreaID:                new(areaID),
+		OriginalPrice:         decimal.NewFromInt(500),
+		OriginalPriceCurrency: "USD",
+		PurchaseDate:          models.ToPDate("2023-03-01"),
+		WarrantyExpiresAt:     models.ToPDate("2026-06-20"),
+		Tags:                  []string{"media", "living", "media"},
+	})
+	mk(models.Commodity{
+		Name:         "Laptop",
+		AreaID:       new(areaID),
+		CurrentPrice: decimal.NewFromInt(800),
+		PurchaseDate: models.ToPDate("2024-01-15"),
+		Tags:         []string{"media"},
+	})
+	// Sold: counted, but worth nothing — same as valuation.Valuator.
+	mk(models.Commodity{
+		Name:              "Old phone",
+		Status:            models.CommodityStatusSold,
+		CurrentPrice:      decimal.NewFromInt(50),
+		WarrantyExpiresAt: models.ToPDate("2020-01-01"),
+	})
+	// Original price in another currency with no conversion: unvalued.
+	mk(models.Commodity{
+		Name:                  "Chair",
+		Type:                  models.CommodityTypeFurniture,
+		OriginalPrice:         decimal.NewFromInt(70),
+		OriginalPriceCurrency: "EUR",
+	})
+	// Drafts never count.
+	mk(models.Commodity{
+		Name:         "Draft",
+		Draft:        true,
+		CurrentPrice: decimal.NewFromInt(1000),
+	})
+
+	stats, err := regSet.CommodityRegistry.AggregateStats(ctx, registry.CommodityStatsOptions{
+		GroupCurrency: "USD",
+		Now:           time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.TotalCount, qt.Equals, 4)
+	c.Assert(stats.TotalValue.String(), qt.Equals, "1300")
+
+	type row struct {
+		Key   string
+		Count int
+		Value string
+	}
+	rows := func(dim registry.CommodityStatsDimension) []row {
+		var out []row
+		for _, b := range stats.Breakdowns[dim] {
+			out = append(out, row{b.Key, b.Count, b.Value.String()})
+		}
+		return out
+	}
+
+	c.Assert(rows(registry.CommodityStatsByStatus), qt.DeepEquals, []row{
+		{"in_use", 3, "1300"},
+		{"sold", 1, "0"},
+	})
+	c.Assert(rows(registry.CommodityStatsByType), qt.DeepEquals, []row{
+		{"electronics", 3, "1300"},
+		{"furniture", 1, "0"},
+	})
+	c.Assert(rows(registry.CommodityStatsByArea), qt.DeepEquals, []row{
+		{"", 2, "0"},
+		{areaID, 2, "1300"},
+	})
+	c.Assert(rows(registry.CommodityStatsByLocation), qt.DeepEquals, []row{
+		{"", 2, "0"},
+		{area.LocationID, 2, "1300"},
+	})
+	c.Assert(rows(registry.CommodityStatsByTag), qt.DeepEquals, []row{
+		{"", 2, "0"},
+		{"media", 2, "1300"},
+		{"living", 1, "500"},
+	})
+	c.Assert(rows(registry.CommodityStatsByPurchaseYear), qt.DeepEquals, []row{
+		{"", 2, "0"},
+		{"2023", 1, "500"},
+		{"2024", 1, "800"},
+	})
+	c.Assert(rows(registry.CommodityStatsByWarrantyStatus), qt.DeepEquals, []row{
+		{"none", 2, "800"},
+		{"expired", 1, "0"},
+		{"expiring", 1, "500"},
+	})
+
+	counts, err := regSet.CommodityRegistry.CountByStatus(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(counts, qt.DeepEquals, map[string]int{"in_use": 3, "sold": 1})
+
+	byArea, err := regSet.CommodityRegistry.AggregateByArea(ctx, "USD")
+	c.Assert(err, qt.IsNil)
+	c.Assert(byArea, qt.HasLen, 1)
+	c.Assert(byArea[0].Count, qt.Equals, 2)
+	c.Assert(byArea[0].Sum["value"], qt.Equals, 1300.0)
+	c.Assert(byArea[0].Avg["value"], qt.Equals, 650.0)
+}
+
+func TestCommodityRegistry_FindByPriceAndDateRange(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID := newCommodityWarrantyFixture(c)
+
+	mk := func(name, price, currency, purchased string, draft bool) {
+		c.Helper()
+		_, err := regSet.CommodityRegistry.Create(ctx, models.Commodity{
+			Name:                  name,
+			AreaID:                new(areaID),
+			Status:                models.CommodityStatusInUse,
+			Type:                  models.CommodityTypeOther,
+			Count:                 1,
+			Draft:                 draft,
+			OriginalPrice:         decimal.RequireFromString(price),
+			OriginalPriceCurrency: models.Currency(currency),
+			PurchaseDate:          models.ToPDate(models.Date(purchased)),
+		})
+		c.Assert(err, qt.IsNil)
+	}
+	mk("cheap", "10", "USD", "2024-01-01", false)
+	mk("mid", "100", "USD", "2024-06-30", false)
+	mk("mid-eur", "100", "EUR", "2024-07-01", false)
+	mk("pricey", "1000", "USD", "2025-01-01", false)
+	mk("draft", "100", "USD", "2024-06-01", true)
+
+	names := func(cs []*models.Commodity) []string {
+		out := make([]string, 0, len(cs))
+		for _, cm := range cs {
+			out = append(out, cm.Name)
+		}
+		return out
+	}
+
+	found, err := regSet.CommodityRegistry.FindByPriceRange(ctx, decimal.NewFromInt(10), decimal.NewFromInt(100), "USD")
+	c.Assert(err, qt.IsNil)
+	c.Assert(names(found), qt.DeepEquals, []string{"cheap", "mid"})
+
+	found, err = regSet.CommodityRegistry.FindByPriceRange(ctx, decimal.NewFromInt(50), decimal.NewFromInt(5000), "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(found, qt.HasLen, 3)
+
+	found, err = regSet.CommodityRegistry.FindByDateRange(ctx, "2024-01-01", "2024-06-30")
+	c.Assert(err, qt.IsNil)
+	c.Assert(names(found), qt.DeepEquals, []string{"cheap", "mid"})
+
+	_, err = regSet.CommodityRegistry.FindByDateRange(ctx, "2024-13-01", "2024-06-30")
+	c.Assert(err, qt.ErrorIs, registry.ErrInvalidInput)
+	_, err = regSet.CommodityRegistry.FindByDateRange(ctx, "2025-01-01", "2024-01-01")
+	c.Assert(err, qt.ErrorIs, registry.ErrInvalidInput)
+}
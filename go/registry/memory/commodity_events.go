@@ -80,6 +80,25 @@ func (r *CommodityEventRegistry) Update(_ context.Context, event models.Commodit
 	return &event, nil
 }
 
+// ReassignCommodity re-points every visible event of fromCommodityID at
+// toCommodityID. The rows are mutated in place under the write lock so the
+// move is atomic with respect to concurrent readers.
+func (r *CommodityEventRegistry) ReassignCommodity(_ context.Context, fromCommodityID, toCommodityID string) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	moved := 0
+	for pair := r.items.Oldest(); pair != nil; pair = pair.Next() {
+		ev := pair.Value
+		if ev == nil || ev.CommodityID != fromCommodityID || !r.isItemVisible(ev) {
+			continue
+		}
+		ev.CommodityID = toCommodityID
+		moved++
+	}
+	return moved, nil
+}
+
 // ListByCommodity returns paginated events for a single commodity newest-first.
 // Filters by Kinds when supplied. Total reflects the filtered count.
 func (r *CommodityEventRegistry) ListByCommodity(ctx context.Context, commodityID string, offset, limit int, opts registry.CommodityEventListOptions) ([]*models.CommodityEvent, int, error) {
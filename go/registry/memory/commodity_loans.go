@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"sort"
+	"strings"
 	"time"
 
 	errxtrace "github.com/go-extras/errx/stacktrace"
@@ -18,6 +19,11 @@ import (
 // registries share the same backing map (mirrors the tag/export pattern).
 type CommodityLoanRegistryFactory struct {
 	base *Registry[models.CommodityLoan, *models.CommodityLoan]
+
+	// commodityFactory resolves a loan to its location for location-scoped
+	// members. Wired by NewFactorySet via SetCommodityFactory; nil for a
+	// bare factory, in which case a restricted member sees no loans.
+	commodityFactory *CommodityRegistryFactory
 }
 
 // CommodityLoanRegistry is the context-aware in-memory registry of loans.
@@ -38,6 +44,12 @@ func NewCommodityLoanRegistryFactory() *CommodityLoanRegistryFactory {
 	}
 }
 
+// SetCommodityFactory wires the commodity registry the location-scope
+// filter walks to find a loan's location.
+func (f *CommodityLoanRegistryFactory) SetCommodityFactory(commodity *CommodityRegistryFactory) {
+	f.commodityFactory = commodity
+}
+
 func (f *CommodityLoanRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.CommodityLoanRegistry {
 	return must.Must(f.CreateUserRegistry(ctx))
 }
@@ -54,6 +66,7 @@ func (f *CommodityLoanRegistryFactory) CreateUserRegistry(ctx context.Context) (
 		lock:    f.base.lock,
 		userID:  user.ID,
 		groupID: groupID,
+		scoper:  newLocationScoper(ctx, models.GroupRoleUser, loanLocator(f.commodityFactory)),
 	}
 
 	return &CommodityLoanRegistry{
@@ -220,18 +233,17 @@ func (r *CommodityLoanRegistry) ListPendingReminders(ctx context.Context, kind r
 		if due.IsZero() {
 			continue
 		}
-		switch kind {
-		case registry.LoanReminderKindOverdue:
-			if l.ReminderSentOverdue {
-				continue
-			}
+		if kind.IsBorrower() && strings.TrimSpace(l.BorrowerEmail) == "" {
+			continue
+		}
+		if loanReminderFlag(l, kind) {
+			continue
+		}
+		if kind.IsOverdue() {
 			if !due.Before(today) {
 				continue
 			}
-		case registry.LoanReminderKindDueSoon:
-			if l.ReminderSentDueSoon {
-				continue
-			}
+		} else {
 			if due.Before(today) {
 				continue
 			}
@@ -273,21 +285,64 @@ func (r *CommodityLoanRegistry) MarkReminderSent(ctx context.Context, loanID str
 	if !ok {
 		return false, nil // already-gone is a stable no-op for the worker.
 	}
+	if loanReminderFlag(loan, kind) {
+		return false, nil
+	}
 	switch kind {
 	case registry.LoanReminderKindOverdue:
-		if loan.ReminderSentOverdue {
-			return false, nil
-		}
 		loan.ReminderSentOverdue = true
 	case registry.LoanReminderKindDueSoon:
-		if loan.ReminderSentDueSoon {
-			return false, nil
-		}
 		loan.ReminderSentDueSoon = true
+	case registry.LoanReminderKindBorrowerOverdue:
+		loan.BorrowerReminderSentOverdue = true
+	case registry.LoanReminderKindBorrowerDueSoon:
+		loan.BorrowerReminderSentDueSoon = true
+	}
+	return true, nil
+}
+
+// RecordPendingRequest mutates the stored pointer under the write lock
+// for the same reason as MarkReminderSent: the open check and the write
+// must not race a concurrent MarkReturned.
+func (r *CommodityLoanRegistry) RecordPendingRequest(ctx context.Context, loanID string, kind models.LoanRequestKind, dueBackAt models.PDate, note string, at time.Time) (bool, error) {
+	if !kind.IsValid() || kind == models.LoanRequestKindNone {
+		return false, registry.ErrInvalidInput
 	}
+	if r.userID != "" {
+		return false, errxtrace.Wrap("RecordPendingRequest requires service-mode registry", registry.ErrInvalidInput)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	loan, ok := r.items.Get(loanID)
+	if !ok || !loan.IsOpen() {
+		return false, nil
+	}
+	at = at.UTC()
+	loan.PendingRequestKind = kind
+	loan.PendingRequestDueBackAt = dueBackAt
+	loan.PendingRequestNote = note
+	loan.PendingRequestAt = &at
+	loan.UpdatedAt = time.Now()
 	return true, nil
 }
 
+// loanReminderFlag returns the idempotency flag backing the given kind.
+func loanReminderFlag(l *models.CommodityLoan, kind registry.LoanReminderKind) bool {
+	switch kind {
+	case registry.LoanReminderKindOverdue:
+		return l.ReminderSentOverdue
+	case registry.LoanReminderKindDueSoon:
+		return l.ReminderSentDueSoon
+	case registry.LoanReminderKindBorrowerOverdue:
+		return l.BorrowerReminderSentOverdue
+	case registry.LoanReminderKindBorrowerDueSoon:
+		return l.BorrowerReminderSentDueSoon
+	}
+	return false
+}
+
 func (r *CommodityLoanRegistry) CountOpenByCommodity(ctx context.Context, commodityIDs []string) (map[string]int, error) {
 	out := make(map[string]int, len(commodityIDs))
 	for _, id := range commodityIDs {
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// CommodityMeterReadingRegistryFactory creates
+// CommodityMeterReadingRegistry instances with proper context. All
+// per-request registries share the base registry's backing map.
+type CommodityMeterReadingRegistryFactory struct {
+	base *Registry[models.CommodityMeterReading, *models.CommodityMeterReading]
+}
+
+// CommodityMeterReadingRegistry is the context-aware in-memory registry
+// of commodity meter readings.
+type CommodityMeterReadingRegistry struct {
+	*Registry[models.CommodityMeterReading, *models.CommodityMeterReading]
+
+	userID string
+}
+
+var (
+	_ registry.CommodityMeterReadingRegistry        = (*CommodityMeterReadingRegistry)(nil)
+	_ registry.CommodityMeterReadingRegistryFactory = (*CommodityMeterReadingRegistryFactory)(nil)
+)
+
+func NewCommodityMeterReadingRegistryFactory() *CommodityMeterReadingRegistryFactory {
+	return &CommodityMeterReadingRegistryFactory{
+		base: NewRegistry[models.CommodityMeterReading, *models.CommodityMeterReading](),
+	}
+}
+
+func (f *CommodityMeterReadingRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.CommodityMeterReadingRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *CommodityMeterReadingRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.CommodityMeterReadingRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+
+	groupID := appctx.GroupIDFromContext(ctx)
+	userRegistry := &Registry[models.CommodityMeterReading, *models.CommodityMeterReading]{
+		items:   f.base.items,
+		lock:    f.base.lock,
+		userID:  user.ID,
+		groupID: groupID,
+	}
+
+	return &CommodityMeterReadingRegistry{
+		Registry: userRegistry,
+		userID:   user.ID,
+	}, nil
+}
+
+func (f *CommodityMeterReadingRegistryFactory) CreateServiceRegistry() registry.CommodityMeterReadingRegistry {
+	serviceRegistry := &Registry[models.CommodityMeterReading, *models.CommodityMeterReading]{
+		items:  f.base.items,
+		lock:   f.base.lock,
+		userID: "",
+	}
+
+	return &CommodityMeterReadingRegistry{
+		Registry: serviceRegistry,
+		userID:   "",
+	}
+}
+
+func (r *CommodityMeterReadingRegistry) Create(ctx context.Context, reading models.CommodityMeterReading) (*models.CommodityMeterReading, error) {
+	reading.CreatedAt = time.Now()
+	created, err := r.Registry.CreateWithUser(ctx, reading)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create commodity meter reading", err)
+	}
+	return created, nil
+}
+
+func (r *CommodityMeterReadingRegistry) Update(ctx context.Context, reading models.CommodityMeterReading) (*models.CommodityMeterReading, error) {
+	updated, err := r.Registry.UpdateWithUser(ctx, reading)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update commodity meter reading", err)
+	}
+	return updated, nil
+}
+
+// ListByCommodity returns the commodity's readings ordered by read_at
+// ascending, optionally narrowed to one unit.
+func (r *CommodityMeterReadingRegistry) ListByCommodity(ctx context.Context, commodityID string, unit models.MeterUnit) ([]*models.CommodityMeterReading, error) {
+	all, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.CommodityMeterReading, 0, len(all))
+	for _, m := range all {
+		if m.CommodityID != commodityID || (unit != "" && m.Unit != unit) {
+			continue
+		}
+		out = append(out, m)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].ReadAt != out[j].ReadAt {
+			return out[i].ReadAt < out[j].ReadAt
+		}
+		return out[i].Value.LessThan(out[j].Value)
+	})
+	return out, nil
+}
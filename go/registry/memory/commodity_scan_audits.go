@@ -2,12 +2,14 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/go-extras/errx"
 	errxtrace "github.com/go-extras/errx/stacktrace"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -121,3 +123,80 @@ func (r *CommodityScanAuditRegistry) DeleteOlderThan(_ context.Context, cutoff t
 	}
 	return nil
 }
+
+// SumCostSince sums CostUSD over the tenant's rows created at or after
+// since, narrowed to groupID when non-empty.
+func (r *CommodityScanAuditRegistry) SumCostSince(_ context.Context, tenantID, groupID string, since time.Time) (decimal.Decimal, error) {
+	if tenantID == "" {
+		return decimal.Zero, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	total := decimal.Zero
+	for _, item := range r.items {
+		if item.TenantID != tenantID {
+			continue
+		}
+		if groupID != "" && item.GroupID != groupID {
+			continue
+		}
+		if item.CreatedAt.Before(since) {
+			continue
+		}
+		total = total.Add(item.CostUSD)
+	}
+	return total, nil
+}
+
+// Usage aggregates the provider-call rows created in [since, until) per
+// (tenant, group, provider, model).
+func (r *CommodityScanAuditRegistry) Usage(_ context.Context, since, until time.Time) ([]models.CommodityScanUsage, error) {
+	type usageKey struct {
+		tenantID, groupID, provider, model string
+	}
+	r.mu.RLock()
+	buckets := make(map[usageKey]*models.CommodityScanUsage)
+	for _, item := range r.items {
+		if item.CreatedAt.Before(since) || !item.CreatedAt.Before(until) {
+			continue
+		}
+		if !providerAttemptStatus(item.Status) {
+			continue
+		}
+		key := usageKey{item.TenantID, item.GroupID, item.Provider, item.Model}
+		row, ok := buckets[key]
+		if !ok {
+			row = &models.CommodityScanUsage{
+				TenantID: item.TenantID,
+				GroupID:  item.GroupID,
+				Provider: item.Provider,
+				Model:    item.Model,
+				CostUSD:  decimal.Zero,
+			}
+			buckets[key] = row
+		}
+		row.Scans++
+		row.TokensUsed += int64(item.TokensUsed)
+		row.CostUSD = row.CostUSD.Add(item.CostUSD)
+	}
+	r.mu.RUnlock()
+
+	out := make([]models.CommodityScanUsage, 0, len(buckets))
+	for _, row := range buckets {
+		out = append(out, *row)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.TenantID != b.TenantID {
+			return a.TenantID < b.TenantID
+		}
+		if a.GroupID != b.GroupID {
+			return a.GroupID < b.GroupID
+		}
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		return a.Model < b.Model
+	})
+	return out, nil
+}
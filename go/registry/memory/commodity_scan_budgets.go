@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var _ registry.CommodityScanBudgetRegistry = (*CommodityScanBudgetRegistry)(nil)
+
+// CommodityScanBudgetRegistry is the in-memory implementation of the AI
+// vision spend caps. A single mutex serialises every operation, the
+// in-memory stand-in for the postgres unique index on (tenant_id,
+// group_id).
+type CommodityScanBudgetRegistry struct {
+	lock sync.Mutex
+	// items is keyed by (tenant_id, group_id).
+	items map[commodityScanBudgetKey]*models.CommodityScanBudget
+}
+
+type commodityScanBudgetKey struct {
+	tenantID, groupID string
+}
+
+// NewCommodityScanBudgetRegistry creates a new in-memory
+// CommodityScanBudgetRegistry.
+func NewCommodityScanBudgetRegistry() *CommodityScanBudgetRegistry {
+	return &CommodityScanBudgetRegistry{
+		items: make(map[commodityScanBudgetKey]*models.CommodityScanBudget),
+	}
+}
+
+// List returns every budget ordered by (tenant_id, group_id), as
+// defensive copies.
+func (r *CommodityScanBudgetRegistry) List(_ context.Context) ([]*models.CommodityScanBudget, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.sorted(""), nil
+}
+
+// ListForTenant returns the tenant's budgets, tenant-wide first.
+func (r *CommodityScanBudgetRegistry) ListForTenant(_ context.Context, tenantID string) ([]*models.CommodityScanBudget, error) {
+	if tenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.sorted(tenantID), nil
+}
+
+// Set creates or replaces the budget for (TenantID, GroupID).
+func (r *CommodityScanBudgetRegistry) Set(_ context.Context, budget models.CommodityScanBudget) (*models.CommodityScanBudget, error) {
+	if budget.TenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := commodityScanBudgetKey{budget.TenantID, budget.GroupID}
+	stored := cloneCommodityScanBudget(&budget)
+	if existing, ok := r.items[key]; ok {
+		stored.ID = existing.ID
+		stored.UUID = existing.UUID
+	} else {
+		stored.ID = uuid.New().String()
+		stored.UUID = uuid.New().String()
+	}
+	stored.UpdatedAt = time.Now().UTC()
+	r.items[key] = stored
+	return cloneCommodityScanBudget(stored), nil
+}
+
+// Delete removes the budget for (tenantID, groupID).
+func (r *CommodityScanBudgetRegistry) Delete(_ context.Context, tenantID, groupID string) error {
+	if tenantID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := commodityScanBudgetKey{tenantID, groupID}
+	if _, ok := r.items[key]; !ok {
+		return errxtrace.Classify(registry.ErrNotFound, errx.Attrs("tenant_id", tenantID, "group_id", groupID))
+	}
+	delete(r.items, key)
+	return nil
+}
+
+// sorted returns copies of the budgets of tenantID (every tenant when
+// empty) ordered by (tenant_id, group_id). Callers hold the lock.
+func (r *CommodityScanBudgetRegistry) sorted(tenantID string) []*models.CommodityScanBudget {
+	out := make([]*models.CommodityScanBudget, 0, len(r.items))
+	for key, b := range r.items {
+		if tenantID != "" && key.tenantID != tenantID {
+			continue
+		}
+		out = append(out, cloneCommodityScanBudget(b))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].TenantID != out[j].TenantID {
+			return out[i].TenantID < out[j].TenantID
+		}
+		return out[i].GroupID < out[j].GroupID
+	})
+	return out
+}
+
+// cloneCommodityScanBudget copies a budget row, duplicating the nullable
+// UpdatedBy so a caller can't reach the stored row.
+func cloneCommodityScanBudget(b *models.CommodityScanBudget) *models.CommodityScanBudget {
+	cp := *b
+	if cp.UpdatedBy != nil {
+		v := *cp.UpdatedBy
+		cp.UpdatedBy = &v
+	}
+	return &cp
+}
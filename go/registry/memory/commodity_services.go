@@ -216,3 +216,82 @@ func (r *CommodityServiceRegistry) CountOpenByCommodity(ctx context.Context, com
 	}
 	return out, nil
 }
+
+// ListPendingReminders mirrors CommodityLoanRegistry.ListPendingReminders
+// with expected_return_at standing in for due_back_at. Service-mode only;
+// the result is ordered by ID for deterministic iteration in tests.
+func (r *CommodityServiceRegistry) ListPendingReminders(ctx context.Context, kind registry.ServiceReminderKind, now time.Time, dueSoonDays int) ([]*models.CommodityService, error) {
+	if !kind.IsValid() {
+		return nil, registry.ErrInvalidInput
+	}
+	if r.userID != "" {
+		return nil, errxtrace.Wrap("ListPendingReminders requires service-mode registry", registry.ErrInvalidInput)
+	}
+	all, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	n := now.UTC()
+	today := time.Date(n.Year(), n.Month(), n.Day(), 0, 0, 0, 0, time.UTC)
+	out := make([]*models.CommodityService, 0, len(all))
+	for _, s := range all {
+		if s == nil || !s.IsOpen() || s.ExpectedReturnAt == nil || string(*s.ExpectedReturnAt) == "" {
+			continue
+		}
+		due := s.ExpectedReturnAt.ToTime()
+		if due.IsZero() {
+			continue
+		}
+		switch kind {
+		case registry.ServiceReminderKindOverdue:
+			if s.ReminderSentOverdue || !due.Before(today) {
+				continue
+			}
+		case registry.ServiceReminderKindDueSoon:
+			if s.ReminderSentDueSoon || due.Before(today) {
+				continue
+			}
+			if due.After(today.AddDate(0, 0, dueSoonDays)) {
+				continue
+			}
+		}
+		out = append(out, s)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// MarkReminderSent flips the in-memory flag under the write lock so the
+// check-and-flip is atomic. (false, nil) covers both "already sent" and
+// "not found", matching the loan registry.
+func (r *CommodityServiceRegistry) MarkReminderSent(_ context.Context, serviceID string, kind registry.ServiceReminderKind) (bool, error) {
+	if !kind.IsValid() {
+		return false, registry.ErrInvalidInput
+	}
+	if r.userID != "" {
+		return false, errxtrace.Wrap("MarkReminderSent requires service-mode registry", registry.ErrInvalidInput)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	svc, ok := r.items.Get(serviceID)
+	if !ok {
+		return false, nil
+	}
+	switch kind {
+	case registry.ServiceReminderKindOverdue:
+		if svc.ReminderSentOverdue {
+			return false, nil
+		}
+		svc.ReminderSentOverdue = true
+	case registry.ServiceReminderKindDueSoon:
+		if svc.ReminderSentDueSoon {
+			return false, nil
+		}
+		svc.ReminderSentDueSoon = true
+	}
+	return true, nil
+}
@@ -193,6 +193,65 @@ func TestCommodityServiceRegistry_Memory_GroupIsolation(t *testing.T) {
 	c.Assert(bList, qt.HasLen, 0, qt.Commentf("services created in group-A must not be visible to group-B"))
 }
 
+func TestCommodityServiceRegistry_Memory_PendingReminders(t *testing.T) {
+	c := qt.New(t)
+
+	svcFactory := memory.NewCommodityServiceRegistryFactory()
+	ctx := appctx.WithUser(c.Context(), &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "user-1"},
+			TenantID: "tenant-1",
+		},
+	})
+	ctx = appctx.WithGroup(ctx, &models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "group-1"},
+			TenantID: "tenant-1",
+		},
+		Slug: "group-1",
+	})
+	userReg := svcFactory.MustCreateUserRegistry(ctx)
+	serviceReg := svcFactory.CreateServiceRegistry()
+
+	pastDue := "2026-05-09"
+	overdueSvc, err := userReg.Create(ctx, makeService("commodity-1", "2026-05-01", &pastDue))
+	c.Assert(err, qt.IsNil)
+	dueSoon := "2026-05-17"
+	dueSoonSvc, err := userReg.Create(ctx, makeService("commodity-2", "2026-05-01", &dueSoon))
+	c.Assert(err, qt.IsNil)
+	later := "2026-05-18"
+	_, err = userReg.Create(ctx, makeService("commodity-3", "2026-05-01", &later))
+	c.Assert(err, qt.IsNil)
+	_, err = userReg.Create(ctx, makeService("commodity-4", "2026-05-01", nil))
+	c.Assert(err, qt.IsNil)
+
+	now := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+
+	_, err = userReg.ListPendingReminders(ctx, registry.ServiceReminderKindOverdue, now, 7)
+	c.Assert(err, qt.ErrorIs, registry.ErrInvalidInput)
+
+	overdue, err := serviceReg.ListPendingReminders(ctx, registry.ServiceReminderKindOverdue, now, 7)
+	c.Assert(err, qt.IsNil)
+	c.Assert(overdue, qt.HasLen, 1)
+	c.Assert(overdue[0].ID, qt.Equals, overdueSvc.ID)
+
+	soon, err := serviceReg.ListPendingReminders(ctx, registry.ServiceReminderKindDueSoon, now, 7)
+	c.Assert(err, qt.IsNil)
+	c.Assert(soon, qt.HasLen, 1)
+	c.Assert(soon[0].ID, qt.Equals, dueSoonSvc.ID)
+
+	flipped, err := serviceReg.MarkReminderSent(ctx, overdueSvc.ID, registry.ServiceReminderKindOverdue)
+	c.Assert(err, qt.IsNil)
+	c.Assert(flipped, qt.IsTrue)
+	flipped, err = serviceReg.MarkReminderSent(ctx, overdueSvc.ID, registry.ServiceReminderKindOverdue)
+	c.Assert(err, qt.IsNil)
+	c.Assert(flipped, qt.IsFalse)
+
+	overdue, err = serviceReg.ListPendingReminders(ctx, registry.ServiceReminderKindOverdue, now, 7)
+	c.Assert(err, qt.IsNil)
+	c.Assert(overdue, qt.HasLen, 0)
+}
+
 func TestCommodityServiceModel_CostPairValidation(t *testing.T) {
 	c := qt.New(t)
 
@@ -0,0 +1,106 @@
+package memory_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+func TestEntityVersion_CreateStartsAtOne(t *testing.T) {
+	c := qt.New(t)
+	fx := newScopeFixture(c)
+
+	c.Assert(fx.locationA.Version, qt.Equals, 1)
+	c.Assert(fx.areaA.Version, qt.Equals, 1)
+	c.Assert(fx.commodityA.Version, qt.Equals, 1)
+	c.Assert(fx.fileA.Version, qt.Equals, 1)
+}
+
+func TestEntityVersion_UpdateBumpsVersion(t *testing.T) {
+	c := qt.New(t)
+	fx := newScopeFixture(c)
+	regs := must.Must(fx.factorySet.CreateUserRegistrySet(fx.ctx))
+
+	area := *fx.areaA
+	area.Name = "A1 renamed"
+	updated, err := regs.AreaRegistry.Update(fx.ctx, area)
+	c.Assert(err, qt.IsNil)
+	c.Assert(updated.Version, qt.Equals, 2)
+
+	stored, err := regs.AreaRegistry.Get(fx.ctx, area.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stored.Version, qt.Equals, 2)
+	c.Assert(stored.Name, qt.Equals, "A1 renamed")
+}
+
+func TestEntityVersion_StaleUpdateConflicts(t *testing.T) {
+	c := qt.New(t)
+	fx := newScopeFixture(c)
+	regs := must.Must(fx.factorySet.CreateUserRegistrySet(fx.ctx))
+
+	first := *fx.commodityA
+	first.Name = "Drill v2"
+	_, err := regs.CommodityRegistry.Update(fx.ctx, first)
+	c.Assert(err, qt.IsNil)
+
+	// Second writer still holds version 1.
+	second := *fx.commodityA
+	second.Name = "Drill v2 (stale)"
+	_, err = regs.CommodityRegistry.Update(fx.ctx, second)
+	c.Assert(err, qt.ErrorIs, registry.ErrVersionConflict)
+
+	stored, err := regs.CommodityRegistry.Get(fx.ctx, fx.commodityA.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stored.Name, qt.Equals, "Drill v2")
+	c.Assert(stored.Version, qt.Equals, 2)
+}
+
+func TestEntityVersion_ZeroVersionSkipsCheck(t *testing.T) {
+	c := qt.New(t)
+	fx := newScopeFixture(c)
+	regs := must.Must(fx.factorySet.CreateUserRegistrySet(fx.ctx))
+
+	for _, name := range []string{"A v2", "A v3"} {
+		location := models.Location{
+			TenantGroupAwareEntityID: fx.locationA.TenantGroupAwareEntityID,
+			Name:                     name,
+		}
+		_, err := regs.LocationRegistry.Update(fx.ctx, location)
+		c.Assert(err, qt.IsNil)
+	}
+
+	stored, err := regs.LocationRegistry.Get(fx.ctx, fx.locationA.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stored.Name, qt.Equals, "A v3")
+	c.Assert(stored.Version, qt.Equals, 3)
+}
+
+func TestEntityVersion_ConditionalDelete(t *testing.T) {
+	c := qt.New(t)
+	fx := newScopeFixture(c)
+	regs := must.Must(fx.factorySet.CreateUserRegistrySet(fx.ctx))
+
+	file := *fx.fileA
+	file.Title = "renamed"
+	_, err := regs.FileRegistry.Update(fx.ctx, file)
+	c.Assert(err, qt.IsNil)
+
+	// A delete still expecting version 1 is refused and keeps the row.
+	stale := registry.WithExpectedVersion(fx.ctx, fx.fileA.ID, 1)
+	err = regs.FileRegistry.Delete(stale, fx.fileA.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrVersionConflict)
+	_, err = regs.FileRegistry.Get(fx.ctx, fx.fileA.ID)
+	c.Assert(err, qt.IsNil)
+
+	// The expectation is keyed by id: other deletes on the context pass.
+	c.Assert(regs.CommodityRegistry.Delete(stale, fx.commodityA.ID), qt.IsNil)
+
+	current := registry.WithExpectedVersion(fx.ctx, fx.fileA.ID, 2)
+	c.Assert(regs.FileRegistry.Delete(current, fx.fileA.ID), qt.IsNil)
+	_, err = regs.FileRegistry.Get(fx.ctx, fx.fileA.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 
 	"github.com/go-extras/errx"
 	errxtrace "github.com/go-extras/errx/stacktrace"
@@ -205,3 +206,35 @@ func (r *ExportRegistry) ListDeleted(ctx context.Context) ([]*models.Export, err
 func (r *ExportRegistry) HardDelete(ctx context.Context, id string) error {
 	return r.Registry.Delete(ctx, id)
 }
+
+// ListPendingReplication returns completed exports still waiting for an
+// off-site copy, oldest completion first
+func (r *ExportRegistry) ListPendingReplication(ctx context.Context, maxAttempts, limit int) ([]*models.Export, error) {
+	allExports, err := r.Registry.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*models.Export
+	for _, export := range allExports {
+		if export.IsDeleted() || export.Imported || export.FilePath == "" {
+			continue
+		}
+		if export.Status != models.ExportStatusCompleted || export.ReplicationStatus == models.ExportReplicationStatusReplicated {
+			continue
+		}
+		if export.ReplicationAttempts >= maxAttempts {
+			continue
+		}
+		pending = append(pending, export)
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CompletedDate.ToTime().Before(pending[j].CompletedDate.ToTime())
+	})
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	return pending, nil
+}
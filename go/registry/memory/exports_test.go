@@ -3,6 +3,7 @@ package memory_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/go-extras/go-kit/must"
@@ -104,3 +105,57 @@ func TestExportRegistry_Memory_DeleteClearsRestorePipeline(t *testing.T) {
 	_, err = rs.RestoreStepRegistry.Get(ctx, restoreStep.ID)
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
+
+func TestExportRegistry_Memory_ListPendingReplication(t *testing.T) {
+	c := qt.New(t)
+
+	factorySet := memory.NewFactorySet()
+	exportReg := factorySet.ExportRegistryFactory.CreateServiceRegistry()
+	ctx := context.Background()
+
+	base := time.Date(2026, 5, 13, 10, 0, 0, 0, time.UTC)
+	seed := func(desc string, hoursAgo int, mut func(*models.Export)) {
+		e := models.Export{
+			TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{TenantID: "t", GroupID: "g"},
+			Type:                     models.ExportTypeFullDatabase,
+			Status:                   models.ExportStatusCompleted,
+			Description:              desc,
+			FilePath:                 "t/t/exports/" + desc + ".inb",
+			CreatedDate:              models.NewPTimestamp(base),
+			CompletedDate:            models.NewPTimestamp(base.Add(-time.Duration(hoursAgo) * time.Hour)),
+		}
+		if mut != nil {
+			mut(&e)
+		}
+		_, err := exportReg.Create(ctx, e)
+		c.Assert(err, qt.IsNil)
+	}
+
+	seed("newer", 1, nil)
+	seed("older", 5, nil)
+	seed("retry", 3, func(e *models.Export) {
+		e.ReplicationStatus = models.ExportReplicationStatusFailed
+		e.ReplicationAttempts = 2
+	})
+	seed("exhausted", 4, func(e *models.Export) {
+		e.ReplicationStatus = models.ExportReplicationStatusFailed
+		e.ReplicationAttempts = 3
+	})
+	seed("replicated", 6, func(e *models.Export) { e.ReplicationStatus = models.ExportReplicationStatusReplicated })
+	seed("pending", 7, func(e *models.Export) { e.Status = models.ExportStatusPending })
+	seed("imported", 8, func(e *models.Export) { e.Imported = true })
+	seed("deleted", 9, func(e *models.Export) { e.DeletedAt = models.PNow() })
+
+	pending, err := exportReg.ListPendingReplication(ctx, 3, 10)
+	c.Assert(err, qt.IsNil)
+	var got []string
+	for _, e := range pending {
+		got = append(got, e.Description)
+	}
+	c.Assert(got, qt.DeepEquals, []string{"older", "retry", "newer"})
+
+	limited, err := exportReg.ListPendingReplication(ctx, 3, 1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(limited, qt.HasLen, 1)
+	c.Assert(limited[0].Description, qt.Equals, "older")
+}
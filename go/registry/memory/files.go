@@ -21,8 +21,9 @@ import (
 type FileRegistryFactory struct {
 	baseFileRegistry *Registry[models.FileEntity, *models.FileEntity]
 
-	// Sibling factories used ONLY by ListOrphanCandidates (#2237) to answer
-	// "does the entity this file is linked to still exist?". Postgres asks
+	// Sibling factories used by ListOrphanCandidates (#2237) to answer
+	// "does the entity this file is linked to still exist?", and by the
+	// location-scope filter to resolve a file's location. Postgres asks
 	// that with a SQL anti-join; the memory backend has no planner, so it
 	// has to hold the other registries. Wired by NewFactorySet via
 	// SetLinkedEntityFactories; nil for a bare NewFileRegistryFactory()
@@ -89,6 +90,7 @@ func (f *FileRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.
 		lock:    f.baseFileRegistry.lock,  // Share the mutex pointer
 		userID:  user.ID,                  // Set user-specific userID
 		groupID: groupID,                  // Set group-specific groupID
+		scoper:  newLocationScoper(ctx, models.GroupRoleUser, fileLocator(f)),
 	}
 
 	return &FileRegistry{
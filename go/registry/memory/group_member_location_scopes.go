@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var _ registry.GroupMemberLocationScopeRegistry = (*GroupMemberLocationScopeRegistry)(nil)
+
+type baseGroupMemberLocationScopeRegistry = Registry[models.GroupMemberLocationScope, *models.GroupMemberLocationScope]
+
+type GroupMemberLocationScopeRegistry struct {
+	*baseGroupMemberLocationScopeRegistry
+}
+
+func NewGroupMemberLocationScopeRegistry() *GroupMemberLocationScopeRegistry {
+	return &GroupMemberLocationScopeRegistry{
+		baseGroupMemberLocationScopeRegistry: NewRegistry[models.GroupMemberLocationScope, *models.GroupMemberLocationScope](),
+	}
+}
+
+func (r *GroupMemberLocationScopeRegistry) ListByMember(_ context.Context, tenantID, groupID, userID string) ([]*models.GroupMemberLocationScope, error) {
+	if tenantID == "" || groupID == "" || userID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id|member_user_id"))
+	}
+	return r.collect(func(s *models.GroupMemberLocationScope) bool {
+		return s.TenantID == tenantID && s.GroupID == groupID && s.MemberUserID == userID
+	}), nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) ListByGroup(_ context.Context, tenantID, groupID string) ([]*models.GroupMemberLocationScope, error) {
+	if tenantID == "" || groupID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id"))
+	}
+	return r.collect(func(s *models.GroupMemberLocationScope) bool {
+		return s.TenantID == tenantID && s.GroupID == groupID
+	}), nil
+}
+
+// ReplaceForMember holds the write lock across the delete and the
+// inserts so a concurrent reader never observes a half-replaced scope —
+// the postgres twin gets the same guarantee from its transaction.
+func (r *GroupMemberLocationScopeRegistry) ReplaceForMember(_ context.Context, tenantID, groupID, userID string, scopes []models.GroupMemberLocationScope) ([]*models.GroupMemberLocationScope, error) {
+	if tenantID == "" || groupID == "" || userID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id|member_user_id"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var toDelete []string
+	for pair := r.items.Oldest(); pair != nil; pair = pair.Next() {
+		s := pair.Value
+		if s.TenantID == tenantID && s.GroupID == groupID && s.MemberUserID == userID {
+			toDelete = append(toDelete, s.GetID())
+		}
+	}
+	for _, id := range toDelete {
+		r.items.Delete(id)
+	}
+
+	now := time.Now().UTC()
+	out := make([]*models.GroupMemberLocationScope, 0, len(scopes))
+	for _, scope := range scopes {
+		row := scope
+		row.ID = uuid.New().String()
+		row.UUID = uuid.New().String()
+		row.TenantID = tenantID
+		row.GroupID = groupID
+		row.MemberUserID = userID
+		row.CreatedAt = now
+		r.items.Set(row.ID, &row)
+		v := row
+		out = append(out, &v)
+	}
+	sortLocationScopes(out)
+	return out, nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) DeleteByGroup(_ context.Context, tenantID, groupID string) (int, error) {
+	if tenantID == "" || groupID == "" {
+		return 0, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id"))
+	}
+	return r.deleteWhere(func(s *models.GroupMemberLocationScope) bool {
+		return s.TenantID == tenantID && s.GroupID == groupID
+	}), nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) DeleteByUser(_ context.Context, userID string) error {
+	if userID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "member_user_id"))
+	}
+	r.deleteWhere(func(s *models.GroupMemberLocationScope) bool {
+		return s.MemberUserID == userID
+	})
+	return nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) collect(match func(*models.GroupMemberLocationScope) bool) []*models.GroupMemberLocationScope {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var out []*models.GroupMemberLocationScope
+	for pair := r.items.Oldest(); pair != nil; pair = pair.Next() {
+		if match(pair.Value) {
+			v := *pair.Value
+			out = append(out, &v)
+		}
+	}
+	sortLocationScopes(out)
+	return out
+}
+
+func (r *GroupMemberLocationScopeRegistry) deleteWhere(match func(*models.GroupMemberLocationScope) bool) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var toDelete []string
+	for pair := r.items.Oldest(); pair != nil; pair = pair.Next() {
+		if match(pair.Value) {
+			toDelete = append(toDelete, pair.Key)
+		}
+	}
+	for _, id := range toDelete {
+		r.items.Delete(id)
+	}
+	return len(toDelete)
+}
+
+// sortLocationScopes mirrors the postgres ORDER BY member_user_id,
+// location_id so both backends return rows in the same order.
+func sortLocationScopes(scopes []*models.GroupMemberLocationScope) {
+	slices.SortFunc(scopes, func(a, b *models.GroupMemberLocationScope) int {
+		return cmp.Or(
+			cmp.Compare(a.MemberUserID, b.MemberUserID),
+			cmp.Compare(a.LocationID, b.LocationID),
+		)
+	})
+}
@@ -33,9 +33,16 @@ type GroupPurger struct {
 	concurrencySlots     registry.UserConcurrencySlotRegistryFactory
 	maintenanceSchedules registry.MaintenanceScheduleRegistryFactory
 	maintenanceReminders registry.MaintenanceReminderRegistry
+	maintenanceLogs      registry.MaintenanceLogRegistryFactory
+	meterReadings        registry.CommodityMeterReadingRegistryFactory
+	invoiceExtractions   registry.InvoiceExtractionRegistryFactory
+	savedViews           registry.SavedViewRegistryFactory
+	backupSchedules      registry.BackupScheduleRegistryFactory
 	currencyMigrations   registry.CurrencyMigrationRegistryFactory
 	notificationPrefs    registry.GroupNotificationPrefRegistry
+	locationScopes       registry.GroupMemberLocationScopeRegistry
 	memberships          registry.GroupMembershipRegistry
+	calendarFeeds        registry.CalendarFeedRegistry
 }
 
 // NewGroupPurger wires a GroupPurger to the registry factories that own the
@@ -59,9 +66,16 @@ func NewGroupPurger(
 	concurrencySlots registry.UserConcurrencySlotRegistryFactory,
 	maintenanceSchedules registry.MaintenanceScheduleRegistryFactory,
 	maintenanceReminders registry.MaintenanceReminderRegistry,
+	maintenanceLogs registry.MaintenanceLogRegistryFactory,
+	meterReadings registry.CommodityMeterReadingRegistryFactory,
+	invoiceExtractions registry.InvoiceExtractionRegistryFactory,
+	savedViews registry.SavedViewRegistryFactory,
+	backupSchedules registry.BackupScheduleRegistryFactory,
 	currencyMigrations registry.CurrencyMigrationRegistryFactory,
 	notificationPrefs registry.GroupNotificationPrefRegistry,
+	locationScopes registry.GroupMemberLocationScopeRegistry,
 	memberships registry.GroupMembershipRegistry,
+	calendarFeeds registry.CalendarFeedRegistry,
 ) *GroupPurger {
 	return &GroupPurger{
 		locations:            locations,
@@ -80,9 +94,16 @@ func NewGroupPurger(
 		concurrencySlots:     concurrencySlots,
 		maintenanceSchedules: maintenanceSchedules,
 		maintenanceReminders: maintenanceReminders,
+		maintenanceLogs:      maintenanceLogs,
+		meterReadings:        meterReadings,
+		invoiceExtractions:   invoiceExtractions,
+		savedViews:           savedViews,
+		backupSchedules:      backupSchedules,
 		currencyMigrations:   currencyMigrations,
 		notificationPrefs:    notificationPrefs,
+		locationScopes:       locationScopes,
 		memberships:          memberships,
+		calendarFeeds:        calendarFeeds,
 	}
 }
 
@@ -158,6 +179,21 @@ func (r *GroupPurger) PurgeGroupDependents(ctx context.Context, tenantID, groupI
 			}
 			return nil
 		}},
+		// Maintenance logs before their schedules, meter readings before
+		// commodities; same explicit-delete reasoning as the schedules.
+		{"maintenance_logs", func() error {
+			reg := r.maintenanceLogs.CreateServiceRegistry()
+			return purgeByTenantGroup(ctx, tenantID, groupID, reg.List, reg.Delete)
+		}},
+		{"commodity_meter_readings", func() error {
+			reg := r.meterReadings.CreateServiceRegistry()
+			return purgeByTenantGroup(ctx, tenantID, groupID, reg.List, reg.Delete)
+		}},
+		// Invoice extractions reference both files and commodities.
+		{"invoice_extractions", func() error {
+			reg := r.invoiceExtractions.CreateServiceRegistry()
+			return purgeByTenantGroup(ctx, tenantID, groupID, reg.List, reg.Delete)
+		}},
 		// Maintenance schedules (#1368) dropped before commodities — FK
 		// is ON DELETE CASCADE but we mirror the postgres purger which
 		// deletes explicitly to keep tenant + group scoping local.
@@ -165,6 +201,18 @@ func (r *GroupPurger) PurgeGroupDependents(ctx context.Context, tenantID, groupI
 			reg := r.maintenanceSchedules.CreateServiceRegistry()
 			return purgeByTenantGroup(ctx, tenantID, groupID, reg.List, reg.Delete)
 		}},
+		// Saved views reference areas and tags only by value inside the
+		// filters blob, so their position in the order is free.
+		{"saved_views", func() error {
+			reg := r.savedViews.CreateServiceRegistry()
+			return purgeByTenantGroup(ctx, tenantID, groupID, reg.List, reg.Delete)
+		}},
+		// Backup schedules. The exports that reference them are already
+		// gone (exports.backup_schedule_id is SET NULL anyway).
+		{"backup_schedules", func() error {
+			reg := r.backupSchedules.CreateServiceRegistry()
+			return purgeByTenantGroup(ctx, tenantID, groupID, reg.List, reg.Delete)
+		}},
 		// Currency-migration audit rows (#2095) dropped before the migration
 		// rows. The audit slice is bespoke (not a generic registry), so use
 		// the Piece-A service-mode DeleteAuditRowsByGroup which mirrors the
@@ -220,9 +268,20 @@ func (r *GroupPurger) PurgeGroupDependents(ctx context.Context, tenantID, groupI
 			_, derr := r.notificationPrefs.DeleteByGroup(ctx, tenantID, groupID)
 			return derr
 		}},
+		// Per-member location scopes; FK to location_groups, so they drop
+		// before the memberships they qualify.
+		{"group_member_location_scopes", func() error {
+			_, derr := r.locationScopes.DeleteByGroup(ctx, tenantID, groupID)
+			return derr
+		}},
 		{"group_memberships", func() error {
 			return purgeMembershipsByTenantGroup(ctx, r.memberships, tenantID, groupID)
 		}},
+		// Group-scoped calendar feeds; postgres drops them through the
+		// fk_calendar_feed_group ON DELETE CASCADE.
+		{"calendar_feeds", func() error {
+			return r.purgeCalendarFeeds(ctx, tenantID, groupID)
+		}},
 	}
 	for _, s := range steps {
 		if err := s.run(); err != nil {
@@ -282,3 +341,21 @@ func (r *GroupPurger) purgeThumbnailChain(ctx context.Context, tenantID, groupID
 
 	return nil
 }
+
+// purgeCalendarFeeds deletes the calendar feeds scoped to the group. Feeds
+// spanning all of a user's groups (nil GroupID) are kept.
+func (r *GroupPurger) purgeCalendarFeeds(ctx context.Context, tenantID, groupID string) error {
+	feeds, err := r.calendarFeeds.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range feeds {
+		if f.TenantID != tenantID || f.GroupID == nil || *f.GroupID != groupID {
+			continue
+		}
+		if err := r.calendarFeeds.Delete(ctx, f.GetID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var _ registry.GroupTransferrer = (*GroupTransferrer)(nil)
+
+// GroupTransferrer is the in-memory counterpart to postgres.GroupTransferrer.
+// The group-scoped registries refuse to change a row's GroupID (Update
+// keeps the stored one), so it reaches into the shared base registries of
+// the factories and rewrites the rows in place.
+//
+// Like TenantPurger it accepts the whole FactorySet and must be wired after
+// every fs.* field is populated. The fields have to hold the memory
+// implementations; NewGroupTransferrer panics otherwise.
+type GroupTransferrer struct {
+	locations            *LocationRegistryFactory
+	areas                *AreaRegistryFactory
+	commodities          *Registry[models.Commodity, *models.Commodity]
+	events               *Registry[models.CommodityEvent, *models.CommodityEvent]
+	loans                *Registry[models.CommodityLoan, *models.CommodityLoan]
+	services             *Registry[models.CommodityService, *models.CommodityService]
+	supplyLinks          *Registry[models.SupplyLink, *models.SupplyLink]
+	maintenanceSchedules *Registry[models.MaintenanceSchedule, *models.MaintenanceSchedule]
+	maintenanceLogs      *Registry[models.MaintenanceLog, *models.MaintenanceLog]
+	maintenanceReminders *Registry[models.MaintenanceReminder, *models.MaintenanceReminder]
+	meterReadings        *Registry[models.CommodityMeterReading, *models.CommodityMeterReading]
+	warrantyReminders    *Registry[models.WarrantyReminder, *models.WarrantyReminder]
+	invoiceExtractions   *Registry[models.InvoiceExtraction, *models.InvoiceExtraction]
+	files                *Registry[models.FileEntity, *models.FileEntity]
+}
+
+// NewGroupTransferrer wires a GroupTransferrer to the base registries of a
+// populated memory FactorySet.
+func NewGroupTransferrer(fs *registry.FactorySet) *GroupTransferrer {
+	return &GroupTransferrer{
+		locations:            fs.LocationRegistryFactory.(*LocationRegistryFactory),
+		areas:                fs.AreaRegistryFactory.(*AreaRegistryFactory),
+		commodities:          fs.CommodityRegistryFactory.(*CommodityRegistryFactory).baseCommodityRegistry,
+		events:               fs.CommodityEventRegistryFactory.(*CommodityEventRegistryFactory).base,
+		loans:                fs.CommodityLoanRegistryFactory.(*CommodityLoanRegistryFactory).base,
+		services:             fs.CommodityServiceRegistryFactory.(*CommodityServiceRegistryFactory).base,
+		supplyLinks:          fs.SupplyLinkRegistryFactory.(*SupplyLinkRegistryFactory).base,
+		maintenanceSchedules: fs.MaintenanceScheduleRegistryFactory.(*MaintenanceScheduleRegistryFactory).base,
+		maintenanceLogs:      fs.MaintenanceLogRegistryFactory.(*MaintenanceLogRegistryFactory).base,
+		maintenanceReminders: fs.MaintenanceReminderRegistry.(*MaintenanceReminderRegistry).baseMaintenanceReminderRegistry,
+		meterReadings:        fs.CommodityMeterReadingRegistryFactory.(*CommodityMeterReadingRegistryFactory).base,
+		warrantyReminders:    fs.WarrantyReminderRegistry.(*WarrantyReminderRegistry).baseWarrantyReminderRegistry,
+		invoiceExtractions:   fs.InvoiceExtractionRegistryFactory.(*InvoiceExtractionRegistryFactory).base,
+		files:                fs.FileRegistryFactory.(*FileRegistryFactory).baseFileRegistry,
+	}
+}
+
+// TransferToGroup moves every row of t. All locations, areas and
+// commodities are checked against the source group before anything is
+// written, so a stale id fails with ErrNotFound and moves nothing. The
+// writes themselves are not atomic across registries — memory mode is only
+// used for tests and single-user dev setups.
+func (r *GroupTransferrer) TransferToGroup(_ context.Context, t registry.GroupTransfer) error {
+	if t.TenantID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+	if t.FromGroupID == "" || t.ToGroupID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "GroupID"))
+	}
+	if t.FromGroupID == t.ToGroupID {
+		return errxtrace.Classify(registry.ErrInvalidInput, errx.Attrs("group_id", t.ToGroupID))
+	}
+
+	locationIDs := idSet(t.LocationIDs)
+	areaTargets := make(map[string]string, len(t.Areas))
+	for _, a := range t.Areas {
+		areaTargets[a.ID] = a.LocationID
+	}
+	commodityRows := make(map[string]*registry.GroupTransferCommodity, len(t.Commodities))
+	for i := range t.Commodities {
+		commodityRows[t.Commodities[i].Commodity.ID] = &t.Commodities[i]
+	}
+
+	if err := requireInGroup(r.locations.baseLocationRegistry, t, t.LocationIDs); err != nil {
+		return err
+	}
+	if err := requireInGroup(r.areas.baseAreaRegistry, t, keys(areaTargets)); err != nil {
+		return err
+	}
+	if err := requireInGroup(r.commodities, t, keys(commodityRows)); err != nil {
+		return err
+	}
+
+	regroup(r.locations.baseLocationRegistry, t, func(l *models.Location) bool {
+		return locationIDs[l.ID]
+	})
+	regroup(r.areas.baseAreaRegistry, t, func(a *models.Area) bool {
+		target, ok := areaTargets[a.ID]
+		if !ok {
+			return false
+		}
+		if a.LocationID != target {
+			r.relinkArea(a.ID, a.LocationID, target)
+			a.LocationID = target
+		}
+		return true
+	})
+	regroup(r.commodities, t, func(c *models.Commodity) bool {
+		tc, ok := commodityRows[c.ID]
+		if !ok {
+			return false
+		}
+		if !ptrEqual(c.AreaID, tc.Commodity.AreaID) {
+			r.relinkCommodity(c.ID, c.AreaID, tc.Commodity.AreaID)
+			c.AreaID = tc.Commodity.AreaID
+		}
+		c.OriginalPrice = tc.Commodity.OriginalPrice
+		c.OriginalPriceCurrency = tc.Commodity.OriginalPriceCurrency
+		c.ConvertedOriginalPrice = tc.Commodity.ConvertedOriginalPrice
+		c.CurrentPrice = tc.Commodity.CurrentPrice
+		// Write-once, same guard as migrationops.SetAcquisition.
+		if tc.FillAcquisition && c.AcquisitionPrice == nil && c.AcquisitionCurrency == nil {
+			price, cur := tc.AcquisitionPrice, tc.AcquisitionCurrency
+			c.AcquisitionPrice = &price
+			c.AcquisitionCurrency = &cur
+		}
+		return true
+	})
+
+	commodityIDs := idSet(keys(commodityRows))
+	regroup(r.events, t, func(e *models.CommodityEvent) bool { return commodityIDs[e.CommodityID] })
+	regroup(r.loans, t, func(l *models.CommodityLoan) bool { return commodityIDs[l.CommodityID] })
+	regroup(r.services, t, func(s *models.CommodityService) bool { return commodityIDs[s.CommodityID] })
+	regroup(r.supplyLinks, t, func(s *models.SupplyLink) bool { return commodityIDs[s.CommodityID] })
+	scheduleIDs := make(map[string]bool)
+	regroup(r.maintenanceSchedules, t, func(s *models.MaintenanceSchedule) bool {
+		if !commodityIDs[s.CommodityID] {
+			return false
+		}
+		scheduleIDs[s.ID] = true
+		return true
+	})
+	regroup(r.maintenanceLogs, t, func(l *models.MaintenanceLog) bool { return commodityIDs[l.CommodityID] })
+	regroup(r.maintenanceReminders, t, func(m *models.MaintenanceReminder) bool { return scheduleIDs[m.ScheduleID] })
+	regroup(r.meterReadings, t, func(m *models.CommodityMeterReading) bool { return commodityIDs[m.CommodityID] })
+	regroup(r.warrantyReminders, t, func(w *models.WarrantyReminder) bool { return commodityIDs[w.CommodityID] })
+	regroup(r.invoiceExtractions, t, func(e *models.InvoiceExtraction) bool { return commodityIDs[e.CommodityID] })
+
+	regroup(r.files, t, func(f *models.FileEntity) bool {
+		switch f.LinkedEntityType {
+		case "commodity":
+			return commodityIDs[f.LinkedEntityID]
+		case "area":
+			_, ok := areaTargets[f.LinkedEntityID]
+			return ok
+		case "location":
+			return locationIDs[f.LinkedEntityID]
+		}
+		return false
+	})
+	return nil
+}
+
+// relinkArea moves an area between locations in the relationship index the
+// location delete-guard reads.
+func (r *GroupTransferrer) relinkArea(areaID, fromLocationID, toLocationID string) {
+	reg := r.locations.CreateServiceRegistry().(*LocationRegistry)
+	_ = reg.DeleteArea(context.Background(), fromLocationID, areaID)
+	_ = reg.AddArea(context.Background(), toLocationID, areaID)
+}
+
+// relinkCommodity moves a commodity between areas in the relationship index
+// the area delete-guard reads.
+func (r *GroupTransferrer) relinkCommodity(commodityID string, fromAreaID, toAreaID *string) {
+	reg := r.areas.CreateServiceRegistry().(*AreaRegistry)
+	if fromAreaID != nil {
+		_ = reg.DeleteCommodity(context.Background(), *fromAreaID, commodityID)
+	}
+	if toAreaID != nil {
+		_ = reg.AddCommodity(context.Background(), *toAreaID, commodityID)
+	}
+}
+
+// requireInGroup reports ErrNotFound for the first id that is missing from
+// reg or not in (t.TenantID, t.FromGroupID).
+func requireInGroup[T any, P registry.PIDable[T]](reg *Registry[T, P], t registry.GroupTransfer, ids []string) error {
+	reg.lock.RLock()
+	defer reg.lock.RUnlock()
+
+	for _, id := range ids {
+		item, ok := reg.items.Get(id)
+		if !ok || !inTenantGroup(item, t.TenantID, t.FromGroupID) {
+			return errxtrace.Classify(registry.ErrNotFound, errx.Attrs("id", id))
+		}
+	}
+	return nil
+}
+
+// regroup moves the rows of reg that sit in (t.TenantID, t.FromGroupID) and
+// satisfy match to t.ToGroupID. match receives a copy of the row and may
+// edit it; the copy replaces the stored row, so callers holding the old
+// pointer keep seeing the old values.
+func regroup[T any, P registry.PIDable[T]](reg *Registry[T, P], t registry.GroupTransfer, match func(P) bool) {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+
+	for pair := reg.items.Oldest(); pair != nil; pair = pair.Next() {
+		if !inTenantGroup(pair.Value, t.TenantID, t.FromGroupID) {
+			continue
+		}
+		cp := *pair.Value
+		row := P(&cp)
+		if !match(row) {
+			continue
+		}
+		any(row).(models.GroupAware).SetGroupID(t.ToGroupID)
+		pair.Value = row
+	}
+}
+
+func inTenantGroup(item any, tenantID, groupID string) bool {
+	ga, ok := item.(models.TenantGroupAware)
+	return ok && ga.GetTenantID() == tenantID && ga.GetGroupID() == groupID
+}
+
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+func ptrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
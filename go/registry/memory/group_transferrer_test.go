@@ -0,0 +1,93 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func newGroupTransferFixture(c *qt.C) (*registry.FactorySet, context.Context, *registry.Set) {
+	c.Helper()
+	factorySet := memory.NewFactorySet()
+	u, err := factorySet.CreateServiceRegistrySet().UserRegistry.Create(context.Background(), models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: "xfer-tenant"},
+		Email:               "xfer@example.com",
+		Name:                "Transfer Test User",
+	})
+	c.Assert(err, qt.IsNil)
+
+	ctx := appctx.WithUser(context.Background(), u)
+	ctx = appctx.WithGroup(ctx, &models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "group-a"},
+			TenantID: "xfer-tenant",
+		},
+	})
+	return factorySet, ctx, must.Must(factorySet.CreateUserRegistrySet(ctx))
+}
+
+func TestGroupTransferrer_TransferToGroup(t *testing.T) {
+	c := qt.New(t)
+	factorySet, ctx, regSet := newGroupTransferFixture(c)
+
+	loc, err := regSet.LocationRegistry.Create(ctx, models.Location{Name: "L1"})
+	c.Assert(err, qt.IsNil)
+	area, err := regSet.AreaRegistry.Create(ctx, models.Area{Name: "A1", LocationID: loc.ID})
+	c.Assert(err, qt.IsNil)
+	commodity, err := regSet.CommodityRegistry.Create(ctx, models.Commodity{Name: "C1", AreaID: &area.ID})
+	c.Assert(err, qt.IsNil)
+	schedule, err := regSet.MaintenanceScheduleRegistry.Create(ctx, models.MaintenanceSchedule{
+		CommodityID: commodity.ID,
+		Title:       "Oil",
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = factorySet.GroupTransferrer.TransferToGroup(ctx, registry.GroupTransfer{
+		TenantID:    "xfer-tenant",
+		FromGroupID: "group-a",
+		ToGroupID:   "group-b",
+		LocationIDs: []string{loc.ID},
+		Areas:       []registry.GroupTransferArea{{ID: area.ID, LocationID: loc.ID}},
+		Commodities: []registry.GroupTransferCommodity{{Commodity: *commodity}},
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = regSet.CommodityRegistry.Get(ctx, commodity.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	_, err = regSet.MaintenanceScheduleRegistry.Get(ctx, schedule.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	moved, err := factorySet.CommodityRegistryFactory.CreateServiceRegistry().Get(ctx, commodity.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(moved.GroupID, qt.Equals, "group-b")
+	movedSchedule, err := factorySet.MaintenanceScheduleRegistryFactory.CreateServiceRegistry().Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(movedSchedule.GroupID, qt.Equals, "group-b")
+}
+
+func TestGroupTransferrer_TransferToGroup_StaleIDMovesNothing(t *testing.T) {
+	c := qt.New(t)
+	factorySet, ctx, regSet := newGroupTransferFixture(c)
+
+	loc, err := regSet.LocationRegistry.Create(ctx, models.Location{Name: "L1"})
+	c.Assert(err, qt.IsNil)
+
+	err = factorySet.GroupTransferrer.TransferToGroup(ctx, registry.GroupTransfer{
+		TenantID:    "xfer-tenant",
+		FromGroupID: "group-a",
+		ToGroupID:   "group-b",
+		LocationIDs: []string{loc.ID, "missing"},
+	})
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	got, err := regSet.LocationRegistry.Get(ctx, loc.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.GroupID, qt.Equals, "group-a")
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var _ registry.IdempotencyKeyRegistry = (*IdempotencyKeyRegistry)(nil)
+
+// IdempotencyKeyRegistry is the in-memory Idempotency-Key store. A single
+// mutex serialises every operation, which is what makes Reserve's
+// lookup-and-insert atomic.
+type IdempotencyKeyRegistry struct {
+	lock sync.Mutex
+	// items is keyed by record id.
+	items map[string]*models.IdempotencyKey
+}
+
+// NewIdempotencyKeyRegistry creates a new in-memory IdempotencyKeyRegistry.
+func NewIdempotencyKeyRegistry() *IdempotencyKeyRegistry {
+	return &IdempotencyKeyRegistry{
+		items: make(map[string]*models.IdempotencyKey),
+	}
+}
+
+// Reserve claims the user's key unless a live record holds it.
+func (r *IdempotencyKeyRegistry) Reserve(_ context.Context, rec models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	if rec.TenantID == "" {
+		return nil, false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+	if rec.UserID == "" {
+		return nil, false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "user_id"))
+	}
+	if rec.Key == "" {
+		return nil, false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "idempotency_key"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, existing := range r.items {
+		if existing.UserID != rec.UserID || existing.Key != rec.Key {
+			continue
+		}
+		if !existing.IsExpired() {
+			return cloneIdempotencyKey(existing), false, nil
+		}
+		delete(r.items, id)
+	}
+
+	stored := cloneIdempotencyKey(&rec)
+	stored.ID = uuid.New().String()
+	stored.UUID = uuid.New().String()
+	stored.StatusCode = 0
+	stored.ContentType = ""
+	stored.ResponseBody = nil
+	stored.CreatedAt = time.Now().UTC()
+	r.items[stored.ID] = stored
+	return cloneIdempotencyKey(stored), true, nil
+}
+
+// Complete stores the response on the reservation.
+func (r *IdempotencyKeyRegistry) Complete(_ context.Context, id string, statusCode int, contentType string, body []byte, expiresAt time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	stored, ok := r.items[id]
+	if !ok {
+		return errxtrace.Classify(registry.ErrNotFound, errx.Attrs("id", id))
+	}
+	stored.StatusCode = statusCode
+	stored.ContentType = contentType
+	stored.ResponseBody = slices.Clone(body)
+	stored.ExpiresAt = expiresAt
+	return nil
+}
+
+// Release deletes the reservation, if any.
+func (r *IdempotencyKeyRegistry) Release(_ context.Context, id string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.items, id)
+	return nil
+}
+
+// DeleteByUserID removes all records of the user.
+func (r *IdempotencyKeyRegistry) DeleteByUserID(_ context.Context, userID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, stored := range r.items {
+		if stored.UserID == userID {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
+
+// DeleteByTenantID removes all records of the tenant.
+func (r *IdempotencyKeyRegistry) DeleteByTenantID(_ context.Context, tenantID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, stored := range r.items {
+		if stored.TenantID == tenantID {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
+
+// DeleteExpired removes all records whose ExpiresAt timestamp is in the past.
+func (r *IdempotencyKeyRegistry) DeleteExpired(_ context.Context) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	deleted := 0
+	for id, stored := range r.items {
+		if stored.IsExpired() {
+			delete(r.items, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// cloneIdempotencyKey copies a record, duplicating the body so a caller
+// can't reach the stored bytes.
+func cloneIdempotencyKey(rec *models.IdempotencyKey) *models.IdempotencyKey {
+	cp := *rec
+	cp.ResponseBody = slices.Clone(rec.ResponseBody)
+	return &cp
+}
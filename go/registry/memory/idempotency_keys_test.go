@@ -0,0 +1,86 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func newIdempotencyKey(userID, key string, expiresIn time.Duration) models.IdempotencyKey {
+	return models.IdempotencyKey{
+		TenantID:    "tenant-1",
+		UserID:      userID,
+		Key:         key,
+		RequestHash: "hash-" + key,
+		ExpiresAt:   time.Now().Add(expiresIn),
+	}
+}
+
+func TestIdempotencyKeyRegistry_ReserveAndComplete(t *testing.T) {
+	c := qt.New(t)
+	ctx := c.Context()
+	reg := memory.NewIdempotencyKeyRegistry()
+
+	rec, created, err := reg.Reserve(ctx, newIdempotencyKey("user-1", "k", time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsTrue)
+	c.Assert(rec.IsCompleted(), qt.IsFalse)
+
+	// A second reservation sees the in-flight record.
+	again, created, err := reg.Reserve(ctx, newIdempotencyKey("user-1", "k", time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsFalse)
+	c.Assert(again.ID, qt.Equals, rec.ID)
+
+	// Keys are scoped per user.
+	_, created, err = reg.Reserve(ctx, newIdempotencyKey("user-2", "k", time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsTrue)
+
+	err = reg.Complete(ctx, rec.ID, 201, "application/json", []byte(`{"ok":true}`), time.Now().Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+
+	stored, created, err := reg.Reserve(ctx, newIdempotencyKey("user-1", "k", time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsFalse)
+	c.Assert(stored.StatusCode, qt.Equals, 201)
+	c.Assert(stored.ContentType, qt.Equals, "application/json")
+	c.Assert(string(stored.ResponseBody), qt.Equals, `{"ok":true}`)
+
+	err = reg.Complete(ctx, "missing", 201, "", nil, time.Now())
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
+
+func TestIdempotencyKeyRegistry_ExpiredAndReleased(t *testing.T) {
+	c := qt.New(t)
+	ctx := c.Context()
+	reg := memory.NewIdempotencyKeyRegistry()
+
+	expired, _, err := reg.Reserve(ctx, newIdempotencyKey("user-1", "old", -time.Minute))
+	c.Assert(err, qt.IsNil)
+
+	// An expired record no longer holds the key.
+	fresh, created, err := reg.Reserve(ctx, newIdempotencyKey("user-1", "old", time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsTrue)
+	c.Assert(fresh.ID, qt.Not(qt.Equals), expired.ID)
+
+	c.Assert(reg.Release(ctx, fresh.ID), qt.IsNil)
+	_, created, err = reg.Reserve(ctx, newIdempotencyKey("user-1", "old", -time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsTrue)
+	_, _, err = reg.Reserve(ctx, newIdempotencyKey("user-1", "live", time.Minute))
+	c.Assert(err, qt.IsNil)
+
+	deleted, err := reg.DeleteExpired(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.Equals, 1)
+
+	_, _, err = reg.Reserve(ctx, models.IdempotencyKey{TenantID: "tenant-1", UserID: "user-1"})
+	c.Assert(err, qt.ErrorIs, registry.ErrFieldRequired)
+}
@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// InvoiceExtractionRegistryFactory creates InvoiceExtractionRegistry
+// instances with proper context. All per-request registries share the
+// base registry's backing map.
+type InvoiceExtractionRegistryFactory struct {
+	base *Registry[models.InvoiceExtraction, *models.InvoiceExtraction]
+}
+
+// InvoiceExtractionRegistry is the context-aware in-memory registry of
+// invoice extractions.
+type InvoiceExtractionRegistry struct {
+	*Registry[models.InvoiceExtraction, *models.InvoiceExtraction]
+
+	userID string
+}
+
+var (
+	_ registry.InvoiceExtractionRegistry        = (*InvoiceExtractionRegistry)(nil)
+	_ registry.InvoiceExtractionRegistryFactory = (*InvoiceExtractionRegistryFactory)(nil)
+)
+
+func NewInvoiceExtractionRegistryFactory() *InvoiceExtractionRegistryFactory {
+	return &InvoiceExtractionRegistryFactory{
+		base: NewRegistry[models.InvoiceExtraction, *models.InvoiceExtraction](),
+	}
+}
+
+func (f *InvoiceExtractionRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.InvoiceExtractionRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *InvoiceExtractionRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.InvoiceExtractionRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+
+	groupID := appctx.GroupIDFromContext(ctx)
+	userRegistry := &Registry[models.InvoiceExtraction, *models.InvoiceExtraction]{
+		items:   f.base.items,
+		lock:    f.base.lock,
+		userID:  user.ID,
+		groupID: groupID,
+	}
+
+	return &InvoiceExtractionRegistry{
+		Registry: userRegistry,
+		userID:   user.ID,
+	}, nil
+}
+
+func (f *InvoiceExtractionRegistryFactory) CreateServiceRegistry() registry.InvoiceExtractionRegistry {
+	serviceRegistry := &Registry[models.InvoiceExtraction, *models.InvoiceExtraction]{
+		items:  f.base.items,
+		lock:   f.base.lock,
+		userID: "",
+	}
+
+	return &InvoiceExtractionRegistry{
+		Registry: serviceRegistry,
+		userID:   "",
+	}
+}
+
+// Create stores a new extraction. A second row for the same file is
+// rejected with ErrAlreadyExists, mirroring the unique file_id index of
+// the postgres table.
+func (r *InvoiceExtractionRegistry) Create(ctx context.Context, extraction models.InvoiceExtraction) (*models.InvoiceExtraction, error) {
+	r.lock.RLock()
+	for pair := r.items.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.FileID == extraction.FileID {
+			r.lock.RUnlock()
+			return nil, errxtrace.Classify(registry.ErrAlreadyExists, errx.Attrs("file_id", extraction.FileID))
+		}
+	}
+	r.lock.RUnlock()
+
+	extraction.CreatedAt = time.Now()
+	created, err := r.Registry.CreateWithUser(ctx, extraction)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create invoice extraction", err)
+	}
+	return created, nil
+}
+
+func (r *InvoiceExtractionRegistry) Update(ctx context.Context, extraction models.InvoiceExtraction) (*models.InvoiceExtraction, error) {
+	updated, err := r.Registry.UpdateWithUser(ctx, extraction)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update invoice extraction", err)
+	}
+	return updated, nil
+}
+
+// GetByFileID returns the extraction of one file.
+func (r *InvoiceExtractionRegistry) GetByFileID(ctx context.Context, fileID string) (*models.InvoiceExtraction, error) {
+	all, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range all {
+		if e.FileID == fileID {
+			return e, nil
+		}
+	}
+	return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "InvoiceExtraction"))
+}
+
+// ListByStatus returns the extractions in the given status (every
+// status when empty), oldest first.
+func (r *InvoiceExtractionRegistry) ListByStatus(ctx context.Context, status models.InvoiceExtractionStatus) ([]*models.InvoiceExtraction, error) {
+	all, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.InvoiceExtraction, 0, len(all))
+	for _, e := range all {
+		if status != "" && e.Status != status {
+			continue
+		}
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+// ListByCommodity returns the extractions that target one commodity,
+// oldest first.
+func (r *InvoiceExtractionRegistry) ListByCommodity(ctx context.Context, commodityID string) ([]*models.InvoiceExtraction, error) {
+	all, err := r.ListByStatus(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.InvoiceExtraction, 0, len(all))
+	for _, e := range all {
+		if e.CommodityID == commodityID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
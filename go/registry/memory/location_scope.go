@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+)
+
+// locationScoper narrows a per-request registry to the locations a
+// restricted group member may access. It is the memory twin of the
+// location_scope_allows() terms in the postgres RLS policies: reads
+// outside the scope are invisible, writes need writeRole on the row's
+// location. locate resolves a row to its location ID ("" when the row
+// has none, which a restricted member can never access).
+type locationScoper[P any] struct {
+	scope     models.LocationScope
+	locate    func(P) string
+	writeRole models.GroupRole
+}
+
+// newLocationScoper returns a scoper for the location scope carried by
+// ctx, or nil when the member is unrestricted.
+func newLocationScoper[P any](ctx context.Context, writeRole models.GroupRole, locate func(P) string) *locationScoper[P] {
+	scope := appctx.LocationScopeFromContext(ctx)
+	if !scope.Restricted() {
+		return nil
+	}
+	return &locationScoper[P]{
+		scope:     scope,
+		locate:    locate,
+		writeRole: writeRole,
+	}
+}
+
+func (s *locationScoper[P]) canRead(item P) bool {
+	return s == nil || s.scope.Allows(s.locate(item), models.GroupRoleViewer)
+}
+
+func (s *locationScoper[P]) canWrite(item P) bool {
+	return s == nil || s.scope.Allows(s.locate(item), s.writeRole)
+}
+
+// The locators below read the shared base registries directly rather
+// than through a per-request registry: they run while the caller holds
+// its own registry lock, and going through a scoped registry would
+// recurse into the scope check. Lock order is always file/loan ->
+// commodity -> area, so they cannot deadlock.
+
+func locateLocation(l *models.Location) string {
+	return l.ID
+}
+
+func locateArea(a *models.Area) string {
+	return a.LocationID
+}
+
+func areaLocation(areas *Registry[models.Area, *models.Area], areaID string) string {
+	if areas == nil || areaID == "" {
+		return ""
+	}
+	area, ok := areas.peek(areaID)
+	if !ok {
+		return ""
+	}
+	return area.LocationID
+}
+
+func commodityLocation(commodities *CommodityRegistryFactory, commodityID string) string {
+	if commodities == nil || commodityID == "" {
+		return ""
+	}
+	commodity, ok := commodities.baseCommodityRegistry.peek(commodityID)
+	if !ok || commodity.AreaID == nil {
+		return ""
+	}
+	return areaLocation(commodities.areaRegistry.baseAreaRegistry, *commodity.AreaID)
+}
+
+func commodityLocator(areas *AreaRegistryFactory) func(*models.Commodity) string {
+	return func(c *models.Commodity) string {
+		if c.AreaID == nil {
+			return ""
+		}
+		return areaLocation(areas.baseAreaRegistry, *c.AreaID)
+	}
+}
+
+func loanLocator(commodities *CommodityRegistryFactory) func(*models.CommodityLoan) string {
+	return func(l *models.CommodityLoan) string {
+		return commodityLocation(commodities, l.CommodityID)
+	}
+}
+
+// fileLocator mirrors file_location_scope_allows(): only files linked to
+// a location, area or commodity resolve; standalone and export files stay
+// out of reach of a restricted member.
+func fileLocator(f *FileRegistryFactory) func(*models.FileEntity) string {
+	return func(file *models.FileEntity) string {
+		switch file.LinkedEntityType {
+		case "location":
+			return file.LinkedEntityID
+		case "area":
+			if f.areaFactory == nil {
+				return ""
+			}
+			return areaLocation(f.areaFactory.baseAreaRegistry, file.LinkedEntityID)
+		case "commodity":
+			return commodityLocation(f.commodityFactory, file.LinkedEntityID)
+		default:
+			return ""
+		}
+	}
+}
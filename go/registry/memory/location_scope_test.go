@@ -0,0 +1,199 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+type scopeFixture struct {
+	factorySet *registry.FactorySet
+	ctx        context.Context
+
+	locationA, locationB   *models.Location
+	areaA, areaB           *models.Area
+	commodityA, commodityB *models.Commodity
+	fileA, fileB           *models.FileEntity
+	loanA, loanB           *models.CommodityLoan
+}
+
+// newScopeFixture builds two parallel location trees (A and B) in one
+// group through an unrestricted registry set.
+func newScopeFixture(c *qt.C) *scopeFixture {
+	c.Helper()
+
+	fs := memory.NewFactorySet()
+	ctx := appctx.WithUser(c.Context(), &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "user-1"},
+			TenantID: "tenant-1",
+		},
+	})
+	ctx = appctx.WithGroup(ctx, &models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "group-1"},
+			TenantID: "tenant-1",
+		},
+		Slug: "group-1",
+	})
+	regs := must.Must(fs.CreateUserRegistrySet(ctx))
+
+	fx := &scopeFixture{factorySet: fs, ctx: ctx}
+	fx.locationA = must.Must(regs.LocationRegistry.Create(ctx, models.Location{Name: "A"}))
+	fx.locationB = must.Must(regs.LocationRegistry.Create(ctx, models.Location{Name: "B"}))
+	fx.areaA = must.Must(regs.AreaRegistry.Create(ctx, models.Area{Name: "A1", LocationID: fx.locationA.ID}))
+	fx.areaB = must.Must(regs.AreaRegistry.Create(ctx, models.Area{Name: "B1", LocationID: fx.locationB.ID}))
+	fx.commodityA = must.Must(regs.CommodityRegistry.Create(ctx, models.Commodity{Name: "Drill", AreaID: new(fx.areaA.ID)}))
+	fx.commodityB = must.Must(regs.CommodityRegistry.Create(ctx, models.Commodity{Name: "Saw", AreaID: new(fx.areaB.ID)}))
+	fx.fileA = must.Must(regs.FileRegistry.Create(ctx, models.FileEntity{Title: "a.jpg", LinkedEntityType: "commodity", LinkedEntityID: fx.commodityA.ID}))
+	fx.fileB = must.Must(regs.FileRegistry.Create(ctx, models.FileEntity{Title: "b.jpg", LinkedEntityType: "area", LinkedEntityID: fx.areaB.ID}))
+	fx.loanA = must.Must(regs.CommodityLoanRegistry.Create(ctx, models.CommodityLoan{CommodityID: fx.commodityA.ID, BorrowerName: "Alice", LentAt: models.Date("2026-05-01")}))
+	fx.loanB = must.Must(regs.CommodityLoanRegistry.Create(ctx, models.CommodityLoan{CommodityID: fx.commodityB.ID, BorrowerName: "Bob", LentAt: models.Date("2026-05-01")}))
+	return fx
+}
+
+func (fx *scopeFixture) scopedSet(c *qt.C, scope models.LocationScope) (context.Context, *registry.Set) {
+	c.Helper()
+	ctx := appctx.WithLocationScope(fx.ctx, scope)
+	return ctx, must.Must(fx.factorySet.CreateUserRegistrySet(ctx))
+}
+
+func TestLocationScope_Memory_ReadsAreNarrowed(t *testing.T) {
+	c := qt.New(t)
+	fx := newScopeFixture(c)
+	ctx, regs := fx.scopedSet(c, models.LocationScope{fx.locationA.ID: models.GroupRoleViewer})
+
+	locations := must.Must(regs.LocationRegistry.List(ctx))
+	c.Assert(locations, qt.HasLen, 1)
+	c.Assert(locations[0].ID, qt.Equals, fx.locationA.ID)
+
+	areas := must.Must(regs.AreaRegistry.List(ctx))
+	c.Assert(areas, qt.HasLen, 1)
+	c.Assert(areas[0].ID, qt.Equals, fx.areaA.ID)
+
+	commodities := must.Must(regs.CommodityRegistry.List(ctx))
+	c.Assert(commodities, qt.HasLen, 1)
+	c.Assert(commodities[0].ID, qt.Equals, fx.commodityA.ID)
+
+	files := must.Must(regs.FileRegistry.List(ctx))
+	c.Assert(files, qt.HasLen, 1)
+	c.Assert(files[0].ID, qt.Equals, fx.fileA.ID)
+
+	loans := must.Must(regs.CommodityLoanRegistry.List(ctx))
+	c.Assert(loans, qt.HasLen, 1)
+	c.Assert(loans[0].ID, qt.Equals, fx.loanA.ID)
+
+	// Out-of-scope rows read as missing, never as forbidden.
+	_, err := regs.CommodityRegistry.Get(ctx, fx.commodityB.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	_, err = regs.FileRegistry.Get(ctx, fx.fileB.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	err = regs.CommodityRegistry.Delete(ctx, fx.commodityB.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	// An unrestricted set in the same group still sees everything.
+	all := must.Must(must.Must(fx.factorySet.CreateUserRegistrySet(fx.ctx)).CommodityRegistry.List(fx.ctx))
+	c.Assert(all, qt.HasLen, 2)
+}
+
+func TestLocationScope_Memory_WritesNeedScopedRole(t *testing.T) {
+	c := qt.New(t)
+	fx := newScopeFixture(c)
+
+	c.Run("viewer cannot write", func(c *qt.C) {
+		ctx, regs := fx.scopedSet(c, models.LocationScope{fx.locationA.ID: models.GroupRoleViewer})
+
+		commodity := *fx.commodityA
+		commodity.Name = "Renamed"
+		_, err := regs.CommodityRegistry.Update(ctx, commodity)
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+
+		err = regs.CommodityLoanRegistry.Delete(ctx, fx.loanA.ID)
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+	})
+
+	c.Run("user writes content only inside scope", func(c *qt.C) {
+		ctx, regs := fx.scopedSet(c, models.LocationScope{fx.locationA.ID: models.GroupRoleUser})
+
+		commodity := *fx.commodityA
+		commodity.Name = "Renamed"
+		updated, err := regs.CommodityRegistry.Update(ctx, commodity)
+		c.Assert(err, qt.IsNil)
+		c.Assert(updated.Name, qt.Equals, "Renamed")
+
+		// Moving the commodity into an area outside the scope is a write
+		// to that location too.
+		commodity.AreaID = new(fx.areaB.ID)
+		_, err = regs.CommodityRegistry.Update(ctx, commodity)
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+
+		_, err = regs.CommodityRegistry.Create(ctx, models.Commodity{Name: "Hammer", AreaID: new(fx.areaB.ID)})
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+
+		// Areas are structural and need admin on the location.
+		area := *fx.areaA
+		area.Name = "Renamed"
+		_, err = regs.AreaRegistry.Update(ctx, area)
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+	})
+
+	c.Run("admin scope manages areas but not new locations", func(c *qt.C) {
+		ctx, regs := fx.scopedSet(c, models.LocationScope{fx.locationA.ID: models.GroupRoleAdmin})
+
+		_, err := regs.AreaRegistry.Create(ctx, models.Area{Name: "A2", LocationID: fx.locationA.ID})
+		c.Assert(err, qt.IsNil)
+
+		_, err = regs.LocationRegistry.Create(ctx, models.Location{Name: "C"})
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+	})
+}
+
+func TestGroupMemberLocationScopeRegistry_Memory(t *testing.T) {
+	c := qt.New(t)
+	reg := memory.NewGroupMemberLocationScopeRegistry()
+	ctx := c.Context()
+
+	stored, err := reg.ReplaceForMember(ctx, "tenant-1", "group-1", "user-2", []models.GroupMemberLocationScope{
+		{LocationID: "loc-b", Role: models.GroupRoleUser},
+		{LocationID: "loc-a", Role: models.GroupRoleViewer},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(stored, qt.HasLen, 2)
+	c.Assert(stored[0].LocationID, qt.Equals, "loc-a")
+	c.Assert(stored[0].GroupID, qt.Equals, "group-1")
+	c.Assert(stored[0].MemberUserID, qt.Equals, "user-2")
+
+	_, err = reg.ReplaceForMember(ctx, "tenant-1", "group-1", "user-3", []models.GroupMemberLocationScope{
+		{LocationID: "loc-a", Role: models.GroupRoleUser},
+	})
+	c.Assert(err, qt.IsNil)
+
+	// Replacing swaps the member's rows and leaves other members alone.
+	_, err = reg.ReplaceForMember(ctx, "tenant-1", "group-1", "user-2", []models.GroupMemberLocationScope{
+		{LocationID: "loc-c", Role: models.GroupRoleAdmin},
+	})
+	c.Assert(err, qt.IsNil)
+	rows, err := reg.ListByMember(ctx, "tenant-1", "group-1", "user-2")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.HasLen, 1)
+	c.Assert(rows[0].LocationID, qt.Equals, "loc-c")
+
+	rows, err = reg.ListByGroup(ctx, "tenant-1", "group-1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.HasLen, 2)
+
+	c.Assert(reg.DeleteByUser(ctx, "user-3"), qt.IsNil)
+	n, err := reg.DeleteByGroup(ctx, "tenant-1", "group-1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(n, qt.Equals, 1)
+
+	_, err = reg.ListByMember(ctx, "", "group-1", "user-2")
+	c.Assert(err, qt.ErrorIs, registry.ErrFieldRequired)
+}
@@ -60,6 +60,7 @@ func (f *LocationRegistryFactory) CreateUserRegistry(ctx context.Context) (regis
 		lock:    f.baseLocationRegistry.lock,  // Share the mutex pointer
 		userID:  user.ID,                      // Set user-specific userID
 		groupID: groupID,                      // Set group-specific groupID
+		scoper:  newLocationScoper(ctx, models.GroupRoleAdmin, locateLocation),
 	}
 
 	return &LocationRegistry{
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// MaintenanceLogRegistryFactory creates MaintenanceLogRegistry instances
+// with proper context. All per-request registries share the base
+// registry's backing map.
+type MaintenanceLogRegistryFactory struct {
+	base *Registry[models.MaintenanceLog, *models.MaintenanceLog]
+}
+
+// MaintenanceLogRegistry is the context-aware in-memory registry of
+// maintenance completion logs.
+type MaintenanceLogRegistry struct {
+	*Registry[models.MaintenanceLog, *models.MaintenanceLog]
+
+	userID string
+}
+
+var (
+	_ registry.MaintenanceLogRegistry        = (*MaintenanceLogRegistry)(nil)
+	_ registry.MaintenanceLogRegistryFactory = (*MaintenanceLogRegistryFactory)(nil)
+)
+
+func NewMaintenanceLogRegistryFactory() *MaintenanceLogRegistryFactory {
+	return &MaintenanceLogRegistryFactory{
+		base: NewRegistry[models.MaintenanceLog, *models.MaintenanceLog](),
+	}
+}
+
+func (f *MaintenanceLogRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.MaintenanceLogRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *MaintenanceLogRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.MaintenanceLogRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+
+	groupID := appctx.GroupIDFromContext(ctx)
+	userRegistry := &Registry[models.MaintenanceLog, *models.MaintenanceLog]{
+		items:   f.base.items,
+		lock:    f.base.lock,
+		userID:  user.ID,
+		groupID: groupID,
+	}
+
+	return &MaintenanceLogRegistry{
+		Registry: userRegistry,
+		userID:   user.ID,
+	}, nil
+}
+
+func (f *MaintenanceLogRegistryFactory) CreateServiceRegistry() registry.MaintenanceLogRegistry {
+	serviceRegistry := &Registry[models.MaintenanceLog, *models.MaintenanceLog]{
+		items:  f.base.items,
+		lock:   f.base.lock,
+		userID: "",
+	}
+
+	return &MaintenanceLogRegistry{
+		Registry: serviceRegistry,
+		userID:   "",
+	}
+}
+
+func (r *MaintenanceLogRegistry) Create(ctx context.Context, log models.MaintenanceLog) (*models.MaintenanceLog, error) {
+	log.CreatedAt = time.Now()
+	created, err := r.Registry.CreateWithUser(ctx, log)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create maintenance log", err)
+	}
+	return created, nil
+}
+
+func (r *MaintenanceLogRegistry) Update(ctx context.Context, log models.MaintenanceLog) (*models.MaintenanceLog, error) {
+	updated, err := r.Registry.UpdateWithUser(ctx, log)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update maintenance log", err)
+	}
+	return updated, nil
+}
+
+// ListBySchedule returns the schedule's completions newest first.
+func (r *MaintenanceLogRegistry) ListBySchedule(ctx context.Context, scheduleID string) ([]*models.MaintenanceLog, error) {
+	all, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.MaintenanceLog, 0, len(all))
+	for _, l := range all {
+		if l.ScheduleID == scheduleID {
+			out = append(out, l)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].DoneAt != out[j].DoneAt {
+			return out[i].DoneAt > out[j].DoneAt
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out, nil
+}
@@ -98,7 +98,7 @@ func (r *MaintenanceScheduleRegistry) Update(ctx context.Context, schedule model
 }
 
 // ListByCommodity returns all schedules for the given commodity ordered
-// by next_due_at ascending (title as tiebreaker for stable ordering).
+// by next_due_at ascending (see lessByNextDue).
 func (r *MaintenanceScheduleRegistry) ListByCommodity(ctx context.Context, commodityID string) ([]*models.MaintenanceSchedule, error) {
 	all, err := r.List(ctx)
 	if err != nil {
@@ -110,12 +110,7 @@ func (r *MaintenanceScheduleRegistry) ListByCommodity(ctx context.Context, commo
 			out = append(out, s)
 		}
 	}
-	sort.SliceStable(out, func(i, j int) bool {
-		if out[i].NextDueAt != out[j].NextDueAt {
-			return out[i].NextDueAt < out[j].NextDueAt
-		}
-		return out[i].Title < out[j].Title
-	})
+	sort.SliceStable(out, func(i, j int) bool { return lessByNextDue(out[i], out[j]) })
 	return out, nil
 }
 
@@ -130,18 +125,13 @@ func (r *MaintenanceScheduleRegistry) ListPaginated(ctx context.Context, offset,
 		if opts.EnabledOnly && !s.Enabled {
 			continue
 		}
-		if opts.DueBefore != "" && string(s.NextDueAt) > opts.DueBefore {
+		if opts.DueBefore != "" && (s.NextDueAt == "" || string(s.NextDueAt) > opts.DueBefore) {
 			continue
 		}
 		filtered = append(filtered, s)
 	}
 
-	sort.SliceStable(filtered, func(i, j int) bool {
-		if filtered[i].NextDueAt != filtered[j].NextDueAt {
-			return filtered[i].NextDueAt < filtered[j].NextDueAt
-		}
-		return filtered[i].Title < filtered[j].Title
-	})
+	sort.SliceStable(filtered, func(i, j int) bool { return lessByNextDue(filtered[i], filtered[j]) })
 
 	total := len(filtered)
 	if offset < 0 {
@@ -155,6 +145,19 @@ func (r *MaintenanceScheduleRegistry) ListPaginated(ctx context.Context, offset,
 	return filtered[start:end], total, nil
 }
 
+// lessByNextDue orders schedules by next_due_at ascending with undated
+// (usage, no estimate yet) rows last, title as tiebreaker — the same
+// order as the postgres registry's NULLS LAST clause.
+func lessByNextDue(a, b *models.MaintenanceSchedule) bool {
+	if a.NextDueAt != b.NextDueAt {
+		if a.NextDueAt == "" || b.NextDueAt == "" {
+			return b.NextDueAt == ""
+		}
+		return a.NextDueAt < b.NextDueAt
+	}
+	return a.Title < b.Title
+}
+
 func (r *MaintenanceScheduleRegistry) CountByCommodity(ctx context.Context, commodityIDs []string) (map[string]int, error) {
 	out := make(map[string]int, len(commodityIDs))
 	for _, id := range commodityIDs {
@@ -2,6 +2,12 @@ package memory
 
 import (
 	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
 
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -27,6 +33,11 @@ func NewFactorySet() *registry.FactorySet {
 	commodityServiceFactory := NewCommodityServiceRegistryFactory()
 	supplyLinkFactory := NewSupplyLinkRegistryFactory()
 	maintenanceScheduleFactory := NewMaintenanceScheduleRegistryFactory()
+	maintenanceLogFactory := NewMaintenanceLogRegistryFactory()
+	commodityMeterReadingFactory := NewCommodityMeterReadingRegistryFactory()
+	invoiceExtractionFactory := NewInvoiceExtractionRegistryFactory()
+	savedViewFactory := NewSavedViewRegistryFactory()
+	backupScheduleFactory := NewBackupScheduleRegistryFactory()
 	restoreStepFactory := NewRestoreStepRegistryFactory()
 	restoreOperationFactory := NewRestoreOperationRegistryFactory(restoreStepFactory)
 	exportFactory := NewExportRegistryFactory(restoreOperationFactory)
@@ -41,6 +52,9 @@ func NewFactorySet() *registry.FactorySet {
 	// commodityFactory depends on areaFactory which depends on
 	// locationFactory, and fileFactory is constructed before all of them.
 	fileFactory.SetLinkedEntityFactories(commodityFactory, areaFactory, locationFactory)
+	// Loans are scoped to a location through their commodity; same
+	// post-construction wiring as above.
+	commodityLoanFactory.SetCommodityFactory(commodityFactory)
 
 	fs := &registry.FactorySet{}
 	fs.LocationRegistryFactory = locationFactory
@@ -54,6 +68,11 @@ func NewFactorySet() *registry.FactorySet {
 	fs.CommodityServiceRegistryFactory = commodityServiceFactory
 	fs.SupplyLinkRegistryFactory = supplyLinkFactory
 	fs.MaintenanceScheduleRegistryFactory = maintenanceScheduleFactory
+	fs.MaintenanceLogRegistryFactory = maintenanceLogFactory
+	fs.CommodityMeterReadingRegistryFactory = commodityMeterReadingFactory
+	fs.InvoiceExtractionRegistryFactory = invoiceExtractionFactory
+	fs.SavedViewRegistryFactory = savedViewFactory
+	fs.BackupScheduleRegistryFactory = backupScheduleFactory
 	fs.ExportRegistryFactory = exportFactory
 	fs.RestoreStepRegistryFactory = restoreStepFactory
 	fs.RestoreOperationRegistryFactory = restoreOperationFactory
@@ -74,6 +93,7 @@ func NewFactorySet() *registry.FactorySet {
 	userReg := NewUserRegistry()
 	fs.UserRegistry = userReg
 	fs.RefreshTokenRegistry = NewRefreshTokenRegistry()
+	fs.CalendarFeedRegistry = NewCalendarFeedRegistry()
 	fs.LoginEventRegistry = NewLoginEventRegistry()
 	fs.UserMFASecretRegistry = NewUserMFASecretRegistry()
 	fs.AuditLogRegistry = NewAuditLogRegistry()
@@ -95,6 +115,17 @@ func NewFactorySet() *registry.FactorySet {
 	// Background-worker soft-pause control (issue #1308). Global control
 	// plane — no tenant scope, no RLS (mirrors SystemAdminGrantRegistry).
 	fs.WorkerControlRegistry = NewWorkerControlRegistry()
+	// Backup-signing keyring — global like the worker controls.
+	fs.TrustedBackupKeyRegistry = NewTrustedBackupKeyRegistry()
+	// AI vision spend caps — set from the back office, global like the
+	// worker controls.
+	fs.CommodityScanBudgetRegistry = NewCommodityScanBudgetRegistry()
+	// Registration approval queue — decided from the back office across
+	// tenants, global like the worker controls.
+	fs.RegistrationRequestRegistry = NewRegistrationRequestRegistry()
+	// Idempotency-Key replay store — resolved by middleware and swept by
+	// the cleanup worker across tenants.
+	fs.IdempotencyKeyRegistry = NewIdempotencyKeyRegistry()
 	// Back-office MFA secrets (issue #1785, Phase 4). One row per
 	// back-office user; the operator CLI mints, regenerates, and wipes
 	// rows. No RLS / tenant scoping — same reasoning as the rest of the
@@ -117,6 +148,7 @@ func NewFactorySet() *registry.FactorySet {
 	fs.GroupInviteRegistry = NewGroupInviteRegistry()
 	fs.GroupInviteAuditRegistry = NewGroupInviteAuditRegistry()
 	fs.GroupNotificationPrefRegistry = NewGroupNotificationPrefRegistry()
+	fs.GroupMemberLocationScopeRegistry = NewGroupMemberLocationScopeRegistry()
 	fs.WarrantyReminderRegistry = NewWarrantyReminderRegistry()
 	fs.StorageQuotaReminderRegistry = NewStorageQuotaReminderRegistry()
 	fs.MaintenanceReminderRegistry = NewMaintenanceReminderRegistry()
@@ -139,9 +171,16 @@ func NewFactorySet() *registry.FactorySet {
 		userConcurrencySlotFactory,
 		maintenanceScheduleFactory,
 		fs.MaintenanceReminderRegistry,
+		maintenanceLogFactory,
+		commodityMeterReadingFactory,
+		invoiceExtractionFactory,
+		savedViewFactory,
+		backupScheduleFactory,
 		fs.CurrencyMigrationRegistryFactory,
 		fs.GroupNotificationPrefRegistry,
+		fs.GroupMemberLocationScopeRegistry,
 		fs.GroupMembershipRegistry,
+		fs.CalendarFeedRegistry,
 	)
 	// UserPurger (#2116): clears a single user's auth/identity rows during the
 	// admin user hard-delete (and, since #2147, the user's group_invites_audit
@@ -149,6 +188,7 @@ func NewFactorySet() *registry.FactorySet {
 	// in-memory maps; all are set above, so it can be wired here.
 	fs.UserPurger = NewUserPurger(
 		fs.RefreshTokenRegistry,
+		fs.CalendarFeedRegistry,
 		fs.UserMFASecretRegistry,
 		fs.OAuthIdentityRegistry,
 		fs.PasswordResetRegistry,
@@ -157,6 +197,10 @@ func NewFactorySet() *registry.FactorySet {
 		fs.GroupMembershipRegistry,
 		fs.SystemAdminGrantRegistry,
 		fs.GroupInviteAuditRegistry,
+		savedViewFactory,
+		fs.RegistrationRequestRegistry,
+		fs.GroupMemberLocationScopeRegistry,
+		fs.IdempotencyKeyRegistry,
 	)
 	// SystemStats (#843): the memory backend is dev/test only and its
 	// data registries are tenant/group-scoped behind the per-request
@@ -180,19 +224,100 @@ func NewFactorySet() *registry.FactorySet {
 	// registries off the FactorySet, so it is wired after every fs.* field too.
 	fs.UserContentOwnershipChecker = NewUserContentOwnershipChecker(fs)
 
+	// GroupTransferrer rewrites rows in the factories' shared base registries,
+	// so it is wired once they are all in place.
+	fs.GroupTransferrer = NewGroupTransferrer(fs)
+
 	return fs
 }
 
 func NewMemoryRegistrySet() (func(registry.Config) (*registry.FactorySet, error), func() error) {
-	newFn := func(_ registry.Config) (*registry.FactorySet, error) {
+	newFn := func(c registry.Config) (*registry.FactorySet, error) {
+		snapshotCfg, err := ParseSnapshotConfig(c)
+		if err != nil {
+			return nil, err
+		}
 		factorySet := NewFactorySet()
+		if snapshotCfg.Path == "" {
+			return factorySet, nil
+		}
+		if err := attachSnapshotter(factorySet, snapshotCfg); err != nil {
+			return nil, err
+		}
 		return factorySet, nil
 	}
 
 	doCleanup := func() error {
-		// Memory registry doesn't need cleanup
+		// Memory registry doesn't need cleanup; snapshot-backed sets flush
+		// through FactorySet.Close.
 		return nil
 	}
 
 	return newFn, doCleanup
 }
+
+// SnapshotConfig is the snapshot part of a memory:// DSN:
+//
+//	memory://?snapshot=/var/lib/inventario/memory.json&snapshot_interval=5m
+//
+// An empty Path keeps the registry purely in memory.
+type SnapshotConfig struct {
+	Path     string
+	Interval time.Duration
+}
+
+// ParseSnapshotConfig extracts the snapshot settings from a memory:// DSN.
+func ParseSnapshotConfig(c registry.Config) (SnapshotConfig, error) {
+	u, err := c.Parse()
+	if err != nil {
+		return SnapshotConfig{}, errxtrace.Classify(registry.ErrInvalidConfig, errx.Attrs("reason", err.Error()))
+	}
+	q := u.Query()
+	cfg := SnapshotConfig{
+		Path:     q.Get("snapshot"),
+		Interval: DefaultSnapshotInterval,
+	}
+	if raw := q.Get("snapshot_interval"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval <= 0 {
+			return SnapshotConfig{}, errxtrace.Classify(registry.ErrInvalidConfig,
+				errx.Attrs("reason", "snapshot_interval must be a positive duration", "value", raw))
+		}
+		cfg.Interval = interval
+	}
+	return cfg, nil
+}
+
+// attachSnapshotter restores fs from the snapshot file, starts the
+// periodic writer and makes fs.Close stop it and write a final snapshot.
+func attachSnapshotter(fs *registry.FactorySet, cfg SnapshotConfig) error {
+	snapshotter, err := NewSnapshotter(fs, cfg.Path)
+	if err != nil {
+		return err
+	}
+	if _, err := snapshotter.Load(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		snapshotter.Run(ctx, cfg.Interval)
+	}()
+
+	var once sync.Once
+	var closeErr error
+	fs.CloseFn = func() error {
+		once.Do(func() {
+			cancel()
+			<-done
+			closeErr = snapshotter.Save(context.Background())
+			if closeErr == nil {
+				slog.Info("Saved memory snapshot", "path", cfg.Path)
+			}
+		})
+		return closeErr
+	}
+	return nil
+}
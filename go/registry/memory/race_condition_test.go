@@ -190,6 +190,8 @@ func TestMemoryRegistryUserContextRaceCondition(t *testing.T) {
 				// Update and retrieve
 				updatedLocation := *createdLocation
 				updatedLocation.Name = "Updated Location"
+				// No version expectation: the goroutines race on purpose.
+				updatedLocation.Version = 0
 
 				_, err := concurrentRegistrySet.LocationRegistry.Update(ctx1, updatedLocation)
 				if err != nil {
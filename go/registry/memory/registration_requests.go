@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var _ registry.RegistrationRequestRegistry = (*RegistrationRequestRegistry)(nil)
+
+// RegistrationRequestRegistry is the in-memory registration approval
+// queue. A single mutex serialises every operation, which is what makes
+// Decide's pending check and update atomic.
+type RegistrationRequestRegistry struct {
+	lock sync.Mutex
+	// items is keyed by request id.
+	items map[string]*models.RegistrationRequest
+}
+
+// NewRegistrationRequestRegistry creates a new in-memory
+// RegistrationRequestRegistry.
+func NewRegistrationRequestRegistry() *RegistrationRequestRegistry {
+	return &RegistrationRequestRegistry{
+		items: make(map[string]*models.RegistrationRequest),
+	}
+}
+
+// Create stores a new pending request.
+func (r *RegistrationRequestRegistry) Create(_ context.Context, req models.RegistrationRequest) (*models.RegistrationRequest, error) {
+	if req.TenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+	if req.UserID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "user_id"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, existing := range r.items {
+		if existing.UserID == req.UserID {
+			return nil, errxtrace.Classify(registry.ErrAlreadyExists, errx.Attrs("user_id", req.UserID))
+		}
+	}
+
+	stored := cloneRegistrationRequest(&req)
+	stored.ID = uuid.New().String()
+	stored.UUID = uuid.New().String()
+	stored.Status = models.RegistrationRequestStatusPending
+	stored.Reason = nil
+	stored.DecidedBy = nil
+	stored.DecidedAt = nil
+	stored.CreatedAt = time.Now().UTC()
+	r.items[stored.ID] = stored
+	return cloneRegistrationRequest(stored), nil
+}
+
+// Get returns the request with the given id.
+func (r *RegistrationRequestRegistry) Get(_ context.Context, id string) (*models.RegistrationRequest, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	stored, ok := r.items[id]
+	if !ok {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("id", id))
+	}
+	return cloneRegistrationRequest(stored), nil
+}
+
+// GetByUserID returns the user's request.
+func (r *RegistrationRequestRegistry) GetByUserID(_ context.Context, userID string) (*models.RegistrationRequest, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, stored := range r.items {
+		if stored.UserID == userID {
+			return cloneRegistrationRequest(stored), nil
+		}
+	}
+	return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("user_id", userID))
+}
+
+// List returns the requests matching filter, oldest first.
+func (r *RegistrationRequestRegistry) List(_ context.Context, filter registry.RegistrationRequestFilter) ([]*models.RegistrationRequest, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	out := make([]*models.RegistrationRequest, 0, len(r.items))
+	for _, stored := range r.items {
+		if filter.TenantID != "" && stored.TenantID != filter.TenantID {
+			continue
+		}
+		if filter.Status != "" && stored.Status != filter.Status {
+			continue
+		}
+		out = append(out, cloneRegistrationRequest(stored))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// Decide moves a pending request to status.
+func (r *RegistrationRequestRegistry) Decide(_ context.Context, id string, status models.RegistrationRequestStatus, reason, decidedBy *string) (*models.RegistrationRequest, error) {
+	if status != models.RegistrationRequestStatusApproved && status != models.RegistrationRequestStatusRejected {
+		return nil, errxtrace.Classify(registry.ErrInvalidInput, errx.Attrs("status", status))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	stored, ok := r.items[id]
+	if !ok {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("id", id))
+	}
+	if !stored.IsPending() {
+		return nil, errxtrace.Classify(registry.ErrRegistrationAlreadyDecided, errx.Attrs("id", id, "status", stored.Status))
+	}
+
+	now := time.Now().UTC()
+	stored.Status = status
+	stored.Reason = cloneStringPtr(reason)
+	stored.DecidedBy = cloneStringPtr(decidedBy)
+	stored.DecidedAt = &now
+	return cloneRegistrationRequest(stored), nil
+}
+
+// DeleteByUserID removes the user's request, if any.
+func (r *RegistrationRequestRegistry) DeleteByUserID(_ context.Context, userID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, stored := range r.items {
+		if stored.UserID == userID {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
+
+// cloneRegistrationRequest copies a request row, duplicating the nullable
+// fields so a caller can't reach the stored row.
+func cloneRegistrationRequest(req *models.RegistrationRequest) *models.RegistrationRequest {
+	cp := *req
+	cp.Reason = cloneStringPtr(req.Reason)
+	cp.DecidedBy = cloneStringPtr(req.DecidedBy)
+	if req.DecidedAt != nil {
+		v := *req.DecidedAt
+		cp.DecidedAt = &v
+	}
+	return &cp
+}
+
+func cloneStringPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
@@ -0,0 +1,141 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func newRegistrationRequest(tenantID, userID string) models.RegistrationRequest {
+	return models.RegistrationRequest{
+		TenantID: tenantID,
+		UserID:   userID,
+		Email:    userID + "@example.com",
+		Name:     "User " + userID,
+	}
+}
+
+// TestRegistrationRequestRegistry_Create_StoresPending verifies Create
+// assigns ids and forces a fresh pending state whatever the caller passed.
+func TestRegistrationRequestRegistry_Create_StoresPending(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := memory.NewRegistrationRequestRegistry()
+
+	in := newRegistrationRequest("tenant-1", "user-1")
+	in.Status = models.RegistrationRequestStatusApproved
+	in.Reason = new("smuggled")
+
+	req, err := r.Create(ctx, in)
+	c.Assert(err, qt.IsNil)
+	c.Assert(req.ID, qt.Not(qt.Equals), "")
+	c.Assert(req.UUID, qt.Not(qt.Equals), "")
+	c.Assert(req.Status, qt.Equals, models.RegistrationRequestStatusPending)
+	c.Assert(req.Reason, qt.IsNil)
+	c.Assert(req.CreatedAt.IsZero(), qt.IsFalse)
+
+	byUser, err := r.GetByUserID(ctx, "user-1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(byUser.ID, qt.Equals, req.ID)
+}
+
+// TestRegistrationRequestRegistry_Create_Validation covers the required
+// fields and the one-request-per-user rule.
+func TestRegistrationRequestRegistry_Create_Validation(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := memory.NewRegistrationRequestRegistry()
+
+	_, err := r.Create(ctx, newRegistrationRequest("", "user-1"))
+	c.Assert(err, qt.ErrorIs, registry.ErrFieldRequired)
+	_, err = r.Create(ctx, newRegistrationRequest("tenant-1", ""))
+	c.Assert(err, qt.ErrorIs, registry.ErrFieldRequired)
+
+	_, err = r.Create(ctx, newRegistrationRequest("tenant-1", "user-1"))
+	c.Assert(err, qt.IsNil)
+	_, err = r.Create(ctx, newRegistrationRequest("tenant-1", "user-1"))
+	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
+}
+
+// TestRegistrationRequestRegistry_List_Filters verifies the tenant and
+// status filters and the oldest-first order.
+func TestRegistrationRequestRegistry_List_Filters(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := memory.NewRegistrationRequestRegistry()
+
+	first, err := r.Create(ctx, newRegistrationRequest("tenant-1", "user-1"))
+	c.Assert(err, qt.IsNil)
+	second, err := r.Create(ctx, newRegistrationRequest("tenant-1", "user-2"))
+	c.Assert(err, qt.IsNil)
+	_, err = r.Create(ctx, newRegistrationRequest("tenant-2", "user-3"))
+	c.Assert(err, qt.IsNil)
+	_, err = r.Decide(ctx, second.ID, models.RegistrationRequestStatusRejected, new("spam"), nil)
+	c.Assert(err, qt.IsNil)
+
+	all, err := r.List(ctx, registry.RegistrationRequestFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(all, qt.HasLen, 3)
+
+	pending, err := r.List(ctx, registry.RegistrationRequestFilter{TenantID: "tenant-1", Status: models.RegistrationRequestStatusPending})
+	c.Assert(err, qt.IsNil)
+	c.Assert(pending, qt.HasLen, 1)
+	c.Assert(pending[0].ID, qt.Equals, first.ID)
+
+	tenant1, err := r.List(ctx, registry.RegistrationRequestFilter{TenantID: "tenant-1"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(tenant1, qt.HasLen, 2)
+	c.Assert(tenant1[0].CreatedAt.After(tenant1[1].CreatedAt), qt.IsFalse)
+}
+
+// TestRegistrationRequestRegistry_Decide covers the recorded decision and
+// the refusal to decide twice.
+func TestRegistrationRequestRegistry_Decide(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := memory.NewRegistrationRequestRegistry()
+
+	req, err := r.Create(ctx, newRegistrationRequest("tenant-1", "user-1"))
+	c.Assert(err, qt.IsNil)
+
+	_, err = r.Decide(ctx, req.ID, models.RegistrationRequestStatusPending, nil, nil)
+	c.Assert(err, qt.ErrorIs, registry.ErrInvalidInput)
+	_, err = r.Decide(ctx, "missing", models.RegistrationRequestStatusApproved, nil, nil)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	decided, err := r.Decide(ctx, req.ID, models.RegistrationRequestStatusApproved, new("known customer"), new("op-1"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(decided.Status, qt.Equals, models.RegistrationRequestStatusApproved)
+	c.Assert(*decided.Reason, qt.Equals, "known customer")
+	c.Assert(*decided.DecidedBy, qt.Equals, "op-1")
+	c.Assert(decided.DecidedAt, qt.IsNotNil)
+
+	_, err = r.Decide(ctx, req.ID, models.RegistrationRequestStatusRejected, new("changed my mind"), nil)
+	c.Assert(err, qt.ErrorIs, registry.ErrRegistrationAlreadyDecided)
+
+	stored, err := r.Get(ctx, req.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stored.Status, qt.Equals, models.RegistrationRequestStatusApproved)
+}
+
+// TestRegistrationRequestRegistry_DeleteByUserID verifies the purger hook
+// removes the row and tolerates a user without one.
+func TestRegistrationRequestRegistry_DeleteByUserID(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := memory.NewRegistrationRequestRegistry()
+
+	_, err := r.Create(ctx, newRegistrationRequest("tenant-1", "user-1"))
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(r.DeleteByUserID(ctx, "user-1"), qt.IsNil)
+	c.Assert(r.DeleteByUserID(ctx, "user-1"), qt.IsNil)
+
+	_, err = r.GetByUserID(ctx, "user-1")
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"sync"
 
+	"github.com/go-extras/errx"
 	errxtrace "github.com/go-extras/errx/stacktrace"
 	"github.com/google/uuid"
 	"github.com/wk8/go-ordered-map/v2"
@@ -18,8 +19,9 @@ import (
 type Registry[T any, P registry.PIDable[T]] struct {
 	items   *orderedmap.OrderedMap[string, P]
 	lock    *sync.RWMutex
-	userID  string // For user-aware filtering (non-group models)
-	groupID string // For group-aware filtering (group-scoped data models)
+	userID  string             // For user-aware filtering (non-group models)
+	groupID string             // For group-aware filtering (group-scoped data models)
+	scoper  *locationScoper[P] // For location-scoped members; nil when unrestricted
 }
 
 //go:noinline
@@ -45,6 +47,9 @@ func (r *Registry[T, P]) Create(ctx context.Context, item T) (P, error) {
 			uuidable.SetUUID(uuid.New().String())
 		}
 	}
+	if versioned, ok := any(iitem).(models.Versioned); ok {
+		versioned.SetVersion(1)
+	}
 
 	r.lock.Lock()
 	r.items.Set(iitem.GetID(), iitem)
@@ -53,6 +58,14 @@ func (r *Registry[T, P]) Create(ctx context.Context, item T) (P, error) {
 	return iitem, nil
 }
 
+// peek returns the stored item without any visibility filtering. It is
+// used by location-scope locators to resolve parent rows.
+func (r *Registry[_, P]) peek(id string) (P, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.items.Get(id)
+}
+
 func (r *Registry[_, P]) Get(_ context.Context, id string) (P, error) {
 	var zero P
 	slog.Info("Getting item", "item_id", id, "user_id", r.userID, "item_type", fmt.Sprintf("%T", zero))
@@ -105,6 +118,14 @@ func (r *Registry[T, P]) Update(_ context.Context, item T) (P, error) {
 		return nil, registry.ErrNotFound
 	}
 
+	if !r.scoper.canWrite(existingItem) || !r.scoper.canWrite(iitem) {
+		return nil, errxtrace.Classify(registry.ErrLocationScopeDenied)
+	}
+
+	if err := advanceVersion(iitem, existingItem); err != nil {
+		return nil, err
+	}
+
 	// Always overwrite the incoming UUID with the value from the existing record.
 	// UUID is immutable after creation; callers must not be able to change it,
 	// whether they supply a non-empty value or an empty one.
@@ -131,7 +152,7 @@ func (r *Registry[T, P]) Update(_ context.Context, item T) (P, error) {
 	return &item, nil
 }
 
-func (r *Registry[_, P]) Delete(_ context.Context, id string) error {
+func (r *Registry[_, P]) Delete(ctx context.Context, id string) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
@@ -144,6 +165,14 @@ func (r *Registry[_, P]) Delete(_ context.Context, id string) error {
 		if !r.isItemVisible(existingItem) {
 			return registry.ErrNotFound
 		}
+
+		if !r.scoper.canWrite(existingItem) {
+			return errxtrace.Classify(registry.ErrLocationScopeDenied)
+		}
+	}
+
+	if err := checkDeleteVersion(ctx, id, r.items); err != nil {
+		return err
 	}
 
 	// For non-user-aware registries, just delete (no error if item doesn't exist)
@@ -169,14 +198,15 @@ func (r *Registry[_, P]) Count(_ context.Context) (int, error) {
 }
 
 // isItemVisible checks if an item should be visible to the current registry context.
-// For group-scoped entities (GroupAware), it filters by groupID.
+// For group-scoped entities (GroupAware), it filters by groupID and, for a
+// location-scoped member, by the member's scoped locations.
 // For user-scoped entities (UserAware), it filters by userID.
 // For data models accessed without group context, it falls back to createdByUserID.
 func (r *Registry[_, P]) isItemVisible(item P) bool {
 	// Group-aware filtering takes priority for data models
 	if r.groupID != "" {
 		if groupAware, ok := any(item).(models.GroupAware); ok {
-			return groupAware.GetGroupID() == r.groupID
+			return groupAware.GetGroupID() == r.groupID && r.scoper.canRead(item)
 		}
 	}
 
@@ -257,6 +287,13 @@ func (r *Registry[T, P]) CreateWithUser(ctx context.Context, item T) (P, error)
 			uuidable.SetUUID(uuid.New().String())
 		}
 	}
+	if versioned, ok := any(iitem).(models.Versioned); ok {
+		versioned.SetVersion(1)
+	}
+
+	if !r.scoper.canWrite(iitem) {
+		return nil, errxtrace.Classify(registry.ErrLocationScopeDenied)
+	}
 
 	r.lock.Lock()
 	r.items.Set(iitem.GetID(), iitem)
@@ -360,6 +397,17 @@ func (r *Registry[T, P]) UpdateWithUser(ctx context.Context, item T) (P, error)
 		return nil, registry.ErrNotFound
 	}
 
+	if !r.scoper.canRead(existingItem) {
+		return nil, registry.ErrNotFound
+	}
+	if !r.scoper.canWrite(existingItem) || !r.scoper.canWrite(iitem) {
+		return nil, errxtrace.Classify(registry.ErrLocationScopeDenied)
+	}
+
+	if err := advanceVersion(iitem, existingItem); err != nil {
+		return nil, err
+	}
+
 	// Preserve immutable UUID from existing entity
 	if uuidable, ok := any(iitem).(models.UUIDable); ok {
 		if existingUUIDable, ok := any(existingItem).(models.UUIDable); ok {
@@ -383,6 +431,24 @@ func (r *Registry[T, P]) UpdateWithUser(ctx context.Context, item T) (P, error)
 	return &item, nil
 }
 
+// advanceVersion enforces optimistic concurrency for models.Versioned
+// entities: a non-zero incoming version must equal the stored one, and the
+// item is written back with the stored version plus one. Callers hold r.lock,
+// which is what makes the check-and-bump atomic.
+func advanceVersion[P any](item, existing P) error {
+	versioned, ok := any(item).(models.Versioned)
+	if !ok {
+		return nil
+	}
+	current := any(existing).(models.Versioned).GetVersion()
+	if expected := versioned.GetVersion(); expected != 0 && expected != current {
+		return errxtrace.Classify(registry.ErrVersionConflict,
+			errx.Attrs("expected_version", expected, "current_version", current))
+	}
+	versioned.SetVersion(current + 1)
+	return nil
+}
+
 // DeleteWithUser deletes an entity with user context
 func (r *Registry[_, _]) DeleteWithUser(ctx context.Context, id string) error {
 	// Use registry's userID if available, otherwise extract from context
@@ -395,17 +461,42 @@ func (r *Registry[_, _]) DeleteWithUser(ctx context.Context, id string) error {
 	}
 
 	// Validate ownership before delete
-	_, err := r.GetWithUser(ctx, id)
+	item, err := r.GetWithUser(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	if !r.scoper.canRead(item) {
+		return registry.ErrNotFound
+	}
+	if !r.scoper.canWrite(item) {
+		return errxtrace.Classify(registry.ErrLocationScopeDenied)
+	}
+
 	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := checkDeleteVersion(ctx, id, r.items); err != nil {
+		return err
+	}
 	r.items.Delete(id)
-	r.lock.Unlock()
 	return nil
 }
 
+// checkDeleteVersion applies a registry.WithExpectedVersion expectation to
+// the stored item, if any. Callers hold r.lock, so the check and the delete
+// are atomic.
+func checkDeleteVersion[P any](ctx context.Context, id string, items *orderedmap.OrderedMap[string, P]) error {
+	existing, ok := items.Get(id)
+	if !ok {
+		return nil
+	}
+	versioned, ok := any(existing).(models.Versioned)
+	if !ok {
+		return nil
+	}
+	return registry.CheckExpectedVersion(ctx, id, versioned)
+}
+
 // CountWithUser counts entities with user context filtering
 func (r *Registry[_, _]) CountWithUser(ctx context.Context) (int, error) {
 	// Use registry's userID if available, otherwise extract from context
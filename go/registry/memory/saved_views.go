@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// SavedViewRegistryFactory creates SavedViewRegistry instances with
+// proper context. Stores the base registry so all per-request registries
+// share the same backing map.
+type SavedViewRegistryFactory struct {
+	base *Registry[models.SavedView, *models.SavedView]
+}
+
+// SavedViewRegistry is the context-aware in-memory registry of saved
+// views. The embedded registry already scopes rows to the group; the
+// private-view check on top of it lives here.
+type SavedViewRegistry struct {
+	*Registry[models.SavedView, *models.SavedView]
+
+	userID string
+}
+
+var (
+	_ registry.SavedViewRegistry        = (*SavedViewRegistry)(nil)
+	_ registry.SavedViewRegistryFactory = (*SavedViewRegistryFactory)(nil)
+)
+
+func NewSavedViewRegistryFactory() *SavedViewRegistryFactory {
+	return &SavedViewRegistryFactory{
+		base: NewRegistry[models.SavedView, *models.SavedView](),
+	}
+}
+
+func (f *SavedViewRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.SavedViewRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *SavedViewRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.SavedViewRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+
+	groupID := appctx.GroupIDFromContext(ctx)
+	userRegistry := &Registry[models.SavedView, *models.SavedView]{
+		items:   f.base.items,
+		lock:    f.base.lock,
+		userID:  user.ID,
+		groupID: groupID,
+	}
+
+	return &SavedViewRegistry{
+		Registry: userRegistry,
+		userID:   user.ID,
+	}, nil
+}
+
+func (f *SavedViewRegistryFactory) CreateServiceRegistry() registry.SavedViewRegistry {
+	serviceRegistry := &Registry[models.SavedView, *models.SavedView]{
+		items:  f.base.items,
+		lock:   f.base.lock,
+		userID: "",
+	}
+
+	return &SavedViewRegistry{
+		Registry: serviceRegistry,
+		userID:   "",
+	}
+}
+
+// visible reports whether the view may be seen through this registry.
+// Service registries (no user) see everything.
+func (r *SavedViewRegistry) visible(view *models.SavedView) bool {
+	return r.userID == "" || view.IsVisibleTo(r.userID)
+}
+
+func (r *SavedViewRegistry) Get(ctx context.Context, id string) (*models.SavedView, error) {
+	view, err := r.Registry.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !r.visible(view) {
+		return nil, errxtrace.Wrap("saved view not found", registry.ErrNotFound)
+	}
+	return view, nil
+}
+
+// List returns the visible views ordered by name (id as tiebreaker).
+func (r *SavedViewRegistry) List(ctx context.Context) ([]*models.SavedView, error) {
+	all, err := r.Registry.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.SavedView, 0, len(all))
+	for _, v := range all {
+		if r.visible(v) {
+			out = append(out, v)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (r *SavedViewRegistry) Count(ctx context.Context) (int, error) {
+	views, err := r.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(views), nil
+}
+
+func (r *SavedViewRegistry) Create(ctx context.Context, view models.SavedView) (*models.SavedView, error) {
+	now := time.Now()
+	view.CreatedAt = now
+	view.UpdatedAt = now
+	created, err := r.Registry.CreateWithUser(ctx, view)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create saved view", err)
+	}
+	return created, nil
+}
+
+func (r *SavedViewRegistry) Update(ctx context.Context, view models.SavedView) (*models.SavedView, error) {
+	existing, err := r.Get(ctx, view.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Ownership and creation time are immutable.
+	view.TenantID = existing.TenantID
+	view.CreatedByUserID = existing.CreatedByUserID
+	view.CreatedAt = existing.CreatedAt
+	view.UpdatedAt = time.Now()
+	updated, err := r.Registry.Update(ctx, view)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update saved view", err)
+	}
+	return updated, nil
+}
+
+func (r *SavedViewRegistry) Delete(ctx context.Context, id string) error {
+	if _, err := r.Get(ctx, id); err != nil {
+		return err
+	}
+	return r.Registry.Delete(ctx, id)
+}
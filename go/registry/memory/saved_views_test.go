@@ -0,0 +1,114 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func TestSavedViewRegistry_PrivateViewsAreCreatorOnly(t *testing.T) {
+	c := qt.New(t)
+	factorySet := memory.NewFactorySet()
+	userReg := factorySet.CreateServiceRegistrySet().UserRegistry
+	newMember := func(id string) (context.Context, *registry.Set) {
+		u, err := userReg.Create(context.Background(), models.User{
+			TenantAwareEntityID: models.TenantAwareEntityID{
+				EntityID: models.EntityID{ID: id},
+				TenantID: "views-tenant",
+			},
+			Email: id + "@example.com",
+			Name:  id,
+		})
+		c.Assert(err, qt.IsNil)
+		ctx := appctx.WithUser(context.Background(), u)
+		ctx = appctx.WithGroup(ctx, &models.LocationGroup{
+			TenantAwareEntityID: models.TenantAwareEntityID{
+				EntityID: models.EntityID{ID: "views-group"},
+				TenantID: "views-tenant",
+			},
+			Slug: "views-group",
+		})
+		return ctx, must.Must(factorySet.CreateUserRegistrySet(ctx))
+	}
+	aliceCtx, alice := newMember("alice")
+	bobCtx, bob := newMember("bob")
+
+	private, err := alice.SavedViewRegistry.Create(aliceCtx, models.SavedView{
+		Name:  "Mine",
+		Scope: models.SavedViewScopePrivate,
+	})
+	c.Assert(err, qt.IsNil)
+	_, err = alice.SavedViewRegistry.Create(aliceCtx, models.SavedView{
+		Name:    "Electronics",
+		Scope:   models.SavedViewScopeGroup,
+		Filters: models.SavedViewFilters{Types: []models.CommodityType{models.CommodityTypeElectronics}},
+	})
+	c.Assert(err, qt.IsNil)
+
+	views, err := alice.SavedViewRegistry.List(aliceCtx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(views, qt.HasLen, 2)
+	c.Assert(views[0].Name, qt.Equals, "Electronics")
+
+	views, err = bob.SavedViewRegistry.List(bobCtx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(views, qt.HasLen, 1)
+	c.Assert(views[0].Name, qt.Equals, "Electronics")
+
+	_, err = bob.SavedViewRegistry.Get(bobCtx, private.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	c.Assert(bob.SavedViewRegistry.Delete(bobCtx, private.ID), qt.ErrorIs, registry.ErrNotFound)
+
+	// The creator cannot be reassigned through Update.
+	creator := private.CreatedByUserID
+	edited := *private
+	edited.Scope = models.SavedViewScopeGroup
+	edited.CreatedByUserID = "someone-else"
+	updated, err := alice.SavedViewRegistry.Update(aliceCtx, edited)
+	c.Assert(err, qt.IsNil)
+	c.Assert(updated.CreatedByUserID, qt.Equals, creator)
+	_, err = bob.SavedViewRegistry.Get(bobCtx, private.ID)
+	c.Assert(err, qt.IsNil)
+}
+
+func TestCommodityRegistry_TagFilterAndFilteredStats(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID := newCommodityWarrantyFixture(c)
+
+	for _, cm := range []models.Commodity{
+		{Name: "TV", CurrentPrice: decimal.NewFromInt(500), Tags: []string{"media"}},
+		{Name: "Speaker", CurrentPrice: decimal.NewFromInt(100), Tags: []string{"audio", "media"}},
+		{Name: "Sofa", CurrentPrice: decimal.NewFromInt(300), Tags: []string{"living"}},
+	} {
+		cm.AreaID = new(areaID)
+		cm.Type = models.CommodityTypeElectronics
+		cm.Status = models.CommodityStatusInUse
+		cm.Count = 1
+		_, err := regSet.CommodityRegistry.Create(ctx, cm)
+		c.Assert(err, qt.IsNil)
+	}
+
+	opts := registry.CommodityListOptions{Tags: []string{"audio", "living"}, IncludeInactive: true}
+	items, total, err := regSet.CommodityRegistry.ListPaginated(ctx, 0, 10, opts)
+	c.Assert(err, qt.IsNil)
+	c.Assert(total, qt.Equals, 2)
+	c.Assert(items[0].Name, qt.Equals, "Sofa")
+	c.Assert(items[1].Name, qt.Equals, "Speaker")
+
+	filter := registry.CommodityListOptions{Tags: []string{"media"}, IncludeInactive: true}
+	stats, err := regSet.CommodityRegistry.AggregateStats(ctx, registry.CommodityStatsOptions{
+		GroupCurrency: "USD",
+		Filter:        &filter,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.TotalCount, qt.Equals, 2)
+	c.Assert(stats.TotalValue.String(), qt.Equals, "600")
+}
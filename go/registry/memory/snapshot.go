@@ -0,0 +1,315 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// SnapshotFormat identifies an Inventario memory snapshot file.
+const SnapshotFormat = "inventario-memory-snapshot"
+
+// SnapshotVersion is the snapshot layout this build writes. Bump it when a
+// table is renamed or a row changes shape in a way the codec can't absorb
+// (added and removed fields are absorbed: missing ones load as zero).
+const SnapshotVersion = 1
+
+// DefaultSnapshotInterval is how often a snapshot-backed memory registry
+// is written when the DSN doesn't say.
+const DefaultSnapshotInterval = time.Minute
+
+var (
+	// ErrSnapshotFormat is returned for a file that isn't a memory
+	// snapshot, or whose version this build can't read.
+	ErrSnapshotFormat = errx.NewSentinel("unsupported memory snapshot")
+	// ErrSnapshotBusy is returned when the registries stay under write
+	// load for the whole capture window.
+	ErrSnapshotBusy = errx.NewSentinel("memory snapshot could not lock the registries")
+)
+
+// snapshotLockAttempts bounds how often a capture retries taking every
+// table lock before giving up until the next interval.
+const snapshotLockAttempts = 200
+
+// snapshotDocument is the on-disk layout: a header and one JSON tree per
+// table, keyed by table name.
+type snapshotDocument struct {
+	Format  string         `json:"format"`
+	Version int            `json:"version"`
+	TakenAt time.Time      `json:"taken_at"`
+	Tables  map[string]any `json:"tables"`
+}
+
+// SnapshotInfo describes a snapshot file without loading it into a
+// registry.
+type SnapshotInfo struct {
+	Path    string
+	Version int
+	TakenAt time.Time
+	Size    int64
+	// Rows is the number of rows (or index entries) per table.
+	Rows map[string]int
+}
+
+// Snapshotter persists a memory FactorySet to a single file. Save
+// captures every table under its lock at once, so the file never holds
+// half of a multi-table write, and replaces the file by atomic rename, so
+// a crash mid-write leaves the previous snapshot intact.
+type Snapshotter struct {
+	path   string
+	tables []snapshotTable
+	nowFn  func() time.Time
+
+	// saveMu serialises Save so the periodic and the shutdown snapshot
+	// never race on the temp file.
+	saveMu sync.Mutex
+}
+
+// NewSnapshotter builds a Snapshotter for fs, which must come from
+// NewFactorySet.
+func NewSnapshotter(fs *registry.FactorySet, path string) (*Snapshotter, error) {
+	if path == "" {
+		return nil, errxtrace.Classify(registry.ErrInvalidConfig, errx.Attrs("reason", "snapshot path is empty"))
+	}
+	tables, err := snapshotTables(fs)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to enumerate memory registries", err)
+	}
+	return &Snapshotter{
+		path:   path,
+		tables: tables,
+		nowFn:  func() time.Time { return time.Now().UTC() },
+	}, nil
+}
+
+// Path returns the snapshot file path.
+func (s *Snapshotter) Path() string {
+	return s.path
+}
+
+// Load replaces the registries' contents with the snapshot file. It
+// reports false when the file does not exist yet, which is the first boot
+// of a new install.
+func (s *Snapshotter) Load() (bool, error) {
+	doc, err := readSnapshotDocument(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	known := make(map[string]bool, len(s.tables))
+	for _, t := range s.tables {
+		known[t.name] = true
+		data, ok := doc.Tables[t.name]
+		if !ok {
+			continue
+		}
+		if err := t.load(data); err != nil {
+			return false, errxtrace.Wrap("failed to restore memory snapshot table", err, errx.Attrs("table", t.name))
+		}
+	}
+	for name := range doc.Tables {
+		if !known[name] {
+			slog.Warn("Ignoring unknown table in memory snapshot", "table", name, "path", s.path)
+		}
+	}
+	slog.Info("Loaded memory snapshot", "path", s.path, "taken_at", doc.TakenAt)
+	return true, nil
+}
+
+// Save captures every table and writes the snapshot file.
+func (s *Snapshotter) Save(ctx context.Context) error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	doc := snapshotDocument{
+		Format:  SnapshotFormat,
+		Version: SnapshotVersion,
+	}
+	tables, err := s.capture(ctx)
+	if err != nil {
+		return err
+	}
+	doc.Tables = tables
+	doc.TakenAt = s.nowFn()
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return errxtrace.Wrap("failed to encode memory snapshot", err)
+	}
+	if err := writeSnapshotAtomically(s.path, data); err != nil {
+		return errxtrace.Wrap("failed to write memory snapshot", err, errx.Attrs("path", s.path))
+	}
+	return nil
+}
+
+// Run saves a snapshot every interval until ctx is done. Failures are
+// logged and retried on the next tick; the final snapshot is the
+// caller's job (see NewMemoryRegistrySet).
+func (s *Snapshotter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Save(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Failed to save memory snapshot", "path", s.path, "error", err)
+			}
+		}
+	}
+}
+
+// capture takes every table lock without blocking and, once it holds all
+// of them, encodes the tables. Blocking on the locks one by one could
+// deadlock against a write that holds one table and waits for another,
+// so a busy lock releases everything and retries after a short pause.
+func (s *Snapshotter) capture(ctx context.Context) (map[string]any, error) {
+	for attempt := range snapshotLockAttempts {
+		if s.tryLockAll() {
+			tables, err := s.encodeLocked()
+			s.unlockAll(len(s.tables))
+			return tables, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(min(attempt+1, 50)) * time.Millisecond):
+		}
+	}
+	return nil, errxtrace.Classify(ErrSnapshotBusy, errx.Attrs("attempts", snapshotLockAttempts))
+}
+
+func (s *Snapshotter) tryLockAll() bool {
+	for i, t := range s.tables {
+		if !t.tryLock() {
+			s.unlockAll(i)
+			return false
+		}
+	}
+	return true
+}
+
+// unlockAll releases the locks of the first n tables.
+func (s *Snapshotter) unlockAll(n int) {
+	for _, t := range s.tables[:n] {
+		t.unlock()
+	}
+}
+
+func (s *Snapshotter) encodeLocked() (map[string]any, error) {
+	tables := make(map[string]any, len(s.tables))
+	for _, t := range s.tables {
+		data, err := t.save()
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to capture memory snapshot table", err, errx.Attrs("table", t.name))
+		}
+		tables[t.name] = data
+	}
+	return tables, nil
+}
+
+// ReadSnapshotInfo reads the header and row counts of the snapshot at
+// path.
+func ReadSnapshotInfo(path string) (*SnapshotInfo, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := readSnapshotDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	info := &SnapshotInfo{
+		Path:    path,
+		Version: doc.Version,
+		TakenAt: doc.TakenAt,
+		Size:    stat.Size(),
+		Rows:    make(map[string]int, len(doc.Tables)),
+	}
+	for name, data := range doc.Tables {
+		switch rows := data.(type) {
+		case []any:
+			info.Rows[name] = len(rows)
+		case map[string]any:
+			info.Rows[name] = len(rows)
+		default:
+			info.Rows[name] = 0
+		}
+	}
+	return info, nil
+}
+
+func readSnapshotDocument(path string) (*snapshotDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc snapshotDocument
+	if err := dec.Decode(&doc); err != nil {
+		return nil, errxtrace.Classify(ErrSnapshotFormat, errx.Attrs("path", path, "reason", err.Error()))
+	}
+	if doc.Format != SnapshotFormat {
+		return nil, errxtrace.Classify(ErrSnapshotFormat, errx.Attrs("path", path, "format", doc.Format))
+	}
+	if doc.Version < 1 || doc.Version > SnapshotVersion {
+		return nil, errxtrace.Classify(ErrSnapshotFormat, errx.Attrs("path", path, "version", doc.Version, "supported", SnapshotVersion))
+	}
+	return &doc, nil
+}
+
+// writeSnapshotAtomically writes data to a temp file next to path, syncs
+// it and renames it over path, so readers see either the old or the new
+// snapshot and never a torn one.
+func writeSnapshotAtomically(path string, data []byte) (err error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp snapshot: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmpName)
+		}
+	}()
+
+	if _, werr := tmp.Write(data); werr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write temp snapshot: %w", werr)
+	}
+	if serr := tmp.Sync(); serr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync temp snapshot: %w", serr)
+	}
+	if cerr := tmp.Close(); cerr != nil {
+		return fmt.Errorf("failed to close temp snapshot: %w", cerr)
+	}
+	if rerr := os.Rename(tmpName, path); rerr != nil {
+		return fmt.Errorf("failed to move snapshot into place: %w", rerr)
+	}
+	// Persist the rename itself. Best effort: not every platform can
+	// fsync a directory.
+	if d, derr := os.Open(dir); derr == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+package memory
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// The snapshot codec turns registry rows into JSON-ready trees keyed by Go
+// field name, and back. encoding/json can't be used on the models directly:
+// their json tags hide tenant ids, token hashes and password hashes from API
+// responses (`json:"-"`), and a snapshot must keep every field. encoding/gob
+// keeps the fields but flattens a pointer to a zero value into nil, which
+// would turn an explicit `false` setting into "not set".
+//
+// The rules are deliberately small:
+//   - structs become objects keyed by exported field name (tags ignored);
+//     unexported fields are skipped;
+//   - structs that keep their state in unexported fields and marshal
+//     themselves (time.Time, decimal.Decimal) are stored via their JSON form;
+//   - nil pointers, maps and slices become null; []byte is base64;
+//   - maps need string or integer keys.
+
+var (
+	jsonMarshalerType   = reflect.TypeFor[json.Marshaler]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// snapshotValue converts v into a tree of JSON-ready values.
+func snapshotValue(v reflect.Value) (any, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return snapshotValue(v.Elem())
+	case reflect.Struct:
+		return snapshotStruct(v)
+	case reflect.Map:
+		return snapshotMap(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+		return snapshotList(v)
+	case reflect.Array:
+		return snapshotList(v)
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	default:
+		return nil, fmt.Errorf("snapshot: unsupported kind %s (%s)", v.Kind(), v.Type())
+	}
+}
+
+func snapshotStruct(v reflect.Value) (any, error) {
+	t := v.Type()
+	if selfMarshaling(t) {
+		raw, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("snapshot: marshal %s: %w", t, err)
+		}
+		return json.RawMessage(raw), nil
+	}
+	out := make(map[string]any, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		val, err := snapshotValue(v.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+		out[f.Name] = val
+	}
+	return out, nil
+}
+
+func snapshotMap(v reflect.Value) (any, error) {
+	if v.IsNil() {
+		return nil, nil
+	}
+	out := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := snapshotMapKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		val, err := snapshotValue(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		out[key] = val
+	}
+	return out, nil
+}
+
+func snapshotList(v reflect.Value) (any, error) {
+	out := make([]any, v.Len())
+	for i := range v.Len() {
+		val, err := snapshotValue(v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = val
+	}
+	return out, nil
+}
+
+func snapshotMapKey(k reflect.Value) (string, error) {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("snapshot: unsupported map key type %s", k.Type())
+	}
+}
+
+// restoreValue decodes data, a tree produced by snapshotValue and read back
+// with json.Decoder.UseNumber, into the settable v.
+func restoreValue(data any, v reflect.Value) error {
+	if data == nil {
+		v.SetZero()
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := restoreValue(data, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("snapshot: cannot restore interface type %s", v.Type())
+		}
+		v.Set(reflect.ValueOf(data))
+		return nil
+	case reflect.Struct:
+		return restoreStruct(data, v)
+	case reflect.Map:
+		return restoreMap(data, v)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			s, err := restoreAs[string](data, v)
+			if err != nil {
+				return err
+			}
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return fmt.Errorf("snapshot: %s: %w", v.Type(), err)
+			}
+			v.Set(reflect.ValueOf(b).Convert(v.Type()))
+			return nil
+		}
+		list, err := restoreAs[[]any](data, v)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.MakeSlice(v.Type(), len(list), len(list)))
+		return restoreList(list, v)
+	case reflect.Array:
+		list, err := restoreAs[[]any](data, v)
+		if err != nil {
+			return err
+		}
+		if len(list) != v.Len() {
+			return fmt.Errorf("snapshot: %s wants %d elements, got %d", v.Type(), v.Len(), len(list))
+		}
+		return restoreList(list, v)
+	case reflect.Bool:
+		b, err := restoreAs[bool](data, v)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := restoreAs[json.Number](data, v)
+		if err != nil {
+			return err
+		}
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil || v.OverflowInt(i) {
+			return fmt.Errorf("snapshot: %q does not fit %s", n, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := restoreAs[json.Number](data, v)
+		if err != nil {
+			return err
+		}
+		u, err := strconv.ParseUint(n.String(), 10, 64)
+		if err != nil || v.OverflowUint(u) {
+			return fmt.Errorf("snapshot: %q does not fit %s", n, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := restoreAs[json.Number](data, v)
+		if err != nil {
+			return err
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return fmt.Errorf("snapshot: %s: %w", v.Type(), err)
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.String:
+		s, err := restoreAs[string](data, v)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+		return nil
+	default:
+		return fmt.Errorf("snapshot: unsupported kind %s (%s)", v.Kind(), v.Type())
+	}
+}
+
+func restoreStruct(data any, v reflect.Value) error {
+	t := v.Type()
+	if selfMarshaling(t) {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("snapshot: %s: %w", t, err)
+		}
+		if err := json.Unmarshal(raw, v.Addr().Interface()); err != nil {
+			return fmt.Errorf("snapshot: %s: %w", t, err)
+		}
+		return nil
+	}
+	fields, err := restoreAs[map[string]any](data, v)
+	if err != nil {
+		return err
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		raw, ok := fields[f.Name]
+		if !ok {
+			// Field added after the snapshot was taken: keep the zero value.
+			continue
+		}
+		if err := restoreValue(raw, v.Field(i)); err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+	}
+	return nil
+}
+
+func restoreMap(data any, v reflect.Value) error {
+	entries, err := restoreAs[map[string]any](data, v)
+	if err != nil {
+		return err
+	}
+	t := v.Type()
+	m := reflect.MakeMapWithSize(t, len(entries))
+	for rawKey, raw := range entries {
+		key := reflect.New(t.Key()).Elem()
+		if err := restoreMapKey(rawKey, key); err != nil {
+			return err
+		}
+		elem := reflect.New(t.Elem()).Elem()
+		if err := restoreValue(raw, elem); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, elem)
+	}
+	v.Set(m)
+	return nil
+}
+
+func restoreList(list []any, v reflect.Value) error {
+	for i, raw := range list {
+		if err := restoreValue(raw, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func restoreMapKey(s string, k reflect.Value) error {
+	switch k.Kind() {
+	case reflect.String:
+		k.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || k.OverflowInt(i) {
+			return fmt.Errorf("snapshot: map key %q does not fit %s", s, k.Type())
+		}
+		k.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || k.OverflowUint(u) {
+			return fmt.Errorf("snapshot: map key %q does not fit %s", s, k.Type())
+		}
+		k.SetUint(u)
+	default:
+		return fmt.Errorf("snapshot: unsupported map key type %s", k.Type())
+	}
+	return nil
+}
+
+// restoreAs asserts data to the JSON shape T expected for v.
+func restoreAs[T any](data any, v reflect.Value) (T, error) {
+	out, ok := data.(T)
+	if !ok {
+		return out, fmt.Errorf("snapshot: cannot restore %T into %s", data, v.Type())
+	}
+	return out, nil
+}
+
+// selfMarshaling reports whether t keeps state in unexported fields and
+// round-trips through its own JSON methods, like time.Time and
+// decimal.Decimal. Model structs have only exported fields, so their
+// MarshalJSON (which may reshape the API view) is never used here.
+func selfMarshaling(t reflect.Type) bool {
+	if !t.Implements(jsonMarshalerType) || !reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return false
+	}
+	for i := range t.NumField() {
+		if !t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
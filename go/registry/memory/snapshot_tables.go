@@ -0,0 +1,264 @@
+package memory
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// snapshotTable is one unit of memory-backend state in a snapshot: a
+// registry's rows or one of the side indexes a registry keeps next to them.
+type snapshotTable struct {
+	name string
+	// tryLock takes the table's lock for reading without blocking; unlock
+	// releases it. The snapshotter holds every table's lock at once while
+	// capturing, which is what makes a snapshot consistent across tables.
+	tryLock func() bool
+	unlock  func()
+	// save captures the table as a JSON-ready tree. Called with the lock
+	// held.
+	save func() (any, error)
+	// load replaces the table with a tree produced by save. It takes the
+	// write lock itself.
+	load func(data any) error
+}
+
+// registryTable snapshots a generic Registry in insertion order.
+func registryTable[T any, P registry.PIDable[T]](name string, r *Registry[T, P]) snapshotTable {
+	return snapshotTable{
+		name:    name,
+		tryLock: r.lock.TryRLock,
+		unlock:  r.lock.RUnlock,
+		save: func() (any, error) {
+			rows := make([]any, 0, r.items.Len())
+			for pair := r.items.Oldest(); pair != nil; pair = pair.Next() {
+				row, err := snapshotValue(reflect.ValueOf(pair.Value))
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, row)
+			}
+			return rows, nil
+		},
+		load: func(data any) error {
+			var items []P
+			if err := restoreValue(data, reflect.ValueOf(&items).Elem()); err != nil {
+				return err
+			}
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			for pair := r.items.Oldest(); pair != nil; {
+				next := pair.Next()
+				r.items.Delete(pair.Key)
+				pair = next
+			}
+			for _, item := range items {
+				r.items.Set(item.GetID(), item)
+			}
+			return nil
+		},
+	}
+}
+
+// mapTable snapshots a string-keyed map guarded by lock. The map is
+// refilled in place on load, because registries derived from a factory
+// share it. reindex, when set, rebuilds derived indexes under the lock.
+func mapTable[V any](name string, lock sync.Locker, tryLock func() bool, m map[string]V, reindex func()) snapshotTable {
+	return snapshotTable{
+		name:    name,
+		tryLock: tryLock,
+		unlock:  lock.Unlock,
+		save: func() (any, error) {
+			return snapshotValue(reflect.ValueOf(m))
+		},
+		load: func(data any) error {
+			var restored map[string]V
+			if err := restoreValue(data, reflect.ValueOf(&restored).Elem()); err != nil {
+				return err
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			clear(m)
+			maps.Copy(m, restored)
+			if reindex != nil {
+				reindex()
+			}
+			return nil
+		},
+	}
+}
+
+// rwMapTable is mapTable for a map guarded by a sync.RWMutex: capture
+// takes the read lock, load the write lock.
+func rwMapTable[V any](name string, lock *sync.RWMutex, m map[string]V, reindex func()) snapshotTable {
+	t := mapTable(name, lock, lock.TryRLock, m, reindex)
+	t.unlock = lock.RUnlock
+	return t
+}
+
+// snapshotTables lists every table of a memory FactorySet. It fails when a
+// field holds something other than the memory implementation, e.g. a test
+// double, since the snapshot could not capture it.
+//
+//nolint:funlen // one line per registry; splitting it hides the inventory.
+func snapshotTables(fs *registry.FactorySet) (tables []snapshotTable, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			tables, err = nil, fmt.Errorf("memory snapshot: factory set is not a memory factory set: %v", r)
+		}
+	}()
+
+	locations := fs.LocationRegistryFactory.(*LocationRegistryFactory)
+	areas := fs.AreaRegistryFactory.(*AreaRegistryFactory)
+	settings := fs.SettingsRegistryFactory.(*SettingsRegistryFactory)
+	currency := fs.CurrencyMigrationRegistryFactory.(*CurrencyMigrationRegistryFactory)
+	opSlots := fs.OperationSlotRegistryFactory.(*OperationSlotRegistryFactory).registry
+	grants := fs.SystemAdminGrantRegistry.(*SystemAdminGrantRegistry)
+	workerControls := fs.WorkerControlRegistry.(*WorkerControlRegistry)
+	backupKeys := fs.TrustedBackupKeyRegistry.(*TrustedBackupKeyRegistry)
+	scanAudits := fs.CommodityScanAuditRegistry.(*CommodityScanAuditRegistry)
+	scanBudgets := fs.CommodityScanBudgetRegistry.(*CommodityScanBudgetRegistry)
+	registrations := fs.RegistrationRequestRegistry.(*RegistrationRequestRegistry)
+	idempotencyKeys := fs.IdempotencyKeyRegistry.(*IdempotencyKeyRegistry)
+
+	tables = []snapshotTable{
+		// Tenant and identity tables.
+		registryTable("tenants", fs.TenantRegistry.(*TenantRegistry).baseTenantRegistry),
+		registryTable("users", fs.UserRegistry.(*UserRegistry).baseUserRegistry),
+		registryTable("refresh_tokens", fs.RefreshTokenRegistry.(*RefreshTokenRegistry).baseRefreshTokenRegistry),
+		registryTable("calendar_feeds", fs.CalendarFeedRegistry.(*CalendarFeedRegistry).baseCalendarFeedRegistry),
+		registryTable("login_events", fs.LoginEventRegistry.(*LoginEventRegistry).baseLoginEventRegistry),
+		registryTable("user_mfa_secrets", fs.UserMFASecretRegistry.(*UserMFASecretRegistry).baseUserMFASecretRegistry),
+		registryTable("email_verifications", fs.EmailVerificationRegistry.(*EmailVerificationRegistry).baseEmailVerificationRegistry),
+		registryTable("password_resets", fs.PasswordResetRegistry.(*PasswordResetRegistry).basePasswordResetRegistry),
+		registryTable("magic_link_tokens", fs.MagicLinkTokenRegistry.(*MagicLinkTokenRegistry).baseMagicLinkTokenRegistry),
+		registryTable("oauth_identities", fs.OAuthIdentityRegistry.(*OAuthIdentityRegistry).baseOAuthIdentityRegistry),
+		registryTable("audit_logs", fs.AuditLogRegistry.(*AuditLogRegistry).baseAuditLogRegistry),
+
+		// Back office and platform control plane.
+		registryTable("backoffice_users", fs.BackofficeUserRegistry.(*BackofficeUserRegistry).baseBackofficeUserRegistry),
+		registryTable("backoffice_refresh_tokens", fs.BackofficeRefreshTokenRegistry.(*BackofficeRefreshTokenRegistry).baseBackofficeRefreshTokenRegistry),
+		registryTable("backoffice_user_mfa_secrets", fs.BackofficeUserMFASecretRegistry.(*BackofficeUserMFASecretRegistry).baseBackofficeUserMFASecretRegistry),
+		mapTable("system_admin_grants", &grants.lock, grants.lock.TryLock, grants.items, func() {
+			clear(grants.byUserID)
+			for id, g := range grants.items {
+				grants.byUserID[g.UserID] = id
+			}
+		}),
+		mapTable("worker_control", &workerControls.lock, workerControls.lock.TryLock, workerControls.items, nil),
+		mapTable("trusted_backup_keys", &backupKeys.lock, backupKeys.lock.TryLock, backupKeys.items, nil),
+		commodityScanBudgetTable(scanBudgets),
+		mapTable("registration_requests", &registrations.lock, registrations.lock.TryLock, registrations.items, nil),
+		mapTable("idempotency_keys", &idempotencyKeys.lock, idempotencyKeys.lock.TryLock, idempotencyKeys.items, nil),
+
+		// Groups.
+		registryTable("location_groups", fs.LocationGroupRegistry.(*LocationGroupRegistry).baseLocationGroupRegistry),
+		registryTable("group_memberships", fs.GroupMembershipRegistry.(*GroupMembershipRegistry).baseGroupMembershipRegistry),
+		registryTable("group_invites", fs.GroupInviteRegistry.(*GroupInviteRegistry).baseGroupInviteRegistry),
+		registryTable("group_invites_audit", fs.GroupInviteAuditRegistry.(*GroupInviteAuditRegistry).baseGroupInviteAuditRegistry),
+		registryTable("group_notification_prefs", fs.GroupNotificationPrefRegistry.(*GroupNotificationPrefRegistry).baseGroupNotificationPrefRegistry),
+		registryTable("group_member_location_scopes", fs.GroupMemberLocationScopeRegistry.(*GroupMemberLocationScopeRegistry).baseGroupMemberLocationScopeRegistry),
+
+		// Inventory.
+		registryTable("locations", locations.baseLocationRegistry),
+		rwMapTable("location_areas", locations.areasLock, locations.areas, nil),
+		registryTable("areas", areas.baseAreaRegistry),
+		rwMapTable("area_commodities", areas.commoditiesLock, areas.commodities, nil),
+		registryTable("commodities", fs.CommodityRegistryFactory.(*CommodityRegistryFactory).baseCommodityRegistry),
+		registryTable("commodity_events", fs.CommodityEventRegistryFactory.(*CommodityEventRegistryFactory).base),
+		registryTable("commodity_loans", fs.CommodityLoanRegistryFactory.(*CommodityLoanRegistryFactory).base),
+		registryTable("commodity_services", fs.CommodityServiceRegistryFactory.(*CommodityServiceRegistryFactory).base),
+		registryTable("commodity_meter_readings", fs.CommodityMeterReadingRegistryFactory.(*CommodityMeterReadingRegistryFactory).base),
+		registryTable("supply_links", fs.SupplyLinkRegistryFactory.(*SupplyLinkRegistryFactory).base),
+		registryTable("maintenance_schedules", fs.MaintenanceScheduleRegistryFactory.(*MaintenanceScheduleRegistryFactory).base),
+		registryTable("maintenance_logs", fs.MaintenanceLogRegistryFactory.(*MaintenanceLogRegistryFactory).base),
+		registryTable("tags", fs.TagRegistryFactory.(*TagRegistryFactory).base),
+		registryTable("files", fs.FileRegistryFactory.(*FileRegistryFactory).baseFileRegistry),
+		registryTable("saved_views", fs.SavedViewRegistryFactory.(*SavedViewRegistryFactory).base),
+		rwMapTable("settings", settings.lock, settings.settings, nil),
+
+		// Reminders, scans and background jobs.
+		registryTable("warranty_reminders", fs.WarrantyReminderRegistry.(*WarrantyReminderRegistry).baseWarrantyReminderRegistry),
+		registryTable("storage_quota_reminders", fs.StorageQuotaReminderRegistry.(*StorageQuotaReminderRegistry).baseStorageQuotaReminderRegistry),
+		registryTable("maintenance_reminders", fs.MaintenanceReminderRegistry.(*MaintenanceReminderRegistry).baseMaintenanceReminderRegistry),
+		registryTable("invoice_extractions", fs.InvoiceExtractionRegistryFactory.(*InvoiceExtractionRegistryFactory).base),
+		rwMapTable("commodity_scan_audits", &scanAudits.mu, scanAudits.items, nil),
+		registryTable("backup_schedules", fs.BackupScheduleRegistryFactory.(*BackupScheduleRegistryFactory).base),
+		registryTable("exports", fs.ExportRegistryFactory.(*ExportRegistryFactory).baseExportRegistry),
+		registryTable("restore_operations", fs.RestoreOperationRegistryFactory.(*RestoreOperationRegistryFactory).baseRestoreOperationRegistry),
+		registryTable("restore_steps", fs.RestoreStepRegistryFactory.(*RestoreStepRegistryFactory).baseRestoreStepRegistry),
+		registryTable("thumbnail_generation_jobs", fs.ThumbnailGenerationJobRegistryFactory.(*ThumbnailGenerationJobRegistryFactory).baseThumbnailGenerationJobRegistry),
+		registryTable("currency_migrations", currency.baseRegistry),
+		currencyMigrationAuditTable(currency),
+		registryTable("user_concurrency_slots", fs.UserConcurrencySlotRegistryFactory.(*UserConcurrencySlotRegistryFactory).baseUserConcurrencySlotRegistry),
+		rwMapTable("operation_slots", &opSlots.mu, opSlots.slots, func() {
+			clear(opSlots.userSlots)
+			for id, slot := range opSlots.slots {
+				if opSlots.userSlots[slot.UserID] == nil {
+					opSlots.userSlots[slot.UserID] = make(map[string]map[int]string)
+				}
+				if opSlots.userSlots[slot.UserID][slot.OperationName] == nil {
+					opSlots.userSlots[slot.UserID][slot.OperationName] = make(map[int]string)
+				}
+				opSlots.userSlots[slot.UserID][slot.OperationName][slot.SlotID] = id
+			}
+		}),
+	}
+	return tables, nil
+}
+
+// commodityScanBudgetTable snapshots the budgets as a list; the map key is
+// rebuilt from each row.
+func commodityScanBudgetTable(r *CommodityScanBudgetRegistry) snapshotTable {
+	return snapshotTable{
+		name:    "commodity_scan_budgets",
+		tryLock: r.lock.TryLock,
+		unlock:  r.lock.Unlock,
+		save: func() (any, error) {
+			return snapshotValue(reflect.ValueOf(r.sorted("")))
+		},
+		load: func(data any) error {
+			var budgets []*models.CommodityScanBudget
+			if err := restoreValue(data, reflect.ValueOf(&budgets).Elem()); err != nil {
+				return err
+			}
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			clear(r.items)
+			for _, b := range budgets {
+				r.items[commodityScanBudgetKey{b.TenantID, b.GroupID}] = b
+			}
+			return nil
+		},
+	}
+}
+
+// currencyMigrationAuditTable snapshots the append-only currency migration
+// audit trail shared by every registry of the factory.
+func currencyMigrationAuditTable(f *CurrencyMigrationRegistryFactory) snapshotTable {
+	return snapshotTable{
+		name:    "currency_migration_audit",
+		tryLock: f.auditLock.TryRLock,
+		unlock:  f.auditLock.RUnlock,
+		save: func() (any, error) {
+			return snapshotValue(reflect.ValueOf(*f.auditRows))
+		},
+		load: func(data any) error {
+			var rows []*models.CurrencyMigrationAuditRow
+			if err := restoreValue(data, reflect.ValueOf(&rows).Elem()); err != nil {
+				return err
+			}
+			if rows == nil {
+				rows = make([]*models.CurrencyMigrationAuditRow, 0)
+			}
+			f.auditLock.Lock()
+			defer f.auditLock.Unlock()
+			*f.auditRows = rows
+			return nil
+		},
+	}
+}
@@ -0,0 +1,215 @@
+package memory_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/debug/seeddata"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func openSnapshotSet(c *qt.C, path string) *registry.FactorySet {
+	c.Helper()
+	newFn, _ := memory.NewMemoryRegistrySet()
+	fs, err := newFn(registry.Config("memory://?snapshot=" + path + "&snapshot_interval=1h"))
+	c.Assert(err, qt.IsNil)
+	return fs
+}
+
+func TestSnapshot_RoundTripAcrossRestart(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "inventario.json")
+
+	fs := openSnapshotSet(c, path)
+
+	tenant, err := fs.TenantRegistry.Create(ctx, models.Tenant{Name: "Home", Slug: "home", Status: models.TenantStatusActive})
+	c.Assert(err, qt.IsNil)
+	u := models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: tenant.ID},
+		Email:               "owner@example.com",
+		Name:                "Owner",
+		IsActive:            true,
+	}
+	c.Assert(u.SetPassword("Password123"), qt.IsNil)
+	user, err := fs.UserRegistry.Create(ctx, u)
+	c.Assert(err, qt.IsNil)
+	_, err = fs.SystemAdminGrantRegistry.Grant(ctx, user.ID, nil)
+	c.Assert(err, qt.IsNil)
+	_, err = fs.CommodityScanBudgetRegistry.Set(ctx, models.CommodityScanBudget{
+		TenantID:        tenant.ID,
+		MonthlyLimitUSD: decimal.RequireFromString("12.50"),
+	})
+	c.Assert(err, qt.IsNil)
+
+	locations := fs.LocationRegistryFactory.CreateServiceRegistry()
+	location, err := locations.Create(ctx, models.Location{Name: "Garage"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(locations.(*memory.LocationRegistry).AddArea(ctx, location.ID, "area-1"), qt.IsNil)
+
+	userCtx := appctx.WithUser(ctx, user)
+	settings, err := fs.SettingsRegistryFactory.CreateUserRegistry(userCtx)
+	c.Assert(err, qt.IsNil)
+	off := false
+	c.Assert(settings.Save(userCtx, models.SettingsObject{ShowDebugInfo: &off}), qt.IsNil)
+
+	c.Assert(fs.Close(), qt.IsNil)
+
+	// A fresh process restores everything from the file.
+	restored := openSnapshotSet(c, path)
+	defer restored.Close()
+
+	gotTenant, err := restored.TenantRegistry.Get(ctx, tenant.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotTenant.Slug, qt.Equals, "home")
+
+	gotUser, err := restored.UserRegistry.Get(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	// Fields hidden from the API (json:"-") survive the snapshot.
+	c.Assert(gotUser.TenantID, qt.Equals, tenant.ID)
+	c.Assert(gotUser.CheckPassword("Password123"), qt.IsTrue)
+
+	// The by-user grant index is rebuilt.
+	isAdmin, err := restored.SystemAdminGrantRegistry.Exists(ctx, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(isAdmin, qt.IsTrue)
+
+	budgets, err := restored.CommodityScanBudgetRegistry.ListForTenant(ctx, tenant.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(budgets, qt.HasLen, 1)
+	c.Assert(budgets[0].MonthlyLimitUSD.String(), qt.Equals, "12.5")
+
+	areas, err := restored.LocationRegistryFactory.CreateServiceRegistry().GetAreas(ctx, location.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(areas, qt.DeepEquals, []string{"area-1"})
+
+	restoredSettings, err := restored.SettingsRegistryFactory.CreateUserRegistry(appctx.WithUser(ctx, gotUser))
+	c.Assert(err, qt.IsNil)
+	got, err := restoredSettings.Get(ctx)
+	c.Assert(err, qt.IsNil)
+	// An explicit false is not collapsed into "not set".
+	c.Assert(got.ShowDebugInfo, qt.IsNotNil)
+	c.Assert(*got.ShowDebugInfo, qt.IsFalse)
+}
+
+func TestSnapshot_SaveIsAtomicAndVersioned(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "inventario.json")
+
+	fs := memory.NewFactorySet()
+	snapshotter, err := memory.NewSnapshotter(fs, path)
+	c.Assert(err, qt.IsNil)
+
+	loaded, err := snapshotter.Load()
+	c.Assert(err, qt.IsNil)
+	c.Assert(loaded, qt.IsFalse)
+
+	c.Assert(snapshotter.Save(context.Background()), qt.IsNil)
+	c.Assert(snapshotter.Save(context.Background()), qt.IsNil)
+
+	// Only the snapshot itself remains; temp files were renamed away.
+	entries, err := os.ReadDir(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 1)
+
+	info, err := memory.ReadSnapshotInfo(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(info.Version, qt.Equals, memory.SnapshotVersion)
+	c.Assert(time.Since(info.TakenAt) < time.Minute, qt.IsTrue)
+	c.Assert(info.Rows["users"], qt.Equals, 0)
+	_, ok := info.Rows["commodities"]
+	c.Assert(ok, qt.IsTrue)
+}
+
+func TestSnapshot_RejectsNewerVersion(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "inventario.json")
+	data, err := json.Marshal(map[string]any{
+		"format":  memory.SnapshotFormat,
+		"version": memory.SnapshotVersion + 1,
+		"tables":  map[string]any{},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(os.WriteFile(path, data, 0o600), qt.IsNil)
+
+	newFn, _ := memory.NewMemoryRegistrySet()
+	_, err = newFn(registry.Config("memory://?snapshot=" + path))
+	c.Assert(err, qt.ErrorIs, memory.ErrSnapshotFormat)
+
+	_, err = memory.ReadSnapshotInfo(path)
+	c.Assert(err, qt.ErrorIs, memory.ErrSnapshotFormat)
+}
+
+func TestParseSnapshotConfig(t *testing.T) {
+	c := qt.New(t)
+
+	cfg, err := memory.ParseSnapshotConfig("memory://")
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Path, qt.Equals, "")
+
+	cfg, err = memory.ParseSnapshotConfig("memory://?snapshot=/data/inv.json&snapshot_interval=30s")
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Path, qt.Equals, "/data/inv.json")
+	c.Assert(cfg.Interval, qt.Equals, 30*time.Second)
+
+	cfg, err = memory.ParseSnapshotConfig("memory://?snapshot=/data/inv.json")
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Interval, qt.Equals, memory.DefaultSnapshotInterval)
+
+	_, err = memory.ParseSnapshotConfig("memory://?snapshot=/data/inv.json&snapshot_interval=soon")
+	c.Assert(err, qt.ErrorIs, registry.ErrInvalidConfig)
+}
+
+// TestSnapshot_SeedDataIsStable seeds the full demo data set, restores its
+// snapshot into a fresh registry and snapshots that again: every table must
+// come back unchanged.
+func TestSnapshot_SeedDataIsStable(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+
+	fs := memory.NewFactorySet()
+	_, err := seeddata.SeedData(fs, seeddata.SeedOptions{})
+	c.Assert(err, qt.IsNil)
+
+	first := filepath.Join(dir, "first.json")
+	snapshotter, err := memory.NewSnapshotter(fs, first)
+	c.Assert(err, qt.IsNil)
+	c.Assert(snapshotter.Save(context.Background()), qt.IsNil)
+
+	restored := memory.NewFactorySet()
+	second := filepath.Join(dir, "second.json")
+	restoredSnapshotter, err := memory.NewSnapshotter(restored, first)
+	c.Assert(err, qt.IsNil)
+	loaded, err := restoredSnapshotter.Load()
+	c.Assert(err, qt.IsNil)
+	c.Assert(loaded, qt.IsTrue)
+	resaver, err := memory.NewSnapshotter(restored, second)
+	c.Assert(err, qt.IsNil)
+	c.Assert(resaver.Save(context.Background()), qt.IsNil)
+
+	readTables := func(path string) map[string]json.RawMessage {
+		data, err := os.ReadFile(path)
+		c.Assert(err, qt.IsNil)
+		var doc struct {
+			Tables map[string]json.RawMessage `json:"tables"`
+		}
+		c.Assert(json.Unmarshal(data, &doc), qt.IsNil)
+		return doc.Tables
+	}
+	want, got := readTables(first), readTables(second)
+	c.Assert(len(want["commodities"]) > 2, qt.IsTrue, qt.Commentf("seed data produced no commodities"))
+	for name := range want {
+		c.Assert(string(got[name]), qt.Equals, string(want[name]), qt.Commentf("table %s", name))
+	}
+}
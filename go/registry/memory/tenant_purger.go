@@ -141,10 +141,30 @@ func (r *TenantPurger) PurgeTenantDependents(ctx context.Context, tenantID strin
 			}
 			return nil
 		}},
+		{"maintenance_logs", func() error {
+			reg := fs.MaintenanceLogRegistryFactory.CreateServiceRegistry()
+			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.MaintenanceLog])
+		}},
+		{"commodity_meter_readings", func() error {
+			reg := fs.CommodityMeterReadingRegistryFactory.CreateServiceRegistry()
+			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.CommodityMeterReading])
+		}},
+		{"invoice_extractions", func() error {
+			reg := fs.InvoiceExtractionRegistryFactory.CreateServiceRegistry()
+			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.InvoiceExtraction])
+		}},
 		{"maintenance_schedules", func() error {
 			reg := fs.MaintenanceScheduleRegistryFactory.CreateServiceRegistry()
 			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.MaintenanceSchedule])
 		}},
+		{"saved_views", func() error {
+			reg := fs.SavedViewRegistryFactory.CreateServiceRegistry()
+			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.SavedView])
+		}},
+		{"backup_schedules", func() error {
+			reg := fs.BackupScheduleRegistryFactory.CreateServiceRegistry()
+			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.BackupSchedule])
+		}},
 		// Commodity sub-resources before commodities.
 		{"commodity_supply_links", func() error {
 			reg := fs.SupplyLinkRegistryFactory.CreateServiceRegistry()
@@ -174,6 +194,23 @@ func (r *TenantPurger) PurgeTenantDependents(ctx context.Context, tenantID strin
 			reg := fs.LocationRegistryFactory.CreateServiceRegistry()
 			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.Location])
 		}},
+		// AI vision spend caps. Platform-level registry without a factory;
+		// nil-guarded for FactorySets built without it.
+		{"commodity_scan_budgets", func() error {
+			if fs.CommodityScanBudgetRegistry == nil {
+				return nil
+			}
+			budgets, listErr := fs.CommodityScanBudgetRegistry.ListForTenant(ctx, tenantID)
+			if listErr != nil {
+				return listErr
+			}
+			for _, b := range budgets {
+				if derr := fs.CommodityScanBudgetRegistry.Delete(ctx, b.TenantID, b.GroupID); derr != nil {
+					return derr
+				}
+			}
+			return nil
+		}},
 		{"tags", func() error {
 			reg := fs.TagRegistryFactory.CreateServiceRegistry()
 			return purgeByTenant(ctx, tenantID, reg.List, reg.Delete, tenantAware[models.Tag])
@@ -189,6 +226,14 @@ func (r *TenantPurger) PurgeTenantDependents(ctx context.Context, tenantID strin
 			}
 			return nil
 		}},
+		{"group_member_location_scopes", func() error {
+			for _, groupID := range groupIDs {
+				if _, derr := fs.GroupMemberLocationScopeRegistry.DeleteByGroup(ctx, tenantID, groupID); derr != nil {
+					return derr
+				}
+			}
+			return nil
+		}},
 		// Audit logs (nullable tenant_id, no FK to tenants); a plain registry.
 		// TenantID is *string, so read it through a nil-safe extractor.
 		{"audit_logs", func() error {
@@ -209,6 +254,9 @@ func (r *TenantPurger) PurgeTenantDependents(ctx context.Context, tenantID strin
 		{"refresh_tokens", func() error {
 			return purgeByTenant(ctx, tenantID, fs.RefreshTokenRegistry.List, fs.RefreshTokenRegistry.Delete, tenantAware[models.RefreshToken])
 		}},
+		{"calendar_feeds", func() error {
+			return purgeByTenant(ctx, tenantID, fs.CalendarFeedRegistry.List, fs.CalendarFeedRegistry.Delete, tenantAware[models.CalendarFeed])
+		}},
 		{"email_verifications", func() error {
 			return purgeByTenant(ctx, tenantID, fs.EmailVerificationRegistry.List, fs.EmailVerificationRegistry.Delete, func(e *models.EmailVerification) string {
 				return e.TenantID
@@ -219,6 +267,31 @@ func (r *TenantPurger) PurgeTenantDependents(ctx context.Context, tenantID strin
 				return p.TenantID
 			})
 		}},
+		// Registration approval queue. Platform-level registry without a
+		// factory; nil-guarded like commodity_scan_budgets.
+		{"registration_requests", func() error {
+			if fs.RegistrationRequestRegistry == nil {
+				return nil
+			}
+			requests, listErr := fs.RegistrationRequestRegistry.List(ctx, registry.RegistrationRequestFilter{TenantID: tenantID})
+			if listErr != nil {
+				return listErr
+			}
+			for _, req := range requests {
+				if derr := fs.RegistrationRequestRegistry.DeleteByUserID(ctx, req.UserID); derr != nil {
+					return derr
+				}
+			}
+			return nil
+		}},
+		// Idempotency-Key replay store. Platform-level registry without a
+		// factory; nil-guarded like registration_requests.
+		{"idempotency_keys", func() error {
+			if fs.IdempotencyKeyRegistry == nil {
+				return nil
+			}
+			return fs.IdempotencyKeyRegistry.DeleteByTenantID(ctx, tenantID)
+		}},
 		{"magic_link_tokens", func() error {
 			return purgeByTenant(ctx, tenantID, fs.MagicLinkTokenRegistry.List, fs.MagicLinkTokenRegistry.Delete, func(m *models.MagicLinkToken) string {
 				return m.TenantID
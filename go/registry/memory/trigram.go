@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"strings"
+	"unicode"
+)
+
+// trigramSimilarity mirrors pg_trgm's similarity() so duplicate detection
+// behaves the same on both backends: the input is lower-cased and split
+// into alphanumeric words, every word is padded with two leading blanks and
+// one trailing blank, and the score is the Jaccard index of the two trigram
+// sets. Returns 0 when either side has no trigrams.
+func trigramSimilarity(a, b string) float64 {
+	ta := trigrams(a)
+	tb := trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+func trigrams(s string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := make(map[string]struct{})
+	for _, w := range words {
+		padded := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			out[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var _ registry.TrustedBackupKeyRegistry = (*TrustedBackupKeyRegistry)(nil)
+
+// TrustedBackupKeyRegistry is the in-memory implementation of the
+// backup-signing keyring. A single mutex serialises every operation, the
+// in-memory stand-in for the postgres unique index on fingerprint.
+// nowFn/uuidFn are injectable so tests get deterministic timestamps and IDs.
+type TrustedBackupKeyRegistry struct {
+	lock sync.Mutex
+	// items is keyed by fingerprint (the natural key).
+	items  map[string]*models.TrustedBackupKey
+	nowFn  func() time.Time
+	uuidFn func() string
+}
+
+// NewTrustedBackupKeyRegistry creates a new in-memory TrustedBackupKeyRegistry.
+func NewTrustedBackupKeyRegistry() *TrustedBackupKeyRegistry {
+	return NewTrustedBackupKeyRegistryForTesting(
+		func() time.Time { return time.Now().UTC() },
+		func() string { return uuid.New().String() },
+	)
+}
+
+// NewTrustedBackupKeyRegistryForTesting builds a registry with injected clock
+// and id generators. Production code must use NewTrustedBackupKeyRegistry.
+func NewTrustedBackupKeyRegistryForTesting(nowFn func() time.Time, uuidFn func() string) *TrustedBackupKeyRegistry {
+	return &TrustedBackupKeyRegistry{
+		items:  make(map[string]*models.TrustedBackupKey),
+		nowFn:  nowFn,
+		uuidFn: uuidFn,
+	}
+}
+
+// List returns every key ordered by (added_at, fingerprint), as defensive
+// copies.
+func (r *TrustedBackupKeyRegistry) List(_ context.Context) ([]*models.TrustedBackupKey, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	out := make([]*models.TrustedBackupKey, 0, len(r.items))
+	for _, k := range r.items {
+		out = append(out, cloneTrustedBackupKey(k))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].AddedAt.Equal(out[j].AddedAt) {
+			return out[i].AddedAt.Before(out[j].AddedAt)
+		}
+		return out[i].Fingerprint < out[j].Fingerprint
+	})
+	return out, nil
+}
+
+// GetByFingerprint returns the key with the given fingerprint.
+func (r *TrustedBackupKeyRegistry) GetByFingerprint(_ context.Context, fingerprint string) (*models.TrustedBackupKey, error) {
+	if fingerprint == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "fingerprint"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	k, ok := r.items[fingerprint]
+	if !ok {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("fingerprint", fingerprint))
+	}
+	return cloneTrustedBackupKey(k), nil
+}
+
+// Add stores a new key, rejecting a fingerprint already in the keyring.
+func (r *TrustedBackupKeyRegistry) Add(_ context.Context, key models.TrustedBackupKey) (*models.TrustedBackupKey, error) {
+	if err := validateTrustedBackupKey(key); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.items[key.Fingerprint]; ok {
+		return nil, errxtrace.Classify(registry.ErrAlreadyExists, errx.Attrs("fingerprint", key.Fingerprint))
+	}
+
+	stored := cloneTrustedBackupKey(&key)
+	stored.ID = r.uuidFn()
+	stored.UUID = r.uuidFn()
+	stored.AddedAt = r.nowFn()
+	stored.RevokedAt = nil
+	stored.RevokedBy = nil
+	stored.RevokeReason = nil
+	r.items[key.Fingerprint] = stored
+	return cloneTrustedBackupKey(stored), nil
+}
+
+// Revoke marks the key revoked; revoking twice keeps the first record.
+func (r *TrustedBackupKeyRegistry) Revoke(_ context.Context, fingerprint, revokedBy, reason string) (*models.TrustedBackupKey, error) {
+	if fingerprint == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "fingerprint"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	k, ok := r.items[fingerprint]
+	if !ok {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("fingerprint", fingerprint))
+	}
+	if k.RevokedAt == nil {
+		now := r.nowFn()
+		k.RevokedAt = &now
+		k.RevokedBy = optionalString(revokedBy)
+		k.RevokeReason = optionalString(reason)
+	}
+	return cloneTrustedBackupKey(k), nil
+}
+
+// validateTrustedBackupKey checks the fields Add requires.
+func validateTrustedBackupKey(key models.TrustedBackupKey) error {
+	switch {
+	case key.Fingerprint == "":
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "fingerprint"))
+	case key.PublicKey == "":
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "public_key"))
+	case key.Label == "":
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "label"))
+	}
+	return nil
+}
+
+// cloneTrustedBackupKey deep-copies a key row, duplicating the nullable
+// pointer fields so a caller can't reach the stored row.
+func cloneTrustedBackupKey(k *models.TrustedBackupKey) *models.TrustedBackupKey {
+	cp := *k
+	for _, p := range []**string{&cp.AddedBy, &cp.RevokedBy, &cp.RevokeReason} {
+		if *p != nil {
+			v := **p
+			*p = &v
+		}
+	}
+	for _, p := range []**time.Time{&cp.ExpiresAt, &cp.RevokedAt} {
+		if *p != nil {
+			v := **p
+			*p = &v
+		}
+	}
+	return &cp
+}
@@ -0,0 +1,106 @@
+package memory_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+)
+
+func newTrustedBackupKeyRegistryForTest() *memory.TrustedBackupKeyRegistry {
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var idSeq int
+	return memory.NewTrustedBackupKeyRegistryForTesting(
+		func() time.Time {
+			cur := clock
+			clock = clock.Add(time.Second)
+			return cur
+		},
+		func() string {
+			idSeq++
+			return "id-" + strconv.Itoa(idSeq)
+		},
+	)
+}
+
+func trustedKey(fingerprint, label string) models.TrustedBackupKey {
+	return models.TrustedBackupKey{Fingerprint: fingerprint, PublicKey: "pk-" + fingerprint, Label: label}
+}
+
+func TestTrustedBackupKeyRegistry_AddListGet(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := newTrustedBackupKeyRegistryForTest()
+
+	staging, err := r.Add(ctx, trustedKey("fp-b", "staging"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(staging.ID, qt.Not(qt.Equals), "")
+	c.Assert(staging.AddedAt.IsZero(), qt.IsFalse)
+	_, err = r.Add(ctx, trustedKey("fp-a", "old prod"))
+	c.Assert(err, qt.IsNil)
+
+	keys, err := r.List(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(keys, qt.HasLen, 2)
+	// Ordered by added_at, not fingerprint.
+	c.Assert(keys[0].Label, qt.Equals, "staging")
+	c.Assert(keys[1].Label, qt.Equals, "old prod")
+
+	got, err := r.GetByFingerprint(ctx, "fp-a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.Label, qt.Equals, "old prod")
+
+	_, err = r.GetByFingerprint(ctx, "fp-missing")
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
+
+func TestTrustedBackupKeyRegistry_Add_Validation(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := newTrustedBackupKeyRegistryForTest()
+
+	_, err := r.Add(ctx, models.TrustedBackupKey{PublicKey: "pk", Label: "x"})
+	c.Assert(err, qt.ErrorIs, registry.ErrFieldRequired)
+	_, err = r.Add(ctx, models.TrustedBackupKey{Fingerprint: "fp", PublicKey: "pk"})
+	c.Assert(err, qt.ErrorIs, registry.ErrFieldRequired)
+
+	_, err = r.Add(ctx, trustedKey("fp", "first"))
+	c.Assert(err, qt.IsNil)
+	_, err = r.Add(ctx, trustedKey("fp", "second"))
+	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
+}
+
+func TestTrustedBackupKeyRegistry_Revoke(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	r := newTrustedBackupKeyRegistryForTest()
+
+	_, err := r.Add(ctx, trustedKey("fp", "staging"))
+	c.Assert(err, qt.IsNil)
+
+	revoked, err := r.Revoke(ctx, "fp", "operator-1", "staging decommissioned")
+	c.Assert(err, qt.IsNil)
+	c.Assert(revoked.RevokedAt, qt.IsNotNil)
+	c.Assert(*revoked.RevokedBy, qt.Equals, "operator-1")
+	c.Assert(*revoked.RevokeReason, qt.Equals, "staging decommissioned")
+	c.Assert(revoked.IsActive(time.Now()), qt.IsFalse)
+
+	// A second revoke keeps the original record.
+	again, err := r.Revoke(ctx, "fp", "cli", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(again.RevokedAt.Equal(*revoked.RevokedAt), qt.IsTrue)
+	c.Assert(*again.RevokedBy, qt.Equals, "operator-1")
+
+	// A revoked key cannot be re-added.
+	_, err = r.Add(ctx, trustedKey("fp", "staging again"))
+	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
+
+	_, err = r.Revoke(ctx, "fp-missing", "cli", "")
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
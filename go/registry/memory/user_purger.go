@@ -14,7 +14,8 @@ var _ registry.UserPurger = (*UserPurger)(nil)
 // hard-deletes a single user's auth / identity rows via the existing
 // service-mode registries, mirroring the postgres DELETE-by-user_id sequence.
 // Since #2147 it also clears the user's group_invites_audit references
-// (created_by / used_by), matching postgres.
+// (created_by / used_by), matching postgres, and the saved views the user
+// created.
 //
 // Unlike the postgres variant these deletes are NOT one transaction — memory
 // mode is only used in tests where partial failure is acceptable.
@@ -38,6 +39,7 @@ var _ registry.UserPurger = (*UserPurger)(nil)
 // final user delete after this returns.
 type UserPurger struct {
 	refreshTokens registry.RefreshTokenRegistry
+	calendarFeeds registry.CalendarFeedRegistry
 	mfaSecrets    registry.UserMFASecretRegistry
 	oauth         registry.OAuthIdentityRegistry
 	passwordReset registry.PasswordResetRegistry
@@ -46,12 +48,17 @@ type UserPurger struct {
 	memberships   registry.GroupMembershipRegistry
 	adminGrants   registry.SystemAdminGrantRegistry
 	inviteAudit   registry.GroupInviteAuditRegistry
+	savedViews    registry.SavedViewRegistryFactory
+	registrations registry.RegistrationRequestRegistry
+	scopes        registry.GroupMemberLocationScopeRegistry
+	idempotency   registry.IdempotencyKeyRegistry
 }
 
 // NewUserPurger wires a UserPurger to the registries that own the shared
 // in-memory data maps. All parameters are required.
 func NewUserPurger(
 	refreshTokens registry.RefreshTokenRegistry,
+	calendarFeeds registry.CalendarFeedRegistry,
 	mfaSecrets registry.UserMFASecretRegistry,
 	oauth registry.OAuthIdentityRegistry,
 	passwordReset registry.PasswordResetRegistry,
@@ -60,9 +67,14 @@ func NewUserPurger(
 	memberships registry.GroupMembershipRegistry,
 	adminGrants registry.SystemAdminGrantRegistry,
 	inviteAudit registry.GroupInviteAuditRegistry,
+	savedViews registry.SavedViewRegistryFactory,
+	registrations registry.RegistrationRequestRegistry,
+	scopes registry.GroupMemberLocationScopeRegistry,
+	idempotency registry.IdempotencyKeyRegistry,
 ) *UserPurger {
 	return &UserPurger{
 		refreshTokens: refreshTokens,
+		calendarFeeds: calendarFeeds,
 		mfaSecrets:    mfaSecrets,
 		oauth:         oauth,
 		passwordReset: passwordReset,
@@ -71,6 +83,10 @@ func NewUserPurger(
 		memberships:   memberships,
 		adminGrants:   adminGrants,
 		inviteAudit:   inviteAudit,
+		savedViews:    savedViews,
+		registrations: registrations,
+		scopes:        scopes,
+		idempotency:   idempotency,
 	}
 }
 
@@ -94,15 +110,20 @@ func (r *UserPurger) PurgeUserDependents(ctx context.Context, tenantID, userID s
 	}
 	steps := []step{
 		{"refresh_tokens", func() error { return r.purgeRefreshTokens(ctx, userID) }},
+		{"calendar_feeds", func() error { return r.purgeCalendarFeeds(ctx, userID) }},
 		{"email_verifications", func() error { return r.purgeEmailVerifications(ctx, userID) }},
 		{"password_resets", func() error { return r.passwordReset.DeleteByUserID(ctx, userID) }},
 		{"magic_link_tokens", func() error { return r.magicLink.DeleteByUserID(ctx, userID) }},
 		{"user_mfa_secrets", func() error { return r.mfaSecrets.DeleteByUser(ctx, tenantID, userID) }},
 		{"user_oauth_identities", func() error { return r.purgeOAuthIdentities(ctx, tenantID, userID) }},
+		{"group_member_location_scopes", func() error { return r.scopes.DeleteByUser(ctx, userID) }},
 		{"group_memberships", func() error { return r.purgeMemberships(ctx, tenantID, userID) }},
 		// group_invites_audit rows the user created or accepted (both NOT NULL FK
 		// -> users(id) NO ACTION on postgres) — #2147. Mirrors the postgres DELETE.
 		{"group_invites_audit", func() error { return r.purgeGroupInvitesAudit(ctx, tenantID, userID) }},
+		{"saved_views", func() error { return r.purgeSavedViews(ctx, tenantID, userID) }},
+		{"registration_requests", func() error { return r.registrations.DeleteByUserID(ctx, userID) }},
+		{"idempotency_keys", func() error { return r.idempotency.DeleteByUserID(ctx, userID) }},
 		// System-admin grant the user HOLDS. RevokeAtomic(allowZero=true)
 		// removes it idempotently. The granted_by back-ref is nulled only on
 		// the postgres side; the memory grant registry has no granted_by index
@@ -140,6 +161,20 @@ func (r *UserPurger) purgeRefreshTokens(ctx context.Context, userID string) erro
 	return nil
 }
 
+// purgeCalendarFeeds hard-deletes every calendar feed the user owns.
+func (r *UserPurger) purgeCalendarFeeds(ctx context.Context, userID string) error {
+	feeds, err := r.calendarFeeds.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, f := range feeds {
+		if err := r.calendarFeeds.Delete(ctx, f.GetID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // purgeEmailVerifications lists the user's email-verification rows and deletes
 // each by id (no dedicated DeleteByUserID on the memory registry).
 func (r *UserPurger) purgeEmailVerifications(ctx context.Context, userID string) error {
@@ -199,6 +234,25 @@ func (r *UserPurger) purgeGroupInvitesAudit(ctx context.Context, tenantID, userI
 	return nil
 }
 
+// purgeSavedViews deletes every saved view the user created in the tenant,
+// private and shared alike, mirroring the postgres DELETE.
+func (r *UserPurger) purgeSavedViews(ctx context.Context, tenantID, userID string) error {
+	reg := r.savedViews.CreateServiceRegistry()
+	views, err := reg.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range views {
+		if v == nil || v.TenantID != tenantID || v.CreatedByUserID != userID {
+			continue
+		}
+		if err := reg.Delete(ctx, v.GetID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // purgeMemberships deletes every membership where the user is the MEMBER.
 // ListByUser filters by member_user_id; Delete(id) bypasses the last-owner
 // invariant on purpose — a hard user purge isn't subject to the interactive
@@ -200,7 +200,7 @@ func (r *AreaRegistry) Create(ctx context.Context, area models.Area) (*models.Ar
 func (r *AreaRegistry) Update(ctx context.Context, area models.Area) (*models.Area, error) {
 	reg := r.newSQLRegistry()
 
-	err := reg.Update(ctx, area, func(ctx context.Context, tx *sqlx.Tx, dbArea models.Area) error {
+	err := reg.UpdateInPlace(ctx, &area, func(ctx context.Context, tx *sqlx.Tx, dbArea models.Area) error {
 		_, err := r.getLocation(ctx, tx, area.LocationID)
 		return err
 	})
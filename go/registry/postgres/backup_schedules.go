@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/postgres/store"
+)
+
+// BackupScheduleRegistryFactory creates BackupScheduleRegistry instances
+// with proper context.
+type BackupScheduleRegistryFactory struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// BackupScheduleRegistry is the postgres-backed group-scoped registry of
+// backup schedules. The one-schedule-per-group rule is backed by the
+// unique idx_backup_schedules_group index.
+type BackupScheduleRegistry struct {
+	dbx             *sqlx.DB
+	tableNames      store.TableNames
+	tenantID        string
+	groupID         string
+	createdByUserID string
+	service         bool
+}
+
+var (
+	_ registry.BackupScheduleRegistry        = (*BackupScheduleRegistry)(nil)
+	_ registry.BackupScheduleRegistryFactory = (*BackupScheduleRegistryFactory)(nil)
+)
+
+func NewBackupScheduleRegistry(dbx *sqlx.DB) *BackupScheduleRegistryFactory {
+	return NewBackupScheduleRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewBackupScheduleRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *BackupScheduleRegistryFactory {
+	return &BackupScheduleRegistryFactory{dbx: dbx, tableNames: tableNames}
+}
+
+func (f *BackupScheduleRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.BackupScheduleRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *BackupScheduleRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.BackupScheduleRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+	return &BackupScheduleRegistry{
+		dbx:             f.dbx,
+		tableNames:      f.tableNames,
+		tenantID:        user.TenantID,
+		groupID:         appctx.GroupIDFromContext(ctx),
+		createdByUserID: user.ID,
+		service:         false,
+	}, nil
+}
+
+func (f *BackupScheduleRegistryFactory) CreateServiceRegistry() registry.BackupScheduleRegistry {
+	return &BackupScheduleRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		service:    true,
+	}
+}
+
+func (r *BackupScheduleRegistry) newSQLRegistry() *store.RLSGroupRepository[models.BackupSchedule, *models.BackupSchedule] {
+	if r.service {
+		return store.NewGroupServiceSQLRegistry[models.BackupSchedule](r.dbx, r.tableNames.BackupSchedules())
+	}
+	return store.NewGroupAwareSQLRegistry[models.BackupSchedule](r.dbx, r.tenantID, r.groupID, r.createdByUserID, r.tableNames.BackupSchedules())
+}
+
+func (r *BackupScheduleRegistry) Get(ctx context.Context, id string) (*models.BackupSchedule, error) {
+	var schedule models.BackupSchedule
+	if err := r.newSQLRegistry().ScanOneByField(ctx, store.Pair("id", id), &schedule); err != nil {
+		return nil, errxtrace.Wrap("failed to get backup schedule", err)
+	}
+	return &schedule, nil
+}
+
+func (r *BackupScheduleRegistry) GetForGroup(ctx context.Context) (*models.BackupSchedule, error) {
+	var schedule models.BackupSchedule
+	if err := r.newSQLRegistry().ScanOneByField(ctx, store.Pair("group_id", r.groupID), &schedule); err != nil {
+		return nil, errxtrace.Wrap("failed to get group backup schedule", err)
+	}
+	return &schedule, nil
+}
+
+func (r *BackupScheduleRegistry) List(ctx context.Context) ([]*models.BackupSchedule, error) {
+	var schedules []*models.BackupSchedule
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`SELECT * FROM %s ORDER BY next_run_at, id`, r.tableNames.BackupSchedules())
+		return tx.SelectContext(ctx, &schedules, query)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list backup schedules", err)
+	}
+	return schedules, nil
+}
+
+func (r *BackupScheduleRegistry) ListPending(ctx context.Context, now time.Time) ([]*models.BackupSchedule, error) {
+	var schedules []*models.BackupSchedule
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`
+			SELECT * FROM %s
+			WHERE (enabled AND next_run_at <= $1)
+			   OR (COALESCE(last_export_id, '') <> '' AND COALESCE(last_export_id, '') <> COALESCE(settled_export_id, ''))
+			ORDER BY next_run_at, id`, r.tableNames.BackupSchedules())
+		return tx.SelectContext(ctx, &schedules, query, now)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list pending backup schedules", err)
+	}
+	return schedules, nil
+}
+
+func (r *BackupScheduleRegistry) Count(ctx context.Context) (int, error) {
+	cnt, err := r.newSQLRegistry().Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count backup schedules", err)
+	}
+	return cnt, nil
+}
+
+func (r *BackupScheduleRegistry) Create(ctx context.Context, schedule models.BackupSchedule) (*models.BackupSchedule, error) {
+	if _, err := r.GetForGroup(ctx); err == nil {
+		return nil, errxtrace.Classify(registry.ErrAlreadyExists, errx.Attrs("group_id", r.groupID))
+	} else if !errors.Is(err, registry.ErrNotFound) {
+		return nil, err
+	}
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+	created, err := r.newSQLRegistry().Create(ctx, schedule, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create backup schedule", err)
+	}
+	return &created, nil
+}
+
+func (r *BackupScheduleRegistry) Update(ctx context.Context, schedule models.BackupSchedule) (*models.BackupSchedule, error) {
+	existing, err := r.Get(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Ownership and creation time are immutable.
+	schedule.TenantGroupAwareEntityID = existing.TenantGroupAwareEntityID
+	schedule.CreatedAt = existing.CreatedAt
+	schedule.UpdatedAt = time.Now()
+	if err := r.newSQLRegistry().Update(ctx, schedule, nil); err != nil {
+		return nil, errxtrace.Wrap("failed to update backup schedule", err)
+	}
+	return &schedule, nil
+}
+
+func (r *BackupScheduleRegistry) Delete(ctx context.Context, id string) error {
+	return r.newSQLRegistry().Delete(ctx, id, nil)
+}
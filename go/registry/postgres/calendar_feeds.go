@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/postgres/store"
+)
+
+var _ registry.CalendarFeedRegistry = (*CalendarFeedRegistry)(nil)
+
+type CalendarFeedRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+func NewCalendarFeedRegistry(dbx *sqlx.DB) *CalendarFeedRegistry {
+	return NewCalendarFeedRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewCalendarFeedRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *CalendarFeedRegistry {
+	return &CalendarFeedRegistry{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+// newSQLRegistry returns an RLSRepository in service mode for the
+// calendar_feeds table. Feed fetches are unauthenticated — the URL token
+// is the credential — so no user/tenant context exists in the database
+// session when the token is resolved; the calendar_feed_background_worker_access
+// policy covers the service role.
+func (r *CalendarFeedRegistry) newSQLRegistry() *store.RLSRepository[models.CalendarFeed, *models.CalendarFeed] {
+	return store.NewServiceSQLRegistry[models.CalendarFeed, *models.CalendarFeed](r.dbx, r.tableNames.CalendarFeeds())
+}
+
+func (r *CalendarFeedRegistry) Create(ctx context.Context, feed models.CalendarFeed) (*models.CalendarFeed, error) {
+	if feed.TokenHash == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TokenHash"))
+	}
+	if feed.UserID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+	if feed.TenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+
+	feed.CreatedAt = time.Now()
+	feed.ID = uuid.New().String()
+	if feed.UUID == "" {
+		feed.UUID = uuid.New().String()
+	}
+
+	reg := r.newSQLRegistry()
+	if err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		txReg := store.NewTxRegistry[models.CalendarFeed](tx, r.tableNames.CalendarFeeds())
+		return txReg.Insert(ctx, feed)
+	}); err != nil {
+		return nil, errxtrace.Wrap("failed to insert calendar feed", err)
+	}
+
+	return &feed, nil
+}
+
+func (r *CalendarFeedRegistry) Get(ctx context.Context, id string) (*models.CalendarFeed, error) {
+	if id == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "ID"))
+	}
+
+	var feed models.CalendarFeed
+	reg := r.newSQLRegistry()
+	err := reg.ScanOneByField(ctx, store.Pair("id", id), &feed)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "CalendarFeed", "entity_id", id))
+		}
+		return nil, errxtrace.Wrap("failed to get calendar feed", err)
+	}
+
+	return &feed, nil
+}
+
+func (r *CalendarFeedRegistry) List(ctx context.Context) ([]*models.CalendarFeed, error) {
+	var feeds []*models.CalendarFeed
+	reg := r.newSQLRegistry()
+
+	for feed, err := range reg.Scan(ctx) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list calendar feeds", err)
+		}
+		feeds = append(feeds, &feed)
+	}
+
+	return feeds, nil
+}
+
+func (r *CalendarFeedRegistry) Update(ctx context.Context, feed models.CalendarFeed) (*models.CalendarFeed, error) {
+	if feed.GetID() == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "ID"))
+	}
+
+	reg := r.newSQLRegistry()
+	err := reg.Update(ctx, feed, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update calendar feed", err)
+	}
+
+	return &feed, nil
+}
+
+func (r *CalendarFeedRegistry) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "ID"))
+	}
+
+	reg := r.newSQLRegistry()
+	err := reg.Delete(ctx, id, nil)
+	if err != nil {
+		return errxtrace.Wrap("failed to delete calendar feed", err)
+	}
+
+	return nil
+}
+
+func (r *CalendarFeedRegistry) Count(ctx context.Context) (int, error) {
+	reg := r.newSQLRegistry()
+	count, err := reg.Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count calendar feeds", err)
+	}
+	return count, nil
+}
+
+// GetByTokenHash returns the feed whose token hashes to tokenHash.
+func (r *CalendarFeedRegistry) GetByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	if tokenHash == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TokenHash"))
+	}
+
+	var feed models.CalendarFeed
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`SELECT * FROM %s WHERE token_hash = $1`, r.tableNames.CalendarFeeds())
+		err := tx.GetContext(ctx, &feed, query, tokenHash)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "CalendarFeed"))
+			}
+			return errxtrace.Wrap("failed to get calendar feed by hash", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+// ListByUserID returns the user's feeds, oldest first.
+func (r *CalendarFeedRegistry) ListByUserID(ctx context.Context, userID string) ([]*models.CalendarFeed, error) {
+	if userID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+
+	var feeds []*models.CalendarFeed
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`SELECT * FROM %s WHERE user_id = $1 ORDER BY created_at, id`, r.tableNames.CalendarFeeds())
+		if err := tx.SelectContext(ctx, &feeds, query, userID); err != nil {
+			return errxtrace.Wrap("failed to list calendar feeds by user", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return feeds, nil
+}
@@ -406,9 +406,16 @@ func buildWarrantyStatusCond(opts registry.CommodityListOptions, startIdx int) (
 }
 
 func buildCommodityWhere(opts registry.CommodityListOptions, commoditiesTable, loansTable string) (string, []any) {
+	return buildCommodityWhereAt(opts, commoditiesTable, loansTable, 1)
+}
+
+// buildCommodityWhereAt is buildCommodityWhere with placeholders starting
+// at startIdx, for callers that embed the filter in a query which already
+// binds its own leading parameters (AggregateStats).
+func buildCommodityWhereAt(opts registry.CommodityListOptions, commoditiesTable, loansTable string, startIdx int) (string, []any) {
 	var conds []string
 	var args []any
-	idx := 1
+	idx := startIdx
 
 	// Default view: hide drafts unless caller asked to see them.
 	if !opts.IncludeInactive {
@@ -459,6 +466,13 @@ func buildCommodityWhere(opts registry.CommodityListOptions, commoditiesTable, l
 		args = append(args, "%"+strings.ToLower(q)+"%")
 		idx++
 	}
+	if len(opts.Tags) > 0 {
+		// `?|` is "any of these keys/elements", so the GIN index on tags
+		// drives the lookup — same operator the tag usage batch uses.
+		conds = append(conds, fmt.Sprintf("tags ?| $%d::text[]", idx))
+		args = append(args, opts.Tags)
+		idx++
+	}
 
 	if cond, wargs, nextIdx := buildWarrantyStatusCond(opts, idx); cond != "" {
 		conds = append(conds, cond)
@@ -576,7 +590,7 @@ func (r *CommodityRegistry) Update(ctx context.Context, commodity models.Commodi
 
 	reg := r.newSQLRegistry()
 
-	err := reg.Update(ctx, commodity, func(ctx context.Context, tx *sqlx.Tx, dbCommodity models.Commodity) error {
+	err := reg.UpdateInPlace(ctx, &commodity, func(ctx context.Context, tx *sqlx.Tx, dbCommodity models.Commodity) error {
 		// Area is optional (issue #1986): only verify it exists when set.
 		if commodity.AreaID != nil && *commodity.AreaID != "" {
 			if _, err := r.getArea(ctx, tx, *commodity.AreaID); err != nil {
@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// commodityDuplicateRow is a commodity plus the pg_trgm scores computed
+// against the FindSimilar reference row.
+type commodityDuplicateRow struct {
+	models.Commodity
+	NameScore      float64 `db:"dup_name_score"`
+	ShortNameScore float64 `db:"dup_short_name_score"`
+}
+
+// commodityDuplicatePairRow is one FindDuplicates candidate pair. The
+// commodities themselves are fetched in a second round-trip so the pair
+// query doesn't have to alias every column twice.
+type commodityDuplicatePairRow struct {
+	AID            string  `db:"a_id"`
+	BID            string  `db:"b_id"`
+	NameScore      float64 `db:"dup_name_score"`
+	ShortNameScore float64 `db:"dup_short_name_score"`
+}
+
+// FindSimilar runs the candidate search in SQL: the `%` operator is
+// served by the commodities_name_trgm_idx / commodities_short_name_trgm_idx
+// GIN indexes with pg_trgm.similarity_threshold set for the transaction
+// only, and the serial / part-number / purchase checks are OR-ed in. The
+// exact reasons are then derived by registry.DuplicateReasons so postgres
+// and memory agree on every rule except the trigram scoring itself.
+func (r *CommodityRegistry) FindSimilar(ctx context.Context, commodityID string, threshold float64) ([]registry.SimilarCommodity, error) {
+	ref, err := r.get(ctx, commodityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []commodityDuplicateRow
+	reg := r.newSQLRegistry()
+	err = reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := setTrigramThreshold(ctx, tx, threshold); err != nil {
+			return err
+		}
+		query := fmt.Sprintf(`
+			SELECT c.*,
+				similarity(c.name, ref.name) AS dup_name_score,
+				CASE WHEN COALESCE(c.short_name, '') <> '' AND COALESCE(ref.short_name, '') <> ''
+					THEN similarity(c.short_name, ref.short_name) ELSE 0 END AS dup_short_name_score
+			FROM %[1]s c
+			JOIN %[1]s ref ON ref.id = $1
+			WHERE c.id <> ref.id
+				AND c.tenant_id = ref.tenant_id
+				AND c.group_id = ref.group_id
+				AND %[2]s`,
+			r.tableNames.Commodities(),
+			duplicateCandidateCond("c", "ref"),
+		)
+		return tx.SelectContext(ctx, &rows, query, commodityID)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to find similar commodities", err)
+	}
+
+	similar := make([]registry.SimilarCommodity, 0, len(rows))
+	for i := range rows {
+		commodity := rows[i].Commodity
+		reasons := registry.DuplicateReasons(ref, &commodity, rows[i].NameScore, rows[i].ShortNameScore, threshold)
+		if len(reasons) == 0 {
+			continue
+		}
+		similar = append(similar, registry.SimilarCommodity{
+			Commodity: &commodity,
+			Score:     max(rows[i].NameScore, rows[i].ShortNameScore),
+			Reasons:   reasons,
+		})
+	}
+
+	registry.SortSimilarCommodities(similar)
+	return similar, nil
+}
+
+// FindDuplicates self-joins the commodities table on the same candidate
+// predicate as FindSimilar. `a.id < b.id` reports every unordered pair
+// once; the tenant / group equality keeps service-mode callers from
+// pairing rows across groups (user-mode callers are already confined by
+// RLS).
+//
+// With a positive limit the pair query is ordered the way
+// registry.SortDuplicatePairs orders the final result (signal count, then
+// score, then ids) and cut off in SQL, so a large group is never loaded
+// whole. It over-fetches by duplicatePairsOverfetch because the Go side
+// may still drop a candidate the SQL prefilter let through.
+func (r *CommodityRegistry) FindDuplicates(ctx context.Context, threshold float64, limit int) ([]registry.DuplicatePair, error) {
+	var pairRows []commodityDuplicatePairRow
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := setTrigramThreshold(ctx, tx, threshold); err != nil {
+			return err
+		}
+		query := fmt.Sprintf(`
+			SELECT a.id AS a_id, b.id AS b_id,
+				similarity(a.name, b.name) AS dup_name_score,
+				CASE WHEN COALESCE(a.short_name, '') <> '' AND COALESCE(b.short_name, '') <> ''
+					THEN similarity(a.short_name, b.short_name) ELSE 0 END AS dup_short_name_score
+			FROM %[1]s a
+			JOIN %[1]s b ON a.id < b.id
+				AND a.tenant_id = b.tenant_id
+				AND a.group_id = b.group_id
+			WHERE %[2]s`,
+			r.tableNames.Commodities(),
+			duplicateCandidateCond("a", "b"),
+		)
+		if limit <= 0 {
+			return tx.SelectContext(ctx, &pairRows, query)
+		}
+		query += fmt.Sprintf(`
+			ORDER BY %s DESC,
+				GREATEST(similarity(a.name, b.name),
+					CASE WHEN COALESCE(a.short_name, '') <> '' AND COALESCE(b.short_name, '') <> ''
+						THEN similarity(a.short_name, b.short_name) ELSE 0 END) DESC,
+				a.id, b.id
+			LIMIT $1`,
+			duplicateSignalCountExpr("a", "b"),
+		)
+		return tx.SelectContext(ctx, &pairRows, query, limit*duplicatePairsOverfetch)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to find duplicate commodities", err)
+	}
+	if len(pairRows) == 0 {
+		return []registry.DuplicatePair{}, nil
+	}
+
+	ids := make([]string, 0, len(pairRows)*2)
+	for _, p := range pairRows {
+		ids = append(ids, p.AID, p.BID)
+	}
+	commodities, err := r.GetMany(ctx, ids)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to fetch duplicate commodities", err)
+	}
+	byID := make(map[string]*models.Commodity, len(commodities))
+	for _, c := range commodities {
+		byID[c.ID] = c
+	}
+
+	pairs := make([]registry.DuplicatePair, 0, len(pairRows))
+	for _, p := range pairRows {
+		a, b := byID[p.AID], byID[p.BID]
+		if a == nil || b == nil {
+			continue
+		}
+		reasons := registry.DuplicateReasons(a, b, p.NameScore, p.ShortNameScore, threshold)
+		if len(reasons) == 0 {
+			continue
+		}
+		pairs = append(pairs, registry.DuplicatePair{
+			A:       a,
+			B:       b,
+			Score:   max(p.NameScore, p.ShortNameScore),
+			Reasons: reasons,
+		})
+	}
+
+	registry.SortDuplicatePairs(pairs)
+	if limit > 0 && len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs, nil
+}
+
+// setTrigramThreshold scopes pg_trgm.similarity_threshold (the cut-off the
+// `%` operator uses) to the current transaction.
+func setTrigramThreshold(ctx context.Context, tx *sqlx.Tx, threshold float64) error {
+	_, err := tx.ExecContext(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`,
+		strconv.FormatFloat(threshold, 'f', -1, 64))
+	if err != nil {
+		return errxtrace.Wrap("failed to set trigram similarity threshold", err)
+	}
+	return nil
+}
+
+// duplicatePairsOverfetch multiplies the requested FindDuplicates limit
+// for the SQL cut-off, leaving room for candidates the Go-side
+// registry.DuplicateReasons check rejects.
+const duplicatePairsOverfetch = 2
+
+// duplicateCandidateCond is the SQL prefilter shared by FindSimilar and
+// FindDuplicates. It is intentionally a superset of what
+// registry.DuplicateReasons accepts; the Go side has the final word.
+func duplicateCandidateCond(a, b string) string {
+	return "(" + strings.Join(duplicateSignalExprs(a, b), "\n\t\tOR ") + ")"
+}
+
+// duplicateSignalCountExpr counts how many duplicate signals a pair
+// carries — the SQL mirror of len(registry.DuplicateReasons(...)).
+func duplicateSignalCountExpr(a, b string) string {
+	signals := duplicateSignalExprs(a, b)
+	for i, s := range signals {
+		signals[i] = "(" + s + ")::int"
+	}
+	return "(" + strings.Join(signals, " + ") + ")"
+}
+
+// duplicateSignalExprs returns one boolean SQL expression per
+// registry.DuplicateReason, in the same order.
+func duplicateSignalExprs(a, b string) []string {
+	return []string{
+		fmt.Sprintf(`%[1]s.name %% %[2]s.name`, a, b),
+		fmt.Sprintf(`(COALESCE(%[1]s.short_name, '') <> '' AND COALESCE(%[2]s.short_name, '') <> '' AND %[1]s.short_name %% %[2]s.short_name)`, a, b),
+		fmt.Sprintf(`%s && %s`, serialNumbersExpr(a), serialNumbersExpr(b)),
+		fmt.Sprintf(`%s && %s`, normalizedJSONBTextArrayExpr(a+".part_numbers"), normalizedJSONBTextArrayExpr(b+".part_numbers")),
+		fmt.Sprintf(`(COALESCE(%[1]s.purchase_date, '') <> ''
+			AND %[1]s.purchase_date = %[2]s.purchase_date
+			AND COALESCE(%[1]s.original_price, 0) <> 0
+			AND %[1]s.original_price = %[2]s.original_price
+			AND %[1]s.original_price_currency IS NOT DISTINCT FROM %[2]s.original_price_currency)`, a, b),
+	}
+}
+
+// serialNumbersExpr folds serial_number and extra_serial_numbers into one
+// lower-cased, trimmed TEXT[] so `&&` can test for any shared serial.
+func serialNumbersExpr(alias string) string {
+	return fmt.Sprintf(`array_remove(array_prepend(lower(btrim(COALESCE(%[1]s.serial_number, ''))), %[2]s), '')`,
+		alias, normalizedJSONBTextArrayExpr(alias+".extra_serial_numbers"))
+}
+
+// normalizedJSONBTextArrayExpr turns a JSONB string array column into a
+// lower-cased, trimmed TEXT[] without empty entries. Non-array values
+// (SQL NULL, JSON null) yield an empty array instead of an error.
+func normalizedJSONBTextArrayExpr(column string) string {
+	return fmt.Sprintf(`array_remove(ARRAY(
+		SELECT lower(btrim(e)) FROM jsonb_array_elements_text(
+			CASE WHEN jsonb_typeof(%[1]s) = 'array' THEN %[1]s ELSE '[]'::jsonb END
+		) AS e
+	), '')`, column)
+}
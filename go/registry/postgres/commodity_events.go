@@ -215,3 +215,23 @@ func (r *CommodityEventRegistry) ListByCommodity(ctx context.Context, commodityI
 
 	return events, total, nil
 }
+
+// ReassignCommodity re-points every event of fromCommodityID at
+// toCommodityID in one UPDATE. RLS keeps the statement inside the
+// caller's group, so a user-mode registry cannot move rows across groups.
+func (r *CommodityEventRegistry) ReassignCommodity(ctx context.Context, fromCommodityID, toCommodityID string) (int, error) {
+	var moved int64
+	err := r.newSQLRegistry().Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`UPDATE %s SET commodity_id = $2 WHERE commodity_id = $1`, r.tableNames.CommodityEvents())
+		res, err := tx.ExecContext(ctx, query, fromCommodityID, toCommodityID)
+		if err != nil {
+			return errxtrace.Wrap("failed to reassign commodity events", err)
+		}
+		moved, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to reassign commodity events", err)
+	}
+	return int(moved), nil
+}
@@ -282,6 +282,13 @@ func (r *CommodityLoanRegistry) ListPendingReminders(ctx context.Context, kind r
 		limit := now.UTC().AddDate(0, 0, dueSoonDays).Format("2006-01-02")
 		whereClause = "returned_at IS NULL AND due_back_at IS NOT NULL AND due_back_at >= $1 AND due_back_at <= $2 AND reminder_sent_due_soon = false"
 		args = []any{today, limit}
+	case registry.LoanReminderKindBorrowerOverdue:
+		whereClause = "returned_at IS NULL AND due_back_at IS NOT NULL AND due_back_at < $1 AND borrower_reminder_sent_overdue = false AND COALESCE(borrower_email, '') <> ''"
+		args = []any{today}
+	case registry.LoanReminderKindBorrowerDueSoon:
+		limit := now.UTC().AddDate(0, 0, dueSoonDays).Format("2006-01-02")
+		whereClause = "returned_at IS NULL AND due_back_at IS NOT NULL AND due_back_at >= $1 AND due_back_at <= $2 AND borrower_reminder_sent_due_soon = false AND COALESCE(borrower_email, '') <> ''"
+		args = []any{today, limit}
 	}
 	var loans []*models.CommodityLoan
 	reg := r.newSQLRegistry()
@@ -330,6 +337,10 @@ func (r *CommodityLoanRegistry) MarkReminderSent(ctx context.Context, loanID str
 		column = "reminder_sent_overdue"
 	case registry.LoanReminderKindDueSoon:
 		column = "reminder_sent_due_soon"
+	case registry.LoanReminderKindBorrowerOverdue:
+		column = "borrower_reminder_sent_overdue"
+	case registry.LoanReminderKindBorrowerDueSoon:
+		column = "borrower_reminder_sent_due_soon"
 	}
 	var flipped bool
 	reg := r.newSQLRegistry()
@@ -354,6 +365,40 @@ func (r *CommodityLoanRegistry) MarkReminderSent(ctx context.Context, loanID str
 	return flipped, nil
 }
 
+// RecordPendingRequest writes the pending_request_* columns in a single
+// UPDATE guarded by returned_at IS NULL, so a request can never land on
+// a loan that was closed in the meantime.
+func (r *CommodityLoanRegistry) RecordPendingRequest(ctx context.Context, loanID string, kind models.LoanRequestKind, dueBackAt models.PDate, note string, at time.Time) (bool, error) {
+	if !kind.IsValid() || kind == models.LoanRequestKindNone {
+		return false, registry.ErrInvalidInput
+	}
+	if !r.service {
+		return false, errxtrace.Wrap("RecordPendingRequest requires service-mode registry", registry.ErrInvalidInput)
+	}
+	var recorded bool
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`UPDATE %s SET pending_request_kind = $2, pending_request_due_back_at = $3, pending_request_note = $4, pending_request_at = $5, updated_at = NOW()
+			 WHERE id = $1 AND returned_at IS NULL`,
+			r.tableNames.CommodityLoans())
+		res, err := tx.ExecContext(ctx, query, loanID, kind, dueBackAt, note, at.UTC())
+		if err != nil {
+			return errxtrace.Wrap("failed to record pending loan request", err)
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return errxtrace.Wrap("failed to read rows affected", err)
+		}
+		recorded = rows > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return recorded, nil
+}
+
 func (r *CommodityLoanRegistry) CountOpenByCommodity(ctx context.Context, commodityIDs []string) (map[string]int, error) {
 	out := make(map[string]int, len(commodityIDs))
 	for _, id := range commodityIDs {
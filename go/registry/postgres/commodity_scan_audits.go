@@ -8,6 +8,7 @@ import (
 	"github.com/go-extras/errx"
 	errxtrace "github.com/go-extras/errx/stacktrace"
 	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -101,3 +102,42 @@ func (r *CommodityScanAuditRegistry) DeleteOlderThan(ctx context.Context, cutoff
 		return err
 	})
 }
+
+// SumCostSince sums cost_usd over the tenant's rows created at or after
+// since, narrowed to groupID when non-empty. The (tenant_id, group_id,
+// created_at) index serves both shapes.
+func (r *CommodityScanAuditRegistry) SumCostSince(ctx context.Context, tenantID, groupID string, since time.Time) (decimal.Decimal, error) {
+	if tenantID == "" {
+		return decimal.Zero, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+	query := fmt.Sprintf(
+		`SELECT COALESCE(SUM(cost_usd), 0) FROM %s WHERE tenant_id = $1 AND ($2 = '' OR group_id = $2) AND created_at >= $3`,
+		r.tableNames.CommodityScanAudits(),
+	)
+	var total decimal.Decimal
+	if err := r.dbx.GetContext(ctx, &total, query, tenantID, groupID, since); err != nil {
+		return decimal.Zero, errxtrace.Wrap("sum commodity scan audit cost", err)
+	}
+	return total, nil
+}
+
+// Usage aggregates the provider-call rows created in [since, until) per
+// (tenant, group, provider, model).
+func (r *CommodityScanAuditRegistry) Usage(ctx context.Context, since, until time.Time) ([]models.CommodityScanUsage, error) {
+	query := fmt.Sprintf(
+		`SELECT tenant_id, group_id, provider, model,
+		        COUNT(*) AS scans,
+		        COALESCE(SUM(tokens_used), 0) AS tokens_used,
+		        COALESCE(SUM(cost_usd), 0) AS cost_usd
+		   FROM %s
+		  WHERE created_at >= $1 AND created_at < $2 AND status IN ('ok', 'error', 'timeout')
+		  GROUP BY tenant_id, group_id, provider, model
+		  ORDER BY tenant_id, group_id, provider, model`,
+		r.tableNames.CommodityScanAudits(),
+	)
+	var rows []models.CommodityScanUsage
+	if err := r.dbx.SelectContext(ctx, &rows, query, since, until); err != nil {
+		return nil, errxtrace.Wrap("aggregate commodity scan usage", err)
+	}
+	return rows, nil
+}
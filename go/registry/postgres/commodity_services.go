@@ -287,3 +287,95 @@ func (r *CommodityServiceRegistry) CountOpenByCommodity(ctx context.Context, com
 	}
 	return out, nil
 }
+
+// ListPendingReminders mirrors the partial index `idx_commodity_services_due`
+// (open + expected_return_at NOT NULL) for the service reminder sweep
+// across every group. Same date semantics as the loan registry: `now` is
+// truncated to a UTC date before comparing.
+func (r *CommodityServiceRegistry) ListPendingReminders(ctx context.Context, kind registry.ServiceReminderKind, now time.Time, dueSoonDays int) ([]*models.CommodityService, error) {
+	if !kind.IsValid() {
+		return nil, registry.ErrInvalidInput
+	}
+	if !r.service {
+		return nil, errxtrace.Wrap("ListPendingReminders requires service-mode registry", registry.ErrInvalidInput)
+	}
+	today := now.UTC().Format("2006-01-02")
+	var (
+		whereClause string
+		args        []any
+	)
+	switch kind {
+	case registry.ServiceReminderKindOverdue:
+		whereClause = "returned_at IS NULL AND expected_return_at IS NOT NULL AND expected_return_at < $1 AND reminder_sent_overdue = false"
+		args = []any{today}
+	case registry.ServiceReminderKindDueSoon:
+		limit := now.UTC().AddDate(0, 0, dueSoonDays).Format("2006-01-02")
+		whereClause = "returned_at IS NULL AND expected_return_at IS NOT NULL AND expected_return_at >= $1 AND expected_return_at <= $2 AND reminder_sent_due_soon = false"
+		args = []any{today, limit}
+	}
+	var services []*models.CommodityService
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT * FROM %s WHERE %s ORDER BY expected_return_at ASC, id ASC`,
+			r.tableNames.CommodityServices(), whereClause)
+		rows, err := tx.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return errxtrace.Wrap("failed to query pending service reminders", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var svc models.CommodityService
+			if err := rows.StructScan(&svc); err != nil {
+				return errxtrace.Wrap("failed to scan commodity service", err)
+			}
+			s := svc
+			services = append(services, &s)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list pending service reminders", err)
+	}
+	return services, nil
+}
+
+// MarkReminderSent atomically flips the matching reminder_sent_* flag
+// from false to true; a concurrent sweep sees RowsAffected == 0 and gets
+// (false, nil). Service-mode only.
+func (r *CommodityServiceRegistry) MarkReminderSent(ctx context.Context, serviceID string, kind registry.ServiceReminderKind) (bool, error) {
+	if !kind.IsValid() {
+		return false, registry.ErrInvalidInput
+	}
+	if !r.service {
+		return false, errxtrace.Wrap("MarkReminderSent requires service-mode registry", registry.ErrInvalidInput)
+	}
+	column := ""
+	switch kind {
+	case registry.ServiceReminderKindOverdue:
+		column = "reminder_sent_overdue"
+	case registry.ServiceReminderKindDueSoon:
+		column = "reminder_sent_due_soon"
+	}
+	var flipped bool
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`UPDATE %s SET %s = true, updated_at = NOW() WHERE id = $1 AND %s = false`,
+			r.tableNames.CommodityServices(), column, column)
+		res, err := tx.ExecContext(ctx, query, serviceID)
+		if err != nil {
+			return errxtrace.Wrap("failed to flip reminder flag", err)
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return errxtrace.Wrap("failed to read rows affected", err)
+		}
+		flipped = rows > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return flipped, nil
+}
@@ -294,6 +294,34 @@ func (r *ExportRegistry) ListDeleted(ctx context.Context) ([]*models.Export, err
 	return exports, nil
 }
 
+// ListPendingReplication returns completed exports still waiting for an
+// off-site copy, oldest completion first
+func (r *ExportRegistry) ListPendingReplication(ctx context.Context, maxAttempts, limit int) ([]*models.Export, error) {
+	var exports []*models.Export
+
+	reg := r.newSQLRegistry()
+
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`
+			SELECT * FROM %s
+			WHERE deleted_at IS NULL
+			  AND NOT imported
+			  AND status = $1
+			  AND COALESCE(file_path, '') <> ''
+			  AND replication_status <> $2
+			  AND replication_attempts < $3
+			ORDER BY completed_date, id
+			LIMIT $4`, r.tableNames.Exports())
+		return tx.SelectContext(ctx, &exports, query,
+			models.ExportStatusCompleted, models.ExportReplicationStatusReplicated, maxAttempts, limit)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list exports pending replication", err)
+	}
+
+	return exports, nil
+}
+
 // HardDelete permanently deletes an export from the database
 func (r *ExportRegistry) HardDelete(ctx context.Context, id string) error {
 	reg := r.newSQLRegistry()
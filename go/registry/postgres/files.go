@@ -129,7 +129,7 @@ func (r *FileRegistry) Create(ctx context.Context, file models.FileEntity) (*mod
 func (r *FileRegistry) Update(ctx context.Context, file models.FileEntity) (*models.FileEntity, error) {
 	reg := r.newSQLRegistry()
 
-	err := reg.Update(ctx, file, func(ctx context.Context, tx *sqlx.Tx, dbFile models.FileEntity) error {
+	err := reg.UpdateInPlace(ctx, &file, func(ctx context.Context, tx *sqlx.Tx, dbFile models.FileEntity) error {
 		return ensureTagRowsInTx(ctx, tx, r.tableNames, r.tenantID, r.groupID, r.createdByUserID, models.TagKindFile, []string(file.Tags))
 	})
 	if err != nil {
@@ -48,6 +48,10 @@ var purgeOrder = []func(t store.TableNames) string{
 	func(t store.TableNames) string { return string(t.RestoreOperations()) },
 	func(t store.TableNames) string { return string(t.Exports()) },
 
+	// Invoice extractions (file_id -> files CASCADE, commodity_id ->
+	// commodities CASCADE). Dropped before both parents.
+	func(t store.TableNames) string { return string(t.InvoiceExtractions()) },
+
 	// Generic group-scoped files (linked by polymorphic entity_type/id, no FK chain).
 	// (Legacy commodity-scoped images/invoices/manuals tables were dropped under #1421.)
 	func(t store.TableNames) string { return string(t.Files()) },
@@ -78,10 +82,24 @@ var purgeOrder = []func(t store.TableNames) string{
 	// rather than relying on the schedules cascade.
 	func(t store.TableNames) string { return string(t.MaintenanceReminders()) },
 
+	// Maintenance logs (schedule_id -> maintenance_schedules CASCADE) and
+	// meter readings (commodity_id -> commodities CASCADE). Dropped
+	// before their parents for the same scoping reason.
+	func(t store.TableNames) string { return string(t.MaintenanceLogs()) },
+	func(t store.TableNames) string { return string(t.CommodityMeterReadings()) },
+
 	// Maintenance schedules (#1368). Dropped before commodities so the
 	// FK cascade isn't relied on for tenant + group scoping.
 	func(t store.TableNames) string { return string(t.MaintenanceSchedules()) },
 
+	// Saved views. No FK to other content (areas / tags are referenced by
+	// value inside the filters blob); group_id -> location_groups is NO ACTION.
+	func(t store.TableNames) string { return string(t.SavedViews()) },
+
+	// Backup schedules. Dropped after exports, whose backup_schedule_id FK
+	// is ON DELETE SET NULL either way.
+	func(t store.TableNames) string { return string(t.BackupSchedules()) },
+
 	// Currency-migration audit rows (#2095). Their FK to currency_migrations
 	// is ON DELETE CASCADE, but group_id -> location_groups is NO ACTION, so
 	// the purge must clear them explicitly. Dropped BEFORE currency_migrations
@@ -117,6 +135,10 @@ var purgeOrder = []func(t store.TableNames) string{
 	// before the orchestration layer drops the group row.
 	func(t store.TableNames) string { return string(t.GroupNotificationPrefs()) },
 
+	// Per-member location scopes. group_id -> location_groups is NO ACTION;
+	// location_id carries no FK, so order relative to locations is free.
+	func(t store.TableNames) string { return string(t.MemberLocationScopes()) },
+
 	// Memberships last — they don't block child deletes but are cheapest to
 	// drop after everything else is already gone.
 	func(t store.TableNames) string { return string(t.GroupMemberships()) },
@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/internal/migrationops"
+	"github.com/denisvmedia/inventario/registry/postgres/store"
+)
+
+var _ registry.GroupTransferrer = (*GroupTransferrer)(nil)
+
+// GroupTransferrer moves locations, areas and commodities (with everything
+// attached to them) to another group of the same tenant in one
+// background-worker transaction. The application role can't do it: every
+// group-scoped RLS policy pins group_id to the request's group in its
+// WITH CHECK clause, so an UPDATE that changes group_id is refused.
+type GroupTransferrer struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// NewGroupTransferrer returns a GroupTransferrer bound to the default table
+// names.
+func NewGroupTransferrer(dbx *sqlx.DB) *GroupTransferrer {
+	return NewGroupTransferrerWithTableNames(dbx, store.DefaultTableNames)
+}
+
+// NewGroupTransferrerWithTableNames returns a GroupTransferrer using a custom
+// TableNames (used by tests that want to sandbox against renamed tables).
+func NewGroupTransferrerWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *GroupTransferrer {
+	return &GroupTransferrer{dbx: dbx, tableNames: tableNames}
+}
+
+// commodityChildTables are the tables whose rows follow a moved commodity
+// through its commodity_id column.
+var commodityChildTables = []func(t store.TableNames) string{
+	func(t store.TableNames) string { return string(t.CommodityEvents()) },
+	func(t store.TableNames) string { return string(t.CommodityLoans()) },
+	func(t store.TableNames) string { return string(t.CommodityServices()) },
+	func(t store.TableNames) string { return string(t.CommoditySupplyLinks()) },
+	func(t store.TableNames) string { return string(t.MaintenanceSchedules()) },
+	func(t store.TableNames) string { return string(t.MaintenanceLogs()) },
+	func(t store.TableNames) string { return string(t.CommodityMeterReadings()) },
+	func(t store.TableNames) string { return string(t.WarrantyReminders()) },
+	func(t store.TableNames) string { return string(t.InvoiceExtractions()) },
+}
+
+// TransferToGroup moves every row of t in a single transaction. Each
+// location, area and commodity UPDATE is keyed on (id, tenant_id,
+// from group_id) and must hit exactly one row; a miss means the row was
+// deleted or moved since the caller resolved it, so the whole transfer
+// rolls back with ErrNotFound.
+//
+// Child rows are moved by parent id and source group, so rows created
+// between the caller's reads and this transaction still travel along.
+// Currency-migration audit rows stay behind on purpose: they record what a
+// past migration did to the source group.
+func (r *GroupTransferrer) TransferToGroup(ctx context.Context, t registry.GroupTransfer) error {
+	if t.TenantID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+	if t.FromGroupID == "" || t.ToGroupID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "GroupID"))
+	}
+	if t.FromGroupID == t.ToGroupID {
+		return errxtrace.Classify(registry.ErrInvalidInput, errx.Attrs("group_id", t.ToGroupID))
+	}
+
+	return store.DoAsBackgroundWorker(ctx, r.dbx, func(ctx context.Context, tx *sqlx.Tx) error {
+		locationsTable := string(r.tableNames.Locations())
+		for _, id := range t.LocationIDs {
+			query := fmt.Sprintf(
+				`UPDATE %s SET group_id = $1 WHERE id = $2 AND tenant_id = $3 AND group_id = $4`,
+				locationsTable,
+			)
+			if err := r.moveOne(ctx, tx, locationsTable, id, query, t.ToGroupID, id, t.TenantID, t.FromGroupID); err != nil {
+				return err
+			}
+		}
+
+		areasTable := string(r.tableNames.Areas())
+		areaIDs := make([]string, 0, len(t.Areas))
+		for _, area := range t.Areas {
+			query := fmt.Sprintf(
+				`UPDATE %s SET group_id = $1, location_id = $5 WHERE id = $2 AND tenant_id = $3 AND group_id = $4`,
+				areasTable,
+			)
+			if err := r.moveOne(ctx, tx, areasTable, area.ID, query, t.ToGroupID, area.ID, t.TenantID, t.FromGroupID, area.LocationID); err != nil {
+				return err
+			}
+			areaIDs = append(areaIDs, area.ID)
+		}
+
+		commoditiesTable := string(r.tableNames.Commodities())
+		commodityIDs := make([]string, 0, len(t.Commodities))
+		for i := range t.Commodities {
+			tc := &t.Commodities[i]
+			c := &tc.Commodity
+			query := fmt.Sprintf(
+				`UPDATE %s
+				    SET group_id = $1,
+				        area_id = $5,
+				        original_price = $6,
+				        original_price_currency = $7,
+				        converted_original_price = $8,
+				        current_price = $9
+				  WHERE id = $2 AND tenant_id = $3 AND group_id = $4`,
+				commoditiesTable,
+			)
+			if err := r.moveOne(ctx, tx, commoditiesTable, c.ID, query,
+				t.ToGroupID, c.ID, t.TenantID, t.FromGroupID,
+				c.AreaID, c.OriginalPrice, c.OriginalPriceCurrency, c.ConvertedOriginalPrice, c.CurrentPrice,
+			); err != nil {
+				return err
+			}
+			if tc.FillAcquisition {
+				if err := migrationops.SetAcquisition(ctx, tx, commoditiesTable, c.ID, tc.AcquisitionPrice, tc.AcquisitionCurrency); err != nil {
+					return errxtrace.Wrap("failed to capture acquisition price", err, errx.Attrs("commodity_id", c.ID))
+				}
+			}
+			commodityIDs = append(commodityIDs, c.ID)
+		}
+
+		if len(commodityIDs) > 0 {
+			if err := r.moveCommodityChildren(ctx, tx, t, commodityIDs); err != nil {
+				return err
+			}
+		}
+		return r.moveFiles(ctx, tx, t, commodityIDs, areaIDs)
+	})
+}
+
+// moveOne runs a single-row move and maps "no row matched" to ErrNotFound.
+func (*GroupTransferrer) moveOne(ctx context.Context, tx *sqlx.Tx, table, id, query string, args ...any) error {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errxtrace.Wrap("failed to move row to group", err, errx.Attrs("table", table, "id", id))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errxtrace.Wrap("failed to read moved row count", err, errx.Attrs("table", table, "id", id))
+	}
+	if n != 1 {
+		return errxtrace.Classify(registry.ErrNotFound, errx.Attrs("table", table, "id", id))
+	}
+	return nil
+}
+
+// moveCommodityChildren re-groups every row hanging off the moved
+// commodities. Maintenance reminders have no commodity_id and follow their
+// schedule instead.
+func (r *GroupTransferrer) moveCommodityChildren(ctx context.Context, tx *sqlx.Tx, t registry.GroupTransfer, commodityIDs []string) error {
+	for _, nameFn := range commodityChildTables {
+		table := nameFn(r.tableNames)
+		query := fmt.Sprintf(
+			`UPDATE %s SET group_id = $1 WHERE tenant_id = $2 AND group_id = $3 AND commodity_id = ANY($4)`,
+			table,
+		)
+		if _, err := tx.ExecContext(ctx, query, t.ToGroupID, t.TenantID, t.FromGroupID, commodityIDs); err != nil {
+			return errxtrace.Wrap("failed to move commodity children", err, errx.Attrs("table", table))
+		}
+	}
+
+	// The schedules were moved above, so they are looked up in the target
+	// group.
+	query := fmt.Sprintf(
+		`UPDATE %s SET group_id = $1
+		  WHERE tenant_id = $2 AND group_id = $3
+		    AND schedule_id IN (SELECT id FROM %s WHERE group_id = $1 AND commodity_id = ANY($4))`,
+		r.tableNames.MaintenanceReminders(),
+		r.tableNames.MaintenanceSchedules(),
+	)
+	if _, err := tx.ExecContext(ctx, query, t.ToGroupID, t.TenantID, t.FromGroupID, commodityIDs); err != nil {
+		return errxtrace.Wrap("failed to move maintenance reminders", err)
+	}
+	return nil
+}
+
+// moveFiles re-groups the files linked to any moved location, area or
+// commodity. Blob keys carry no group segment, so only the row changes.
+func (r *GroupTransferrer) moveFiles(ctx context.Context, tx *sqlx.Tx, t registry.GroupTransfer, commodityIDs, areaIDs []string) error {
+	query := fmt.Sprintf(
+		`UPDATE %s SET group_id = $1
+		  WHERE tenant_id = $2 AND group_id = $3
+		    AND ((linked_entity_type = 'commodity' AND linked_entity_id = ANY($4))
+		      OR (linked_entity_type = 'area' AND linked_entity_id = ANY($5))
+		      OR (linked_entity_type = 'location' AND linked_entity_id = ANY($6)))`,
+		r.tableNames.Files(),
+	)
+	locationIDs := t.LocationIDs
+	if locationIDs == nil {
+		locationIDs = []string{}
+	}
+	if _, err := tx.ExecContext(ctx, query, t.ToGroupID, t.TenantID, t.FromGroupID, commodityIDs, areaIDs, locationIDs); err != nil {
+		return errxtrace.Wrap("failed to move linked files", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/postgres/store"
+)
+
+// InvoiceExtractionRegistryFactory creates
+// InvoiceExtractionRegistry instances with proper context.
+type InvoiceExtractionRegistryFactory struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// InvoiceExtractionRegistry is the postgres-backed group-scoped
+// registry of invoice extractions.
+type InvoiceExtractionRegistry struct {
+	dbx             *sqlx.DB
+	tableNames      store.TableNames
+	tenantID        string
+	groupID         string
+	createdByUserID string
+	service         bool
+}
+
+var (
+	_ registry.InvoiceExtractionRegistry        = (*InvoiceExtractionRegistry)(nil)
+	_ registry.InvoiceExtractionRegistryFactory = (*InvoiceExtractionRegistryFactory)(nil)
+)
+
+func NewInvoiceExtractionRegistry(dbx *sqlx.DB) *InvoiceExtractionRegistryFactory {
+	return NewInvoiceExtractionRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewInvoiceExtractionRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *InvoiceExtractionRegistryFactory {
+	return &InvoiceExtractionRegistryFactory{dbx: dbx, tableNames: tableNames}
+}
+
+func (f *InvoiceExtractionRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.InvoiceExtractionRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *InvoiceExtractionRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.InvoiceExtractionRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+	return &InvoiceExtractionRegistry{
+		dbx:             f.dbx,
+		tableNames:      f.tableNames,
+		tenantID:        user.TenantID,
+		groupID:         appctx.GroupIDFromContext(ctx),
+		createdByUserID: user.ID,
+		service:         false,
+	}, nil
+}
+
+func (f *InvoiceExtractionRegistryFactory) CreateServiceRegistry() registry.InvoiceExtractionRegistry {
+	return &InvoiceExtractionRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		service:    true,
+	}
+}
+
+func (r *InvoiceExtractionRegistry) newSQLRegistry() *store.RLSGroupRepository[models.InvoiceExtraction, *models.InvoiceExtraction] {
+	if r.service {
+		return store.NewGroupServiceSQLRegistry[models.InvoiceExtraction](r.dbx, r.tableNames.InvoiceExtractions())
+	}
+	return store.NewGroupAwareSQLRegistry[models.InvoiceExtraction](r.dbx, r.tenantID, r.groupID, r.createdByUserID, r.tableNames.InvoiceExtractions())
+}
+
+func (r *InvoiceExtractionRegistry) Get(ctx context.Context, id string) (*models.InvoiceExtraction, error) {
+	var item models.InvoiceExtraction
+	if err := r.newSQLRegistry().ScanOneByField(ctx, store.Pair("id", id), &item); err != nil {
+		return nil, errxtrace.Wrap("failed to get invoice extraction", err)
+	}
+	return &item, nil
+}
+
+func (r *InvoiceExtractionRegistry) List(ctx context.Context) ([]*models.InvoiceExtraction, error) {
+	var items []*models.InvoiceExtraction
+	for item, err := range r.newSQLRegistry().Scan(ctx) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list invoice extractions", err)
+		}
+		it := item
+		items = append(items, &it)
+	}
+	return items, nil
+}
+
+func (r *InvoiceExtractionRegistry) Count(ctx context.Context) (int, error) {
+	cnt, err := r.newSQLRegistry().Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count invoice extractions", err)
+	}
+	return cnt, nil
+}
+
+func (r *InvoiceExtractionRegistry) Create(ctx context.Context, entity models.InvoiceExtraction) (*models.InvoiceExtraction, error) {
+	entity.CreatedAt = time.Now()
+	created, err := r.newSQLRegistry().Create(ctx, entity, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create invoice extraction", err)
+	}
+	return &created, nil
+}
+
+func (r *InvoiceExtractionRegistry) Update(ctx context.Context, entity models.InvoiceExtraction) (*models.InvoiceExtraction, error) {
+	if err := r.newSQLRegistry().Update(ctx, entity, nil); err != nil {
+		return nil, errxtrace.Wrap("failed to update invoice extraction", err)
+	}
+	return &entity, nil
+}
+
+func (r *InvoiceExtractionRegistry) Delete(ctx context.Context, id string) error {
+	return r.newSQLRegistry().Delete(ctx, id, nil)
+}
+
+func (r *InvoiceExtractionRegistry) GetByFileID(ctx context.Context, fileID string) (*models.InvoiceExtraction, error) {
+	var item models.InvoiceExtraction
+	if err := r.newSQLRegistry().ScanOneByField(ctx, store.Pair("file_id", fileID), &item); err != nil {
+		return nil, errxtrace.Wrap("failed to get invoice extraction by file", err)
+	}
+	return &item, nil
+}
+
+func (r *InvoiceExtractionRegistry) ListByStatus(ctx context.Context, status models.InvoiceExtractionStatus) ([]*models.InvoiceExtraction, error) {
+	where := ""
+	var args []any
+	if status != "" {
+		where = "WHERE status = $1"
+		args = append(args, string(status))
+	}
+
+	var extractions []*models.InvoiceExtraction
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT * FROM %s %s ORDER BY created_at ASC, id ASC`,
+			r.tableNames.InvoiceExtractions(), where)
+		rows, err := tx.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return errxtrace.Wrap("failed to query invoice extractions", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var extraction models.InvoiceExtraction
+			if err := rows.StructScan(&extraction); err != nil {
+				return errxtrace.Wrap("failed to scan invoice extraction", err)
+			}
+			e := extraction
+			extractions = append(extractions, &e)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list invoice extractions by status", err)
+	}
+	return extractions, nil
+}
+
+func (r *InvoiceExtractionRegistry) ListByCommodity(ctx context.Context, commodityID string) ([]*models.InvoiceExtraction, error) {
+	var extractions []*models.InvoiceExtraction
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT * FROM %s WHERE commodity_id = $1 ORDER BY created_at ASC, id ASC`,
+			r.tableNames.InvoiceExtractions())
+		rows, err := tx.QueryxContext(ctx, query, commodityID)
+		if err != nil {
+			return errxtrace.Wrap("failed to query invoice extractions", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var extraction models.InvoiceExtraction
+			if err := rows.StructScan(&extraction); err != nil {
+				return errxtrace.Wrap("failed to scan invoice extraction", err)
+			}
+			e := extraction
+			extractions = append(extractions, &e)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list invoice extractions for commodity", err)
+	}
+	return extractions, nil
+}
@@ -179,7 +179,7 @@ func (r *LocationRegistry) Update(ctx context.Context, location models.Location)
 
 	reg := r.newSQLRegistry()
 
-	err := reg.Update(ctx, location, nil)
+	err := reg.UpdateInPlace(ctx, &location, nil)
 	if err != nil {
 		return nil, errxtrace.Wrap("failed to update location", err)
 	}
@@ -137,7 +137,7 @@ func (r *MaintenanceScheduleRegistry) ListByCommodity(ctx context.Context, commo
 	reg := r.newSQLRegistry()
 	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
 		query := fmt.Sprintf(
-			`SELECT * FROM %s WHERE commodity_id = $1 ORDER BY next_due_at ASC, title ASC`,
+			`SELECT * FROM %s WHERE commodity_id = $1 ORDER BY NULLIF(next_due_at, '') ASC NULLS LAST, title ASC`,
 			r.tableNames.MaintenanceSchedules())
 		rows, err := tx.QueryxContext(ctx, query, commodityID)
 		if err != nil {
@@ -178,7 +178,9 @@ func (r *MaintenanceScheduleRegistry) ListPaginated(ctx context.Context, offset,
 		conditions = append(conditions, "enabled = true")
 	}
 	if opts.DueBefore != "" {
-		conditions = append(conditions, fmt.Sprintf("next_due_at <= $%d", len(args)+1))
+		// Usage schedules without a due estimate carry an empty
+		// next_due_at, which would otherwise sort before every date.
+		conditions = append(conditions, fmt.Sprintf("next_due_at <> '' AND next_due_at <= $%d", len(args)+1))
 		args = append(args, opts.DueBefore)
 	}
 
@@ -200,7 +202,7 @@ func (r *MaintenanceScheduleRegistry) ListPaginated(ctx context.Context, offset,
 		dataArgs := append([]any{}, args...)
 		dataArgs = append(dataArgs, limit, offset)
 		dataQuery := fmt.Sprintf(
-			`SELECT * FROM %s %s ORDER BY next_due_at ASC, title ASC LIMIT $%d OFFSET $%d`,
+			`SELECT * FROM %s %s ORDER BY NULLIF(next_due_at, '') ASC NULLS LAST, title ASC LIMIT $%d OFFSET $%d`,
 			r.tableNames.MaintenanceSchedules(), whereClause, len(args)+1, len(args)+2)
 		rows, err := tx.QueryxContext(ctx, dataQuery, dataArgs...)
 		if err != nil {
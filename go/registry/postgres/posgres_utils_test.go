@@ -12,7 +12,6 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" // PostgreSQL driver
-	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -278,106 +277,3 @@ func getTestUser(c *qt.C, registrySet *registry.Set) *models.User {
 	// Use the first seeded user (should be the admin user created by setupTestTenantAndUser)
 	return users[0]
 }
-
-// createTestLocation creates a test location for use in tests.
-// This function requires that setupTestTenantAndUser has been called to seed test data.
-func createTestLocation(c *qt.C, registrySet *registry.Set) *models.Location {
-	c.Helper()
-
-	ctx := c.Context()
-
-	// Get the first seeded user to use for creating the location
-	users, err := registrySet.UserRegistry.List(ctx)
-	c.Assert(err, qt.IsNil)
-	c.Assert(users, qt.Not(qt.HasLen), 0, qt.Commentf("No users found - ensure setupTestTenantAndUser was called"))
-
-	// Use the first seeded user (should be the admin user created by seeddata)
-	seededUser := users[0]
-
-	location := models.Location{
-		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
-			TenantID:        seededUser.TenantID,
-			CreatedByUserID: seededUser.ID, // Use the actual generated user ID
-		},
-		Name:    "Test Location",
-		Address: "123 Test Street",
-	}
-
-	createdLocation, err := registrySet.LocationRegistry.Create(ctx, location)
-	c.Assert(err, qt.IsNil)
-	c.Assert(createdLocation, qt.IsNotNil)
-
-	return createdLocation
-}
-
-// createTestArea creates a test area for use in tests.
-func createTestArea(c *qt.C, registrySet *registry.Set, locationID string) *models.Area {
-	c.Helper()
-
-	ctx := c.Context()
-
-	// Get the first seeded user to use for creating the area
-	users, err := registrySet.UserRegistry.List(ctx)
-	c.Assert(err, qt.IsNil)
-	c.Assert(users, qt.Not(qt.HasLen), 0, qt.Commentf("No users found - ensure setupTestTenantAndUser was called"))
-
-	// Use the first seeded user (should be the admin user created by seeddata)
-	seededUser := users[0]
-
-	area := models.Area{
-		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
-			TenantID:        seededUser.TenantID,
-			CreatedByUserID: seededUser.ID, // Use the actual generated user ID
-		},
-		Name:       "Test Area",
-		LocationID: locationID,
-	}
-
-	createdArea, err := registrySet.AreaRegistry.Create(ctx, area)
-	c.Assert(err, qt.IsNil)
-	c.Assert(createdArea, qt.IsNotNil)
-
-	return createdArea
-}
-
-// createTestCommodity creates a test commodity for use in tests.
-func createTestCommodity(c *qt.C, registrySet *registry.Set, areaID string) *models.Commodity {
-	c.Helper()
-
-	ctx := c.Context()
-
-	// Get the first seeded user to use for creating the commodity
-	users, err := registrySet.UserRegistry.List(ctx)
-	c.Assert(err, qt.IsNil)
-	c.Assert(users, qt.Not(qt.HasLen), 0, qt.Commentf("No users found - ensure setupTestTenantAndUser was called"))
-
-	// Use the first seeded user (should be the admin user created by seeddata)
-	seededUser := users[0]
-
-	commodity := models.Commodity{
-		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
-			TenantID:        seededUser.TenantID,
-			CreatedByUserID: seededUser.ID, // Use the actual generated user ID
-		},
-		Name:                   "Test Commodity",
-		ShortName:              "TC",
-		Type:                   models.CommodityTypeElectronics,
-		AreaID:                 new(areaID),
-		Count:                  1,
-		OriginalPrice:          decimal.NewFromFloat(100.00),
-		OriginalPriceCurrency:  "USD",
-		ConvertedOriginalPrice: decimal.Zero,
-		CurrentPrice:           decimal.NewFromFloat(90.00),
-		Status:                 models.CommodityStatusInUse,
-		PurchaseDate:           models.ToPDate("2023-01-01"),
-		RegisteredDate:         models.ToPDate("2023-01-02"),
-		LastModifiedDate:       models.ToPDate("2023-01-03"),
-		Draft:                  false,
-	}
-
-	createdCommodity, err := registrySet.CommodityRegistry.Create(ctx, commodity)
-	c.Assert(err, qt.IsNil)
-	c.Assert(createdCommodity, qt.IsNotNil)
-
-	return createdCommodity
-}
@@ -40,12 +40,18 @@ func NewFactorySet(dbx *sqlx.DB) *registry.FactorySet {
 	fs.CommodityServiceRegistryFactory = NewCommodityServiceRegistry(dbx)
 	fs.SupplyLinkRegistryFactory = NewSupplyLinkRegistry(dbx)
 	fs.MaintenanceScheduleRegistryFactory = NewMaintenanceScheduleRegistry(dbx)
+	fs.MaintenanceLogRegistryFactory = NewMaintenanceLogRegistry(dbx)
+	fs.CommodityMeterReadingRegistryFactory = NewCommodityMeterReadingRegistry(dbx)
+	fs.InvoiceExtractionRegistryFactory = NewInvoiceExtractionRegistry(dbx)
+	fs.SavedViewRegistryFactory = NewSavedViewRegistry(dbx)
+	fs.BackupScheduleRegistryFactory = NewBackupScheduleRegistry(dbx)
 	fs.ExportRegistryFactory = NewExportRegistry(dbx)
 	fs.RestoreStepRegistryFactory = restoreStepFactory
 	fs.RestoreOperationRegistryFactory = NewRestoreOperationRegistry(dbx, restoreStepFactory)
 	fs.TenantRegistry = NewTenantRegistry(dbx)
 	fs.UserRegistry = NewUserRegistry(dbx)
 	fs.RefreshTokenRegistry = NewRefreshTokenRegistry(dbx)
+	fs.CalendarFeedRegistry = NewCalendarFeedRegistry(dbx)
 	fs.LoginEventRegistry = NewLoginEventRegistry(dbx)
 	fs.UserMFASecretRegistry = NewUserMFASecretRegistry(dbx)
 	fs.AuditLogRegistry = NewAuditLogRegistry(dbx)
@@ -53,6 +59,15 @@ func NewFactorySet(dbx *sqlx.DB) *registry.FactorySet {
 	// Background-worker soft-pause control (issue #1308). Global control
 	// plane — not tenant-scoped, no RLS (same posture as system_admin_grants).
 	fs.WorkerControlRegistry = NewWorkerControlRegistry(dbx)
+	// Backup-signing keyring — global like worker_control, no RLS.
+	fs.TrustedBackupKeyRegistry = NewTrustedBackupKeyRegistry(dbx)
+	// AI vision spend caps — set from the back office, no RLS.
+	fs.CommodityScanBudgetRegistry = NewCommodityScanBudgetRegistry(dbx)
+	// Registration approval queue — decided across tenants, no RLS.
+	fs.RegistrationRequestRegistry = NewRegistrationRequestRegistry(dbx)
+	// Idempotency-Key replay records — resolved before the handler runs and
+	// swept across tenants by the cleanup worker, no RLS.
+	fs.IdempotencyKeyRegistry = NewIdempotencyKeyRegistry(dbx)
 	fs.EmailVerificationRegistry = NewEmailVerificationRegistry(dbx)
 	fs.PasswordResetRegistry = NewPasswordResetRegistry(dbx)
 	// Magic-link sign-in tokens — service-mode lookup resolved before any
@@ -70,7 +85,9 @@ func NewFactorySet(dbx *sqlx.DB) *registry.FactorySet {
 	fs.GroupInviteRegistry = NewGroupInviteRegistry(dbx)
 	fs.GroupInviteAuditRegistry = NewGroupInviteAuditRegistry(dbx)
 	fs.GroupNotificationPrefRegistry = NewGroupNotificationPrefRegistry(dbx)
+	fs.GroupMemberLocationScopeRegistry = NewGroupMemberLocationScopeRegistry(dbx)
 	fs.GroupPurger = NewGroupPurger(dbx)
+	fs.GroupTransferrer = NewGroupTransferrer(dbx)
 	fs.TenantPurger = NewTenantPurger(dbx)
 	fs.UserPurger = NewUserPurger(dbx)
 	fs.UserContentOwnershipChecker = NewUserContentOwnershipChecker(dbx)
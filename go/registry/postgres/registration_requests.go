@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/postgres/store"
+)
+
+var _ registry.RegistrationRequestRegistry = (*RegistrationRequestRegistry)(nil)
+
+// RegistrationRequestRegistry is the postgres-backed registration approval
+// queue. The table is NOT RLS-enabled (same posture as
+// commodity_scan_budgets) and every operation is a single statement
+// against r.dbx. Decide's pending check lives in the UPDATE's WHERE clause,
+// so two operators racing on one request can't both win.
+type RegistrationRequestRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// NewRegistrationRequestRegistry creates a new RegistrationRequestRegistry.
+func NewRegistrationRequestRegistry(dbx *sqlx.DB) *RegistrationRequestRegistry {
+	return NewRegistrationRequestRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+// NewRegistrationRequestRegistryWithTableNames is the test-friendly
+// constructor that lets a caller override the table-name mapping.
+func NewRegistrationRequestRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *RegistrationRequestRegistry {
+	return &RegistrationRequestRegistry{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+// Create stores a new pending request. The unique user_id index turns a
+// second request for the same user into ErrAlreadyExists.
+func (r *RegistrationRequestRegistry) Create(ctx context.Context, req models.RegistrationRequest) (*models.RegistrationRequest, error) {
+	if req.TenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+	if req.UserID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "user_id"))
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (id, uuid, tenant_id, user_id, email, name, status, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+		 ON CONFLICT (user_id) DO NOTHING
+		 RETURNING *`,
+		r.tableNames.RegistrationRequests(),
+	)
+
+	var stored models.RegistrationRequest
+	err := r.dbx.QueryRowxContext(ctx, query,
+		uuid.New().String(), uuid.New().String(), req.TenantID, req.UserID, req.Email, req.Name, models.RegistrationRequestStatusPending,
+	).StructScan(&stored)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errxtrace.Classify(registry.ErrAlreadyExists, errx.Attrs("user_id", req.UserID))
+	}
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create registration request", err)
+	}
+	return &stored, nil
+}
+
+// Get returns the request with the given id.
+func (r *RegistrationRequestRegistry) Get(ctx context.Context, id string) (*models.RegistrationRequest, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE id = $1`, r.tableNames.RegistrationRequests())
+	return r.getOne(ctx, query, id, errx.Attrs("id", id))
+}
+
+// GetByUserID returns the user's request.
+func (r *RegistrationRequestRegistry) GetByUserID(ctx context.Context, userID string) (*models.RegistrationRequest, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE user_id = $1`, r.tableNames.RegistrationRequests())
+	return r.getOne(ctx, query, userID, errx.Attrs("user_id", userID))
+}
+
+// List returns the requests matching filter, oldest first.
+func (r *RegistrationRequestRegistry) List(ctx context.Context, filter registry.RegistrationRequestFilter) ([]*models.RegistrationRequest, error) {
+	query := fmt.Sprintf(
+		`SELECT * FROM %s
+		 WHERE ($1 = '' OR tenant_id = $1) AND ($2 = '' OR status = $2)
+		 ORDER BY created_at, id`,
+		r.tableNames.RegistrationRequests(),
+	)
+
+	rows, err := r.dbx.QueryxContext(ctx, query, filter.TenantID, string(filter.Status))
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list registration requests", err)
+	}
+	defer rows.Close()
+
+	var requests []*models.RegistrationRequest
+	for rows.Next() {
+		var req models.RegistrationRequest
+		if scanErr := rows.StructScan(&req); scanErr != nil {
+			return nil, errxtrace.Wrap("failed to scan registration request row", scanErr)
+		}
+		requests = append(requests, &req)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errxtrace.Wrap("failed during registration request iteration", err)
+	}
+	return requests, nil
+}
+
+// Decide moves a pending request to status. When the conditional UPDATE
+// matches nothing, a follow-up Get tells a missing row (ErrNotFound) from
+// one that was already decided (ErrRegistrationAlreadyDecided).
+func (r *RegistrationRequestRegistry) Decide(ctx context.Context, id string, status models.RegistrationRequestStatus, reason, decidedBy *string) (*models.RegistrationRequest, error) {
+	if status != models.RegistrationRequestStatusApproved && status != models.RegistrationRequestStatusRejected {
+		return nil, errxtrace.Classify(registry.ErrInvalidInput, errx.Attrs("status", status))
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET status = $2, reason = $3, decided_by = $4, decided_at = now()
+		 WHERE id = $1 AND status = $5
+		 RETURNING *`,
+		r.tableNames.RegistrationRequests(),
+	)
+
+	var stored models.RegistrationRequest
+	err := r.dbx.QueryRowxContext(ctx, query, id, status, reason, decidedBy, models.RegistrationRequestStatusPending).StructScan(&stored)
+	if errors.Is(err, sql.ErrNoRows) {
+		existing, getErr := r.Get(ctx, id)
+		if getErr != nil {
+			return nil, getErr
+		}
+		return nil, errxtrace.Classify(registry.ErrRegistrationAlreadyDecided, errx.Attrs("id", id, "status", existing.Status))
+	}
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to decide registration request", err)
+	}
+	return &stored, nil
+}
+
+// DeleteByUserID removes the user's request, if any.
+func (r *RegistrationRequestRegistry) DeleteByUserID(ctx context.Context, userID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, r.tableNames.RegistrationRequests())
+	if _, err := r.dbx.ExecContext(ctx, query, userID); err != nil {
+		return errxtrace.Wrap("failed to delete registration request", err, errx.Attrs("user_id", userID))
+	}
+	return nil
+}
+
+// getOne runs a single-row SELECT, mapping no rows to ErrNotFound.
+func (r *RegistrationRequestRegistry) getOne(ctx context.Context, query, arg string, attrs errx.Classified) (*models.RegistrationRequest, error) {
+	var req models.RegistrationRequest
+	err := r.dbx.QueryRowxContext(ctx, query, arg).StructScan(&req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errxtrace.Classify(registry.ErrNotFound, attrs)
+	}
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get registration request", err)
+	}
+	return &req, nil
+}
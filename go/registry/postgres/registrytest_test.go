@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/postgres"
+	"github.com/denisvmedia/inventario/registry/postgres/store"
+	"github.com/denisvmedia/inventario/registry/registrytest"
+	"github.com/denisvmedia/inventario/schema/migrations/migrator"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// TestRegistry runs the shared registry suite against the database
+// POSTGRES_TEST_DSN points at. It self-skips when the variable is unset.
+func TestRegistry(t *testing.T) {
+	registrytest.Run(t, harness{})
+}
+
+// harness drops and re-migrates the shared test database for every store,
+// so a store starts out empty.
+type harness struct{}
+
+func (harness) OpenStore(t *testing.T) registrytest.Store {
+	t.Helper()
+
+	dsn := skipIfNoPostgreSQL(t)
+	c := qt.New(t)
+
+	pool, err := getOrCreatePool(dsn)
+	c.Assert(err, qt.IsNil)
+
+	migr := migrator.NewWithFallback(dsn, "../../models")
+	c.Assert(migrateUp(t, context.Background(), migr, dsn), qt.IsNil)
+
+	return testStore{t: t, pool: pool}
+}
+
+type testStore struct {
+	t    *testing.T
+	pool *pgxpool.Pool
+}
+
+// FactorySet returns a factory set on its own *sql.DB handle over the
+// shared pool, so factory sets don't share transaction state.
+func (s testStore) FactorySet() *registry.FactorySet {
+	return postgres.NewFactorySet(s.db())
+}
+
+func (s testStore) CurrencyMigrationProcessor() services.CurrencyMigrationProcessor {
+	return processorAdapter{inner: postgres.NewCurrencyMigrationRegistry(s.db()).NewProcessor()}
+}
+
+func (s testStore) DoAsBackgroundWorker(ctx context.Context, fn func(context.Context, *sqlx.Tx) error) error {
+	return store.DoAsBackgroundWorker(ctx, s.db(), fn)
+}
+
+func (s testStore) db() *sqlx.DB {
+	sqlDB := stdlib.OpenDBFromPool(s.pool)
+	s.t.Cleanup(func() { _ = sqlDB.Close() })
+	return sqlx.NewDb(sqlDB, "pgx")
+}
+
+// processorAdapter bridges the postgres processor to
+// services.CurrencyMigrationProcessor, as the worker bootstrap does.
+type processorAdapter struct {
+	inner *postgres.CurrencyMigrationProcessor
+}
+
+func (a processorAdapter) ProcessRunningMigration(ctx context.Context, op *models.CurrencyMigration) (services.CurrencyMigrationProcessSummary, error) {
+	res, err := a.inner.ProcessRunningMigration(ctx, op)
+	return services.CurrencyMigrationProcessSummary(res), err
+}
+
+func (a processorAdapter) WriteSweepFailureAuditLog(ctx context.Context, op *models.CurrencyMigration) error {
+	return a.inner.WriteSweepFailureAuditLog(ctx, op)
+}
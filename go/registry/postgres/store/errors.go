@@ -1,8 +1,12 @@
 package store
 
 import (
+	"context"
 	"errors"
 
+	"github.com/go-extras/errx"
+
+	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/registry"
 )
 
@@ -13,3 +17,24 @@ var (
 	ErrTenantIDRequired        = errors.New("tenant ID is required")
 	ErrCreatedByUserIDRequired = errors.New("created_by_user_id is required")
 )
+
+// pgInsufficientPrivilege is the SQLSTATE Postgres raises when a row
+// fails an RLS WITH CHECK clause, and the code the location-scope write
+// trigger raises on purpose.
+const pgInsufficientPrivilege = "42501"
+
+// scopeViolation returns the classification for a write rejected by the
+// location-scope RLS terms. It only fires for a location-scoped member:
+// for everybody else the scope terms are always true, so a 42501 means
+// something else and is left unclassified.
+func scopeViolation(ctx context.Context, err error) []errx.Classified {
+	if !appctx.LocationScopeFromContext(ctx).Restricted() {
+		return nil
+	}
+	type sqlStater interface{ SQLState() string }
+	var s sqlStater
+	if !errors.As(err, &s) || s.SQLState() != pgInsufficientPrivilege {
+		return nil
+	}
+	return []errx.Classified{registry.ErrLocationScopeDenied}
+}
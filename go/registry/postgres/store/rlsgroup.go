@@ -10,6 +10,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
 )
 
 // RLSGroupRepository provides SQL operations with tenant + group RLS enforcement.
@@ -149,6 +150,9 @@ func (r *RLSGroupRepository[T, P]) Create(ctx context.Context, entity T, checker
 	if uuidable, ok := any(P(&entity)).(models.UUIDable); ok {
 		uuidable.SetUUID(generateID())
 	}
+	if versioned, ok := any(P(&entity)).(models.Versioned); ok {
+		versioned.SetVersion(1)
+	}
 
 	if !r.service {
 		P(&entity).SetTenantID(r.tenantID)
@@ -173,6 +177,19 @@ func (r *RLSGroupRepository[T, P]) Create(ctx context.Context, entity T, checker
 }
 
 func (r *RLSGroupRepository[T, P]) Update(ctx context.Context, entity T, checkerFn func(context.Context, *sqlx.Tx, T) error) error {
+	return r.UpdateInPlace(ctx, &entity, checkerFn)
+}
+
+// UpdateInPlace is Update for callers that hand the written entity back: the
+// server-managed fields it fills in — the preserved UUID and
+// created_by_user_id, and the bumped version of a models.Versioned entity —
+// are stored into *entity.
+//
+// For a models.Versioned entity the row is locked (SELECT ... FOR UPDATE)
+// before it is read, so the optimistic version check and the write cannot
+// interleave with a concurrent Update: a non-zero incoming version must match
+// the stored one or the call fails with registry.ErrVersionConflict.
+func (r *RLSGroupRepository[T, P]) UpdateInPlace(ctx context.Context, entity *T, checkerFn func(context.Context, *sqlx.Tx, T) error) error {
 	tx, err := r.beginTx(ctx)
 	if err != nil {
 		return errxtrace.Wrap("failed to begin transaction", err)
@@ -182,11 +199,19 @@ func (r *RLSGroupRepository[T, P]) Update(ctx context.Context, entity T, checker
 	}()
 
 	if !r.service {
-		P(&entity).SetTenantID(r.tenantID)
-		P(&entity).SetGroupID(r.groupID)
+		P(entity).SetTenantID(r.tenantID)
+		P(entity).SetGroupID(r.groupID)
 	}
 
-	field := Pair("id", P(&entity).GetID())
+	field := Pair("id", P(entity).GetID())
+
+	versioned, isVersioned := any(P(entity)).(models.Versioned)
+	if isVersioned {
+		err = NewTxRegistry[T](tx, r.table).LockByField(ctx, field)
+		if err != nil {
+			return errxtrace.Wrap("failed to lock entity", err)
+		}
+	}
 
 	var dbEntity T
 	err = NewTxRegistry[T](tx, r.table).ScanOneByField(ctx, field, &dbEntity)
@@ -194,7 +219,17 @@ func (r *RLSGroupRepository[T, P]) Update(ctx context.Context, entity T, checker
 		return errxtrace.Wrap("failed to scan entity", err)
 	}
 
-	if uuidable, ok := any(P(&entity)).(models.UUIDable); ok {
+	if isVersioned {
+		current := any(P(&dbEntity)).(models.Versioned).GetVersion()
+		if expected := versioned.GetVersion(); expected != 0 && expected != current {
+			err = errxtrace.Classify(registry.ErrVersionConflict,
+				errx.Attrs("entity_type", r.table, "expected_version", expected, "current_version", current))
+			return err
+		}
+		versioned.SetVersion(current + 1)
+	}
+
+	if uuidable, ok := any(P(entity)).(models.UUIDable); ok {
 		if dbUuidable, ok := any(P(&dbEntity)).(models.UUIDable); ok {
 			uuidable.SetUUID(dbUuidable.GetUUID())
 		}
@@ -204,7 +239,7 @@ func (r *RLSGroupRepository[T, P]) Update(ctx context.Context, entity T, checker
 	// Preserve the existing DB value so a caller can't accidentally clear or
 	// change it via Update (which would break the NOT NULL + FK invariant and
 	// lose provenance information).
-	P(&entity).SetCreatedByUserID(P(&dbEntity).GetCreatedByUserID())
+	P(entity).SetCreatedByUserID(P(&dbEntity).GetCreatedByUserID())
 
 	if checkerFn != nil {
 		err = checkerFn(ctx, tx, dbEntity)
@@ -214,7 +249,7 @@ func (r *RLSGroupRepository[T, P]) Update(ctx context.Context, entity T, checker
 	}
 
 	txreg := NewTxRegistry[T](tx, r.table)
-	err = txreg.UpdateByField(ctx, field, entity)
+	err = txreg.UpdateByField(ctx, field, *entity)
 	if err != nil {
 		return errxtrace.Wrap("failed to update entity", err)
 	}
@@ -235,11 +270,28 @@ func (r *RLSGroupRepository[T, P]) Delete(ctx context.Context, id string, checke
 
 	var entity T
 	txreg := NewTxRegistry[T](tx, r.table)
+	versioned, isVersioned := any(P(&entity)).(models.Versioned)
+	if isVersioned {
+		// Same lock as UpdateInPlace, so a conditional delete
+		// (registry.WithExpectedVersion) cannot interleave with an Update
+		// that bumps the version.
+		err = txreg.LockByField(ctx, field)
+		if err != nil {
+			return errxtrace.Wrap("failed to lock entity", err)
+		}
+	}
 	err = txreg.ScanOneByField(ctx, field, &entity)
 	if err != nil {
 		return errxtrace.Wrap("entity not found", err)
 	}
 
+	if isVersioned {
+		err = registry.CheckExpectedVersion(ctx, id, versioned)
+		if err != nil {
+			return err
+		}
+	}
+
 	if checkerFn != nil {
 		err = checkerFn(ctx, tx)
 		if err != nil {
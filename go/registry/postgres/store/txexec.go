@@ -101,7 +101,7 @@ func (r *TxExecutor[T]) Insert(ctx context.Context, entity any) error {
 
 	_, err = sqlx.NamedExecContext(ctx, r.tx, query, params)
 	if err != nil {
-		return errxtrace.Wrap("failed to insert entity", err)
+		return errxtrace.Wrap("failed to insert entity", err, scopeViolation(ctx, err)...)
 	}
 
 	return nil
@@ -133,7 +133,20 @@ func (r *TxExecutor[T]) UpdateByField(ctx context.Context, field FieldValue, ent
 
 	_, err = sqlx.NamedExecContext(ctx, r.tx, query, params)
 	if err != nil {
-		return errxtrace.Wrap("failed to update entity", err)
+		return errxtrace.Wrap("failed to update entity", err, scopeViolation(ctx, err)...)
+	}
+
+	return nil
+}
+
+// LockByField takes a row lock (SELECT ... FOR UPDATE) on the rows matching
+// field, held until the transaction ends. Matching no row is not an error.
+func (r *TxExecutor[T]) LockByField(ctx context.Context, field FieldValue) error {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE %s = $1 FOR UPDATE", r.table, field.Field)
+
+	_, err := r.tx.ExecContext(ctx, query, field.Value)
+	if err != nil {
+		return errxtrace.Wrap("failed to lock entity", err)
 	}
 
 	return nil
@@ -216,7 +229,7 @@ func (r *TxExecutor[T]) DeleteByField(ctx context.Context, field FieldValue) err
 
 	_, err := r.tx.ExecContext(ctx, query, field.Value)
 	if err != nil {
-		return errxtrace.Wrap("failed to delete entity", err)
+		return errxtrace.Wrap("failed to delete entity", err, scopeViolation(ctx, err)...)
 	}
 
 	return nil
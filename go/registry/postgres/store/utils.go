@@ -9,6 +9,8 @@ import (
 	errxtrace "github.com/go-extras/errx/stacktrace"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
 )
 
 func RollbackOrCommit(tx *sqlx.Tx, err error) error {
@@ -146,6 +148,23 @@ func setGroupContext(ctx context.Context, tx *sqlx.Tx, groupID string) error {
 	return nil
 }
 
+// setLocationScopeContext publishes a restricted member's location scope
+// to the location_scope_allows() RLS helper. Unrestricted members leave
+// the setting empty, which the helper treats as "allow everything".
+func setLocationScopeContext(ctx context.Context, tx *sqlx.Tx) error {
+	scope := appctx.LocationScopeFromContext(ctx)
+	if !scope.Restricted() {
+		return nil
+	}
+	escapedScope := strings.ReplaceAll(scope.Encode(), "'", "''")
+	query := fmt.Sprintf("SET LOCAL app.current_location_scope = '%s'", escapedScope)
+	_, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		return errxtrace.Wrap("failed to set location scope context", err)
+	}
+	return nil
+}
+
 func beginTxWithTenantAndGroup(ctx context.Context, dbx *sqlx.DB, tenantID, groupID string) (*sqlx.Tx, error) {
 	if tenantID == "" {
 		return nil, ErrTenantIDRequired
@@ -177,6 +196,12 @@ func beginTxWithTenantAndGroup(ctx context.Context, dbx *sqlx.DB, tenantID, grou
 		return nil, errxtrace.Wrap("failed to set group context", err)
 	}
 
+	err = setLocationScopeContext(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, errxtrace.Wrap("failed to set location scope context", err)
+	}
+
 	return tx, nil
 }
 
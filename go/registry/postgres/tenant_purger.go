@@ -92,6 +92,10 @@ var tenantPurgeOrder = []func(t store.TableNames) string{
 	func(t store.TableNames) string { return string(t.UserConcurrencySlots()) },
 	func(t store.TableNames) string { return string(t.ThumbnailGenerationJobs()) },
 
+	// Invoice extractions (file_id -> files CASCADE, commodity_id ->
+	// commodities CASCADE); dropped before both parents.
+	func(t store.TableNames) string { return string(t.InvoiceExtractions()) },
+
 	// Generic polymorphic files (linked by entity_type/id, no FK chain). Must
 	// drop after the thumbnail chain (jobs.file_id -> files NO ACTION);
 	// exports.file_id (SET NULL, #2180) and commodities.cover_file_id (SET NULL)
@@ -116,10 +120,23 @@ var tenantPurgeOrder = []func(t store.TableNames) string{
 	// maintenance_schedules CASCADE; dropped before the schedules.
 	func(t store.TableNames) string { return string(t.MaintenanceReminders()) },
 
+	// Maintenance logs (schedule_id -> maintenance_schedules CASCADE) and
+	// meter readings (commodity_id -> commodities CASCADE); dropped before
+	// their parents.
+	func(t store.TableNames) string { return string(t.MaintenanceLogs()) },
+	func(t store.TableNames) string { return string(t.CommodityMeterReadings()) },
+
 	// Maintenance schedules (#1368). commodity_id -> commodities CASCADE;
 	// dropped before commodities.
 	func(t store.TableNames) string { return string(t.MaintenanceSchedules()) },
 
+	// Saved views. No content FKs; group_id / tenant_id are NO ACTION.
+	func(t store.TableNames) string { return string(t.SavedViews()) },
+
+	// Backup schedules. exports.backup_schedule_id is SET NULL; group_id /
+	// tenant_id are NO ACTION.
+	func(t store.TableNames) string { return string(t.BackupSchedules()) },
+
 	// Currency-migration audit rows (#2095). migration_id ->
 	// currency_migrations CASCADE, commodity_id -> commodities SET NULL.
 	// Dropped before both currency_migrations and commodities.
@@ -140,6 +157,15 @@ var tenantPurgeOrder = []func(t store.TableNames) string{
 	// no children, so unconstrained — drop before users (appended at the end).
 	func(t store.TableNames) string { return string(t.CommodityScanAudits()) },
 
+	// AI vision spend caps. tenant_id / group_id are plain columns (no FK);
+	// dropped with the tenant so a recreated id does not inherit a cap.
+	func(t store.TableNames) string { return string(t.CommodityScanBudgets()) },
+
+	// Registration approval queue. tenant_id / user_id are plain columns (no
+	// FK), so the position is free; kept with the other no-FK platform rows.
+	func(t store.TableNames) string { return string(t.RegistrationRequests()) },
+	func(t store.TableNames) string { return string(t.IdempotencyKeys()) },
+
 	// Inventory hierarchy: commodities -> areas -> locations (NO ACTION).
 	func(t store.TableNames) string { return string(t.Commodities()) },
 	func(t store.TableNames) string { return string(t.Areas()) },
@@ -153,6 +179,10 @@ var tenantPurgeOrder = []func(t store.TableNames) string{
 	// location_groups NO ACTION; cleared before location_groups.
 	func(t store.TableNames) string { return string(t.GroupNotificationPrefs()) },
 
+	// Per-member location scopes. group_id -> location_groups NO ACTION;
+	// cleared before location_groups.
+	func(t store.TableNames) string { return string(t.MemberLocationScopes()) },
+
 	// Installation settings rows. One per (tenant, key); no children, no
 	// incoming FK — unconstrained.
 	func(t store.TableNames) string { return string(t.Settings()) },
@@ -163,9 +193,11 @@ var tenantPurgeOrder = []func(t store.TableNames) string{
 
 	// Auth/session tables (all FK user_id -> users NO ACTION, so they must
 	// drop before users; none FK each other). login_events, refresh_tokens,
-	// the token tables, MFA + OAuth identities, operation slots.
+	// calendar feeds, the token tables, MFA + OAuth identities, operation
+	// slots.
 	func(t store.TableNames) string { return string(t.LoginEvents()) },
 	func(t store.TableNames) string { return string(t.RefreshTokens()) },
+	func(t store.TableNames) string { return string(t.CalendarFeeds()) },
 	func(t store.TableNames) string { return string(t.EmailVerifications()) },
 	func(t store.TableNames) string { return string(t.PasswordResets()) },
 	func(t store.TableNames) string { return string(t.MagicLinkTokens()) },
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/postgres/store"
+)
+
+var _ registry.TrustedBackupKeyRegistry = (*TrustedBackupKeyRegistry)(nil)
+
+// TrustedBackupKeyRegistry is the postgres-backed backup-signing keyring.
+// The table is NOT RLS-enabled (same posture as worker_control) and every
+// operation is a single statement against r.dbx. The unique index on
+// fingerprint backs Add's ON CONFLICT guard.
+type TrustedBackupKeyRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// NewTrustedBackupKeyRegistry creates a new TrustedBackupKeyRegistry.
+func NewTrustedBackupKeyRegistry(dbx *sqlx.DB) *TrustedBackupKeyRegistry {
+	return NewTrustedBackupKeyRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+// NewTrustedBackupKeyRegistryWithTableNames is the test-friendly constructor
+// that lets a caller override the table-name mapping.
+func NewTrustedBackupKeyRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *TrustedBackupKeyRegistry {
+	return &TrustedBackupKeyRegistry{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+// List returns every key ordered by (added_at, fingerprint).
+func (r *TrustedBackupKeyRegistry) List(ctx context.Context) ([]*models.TrustedBackupKey, error) {
+	query := fmt.Sprintf(
+		`SELECT * FROM %s ORDER BY added_at, fingerprint`,
+		r.tableNames.TrustedBackupKeys(),
+	)
+	rows, err := r.dbx.QueryxContext(ctx, query)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list trusted backup keys", err)
+	}
+	defer rows.Close()
+
+	var keys []*models.TrustedBackupKey
+	for rows.Next() {
+		var k models.TrustedBackupKey
+		if scanErr := rows.StructScan(&k); scanErr != nil {
+			return nil, errxtrace.Wrap("failed to scan trusted backup key row", scanErr)
+		}
+		keys = append(keys, &k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errxtrace.Wrap("failed during trusted backup key iteration", err)
+	}
+	return keys, nil
+}
+
+// GetByFingerprint returns the key with the given fingerprint.
+func (r *TrustedBackupKeyRegistry) GetByFingerprint(ctx context.Context, fingerprint string) (*models.TrustedBackupKey, error) {
+	if fingerprint == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "fingerprint"))
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE fingerprint = $1`, r.tableNames.TrustedBackupKeys())
+
+	var k models.TrustedBackupKey
+	switch err := r.dbx.QueryRowxContext(ctx, query, fingerprint).StructScan(&k); {
+	case err == nil:
+		return &k, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("fingerprint", fingerprint))
+	default:
+		return nil, errxtrace.Wrap("failed to get trusted backup key", err)
+	}
+}
+
+// Add stores a new key. ON CONFLICT DO NOTHING turns a duplicate fingerprint
+// into an empty RETURNING set, which maps to ErrAlreadyExists without
+// inspecting driver error codes.
+func (r *TrustedBackupKeyRegistry) Add(ctx context.Context, key models.TrustedBackupKey) (*models.TrustedBackupKey, error) {
+	switch {
+	case key.Fingerprint == "":
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "fingerprint"))
+	case key.PublicKey == "":
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "public_key"))
+	case key.Label == "":
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "label"))
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (id, uuid, fingerprint, public_key, label, added_at, added_by, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, now(), $6, $7)
+		 ON CONFLICT (fingerprint) DO NOTHING
+		 RETURNING *`,
+		r.tableNames.TrustedBackupKeys(),
+	)
+
+	var stored models.TrustedBackupKey
+	switch err := r.dbx.QueryRowxContext(ctx, query,
+		uuid.New().String(), uuid.New().String(), key.Fingerprint, key.PublicKey, key.Label, key.AddedBy, key.ExpiresAt,
+	).StructScan(&stored); {
+	case err == nil:
+		return &stored, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errxtrace.Classify(registry.ErrAlreadyExists, errx.Attrs("fingerprint", key.Fingerprint))
+	default:
+		return nil, errxtrace.Wrap("failed to add trusted backup key", err)
+	}
+}
+
+// Revoke marks the key revoked. The WHERE guard leaves an already-revoked
+// row untouched, in which case the current row is read back so the caller
+// still sees the original revocation.
+func (r *TrustedBackupKeyRegistry) Revoke(ctx context.Context, fingerprint, revokedBy, reason string) (*models.TrustedBackupKey, error) {
+	if fingerprint == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "fingerprint"))
+	}
+
+	var revokedByArg, reasonArg any
+	if revokedBy != "" {
+		revokedByArg = revokedBy
+	}
+	if reason != "" {
+		reasonArg = reason
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET
+		   revoked_at = now(),
+		   revoked_by = $2,
+		   revoke_reason = $3
+		 WHERE fingerprint = $1 AND revoked_at IS NULL
+		 RETURNING *`,
+		r.tableNames.TrustedBackupKeys(),
+	)
+
+	var k models.TrustedBackupKey
+	switch err := r.dbx.QueryRowxContext(ctx, query, fingerprint, revokedByArg, reasonArg).StructScan(&k); {
+	case err == nil:
+		return &k, nil
+	case errors.Is(err, sql.ErrNoRows):
+		// Either already revoked or unknown — GetByFingerprint tells them
+		// apart (ErrNotFound for the latter).
+		return r.GetByFingerprint(ctx, fingerprint)
+	default:
+		return nil, errxtrace.Wrap("failed to revoke trusted backup key", err)
+	}
+}
@@ -30,7 +30,9 @@ var _ registry.UserPurger = (*UserPurger)(nil)
 //     and the group_invites_audit rows that reference the user as the invite
 //     creator (created_by) or accepter (used_by) — both NOT NULL FK -> users(id)
 //     NO ACTION, so they must be cleared here or the final users delete is
-//     rejected (#2147).
+//     rejected (#2147). The saved views the user created go too: they are
+//     list presets rather than inventory content, and created_by_user_id is
+//     NOT NULL.
 //   - SET NULL the authorship back-refs that are NULLABLE: login_events.user_id
 //     (already nulled by the DELETE-by-user above — see note), and
 //     system_admin_grants.granted_by (the *operator* who granted someone else's
@@ -80,6 +82,7 @@ func NewUserPurgerWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *Use
 var userDeleteByUserID = []func(t store.TableNames) string{
 	// Auth / session.
 	func(t store.TableNames) string { return string(t.RefreshTokens()) },
+	func(t store.TableNames) string { return string(t.CalendarFeeds()) },
 	func(t store.TableNames) string { return string(t.LoginEvents()) },
 	func(t store.TableNames) string { return string(t.EmailVerifications()) },
 	func(t store.TableNames) string { return string(t.PasswordResets()) },
@@ -96,8 +99,15 @@ var userDeleteByUserID = []func(t store.TableNames) string{
 	// AI vision per-user audit + rate-limit rows.
 	func(t store.TableNames) string { return string(t.CommodityScanAudits()) },
 
-	// NB: group_memberships is intentionally NOT here — it is keyed solely by
-	// member_user_id (no plain user_id column), so it can't ride the
+	// Registration approval request (decided or not).
+	func(t store.TableNames) string { return string(t.RegistrationRequests()) },
+
+	// Stored Idempotency-Key responses.
+	func(t store.TableNames) string { return string(t.IdempotencyKeys()) },
+
+	// NB: group_memberships (and group_member_location_scopes) are
+	// intentionally NOT here — they are keyed solely by
+	// member_user_id (no plain user_id column), so they can't ride the
 	// user_id template. It is handled by its own DELETE in PurgeUserDependents.
 
 	// Per-user/per-group notification overrides.
@@ -148,20 +158,26 @@ func (r *UserPurger) PurgeUserDependents(ctx context.Context, tenantID, userID s
 			}
 		}
 
-		// Group memberships are keyed SOLELY by member_user_id (there is no
-		// plain user_id column on this table), so this single DELETE removes
-		// every membership row that names the user as the member. It lives here
-		// rather than in the user_id loop above for exactly that reason.
-		memberships := string(r.tableNames.GroupMemberships())
-		memberQuery := fmt.Sprintf(
-			"DELETE FROM %s WHERE tenant_id = $1 AND member_user_id = $2", memberships,
-		)
-		if _, err := tx.ExecContext(ctx, memberQuery, tenantID, userID); err != nil {
-			return errxtrace.Wrap(
-				"failed to purge user memberships by member_user_id",
-				err,
-				errx.Attrs("table", memberships, "tenant_id", tenantID, "user_id", userID),
+		// Group memberships and the location scopes that qualify them are
+		// keyed SOLELY by member_user_id (there is no plain user_id column on
+		// either table), so these DELETEs remove every row that names the user
+		// as the member. They live here rather than in the user_id loop above
+		// for exactly that reason; scopes go first as they describe a
+		// membership.
+		for _, table := range []string{
+			string(r.tableNames.MemberLocationScopes()),
+			string(r.tableNames.GroupMemberships()),
+		} {
+			memberQuery := fmt.Sprintf(
+				"DELETE FROM %s WHERE tenant_id = $1 AND member_user_id = $2", table,
 			)
+			if _, err := tx.ExecContext(ctx, memberQuery, tenantID, userID); err != nil {
+				return errxtrace.Wrap(
+					"failed to purge user memberships by member_user_id",
+					err,
+					errx.Attrs("table", table, "tenant_id", tenantID, "user_id", userID),
+				)
+			}
 		}
 
 		// group_invites_audit immortalises who created and who used each invite.
@@ -176,6 +192,19 @@ func (r *UserPurger) PurgeUserDependents(ctx context.Context, tenantID, userID s
 			return err
 		}
 
+		// Saved views are keyed by created_by_user_id (no user_id column).
+		views := string(r.tableNames.SavedViews())
+		viewQuery := fmt.Sprintf(
+			"DELETE FROM %s WHERE tenant_id = $1 AND created_by_user_id = $2", views,
+		)
+		if _, err := tx.ExecContext(ctx, viewQuery, tenantID, userID); err != nil {
+			return errxtrace.Wrap(
+				"failed to purge user saved views",
+				err,
+				errx.Attrs("table", views, "tenant_id", tenantID, "user_id", userID),
+			)
+		}
+
 		// system_admin_grants is NON-RLS and carries NO tenant_id column, so it
 		// can't ride the tenant_id template. Two FKs point at users(id):
 		//   user_id    -> ON DELETE CASCADE (the granted user) — DELETE the row.
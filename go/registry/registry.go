@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
 	"github.com/jellydator/validation"
 	"github.com/shopspring/decimal"
 
@@ -156,6 +158,10 @@ type CommodityListOptions struct {
 	// Search runs a case-insensitive substring match against the Name
 	// and ShortName fields. Empty = no search.
 	Search string
+	// Tags restricts the result to commodities carrying at least one of
+	// the listed tag slugs (OR-ed like the other slice filters). Empty =
+	// unrestricted.
+	Tags []string
 	// IncludeInactive controls whether non-`in_use` commodities AND
 	// drafts are included. The list page hides them by default; when
 	// the user toggles "Show inactive" the FE sends true. This is
@@ -221,6 +227,14 @@ type CommodityEventRegistry interface {
 	// ListByCommodity returns paginated events for the given commodity,
 	// newest first. Total reflects the filtered count (post-Kinds, pre-LIMIT).
 	ListByCommodity(ctx context.Context, commodityID string, offset, limit int, opts CommodityEventListOptions) ([]*models.CommodityEvent, int, error)
+
+	// ReassignCommodity moves every event of fromCommodityID onto
+	// toCommodityID and returns the number of rows moved. This is the only
+	// sanctioned mutation of existing rows: the commodity merge flow uses
+	// it so the surviving commodity keeps the duplicate's history instead
+	// of losing it to the ON DELETE CASCADE. Kind / payload / occurred_at
+	// are left untouched.
+	ReassignCommodity(ctx context.Context, fromCommodityID, toCommodityID string) (int, error)
 }
 
 // restoreAcquisitionCtxKey keys a trusted, restore-only acquisition pair on a
@@ -258,6 +272,48 @@ func RestoreAcquisitionFromContext(ctx context.Context) (price decimal.Decimal,
 	return v.price, v.currency, true
 }
 
+// expectedVersionCtxKey keys the version a conditional delete expects (see
+// WithExpectedVersion).
+type expectedVersionCtxKey struct{}
+
+type expectedVersion struct {
+	id      string
+	version int
+}
+
+// WithExpectedVersion makes the Delete of the models.Versioned entity id
+// conditional: the registry deletes the row only while it still carries
+// version, checked under the same lock as the delete, and fails with
+// ErrVersionConflict otherwise. It is what an If-Match DELETE runs under.
+//
+// Like WithRestoreAcquisition it is a context signal rather than a registry
+// method, so the multi-registry delete flows in services (recursive and
+// unlink deletes, file + blob deletes) honour it without a parallel set of
+// signatures. It is keyed by id: deletes of the entity's children on the
+// same context are unaffected. A zero version sets no expectation.
+func WithExpectedVersion(ctx context.Context, id string, version int) context.Context {
+	if version == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, expectedVersionCtxKey{}, expectedVersion{id: id, version: version})
+}
+
+// CheckExpectedVersion returns ErrVersionConflict when ctx carries a
+// WithExpectedVersion expectation for id that entity no longer meets. The
+// registries call it inside Delete; the services call it up front so a
+// stale delete is refused before any child is touched.
+func CheckExpectedVersion(ctx context.Context, id string, entity models.Versioned) error {
+	v, ok := ctx.Value(expectedVersionCtxKey{}).(expectedVersion)
+	if !ok || v.id != id {
+		return nil
+	}
+	if current := entity.GetVersion(); current != v.version {
+		return errxtrace.Classify(ErrVersionConflict,
+			errx.Attrs("id", id, "expected_version", v.version, "current_version", current))
+	}
+	return nil
+}
+
 type CommodityRegistry interface {
 	Registry[models.Commodity]
 
@@ -288,15 +344,51 @@ type CommodityRegistry interface {
 	// follow-up (#1451) is expected to reuse the same primitive.
 	GetMany(ctx context.Context, ids []string) ([]*models.Commodity, error)
 
+	// FindSimilar returns the commodities that look like possible duplicates
+	// of commodityID: name / short-name trigram similarity at or above
+	// threshold (0..1), a shared serial or part number, or the same
+	// purchase date and original price. The reference commodity itself is
+	// never returned. Results are ordered by SortSimilarCommodities.
+	// Returns ErrNotFound when commodityID is not visible to the caller.
+	FindSimilar(ctx context.Context, commodityID string, threshold float64) ([]SimilarCommodity, error)
+
+	// FindDuplicates is the group-wide counterpart of FindSimilar: every
+	// unordered pair of visible commodities that FindSimilar would match,
+	// ordered by SortDuplicatePairs and capped at limit (limit <= 0 means
+	// no cap).
+	FindDuplicates(ctx context.Context, threshold float64, limit int) ([]DuplicatePair, error)
+
+	// AggregateStats computes the dashboard counts and values for every
+	// CommodityStatsDimension in one pass. Drafts are excluded and only
+	// in-use commodities carry value — the same rules valuation.Valuator
+	// applies — so the totals here agree with GET /commodities/values.
+	AggregateStats(ctx context.Context, opts CommodityStatsOptions) (*CommodityStats, error)
+
+	// AggregateByArea returns one AggregationResult per area holding
+	// non-draft commodities: GroupBy["area_id"], the row count, and the
+	// Sum / Avg of the in-use valuation in groupCurrency under the "value"
+	// key. Unassigned commodities are skipped.
+	AggregateByArea(ctx context.Context, groupCurrency string) ([]AggregationResult, error)
+
+	// CountByStatus counts non-draft commodities per status.
+	CountByStatus(ctx context.Context) (map[string]int, error)
+
+	// CountByType counts non-draft commodities per type.
+	CountByType(ctx context.Context) (map[string]int, error)
+
+	// FindByPriceRange returns the non-draft commodities whose original
+	// price lies in [minPrice, maxPrice], ordered by price then id. A
+	// non-empty currency restricts the match to that original currency.
+	FindByPriceRange(ctx context.Context, minPrice, maxPrice decimal.Decimal, currency string) ([]*models.Commodity, error)
+
+	// FindByDateRange returns the non-draft commodities purchased between
+	// startDate and endDate (YYYY-MM-DD, both inclusive), ordered by
+	// purchase date then id. Malformed dates yield ErrInvalidInput.
+	FindByDateRange(ctx context.Context, startDate, endDate string) ([]*models.Commodity, error)
+
 	// Enhanced search methods
 	// SearchByTags(ctx context.Context, tags []string, operator TagOperator) ([]*models.Commodity, error)
 	// FullTextSearch(ctx context.Context, query string, options ...SearchOption) ([]*models.Commodity, error)
-	// FindSimilar(ctx context.Context, commodityID string, threshold float64) ([]*models.Commodity, error)
-	// AggregateByArea(ctx context.Context, groupBy []string) ([]AggregationResult, error)
-	// CountByStatus(ctx context.Context) (map[string]int, error)
-	// CountByType(ctx context.Context) (map[string]int, error)
-	// FindByPriceRange(ctx context.Context, minPrice, maxPrice float64, currency string) ([]*models.Commodity, error)
-	// FindByDateRange(ctx context.Context, startDate, endDate string) ([]*models.Commodity, error)
 	// FindBySerialNumbers(ctx context.Context, serialNumbers []string) ([]*models.Commodity, error)
 }
 
@@ -343,6 +435,12 @@ type ExportRegistry interface {
 
 	// HardDelete permanently deletes an export from the database
 	HardDelete(ctx context.Context, id string) error
+
+	// ListPendingReplication returns up to limit completed, non-deleted,
+	// locally generated exports that have not been replicated off-site yet
+	// and have failed fewer than maxAttempts times, oldest first. Used by
+	// the replication worker with a service registry.
+	ListPendingReplication(ctx context.Context, maxAttempts, limit int) ([]*models.Export, error)
 }
 
 type FileRegistry interface {
@@ -766,6 +864,10 @@ type CommodityLoanRegistry interface {
 	// (a loan due today gets one reminder); once tomorrow rolls around
 	// and the row is still open, it transitions to the overdue kind and
 	// gets a second reminder against the separate flag.
+	//
+	// The borrower kinds use the same windows against the
+	// borrower_reminder_sent_* flags and additionally require a
+	// non-empty borrower_email.
 	ListPendingReminders(ctx context.Context, kind LoanReminderKind, now time.Time, dueSoonDays int) ([]*models.CommodityLoan, error)
 
 	// MarkReminderSent flips the matching reminder_sent_* boolean from
@@ -776,6 +878,13 @@ type CommodityLoanRegistry interface {
 	// already flipped (another worker beat us) OR the loan disappeared.
 	// Used by the worker right after a successful email enqueue.
 	MarkReminderSent(ctx context.Context, loanID string, kind LoanReminderKind) (bool, error)
+
+	// RecordPendingRequest stores a borrower's return / extension
+	// request on an OPEN loan, replacing any unreviewed one. Service-mode
+	// only: the borrower reaches it through a signed link with no session
+	// and therefore no user or group context. Returns (false, nil) when
+	// the loan has been returned or has disappeared.
+	RecordPendingRequest(ctx context.Context, loanID string, kind models.LoanRequestKind, dueBackAt models.PDate, note string, at time.Time) (bool, error)
 }
 
 // LoanReminderKind narrows the kind of reminder the worker emits.
@@ -790,17 +899,36 @@ const (
 	// LoanReminderKindDueSoon selects loans whose due_back_at is between
 	// today and today + N days (inclusive).
 	LoanReminderKindDueSoon LoanReminderKind = "due_soon"
+	// LoanReminderKindBorrowerOverdue is the courtesy counterpart of
+	// LoanReminderKindOverdue addressed to the borrower's email.
+	LoanReminderKindBorrowerOverdue LoanReminderKind = "borrower_overdue"
+	// LoanReminderKindBorrowerDueSoon is the courtesy counterpart of
+	// LoanReminderKindDueSoon addressed to the borrower's email.
+	LoanReminderKindBorrowerDueSoon LoanReminderKind = "borrower_due_soon"
 )
 
 // IsValid reports whether the kind is one of the known values.
 func (k LoanReminderKind) IsValid() bool {
 	switch k {
-	case LoanReminderKindOverdue, LoanReminderKindDueSoon:
+	case LoanReminderKindOverdue, LoanReminderKindDueSoon,
+		LoanReminderKindBorrowerOverdue, LoanReminderKindBorrowerDueSoon:
 		return true
 	}
 	return false
 }
 
+// IsBorrower reports whether the kind addresses the borrower rather
+// than the lender.
+func (k LoanReminderKind) IsBorrower() bool {
+	return k == LoanReminderKindBorrowerOverdue || k == LoanReminderKindBorrowerDueSoon
+}
+
+// IsOverdue reports whether the kind selects loans past their due date
+// (lender or borrower flavour).
+func (k LoanReminderKind) IsOverdue() bool {
+	return k == LoanReminderKindOverdue || k == LoanReminderKindBorrowerOverdue
+}
+
 // ServiceState filters CommodityServiceRegistry.ListPaginated. Mirrors
 // LoanState — same conventions ("all" sentinel, empty string treated as
 // "all" by callers, IsValid returns false on "" so handlers fall back
@@ -866,6 +994,42 @@ type CommodityServiceRegistry interface {
 	// per-id count of open service rows. Drives the "in service" list
 	// badge.
 	CountOpenByCommodity(ctx context.Context, commodityIDs []string) (map[string]int, error)
+
+	// ListPendingReminders returns OPEN service rows whose
+	// expected_return_at falls into the requested ServiceReminderKind
+	// window AND whose matching idempotency flag is still false. Used by
+	// the service reminder worker in service-mode across every group.
+	// Same semantics as CommodityLoanRegistry.ListPendingReminders with
+	// expected_return_at standing in for due_back_at.
+	ListPendingReminders(ctx context.Context, kind ServiceReminderKind, now time.Time, dueSoonDays int) ([]*models.CommodityService, error)
+
+	// MarkReminderSent flips the matching reminder_sent_* boolean from
+	// false to true atomically. Returns (false, nil) when the row was
+	// already flipped or has disappeared. See
+	// CommodityLoanRegistry.MarkReminderSent.
+	MarkReminderSent(ctx context.Context, serviceID string, kind ServiceReminderKind) (bool, error)
+}
+
+// ServiceReminderKind narrows the kind of reminder the service reminder
+// worker emits. Mirrors LoanReminderKind.
+type ServiceReminderKind string
+
+const (
+	// ServiceReminderKindOverdue selects service rows whose
+	// expected_return_at is in the past.
+	ServiceReminderKindOverdue ServiceReminderKind = "overdue"
+	// ServiceReminderKindDueSoon selects service rows whose
+	// expected_return_at is between today and today + N days (inclusive).
+	ServiceReminderKindDueSoon ServiceReminderKind = "due_soon"
+)
+
+// IsValid reports whether the kind is one of the known values.
+func (k ServiceReminderKind) IsValid() bool {
+	switch k {
+	case ServiceReminderKindOverdue, ServiceReminderKindDueSoon:
+		return true
+	}
+	return false
 }
 
 // SupplyLinkRegistry is the group-scoped registry of commodity_supply_links
@@ -932,6 +1096,73 @@ type MaintenanceScheduleRegistry interface {
 	CountByCommodity(ctx context.Context, commodityIDs []string) (map[string]int, error)
 }
 
+// MaintenanceLogRegistry is the group-scoped registry of
+// maintenance_logs — the completion history of maintenance schedules.
+type MaintenanceLogRegistry interface {
+	Registry[models.MaintenanceLog]
+
+	// ListBySchedule returns the completions of one schedule, newest
+	// first (done_at descending, created_at as tiebreaker).
+	ListBySchedule(ctx context.Context, scheduleID string) ([]*models.MaintenanceLog, error)
+}
+
+// CommodityMeterReadingRegistry is the group-scoped registry of
+// commodity_meter_readings.
+type CommodityMeterReadingRegistry interface {
+	Registry[models.CommodityMeterReading]
+
+	// ListByCommodity returns the readings of one commodity ordered by
+	// read_at ascending (value as tiebreaker). An empty unit returns
+	// every unit; otherwise only readings in that unit.
+	ListByCommodity(ctx context.Context, commodityID string, unit models.MeterUnit) ([]*models.CommodityMeterReading, error)
+}
+
+// InvoiceExtractionRegistry is the group-scoped registry of
+// invoice_extractions — the AI-read invoice proposals and their review
+// state.
+type InvoiceExtractionRegistry interface {
+	Registry[models.InvoiceExtraction]
+
+	// GetByFileID returns the extraction of one file, or ErrNotFound
+	// when the file has not been read yet.
+	GetByFileID(ctx context.Context, fileID string) (*models.InvoiceExtraction, error)
+
+	// ListByStatus returns the extractions in the given status, oldest
+	// first. An empty status returns every extraction.
+	ListByStatus(ctx context.Context, status models.InvoiceExtractionStatus) ([]*models.InvoiceExtraction, error)
+
+	// ListByCommodity returns the extractions that target one commodity,
+	// oldest first.
+	ListByCommodity(ctx context.Context, commodityID string) ([]*models.InvoiceExtraction, error)
+}
+
+// SavedViewRegistry is the group-scoped registry of saved commodity list
+// views. User-mode registries only see the group's shared views plus the
+// caller's own private ones — Get / Update / Delete on another member's
+// private view return ErrNotFound, the same as a row in another group.
+// Service-mode registries see every row.
+type SavedViewRegistry interface {
+	Registry[models.SavedView]
+}
+
+// BackupScheduleRegistry is the group-scoped registry of
+// backup_schedules. A group has at most one schedule: Create returns
+// ErrAlreadyExists when the group already has one.
+type BackupScheduleRegistry interface {
+	Registry[models.BackupSchedule]
+
+	// GetForGroup returns the current group's schedule, or ErrNotFound.
+	GetForGroup(ctx context.Context) (*models.BackupSchedule, error)
+
+	// ListPending returns, across all groups, the schedules the backup
+	// scheduler has to act on at now: enabled schedules whose next_run_at
+	// has passed, plus any schedule whose last export is not settled yet
+	// (even if it has since been disabled, so a failure is still
+	// reported). Ordered by next_run_at. Intended for service-mode
+	// registries.
+	ListPending(ctx context.Context, now time.Time) ([]*models.BackupSchedule, error)
+}
+
 // MaintenanceReminderRegistry is the worker-only registry that records
 // "reminder X for schedule Y at threshold Z has been emitted" rows.
 // The (schedule_id, threshold_days) tuple is unique — Create returns
@@ -1410,6 +1641,117 @@ type CommodityScanAuditRegistry interface {
 	// retention worker (future) — not yet wired but the entry point
 	// belongs to this registry, not the worker.
 	DeleteOlderThan(ctx context.Context, cutoff time.Time) error
+
+	// SumCostSince returns the summed CostUSD of the tenant's rows created
+	// at or after since. A non-empty groupID narrows the sum to that
+	// group. Backs the monthly budget check in CommodityScanService.
+	SumCostSince(ctx context.Context, tenantID, groupID string, since time.Time) (decimal.Decimal, error)
+
+	// Usage aggregates the provider calls (`ok` / `error` / `timeout`
+	// rows) created in [since, until) per tenant, group, provider and
+	// model, ordered by tenant, group, provider, model. Backs the admin
+	// usage report.
+	Usage(ctx context.Context, since, until time.Time) ([]models.CommodityScanUsage, error)
+}
+
+// CommodityScanBudgetRegistry stores the monthly AI vision spend caps per
+// tenant and per location group. Budgets are keyed by (tenant, group);
+// an empty group is the tenant-wide budget.
+//
+// Like WorkerControlRegistry it has NO RLS and lives directly on
+// FactorySet: budgets are written from the back office only.
+type CommodityScanBudgetRegistry interface {
+	// List returns every budget ordered by (tenant_id, group_id).
+	List(ctx context.Context) ([]*models.CommodityScanBudget, error)
+
+	// ListForTenant returns the tenant's budgets (tenant-wide first).
+	ListForTenant(ctx context.Context, tenantID string) ([]*models.CommodityScanBudget, error)
+
+	// Set creates or replaces the budget for (tenantID, groupID). ID,
+	// UUID and UpdatedAt are assigned by the registry. Returns the stored
+	// row.
+	Set(ctx context.Context, budget models.CommodityScanBudget) (*models.CommodityScanBudget, error)
+
+	// Delete removes the budget for (tenantID, groupID). Returns
+	// ErrNotFound when there is none.
+	Delete(ctx context.Context, tenantID, groupID string) error
+}
+
+// RegistrationRequestFilter narrows RegistrationRequestRegistry.List. Empty
+// fields match everything.
+type RegistrationRequestFilter struct {
+	TenantID string
+	Status   models.RegistrationRequestStatus
+}
+
+// RegistrationRequestRegistry stores the approval queue of
+// RegistrationModeApproval tenants: one row per registered user, pending
+// until an operator decides it.
+//
+// Like CommodityScanBudgetRegistry it has NO RLS and lives directly on
+// FactorySet: the queue is read and decided from the back office and the
+// CLI, across tenants.
+type RegistrationRequestRegistry interface {
+	// Create stores a new pending request. ID, UUID, Status and
+	// CreatedAt are assigned by the registry. Returns ErrAlreadyExists
+	// when the user already has a request.
+	Create(ctx context.Context, req models.RegistrationRequest) (*models.RegistrationRequest, error)
+
+	// Get returns the request with the given id, or ErrNotFound.
+	Get(ctx context.Context, id string) (*models.RegistrationRequest, error)
+
+	// GetByUserID returns the user's request, or ErrNotFound.
+	GetByUserID(ctx context.Context, userID string) (*models.RegistrationRequest, error)
+
+	// List returns the requests matching filter, oldest first.
+	List(ctx context.Context, filter RegistrationRequestFilter) ([]*models.RegistrationRequest, error)
+
+	// Decide moves a pending request to status (approved or rejected),
+	// stamping reason, decidedBy and the decision time. Returns
+	// ErrNotFound for an unknown id and ErrRegistrationAlreadyDecided
+	// when the request is no longer pending, so two operators deciding
+	// the same request can't both win.
+	Decide(ctx context.Context, id string, status models.RegistrationRequestStatus, reason, decidedBy *string) (*models.RegistrationRequest, error)
+
+	// DeleteByUserID removes the user's request, if any. Used by the
+	// user purger.
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+// IdempotencyKeyRegistry stores Idempotency-Key records: one per user and
+// key, holding the first response for replay.
+//
+// Like RegistrationRequestRegistry it has NO RLS and lives directly on
+// FactorySet. Expired records are treated as absent everywhere; the
+// cleanup worker deletes them.
+type IdempotencyKeyRegistry interface {
+	// Reserve claims rec.UserID/rec.Key for a new request. When no live
+	// record exists (none at all, or only an expired one) it stores rec as
+	// an in-flight reservation and returns (stored, true). Otherwise it
+	// returns the live record — in flight or completed — and false, so two
+	// concurrent requests with the same key can't both run.
+	Reserve(ctx context.Context, rec models.IdempotencyKey) (*models.IdempotencyKey, bool, error)
+
+	// Complete stores the response on the reservation with the given id
+	// and moves its expiry to expiresAt. Returns ErrNotFound when the
+	// reservation is gone.
+	Complete(ctx context.Context, id string, statusCode int, contentType string, body []byte, expiresAt time.Time) error
+
+	// Release deletes the reservation with the given id so a retry runs
+	// the request again. A missing record is not an error.
+	Release(ctx context.Context, id string) error
+
+	// DeleteByUserID removes all records of the user. Used by the user
+	// purger.
+	DeleteByUserID(ctx context.Context, userID string) error
+
+	// DeleteByTenantID removes all records of the tenant. Used by the
+	// tenant purger.
+	DeleteByTenantID(ctx context.Context, tenantID string) error
+
+	// DeleteExpired removes all records whose expiry time has passed and
+	// returns how many were deleted.
+	DeleteExpired(ctx context.Context) (int, error)
 }
 
 // PasswordResetRegistry manages password-reset tokens.
@@ -1819,6 +2161,36 @@ type GroupNotificationPrefRegistry interface {
 	DeleteByGroup(ctx context.Context, tenantID, groupID string) (int, error)
 }
 
+// GroupMemberLocationScopeRegistry stores the per-location role rows
+// that restrict a group member to specific locations. A member without
+// rows sees the whole group at their membership role.
+//
+// Like GroupNotificationPrefRegistry it runs in service mode under the
+// tenant-isolation policy; member filtering happens in the service layer.
+type GroupMemberLocationScopeRegistry interface {
+	// ListByMember returns the member's scope rows in one group, ordered
+	// by location_id. An empty result means the member is unrestricted.
+	ListByMember(ctx context.Context, tenantID, groupID, userID string) ([]*models.GroupMemberLocationScope, error)
+
+	// ListByGroup returns every scope row of the group, ordered by
+	// member_user_id and location_id.
+	ListByGroup(ctx context.Context, tenantID, groupID string) ([]*models.GroupMemberLocationScope, error)
+
+	// ReplaceForMember swaps the member's rows in one group for scopes in
+	// a single transaction and returns the stored rows. Tenant, group and
+	// member fields on the input are overwritten. An empty scopes slice
+	// lifts the restriction.
+	ReplaceForMember(ctx context.Context, tenantID, groupID, userID string, scopes []models.GroupMemberLocationScope) ([]*models.GroupMemberLocationScope, error)
+
+	// DeleteByGroup removes every scope row of the group. Used by the
+	// group purge. Returns the number of rows deleted.
+	DeleteByGroup(ctx context.Context, tenantID, groupID string) (int, error)
+
+	// DeleteByUser removes the user's scope rows across all groups. Used
+	// by the user purger.
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
 // GroupInviteRegistry manages invite links for location groups.
 type GroupInviteRegistry interface {
 	Registry[models.GroupInvite]
@@ -1988,6 +2360,36 @@ type WorkerControlRegistry interface {
 	Resume(ctx context.Context, workerType string) (*models.WorkerControl, error)
 }
 
+// TrustedBackupKeyRegistry stores the backup-signing keyring: the Ed25519
+// public keys whose `.inb` signatures import and restore accept alongside
+// the server's own key. Keys are addressed by fingerprint and never deleted;
+// Revoke keeps the row so the keyring doubles as a record of every key that
+// was ever trusted.
+//
+// Like WorkerControlRegistry it is NOT tenant-scoped, has NO RLS, and lives
+// directly on FactorySet.
+type TrustedBackupKeyRegistry interface {
+	// List returns every key, revoked and expired ones included, ordered by
+	// (added_at ASC, fingerprint ASC).
+	List(ctx context.Context) ([]*models.TrustedBackupKey, error)
+
+	// GetByFingerprint returns the key with the given fingerprint, or
+	// ErrNotFound.
+	GetByFingerprint(ctx context.Context, fingerprint string) (*models.TrustedBackupKey, error)
+
+	// Add stores a new key. Fingerprint, PublicKey and Label are required;
+	// ID, UUID and AddedAt are assigned by the registry. A key whose
+	// fingerprint is already in the keyring — even a revoked one — is
+	// rejected with ErrAlreadyExists: a revoked key stays revoked.
+	Add(ctx context.Context, key models.TrustedBackupKey) (*models.TrustedBackupKey, error)
+
+	// Revoke marks the key revoked. revokedBy and reason may be "" (stored
+	// as NULL). Revoking an already-revoked key is a no-op that returns the
+	// row unchanged, keeping the original revocation record. Returns
+	// ErrNotFound for an unknown fingerprint.
+	Revoke(ctx context.Context, fingerprint, revokedBy, reason string) (*models.TrustedBackupKey, error)
+}
+
 // AdminUserSortField names the columns the admin user listing endpoint
 // understands for sorting. Names are part of the public API surface; the
 // FE codegen treats them as opaque strings sent in `?sort`.
@@ -2079,6 +2481,22 @@ type RefreshTokenRegistry interface {
 	DeleteExpired(ctx context.Context) error
 }
 
+// CalendarFeedRegistry persists users' iCalendar subscription feeds.
+// Like RefreshTokenRegistry it runs in service mode: the public feed
+// endpoint resolves a token before any user or tenant context exists,
+// so ownership is enforced by the explicit userID arguments instead of
+// RLS.
+type CalendarFeedRegistry interface {
+	Registry[models.CalendarFeed]
+
+	// GetByTokenHash returns the feed whose token hashes to tokenHash,
+	// or ErrNotFound.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error)
+
+	// ListByUserID returns the user's feeds, oldest first.
+	ListByUserID(ctx context.Context, userID string) ([]*models.CalendarFeed, error)
+}
+
 // LoginEventRegistry stores the append-only login_events audit trail
 // (issue #1379). The registry runs under the background-worker role so
 // the unauthenticated login flow (where no tenant context is set in the
@@ -2295,6 +2713,64 @@ type TenantPurger interface {
 	PurgeTenantDependents(ctx context.Context, tenantID string) error
 }
 
+// GroupTransfer is one resolved move of inventory from one LocationGroup to
+// another inside the same tenant. services.GroupTransferService builds it:
+// every id has already been checked against the source group, every target
+// reference (area, location) against the target group, and every commodity
+// carries its target-group prices.
+type GroupTransfer struct {
+	TenantID    string
+	FromGroupID string
+	ToGroupID   string
+
+	// LocationIDs move as they are.
+	LocationIDs []string
+	// Areas move under the location named on each entry. An area of a
+	// moved location keeps its own location id.
+	Areas []GroupTransferArea
+	// Commodities move with their children.
+	Commodities []GroupTransferCommodity
+}
+
+// GroupTransferArea is one area of a GroupTransfer and the target-group
+// location it ends up under.
+type GroupTransferArea struct {
+	ID         string
+	LocationID string
+}
+
+// GroupTransferCommodity is one commodity of a GroupTransfer. Commodity is
+// the row as it must read in the target group: AreaID and the four price
+// fields are written, everything else is left alone. When FillAcquisition
+// is set the write-once acquisition pair is captured from
+// AcquisitionPrice / AcquisitionCurrency (see currency.ApplyResult).
+type GroupTransferCommodity struct {
+	Commodity           models.Commodity
+	FillAcquisition     bool
+	AcquisitionPrice    decimal.Decimal
+	AcquisitionCurrency models.Currency
+}
+
+// GroupTransferrer re-homes locations, areas and commodities to another
+// LocationGroup of the same tenant. Alongside the rows themselves it moves
+// what hangs off them: files linked to any moved location, area or
+// commodity, and the commodities' events, loans, service records, supply
+// links, maintenance schedules (with their logs and reminders), meter
+// readings, warranty reminders and invoice extractions.
+//
+// Like GroupPurger it is a separate abstraction from per-registry CRUD:
+// rows change group, which no group-scoped registry allows, and the move
+// has to land in one background-worker transaction so a failure never
+// splits a commodity from its children across two groups. Tags are NOT
+// created here — commodities and files reference tags by slug, so the
+// caller makes sure the target group has them first.
+type GroupTransferrer interface {
+	// TransferToGroup applies t. Every listed row must still belong to
+	// (t.TenantID, t.FromGroupID); ErrNotFound is returned and nothing
+	// moves otherwise.
+	TransferToGroup(ctx context.Context, t GroupTransfer) error
+}
+
 // UserPurger hard-deletes a single user's auth / identity rows (and orphans the
 // nullable authorship back-refs) before the users row itself is dropped by the
 // orchestration layer (#2116). It exists because a bare DELETE FROM users is
@@ -2359,6 +2835,11 @@ type Set struct {
 	CommodityServiceRegistry       CommodityServiceRegistry
 	SupplyLinkRegistry             SupplyLinkRegistry
 	MaintenanceScheduleRegistry    MaintenanceScheduleRegistry
+	MaintenanceLogRegistry         MaintenanceLogRegistry
+	CommodityMeterReadingRegistry  CommodityMeterReadingRegistry
+	InvoiceExtractionRegistry      InvoiceExtractionRegistry
+	SavedViewRegistry              SavedViewRegistry
+	BackupScheduleRegistry         BackupScheduleRegistry
 	ThumbnailGenerationJobRegistry ThumbnailGenerationJobRegistry
 	UserConcurrencySlotRegistry    UserConcurrencySlotRegistry
 	OperationSlotRegistry          OperationSlotRegistry
@@ -2450,6 +2931,11 @@ func (s *Set) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&s.CommodityServiceRegistry, validation.Required),
 		validation.Field(&s.SupplyLinkRegistry, validation.Required),
 		validation.Field(&s.MaintenanceScheduleRegistry, validation.Required),
+		validation.Field(&s.MaintenanceLogRegistry, validation.Required),
+		validation.Field(&s.CommodityMeterReadingRegistry, validation.Required),
+		validation.Field(&s.InvoiceExtractionRegistry, validation.Required),
+		validation.Field(&s.SavedViewRegistry, validation.Required),
+		validation.Field(&s.BackupScheduleRegistry, validation.Required),
 		validation.Field(&s.TenantRegistry, validation.Required),
 		validation.Field(&s.UserRegistry, validation.Required),
 		validation.Field(&s.CommodityScanAuditRegistry, validation.Required),
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"testing"
@@ -7,9 +7,10 @@ import (
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
 )
 
-func TestAreaRegistry_Create_HappyPath(t *testing.T) {
+func testAreaRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
 	testCases := []struct {
 		name string
 		area models.Area
@@ -40,7 +41,7 @@ func TestAreaRegistry_Create_HappyPath(t *testing.T) {
 				},
 			})
 
-			registrySet, cleanup := setupTestRegistrySet(t)
+			registrySet, cleanup := h.setupTestRegistrySet(t)
 			c.Cleanup(cleanup)
 
 			// Create a test location first
@@ -58,7 +59,7 @@ func TestAreaRegistry_Create_HappyPath(t *testing.T) {
 	}
 }
 
-func TestAreaRegistry_Create_UnhappyPath(t *testing.T) {
+func testAreaRegistry_Create_UnhappyPath(t *testing.T, h *testHarness) {
 	testCases := []struct {
 		name string
 		area models.Area
@@ -95,7 +96,7 @@ func TestAreaRegistry_Create_UnhappyPath(t *testing.T) {
 				},
 			})
 
-			registrySet, cleanup := setupTestRegistrySet(t)
+			registrySet, cleanup := h.setupTestRegistrySet(t)
 			defer cleanup()
 
 			// For the non-existent location test, we don't need to create a location
@@ -112,8 +113,8 @@ func TestAreaRegistry_Create_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestAreaRegistry_Get_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Get_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -137,8 +138,8 @@ func TestAreaRegistry_Get_HappyPath(t *testing.T) {
 	c.Assert(result.LocationID, qt.Equals, created.LocationID)
 }
 
-func TestAreaRegistry_Get_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Get_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -174,8 +175,8 @@ func TestAreaRegistry_Get_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestAreaRegistry_List_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_List_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -210,8 +211,8 @@ func TestAreaRegistry_List_HappyPath(t *testing.T) {
 	c.Assert(areaIDs[area2.ID], qt.IsTrue)
 }
 
-func TestAreaRegistry_Update_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Update_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -243,8 +244,74 @@ func TestAreaRegistry_Update_HappyPath(t *testing.T) {
 	c.Assert(retrieved.LocationID, qt.Equals, created.LocationID)
 }
 
-func TestAreaRegistry_Update_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Update_VersionConflict(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
+	defer cleanup()
+
+	c := qt.New(t)
+	ctx := appctx.WithUser(c.Context(), &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "test-user-id"},
+			TenantID: "test-tenant-id",
+		},
+	})
+
+	location := createTestLocation(c, registrySet)
+	created := createTestArea(c, registrySet, location.ID)
+	c.Assert(created.Version, qt.Equals, 1)
+
+	first := *created
+	first.Name = "First Writer"
+	result, err := registrySet.AreaRegistry.Update(ctx, first)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Version, qt.Equals, 2)
+
+	// The second writer still holds version 1.
+	second := *created
+	second.Name = "Second Writer"
+	_, err = registrySet.AreaRegistry.Update(ctx, second)
+	c.Assert(err, qt.ErrorIs, registry.ErrVersionConflict)
+
+	retrieved, err := registrySet.AreaRegistry.Get(ctx, created.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(retrieved.Name, qt.Equals, "First Writer")
+	c.Assert(retrieved.Version, qt.Equals, 2)
+}
+
+func testAreaRegistry_Delete_VersionConflict(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
+	defer cleanup()
+
+	c := qt.New(t)
+	ctx := appctx.WithUser(c.Context(), &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "test-user-id"},
+			TenantID: "test-tenant-id",
+		},
+	})
+
+	location := createTestLocation(c, registrySet)
+	created := createTestArea(c, registrySet, location.ID)
+
+	renamed := *created
+	renamed.Name = "Renamed"
+	_, err := registrySet.AreaRegistry.Update(ctx, renamed)
+	c.Assert(err, qt.IsNil)
+
+	// A delete still expecting version 1 matches no row.
+	err = registrySet.AreaRegistry.Delete(registry.WithExpectedVersion(ctx, created.ID, 1), created.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrVersionConflict)
+	_, err = registrySet.AreaRegistry.Get(ctx, created.ID)
+	c.Assert(err, qt.IsNil)
+
+	err = registrySet.AreaRegistry.Delete(registry.WithExpectedVersion(ctx, created.ID, 2), created.ID)
+	c.Assert(err, qt.IsNil)
+	_, err = registrySet.AreaRegistry.Get(ctx, created.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
+
+func testAreaRegistry_Update_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -280,8 +347,8 @@ func TestAreaRegistry_Update_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestAreaRegistry_Delete_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Delete_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -307,8 +374,8 @@ func TestAreaRegistry_Delete_HappyPath(t *testing.T) {
 	c.Assert(result, qt.IsNil)
 }
 
-func TestAreaRegistry_Delete_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Delete_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -343,8 +410,8 @@ func TestAreaRegistry_Delete_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestAreaRegistry_Delete_WithCommodities_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Delete_WithCommodities_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -374,8 +441,8 @@ func TestAreaRegistry_Delete_WithCommodities_UnhappyPath(t *testing.T) {
 	_ = commodity // commodity is created but we don't need to verify it here
 }
 
-func TestAreaRegistry_Count_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_Count_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -403,8 +470,8 @@ func TestAreaRegistry_Count_HappyPath(t *testing.T) {
 	c.Assert(count, qt.Equals, 2)
 }
 
-func TestAreaRegistry_GetCommodities_WithCreatedCommodity_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_GetCommodities_WithCreatedCommodity_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -429,8 +496,8 @@ func TestAreaRegistry_GetCommodities_WithCreatedCommodity_HappyPath(t *testing.T
 	c.Assert(commodities[0], qt.Equals, commodity.ID)
 }
 
-func TestAreaRegistry_GetCommodities_WithInvalidArea_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_GetCommodities_WithInvalidArea_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -464,8 +531,8 @@ func TestAreaRegistry_GetCommodities_WithInvalidArea_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestAreaRegistry_GetCommodities_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_GetCommodities_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -503,8 +570,8 @@ func TestAreaRegistry_GetCommodities_HappyPath(t *testing.T) {
 	c.Assert(commodityIDs[commodity2.ID], qt.IsTrue)
 }
 
-func TestAreaRegistry_GetCommodities_EmptyArea_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testAreaRegistry_GetCommodities_EmptyArea_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -11,8 +11,7 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// newTestBackofficeRefreshToken builds a valid refresh-token row for the
-// postgres backend, FK'd to the supplied backoffice_user_id. Mirrors the
+// newTestBackofficeRefreshToken builds a valid refresh-token row, FK'd to the supplied backoffice_user_id. Mirrors the
 // memory-side helper.
 func newTestBackofficeRefreshToken(backofficeUserID, tokenHash string) models.BackofficeRefreshToken {
 	return models.BackofficeRefreshToken{
@@ -28,10 +27,10 @@ func newTestBackofficeRefreshToken(backofficeUserID, tokenHash string) models.Ba
 // refresh-token rows can FK to. Returns the row id. Each call uses a
 // fresh email so concurrent table-driven cases never collide on the
 // platform-wide unique index.
-func createBackofficeUserForRefreshTest(t *testing.T, registrySet *registry.Set, email string) string {
+func createBackofficeUserForRefreshTest(t *testing.T, h *testHarness, email string) string {
 	t.Helper()
 	c := qt.New(t)
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 	user, err := bo.Create(context.Background(), newTestBackofficeUser(email))
 	c.Assert(err, qt.IsNil)
 	return user.ID
@@ -40,21 +39,16 @@ func createBackofficeUserForRefreshTest(t *testing.T, registrySet *registry.Set,
 // getBackofficeRefreshTokenRegistry mirrors getBackofficeRegistry — the
 // per-request *Set only carries user-aware data, and back-office refresh
 // tokens are cross-cutting infra, so we resolve via a fresh factory set.
-func getBackofficeRefreshTokenRegistry(t *testing.T, _ *registry.Set) registry.BackofficeRefreshTokenRegistry {
+func getBackofficeRefreshTokenRegistry(t *testing.T, h *testHarness) registry.BackofficeRefreshTokenRegistry {
 	t.Helper()
-	dsn := skipIfNoPostgreSQL(t)
-	c := qt.New(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	fs := createRegistrySetFromPool(pool)
-	return fs.BackofficeRefreshTokenRegistry
+	return h.factorySet(t).BackofficeRefreshTokenRegistry
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-create@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-create@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -70,10 +64,10 @@ func TestBackofficeRefreshTokenRegistryPostgres_Create_HappyPath(t *testing.T) {
 	c.Assert(created.IsValid(), qt.IsTrue)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_Create_MissingFields(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_Create_MissingFields(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
+	r := getBackofficeRefreshTokenRegistry(t, h)
 	ctx := context.Background()
 
 	cases := []struct {
@@ -94,11 +88,11 @@ func TestBackofficeRefreshTokenRegistryPostgres_Create_MissingFields(t *testing.
 	}
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_GetByHash_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_GetByHash_HappyPath(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-byhash@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-byhash@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -112,10 +106,10 @@ func TestBackofficeRefreshTokenRegistryPostgres_GetByHash_HappyPath(t *testing.T
 	c.Assert(got.BackofficeUserID, qt.Equals, userID)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_GetByHash_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_GetByHash_NotFound(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
+	r := getBackofficeRefreshTokenRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -125,11 +119,11 @@ func TestBackofficeRefreshTokenRegistryPostgres_GetByHash_NotFound(t *testing.T)
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_Revoke_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_Revoke_HappyPath(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-revoke@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-revoke@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -145,11 +139,11 @@ func TestBackofficeRefreshTokenRegistryPostgres_Revoke_HappyPath(t *testing.T) {
 	c.Assert(got.IsValid(), qt.IsFalse)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_Revoke_Idempotent(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_Revoke_Idempotent(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-revoke-idem@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-revoke-idem@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -161,12 +155,12 @@ func TestBackofficeRefreshTokenRegistryPostgres_Revoke_Idempotent(t *testing.T)
 	c.Assert(r.Revoke(ctx, userID, created.ID), qt.IsNil)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_Revoke_WrongUser(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_Revoke_WrongUser(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-revoke-wrong@example.com")
-	otherUserID := createBackofficeUserForRefreshTest(t, registrySet, "rt-revoke-wrong-other@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-revoke-wrong@example.com")
+	otherUserID := createBackofficeUserForRefreshTest(t, h, "rt-revoke-wrong-other@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -182,12 +176,12 @@ func TestBackofficeRefreshTokenRegistryPostgres_Revoke_WrongUser(t *testing.T) {
 	c.Assert(got.RevokedAt, qt.IsNil)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_ListActive(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_ListActive(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-list@example.com")
-	otherUserID := createBackofficeUserForRefreshTest(t, registrySet, "rt-list-other@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-list@example.com")
+	otherUserID := createBackofficeUserForRefreshTest(t, h, "rt-list-other@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -211,12 +205,12 @@ func TestBackofficeRefreshTokenRegistryPostgres_ListActive(t *testing.T) {
 	c.Assert(out[0].ID, qt.Equals, active.ID)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_RevokeByBackofficeUserID(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_RevokeByBackofficeUserID(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-revoke-by-user@example.com")
-	otherUserID := createBackofficeUserForRefreshTest(t, registrySet, "rt-revoke-by-user-other@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-revoke-by-user@example.com")
+	otherUserID := createBackofficeUserForRefreshTest(t, h, "rt-revoke-by-user-other@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -240,13 +234,13 @@ func TestBackofficeRefreshTokenRegistryPostgres_RevokeByBackofficeUserID(t *test
 	c.Assert(got.RevokedAt, qt.IsNil)
 }
 
-// TestBackofficeRefreshTokenRegistryPostgres_BumpLastUsedAt_HappyPath
+// testBackofficeRefreshTokenRegistry_BumpLastUsedAt_HappyPath
 // pins the narrow-mutation contract — only last_used_at is touched.
-func TestBackofficeRefreshTokenRegistryPostgres_BumpLastUsedAt_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_BumpLastUsedAt_HappyPath(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-bump@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-bump@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -266,15 +260,15 @@ func TestBackofficeRefreshTokenRegistryPostgres_BumpLastUsedAt_HappyPath(t *test
 	c.Assert(got.BackofficeUserID, qt.Equals, userID)
 }
 
-// TestBackofficeRefreshTokenRegistryPostgres_BumpLastUsedAt_WrongUser
+// testBackofficeRefreshTokenRegistry_BumpLastUsedAt_WrongUser
 // pins the per-user gate: a stolen id paired with the wrong
 // backoffice_user_id must 404 rather than rewrite the victim's row.
-func TestBackofficeRefreshTokenRegistryPostgres_BumpLastUsedAt_WrongUser(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_BumpLastUsedAt_WrongUser(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	victimID := createBackofficeUserForRefreshTest(t, registrySet, "rt-bump-victim@example.com")
-	attackerID := createBackofficeUserForRefreshTest(t, registrySet, "rt-bump-attacker@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	victimID := createBackofficeUserForRefreshTest(t, h, "rt-bump-victim@example.com")
+	attackerID := createBackofficeUserForRefreshTest(t, h, "rt-bump-attacker@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -291,11 +285,11 @@ func TestBackofficeRefreshTokenRegistryPostgres_BumpLastUsedAt_WrongUser(t *test
 	c.Assert(got.LastUsedAt, qt.IsNil)
 }
 
-func TestBackofficeRefreshTokenRegistryPostgres_DeleteExpired(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeRefreshTokenRegistry_DeleteExpired(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	r := getBackofficeRefreshTokenRegistry(t, registrySet)
-	userID := createBackofficeUserForRefreshTest(t, registrySet, "rt-delete-expired@example.com")
+	r := getBackofficeRefreshTokenRegistry(t, h)
+	userID := createBackofficeUserForRefreshTest(t, h, "rt-delete-expired@example.com")
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -11,8 +11,7 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// newTestBackofficeUser builds a valid BackofficeUser for the postgres
-// backend. backoffice_users has NO foreign keys — back-office identities
+// newTestBackofficeUser builds a valid BackofficeUser. backoffice_users has NO foreign keys — back-office identities
 // live OUTSIDE the tenant model — so the row stands alone without any
 // preceding tenant / user setup. The PasswordHash is a placeholder
 // string; bcrypt correctness lives in models.User's own tests.
@@ -27,10 +26,10 @@ func newTestBackofficeUser(email string) models.BackofficeUser {
 	}
 }
 
-func TestBackofficeUserRegistryPostgres_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -45,10 +44,10 @@ func TestBackofficeUserRegistryPostgres_Create_HappyPath(t *testing.T) {
 	c.Assert(created.CreatedAt.IsZero(), qt.IsFalse)
 }
 
-func TestBackofficeUserRegistryPostgres_Create_LowercasesEmail(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Create_LowercasesEmail(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -58,10 +57,10 @@ func TestBackofficeUserRegistryPostgres_Create_LowercasesEmail(t *testing.T) {
 	c.Assert(created.Email, qt.Equals, "ops@example.com")
 }
 
-func TestBackofficeUserRegistryPostgres_Create_MissingFields(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Create_MissingFields(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 	ctx := context.Background()
 
 	cases := []struct {
@@ -83,10 +82,10 @@ func TestBackofficeUserRegistryPostgres_Create_MissingFields(t *testing.T) {
 	}
 }
 
-func TestBackofficeUserRegistryPostgres_Create_InvalidRole(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Create_InvalidRole(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -97,15 +96,15 @@ func TestBackofficeUserRegistryPostgres_Create_InvalidRole(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrInvalidBackofficeRole)
 }
 
-// TestBackofficeUserRegistryPostgres_Create_RejectsMalformedEmail proves
-// the defence-in-depth model validation runs at the postgres registry
+// testBackofficeUserRegistry_Create_RejectsMalformedEmail proves
+// the defence-in-depth model validation runs at the SQL registry
 // layer — a string that passes the bespoke TrimSpace-required check
 // ("not-an-email") still gets rejected by BackofficeUser.ValidateWith-
 // Context's EmailPattern rule before the INSERT touches the database.
-func TestBackofficeUserRegistryPostgres_Create_RejectsMalformedEmail(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Create_RejectsMalformedEmail(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -116,10 +115,10 @@ func TestBackofficeUserRegistryPostgres_Create_RejectsMalformedEmail(t *testing.
 	c.Assert(err.Error(), qt.Contains, "model validation")
 }
 
-func TestBackofficeUserRegistryPostgres_Create_DuplicateEmail(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Create_DuplicateEmail(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -131,10 +130,10 @@ func TestBackofficeUserRegistryPostgres_Create_DuplicateEmail(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrBackofficeEmailAlreadyExists)
 }
 
-func TestBackofficeUserRegistryPostgres_Get(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Get(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -147,10 +146,10 @@ func TestBackofficeUserRegistryPostgres_Get(t *testing.T) {
 	c.Assert(fetched.ID, qt.Equals, created.ID)
 }
 
-func TestBackofficeUserRegistryPostgres_Get_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Get_NotFound(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -160,10 +159,10 @@ func TestBackofficeUserRegistryPostgres_Get_NotFound(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestBackofficeUserRegistryPostgres_GetByEmail_CaseInsensitive(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_GetByEmail_CaseInsensitive(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -176,10 +175,10 @@ func TestBackofficeUserRegistryPostgres_GetByEmail_CaseInsensitive(t *testing.T)
 	c.Assert(fetched.Email, qt.Equals, "case@example.com")
 }
 
-func TestBackofficeUserRegistryPostgres_GetByEmail_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_GetByEmail_NotFound(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -188,13 +187,13 @@ func TestBackofficeUserRegistryPostgres_GetByEmail_NotFound(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrBackofficeUserNotFound)
 }
 
-// TestBackofficeUserRegistryPostgres_GetByEmail_WhitespaceOnly pins the
+// testBackofficeUserRegistry_GetByEmail_WhitespaceOnly pins the
 // whitespace-rejection invariant — a stray "   " from the caller must
 // surface as ErrFieldRequired, not as a no-rows lookup.
-func TestBackofficeUserRegistryPostgres_GetByEmail_WhitespaceOnly(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_GetByEmail_WhitespaceOnly(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -203,10 +202,10 @@ func TestBackofficeUserRegistryPostgres_GetByEmail_WhitespaceOnly(t *testing.T)
 	c.Assert(err, qt.ErrorIs, registry.ErrFieldRequired)
 }
 
-func TestBackofficeUserRegistryPostgres_Update_PreservesPasswordHash(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Update_PreservesPasswordHash(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -230,10 +229,10 @@ func TestBackofficeUserRegistryPostgres_Update_PreservesPasswordHash(t *testing.
 	c.Assert(reloaded.Name, qt.Equals, "Renamed")
 }
 
-func TestBackofficeUserRegistryPostgres_Update_RejectsEmailCollision(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Update_RejectsEmailCollision(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -248,20 +247,20 @@ func TestBackofficeUserRegistryPostgres_Update_RejectsEmailCollision(t *testing.
 	c.Assert(err, qt.ErrorIs, registry.ErrBackofficeEmailAlreadyExists)
 }
 
-// TestBackofficeUserRegistryPostgres_Update_NotFoundOnDeletedRow pins
+// testBackofficeUserRegistry_Update_NotFoundOnDeletedRow pins
 // the defence-in-depth RowsAffected check: if the row is deleted
 // between Update's pre-SELECT and its UPDATE, the call must surface
 // ErrBackofficeUserNotFound rather than silently succeed. We simulate
 // the race by deleting the row immediately before Update (no need for
-// real concurrency — the postgres pre-SELECT inside Update's tx will
+// real concurrency — the SQL backends' pre-SELECT inside Update's tx will
 // see no row, which itself returns ErrBackofficeUserNotFound; this
 // test guards the secondary path where the row could vanish between
-// the pre-SELECT and the UPDATE if SELECT FOR UPDATE were ever added
-// or removed without updating the contract).
-func TestBackofficeUserRegistryPostgres_Update_NotFoundOnDeletedRow(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+// the pre-SELECT and the UPDATE if the row locking were ever changed
+// without updating the contract).
+func testBackofficeUserRegistry_Update_NotFoundOnDeletedRow(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -277,10 +276,10 @@ func TestBackofficeUserRegistryPostgres_Update_NotFoundOnDeletedRow(t *testing.T
 	c.Assert(err, qt.ErrorIs, registry.ErrBackofficeUserNotFound)
 }
 
-func TestBackofficeUserRegistryPostgres_Delete(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Delete(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -293,15 +292,15 @@ func TestBackofficeUserRegistryPostgres_Delete(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrBackofficeUserNotFound)
 }
 
-// TestBackofficeUserRegistryPostgres_Delete_Idempotent pins the cross-
+// testBackofficeUserRegistry_Delete_Idempotent pins the cross-
 // backend idempotency contract: Delete on a missing id is a no-op
-// rather than an error. The postgres backend's NonRLSRepository.Delete
+// rather than an error. The SQL backends' NonRLSRepository.Delete
 // returns store.ErrNotFound on a missing row; the registry swallows it
 // so the contract matches the memory backend.
-func TestBackofficeUserRegistryPostgres_Delete_Idempotent(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Delete_Idempotent(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -309,10 +308,10 @@ func TestBackofficeUserRegistryPostgres_Delete_Idempotent(t *testing.T) {
 	c.Assert(bo.Delete(ctx, "no-such-id"), qt.IsNil)
 }
 
-func TestBackofficeUserRegistryPostgres_Count(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_Count(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -331,7 +330,7 @@ func TestBackofficeUserRegistryPostgres_Count(t *testing.T) {
 	c.Assert(count, qt.Equals, 2)
 }
 
-// TestBackofficeUserRegistryPostgres_List_DistinctRowsAndOrder pins two
+// testBackofficeUserRegistry_List_DistinctRowsAndOrder pins two
 // invariants on the List query that earlier shapes got wrong:
 //
 //  1. Each returned pointer references a distinct row, not N copies of
@@ -339,17 +338,17 @@ func TestBackofficeUserRegistryPostgres_Count(t *testing.T) {
 //     iteration variable's address per yield, so appending `&user`
 //     produced a slice of aliased pointers.
 //  2. Rows come back ordered by created_at (then id as the tiebreaker).
-//     The previous shape relied on Postgres's un-ordered SELECT and
+//     The previous shape relied on an un-ordered SELECT and
 //     happened to look stable in tests, but the contract was not
 //     enforced.
 //
 // We insert three rows with explicit, monotonically-increasing
 // CreatedAt stamps and assert (a) three distinct IDs, (b) emails come
 // back in created_at order.
-func TestBackofficeUserRegistryPostgres_List_DistinctRowsAndOrder(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_List_DistinctRowsAndOrder(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -378,10 +377,10 @@ func TestBackofficeUserRegistryPostgres_List_DistinctRowsAndOrder(t *testing.T)
 	c.Assert(gotEmails, qt.DeepEquals, emails)
 }
 
-func TestBackofficeUserRegistryPostgres_SetPasswordHash(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_SetPasswordHash(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -395,10 +394,10 @@ func TestBackofficeUserRegistryPostgres_SetPasswordHash(t *testing.T) {
 	c.Assert(fetched.PasswordHash, qt.Equals, "$2a$10$newvalue")
 }
 
-func TestBackofficeUserRegistryPostgres_SetPasswordHash_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_SetPasswordHash_NotFound(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -407,10 +406,10 @@ func TestBackofficeUserRegistryPostgres_SetPasswordHash_NotFound(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrBackofficeUserNotFound)
 }
 
-func TestBackofficeUserRegistryPostgres_UpdateLastLogin(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_UpdateLastLogin(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -428,10 +427,10 @@ func TestBackofficeUserRegistryPostgres_UpdateLastLogin(t *testing.T) {
 	c.Assert(fetched.LastLoginAt.UTC().Truncate(time.Second).Equal(at), qt.IsTrue)
 }
 
-func TestBackofficeUserRegistryPostgres_SetActive(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testBackofficeUserRegistry_SetActive(t *testing.T, h *testHarness) {
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
-	bo := getBackofficeRegistry(t, registrySet)
+	bo := getBackofficeRegistry(t, h)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -454,12 +453,7 @@ func TestBackofficeUserRegistryPostgres_SetActive(t *testing.T) {
 // used and grab the registry off it. The rows we write here share the
 // database with the helper's test tenant rows but never overlap them
 // (no FK, separate table).
-func getBackofficeRegistry(t *testing.T, _ *registry.Set) registry.BackofficeUserRegistry {
+func getBackofficeRegistry(t *testing.T, h *testHarness) registry.BackofficeUserRegistry {
 	t.Helper()
-	dsn := skipIfNoPostgreSQL(t)
-	c := qt.New(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	fs := createRegistrySetFromPool(pool)
-	return fs.BackofficeUserRegistry
+	return h.factorySet(t).BackofficeUserRegistry
 }
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"testing"
@@ -12,7 +12,7 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-func TestCommodityRegistry_Create_HappyPath(t *testing.T) {
+func testCommodityRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
 	testCases := []struct {
 		name      string
 		commodity models.Commodity
@@ -84,7 +84,7 @@ func TestCommodityRegistry_Create_HappyPath(t *testing.T) {
 				},
 			})
 
-			registrySet, cleanup := setupTestRegistrySet(t)
+			registrySet, cleanup := h.setupTestRegistrySet(t)
 			defer cleanup()
 
 			// Create test hierarchy
@@ -110,7 +110,7 @@ func TestCommodityRegistry_Create_HappyPath(t *testing.T) {
 	}
 }
 
-func TestCommodityRegistry_Create_UnhappyPath(t *testing.T) {
+func testCommodityRegistry_Create_UnhappyPath(t *testing.T, h *testHarness) {
 	// The commodity registry's Create enforces referential integrity (the
 	// area must exist when set) but does NOT run model field validation —
 	// that lives at the model layer (models/commodity_test.go) and the API
@@ -152,7 +152,7 @@ func TestCommodityRegistry_Create_UnhappyPath(t *testing.T) {
 				},
 			})
 
-			registrySet, cleanup := setupTestRegistrySet(t)
+			registrySet, cleanup := h.setupTestRegistrySet(t)
 			defer cleanup()
 
 			// For valid area ID tests, create test hierarchy. AreaID is now a
@@ -171,8 +171,8 @@ func TestCommodityRegistry_Create_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestCommodityRegistry_Get_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Get_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -204,8 +204,8 @@ func TestCommodityRegistry_Get_HappyPath(t *testing.T) {
 	c.Assert(result.Status, qt.Equals, created.Status)
 }
 
-func TestCommodityRegistry_Get_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Get_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -234,8 +234,8 @@ func TestCommodityRegistry_Get_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestCommodityRegistry_List_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_List_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -271,8 +271,8 @@ func TestCommodityRegistry_List_HappyPath(t *testing.T) {
 	c.Assert(commodityIDs[commodity2.ID], qt.IsTrue)
 }
 
-func TestCommodityRegistry_List_SortedByPurchaseDate(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_List_SortedByPurchaseDate(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -329,8 +329,8 @@ func TestCommodityRegistry_List_SortedByPurchaseDate(t *testing.T) {
 	c.Assert(commodities[3].PurchaseDate, qt.IsNil)
 }
 
-func TestCommodityRegistry_Update_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Update_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -369,11 +369,11 @@ func TestCommodityRegistry_Update_HappyPath(t *testing.T) {
 	c.Assert(retrieved.Comments, qt.Equals, "Updated comments")
 }
 
-// TestCommodityRegistry_Create_NilArea verifies a commodity can be created with
+// testCommodityRegistry_Create_NilArea verifies a commodity can be created with
 // no area (issue #1986): the existence check is skipped and the row persists
 // with a nil AreaID.
-func TestCommodityRegistry_Create_NilArea(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Create_NilArea(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -411,10 +411,10 @@ func TestCommodityRegistry_Create_NilArea(t *testing.T) {
 	c.Assert(retrieved.AreaID, qt.IsNil)
 }
 
-// TestCommodityRegistry_Update_UnassignArea verifies the un-assign path (issue
+// testCommodityRegistry_Update_UnassignArea verifies the un-assign path (issue
 // #1986): updating an area-bound commodity to a nil AreaID clears the column.
-func TestCommodityRegistry_Update_UnassignArea(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Update_UnassignArea(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -439,11 +439,11 @@ func TestCommodityRegistry_Update_UnassignArea(t *testing.T) {
 	c.Assert(retrieved.AreaID, qt.IsNil)
 }
 
-// TestCommodityRegistry_ListPaginated_Unassigned verifies the Unassigned filter
+// testCommodityRegistry_ListPaginated_Unassigned verifies the Unassigned filter
 // (issue #1986) selects only area-less rows (area_id IS NULL), and that an
 // explicit AreaID filter wins over Unassigned.
-func TestCommodityRegistry_ListPaginated_Unassigned(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_ListPaginated_Unassigned(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -491,8 +491,8 @@ func TestCommodityRegistry_ListPaginated_Unassigned(t *testing.T) {
 	c.Assert(got[0].ID, qt.Equals, filed.ID)
 }
 
-func TestCommodityRegistry_Update_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Update_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -531,8 +531,8 @@ func TestCommodityRegistry_Update_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestCommodityRegistry_Delete_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Delete_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -558,8 +558,8 @@ func TestCommodityRegistry_Delete_HappyPath(t *testing.T) {
 	c.Assert(result, qt.IsNil)
 }
 
-func TestCommodityRegistry_Delete_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Delete_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -587,14 +587,14 @@ func TestCommodityRegistry_Delete_UnhappyPath(t *testing.T) {
 	}
 }
 
-// TestCommodityRegistry_GetMany pins the batched primitive added for
+// testCommodityRegistry_GetMany pins the batched primitive added for
 // issue #1512. The handler-level test in apiserver/ asserts that the
 // listGroupLoans path makes exactly one commodity-fetch round-trip — this
 // test locks the registry contract that path depends on: arbitrary
 // result order, missing/cross-scope ids dropped silently, duplicates
 // collapsed, and empty input never opens a transaction.
-func TestCommodityRegistry_GetMany(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_GetMany(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -649,8 +649,8 @@ func TestCommodityRegistry_GetMany(t *testing.T) {
 	})
 }
 
-func TestCommodityRegistry_Count_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityRegistry_Count_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,20 +6,17 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
 	"github.com/denisvmedia/inventario/services"
 )
 
-// loanPGFixture mirrors tagPGFixture for the loan registry. Two groups
+// loanFixture mirrors tagFixture for the loan registry. Two groups
 // so cross-group isolation can be exercised; one commodity per group so
 // loans have something to attach to.
-type loanPGFixture struct {
+type loanFixture struct {
 	factorySet *registry.FactorySet
 	groupASet  *registry.Set
 	groupBSet  *registry.Set
@@ -32,17 +29,13 @@ type loanPGFixture struct {
 	commBID    string
 }
 
-func newLoanPGFixture(t *testing.T) loanPGFixture {
+func newLoanFixture(t *testing.T, h *testHarness) loanFixture {
 	t.Helper()
 	c := qt.New(t)
 
-	groupASet, _ := setupTestRegistrySet(t)
+	groupASet, _ := h.setupTestRegistrySet(t)
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	factorySet := postgres.NewFactorySet(dbx)
+	factorySet := h.factorySet(t)
 
 	user := getTestUser(c, groupASet)
 
@@ -65,7 +58,7 @@ func newLoanPGFixture(t *testing.T) loanPGFixture {
 	c.Assert(err, qt.IsNil)
 	groupBID := groupB.ID
 
-	groupBSet := postgres.NewRegistrySetWithUserAndGroupID(dbx, user.ID, user.TenantID, groupBID)
+	groupBSet := h.registrySetWithUserAndGroupID(t, user.ID, user.TenantID, groupBID)
 
 	ctxA := loanCtxFor(user, groupAID)
 	ctxB := loanCtxFor(user, groupBID)
@@ -76,7 +69,7 @@ func newLoanPGFixture(t *testing.T) loanPGFixture {
 	commAID := seedTagCommodity(c, groupASet, ctxA, areaAID, "Drill A")
 	commBID := seedTagCommodity(c, groupBSet, ctxB, areaBID, "Drill B")
 
-	return loanPGFixture{
+	return loanFixture{
 		factorySet: factorySet,
 		groupASet:  groupASet,
 		groupBSet:  groupBSet,
@@ -100,9 +93,9 @@ func loanCtxFor(user *models.User, groupID string) context.Context {
 	})
 }
 
-func TestCommodityLoanRegistry_Postgres_CreateAndGet(t *testing.T) {
+func testCommodityLoanRegistry_CreateAndGet(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newLoanPGFixture(t)
+	fx := newLoanFixture(t, h)
 
 	created, err := fx.groupASet.CommodityLoanRegistry.Create(fx.ctxA, models.CommodityLoan{
 		CommodityID:  fx.commAID,
@@ -119,9 +112,9 @@ func TestCommodityLoanRegistry_Postgres_CreateAndGet(t *testing.T) {
 	c.Assert(got.BorrowerName, qt.Equals, "Alice")
 }
 
-func TestCommodityLoanRegistry_Postgres_StartLoan_RejectsSecondOpen(t *testing.T) {
+func testCommodityLoanRegistry_StartLoan_RejectsSecondOpen(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newLoanPGFixture(t)
+	fx := newLoanFixture(t, h)
 	svc := services.NewCommodityLoanService(fx.factorySet)
 
 	first, _, _, err := svc.StartLoan(fx.ctxA, models.CommodityLoan{
@@ -155,9 +148,9 @@ func TestCommodityLoanRegistry_Postgres_StartLoan_RejectsSecondOpen(t *testing.T
 	c.Assert(third.ID, qt.Not(qt.Equals), first.ID)
 }
 
-func TestCommodityLoanRegistry_Postgres_StateFilter(t *testing.T) {
+func testCommodityLoanRegistry_StateFilter(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newLoanPGFixture(t)
+	fx := newLoanFixture(t, h)
 
 	now := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
 
@@ -188,9 +181,9 @@ func TestCommodityLoanRegistry_Postgres_StateFilter(t *testing.T) {
 	c.Assert(returnedList, qt.HasLen, 0)
 }
 
-func TestCommodityLoanRegistry_Postgres_GroupIsolation(t *testing.T) {
+func testCommodityLoanRegistry_GroupIsolation(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newLoanPGFixture(t)
+	fx := newLoanFixture(t, h)
 
 	_, err := fx.groupASet.CommodityLoanRegistry.Create(fx.ctxA, models.CommodityLoan{
 		CommodityID:  fx.commAID,
@@ -204,9 +197,9 @@ func TestCommodityLoanRegistry_Postgres_GroupIsolation(t *testing.T) {
 	c.Assert(bList, qt.HasLen, 0, qt.Commentf("loans in group A must not be visible to group B via RLS"))
 }
 
-func TestCommodityLoanRegistry_Postgres_CountOpenByCommodity(t *testing.T) {
+func testCommodityLoanRegistry_CountOpenByCommodity(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newLoanPGFixture(t)
+	fx := newLoanFixture(t, h)
 
 	_, err := fx.groupASet.CommodityLoanRegistry.Create(fx.ctxA, models.CommodityLoan{
 		CommodityID:  fx.commAID,
@@ -221,12 +214,12 @@ func TestCommodityLoanRegistry_Postgres_CountOpenByCommodity(t *testing.T) {
 	c.Assert(counts["ghost"], qt.Equals, 0)
 }
 
-// TestCommodityLoanRegistry_Postgres_FKCascade verifies that hard-deleting
+// testCommodityLoanRegistry_FKCascade verifies that hard-deleting
 // the parent commodity drops every loan row pointing at it. Mirrors the
 // `ON DELETE CASCADE` clause we hand-add to the migration.
-func TestCommodityLoanRegistry_Postgres_FKCascade(t *testing.T) {
+func testCommodityLoanRegistry_FKCascade(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newLoanPGFixture(t)
+	fx := newLoanFixture(t, h)
 
 	loan, err := fx.groupASet.CommodityLoanRegistry.Create(fx.ctxA, models.CommodityLoan{
 		CommodityID:  fx.commAID,
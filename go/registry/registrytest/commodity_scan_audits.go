@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -11,8 +11,7 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// newTestScanAudit builds a valid CommodityScanAudit for the postgres
-// backend. user/tenant must reference real rows because the table
+// newTestScanAudit builds a valid CommodityScanAudit. user/tenant must reference real rows because the table
 // carries FKs onto users / tenants.
 func newTestScanAudit(user *models.User) models.CommodityScanAudit {
 	return models.CommodityScanAudit{
@@ -29,8 +28,8 @@ func newTestScanAudit(user *models.User) models.CommodityScanAudit {
 	}
 }
 
-func TestCommodityScanAuditRegistry_Record_HappyPath_Postgres(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityScanAuditRegistry_Record_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -45,8 +44,8 @@ func TestCommodityScanAuditRegistry_Record_HappyPath_Postgres(t *testing.T) {
 	c.Assert(created.CreatedAt.IsZero(), qt.IsFalse)
 }
 
-func TestCommodityScanAuditRegistry_Record_MissingFields_Postgres(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityScanAuditRegistry_Record_MissingFields(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -74,8 +73,8 @@ func TestCommodityScanAuditRegistry_Record_MissingFields_Postgres(t *testing.T)
 	}
 }
 
-func TestCommodityScanAuditRegistry_CountRecentForUser_Postgres(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityScanAuditRegistry_CountRecentForUser(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -92,8 +91,8 @@ func TestCommodityScanAuditRegistry_CountRecentForUser_Postgres(t *testing.T) {
 	c.Assert(count, qt.Equals, 3)
 }
 
-func TestCommodityScanAuditRegistry_DeleteOlderThan_Postgres(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testCommodityScanAuditRegistry_DeleteOlderThan(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -8,38 +8,29 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
 )
 
-// TestCurrencyMigrationRegistry_Postgres_HappyPath exercises the
+// testCurrencyMigrationRegistry_HappyPath exercises the
 // pending → running → completed transitions and verifies that the
 // daily-cap query, in-flight query, and audit-row write all return
-// the expected results against a real Postgres backend.
-func TestCurrencyMigrationRegistry_Postgres_HappyPath(t *testing.T) {
-	dsn := skipIfNoPostgreSQL(t)
+// the expected results against a real backend.
+func testCurrencyMigrationRegistry_HappyPath(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	ctx := context.Background()
 	user := getTestUser(c, registrySet)
 	groupID := getTestGroupID(c, registrySet, user)
 
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-
 	// Build a separate factory so we can grab a service registry that
 	// the worker uses (TX1/TX2 simulation).
-	factory := postgres.NewCurrencyMigrationRegistry(dbx)
-	serviceReg := factory.CreateServiceRegistry()
+	serviceReg := h.factorySet(t).CurrencyMigrationRegistryFactory.CreateServiceRegistry()
 
 	created, err := registrySet.CurrencyMigrationRegistry.Create(ctx, models.CurrencyMigration{
 		FromCurrency: "USD",
@@ -82,16 +73,15 @@ func TestCurrencyMigrationRegistry_Postgres_HappyPath(t *testing.T) {
 	c.Assert(round.CommodityCount, qt.Equals, 1)
 }
 
-// TestCurrencyMigrationRegistry_Postgres_ParallelInFlightUniqueViolation
+// testCurrencyMigrationRegistry_ParallelInFlightUniqueViolation
 // is the integration test mandated by issue #1550 §4: two parallel raw
 // inserts with the same group_id and status='pending' must result in
-// exactly one success and one ErrMigrationInFlight (mapped from
-// Postgres SQLState 23505 on the partial unique index).
-func TestCurrencyMigrationRegistry_Postgres_ParallelInFlightUniqueViolation(t *testing.T) {
-	skipIfNoPostgreSQL(t)
+// exactly one success and one ErrMigrationInFlight (mapped from the
+// backend's unique violation on the partial unique index).
+func testCurrencyMigrationRegistry_ParallelInFlightUniqueViolation(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -139,24 +129,20 @@ func TestCurrencyMigrationRegistry_Postgres_ParallelInFlightUniqueViolation(t *t
 	c.Assert(conflictErrors, qt.Equals, 1, qt.Commentf("the loser must surface ErrMigrationInFlight"))
 }
 
-// TestCurrencyMigrationRegistry_Postgres_FailedRunsDontCount verifies
+// testCurrencyMigrationRegistry_FailedRunsDontCount verifies
 // that a failed run does not consume daily-cap quota — only completed
 // rows count.
-func TestCurrencyMigrationRegistry_Postgres_FailedRunsDontCount(t *testing.T) {
-	dsn := skipIfNoPostgreSQL(t)
+func testCurrencyMigrationRegistry_FailedRunsDontCount(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	ctx := context.Background()
 	user := getTestUser(c, registrySet)
 	groupID := getTestGroupID(c, registrySet, user)
 
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	serviceReg := postgres.NewCurrencyMigrationRegistry(dbx).CreateServiceRegistry()
+	serviceReg := h.factorySet(t).CurrencyMigrationRegistryFactory.CreateServiceRegistry()
 
 	// Migration #1 fails.
 	created, err := registrySet.CurrencyMigrationRegistry.Create(ctx, models.CurrencyMigration{
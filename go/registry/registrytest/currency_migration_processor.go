@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,27 +6,30 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
 )
 
-// TestCurrencyMigrationProcessor_HappyPath_SmallGroup walks the worker
-// TX2 lifecycle end-to-end against postgres: seed two commodities,
+// The audit actions and sweep threshold the SQL backends' processors use.
+const (
+	auditActionCurrencyMigrationComplete = "currency_migration.complete"
+	auditActionCurrencyMigrationFail     = "currency_migration.fail"
+	currencyMigrationStuckThreshold      = 10 * time.Minute
+)
+
+// testCurrencyMigrationProcessor_HappyPath_SmallGroup walks the worker
+// TX2 lifecycle end-to-end: seed two commodities,
 // insert+claim a pending migration, run ProcessRunningMigration, and
 // assert: commodity rows updated; migration row marked completed with
 // totals; group's GroupCurrency flipped + currency_migration_id
 // cleared; one audit row per commodity; one audit_logs row keyed to
 // the migration; one price_changed CommodityEvent per row.
-func TestCurrencyMigrationProcessor_HappyPath_SmallGroup(t *testing.T) {
-	dsn := skipIfNoPostgreSQL(t)
+func testCurrencyMigrationProcessor_HappyPath_SmallGroup(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -65,12 +68,8 @@ func TestCurrencyMigrationProcessor_HappyPath_SmallGroup(t *testing.T) {
 
 	// Build a separate processor + service registry so the worker
 	// surface is exercised exactly the way bootstrap wires it.
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	pgFactory := postgres.NewCurrencyMigrationRegistry(dbx)
-	processor := pgFactory.NewProcessor()
-	serviceReg := pgFactory.CreateServiceRegistry()
+	processor := h.currencyMigrationProcessor(t)
+	serviceReg := h.factorySet(t).CurrencyMigrationRegistryFactory.CreateServiceRegistry()
 
 	// Pending row: USD → EUR at 0.9.
 	created, err := registrySet.CurrencyMigrationRegistry.Create(ctx, models.CurrencyMigration{
@@ -153,7 +152,7 @@ func TestCurrencyMigrationProcessor_HappyPath_SmallGroup(t *testing.T) {
 
 	// audit_logs has one currency_migration.complete row keyed to the
 	// migration. We list newest-first and find the matching action.
-	logs, err := registrySet.AuditLogRegistry.ListByAction(ctx, postgres.AuditActionCurrencyMigrationComplete)
+	logs, err := registrySet.AuditLogRegistry.ListByAction(ctx, auditActionCurrencyMigrationComplete)
 	c.Assert(err, qt.IsNil)
 	matched := 0
 	for _, log := range logs {
@@ -165,16 +164,15 @@ func TestCurrencyMigrationProcessor_HappyPath_SmallGroup(t *testing.T) {
 	c.Assert(matched, qt.Equals, 1)
 }
 
-// TestCurrencyMigrationProcessor_ForwardThenInverse round-trips the
+// testCurrencyMigrationProcessor_ForwardThenInverse round-trips the
 // conversion: G_old → G_new at r, then G_new → G_old at 1/r. The final
 // CurrentPrice must equal the starting CurrentPrice (within rounding)
 // and the acquisition columns must remain at the values fixed on the
 // first migration — write-once invariant from #1550.
-func TestCurrencyMigrationProcessor_ForwardThenInverse(t *testing.T) {
-	dsn := skipIfNoPostgreSQL(t)
+func testCurrencyMigrationProcessor_ForwardThenInverse(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -188,12 +186,8 @@ func TestCurrencyMigrationProcessor_ForwardThenInverse(t *testing.T) {
 	startingCurrentPrice := com.CurrentPrice
 	startingCurrency := com.OriginalPriceCurrency
 
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	pgFactory := postgres.NewCurrencyMigrationRegistry(dbx)
-	processor := pgFactory.NewProcessor()
-	serviceReg := pgFactory.CreateServiceRegistry()
+	processor := h.currencyMigrationProcessor(t)
+	serviceReg := h.factorySet(t).CurrencyMigrationRegistryFactory.CreateServiceRegistry()
 
 	// Forward: USD → EUR @ 0.5.
 	forwardOp, err := registrySet.CurrencyMigrationRegistry.Create(ctx, models.CurrencyMigration{
@@ -247,28 +241,23 @@ func TestCurrencyMigrationProcessor_ForwardThenInverse(t *testing.T) {
 	c.Assert(string(finalGroup.GroupCurrency), qt.Equals, "USD")
 }
 
-// TestCurrencyMigrationProcessor_RecoverySweepWithAuditLog asserts the
+// testCurrencyMigrationProcessor_RecoverySweepWithAuditLog asserts the
 // post-sweep failure path: a stuck running row passes the threshold,
 // the registry's SweepStuckRunning returns the swept row, and the
 // processor's WriteSweepFailureAuditLog inserts a matching audit log
 // keyed to the migration.
-func TestCurrencyMigrationProcessor_RecoverySweepWithAuditLog(t *testing.T) {
-	dsn := skipIfNoPostgreSQL(t)
+func testCurrencyMigrationProcessor_RecoverySweepWithAuditLog(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	ctx := context.Background()
 	user := getTestUser(c, registrySet)
 	_ = user
 
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	pgFactory := postgres.NewCurrencyMigrationRegistry(dbx)
-	processor := pgFactory.NewProcessor()
-	serviceReg := pgFactory.CreateServiceRegistry()
+	processor := h.currencyMigrationProcessor(t)
+	serviceReg := h.factorySet(t).CurrencyMigrationRegistryFactory.CreateServiceRegistry()
 
 	created, err := registrySet.CurrencyMigrationRegistry.Create(ctx, models.CurrencyMigration{
 		FromCurrency: "USD",
@@ -286,7 +275,7 @@ func TestCurrencyMigrationProcessor_RecoverySweepWithAuditLog(t *testing.T) {
 	}), qt.IsNil)
 
 	// Sweep with the production threshold.
-	swept, err := serviceReg.SweepStuckRunning(ctx, time.Now().UTC(), postgres.CurrencyMigrationStuckThreshold)
+	swept, err := serviceReg.SweepStuckRunning(ctx, time.Now().UTC(), currencyMigrationStuckThreshold)
 	c.Assert(err, qt.IsNil)
 	c.Assert(swept, qt.HasLen, 1)
 	c.Assert(swept[0].ID, qt.Equals, created.ID)
@@ -302,7 +291,7 @@ func TestCurrencyMigrationProcessor_RecoverySweepWithAuditLog(t *testing.T) {
 	c.Assert(round.ErrorMessage, qt.Equals, "worker crashed or stalled")
 
 	// audit_logs has the corresponding fail entry.
-	logs, err := registrySet.AuditLogRegistry.ListByAction(ctx, postgres.AuditActionCurrencyMigrationFail)
+	logs, err := registrySet.AuditLogRegistry.ListByAction(ctx, auditActionCurrencyMigrationFail)
 	c.Assert(err, qt.IsNil)
 	matched := 0
 	for _, log := range logs {
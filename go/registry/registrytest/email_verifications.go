@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -11,8 +11,7 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// newTestEmailVerification builds a valid EmailVerification for the postgres
-// backend. The user/tenant IDs must reference real rows (FK constraints
+// newTestEmailVerification builds a valid EmailVerification. The user/tenant IDs must reference real rows (FK constraints
 // fk_email_verification_user / fk_email_verification_tenant), and token must be
 // unique per record (UNIQUE index). UUID is left empty so the registry layer
 // fills it: store.NonRLSRepository.Create generates one server-side, with the
@@ -27,8 +26,8 @@ func newTestEmailVerification(user *models.User, token string) models.EmailVerif
 	}
 }
 
-func TestEmailVerificationRegistry_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -44,8 +43,8 @@ func TestEmailVerificationRegistry_Create_HappyPath(t *testing.T) {
 	c.Assert(created.CreatedAt.IsZero(), qt.IsFalse)
 }
 
-func TestEmailVerificationRegistry_Create_MissingFields(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Create_MissingFields(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -73,8 +72,8 @@ func TestEmailVerificationRegistry_Create_MissingFields(t *testing.T) {
 	}
 }
 
-func TestEmailVerificationRegistry_Get(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Get(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -90,8 +89,8 @@ func TestEmailVerificationRegistry_Get(t *testing.T) {
 	c.Assert(fetched.Token, qt.Equals, "token-get")
 }
 
-func TestEmailVerificationRegistry_Get_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Get_NotFound(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -101,8 +100,8 @@ func TestEmailVerificationRegistry_Get_NotFound(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestEmailVerificationRegistry_List(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_List(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -119,8 +118,8 @@ func TestEmailVerificationRegistry_List(t *testing.T) {
 	c.Assert(all, qt.HasLen, 2)
 }
 
-func TestEmailVerificationRegistry_GetByToken(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_GetByToken(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -138,8 +137,8 @@ func TestEmailVerificationRegistry_GetByToken(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestEmailVerificationRegistry_GetByUserID(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_GetByUserID(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -159,8 +158,8 @@ func TestEmailVerificationRegistry_GetByUserID(t *testing.T) {
 	c.Assert(empty, qt.HasLen, 0)
 }
 
-func TestEmailVerificationRegistry_Update(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Update(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -182,11 +181,11 @@ func TestEmailVerificationRegistry_Update(t *testing.T) {
 	c.Assert(reloaded.IsVerified(), qt.IsTrue)
 }
 
-// TestEmailVerificationRegistry_Update_NotFound pins parity with the memory
+// testEmailVerificationRegistry_Update_NotFound pins parity with the memory
 // backend: Update against an unknown ID returns registry.ErrNotFound rather
 // than silently succeeding with a zero-row UPDATE. See #1814.
-func TestEmailVerificationRegistry_Update_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Update_NotFound(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -199,8 +198,8 @@ func TestEmailVerificationRegistry_Update_NotFound(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestEmailVerificationRegistry_Delete(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Delete(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -217,10 +216,10 @@ func TestEmailVerificationRegistry_Delete(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-// TestEmailVerificationRegistry_DeleteExpired pins that DeleteExpired removes
+// testEmailVerificationRegistry_DeleteExpired pins that DeleteExpired removes
 // only records whose ExpiresAt is in the past and keeps future-dated ones.
-func TestEmailVerificationRegistry_DeleteExpired(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_DeleteExpired(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -246,11 +245,11 @@ func TestEmailVerificationRegistry_DeleteExpired(t *testing.T) {
 	c.Assert(all[0].ID, qt.Equals, futureCreated.ID)
 }
 
-// TestEmailVerificationRegistry_MarkVerified pins the atomic claim: the first
+// testEmailVerificationRegistry_MarkVerified pins the atomic claim: the first
 // call flips verified_at and reports true; a second call on the same token
 // reports false (idempotent), and the row stays verified. See #1005.
-func TestEmailVerificationRegistry_MarkVerified(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_MarkVerified(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -274,11 +273,11 @@ func TestEmailVerificationRegistry_MarkVerified(t *testing.T) {
 	c.Assert(again, qt.IsFalse, qt.Commentf("re-claiming an already-verified token must not win"))
 }
 
-// TestEmailVerificationRegistry_MarkVerified_TokenNotFound pins that an unknown
+// testEmailVerificationRegistry_MarkVerified_TokenNotFound pins that an unknown
 // token yields (false, nil) rather than an error — the zero-row UPDATE is a
 // non-event for the claim primitive.
-func TestEmailVerificationRegistry_MarkVerified_TokenNotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_MarkVerified_TokenNotFound(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -289,8 +288,8 @@ func TestEmailVerificationRegistry_MarkVerified_TokenNotFound(t *testing.T) {
 	c.Assert(claimed, qt.IsFalse)
 }
 
-func TestEmailVerificationRegistry_Count(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testEmailVerificationRegistry_Count(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
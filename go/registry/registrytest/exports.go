@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -11,7 +11,7 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// TestExportRegistry_Postgres_DeleteClearsRestorePipeline is the #2118
+// testExportRegistry_DeleteClearsRestorePipeline is the #2118
 // regression: restore_operations.export_id is a NOT NULL NO ACTION FK to
 // exports(id), and restore_steps.restore_operation_id is a NO ACTION FK to
 // restore_operations(id). Before the fix, ExportRegistry.Delete issued a
@@ -21,10 +21,10 @@ import (
 // export row is removed. This test seeds an export with a restore_operation
 // and a restore_step, then asserts Delete succeeds (no FK error) and all
 // three rows are gone.
-func TestExportRegistry_Postgres_DeleteClearsRestorePipeline(t *testing.T) {
+func testExportRegistry_DeleteClearsRestorePipeline(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	set, _ := setupTestRegistrySet(t)
+	set, _ := h.setupTestRegistrySet(t)
 	user := getTestUser(c, set)
 
 	ctx := appctx.WithUser(context.Background(), user)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"testing"
@@ -10,15 +10,15 @@ import (
 	"github.com/denisvmedia/inventario/models"
 )
 
-// TestFileRegistry_Postgres_CategoryFilter exercises the category WHERE-clause
-// path on the postgres FileRegistry — the actual path GET /files?category= will
+// testFileRegistry_CategoryFilter exercises the category WHERE-clause
+// path on the SQL FileRegistry — the actual path GET /files?category= will
 // take in production. CountByCategory is verified end-to-end against a real
 // SQL GROUP BY so we catch ordering / scan / RLS regressions that the memory
 // fast-path would miss.
-func TestFileRegistry_Postgres_CategoryFilter(t *testing.T) {
+func testFileRegistry_CategoryFilter(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	user := getTestUser(c, registrySet)
@@ -29,7 +29,7 @@ func TestFileRegistry_Postgres_CategoryFilter(t *testing.T) {
 	// services that read GroupIDFromContext directly — none of which we hit
 	// from this test.
 
-	for _, fe := range categoryPostgresSeed() {
+	for _, fe := range categoryFileSeed() {
 		fe.TenantID = user.TenantID
 		fe.CreatedByUserID = user.ID
 		_, err := registrySet.FileRegistry.Create(ctx, fe)
@@ -90,21 +90,21 @@ func TestFileRegistry_Postgres_CategoryFilter(t *testing.T) {
 	})
 }
 
-// TestFileRegistry_Postgres_LinkedEntityFilter exercises the
+// testFileRegistry_LinkedEntityFilter exercises the
 // linked_entity_type / linked_entity_id WHERE-clause path on the
-// postgres FileRegistry — what GET /files?linked_entity_type=... will
+// SQL FileRegistry — what GET /files?linked_entity_type=... will
 // hit in production for the commodity / location detail Files panels.
 // Cross-entity isolation is the load-bearing assertion.
-func TestFileRegistry_Postgres_LinkedEntityFilter(t *testing.T) {
+func testFileRegistry_LinkedEntityFilter(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	registrySet, cleanup := setupTestRegistrySet(t)
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	user := getTestUser(c, registrySet)
 	ctx := appctx.WithUser(c.Context(), user)
 
-	for _, fe := range linkedEntityPostgresSeed() {
+	for _, fe := range linkedEntityFileSeed() {
 		fe.TenantID = user.TenantID
 		fe.CreatedByUserID = user.ID
 		_, err := registrySet.FileRegistry.Create(ctx, fe)
@@ -183,7 +183,7 @@ func TestFileRegistry_Postgres_LinkedEntityFilter(t *testing.T) {
 	})
 }
 
-func linkedEntityPostgresSeed() []models.FileEntity {
+func linkedEntityFileSeed() []models.FileEntity {
 	now := time.Now()
 	mk := func(name, mime, ext string, cat models.FileCategory, linkedType, linkedID, linkedMeta string) models.FileEntity {
 		return models.FileEntity{
@@ -214,7 +214,7 @@ func linkedEntityPostgresSeed() []models.FileEntity {
 	}
 }
 
-func categoryPostgresSeed() []models.FileEntity {
+func categoryFileSeed() []models.FileEntity {
 	now := time.Now()
 	mk := func(name, mime, ext string, cat models.FileCategory, tags ...string) models.FileEntity {
 		return models.FileEntity{
@@ -1,29 +1,26 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/models"
-	"github.com/denisvmedia/inventario/registry/postgres"
 )
 
-// TestFileRegistry_Postgres_ListByLinkedEntity_AreaAndLocation exercises the
+// testFileRegistry_ListByLinkedEntity_AreaAndLocation exercises the
 // ListByLinkedEntity SQL path — the query the #2119 fix
 // (EntityService → FileService.DeleteLinkedFiles) depends on — for the 'area'
-// and 'location' link types against a real PostgreSQL schema. The query
+// and 'location' link types against a real schema. The query
 // itself carries no tenant filter (RLS supplies it), so the cross-group
 // isolation subtest is the load-bearing assertion: a user from another
 // tenant/group must see nothing for the same linked-entity id.
-func TestFileRegistry_Postgres_ListByLinkedEntity_AreaAndLocation(t *testing.T) {
+func testFileRegistry_ListByLinkedEntity_AreaAndLocation(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	set, cleanup := setupTestRegistrySet(t)
+	set, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	user := getTestUser(c, set)
@@ -99,18 +96,14 @@ func TestFileRegistry_Postgres_ListByLinkedEntity_AreaAndLocation(t *testing.T)
 	t.Run("RLS isolates another tenant and group", func(t *testing.T) {
 		c := qt.New(t)
 
-		dsn := skipIfNoPostgreSQL(t)
-		pool, err := getOrCreatePool(dsn)
-		c.Assert(err, qt.IsNil)
-		dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-		fs := postgres.NewFactorySet(dbx)
+		fs := h.factorySet(t)
 
 		bgCtx := context.Background()
 		tenantB := mustCreateTenant(c, bgCtx, fs, "tenant-b-files")
 		userB := mustCreateUser(c, bgCtx, fs, tenantB, "user-b@files.example")
 		groupB := mustCreateActiveGroup(c, bgCtx, fs, tenantB, userB.ID)
 
-		setB := postgres.NewRegistrySetWithUserAndGroupID(dbx, userB.ID, tenantB, groupB)
+		setB := h.registrySetWithUserAndGroupID(t, userB.ID, tenantB, groupB)
 		ctxB := appctx.WithUser(bgCtx, userB)
 
 		// The same (type, id) pair that has two rows in group A yields NOTHING
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,18 +6,15 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
 )
 
-// TestFileRegistry_Postgres_ListOrphanCandidates exercises the SQL anti-join
-// that backs the orphan-file GC (#2237) against a real PostgreSQL schema.
+// testFileRegistry_ListOrphanCandidates exercises the SQL anti-join
+// that backs the orphan-file GC (#2237) against a real schema.
 //
 // This worker DELETES USER DATA, so the assertions that matter are the ones
 // about what must NOT come back:
@@ -34,19 +31,13 @@ import (
 //     never validates the target's group, so this row is reachable in
 //     production, and it is the one a group-scoped (RLS) query would wrongly
 //     report as an orphan. The anti-join therefore has to run in SERVICE mode.
-//
-// Self-skips when POSTGRES_TEST_DSN is unset (via setupTestRegistrySet).
-func TestFileRegistry_Postgres_ListOrphanCandidates(t *testing.T) {
+func testFileRegistry_ListOrphanCandidates(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	_, cleanup := setupTestRegistrySet(t)
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	fs := postgres.NewFactorySet(dbx)
+	fs := h.factorySet(t)
 
 	ctx := context.Background()
 
@@ -175,7 +166,7 @@ func TestFileRegistry_Postgres_ListOrphanCandidates(t *testing.T) {
 	})
 }
 
-// TestFileRegistry_Postgres_ListIDsByOriginalPath pins the lookup that stops
+// testFileRegistry_ListIDsByOriginalPath pins the lookup that stops
 // every delete path — and the orphan-file GC — from destroying a LIVE file's
 // bytes.
 //
@@ -185,17 +176,13 @@ func TestFileRegistry_Postgres_ListOrphanCandidates(t *testing.T) {
 // share one blob key, while the blob delete inside DeleteFileWithPhysical is
 // key-scoped: deleting one row would take the other's bytes with it,
 // irreversibly (`files` has no soft-delete).
-func TestFileRegistry_Postgres_ListIDsByOriginalPath(t *testing.T) {
+func testFileRegistry_ListIDsByOriginalPath(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	_, cleanup := setupTestRegistrySet(t)
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	fs := postgres.NewFactorySet(dbx)
+	fs := h.factorySet(t)
 
 	ctx := context.Background()
 
@@ -242,20 +229,16 @@ func TestFileRegistry_Postgres_ListIDsByOriginalPath(t *testing.T) {
 	})
 }
 
-// TestFileRegistry_Postgres_ExistingIDs checks the membership query that backs
+// testFileRegistry_ExistingIDs checks the membership query that backs
 // the thumbnail sweep: a single ANY($1) round-trip answering, BY ID ALONE,
 // which of the ids the listed keys named still have a row.
-func TestFileRegistry_Postgres_ExistingIDs(t *testing.T) {
+func testFileRegistry_ExistingIDs(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	_, cleanup := setupTestRegistrySet(t)
+	_, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	fs := postgres.NewFactorySet(dbx)
+	fs := h.factorySet(t)
 
 	ctx := context.Background()
 
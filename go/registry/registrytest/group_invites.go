@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -18,9 +18,10 @@ func inviteFor(tenantID, groupID, createdByUserID string, expires time.Time) mod
 		GroupID:             groupID,
 		Token:               token,
 		CreatedBy:           createdByUserID,
-		// Normalize to UTC: expires_at is a TIMESTAMP (no tz) column, so
-		// pgx serializes the wall-clock components in time.Local on
-		// write and reconstructs the value with location=UTC on read.
+		// Normalize to UTC: expires_at is a TIMESTAMP (no tz) column, and
+		// on PostgreSQL pgx serializes the wall-clock components in
+		// time.Local on write and reconstructs the value with location=UTC
+		// on read.
 		// On a non-UTC dev machine (e.g. CEST) that round-trip skews the
 		// instant by the offset, making "1 hour ago" look like a future
 		// timestamp to ListActiveByGroup's `ExpiresAt.After(now)` check.
@@ -30,8 +31,8 @@ func inviteFor(tenantID, groupID, createdByUserID string, expires time.Time) mod
 	}
 }
 
-func TestGroupInviteRegistry_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupInviteRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -50,8 +51,8 @@ func TestGroupInviteRegistry_Create_HappyPath(t *testing.T) {
 	c.Assert(created.UsedAt, qt.IsNil)
 }
 
-func TestGroupInviteRegistry_Create_MissingFields(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupInviteRegistry_Create_MissingFields(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -85,8 +86,8 @@ func TestGroupInviteRegistry_Create_MissingFields(t *testing.T) {
 	}
 }
 
-func TestGroupInviteRegistry_GetByToken_And_ListActive(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupInviteRegistry_GetByToken_And_ListActive(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -122,11 +123,11 @@ func TestGroupInviteRegistry_GetByToken_And_ListActive(t *testing.T) {
 	c.Assert(gotExpired.IsExpired(), qt.IsTrue)
 }
 
-// TestGroupInviteRegistry_MarkUsed_CAS verifies compare-and-swap semantics:
+// testGroupInviteRegistry_MarkUsed_CAS verifies compare-and-swap semantics:
 // the first MarkUsed call mutates the row and returns (true, nil); a second
 // call for the same invite returns (false, nil) without touching used_by.
-func TestGroupInviteRegistry_MarkUsed_CAS(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupInviteRegistry_MarkUsed_CAS(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -0,0 +1,116 @@
+package registrytest
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+func testGroupMemberLocationScopeRegistry_ReplaceAndList(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
+	defer cleanup()
+
+	c := qt.New(t)
+	scopes := h.factorySet(t).GroupMemberLocationScopeRegistry
+
+	user := getTestUser(c, registrySet)
+	first := createTestLocation(c, registrySet)
+	second := createTestLocation(c, registrySet)
+
+	stored, err := scopes.ReplaceForMember(c.Context(), user.TenantID, first.GroupID, user.ID, []models.GroupMemberLocationScope{
+		{LocationID: first.ID, Role: models.GroupRoleViewer},
+		{LocationID: second.ID, Role: models.GroupRoleUser},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(stored, qt.HasLen, 2)
+
+	rows, err := scopes.ListByMember(c.Context(), user.TenantID, first.GroupID, user.ID)
+	c.Assert(err, qt.IsNil)
+	scope := models.NewLocationScope(rows)
+	c.Assert(scope[first.ID], qt.Equals, models.GroupRoleViewer)
+	c.Assert(scope[second.ID], qt.Equals, models.GroupRoleUser)
+
+	// Replacing with nothing lifts the restriction.
+	_, err = scopes.ReplaceForMember(c.Context(), user.TenantID, first.GroupID, user.ID, nil)
+	c.Assert(err, qt.IsNil)
+	rows, err = scopes.ListByMember(c.Context(), user.TenantID, first.GroupID, user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.HasLen, 0)
+}
+
+// testLocationScope_RLSAndWriteTrigger checks the database side of the
+// location scope: the isolation policies hide rows outside the scope,
+// WITH CHECK rejects inserts below the required role, and the
+// enforce_location_scope_write trigger rejects updates and deletes of
+// visible rows the member may not write.
+func testLocationScope_RLSAndWriteTrigger(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
+	defer cleanup()
+
+	c := qt.New(t)
+	user := getTestUser(c, registrySet)
+	ctx := appctx.WithUser(c.Context(), user)
+
+	home := createTestLocation(c, registrySet)
+	cabin := createTestLocation(c, registrySet)
+	cabinArea := createTestArea(c, registrySet, cabin.ID)
+	commodity := createTestCommodity(c, registrySet, cabinArea.ID)
+
+	withScope := func(scope models.LocationScope) context.Context {
+		return appctx.WithLocationScope(ctx, scope)
+	}
+
+	c.Run("rows outside the scope are hidden", func(c *qt.C) {
+		homeViewer := withScope(models.LocationScope{home.ID: models.GroupRoleViewer})
+		locations, err := registrySet.LocationRegistry.List(homeViewer)
+		c.Assert(err, qt.IsNil)
+		c.Assert(locations, qt.HasLen, 1)
+		c.Assert(locations[0].ID, qt.Equals, home.ID)
+
+		_, err = registrySet.LocationRegistry.Get(homeViewer, cabin.ID)
+		c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+		_, err = registrySet.AreaRegistry.Get(homeViewer, cabinArea.ID)
+		c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+		_, err = registrySet.CommodityRegistry.Get(homeViewer, commodity.ID)
+		c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	})
+
+	c.Run("viewer cannot write visible rows", func(c *qt.C) {
+		cabinViewer := withScope(models.LocationScope{cabin.ID: models.GroupRoleViewer})
+		_, err := registrySet.CommodityRegistry.Get(cabinViewer, commodity.ID)
+		c.Assert(err, qt.IsNil)
+
+		_, err = registrySet.AreaRegistry.Create(cabinViewer, models.Area{Name: "Shed", LocationID: cabin.ID})
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+
+		renamed := *commodity
+		renamed.Name = "Renamed"
+		_, err = registrySet.CommodityRegistry.Update(cabinViewer, renamed)
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+
+		err = registrySet.CommodityRegistry.Delete(cabinViewer, commodity.ID)
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+		_, err = registrySet.CommodityRegistry.Get(ctx, commodity.ID)
+		c.Assert(err, qt.IsNil)
+	})
+
+	c.Run("scoped role grants writes", func(c *qt.C) {
+		cabinUser := withScope(models.LocationScope{cabin.ID: models.GroupRoleUser})
+		renamed := *commodity
+		renamed.Name = "Renamed"
+		_, err := registrySet.CommodityRegistry.Update(cabinUser, renamed)
+		c.Assert(err, qt.IsNil)
+
+		// Areas need admin on the location.
+		_, err = registrySet.AreaRegistry.Create(cabinUser, models.Area{Name: "Shed", LocationID: cabin.ID})
+		c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+		cabinAdmin := withScope(models.LocationScope{cabin.ID: models.GroupRoleAdmin})
+		_, err = registrySet.AreaRegistry.Create(cabinAdmin, models.Area{Name: "Shed", LocationID: cabin.ID})
+		c.Assert(err, qt.IsNil)
+	})
+}
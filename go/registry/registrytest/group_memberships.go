@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -21,8 +21,8 @@ func membershipFor(tenantID, groupID, memberUserID string, role models.GroupRole
 	}
 }
 
-func TestGroupMembershipRegistry_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupMembershipRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -38,8 +38,8 @@ func TestGroupMembershipRegistry_Create_HappyPath(t *testing.T) {
 	c.Assert(m.Role, qt.Equals, models.GroupRoleAdmin)
 }
 
-func TestGroupMembershipRegistry_Create_MissingFields(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupMembershipRegistry_Create_MissingFields(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -72,8 +72,8 @@ func TestGroupMembershipRegistry_Create_MissingFields(t *testing.T) {
 	}
 }
 
-func TestGroupMembershipRegistry_Create_Duplicate(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupMembershipRegistry_Create_Duplicate(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -93,8 +93,8 @@ func TestGroupMembershipRegistry_Create_Duplicate(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
 }
 
-func TestGroupMembershipRegistry_GetByGroupAndUser(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupMembershipRegistry_GetByGroupAndUser(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -115,13 +115,13 @@ func TestGroupMembershipRegistry_GetByGroupAndUser(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-// #1652: postgres-side coverage for the new invariant-checked delete
+// #1652: registry-level coverage for the new invariant-checked delete
 // path. The shared service tests exercise the memory backend; this
-// pins the hand-written SQL + pg_advisory_xact_lock + sentinel
-// mapping on the production registry so regressions in the
-// postgres-specific code can't slip past memory-only tests.
-func TestGroupMembershipRegistry_DeleteWithMemberInvariants(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+// pins the hand-written SQL + locking + sentinel mapping on the
+// production registries so regressions in the backend-specific code
+// can't slip past memory-only tests.
+func testGroupMembershipRegistry_DeleteWithMemberInvariants(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -180,12 +180,12 @@ func TestGroupMembershipRegistry_DeleteWithMemberInvariants(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrLastMember, qt.Commentf("expected ErrLastMember, got %v", err))
 }
 
-// #1652: postgres-side coverage for the new invariant-checked role
+// #1652: registry-level coverage for the new invariant-checked role
 // update. Catches regressions in the hand-written `UPDATE ...
-// RETURNING *` scan, the advisory-lock key (must match
+// RETURNING *` scan, the locking (must match
 // DeleteWithMemberInvariants), and the sentinel propagation.
-func TestGroupMembershipRegistry_UpdateRoleWithMemberInvariants(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupMembershipRegistry_UpdateRoleWithMemberInvariants(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -234,8 +234,8 @@ func TestGroupMembershipRegistry_UpdateRoleWithMemberInvariants(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestGroupMembershipRegistry_ListByGroup_ByUser_CountAdmins(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupMembershipRegistry_ListByGroup_ByUser_CountAdmins(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -278,8 +278,8 @@ func TestGroupMembershipRegistry_ListByGroup_ByUser_CountAdmins(t *testing.T) {
 // the LocationGroup JSON:API resource (#1650). Single-group and batch
 // shapes are exercised side-by-side so a regression in either method
 // fails the same test.
-func TestGroupMembershipRegistry_CountByGroup_AndCountByGroups(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testGroupMembershipRegistry_CountByGroup_AndCountByGroups(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
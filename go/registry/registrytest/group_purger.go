@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -7,44 +7,19 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/appctx"
 	_ "github.com/denisvmedia/inventario/internal/fileblob" // register file:// driver
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
-	"github.com/denisvmedia/inventario/schema/migrations/migrator"
 	"github.com/denisvmedia/inventario/services"
 )
 
-// setupCleanPostgresFactorySet migrates the schema and returns a raw factory
-// set with no seeded tenants/users/groups. Used by multi-tenant purge tests
-// that need to manage their own fixtures.
-func setupCleanPostgresFactorySet(t *testing.T) *registry.FactorySet {
-	t.Helper()
-
-	dsn := skipIfNoPostgreSQL(t)
-	c := qt.New(t)
-
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-
-	// Drop and recreate the schema so fixtures from prior tests don't bleed in.
-	migr := migrator.NewWithFallback(dsn, "../../models")
-	ctx := context.Background()
-	c.Assert(migrateUp(t, ctx, migr, dsn), qt.IsNil)
-
-	sqlxDB := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	return postgres.NewFactorySet(sqlxDB)
-}
-
-// newPostgresFileUploadLocation returns a file:// DSN for a temp dir so the
+// newFileUploadLocation returns a file:// DSN for a temp dir so the
 // FileService can open its bucket during purge (a no-op when no files are
 // seeded, but the code path must not error out).
-func newPostgresFileUploadLocation(c *qt.C) string {
+func newFileUploadLocation(c *qt.C) string {
 	tempDir := c.TempDir()
 	if runtime.GOOS == "windows" {
 		return "file:///" + tempDir + "?create_dir=1"
@@ -105,7 +80,7 @@ func seedTenantWithPendingGroup(c *qt.C, ctx context.Context, fs *registry.Facto
 	return tenant.ID, createdUser.ID, group.ID, token
 }
 
-// TestGroupPurgeService_Postgres_CrossTenantRLSBypass verifies that the
+// testGroupPurgeService_CrossTenantRLSBypass verifies that the
 // purge worker can see and delete pending_deletion LocationGroups belonging
 // to multiple tenants in a single sweep, AND that the resulting audit rows
 // are written across tenant boundaries. Under the inventario_app role the
@@ -113,9 +88,9 @@ func seedTenantWithPendingGroup(c *qt.C, ctx context.Context, fs *registry.Facto
 // group_invites_audit would hide rows from foreign tenants; the
 // inventario_background_worker role has a bypass policy on each of those
 // tables and that is what makes cross-tenant maintenance work. This test
-// asserts the bypass end-to-end against a real PostgreSQL schema.
-func TestGroupPurgeService_Postgres_CrossTenantRLSBypass(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+// asserts the bypass end-to-end against a real schema.
+func testGroupPurgeService_CrossTenantRLSBypass(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -123,7 +98,7 @@ func TestGroupPurgeService_Postgres_CrossTenantRLSBypass(t *testing.T) {
 	tenantA, _, groupA, _ := seedTenantWithPendingGroup(c, ctx, fs, "tenant-a", "admin@a.example")
 	tenantB, _, groupB, _ := seedTenantWithPendingGroup(c, ctx, fs, "tenant-b", "admin@b.example")
 
-	fileSvc := services.NewFileService(fs, newPostgresFileUploadLocation(c))
+	fileSvc := services.NewFileService(fs, newFileUploadLocation(c))
 	svc := services.NewGroupPurgeService(fs, fileSvc)
 
 	purged, failed, err := svc.PurgeOnce(ctx)
@@ -171,13 +146,13 @@ func TestGroupPurgeService_Postgres_CrossTenantRLSBypass(t *testing.T) {
 	c.Assert(invitesB, qt.HasLen, 0)
 }
 
-// TestGroupPurgeService_Postgres_CleanExpiredInvitesCrossTenant verifies
+// testGroupPurgeService_CleanExpiredInvitesCrossTenant verifies
 // the Option 2i sweep reaches across tenants: unused expired invites owned
 // by different tenants are all removed in one service-mode DELETE, while
 // used invites (which are the domain of the per-group purge audit path)
 // and unused non-expired invites are preserved regardless of tenant.
-func TestGroupPurgeService_Postgres_CleanExpiredInvitesCrossTenant(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testGroupPurgeService_CleanExpiredInvitesCrossTenant(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -204,7 +179,7 @@ func TestGroupPurgeService_Postgres_CleanExpiredInvitesCrossTenant(t *testing.T)
 	usedBy := userB.ID
 	usedInvite := mustCreateInvite(c, ctx, fs, tenantB, groupB, userB.ID, time.Now().Add(-1*time.Hour), &usedBy, &usedAt)
 
-	fileSvc := services.NewFileService(fs, newPostgresFileUploadLocation(c))
+	fileSvc := services.NewFileService(fs, newFileUploadLocation(c))
 	svc := services.NewGroupPurgeService(fs, fileSvc)
 
 	deleted, err := svc.CleanExpiredInvites(ctx)
@@ -223,7 +198,7 @@ func TestGroupPurgeService_Postgres_CleanExpiredInvitesCrossTenant(t *testing.T)
 	c.Assert(err, qt.IsNil)
 }
 
-// TestGroupPurger_Postgres_PurgesAllGroupDependents is the #2095 + #2117
+// testGroupPurger_PurgesAllGroupDependents is the #2095 + #2117
 // regression: a group carrying every late-added group-scoped dependent
 // (tag, currency_migration + audit row, group_notification_pref,
 // commodity_loan + commodity, and a file with a thumbnail_generation_job +
@@ -231,16 +206,12 @@ func TestGroupPurgeService_Postgres_CleanExpiredInvitesCrossTenant(t *testing.T)
 // subsequent location_groups DELETE succeeds. Before the fix the NO ACTION
 // FKs on those tables (and the group-id-less thumbnail chain) left orphan
 // rows that blocked the final group delete.
-func TestGroupPurger_Postgres_PurgesAllGroupDependents(t *testing.T) {
+func testGroupPurger_PurgesAllGroupDependents(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	set, _ := setupTestRegistrySet(t)
+	set, _ := h.setupTestRegistrySet(t)
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	fs := postgres.NewFactorySet(dbx)
+	fs := h.factorySet(t)
 
 	user := getTestUser(c, set)
 
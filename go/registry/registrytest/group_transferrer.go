@@ -0,0 +1,87 @@
+package registrytest
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// testGroupTransferrer_MovesAcrossGroupRLS moves a location →
+// area → commodity chain with a maintenance schedule between two groups of
+// one tenant. The app role's RLS WITH CHECK refuses a group_id change, so
+// this only passes because the transferrer runs as the background worker.
+func testGroupTransferrer_MovesAcrossGroupRLS(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
+
+	c := qt.New(t)
+	ctx := context.Background()
+
+	tenantID := mustCreateTenant(c, ctx, fs, "tenant-xfer")
+	user := mustCreateUser(c, ctx, fs, tenantID, "xfer@example.com")
+	fromID := mustCreateActiveGroup(c, ctx, fs, tenantID, user.ID)
+	toID := mustCreateActiveGroup(c, ctx, fs, tenantID, user.ID)
+
+	groupCtx := func(groupID string) (context.Context, *registry.Set) {
+		group, err := fs.LocationGroupRegistry.Get(ctx, groupID)
+		c.Assert(err, qt.IsNil)
+		gctx := appctx.WithGroup(appctx.WithUser(ctx, user), group)
+		set, err := fs.CreateUserRegistrySet(gctx)
+		c.Assert(err, qt.IsNil)
+		return gctx, set
+	}
+	fromCtx, fromSet := groupCtx(fromID)
+	toCtx, toSet := groupCtx(toID)
+
+	loc, err := fromSet.LocationRegistry.Create(fromCtx, models.Location{Name: "Garage"})
+	c.Assert(err, qt.IsNil)
+	area, err := fromSet.AreaRegistry.Create(fromCtx, models.Area{Name: "Shelf", LocationID: loc.ID})
+	c.Assert(err, qt.IsNil)
+	commodity, err := fromSet.CommodityRegistry.Create(fromCtx, models.Commodity{
+		Name:      "Drill",
+		ShortName: "drill",
+		Type:      models.CommodityTypeEquipment,
+		Status:    models.CommodityStatusInUse,
+		Count:     1,
+		AreaID:    &area.ID,
+	})
+	c.Assert(err, qt.IsNil)
+	schedule, err := fromSet.MaintenanceScheduleRegistry.Create(fromCtx, models.MaintenanceSchedule{
+		CommodityID:  commodity.ID,
+		Title:        "Oil",
+		IntervalDays: 90,
+		NextDueAt:    models.Date("2026-12-01"),
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = fs.GroupTransferrer.TransferToGroup(ctx, registry.GroupTransfer{
+		TenantID:    tenantID,
+		FromGroupID: fromID,
+		ToGroupID:   toID,
+		LocationIDs: []string{loc.ID},
+		Areas:       []registry.GroupTransferArea{{ID: area.ID, LocationID: loc.ID}},
+		Commodities: []registry.GroupTransferCommodity{{Commodity: *commodity}},
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = fromSet.CommodityRegistry.Get(fromCtx, commodity.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	moved, err := toSet.CommodityRegistry.Get(toCtx, commodity.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(*moved.AreaID, qt.Equals, area.ID)
+	_, err = toSet.MaintenanceScheduleRegistry.Get(toCtx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+
+	// A second run finds nothing left in the source group and rolls back.
+	err = fs.GroupTransferrer.TransferToGroup(ctx, registry.GroupTransfer{
+		TenantID:    tenantID,
+		FromGroupID: fromID,
+		ToGroupID:   toID,
+		LocationIDs: []string{loc.ID},
+	})
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
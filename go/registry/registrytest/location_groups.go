@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -29,8 +29,8 @@ func newTestGroup(c *qt.C, tenantID, userID, name string) models.LocationGroup {
 
 // -- Create ----------------------------------------------------------------
 
-func TestLocationGroupRegistry_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationGroupRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -45,8 +45,8 @@ func TestLocationGroupRegistry_Create_HappyPath(t *testing.T) {
 	c.Assert(created.Status, qt.Equals, models.LocationGroupStatusActive)
 }
 
-func TestLocationGroupRegistry_Create_MissingFields(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationGroupRegistry_Create_MissingFields(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -76,8 +76,8 @@ func TestLocationGroupRegistry_Create_MissingFields(t *testing.T) {
 	}
 }
 
-func TestLocationGroupRegistry_Create_DuplicateSlug(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationGroupRegistry_Create_DuplicateSlug(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -99,8 +99,8 @@ func TestLocationGroupRegistry_Create_DuplicateSlug(t *testing.T) {
 
 // -- Get / GetBySlug -------------------------------------------------------
 
-func TestLocationGroupRegistry_Get_And_GetBySlug(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationGroupRegistry_Get_And_GetBySlug(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -127,8 +127,8 @@ func TestLocationGroupRegistry_Get_And_GetBySlug(t *testing.T) {
 
 // -- ListByTenant ----------------------------------------------------------
 
-func TestLocationGroupRegistry_ListByTenant(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationGroupRegistry_ListByTenant(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -151,8 +151,8 @@ func TestLocationGroupRegistry_ListByTenant(t *testing.T) {
 
 // -- Update / Delete -------------------------------------------------------
 
-func TestLocationGroupRegistry_Update_And_Delete(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationGroupRegistry_Update_And_Delete(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -10,8 +10,8 @@ import (
 	"github.com/denisvmedia/inventario/models"
 )
 
-func TestLocationRegistry_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -56,8 +56,8 @@ func TestLocationRegistry_Create_HappyPath(t *testing.T) {
 	}
 }
 
-func TestLocationRegistry_Create_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Create_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -93,8 +93,8 @@ func TestLocationRegistry_Create_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestLocationRegistry_Get_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Get_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -120,8 +120,8 @@ func TestLocationRegistry_Get_HappyPath(t *testing.T) {
 	c.Assert(result.Address, qt.Equals, created.Address)
 }
 
-func TestLocationRegistry_Get_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Get_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -158,8 +158,8 @@ func TestLocationRegistry_Get_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestLocationRegistry_List_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_List_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -196,8 +196,8 @@ func TestLocationRegistry_List_HappyPath(t *testing.T) {
 	c.Assert(locationIDs[location2.ID], qt.IsTrue)
 }
 
-func TestLocationRegistry_Update_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Update_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -232,8 +232,8 @@ func TestLocationRegistry_Update_HappyPath(t *testing.T) {
 	c.Assert(retrieved.Address, qt.Equals, "Updated Address")
 }
 
-func TestLocationRegistry_Update_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Update_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -270,8 +270,8 @@ func TestLocationRegistry_Update_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestLocationRegistry_Delete_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Delete_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -298,8 +298,8 @@ func TestLocationRegistry_Delete_HappyPath(t *testing.T) {
 	c.Assert(result, qt.IsNil)
 }
 
-func TestLocationRegistry_Delete_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Delete_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -335,8 +335,8 @@ func TestLocationRegistry_Delete_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestLocationRegistry_Delete_WithAreas_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Delete_WithAreas_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -373,8 +373,8 @@ func TestLocationRegistry_Delete_WithAreas_UnhappyPath(t *testing.T) {
 	c.Assert(areaResult, qt.IsNotNil)
 }
 
-func TestLocationRegistry_Count_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_Count_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -403,8 +403,8 @@ func TestLocationRegistry_Count_HappyPath(t *testing.T) {
 	c.Assert(count, qt.Equals, 2)
 }
 
-func TestLocationRegistry_GetAreas_WithCreatedArea_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_GetAreas_WithCreatedArea_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -429,8 +429,8 @@ func TestLocationRegistry_GetAreas_WithCreatedArea_HappyPath(t *testing.T) {
 	c.Assert(areas[0], qt.Equals, area.ID)
 }
 
-func TestLocationRegistry_GetAreas_WithInvalidLocation_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_GetAreas_WithInvalidLocation_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -467,8 +467,8 @@ func TestLocationRegistry_GetAreas_WithInvalidLocation_UnhappyPath(t *testing.T)
 	}
 }
 
-func TestLocationRegistry_GetAreas_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_GetAreas_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -508,8 +508,8 @@ func TestLocationRegistry_GetAreas_HappyPath(t *testing.T) {
 	c.Assert(areaIDs[area2.ID], qt.IsTrue)
 }
 
-func TestLocationRegistry_GetAreas_EmptyLocation_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testLocationRegistry_GetAreas_EmptyLocation_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
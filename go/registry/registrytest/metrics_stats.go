@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,33 +6,25 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
 )
 
-// TestSystemStats_CrossTenantTotals is the security-relevant regression
+// testSystemStats_CrossTenantTotals is the security-relevant regression
 // test for the business-metrics source (#843). It seeds entities in TWO
 // tenants and asserts that FactorySet.SystemStats reports the COMBINED
-// totals — proving the source runs under the RLS-bypassing
-// background-worker role and sees every tenant's rows, not just the
-// caller's. If a future change accidentally scoped SystemStats to a
-// single tenant (e.g. by routing through inventario_app), the
-// cross-tenant counts would drop and this test would fail.
-//
-// Self-skips when POSTGRES_TEST_DSN is unset (via skipIfNoPostgreSQL),
-// so it is inert under the default `make test-go`.
-func TestSystemStats_CrossTenantTotals(t *testing.T) {
-	dsn := skipIfNoPostgreSQL(t)
+// totals — proving the source runs without tenant isolation and sees
+// every tenant's rows, not just the caller's. If a future change
+// accidentally scoped SystemStats to a single tenant, the cross-tenant
+// counts would drop and this test would fail.
+func testSystemStats_CrossTenantTotals(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 	ctx := context.Background()
 
 	// Tenant A: setupTestRegistrySet bootstraps the schema and returns a
 	// user+group-aware set already scoped to tenant A's seeded user/group.
-	tenantASet, cleanup := setupTestRegistrySet(t)
+	tenantASet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	const imageSize = int64(1024)
@@ -47,7 +39,7 @@ func TestSystemStats_CrossTenantTotals(t *testing.T) {
 	// via the service (RLS-bypass) registry set, then a user+group-aware
 	// set built for it so the per-entity registries pass their tenant /
 	// group context checks.
-	serviceSet := postgres.NewFactorySet(newSystemStatsDBX(c, dsn)).CreateServiceRegistrySet()
+	serviceSet := h.factorySet(t).CreateServiceRegistrySet()
 
 	tenantB, err := serviceSet.TenantRegistry.Create(ctx, models.Tenant{
 		Name:   "Metrics Tenant B",
@@ -76,9 +68,7 @@ func TestSystemStats_CrossTenantTotals(t *testing.T) {
 	})
 	c.Assert(err, qt.IsNil)
 
-	tenantBSet := postgres.NewRegistrySetWithUserAndGroupID(
-		newSystemStatsDBX(c, dsn), userB.ID, tenantB.ID, groupB.ID,
-	)
+	tenantBSet := h.registrySetWithUserAndGroupID(t, userB.ID, tenantB.ID, groupB.ID)
 
 	locB := createTestLocation(c, tenantBSet)
 	areaB := createTestArea(c, tenantBSet, locB.ID)
@@ -86,7 +76,7 @@ func TestSystemStats_CrossTenantTotals(t *testing.T) {
 	seedImageFile(c, tenantBSet, imageSize)
 
 	// Collect installation-wide stats through the wired SystemStats source.
-	statsFn := postgres.NewFactorySet(newSystemStatsDBX(c, dsn)).SystemStats
+	statsFn := h.factorySet(t).SystemStats
 	c.Assert(statsFn, qt.IsNotNil)
 
 	stats, err := statsFn(ctx)
@@ -112,18 +102,6 @@ func TestSystemStats_CrossTenantTotals(t *testing.T) {
 		qt.Commentf("storage_images=%d", stats.StorageImages))
 }
 
-// newSystemStatsDBX opens a fresh *sqlx.DB over the shared test pool. A
-// distinct *sql.DB handle per FactorySet keeps the test from sharing
-// transaction state across the several factory sets it builds.
-func newSystemStatsDBX(c *qt.C, dsn string) *sqlx.DB {
-	c.Helper()
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	sqlDB := stdlib.OpenDBFromPool(pool)
-	c.Cleanup(func() { _ = sqlDB.Close() })
-	return sqlx.NewDb(sqlDB, "pgx")
-}
-
 // seedImageFile creates one image FileEntity with an explicit byte size
 // so the storage-breakdown SUM is non-zero and provably cross-tenant.
 func seedImageFile(c *qt.C, set *registry.Set, size int64) {
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -10,7 +10,7 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// newTestOAuthIdentity builds a valid OAuthIdentity for the postgres backend.
+// newTestOAuthIdentity builds a valid OAuthIdentity.
 // The user/tenant IDs must reference real rows (FK constraints
 // fk_oauth_identity_user / fk_entity_tenant), and the (provider,
 // provider_user_id) pair must be unique per record (UNIQUE index).
@@ -26,8 +26,8 @@ func newTestOAuthIdentity(user *models.User, provider models.OAuthProvider, prov
 	}
 }
 
-func TestOAuthIdentityRegistry_Create_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_Create_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -44,8 +44,8 @@ func TestOAuthIdentityRegistry_Create_HappyPath(t *testing.T) {
 	c.Assert(created.LinkedAt.IsZero(), qt.IsFalse)
 }
 
-func TestOAuthIdentityRegistry_Create_MissingFields(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_Create_MissingFields(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -75,11 +75,11 @@ func TestOAuthIdentityRegistry_Create_MissingFields(t *testing.T) {
 	}
 }
 
-// TestOAuthIdentityRegistry_Create_DuplicateProviderSubject_PG pins that the
-// postgres unique-constraint on (provider, provider_user_id) trips and is
-// mapped onto registry.ErrAlreadyExists (SQLSTATE 23505 → sentinel).
-func TestOAuthIdentityRegistry_Create_DuplicateProviderSubject(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+// testOAuthIdentityRegistry_Create_DuplicateProviderSubject pins that the
+// unique constraint on (provider, provider_user_id) trips and is mapped
+// onto registry.ErrAlreadyExists.
+func testOAuthIdentityRegistry_Create_DuplicateProviderSubject(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -96,8 +96,8 @@ func TestOAuthIdentityRegistry_Create_DuplicateProviderSubject(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
 }
 
-func TestOAuthIdentityRegistry_Get(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_Get(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -113,8 +113,8 @@ func TestOAuthIdentityRegistry_Get(t *testing.T) {
 	c.Assert(fetched.ProviderUserID, qt.Equals, "g-get")
 }
 
-func TestOAuthIdentityRegistry_Get_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_Get_NotFound(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -124,8 +124,8 @@ func TestOAuthIdentityRegistry_Get_NotFound(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestOAuthIdentityRegistry_GetByProviderSubject(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_GetByProviderSubject(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -146,12 +146,12 @@ func TestOAuthIdentityRegistry_GetByProviderSubject(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-// TestOAuthIdentityRegistry_ListByUser_TenantScoping pins the
-// defense-in-depth tenant filter at the postgres layer: even though service
+// testOAuthIdentityRegistry_ListByUser_TenantScoping pins the
+// defense-in-depth tenant filter at the registry layer: even though service
 // mode bypasses RLS, the explicit `tenant_id = $1` qualifier in
 // ListByUser must hide rows from other tenants.
-func TestOAuthIdentityRegistry_ListByUser_TenantScoping(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_ListByUser_TenantScoping(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -179,8 +179,8 @@ func TestOAuthIdentityRegistry_ListByUser_TenantScoping(t *testing.T) {
 	c.Assert(rows, qt.HasLen, 0)
 }
 
-func TestOAuthIdentityRegistry_GetByUserAndProvider(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_GetByUserAndProvider(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -203,11 +203,11 @@ func TestOAuthIdentityRegistry_GetByUserAndProvider(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-// TestOAuthIdentityRegistry_DeleteByUserAndProvider_Idempotent pins parity
+// testOAuthIdentityRegistry_DeleteByUserAndProvider_Idempotent pins parity
 // with the memory backend: second delete on an already removed row returns
 // nil, not ErrNotFound.
-func TestOAuthIdentityRegistry_DeleteByUserAndProvider_Idempotent(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_DeleteByUserAndProvider_Idempotent(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -228,8 +228,8 @@ func TestOAuthIdentityRegistry_DeleteByUserAndProvider_Idempotent(t *testing.T)
 	c.Assert(err, qt.IsNil)
 }
 
-func TestOAuthIdentityRegistry_Update(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_Update(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -248,8 +248,8 @@ func TestOAuthIdentityRegistry_Update(t *testing.T) {
 	c.Assert(reloaded.Email, qt.Equals, "rotated@test-org.com")
 }
 
-func TestOAuthIdentityRegistry_Delete(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_Delete(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -266,8 +266,8 @@ func TestOAuthIdentityRegistry_Delete(t *testing.T) {
 	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
 }
 
-func TestOAuthIdentityRegistry_List(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_List(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -284,8 +284,8 @@ func TestOAuthIdentityRegistry_List(t *testing.T) {
 	c.Assert(all, qt.HasLen, 2)
 }
 
-func TestOAuthIdentityRegistry_Count(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testOAuthIdentityRegistry_Count(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -11,11 +11,11 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// TestPasswordResetRegistry_Update_NotFound pins parity with the memory
+// testPasswordResetRegistry_Update_NotFound pins parity with the memory
 // backend: Update against an unknown ID returns registry.ErrNotFound rather
 // than silently succeeding with a zero-row UPDATE. See #1814.
-func TestPasswordResetRegistry_Update_NotFound(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testPasswordResetRegistry_Update_NotFound(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,31 +6,24 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 
 	"github.com/denisvmedia/inventario/models"
-	"github.com/denisvmedia/inventario/registry/postgres"
 )
 
-// TestRefreshTokenRegistryPostgres_GetByTokenHash_ReturnsRevokedRow pins the
+// testRefreshTokenRegistry_GetByTokenHash_ReturnsRevokedRow pins the
 // property the #967 reuse-detection handler depends on: GetByTokenHash returns
 // a row even after it has been revoked (it does NOT filter on
 // revoked_at IS NULL). Without this, a replayed-after-rotation cookie would
 // surface as ErrNotFound and the theft cascade could never fire. Exercised in
 // service mode (RLS-bypass) because /auth/refresh resolves the row in service
 // mode.
-func TestRefreshTokenRegistryPostgres_GetByTokenHash_ReturnsRevokedRow(t *testing.T) {
+func testRefreshTokenRegistry_GetByTokenHash_ReturnsRevokedRow(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	set, _ := setupTestRegistrySet(t)
+	set, _ := h.setupTestRegistrySet(t)
 	user := getTestUser(c, set)
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	fs := postgres.NewFactorySet(dbx)
+	fs := h.factorySet(t)
 	serviceSet := fs.CreateServiceRegistrySet()
 	r := serviceSet.RefreshTokenRegistry
 
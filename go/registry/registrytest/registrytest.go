@@ -0,0 +1,134 @@
+// Package registrytest holds the registry tests every SQL registry backend
+// runs. A backend's test package implements Harness and calls Run; the
+// tests only reach the backend through the registry.FactorySet the
+// harness builds, so the same assertions cover every backend.
+package registrytest
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// Harness opens stores of the backend under test.
+type Harness interface {
+	// OpenStore returns a freshly migrated store holding no rows. The
+	// harness releases it when t finishes; it skips t when the backend
+	// is not available (e.g. no test database configured).
+	OpenStore(t *testing.T) Store
+}
+
+// Store is one database of the backend under test.
+type Store interface {
+	// FactorySet returns a factory set over the store. Every factory set
+	// of a store sees the same data.
+	FactorySet() *registry.FactorySet
+	// CurrencyMigrationProcessor returns the processor that completes
+	// claimed currency migrations.
+	CurrencyMigrationProcessor() services.CurrencyMigrationProcessor
+	// DoAsBackgroundWorker runs fn in a transaction that sees the rows of
+	// every tenant and group.
+	DoAsBackgroundWorker(ctx context.Context, fn func(context.Context, *sqlx.Tx) error) error
+}
+
+// Run runs every shared registry test against the backend h opens.
+func Run(t *testing.T, h Harness) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fn(t, &testHarness{harness: h})
+		})
+	}
+}
+
+// testHarness is what one shared test sees of the backend: the harness and
+// the store the test currently works on. Subtests share it, so a subtest
+// that opens a fresh store replaces the one its siblings see, as the
+// per-backend suites did when each test reset the database.
+type testHarness struct {
+	harness Harness
+	store   Store
+}
+
+// currentStore returns the store the test works on, opening one on first
+// use.
+func (h *testHarness) currentStore(t *testing.T) Store {
+	t.Helper()
+	if h.store == nil {
+		h.open(t)
+	}
+	return h.store
+}
+
+// open opens a new store and makes it the current one until t finishes.
+func (h *testHarness) open(t *testing.T) {
+	t.Helper()
+	store := h.harness.OpenStore(t)
+	h.store = store
+	t.Cleanup(func() {
+		if h.store == store {
+			h.store = nil
+		}
+	})
+}
+
+// factorySet returns a factory set over the test's current store.
+func (h *testHarness) factorySet(t *testing.T) *registry.FactorySet {
+	t.Helper()
+	return h.currentStore(t).FactorySet()
+}
+
+// freshFactorySet opens a new empty store, makes it the test's current one
+// and returns a factory set over it, with no tenants, users or groups
+// seeded.
+func (h *testHarness) freshFactorySet(t *testing.T) *registry.FactorySet {
+	t.Helper()
+	h.open(t)
+	return h.store.FactorySet()
+}
+
+// registrySetWithUserAndGroupID returns a registry set over the test's
+// current store scoped to the given user and group. groupID may be empty
+// for user-scoped registries.
+func (h *testHarness) registrySetWithUserAndGroupID(t *testing.T, userID, tenantID, groupID string) *registry.Set {
+	t.Helper()
+
+	ctx := appctx.WithUser(context.Background(), &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: userID},
+			TenantID: tenantID,
+		},
+	})
+	if groupID != "" {
+		ctx = appctx.WithGroup(ctx, &models.LocationGroup{
+			TenantAwareEntityID: models.TenantAwareEntityID{
+				EntityID: models.EntityID{ID: groupID},
+				TenantID: tenantID,
+			},
+		})
+	}
+
+	set, err := h.factorySet(t).CreateUserRegistrySet(ctx)
+	qt.New(t).Assert(err, qt.IsNil)
+	return set
+}
+
+// currencyMigrationProcessor returns the processor of the test's current
+// store.
+func (h *testHarness) currencyMigrationProcessor(t *testing.T) services.CurrencyMigrationProcessor {
+	t.Helper()
+	return h.currentStore(t).CurrencyMigrationProcessor()
+}
+
+// doAsBackgroundWorker runs fn as a background worker on the test's
+// current store.
+func (h *testHarness) doAsBackgroundWorker(t *testing.T, fn func(context.Context, *sqlx.Tx) error) error {
+	t.Helper()
+	return h.currentStore(t).DoAsBackgroundWorker(context.Background(), fn)
+}
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -10,8 +10,8 @@ import (
 	"github.com/denisvmedia/inventario/models"
 )
 
-func TestSettingsRegistry_Get_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Get_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -31,8 +31,8 @@ func TestSettingsRegistry_Get_HappyPath(t *testing.T) {
 	c.Assert(settings.DefaultDateFormat, qt.IsNil)
 }
 
-func TestSettingsRegistry_Save_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Save_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -96,8 +96,8 @@ func TestSettingsRegistry_Save_HappyPath(t *testing.T) {
 	}
 }
 
-func TestSettingsRegistry_Save_UpdateExisting_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Save_UpdateExisting_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -135,8 +135,8 @@ func TestSettingsRegistry_Save_UpdateExisting_HappyPath(t *testing.T) {
 	c.Assert(*retrievedSettings.DefaultDateFormat, qt.Equals, "02/01/2006")
 }
 
-func TestSettingsRegistry_Patch_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Patch_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -192,8 +192,8 @@ func TestSettingsRegistry_Patch_HappyPath(t *testing.T) {
 	}
 }
 
-func TestSettingsRegistry_Patch_UpdateExisting_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Patch_UpdateExisting_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -214,8 +214,8 @@ func TestSettingsRegistry_Patch_UpdateExisting_HappyPath(t *testing.T) {
 	c.Assert(*retrievedSettings.Theme, qt.Equals, "light")
 }
 
-func TestSettingsRegistry_Patch_UnhappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Patch_UnhappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
@@ -246,8 +246,8 @@ func TestSettingsRegistry_Patch_UnhappyPath(t *testing.T) {
 	}
 }
 
-func TestSettingsRegistry_MixedOperations_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_MixedOperations_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -300,8 +300,8 @@ func TestSettingsRegistry_MixedOperations_HappyPath(t *testing.T) {
 	c.Assert(*settings.DefaultDateFormat, qt.Equals, "2006-01-02")
 }
 
-func TestSettingsRegistry_Save_EmptySettings_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Save_EmptySettings_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -320,8 +320,8 @@ func TestSettingsRegistry_Save_EmptySettings_HappyPath(t *testing.T) {
 	c.Assert(retrievedSettings.DefaultDateFormat, qt.IsNil)
 }
 
-func TestSettingsRegistry_Patch_ComplexValues_HappyPath(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSettingsRegistry_Patch_ComplexValues_HappyPath(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	testCases := []struct {
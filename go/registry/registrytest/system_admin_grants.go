@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -51,8 +51,8 @@ func resetGrants(c *qt.C, registrySet *registry.Set) {
 	}
 }
 
-func TestSystemAdminGrantRegistry_Postgres_Grant_Idempotent(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_Grant_Idempotent(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -74,8 +74,8 @@ func TestSystemAdminGrantRegistry_Postgres_Grant_Idempotent(t *testing.T) {
 	c.Assert(grants[0].UserID, qt.Equals, userID)
 }
 
-func TestSystemAdminGrantRegistry_Postgres_Exists_AfterGrantAndRevoke(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_Exists_AfterGrantAndRevoke(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -109,8 +109,8 @@ func TestSystemAdminGrantRegistry_Postgres_Exists_AfterGrantAndRevoke(t *testing
 	c.Assert(exists, qt.IsFalse)
 }
 
-func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_NoGrant(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_RevokeAtomic_NoGrant(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -123,8 +123,8 @@ func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_NoGrant(t *testing.T) {
 	c.Assert(hadGrant, qt.IsFalse)
 }
 
-func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_LastAdminGuard(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_RevokeAtomic_LastAdminGuard(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -145,8 +145,8 @@ func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_LastAdminGuard(t *testin
 	c.Assert(exists, qt.IsTrue)
 }
 
-func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_AllowZeroBypass(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_RevokeAtomic_AllowZeroBypass(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -166,12 +166,12 @@ func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_AllowZeroBypass(t *testi
 	c.Assert(grants, qt.HasLen, 0)
 }
 
-// TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_NoConcurrentLastAdminRevoke
-// pins the postgres race-safety guarantee. The advisory lock + FOR
-// UPDATE on the candidate row serialise the two goroutines so exactly
+// testSystemAdminGrantRegistry_RevokeAtomic_NoConcurrentLastAdminRevoke
+// pins the race-safety guarantee. The backend's locking (advisory lock
+// + FOR UPDATE, or a write transaction) serialises the two goroutines so exactly
 // one ends with hadGrant=true and exactly one hits ErrLastSystemAdmin.
-func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_NoConcurrentLastAdminRevoke(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_RevokeAtomic_NoConcurrentLastAdminRevoke(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -219,8 +219,8 @@ func TestSystemAdminGrantRegistry_Postgres_RevokeAtomic_NoConcurrentLastAdminRev
 	c.Assert(grants, qt.HasLen, 1)
 }
 
-func TestSystemAdminGrantRegistry_Postgres_List_OrderedByGrantedAt(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_List_OrderedByGrantedAt(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -242,8 +242,8 @@ func TestSystemAdminGrantRegistry_Postgres_List_OrderedByGrantedAt(t *testing.T)
 	c.Assert(ids, qt.DeepEquals, []string{first, second, third})
 }
 
-func TestSystemAdminGrantRegistry_Postgres_Exists_EmptyUserID(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_Exists_EmptyUserID(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -255,11 +255,11 @@ func TestSystemAdminGrantRegistry_Postgres_Exists_EmptyUserID(t *testing.T) {
 		qt.Commentf("expected ErrFieldRequired, got %v", err))
 }
 
-// TestSystemAdminGrantRegistry_Postgres_UserDelete_CascadesGrant pins
+// testSystemAdminGrantRegistry_UserDelete_CascadesGrant pins
 // the FK ON DELETE CASCADE on user_id: hard-deleting a user MUST
 // remove their grant row so there are no dangling FK references.
-func TestSystemAdminGrantRegistry_Postgres_UserDelete_CascadesGrant(t *testing.T) {
-	registrySet, cleanup := setupTestRegistrySet(t)
+func testSystemAdminGrantRegistry_UserDelete_CascadesGrant(t *testing.T, h *testHarness) {
+	registrySet, cleanup := h.setupTestRegistrySet(t)
 	defer cleanup()
 
 	c := qt.New(t)
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,18 +6,16 @@ import (
 	"testing"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
 	"github.com/denisvmedia/inventario/services"
 )
 
-// tagPGFixture wires what every postgres tag test needs:
+// tagFixture wires what every tag test needs:
 //   - a shared *FactorySet for service-layer code (TagService) that resolves
 //     the group from ctx;
 //   - one user-aware *Set per group (registries with their groupID baked in,
@@ -26,7 +24,7 @@ import (
 //
 // The fixture is sized for two groups so cross-group RLS isolation can be
 // exercised — the most load-bearing assertion in #1488 beyond the SQL itself.
-type tagPGFixture struct {
+type tagFixture struct {
 	factorySet *registry.FactorySet
 	groupASet  *registry.Set
 	groupBSet  *registry.Set
@@ -37,23 +35,18 @@ type tagPGFixture struct {
 	groupBID   string
 	areaAID    string
 	areaBID    string
-	dbx        *sqlx.DB
 }
 
-// newTagPGFixture re-creates the schema, seeds tenant+user+two groups, and
-// returns a fixture. setupTestRegistrySet drops + bootstraps before this
+// newTagFixture opens a fresh store, seeds tenant+user+two groups, and
+// returns a fixture. setupTestRegistrySet opens the store before this
 // returns, so each call is hermetic.
-func newTagPGFixture(t *testing.T) tagPGFixture {
+func newTagFixture(t *testing.T, h *testHarness) tagFixture {
 	t.Helper()
 	c := qt.New(t)
 
-	groupASet, _ := setupTestRegistrySet(t)
+	groupASet, _ := h.setupTestRegistrySet(t)
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	factorySet := postgres.NewFactorySet(dbx)
+	factorySet := h.factorySet(t)
 
 	user := getTestUser(c, groupASet)
 
@@ -79,7 +72,7 @@ func newTagPGFixture(t *testing.T) tagPGFixture {
 	c.Assert(err, qt.IsNil)
 	groupBID := groupB.ID
 
-	groupBSet := postgres.NewRegistrySetWithUserAndGroupID(dbx, user.ID, user.TenantID, groupBID)
+	groupBSet := h.registrySetWithUserAndGroupID(t, user.ID, user.TenantID, groupBID)
 
 	ctxA := tagCtxFor(user, groupAID)
 	ctxB := tagCtxFor(user, groupBID)
@@ -87,7 +80,7 @@ func newTagPGFixture(t *testing.T) tagPGFixture {
 	areaAID := seedTagArea(c, groupASet, ctxA)
 	areaBID := seedTagArea(c, groupBSet, ctxB)
 
-	return tagPGFixture{
+	return tagFixture{
 		factorySet: factorySet,
 		groupASet:  groupASet,
 		groupBSet:  groupBSet,
@@ -98,7 +91,6 @@ func newTagPGFixture(t *testing.T) tagPGFixture {
 		groupBID:   groupBID,
 		areaAID:    areaAID,
 		areaBID:    areaBID,
-		dbx:        dbx,
 	}
 }
 
@@ -179,14 +171,16 @@ func mustCreateTag(c *qt.C, reg registry.TagRegistry, ctx context.Context, kind
 	return tag
 }
 
-// rawCommodityTagsText reads the literal JSONB column as text — needed to
+// rawCommodityTagsText reads the literal JSON column as text — needed to
 // distinguish `[]` from `null`, which both round-trip to an empty Go slice
 // after StructScan.
-func rawCommodityTagsText(c *qt.C, dbx *sqlx.DB, id string) string {
-	c.Helper()
+func rawCommodityTagsText(t *testing.T, h *testHarness, id string) string {
+	t.Helper()
+	c := qt.New(t)
 	var raw *string
-	err := dbx.QueryRowxContext(context.Background(),
-		`SELECT tags::text FROM commodities WHERE id = $1`, id).Scan(&raw)
+	err := h.doAsBackgroundWorker(t, func(ctx context.Context, tx *sqlx.Tx) error {
+		return tx.QueryRowxContext(ctx, `SELECT CAST(tags AS TEXT) FROM commodities WHERE id = $1`, id).Scan(&raw)
+	})
 	c.Assert(err, qt.IsNil)
 	if raw == nil {
 		return "null"
@@ -194,12 +188,12 @@ func rawCommodityTagsText(c *qt.C, dbx *sqlx.DB, id string) string {
 	return *raw
 }
 
-// TestTagRegistry_Postgres_SameSlugDifferentKinds pins the core split
+// testTagRegistry_SameSlugDifferentKinds pins the core split
 // invariant at the DB level: the (group_id, kind, slug) unique index lets
 // the same slug exist once per kind as two distinct rows.
-func TestTagRegistry_Postgres_SameSlugDifferentKinds(t *testing.T) {
+func testTagRegistry_SameSlugDifferentKinds(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	commTag := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "warranty")
 	fileTag := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindFile, "warranty")
@@ -213,9 +207,9 @@ func TestTagRegistry_Postgres_SameSlugDifferentKinds(t *testing.T) {
 	c.Assert(gotFile.ID, qt.Equals, fileTag.ID)
 }
 
-func TestTagRegistry_Postgres_RewriteSlugReferences(t *testing.T) {
+func testTagRegistry_RewriteSlugReferences(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	cmdID := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "fridge", "kitchen", "appliance")
 	fileID := seedTagFile(c, fx.groupASet, fx.ctxA, "fridge-photo", "kitchen")
@@ -248,9 +242,9 @@ func TestTagRegistry_Postgres_RewriteSlugReferences(t *testing.T) {
 	c.Assert([]string(file.Tags), qt.DeepEquals, []string{"kitchen-area"})
 }
 
-func TestTagRegistry_Postgres_RewriteSlugReferences_DedupOnRenameOntoExisting(t *testing.T) {
+func testTagRegistry_RewriteSlugReferences_DedupOnRenameOntoExisting(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	// Row already contains both old and new slugs — DISTINCT in jsonb_agg
 	// must collapse them to a single occurrence post-rewrite.
@@ -267,9 +261,9 @@ func TestTagRegistry_Postgres_RewriteSlugReferences_DedupOnRenameOntoExisting(t
 	c.Assert([]string(cmd.Tags), qt.Contains, "appliance")
 }
 
-func TestTagRegistry_Postgres_RewriteSlugReferences_CrossGroupIsolation(t *testing.T) {
+func testTagRegistry_RewriteSlugReferences_CrossGroupIsolation(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	cmdA := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "in-A", "kitchen")
 	cmdB := seedTagCommodity(c, fx.groupBSet, fx.ctxB, fx.areaBID, "in-B", "kitchen")
@@ -295,9 +289,9 @@ func TestTagRegistry_Postgres_RewriteSlugReferences_CrossGroupIsolation(t *testi
 	c.Assert([]string(gotFileB.Tags), qt.DeepEquals, []string{"kitchen"})
 }
 
-func TestTagRegistry_Postgres_RewriteSlugReferences_LeavesUnrelatedRowsAlone(t *testing.T) {
+func testTagRegistry_RewriteSlugReferences_LeavesUnrelatedRowsAlone(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	emptyID := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "no-tags")
 	otherID := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "other", "appliance")
@@ -320,9 +314,9 @@ func TestTagRegistry_Postgres_RewriteSlugReferences_LeavesUnrelatedRowsAlone(t *
 	c.Assert([]string(gotTagged.Tags), qt.DeepEquals, []string{"kitchen-area"})
 }
 
-func TestTagRegistry_Postgres_RewriteSlugReferences_NoOpWhenSlugUnchanged(t *testing.T) {
+func testTagRegistry_RewriteSlugReferences_NoOpWhenSlugUnchanged(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	cmdID := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "fridge", "kitchen")
 
@@ -336,9 +330,9 @@ func TestTagRegistry_Postgres_RewriteSlugReferences_NoOpWhenSlugUnchanged(t *tes
 	c.Assert([]string(cmd.Tags), qt.DeepEquals, []string{"kitchen"})
 }
 
-func TestTagRegistry_Postgres_StripSlugReferences(t *testing.T) {
+func testTagRegistry_StripSlugReferences(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	cmdID := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "fridge", "kitchen", "appliance")
 	fileID := seedTagFile(c, fx.groupASet, fx.ctxA, "fridge-photo", "kitchen")
@@ -359,9 +353,9 @@ func TestTagRegistry_Postgres_StripSlugReferences(t *testing.T) {
 	c.Assert([]string(file.Tags), qt.DeepEquals, []string{"kitchen"})
 }
 
-func TestTagRegistry_Postgres_StripSlugReferences_EmptyArrayNotNull(t *testing.T) {
+func testTagRegistry_StripSlugReferences_EmptyArrayNotNull(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	// Single-tag row → after strip the JSONB value must persist as `[]`,
 	// not `null`. Mismatch would break downstream code that calls
@@ -371,12 +365,12 @@ func TestTagRegistry_Postgres_StripSlugReferences_EmptyArrayNotNull(t *testing.T
 	_, _, err := fx.groupASet.TagRegistry.StripSlugReferences(fx.ctxA, models.TagKindCommodity, "kitchen")
 	c.Assert(err, qt.IsNil)
 
-	c.Assert(rawCommodityTagsText(c, fx.dbx, cmdID), qt.Equals, "[]")
+	c.Assert(rawCommodityTagsText(t, h, cmdID), qt.Equals, "[]")
 }
 
-func TestTagRegistry_Postgres_StripSlugReferences_CrossGroupIsolation(t *testing.T) {
+func testTagRegistry_StripSlugReferences_CrossGroupIsolation(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	cmdA := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "in-A", "kitchen", "appliance")
 	cmdB := seedTagCommodity(c, fx.groupBSet, fx.ctxB, fx.areaBID, "in-B", "kitchen")
@@ -394,12 +388,12 @@ func TestTagRegistry_Postgres_StripSlugReferences_CrossGroupIsolation(t *testing
 	c.Assert([]string(gotB.Tags), qt.DeepEquals, []string{"kitchen"})
 }
 
-// TestTagService_Postgres_RenameTag_RefusesPreemptivelyOnSlugClash asserts
+// testTagService_RenameTag_RefusesPreemptivelyOnSlugClash asserts
 // the slug-clash check fires before any JSONB UPDATE — partial rewrite
 // would be the worst-of-both-worlds outcome.
-func TestTagService_Postgres_RenameTag_RefusesPreemptivelyOnSlugClash(t *testing.T) {
+func testTagService_RenameTag_RefusesPreemptivelyOnSlugClash(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	srcTag := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen")
 	_ = mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen-area")
@@ -417,12 +411,12 @@ func TestTagService_Postgres_RenameTag_RefusesPreemptivelyOnSlugClash(t *testing
 		qt.Commentf("JSONB must be untouched when rename is refused — no partial rewrite"))
 }
 
-// TestTagService_Postgres_RenameTag_SameSlugDifferentKindNoClash asserts a
+// testTagService_RenameTag_SameSlugDifferentKindNoClash asserts a
 // commodity-tag rename onto a slug that exists only as a *file* tag succeeds
 // — uniqueness is per (group, kind, slug), so the kinds don't collide.
-func TestTagService_Postgres_RenameTag_SameSlugDifferentKindNoClash(t *testing.T) {
+func testTagService_RenameTag_SameSlugDifferentKindNoClash(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	srcTag := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen")
 	_ = mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindFile, "kitchen-area")
@@ -434,12 +428,12 @@ func TestTagService_Postgres_RenameTag_SameSlugDifferentKindNoClash(t *testing.T
 	c.Assert(updated.Kind, qt.Equals, models.TagKindCommodity)
 }
 
-// TestTagService_Postgres_RenameTag_ParallelDifferentSourceSlugs covers two
+// testTagService_RenameTag_ParallelDifferentSourceSlugs covers two
 // renames operating on distinct tags in the same group: they share no
 // row-level state, so both must succeed and both rewrites must land.
-func TestTagService_Postgres_RenameTag_ParallelDifferentSourceSlugs(t *testing.T) {
+func testTagService_RenameTag_ParallelDifferentSourceSlugs(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	tagA := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "alpha")
 	tagB := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "beta")
@@ -484,15 +478,15 @@ func TestTagService_Postgres_RenameTag_ParallelDifferentSourceSlugs(t *testing.T
 	c.Assert(tagBFinal.Slug, qt.Equals, "beta-2")
 }
 
-// TestTagService_Postgres_RenameTag_ParallelSameSourceSlug is the racey one:
+// testTagService_RenameTag_ParallelSameSourceSlug is the racey one:
 // two parallel renames target the same tag id but pick different new slugs.
 // The invariant the issue calls out — "JSONB references match the surviving
 // tags row's slug" — is what we assert. If the implementation can't deliver
 // it, this test fails and exposes a real concurrency gap; we don't soften
 // the assertion to keep the bar honest.
-func TestTagService_Postgres_RenameTag_ParallelSameSourceSlug(t *testing.T) {
+func testTagService_RenameTag_ParallelSameSourceSlug(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	srcTag := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen")
 	cmdID := seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "fridge", "kitchen")
@@ -529,7 +523,7 @@ func TestTagService_Postgres_RenameTag_ParallelSameSourceSlug(t *testing.T) {
 			cmd.Tags, finalTag.Slug, errA, errB))
 }
 
-// TestTagService_Postgres_DeleteTag_ForceUnderConcurrentInsert covers the
+// testTagService_DeleteTag_ForceUnderConcurrentInsert covers the
 // atomicity invariant from the issue: under a force-delete racing with a
 // concurrent commodity insert that references the same slug, no commodity
 // may end up referencing a slug whose tags row no longer exists.
@@ -540,9 +534,9 @@ func TestTagService_Postgres_RenameTag_ParallelSameSourceSlug(t *testing.T) {
 //     same slug, commodity points at the fresh row.
 //
 // What we refuse: a JSONB reference to a slug with no matching row.
-func TestTagService_Postgres_DeleteTag_ForceUnderConcurrentInsert(t *testing.T) {
+func testTagService_DeleteTag_ForceUnderConcurrentInsert(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	srcTag := mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen")
 	_ = seedTagCommodity(c, fx.groupASet, fx.ctxA, fx.areaAID, "existing", "kitchen")
@@ -587,13 +581,13 @@ func TestTagService_Postgres_DeleteTag_ForceUnderConcurrentInsert(t *testing.T)
 	}
 }
 
-// TestTagRegistry_Postgres_SearchByKind verifies the intrinsic kind filter on
+// testTagRegistry_SearchByKind verifies the intrinsic kind filter on
 // the autocomplete (Search) endpoint: a commodity input only ever sees
 // commodity tags (including zero-usage ones), a file input only file tags,
 // and the same slug appears under both kinds as two distinct rows.
-func TestTagRegistry_Postgres_SearchByKind(t *testing.T) {
+func testTagRegistry_SearchByKind(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen")
 	mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "warranty")
@@ -628,11 +622,11 @@ func searchSlugs(c *qt.C, reg registry.TagRegistry, ctx context.Context, kind mo
 	return out
 }
 
-// TestTagRegistry_Postgres_ListPaginatedByKind mirrors SearchByKind for the
+// testTagRegistry_ListPaginatedByKind mirrors SearchByKind for the
 // paginated listing endpoint. Asserts both filter + total count.
-func TestTagRegistry_Postgres_ListPaginatedByKind(t *testing.T) {
+func testTagRegistry_ListPaginatedByKind(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen")
 	mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "warranty")
@@ -672,11 +666,11 @@ func TestTagRegistry_Postgres_ListPaginatedByKind(t *testing.T) {
 	c.Assert(got, qt.HasLen, 4)
 }
 
-// TestTagRegistry_Postgres_GetStats_PerKindTotals asserts the per-kind tag
+// testTagRegistry_GetStats_PerKindTotals asserts the per-kind tag
 // totals added for the split-view stats bar.
-func TestTagRegistry_Postgres_GetStats_PerKindTotals(t *testing.T) {
+func testTagRegistry_GetStats_PerKindTotals(t *testing.T, h *testHarness) {
 	c := qt.New(t)
-	fx := newTagPGFixture(t)
+	fx := newTagFixture(t, h)
 
 	mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "kitchen")
 	mustCreateTag(c, fx.groupASet.TagRegistry, fx.ctxA, models.TagKindCommodity, "warranty")
@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,15 +6,12 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/denisvmedia/inventario/appctx"
 	_ "github.com/denisvmedia/inventario/internal/fileblob" // register file:// driver
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
-	"github.com/denisvmedia/inventario/registry/postgres/store"
 )
 
 // seededTenant bundles the ids a purge test needs to assert against one tenant.
@@ -27,8 +24,9 @@ type seededTenant struct {
 // hierarchy (location/area/commodity), a file, a tag, and the two tenant-only
 // auth tables (refresh_tokens, login_events). It returns the ids so the test
 // can prove they vanish (or survive) after a purge.
-func seedTenantWithDependents(c *qt.C, ctx context.Context, fs *registry.FactorySet, dbx *sqlx.DB, slug, email string) seededTenant {
-	c.Helper()
+func seedTenantWithDependents(t *testing.T, h *testHarness, ctx context.Context, fs *registry.FactorySet, slug, email string) seededTenant {
+	t.Helper()
+	c := qt.New(t)
 
 	svc := fs.CreateServiceRegistrySet()
 
@@ -64,7 +62,7 @@ func seedTenantWithDependents(c *qt.C, ctx context.Context, fs *registry.Factory
 	// User+group-aware set so the inventory/file/tag seeds land with the right
 	// tenant + group + created_by stamped from context. The seed helpers read
 	// the user + group (incl. currency) off the context for validation.
-	userSet := postgres.NewRegistrySetWithUserAndGroupID(dbx, createdUser.ID, tenant.ID, group.ID)
+	userSet := h.registrySetWithUserAndGroupID(t, createdUser.ID, tenant.ID, group.ID)
 	userCtx := appctx.WithUser(ctx, createdUser)
 	userCtx = appctx.WithGroup(userCtx, &models.LocationGroup{
 		TenantAwareEntityID: models.TenantAwareEntityID{
@@ -103,41 +101,37 @@ func seedTenantWithDependents(c *qt.C, ctx context.Context, fs *registry.Factory
 	return seededTenant{tenantID: tenant.ID}
 }
 
-// countRowsForTenant counts rows in a table for one tenant, reading under the
-// background-worker role so RLS doesn't hide foreign-tenant rows (the same
-// bypass the purger itself relies on).
-func countRowsForTenant(c *qt.C, dbx *sqlx.DB, table, tenantID string) int {
-	c.Helper()
+// countRowsForTenant counts rows in a table for one tenant, reading as a
+// background worker so tenant isolation doesn't hide foreign-tenant rows (the
+// same bypass the purger itself relies on).
+func countRowsForTenant(t *testing.T, h *testHarness, table, tenantID string) int {
+	t.Helper()
+	c := qt.New(t)
 	var n int
-	err := store.DoAsBackgroundWorker(context.Background(), dbx, func(ctx context.Context, tx *sqlx.Tx) error {
+	err := h.doAsBackgroundWorker(t, func(ctx context.Context, tx *sqlx.Tx) error {
 		return tx.GetContext(ctx, &n, "SELECT COUNT(*) FROM "+table+" WHERE tenant_id = $1", tenantID)
 	})
 	c.Assert(err, qt.IsNil)
 	return n
 }
 
-// TestTenantPurger_Postgres_PurgesAllTenantDependents is the #2115 regression:
+// testTenantPurger_PurgesAllTenantDependents is the #2115 regression:
 // a tenant carrying data across the FK graph (inventory hierarchy, file, tag,
 // group, user, refresh_token, login_event) must be fully cleared by
 // PurgeTenantDependents so the subsequent tenants DELETE succeeds. A second
 // tenant's rows must survive untouched, and a repeat purge must be a no-op.
-func TestTenantPurger_Postgres_PurgesAllTenantDependents(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testTenantPurger_PurgesAllTenantDependents(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
+	a := seedTenantWithDependents(t, h, ctx, fs, "tenant-a", "admin@a.example")
+	b := seedTenantWithDependents(t, h, ctx, fs, "tenant-b", "admin@b.example")
 
-	a := seedTenantWithDependents(c, ctx, fs, dbx, "tenant-a", "admin@a.example")
-	b := seedTenantWithDependents(c, ctx, fs, dbx, "tenant-b", "admin@b.example")
+	purger := fs.TenantPurger
 
-	purger := postgres.NewTenantPurger(dbx)
-
-	err = purger.PurgeTenantDependents(ctx, a.tenantID)
+	err := purger.PurgeTenantDependents(ctx, a.tenantID)
 	c.Assert(err, qt.IsNil)
 
 	// Every dependent table is empty for tenant A.
@@ -145,7 +139,7 @@ func TestTenantPurger_Postgres_PurgesAllTenantDependents(t *testing.T) {
 		"locations", "areas", "commodities", "files", "tags",
 		"location_groups", "users", "refresh_tokens", "login_events",
 	} {
-		c.Assert(countRowsForTenant(c, dbx, table, a.tenantID), qt.Equals, 0,
+		c.Assert(countRowsForTenant(t, h, table, a.tenantID), qt.Equals, 0,
 			qt.Commentf("table %s should be empty for purged tenant A", table))
 	}
 
@@ -161,7 +155,7 @@ func TestTenantPurger_Postgres_PurgesAllTenantDependents(t *testing.T) {
 		"locations", "areas", "commodities", "files", "tags",
 		"location_groups", "users", "refresh_tokens", "login_events",
 	} {
-		c.Assert(countRowsForTenant(c, dbx, table, b.tenantID) > 0, qt.IsTrue,
+		c.Assert(countRowsForTenant(t, h, table, b.tenantID) > 0, qt.IsTrue,
 			qt.Commentf("table %s must still carry tenant B rows", table))
 	}
 
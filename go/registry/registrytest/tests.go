@@ -0,0 +1,246 @@
+package registrytest
+
+import "testing"
+
+// tests lists the shared registry tests Run runs, grouped by file.
+var tests = []struct {
+	name string
+	fn   func(t *testing.T, h *testHarness)
+}{
+	{"TestAreaRegistry_Create_HappyPath", testAreaRegistry_Create_HappyPath},
+	{"TestAreaRegistry_Create_UnhappyPath", testAreaRegistry_Create_UnhappyPath},
+	{"TestAreaRegistry_Get_HappyPath", testAreaRegistry_Get_HappyPath},
+	{"TestAreaRegistry_Get_UnhappyPath", testAreaRegistry_Get_UnhappyPath},
+	{"TestAreaRegistry_List_HappyPath", testAreaRegistry_List_HappyPath},
+	{"TestAreaRegistry_Update_HappyPath", testAreaRegistry_Update_HappyPath},
+	{"TestAreaRegistry_Update_VersionConflict", testAreaRegistry_Update_VersionConflict},
+	{"TestAreaRegistry_Delete_VersionConflict", testAreaRegistry_Delete_VersionConflict},
+	{"TestAreaRegistry_Update_UnhappyPath", testAreaRegistry_Update_UnhappyPath},
+	{"TestAreaRegistry_Delete_HappyPath", testAreaRegistry_Delete_HappyPath},
+	{"TestAreaRegistry_Delete_UnhappyPath", testAreaRegistry_Delete_UnhappyPath},
+	{"TestAreaRegistry_Delete_WithCommodities_UnhappyPath", testAreaRegistry_Delete_WithCommodities_UnhappyPath},
+	{"TestAreaRegistry_Count_HappyPath", testAreaRegistry_Count_HappyPath},
+	{"TestAreaRegistry_GetCommodities_WithCreatedCommodity_HappyPath", testAreaRegistry_GetCommodities_WithCreatedCommodity_HappyPath},
+	{"TestAreaRegistry_GetCommodities_WithInvalidArea_UnhappyPath", testAreaRegistry_GetCommodities_WithInvalidArea_UnhappyPath},
+	{"TestAreaRegistry_GetCommodities_HappyPath", testAreaRegistry_GetCommodities_HappyPath},
+	{"TestAreaRegistry_GetCommodities_EmptyArea_HappyPath", testAreaRegistry_GetCommodities_EmptyArea_HappyPath},
+
+	{"TestBackofficeRefreshTokenRegistry_Create_HappyPath", testBackofficeRefreshTokenRegistry_Create_HappyPath},
+	{"TestBackofficeRefreshTokenRegistry_Create_MissingFields", testBackofficeRefreshTokenRegistry_Create_MissingFields},
+	{"TestBackofficeRefreshTokenRegistry_GetByHash_HappyPath", testBackofficeRefreshTokenRegistry_GetByHash_HappyPath},
+	{"TestBackofficeRefreshTokenRegistry_GetByHash_NotFound", testBackofficeRefreshTokenRegistry_GetByHash_NotFound},
+	{"TestBackofficeRefreshTokenRegistry_Revoke_HappyPath", testBackofficeRefreshTokenRegistry_Revoke_HappyPath},
+	{"TestBackofficeRefreshTokenRegistry_Revoke_Idempotent", testBackofficeRefreshTokenRegistry_Revoke_Idempotent},
+	{"TestBackofficeRefreshTokenRegistry_Revoke_WrongUser", testBackofficeRefreshTokenRegistry_Revoke_WrongUser},
+	{"TestBackofficeRefreshTokenRegistry_ListActive", testBackofficeRefreshTokenRegistry_ListActive},
+	{"TestBackofficeRefreshTokenRegistry_RevokeByBackofficeUserID", testBackofficeRefreshTokenRegistry_RevokeByBackofficeUserID},
+	{"TestBackofficeRefreshTokenRegistry_BumpLastUsedAt_HappyPath", testBackofficeRefreshTokenRegistry_BumpLastUsedAt_HappyPath},
+	{"TestBackofficeRefreshTokenRegistry_BumpLastUsedAt_WrongUser", testBackofficeRefreshTokenRegistry_BumpLastUsedAt_WrongUser},
+	{"TestBackofficeRefreshTokenRegistry_DeleteExpired", testBackofficeRefreshTokenRegistry_DeleteExpired},
+
+	{"TestBackofficeUserRegistry_Create_HappyPath", testBackofficeUserRegistry_Create_HappyPath},
+	{"TestBackofficeUserRegistry_Create_LowercasesEmail", testBackofficeUserRegistry_Create_LowercasesEmail},
+	{"TestBackofficeUserRegistry_Create_MissingFields", testBackofficeUserRegistry_Create_MissingFields},
+	{"TestBackofficeUserRegistry_Create_InvalidRole", testBackofficeUserRegistry_Create_InvalidRole},
+	{"TestBackofficeUserRegistry_Create_RejectsMalformedEmail", testBackofficeUserRegistry_Create_RejectsMalformedEmail},
+	{"TestBackofficeUserRegistry_Create_DuplicateEmail", testBackofficeUserRegistry_Create_DuplicateEmail},
+	{"TestBackofficeUserRegistry_Get", testBackofficeUserRegistry_Get},
+	{"TestBackofficeUserRegistry_Get_NotFound", testBackofficeUserRegistry_Get_NotFound},
+	{"TestBackofficeUserRegistry_GetByEmail_CaseInsensitive", testBackofficeUserRegistry_GetByEmail_CaseInsensitive},
+	{"TestBackofficeUserRegistry_GetByEmail_NotFound", testBackofficeUserRegistry_GetByEmail_NotFound},
+	{"TestBackofficeUserRegistry_GetByEmail_WhitespaceOnly", testBackofficeUserRegistry_GetByEmail_WhitespaceOnly},
+	{"TestBackofficeUserRegistry_Update_PreservesPasswordHash", testBackofficeUserRegistry_Update_PreservesPasswordHash},
+	{"TestBackofficeUserRegistry_Update_RejectsEmailCollision", testBackofficeUserRegistry_Update_RejectsEmailCollision},
+	{"TestBackofficeUserRegistry_Update_NotFoundOnDeletedRow", testBackofficeUserRegistry_Update_NotFoundOnDeletedRow},
+	{"TestBackofficeUserRegistry_Delete", testBackofficeUserRegistry_Delete},
+	{"TestBackofficeUserRegistry_Delete_Idempotent", testBackofficeUserRegistry_Delete_Idempotent},
+	{"TestBackofficeUserRegistry_Count", testBackofficeUserRegistry_Count},
+	{"TestBackofficeUserRegistry_List_DistinctRowsAndOrder", testBackofficeUserRegistry_List_DistinctRowsAndOrder},
+	{"TestBackofficeUserRegistry_SetPasswordHash", testBackofficeUserRegistry_SetPasswordHash},
+	{"TestBackofficeUserRegistry_SetPasswordHash_NotFound", testBackofficeUserRegistry_SetPasswordHash_NotFound},
+	{"TestBackofficeUserRegistry_UpdateLastLogin", testBackofficeUserRegistry_UpdateLastLogin},
+	{"TestBackofficeUserRegistry_SetActive", testBackofficeUserRegistry_SetActive},
+
+	{"TestCommodityRegistry_Create_HappyPath", testCommodityRegistry_Create_HappyPath},
+	{"TestCommodityRegistry_Create_UnhappyPath", testCommodityRegistry_Create_UnhappyPath},
+	{"TestCommodityRegistry_Get_HappyPath", testCommodityRegistry_Get_HappyPath},
+	{"TestCommodityRegistry_Get_UnhappyPath", testCommodityRegistry_Get_UnhappyPath},
+	{"TestCommodityRegistry_List_HappyPath", testCommodityRegistry_List_HappyPath},
+	{"TestCommodityRegistry_List_SortedByPurchaseDate", testCommodityRegistry_List_SortedByPurchaseDate},
+	{"TestCommodityRegistry_Update_HappyPath", testCommodityRegistry_Update_HappyPath},
+	{"TestCommodityRegistry_Create_NilArea", testCommodityRegistry_Create_NilArea},
+	{"TestCommodityRegistry_Update_UnassignArea", testCommodityRegistry_Update_UnassignArea},
+	{"TestCommodityRegistry_ListPaginated_Unassigned", testCommodityRegistry_ListPaginated_Unassigned},
+	{"TestCommodityRegistry_Update_UnhappyPath", testCommodityRegistry_Update_UnhappyPath},
+	{"TestCommodityRegistry_Delete_HappyPath", testCommodityRegistry_Delete_HappyPath},
+	{"TestCommodityRegistry_Delete_UnhappyPath", testCommodityRegistry_Delete_UnhappyPath},
+	{"TestCommodityRegistry_GetMany", testCommodityRegistry_GetMany},
+	{"TestCommodityRegistry_Count_HappyPath", testCommodityRegistry_Count_HappyPath},
+
+	{"TestCommodityLoanRegistry_CreateAndGet", testCommodityLoanRegistry_CreateAndGet},
+	{"TestCommodityLoanRegistry_StartLoan_RejectsSecondOpen", testCommodityLoanRegistry_StartLoan_RejectsSecondOpen},
+	{"TestCommodityLoanRegistry_StateFilter", testCommodityLoanRegistry_StateFilter},
+	{"TestCommodityLoanRegistry_GroupIsolation", testCommodityLoanRegistry_GroupIsolation},
+	{"TestCommodityLoanRegistry_CountOpenByCommodity", testCommodityLoanRegistry_CountOpenByCommodity},
+	{"TestCommodityLoanRegistry_FKCascade", testCommodityLoanRegistry_FKCascade},
+
+	{"TestCommodityScanAuditRegistry_Record_HappyPath", testCommodityScanAuditRegistry_Record_HappyPath},
+	{"TestCommodityScanAuditRegistry_Record_MissingFields", testCommodityScanAuditRegistry_Record_MissingFields},
+	{"TestCommodityScanAuditRegistry_CountRecentForUser", testCommodityScanAuditRegistry_CountRecentForUser},
+	{"TestCommodityScanAuditRegistry_DeleteOlderThan", testCommodityScanAuditRegistry_DeleteOlderThan},
+
+	{"TestCurrencyMigrationProcessor_HappyPath_SmallGroup", testCurrencyMigrationProcessor_HappyPath_SmallGroup},
+	{"TestCurrencyMigrationProcessor_ForwardThenInverse", testCurrencyMigrationProcessor_ForwardThenInverse},
+	{"TestCurrencyMigrationProcessor_RecoverySweepWithAuditLog", testCurrencyMigrationProcessor_RecoverySweepWithAuditLog},
+
+	{"TestCurrencyMigrationRegistry_HappyPath", testCurrencyMigrationRegistry_HappyPath},
+	{"TestCurrencyMigrationRegistry_ParallelInFlightUniqueViolation", testCurrencyMigrationRegistry_ParallelInFlightUniqueViolation},
+	{"TestCurrencyMigrationRegistry_FailedRunsDontCount", testCurrencyMigrationRegistry_FailedRunsDontCount},
+
+	{"TestEmailVerificationRegistry_Create_HappyPath", testEmailVerificationRegistry_Create_HappyPath},
+	{"TestEmailVerificationRegistry_Create_MissingFields", testEmailVerificationRegistry_Create_MissingFields},
+	{"TestEmailVerificationRegistry_Get", testEmailVerificationRegistry_Get},
+	{"TestEmailVerificationRegistry_Get_NotFound", testEmailVerificationRegistry_Get_NotFound},
+	{"TestEmailVerificationRegistry_List", testEmailVerificationRegistry_List},
+	{"TestEmailVerificationRegistry_GetByToken", testEmailVerificationRegistry_GetByToken},
+	{"TestEmailVerificationRegistry_GetByUserID", testEmailVerificationRegistry_GetByUserID},
+	{"TestEmailVerificationRegistry_Update", testEmailVerificationRegistry_Update},
+	{"TestEmailVerificationRegistry_Update_NotFound", testEmailVerificationRegistry_Update_NotFound},
+	{"TestEmailVerificationRegistry_Delete", testEmailVerificationRegistry_Delete},
+	{"TestEmailVerificationRegistry_DeleteExpired", testEmailVerificationRegistry_DeleteExpired},
+	{"TestEmailVerificationRegistry_MarkVerified", testEmailVerificationRegistry_MarkVerified},
+	{"TestEmailVerificationRegistry_MarkVerified_TokenNotFound", testEmailVerificationRegistry_MarkVerified_TokenNotFound},
+	{"TestEmailVerificationRegistry_Count", testEmailVerificationRegistry_Count},
+
+	{"TestExportRegistry_DeleteClearsRestorePipeline", testExportRegistry_DeleteClearsRestorePipeline},
+
+	{"TestFileRegistry_CategoryFilter", testFileRegistry_CategoryFilter},
+	{"TestFileRegistry_LinkedEntityFilter", testFileRegistry_LinkedEntityFilter},
+
+	{"TestFileRegistry_ListByLinkedEntity_AreaAndLocation", testFileRegistry_ListByLinkedEntity_AreaAndLocation},
+
+	{"TestFileRegistry_ListOrphanCandidates", testFileRegistry_ListOrphanCandidates},
+	{"TestFileRegistry_ListIDsByOriginalPath", testFileRegistry_ListIDsByOriginalPath},
+	{"TestFileRegistry_ExistingIDs", testFileRegistry_ExistingIDs},
+
+	{"TestGroupInviteRegistry_Create_HappyPath", testGroupInviteRegistry_Create_HappyPath},
+	{"TestGroupInviteRegistry_Create_MissingFields", testGroupInviteRegistry_Create_MissingFields},
+	{"TestGroupInviteRegistry_GetByToken_And_ListActive", testGroupInviteRegistry_GetByToken_And_ListActive},
+	{"TestGroupInviteRegistry_MarkUsed_CAS", testGroupInviteRegistry_MarkUsed_CAS},
+
+	{"TestGroupMemberLocationScopeRegistry_ReplaceAndList", testGroupMemberLocationScopeRegistry_ReplaceAndList},
+	{"TestLocationScope_RLSAndWriteTrigger", testLocationScope_RLSAndWriteTrigger},
+
+	{"TestGroupMembershipRegistry_Create_HappyPath", testGroupMembershipRegistry_Create_HappyPath},
+	{"TestGroupMembershipRegistry_Create_MissingFields", testGroupMembershipRegistry_Create_MissingFields},
+	{"TestGroupMembershipRegistry_Create_Duplicate", testGroupMembershipRegistry_Create_Duplicate},
+	{"TestGroupMembershipRegistry_GetByGroupAndUser", testGroupMembershipRegistry_GetByGroupAndUser},
+	{"TestGroupMembershipRegistry_DeleteWithMemberInvariants", testGroupMembershipRegistry_DeleteWithMemberInvariants},
+	{"TestGroupMembershipRegistry_UpdateRoleWithMemberInvariants", testGroupMembershipRegistry_UpdateRoleWithMemberInvariants},
+	{"TestGroupMembershipRegistry_ListByGroup_ByUser_CountAdmins", testGroupMembershipRegistry_ListByGroup_ByUser_CountAdmins},
+	{"TestGroupMembershipRegistry_CountByGroup_AndCountByGroups", testGroupMembershipRegistry_CountByGroup_AndCountByGroups},
+
+	{"TestGroupPurgeService_CrossTenantRLSBypass", testGroupPurgeService_CrossTenantRLSBypass},
+	{"TestGroupPurgeService_CleanExpiredInvitesCrossTenant", testGroupPurgeService_CleanExpiredInvitesCrossTenant},
+	{"TestGroupPurger_PurgesAllGroupDependents", testGroupPurger_PurgesAllGroupDependents},
+
+	{"TestGroupTransferrer_MovesAcrossGroupRLS", testGroupTransferrer_MovesAcrossGroupRLS},
+
+	{"TestLocationGroupRegistry_Create_HappyPath", testLocationGroupRegistry_Create_HappyPath},
+	{"TestLocationGroupRegistry_Create_MissingFields", testLocationGroupRegistry_Create_MissingFields},
+	{"TestLocationGroupRegistry_Create_DuplicateSlug", testLocationGroupRegistry_Create_DuplicateSlug},
+	{"TestLocationGroupRegistry_Get_And_GetBySlug", testLocationGroupRegistry_Get_And_GetBySlug},
+	{"TestLocationGroupRegistry_ListByTenant", testLocationGroupRegistry_ListByTenant},
+	{"TestLocationGroupRegistry_Update_And_Delete", testLocationGroupRegistry_Update_And_Delete},
+
+	{"TestLocationRegistry_Create_HappyPath", testLocationRegistry_Create_HappyPath},
+	{"TestLocationRegistry_Create_UnhappyPath", testLocationRegistry_Create_UnhappyPath},
+	{"TestLocationRegistry_Get_HappyPath", testLocationRegistry_Get_HappyPath},
+	{"TestLocationRegistry_Get_UnhappyPath", testLocationRegistry_Get_UnhappyPath},
+	{"TestLocationRegistry_List_HappyPath", testLocationRegistry_List_HappyPath},
+	{"TestLocationRegistry_Update_HappyPath", testLocationRegistry_Update_HappyPath},
+	{"TestLocationRegistry_Update_UnhappyPath", testLocationRegistry_Update_UnhappyPath},
+	{"TestLocationRegistry_Delete_HappyPath", testLocationRegistry_Delete_HappyPath},
+	{"TestLocationRegistry_Delete_UnhappyPath", testLocationRegistry_Delete_UnhappyPath},
+	{"TestLocationRegistry_Delete_WithAreas_UnhappyPath", testLocationRegistry_Delete_WithAreas_UnhappyPath},
+	{"TestLocationRegistry_Count_HappyPath", testLocationRegistry_Count_HappyPath},
+	{"TestLocationRegistry_GetAreas_WithCreatedArea_HappyPath", testLocationRegistry_GetAreas_WithCreatedArea_HappyPath},
+	{"TestLocationRegistry_GetAreas_WithInvalidLocation_UnhappyPath", testLocationRegistry_GetAreas_WithInvalidLocation_UnhappyPath},
+	{"TestLocationRegistry_GetAreas_HappyPath", testLocationRegistry_GetAreas_HappyPath},
+	{"TestLocationRegistry_GetAreas_EmptyLocation_HappyPath", testLocationRegistry_GetAreas_EmptyLocation_HappyPath},
+
+	{"TestSystemStats_CrossTenantTotals", testSystemStats_CrossTenantTotals},
+
+	{"TestOAuthIdentityRegistry_Create_HappyPath", testOAuthIdentityRegistry_Create_HappyPath},
+	{"TestOAuthIdentityRegistry_Create_MissingFields", testOAuthIdentityRegistry_Create_MissingFields},
+	{"TestOAuthIdentityRegistry_Create_DuplicateProviderSubject", testOAuthIdentityRegistry_Create_DuplicateProviderSubject},
+	{"TestOAuthIdentityRegistry_Get", testOAuthIdentityRegistry_Get},
+	{"TestOAuthIdentityRegistry_Get_NotFound", testOAuthIdentityRegistry_Get_NotFound},
+	{"TestOAuthIdentityRegistry_GetByProviderSubject", testOAuthIdentityRegistry_GetByProviderSubject},
+	{"TestOAuthIdentityRegistry_ListByUser_TenantScoping", testOAuthIdentityRegistry_ListByUser_TenantScoping},
+	{"TestOAuthIdentityRegistry_GetByUserAndProvider", testOAuthIdentityRegistry_GetByUserAndProvider},
+	{"TestOAuthIdentityRegistry_DeleteByUserAndProvider_Idempotent", testOAuthIdentityRegistry_DeleteByUserAndProvider_Idempotent},
+	{"TestOAuthIdentityRegistry_Update", testOAuthIdentityRegistry_Update},
+	{"TestOAuthIdentityRegistry_Delete", testOAuthIdentityRegistry_Delete},
+	{"TestOAuthIdentityRegistry_List", testOAuthIdentityRegistry_List},
+	{"TestOAuthIdentityRegistry_Count", testOAuthIdentityRegistry_Count},
+
+	{"TestPasswordResetRegistry_Update_NotFound", testPasswordResetRegistry_Update_NotFound},
+
+	{"TestRefreshTokenRegistry_GetByTokenHash_ReturnsRevokedRow", testRefreshTokenRegistry_GetByTokenHash_ReturnsRevokedRow},
+
+	{"TestSettingsRegistry_Get_HappyPath", testSettingsRegistry_Get_HappyPath},
+	{"TestSettingsRegistry_Save_HappyPath", testSettingsRegistry_Save_HappyPath},
+	{"TestSettingsRegistry_Save_UpdateExisting_HappyPath", testSettingsRegistry_Save_UpdateExisting_HappyPath},
+	{"TestSettingsRegistry_Patch_HappyPath", testSettingsRegistry_Patch_HappyPath},
+	{"TestSettingsRegistry_Patch_UpdateExisting_HappyPath", testSettingsRegistry_Patch_UpdateExisting_HappyPath},
+	{"TestSettingsRegistry_Patch_UnhappyPath", testSettingsRegistry_Patch_UnhappyPath},
+	{"TestSettingsRegistry_MixedOperations_HappyPath", testSettingsRegistry_MixedOperations_HappyPath},
+	{"TestSettingsRegistry_Save_EmptySettings_HappyPath", testSettingsRegistry_Save_EmptySettings_HappyPath},
+	{"TestSettingsRegistry_Patch_ComplexValues_HappyPath", testSettingsRegistry_Patch_ComplexValues_HappyPath},
+
+	{"TestSystemAdminGrantRegistry_Grant_Idempotent", testSystemAdminGrantRegistry_Grant_Idempotent},
+	{"TestSystemAdminGrantRegistry_Exists_AfterGrantAndRevoke", testSystemAdminGrantRegistry_Exists_AfterGrantAndRevoke},
+	{"TestSystemAdminGrantRegistry_RevokeAtomic_NoGrant", testSystemAdminGrantRegistry_RevokeAtomic_NoGrant},
+	{"TestSystemAdminGrantRegistry_RevokeAtomic_LastAdminGuard", testSystemAdminGrantRegistry_RevokeAtomic_LastAdminGuard},
+	{"TestSystemAdminGrantRegistry_RevokeAtomic_AllowZeroBypass", testSystemAdminGrantRegistry_RevokeAtomic_AllowZeroBypass},
+	{"TestSystemAdminGrantRegistry_RevokeAtomic_NoConcurrentLastAdminRevoke", testSystemAdminGrantRegistry_RevokeAtomic_NoConcurrentLastAdminRevoke},
+	{"TestSystemAdminGrantRegistry_List_OrderedByGrantedAt", testSystemAdminGrantRegistry_List_OrderedByGrantedAt},
+	{"TestSystemAdminGrantRegistry_Exists_EmptyUserID", testSystemAdminGrantRegistry_Exists_EmptyUserID},
+	{"TestSystemAdminGrantRegistry_UserDelete_CascadesGrant", testSystemAdminGrantRegistry_UserDelete_CascadesGrant},
+
+	{"TestTagRegistry_SameSlugDifferentKinds", testTagRegistry_SameSlugDifferentKinds},
+	{"TestTagRegistry_RewriteSlugReferences", testTagRegistry_RewriteSlugReferences},
+	{"TestTagRegistry_RewriteSlugReferences_DedupOnRenameOntoExisting", testTagRegistry_RewriteSlugReferences_DedupOnRenameOntoExisting},
+	{"TestTagRegistry_RewriteSlugReferences_CrossGroupIsolation", testTagRegistry_RewriteSlugReferences_CrossGroupIsolation},
+	{"TestTagRegistry_RewriteSlugReferences_LeavesUnrelatedRowsAlone", testTagRegistry_RewriteSlugReferences_LeavesUnrelatedRowsAlone},
+	{"TestTagRegistry_RewriteSlugReferences_NoOpWhenSlugUnchanged", testTagRegistry_RewriteSlugReferences_NoOpWhenSlugUnchanged},
+	{"TestTagRegistry_StripSlugReferences", testTagRegistry_StripSlugReferences},
+	{"TestTagRegistry_StripSlugReferences_EmptyArrayNotNull", testTagRegistry_StripSlugReferences_EmptyArrayNotNull},
+	{"TestTagRegistry_StripSlugReferences_CrossGroupIsolation", testTagRegistry_StripSlugReferences_CrossGroupIsolation},
+	{"TestTagService_RenameTag_RefusesPreemptivelyOnSlugClash", testTagService_RenameTag_RefusesPreemptivelyOnSlugClash},
+	{"TestTagService_RenameTag_SameSlugDifferentKindNoClash", testTagService_RenameTag_SameSlugDifferentKindNoClash},
+	{"TestTagService_RenameTag_ParallelDifferentSourceSlugs", testTagService_RenameTag_ParallelDifferentSourceSlugs},
+	{"TestTagService_RenameTag_ParallelSameSourceSlug", testTagService_RenameTag_ParallelSameSourceSlug},
+	{"TestTagService_DeleteTag_ForceUnderConcurrentInsert", testTagService_DeleteTag_ForceUnderConcurrentInsert},
+	{"TestTagRegistry_SearchByKind", testTagRegistry_SearchByKind},
+	{"TestTagRegistry_ListPaginatedByKind", testTagRegistry_ListPaginatedByKind},
+	{"TestTagRegistry_GetStats_PerKindTotals", testTagRegistry_GetStats_PerKindTotals},
+
+	{"TestTenantPurger_PurgesAllTenantDependents", testTenantPurger_PurgesAllTenantDependents},
+
+	{"TestThumbnailGenerationJobRegistry_GetJobByFileID_NotFound", testThumbnailGenerationJobRegistry_GetJobByFileID_NotFound},
+
+	{"TestTrustedBackupKeyRegistry_Lifecycle", testTrustedBackupKeyRegistry_Lifecycle},
+
+	{"TestUserPurger_PurgesAuthRowsKeepsContent", testUserPurger_PurgesAuthRowsKeepsContent},
+	{"TestUserPurger_ClearsGroupInvitesAuditReferences", testUserPurger_ClearsGroupInvitesAuditReferences},
+
+	{"TestWorkerControlRegistry_Pause_Creates", testWorkerControlRegistry_Pause_Creates},
+	{"TestWorkerControlRegistry_Pause_IdempotentPreservesPausedAt", testWorkerControlRegistry_Pause_IdempotentPreservesPausedAt},
+	{"TestWorkerControlRegistry_Pause_EmptyByAndReasonStoredAsNull", testWorkerControlRegistry_Pause_EmptyByAndReasonStoredAsNull},
+	{"TestWorkerControlRegistry_Resume_Clears", testWorkerControlRegistry_Resume_Clears},
+	{"TestWorkerControlRegistry_Resume_Absent", testWorkerControlRegistry_Resume_Absent},
+	{"TestWorkerControlRegistry_List_OrderedByWorkerType", testWorkerControlRegistry_List_OrderedByWorkerType},
+	{"TestWorkerControlRegistry_RejectsUnknownType", testWorkerControlRegistry_RejectsUnknownType},
+}
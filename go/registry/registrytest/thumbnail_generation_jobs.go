@@ -0,0 +1,27 @@
+package registrytest
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/registry"
+)
+
+func testThumbnailGenerationJobRegistry_GetJobByFileID_NotFound(t *testing.T, h *testHarness) {
+	c := qt.New(t)
+
+	factorySet := h.factorySet(t)
+
+	// Create service registry (no tenant or group scope)
+	jobRegistry := factorySet.ThumbnailGenerationJobRegistryFactory.CreateServiceRegistry()
+
+	// Try to get a job for a non-existent file ID
+	nonExistentFileID := "non-existent-file-id"
+	job, err := jobRegistry.GetJobByFileID(context.Background(), nonExistentFileID)
+
+	// Should return ErrNotFound, not a generic SQL error
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	c.Assert(job, qt.IsNil)
+}
@@ -0,0 +1,57 @@
+package registrytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// testTrustedBackupKeyRegistry_Lifecycle covers add, duplicate
+// rejection, revoke and the idempotent second revoke. trusted_backup_keys is
+// NOT tenant-scoped, so a clean factory set suffices.
+func testTrustedBackupKeyRegistry_Lifecycle(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
+
+	c := qt.New(t)
+	ctx := context.Background()
+	reg := fs.TrustedBackupKeyRegistry
+
+	addedBy := "cli"
+	expires := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	added, err := reg.Add(ctx, models.TrustedBackupKey{
+		Fingerprint: "fp-staging",
+		PublicKey:   "pk",
+		Label:       "staging",
+		AddedBy:     &addedBy,
+		ExpiresAt:   &expires,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(added.ID, qt.Not(qt.Equals), "")
+	c.Assert(added.AddedAt.IsZero(), qt.IsFalse)
+	c.Assert(added.ExpiresAt, qt.IsNotNil)
+	c.Assert(added.RevokedAt, qt.IsNil)
+
+	_, err = reg.Add(ctx, models.TrustedBackupKey{Fingerprint: "fp-staging", PublicKey: "pk", Label: "dup"})
+	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
+
+	revoked, err := reg.Revoke(ctx, "fp-staging", "operator-1", "decommissioned")
+	c.Assert(err, qt.IsNil)
+	c.Assert(revoked.RevokedAt, qt.IsNotNil)
+	c.Assert(*revoked.RevokeReason, qt.Equals, "decommissioned")
+
+	again, err := reg.Revoke(ctx, "fp-staging", "cli", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(*again.RevokedBy, qt.Equals, "operator-1")
+
+	_, err = reg.Revoke(ctx, "fp-missing", "cli", "")
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	keys, err := reg.List(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(keys, qt.HasLen, 1)
+}
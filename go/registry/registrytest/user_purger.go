@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -6,32 +6,25 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
-	"github.com/denisvmedia/inventario/registry/postgres"
 )
 
-// TestUserPurger_Postgres_PurgesAuthRowsKeepsContent is the #2116 regression:
+// testUserPurger_PurgesAuthRowsKeepsContent is the #2116 regression:
 // PurgeUserDependents must hard-delete a user's auth / identity rows (refresh
 // tokens, MFA secret) so the eventual DELETE FROM users isn't blocked by the
 // NO ACTION child FKs, while LEAVING the user's authored content rows in place
 // (commodities/areas/locations carry NOT NULL user_id + NOT NULL created_by,
 // so orphaning is impossible — the purger must not touch them, and the
 // orchestration layer reassigns ownership before the final user delete).
-func TestUserPurger_Postgres_PurgesAuthRowsKeepsContent(t *testing.T) {
+func testUserPurger_PurgesAuthRowsKeepsContent(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	set, _ := setupTestRegistrySet(t)
+	set, _ := h.setupTestRegistrySet(t)
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	fs := postgres.NewFactorySet(dbx)
+	fs := h.factorySet(t)
 
 	user := getTestUser(c, set)
 	tenantID := user.TenantID
@@ -41,7 +34,7 @@ func TestUserPurger_Postgres_PurgesAuthRowsKeepsContent(t *testing.T) {
 
 	// -- seed auth/identity rows the purge must remove --------------------
 
-	_, err = fs.RefreshTokenRegistry.Create(ctx, models.RefreshToken{
+	_, err := fs.RefreshTokenRegistry.Create(ctx, models.RefreshToken{
 		TenantUserAwareEntityID: models.WithTenantUserAwareEntityID("", tenantID, userID),
 		TokenHash:               "hash-user-a",
 		ExpiresAt:               time.Now().Add(24 * time.Hour),
@@ -109,23 +102,19 @@ func TestUserPurger_Postgres_PurgesAuthRowsKeepsContent(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 }
 
-// TestUserPurger_Postgres_ClearsGroupInvitesAuditReferences is the #2147
+// testUserPurger_ClearsGroupInvitesAuditReferences is the #2147
 // regression: group_invites_audit.created_by and .used_by are NOT NULL FK ->
 // users(id) with NO ACTION, so a user whose group ever had a USED invite would
 // block the final DELETE FROM users. PurgeUserDependents must clear those audit
 // rows (matching either column). It seeds one audit row that names the user as
 // BOTH creator and accepter, and a second referencing a different user that must
 // survive.
-func TestUserPurger_Postgres_ClearsGroupInvitesAuditReferences(t *testing.T) {
+func testUserPurger_ClearsGroupInvitesAuditReferences(t *testing.T, h *testHarness) {
 	c := qt.New(t)
 
-	set, _ := setupTestRegistrySet(t)
+	set, _ := h.setupTestRegistrySet(t)
 
-	dsn := skipIfNoPostgreSQL(t)
-	pool, err := getOrCreatePool(dsn)
-	c.Assert(err, qt.IsNil)
-	dbx := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
-	fs := postgres.NewFactorySet(dbx)
+	fs := h.factorySet(t)
 
 	user := getTestUser(c, set)
 	tenantID := user.TenantID
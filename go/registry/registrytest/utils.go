@@ -0,0 +1,239 @@
+package registrytest
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// setupTestRegistrySet opens a fresh store, seeds the test tenant, user and
+// group, and returns a registry set scoped to that user and group.
+func (h *testHarness) setupTestRegistrySet(t *testing.T) (*registry.Set, func()) {
+	t.Helper()
+
+	c := qt.New(t)
+	factorySet := h.freshFactorySet(t)
+
+	// Create a service registry set (without user context) to create tenant and user
+	serviceRegistrySet := factorySet.CreateServiceRegistrySet()
+
+	// Create test tenant and user that the tests expect
+	tenantID, userID := setupTestTenantAndUser(c, serviceRegistrySet)
+
+	// Create a default group for the test user. Stamp GroupCurrency=USD
+	// explicitly so commodity validation — which reads group_currency
+	// off the context — passes regardless of whether the DB default
+	// fires for this INSERT path.
+	ctx := context.Background()
+	groupSlug, err := models.GenerateGroupSlug()
+	c.Assert(err, qt.IsNil)
+	testGroup, err := serviceRegistrySet.LocationGroupRegistry.Create(ctx, models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: tenantID},
+		Name:                "Test Group",
+		Slug:                groupSlug,
+		Status:              models.LocationGroupStatusActive,
+		CreatedBy:           userID,
+		GroupCurrency:       models.Currency("USD"),
+	})
+	c.Assert(err, qt.IsNil)
+	groupID := testGroup.ID
+
+	// Now create a user+group-aware registry set with the actual generated IDs
+	userAwareRegistrySet := h.registrySetWithUserAndGroupID(t, userID, tenantID, groupID)
+
+	return userAwareRegistrySet, func() {}
+}
+
+// setupTestTenantAndUser creates the test tenant and user that the tests expect
+// Returns the created tenant ID and user ID for use in creating user-aware registry sets
+func setupTestTenantAndUser(c *qt.C, registrySet *registry.Set) (tenantID, userID string) {
+	c.Helper()
+
+	ctx := context.Background()
+
+	// Create test tenant (let the system generate the ID for security)
+	testTenant := models.Tenant{
+		// ID will be generated server-side for security
+		Name:   "Test Organization",
+		Slug:   "test-org",
+		Status: models.TenantStatusActive,
+	}
+
+	// Check if tenant already exists by slug
+	tenants, err := registrySet.TenantRegistry.List(ctx)
+	c.Assert(err, qt.IsNil)
+
+	var existingTenant *models.Tenant
+	for _, tenant := range tenants {
+		if tenant.Slug == testTenant.Slug {
+			existingTenant = tenant
+			tenantID = tenant.ID
+			break
+		}
+	}
+
+	if existingTenant == nil {
+		// Tenant doesn't exist, create it
+		createdTenant, err := registrySet.TenantRegistry.Create(ctx, testTenant)
+		c.Assert(err, qt.IsNil)
+		tenantID = createdTenant.ID
+	}
+
+	// Create test user (let the system generate the ID for security)
+	testUser1 := models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			// ID will be generated server-side for security
+			TenantID: tenantID, // Use the generated tenant ID
+		},
+		Email:    "admin@test-org.com",
+		Name:     "Test Administrator",
+		IsActive: true,
+	}
+
+	err = testUser1.SetPassword("TestPassword123")
+	c.Assert(err, qt.IsNil)
+
+	// Check if user already exists by email
+	users, err := registrySet.UserRegistry.List(ctx)
+	c.Assert(err, qt.IsNil)
+
+	var existingUser *models.User
+	for _, user := range users {
+		if user.Email == testUser1.Email {
+			existingUser = user
+			break
+		}
+	}
+
+	if existingUser == nil {
+		// User doesn't exist, create it
+		createdUser, err := registrySet.UserRegistry.Create(ctx, testUser1)
+		c.Assert(err, qt.IsNil)
+		return tenantID, createdUser.ID
+	}
+
+	// User exists, return its ID
+	return tenantID, existingUser.ID
+}
+
+// getTestUser gets the test user created by setupTestTenantAndUser
+// This is a helper function for tests that need to set user context
+func getTestUser(c *qt.C, registrySet *registry.Set) *models.User {
+	c.Helper()
+
+	ctx := context.Background()
+	users, err := registrySet.UserRegistry.List(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.Not(qt.HasLen), 0, qt.Commentf("No users found - ensure setupTestTenantAndUser was called"))
+
+	// Use the first seeded user (should be the admin user created by setupTestTenantAndUser)
+	return users[0]
+}
+
+// createTestLocation creates a test location for use in tests.
+// This function requires that setupTestTenantAndUser has been called to seed test data.
+func createTestLocation(c *qt.C, registrySet *registry.Set) *models.Location {
+	c.Helper()
+
+	ctx := c.Context()
+
+	// Get the first seeded user to use for creating the location
+	users, err := registrySet.UserRegistry.List(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.Not(qt.HasLen), 0, qt.Commentf("No users found - ensure setupTestTenantAndUser was called"))
+
+	// Use the first seeded user (should be the admin user created by seeddata)
+	seededUser := users[0]
+
+	location := models.Location{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
+			TenantID:        seededUser.TenantID,
+			CreatedByUserID: seededUser.ID, // Use the actual generated user ID
+		},
+		Name:    "Test Location",
+		Address: "123 Test Street",
+	}
+
+	createdLocation, err := registrySet.LocationRegistry.Create(ctx, location)
+	c.Assert(err, qt.IsNil)
+	c.Assert(createdLocation, qt.IsNotNil)
+
+	return createdLocation
+}
+
+// createTestArea creates a test area for use in tests.
+func createTestArea(c *qt.C, registrySet *registry.Set, locationID string) *models.Area {
+	c.Helper()
+
+	ctx := c.Context()
+
+	// Get the first seeded user to use for creating the area
+	users, err := registrySet.UserRegistry.List(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.Not(qt.HasLen), 0, qt.Commentf("No users found - ensure setupTestTenantAndUser was called"))
+
+	// Use the first seeded user (should be the admin user created by seeddata)
+	seededUser := users[0]
+
+	area := models.Area{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
+			TenantID:        seededUser.TenantID,
+			CreatedByUserID: seededUser.ID, // Use the actual generated user ID
+		},
+		Name:       "Test Area",
+		LocationID: locationID,
+	}
+
+	createdArea, err := registrySet.AreaRegistry.Create(ctx, area)
+	c.Assert(err, qt.IsNil)
+	c.Assert(createdArea, qt.IsNotNil)
+
+	return createdArea
+}
+
+// createTestCommodity creates a test commodity for use in tests.
+func createTestCommodity(c *qt.C, registrySet *registry.Set, areaID string) *models.Commodity {
+	c.Helper()
+
+	ctx := c.Context()
+
+	// Get the first seeded user to use for creating the commodity
+	users, err := registrySet.UserRegistry.List(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.Not(qt.HasLen), 0, qt.Commentf("No users found - ensure setupTestTenantAndUser was called"))
+
+	// Use the first seeded user (should be the admin user created by seeddata)
+	seededUser := users[0]
+
+	commodity := models.Commodity{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
+			TenantID:        seededUser.TenantID,
+			CreatedByUserID: seededUser.ID, // Use the actual generated user ID
+		},
+		Name:                   "Test Commodity",
+		ShortName:              "TC",
+		Type:                   models.CommodityTypeElectronics,
+		AreaID:                 new(areaID),
+		Count:                  1,
+		OriginalPrice:          decimal.NewFromFloat(100.00),
+		OriginalPriceCurrency:  "USD",
+		ConvertedOriginalPrice: decimal.Zero,
+		CurrentPrice:           decimal.NewFromFloat(90.00),
+		Status:                 models.CommodityStatusInUse,
+		PurchaseDate:           models.ToPDate("2023-01-01"),
+		RegisteredDate:         models.ToPDate("2023-01-02"),
+		LastModifiedDate:       models.ToPDate("2023-01-03"),
+		Draft:                  false,
+	}
+
+	createdCommodity, err := registrySet.CommodityRegistry.Create(ctx, commodity)
+	c.Assert(err, qt.IsNil)
+	c.Assert(createdCommodity, qt.IsNotNil)
+
+	return createdCommodity
+}
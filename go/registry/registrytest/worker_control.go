@@ -1,4 +1,4 @@
-package postgres_test
+package registrytest
 
 import (
 	"context"
@@ -10,12 +10,12 @@ import (
 	"github.com/denisvmedia/inventario/registry"
 )
 
-// TestWorkerControlRegistry_Pause_Creates_Postgres verifies the first
+// testWorkerControlRegistry_Pause_Creates verifies the first
 // Pause writes a paused row carrying the operator, reason, and a pause
 // timestamp. worker_control is NOT tenant-scoped, so no seeded
 // tenant/user/group fixtures are required — a clean factory set suffices.
-func TestWorkerControlRegistry_Pause_Creates_Postgres(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testWorkerControlRegistry_Pause_Creates(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -38,12 +38,12 @@ func TestWorkerControlRegistry_Pause_Creates_Postgres(t *testing.T) {
 	c.Assert(controls, qt.HasLen, 1)
 }
 
-// TestWorkerControlRegistry_Pause_IdempotentPreservesPausedAt_Postgres
+// testWorkerControlRegistry_Pause_IdempotentPreservesPausedAt
 // verifies a second Pause updates paused_by/reason but PRESERVES the
 // original paused_at via the CASE expression — the pause time must
 // reflect when the worker first stopped, not the latest note edit.
-func TestWorkerControlRegistry_Pause_IdempotentPreservesPausedAt_Postgres(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testWorkerControlRegistry_Pause_IdempotentPreservesPausedAt(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -70,11 +70,11 @@ func TestWorkerControlRegistry_Pause_IdempotentPreservesPausedAt_Postgres(t *tes
 	c.Assert(controls, qt.HasLen, 1)
 }
 
-// TestWorkerControlRegistry_Pause_EmptyByAndReasonStoredAsNull_Postgres
+// testWorkerControlRegistry_Pause_EmptyByAndReasonStoredAsNull
 // verifies the CLI pause path (no operator session, no reason) lands NULL
 // columns rather than empty-string sentinels.
-func TestWorkerControlRegistry_Pause_EmptyByAndReasonStoredAsNull_Postgres(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testWorkerControlRegistry_Pause_EmptyByAndReasonStoredAsNull(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -87,10 +87,10 @@ func TestWorkerControlRegistry_Pause_EmptyByAndReasonStoredAsNull_Postgres(t *te
 	c.Assert(wc.Reason, qt.IsNil)
 }
 
-// TestWorkerControlRegistry_Resume_Clears_Postgres verifies Resume flips
+// testWorkerControlRegistry_Resume_Clears verifies Resume flips
 // paused to false and clears paused_at/paused_by/reason.
-func TestWorkerControlRegistry_Resume_Clears_Postgres(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testWorkerControlRegistry_Resume_Clears(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -108,11 +108,11 @@ func TestWorkerControlRegistry_Resume_Clears_Postgres(t *testing.T) {
 	c.Assert(resumed.Reason, qt.IsNil)
 }
 
-// TestWorkerControlRegistry_Resume_Absent_Postgres verifies resuming a
+// testWorkerControlRegistry_Resume_Absent verifies resuming a
 // worker that was never paused is a no-op: it returns a synthetic
 // not-paused state and does NOT insert a row.
-func TestWorkerControlRegistry_Resume_Absent_Postgres(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testWorkerControlRegistry_Resume_Absent(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -128,11 +128,11 @@ func TestWorkerControlRegistry_Resume_Absent_Postgres(t *testing.T) {
 	c.Assert(controls, qt.HasLen, 0)
 }
 
-// TestWorkerControlRegistry_List_OrderedByWorkerType_Postgres verifies
+// testWorkerControlRegistry_List_OrderedByWorkerType verifies
 // List comes back sorted by worker_type so the CLI/admin render is
 // deterministic.
-func TestWorkerControlRegistry_List_OrderedByWorkerType_Postgres(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testWorkerControlRegistry_List_OrderedByWorkerType(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -160,12 +160,12 @@ func TestWorkerControlRegistry_List_OrderedByWorkerType_Postgres(t *testing.T) {
 	})
 }
 
-// TestWorkerControlRegistry_RejectsUnknownType_Postgres verifies the
+// testWorkerControlRegistry_RejectsUnknownType verifies the
 // registry-level defence-in-depth validation (#1308 review fix): Pause
 // and Resume reject a worker type that is not in the canonical set,
 // before touching the table.
-func TestWorkerControlRegistry_RejectsUnknownType_Postgres(t *testing.T) {
-	fs := setupCleanPostgresFactorySet(t)
+func testWorkerControlRegistry_RejectsUnknownType(t *testing.T, h *testHarness) {
+	fs := h.freshFactorySet(t)
 
 	c := qt.New(t)
 	ctx := context.Background()
@@ -0,0 +1,364 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+// AreaRegistryFactory creates AreaRegistry instances with proper context
+type AreaRegistryFactory struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// AreaRegistry is a context-aware registry that can only be created through the factory
+type AreaRegistry struct {
+	dbx             *sqlx.DB
+	tableNames      store.TableNames
+	tenantID        string
+	groupID         string
+	createdByUserID string
+	service         bool
+}
+
+var _ registry.AreaRegistry = (*AreaRegistry)(nil)
+var _ registry.AreaRegistryFactory = (*AreaRegistryFactory)(nil)
+
+func NewAreaRegistry(dbx *sqlx.DB) *AreaRegistryFactory {
+	return NewAreaRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewAreaRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *AreaRegistryFactory {
+	return &AreaRegistryFactory{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+// Factory methods implementing registry.AreaRegistryFactory
+
+func (f *AreaRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.AreaRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *AreaRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.AreaRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user ID from context", err)
+	}
+
+	return &AreaRegistry{
+		dbx:             f.dbx,
+		tableNames:      f.tableNames,
+		tenantID:        user.TenantID,
+		groupID:         appctx.GroupIDFromContext(ctx),
+		createdByUserID: user.ID,
+		service:         false,
+	}, nil
+}
+
+func (f *AreaRegistryFactory) CreateServiceRegistry() registry.AreaRegistry {
+	return &AreaRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		service:    true,
+	}
+}
+
+func (r *AreaRegistry) Get(ctx context.Context, id string) (*models.Area, error) {
+	return r.get(ctx, id)
+}
+
+func (r *AreaRegistry) List(ctx context.Context) ([]*models.Area, error) {
+	var areas []*models.Area
+
+	reg := r.newSQLRegistry()
+
+	// Query the database for all locations (atomic operation)
+	for area, err := range reg.Scan(ctx) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list areas", err)
+		}
+		areas = append(areas, &area)
+	}
+
+	return areas, nil
+}
+
+func (r *AreaRegistry) Count(ctx context.Context) (int, error) {
+	reg := r.newSQLRegistry()
+
+	cnt, err := reg.Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count areas", err)
+	}
+
+	return cnt, nil
+}
+
+// ListPaginated returns a paginated list of areas along with the total
+// count, optionally narrowed by opts — see registry.AreaListOptions for the
+// field-by-field semantics.
+func (r *AreaRegistry) ListPaginated(ctx context.Context, offset, limit int, opts registry.AreaListOptions) ([]*models.Area, int, error) {
+	// Normalize pagination parameters to prevent negative SQL OFFSET/LIMIT errors.
+	if offset < 0 {
+		offset = 0
+	}
+	if limit < 0 {
+		limit = 0
+	}
+
+	var areas []*models.Area
+	var total int
+
+	whereClause, whereArgs := buildAreaWhere(opts)
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s%s`, r.tableNames.Areas(), whereClause)
+		if err := tx.QueryRowContext(ctx, countQuery, whereArgs...).Scan(&total); err != nil {
+			return errxtrace.Wrap("failed to count areas", err)
+		}
+
+		// LIMIT/OFFSET placeholders follow the WHERE arguments so a
+		// non-empty filter shifts them to $2/$3 rather than colliding
+		// with $1.
+		limitPlaceholder := fmt.Sprintf("$%d", len(whereArgs)+1)
+		offsetPlaceholder := fmt.Sprintf("$%d", len(whereArgs)+2)
+		dataQuery := fmt.Sprintf(`
+			SELECT * FROM %s%s
+			ORDER BY name, id
+			LIMIT %s OFFSET %s`, r.tableNames.Areas(), whereClause, limitPlaceholder, offsetPlaceholder)
+
+		dataArgs := append(append([]any{}, whereArgs...), limit, offset)
+		rows, err := tx.QueryxContext(ctx, dataQuery, dataArgs...)
+		if err != nil {
+			return errxtrace.Wrap("failed to list paginated areas", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var area models.Area
+			if err := rows.StructScan(&area); err != nil {
+				return errxtrace.Wrap("failed to scan area", err)
+			}
+			areas = append(areas, &area)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, 0, errxtrace.Wrap("failed to list paginated areas", err)
+	}
+
+	return areas, total, nil
+}
+
+// buildAreaWhere converts opts into a WHERE clause + positional args. The
+// returned clause already starts with a leading space (or is empty) so it
+// can be concatenated directly after the table name.
+func buildAreaWhere(opts registry.AreaListOptions) (string, []any) {
+	var conditions []string
+	var args []any
+	if opts.LocationID != "" {
+		conditions = append(conditions, fmt.Sprintf("location_id = $%d", len(args)+1))
+		args = append(args, opts.LocationID)
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *AreaRegistry) Create(ctx context.Context, area models.Area) (*models.Area, error) {
+	// ID, TenantID, and UserID are now set automatically by RLSRepository.Create
+
+	reg := r.newSQLRegistry()
+
+	createdArea, err := reg.Create(ctx, area, func(ctx context.Context, tx *sqlx.Tx) error {
+		_, err := r.getLocation(ctx, tx, area.LocationID)
+		return err
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to count areas", err)
+	}
+
+	return &createdArea, nil
+}
+
+func (r *AreaRegistry) Update(ctx context.Context, area models.Area) (*models.Area, error) {
+	reg := r.newSQLRegistry()
+
+	err := reg.UpdateInPlace(ctx, &area, func(ctx context.Context, tx *sqlx.Tx, dbArea models.Area) error {
+		_, err := r.getLocation(ctx, tx, area.LocationID)
+		return err
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update area", err)
+	}
+
+	return &area, nil
+}
+
+// Delete removes ONLY the area row (after the in-tx empty-check below rejects
+// an area that still holds commodities with ErrCannotDelete). The files LINKED
+// to the area (linked_entity_type='area') are NOT cascaded — the link is
+// polymorphic, there is no FK — and their physical blobs are not touched here.
+//
+// INVARIANT (#2119, mirroring the #2121 commodity note): user-initiated area
+// deletes MUST go through services.EntityService (DeleteArea /
+// DeleteAreaRecursive / UnlinkAndDeleteArea), which drop this row and then
+// remove each linked file (row + best-effort blob) via
+// FileService.DeleteLinkedFiles. Calling this bare row delete directly leaves
+// every area-linked file row and blob orphaned. The only legitimate direct
+// callers are tests; the group/tenant purgers never call this method — they
+// bypass it entirely with raw `DELETE FROM <table>` sweeps that also wipe the
+// (tenant, group)'s files table.
+func (r *AreaRegistry) Delete(ctx context.Context, id string) error {
+	reg := r.newSQLRegistry()
+	err := reg.Delete(ctx, id, func(ctx context.Context, tx *sqlx.Tx) error {
+		// Check if the area has commodities
+		commodities, err := r.getCommodities(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if len(commodities) > 0 {
+			return errxtrace.Wrap("area has commodities", registry.ErrCannotDelete)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *AreaRegistry) GetCommodities(ctx context.Context, areaID string) ([]string, error) {
+	var commodities []string
+
+	reg := r.newSQLRegistry()
+	err := reg.DoWithEntityID(ctx, areaID, func(ctx context.Context, tx *sqlx.Tx, _ models.Area) error {
+		var err error
+		commodities, err = r.getCommodities(ctx, tx, areaID)
+		return err
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list commodities", err)
+	}
+
+	return commodities, nil
+}
+
+// GetCommodityCount returns the number of commodities in an area
+func (r *AreaRegistry) GetCommodityCount(ctx context.Context, areaID string) (int, error) {
+	commodities, err := r.GetCommodities(ctx, areaID)
+	if err != nil {
+		return 0, err
+	}
+	return len(commodities), nil
+}
+
+// GetTotalValue calculates the total value of commodities in an area
+func (r *AreaRegistry) GetTotalValue(ctx context.Context, areaID string, currency string) (float64, error) {
+	var totalValue float64
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		sql := fmt.Sprintf(`
+			SELECT COALESCE(SUM(COALESCE(converted_original_price, original_price)), 0)
+			FROM %s
+			WHERE area_id = $1
+			AND (original_price_currency = $2 OR $2 = '')
+			AND draft = false
+	`, r.tableNames.Commodities())
+
+		err := tx.GetContext(ctx, &totalValue, sql, areaID, currency)
+		if err != nil {
+			return errxtrace.Wrap("failed to calculate total value", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to list commodities", err)
+	}
+
+	return totalValue, nil
+}
+
+// SearchByName searches areas by name (case-insensitive substring match)
+func (r *AreaRegistry) SearchByName(ctx context.Context, query string) ([]*models.Area, error) {
+	var areas []*models.Area
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query = strings.ToLower(query)
+		sql := fmt.Sprintf(`
+			SELECT * FROM %s
+			WHERE LOWER(name) LIKE $1
+			ORDER BY name
+	`, r.tableNames.Areas())
+		err := tx.SelectContext(ctx, &areas, sql, "%"+query+"%")
+		if err != nil {
+			return errxtrace.Wrap("failed to search areas by name", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list commodities", err)
+	}
+
+	return areas, nil
+}
+
+func (r *AreaRegistry) newSQLRegistry() *store.RLSGroupRepository[models.Area, *models.Area] {
+	if r.service {
+		return store.NewGroupServiceSQLRegistry[models.Area](r.dbx, r.tableNames.Areas())
+	}
+	slog.Info("Creating new group-aware SQL registry for areas", "createdByUserID", r.createdByUserID)
+	return store.NewGroupAwareSQLRegistry[models.Area](r.dbx, r.tenantID, r.groupID, r.createdByUserID, r.tableNames.Areas())
+}
+
+func (r *AreaRegistry) get(ctx context.Context, id string) (*models.Area, error) {
+	var area models.Area
+	reg := r.newSQLRegistry()
+
+	err := reg.ScanOneByField(ctx, store.Pair("id", id), &area)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get area", err)
+	}
+
+	return &area, nil
+}
+
+func (r *AreaRegistry) getCommodities(ctx context.Context, tx *sqlx.Tx, areaID string) ([]string, error) {
+	var commodities []string
+
+	comReg := store.NewTxRegistry[models.Commodity](tx, r.tableNames.Commodities())
+	for commodity, err := range comReg.ScanByField(ctx, store.Pair("area_id", areaID)) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list commodities", err)
+		}
+		commodities = append(commodities, commodity.GetID())
+	}
+
+	return commodities, nil
+}
+
+func (r *AreaRegistry) getLocation(ctx context.Context, tx *sqlx.Tx, id string) (*models.Location, error) {
+	var location models.Location
+	txreg := store.NewTxRegistry[models.Location](tx, r.tableNames.Locations())
+	err := txreg.ScanOneByField(ctx, store.Pair("id", id), &location)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get location", err)
+	}
+	return &location, nil
+}
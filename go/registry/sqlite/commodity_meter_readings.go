@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+// CommodityMeterReadingRegistryFactory creates
+// CommodityMeterReadingRegistry instances with proper context.
+type CommodityMeterReadingRegistryFactory struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// CommodityMeterReadingRegistry is the SQLite-backed group-scoped
+// registry of commodity meter readings.
+type CommodityMeterReadingRegistry struct {
+	dbx             *sqlx.DB
+	tableNames      store.TableNames
+	tenantID        string
+	groupID         string
+	createdByUserID string
+	service         bool
+}
+
+var (
+	_ registry.CommodityMeterReadingRegistry        = (*CommodityMeterReadingRegistry)(nil)
+	_ registry.CommodityMeterReadingRegistryFactory = (*CommodityMeterReadingRegistryFactory)(nil)
+)
+
+func NewCommodityMeterReadingRegistry(dbx *sqlx.DB) *CommodityMeterReadingRegistryFactory {
+	return NewCommodityMeterReadingRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewCommodityMeterReadingRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *CommodityMeterReadingRegistryFactory {
+	return &CommodityMeterReadingRegistryFactory{dbx: dbx, tableNames: tableNames}
+}
+
+func (f *CommodityMeterReadingRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.CommodityMeterReadingRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *CommodityMeterReadingRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.CommodityMeterReadingRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+	return &CommodityMeterReadingRegistry{
+		dbx:             f.dbx,
+		tableNames:      f.tableNames,
+		tenantID:        user.TenantID,
+		groupID:         appctx.GroupIDFromContext(ctx),
+		createdByUserID: user.ID,
+		service:         false,
+	}, nil
+}
+
+func (f *CommodityMeterReadingRegistryFactory) CreateServiceRegistry() registry.CommodityMeterReadingRegistry {
+	return &CommodityMeterReadingRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		service:    true,
+	}
+}
+
+func (r *CommodityMeterReadingRegistry) newSQLRegistry() *store.RLSGroupRepository[models.CommodityMeterReading, *models.CommodityMeterReading] {
+	if r.service {
+		return store.NewGroupServiceSQLRegistry[models.CommodityMeterReading](r.dbx, r.tableNames.CommodityMeterReadings())
+	}
+	return store.NewGroupAwareSQLRegistry[models.CommodityMeterReading](r.dbx, r.tenantID, r.groupID, r.createdByUserID, r.tableNames.CommodityMeterReadings())
+}
+
+func (r *CommodityMeterReadingRegistry) Get(ctx context.Context, id string) (*models.CommodityMeterReading, error) {
+	var item models.CommodityMeterReading
+	if err := r.newSQLRegistry().ScanOneByField(ctx, store.Pair("id", id), &item); err != nil {
+		return nil, errxtrace.Wrap("failed to get commodity meter reading", err)
+	}
+	return &item, nil
+}
+
+func (r *CommodityMeterReadingRegistry) List(ctx context.Context) ([]*models.CommodityMeterReading, error) {
+	var items []*models.CommodityMeterReading
+	for item, err := range r.newSQLRegistry().Scan(ctx) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list commodity meter readings", err)
+		}
+		it := item
+		items = append(items, &it)
+	}
+	return items, nil
+}
+
+func (r *CommodityMeterReadingRegistry) Count(ctx context.Context) (int, error) {
+	cnt, err := r.newSQLRegistry().Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count commodity meter readings", err)
+	}
+	return cnt, nil
+}
+
+func (r *CommodityMeterReadingRegistry) Create(ctx context.Context, entity models.CommodityMeterReading) (*models.CommodityMeterReading, error) {
+	entity.CreatedAt = time.Now()
+	created, err := r.newSQLRegistry().Create(ctx, entity, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create commodity meter reading", err)
+	}
+	return &created, nil
+}
+
+func (r *CommodityMeterReadingRegistry) Update(ctx context.Context, entity models.CommodityMeterReading) (*models.CommodityMeterReading, error) {
+	if err := r.newSQLRegistry().Update(ctx, entity, nil); err != nil {
+		return nil, errxtrace.Wrap("failed to update commodity meter reading", err)
+	}
+	return &entity, nil
+}
+
+func (r *CommodityMeterReadingRegistry) Delete(ctx context.Context, id string) error {
+	return r.newSQLRegistry().Delete(ctx, id, nil)
+}
+
+func (r *CommodityMeterReadingRegistry) ListByCommodity(ctx context.Context, commodityID string, unit models.MeterUnit) ([]*models.CommodityMeterReading, error) {
+	conditions := []string{"commodity_id = $1"}
+	args := []any{commodityID}
+	if unit != "" {
+		conditions = append(conditions, "unit = $2")
+		args = append(args, string(unit))
+	}
+
+	var readings []*models.CommodityMeterReading
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT * FROM %s WHERE %s ORDER BY read_at ASC, value ASC`,
+			r.tableNames.CommodityMeterReadings(), strings.Join(conditions, " AND "))
+		rows, err := tx.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return errxtrace.Wrap("failed to query commodity meter readings", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var reading models.CommodityMeterReading
+			if err := rows.StructScan(&reading); err != nil {
+				return errxtrace.Wrap("failed to scan commodity meter reading", err)
+			}
+			m := reading
+			readings = append(readings, &m)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list commodity meter readings for commodity", err)
+	}
+	return readings, nil
+}
@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+var _ registry.CommodityScanAuditRegistry = (*CommodityScanAuditRegistry)(nil)
+
+// CommodityScanAuditRegistry is the SQLite-backed implementation of
+// registry.CommodityScanAuditRegistry (#1720). It uses NonRLSRepository
+// because the audit row is written by service code that may be
+// finishing up after the request RLS context has already gone (e.g.
+// on rate-limit reject before user identity was resolved into RLS) —
+// the row carries explicit (tenant_id, user_id) columns so RLS can
+// still apply to *reads* via the configured policies, but writes are
+// intentionally bypassed.
+type CommodityScanAuditRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// NewCommodityScanAuditRegistry constructs a CommodityScanAuditRegistry.
+func NewCommodityScanAuditRegistry(dbx *sqlx.DB) *CommodityScanAuditRegistry {
+	return &CommodityScanAuditRegistry{dbx: dbx, tableNames: store.DefaultTableNames}
+}
+
+func (r *CommodityScanAuditRegistry) newRepo() *store.NonRLSRepository[models.CommodityScanAudit, *models.CommodityScanAudit] {
+	return store.NewSQLRegistry[models.CommodityScanAudit, *models.CommodityScanAudit](r.dbx, r.tableNames.CommodityScanAudits())
+}
+
+// Record persists a new commodity scan audit row.
+func (r *CommodityScanAuditRegistry) Record(ctx context.Context, audit models.CommodityScanAudit) (*models.CommodityScanAudit, error) {
+	if audit.UserID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+	if audit.TenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+	if audit.Status == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "Status"))
+	}
+	if audit.CreatedAt.IsZero() {
+		audit.CreatedAt = time.Now()
+	}
+	created, err := r.newRepo().Create(ctx, audit, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to record commodity scan audit", err)
+	}
+	return &created, nil
+}
+
+// CountRecentForUser returns the number of audit rows for
+// (tenantID, userID) created at or after since that count toward the
+// per-user rate limit. The explicit tenant_id predicate is the contract
+// guarantee that the memory implementation also enforces; SQLite
+// deployments can additionally rely on the isolation views via a
+// user-scoped registry, but the predicate stays for parity so the caller doesn't need to
+// know which mode the registry was built in.
+//
+// Only rows where the provider was actually called are counted (`ok`,
+// `error`, `timeout`). Validation rejections (`validation`), rate-limit
+// rejections (`rate_limited`), and provider-disabled rows (`disabled`)
+// are excluded because they don't consume vendor budget — counting
+// them would let a user lock themselves out by sending malformed
+// requests, and would self-perpetuate the lockout once the rate-limit
+// row itself bumped the count past the threshold.
+func (r *CommodityScanAuditRegistry) CountRecentForUser(ctx context.Context, tenantID, userID string, since time.Time) (int, error) {
+	if tenantID == "" {
+		return 0, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+	if userID == "" {
+		return 0, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+	var count int
+	query := fmt.Sprintf(
+		`SELECT COUNT(*) FROM %s WHERE tenant_id = $1 AND user_id = $2 AND created_at >= $3 AND status IN ('ok', 'error', 'timeout')`,
+		r.tableNames.CommodityScanAudits(),
+	)
+	if err := r.dbx.GetContext(ctx, &count, query, tenantID, userID, since); err != nil {
+		return 0, errxtrace.Wrap("count recent commodity scan audits", err)
+	}
+	return count, nil
+}
+
+// DeleteOlderThan removes every audit row whose created_at is before
+// cutoff. The retention worker calls this on a schedule.
+func (r *CommodityScanAuditRegistry) DeleteOlderThan(ctx context.Context, cutoff time.Time) error {
+	return r.newRepo().Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`DELETE FROM main.%s WHERE created_at < $1`, r.tableNames.CommodityScanAudits())
+		_, err := tx.ExecContext(ctx, query, cutoff)
+		return err
+	})
+}
+
+// SumCostSince sums cost_usd over the tenant's rows created at or after
+// since, narrowed to groupID when non-empty. The (tenant_id, group_id,
+// created_at) index serves both shapes.
+func (r *CommodityScanAuditRegistry) SumCostSince(ctx context.Context, tenantID, groupID string, since time.Time) (decimal.Decimal, error) {
+	if tenantID == "" {
+		return decimal.Zero, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "TenantID"))
+	}
+	query := fmt.Sprintf(
+		`SELECT COALESCE(SUM(cost_usd), 0) FROM %s WHERE tenant_id = $1 AND ($2 = '' OR group_id = $2) AND created_at >= $3`,
+		r.tableNames.CommodityScanAudits(),
+	)
+	var total decimal.Decimal
+	if err := r.dbx.GetContext(ctx, &total, query, tenantID, groupID, since); err != nil {
+		return decimal.Zero, errxtrace.Wrap("sum commodity scan audit cost", err)
+	}
+	return total, nil
+}
+
+// Usage aggregates the provider-call rows created in [since, until) per
+// (tenant, group, provider, model).
+func (r *CommodityScanAuditRegistry) Usage(ctx context.Context, since, until time.Time) ([]models.CommodityScanUsage, error) {
+	query := fmt.Sprintf(
+		`SELECT tenant_id, group_id, provider, model,
+		        COUNT(*) AS scans,
+		        COALESCE(SUM(tokens_used), 0) AS tokens_used,
+		        COALESCE(SUM(cost_usd), 0) AS cost_usd
+		   FROM %s
+		  WHERE created_at >= $1 AND created_at < $2 AND status IN ('ok', 'error', 'timeout')
+		  GROUP BY tenant_id, group_id, provider, model
+		  ORDER BY tenant_id, group_id, provider, model`,
+		r.tableNames.CommodityScanAudits(),
+	)
+	var rows []models.CommodityScanUsage
+	if err := r.dbx.SelectContext(ctx, &rows, query, since, until); err != nil {
+		return nil, errxtrace.Wrap("aggregate commodity scan usage", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+var _ registry.CommodityScanBudgetRegistry = (*CommodityScanBudgetRegistry)(nil)
+
+// CommodityScanBudgetRegistry is the SQLite-backed store of AI vision
+// spend caps. The table is NOT RLS-enabled (same posture as
+// worker_control) and every operation is a single statement against
+// r.dbx. The unique (tenant_id, group_id) index backs Set's upsert.
+type CommodityScanBudgetRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// NewCommodityScanBudgetRegistry creates a new CommodityScanBudgetRegistry.
+func NewCommodityScanBudgetRegistry(dbx *sqlx.DB) *CommodityScanBudgetRegistry {
+	return NewCommodityScanBudgetRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+// NewCommodityScanBudgetRegistryWithTableNames is the test-friendly
+// constructor that lets a caller override the table-name mapping.
+func NewCommodityScanBudgetRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *CommodityScanBudgetRegistry {
+	return &CommodityScanBudgetRegistry{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+// List returns every budget ordered by (tenant_id, group_id).
+func (r *CommodityScanBudgetRegistry) List(ctx context.Context) ([]*models.CommodityScanBudget, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s ORDER BY tenant_id, group_id`, r.tableNames.CommodityScanBudgets())
+	return r.query(ctx, query)
+}
+
+// ListForTenant returns the tenant's budgets, tenant-wide first.
+func (r *CommodityScanBudgetRegistry) ListForTenant(ctx context.Context, tenantID string) ([]*models.CommodityScanBudget, error) {
+	if tenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE tenant_id = $1 ORDER BY group_id`, r.tableNames.CommodityScanBudgets())
+	return r.query(ctx, query, tenantID)
+}
+
+// Set creates or replaces the budget for (TenantID, GroupID). The
+// conflict branch keeps the row's id and uuid.
+func (r *CommodityScanBudgetRegistry) Set(ctx context.Context, budget models.CommodityScanBudget) (*models.CommodityScanBudget, error) {
+	if budget.TenantID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO main.%s (id, uuid, tenant_id, group_id, monthly_limit_usd, updated_at, updated_by)
+		 VALUES ($1, $2, $3, $4, $5, now(), $6)
+		 ON CONFLICT (tenant_id, group_id) DO UPDATE SET
+		   monthly_limit_usd = EXCLUDED.monthly_limit_usd,
+		   updated_at = EXCLUDED.updated_at,
+		   updated_by = EXCLUDED.updated_by
+		 RETURNING *`,
+		r.tableNames.CommodityScanBudgets(),
+	)
+
+	var stored models.CommodityScanBudget
+	if err := r.dbx.QueryRowxContext(ctx, query,
+		uuid.New().String(), uuid.New().String(), budget.TenantID, budget.GroupID, budget.MonthlyLimitUSD, budget.UpdatedBy,
+	).StructScan(&stored); err != nil {
+		return nil, errxtrace.Wrap("failed to set commodity scan budget", err)
+	}
+	return &stored, nil
+}
+
+// Delete removes the budget for (tenantID, groupID).
+func (r *CommodityScanBudgetRegistry) Delete(ctx context.Context, tenantID, groupID string) error {
+	if tenantID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+
+	query := fmt.Sprintf(`DELETE FROM main.%s WHERE tenant_id = $1 AND group_id = $2`, r.tableNames.CommodityScanBudgets())
+	res, err := r.dbx.ExecContext(ctx, query, tenantID, groupID)
+	if err != nil {
+		return errxtrace.Wrap("failed to delete commodity scan budget", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errxtrace.Wrap("failed to read deleted commodity scan budget count", err)
+	}
+	if affected == 0 {
+		return errxtrace.Classify(registry.ErrNotFound, errx.Attrs("tenant_id", tenantID, "group_id", groupID))
+	}
+	return nil
+}
+
+// query runs a SELECT over the budgets table and scans every row.
+func (r *CommodityScanBudgetRegistry) query(ctx context.Context, query string, args ...any) ([]*models.CommodityScanBudget, error) {
+	rows, err := r.dbx.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list commodity scan budgets", err)
+	}
+	defer rows.Close()
+
+	var budgets []*models.CommodityScanBudget
+	for rows.Next() {
+		var b models.CommodityScanBudget
+		if scanErr := rows.StructScan(&b); scanErr != nil {
+			return nil, errxtrace.Wrap("failed to scan commodity scan budget row", scanErr)
+		}
+		budgets = append(budgets, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errxtrace.Wrap("failed during commodity scan budget iteration", err)
+	}
+	return budgets, nil
+}
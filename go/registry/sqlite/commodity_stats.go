@@ -0,0 +1,257 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// commodityStatsValueExpr is registry.CommodityValue in SQL, gated on
+// status = 'in_use' like valuation.Valuator. $1 is the group currency.
+// The price columns are nullable, hence the COALESCEs.
+const commodityStatsValueExpr = `CASE WHEN c.status = 'in_use' THEN
+		CASE
+			WHEN COALESCE(c.current_price, 0) <> 0 THEN c.current_price
+			WHEN COALESCE(c.original_price, 0) <> 0 AND c.original_price_currency = $1 THEN c.original_price
+			WHEN COALESCE(c.converted_original_price, 0) <> 0 THEN c.converted_original_price
+			ELSE 0
+		END
+	ELSE 0 END`
+
+// commodityStatsRow is one GROUP BY row of an AggregateStats breakdown.
+type commodityStatsRow struct {
+	Key   string          `db:"key"`
+	Count int             `db:"count"`
+	Value decimal.Decimal `db:"value"`
+}
+
+// commodityStatsKeyExprs maps each scalar dimension to its grouping key.
+// Warranty status uses $2 (today) and $3 (the expiring cutoff) with the
+// same empty-string guard as buildWarrantyStatusCond. Tags are handled
+// separately because they fan out through a lateral join.
+var commodityStatsKeyExprs = map[registry.CommodityStatsDimension]string{
+	registry.CommodityStatsByStatus:       "c.status",
+	registry.CommodityStatsByType:         "c.type",
+	registry.CommodityStatsByArea:         "COALESCE(c.area_id, '')",
+	registry.CommodityStatsByLocation:     "COALESCE(a.location_id, '')",
+	registry.CommodityStatsByPurchaseYear: "CASE WHEN length(COALESCE(c.purchase_date, '')) >= 4 THEN left(c.purchase_date, 4) ELSE '' END",
+	registry.CommodityStatsByWarrantyStatus: `CASE
+		WHEN COALESCE(c.warranty_expires_at, '') = '' THEN 'none'
+		WHEN c.warranty_expires_at < $2 THEN 'expired'
+		WHEN c.warranty_expires_at <= $3 THEN 'expiring'
+		ELSE 'active'
+	END`,
+}
+
+// AggregateStats runs one GROUP BY per dimension inside a single
+// transaction so every breakdown sees the same snapshot. Results are
+// ordered in Go with registry.SortCommodityStatsBuckets to match memory.
+func (r *CommodityRegistry) AggregateStats(ctx context.Context, opts registry.CommodityStatsOptions) (*registry.CommodityStats, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	today := now.UTC().Format("2006-01-02")
+	cutoff := now.UTC().AddDate(0, 0, models.WarrantyExpiringWindowDays).Format("2006-01-02")
+
+	stats := &registry.CommodityStats{
+		Breakdowns: make(map[registry.CommodityStatsDimension][]registry.CommodityStatsBucket, len(registry.CommodityStatsDimensions)),
+	}
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		totalsArgs := []any{opts.GroupCurrency}
+		filterCond, totalsArgs := r.commodityStatsFilterCond(opts.Filter, totalsArgs)
+		totalsQuery := fmt.Sprintf(`
+			SELECT count(*), COALESCE(sum(%s), 0)
+			FROM %s c
+			WHERE c.draft = false%s`,
+			commodityStatsValueExpr, r.tableNames.Commodities(), filterCond)
+		if err := tx.QueryRowxContext(ctx, totalsQuery, totalsArgs...).Scan(&stats.TotalCount, &stats.TotalValue); err != nil {
+			return errxtrace.Wrap("failed to aggregate commodity totals", err)
+		}
+
+		for _, dim := range registry.CommodityStatsDimensions {
+			var query string
+			args := []any{opts.GroupCurrency}
+			if dim == registry.CommodityStatsByWarrantyStatus {
+				args = append(args, today, cutoff)
+			}
+			filterCond, args := r.commodityStatsFilterCond(opts.Filter, args)
+			switch dim {
+			case registry.CommodityStatsByTag:
+				// DISTINCT per commodity so a tag repeated in one row's
+				// array is counted once, matching memory.
+				query = fmt.Sprintf(`
+					SELECT COALESCE(t.tag, '') AS key, count(*) AS count, COALESCE(sum(%[1]s), 0) AS value
+					FROM %[2]s c
+					LEFT JOIN (
+						SELECT DISTINCT c2.id AS commodity_id, e.value AS tag
+						FROM %[2]s c2, %[3]s AS e
+					) t ON t.commodity_id = c.id
+					WHERE c.draft = false%[4]s
+					GROUP BY 1`,
+					commodityStatsValueExpr, r.tableNames.Commodities(), jsonArrayElements("c2.tags"), filterCond)
+			default:
+				query = fmt.Sprintf(`
+					SELECT %s AS key, count(*) AS count, COALESCE(sum(%s), 0) AS value
+					FROM %s c
+					LEFT JOIN %s a ON a.id = c.area_id
+					WHERE c.draft = false%s
+					GROUP BY 1`,
+					commodityStatsKeyExprs[dim], commodityStatsValueExpr, r.tableNames.Commodities(), r.tableNames.Areas(), filterCond)
+			}
+
+			var rows []commodityStatsRow
+			if err := tx.SelectContext(ctx, &rows, query, args...); err != nil {
+				return errxtrace.Wrap("failed to aggregate commodity stats", err)
+			}
+			buckets := make([]registry.CommodityStatsBucket, 0, len(rows))
+			for _, row := range rows {
+				buckets = append(buckets, registry.CommodityStatsBucket(row))
+			}
+			registry.SortCommodityStatsBuckets(buckets)
+			stats.Breakdowns[dim] = buckets
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to aggregate commodity stats", err)
+	}
+
+	return stats, nil
+}
+
+// commodityStatsFilterCond renders opts.Filter as an extra AND-ed
+// predicate for the AggregateStats queries, numbering its placeholders
+// after args and returning the extended argument list. The list-filter
+// builder emits unqualified column names, so it runs in a subquery on the
+// bare commodities table rather than against the aliased, joined outer
+// query.
+func (r *CommodityRegistry) commodityStatsFilterCond(filter *registry.CommodityListOptions, args []any) (string, []any) {
+	if filter == nil {
+		return "", args
+	}
+	where, whereArgs := buildCommodityWhereAt(*filter, string(r.tableNames.Commodities()), string(r.tableNames.CommodityLoans()), len(args)+1)
+	if where == "" {
+		return "", args
+	}
+	return fmt.Sprintf(" AND c.id IN (SELECT id FROM %s %s)", r.tableNames.Commodities(), where), append(args, whereArgs...)
+}
+
+// AggregateByArea sums the in-use valuation of non-draft commodities per area.
+func (r *CommodityRegistry) AggregateByArea(ctx context.Context, groupCurrency string) ([]registry.AggregationResult, error) {
+	var rows []commodityStatsRow
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`
+			SELECT c.area_id AS key, count(*) AS count, COALESCE(sum(%s), 0) AS value
+			FROM %s c
+			WHERE c.draft = false AND COALESCE(c.area_id, '') <> ''
+			GROUP BY c.area_id
+			ORDER BY c.area_id`,
+			commodityStatsValueExpr, r.tableNames.Commodities())
+		return tx.SelectContext(ctx, &rows, query, groupCurrency)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to aggregate commodities by area", err)
+	}
+
+	results := make([]registry.AggregationResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, registry.AggregationResult{
+			GroupBy: map[string]any{"area_id": row.Key},
+			Count:   row.Count,
+			Sum:     map[string]float64{"value": row.Value.InexactFloat64()},
+			Avg:     map[string]float64{"value": row.Value.Div(decimal.NewFromInt(int64(row.Count))).InexactFloat64()},
+		})
+	}
+	return results, nil
+}
+
+// CountByStatus counts non-draft commodities per status.
+func (r *CommodityRegistry) CountByStatus(ctx context.Context) (map[string]int, error) {
+	return r.countBy(ctx, "status")
+}
+
+// CountByType counts non-draft commodities per type.
+func (r *CommodityRegistry) CountByType(ctx context.Context) (map[string]int, error) {
+	return r.countBy(ctx, "type")
+}
+
+// countBy groups non-draft commodities on column. column is always a
+// hard-coded identifier from the callers above, never user input.
+func (r *CommodityRegistry) countBy(ctx context.Context, column string) (map[string]int, error) {
+	var rows []commodityStatsRow
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT %[1]s AS key, count(*) AS count, 0 AS value FROM %[2]s WHERE draft = false GROUP BY %[1]s`,
+			column, r.tableNames.Commodities(),
+		)
+		return tx.SelectContext(ctx, &rows, query)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to count commodities", err)
+	}
+
+	result := make(map[string]int, len(rows))
+	for _, row := range rows {
+		result[row.Key] = row.Count
+	}
+	return result, nil
+}
+
+// FindByPriceRange returns non-draft commodities whose original price is
+// within [minPrice, maxPrice], optionally restricted to one currency.
+func (r *CommodityRegistry) FindByPriceRange(ctx context.Context, minPrice, maxPrice decimal.Decimal, currency string) ([]*models.Commodity, error) {
+	var commodities []*models.Commodity
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`
+			SELECT * FROM %s
+			WHERE draft = false
+				AND COALESCE(original_price, 0) BETWEEN $1 AND $2
+				AND ($3 = '' OR original_price_currency = $3)
+			ORDER BY COALESCE(original_price, 0), id`,
+			r.tableNames.Commodities())
+		return tx.SelectContext(ctx, &commodities, query, minPrice, maxPrice, currency)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to find commodities by price range", err)
+	}
+	return commodities, nil
+}
+
+// FindByDateRange returns non-draft commodities purchased within
+// [startDate, endDate]. purchase_date is ISO text, so the range is a
+// plain string comparison.
+func (r *CommodityRegistry) FindByDateRange(ctx context.Context, startDate, endDate string) ([]*models.Commodity, error) {
+	if err := registry.ValidateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	var commodities []*models.Commodity
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`
+			SELECT * FROM %s
+			WHERE draft = false
+				AND COALESCE(purchase_date, '') <> ''
+				AND purchase_date BETWEEN $1 AND $2
+			ORDER BY purchase_date, id`,
+			r.tableNames.Commodities())
+		return tx.SelectContext(ctx, &commodities, query, startDate, endDate)
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to find commodities by date range", err)
+	}
+	return commodities, nil
+}
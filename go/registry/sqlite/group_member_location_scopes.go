@@ -0,0 +1,187 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+var _ registry.GroupMemberLocationScopeRegistry = (*GroupMemberLocationScopeRegistry)(nil)
+
+// GroupMemberLocationScopeRegistry persists the per-location role rows
+// that restrict a group member. Runs in service mode like
+// GroupNotificationPrefRegistry: the slug resolver reads the scope before
+// any group-scoped transaction exists, and the table's RLS policy covers
+// tenant isolation.
+type GroupMemberLocationScopeRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+func NewGroupMemberLocationScopeRegistry(dbx *sqlx.DB) *GroupMemberLocationScopeRegistry {
+	return &GroupMemberLocationScopeRegistry{
+		dbx:        dbx,
+		tableNames: store.DefaultTableNames,
+	}
+}
+
+func (r *GroupMemberLocationScopeRegistry) newSQLRegistry() *store.RLSRepository[models.GroupMemberLocationScope, *models.GroupMemberLocationScope] {
+	return store.NewServiceSQLRegistry[models.GroupMemberLocationScope, *models.GroupMemberLocationScope](r.dbx, r.tableNames.MemberLocationScopes())
+}
+
+const memberLocationScopeColumns = `id, uuid, tenant_id, group_id, member_user_id, location_id, role, created_at`
+
+func (r *GroupMemberLocationScopeRegistry) ListByMember(ctx context.Context, tenantID, groupID, userID string) ([]*models.GroupMemberLocationScope, error) {
+	if tenantID == "" || groupID == "" || userID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id|member_user_id"))
+	}
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s
+		 WHERE tenant_id = $1 AND group_id = $2 AND member_user_id = $3
+		 ORDER BY location_id`,
+		memberLocationScopeColumns, r.tableNames.MemberLocationScopes(),
+	)
+	out, err := r.query(ctx, query, tenantID, groupID, userID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list member location scopes", err)
+	}
+	return out, nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) ListByGroup(ctx context.Context, tenantID, groupID string) ([]*models.GroupMemberLocationScope, error) {
+	if tenantID == "" || groupID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id"))
+	}
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s
+		 WHERE tenant_id = $1 AND group_id = $2
+		 ORDER BY member_user_id, location_id`,
+		memberLocationScopeColumns, r.tableNames.MemberLocationScopes(),
+	)
+	out, err := r.query(ctx, query, tenantID, groupID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list group location scopes", err)
+	}
+	return out, nil
+}
+
+// ReplaceForMember deletes the member's rows and inserts the new set in
+// one transaction, so the slug resolver never sees a half-written scope
+// (an empty intermediate state would briefly lift the restriction).
+func (r *GroupMemberLocationScopeRegistry) ReplaceForMember(ctx context.Context, tenantID, groupID, userID string, scopes []models.GroupMemberLocationScope) ([]*models.GroupMemberLocationScope, error) {
+	if tenantID == "" || groupID == "" || userID == "" {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id|member_user_id"))
+	}
+
+	table := r.tableNames.MemberLocationScopes()
+	now := time.Now().UTC()
+	out := make([]*models.GroupMemberLocationScope, 0, len(scopes))
+	err := r.newSQLRegistry().Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		deleteQuery := fmt.Sprintf(
+			`DELETE FROM main.%s WHERE tenant_id = $1 AND group_id = $2 AND member_user_id = $3`, table,
+		)
+		if _, err := tx.ExecContext(ctx, deleteQuery, tenantID, groupID, userID); err != nil {
+			return err
+		}
+
+		insertQuery := fmt.Sprintf(
+			`INSERT INTO main.%s (id, tenant_id, group_id, member_user_id, location_id, role, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 RETURNING %s`,
+			table, memberLocationScopeColumns,
+		)
+		for _, scope := range scopes {
+			var written models.GroupMemberLocationScope
+			row := tx.QueryRowxContext(ctx, insertQuery,
+				uuid.NewString(),
+				tenantID,
+				groupID,
+				userID,
+				scope.LocationID,
+				scope.Role,
+				now,
+			)
+			if err := row.StructScan(&written); err != nil {
+				return err
+			}
+			out = append(out, &written)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to replace member location scopes", err)
+	}
+	return out, nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) DeleteByGroup(ctx context.Context, tenantID, groupID string) (int, error) {
+	if tenantID == "" || groupID == "" {
+		return 0, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id|group_id"))
+	}
+
+	var deleted int64
+	err := r.newSQLRegistry().Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`DELETE FROM main.%s WHERE tenant_id = $1 AND group_id = $2`,
+			r.tableNames.MemberLocationScopes(),
+		)
+		res, err := tx.ExecContext(ctx, query, tenantID, groupID)
+		if err != nil {
+			return err
+		}
+		deleted, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to delete location scopes by group", err)
+	}
+	return int(deleted), nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) DeleteByUser(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "member_user_id"))
+	}
+
+	err := r.newSQLRegistry().Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`DELETE FROM main.%s WHERE member_user_id = $1`,
+			r.tableNames.MemberLocationScopes(),
+		)
+		_, err := tx.ExecContext(ctx, query, userID)
+		return err
+	})
+	if err != nil {
+		return errxtrace.Wrap("failed to delete location scopes by user", err)
+	}
+	return nil
+}
+
+func (r *GroupMemberLocationScopeRegistry) query(ctx context.Context, query string, args ...any) ([]*models.GroupMemberLocationScope, error) {
+	var out []*models.GroupMemberLocationScope
+	err := r.newSQLRegistry().Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		rows, err := tx.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var s models.GroupMemberLocationScope
+			if err := rows.StructScan(&s); err != nil {
+				return err
+			}
+			out = append(out, &s)
+		}
+		return rows.Err()
+	})
+	return out, err
+}
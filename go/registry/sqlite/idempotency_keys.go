@@ -0,0 +1,168 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+var _ registry.IdempotencyKeyRegistry = (*IdempotencyKeyRegistry)(nil)
+
+// IdempotencyKeyRegistry is the SQLite-backed Idempotency-Key store. The
+// table is NOT RLS-enabled (same posture as registration_requests) and
+// every operation is a single statement against r.dbx. Reserve relies on
+// the unique (user_id, idempotency_key) index, so two requests racing on
+// one key can't both win.
+type IdempotencyKeyRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// NewIdempotencyKeyRegistry creates a new IdempotencyKeyRegistry.
+func NewIdempotencyKeyRegistry(dbx *sqlx.DB) *IdempotencyKeyRegistry {
+	return NewIdempotencyKeyRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+// NewIdempotencyKeyRegistryWithTableNames is the test-friendly constructor
+// that lets a caller override the table-name mapping.
+func NewIdempotencyKeyRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *IdempotencyKeyRegistry {
+	return &IdempotencyKeyRegistry{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+// Reserve claims the user's key unless a live record holds it. The upsert
+// only overwrites an expired row; when a live row wins the conflict the
+// INSERT returns nothing and a follow-up SELECT loads it. A live row
+// deleted in between (released or swept) surfaces as ErrNotFound, which the
+// caller treats like any other registry failure.
+func (r *IdempotencyKeyRegistry) Reserve(ctx context.Context, rec models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	if rec.TenantID == "" {
+		return nil, false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "tenant_id"))
+	}
+	if rec.UserID == "" {
+		return nil, false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "user_id"))
+	}
+	if rec.Key == "" {
+		return nil, false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "idempotency_key"))
+	}
+
+	table := r.tableNames.IdempotencyKeys()
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (id, uuid, tenant_id, user_id, idempotency_key, request_hash, status_code, content_type, response_body, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, 0, '', NULL, $7, now())
+		 ON CONFLICT (user_id, idempotency_key) DO UPDATE SET
+		   id = EXCLUDED.id, uuid = EXCLUDED.uuid, tenant_id = EXCLUDED.tenant_id,
+		   request_hash = EXCLUDED.request_hash, status_code = 0, content_type = '',
+		   response_body = NULL, expires_at = EXCLUDED.expires_at, created_at = now()
+		 WHERE %[1]s.expires_at < now()
+		 RETURNING *`,
+		table,
+	)
+
+	var stored models.IdempotencyKey
+	err := r.dbx.QueryRowxContext(ctx, query,
+		uuid.New().String(), uuid.New().String(), rec.TenantID, rec.UserID, rec.Key, rec.RequestHash, rec.ExpiresAt,
+	).StructScan(&stored)
+	if err == nil {
+		return &stored, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, errxtrace.Wrap("failed to reserve idempotency key", err)
+	}
+
+	existing, err := r.getOne(ctx,
+		fmt.Sprintf(`SELECT * FROM %s WHERE user_id = $1 AND idempotency_key = $2`, table),
+		errx.Attrs("user_id", rec.UserID), rec.UserID, rec.Key,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+// Complete stores the response on the reservation.
+func (r *IdempotencyKeyRegistry) Complete(ctx context.Context, id string, statusCode int, contentType string, body []byte, expiresAt time.Time) error {
+	query := fmt.Sprintf(
+		`UPDATE main.%s SET status_code = $2, content_type = $3, response_body = $4, expires_at = $5 WHERE id = $1`,
+		r.tableNames.IdempotencyKeys(),
+	)
+	res, err := r.dbx.ExecContext(ctx, query, id, statusCode, contentType, body, expiresAt)
+	if err != nil {
+		return errxtrace.Wrap("failed to complete idempotency key", err, errx.Attrs("id", id))
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errxtrace.Wrap("failed to read rows affected", err)
+	}
+	if rows == 0 {
+		return errxtrace.Classify(registry.ErrNotFound, errx.Attrs("id", id))
+	}
+	return nil
+}
+
+// Release deletes the reservation, if any.
+func (r *IdempotencyKeyRegistry) Release(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`DELETE FROM main.%s WHERE id = $1`, r.tableNames.IdempotencyKeys())
+	if _, err := r.dbx.ExecContext(ctx, query, id); err != nil {
+		return errxtrace.Wrap("failed to release idempotency key", err, errx.Attrs("id", id))
+	}
+	return nil
+}
+
+// DeleteByUserID removes all records of the user.
+func (r *IdempotencyKeyRegistry) DeleteByUserID(ctx context.Context, userID string) error {
+	query := fmt.Sprintf(`DELETE FROM main.%s WHERE user_id = $1`, r.tableNames.IdempotencyKeys())
+	if _, err := r.dbx.ExecContext(ctx, query, userID); err != nil {
+		return errxtrace.Wrap("failed to delete idempotency keys", err, errx.Attrs("user_id", userID))
+	}
+	return nil
+}
+
+// DeleteByTenantID removes all records of the tenant.
+func (r *IdempotencyKeyRegistry) DeleteByTenantID(ctx context.Context, tenantID string) error {
+	query := fmt.Sprintf(`DELETE FROM main.%s WHERE tenant_id = $1`, r.tableNames.IdempotencyKeys())
+	if _, err := r.dbx.ExecContext(ctx, query, tenantID); err != nil {
+		return errxtrace.Wrap("failed to delete idempotency keys", err, errx.Attrs("tenant_id", tenantID))
+	}
+	return nil
+}
+
+// DeleteExpired removes all records whose ExpiresAt timestamp is in the past.
+func (r *IdempotencyKeyRegistry) DeleteExpired(ctx context.Context) (int, error) {
+	query := fmt.Sprintf(`DELETE FROM main.%s WHERE expires_at < now()`, r.tableNames.IdempotencyKeys())
+	res, err := r.dbx.ExecContext(ctx, query)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to delete expired idempotency keys", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to read rows affected", err)
+	}
+	return int(rows), nil
+}
+
+// getOne runs a single-row SELECT, mapping no rows to ErrNotFound.
+func (r *IdempotencyKeyRegistry) getOne(ctx context.Context, query string, attrs errx.Classified, args ...any) (*models.IdempotencyKey, error) {
+	var rec models.IdempotencyKey
+	err := r.dbx.QueryRowxContext(ctx, query, args...).StructScan(&rec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errxtrace.Classify(registry.ErrNotFound, attrs)
+	}
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get idempotency key", err)
+	}
+	return &rec, nil
+}
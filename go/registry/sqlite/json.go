@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"fmt"
+)
+
+// The models' JSON columns are written as the []byte their Valuers return,
+// so SQLite stores them as BLOBs, which its JSON functions would read as
+// binary JSON. Every JSON expression below therefore reads the column as
+// text first.
+
+// jsonText reads a JSON column as JSON text.
+func jsonText(column string) string {
+	return fmt.Sprintf("CAST(%s AS TEXT)", column)
+}
+
+// jsonArrayElements is a json_each source over a JSON array column, the
+// counterpart of jsonb_array_elements_text. Values that are not arrays
+// (SQL NULL, JSON null) yield no rows instead of an error.
+func jsonArrayElements(column string) string {
+	return fmt.Sprintf(`json_each(CASE WHEN json_type(%[1]s) = 'array' THEN %[1]s ELSE '[]' END)`, jsonText(column))
+}
+
+// jsonArrayContains is true when the JSON array column holds value, the
+// counterpart of `column @> jsonb_build_array(value)`.
+func jsonArrayContains(column, value string) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM %s WHERE value = %s)`, jsonArrayElements(column), value)
+}
+
+// jsonArrayContainsAny is true when the JSON array column holds any of the
+// values in the store.ValueList parameter list, the counterpart of
+// `column ?| list`.
+func jsonArrayContainsAny(column, list string) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM %s WHERE value IN (SELECT value FROM json_each(%s)))`, jsonArrayElements(column), list)
+}
+
+// jsonArrayContainsAll is true when the JSON array column holds every value
+// in the store.ValueList parameter list, the counterpart of
+// `column @> list`.
+func jsonArrayContainsAll(column, list string) string {
+	return fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM json_each(%s) AS want WHERE want.value NOT IN (SELECT value FROM %s))`, list, jsonArrayElements(column))
+}
+
+// jsonArrayLength is the number of elements of the JSON array column, 0
+// for SQL NULL and for values that are not arrays.
+func jsonArrayLength(column string) string {
+	return fmt.Sprintf(`(SELECT COUNT(*) FROM %s)`, jsonArrayElements(column))
+}
@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+// MaintenanceLogRegistryFactory creates
+// MaintenanceLogRegistry instances with proper context.
+type MaintenanceLogRegistryFactory struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// MaintenanceLogRegistry is the SQLite-backed group-scoped
+// registry of maintenance logs.
+type MaintenanceLogRegistry struct {
+	dbx             *sqlx.DB
+	tableNames      store.TableNames
+	tenantID        string
+	groupID         string
+	createdByUserID string
+	service         bool
+}
+
+var (
+	_ registry.MaintenanceLogRegistry        = (*MaintenanceLogRegistry)(nil)
+	_ registry.MaintenanceLogRegistryFactory = (*MaintenanceLogRegistryFactory)(nil)
+)
+
+func NewMaintenanceLogRegistry(dbx *sqlx.DB) *MaintenanceLogRegistryFactory {
+	return NewMaintenanceLogRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewMaintenanceLogRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *MaintenanceLogRegistryFactory {
+	return &MaintenanceLogRegistryFactory{dbx: dbx, tableNames: tableNames}
+}
+
+func (f *MaintenanceLogRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.MaintenanceLogRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *MaintenanceLogRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.MaintenanceLogRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+	return &MaintenanceLogRegistry{
+		dbx:             f.dbx,
+		tableNames:      f.tableNames,
+		tenantID:        user.TenantID,
+		groupID:         appctx.GroupIDFromContext(ctx),
+		createdByUserID: user.ID,
+		service:         false,
+	}, nil
+}
+
+func (f *MaintenanceLogRegistryFactory) CreateServiceRegistry() registry.MaintenanceLogRegistry {
+	return &MaintenanceLogRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		service:    true,
+	}
+}
+
+func (r *MaintenanceLogRegistry) newSQLRegistry() *store.RLSGroupRepository[models.MaintenanceLog, *models.MaintenanceLog] {
+	if r.service {
+		return store.NewGroupServiceSQLRegistry[models.MaintenanceLog](r.dbx, r.tableNames.MaintenanceLogs())
+	}
+	return store.NewGroupAwareSQLRegistry[models.MaintenanceLog](r.dbx, r.tenantID, r.groupID, r.createdByUserID, r.tableNames.MaintenanceLogs())
+}
+
+func (r *MaintenanceLogRegistry) Get(ctx context.Context, id string) (*models.MaintenanceLog, error) {
+	var item models.MaintenanceLog
+	if err := r.newSQLRegistry().ScanOneByField(ctx, store.Pair("id", id), &item); err != nil {
+		return nil, errxtrace.Wrap("failed to get maintenance log", err)
+	}
+	return &item, nil
+}
+
+func (r *MaintenanceLogRegistry) List(ctx context.Context) ([]*models.MaintenanceLog, error) {
+	var items []*models.MaintenanceLog
+	for item, err := range r.newSQLRegistry().Scan(ctx) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list maintenance logs", err)
+		}
+		it := item
+		items = append(items, &it)
+	}
+	return items, nil
+}
+
+func (r *MaintenanceLogRegistry) Count(ctx context.Context) (int, error) {
+	cnt, err := r.newSQLRegistry().Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count maintenance logs", err)
+	}
+	return cnt, nil
+}
+
+func (r *MaintenanceLogRegistry) Create(ctx context.Context, entity models.MaintenanceLog) (*models.MaintenanceLog, error) {
+	entity.CreatedAt = time.Now()
+	created, err := r.newSQLRegistry().Create(ctx, entity, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create maintenance log", err)
+	}
+	return &created, nil
+}
+
+func (r *MaintenanceLogRegistry) Update(ctx context.Context, entity models.MaintenanceLog) (*models.MaintenanceLog, error) {
+	if err := r.newSQLRegistry().Update(ctx, entity, nil); err != nil {
+		return nil, errxtrace.Wrap("failed to update maintenance log", err)
+	}
+	return &entity, nil
+}
+
+func (r *MaintenanceLogRegistry) Delete(ctx context.Context, id string) error {
+	return r.newSQLRegistry().Delete(ctx, id, nil)
+}
+
+func (r *MaintenanceLogRegistry) ListBySchedule(ctx context.Context, scheduleID string) ([]*models.MaintenanceLog, error) {
+	var logs []*models.MaintenanceLog
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT * FROM %s WHERE schedule_id = $1 ORDER BY done_at DESC, created_at DESC`,
+			r.tableNames.MaintenanceLogs())
+		rows, err := tx.QueryxContext(ctx, query, scheduleID)
+		if err != nil {
+			return errxtrace.Wrap("failed to query maintenance logs", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var log models.MaintenanceLog
+			if err := rows.StructScan(&log); err != nil {
+				return errxtrace.Wrap("failed to scan maintenance log", err)
+			}
+			l := log
+			logs = append(logs, &l)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list maintenance logs for schedule", err)
+	}
+	return logs, nil
+}
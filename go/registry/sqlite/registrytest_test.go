@@ -0,0 +1,114 @@
+package sqlite_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/registrytest"
+	"github.com/denisvmedia/inventario/registry/sqlite"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+	sqliteschema "github.com/denisvmedia/inventario/schema/migrations/sqlite"
+	"github.com/denisvmedia/inventario/services"
+)
+
+var (
+	// The migrated schema every test database starts from, built once per
+	// test binary and copied per database.
+	templateOnce sync.Once
+	templateDB   []byte
+	templateErr  error
+)
+
+func TestRegistry(t *testing.T) {
+	registrytest.Run(t, harness{})
+}
+
+// harness opens a new database file per store, copied from the migrated
+// template, so the suite needs no external service.
+type harness struct{}
+
+func (harness) OpenStore(t *testing.T) registrytest.Store {
+	t.Helper()
+	c := qt.New(t)
+
+	template, err := migratedTemplate()
+	c.Assert(err, qt.IsNil)
+
+	path := filepath.Join(t.TempDir(), "inventario.db")
+	c.Assert(os.WriteFile(path, template, 0o600), qt.IsNil)
+
+	dbx, err := store.Open("sqlite://" + path)
+	c.Assert(err, qt.IsNil)
+	t.Cleanup(func() { dbx.Close() })
+
+	return testStore{dbx: dbx}
+}
+
+type testStore struct {
+	dbx *sqlx.DB
+}
+
+func (s testStore) FactorySet() *registry.FactorySet {
+	return sqlite.NewFactorySet(s.dbx)
+}
+
+func (s testStore) CurrencyMigrationProcessor() services.CurrencyMigrationProcessor {
+	return processorAdapter{inner: sqlite.NewCurrencyMigrationRegistry(s.dbx).NewProcessor()}
+}
+
+func (s testStore) DoAsBackgroundWorker(ctx context.Context, fn func(context.Context, *sqlx.Tx) error) error {
+	return store.DoAsBackgroundWorker(ctx, s.dbx, fn)
+}
+
+// processorAdapter bridges the sqlite processor to
+// services.CurrencyMigrationProcessor, as the worker bootstrap does.
+type processorAdapter struct {
+	inner *sqlite.CurrencyMigrationProcessor
+}
+
+func (a processorAdapter) ProcessRunningMigration(ctx context.Context, op *models.CurrencyMigration) (services.CurrencyMigrationProcessSummary, error) {
+	res, err := a.inner.ProcessRunningMigration(ctx, op)
+	return services.CurrencyMigrationProcessSummary(res), err
+}
+
+func (a processorAdapter) WriteSweepFailureAuditLog(ctx context.Context, op *models.CurrencyMigration) error {
+	return a.inner.WriteSweepFailureAuditLog(ctx, op)
+}
+
+// migratedTemplate returns the bytes of a freshly migrated database file.
+func migratedTemplate() ([]byte, error) {
+	templateOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "inventario-sqlite-template")
+		if err != nil {
+			templateErr = err
+			return
+		}
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "template.db")
+		db, err := sqliteschema.Open("sqlite://" + path)
+		if err != nil {
+			templateErr = err
+			return
+		}
+		_, err = sqliteschema.MigrateUp(context.Background(), db, must.Must(sqliteschema.EmbeddedMigrationsFS()))
+		if closeErr := db.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			templateErr = err
+			return
+		}
+		templateDB, templateErr = os.ReadFile(path)
+	})
+	return templateDB, templateErr
+}
@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+const Name = "sqlite"
+
+func Register() (cleanup func() error) {
+	newFn, cleanup := NewSQLiteRegistrySet()
+	registry.Register(Name, newFn)
+	return cleanup
+}
+
+func NewFactorySet(dbx *sqlx.DB) *registry.FactorySet {
+	// Create factory instances that will create context-aware registries
+	restoreStepFactory := NewRestoreStepRegistry(dbx)
+
+	fs := &registry.FactorySet{}
+	fs.LocationRegistryFactory = NewLocationRegistry(dbx)
+	fs.AreaRegistryFactory = NewAreaRegistry(dbx)
+	fs.SettingsRegistryFactory = NewSettingsRegistry(dbx)
+	fs.FileRegistryFactory = NewFileRegistry(dbx)
+	fs.CommodityRegistryFactory = NewCommodityRegistry(dbx)
+	fs.CommodityEventRegistryFactory = NewCommodityEventRegistry(dbx)
+	fs.TagRegistryFactory = NewTagRegistry(dbx)
+	fs.CommodityLoanRegistryFactory = NewCommodityLoanRegistry(dbx)
+	fs.CommodityServiceRegistryFactory = NewCommodityServiceRegistry(dbx)
+	fs.SupplyLinkRegistryFactory = NewSupplyLinkRegistry(dbx)
+	fs.MaintenanceScheduleRegistryFactory = NewMaintenanceScheduleRegistry(dbx)
+	fs.MaintenanceLogRegistryFactory = NewMaintenanceLogRegistry(dbx)
+	fs.CommodityMeterReadingRegistryFactory = NewCommodityMeterReadingRegistry(dbx)
+	fs.InvoiceExtractionRegistryFactory = NewInvoiceExtractionRegistry(dbx)
+	fs.SavedViewRegistryFactory = NewSavedViewRegistry(dbx)
+	fs.BackupScheduleRegistryFactory = NewBackupScheduleRegistry(dbx)
+	fs.ExportRegistryFactory = NewExportRegistry(dbx)
+	fs.RestoreStepRegistryFactory = restoreStepFactory
+	fs.RestoreOperationRegistryFactory = NewRestoreOperationRegistry(dbx, restoreStepFactory)
+	fs.TenantRegistry = NewTenantRegistry(dbx)
+	fs.UserRegistry = NewUserRegistry(dbx)
+	fs.RefreshTokenRegistry = NewRefreshTokenRegistry(dbx)
+	fs.CalendarFeedRegistry = NewCalendarFeedRegistry(dbx)
+	fs.LoginEventRegistry = NewLoginEventRegistry(dbx)
+	fs.UserMFASecretRegistry = NewUserMFASecretRegistry(dbx)
+	fs.AuditLogRegistry = NewAuditLogRegistry(dbx)
+	fs.SystemAdminGrantRegistry = NewSystemAdminGrantRegistry(dbx)
+	// Background-worker soft-pause control (issue #1308). Global control
+	// plane — not tenant-scoped, no RLS (same posture as system_admin_grants).
+	fs.WorkerControlRegistry = NewWorkerControlRegistry(dbx)
+	// Backup-signing keyring — global like worker_control, no RLS.
+	fs.TrustedBackupKeyRegistry = NewTrustedBackupKeyRegistry(dbx)
+	// AI vision spend caps — set from the back office, no RLS.
+	fs.CommodityScanBudgetRegistry = NewCommodityScanBudgetRegistry(dbx)
+	// Registration approval queue — decided across tenants, no RLS.
+	fs.RegistrationRequestRegistry = NewRegistrationRequestRegistry(dbx)
+	// Idempotency-Key replay records — resolved before the handler runs and
+	// swept across tenants by the cleanup worker, no RLS.
+	fs.IdempotencyKeyRegistry = NewIdempotencyKeyRegistry(dbx)
+	fs.EmailVerificationRegistry = NewEmailVerificationRegistry(dbx)
+	fs.PasswordResetRegistry = NewPasswordResetRegistry(dbx)
+	// Magic-link sign-in tokens — service-mode lookup resolved before any
+	// user session exists (same posture as reset/verification tokens).
+	fs.MagicLinkTokenRegistry = NewMagicLinkTokenRegistry(dbx)
+	// OAuth identities (#1394) — service-mode lookup keyed by
+	// (provider, provider_user_id) during the callback before any user
+	// session exists.
+	fs.OAuthIdentityRegistry = NewOAuthIdentityRegistry(dbx)
+	fs.ThumbnailGenerationJobRegistryFactory = NewThumbnailGenerationJobRegistry(dbx)
+	fs.UserConcurrencySlotRegistryFactory = NewUserConcurrencySlotRegistry(dbx)
+	fs.OperationSlotRegistryFactory = NewOperationSlotRegistryFactory(dbx)
+	fs.LocationGroupRegistry = NewLocationGroupRegistry(dbx)
+	fs.GroupMembershipRegistry = NewGroupMembershipRegistry(dbx)
+	fs.GroupInviteRegistry = NewGroupInviteRegistry(dbx)
+	fs.GroupInviteAuditRegistry = NewGroupInviteAuditRegistry(dbx)
+	fs.GroupNotificationPrefRegistry = NewGroupNotificationPrefRegistry(dbx)
+	fs.GroupMemberLocationScopeRegistry = NewGroupMemberLocationScopeRegistry(dbx)
+	fs.GroupPurger = NewGroupPurger(dbx)
+	fs.GroupTransferrer = NewGroupTransferrer(dbx)
+	fs.TenantPurger = NewTenantPurger(dbx)
+	fs.UserPurger = NewUserPurger(dbx)
+	fs.UserContentOwnershipChecker = NewUserContentOwnershipChecker(dbx)
+	fs.WarrantyReminderRegistry = NewWarrantyReminderRegistry(dbx)
+	fs.StorageQuotaReminderRegistry = NewStorageQuotaReminderRegistry(dbx)
+	fs.MaintenanceReminderRegistry = NewMaintenanceReminderRegistry(dbx)
+	fs.CurrencyMigrationRegistryFactory = NewCurrencyMigrationRegistry(dbx)
+	fs.CommodityScanAuditRegistry = NewCommodityScanAuditRegistry(dbx)
+	// Back-office identities (issue #1785) — platform-operator users
+	// that live OUTSIDE the tenant model. Wired on FactorySet only;
+	// not part of the per-request Set since back-office identities are
+	// cross-cutting infra, not user-aware data.
+	fs.BackofficeUserRegistry = NewBackofficeUserRegistry(dbx)
+	// Back-office refresh tokens (issue #1785, Phase 2). No RLS on the
+	// underlying table — the login flow runs before any DB session
+	// context is set, so the registry uses a plain (non-RLS) repository
+	// rather than the service-mode pattern the tenant-side
+	// RefreshTokenRegistry uses to satisfy the RLS background-worker
+	// policy.
+	fs.BackofficeRefreshTokenRegistry = NewBackofficeRefreshTokenRegistry(dbx)
+	// Back-office MFA secrets (issue #1785, Phase 4). One row per
+	// back-office user; the operator CLI mints, regenerates, and wipes
+	// rows. Same no-RLS rationale as the rest of the back-office tables.
+	fs.BackofficeUserMFASecretRegistry = NewBackofficeUserMFASecretRegistry(dbx)
+	// Installation-wide business-metrics source (#843). Runs its aggregate
+	// reads under the background-worker role, so the counts and storage
+	// totals span every tenant; the bootstrap layer adapts this into the
+	// metrics.BusinessStats gauges. Service-mode only — never reachable
+	// from a request path.
+	fs.SystemStats = newSystemStatsFunc(dbx)
+	fs.PingFn = dbx.PingContext
+
+	return fs
+}
+
+// NewRegistrySetWithUserAndGroupID builds a per-request, user+group-aware
+// registry set directly (no middleware). Group-scoped data registries (for
+// the group-isolated tables) require a non-empty groupID: a missing group
+// would let validation on TenantGroupAwareEntityID pass at the Go level but
+// fail later with a NOT NULL / RLS violation that's hard to debug.
+//
+// The previous `NewRegistrySetWithUserID` helper took only (userID, tenantID)
+// and implicitly passed groupID="". That shape was safe to call from user-
+// scoped flows (refresh-token / settings bootstrap) but produced broken
+// registry sets when called from group-scoped code paths. It was removed
+// rather than fixed — callers that really don't need a group can pass "" to
+// this function explicitly, which makes the intent visible at the call site.
+func NewRegistrySetWithUserAndGroupID(dbx *sqlx.DB, userID, tenantID, groupID string) *registry.Set {
+	ctx := appctx.WithUser(context.Background(), &models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: userID},
+			TenantID: tenantID,
+		},
+	})
+
+	if groupID != "" {
+		ctx = appctx.WithGroup(ctx, &models.LocationGroup{
+			TenantAwareEntityID: models.TenantAwareEntityID{
+				EntityID: models.EntityID{ID: groupID},
+				TenantID: tenantID,
+			},
+		})
+	}
+
+	fs := NewFactorySet(dbx)
+	s, err := fs.CreateUserRegistrySet(ctx)
+	if err != nil {
+		panic(err) // This maintains the same behavior as the original must.Must calls
+	}
+	return s
+}
+
+func NewSQLiteRegistrySet() (registrySetFunc func(c registry.Config) (factorySet *registry.FactorySet, err error), cleanup func() error) {
+	var doCleanup = func() error { return nil }
+
+	return func(c registry.Config) (factorySet *registry.FactorySet, err error) {
+		parsed, err := c.Parse()
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to parse config DSN", err)
+		}
+
+		if parsed.Scheme != Name {
+			return nil, errxtrace.Wrap("invalid scheme", registry.ErrInvalidConfig, errx.Attrs("expected", Name, "got", parsed.Scheme))
+		}
+
+		// The connections carry the row isolation objects (see
+		// store/isolation.go), so the schema must be migrated before the
+		// first connection is opened.
+		sqlxDB, err := store.Open(string(c))
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to open SQLite database", err)
+		}
+
+		if err := sqlxDB.PingContext(context.Background()); err != nil {
+			sqlxDB.Close()
+			return nil, errxtrace.Wrap("failed to connect to SQLite", err)
+		}
+
+		factorySet = NewFactorySet(sqlxDB)
+
+		doCleanup = sqlxDB.Close
+
+		return factorySet, nil
+	}, func() error { return doCleanup() }
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-extras/errx"
+	sqlitedriver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var (
+	ErrNotFound                = registry.ErrNotFound
+	ErrUserIDRequired          = errors.New("user ID is required")
+	ErrGroupIDRequired         = errors.New("group ID is required")
+	ErrTenantIDRequired        = errors.New("tenant ID is required")
+	ErrCreatedByUserIDRequired = errors.New("created_by_user_id is required")
+)
+
+// scopeViolation returns the classification for a write rejected by the
+// location-scope terms of the isolation triggers. It only fires for a
+// location-scoped member: for everybody else the scope terms are always
+// true, so an isolation abort means something else and is left
+// unclassified.
+func scopeViolation(ctx context.Context, err error) []errx.Classified {
+	if !appctx.LocationScopeFromContext(ctx).Restricted() {
+		return nil
+	}
+	if !strings.Contains(err.Error(), rlsViolation) && !strings.Contains(err.Error(), scopeWriteViolation) {
+		return nil
+	}
+	return []errx.Classified{registry.ErrLocationScopeDenied}
+}
+
+// IsUniqueViolation reports whether err is a UNIQUE or PRIMARY KEY
+// constraint failure. SQLite names the columns rather than the index that
+// failed ("UNIQUE constraint failed: t.a, t.b"), so with columns given it
+// only matches a failure on exactly those columns of table.
+func IsUniqueViolation(err error, table TableName, columns ...string) bool {
+	var sqliteErr *sqlitedriver.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	if code := sqliteErr.Code(); code != sqlite3.SQLITE_CONSTRAINT_UNIQUE && code != sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return false
+	}
+	if len(columns) == 0 {
+		return true
+	}
+	qualified := make([]string, len(columns))
+	for i, column := range columns {
+		qualified[i] = string(table) + "." + column
+	}
+	return strings.Contains(sqliteErr.Error(), fmt.Sprintf("constraint failed: %s (", strings.Join(qualified, ", ")))
+}
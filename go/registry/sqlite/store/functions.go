@@ -0,0 +1,73 @@
+package store
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+	"unicode"
+
+	sqlitedriver "modernc.org/sqlite"
+)
+
+// SQL functions the registries use that PostgreSQL has built in and SQLite
+// does not. They are registered with the driver once, for every
+// connection it opens.
+
+// TimeLayout is how the driver stores time.Time values (the _time_format
+// and _timezone settings in the schema package). Times in this layout sort
+// and compare as text.
+const TimeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+func init() {
+	sqlitedriver.MustRegisterScalarFunction("now", 0, sqlNow)
+	sqlitedriver.MustRegisterDeterministicScalarFunction("similarity", 2, sqlSimilarity)
+}
+
+// sqlNow is now(): the current UTC time in TimeLayout, so it compares with
+// stored timestamps the way PostgreSQL's now() does.
+func sqlNow(*sqlitedriver.FunctionContext, []driver.Value) (driver.Value, error) {
+	return time.Now().UTC().Format(TimeLayout), nil
+}
+
+// sqlSimilarity is pg_trgm's similarity(a, b): the share of trigrams the
+// two strings have in common.
+func sqlSimilarity(_ *sqlitedriver.FunctionContext, args []driver.Value) (driver.Value, error) {
+	a, aok := args[0].(string)
+	b, bok := args[1].(string)
+	if !aok || !bok {
+		return nil, nil
+	}
+	return Similarity(a, b), nil
+}
+
+// Similarity mirrors pg_trgm's similarity: both strings are lower-cased and
+// split into words of letters and digits, every word is padded with two
+// spaces in front and one behind, and the result is the number of shared
+// trigrams divided by the number of distinct trigrams of both strings.
+func Similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range ta {
+		if tb[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,221 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jmoiron/sqlx"
+	sqlitedriver "modernc.org/sqlite"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	sqliteschema "github.com/denisvmedia/inventario/schema/migrations/sqlite"
+)
+
+// Row-level isolation for SQLite.
+//
+// Every connection the registry opens gets, in its TEMP schema:
+//   - isolation_context: at most one row carrying the tenant, group and user
+//     of the running transaction — the SQLite stand-in for the
+//     app.current_* settings the PostgreSQL registry SETs LOCAL;
+//   - isolation_location_scope: the location scope of a restricted member,
+//     one row per location;
+//   - a view named after every RLS table that shadows main.<table> and
+//     filters it by the table's policy, so every read — joins and
+//     subqueries included — sees only the rows the USING clause admits;
+//   - BEFORE INSERT/UPDATE/DELETE triggers on main.<table> that skip rows
+//     the USING clause hides and abort writes the WITH CHECK clause and the
+//     location-scope write trigger would reject.
+//
+// With no context row everything is visible, which is what the background
+// worker and admin roles (and plain owner connections) get on PostgreSQL.
+// The context row is written inside the transaction and removed again
+// before it commits; a rollback removes it on its own.
+//
+// Views cannot be written to, so registry code writes to main.<table>.
+
+const (
+	contextTable = "temp.isolation_context"
+	scopeTable   = "temp.isolation_location_scope"
+)
+
+// rlsViolation is the message the isolation triggers abort with; it names
+// the PostgreSQL error so logs read the same on both backends.
+const rlsViolation = "new row violates row-level security policy"
+
+// scopeWriteViolation is the message for a write the location-scope write
+// trigger rejects.
+const scopeWriteViolation = "location scope denies write"
+
+// sqliteDriverName is the name sqlx derives the bind style from; "sqlite3"
+// selects ? placeholders for named queries.
+const sqliteDriverName = "sqlite3"
+
+// Open opens the registry's database handle on a sqlite:// DSN, with the
+// isolation objects installed on every connection.
+func Open(dsn string) (*sqlx.DB, error) {
+	driverDSN, err := sqliteschema.DriverDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := sqlitedriver.NewConnector(driverDSN)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create SQLite connector", err)
+	}
+	return sqlx.NewDb(sql.OpenDB(isolatingConnector{Connector: connector}), sqliteDriverName), nil
+}
+
+type isolatingConnector struct {
+	driver.Connector
+}
+
+func (c isolatingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	eq, ok := conn.(sqlitedriver.ExecQuerierContext)
+	if !ok {
+		conn.Close()
+		return nil, errors.New("SQLite connection does not support ExecContext/QueryContext")
+	}
+	if err := installIsolation(ctx, eq); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func installIsolation(ctx context.Context, conn sqlitedriver.ExecQuerierContext) error {
+	tables, err := mainTables(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	statements := []string{
+		`CREATE TABLE ` + contextTable + ` (
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    user_id TEXT NOT NULL
+)`,
+		`CREATE TABLE ` + scopeTable + ` (
+    location_id TEXT PRIMARY KEY NOT NULL,
+    role TEXT NOT NULL
+)`,
+	}
+	for _, table := range tables {
+		if p, ok := policies[TableName(table)]; ok {
+			statements = append(statements, isolationStatements(table, p)...)
+		}
+	}
+
+	for _, stmt := range statements {
+		if _, err := conn.ExecContext(ctx, stmt, nil); err != nil {
+			return errxtrace.Wrap("failed to install row isolation", err, errx.Attrs("statement", stmt))
+		}
+	}
+	return nil
+}
+
+func mainTables(ctx context.Context, conn sqlitedriver.ExecQuerierContext) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT name FROM main.sqlite_master WHERE type = 'table'`, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list tables", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	values := make([]driver.Value, 1)
+	for {
+		err := rows.Next(values)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list tables", err)
+		}
+		if name, ok := values[0].(string); ok {
+			tables = append(tables, name)
+		}
+	}
+	slices.Sort(tables)
+	return tables, nil
+}
+
+// isolationStatements renders the view and triggers that enforce p on table.
+func isolationStatements(table string, p policy) []string {
+	active := fmt.Sprintf("EXISTS (SELECT 1 FROM %s)", contextTable)
+	visible := func(row string) string {
+		return p.isolationPredicate(row) + " AND " + p.scopePredicate(row, models.GroupRoleViewer)
+	}
+	writable := func(row string) string {
+		return p.isolationPredicate(row) + " AND " + p.scopePredicate(row, p.writeRole)
+	}
+	hide := fmt.Sprintf("SELECT RAISE(IGNORE) WHERE %s AND NOT (%s);", active, visible("OLD"))
+	check := fmt.Sprintf("SELECT RAISE(ABORT, '%s for table \"%s\"') WHERE %s AND NOT (%s);", rlsViolation, table, active, writable("NEW"))
+	scopeWrite := ""
+	if p.scope != noScope {
+		scopeWrite = fmt.Sprintf("SELECT RAISE(ABORT, '%s on %s') WHERE %s AND NOT %s;", scopeWriteViolation, table, active, p.scopePredicate("OLD", p.writeRole))
+	}
+
+	return []string{
+		fmt.Sprintf("CREATE TEMP VIEW %[1]s AS SELECT * FROM main.%[1]s AS %[1]s WHERE NOT %[2]s OR (%[3]s)", table, active, visible(table)),
+		fmt.Sprintf("CREATE TEMP TRIGGER %[1]s_isolation_insert BEFORE INSERT ON main.%[1]s BEGIN %[2]s END", table, check),
+		fmt.Sprintf("CREATE TEMP TRIGGER %[1]s_isolation_update BEFORE UPDATE ON main.%[1]s BEGIN %[2]s %[3]s %[4]s END", table, hide, scopeWrite, check),
+		fmt.Sprintf("CREATE TEMP TRIGGER %[1]s_isolation_delete BEFORE DELETE ON main.%[1]s BEGIN %[2]s %[3]s END", table, hide, scopeWrite),
+	}
+}
+
+// isolationContext is what a transaction publishes to the isolation views.
+type isolationContext struct {
+	tenantID string
+	groupID  string
+	userID   string
+	scope    models.LocationScope
+}
+
+// setIsolationContext publishes c for the rest of tx.
+func setIsolationContext(ctx context.Context, tx *sqlx.Tx, c isolationContext) error {
+	_, err := tx.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (tenant_id, group_id, user_id) VALUES ($1, $2, $3)", contextTable),
+		c.tenantID, c.groupID, c.userID)
+	if err != nil {
+		return errxtrace.Wrap("failed to set isolation context", err)
+	}
+	for _, locationID := range c.scope.LocationIDs() {
+		_, err := tx.ExecContext(ctx,
+			fmt.Sprintf("INSERT INTO %s (location_id, role) VALUES ($1, $2)", scopeTable),
+			locationID, string(c.scope[locationID]))
+		if err != nil {
+			return errxtrace.Wrap("failed to set location scope context", err)
+		}
+	}
+	return nil
+}
+
+// clearIsolationContext removes the context rows, so they don't outlive tx
+// on the pooled connection.
+func clearIsolationContext(ctx context.Context, tx *sqlx.Tx) error {
+	for _, table := range []string{contextTable, scopeTable} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+			return errxtrace.Wrap("failed to clear isolation context", err)
+		}
+	}
+	return nil
+}
+
+// locationScopeContext returns the location scope of a group transaction.
+func locationScopeContext(ctx context.Context) models.LocationScope {
+	scope := appctx.LocationScopeFromContext(ctx)
+	if !scope.Restricted() {
+		return nil
+	}
+	return scope
+}
@@ -0,0 +1,158 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
+)
+
+// openIsolationDB returns a migrated database with foreign keys switched
+// off, so the tests can insert bare rows without their parents.
+func openIsolationDB(c *qt.C) *sqlx.DB {
+	dsn := "sqlite://" + filepath.Join(c.TempDir(), "isolation.db") + "?_pragma=foreign_keys(0)"
+
+	plain, err := sqlite.Open(dsn)
+	c.Assert(err, qt.IsNil)
+	_, err = sqlite.MigrateUp(context.Background(), plain, must.Must(sqlite.EmbeddedMigrationsFS()))
+	c.Assert(err, qt.IsNil)
+	c.Assert(plain.Close(), qt.IsNil)
+
+	dbx, err := store.Open(dsn)
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(func() { dbx.Close() })
+	return dbx
+}
+
+func locationRegistry(dbx *sqlx.DB, tenantID, groupID string) *store.RLSGroupRepository[models.Location, *models.Location] {
+	return store.NewGroupAwareSQLRegistry[models.Location](dbx, tenantID, groupID, "user-1", store.DefaultTableNames.Locations())
+}
+
+func createLocation(c *qt.C, dbx *sqlx.DB, tenantID, groupID, name string) models.Location {
+	loc, err := locationRegistry(dbx, tenantID, groupID).Create(context.Background(), models.Location{Name: name}, nil)
+	c.Assert(err, qt.IsNil)
+	return loc
+}
+
+func locationNames(c *qt.C, repo *store.RLSGroupRepository[models.Location, *models.Location], ctx context.Context) []string {
+	var names []string
+	for loc, err := range repo.Scan(ctx) {
+		c.Assert(err, qt.IsNil)
+		names = append(names, loc.Name)
+	}
+	return names
+}
+
+func TestIsolation_GroupContextFiltersReads(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	dbx := openIsolationDB(c)
+
+	createLocation(c, dbx, "tenant-1", "group-1", "mine")
+	createLocation(c, dbx, "tenant-1", "group-2", "other group")
+	createLocation(c, dbx, "tenant-2", "group-1", "other tenant")
+
+	c.Assert(locationNames(c, locationRegistry(dbx, "tenant-1", "group-1"), ctx), qt.DeepEquals, []string{"mine"})
+
+	count, err := locationRegistry(dbx, "tenant-1", "group-2").Count(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 1)
+
+	// The service registry runs without a context and sees every row.
+	count, err = store.NewGroupServiceSQLRegistry[models.Location](dbx, store.DefaultTableNames.Locations()).Count(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 3)
+}
+
+func TestIsolation_ContextDoesNotOutliveTransaction(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	dbx := openIsolationDB(c)
+	// One connection, so every transaction reuses the one the previous
+	// transaction published its context on.
+	dbx.SetMaxOpenConns(1)
+
+	createLocation(c, dbx, "tenant-1", "group-1", "first")
+	createLocation(c, dbx, "tenant-2", "group-2", "second")
+
+	var count int
+	err := dbx.GetContext(ctx, &count, `SELECT COUNT(*) FROM locations`)
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 2)
+}
+
+func TestIsolation_HiddenRowsAreNotWritable(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	dbx := openIsolationDB(c)
+
+	other := createLocation(c, dbx, "tenant-2", "group-1", "other tenant")
+	repo := locationRegistry(dbx, "tenant-1", "group-1")
+
+	// Updates and deletes skip rows the context cannot see, like a USING
+	// clause does.
+	err := repo.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE main.locations SET name = 'taken' WHERE id = $1`, other.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(must.Must(res.RowsAffected()), qt.Equals, int64(0))
+
+		res, err = tx.ExecContext(ctx, `DELETE FROM main.locations WHERE id = $1`, other.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(must.Must(res.RowsAffected()), qt.Equals, int64(0))
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+
+	// Inserting into another tenant fails like a WITH CHECK clause does.
+	err = repo.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO main.locations (id, uuid, tenant_id, group_id, created_by_user_id, name, address)
+			VALUES ('x', 'x', 'tenant-2', 'group-1', 'user-1', 'sneaky', '')`)
+		return err
+	})
+	c.Assert(err, qt.ErrorMatches, `(?s).*new row violates row-level security policy for table "locations".*`)
+
+	var loc models.Location
+	err = store.NewGroupServiceSQLRegistry[models.Location](dbx, store.DefaultTableNames.Locations()).
+		ScanOneByField(ctx, store.Pair("id", other.ID), &loc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(loc.Name, qt.Equals, "other tenant")
+}
+
+func TestIsolation_LocationScope(t *testing.T) {
+	c := qt.New(t)
+	dbx := openIsolationDB(c)
+
+	viewable := createLocation(c, dbx, "tenant-1", "group-1", "viewable")
+	managed := createLocation(c, dbx, "tenant-1", "group-1", "managed")
+	createLocation(c, dbx, "tenant-1", "group-1", "out of scope")
+
+	ctx := appctx.WithLocationScope(context.Background(), models.LocationScope{
+		viewable.ID: models.GroupRoleViewer,
+		managed.ID:  models.GroupRoleAdmin,
+	})
+	repo := locationRegistry(dbx, "tenant-1", "group-1")
+
+	c.Assert(locationNames(c, repo, ctx), qt.ContentEquals, []string{"viewable", "managed"})
+
+	managed.Name = "renamed"
+	c.Assert(repo.Update(ctx, managed, nil), qt.IsNil)
+
+	viewable.Name = "renamed"
+	err := repo.Update(ctx, viewable, nil)
+	c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+
+	err = repo.Delete(ctx, viewable.ID, nil)
+	c.Assert(err, qt.ErrorIs, registry.ErrLocationScopeDenied)
+
+	// Without a scope in the context the member is unrestricted again.
+	c.Assert(locationNames(c, repo, context.Background()), qt.HasLen, 3)
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+// SQLite has no row-level security, so the PostgreSQL isolation policies are
+// emulated per connection (see isolation.go). The policies themselves are
+// listed here, one entry per RLS-enabled table; TestPoliciesMatchAnnotations
+// keeps the list in step with the //migrator:schema:rls:policy annotations
+// the PostgreSQL schema is generated from.
+
+// policyKind is the shape of a table's tenant isolation predicate.
+type policyKind int
+
+const (
+	// tenantGroupPolicy: tenant_id and group_id match the group context.
+	tenantGroupPolicy policyKind = iota
+	// tenantPolicy: tenant_id matches the tenant context.
+	tenantPolicy
+	// tenantUserPolicy: tenant_id and user_id match the user context.
+	tenantUserPolicy
+	// tenantSelfPolicy: tenant_id matches and id is the current user (users).
+	tenantSelfPolicy
+)
+
+// scopeKind says how a row resolves to the location a location-scoped
+// member's access is checked against.
+type scopeKind int
+
+const (
+	noScope scopeKind = iota
+	// locationScope: the row is a location (locations.id) or names one
+	// directly (areas.location_id).
+	locationScope
+	// areaScope: the row names an area (commodities.area_id).
+	areaScope
+	// commodityScope: the row names a commodity (commodity_loans.commodity_id).
+	commodityScope
+	// fileScope: the row is a file linked to a location, area or commodity.
+	fileScope
+)
+
+type policy struct {
+	kind policyKind
+	// scope, scopeColumn and writeRole mirror the *_location_scope_allows
+	// term of the policy: reads need viewer, writes need writeRole.
+	scope       scopeKind
+	scopeColumn string
+	writeRole   models.GroupRole
+}
+
+var policies = map[TableName]policy{
+	"areas":                         {kind: tenantGroupPolicy, scope: locationScope, scopeColumn: "location_id", writeRole: models.GroupRoleAdmin},
+	"backup_schedules":              {kind: tenantGroupPolicy},
+	"calendar_feeds":                {kind: tenantUserPolicy},
+	"commodities":                   {kind: tenantGroupPolicy, scope: areaScope, scopeColumn: "area_id", writeRole: models.GroupRoleUser},
+	"commodity_events":              {kind: tenantGroupPolicy},
+	"commodity_loans":               {kind: tenantGroupPolicy, scope: commodityScope, scopeColumn: "commodity_id", writeRole: models.GroupRoleUser},
+	"commodity_meter_readings":      {kind: tenantGroupPolicy},
+	"commodity_scan_audits":         {kind: tenantUserPolicy},
+	"commodity_services":            {kind: tenantGroupPolicy},
+	"commodity_supply_links":        {kind: tenantGroupPolicy},
+	"currency_migration_audit_rows": {kind: tenantGroupPolicy},
+	"currency_migrations":           {kind: tenantGroupPolicy},
+	"exports":                       {kind: tenantGroupPolicy},
+	"files":                         {kind: tenantGroupPolicy, scope: fileScope, writeRole: models.GroupRoleUser},
+	"group_invites":                 {kind: tenantPolicy},
+	"group_invites_audit":           {kind: tenantPolicy},
+	"group_member_location_scopes":  {kind: tenantPolicy},
+	"group_memberships":             {kind: tenantPolicy},
+	"group_notification_prefs":      {kind: tenantPolicy},
+	"invoice_extractions":           {kind: tenantGroupPolicy},
+	"location_groups":               {kind: tenantPolicy},
+	"locations":                     {kind: tenantGroupPolicy, scope: locationScope, scopeColumn: "id", writeRole: models.GroupRoleAdmin},
+	"login_events":                  {kind: tenantPolicy},
+	"maintenance_logs":              {kind: tenantGroupPolicy},
+	"maintenance_reminders":         {kind: tenantGroupPolicy},
+	"maintenance_schedules":         {kind: tenantGroupPolicy},
+	"refresh_tokens":                {kind: tenantUserPolicy},
+	"restore_operations":            {kind: tenantGroupPolicy},
+	"restore_steps":                 {kind: tenantGroupPolicy},
+	"saved_views":                   {kind: tenantGroupPolicy},
+	"settings":                      {kind: tenantUserPolicy},
+	"storage_quota_reminders":       {kind: tenantGroupPolicy},
+	"tags":                          {kind: tenantGroupPolicy},
+	"thumbnail_generation_jobs":     {kind: tenantUserPolicy},
+	"user_concurrency_slots":        {kind: tenantUserPolicy},
+	"user_mfa_secrets":              {kind: tenantUserPolicy},
+	"user_oauth_identities":         {kind: tenantUserPolicy},
+	"users":                         {kind: tenantSelfPolicy},
+	"warranty_reminders":            {kind: tenantGroupPolicy},
+}
+
+// rolesAtLeast lists the roles that satisfy minRole, for an IN (...) test.
+var rolesAtLeast = map[models.GroupRole]string{
+	models.GroupRoleViewer: "'viewer', 'user', 'admin', 'owner'",
+	models.GroupRoleUser:   "'user', 'admin', 'owner'",
+	models.GroupRoleAdmin:  "'admin', 'owner'",
+}
+
+// isolationPredicate renders the policy's tenant predicate for the row
+// named row ("OLD", "NEW" or the table itself). It is only consulted while
+// an isolation context is set; see isolation.go.
+func (p policy) isolationPredicate(row string) string {
+	var match string
+	switch p.kind {
+	case tenantGroupPolicy:
+		match = fmt.Sprintf("c.group_id <> '' AND c.group_id = %[1]s.group_id", row)
+	case tenantUserPolicy:
+		match = fmt.Sprintf("c.user_id <> '' AND c.user_id = %[1]s.user_id", row)
+	case tenantSelfPolicy:
+		match = fmt.Sprintf("c.user_id <> '' AND c.user_id = %[1]s.id", row)
+	case tenantPolicy:
+		match = "1"
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM %s c WHERE c.tenant_id <> '' AND c.tenant_id = %s.tenant_id AND %s)",
+		contextTable, row, match)
+}
+
+// scopePredicate renders the location-scope term for the row named row at
+// minRole; it is true for every row while the member is unrestricted.
+// Lookups go through the isolation views, as the PostgreSQL helpers run
+// under the caller's role and see only what RLS lets them see.
+func (p policy) scopePredicate(row string, minRole models.GroupRole) string {
+	if p.scope == noScope {
+		return "1"
+	}
+
+	roles := rolesAtLeast[minRole]
+	allows := func(locationExpr string) string {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM %s s WHERE s.location_id = (%s) AND s.role IN (%s))",
+			scopeTable, locationExpr, roles)
+	}
+	areaLocation := func(areaExpr string) string {
+		return fmt.Sprintf("SELECT location_id FROM areas WHERE id = (%s)", areaExpr)
+	}
+	commodityLocation := func(commodityExpr string) string {
+		return areaLocation(fmt.Sprintf("SELECT area_id FROM commodities WHERE id = (%s)", commodityExpr))
+	}
+
+	var term string
+	switch p.scope {
+	case locationScope:
+		term = allows(row + "." + p.scopeColumn)
+	case areaScope:
+		term = allows(areaLocation(row + "." + p.scopeColumn))
+	case commodityScope:
+		term = allows(commodityLocation(row + "." + p.scopeColumn))
+	case fileScope:
+		id := row + ".linked_entity_id"
+		term = fmt.Sprintf("CASE %s.linked_entity_type WHEN 'location' THEN %s WHEN 'area' THEN %s WHEN 'commodity' THEN %s ELSE 0 END",
+			row, allows(id), allows(areaLocation(id)), allows(commodityLocation(id)))
+	}
+	return fmt.Sprintf("(NOT EXISTS (SELECT 1 FROM %s) OR %s)", scopeTable, strings.TrimSpace(term))
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stokaro/ptah/core/goschema"
+
+	"github.com/denisvmedia/inventario/models"
+)
+
+var scopeCall = regexp.MustCompile(`(\w*)location_scope_allows\(([\w, ]+), '(\w+)'\)`)
+
+// policyFromExpressions derives the emulated policy from the USING and
+// WITH CHECK expressions of an inventario_app policy.
+func policyFromExpressions(c *qt.C, table, using, withCheck string) policy {
+	var p policy
+	switch {
+	case strings.Contains(using, "group_id = get_current_group_id()"):
+		p.kind = tenantGroupPolicy
+	case strings.Contains(using, "user_id = get_current_user_id()"):
+		p.kind = tenantUserPolicy
+	case strings.Contains(using, "id = get_current_user_id()"):
+		p.kind = tenantSelfPolicy
+	default:
+		p.kind = tenantPolicy
+	}
+	c.Assert(using, qt.Contains, "tenant_id = get_current_tenant_id()", qt.Commentf("table %s", table))
+
+	read := scopeCall.FindStringSubmatch(using)
+	write := scopeCall.FindStringSubmatch(withCheck)
+	if read == nil {
+		c.Assert(write, qt.IsNil, qt.Commentf("table %s", table))
+		return p
+	}
+	c.Assert(write, qt.IsNotNil, qt.Commentf("table %s", table))
+	c.Assert(read[3], qt.Equals, string(models.GroupRoleViewer), qt.Commentf("table %s", table))
+	p.writeRole = models.GroupRole(write[3])
+
+	switch read[1] {
+	case "":
+		p.scope, p.scopeColumn = locationScope, read[2]
+	case "area_":
+		p.scope, p.scopeColumn = areaScope, read[2]
+	case "commodity_":
+		p.scope, p.scopeColumn = commodityScope, read[2]
+	case "file_":
+		p.scope = fileScope
+	default:
+		c.Fatalf("table %s: unknown scope function %slocation_scope_allows", table, read[1])
+	}
+	return p
+}
+
+func TestPoliciesMatchAnnotations(t *testing.T) {
+	c := qt.New(t)
+
+	schema, err := goschema.ParseDir("../../../models")
+	c.Assert(err, qt.IsNil)
+
+	want := make(map[TableName]policy)
+	for _, rls := range schema.RLSPolicies {
+		if rls.ToRoles != "inventario_app" {
+			continue
+		}
+		c.Assert(rls.PolicyFor, qt.Equals, "ALL", qt.Commentf("policy %s", rls.Name))
+		want[TableName(rls.Table)] = policyFromExpressions(c, rls.Table, rls.UsingExpression, rls.WithCheckExpression)
+	}
+	c.Assert(policies, qt.CmpEquals(cmp.AllowUnexported(policy{})), want)
+
+	for _, enabled := range schema.RLSEnabledTables {
+		_, ok := policies[TableName(enabled.Table)]
+		c.Assert(ok, qt.IsTrue, qt.Commentf("RLS-enabled table %s has no policy", enabled.Table))
+	}
+}
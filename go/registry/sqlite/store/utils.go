@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// readOnlyTx begins a deferred transaction: read paths must not take the
+// write lock every other transaction takes up front (see the _txlock
+// setting in the schema package), or an open Scan iterator would block
+// the writes its caller makes while iterating.
+var readOnlyTx = &sql.TxOptions{ReadOnly: true}
+
+func RollbackOrCommit(tx *sqlx.Tx, err error) error {
+	if err != nil {
+		return tx.Rollback()
+	}
+	// The isolation context must not outlive the transaction on the
+	// pooled connection; a rollback discards it on its own.
+	if err := clearIsolationContext(context.Background(), tx); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+func beginTx(ctx context.Context, dbx *sqlx.DB, opts *sql.TxOptions, c *isolationContext) (*sqlx.Tx, error) {
+	tx, err := dbx.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to begin transaction", err)
+	}
+	if c == nil {
+		return tx, nil
+	}
+
+	err = setIsolationContext(ctx, tx, *c)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return tx, nil
+}
+
+func beginTxWithTenantAndUser(ctx context.Context, dbx *sqlx.DB, opts *sql.TxOptions, userID, tenantID string) (*sqlx.Tx, error) {
+	if userID == "" {
+		return nil, ErrUserIDRequired
+	}
+
+	return beginTx(ctx, dbx, opts, &isolationContext{
+		tenantID: tenantID,
+		userID:   userID,
+	})
+}
+
+// beginServiceTx begins a transaction without an isolation context, the
+// SQLite counterpart of the inventario_background_worker role.
+func beginServiceTx(ctx context.Context, dbx *sqlx.DB, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return beginTx(ctx, dbx, opts, nil)
+}
+
+// beginAdminTx begins a transaction without an isolation context, the
+// SQLite counterpart of the BYPASSRLS inventario_admin role used by the
+// cross-tenant admin surfaces (#1787).
+func beginAdminTx(ctx context.Context, dbx *sqlx.DB) (*sqlx.Tx, error) {
+	return beginTx(ctx, dbx, nil, nil)
+}
+
+func beginTxWithTenantAndGroup(ctx context.Context, dbx *sqlx.DB, opts *sql.TxOptions, tenantID, groupID string) (*sqlx.Tx, error) {
+	if tenantID == "" {
+		return nil, ErrTenantIDRequired
+	}
+	if groupID == "" {
+		return nil, ErrGroupIDRequired
+	}
+
+	return beginTx(ctx, dbx, opts, &isolationContext{
+		tenantID: tenantID,
+		groupID:  groupID,
+		scope:    locationScopeContext(ctx),
+	})
+}
+
+// generateID generates a new UUID string
+func generateID() string {
+	return uuid.New().String()
+}
+
+// DoAsBackgroundWorker runs fn inside a transaction without an isolation
+// context, committing on nil return and rolling back otherwise. It is the
+// SQLite counterpart of the PostgreSQL helper of the same name, for
+// cross-table maintenance that legitimately spans tenants (group purge,
+// housekeeping sweeps, etc.). New callers should be rare; prefer
+// per-registry methods where possible.
+func DoAsBackgroundWorker(ctx context.Context, dbx *sqlx.DB, fn func(context.Context, *sqlx.Tx) error) (err error) {
+	tx, err := beginServiceTx(ctx, dbx, nil)
+	if err != nil {
+		return errxtrace.Wrap("failed to begin background-worker transaction", err)
+	}
+	defer func() {
+		if rbErr := RollbackOrCommit(tx, err); rbErr != nil && err == nil {
+			err = errxtrace.Wrap("failed to finish background-worker transaction", rbErr)
+		}
+	}()
+
+	if err = fn(ctx, tx); err != nil {
+		return errxtrace.Wrap("background-worker operation failed", err)
+	}
+	return nil
+}
+
+// DoAsAdmin runs fn inside a transaction without an isolation context,
+// committing on nil return and rolling back otherwise. It is the entry
+// point for the cross-tenant system-admin surfaces (#1787).
+//
+// New callers should be rare and gated behind RequireSystemAdmin.
+func DoAsAdmin(ctx context.Context, dbx *sqlx.DB, fn func(context.Context, *sqlx.Tx) error) (err error) {
+	tx, err := beginAdminTx(ctx, dbx)
+	if err != nil {
+		return errxtrace.Wrap("failed to begin admin transaction", err)
+	}
+	defer func() {
+		if rbErr := RollbackOrCommit(tx, err); rbErr != nil && err == nil {
+			err = errxtrace.Wrap("failed to finish admin transaction", rbErr)
+		}
+	}()
+
+	if err = fn(ctx, tx); err != nil {
+		return errxtrace.Wrap("admin operation failed", err)
+	}
+	return nil
+}
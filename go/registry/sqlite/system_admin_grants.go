@@ -0,0 +1,245 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+var _ registry.SystemAdminGrantRegistry = (*SystemAdminGrantRegistry)(nil)
+
+// SystemAdminGrantRegistry is the SQLite-backed system-admin grant
+// store (#1784). The table is NOT RLS-enabled — system-admin is a
+// platform privilege orthogonal to tenants. Mutating operations
+// (Grant / RevokeAtomic / List) route through NonRLSRepository so the
+// transaction pattern stays consistent with the rest of the admin write
+// path; Exists is a single read on the unique (user_id) index and runs
+// against `r.dbx` directly — no transaction needed, and the hot
+// middleware path stays a single round-trip.
+//
+// Mutating operations run in write transactions, which SQLite executes
+// one at a time, so concurrent grants and revokes cannot interleave.
+type SystemAdminGrantRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// NewSystemAdminGrantRegistry creates a new SystemAdminGrantRegistry.
+func NewSystemAdminGrantRegistry(dbx *sqlx.DB) *SystemAdminGrantRegistry {
+	return NewSystemAdminGrantRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+// NewSystemAdminGrantRegistryWithTableNames is the test-friendly
+// constructor that lets a caller override the table-name mapping.
+func NewSystemAdminGrantRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *SystemAdminGrantRegistry {
+	return &SystemAdminGrantRegistry{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+func (r *SystemAdminGrantRegistry) newSQLRegistry() *store.NonRLSRepository[models.SystemAdminGrant, *models.SystemAdminGrant] {
+	return store.NewSQLRegistry[models.SystemAdminGrant](r.dbx, r.tableNames.SystemAdminGrants())
+}
+
+// Exists returns true when the user has a grant row. Hot path —
+// RequireSystemAdmin runs this on every /api/v1/admin/* request.
+// Backed by the unique index on user_id so the lookup is constant-time
+// regardless of the total grant count.
+func (r *SystemAdminGrantRegistry) Exists(ctx context.Context, userID string) (bool, error) {
+	if userID == "" {
+		return false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+
+	var ok bool
+	query := fmt.Sprintf(
+		`SELECT EXISTS(SELECT 1 FROM %s WHERE user_id = $1)`,
+		r.tableNames.SystemAdminGrants(),
+	)
+	if err := r.dbx.QueryRowContext(ctx, query, userID).Scan(&ok); err != nil {
+		return false, errxtrace.Wrap("failed to check system_admin_grants existence", err)
+	}
+	return ok, nil
+}
+
+// Grant inserts a grant row. Idempotent: when the user already has a
+// grant returns (true, nil); when the insert mints a fresh row returns
+// (false, nil).
+//
+// Concurrency is enforced in two layers:
+//
+//  1. The write transaction serialises the call sites, and the
+//     probe-SELECT inside it is how we set hadGrant=true for the
+//     idempotent return contract.
+//  2. `ON CONFLICT (user_id) DO NOTHING` on the INSERT is the
+//     defense-in-depth backstop for the out-of-band INSERT path — a
+//     manual SQL INSERT during ops. The unique index on user_id would
+//     otherwise make any such race fail the whole transaction; this
+//     clause turns it into a quiet no-op consistent with the
+//     idempotent contract above.
+func (r *SystemAdminGrantRegistry) Grant(ctx context.Context, userID string, grantedBy *string) (hadGrant bool, err error) {
+	if userID == "" {
+		return false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+
+	reg := r.newSQLRegistry()
+	err = reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		// Check existing grant inside the write transaction so the
+		// idempotent branch returns a stable hadGrant=true rather than
+		// racing with another grant call.
+		var existingID string
+		probe := fmt.Sprintf(
+			`SELECT id FROM %s WHERE user_id = $1`,
+			r.tableNames.SystemAdminGrants(),
+		)
+		switch scanErr := tx.QueryRowContext(ctx, probe, userID).Scan(&existingID); {
+		case scanErr == nil:
+			hadGrant = true
+			return nil
+		case errors.Is(scanErr, sql.ErrNoRows):
+			// Fall through to the insert.
+		default:
+			return errxtrace.Wrap("failed to probe existing system_admin_grants row", scanErr)
+		}
+
+		// ON CONFLICT DO NOTHING is the belt-and-braces backstop against
+		// an out-of-band INSERT; the probe-SELECT has already established hadGrant=false for the
+		// in-tree call sites, so a conflict here can only come from a
+		// concurrent out-of-band INSERT — we treat it the same as the
+		// probe finding an existing row (no error, idempotent no-op).
+		insertQuery := fmt.Sprintf(
+			`INSERT INTO main.%s (id, uuid, user_id, granted_by, granted_at)
+			 VALUES ($1, $2, $3, $4, now())
+			 ON CONFLICT (user_id) DO NOTHING`,
+			r.tableNames.SystemAdminGrants(),
+		)
+		res, execErr := tx.ExecContext(ctx, insertQuery,
+			uuid.New().String(), uuid.New().String(), userID, grantedBy,
+		)
+		if execErr != nil {
+			return errxtrace.Wrap("failed to insert system_admin_grants row", execErr)
+		}
+		// When the INSERT no-ops on ON CONFLICT, RowsAffected returns 0.
+		// That can only happen via the out-of-band path (the probe already
+		// would have set hadGrant=true otherwise), so flip hadGrant=true
+		// to keep the return contract consistent: at commit time the row
+		// exists either way, and a caller branching on hadGrant must not
+		// see it as "fresh grant" when a concurrent INSERT actually wrote
+		// the row. If RowsAffected ever returned an error we'd still fall
+		// back to false.
+		if affected, raErr := res.RowsAffected(); raErr == nil && affected == 0 {
+			hadGrant = true
+		}
+		return nil
+	})
+	if err != nil {
+		return hadGrant, err
+	}
+	return hadGrant, nil
+}
+
+// RevokeAtomic deletes the grant row for the target user inside a write
+// transaction. allowZero=false makes the count check (in the same
+// transaction) refuse to drop to zero grants — two concurrent revokes
+// serialise; the loser sees count==1 and gets ErrLastSystemAdmin.
+//
+//revive:disable-next-line:flag-parameter
+func (r *SystemAdminGrantRegistry) RevokeAtomic(ctx context.Context, userID string, allowZero bool) (hadGrant bool, err error) {
+	if userID == "" {
+		return false, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "UserID"))
+	}
+
+	reg := r.newSQLRegistry()
+	err = reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		var grantID string
+		probe := fmt.Sprintf(
+			`SELECT id FROM %s WHERE user_id = $1`,
+			r.tableNames.SystemAdminGrants(),
+		)
+		switch scanErr := tx.QueryRowContext(ctx, probe, userID).Scan(&grantID); {
+		case scanErr == nil:
+			hadGrant = true
+		case errors.Is(scanErr, sql.ErrNoRows):
+			// Idempotent: no grant, nothing to do.
+			return nil
+		default:
+			return errxtrace.Wrap("failed to probe system_admin_grants for revoke", scanErr)
+		}
+
+		if !allowZero {
+			var count int
+			countQuery := fmt.Sprintf(
+				`SELECT COUNT(*) FROM %s`,
+				r.tableNames.SystemAdminGrants(),
+			)
+			if countErr := tx.QueryRowContext(ctx, countQuery).Scan(&count); countErr != nil {
+				return errxtrace.Wrap("failed to count system_admin_grants", countErr)
+			}
+			if count <= 1 {
+				return errxtrace.Classify(registry.ErrLastSystemAdmin, errx.Attrs("user_id", userID))
+			}
+		}
+
+		deleteQuery := fmt.Sprintf(
+			`DELETE FROM main.%s WHERE id = $1`,
+			r.tableNames.SystemAdminGrants(),
+		)
+		if _, execErr := tx.ExecContext(ctx, deleteQuery, grantID); execErr != nil {
+			return errxtrace.Wrap("failed to delete system_admin_grants row", execErr)
+		}
+		return nil
+	})
+	if err != nil {
+		return hadGrant, err
+	}
+	return hadGrant, nil
+}
+
+// List returns every grant row, ordered by (granted_at ASC, user_id ASC).
+// Backs the CLI list-system-admins command; the CLI joins each row to
+// its user row separately so the registry stays focused on a single
+// table. The user_id secondary key keeps the rendered list deterministic
+// when two grants share a granted_at — fast-fired CLI grants can tie on
+// `now()` resolution otherwise. The memory backend applies the same
+// composite ordering so both backends agree.
+func (r *SystemAdminGrantRegistry) List(ctx context.Context) ([]*models.SystemAdminGrant, error) {
+	reg := r.newSQLRegistry()
+
+	var grants []*models.SystemAdminGrant
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT * FROM %s ORDER BY granted_at ASC, user_id ASC`,
+			r.tableNames.SystemAdminGrants(),
+		)
+		rows, err := tx.QueryxContext(ctx, query)
+		if err != nil {
+			return errxtrace.Wrap("failed to list system_admin_grants", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var g models.SystemAdminGrant
+			if err := rows.StructScan(&g); err != nil {
+				return errxtrace.Wrap("failed to scan system_admin_grants row", err)
+			}
+			grants = append(grants, &g)
+		}
+		if err := rows.Err(); err != nil {
+			return errxtrace.Wrap("failed during system_admin_grants iteration", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
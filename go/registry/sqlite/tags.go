@@ -0,0 +1,741 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+// TagRegistryFactory creates TagRegistry instances with proper context.
+type TagRegistryFactory struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// TagRegistry is the SQLite-backed group-scoped registry of tags.
+type TagRegistry struct {
+	dbx             *sqlx.DB
+	tableNames      store.TableNames
+	tenantID        string
+	groupID         string
+	createdByUserID string
+	service         bool
+}
+
+var (
+	_ registry.TagRegistry        = (*TagRegistry)(nil)
+	_ registry.TagRegistryFactory = (*TagRegistryFactory)(nil)
+)
+
+func NewTagRegistry(dbx *sqlx.DB) *TagRegistryFactory {
+	return NewTagRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewTagRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *TagRegistryFactory {
+	return &TagRegistryFactory{dbx: dbx, tableNames: tableNames}
+}
+
+func (f *TagRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.TagRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *TagRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.TagRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+	return &TagRegistry{
+		dbx:             f.dbx,
+		tableNames:      f.tableNames,
+		tenantID:        user.TenantID,
+		groupID:         appctx.GroupIDFromContext(ctx),
+		createdByUserID: user.ID,
+		service:         false,
+	}, nil
+}
+
+func (f *TagRegistryFactory) CreateServiceRegistry() registry.TagRegistry {
+	return &TagRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		service:    true,
+	}
+}
+
+func (r *TagRegistry) newSQLRegistry() *store.RLSGroupRepository[models.Tag, *models.Tag] {
+	if r.service {
+		return store.NewGroupServiceSQLRegistry[models.Tag](r.dbx, r.tableNames.Tags())
+	}
+	return store.NewGroupAwareSQLRegistry[models.Tag](r.dbx, r.tenantID, r.groupID, r.createdByUserID, r.tableNames.Tags())
+}
+
+func (r *TagRegistry) Get(ctx context.Context, id string) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.newSQLRegistry().ScanOneByField(ctx, store.Pair("id", id), &tag)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get tag", err)
+	}
+	return &tag, nil
+}
+
+func (r *TagRegistry) GetBySlug(ctx context.Context, kind models.TagKind, slug string) (*models.Tag, error) {
+	var tag models.Tag
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		// RLS scopes the query to the current tenant+group; (kind, slug)
+		// disambiguates the per-(group, kind) unique tuple.
+		err := tx.QueryRowxContext(ctx,
+			fmt.Sprintf(`SELECT * FROM %s WHERE kind = $1 AND slug = $2 LIMIT 1`, r.tableNames.Tags()),
+			kind, slug).StructScan(&tag)
+		if errors.Is(err, sql.ErrNoRows) {
+			return registry.ErrNotFound
+		}
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return nil, err
+		}
+		return nil, errxtrace.Wrap("failed to get tag by slug", err)
+	}
+	return &tag, nil
+}
+
+// tableForKind returns the JSON-bearing table whose `tags` column holds
+// references for the given tag kind: commodities for commodity tags, files
+// for file tags. Unknown/empty kind falls back to commodities (defence in
+// depth — the write paths always pass a concrete kind).
+func (r *TagRegistry) tableForKind(kind models.TagKind) store.TableName {
+	if kind == models.TagKindFile {
+		return r.tableNames.Files()
+	}
+	return r.tableNames.Commodities()
+}
+
+func (r *TagRegistry) List(ctx context.Context) ([]*models.Tag, error) {
+	var tags []*models.Tag
+	for tag, err := range r.newSQLRegistry().Scan(ctx) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list tags", err)
+		}
+		t := tag
+		tags = append(tags, &t)
+	}
+	return tags, nil
+}
+
+func (r *TagRegistry) Count(ctx context.Context) (int, error) {
+	cnt, err := r.newSQLRegistry().Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count tags", err)
+	}
+	return cnt, nil
+}
+
+func (r *TagRegistry) Create(ctx context.Context, tag models.Tag) (*models.Tag, error) {
+	created, err := r.newSQLRegistry().Create(ctx, tag, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create tag", err)
+	}
+	return &created, nil
+}
+
+func (r *TagRegistry) Update(ctx context.Context, tag models.Tag) (*models.Tag, error) {
+	if err := r.newSQLRegistry().Update(ctx, tag, nil); err != nil {
+		return nil, errxtrace.Wrap("failed to update tag", err)
+	}
+	return &tag, nil
+}
+
+func (r *TagRegistry) Delete(ctx context.Context, id string) error {
+	return r.newSQLRegistry().Delete(ctx, id, nil)
+}
+
+// kindUsageExpr returns the per-tag reference-count SQL expression for the
+// given kind: commodity tags count commodity rows, file tags count file
+// rows. The expression is evaluated against the outer `t` alias (the tags
+// row), so callers must reference the tags table as `t`.
+//
+// Empty/unknown kind falls back to the combined expression — only reached
+// when listing across all kinds (the public handlers always pass a concrete
+// kind).
+func (r *TagRegistry) kindUsageExpr(kind models.TagKind) string {
+	commodityExpr := fmt.Sprintf(
+		`(SELECT COUNT(*) FROM %s WHERE %s)`,
+		r.tableNames.Commodities(), jsonArrayContains("tags", "t.slug"))
+	fileExpr := fmt.Sprintf(
+		`(SELECT COUNT(*) FROM %s WHERE %s)`,
+		r.tableNames.Files(), jsonArrayContains("tags", "t.slug"))
+	switch kind {
+	case models.TagKindCommodity:
+		return commodityExpr
+	case models.TagKindFile:
+		return fileExpr
+	default:
+		return "(" + commodityExpr + " + " + fileExpr + ")"
+	}
+}
+
+func (r *TagRegistry) ListPaginated(ctx context.Context, offset, limit int, opts registry.TagListOptions) ([]*models.Tag, int, error) {
+	var tags []*models.Tag
+	var total int
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		var conditions []string
+		var args []any
+		argIdx := 1
+		if opts.Search != "" {
+			conditions = append(conditions, fmt.Sprintf("(t.label LIKE $%d OR t.slug LIKE $%d)", argIdx, argIdx+1))
+			pattern := "%" + opts.Search + "%"
+			args = append(args, pattern, pattern)
+			argIdx += 2
+		}
+		// Kind filter: intrinsic. A tag passes ?kind=commodity iff its
+		// stored kind is "commodity"; same for file. Zero kind adds no
+		// condition (list across all kinds — internal callers only).
+		if opts.Kind == models.TagKindCommodity || opts.Kind == models.TagKindFile {
+			conditions = append(conditions, fmt.Sprintf("t.kind = $%d", argIdx))
+			args = append(args, opts.Kind)
+			argIdx++
+		}
+		whereClause := ""
+		if len(conditions) > 0 {
+			whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		}
+
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s t %s", r.tableNames.Tags(), whereClause)
+		if err := tx.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			return errxtrace.Wrap("failed to count tags", err)
+		}
+
+		sortField := opts.SortField
+		if !sortField.IsValid() {
+			sortField = registry.TagSortLabel
+		}
+		dir := "ASC"
+		if opts.SortDesc {
+			dir = "DESC"
+		}
+		var orderBy string
+		switch sortField {
+		case registry.TagSortCreatedAt:
+			orderBy = fmt.Sprintf("ORDER BY t.created_at %s, t.label ASC", dir)
+		case registry.TagSortUsage:
+			// Usage sort counts only the kind's own table (commodity tags →
+			// commodities, file tags → files), so "Sort by usage" reflects
+			// the tag's relevant usage.
+			orderBy = fmt.Sprintf("ORDER BY %s %s, t.label ASC", r.kindUsageExpr(opts.Kind), dir)
+		default:
+			orderBy = fmt.Sprintf("ORDER BY LOWER(t.label) %s, t.id ASC", dir)
+		}
+
+		dataArgs := append([]any{}, args...)
+		dataArgs = append(dataArgs, limit, offset)
+		dataQuery := fmt.Sprintf(
+			`SELECT t.* FROM %s t %s %s LIMIT $%d OFFSET $%d`,
+			r.tableNames.Tags(), whereClause, orderBy, argIdx, argIdx+1,
+		)
+		rows, err := tx.QueryxContext(ctx, dataQuery, dataArgs...)
+		if err != nil {
+			return errxtrace.Wrap("failed to list tags", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var tag models.Tag
+			if err := rows.StructScan(&tag); err != nil {
+				return errxtrace.Wrap("failed to scan tag", err)
+			}
+			t := tag
+			tags = append(tags, &t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, 0, errxtrace.Wrap("failed to list paginated tags", err)
+	}
+	return tags, total, nil
+}
+
+func (r *TagRegistry) Search(ctx context.Context, q string, limit int, kind models.TagKind) ([]*models.Tag, error) {
+	var tags []*models.Tag
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		var conditions []string
+		var args []any
+		argIdx := 1
+		if q != "" {
+			conditions = append(conditions, fmt.Sprintf("(t.label LIKE $%d OR t.slug LIKE $%d)", argIdx, argIdx+1))
+			pattern := "%" + q + "%"
+			args = append(args, pattern, pattern)
+			argIdx += 2
+		}
+		// Kind filter (intrinsic): the autocomplete dropdown on a commodity
+		// input only ever offers commodity tags, and a file input only file
+		// tags — they are separate entities.
+		if kind == models.TagKindCommodity || kind == models.TagKindFile {
+			conditions = append(conditions, fmt.Sprintf("t.kind = $%d", argIdx))
+			args = append(args, kind)
+			argIdx++
+		}
+		whereClause := ""
+		if len(conditions) > 0 {
+			whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		}
+
+		// Rank by the kind's own usage desc, then created_at desc
+		// (recency tiebreaker).
+		kindUsageExpr := r.kindUsageExpr(kind)
+		args = append(args, limit)
+		query := fmt.Sprintf(
+			`SELECT t.* FROM %s t %s ORDER BY %s DESC, t.created_at DESC LIMIT $%d`,
+			r.tableNames.Tags(), whereClause, kindUsageExpr, argIdx,
+		)
+
+		rows, err := tx.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return errxtrace.Wrap("failed to search tags", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var tag models.Tag
+			if err := rows.StructScan(&tag); err != nil {
+				return errxtrace.Wrap("failed to scan tag", err)
+			}
+			t := tag
+			tags = append(tags, &t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to search tags", err)
+	}
+	return tags, nil
+}
+
+func (r *TagRegistry) GetUsageBatch(ctx context.Context, kind models.TagKind, slugs []string) (map[string]registry.TagUsage, error) {
+	out := make(map[string]registry.TagUsage, len(slugs))
+	for _, s := range slugs {
+		out[s] = registry.TagUsage{}
+	}
+	if len(slugs) == 0 {
+		return out, nil
+	}
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		// Single-pass query over the kind's own table only (commodity tags
+		// count commodity rows, file tags count file rows): expand the
+		// JSON arrays once per row, COUNT(DISTINCT id) per slug to match
+		// the containment semantics of GetUsage (a row with duplicate slugs
+		// still counts as 1), then LEFT JOIN back to the requested input
+		// list so missing slugs land as 0 instead of being dropped.
+		query := fmt.Sprintf(`
+			WITH input(slug) AS (SELECT value FROM json_each($1)),
+			counts AS (
+				SELECT t.value AS slug, COUNT(DISTINCT id) AS cnt
+				FROM %s, %s AS t
+				WHERE t.value IN (SELECT slug FROM input)
+				GROUP BY t.value
+			)
+			SELECT input.slug, COALESCE(c.cnt, 0) AS cnt
+			FROM input
+			LEFT JOIN counts c ON c.slug = input.slug
+		`, r.tableForKind(kind), jsonArrayElements("tags"))
+
+		rows, err := tx.QueryxContext(ctx, query, store.ValueList[string](slugs))
+		if err != nil {
+			return errxtrace.Wrap("failed to batch-compute tag usage", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var slug string
+			var cnt int
+			if err := rows.Scan(&slug, &cnt); err != nil {
+				return errxtrace.Wrap("failed to scan tag usage", err)
+			}
+			out[slug] = usageForKind(kind, cnt)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to compute tag usage batch", err)
+	}
+	return out, nil
+}
+
+// usageForKind packs a single per-kind reference count into the relevant
+// side of TagUsage (the other side stays zero).
+func usageForKind(kind models.TagKind, cnt int) registry.TagUsage {
+	if kind == models.TagKindFile {
+		return registry.TagUsage{Files: cnt}
+	}
+	return registry.TagUsage{Commodities: cnt}
+}
+
+func (r *TagRegistry) GetStats(ctx context.Context) (registry.TagStats, error) {
+	var stats registry.TagStats
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		// One round-trip: each subquery is a sealed COUNT under the current
+		// isolation scope. jsonArrayLength treats NULL the same as []:
+		// untagged.
+		tagCount := jsonArrayLength("tags")
+		query := fmt.Sprintf(`
+			SELECT
+				(SELECT COUNT(*) FROM %[1]s) AS tags_total,
+				(SELECT COUNT(*) FROM %[1]s WHERE kind = 'commodity') AS commodity_tags_total,
+				(SELECT COUNT(*) FROM %[1]s WHERE kind = 'file') AS file_tags_total,
+				(SELECT COUNT(*) FROM %[2]s WHERE %[4]s > 0) AS items_tagged,
+				(SELECT COUNT(*) FROM %[2]s WHERE %[4]s = 0) AS items_untagged,
+				(SELECT COUNT(*) FROM %[3]s WHERE %[4]s > 0) AS files_tagged,
+				(SELECT COUNT(*) FROM %[3]s WHERE %[4]s = 0) AS files_untagged
+		`,
+			r.tableNames.Tags(), r.tableNames.Commodities(), r.tableNames.Files(), tagCount,
+		)
+		return tx.QueryRowxContext(ctx, query).Scan(
+			&stats.TagsTotal,
+			&stats.CommodityTagsTotal, &stats.FileTagsTotal,
+			&stats.ItemsTagged, &stats.ItemsUntagged,
+			&stats.FilesTagged, &stats.FilesUntagged,
+		)
+	})
+	if err != nil {
+		return registry.TagStats{}, errxtrace.Wrap("failed to compute tag stats", err)
+	}
+	return stats, nil
+}
+
+func (r *TagRegistry) GetUsage(ctx context.Context, kind models.TagKind, slug string) (registry.TagUsage, error) {
+	var cnt int
+
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`SELECT COUNT(*) FROM %s WHERE %s`,
+			r.tableForKind(kind), jsonArrayContains("tags", "$1"),
+		)
+		return tx.QueryRowxContext(ctx, query, slug).Scan(&cnt)
+	})
+	if err != nil {
+		return registry.TagUsage{}, errxtrace.Wrap("failed to compute tag usage", err)
+	}
+	return usageForKind(kind, cnt), nil
+}
+
+// jsonReplaceSlugExpr emits the SQL expression that returns the rewritten
+// JSON array for a single row, deduplicating after the substitution so a
+// rename onto an already-present slug doesn't produce a duplicate. Like
+// jsonStripSlugExpr it yields a BLOB, which is how the models' Valuers
+// store JSON and the only form their Scanners read back.
+func jsonReplaceSlugExpr(oldSlugParam, newSlugParam int) string {
+	return fmt.Sprintf(
+		`CAST((SELECT json_group_array(DISTINCT (CASE WHEN value = $%d THEN $%d ELSE value END))
+		  FROM %s) AS BLOB)`,
+		oldSlugParam, newSlugParam, jsonArrayElements("tags"),
+	)
+}
+
+// jsonStripSlugExpr emits the SQL expression that returns the JSON array
+// with every occurrence of the given slug removed.
+func jsonStripSlugExpr(slugParam int) string {
+	return fmt.Sprintf(
+		`CAST((SELECT json_group_array(value) FROM %s WHERE value <> $%d) AS BLOB)`,
+		jsonArrayElements("tags"), slugParam,
+	)
+}
+
+func (r *TagRegistry) RewriteSlugReferences(ctx context.Context, kind models.TagKind, oldSlug, newSlug string) (commodityRows, fileRows int, err error) {
+	if oldSlug == newSlug {
+		return 0, 0, nil
+	}
+
+	var affected int
+	reg := r.newSQLRegistry()
+	err = reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`UPDATE main.%s SET tags = %s WHERE %s`,
+			r.tableForKind(kind), jsonReplaceSlugExpr(1, 2), jsonArrayContains("tags", "$1"),
+		)
+		res, execErr := tx.ExecContext(ctx, query, oldSlug, newSlug)
+		if execErr != nil {
+			return errxtrace.Wrap("failed to rewrite tag references", execErr)
+		}
+		n, raErr := res.RowsAffected()
+		if raErr != nil {
+			return errxtrace.Wrap("failed to read rows affected", raErr)
+		}
+		affected = int(n)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, errxtrace.Wrap("failed to rewrite slug references", err)
+	}
+	return rowsForKind(kind, affected)
+}
+
+// rowsForKind splits a single affected-row count into the
+// (commodityRows, fileRows) return tuple used by Rewrite/StripSlugReferences.
+func rowsForKind(kind models.TagKind, affected int) (commodityRows, fileRows int, err error) {
+	if kind == models.TagKindFile {
+		return 0, affected, nil
+	}
+	return affected, 0, nil
+}
+
+// defaultTagLabelFromSlug mirrors services.defaultLabelFromSlug — split a
+// kebab-case slug on '-' and Title-case each word. Duplicated here so the
+// registry's own auto-create path doesn't import services (which would be
+// a cycle: services imports registry).
+func defaultTagLabelFromSlug(slug string) string {
+	parts := strings.Split(slug, "-")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		runes := []rune(p)
+		runes[0] = unicode.ToUpper(runes[0])
+		parts[i] = string(runes)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ensureTagRowsInTx is the cross-tx safety net for the orphan-reference
+// race surfaced by #1488: a commodity / file insert that references a
+// slug must not commit if a concurrent DeleteTag already removed (or is
+// removing) the tags row for that slug.
+//
+// Write transactions hold the database write lock from BEGIN IMMEDIATE
+// on, so the insert and DeleteAtomic cannot interleave: if the delete
+// already committed, our INSERT ... ON CONFLICT DO NOTHING creates a
+// fresh tag row; if it hadn't started, it waits for our tx, then counts
+// usage (which now includes our new row) and either refuses
+// (force=false) or strips it (force=true).
+func ensureTagRowsInTx(ctx context.Context, tx *sqlx.Tx, tableNames store.TableNames, tenantID, groupID, userID string, kind models.TagKind, slugs []string) error {
+	if len(slugs) == 0 || groupID == "" {
+		return nil
+	}
+	cleaned := make([]string, 0, len(slugs))
+	seen := make(map[string]struct{}, len(slugs))
+	for _, s := range slugs {
+		if s == "" {
+			continue
+		}
+		if _, dup := seen[s]; dup {
+			continue
+		}
+		seen[s] = struct{}{}
+		cleaned = append(cleaned, s)
+	}
+	if len(cleaned) == 0 {
+		return nil
+	}
+	sort.Strings(cleaned)
+
+	// ON CONFLICT DO NOTHING leaves an existing (kind, slug) row alone.
+	insertQuery := fmt.Sprintf(`
+		INSERT INTO main.%s (id, tenant_id, group_id, kind, slug, label, color, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (group_id, kind, slug) DO NOTHING`, tableNames.Tags())
+
+	for _, slug := range cleaned {
+		if _, err := tx.ExecContext(ctx, insertQuery,
+			uuid.NewString(), tenantID, groupID, kind, slug, defaultTagLabelFromSlug(slug), models.DefaultTagColor, userID); err != nil {
+			return errxtrace.Wrap("failed to insert tag row", err, errx.Attrs("slug", slug))
+		}
+	}
+	return nil
+}
+
+// renameRewriteSlug runs the JSON-rewrite half of RenameAtomic — slug
+// clash check + the table update — inside the caller's tx. Lifted out of
+// RenameAtomic so the orchestrator stays under gocognit's threshold
+// without losing the rewrite step.
+func (r *TagRegistry) renameRewriteSlug(ctx context.Context, tx *sqlx.Tx, id string, kind models.TagKind, oldSlug, newSlug string) error {
+	// Pre-emptive slug-clash check, inside the tx — scoped to the same
+	// kind, because (group, kind, slug) is the uniqueness tuple: the same
+	// slug may legitimately exist under the other kind. Relying on the
+	// unique index alone would still work, but yields a worse error
+	// message (SQLite-level uniqueness violation vs. our domain
+	// ErrAlreadyExists).
+	var clashID string
+	clashErr := tx.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT id FROM %s WHERE kind = $1 AND slug = $2 AND id != $3 LIMIT 1`, r.tableNames.Tags()),
+		kind, newSlug, id).Scan(&clashID)
+	switch {
+	case errors.Is(clashErr, sql.ErrNoRows):
+		// no clash — proceed
+	case clashErr != nil:
+		return errxtrace.Wrap("failed to check slug availability", clashErr)
+	default:
+		return errxtrace.Wrap("target slug is already used by another tag",
+			registry.ErrAlreadyExists, errx.Attrs("slug", newSlug))
+	}
+
+	// Rewrite references only on the kind's own table — a commodity-tag
+	// rename must not touch files (those slugs belong to file tags).
+	rewriteQuery := fmt.Sprintf(
+		`UPDATE main.%s SET tags = %s WHERE %s`,
+		r.tableForKind(kind), jsonReplaceSlugExpr(1, 2), jsonArrayContains("tags", "$1"))
+	if _, err := tx.ExecContext(ctx, rewriteQuery, oldSlug, newSlug); err != nil {
+		return errxtrace.Wrap("failed to rewrite tag references", err)
+	}
+	return nil
+}
+
+// RenameAtomic does the slug-clash check, JSON rewrite, and tags-row
+// update inside a single transaction. Write transactions hold the
+// database write lock from BEGIN IMMEDIATE on, so two parallel renames of
+// the same tag id serialize; the second reads the row after the first
+// commits and renames from whatever slug the first one settled on.
+func (r *TagRegistry) RenameAtomic(ctx context.Context, id, newLabel, newSlug string, newColor models.TagColor) (*models.Tag, error) {
+	var final *models.Tag
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		var current models.Tag
+		err := tx.QueryRowxContext(ctx,
+			fmt.Sprintf(`SELECT * FROM %s WHERE id = $1`, r.tableNames.Tags()),
+			id).StructScan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errxtrace.Wrap("failed to look up tag", registry.ErrNotFound, errx.Attrs("id", id))
+		}
+		if err != nil {
+			return errxtrace.Wrap("failed to look up tag", err)
+		}
+
+		updated := current
+		updated.UpdatedAt = time.Now()
+		if strings.TrimSpace(newLabel) != "" {
+			updated.Label = newLabel
+		}
+		if newColor != "" {
+			updated.Color = newColor
+		}
+
+		if newSlug != "" && newSlug != current.Slug {
+			updated.Slug = newSlug
+			if err := r.renameRewriteSlug(ctx, tx, id, current.Kind, current.Slug, newSlug); err != nil {
+				return err
+			}
+		}
+
+		var result models.Tag
+		err = tx.QueryRowxContext(ctx, fmt.Sprintf(
+			`UPDATE main.%s SET slug = $1, label = $2, color = $3, updated_at = $4 WHERE id = $5 RETURNING *`,
+			r.tableNames.Tags()),
+			updated.Slug, updated.Label, updated.Color, updated.UpdatedAt, id).StructScan(&result)
+		if err != nil {
+			return errxtrace.Wrap("failed to update tag row", err)
+		}
+		final = &result
+		return nil
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to rename tag atomically", err)
+	}
+	return final, nil
+}
+
+// DeleteAtomic checks usage, strips JSON references (when force=true),
+// and deletes the tags row inside one tx. Holding the database write lock
+// is what coordinates with concurrent commodity / file inserts via
+// ensureTagRowsInTx — they either commit first, and their references are
+// counted here, or start after us, see the row gone and re-INSERT a fresh
+// one via ON CONFLICT DO NOTHING.
+//
+// When force=false and usage > 0, returns the populated TagUsage along
+// with registry.ErrTagInUse and rolls back without mutating state.
+//
+//revive:disable-next-line:flag-parameter
+func (r *TagRegistry) DeleteAtomic(ctx context.Context, id string, force bool) (registry.TagUsage, error) {
+	var usage registry.TagUsage
+	reg := r.newSQLRegistry()
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		var current models.Tag
+		err := tx.QueryRowxContext(ctx,
+			fmt.Sprintf(`SELECT * FROM %s WHERE id = $1`, r.tableNames.Tags()),
+			id).StructScan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errxtrace.Wrap("failed to look up tag", registry.ErrNotFound, errx.Attrs("id", id))
+		}
+		if err != nil {
+			return errxtrace.Wrap("failed to look up tag", err)
+		}
+
+		// Usage counts only the tag's own kind table — a commodity tag's
+		// usage is its commodities, never files that happen to share the slug.
+		var refCount int
+		usageQuery := fmt.Sprintf(
+			`SELECT COUNT(*) FROM %s WHERE %s`,
+			r.tableForKind(current.Kind), jsonArrayContains("tags", "$1"))
+		if err := tx.QueryRowxContext(ctx, usageQuery, current.Slug).Scan(&refCount); err != nil {
+			return errxtrace.Wrap("failed to compute tag usage", err)
+		}
+		usage = usageForKind(current.Kind, refCount)
+
+		if refCount > 0 && !force {
+			return registry.ErrTagInUse
+		}
+		if refCount > 0 {
+			stripQuery := fmt.Sprintf(
+				`UPDATE main.%s SET tags = %s WHERE %s`,
+				r.tableForKind(current.Kind), jsonStripSlugExpr(1), jsonArrayContains("tags", "$1"))
+			if _, err := tx.ExecContext(ctx, stripQuery, current.Slug); err != nil {
+				return errxtrace.Wrap("failed to strip tag references", err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf(`DELETE FROM main.%s WHERE id = $1`, r.tableNames.Tags()), id); err != nil {
+			return errxtrace.Wrap("failed to delete tag row", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return usage, err
+	}
+	return usage, nil
+}
+
+func (r *TagRegistry) StripSlugReferences(ctx context.Context, kind models.TagKind, slug string) (commodityRows, fileRows int, err error) {
+	var affected int
+	reg := r.newSQLRegistry()
+	err = reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(
+			`UPDATE main.%s SET tags = %s WHERE %s`,
+			r.tableForKind(kind), jsonStripSlugExpr(1), jsonArrayContains("tags", "$1"),
+		)
+		res, execErr := tx.ExecContext(ctx, query, slug)
+		if execErr != nil {
+			return errxtrace.Wrap("failed to strip tag references", execErr)
+		}
+		n, raErr := res.RowsAffected()
+		if raErr != nil {
+			return errxtrace.Wrap("failed to read rows affected", raErr)
+		}
+		affected = int(n)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, errxtrace.Wrap("failed to strip slug references", err)
+	}
+	return rowsForKind(kind, affected)
+}
@@ -0,0 +1,307 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/go-kit/must"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/sqlite/store"
+)
+
+// UserConcurrencySlotRegistryFactory creates UserConcurrencySlotRegistry instances with proper context
+type UserConcurrencySlotRegistryFactory struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+}
+
+// UserConcurrencySlotRegistry is a context-aware registry that can only be created through the factory
+type UserConcurrencySlotRegistry struct {
+	dbx        *sqlx.DB
+	tableNames store.TableNames
+	userID     string
+	tenantID   string
+	service    bool
+}
+
+var _ registry.UserConcurrencySlotRegistry = (*UserConcurrencySlotRegistry)(nil)
+var _ registry.UserConcurrencySlotRegistryFactory = (*UserConcurrencySlotRegistryFactory)(nil)
+
+func NewUserConcurrencySlotRegistry(dbx *sqlx.DB) *UserConcurrencySlotRegistryFactory {
+	return NewUserConcurrencySlotRegistryWithTableNames(dbx, store.DefaultTableNames)
+}
+
+func NewUserConcurrencySlotRegistryWithTableNames(dbx *sqlx.DB, tableNames store.TableNames) *UserConcurrencySlotRegistryFactory {
+	return &UserConcurrencySlotRegistryFactory{
+		dbx:        dbx,
+		tableNames: tableNames,
+	}
+}
+
+// Factory methods implementing registry.UserConcurrencySlotRegistryFactory
+
+func (f *UserConcurrencySlotRegistryFactory) MustCreateUserRegistry(ctx context.Context) registry.UserConcurrencySlotRegistry {
+	return must.Must(f.CreateUserRegistry(ctx))
+}
+
+func (f *UserConcurrencySlotRegistryFactory) CreateUserRegistry(ctx context.Context) (registry.UserConcurrencySlotRegistry, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user ID from context", err)
+	}
+
+	return &UserConcurrencySlotRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		userID:     user.ID,
+		tenantID:   user.TenantID,
+		service:    false,
+	}, nil
+}
+
+func (f *UserConcurrencySlotRegistryFactory) CreateServiceRegistry() registry.UserConcurrencySlotRegistry {
+	return &UserConcurrencySlotRegistry{
+		dbx:        f.dbx,
+		tableNames: f.tableNames,
+		userID:     "",
+		tenantID:   "",
+		service:    true,
+	}
+}
+
+// Get returns a user concurrency slot by ID
+func (r *UserConcurrencySlotRegistry) Get(ctx context.Context, id string) (*models.UserConcurrencySlot, error) {
+	var slot models.UserConcurrencySlot
+	reg := r.newSQLRegistry()
+
+	err := reg.ScanOneByField(ctx, store.Pair("id", id), &slot)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user concurrency slot", err)
+	}
+
+	return &slot, nil
+}
+
+// List returns all user concurrency slots
+func (r *UserConcurrencySlotRegistry) List(ctx context.Context) ([]*models.UserConcurrencySlot, error) {
+	var slots []*models.UserConcurrencySlot
+	reg := r.newSQLRegistry()
+
+	for slot, err := range reg.Scan(ctx) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list user concurrency slots", err)
+		}
+		slots = append(slots, &slot)
+	}
+
+	return slots, nil
+}
+
+// Count returns the number of user concurrency slots
+func (r *UserConcurrencySlotRegistry) Count(ctx context.Context) (int, error) {
+	reg := r.newSQLRegistry()
+
+	cnt, err := reg.Count(ctx)
+	if err != nil {
+		return 0, errxtrace.Wrap("failed to count user concurrency slots", err)
+	}
+
+	return cnt, nil
+}
+
+// Create creates a new user concurrency slot
+func (r *UserConcurrencySlotRegistry) Create(ctx context.Context, slot models.UserConcurrencySlot) (*models.UserConcurrencySlot, error) {
+	reg := r.newSQLRegistry()
+
+	result, err := reg.Create(ctx, slot, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create user concurrency slot", err)
+	}
+
+	return &result, nil
+}
+
+// Update updates a user concurrency slot
+func (r *UserConcurrencySlotRegistry) Update(ctx context.Context, slot models.UserConcurrencySlot) (*models.UserConcurrencySlot, error) {
+	reg := r.newSQLRegistry()
+
+	err := reg.Update(ctx, slot, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update user concurrency slot", err)
+	}
+
+	return &slot, nil
+}
+
+// Delete deletes a user concurrency slot
+func (r *UserConcurrencySlotRegistry) Delete(ctx context.Context, id string) error {
+	reg := r.newSQLRegistry()
+
+	err := reg.Delete(ctx, id, nil)
+	if err != nil {
+		return errxtrace.Wrap("failed to delete user concurrency slot", err)
+	}
+
+	return nil
+}
+
+// AcquireSlot attempts to acquire a concurrency slot for a user
+func (r *UserConcurrencySlotRegistry) AcquireSlot(ctx context.Context, userID, jobID string, maxSlots int, slotDuration time.Duration) (*models.UserConcurrencySlot, error) {
+	reg := r.newSQLRegistry()
+	var result *models.UserConcurrencySlot
+
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		// The transaction holds the database write lock, so the
+		// COUNT+INSERT below is atomic — two callers cannot both read a
+		// stale count and both insert, blowing past maxSlots (the unique
+		// index is only on job_id, so it doesn't enforce the per-user
+		// cap). The cap is enforced over the user-global COUNT (WHERE
+		// user_id = $1, no tenant filter — AcquireSlot runs in service
+		// mode with no isolation context).
+
+		// Check current slot counts for the user
+		var activeCount int
+		countQuery := fmt.Sprintf(`
+			SELECT COUNT(*)
+			FROM %s
+			WHERE user_id = $1 AND status = 'active'`, r.tableNames.UserConcurrencySlots())
+
+		err := tx.QueryRowContext(ctx, countQuery, userID).Scan(&activeCount)
+		if err != nil {
+			return errxtrace.Wrap("failed to count existing slots", err)
+		}
+
+		// Check limits
+		if activeCount >= maxSlots {
+			return registry.ErrResourceLimitExceeded
+		}
+
+		// Get tenant ID from the job being processed
+		var tenantID string
+		jobQuery := fmt.Sprintf(`SELECT tenant_id FROM %s WHERE id = $1`, r.tableNames.ThumbnailGenerationJobs())
+		err = tx.QueryRowContext(ctx, jobQuery, jobID).Scan(&tenantID)
+		if err != nil {
+			return errxtrace.Wrap("failed to get tenant ID from job", err)
+		}
+
+		// Create the slot with proper tenant/user context
+		slot := models.UserConcurrencySlot{
+			TenantUserAwareEntityID: models.TenantUserAwareEntityID{
+				UserID:   userID,
+				TenantID: tenantID,
+			},
+			JobID:     jobID,
+			Status:    models.SlotStatusActive,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		// Use the RLS repository to create the slot (it will set user/tenant context)
+		txReg := store.NewTxRegistry[models.UserConcurrencySlot](tx, r.tableNames.UserConcurrencySlots())
+		err = txReg.Insert(ctx, slot)
+		if err != nil {
+			return errxtrace.Wrap("failed to create concurrency slot", err)
+		}
+
+		result = &slot
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReleaseSlot releases a concurrency slot
+func (r *UserConcurrencySlotRegistry) ReleaseSlot(ctx context.Context, userID, jobID string) error {
+	reg := r.newSQLRegistry()
+
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`DELETE FROM main.%s WHERE user_id = $1 AND job_id = $2`, r.tableNames.UserConcurrencySlots())
+		result, err := tx.ExecContext(ctx, query, userID, jobID)
+		if err != nil {
+			return errxtrace.Wrap("failed to release concurrency slot", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return errxtrace.Wrap("failed to get rows affected", err)
+		}
+
+		if rowsAffected == 0 {
+			return registry.ErrNotFound
+		}
+		return nil
+	})
+
+	return err
+}
+
+// GetUserSlots returns all slots for a user
+func (r *UserConcurrencySlotRegistry) GetUserSlots(ctx context.Context, userID string) ([]*models.UserConcurrencySlot, error) {
+	var slots []*models.UserConcurrencySlot
+	reg := r.newSQLRegistry()
+
+	for slot, err := range reg.ScanByField(ctx, store.Pair("user_id", userID)) {
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list user slots", err)
+		}
+		slots = append(slots, &slot)
+	}
+
+	return slots, nil
+}
+
+// CleanupExpiredSlots removes expired slots
+func (r *UserConcurrencySlotRegistry) CleanupExpiredSlots(ctx context.Context) error {
+	reg := r.newSQLRegistry()
+
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		// Remove slots that are older than 1 hour (expired)
+		expiredBefore := time.Now().Add(-1 * time.Hour)
+		query := fmt.Sprintf(`
+			DELETE FROM main.%s
+			WHERE created_at < $1`, r.tableNames.UserConcurrencySlots())
+
+		_, err := tx.ExecContext(ctx, query, expiredBefore)
+		if err != nil {
+			return errxtrace.Wrap("failed to cleanup expired slots", err)
+		}
+		return nil
+	})
+
+	return err
+}
+
+// DeleteByJobID removes every concurrency slot referencing the given
+// thumbnail generation job. Idempotent: a parameterized DELETE that
+// matches zero rows is a no-op (no ErrNotFound), so the thumbnail-cleanup
+// path can call it unconditionally before dropping the job row.
+func (r *UserConcurrencySlotRegistry) DeleteByJobID(ctx context.Context, jobID string) error {
+	reg := r.newSQLRegistry()
+
+	err := reg.Do(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`DELETE FROM main.%s WHERE job_id = $1`, r.tableNames.UserConcurrencySlots())
+		if _, err := tx.ExecContext(ctx, query, jobID); err != nil {
+			return errxtrace.Wrap("failed to delete concurrency slots by job ID", err)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *UserConcurrencySlotRegistry) newSQLRegistry() *store.RLSRepository[models.UserConcurrencySlot, *models.UserConcurrencySlot] {
+	if r.service {
+		return store.NewServiceSQLRegistry[models.UserConcurrencySlot](r.dbx, r.tableNames.UserConcurrencySlots())
+	}
+	return store.NewUserAwareSQLRegistry[models.UserConcurrencySlot](r.dbx, r.userID, r.tenantID, r.tableNames.UserConcurrencySlots())
+}
@@ -0,0 +1,10 @@
+package sqlite
+
+import (
+	"github.com/google/uuid"
+)
+
+// generateID generates a new UUID string
+func generateID() string {
+	return uuid.New().String()
+}
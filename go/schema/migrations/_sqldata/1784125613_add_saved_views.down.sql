@@ -0,0 +1,15 @@
+-- Migration rollback
+-- Generated on: 2026-07-15T14:26:53Z
+-- Direction: DOWN
+
+DROP INDEX IF EXISTS idx_saved_views_group_creator;
+DROP INDEX IF EXISTS idx_saved_views_tenant_group;
+DROP INDEX IF EXISTS idx_saved_views_tenant_id;
+DROP INDEX IF EXISTS idx_saved_views_uuid;
+-- Drop RLS policy saved_view_background_worker_access from table saved_views
+DROP POLICY IF EXISTS saved_view_background_worker_access ON saved_views;
+-- Drop RLS policy saved_view_isolation from table saved_views
+DROP POLICY IF EXISTS saved_view_isolation ON saved_views;
+-- NOTE: RLS policies were removed from table saved_views - verify if RLS should be disabled --
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS saved_views CASCADE;
\ No newline at end of file
@@ -0,0 +1,40 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-07-15T14:26:53Z
+-- Direction: UP
+
+-- POSTGRES TABLE: saved_views --
+CREATE TABLE saved_views (
+  name TEXT NOT NULL,
+  scope TEXT NOT NULL DEFAULT 'private',
+  filters JSONB NOT NULL,
+  columns JSONB,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tenant_id TEXT NOT NULL,
+  group_id TEXT NOT NULL,
+  created_by_user_id TEXT NOT NULL,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+-- ALTER statements: --
+ALTER TABLE saved_views ADD CONSTRAINT fk_entity_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+-- ALTER statements: --
+ALTER TABLE saved_views ADD CONSTRAINT fk_entity_group FOREIGN KEY (group_id) REFERENCES location_groups(id);
+-- ALTER statements: --
+ALTER TABLE saved_views ADD CONSTRAINT fk_entity_created_by FOREIGN KEY (created_by_user_id) REFERENCES users(id);
+-- Enable RLS for saved_views table
+ALTER TABLE saved_views ENABLE ROW LEVEL SECURITY;
+-- Allows background workers to access all saved views for processing
+DROP POLICY IF EXISTS saved_view_background_worker_access ON saved_views;
+CREATE POLICY saved_view_background_worker_access ON saved_views FOR ALL TO inventario_background_worker
+    USING (true)
+    WITH CHECK (true);
+-- Ensures saved views can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS saved_view_isolation ON saved_views;
+CREATE POLICY saved_view_isolation ON saved_views FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+CREATE INDEX IF NOT EXISTS idx_saved_views_group_creator ON saved_views (group_id, created_by_user_id);
+CREATE INDEX IF NOT EXISTS idx_saved_views_tenant_group ON saved_views (tenant_id, group_id);
+CREATE INDEX IF NOT EXISTS idx_saved_views_tenant_id ON saved_views (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_views_uuid ON saved_views (uuid);
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Migration rollback
+-- Generated on: 2026-07-19T14:22:01Z
+-- Direction: DOWN
+
+-- Remove columns from table: commodity_loans --
+-- ALTER statements: --
+ALTER TABLE commodity_loans DROP COLUMN pending_request_at CASCADE;
+-- WARNING: Dropping column commodity_loans.pending_request_at with CASCADE - This will delete data and dependent objects! --
+ALTER TABLE commodity_loans DROP COLUMN pending_request_note CASCADE;
+-- WARNING: Dropping column commodity_loans.pending_request_note with CASCADE - This will delete data and dependent objects! --
+ALTER TABLE commodity_loans DROP COLUMN pending_request_due_back_at CASCADE;
+-- WARNING: Dropping column commodity_loans.pending_request_due_back_at with CASCADE - This will delete data and dependent objects! --
+ALTER TABLE commodity_loans DROP COLUMN pending_request_kind CASCADE;
+-- WARNING: Dropping column commodity_loans.pending_request_kind with CASCADE - This will delete data and dependent objects! --
+ALTER TABLE commodity_loans DROP COLUMN borrower_reminder_sent_due_soon CASCADE;
+-- WARNING: Dropping column commodity_loans.borrower_reminder_sent_due_soon with CASCADE - This will delete data and dependent objects! --
+ALTER TABLE commodity_loans DROP COLUMN borrower_reminder_sent_overdue CASCADE;
+-- WARNING: Dropping column commodity_loans.borrower_reminder_sent_overdue with CASCADE - This will delete data and dependent objects! --
+ALTER TABLE commodity_loans DROP COLUMN borrower_email CASCADE;
+-- WARNING: Dropping column commodity_loans.borrower_email with CASCADE - This will delete data and dependent objects! --;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-07-19T14:22:01Z
+-- Direction: UP
+
+-- Add/modify columns for table: commodity_loans --
+-- ALTER statements: --
+ALTER TABLE commodity_loans ADD COLUMN borrower_email TEXT;
+ALTER TABLE commodity_loans ADD COLUMN borrower_reminder_sent_overdue BOOLEAN NOT NULL DEFAULT 'false';
+ALTER TABLE commodity_loans ADD COLUMN borrower_reminder_sent_due_soon BOOLEAN NOT NULL DEFAULT 'false';
+ALTER TABLE commodity_loans ADD COLUMN pending_request_kind TEXT;
+ALTER TABLE commodity_loans ADD COLUMN pending_request_due_back_at TEXT;
+ALTER TABLE commodity_loans ADD COLUMN pending_request_note TEXT;
+ALTER TABLE commodity_loans ADD COLUMN pending_request_at TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- Migration rollback
+-- Generated on: 2026-07-23T13:13:20Z
+-- Direction: DOWN
+
+-- Remove columns from table: exports --
+-- ALTER statements: --
+ALTER TABLE exports DROP COLUMN signature_key_fingerprint CASCADE;
+-- WARNING: Dropping column exports.signature_key_fingerprint with CASCADE - This will delete data and dependent objects! --;
+DROP INDEX IF EXISTS idx_trusted_backup_keys_uuid;
+DROP INDEX IF EXISTS trusted_backup_keys_fingerprint_idx;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS trusted_backup_keys CASCADE;
\ No newline at end of file
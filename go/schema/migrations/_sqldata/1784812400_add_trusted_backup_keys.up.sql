@@ -0,0 +1,24 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-07-23T13:13:20Z
+-- Direction: UP
+
+-- POSTGRES TABLE: trusted_backup_keys --
+CREATE TABLE trusted_backup_keys (
+  fingerprint TEXT NOT NULL,
+  public_key TEXT NOT NULL,
+  label TEXT NOT NULL,
+  added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  added_by TEXT,
+  expires_at TIMESTAMP,
+  revoked_at TIMESTAMP,
+  revoked_by TEXT,
+  revoke_reason TEXT,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trusted_backup_keys_uuid ON trusted_backup_keys (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS trusted_backup_keys_fingerprint_idx ON trusted_backup_keys (fingerprint);
+
+-- Add/modify columns for table: exports --
+-- ALTER statements: --
+ALTER TABLE exports ADD COLUMN signature_key_fingerprint TEXT;
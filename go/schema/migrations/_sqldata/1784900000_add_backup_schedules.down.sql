@@ -0,0 +1,17 @@
+-- Migration rollback
+-- Generated on: 2026-07-24T13:33:20Z
+-- Direction: DOWN
+
+ALTER TABLE exports DROP CONSTRAINT IF EXISTS fk_export_backup_schedule;
+ALTER TABLE exports DROP COLUMN IF EXISTS backup_schedule_id;
+DROP INDEX IF EXISTS idx_backup_schedules_group;
+DROP INDEX IF EXISTS idx_backup_schedules_next_run;
+DROP INDEX IF EXISTS idx_backup_schedules_tenant_id;
+DROP INDEX IF EXISTS idx_backup_schedules_uuid;
+-- Drop RLS policy backup_schedule_background_worker_access from table backup_schedules
+DROP POLICY IF EXISTS backup_schedule_background_worker_access ON backup_schedules;
+-- Drop RLS policy backup_schedule_isolation from table backup_schedules
+DROP POLICY IF EXISTS backup_schedule_isolation ON backup_schedules;
+-- NOTE: RLS policies were removed from table backup_schedules - verify if RLS should be disabled --
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS backup_schedules CASCADE;
\ No newline at end of file
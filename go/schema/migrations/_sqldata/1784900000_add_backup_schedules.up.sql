@@ -0,0 +1,54 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-07-24T13:33:20Z
+-- Direction: UP
+
+-- POSTGRES TABLE: backup_schedules --
+CREATE TABLE backup_schedules (
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  frequency TEXT NOT NULL,
+  time_of_day TEXT NOT NULL DEFAULT '03:00',
+  weekday INTEGER NOT NULL DEFAULT 0,
+  day_of_month INTEGER NOT NULL DEFAULT 1,
+  include_file_data BOOLEAN NOT NULL DEFAULT false,
+  keep_daily INTEGER NOT NULL DEFAULT 7,
+  keep_weekly INTEGER NOT NULL DEFAULT 4,
+  keep_monthly INTEGER NOT NULL DEFAULT 6,
+  next_run_at TIMESTAMP NOT NULL,
+  last_run_at TIMESTAMP,
+  last_export_id TEXT,
+  settled_export_id TEXT,
+  last_error TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tenant_id TEXT NOT NULL,
+  group_id TEXT NOT NULL,
+  created_by_user_id TEXT NOT NULL,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+-- ALTER statements: --
+ALTER TABLE backup_schedules ADD CONSTRAINT fk_entity_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+-- ALTER statements: --
+ALTER TABLE backup_schedules ADD CONSTRAINT fk_entity_group FOREIGN KEY (group_id) REFERENCES location_groups(id);
+-- ALTER statements: --
+ALTER TABLE backup_schedules ADD CONSTRAINT fk_entity_created_by FOREIGN KEY (created_by_user_id) REFERENCES users(id);
+-- Enable RLS for backup_schedules table
+ALTER TABLE backup_schedules ENABLE ROW LEVEL SECURITY;
+-- Allows background workers to access all backup schedules for processing
+DROP POLICY IF EXISTS backup_schedule_background_worker_access ON backup_schedules;
+CREATE POLICY backup_schedule_background_worker_access ON backup_schedules FOR ALL TO inventario_background_worker
+    USING (true)
+    WITH CHECK (true);
+-- Ensures backup schedules can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS backup_schedule_isolation ON backup_schedules;
+CREATE POLICY backup_schedule_isolation ON backup_schedules FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+CREATE UNIQUE INDEX IF NOT EXISTS idx_backup_schedules_group ON backup_schedules (group_id);
+CREATE INDEX IF NOT EXISTS idx_backup_schedules_next_run ON backup_schedules (next_run_at);
+CREATE INDEX IF NOT EXISTS idx_backup_schedules_tenant_id ON backup_schedules (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_backup_schedules_uuid ON backup_schedules (uuid);
+-- ALTER statements: --
+ALTER TABLE exports ADD COLUMN backup_schedule_id TEXT;
+-- ALTER statements: --
+ALTER TABLE exports ADD CONSTRAINT fk_export_backup_schedule FOREIGN KEY (backup_schedule_id) REFERENCES backup_schedules(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T09:12:44Z
+-- Direction: DOWN
+
+-- Remove columns from table: exports --
+-- ALTER statements: --
+ALTER TABLE exports DROP COLUMN replication_attempts CASCADE;
+-- WARNING: Dropping column exports.replication_attempts with CASCADE - This will delete data and dependent objects! --;
+ALTER TABLE exports DROP COLUMN replication_error CASCADE;
+-- WARNING: Dropping column exports.replication_error with CASCADE - This will delete data and dependent objects! --;
+ALTER TABLE exports DROP COLUMN replicated_at CASCADE;
+-- WARNING: Dropping column exports.replicated_at with CASCADE - This will delete data and dependent objects! --;
+ALTER TABLE exports DROP COLUMN replica_sha256 CASCADE;
+-- WARNING: Dropping column exports.replica_sha256 with CASCADE - This will delete data and dependent objects! --;
+ALTER TABLE exports DROP COLUMN replica_url CASCADE;
+-- WARNING: Dropping column exports.replica_url with CASCADE - This will delete data and dependent objects! --;
+ALTER TABLE exports DROP COLUMN replication_status CASCADE;
+-- WARNING: Dropping column exports.replication_status with CASCADE - This will delete data and dependent objects! --;
\ No newline at end of file
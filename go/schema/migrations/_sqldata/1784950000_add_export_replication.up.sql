@@ -0,0 +1,12 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T09:12:44Z
+-- Direction: UP
+
+-- Add/modify columns for table: exports --
+-- ALTER statements: --
+ALTER TABLE exports ADD COLUMN replication_status TEXT NOT NULL DEFAULT '';
+ALTER TABLE exports ADD COLUMN replica_url TEXT;
+ALTER TABLE exports ADD COLUMN replica_sha256 TEXT;
+ALTER TABLE exports ADD COLUMN replicated_at TIMESTAMP;
+ALTER TABLE exports ADD COLUMN replication_error TEXT;
+ALTER TABLE exports ADD COLUMN replication_attempts INTEGER NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T10:05:31Z
+-- Direction: DOWN
+
+-- Remove columns from table: restore_operations --
+-- ALTER statements: --
+ALTER TABLE restore_operations DROP COLUMN preview CASCADE;
+-- WARNING: Dropping column restore_operations.preview with CASCADE - This will delete data and dependent objects! --;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T10:05:31Z
+-- Direction: UP
+
+-- Add/modify columns for table: restore_operations --
+-- ALTER statements: --
+ALTER TABLE restore_operations ADD COLUMN preview JSONB;
\ No newline at end of file
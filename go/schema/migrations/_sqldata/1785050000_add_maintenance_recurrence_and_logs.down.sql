@@ -0,0 +1,30 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T11:20:14Z
+-- Direction: DOWN
+
+DROP INDEX IF EXISTS idx_commodity_meter_readings_commodity;
+DROP INDEX IF EXISTS idx_commodity_meter_readings_tenant_group;
+DROP INDEX IF EXISTS idx_commodity_meter_readings_tenant_id;
+DROP INDEX IF EXISTS idx_commodity_meter_readings_uuid;
+DROP INDEX IF EXISTS idx_maintenance_logs_commodity;
+DROP INDEX IF EXISTS idx_maintenance_logs_schedule;
+DROP INDEX IF EXISTS idx_maintenance_logs_tenant_group;
+DROP INDEX IF EXISTS idx_maintenance_logs_tenant_id;
+DROP INDEX IF EXISTS idx_maintenance_logs_uuid;
+-- Drop RLS policy commodity_meter_reading_background_worker_access from table commodity_meter_readings
+DROP POLICY IF EXISTS commodity_meter_reading_background_worker_access ON commodity_meter_readings;
+-- Drop RLS policy commodity_meter_reading_isolation from table commodity_meter_readings
+DROP POLICY IF EXISTS commodity_meter_reading_isolation ON commodity_meter_readings;
+-- Drop RLS policy maintenance_log_background_worker_access from table maintenance_logs
+DROP POLICY IF EXISTS maintenance_log_background_worker_access ON maintenance_logs;
+-- Drop RLS policy maintenance_log_isolation from table maintenance_logs
+DROP POLICY IF EXISTS maintenance_log_isolation ON maintenance_logs;
+ALTER TABLE maintenance_schedules DROP COLUMN IF EXISTS next_due_meter;
+ALTER TABLE maintenance_schedules DROP COLUMN IF EXISTS meter_interval;
+ALTER TABLE maintenance_schedules DROP COLUMN IF EXISTS meter_unit;
+ALTER TABLE maintenance_schedules DROP COLUMN IF EXISTS calendar_rule;
+ALTER TABLE maintenance_schedules DROP COLUMN IF EXISTS recurrence;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS maintenance_logs CASCADE;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS commodity_meter_readings CASCADE;
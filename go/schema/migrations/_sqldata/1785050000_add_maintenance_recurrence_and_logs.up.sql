@@ -0,0 +1,96 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T11:20:14Z
+-- Direction: UP
+
+-- POSTGRES TABLE: commodity_meter_readings --
+CREATE TABLE commodity_meter_readings (
+  commodity_id TEXT NOT NULL,
+  unit TEXT NOT NULL,
+  value DECIMAL(14,2) NOT NULL,
+  read_at TEXT NOT NULL,
+  notes TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tenant_id TEXT NOT NULL,
+  group_id TEXT NOT NULL,
+  created_by_user_id TEXT NOT NULL,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+-- POSTGRES TABLE: maintenance_logs --
+CREATE TABLE maintenance_logs (
+  schedule_id TEXT NOT NULL,
+  commodity_id TEXT NOT NULL,
+  done_at TEXT NOT NULL,
+  meter_value DECIMAL(14,2),
+  notes TEXT,
+  cost_amount DECIMAL(14,2),
+  cost_currency TEXT,
+  file_ids JSONB,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tenant_id TEXT NOT NULL,
+  group_id TEXT NOT NULL,
+  created_by_user_id TEXT NOT NULL,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+-- ALTER statements: --
+ALTER TABLE maintenance_schedules ADD COLUMN recurrence TEXT NOT NULL DEFAULT 'interval';
+ALTER TABLE maintenance_schedules ADD COLUMN calendar_rule JSONB;
+ALTER TABLE maintenance_schedules ADD COLUMN meter_unit TEXT;
+ALTER TABLE maintenance_schedules ADD COLUMN meter_interval DECIMAL(14,2) NOT NULL DEFAULT '0';
+ALTER TABLE maintenance_schedules ADD COLUMN next_due_meter DECIMAL(14,2) NOT NULL DEFAULT '0';
+-- ALTER statements: --
+-- ON DELETE CASCADE — drop readings when their commodity is hard-deleted.
+ALTER TABLE commodity_meter_readings ADD CONSTRAINT fk_commodity_meter_reading_commodity FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE;
+-- ALTER statements: --
+ALTER TABLE commodity_meter_readings ADD CONSTRAINT fk_entity_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+-- ALTER statements: --
+ALTER TABLE commodity_meter_readings ADD CONSTRAINT fk_entity_group FOREIGN KEY (group_id) REFERENCES location_groups(id);
+-- ALTER statements: --
+ALTER TABLE commodity_meter_readings ADD CONSTRAINT fk_entity_created_by FOREIGN KEY (created_by_user_id) REFERENCES users(id);
+-- ALTER statements: --
+-- ON DELETE CASCADE is added manually: the Ptah generator does not yet
+-- emit on_delete clauses. Log rows go with their schedule and with their
+-- commodity (the schedule cascades from the commodity as well).
+ALTER TABLE maintenance_logs ADD CONSTRAINT fk_maintenance_log_schedule FOREIGN KEY (schedule_id) REFERENCES maintenance_schedules(id) ON DELETE CASCADE;
+-- ALTER statements: --
+ALTER TABLE maintenance_logs ADD CONSTRAINT fk_maintenance_log_commodity FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE;
+-- ALTER statements: --
+ALTER TABLE maintenance_logs ADD CONSTRAINT fk_entity_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+-- ALTER statements: --
+ALTER TABLE maintenance_logs ADD CONSTRAINT fk_entity_group FOREIGN KEY (group_id) REFERENCES location_groups(id);
+-- ALTER statements: --
+ALTER TABLE maintenance_logs ADD CONSTRAINT fk_entity_created_by FOREIGN KEY (created_by_user_id) REFERENCES users(id);
+-- Enable RLS for commodity_meter_readings table
+ALTER TABLE commodity_meter_readings ENABLE ROW LEVEL SECURITY;
+-- Enable RLS for maintenance_logs table
+ALTER TABLE maintenance_logs ENABLE ROW LEVEL SECURITY;
+-- Allows background workers to access all commodity meter readings for processing
+DROP POLICY IF EXISTS commodity_meter_reading_background_worker_access ON commodity_meter_readings;
+CREATE POLICY commodity_meter_reading_background_worker_access ON commodity_meter_readings FOR ALL TO inventario_background_worker
+    USING (true)
+    WITH CHECK (true);
+-- Ensures commodity meter readings can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS commodity_meter_reading_isolation ON commodity_meter_readings;
+CREATE POLICY commodity_meter_reading_isolation ON commodity_meter_readings FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+-- Allows background workers to access all maintenance logs for processing
+DROP POLICY IF EXISTS maintenance_log_background_worker_access ON maintenance_logs;
+CREATE POLICY maintenance_log_background_worker_access ON maintenance_logs FOR ALL TO inventario_background_worker
+    USING (true)
+    WITH CHECK (true);
+-- Ensures maintenance logs can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS maintenance_log_isolation ON maintenance_logs;
+CREATE POLICY maintenance_log_isolation ON maintenance_logs FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+CREATE INDEX IF NOT EXISTS idx_commodity_meter_readings_commodity ON commodity_meter_readings (commodity_id, unit, read_at);
+CREATE INDEX IF NOT EXISTS idx_commodity_meter_readings_tenant_group ON commodity_meter_readings (tenant_id, group_id);
+CREATE INDEX IF NOT EXISTS idx_commodity_meter_readings_tenant_id ON commodity_meter_readings (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_commodity_meter_readings_uuid ON commodity_meter_readings (uuid);
+CREATE INDEX IF NOT EXISTS idx_maintenance_logs_commodity ON maintenance_logs (commodity_id, done_at);
+CREATE INDEX IF NOT EXISTS idx_maintenance_logs_schedule ON maintenance_logs (schedule_id, done_at);
+CREATE INDEX IF NOT EXISTS idx_maintenance_logs_tenant_group ON maintenance_logs (tenant_id, group_id);
+CREATE INDEX IF NOT EXISTS idx_maintenance_logs_tenant_id ON maintenance_logs (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_maintenance_logs_uuid ON maintenance_logs (uuid);
@@ -0,0 +1,13 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T14:05:37Z
+-- Direction: DOWN
+
+DROP INDEX IF EXISTS idx_calendar_feeds_token_hash;
+DROP INDEX IF EXISTS idx_calendar_feeds_user_id;
+DROP INDEX IF EXISTS idx_calendar_feeds_uuid;
+-- Drop RLS policy calendar_feed_background_worker_access from table calendar_feeds
+DROP POLICY IF EXISTS calendar_feed_background_worker_access ON calendar_feeds;
+-- Drop RLS policy calendar_feed_isolation from table calendar_feeds
+DROP POLICY IF EXISTS calendar_feed_isolation ON calendar_feeds;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS calendar_feeds CASCADE;
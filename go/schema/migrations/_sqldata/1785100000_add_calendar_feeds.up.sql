@@ -0,0 +1,41 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T14:05:37Z
+-- Direction: UP
+
+-- POSTGRES TABLE: calendar_feeds --
+CREATE TABLE calendar_feeds (
+  group_id TEXT,
+  name TEXT,
+  token_hash VARCHAR(128) NOT NULL,
+  categories JSONB,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  rotated_at TIMESTAMP,
+  last_used_at TIMESTAMP,
+  tenant_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+-- ALTER statements: --
+-- ON DELETE CASCADE is added manually: the Ptah generator does not yet
+-- emit on_delete clauses. A group-scoped feed goes with its group.
+ALTER TABLE calendar_feeds ADD CONSTRAINT fk_calendar_feed_group FOREIGN KEY (group_id) REFERENCES location_groups(id) ON DELETE CASCADE;
+-- ALTER statements: --
+ALTER TABLE calendar_feeds ADD CONSTRAINT fk_entity_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+-- ALTER statements: --
+ALTER TABLE calendar_feeds ADD CONSTRAINT fk_entity_user FOREIGN KEY (user_id) REFERENCES users(id);
+-- Enable RLS for calendar_feeds table
+ALTER TABLE calendar_feeds ENABLE ROW LEVEL SECURITY;
+-- Allows background workers to resolve calendar feed tokens for unauthenticated feed requests
+DROP POLICY IF EXISTS calendar_feed_background_worker_access ON calendar_feeds;
+CREATE POLICY calendar_feed_background_worker_access ON calendar_feeds FOR ALL TO inventario_background_worker
+    USING (true)
+    WITH CHECK (true);
+-- Ensures calendar feeds can only be accessed and modified by the owning user within their tenant
+DROP POLICY IF EXISTS calendar_feed_isolation ON calendar_feeds;
+CREATE POLICY calendar_feed_isolation ON calendar_feeds FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND user_id = get_current_user_id() AND get_current_user_id() IS NOT NULL AND get_current_user_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND user_id = get_current_user_id() AND get_current_user_id() IS NOT NULL AND get_current_user_id() != '');
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token_hash ON calendar_feeds (token_hash);
+CREATE INDEX IF NOT EXISTS idx_calendar_feeds_user_id ON calendar_feeds (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_uuid ON calendar_feeds (uuid);
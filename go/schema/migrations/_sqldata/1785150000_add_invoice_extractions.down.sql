@@ -0,0 +1,14 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T15:42:07Z
+-- Direction: DOWN
+
+DROP INDEX IF EXISTS idx_invoice_extractions_file;
+DROP INDEX IF EXISTS idx_invoice_extractions_tenant_group_status;
+DROP INDEX IF EXISTS idx_invoice_extractions_tenant_id;
+DROP INDEX IF EXISTS idx_invoice_extractions_uuid;
+-- Drop RLS policy invoice_extraction_background_worker_access from table invoice_extractions
+DROP POLICY IF EXISTS invoice_extraction_background_worker_access ON invoice_extractions;
+-- Drop RLS policy invoice_extraction_isolation from table invoice_extractions
+DROP POLICY IF EXISTS invoice_extraction_isolation ON invoice_extractions;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS invoice_extractions CASCADE;
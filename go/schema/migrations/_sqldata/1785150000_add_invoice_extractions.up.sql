@@ -0,0 +1,54 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T15:42:07Z
+-- Direction: UP
+
+-- POSTGRES TABLE: invoice_extractions --
+CREATE TABLE invoice_extractions (
+  file_id TEXT NOT NULL,
+  commodity_id TEXT NOT NULL,
+  status TEXT NOT NULL,
+  vendor TEXT,
+  purchase_date TEXT,
+  price DECIMAL(15,2),
+  currency TEXT,
+  line_items JSONB,
+  changes JSONB,
+  error_code TEXT,
+  reviewed_by_user_id TEXT,
+  reviewed_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tenant_id TEXT NOT NULL,
+  group_id TEXT NOT NULL,
+  created_by_user_id TEXT NOT NULL,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+-- ALTER statements: --
+-- ON DELETE CASCADE is added manually: the Ptah generator does not yet
+-- emit on_delete clauses. An extraction goes with its file and with its
+-- commodity.
+ALTER TABLE invoice_extractions ADD CONSTRAINT fk_invoice_extraction_file FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE CASCADE;
+-- ALTER statements: --
+ALTER TABLE invoice_extractions ADD CONSTRAINT fk_invoice_extraction_commodity FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE;
+-- ALTER statements: --
+ALTER TABLE invoice_extractions ADD CONSTRAINT fk_entity_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+-- ALTER statements: --
+ALTER TABLE invoice_extractions ADD CONSTRAINT fk_entity_group FOREIGN KEY (group_id) REFERENCES location_groups(id);
+-- ALTER statements: --
+ALTER TABLE invoice_extractions ADD CONSTRAINT fk_entity_created_by FOREIGN KEY (created_by_user_id) REFERENCES users(id);
+-- Enable RLS for invoice_extractions table
+ALTER TABLE invoice_extractions ENABLE ROW LEVEL SECURITY;
+-- Allows background workers to access all invoice extractions for processing
+DROP POLICY IF EXISTS invoice_extraction_background_worker_access ON invoice_extractions;
+CREATE POLICY invoice_extraction_background_worker_access ON invoice_extractions FOR ALL TO inventario_background_worker
+    USING (true)
+    WITH CHECK (true);
+-- Ensures invoice extractions can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS invoice_extraction_isolation ON invoice_extractions;
+CREATE POLICY invoice_extraction_isolation ON invoice_extractions FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_extractions_file ON invoice_extractions (file_id);
+CREATE INDEX IF NOT EXISTS idx_invoice_extractions_tenant_group_status ON invoice_extractions (tenant_id, group_id, status);
+CREATE INDEX IF NOT EXISTS idx_invoice_extractions_tenant_id ON invoice_extractions (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_extractions_uuid ON invoice_extractions (uuid);
@@ -0,0 +1,14 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T17:05:41Z
+-- Direction: DOWN
+
+DROP INDEX IF EXISTS idx_commodity_scan_audits_tenant_group_created;
+-- Add/modify columns for table: commodity_scan_audits --
+-- ALTER statements: --
+ALTER TABLE commodity_scan_audits DROP COLUMN IF EXISTS cost_usd;
+ALTER TABLE commodity_scan_audits DROP COLUMN IF EXISTS group_id;
+
+DROP INDEX IF EXISTS idx_commodity_scan_budgets_tenant_group;
+DROP INDEX IF EXISTS idx_commodity_scan_budgets_uuid;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS commodity_scan_budgets CASCADE;
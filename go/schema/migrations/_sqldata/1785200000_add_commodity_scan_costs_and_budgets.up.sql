@@ -0,0 +1,22 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T17:05:41Z
+-- Direction: UP
+
+-- POSTGRES TABLE: commodity_scan_budgets --
+CREATE TABLE commodity_scan_budgets (
+  tenant_id TEXT NOT NULL,
+  group_id TEXT NOT NULL DEFAULT '',
+  monthly_limit_usd DECIMAL(12,2) NOT NULL,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by TEXT,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_commodity_scan_budgets_uuid ON commodity_scan_budgets (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_commodity_scan_budgets_tenant_group ON commodity_scan_budgets (tenant_id, group_id);
+
+-- Add/modify columns for table: commodity_scan_audits --
+-- ALTER statements: --
+ALTER TABLE commodity_scan_audits ADD COLUMN group_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE commodity_scan_audits ADD COLUMN cost_usd DECIMAL(12,6) NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_commodity_scan_audits_tenant_group_created ON commodity_scan_audits (tenant_id, group_id, created_at);
@@ -0,0 +1,9 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T18:12:09Z
+-- Direction: DOWN
+
+DROP INDEX IF EXISTS idx_registration_requests_tenant_status_created;
+DROP INDEX IF EXISTS idx_registration_requests_user_id;
+DROP INDEX IF EXISTS idx_registration_requests_uuid;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS registration_requests CASCADE;
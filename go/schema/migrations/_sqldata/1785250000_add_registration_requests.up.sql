@@ -0,0 +1,21 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T18:12:09Z
+-- Direction: UP
+
+-- POSTGRES TABLE: registration_requests --
+CREATE TABLE registration_requests (
+  tenant_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  email TEXT NOT NULL,
+  name TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending',
+  reason TEXT,
+  decided_by TEXT,
+  decided_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registration_requests_uuid ON registration_requests (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registration_requests_user_id ON registration_requests (user_id);
+CREATE INDEX IF NOT EXISTS idx_registration_requests_tenant_status_created ON registration_requests (tenant_id, status, created_at);
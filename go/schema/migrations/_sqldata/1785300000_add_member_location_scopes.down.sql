@@ -0,0 +1,56 @@
+-- Rollback of the location-scoped group memberships migration.
+-- Restores the tenant + group isolation policies without the scope
+-- check before dropping the helpers they referenced.
+
+DROP TRIGGER IF EXISTS locations_location_scope_write ON locations;
+DROP TRIGGER IF EXISTS areas_location_scope_write ON areas;
+DROP TRIGGER IF EXISTS commodities_location_scope_write ON commodities;
+DROP TRIGGER IF EXISTS commodity_loans_location_scope_write ON commodity_loans;
+DROP TRIGGER IF EXISTS files_location_scope_write ON files;
+DROP FUNCTION IF EXISTS enforce_location_scope_write();
+
+-- Ensures locations can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS location_isolation ON locations;
+CREATE POLICY location_isolation ON locations FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+
+-- Ensures areas can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS area_isolation ON areas;
+CREATE POLICY area_isolation ON areas FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+
+-- Ensures commodities can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS commodity_isolation ON commodities;
+CREATE POLICY commodity_isolation ON commodities FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+
+-- Ensures commodity loans can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS commodity_loan_isolation ON commodity_loans;
+CREATE POLICY commodity_loan_isolation ON commodity_loans FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+
+-- Ensures files can only be accessed and modified by their tenant and group with required contexts
+DROP POLICY IF EXISTS file_isolation ON files;
+CREATE POLICY file_isolation ON files FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '');
+
+DROP FUNCTION IF EXISTS file_location_scope_allows(TEXT, TEXT, TEXT);
+DROP FUNCTION IF EXISTS commodity_location_scope_allows(TEXT, TEXT);
+DROP FUNCTION IF EXISTS area_location_scope_allows(TEXT, TEXT);
+DROP FUNCTION IF EXISTS location_scope_allows(TEXT, TEXT);
+DROP FUNCTION IF EXISTS get_current_location_scope();
+
+DROP INDEX IF EXISTS idx_group_member_location_scopes_member_user_id;
+DROP INDEX IF EXISTS idx_group_member_location_scopes_unique;
+DROP INDEX IF EXISTS idx_group_member_location_scopes_uuid;
+-- Drop RLS policy group_member_location_scope_background_worker_access from table group_member_location_scopes
+DROP POLICY IF EXISTS group_member_location_scope_background_worker_access ON group_member_location_scopes;
+-- Drop RLS policy group_member_location_scope_tenant_isolation from table group_member_location_scopes
+DROP POLICY IF EXISTS group_member_location_scope_tenant_isolation ON group_member_location_scopes;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS group_member_location_scopes CASCADE;
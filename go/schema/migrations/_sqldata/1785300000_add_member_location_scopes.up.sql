@@ -0,0 +1,154 @@
+-- Location-scoped group memberships.
+--
+-- Adds group_member_location_scopes (one row per member per location,
+-- carrying the member's role inside that location), the RLS helpers
+-- that read the transaction-local app.current_location_scope GUC, and
+-- extends the tenant + group isolation policies of locations, areas,
+-- commodities, commodity_loans and files with a location-scope check.
+--
+-- FOR ALL policies cannot tell a DELETE apart from a SELECT, so the
+-- USING side only asks for viewer. The enforce_location_scope_write
+-- trigger closes that gap: it rejects UPDATE and DELETE of a row the
+-- member can see but may not write (and moves out of such a row) with
+-- SQLSTATE 42501, the same code a failed WITH CHECK raises. Ptah does
+-- not model triggers, so this migration is hand-written; the Go
+-- annotations in models/group_member_location_scope.go,
+-- models/group_rls_functions.go and the five isolation policies were
+-- updated in the same commit so a fresh install reaches the same state.
+
+-- POSTGRES TABLE: group_member_location_scopes --
+CREATE TABLE group_member_location_scopes (
+  group_id TEXT NOT NULL,
+  member_user_id TEXT NOT NULL,
+  location_id TEXT NOT NULL,
+  role TEXT NOT NULL DEFAULT 'viewer',
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tenant_id TEXT NOT NULL,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+-- ALTER statements: --
+ALTER TABLE group_member_location_scopes ADD CONSTRAINT fk_member_location_scope_group FOREIGN KEY (group_id) REFERENCES location_groups(id);
+-- ALTER statements: --
+ALTER TABLE group_member_location_scopes ADD CONSTRAINT fk_member_location_scope_user FOREIGN KEY (member_user_id) REFERENCES users(id);
+-- ALTER statements: --
+ALTER TABLE group_member_location_scopes ADD CONSTRAINT fk_entity_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+-- Enable RLS for group_member_location_scopes table
+ALTER TABLE group_member_location_scopes ENABLE ROW LEVEL SECURITY;
+-- Allows background workers to access all member location scopes for processing
+DROP POLICY IF EXISTS group_member_location_scope_background_worker_access ON group_member_location_scopes;
+CREATE POLICY group_member_location_scope_background_worker_access ON group_member_location_scopes FOR ALL TO inventario_background_worker
+    USING (true)
+    WITH CHECK (true);
+-- Ensures member location scopes are isolated by tenant; member-level filtering happens in application logic
+DROP POLICY IF EXISTS group_member_location_scope_tenant_isolation ON group_member_location_scopes;
+CREATE POLICY group_member_location_scope_tenant_isolation ON group_member_location_scopes FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '')
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '');
+CREATE INDEX IF NOT EXISTS idx_group_member_location_scopes_member_user_id ON group_member_location_scopes (member_user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_group_member_location_scopes_unique ON group_member_location_scopes (tenant_id, group_id, member_user_id, location_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_group_member_location_scopes_uuid ON group_member_location_scopes (uuid);
+
+
+-- Gets the current member location scope from session for RLS policies
+CREATE OR REPLACE FUNCTION get_current_location_scope() RETURNS TEXT AS $$
+BEGIN RETURN COALESCE(current_setting('app.current_location_scope', true), ''); END;
+$$
+LANGUAGE plpgsql STABLE;
+
+-- Checks that the current member location scope grants at least the given role on a location
+CREATE OR REPLACE FUNCTION location_scope_allows(location_id_param TEXT, min_role_param TEXT) RETURNS BOOLEAN AS $$
+DECLARE entry TEXT; roles TEXT[] := ARRAY['viewer','user','admin','owner']; BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; IF location_id_param IS NULL THEN RETURN FALSE; END IF; FOREACH entry IN ARRAY string_to_array(get_current_location_scope(), ',') LOOP IF split_part(entry, ':', 1) = location_id_param THEN RETURN COALESCE(array_position(roles, split_part(entry, ':', 2)) >= array_position(roles, min_role_param), FALSE); END IF; END LOOP; RETURN FALSE; END;
+$$
+LANGUAGE plpgsql STABLE;
+
+-- Checks that the current member location scope grants at least the given role on the location of an area
+CREATE OR REPLACE FUNCTION area_location_scope_allows(area_id_param TEXT, min_role_param TEXT) RETURNS BOOLEAN AS $$
+BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; RETURN location_scope_allows((SELECT location_id FROM areas WHERE id = area_id_param), min_role_param); END;
+$$
+LANGUAGE plpgsql STABLE;
+
+-- Checks that the current member location scope grants at least the given role on the location of a commodity
+CREATE OR REPLACE FUNCTION commodity_location_scope_allows(commodity_id_param TEXT, min_role_param TEXT) RETURNS BOOLEAN AS $$
+BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; RETURN area_location_scope_allows((SELECT area_id FROM commodities WHERE id = commodity_id_param), min_role_param); END;
+$$
+LANGUAGE plpgsql STABLE;
+
+-- Checks that the current member location scope grants at least the given role on the location a file is linked to
+CREATE OR REPLACE FUNCTION file_location_scope_allows(entity_type_param TEXT, entity_id_param TEXT, min_role_param TEXT) RETURNS BOOLEAN AS $$
+BEGIN IF get_current_location_scope() = '' THEN RETURN TRUE; END IF; CASE entity_type_param WHEN 'location' THEN RETURN location_scope_allows(entity_id_param, min_role_param); WHEN 'area' THEN RETURN area_location_scope_allows(entity_id_param, min_role_param); WHEN 'commodity' THEN RETURN commodity_location_scope_allows(entity_id_param, min_role_param); ELSE RETURN FALSE; END CASE; END;
+$$
+LANGUAGE plpgsql STABLE;
+
+
+-- Ensures locations can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations
+DROP POLICY IF EXISTS location_isolation ON locations;
+CREATE POLICY location_isolation ON locations FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(id, 'viewer'))
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(id, 'admin'));
+
+-- Ensures areas can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations
+DROP POLICY IF EXISTS area_isolation ON areas;
+CREATE POLICY area_isolation ON areas FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(location_id, 'viewer'))
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND location_scope_allows(location_id, 'admin'));
+
+-- Ensures commodities can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations
+DROP POLICY IF EXISTS commodity_isolation ON commodities;
+CREATE POLICY commodity_isolation ON commodities FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND area_location_scope_allows(area_id, 'viewer'))
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND area_location_scope_allows(area_id, 'user'));
+
+-- Ensures commodity loans can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations
+DROP POLICY IF EXISTS commodity_loan_isolation ON commodity_loans;
+CREATE POLICY commodity_loan_isolation ON commodity_loans FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND commodity_location_scope_allows(commodity_id, 'viewer'))
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND commodity_location_scope_allows(commodity_id, 'user'));
+
+-- Ensures files can only be accessed and modified by their tenant and group with required contexts; location-scoped members are limited to their scoped locations
+DROP POLICY IF EXISTS file_isolation ON files;
+CREATE POLICY file_isolation ON files FOR ALL TO inventario_app
+    USING (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND file_location_scope_allows(linked_entity_type, linked_entity_id, 'viewer'))
+    WITH CHECK (tenant_id = get_current_tenant_id() AND get_current_tenant_id() IS NOT NULL AND get_current_tenant_id() != '' AND group_id = get_current_group_id() AND get_current_group_id() IS NOT NULL AND get_current_group_id() != '' AND file_location_scope_allows(linked_entity_type, linked_entity_id, 'user'));
+
+-- Rejects UPDATE / DELETE of a row whose current location the member may
+-- not write. TG_ARGV[0] is the minimum role for the table.
+CREATE OR REPLACE FUNCTION enforce_location_scope_write() RETURNS TRIGGER AS $$
+DECLARE allowed BOOLEAN;
+BEGIN
+  IF get_current_location_scope() = '' THEN
+    IF TG_OP = 'DELETE' THEN RETURN OLD; END IF;
+    RETURN NEW;
+  END IF;
+  CASE TG_TABLE_NAME
+    WHEN 'locations' THEN allowed := location_scope_allows(OLD.id, TG_ARGV[0]);
+    WHEN 'areas' THEN allowed := location_scope_allows(OLD.location_id, TG_ARGV[0]);
+    WHEN 'commodities' THEN allowed := area_location_scope_allows(OLD.area_id, TG_ARGV[0]);
+    WHEN 'commodity_loans' THEN allowed := commodity_location_scope_allows(OLD.commodity_id, TG_ARGV[0]);
+    WHEN 'files' THEN allowed := file_location_scope_allows(OLD.linked_entity_type, OLD.linked_entity_id, TG_ARGV[0]);
+    ELSE allowed := FALSE;
+  END CASE;
+  IF NOT allowed THEN
+    RAISE EXCEPTION 'location scope denies % on %', TG_OP, TG_TABLE_NAME USING ERRCODE = 'insufficient_privilege';
+  END IF;
+  IF TG_OP = 'DELETE' THEN RETURN OLD; END IF;
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS locations_location_scope_write ON locations;
+CREATE TRIGGER locations_location_scope_write BEFORE UPDATE OR DELETE ON locations
+    FOR EACH ROW EXECUTE FUNCTION enforce_location_scope_write('admin');
+DROP TRIGGER IF EXISTS areas_location_scope_write ON areas;
+CREATE TRIGGER areas_location_scope_write BEFORE UPDATE OR DELETE ON areas
+    FOR EACH ROW EXECUTE FUNCTION enforce_location_scope_write('admin');
+DROP TRIGGER IF EXISTS commodities_location_scope_write ON commodities;
+CREATE TRIGGER commodities_location_scope_write BEFORE UPDATE OR DELETE ON commodities
+    FOR EACH ROW EXECUTE FUNCTION enforce_location_scope_write('user');
+DROP TRIGGER IF EXISTS commodity_loans_location_scope_write ON commodity_loans;
+CREATE TRIGGER commodity_loans_location_scope_write BEFORE UPDATE OR DELETE ON commodity_loans
+    FOR EACH ROW EXECUTE FUNCTION enforce_location_scope_write('user');
+DROP TRIGGER IF EXISTS files_location_scope_write ON files;
+CREATE TRIGGER files_location_scope_write BEFORE UPDATE OR DELETE ON files
+    FOR EACH ROW EXECUTE FUNCTION enforce_location_scope_write('user');
@@ -0,0 +1,18 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T14:05:31Z
+-- Direction: DOWN
+
+-- Remove columns from table: thumbnail_generation_jobs --
+-- ALTER statements: --
+ALTER TABLE thumbnail_generation_jobs DROP COLUMN trace_parent CASCADE;
+-- WARNING: Dropping column thumbnail_generation_jobs.trace_parent with CASCADE - This will delete data and dependent objects! --;
+
+-- Remove columns from table: restore_operations --
+-- ALTER statements: --
+ALTER TABLE restore_operations DROP COLUMN trace_parent CASCADE;
+-- WARNING: Dropping column restore_operations.trace_parent with CASCADE - This will delete data and dependent objects! --;
+
+-- Remove columns from table: exports --
+-- ALTER statements: --
+ALTER TABLE exports DROP COLUMN trace_parent CASCADE;
+-- WARNING: Dropping column exports.trace_parent with CASCADE - This will delete data and dependent objects! --;
\ No newline at end of file
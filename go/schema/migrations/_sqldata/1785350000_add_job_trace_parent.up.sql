@@ -0,0 +1,15 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T14:05:31Z
+-- Direction: UP
+
+-- Add/modify columns for table: exports --
+-- ALTER statements: --
+ALTER TABLE exports ADD COLUMN trace_parent TEXT;
+
+-- Add/modify columns for table: restore_operations --
+-- ALTER statements: --
+ALTER TABLE restore_operations ADD COLUMN trace_parent TEXT;
+
+-- Add/modify columns for table: thumbnail_generation_jobs --
+-- ALTER statements: --
+ALTER TABLE thumbnail_generation_jobs ADD COLUMN trace_parent TEXT;
\ No newline at end of file
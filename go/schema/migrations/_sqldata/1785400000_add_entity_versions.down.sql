@@ -0,0 +1,23 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T16:22:08Z
+-- Direction: DOWN
+
+-- Remove columns from table: locations --
+-- ALTER statements: --
+ALTER TABLE locations DROP COLUMN version CASCADE;
+-- WARNING: Dropping column locations.version with CASCADE - This will delete data and dependent objects! --;
+
+-- Remove columns from table: files --
+-- ALTER statements: --
+ALTER TABLE files DROP COLUMN version CASCADE;
+-- WARNING: Dropping column files.version with CASCADE - This will delete data and dependent objects! --;
+
+-- Remove columns from table: commodities --
+-- ALTER statements: --
+ALTER TABLE commodities DROP COLUMN version CASCADE;
+-- WARNING: Dropping column commodities.version with CASCADE - This will delete data and dependent objects! --;
+
+-- Remove columns from table: areas --
+-- ALTER statements: --
+ALTER TABLE areas DROP COLUMN version CASCADE;
+-- WARNING: Dropping column areas.version with CASCADE - This will delete data and dependent objects! --;
\ No newline at end of file
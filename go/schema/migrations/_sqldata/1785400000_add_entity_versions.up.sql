@@ -0,0 +1,19 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T16:22:08Z
+-- Direction: UP
+
+-- Add/modify columns for table: areas --
+-- ALTER statements: --
+ALTER TABLE areas ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Add/modify columns for table: commodities --
+-- ALTER statements: --
+ALTER TABLE commodities ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Add/modify columns for table: files --
+-- ALTER statements: --
+ALTER TABLE files ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Add/modify columns for table: locations --
+-- ALTER statements: --
+ALTER TABLE locations ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
\ No newline at end of file
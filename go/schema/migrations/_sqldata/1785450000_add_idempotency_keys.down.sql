@@ -0,0 +1,10 @@
+-- Migration rollback
+-- Generated on: 2026-10-18T21:40:12Z
+-- Direction: DOWN
+
+DROP INDEX IF EXISTS idx_idempotency_keys_tenant_id;
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+DROP INDEX IF EXISTS idx_idempotency_keys_user_key;
+DROP INDEX IF EXISTS idx_idempotency_keys_uuid;
+-- WARNING: This will delete all data!
+DROP TABLE IF EXISTS idempotency_keys CASCADE;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-18T21:40:12Z
+-- Direction: UP
+
+-- POSTGRES TABLE: idempotency_keys --
+CREATE TABLE idempotency_keys (
+  tenant_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  idempotency_key TEXT NOT NULL,
+  request_hash TEXT NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  content_type TEXT NOT NULL DEFAULT '',
+  response_body BYTEA,
+  expires_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  id TEXT PRIMARY KEY NOT NULL,
+  uuid TEXT NOT NULL DEFAULT (gen_random_uuid())::text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_uuid ON idempotency_keys (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_user_key ON idempotency_keys (user_id, idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_tenant_id ON idempotency_keys (tenant_id);
\ No newline at end of file
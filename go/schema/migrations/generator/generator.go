@@ -10,6 +10,8 @@ import (
 	"github.com/stokaro/ptah/core/goschema"
 	"github.com/stokaro/ptah/dbschema"
 	"github.com/stokaro/ptah/migration/generator"
+
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
 )
 
 type Generator struct {
@@ -42,6 +44,10 @@ func (m *Generator) GenerateMigrationFiles(ctx context.Context, migrationName, m
 		return nil, errxtrace.Wrap("failed to create output directory", err)
 	}
 
+	if sqlite.IsDSN(m.dbURL) {
+		return m.generateSQLiteMigrationFiles(ctx, migrationName, migrationsDir)
+	}
+
 	// Connect to database first
 	conn, err := dbschema.ConnectToDatabase(ctx, m.dbURL)
 	if err != nil {
@@ -122,6 +128,10 @@ func (m *Generator) GenerateSchemaSQL(ctx context.Context) ([]string, error) {
 		"extensions", len(goSchema.Extensions),
 	)
 
+	if sqlite.IsDSN(m.dbURL) {
+		return sqlite.RenderSchema(goSchema), nil
+	}
+
 	// For now, return a simple message indicating the schema was parsed
 	// The actual SQL generation is handled by Ptah's migration generator
 	m.logger.Info("Use migration generation for SQL output")
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/stokaro/ptah/core/goschema"
+	"github.com/stokaro/ptah/migration/generator"
+	"github.com/stokaro/ptah/migration/migrator"
+
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
+)
+
+// The SQLite flavour of the generator. ptah has no SQLite dialect, so the
+// schema diff comes from the sqlite package; the files are named and laid
+// out the way ptah writes the PostgreSQL ones.
+
+func (m *Generator) generateSQLiteMigrationFiles(ctx context.Context, migrationName, migrationsDir string) (*generator.MigrationFiles, error) {
+	db, err := sqlite.Open(m.dbURL)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to connect to database", err)
+	}
+	defer func() {
+		err := db.Close()
+		if err != nil {
+			m.logger.Error("Failed to close database connection", "error", err)
+		}
+	}()
+
+	goSchema, err := goschema.ParseDir(m.goEntitiesDir)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to parse Go schema", err)
+	}
+
+	diff, err := sqlite.Diff(ctx, db, goSchema)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to compare schemas", err)
+	}
+	if !diff.HasChanges() {
+		m.logger.Info("No schema changes detected - no migration files generated")
+		return nil, nil
+	}
+
+	files, err := writeMigrationFiles(migrationsDir, migrationName, diff)
+	if err != nil {
+		return nil, err
+	}
+
+	m.logger.Info("Migration files generated", "up_file", files.UpFile, "down_file", files.DownFile, "version", files.Version)
+
+	return files, nil
+}
+
+// writeMigrationFiles writes diff as an up/down pair, bumping the version
+// past any pair that already exists, as ptah does.
+func writeMigrationFiles(migrationsDir, migrationName string, diff *sqlite.SchemaDiff) (*generator.MigrationFiles, error) {
+	version := migrator.GetNextMigrationVersion()
+	var upPath, downPath string
+	for {
+		upPath = filepath.Join(migrationsDir, migrator.GenerateMigrationFileName(version, migrationName, "up"))
+		downPath = filepath.Join(migrationsDir, migrator.GenerateMigrationFileName(version, migrationName, "down"))
+		if _, err := os.Stat(upPath); err != nil {
+			break
+		}
+		version++
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	upSQL := fmt.Sprintf("-- Migration generated from schema differences\n-- Generated on: %s\n-- Direction: UP\n\n", now) +
+		strings.Join(diff.Up, ";\n") + ";"
+	downSQL := fmt.Sprintf("-- Migration rollback\n-- Generated on: %s\n-- Direction: DOWN\n\n", now) +
+		strings.Join(diff.Down, ";\n") + ";"
+
+	if err := os.WriteFile(upPath, []byte(upSQL), 0o644); err != nil { //nolint:gosec // migrations are checked in
+		return nil, errxtrace.Wrap("failed to write up migration file", err)
+	}
+	if err := os.WriteFile(downPath, []byte(downSQL), 0o644); err != nil { //nolint:gosec // migrations are checked in
+		return nil, errxtrace.Wrap("failed to write down migration file", err)
+	}
+
+	return &generator.MigrationFiles{
+		UpFile:   upPath,
+		DownFile: downPath,
+		Version:  version,
+	}, nil
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/denisvmedia/inventario/schema/dsnutil"
 	"github.com/denisvmedia/inventario/schema/migrations"
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
 )
 
 // ErrSchemaLagsBinary is returned by VerifySchemaUpToDate when the binary
@@ -54,7 +55,11 @@ func New(dbURL string, migFS fs.FS) *Migrator {
 
 func NewWithFallback(dbURL, fallbackDir string) *Migrator {
 	var migFS fs.FS
-	if migrations.HasEmbeddedMigrations() {
+	if sqlite.IsDSN(dbURL) {
+		// SQLite migrations live in their own embedded directory; the
+		// fallback directory only ever holds PostgreSQL migrations.
+		migFS = must.Must(sqlite.EmbeddedMigrationsFS())
+	} else if migrations.HasEmbeddedMigrations() {
 		migFS = must.Must(migrations.EmbeddedMigrationsFS())
 	} else {
 		migFS = migrations.MigrationsFS(fallbackDir)
@@ -76,6 +81,10 @@ func (m *Migrator) MigrateUp(ctx context.Context, args Args) error {
 		m.logger.Info("Dry run mode enabled - no actual changes will be made")
 	}
 
+	if sqlite.IsDSN(m.dbURL) {
+		return m.migrateUpSQLite(ctx, args)
+	}
+
 	// Connect to database using standard ptah approach
 	// When using a shared pool, we still create a separate connection for migrations
 	// but the pool limits will prevent connection exhaustion
@@ -126,6 +135,10 @@ func (m *Migrator) MigrateUp(ctx context.Context, args Args) error {
 // docker-compose migrate container can't quietly leave the app running
 // against a half-migrated schema (#1655).
 func (m *Migrator) VerifySchemaUpToDate(ctx context.Context) error {
+	if sqlite.IsDSN(m.dbURL) {
+		return m.verifySQLite(ctx)
+	}
+
 	conn, err := dbschema.ConnectToDatabase(ctx, m.dbURL)
 	if err != nil {
 		return errxtrace.Wrap("failed to connect to database", err)
@@ -241,6 +254,10 @@ func (m *Migrator) DropTables(ctx context.Context, dryRun bool, confirm bool) er
 		fmt.Println()
 	}
 
+	if sqlite.IsDSN(m.dbURL) {
+		return m.dropTablesSQLite(ctx, dryRun, confirm)
+	}
+
 	// Create direct database connection for drop operations
 	db, err := sql.Open("postgres", m.dbURL)
 	if err != nil {
@@ -310,6 +327,10 @@ func (m *Migrator) DropDatabase(ctx context.Context, dryRun bool, confirm bool)
 		fmt.Println()
 	}
 
+	if sqlite.IsDSN(m.dbURL) {
+		return m.dropDatabaseSQLite(dryRun, confirm)
+	}
+
 	// Parse the database URL to extract database name and connection info
 	dbName, adminDSN, err := m.parsePostgreSQLDSN()
 	if err != nil {
@@ -382,6 +403,10 @@ func (m *Migrator) DropDatabase(ctx context.Context, dryRun bool, confirm bool)
 
 // PrintMigrationStatus prints detailed migration status information
 func (m *Migrator) PrintMigrationStatus(ctx context.Context, verbose bool) error { //revive:disable:flag-parameter
+	if sqlite.IsDSN(m.dbURL) {
+		return m.printMigrationStatusSQLite(ctx, verbose)
+	}
+
 	fmt.Println("=== MIGRATION STATUS ===")
 	fmt.Printf("Database: %s\n", dbschema.FormatDatabaseURL(m.dbURL))
 	fmt.Println("Schema source: File system")
@@ -2,6 +2,8 @@ package migrator_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -99,3 +101,34 @@ func TestMigrator_DropDatabase_InvalidScheme(t *testing.T) {
 	c.Assert(err, qt.IsNotNil)
 	c.Assert(err.Error(), qt.Contains, "unsupported database scheme")
 }
+
+func TestMigrator_SQLite(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	path := filepath.Join(c.TempDir(), "inventario.db")
+	m := migrator.NewWithFallback("sqlite://"+path, "")
+
+	// A fresh database lags the embedded migrations.
+	err := m.VerifySchemaUpToDate(ctx)
+	c.Assert(err, qt.ErrorIs, migrator.ErrSchemaLagsBinary)
+
+	err = m.MigrateUp(ctx, migrator.Args{})
+	c.Assert(err, qt.IsNil)
+	err = m.VerifySchemaUpToDate(ctx)
+	c.Assert(err, qt.IsNil)
+
+	// Migrating again is a no-op.
+	err = m.MigrateUp(ctx, migrator.Args{})
+	c.Assert(err, qt.IsNil)
+
+	err = m.ResetDatabase(ctx, migrator.Args{}, true)
+	c.Assert(err, qt.IsNil)
+	err = m.VerifySchemaUpToDate(ctx)
+	c.Assert(err, qt.IsNil)
+
+	err = m.DropDatabase(ctx, false, true)
+	c.Assert(err, qt.IsNil)
+	_, err = os.Stat(path)
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+}
@@ -0,0 +1,237 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"slices"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/schema/migrations"
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
+)
+
+// The SQLite flavour of the migrator. ptah has no SQLite dialect, so these
+// methods run the generated migrations from the sqlite package and keep the
+// same schema_migrations bookkeeping the PostgreSQL path has.
+
+func (m *Migrator) openSQLite() (*sql.DB, error) {
+	db, err := sqlite.Open(m.dbURL)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to connect to database", err)
+	}
+	return db, nil
+}
+
+func (m *Migrator) migrateUpSQLite(ctx context.Context, args Args) error {
+	db, err := m.openSQLite()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if args.DryRun {
+		pending, err := m.pendingSQLite(ctx, db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Would apply %d migration(s)\n", len(pending))
+		for _, mig := range pending {
+			fmt.Printf("  - %d_%s\n", mig.Version, mig.Description)
+		}
+		m.logger.Info("Dry run completed successfully")
+		return nil
+	}
+
+	applied, err := sqlite.MigrateUp(ctx, db, m.migFS)
+	if err != nil {
+		return errxtrace.Wrap("failed to run migrations", err)
+	}
+	for _, mig := range applied {
+		fmt.Printf("Applied migration %d_%s\n", mig.Version, mig.Description)
+	}
+
+	if err := m.verifySQLiteDB(ctx, db); err != nil {
+		return errxtrace.Wrap("post-migrate schema verification failed", err)
+	}
+
+	m.logger.Info("Migrations completed successfully")
+	return nil
+}
+
+func (m *Migrator) verifySQLite(ctx context.Context) error {
+	db, err := m.openSQLite()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return m.verifySQLiteDB(ctx, db)
+}
+
+func (m *Migrator) verifySQLiteDB(ctx context.Context, db *sql.DB) error {
+	embedMax, err := migrations.MaxVersion(m.migFS)
+	if err != nil {
+		return errxtrace.Wrap("failed to inspect embedded migrations", err)
+	}
+	if embedMax == 0 {
+		return nil
+	}
+
+	dbVersion, err := sqlite.CurrentVersion(ctx, db)
+	if err != nil {
+		return errxtrace.Wrap("failed to read schema_migrations.version", err)
+	}
+
+	return compareSchemaVersion(m.logger, dbVersion, embedMax)
+}
+
+func (m *Migrator) pendingSQLite(ctx context.Context, db *sql.DB) ([]sqlite.Migration, error) {
+	all, err := sqlite.LoadMigrations(m.migFS)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := sqlite.AppliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []sqlite.Migration
+	for _, mig := range all {
+		if !slices.Contains(applied, mig.Version) {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+//revive:disable-next-line:flag-parameter CLI flags are appropriate
+func (m *Migrator) dropTablesSQLite(ctx context.Context, dryRun bool, confirm bool) error {
+	db, err := m.openSQLite()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tables, err := sqlite.Tables(ctx, db)
+	if err != nil {
+		return errxtrace.Wrap("failed to get table list", err)
+	}
+	if len(tables) == 0 {
+		fmt.Println("No tables found in database.")
+		return nil
+	}
+
+	fmt.Printf("Found %d tables to drop:\n", len(tables))
+	for _, table := range tables {
+		fmt.Printf("  - %s\n", table)
+	}
+	fmt.Println()
+
+	if !confirm && !dryRun && !askConfirmation("⚠️  WARNING: This will DELETE ALL DATA and SCHEMA in the database!\n") {
+		return nil
+	}
+
+	if dryRun {
+		fmt.Println("Would drop all tables and their data...")
+		fmt.Println("✅ Dry run completed successfully!")
+		return nil
+	}
+
+	fmt.Println("Dropping all tables...")
+	if _, err := sqlite.DropAll(ctx, db); err != nil {
+		return errxtrace.Wrap("failed to drop tables", err)
+	}
+
+	fmt.Println("✅ All tables dropped successfully!")
+	return nil
+}
+
+// dropDatabaseSQLite removes the database file together with the WAL and
+// shared-memory files SQLite keeps next to it.
+//
+//revive:disable-next-line:flag-parameter CLI flags are appropriate
+func (m *Migrator) dropDatabaseSQLite(dryRun bool, confirm bool) error {
+	path, err := sqlite.DatabasePath(m.dbURL)
+	if err != nil {
+		return errxtrace.Wrap("failed to parse database DSN", err)
+	}
+
+	fmt.Printf("Target database file: %s\n", path)
+	fmt.Println()
+
+	if !confirm && !dryRun && !askConfirmation("⚠️  WARNING: This will COMPLETELY DELETE the entire database and ALL its data!\n"+
+		"This operation cannot be undone!\n") {
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("Would delete database file: %s\n", path)
+		fmt.Println("✅ Dry run completed successfully!")
+		return nil
+	}
+
+	for _, file := range []string{path, path + "-wal", path + "-shm"} {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return errxtrace.Wrap("failed to delete database file", err)
+		}
+	}
+
+	fmt.Println("✅ Database dropped successfully!")
+	return nil
+}
+
+func (m *Migrator) printMigrationStatusSQLite(ctx context.Context, verbose bool) error {
+	fmt.Println("=== MIGRATION STATUS ===")
+	fmt.Printf("Database: %s\n", m.dbURL)
+	fmt.Println("Schema source: Embedded SQLite migrations")
+	fmt.Println()
+
+	db, err := m.openSQLite()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	current, err := sqlite.CurrentVersion(ctx, db)
+	if err != nil {
+		return errxtrace.Wrap("failed to read schema_migrations.version", err)
+	}
+	pending, err := m.pendingSQLite(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Current version: %d\n", current)
+	if len(pending) == 0 {
+		fmt.Println("Status: ✅ Up to date")
+	} else {
+		fmt.Printf("Status: ⚠️  %d pending migration(s)\n", len(pending))
+	}
+
+	if verbose && len(pending) > 0 {
+		fmt.Println("\nPending migrations:")
+		for _, mig := range pending {
+			fmt.Printf("  - %d_%s\n", mig.Version, mig.Description)
+		}
+	}
+
+	return nil
+}
+
+func askConfirmation(warning string) bool {
+	fmt.Print(warning)
+	fmt.Print("Are you sure you want to continue? (type 'yes' to confirm): ")
+
+	var response string
+	fmt.Scanln(&response)
+
+	if response != "yes" {
+		fmt.Println("Operation cancelled.")
+		return false
+	}
+	fmt.Println()
+	return true
+}
@@ -0,0 +1,55 @@
+-- Migration rollback
+-- Generated on: 2026-10-19T00:24:15Z
+-- Direction: DOWN
+
+DROP TABLE IF EXISTS worker_control;
+DROP TABLE IF EXISTS warranty_reminders;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS user_oauth_identities;
+DROP TABLE IF EXISTS user_mfa_secrets;
+DROP TABLE IF EXISTS user_concurrency_slots;
+DROP TABLE IF EXISTS trusted_backup_keys;
+DROP TABLE IF EXISTS thumbnail_generation_jobs;
+DROP TABLE IF EXISTS tenants;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS system_admin_grants;
+DROP TABLE IF EXISTS storage_quota_reminders;
+DROP TABLE IF EXISTS settings;
+DROP TABLE IF EXISTS saved_views;
+DROP TABLE IF EXISTS restore_steps;
+DROP TABLE IF EXISTS restore_operations;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS password_resets;
+DROP TABLE IF EXISTS operation_slots;
+DROP TABLE IF EXISTS maintenance_schedules;
+DROP TABLE IF EXISTS maintenance_reminders;
+DROP TABLE IF EXISTS maintenance_logs;
+DROP TABLE IF EXISTS magic_link_tokens;
+DROP TABLE IF EXISTS login_events;
+DROP TABLE IF EXISTS locations;
+DROP TABLE IF EXISTS location_groups;
+DROP TABLE IF EXISTS invoice_extractions;
+DROP TABLE IF EXISTS group_notification_prefs;
+DROP TABLE IF EXISTS group_memberships;
+DROP TABLE IF EXISTS group_invites_audit;
+DROP TABLE IF EXISTS group_invites;
+DROP TABLE IF EXISTS files;
+DROP TABLE IF EXISTS exports;
+DROP TABLE IF EXISTS email_verifications;
+DROP TABLE IF EXISTS currency_migrations;
+DROP TABLE IF EXISTS currency_migration_audit_rows;
+DROP TABLE IF EXISTS commodity_supply_links;
+DROP TABLE IF EXISTS commodity_services;
+DROP TABLE IF EXISTS commodity_scan_budgets;
+DROP TABLE IF EXISTS commodity_scan_audits;
+DROP TABLE IF EXISTS commodity_meter_readings;
+DROP TABLE IF EXISTS commodity_loans;
+DROP TABLE IF EXISTS commodity_events;
+DROP TABLE IF EXISTS commodities;
+DROP TABLE IF EXISTS calendar_feeds;
+DROP TABLE IF EXISTS backup_schedules;
+DROP TABLE IF EXISTS backoffice_users;
+DROP TABLE IF EXISTS backoffice_user_mfa_secrets;
+DROP TABLE IF EXISTS backoffice_refresh_tokens;
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS areas;
\ No newline at end of file
@@ -0,0 +1,1168 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-19T00:24:15Z
+-- Direction: UP
+
+-- SQLITE TABLE: areas --
+CREATE TABLE areas (
+    name TEXT NOT NULL,
+    location_id TEXT NOT NULL,
+    icon TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_areas_uuid ON areas (uuid);
+CREATE INDEX idx_areas_tenant_id ON areas (tenant_id);
+CREATE INDEX idx_areas_tenant_location ON areas (tenant_id, location_id);
+CREATE INDEX idx_areas_tenant_group ON areas (tenant_id, group_id);
+-- SQLITE TABLE: audit_logs --
+CREATE TABLE audit_logs (
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    timestamp TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    user_id TEXT,
+    tenant_id TEXT,
+    action TEXT NOT NULL,
+    entity_type TEXT,
+    entity_id TEXT,
+    ip_address TEXT,
+    user_agent TEXT,
+    success BOOLEAN NOT NULL DEFAULT TRUE,
+    error_message TEXT,
+    impersonated_by TEXT
+);
+CREATE UNIQUE INDEX idx_audit_logs_uuid ON audit_logs (uuid);
+CREATE INDEX audit_logs_user_id_idx ON audit_logs (user_id);
+CREATE INDEX audit_logs_tenant_id_idx ON audit_logs (tenant_id);
+CREATE INDEX audit_logs_timestamp_idx ON audit_logs (timestamp);
+CREATE INDEX audit_logs_action_idx ON audit_logs (action);
+CREATE INDEX audit_logs_entity_idx ON audit_logs (entity_type, entity_id);
+-- SQLITE TABLE: backoffice_refresh_tokens --
+CREATE TABLE backoffice_refresh_tokens (
+    backoffice_user_id TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    last_used_at TIMESTAMP,
+    ip_address TEXT,
+    user_agent TEXT,
+    revoked_at TIMESTAMP,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (backoffice_user_id) REFERENCES backoffice_users(id)
+);
+CREATE UNIQUE INDEX idx_backoffice_refresh_tokens_uuid ON backoffice_refresh_tokens (uuid);
+CREATE INDEX idx_backoffice_refresh_tokens_user_id ON backoffice_refresh_tokens (backoffice_user_id);
+CREATE UNIQUE INDEX idx_backoffice_refresh_tokens_token_hash ON backoffice_refresh_tokens (token_hash);
+CREATE INDEX idx_backoffice_refresh_tokens_expires_at ON backoffice_refresh_tokens (expires_at);
+-- SQLITE TABLE: backoffice_user_mfa_secrets --
+CREATE TABLE backoffice_user_mfa_secrets (
+    backoffice_user_id TEXT NOT NULL,
+    secret_encrypted TEXT NOT NULL,
+    enabled_at TIMESTAMP,
+    backup_codes_hashed BLOB NOT NULL DEFAULT (CAST('[]' AS BLOB)),
+    last_used_at TIMESTAMP,
+    last_used_step INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (backoffice_user_id) REFERENCES backoffice_users(id)
+);
+CREATE UNIQUE INDEX idx_backoffice_user_mfa_secrets_uuid ON backoffice_user_mfa_secrets (uuid);
+CREATE UNIQUE INDEX idx_backoffice_user_mfa_secrets_user ON backoffice_user_mfa_secrets (backoffice_user_id);
+-- SQLITE TABLE: backoffice_users --
+CREATE TABLE backoffice_users (
+    email TEXT NOT NULL,
+    name TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    role TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    mfa_enforced BOOLEAN NOT NULL DEFAULT TRUE,
+    last_login_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))
+);
+CREATE UNIQUE INDEX idx_backoffice_users_uuid ON backoffice_users (uuid);
+CREATE UNIQUE INDEX idx_backoffice_users_email ON backoffice_users (email);
+CREATE INDEX idx_backoffice_users_active ON backoffice_users (is_active);
+-- SQLITE TABLE: backup_schedules --
+CREATE TABLE backup_schedules (
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    frequency TEXT NOT NULL,
+    time_of_day TEXT NOT NULL DEFAULT '03:00',
+    weekday INTEGER NOT NULL DEFAULT 0,
+    day_of_month INTEGER NOT NULL DEFAULT 1,
+    include_file_data BOOLEAN NOT NULL DEFAULT FALSE,
+    keep_daily INTEGER NOT NULL DEFAULT 7,
+    keep_weekly INTEGER NOT NULL DEFAULT 4,
+    keep_monthly INTEGER NOT NULL DEFAULT 6,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    last_export_id TEXT,
+    settled_export_id TEXT,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_backup_schedules_uuid ON backup_schedules (uuid);
+CREATE UNIQUE INDEX idx_backup_schedules_group ON backup_schedules (group_id);
+CREATE INDEX idx_backup_schedules_tenant_id ON backup_schedules (tenant_id);
+CREATE INDEX idx_backup_schedules_next_run ON backup_schedules (next_run_at);
+-- SQLITE TABLE: calendar_feeds --
+CREATE TABLE calendar_feeds (
+    group_id TEXT,
+    name TEXT,
+    token_hash TEXT NOT NULL,
+    categories BLOB,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    rotated_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_calendar_feeds_uuid ON calendar_feeds (uuid);
+CREATE INDEX idx_calendar_feeds_user_id ON calendar_feeds (user_id);
+CREATE UNIQUE INDEX idx_calendar_feeds_token_hash ON calendar_feeds (token_hash);
+-- SQLITE TABLE: commodities --
+CREATE TABLE commodities (
+    name TEXT NOT NULL,
+    short_name TEXT,
+    type TEXT NOT NULL,
+    area_id TEXT,
+    count INTEGER NOT NULL DEFAULT 1,
+    original_price NUMERIC,
+    original_price_currency TEXT,
+    converted_original_price NUMERIC,
+    current_price NUMERIC,
+    serial_number TEXT,
+    extra_serial_numbers BLOB,
+    part_numbers BLOB,
+    tags BLOB,
+    status TEXT NOT NULL,
+    purchase_date TEXT,
+    registered_date TEXT,
+    last_modified_date TEXT,
+    urls BLOB,
+    comments TEXT,
+    draft BOOLEAN NOT NULL DEFAULT FALSE,
+    cover_file_id TEXT,
+    warranty_expires_at TEXT,
+    warranty_notes TEXT,
+    acquisition_price NUMERIC,
+    acquisition_currency TEXT,
+    status_date TEXT,
+    status_note TEXT,
+    sale_price NUMERIC,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (area_id) REFERENCES areas(id),
+    FOREIGN KEY (cover_file_id) REFERENCES files(id) ON DELETE SET NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_commodities_uuid ON commodities (uuid);
+CREATE INDEX idx_commodities_tenant_id ON commodities (tenant_id);
+CREATE INDEX idx_commodities_tenant_area ON commodities (tenant_id, area_id);
+CREATE INDEX idx_commodities_tenant_status ON commodities (tenant_id, status);
+CREATE INDEX idx_commodities_tenant_group ON commodities (tenant_id, group_id);
+CREATE INDEX commodities_active_idx ON commodities (status, area_id) WHERE draft = false;
+CREATE INDEX commodities_draft_idx ON commodities (last_modified_date) WHERE draft = true;
+CREATE INDEX commodities_warranty_expires_at_idx ON commodities (warranty_expires_at) WHERE warranty_expires_at IS NOT NULL;
+-- SQLITE TABLE: commodity_events --
+CREATE TABLE commodity_events (
+    commodity_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    before BLOB,
+    after BLOB,
+    note TEXT,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_commodity_events_uuid ON commodity_events (uuid);
+CREATE INDEX commodity_events_lookup ON commodity_events (group_id, commodity_id, occurred_at);
+CREATE INDEX idx_commodity_events_tenant_id ON commodity_events (tenant_id);
+CREATE INDEX idx_commodity_events_tenant_group ON commodity_events (tenant_id, group_id);
+CREATE INDEX commodity_events_kind_idx ON commodity_events (commodity_id, kind);
+-- SQLITE TABLE: commodity_loans --
+CREATE TABLE commodity_loans (
+    commodity_id TEXT NOT NULL,
+    borrower_name TEXT NOT NULL,
+    borrower_contact TEXT,
+    borrower_email TEXT,
+    borrower_note TEXT,
+    lent_at TEXT NOT NULL,
+    due_back_at TEXT,
+    returned_at TEXT,
+    reminder_sent_overdue BOOLEAN NOT NULL DEFAULT FALSE,
+    reminder_sent_due_soon BOOLEAN NOT NULL DEFAULT FALSE,
+    borrower_reminder_sent_overdue BOOLEAN NOT NULL DEFAULT FALSE,
+    borrower_reminder_sent_due_soon BOOLEAN NOT NULL DEFAULT FALSE,
+    pending_request_kind TEXT,
+    pending_request_due_back_at TEXT,
+    pending_request_note TEXT,
+    pending_request_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_commodity_loans_uuid ON commodity_loans (uuid);
+CREATE INDEX idx_commodity_loans_tenant_id ON commodity_loans (tenant_id);
+CREATE INDEX idx_commodity_loans_tenant_group ON commodity_loans (tenant_id, group_id);
+CREATE INDEX idx_commodity_loans_commodity ON commodity_loans (commodity_id, lent_at);
+CREATE INDEX idx_commodity_loans_active ON commodity_loans (group_id, due_back_at) WHERE returned_at IS NULL;
+CREATE INDEX idx_commodity_loans_due ON commodity_loans (due_back_at) WHERE returned_at IS NULL AND due_back_at IS NOT NULL;
+-- SQLITE TABLE: commodity_meter_readings --
+CREATE TABLE commodity_meter_readings (
+    commodity_id TEXT NOT NULL,
+    unit TEXT NOT NULL,
+    value NUMERIC NOT NULL,
+    read_at TEXT NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_commodity_meter_readings_uuid ON commodity_meter_readings (uuid);
+CREATE INDEX idx_commodity_meter_readings_tenant_id ON commodity_meter_readings (tenant_id);
+CREATE INDEX idx_commodity_meter_readings_tenant_group ON commodity_meter_readings (tenant_id, group_id);
+CREATE INDEX idx_commodity_meter_readings_commodity ON commodity_meter_readings (commodity_id, unit, read_at);
+-- SQLITE TABLE: commodity_scan_audits --
+CREATE TABLE commodity_scan_audits (
+    group_id TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    photo_count INTEGER NOT NULL,
+    total_photo_bytes INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    error_code TEXT,
+    latency_ms INTEGER NOT NULL,
+    tokens_used INTEGER NOT NULL DEFAULT 0,
+    cost_usd NUMERIC NOT NULL DEFAULT 0,
+    result_json BLOB,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_commodity_scan_audits_uuid ON commodity_scan_audits (uuid);
+CREATE INDEX idx_commodity_scan_audits_user_created ON commodity_scan_audits (user_id, created_at);
+CREATE INDEX idx_commodity_scan_audits_tenant_created ON commodity_scan_audits (tenant_id, created_at);
+CREATE INDEX idx_commodity_scan_audits_tenant_group_created ON commodity_scan_audits (tenant_id, group_id, created_at);
+-- SQLITE TABLE: commodity_scan_budgets --
+CREATE TABLE commodity_scan_budgets (
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    monthly_limit_usd NUMERIC NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_by TEXT,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))
+);
+CREATE UNIQUE INDEX idx_commodity_scan_budgets_uuid ON commodity_scan_budgets (uuid);
+CREATE UNIQUE INDEX idx_commodity_scan_budgets_tenant_group ON commodity_scan_budgets (tenant_id, group_id);
+-- SQLITE TABLE: commodity_services --
+CREATE TABLE commodity_services (
+    commodity_id TEXT NOT NULL,
+    provider_name TEXT NOT NULL,
+    provider_contact TEXT,
+    reason TEXT,
+    sent_at TEXT NOT NULL,
+    expected_return_at TEXT,
+    returned_at TEXT,
+    cost_amount NUMERIC,
+    cost_currency TEXT,
+    reminder_sent_overdue BOOLEAN NOT NULL DEFAULT FALSE,
+    reminder_sent_due_soon BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_commodity_services_uuid ON commodity_services (uuid);
+CREATE INDEX idx_commodity_services_tenant_id ON commodity_services (tenant_id);
+CREATE INDEX idx_commodity_services_tenant_group ON commodity_services (tenant_id, group_id);
+CREATE INDEX idx_commodity_services_commodity ON commodity_services (commodity_id, sent_at);
+CREATE INDEX idx_commodity_services_active ON commodity_services (group_id, expected_return_at) WHERE returned_at IS NULL;
+CREATE INDEX idx_commodity_services_due ON commodity_services (expected_return_at) WHERE returned_at IS NULL AND expected_return_at IS NOT NULL;
+-- SQLITE TABLE: commodity_supply_links --
+CREATE TABLE commodity_supply_links (
+    commodity_id TEXT NOT NULL,
+    label TEXT NOT NULL,
+    url TEXT NOT NULL,
+    notes TEXT,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_supply_links_uuid ON commodity_supply_links (uuid);
+CREATE INDEX idx_supply_links_tenant_id ON commodity_supply_links (tenant_id);
+CREATE INDEX idx_supply_links_tenant_group ON commodity_supply_links (tenant_id, group_id);
+CREATE INDEX idx_supply_links_commodity ON commodity_supply_links (commodity_id, sort_order);
+-- SQLITE TABLE: currency_migration_audit_rows --
+CREATE TABLE currency_migration_audit_rows (
+    migration_id TEXT NOT NULL,
+    commodity_id TEXT,
+    original_price_before NUMERIC,
+    original_price_after NUMERIC,
+    original_currency_before TEXT,
+    original_currency_after TEXT,
+    converted_before NUMERIC,
+    converted_after NUMERIC,
+    current_before NUMERIC,
+    current_after NUMERIC,
+    acquisition_filled_in_this_run BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (migration_id) REFERENCES currency_migrations(id) ON DELETE CASCADE,
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE SET NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_currency_migration_audit_uuid ON currency_migration_audit_rows (uuid);
+CREATE INDEX idx_currency_migration_audit_migration ON currency_migration_audit_rows (migration_id);
+CREATE INDEX idx_currency_migration_audit_commodity ON currency_migration_audit_rows (commodity_id);
+CREATE INDEX idx_currency_migration_audit_tenant_group ON currency_migration_audit_rows (tenant_id, group_id);
+-- SQLITE TABLE: currency_migrations --
+CREATE TABLE currency_migrations (
+    status TEXT NOT NULL,
+    from_currency TEXT NOT NULL,
+    to_currency TEXT NOT NULL,
+    exchange_rate NUMERIC NOT NULL,
+    commodity_count INTEGER NOT NULL DEFAULT 0,
+    total_before NUMERIC,
+    total_after NUMERIC,
+    preview_token TEXT,
+    preview_expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    error_message TEXT,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_currency_migrations_uuid ON currency_migrations (uuid);
+CREATE INDEX idx_currency_migrations_tenant_group ON currency_migrations (tenant_id, group_id);
+CREATE INDEX idx_currency_migrations_group_status ON currency_migrations (group_id, status);
+CREATE INDEX idx_currency_migrations_group_completed ON currency_migrations (group_id, completed_at) WHERE status = 'completed';
+CREATE UNIQUE INDEX idx_currency_migrations_group_in_flight ON currency_migrations (group_id) WHERE status IN ('pending', 'running');
+-- SQLITE TABLE: email_verifications --
+CREATE TABLE email_verifications (
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    user_id TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    token TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    verified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_email_verifications_uuid ON email_verifications (uuid);
+CREATE INDEX email_verifications_user_id_idx ON email_verifications (user_id);
+CREATE UNIQUE INDEX email_verifications_token_idx ON email_verifications (token);
+CREATE INDEX email_verifications_email_idx ON email_verifications (email);
+-- SQLITE TABLE: exports --
+CREATE TABLE exports (
+    type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    include_file_data BOOLEAN NOT NULL DEFAULT FALSE,
+    selected_items BLOB,
+    file_id TEXT,
+    file_path TEXT,
+    created_date TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    completed_date TIMESTAMP,
+    deleted_at TIMESTAMP,
+    error_message TEXT,
+    description TEXT,
+    imported BOOLEAN NOT NULL DEFAULT FALSE,
+    file_size INTEGER DEFAULT 0,
+    location_count INTEGER DEFAULT 0,
+    area_count INTEGER DEFAULT 0,
+    commodity_count INTEGER DEFAULT 0,
+    image_count INTEGER DEFAULT 0,
+    invoice_count INTEGER DEFAULT 0,
+    manual_count INTEGER DEFAULT 0,
+    file_count INTEGER DEFAULT 0,
+    binary_data_size INTEGER DEFAULT 0,
+    signature_key_fingerprint TEXT,
+    backup_schedule_id TEXT,
+    replication_status TEXT NOT NULL,
+    replica_url TEXT,
+    replica_sha256 TEXT,
+    replicated_at TIMESTAMP,
+    replication_error TEXT,
+    replication_attempts INTEGER NOT NULL DEFAULT 0,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE SET NULL,
+    FOREIGN KEY (backup_schedule_id) REFERENCES backup_schedules(id) ON DELETE SET NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_exports_uuid ON exports (uuid);
+CREATE INDEX idx_exports_tenant_id ON exports (tenant_id);
+CREATE INDEX idx_exports_tenant_status ON exports (tenant_id, status);
+CREATE INDEX idx_exports_tenant_type ON exports (tenant_id, type);
+CREATE INDEX idx_exports_tenant_group ON exports (tenant_id, group_id);
+-- SQLITE TABLE: files --
+CREATE TABLE files (
+    title TEXT,
+    description TEXT,
+    type TEXT NOT NULL,
+    category TEXT NOT NULL DEFAULT 'other',
+    tags BLOB,
+    linked_entity_type TEXT,
+    linked_entity_id TEXT,
+    linked_entity_meta TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    path TEXT NOT NULL,
+    original_path TEXT NOT NULL,
+    ext TEXT NOT NULL,
+    mime_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_files_uuid ON files (uuid);
+CREATE INDEX idx_files_tenant_id ON files (tenant_id);
+CREATE INDEX idx_files_tenant_type ON files (tenant_id, type);
+CREATE INDEX idx_files_tenant_linked_entity ON files (tenant_id, linked_entity_type, linked_entity_id);
+CREATE INDEX idx_files_tenant_group ON files (tenant_id, group_id);
+CREATE INDEX files_type_created_idx ON files (type, created_at);
+CREATE INDEX idx_files_tenant_group_category ON files (tenant_id, group_id, category);
+CREATE INDEX files_linked_entity_idx ON files (linked_entity_type, linked_entity_id);
+CREATE INDEX files_linked_entity_meta_idx ON files (linked_entity_type, linked_entity_id, linked_entity_meta);
+CREATE INDEX files_original_path_idx ON files (original_path);
+-- SQLITE TABLE: group_invites --
+CREATE TABLE group_invites (
+    group_id TEXT NOT NULL,
+    token TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_by TEXT,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    invitee_email TEXT,
+    role TEXT NOT NULL DEFAULT 'user',
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    FOREIGN KEY (used_by) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_group_invites_uuid ON group_invites (uuid);
+CREATE UNIQUE INDEX idx_group_invites_token ON group_invites (token);
+CREATE INDEX idx_group_invites_group_id ON group_invites (group_id);
+CREATE INDEX idx_group_invites_tenant_id ON group_invites (tenant_id);
+CREATE INDEX idx_group_invites_expires_at ON group_invites (expires_at);
+CREATE INDEX idx_group_invites_invitee_email ON group_invites (invitee_email) WHERE invitee_email IS NOT NULL;
+-- SQLITE TABLE: group_invites_audit --
+CREATE TABLE group_invites_audit (
+    original_invite_id TEXT NOT NULL,
+    original_invite_uuid TEXT NOT NULL,
+    original_group_id TEXT NOT NULL,
+    original_group_slug TEXT NOT NULL,
+    original_group_name TEXT NOT NULL,
+    token TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    used_by TEXT NOT NULL,
+    original_created_at TIMESTAMP NOT NULL,
+    original_expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NOT NULL,
+    archived_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    FOREIGN KEY (used_by) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_group_invites_audit_uuid ON group_invites_audit (uuid);
+CREATE UNIQUE INDEX idx_group_invites_audit_tenant_invite ON group_invites_audit (tenant_id, original_invite_id);
+CREATE INDEX idx_group_invites_audit_tenant_id ON group_invites_audit (tenant_id);
+CREATE INDEX idx_group_invites_audit_original_group_id ON group_invites_audit (original_group_id);
+CREATE INDEX idx_group_invites_audit_used_by ON group_invites_audit (used_by);
+CREATE INDEX idx_group_invites_audit_archived_at ON group_invites_audit (archived_at);
+-- SQLITE TABLE: group_memberships --
+CREATE TABLE group_memberships (
+    group_id TEXT NOT NULL,
+    member_user_id TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'user',
+    joined_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (member_user_id) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_group_memberships_uuid ON group_memberships (uuid);
+CREATE UNIQUE INDEX idx_group_memberships_unique ON group_memberships (tenant_id, group_id, member_user_id);
+CREATE INDEX idx_group_memberships_group_id ON group_memberships (group_id);
+CREATE INDEX idx_group_memberships_member_user_id ON group_memberships (member_user_id);
+CREATE INDEX idx_group_memberships_tenant_id ON group_memberships (tenant_id);
+-- SQLITE TABLE: group_notification_prefs --
+CREATE TABLE group_notification_prefs (
+    group_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    category TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_group_notification_prefs_uuid ON group_notification_prefs (uuid);
+CREATE UNIQUE INDEX idx_group_notification_prefs_unique ON group_notification_prefs (tenant_id, group_id, user_id, category);
+CREATE INDEX idx_group_notification_prefs_user_group ON group_notification_prefs (user_id, group_id);
+CREATE INDEX idx_group_notification_prefs_tenant_id ON group_notification_prefs (tenant_id);
+-- SQLITE TABLE: invoice_extractions --
+CREATE TABLE invoice_extractions (
+    file_id TEXT NOT NULL,
+    commodity_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    vendor TEXT,
+    purchase_date TEXT,
+    price NUMERIC,
+    currency TEXT,
+    line_items BLOB,
+    changes BLOB,
+    error_code TEXT,
+    reviewed_by_user_id TEXT,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE CASCADE,
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_invoice_extractions_uuid ON invoice_extractions (uuid);
+CREATE INDEX idx_invoice_extractions_tenant_id ON invoice_extractions (tenant_id);
+CREATE INDEX idx_invoice_extractions_tenant_group_status ON invoice_extractions (tenant_id, group_id, status);
+CREATE UNIQUE INDEX idx_invoice_extractions_file ON invoice_extractions (file_id);
+-- SQLITE TABLE: location_groups --
+CREATE TABLE location_groups (
+    slug TEXT NOT NULL,
+    name TEXT NOT NULL,
+    icon TEXT,
+    description TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active',
+    created_by TEXT NOT NULL,
+    group_currency TEXT NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    currency_migration_id TEXT,
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    FOREIGN KEY (currency_migration_id) REFERENCES currency_migrations(id) ON DELETE SET NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_location_groups_uuid ON location_groups (uuid);
+CREATE UNIQUE INDEX idx_location_groups_tenant_slug ON location_groups (tenant_id, slug);
+CREATE INDEX idx_location_groups_tenant_id ON location_groups (tenant_id);
+CREATE INDEX idx_location_groups_status ON location_groups (status);
+-- SQLITE TABLE: locations --
+CREATE TABLE locations (
+    name TEXT NOT NULL,
+    address TEXT NOT NULL,
+    icon TEXT NOT NULL,
+    description TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_locations_uuid ON locations (uuid);
+CREATE INDEX idx_locations_tenant_id ON locations (tenant_id);
+CREATE INDEX idx_locations_tenant_group ON locations (tenant_id, group_id);
+-- SQLITE TABLE: login_events --
+CREATE TABLE login_events (
+    user_id TEXT,
+    email TEXT NOT NULL,
+    outcome TEXT NOT NULL,
+    method TEXT NOT NULL DEFAULT 'password',
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_login_events_uuid ON login_events (uuid);
+CREATE INDEX idx_login_events_user_created_at ON login_events (user_id, created_at);
+CREATE INDEX idx_login_events_tenant_id ON login_events (tenant_id);
+CREATE INDEX idx_login_events_created_at ON login_events (created_at);
+-- SQLITE TABLE: magic_link_tokens --
+CREATE TABLE magic_link_tokens (
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    user_id TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    token TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    claimed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_magic_link_tokens_uuid ON magic_link_tokens (uuid);
+CREATE INDEX magic_link_tokens_user_id_idx ON magic_link_tokens (user_id);
+CREATE UNIQUE INDEX magic_link_tokens_token_idx ON magic_link_tokens (token);
+CREATE INDEX magic_link_tokens_email_idx ON magic_link_tokens (email);
+-- SQLITE TABLE: maintenance_logs --
+CREATE TABLE maintenance_logs (
+    schedule_id TEXT NOT NULL,
+    commodity_id TEXT NOT NULL,
+    done_at TEXT NOT NULL,
+    meter_value NUMERIC,
+    notes TEXT,
+    cost_amount NUMERIC,
+    cost_currency TEXT,
+    file_ids BLOB,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (schedule_id) REFERENCES maintenance_schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_maintenance_logs_uuid ON maintenance_logs (uuid);
+CREATE INDEX idx_maintenance_logs_tenant_id ON maintenance_logs (tenant_id);
+CREATE INDEX idx_maintenance_logs_tenant_group ON maintenance_logs (tenant_id, group_id);
+CREATE INDEX idx_maintenance_logs_schedule ON maintenance_logs (schedule_id, done_at);
+CREATE INDEX idx_maintenance_logs_commodity ON maintenance_logs (commodity_id, done_at);
+-- SQLITE TABLE: maintenance_reminders --
+CREATE TABLE maintenance_reminders (
+    schedule_id TEXT NOT NULL,
+    threshold_days INTEGER NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (schedule_id) REFERENCES maintenance_schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_maintenance_reminders_schedule_threshold ON maintenance_reminders (schedule_id, threshold_days);
+CREATE INDEX idx_maintenance_reminders_tenant_id ON maintenance_reminders (tenant_id);
+CREATE INDEX idx_maintenance_reminders_group_id ON maintenance_reminders (group_id);
+-- SQLITE TABLE: maintenance_schedules --
+CREATE TABLE maintenance_schedules (
+    commodity_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    recurrence TEXT NOT NULL DEFAULT 'interval',
+    interval_days INTEGER NOT NULL,
+    calendar_rule BLOB,
+    meter_unit TEXT,
+    meter_interval NUMERIC NOT NULL DEFAULT 0,
+    next_due_meter NUMERIC NOT NULL DEFAULT 0,
+    next_due_at TEXT NOT NULL,
+    last_done_at TEXT,
+    notes TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_maintenance_schedules_uuid ON maintenance_schedules (uuid);
+CREATE INDEX idx_maintenance_schedules_tenant_id ON maintenance_schedules (tenant_id);
+CREATE INDEX idx_maintenance_schedules_tenant_group ON maintenance_schedules (tenant_id, group_id);
+CREATE INDEX idx_maintenance_schedules_commodity ON maintenance_schedules (commodity_id, next_due_at);
+CREATE INDEX idx_maintenance_schedules_group_due ON maintenance_schedules (group_id, next_due_at);
+CREATE INDEX idx_maintenance_schedules_enabled_due ON maintenance_schedules (next_due_at) WHERE enabled = true;
+-- SQLITE TABLE: operation_slots --
+CREATE TABLE operation_slots (
+    slot_id INTEGER NOT NULL,
+    operation_name TEXT NOT NULL DEFAULT 'upload',
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_operation_slots_uuid ON operation_slots (uuid);
+CREATE INDEX idx_operation_slots_user_operation ON operation_slots (tenant_id, user_id, operation_name, expires_at);
+CREATE INDEX idx_operation_slots_cleanup ON operation_slots (expires_at);
+CREATE UNIQUE INDEX idx_operation_slots_unique ON operation_slots (tenant_id, user_id, operation_name, slot_id);
+CREATE INDEX idx_operation_slots_operation ON operation_slots (operation_name, expires_at);
+-- SQLITE TABLE: password_resets --
+CREATE TABLE password_resets (
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    user_id TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    token TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_password_resets_uuid ON password_resets (uuid);
+CREATE INDEX password_resets_user_id_idx ON password_resets (user_id);
+CREATE UNIQUE INDEX password_resets_token_idx ON password_resets (token);
+CREATE INDEX password_resets_email_idx ON password_resets (email);
+-- SQLITE TABLE: refresh_tokens --
+CREATE TABLE refresh_tokens (
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    last_used_at TIMESTAMP,
+    ip_address TEXT,
+    user_agent TEXT,
+    revoked_at TIMESTAMP,
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_refresh_tokens_uuid ON refresh_tokens (uuid);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+-- SQLITE TABLE: restore_operations --
+CREATE TABLE restore_operations (
+    export_id TEXT NOT NULL,
+    description TEXT NOT NULL,
+    status TEXT NOT NULL,
+    options BLOB NOT NULL,
+    created_date TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    started_date TIMESTAMP,
+    completed_date TIMESTAMP,
+    error_message TEXT,
+    location_count INTEGER DEFAULT 0,
+    area_count INTEGER DEFAULT 0,
+    commodity_count INTEGER DEFAULT 0,
+    image_count INTEGER DEFAULT 0,
+    invoice_count INTEGER DEFAULT 0,
+    manual_count INTEGER DEFAULT 0,
+    file_count INTEGER DEFAULT 0,
+    binary_data_size INTEGER DEFAULT 0,
+    error_count INTEGER DEFAULT 0,
+    preview BLOB,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (export_id) REFERENCES exports(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_restore_operations_uuid ON restore_operations (uuid);
+CREATE INDEX idx_restore_operations_tenant_id ON restore_operations (tenant_id);
+CREATE INDEX idx_restore_operations_tenant_status ON restore_operations (tenant_id, status);
+CREATE INDEX idx_restore_operations_tenant_export ON restore_operations (tenant_id, export_id);
+CREATE INDEX idx_restore_operations_tenant_group ON restore_operations (tenant_id, group_id);
+-- SQLITE TABLE: restore_steps --
+CREATE TABLE restore_steps (
+    restore_operation_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    result TEXT NOT NULL,
+    duration INTEGER,
+    reason TEXT,
+    created_date TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_date TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (restore_operation_id) REFERENCES restore_operations(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_restore_steps_uuid ON restore_steps (uuid);
+CREATE INDEX idx_restore_steps_tenant_id ON restore_steps (tenant_id);
+CREATE INDEX idx_restore_steps_tenant_operation ON restore_steps (tenant_id, restore_operation_id);
+CREATE INDEX idx_restore_steps_tenant_result ON restore_steps (tenant_id, result);
+CREATE INDEX idx_restore_steps_tenant_group ON restore_steps (tenant_id, group_id);
+-- SQLITE TABLE: saved_views --
+CREATE TABLE saved_views (
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT 'private',
+    filters BLOB NOT NULL,
+    columns BLOB,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_saved_views_uuid ON saved_views (uuid);
+CREATE INDEX idx_saved_views_tenant_id ON saved_views (tenant_id);
+CREATE INDEX idx_saved_views_tenant_group ON saved_views (tenant_id, group_id);
+CREATE INDEX idx_saved_views_group_creator ON saved_views (group_id, created_by_user_id);
+-- SQLITE TABLE: settings --
+CREATE TABLE settings (
+    name TEXT NOT NULL,
+    value BLOB NOT NULL,
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_settings_uuid ON settings (uuid);
+CREATE UNIQUE INDEX idx_settings_tenant_user_name ON settings (tenant_id, user_id, name);
+CREATE INDEX idx_settings_tenant_id ON settings (tenant_id);
+CREATE INDEX idx_settings_user_id ON settings (user_id);
+-- SQLITE TABLE: storage_quota_reminders --
+CREATE TABLE storage_quota_reminders (
+    threshold_percent INTEGER NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_storage_quota_reminders_group_threshold ON storage_quota_reminders (group_id, threshold_percent);
+CREATE INDEX idx_storage_quota_reminders_tenant_id ON storage_quota_reminders (tenant_id);
+-- SQLITE TABLE: system_admin_grants --
+CREATE TABLE system_admin_grants (
+    user_id TEXT NOT NULL,
+    granted_by TEXT,
+    granted_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (granted_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE UNIQUE INDEX idx_system_admin_grants_uuid ON system_admin_grants (uuid);
+CREATE UNIQUE INDEX system_admin_grants_user_id_idx ON system_admin_grants (user_id);
+-- SQLITE TABLE: tags --
+CREATE TABLE tags (
+    kind TEXT NOT NULL DEFAULT 'commodity',
+    slug TEXT NOT NULL,
+    label TEXT NOT NULL,
+    color TEXT NOT NULL DEFAULT 'muted',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_tags_uuid ON tags (uuid);
+CREATE INDEX idx_tags_tenant_id ON tags (tenant_id);
+CREATE INDEX idx_tags_tenant_group ON tags (tenant_id, group_id);
+CREATE UNIQUE INDEX idx_tags_group_kind_slug ON tags (group_id, kind, slug);
+-- SQLITE TABLE: tenants --
+CREATE TABLE tenants (
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    domain TEXT,
+    status TEXT NOT NULL DEFAULT 'active',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    registration_mode TEXT NOT NULL DEFAULT 'closed',
+    settings BLOB,
+    plan_id TEXT NOT NULL DEFAULT 'unlimited',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))
+);
+CREATE UNIQUE INDEX idx_tenants_uuid ON tenants (uuid);
+CREATE UNIQUE INDEX tenants_slug_idx ON tenants (slug);
+CREATE INDEX tenants_domain_idx ON tenants (domain);
+CREATE INDEX tenants_status_idx ON tenants (status);
+CREATE UNIQUE INDEX tenants_single_default_idx ON tenants (is_default) WHERE is_default = true;
+CREATE INDEX idx_tenants_plan_id ON tenants (plan_id);
+-- SQLITE TABLE: thumbnail_generation_jobs --
+CREATE TABLE thumbnail_generation_jobs (
+    file_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 3,
+    error_message TEXT,
+    processing_started_at TIMESTAMP,
+    processing_completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (file_id) REFERENCES files(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_thumbnail_jobs_uuid ON thumbnail_generation_jobs (uuid);
+CREATE INDEX idx_thumbnail_jobs_tenant_id ON thumbnail_generation_jobs (tenant_id);
+CREATE INDEX idx_thumbnail_jobs_status_created ON thumbnail_generation_jobs (status, created_at ASC);
+CREATE INDEX idx_thumbnail_jobs_file_id ON thumbnail_generation_jobs (file_id);
+CREATE INDEX idx_thumbnail_jobs_user_status ON thumbnail_generation_jobs (user_id, status);
+CREATE INDEX idx_thumbnail_jobs_cleanup ON thumbnail_generation_jobs (status, processing_completed_at);
+-- SQLITE TABLE: trusted_backup_keys --
+CREATE TABLE trusted_backup_keys (
+    fingerprint TEXT NOT NULL,
+    public_key TEXT NOT NULL,
+    label TEXT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    added_by TEXT,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    revoked_by TEXT,
+    revoke_reason TEXT,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))
+);
+CREATE UNIQUE INDEX idx_trusted_backup_keys_uuid ON trusted_backup_keys (uuid);
+CREATE UNIQUE INDEX trusted_backup_keys_fingerprint_idx ON trusted_backup_keys (fingerprint);
+-- SQLITE TABLE: user_concurrency_slots --
+CREATE TABLE user_concurrency_slots (
+    job_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (job_id) REFERENCES thumbnail_generation_jobs(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX idx_user_concurrency_slots_tenant_id ON user_concurrency_slots (tenant_id);
+CREATE INDEX idx_user_concurrency_slots_user_id ON user_concurrency_slots (user_id);
+CREATE UNIQUE INDEX idx_user_concurrency_slots_job_id ON user_concurrency_slots (job_id);
+CREATE INDEX idx_user_concurrency_slots_status ON user_concurrency_slots (status);
+CREATE INDEX idx_user_concurrency_slots_user_status ON user_concurrency_slots (user_id, status);
+-- SQLITE TABLE: user_mfa_secrets --
+CREATE TABLE user_mfa_secrets (
+    secret_encrypted TEXT NOT NULL,
+    enabled_at TIMESTAMP,
+    backup_codes_hashed BLOB NOT NULL DEFAULT '[]',
+    last_used_at TIMESTAMP,
+    last_used_step INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_user_mfa_secrets_uuid ON user_mfa_secrets (uuid);
+CREATE UNIQUE INDEX idx_user_mfa_secrets_user ON user_mfa_secrets (tenant_id, user_id);
+-- SQLITE TABLE: user_oauth_identities --
+CREATE TABLE user_oauth_identities (
+    user_id TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    provider_user_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    linked_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_oauth_identities_uuid ON user_oauth_identities (uuid);
+CREATE UNIQUE INDEX idx_oauth_identities_provider_subject ON user_oauth_identities (provider, provider_user_id);
+CREATE INDEX idx_oauth_identities_tenant_user ON user_oauth_identities (tenant_id, user_id);
+CREATE UNIQUE INDEX idx_oauth_identities_tenant_user_provider ON user_oauth_identities (tenant_id, user_id, provider);
+CREATE INDEX idx_oauth_identities_tenant_id ON user_oauth_identities (tenant_id);
+-- SQLITE TABLE: users --
+CREATE TABLE users (
+    email TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    name TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_login_at TIMESTAMP,
+    default_group_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (default_group_id) REFERENCES location_groups(id) ON DELETE SET NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_users_uuid ON users (uuid);
+CREATE UNIQUE INDEX users_tenant_email_idx ON users (tenant_id, email);
+CREATE INDEX users_tenant_idx ON users (tenant_id);
+CREATE INDEX users_active_idx ON users (is_active);
+-- SQLITE TABLE: warranty_reminders --
+CREATE TABLE warranty_reminders (
+    commodity_id TEXT NOT NULL,
+    threshold_days INTEGER NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (commodity_id) REFERENCES commodities(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX idx_warranty_reminders_commodity_threshold ON warranty_reminders (commodity_id, threshold_days);
+CREATE INDEX idx_warranty_reminders_tenant_id ON warranty_reminders (tenant_id);
+CREATE INDEX idx_warranty_reminders_group_id ON warranty_reminders (group_id);
+-- SQLITE TABLE: worker_control --
+CREATE TABLE worker_control (
+    worker_type TEXT NOT NULL,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    paused_by TEXT,
+    paused_at TIMESTAMP,
+    reason TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))
+);
+CREATE UNIQUE INDEX idx_worker_control_uuid ON worker_control (uuid);
+CREATE UNIQUE INDEX worker_control_worker_type_idx ON worker_control (worker_type);
\ No newline at end of file
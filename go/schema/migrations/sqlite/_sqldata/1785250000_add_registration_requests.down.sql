@@ -0,0 +1,5 @@
+-- Migration rollback
+-- Generated on: 2026-10-19T00:33:59Z
+-- Direction: DOWN
+
+DROP TABLE IF EXISTS registration_requests;
\ No newline at end of file
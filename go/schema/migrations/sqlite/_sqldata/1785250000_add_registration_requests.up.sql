@@ -0,0 +1,21 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-19T00:33:59Z
+-- Direction: UP
+
+-- SQLITE TABLE: registration_requests --
+CREATE TABLE registration_requests (
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    name TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    reason TEXT,
+    decided_by TEXT,
+    decided_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))
+);
+CREATE UNIQUE INDEX idx_registration_requests_uuid ON registration_requests (uuid);
+CREATE UNIQUE INDEX idx_registration_requests_user_id ON registration_requests (user_id);
+CREATE INDEX idx_registration_requests_tenant_status_created ON registration_requests (tenant_id, status, created_at);
\ No newline at end of file
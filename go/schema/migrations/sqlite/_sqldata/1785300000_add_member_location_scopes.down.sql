@@ -0,0 +1,5 @@
+-- Migration rollback
+-- Generated on: 2026-10-19T00:42:39Z
+-- Direction: DOWN
+
+DROP TABLE IF EXISTS group_member_location_scopes;
\ No newline at end of file
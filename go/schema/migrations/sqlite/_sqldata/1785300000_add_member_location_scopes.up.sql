@@ -0,0 +1,21 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-19T00:42:39Z
+-- Direction: UP
+
+-- SQLITE TABLE: group_member_location_scopes --
+CREATE TABLE group_member_location_scopes (
+    group_id TEXT NOT NULL,
+    member_user_id TEXT NOT NULL,
+    location_id TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'viewer',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (member_user_id) REFERENCES users(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_group_member_location_scopes_uuid ON group_member_location_scopes (uuid);
+CREATE UNIQUE INDEX idx_group_member_location_scopes_unique ON group_member_location_scopes (tenant_id, group_id, member_user_id, location_id);
+CREATE INDEX idx_group_member_location_scopes_member_user_id ON group_member_location_scopes (member_user_id);
\ No newline at end of file
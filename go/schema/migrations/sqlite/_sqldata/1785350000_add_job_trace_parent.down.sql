@@ -0,0 +1,120 @@
+-- Migration rollback
+-- Generated on: 2026-10-19T00:50:16Z
+-- Direction: DOWN
+
+-- SQLITE TABLE REBUILD: thumbnail_generation_jobs --
+CREATE TABLE thumbnail_generation_jobs__new (
+    file_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 3,
+    error_message TEXT,
+    processing_started_at TIMESTAMP,
+    processing_completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (file_id) REFERENCES files(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+INSERT INTO thumbnail_generation_jobs__new (file_id, status, attempt_count, max_attempts, error_message, processing_started_at, processing_completed_at, created_at, updated_at, tenant_id, user_id, id, uuid) SELECT file_id, status, attempt_count, max_attempts, error_message, processing_started_at, processing_completed_at, created_at, updated_at, tenant_id, user_id, id, uuid FROM thumbnail_generation_jobs;
+DROP TABLE thumbnail_generation_jobs;
+ALTER TABLE thumbnail_generation_jobs__new RENAME TO thumbnail_generation_jobs;
+CREATE INDEX idx_thumbnail_jobs_cleanup ON thumbnail_generation_jobs (status, processing_completed_at);
+CREATE INDEX idx_thumbnail_jobs_file_id ON thumbnail_generation_jobs (file_id);
+CREATE INDEX idx_thumbnail_jobs_status_created ON thumbnail_generation_jobs (status, created_at ASC);
+CREATE INDEX idx_thumbnail_jobs_tenant_id ON thumbnail_generation_jobs (tenant_id);
+CREATE INDEX idx_thumbnail_jobs_user_status ON thumbnail_generation_jobs (user_id, status);
+CREATE UNIQUE INDEX idx_thumbnail_jobs_uuid ON thumbnail_generation_jobs (uuid);
+-- SQLITE TABLE REBUILD: restore_operations --
+CREATE TABLE restore_operations__new (
+    export_id TEXT NOT NULL,
+    description TEXT NOT NULL,
+    status TEXT NOT NULL,
+    options BLOB NOT NULL,
+    created_date TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    started_date TIMESTAMP,
+    completed_date TIMESTAMP,
+    error_message TEXT,
+    location_count INTEGER DEFAULT 0,
+    area_count INTEGER DEFAULT 0,
+    commodity_count INTEGER DEFAULT 0,
+    image_count INTEGER DEFAULT 0,
+    invoice_count INTEGER DEFAULT 0,
+    manual_count INTEGER DEFAULT 0,
+    file_count INTEGER DEFAULT 0,
+    binary_data_size INTEGER DEFAULT 0,
+    error_count INTEGER DEFAULT 0,
+    preview BLOB,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (export_id) REFERENCES exports(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+INSERT INTO restore_operations__new (export_id, description, status, options, created_date, started_date, completed_date, error_message, location_count, area_count, commodity_count, image_count, invoice_count, manual_count, file_count, binary_data_size, error_count, preview, tenant_id, group_id, created_by_user_id, id, uuid) SELECT export_id, description, status, options, created_date, started_date, completed_date, error_message, location_count, area_count, commodity_count, image_count, invoice_count, manual_count, file_count, binary_data_size, error_count, preview, tenant_id, group_id, created_by_user_id, id, uuid FROM restore_operations;
+DROP TABLE restore_operations;
+ALTER TABLE restore_operations__new RENAME TO restore_operations;
+CREATE INDEX idx_restore_operations_tenant_export ON restore_operations (tenant_id, export_id);
+CREATE INDEX idx_restore_operations_tenant_group ON restore_operations (tenant_id, group_id);
+CREATE INDEX idx_restore_operations_tenant_id ON restore_operations (tenant_id);
+CREATE INDEX idx_restore_operations_tenant_status ON restore_operations (tenant_id, status);
+CREATE UNIQUE INDEX idx_restore_operations_uuid ON restore_operations (uuid);
+-- SQLITE TABLE REBUILD: exports --
+CREATE TABLE exports__new (
+    type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    include_file_data BOOLEAN NOT NULL DEFAULT FALSE,
+    selected_items BLOB,
+    file_id TEXT,
+    file_path TEXT,
+    created_date TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    completed_date TIMESTAMP,
+    deleted_at TIMESTAMP,
+    error_message TEXT,
+    description TEXT,
+    imported BOOLEAN NOT NULL DEFAULT FALSE,
+    file_size INTEGER DEFAULT 0,
+    location_count INTEGER DEFAULT 0,
+    area_count INTEGER DEFAULT 0,
+    commodity_count INTEGER DEFAULT 0,
+    image_count INTEGER DEFAULT 0,
+    invoice_count INTEGER DEFAULT 0,
+    manual_count INTEGER DEFAULT 0,
+    file_count INTEGER DEFAULT 0,
+    binary_data_size INTEGER DEFAULT 0,
+    signature_key_fingerprint TEXT,
+    backup_schedule_id TEXT,
+    replication_status TEXT NOT NULL,
+    replica_url TEXT,
+    replica_sha256 TEXT,
+    replicated_at TIMESTAMP,
+    replication_error TEXT,
+    replication_attempts INTEGER NOT NULL DEFAULT 0,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE SET NULL,
+    FOREIGN KEY (backup_schedule_id) REFERENCES backup_schedules(id) ON DELETE SET NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+INSERT INTO exports__new (type, status, include_file_data, selected_items, file_id, file_path, created_date, completed_date, deleted_at, error_message, description, imported, file_size, location_count, area_count, commodity_count, image_count, invoice_count, manual_count, file_count, binary_data_size, signature_key_fingerprint, backup_schedule_id, replication_status, replica_url, replica_sha256, replicated_at, replication_error, replication_attempts, tenant_id, group_id, created_by_user_id, id, uuid) SELECT type, status, include_file_data, selected_items, file_id, file_path, created_date, completed_date, deleted_at, error_message, description, imported, file_size, location_count, area_count, commodity_count, image_count, invoice_count, manual_count, file_count, binary_data_size, signature_key_fingerprint, backup_schedule_id, replication_status, replica_url, replica_sha256, replicated_at, replication_error, replication_attempts, tenant_id, group_id, created_by_user_id, id, uuid FROM exports;
+DROP TABLE exports;
+ALTER TABLE exports__new RENAME TO exports;
+CREATE INDEX idx_exports_tenant_group ON exports (tenant_id, group_id);
+CREATE INDEX idx_exports_tenant_id ON exports (tenant_id);
+CREATE INDEX idx_exports_tenant_status ON exports (tenant_id, status);
+CREATE INDEX idx_exports_tenant_type ON exports (tenant_id, type);
+CREATE UNIQUE INDEX idx_exports_uuid ON exports (uuid);
\ No newline at end of file
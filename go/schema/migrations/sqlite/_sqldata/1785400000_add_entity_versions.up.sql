@@ -0,0 +1,142 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-19T00:54:53Z
+-- Direction: UP
+
+-- SQLITE TABLE REBUILD: areas --
+CREATE TABLE areas__new (
+    name TEXT NOT NULL,
+    location_id TEXT NOT NULL,
+    icon TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    version INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+INSERT INTO areas__new (name, location_id, icon, tenant_id, group_id, created_by_user_id, id, uuid) SELECT name, location_id, icon, tenant_id, group_id, created_by_user_id, id, uuid FROM areas;
+DROP TABLE areas;
+ALTER TABLE areas__new RENAME TO areas;
+CREATE UNIQUE INDEX idx_areas_uuid ON areas (uuid);
+CREATE INDEX idx_areas_tenant_id ON areas (tenant_id);
+CREATE INDEX idx_areas_tenant_location ON areas (tenant_id, location_id);
+CREATE INDEX idx_areas_tenant_group ON areas (tenant_id, group_id);
+-- SQLITE TABLE REBUILD: commodities --
+CREATE TABLE commodities__new (
+    name TEXT NOT NULL,
+    short_name TEXT,
+    type TEXT NOT NULL,
+    area_id TEXT,
+    count INTEGER NOT NULL DEFAULT 1,
+    original_price NUMERIC,
+    original_price_currency TEXT,
+    converted_original_price NUMERIC,
+    current_price NUMERIC,
+    serial_number TEXT,
+    extra_serial_numbers BLOB,
+    part_numbers BLOB,
+    tags BLOB,
+    status TEXT NOT NULL,
+    purchase_date TEXT,
+    registered_date TEXT,
+    last_modified_date TEXT,
+    urls BLOB,
+    comments TEXT,
+    draft BOOLEAN NOT NULL DEFAULT FALSE,
+    cover_file_id TEXT,
+    warranty_expires_at TEXT,
+    warranty_notes TEXT,
+    acquisition_price NUMERIC,
+    acquisition_currency TEXT,
+    status_date TEXT,
+    status_note TEXT,
+    sale_price NUMERIC,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    version INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (area_id) REFERENCES areas(id),
+    FOREIGN KEY (cover_file_id) REFERENCES files(id) ON DELETE SET NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+INSERT INTO commodities__new (name, short_name, type, area_id, count, original_price, original_price_currency, converted_original_price, current_price, serial_number, extra_serial_numbers, part_numbers, tags, status, purchase_date, registered_date, last_modified_date, urls, comments, draft, cover_file_id, warranty_expires_at, warranty_notes, acquisition_price, acquisition_currency, status_date, status_note, sale_price, tenant_id, group_id, created_by_user_id, id, uuid) SELECT name, short_name, type, area_id, count, original_price, original_price_currency, converted_original_price, current_price, serial_number, extra_serial_numbers, part_numbers, tags, status, purchase_date, registered_date, last_modified_date, urls, comments, draft, cover_file_id, warranty_expires_at, warranty_notes, acquisition_price, acquisition_currency, status_date, status_note, sale_price, tenant_id, group_id, created_by_user_id, id, uuid FROM commodities;
+DROP TABLE commodities;
+ALTER TABLE commodities__new RENAME TO commodities;
+CREATE UNIQUE INDEX idx_commodities_uuid ON commodities (uuid);
+CREATE INDEX idx_commodities_tenant_id ON commodities (tenant_id);
+CREATE INDEX idx_commodities_tenant_area ON commodities (tenant_id, area_id);
+CREATE INDEX idx_commodities_tenant_status ON commodities (tenant_id, status);
+CREATE INDEX idx_commodities_tenant_group ON commodities (tenant_id, group_id);
+CREATE INDEX commodities_active_idx ON commodities (status, area_id) WHERE draft = false;
+CREATE INDEX commodities_draft_idx ON commodities (last_modified_date) WHERE draft = true;
+CREATE INDEX commodities_warranty_expires_at_idx ON commodities (warranty_expires_at) WHERE warranty_expires_at IS NOT NULL;
+-- SQLITE TABLE REBUILD: files --
+CREATE TABLE files__new (
+    title TEXT,
+    description TEXT,
+    type TEXT NOT NULL,
+    category TEXT NOT NULL DEFAULT 'other',
+    tags BLOB,
+    linked_entity_type TEXT,
+    linked_entity_id TEXT,
+    linked_entity_meta TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    version INTEGER NOT NULL DEFAULT 1,
+    path TEXT NOT NULL,
+    original_path TEXT NOT NULL,
+    ext TEXT NOT NULL,
+    mime_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+INSERT INTO files__new (title, description, type, category, tags, linked_entity_type, linked_entity_id, linked_entity_meta, created_at, updated_at, tenant_id, group_id, created_by_user_id, id, uuid, path, original_path, ext, mime_type, size_bytes) SELECT title, description, type, category, tags, linked_entity_type, linked_entity_id, linked_entity_meta, created_at, updated_at, tenant_id, group_id, created_by_user_id, id, uuid, path, original_path, ext, mime_type, size_bytes FROM files;
+DROP TABLE files;
+ALTER TABLE files__new RENAME TO files;
+CREATE UNIQUE INDEX idx_files_uuid ON files (uuid);
+CREATE INDEX idx_files_tenant_id ON files (tenant_id);
+CREATE INDEX idx_files_tenant_type ON files (tenant_id, type);
+CREATE INDEX idx_files_tenant_linked_entity ON files (tenant_id, linked_entity_type, linked_entity_id);
+CREATE INDEX idx_files_tenant_group ON files (tenant_id, group_id);
+CREATE INDEX files_type_created_idx ON files (type, created_at);
+CREATE INDEX idx_files_tenant_group_category ON files (tenant_id, group_id, category);
+CREATE INDEX files_linked_entity_idx ON files (linked_entity_type, linked_entity_id);
+CREATE INDEX files_linked_entity_meta_idx ON files (linked_entity_type, linked_entity_id, linked_entity_meta);
+CREATE INDEX files_original_path_idx ON files (original_path);
+-- SQLITE TABLE REBUILD: locations --
+CREATE TABLE locations__new (
+    name TEXT NOT NULL,
+    address TEXT NOT NULL,
+    icon TEXT NOT NULL,
+    description TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    created_by_user_id TEXT NOT NULL,
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    version INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    FOREIGN KEY (group_id) REFERENCES location_groups(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+INSERT INTO locations__new (name, address, icon, description, tenant_id, group_id, created_by_user_id, id, uuid) SELECT name, address, icon, description, tenant_id, group_id, created_by_user_id, id, uuid FROM locations;
+DROP TABLE locations;
+ALTER TABLE locations__new RENAME TO locations;
+CREATE UNIQUE INDEX idx_locations_uuid ON locations (uuid);
+CREATE INDEX idx_locations_tenant_id ON locations (tenant_id);
+CREATE INDEX idx_locations_tenant_group ON locations (tenant_id, group_id);
\ No newline at end of file
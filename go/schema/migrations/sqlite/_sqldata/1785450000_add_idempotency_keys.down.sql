@@ -0,0 +1,5 @@
+-- Migration rollback
+-- Generated on: 2026-10-19T00:59:27Z
+-- Direction: DOWN
+
+DROP TABLE IF EXISTS idempotency_keys;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- Migration generated from schema differences
+-- Generated on: 2026-10-19T00:59:27Z
+-- Direction: UP
+
+-- SQLITE TABLE: idempotency_keys --
+CREATE TABLE idempotency_keys (
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    content_type TEXT NOT NULL DEFAULT '',
+    response_body BLOB,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    id TEXT PRIMARY KEY NOT NULL,
+    uuid TEXT NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))
+);
+CREATE UNIQUE INDEX idx_idempotency_keys_uuid ON idempotency_keys (uuid);
+CREATE UNIQUE INDEX idx_idempotency_keys_user_key ON idempotency_keys (user_id, idempotency_key);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+CREATE INDEX idx_idempotency_keys_tenant_id ON idempotency_keys (tenant_id);
\ No newline at end of file
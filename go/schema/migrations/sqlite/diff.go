@@ -0,0 +1,264 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/stokaro/ptah/core/goschema"
+)
+
+// rebuildSuffix names the scratch table a rebuild copies the rows into.
+const rebuildSuffix = "__new"
+
+var createTablePrefix = regexp.MustCompile(`^CREATE TABLE\s+("[^"]+"|\S+)\s*`)
+
+// SchemaDiff holds the statements that move a SQLite database from its
+// current schema to the one rendered from the Go annotations (Up) and
+// back (Down).
+type SchemaDiff struct {
+	Up   []string
+	Down []string
+}
+
+// HasChanges reports whether the database differs from the annotations.
+func (d *SchemaDiff) HasChanges() bool {
+	return len(d.Up) > 0
+}
+
+// liveTable is a table as SQLite stores it in sqlite_master.
+type liveTable struct {
+	name    string
+	create  string
+	columns []string
+	indexes []renderedIndex
+}
+
+// Diff compares the schema of db with RenderSchema(goSchema). It is the
+// SQLite counterpart of ptah's schema diff, which has no SQLite dialect.
+//
+// SQLite can add columns but not change or drop them, nor alter
+// constraints, so a table whose definition changed is rebuilt the way the
+// SQLite documentation describes: the new definition is created under a
+// scratch name, the shared columns are copied over, the old table is
+// dropped and the new one renamed into place. MigrateUp applies migrations
+// with foreign key enforcement off and checks the keys before committing,
+// which the rebuild relies on. New and removed tables are created and
+// dropped; index changes on tables that are not rebuilt are applied with
+// DROP/CREATE INDEX. Down reverses Up.
+func Diff(ctx context.Context, db *sql.DB, goSchema *goschema.Database) (*SchemaDiff, error) {
+	live, err := readLiveTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	// The parser orders tables by dependency, breaking ties in map order.
+	// SQLite resolves references only when rows are written, so sorting by
+	// name is safe and keeps generated files stable.
+	desired := renderTables(goSchema)
+	slices.SortFunc(desired, func(a, b renderedTable) int {
+		return strings.Compare(a.name, b.name)
+	})
+	desiredNames := make(map[string]bool, len(desired))
+	for _, t := range desired {
+		desiredNames[t.name] = true
+	}
+
+	var (
+		indexDrops, tableUps, indexCreates      []string
+		indexDropsDown, tableDowns, indexRecrea []string
+	)
+	for _, want := range desired {
+		have, ok := live[want.name]
+		switch {
+		case !ok:
+			tableUps = append(tableUps, createTableStatements(want.name, want.create, want.indexes)...)
+			tableDowns = append(tableDowns, "DROP TABLE IF EXISTS "+want.name)
+		case tableBody(have.create) != tableBody(want.create):
+			tableUps = append(tableUps, rebuildStatements(want.name, want.create, want.indexes, common(have.columns, want.columns))...)
+			tableDowns = append(tableDowns, rebuildStatements(have.name, have.create, have.indexes, common(want.columns, have.columns))...)
+		default:
+			up, down := indexChanges(have.indexes, want.indexes)
+			indexDrops = append(indexDrops, up.drops...)
+			indexCreates = append(indexCreates, up.creates...)
+			indexDropsDown = append(indexDropsDown, down.drops...)
+			indexRecrea = append(indexRecrea, down.creates...)
+		}
+	}
+
+	var removed []string
+	for name := range live {
+		if !desiredNames[name] {
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(removed)
+	for _, name := range removed {
+		have := live[name]
+		tableUps = append(tableUps, "-- WARNING: This will delete all data!\nDROP TABLE IF EXISTS "+name)
+		tableDowns = append(tableDowns, createTableStatements(have.name, have.create, have.indexes)...)
+	}
+
+	diff := &SchemaDiff{}
+	diff.Up = slices.Concat(indexDrops, tableUps, indexCreates)
+	// Undo in the opposite order: the indexes the up migration created go
+	// first, the tables are restored last to first.
+	diff.Down = slices.Concat(indexDropsDown, reverseGroups(tableDowns), indexRecrea)
+	return diff, nil
+}
+
+// indexDelta is the DROP and CREATE INDEX statements that move one table's
+// indexes to another set.
+type indexDelta struct {
+	drops   []string
+	creates []string
+}
+
+func indexChanges(have, want []renderedIndex) (up, down indexDelta) {
+	haveByName := make(map[string]string, len(have))
+	for _, idx := range have {
+		haveByName[idx.name] = idx.create
+	}
+	wantByName := make(map[string]string, len(want))
+	for _, idx := range want {
+		wantByName[idx.name] = idx.create
+	}
+
+	for _, idx := range have {
+		if wantByName[idx.name] != idx.create {
+			up.drops = append(up.drops, "DROP INDEX IF EXISTS "+idx.name)
+			down.creates = append(down.creates, idx.create)
+		}
+	}
+	for _, idx := range want {
+		if haveByName[idx.name] != idx.create {
+			up.creates = append(up.creates, idx.create)
+			down.drops = append(down.drops, "DROP INDEX IF EXISTS "+idx.name)
+		}
+	}
+	return up, down
+}
+
+func createTableStatements(name, create string, indexes []renderedIndex) []string {
+	statements := []string{fmt.Sprintf("-- SQLITE TABLE: %s --\n%s", name, create)}
+	for _, idx := range indexes {
+		statements = append(statements, idx.create)
+	}
+	return statements
+}
+
+func rebuildStatements(name, create string, indexes []renderedIndex, columns []string) []string {
+	scratch := name + rebuildSuffix
+	statements := []string{
+		fmt.Sprintf("-- SQLITE TABLE REBUILD: %s --\nCREATE TABLE %s %s", name, scratch, tableBody(create)),
+	}
+	if len(columns) > 0 {
+		cols := strings.Join(columns, ", ")
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", scratch, cols, cols, name))
+	}
+	statements = append(statements,
+		"DROP TABLE "+name,
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", scratch, name),
+	)
+	for _, idx := range indexes {
+		statements = append(statements, idx.create)
+	}
+	return statements
+}
+
+// reverseGroups reverses the order of the per-table statement groups in
+// statements while keeping each group's own order. A group starts at a
+// statement that opens with a "-- SQLITE TABLE" comment or at a bare DROP
+// TABLE.
+func reverseGroups(statements []string) []string {
+	var groups [][]string
+	for _, stmt := range statements {
+		if len(groups) == 0 || strings.HasPrefix(stmt, "-- SQLITE TABLE") || strings.HasPrefix(stmt, "DROP TABLE IF EXISTS") {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], stmt)
+	}
+	slices.Reverse(groups)
+	return slices.Concat(groups...)
+}
+
+// tableBody strips "CREATE TABLE <name>" from a table definition. A table
+// renamed into place is stored with its name quoted, so the name is not
+// part of the comparison.
+func tableBody(create string) string {
+	return createTablePrefix.ReplaceAllString(strings.TrimSpace(create), "")
+}
+
+// common returns the columns of to that from has too, in to's order.
+func common(from, to []string) []string {
+	var result []string
+	for _, col := range to {
+		if slices.Contains(from, col) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
+
+// readLiveTables reads the tables and explicitly created indexes of db,
+// leaving out SQLite's own objects and the schema_migrations bookkeeping.
+func readLiveTables(ctx context.Context, db *sql.DB) (map[string]*liveTable, error) {
+	rows, err := db.QueryContext(ctx, `SELECT type, name, tbl_name, sql FROM sqlite_master
+		WHERE type IN ('table', 'index') AND sql IS NOT NULL
+		  AND name NOT LIKE 'sqlite_%' AND tbl_name != 'schema_migrations'
+		ORDER BY type DESC, name`)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to read sqlite_master", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string]*liveTable)
+	for rows.Next() {
+		var typ, name, tableName, stmt string
+		if err := rows.Scan(&typ, &name, &tableName, &stmt); err != nil {
+			return nil, errxtrace.Wrap("failed to scan sqlite_master row", err)
+		}
+		if typ == "table" {
+			tables[name] = &liveTable{name: name, create: stmt}
+			continue
+		}
+		if t, ok := tables[tableName]; ok {
+			t.indexes = append(t.indexes, renderedIndex{name: name, create: stmt})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errxtrace.Wrap("error iterating sqlite_master rows", err)
+	}
+
+	for _, t := range tables {
+		t.columns, err = tableColumns(ctx, db, t.name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tables, nil
+}
+
+func tableColumns(ctx context.Context, db *sql.DB, table string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info($1) ORDER BY cid`, table)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to read table columns", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errxtrace.Wrap("failed to scan column name", err)
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errxtrace.Wrap("error iterating table columns", err)
+	}
+	return columns, nil
+}
@@ -0,0 +1,283 @@
+package sqlite
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+)
+
+// SQLite migrations written by `inventool db migrations generate` against a
+// sqlite:// database, named like the PostgreSQL ones:
+// <unix-seconds>_<description>.{up,down}.sql.
+//
+//go:embed _sqldata/*
+var embeddedMigrations embed.FS
+
+// ErrForeignKeyViolation is returned when a migration leaves rows whose
+// foreign keys point nowhere.
+var ErrForeignKeyViolation = errx.NewSentinel("foreign key violation")
+
+// createMigrationsTable mirrors the schema_migrations table ptah keeps on
+// PostgreSQL, so `db migrate status/verify` read the same shape on both.
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY NOT NULL,
+    description TEXT NOT NULL,
+    applied_at TIMESTAMP NOT NULL
+)`
+
+// Migration is one versioned SQLite migration.
+type Migration struct {
+	Version     int64
+	Description string
+	UpSQL       string
+	DownSQL     string
+}
+
+// EmbeddedMigrationsFS returns the SQLite migrations embedded in the binary.
+func EmbeddedMigrationsFS() (fs.FS, error) {
+	return fs.Sub(embeddedMigrations, "_sqldata")
+}
+
+// LoadMigrations reads the migrations in fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to read migration filesystem", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+		base, direction, ok := strings.Cut(strings.TrimSuffix(name, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			continue
+		}
+		prefix, description, ok := strings.Cut(base, "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to read migration file", err, errx.Attrs("file", name))
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Description: description}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.UpSQL = string(body)
+		} else {
+			m.DownSQL = string(body)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		result = append(result, *m)
+	}
+	slices.SortFunc(result, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return result, nil
+}
+
+// AppliedVersions returns the versions recorded in schema_migrations, in
+// ascending order. A database that was never migrated has none.
+func AppliedVersions(ctx context.Context, db *sql.DB) ([]int64, error) {
+	if _, err := db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, errxtrace.Wrap("failed to create schema_migrations table", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to query schema_migrations", err)
+	}
+	defer rows.Close()
+
+	var versions []int64
+	for rows.Next() {
+		var v int64
+		if err := rows.Scan(&v); err != nil {
+			return nil, errxtrace.Wrap("failed to scan migration version", err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errxtrace.Wrap("error iterating migration versions", err)
+	}
+	return versions, nil
+}
+
+// CurrentVersion returns the highest applied migration version, or 0.
+func CurrentVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	versions, err := AppliedVersions(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	return versions[len(versions)-1], nil
+}
+
+// MigrateUp applies every migration in fsys that is not yet recorded in
+// schema_migrations. Each migration runs in its own transaction together
+// with its schema_migrations row, so a failed migration leaves no trace.
+// It returns the migrations it applied.
+func MigrateUp(ctx context.Context, db *sql.DB, fsys fs.FS) ([]Migration, error) {
+	all, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := AppliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range all {
+		if slices.Contains(applied, m.Version) {
+			continue
+		}
+		if err := applyMigration(ctx, db, m); err != nil {
+			return done, err
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// applyMigration runs one migration with foreign key enforcement off, as
+// the SQLite table rebuild procedure requires: dropping the old copy of a
+// rebuilt table would otherwise cascade into the tables referencing it.
+// The pragma is a no-op inside a transaction, so it is set on a dedicated
+// connection first, and the keys are checked before the commit instead.
+func applyMigration(ctx context.Context, db *sql.DB, m Migration) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to acquire connection", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return errxtrace.Wrap("failed to disable foreign keys", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), `PRAGMA foreign_keys = ON`) //nolint:errcheck // best effort; the pool's DSN sets it on new connections
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errxtrace.Wrap("failed to begin migration transaction", err)
+	}
+	defer tx.Rollback() // no-op after a successful commit
+
+	if _, err := tx.ExecContext(ctx, m.UpSQL); err != nil {
+		return errxtrace.Wrap("failed to apply migration", err, errx.Attrs("version", m.Version, "description", m.Description))
+	}
+	if err := checkForeignKeys(ctx, tx); err != nil {
+		return errxtrace.Wrap("migration broke a foreign key", err, errx.Attrs("version", m.Version, "description", m.Description))
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, description, applied_at) VALUES ($1, $2, $3)`,
+		m.Version, m.Description, time.Now().UTC())
+	if err != nil {
+		return errxtrace.Wrap("failed to record migration", err, errx.Attrs("version", m.Version))
+	}
+	if err := tx.Commit(); err != nil {
+		return errxtrace.Wrap("failed to commit migration", err, errx.Attrs("version", m.Version))
+	}
+	return nil
+}
+
+// checkForeignKeys fails with the first row PRAGMA foreign_key_check
+// reports.
+func checkForeignKeys(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return errxtrace.Wrap("failed to check foreign keys", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var (
+			table, parent string
+			rowid         sql.NullInt64
+			fkid          int64
+		)
+		if err := rows.Scan(&table, &rowid, &parent, &fkid); err != nil {
+			return errxtrace.Wrap("failed to scan foreign key violation", err)
+		}
+		return errxtrace.Classify(ErrForeignKeyViolation, errx.Attrs("table", table, "rowid", rowid.Int64, "parent", parent))
+	}
+	return rows.Err()
+}
+
+// DropAll drops every table in the database, schema_migrations included.
+// Foreign keys are switched off for the duration, because the schema has
+// reference cycles that no drop order satisfies.
+func DropAll(ctx context.Context, db *sql.DB) ([]string, error) {
+	tables, err := Tables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to acquire connection", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return nil, errxtrace.Wrap("failed to disable foreign keys", err)
+	}
+	for _, table := range tables {
+		if _, err := conn.ExecContext(ctx, "DROP TABLE IF EXISTS "+table); err != nil {
+			return nil, errxtrace.Wrap("failed to drop table", err, errx.Attrs("table", table))
+		}
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`); err != nil {
+		return nil, errxtrace.Wrap("failed to re-enable foreign keys", err)
+	}
+	return tables, nil
+}
+
+// Tables lists the user tables in the database.
+func Tables(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to query tables", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errxtrace.Wrap("failed to scan table name", err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errxtrace.Wrap("error iterating table rows", err)
+	}
+	return tables, nil
+}
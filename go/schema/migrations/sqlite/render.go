@@ -0,0 +1,231 @@
+package sqlite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stokaro/ptah/core/goschema"
+)
+
+// uuidDefault is the SQLite spelling of (gen_random_uuid())::text: a random
+// version-4 UUID in the canonical lower-case text form. The registries
+// always set the UUID themselves; the default only covers raw inserts.
+const uuidDefault = "(lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || " +
+	"substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || " +
+	"substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6))))"
+
+// timestampDefault replaces CURRENT_TIMESTAMP / now(). SQLite's own
+// CURRENT_TIMESTAMP stops at whole seconds; keeping the milliseconds keeps
+// ORDER BY created_at stable for rows inserted within the same second.
+const timestampDefault = "(strftime('%Y-%m-%d %H:%M:%f', 'now'))"
+
+var numericType = regexp.MustCompile(`^(DECIMAL|NUMERIC)\b`)
+
+// RenderSchema renders the Go-annotation schema into SQLite DDL: one
+// CREATE TABLE per table, in dependency order, followed by its indexes.
+//
+// PostgreSQL-only constructs have no SQLite counterpart and are left out:
+// GIN / trigram indexes, extensions, functions, roles and RLS policies.
+// Tenant and group isolation is enforced by the SQLite registry instead.
+func RenderSchema(db *goschema.Database) []string {
+	var statements []string
+	for _, table := range renderTables(db) {
+		statements = append(statements, table.create)
+		for _, idx := range table.indexes {
+			statements = append(statements, idx.create)
+		}
+	}
+	return statements
+}
+
+// renderedTable is one table of the rendered schema together with the
+// column names it declares and its indexes.
+type renderedTable struct {
+	name    string
+	create  string
+	columns []string
+	indexes []renderedIndex
+}
+
+type renderedIndex struct {
+	name   string
+	create string
+}
+
+func renderTables(db *goschema.Database) []renderedTable {
+	fieldsByStruct := make(map[string][]goschema.Field)
+	for _, f := range db.Fields {
+		fieldsByStruct[f.StructName] = append(fieldsByStruct[f.StructName], f)
+	}
+	indexesByTable := make(map[string][]goschema.Index)
+	for _, idx := range db.Indexes {
+		indexesByTable[idx.TableName] = append(indexesByTable[idx.TableName], idx)
+	}
+
+	tables := make([]renderedTable, 0, len(db.Tables))
+	for _, table := range db.Tables {
+		fields := fieldsByStruct[table.StructName]
+		rendered := renderedTable{
+			name:   table.Name,
+			create: renderTable(table, fields),
+		}
+		for _, f := range fields {
+			rendered.columns = append(rendered.columns, f.Name)
+		}
+		for _, idx := range indexesByTable[table.Name] {
+			if stmt, ok := renderIndex(table.Name, idx); ok {
+				rendered.indexes = append(rendered.indexes, renderedIndex{name: idx.Name, create: stmt})
+			}
+		}
+		tables = append(tables, rendered)
+	}
+	return tables
+}
+
+// RenderDropSchema renders the statements that undo RenderSchema, in reverse
+// dependency order.
+func RenderDropSchema(db *goschema.Database) []string {
+	statements := make([]string, 0, len(db.Tables))
+	for i := len(db.Tables) - 1; i >= 0; i-- {
+		statements = append(statements, fmt.Sprintf("DROP TABLE IF EXISTS %s", db.Tables[i].Name))
+	}
+	return statements
+}
+
+func renderTable(table goschema.Table, fields []goschema.Field) string {
+	var lines []string
+	var foreignKeys []string
+	for _, f := range fields {
+		lines = append(lines, "    "+renderColumn(f))
+		if f.Foreign != "" {
+			fk := "    FOREIGN KEY (" + f.Name + ") REFERENCES " + f.Foreign
+			if f.OnDelete != "" {
+				fk += " ON DELETE " + f.OnDelete
+			}
+			if f.OnUpdate != "" {
+				fk += " ON UPDATE " + f.OnUpdate
+			}
+			foreignKeys = append(foreignKeys, fk)
+		}
+	}
+	// The parser reports "no composite key" and "no checks" as a single
+	// empty entry rather than an empty slice.
+	if primaryKey := nonEmpty(table.PrimaryKey); len(primaryKey) > 0 {
+		lines = append(lines, "    PRIMARY KEY ("+strings.Join(primaryKey, ", ")+")")
+	}
+	for _, check := range nonEmpty(table.Checks) {
+		lines = append(lines, "    CHECK ("+check+")")
+	}
+	lines = append(lines, foreignKeys...)
+
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n)", table.Name, strings.Join(lines, ",\n"))
+}
+
+func renderColumn(f goschema.Field) string {
+	parts := []string{f.Name, columnType(f.Type)}
+	if f.Primary {
+		parts = append(parts, "PRIMARY KEY")
+	}
+	if !f.Nullable || f.Primary {
+		parts = append(parts, "NOT NULL")
+	}
+	if f.Unique {
+		parts = append(parts, "UNIQUE")
+	}
+	if def := columnDefault(f); def != "" {
+		parts = append(parts, "DEFAULT "+def)
+	}
+	if f.Check != "" {
+		parts = append(parts, "CHECK ("+f.Check+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// columnType maps a PostgreSQL column type onto the SQLite type name that
+// gives the intended affinity. TIMESTAMP and DATE keep their names because
+// the driver converts declared TIMESTAMP/DATE columns back into time.Time.
+// JSON documents are stored as the bytes the model Valuers produce, hence
+// BLOB.
+func columnType(pgType string) string {
+	t := strings.ToUpper(strings.TrimSpace(pgType))
+	switch {
+	case t == "BOOLEAN":
+		return "BOOLEAN"
+	case strings.HasPrefix(t, "TIMESTAMP"):
+		return "TIMESTAMP"
+	case t == "DATE":
+		return "DATE"
+	case t == "INTEGER", t == "BIGINT", t == "SMALLINT", strings.HasSuffix(t, "SERIAL"):
+		return "INTEGER"
+	case numericType.MatchString(t):
+		return "NUMERIC"
+	case t == "JSONB", t == "JSON", t == "BYTEA":
+		return "BLOB"
+	default:
+		return "TEXT"
+	}
+}
+
+func columnDefault(f goschema.Field) string {
+	if f.DefaultExpr != "" {
+		return defaultExpr(f)
+	}
+	if f.Default == "" {
+		return ""
+	}
+	switch columnType(f.Type) {
+	case "BOOLEAN":
+		return strings.ToUpper(f.Default)
+	case "INTEGER", "NUMERIC":
+		return f.Default
+	default:
+		return quote(strings.Trim(f.Default, "'"))
+	}
+}
+
+func defaultExpr(f goschema.Field) string {
+	expr := strings.TrimSpace(f.DefaultExpr)
+	switch strings.ToLower(expr) {
+	case "(gen_random_uuid())::text", "gen_random_uuid()":
+		return uuidDefault
+	case "current_timestamp", "now()":
+		return timestampDefault
+	}
+	if columnType(f.Type) == "BLOB" && strings.HasPrefix(expr, "'") {
+		return "(CAST(" + expr + " AS BLOB))"
+	}
+	return "(" + expr + ")"
+}
+
+func renderIndex(table string, idx goschema.Index) (string, bool) {
+	// GIN (array containment, trigram search) has no SQLite equivalent;
+	// the registry falls back to scans for those queries.
+	if idx.Type != "" && !strings.EqualFold(idx.Type, "BTREE") {
+		return "", false
+	}
+
+	stmt := "CREATE "
+	if idx.Unique {
+		stmt += "UNIQUE "
+	}
+	stmt += fmt.Sprintf("INDEX %s ON %s (%s)", idx.Name, table, strings.Join(idx.Fields, ", "))
+	if idx.Condition != "" {
+		stmt += " WHERE " + idx.Condition
+	}
+	return stmt, true
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,137 @@
+// Package sqlite holds the SQLite flavour of the inventario schema layer:
+// DSN handling, the DDL renderer over the ptah Go-annotation schema, the
+// schema diff, the embedded SQLite migrations and the small migrator that
+// applies them.
+//
+// ptah generates the PostgreSQL migrations but has no SQLite dialect.
+// RenderSchema renders the annotations into SQLite DDL and Diff compares
+// that with a live database; `inventool db migrations generate` writes the
+// result into _sqldata in ptah's file format, next to the PostgreSQL files.
+package sqlite
+
+import (
+	"database/sql"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	_ "modernc.org/sqlite" // pure-Go SQLite driver for database/sql
+)
+
+// Scheme is the DSN scheme that selects the SQLite backend.
+const Scheme = "sqlite"
+
+// DriverName is the database/sql driver name registered by modernc.org/sqlite.
+const DriverName = "sqlite"
+
+// ErrInvalidDSN is returned when a DSN is not a usable sqlite:// DSN.
+var ErrInvalidDSN = errx.NewSentinel("invalid SQLite DSN")
+
+// driverParams are the connection settings every inventario SQLite
+// connection runs with:
+//   - foreign keys are off by default in SQLite and the schema relies on them;
+//   - WAL lets readers proceed while a writer holds the database;
+//   - the busy timeout makes a second writer wait instead of failing at once;
+//   - write transactions take the write lock up front (BEGIN IMMEDIATE), so a
+//     read-then-write transaction cannot lose the lock upgrade to another
+//     writer; read-only transactions stay deferred;
+//   - times are written in one sortable UTC text format, so comparisons on
+//     TIMESTAMP columns order correctly.
+var driverParams = [][2]string{
+	{"_pragma", "foreign_keys(1)"},
+	{"_pragma", "journal_mode(WAL)"},
+	{"_pragma", "busy_timeout(10000)"},
+	{"_pragma", "synchronous(NORMAL)"},
+	{"_txlock", "immediate"},
+	{"_time_format", "sqlite"},
+	{"_timezone", "UTC"},
+	{"_texttotime", "1"},
+}
+
+// IsDSN reports whether dsn selects the SQLite backend.
+func IsDSN(dsn string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(dsn)), Scheme+"://")
+}
+
+// DatabasePath returns the database file path of a sqlite:// DSN:
+// everything between the scheme and the query string, so both
+// sqlite://./inventario.db and sqlite:///var/lib/inventario.db work.
+func DatabasePath(dsn string) (string, error) {
+	if !IsDSN(dsn) {
+		return "", errxtrace.Wrap("not a sqlite:// DSN", ErrInvalidDSN)
+	}
+	rest := strings.TrimSpace(dsn)[len(Scheme+"://"):]
+	path, _, _ := strings.Cut(rest, "?")
+	if path == "" {
+		return "", errxtrace.Wrap("database path is empty", ErrInvalidDSN)
+	}
+	return path, nil
+}
+
+// DriverDSN converts a sqlite:// DSN into the DSN understood by
+// modernc.org/sqlite, adding the connection settings inventario relies on.
+// Query parameters on the original DSN are passed through to the driver and
+// take precedence over the defaults, so an operator can add or change
+// _pragma settings.
+func DriverDSN(dsn string) (string, error) {
+	path, err := DatabasePath(dsn)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	if _, rawQuery, ok := strings.Cut(strings.TrimSpace(dsn), "?"); ok {
+		query, err = url.ParseQuery(rawQuery)
+		if err != nil {
+			return "", errxtrace.Wrap("failed to parse DSN query", ErrInvalidDSN, errx.Attrs("error", err.Error()))
+		}
+	}
+
+	// The driver runs _pragma settings sorted by text rather than in DSN
+	// order, so a default is dropped when the DSN sets the same pragma.
+	overridden := make(map[string]bool)
+	for _, v := range query["_pragma"] {
+		overridden[pragmaName(v)] = true
+	}
+
+	params := make([]string, 0, len(driverParams)+len(query))
+	for _, p := range driverParams {
+		if p[0] == "_pragma" && overridden[pragmaName(p[1])] || p[0] != "_pragma" && query.Has(p[0]) {
+			continue
+		}
+		params = append(params, p[0]+"="+url.QueryEscape(p[1]))
+	}
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		for _, v := range query[key] {
+			params = append(params, key+"="+url.QueryEscape(v))
+		}
+	}
+
+	return "file:" + path + "?" + strings.Join(params, "&"), nil
+}
+
+// pragmaName returns the name of a _pragma value such as foreign_keys(1)
+// or journal_mode=WAL.
+func pragmaName(v string) string {
+	name, _, _ := strings.Cut(v, "(")
+	name, _, _ = strings.Cut(name, "=")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Open opens a plain database/sql handle on a sqlite:// DSN. The registry
+// opens its own handle with the per-connection isolation objects installed;
+// Open is for the schema tooling, which works on the base tables.
+func Open(dsn string) (*sql.DB, error) {
+	driverDSN, err := DriverDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open(DriverName, driverDSN)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to open SQLite database", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,238 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"github.com/stokaro/ptah/core/goschema"
+
+	"github.com/denisvmedia/inventario/schema/migrations"
+	"github.com/denisvmedia/inventario/schema/migrations/sqlite"
+)
+
+func openTestDB(c *qt.C) *sql.DB {
+	db, err := sqlite.Open("sqlite://" + filepath.Join(c.TempDir(), "test.db"))
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestDriverDSN(t *testing.T) {
+	c := qt.New(t)
+
+	dsn, err := sqlite.DriverDSN("sqlite://./data/inventario.db?_txlock=deferred&_pragma=cache_size(-2000)")
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(dsn, "file:./data/inventario.db?_pragma=foreign_keys%281%29&"), qt.IsTrue, qt.Commentf("dsn: %s", dsn))
+	c.Assert(strings.Contains(dsn, "_txlock=immediate"), qt.IsFalse)
+	c.Assert(strings.Contains(dsn, "_txlock=deferred"), qt.IsTrue)
+	c.Assert(strings.Contains(dsn, "_pragma=cache_size%28-2000%29"), qt.IsTrue)
+
+	dsn, err = sqlite.DriverDSN("sqlite:///tmp/inventario.db?_pragma=foreign_keys(0)")
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.Contains(dsn, "foreign_keys%281%29"), qt.IsFalse)
+	c.Assert(strings.Contains(dsn, "foreign_keys%280%29"), qt.IsTrue)
+
+	_, err = sqlite.DriverDSN("sqlite://")
+	c.Assert(err, qt.ErrorIs, sqlite.ErrInvalidDSN)
+	_, err = sqlite.DriverDSN("postgres://localhost/inventario")
+	c.Assert(err, qt.ErrorIs, sqlite.ErrInvalidDSN)
+}
+
+func TestMigrateUp(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	db := openTestDB(c)
+	migFS := must.Must(sqlite.EmbeddedMigrationsFS())
+
+	applied, err := sqlite.MigrateUp(ctx, db, migFS)
+	c.Assert(err, qt.IsNil)
+	c.Assert(applied, qt.Not(qt.HasLen), 0)
+
+	version, err := sqlite.CurrentVersion(ctx, db)
+	c.Assert(err, qt.IsNil)
+	c.Assert(version, qt.Equals, must.Must(migrations.MaxVersion(migFS)))
+
+	// A second run has nothing left to apply.
+	applied, err = sqlite.MigrateUp(ctx, db, migFS)
+	c.Assert(err, qt.IsNil)
+	c.Assert(applied, qt.HasLen, 0)
+
+	dropped, err := sqlite.DropAll(ctx, db)
+	c.Assert(err, qt.IsNil)
+	c.Assert(dropped, qt.Contains, "schema_migrations")
+	c.Assert(must.Must(sqlite.Tables(ctx, db)), qt.HasLen, 0)
+}
+
+// TestMigrationsMatchAnnotations is the SQLite counterpart of the PostgreSQL
+// schema drift test and of `inventool db migrations generate --check`: a
+// database migrated with the embedded migrations must have nothing left to
+// diff against the current Go annotations.
+func TestMigrationsMatchAnnotations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db := openTestDB(c)
+	_, err := sqlite.MigrateUp(ctx, db, must.Must(sqlite.EmbeddedMigrationsFS()))
+	c.Assert(err, qt.IsNil)
+
+	goSchema, err := goschema.ParseDir("../../../models")
+	c.Assert(err, qt.IsNil)
+	diff, err := sqlite.Diff(ctx, db, goSchema)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff.Up, qt.HasLen, 0, qt.Commentf(
+		"the SQLite migrations under schema/migrations/sqlite/_sqldata are out of sync with the Go annotations; "+
+			"run `inventool db migrations generate --db-dsn sqlite://...` against a migrated database"))
+}
+
+func TestDiff(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	db := openTestDB(c)
+	migrate := migrationRunner(c, db)
+
+	v1 := testSchema(false)
+	v2 := testSchema(true)
+
+	diff, err := sqlite.Diff(ctx, db, v1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff.HasChanges(), qt.IsTrue)
+	migrate(diff.Up)
+	assertNoDiff(c, db, v1)
+
+	_, err = db.ExecContext(ctx, `INSERT INTO owners (id, name) VALUES ('o1', 'Alice')`)
+	c.Assert(err, qt.IsNil)
+	_, err = db.ExecContext(ctx, `INSERT INTO items (id, owner_id, name) VALUES ('i1', 'o1', 'Lamp')`)
+	c.Assert(err, qt.IsNil)
+
+	// v2 adds a column to owners, which SQLite can only do by rebuilding
+	// the table, swaps an index on items and adds a table.
+	diff, err = sqlite.Diff(ctx, db, v2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff.Up, qt.Contains, "DROP TABLE owners")
+	c.Assert(diff.Up, qt.Contains, "DROP INDEX IF EXISTS idx_items_name")
+	c.Assert(diff.Up, qt.Contains, "CREATE INDEX idx_items_owner_name ON items (owner_id, name)")
+	migrate(diff.Up)
+	assertNoDiff(c, db, v2)
+
+	// The rebuild keeps the rows, and dropping the old owners table did
+	// not cascade into items.
+	var owner, item string
+	c.Assert(db.QueryRowContext(ctx, `SELECT name FROM owners WHERE id = 'o1'`).Scan(&owner), qt.IsNil)
+	c.Assert(owner, qt.Equals, "Alice")
+	c.Assert(db.QueryRowContext(ctx, `SELECT name FROM items WHERE owner_id = 'o1'`).Scan(&item), qt.IsNil)
+	c.Assert(item, qt.Equals, "Lamp")
+
+	// Down brings the v1 schema back, data included.
+	migrate(diff.Down)
+	assertNoDiff(c, db, v1)
+	c.Assert(db.QueryRowContext(ctx, `SELECT name FROM items WHERE owner_id = 'o1'`).Scan(&item), qt.IsNil)
+	c.Assert(item, qt.Equals, "Lamp")
+}
+
+func TestDiff_DroppedTable(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	db := openTestDB(c)
+	migrate := migrationRunner(c, db)
+
+	diff, err := sqlite.Diff(ctx, db, testSchema(true))
+	c.Assert(err, qt.IsNil)
+	migrate(diff.Up)
+
+	diff, err = sqlite.Diff(ctx, db, testSchema(false))
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff.Up, qt.Contains, "-- WARNING: This will delete all data!\nDROP TABLE IF EXISTS labels")
+	migrate(diff.Up)
+	assertNoDiff(c, db, testSchema(false))
+
+	migrate(diff.Down)
+	assertNoDiff(c, db, testSchema(true))
+}
+
+func TestDiff_ForeignKeyViolation(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	db := openTestDB(c)
+
+	diff, err := sqlite.Diff(ctx, db, testSchema(false))
+	c.Assert(err, qt.IsNil)
+	migrationRunner(c, db)(diff.Up)
+
+	// Foreign keys are off while a migration runs, so a migration that
+	// leaves a dangling reference must be caught before it commits.
+	_, err = sqlite.MigrateUp(ctx, db, fstest.MapFS{
+		"2000000000_orphan.up.sql": {Data: []byte(`INSERT INTO items (id, owner_id, name) VALUES ('i1', 'missing', 'Lamp')`)},
+	})
+	c.Assert(err, qt.ErrorIs, sqlite.ErrForeignKeyViolation)
+
+	var count int
+	c.Assert(db.QueryRowContext(ctx, `SELECT COUNT(*) FROM items`).Scan(&count), qt.IsNil)
+	c.Assert(count, qt.Equals, 0)
+	version, err := sqlite.CurrentVersion(ctx, db)
+	c.Assert(err, qt.IsNil)
+	c.Assert(version, qt.Not(qt.Equals), int64(2000000000))
+}
+
+// testSchema is a small annotated schema: owners and items referencing
+// them. The changed variant adds owners.email, replaces the items name
+// index with a composite one and adds a labels table.
+func testSchema(changed bool) *goschema.Database {
+	db := &goschema.Database{
+		Tables: []goschema.Table{
+			{StructName: "Owner", Name: "owners"},
+			{StructName: "Item", Name: "items"},
+		},
+		Fields: []goschema.Field{
+			{StructName: "Owner", Name: "id", Type: "TEXT", Primary: true},
+			{StructName: "Owner", Name: "name", Type: "TEXT"},
+			{StructName: "Item", Name: "id", Type: "TEXT", Primary: true},
+			{StructName: "Item", Name: "owner_id", Type: "TEXT", Foreign: "owners(id)", OnDelete: "CASCADE"},
+			{StructName: "Item", Name: "name", Type: "TEXT"},
+		},
+	}
+	if !changed {
+		db.Indexes = []goschema.Index{
+			{StructName: "Item", TableName: "items", Name: "idx_items_name", Fields: []string{"name"}},
+		}
+		return db
+	}
+
+	db.Tables = append(db.Tables, goschema.Table{StructName: "Label", Name: "labels"})
+	db.Fields = append(db.Fields,
+		goschema.Field{StructName: "Owner", Name: "email", Type: "TEXT", Nullable: true},
+		goschema.Field{StructName: "Label", Name: "id", Type: "TEXT", Primary: true},
+	)
+	db.Indexes = []goschema.Index{
+		{StructName: "Item", TableName: "items", Name: "idx_items_owner_name", Fields: []string{"owner_id", "name"}},
+	}
+	return db
+}
+
+// migrationRunner returns a function that applies statements the way the
+// generated files are applied: as the next migration through MigrateUp.
+func migrationRunner(c *qt.C, db *sql.DB) func(statements []string) {
+	version := 1000000000
+	return func(statements []string) {
+		version++
+		name := fmt.Sprintf("%d_step.up.sql", version)
+		_, err := sqlite.MigrateUp(context.Background(), db, fstest.MapFS{
+			name: {Data: []byte(strings.Join(statements, ";\n") + ";")},
+		})
+		c.Assert(err, qt.IsNil, qt.Commentf("statements: %s", strings.Join(statements, ";\n")))
+	}
+}
+
+func assertNoDiff(c *qt.C, db *sql.DB, goSchema *goschema.Database) {
+	c.Helper()
+	diff, err := sqlite.Diff(context.Background(), db, goSchema)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff.Up, qt.HasLen, 0)
+	c.Assert(diff.Down, qt.HasLen, 0)
+}
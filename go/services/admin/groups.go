@@ -0,0 +1,512 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jellydator/validation"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// Location-group action names written by the groups CLI. The member and
+// delete actions reuse the literals of the admin REST surface
+// (apiserver/admin_groups.go, apiserver/admin_group_members.go) so audit
+// consumers see one action per operation whichever plane performed it.
+const (
+	auditActionGroupCreate            = "admin.group_create"
+	auditActionGroupUpdate            = "admin.group_update"
+	auditActionGroupDelete            = "admin.delete_group"
+	auditActionGroupRestore           = "admin.group_restore_pending_deletion"
+	auditActionGroupMemberAdd         = "admin.group_member_add"
+	auditActionGroupMemberRemove      = "admin.group_member_remove"
+	auditActionGroupMemberRoleChange  = "admin.group_member_role_change"
+	auditActionGroupTransferOwnership = "admin.group_transfer_ownership"
+)
+
+var (
+	// ErrGroupNotPendingDeletion is returned by RestorePendingDeletionGroup
+	// for a group that is not (or no longer) awaiting the purge worker.
+	ErrGroupNotPendingDeletion = errx.NewSentinel("group is not pending deletion")
+
+	// ErrAmbiguousGroupSlug is returned when a slug lookup matches groups in
+	// more than one tenant; the caller must use the group ID instead.
+	ErrAmbiguousGroupSlug = errx.NewSentinel("group slug matches groups in several tenants")
+
+	// ErrTenantMismatch is returned when the user named for a group lives in
+	// another tenant than the group. Re-exported so CLI callers don't have to
+	// import the services package to branch on it.
+	ErrTenantMismatch = services.ErrTenantMismatch
+)
+
+// GroupListRequest represents a group listing request. Tenant accepts an ID
+// or a slug.
+type GroupListRequest struct {
+	Tenant  string
+	Status  string
+	Search  string
+	Page    int
+	PerPage int
+}
+
+// GroupListResponse represents a group listing response.
+type GroupListResponse struct {
+	Groups     []*registry.AdminGroupListItem
+	TotalCount int
+}
+
+// GroupCreateRequest represents a group creation request. Tenant accepts an
+// ID or a slug, Owner a user ID or email.
+type GroupCreateRequest struct {
+	Tenant      string
+	Owner       string
+	Name        string
+	Icon        string
+	Description string
+	Currency    string
+}
+
+// GroupUpdateRequest represents a group update request. Nil fields are left
+// unchanged.
+type GroupUpdateRequest struct {
+	Name        *string
+	Icon        *string
+	Description *string
+}
+
+// groupService builds the GroupService the membership operations delegate
+// to, so the CLI enforces exactly the invariants the HTTP surfaces do (≥1
+// owner, ≥1 member, the per-user membership cap, default-group promotion).
+func (s *Service) groupService() *services.GroupService {
+	gs := services.NewGroupService(
+		s.factorySet.LocationGroupRegistry,
+		s.factorySet.GroupMembershipRegistry,
+		s.factorySet.GroupInviteRegistry,
+	)
+	gs.SetUserRegistry(s.factorySet.UserRegistry)
+	gs.SetLocationScopeRegistries(s.factorySet.GroupMemberLocationScopeRegistry, s.factorySet.LocationRegistryFactory)
+	return gs
+}
+
+// ListGroups lists groups across tenants with filtering and pagination.
+func (s *Service) ListGroups(ctx context.Context, req GroupListRequest) (*GroupListResponse, error) {
+	opts := registry.AdminGroupListOptions{
+		Page:    req.Page,
+		PerPage: req.PerPage,
+		Query:   req.Search,
+		Status:  req.Status,
+	}
+	if req.Tenant != "" {
+		tenant, err := s.GetTenant(ctx, req.Tenant)
+		if err != nil {
+			return nil, err
+		}
+		opts.TenantID = tenant.ID
+	}
+
+	items, total, err := s.factorySet.LocationGroupRegistry.ListAdmin(ctx, opts)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list groups", err)
+	}
+	return &GroupListResponse{Groups: items, TotalCount: total}, nil
+}
+
+// GetGroup retrieves a group by ID or slug, with its member count and owning
+// tenant. Slugs are only unique per tenant, so a slug shared across tenants
+// is rejected with ErrAmbiguousGroupSlug.
+func (s *Service) GetGroup(ctx context.Context, idOrSlug string) (*registry.AdminGroupDetail, error) {
+	detail, err := s.factorySet.LocationGroupRegistry.GetAdmin(ctx, idOrSlug)
+	if err == nil {
+		return detail, nil
+	}
+	if !errors.Is(err, registry.ErrNotFound) {
+		return nil, errxtrace.Wrap("failed to get group", err)
+	}
+
+	items, _, err := s.factorySet.LocationGroupRegistry.ListAdmin(ctx, registry.AdminGroupListOptions{
+		Query:   idOrSlug,
+		PerPage: 100,
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to look up group by slug", err)
+	}
+	var match *registry.AdminGroupListItem
+	for _, item := range items {
+		if item.Group.Slug != idOrSlug {
+			continue
+		}
+		if match != nil {
+			return nil, errxtrace.Classify(ErrAmbiguousGroupSlug, errx.Attrs("slug", idOrSlug))
+		}
+		match = item
+	}
+	if match == nil {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "LocationGroup", "id_or_slug", idOrSlug))
+	}
+	return &registry.AdminGroupDetail{Group: match.Group, MemberCount: match.MemberCount, Tenant: match.Tenant}, nil
+}
+
+// getActiveGroup is GetGroup for the mutating operations: a pending_deletion
+// group only accepts RestorePendingDeletionGroup, as in GroupService.
+func (s *Service) getActiveGroup(ctx context.Context, idOrSlug string) (*models.LocationGroup, error) {
+	detail, err := s.GetGroup(ctx, idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !detail.Group.IsActive() {
+		return detail.Group, errxtrace.Classify(services.ErrGroupNotActive, errx.Attrs("group_id", detail.Group.ID))
+	}
+	return detail.Group, nil
+}
+
+// CreateGroup creates a group in a tenant with the given user as its sole
+// owner, through GroupService.CreateGroup (membership cap included).
+func (s *Service) CreateGroup(ctx context.Context, req GroupCreateRequest) (*models.LocationGroup, error) {
+	group, err := s.createGroup(ctx, req)
+	var tenantID *string
+	groupID := ""
+	if group != nil {
+		tenantID = &group.TenantID
+		groupID = group.ID
+	}
+	s.logGroupAction(ctx, auditActionGroupCreate, tenantID, "group", groupID, map[string]any{"name": req.Name}, err)
+	return group, err
+}
+
+func (s *Service) createGroup(ctx context.Context, req GroupCreateRequest) (*models.LocationGroup, error) {
+	if err := validateGroupAttributes(ctx, req.Name, req.Icon, req.Description); err != nil {
+		return nil, err
+	}
+	currency := models.Currency(strings.ToUpper(req.Currency))
+	if currency != "" && !currency.IsValid() {
+		return nil, errxtrace.Classify(registry.ErrInvalidInput, errx.Attrs("field", "currency", "value", req.Currency))
+	}
+
+	tenant, err := s.GetTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := s.GetUser(ctx, req.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if owner.TenantID != tenant.ID {
+		return nil, errxtrace.Classify(ErrTenantMismatch, errx.Attrs("user_id", owner.ID, "tenant_id", tenant.ID))
+	}
+
+	group, err := s.groupService().CreateGroup(ctx, tenant.ID, owner.ID, req.Name, req.Icon, req.Description, currency)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// UpdateGroup changes a group's name, icon or description. The group
+// currency is immutable, as on the HTTP surface.
+func (s *Service) UpdateGroup(ctx context.Context, idOrSlug string, req GroupUpdateRequest) (*models.LocationGroup, error) {
+	group, err := s.getActiveGroup(ctx, idOrSlug)
+	if err != nil {
+		s.logGroupActionOn(ctx, auditActionGroupUpdate, group, idOrSlug, nil, err)
+		return nil, err
+	}
+
+	name, icon, description := group.Name, group.Icon, group.Description
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.Icon != nil {
+		icon = *req.Icon
+	}
+	if req.Description != nil {
+		description = *req.Description
+	}
+	if err := validateGroupAttributes(ctx, name, icon, description); err != nil {
+		s.logGroupActionOn(ctx, auditActionGroupUpdate, group, idOrSlug, nil, err)
+		return nil, err
+	}
+
+	updated, err := s.groupService().UpdateGroup(ctx, group.ID, name, icon, description)
+	s.logGroupActionOn(ctx, auditActionGroupUpdate, group, idOrSlug, nil, err)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update group", err)
+	}
+	return updated, nil
+}
+
+// DeleteGroup soft-deletes a group: it is marked pending_deletion and the
+// group purge worker hard-deletes it on its next sweep. Idempotent —
+// alreadyPending reports a group that was already marked.
+func (s *Service) DeleteGroup(ctx context.Context, idOrSlug string) (detail *registry.AdminGroupDetail, alreadyPending bool, err error) {
+	found, err := s.GetGroup(ctx, idOrSlug)
+	if err != nil {
+		s.logGroupActionOn(ctx, auditActionGroupDelete, nil, idOrSlug, nil, err)
+		return nil, false, err
+	}
+
+	detail, alreadyPending, err = s.factorySet.LocationGroupRegistry.MarkPendingDeletionAdmin(ctx, found.Group.ID)
+	s.logGroupActionOn(ctx, auditActionGroupDelete, found.Group, idOrSlug, map[string]any{"already_pending": alreadyPending}, err)
+	if err != nil {
+		return nil, false, errxtrace.Wrap("failed to mark group for deletion", err)
+	}
+	return detail, alreadyPending, nil
+}
+
+// RestorePendingDeletionGroup returns a pending_deletion group to active. It
+// only helps before the purge worker has run: a purged group is gone and
+// surfaces as not found.
+func (s *Service) RestorePendingDeletionGroup(ctx context.Context, idOrSlug string) (*models.LocationGroup, error) {
+	detail, err := s.GetGroup(ctx, idOrSlug)
+	if err != nil {
+		s.logGroupActionOn(ctx, auditActionGroupRestore, nil, idOrSlug, nil, err)
+		return nil, err
+	}
+	group := detail.Group
+	if group.Status != models.LocationGroupStatusPendingDeletion {
+		err := errxtrace.Classify(ErrGroupNotPendingDeletion, errx.Attrs("group_id", group.ID, "status", group.Status))
+		s.logGroupActionOn(ctx, auditActionGroupRestore, group, idOrSlug, nil, err)
+		return nil, err
+	}
+
+	group.Status = models.LocationGroupStatusActive
+	group.UpdatedAt = time.Now()
+	restored, err := s.factorySet.LocationGroupRegistry.Update(ctx, *group)
+	s.logGroupActionOn(ctx, auditActionGroupRestore, group, idOrSlug, nil, err)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to restore group", err)
+	}
+	return restored, nil
+}
+
+// ListGroupMembers returns a group with its memberships joined to their
+// users.
+func (s *Service) ListGroupMembers(ctx context.Context, idOrSlug string) (*registry.AdminGroupDetail, []*models.MembershipWithUser, error) {
+	detail, err := s.GetGroup(ctx, idOrSlug)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := s.groupService().AdminListMembersWithUsers(ctx, detail.Group.ID)
+	if err != nil {
+		return nil, nil, errxtrace.Wrap("failed to list group members", err)
+	}
+	return detail, members, nil
+}
+
+// AddGroupMember adds a user (ID or email) of the group's tenant with the
+// given role, through GroupService.AdminAddMember.
+func (s *Service) AddGroupMember(ctx context.Context, groupIDOrSlug, userIDOrEmail string, role models.GroupRole) (*models.GroupMembership, error) {
+	group, user, err := s.resolveGroupAndUser(ctx, groupIDOrSlug, userIDOrEmail)
+	if err == nil {
+		err = role.Validate()
+	}
+	var membership *models.GroupMembership
+	if err == nil {
+		membership, err = s.groupService().AdminAddMember(ctx, group.ID, group.TenantID, user.ID, user.TenantID, role)
+	}
+	s.logMemberAction(ctx, auditActionGroupMemberAdd, group, user, userIDOrEmail, role, err)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// RemoveGroupMember removes a member. Removing the last owner or the last
+// member is rejected (services.ErrLastOwner / services.ErrLastMember).
+func (s *Service) RemoveGroupMember(ctx context.Context, groupIDOrSlug, userIDOrEmail string) error {
+	group, user, err := s.resolveGroupAndUser(ctx, groupIDOrSlug, userIDOrEmail)
+	if err == nil {
+		err = s.groupService().RemoveMember(ctx, group.ID, user.ID)
+	}
+	s.logMemberAction(ctx, auditActionGroupMemberRemove, group, user, userIDOrEmail, "", err)
+	return err
+}
+
+// SetGroupMemberRole changes a member's role. Demoting the last owner is
+// rejected with services.ErrLastOwner.
+func (s *Service) SetGroupMemberRole(ctx context.Context, groupIDOrSlug, userIDOrEmail string, role models.GroupRole) (*models.GroupMembership, error) {
+	group, user, err := s.resolveGroupAndUser(ctx, groupIDOrSlug, userIDOrEmail)
+	if err == nil {
+		err = role.Validate()
+	}
+	var membership *models.GroupMembership
+	if err == nil {
+		membership, err = s.groupService().UpdateMemberRole(ctx, group.ID, user.ID, role)
+	}
+	s.logMemberAction(ctx, auditActionGroupMemberRoleChange, group, user, userIDOrEmail, role, err)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// TransferGroupOwnership makes an existing member the owner and demotes
+// every other owner to demoteTo. The new owner is promoted first, so the
+// group never passes through a state without an owner; a failed demotion
+// leaves the group with several owners, never none. demoteTo == owner keeps
+// the previous owners as co-owners.
+func (s *Service) TransferGroupOwnership(
+	ctx context.Context,
+	groupIDOrSlug, userIDOrEmail string,
+	demoteTo models.GroupRole,
+) (owner *models.GroupMembership, demoted []*models.GroupMembership, err error) {
+	group, user, err := s.resolveGroupAndUser(ctx, groupIDOrSlug, userIDOrEmail)
+	if err == nil {
+		err = demoteTo.Validate()
+	}
+	if err == nil {
+		owner, demoted, err = s.transferGroupOwnership(ctx, group, user, demoteTo)
+	}
+	s.logMemberAction(ctx, auditActionGroupTransferOwnership, group, user, userIDOrEmail, demoteTo, err)
+	return owner, demoted, err
+}
+
+func (s *Service) transferGroupOwnership(
+	ctx context.Context,
+	group *models.LocationGroup,
+	user *models.User,
+	demoteTo models.GroupRole,
+) (*models.GroupMembership, []*models.GroupMembership, error) {
+	gs := s.groupService()
+	owner, err := gs.UpdateMemberRole(ctx, group.ID, user.ID, models.GroupRoleOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if demoteTo == models.GroupRoleOwner {
+		return owner, nil, nil
+	}
+
+	members, err := gs.ListMembers(ctx, group.ID)
+	if err != nil {
+		return owner, nil, errxtrace.Wrap("failed to list group members", err)
+	}
+	var demoted []*models.GroupMembership
+	for _, m := range members {
+		if m.Role != models.GroupRoleOwner || m.MemberUserID == user.ID {
+			continue
+		}
+		updated, err := gs.UpdateMemberRole(ctx, group.ID, m.MemberUserID, demoteTo)
+		if err != nil {
+			return owner, demoted, errxtrace.Wrap("failed to demote previous owner", err, errx.Attrs("user_id", m.MemberUserID))
+		}
+		demoted = append(demoted, updated)
+	}
+	return owner, demoted, nil
+}
+
+// resolveGroupAndUser resolves the active group and the user a membership
+// operation targets. Whatever was resolved is returned alongside an error so
+// the audit row can still name it.
+func (s *Service) resolveGroupAndUser(ctx context.Context, groupIDOrSlug, userIDOrEmail string) (*models.LocationGroup, *models.User, error) {
+	group, err := s.getActiveGroup(ctx, groupIDOrSlug)
+	if err != nil {
+		return group, nil, err
+	}
+	user, err := s.GetUser(ctx, userIDOrEmail)
+	if err != nil {
+		return group, nil, err
+	}
+	return group, user, nil
+}
+
+// validateGroupAttributes applies the rules the HTTP create/update request
+// enforces on the user-settable group fields.
+func validateGroupAttributes(ctx context.Context, name, icon, description string) error {
+	attrs := struct {
+		Name        string
+		Icon        string
+		Description string
+	}{name, icon, description}
+	return validation.ValidateStructWithContext(ctx, &attrs,
+		validation.Field(&attrs.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&attrs.Icon, validation.By(func(any) error {
+			if attrs.Icon != "" && !models.IsValidGroupIcon(attrs.Icon) {
+				return validation.NewError("validation_invalid_group_icon", "icon must be one of the supported icons")
+			}
+			return nil
+		})),
+		validation.Field(&attrs.Description, validation.Length(0, 200)),
+	)
+}
+
+// logGroupActionOn writes a group audit row for group, falling back to the
+// operator-supplied identifier when the group could not be resolved.
+func (s *Service) logGroupActionOn(ctx context.Context, action string, group *models.LocationGroup, idOrSlug string, extra map[string]any, opErr error) {
+	if group == nil {
+		s.logGroupAction(ctx, action, nil, "group", idOrSlug, extra, opErr)
+		return
+	}
+	s.logGroupAction(ctx, action, &group.TenantID, "group", group.ID, extra, opErr)
+}
+
+// logMemberAction writes a membership audit row in the shape the admin REST
+// surface uses: the target user is the subject, the group ID (and role, when
+// one applies) ride in the breadcrumb.
+func (s *Service) logMemberAction(
+	ctx context.Context,
+	action string,
+	group *models.LocationGroup,
+	user *models.User,
+	userIDOrEmail string,
+	role models.GroupRole,
+	opErr error,
+) {
+	var tenantID *string
+	extra := map[string]any{}
+	if group != nil {
+		tenantID = &group.TenantID
+		extra["group_id"] = group.ID
+	}
+	if role != "" {
+		extra["role"] = string(role)
+	}
+	subjectID := userIDOrEmail
+	if user != nil {
+		subjectID = user.ID
+	}
+	s.logGroupAction(ctx, action, tenantID, "user", subjectID, extra, opErr)
+}
+
+// logGroupAction writes a group admin audit row through
+// AuditService.LogAdmin, whose breadcrumb carries the extra fields. The
+// registration decisions reuse it for their rows. Same
+// best-effort, actor-less shape as logAdminAction: the CLI has no
+// authenticated operator row in this database.
+func (s *Service) logGroupAction(
+	ctx context.Context,
+	action string,
+	tenantID *string,
+	subjectType, subjectID string,
+	extra map[string]any,
+	opErr error,
+) {
+	if s.factorySet == nil || s.factorySet.AuditLogRegistry == nil {
+		return
+	}
+
+	ev := services.AdminEvent{
+		Action:   action,
+		TenantID: tenantID,
+		Success:  opErr == nil,
+		Extra:    extra,
+	}
+	if subjectID != "" {
+		ev.SubjectType = &subjectType
+		ev.SubjectID = &subjectID
+	}
+	if opErr != nil {
+		msg := opErr.Error()
+		ev.ErrMsg = &msg
+	}
+	if len(extra) == 0 {
+		ev.Extra = nil
+	}
+
+	services.NewAuditService(s.factorySet.AuditLogRegistry).LogAdmin(ctx, ev)
+	slog.Debug("Group admin action", "action", action, "subject_id", subjectID, "success", opErr == nil)
+}
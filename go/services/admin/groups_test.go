@@ -0,0 +1,145 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+)
+
+type groupFixture struct {
+	svc   *Service
+	owner *models.User
+	other *models.User
+	group *models.LocationGroup
+}
+
+func newGroupFixture(c *qt.C) *groupFixture {
+	ctx := context.Background()
+	fs := memory.NewFactorySet()
+
+	tenant, err := fs.TenantRegistry.Create(ctx, models.Tenant{Name: "Acme", Slug: "acme", Status: models.TenantStatusActive})
+	c.Assert(err, qt.IsNil)
+	newUser := func(email string) *models.User {
+		u, err := fs.UserRegistry.Create(ctx, models.User{
+			TenantAwareEntityID: models.TenantAwareEntityID{TenantID: tenant.ID},
+			Email:               email,
+			Name:                email,
+			IsActive:            true,
+		})
+		c.Assert(err, qt.IsNil)
+		return u
+	}
+
+	f := &groupFixture{
+		svc:   &Service{factorySet: fs},
+		owner: newUser("owner@example.com"),
+		other: newUser("other@example.com"),
+	}
+	f.group, err = f.svc.CreateGroup(ctx, GroupCreateRequest{
+		Tenant:   "acme",
+		Owner:    "owner@example.com",
+		Name:     "Home",
+		Currency: "usd",
+	})
+	c.Assert(err, qt.IsNil)
+	return f
+}
+
+func (f *groupFixture) role(c *qt.C, userID string) models.GroupRole {
+	_, members, err := f.svc.ListGroupMembers(context.Background(), f.group.ID)
+	c.Assert(err, qt.IsNil)
+	for _, m := range members {
+		if m.Membership.MemberUserID == userID {
+			return m.Membership.Role
+		}
+	}
+	return ""
+}
+
+func TestService_CreateGroup_MakesOwnerAndAudits(t *testing.T) {
+	c := qt.New(t)
+	f := newGroupFixture(c)
+
+	c.Assert(f.group.GroupCurrency, qt.Equals, models.Currency("USD"))
+	c.Assert(f.role(c, f.owner.ID), qt.Equals, models.GroupRoleOwner)
+
+	entries, err := f.svc.factorySet.AuditLogRegistry.List(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 1)
+	c.Assert(entries[0].Action, qt.Equals, "admin.group_create")
+	c.Assert(entries[0].Success, qt.IsTrue)
+	c.Assert(entries[0].UserID, qt.IsNil)
+	c.Assert(*entries[0].EntityID, qt.Equals, f.group.ID)
+}
+
+func TestService_GroupMembers_KeepLastOwner(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	f := newGroupFixture(c)
+
+	_, err := f.svc.SetGroupMemberRole(ctx, f.group.ID, f.owner.Email, models.GroupRoleAdmin)
+	c.Assert(err, qt.ErrorIs, services.ErrLastOwner)
+
+	_, err = f.svc.AddGroupMember(ctx, f.group.Slug, f.other.Email, models.GroupRoleUser)
+	c.Assert(err, qt.IsNil)
+	_, err = f.svc.AddGroupMember(ctx, f.group.Slug, f.other.Email, models.GroupRoleUser)
+	c.Assert(err, qt.ErrorIs, services.ErrAlreadyMember)
+
+	err = f.svc.RemoveGroupMember(ctx, f.group.ID, f.owner.ID)
+	c.Assert(err, qt.ErrorIs, services.ErrLastOwner)
+	c.Assert(f.svc.RemoveGroupMember(ctx, f.group.ID, f.other.ID), qt.IsNil)
+}
+
+func TestService_TransferGroupOwnership(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	f := newGroupFixture(c)
+
+	_, _, err := f.svc.TransferGroupOwnership(ctx, f.group.ID, f.other.ID, models.GroupRoleAdmin)
+	c.Assert(err, qt.ErrorIs, services.ErrNotGroupMember)
+
+	_, err = f.svc.AddGroupMember(ctx, f.group.ID, f.other.ID, models.GroupRoleViewer)
+	c.Assert(err, qt.IsNil)
+
+	owner, demoted, err := f.svc.TransferGroupOwnership(ctx, f.group.ID, f.other.ID, models.GroupRoleAdmin)
+	c.Assert(err, qt.IsNil)
+	c.Assert(owner.Role, qt.Equals, models.GroupRoleOwner)
+	c.Assert(demoted, qt.HasLen, 1)
+	c.Assert(f.role(c, f.other.ID), qt.Equals, models.GroupRoleOwner)
+	c.Assert(f.role(c, f.owner.ID), qt.Equals, models.GroupRoleAdmin)
+}
+
+func TestService_DeleteAndRestoreGroup(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	f := newGroupFixture(c)
+
+	_, err := f.svc.RestorePendingDeletionGroup(ctx, f.group.ID)
+	c.Assert(err, qt.ErrorIs, ErrGroupNotPendingDeletion)
+
+	_, alreadyPending, err := f.svc.DeleteGroup(ctx, f.group.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(alreadyPending, qt.IsFalse)
+	_, alreadyPending, err = f.svc.DeleteGroup(ctx, f.group.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(alreadyPending, qt.IsTrue)
+
+	name := "Renamed"
+	_, err = f.svc.UpdateGroup(ctx, f.group.ID, GroupUpdateRequest{Name: &name})
+	c.Assert(err, qt.ErrorIs, services.ErrGroupNotActive)
+	_, err = f.svc.AddGroupMember(ctx, f.group.ID, f.other.ID, models.GroupRoleUser)
+	c.Assert(err, qt.ErrorIs, services.ErrGroupNotActive)
+
+	restored, err := f.svc.RestorePendingDeletionGroup(ctx, f.group.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(restored.Status, qt.Equals, models.LocationGroupStatusActive)
+
+	updated, err := f.svc.UpdateGroup(ctx, f.group.ID, GroupUpdateRequest{Name: &name})
+	c.Assert(err, qt.IsNil)
+	c.Assert(updated.Name, qt.Equals, "Renamed")
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"context"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// Registration approval action names written by the users registrations
+// CLI. Same literals as the admin REST surface
+// (apiserver/admin_registrations.go).
+const (
+	auditActionRegistrationApprove = "admin.registration_approve"
+	auditActionRegistrationReject  = "admin.registration_reject"
+)
+
+// ErrRegistrationAlreadyDecided is re-exported so CLI callers don't have
+// to import the services package to branch on it.
+var ErrRegistrationAlreadyDecided = services.ErrRegistrationAlreadyDecided
+
+// registrationApprovals builds the approval service the CLI decides
+// through. It has no EmailService: the CLI has no mail transport, so the
+// applicant is not notified (the command says so).
+func (s *Service) registrationApprovals() *services.RegistrationApprovalService {
+	return services.NewRegistrationApprovalService(s.factorySet, nil, "")
+}
+
+// ListRegistrations returns the approval queue, oldest first. tenant
+// accepts an ID or a slug and may be empty; an empty status lists every
+// status.
+func (s *Service) ListRegistrations(ctx context.Context, tenant string, status models.RegistrationRequestStatus) ([]*models.RegistrationRequest, error) {
+	filter := registry.RegistrationRequestFilter{Status: status}
+	if tenant != "" {
+		t, err := s.GetTenant(ctx, tenant)
+		if err != nil {
+			return nil, err
+		}
+		filter.TenantID = t.ID
+	}
+	requests, err := s.registrationApprovals().List(ctx, filter)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list registration requests", err)
+	}
+	return requests, nil
+}
+
+// GetRegistration returns a single registration request by id.
+func (s *Service) GetRegistration(ctx context.Context, id string) (*models.RegistrationRequest, error) {
+	req, err := s.factorySet.RegistrationRequestRegistry.Get(ctx, id)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get registration request", err)
+	}
+	return req, nil
+}
+
+// ApproveRegistration approves a pending request and activates its user.
+// reason is optional.
+func (s *Service) ApproveRegistration(ctx context.Context, id, reason string) (*models.RegistrationRequest, error) {
+	req, err := s.registrationApprovals().Approve(ctx, id, &reason, nil)
+	s.logRegistrationAction(ctx, auditActionRegistrationApprove, req, id, reason, err)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// RejectRegistration rejects a pending request. reason is required
+// (services.ErrRegistrationReasonRequired).
+func (s *Service) RejectRegistration(ctx context.Context, id, reason string) (*models.RegistrationRequest, error) {
+	req, err := s.registrationApprovals().Reject(ctx, id, reason, nil)
+	s.logRegistrationAction(ctx, auditActionRegistrationReject, req, id, reason, err)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// logRegistrationAction writes a decision audit row in the shape the
+// admin REST surface uses: the applicant's user is the subject and the
+// request id rides in the breadcrumb. req is nil when the decision failed
+// before it was recorded; the row then names the request id only.
+func (s *Service) logRegistrationAction(ctx context.Context, action string, req *models.RegistrationRequest, id, reason string, opErr error) {
+	extra := map[string]any{"registration_request_id": id}
+	if reason != "" {
+		extra["reason"] = reason
+	}
+	if req == nil {
+		s.logGroupAction(ctx, action, nil, "", "", extra, opErr)
+		return
+	}
+	s.logGroupAction(ctx, action, &req.TenantID, "user", req.UserID, extra, opErr)
+}
@@ -830,6 +830,118 @@ func (s *Service) ListWorkerControls(ctx context.Context) ([]*models.WorkerContr
 	return controls, nil
 }
 
+// Backup-signing keyring action names. Same literals as the admin REST
+// surface (admin.backup_key_add / admin.backup_key_revoke).
+const (
+	// auditActionBackupKeyAdd is the audit-row Action emitted when an
+	// operator trusts a backup-signing key via the CLI.
+	auditActionBackupKeyAdd = "admin.backup_key_add"
+	// auditActionBackupKeyRevoke is the audit-row Action emitted when an
+	// operator revokes a trusted backup-signing key via the CLI.
+	auditActionBackupKeyRevoke = "admin.backup_key_revoke"
+)
+
+// backupKeyActorCLI is the added_by / revoked_by marker recorded for CLI
+// keyring changes (the admin REST surface records the operator's id).
+const backupKeyActorCLI = "cli"
+
+// AddTrustedBackupKey adds publicKey (PEM, base64 or hex) to the
+// backup-signing keyring under label, optionally expiring at expiresAt.
+// Imports and restores accept archives signed with the key from then on.
+// added_by is recorded as "cli", for the same reason as workerPausedByCLI. Writes an
+// `admin.backup_key_add` audit row regardless of outcome; an invalid key is
+// audited without a subject since it has no fingerprint.
+func (s *Service) AddTrustedBackupKey(ctx context.Context, publicKey, label string, expiresAt *time.Time) (*models.TrustedBackupKey, error) {
+	key, err := services.NewTrustedBackupKey(publicKey, label, expiresAt, time.Now())
+	if err != nil {
+		err = errxtrace.Classify(registry.ErrInvalidInput, errx.Attrs("reason", err.Error()))
+		s.logBackupKeyAction(ctx, auditActionBackupKeyAdd, "", err)
+		return nil, err
+	}
+
+	if s.factorySet.TrustedBackupKeyRegistry == nil {
+		configErr := errxtrace.Classify(registry.ErrInvalidConfig, errx.Attrs("missing", "TrustedBackupKeyRegistry"))
+		s.logBackupKeyAction(ctx, auditActionBackupKeyAdd, key.Fingerprint, configErr)
+		return nil, configErr
+	}
+
+	addedBy := backupKeyActorCLI
+	key.AddedBy = &addedBy
+	added, err := s.factorySet.TrustedBackupKeyRegistry.Add(ctx, key)
+	if err != nil {
+		s.logBackupKeyAction(ctx, auditActionBackupKeyAdd, key.Fingerprint, err)
+		return nil, errxtrace.Wrap("failed to add trusted backup key", err)
+	}
+
+	s.logBackupKeyAction(ctx, auditActionBackupKeyAdd, added.Fingerprint, nil)
+	return added, nil
+}
+
+// RevokeTrustedBackupKey revokes the keyring entry with the given
+// fingerprint, recording "cli" as revoked_by. Revoking an already-revoked
+// key is a no-op that keeps the original revocation. Writes an
+// `admin.backup_key_revoke` audit row regardless of outcome.
+func (s *Service) RevokeTrustedBackupKey(ctx context.Context, fingerprint, reason string) (*models.TrustedBackupKey, error) {
+	fingerprint = strings.ToLower(strings.TrimSpace(fingerprint))
+
+	if s.factorySet.TrustedBackupKeyRegistry == nil {
+		configErr := errxtrace.Classify(registry.ErrInvalidConfig, errx.Attrs("missing", "TrustedBackupKeyRegistry"))
+		s.logBackupKeyAction(ctx, auditActionBackupKeyRevoke, fingerprint, configErr)
+		return nil, configErr
+	}
+
+	revoked, err := s.factorySet.TrustedBackupKeyRegistry.Revoke(ctx, fingerprint, backupKeyActorCLI, reason)
+	if err != nil {
+		s.logBackupKeyAction(ctx, auditActionBackupKeyRevoke, fingerprint, err)
+		return nil, errxtrace.Wrap("failed to revoke trusted backup key", err)
+	}
+
+	s.logBackupKeyAction(ctx, auditActionBackupKeyRevoke, fingerprint, nil)
+	return revoked, nil
+}
+
+// ListTrustedBackupKeys returns every keyring entry, revoked and expired ones
+// included. Read-only and not audited: the keyring holds public keys only.
+func (s *Service) ListTrustedBackupKeys(ctx context.Context) ([]*models.TrustedBackupKey, error) {
+	if s.factorySet.TrustedBackupKeyRegistry == nil {
+		return nil, errxtrace.Classify(registry.ErrInvalidConfig, errx.Attrs("missing", "TrustedBackupKeyRegistry"))
+	}
+	keys, err := s.factorySet.TrustedBackupKeyRegistry.List(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list trusted backup keys", err)
+	}
+	return keys, nil
+}
+
+// logBackupKeyAction writes a keyring admin audit row with EntityType
+// "backup_key" and the key fingerprint as EntityID. Same best-effort,
+// actor-less shape as logWorkerAction.
+func (s *Service) logBackupKeyAction(ctx context.Context, action, fingerprint string, opErr error) {
+	if s.factorySet == nil || s.factorySet.AuditLogRegistry == nil {
+		return
+	}
+
+	entry := models.AuditLog{
+		Action:  action,
+		UserID:  nil, // CLI invocations have no authenticated actor — see logAdminAction doc.
+		Success: opErr == nil,
+	}
+	if fingerprint != "" {
+		subjectType := "backup_key"
+		entry.EntityType = &subjectType
+		entry.EntityID = &fingerprint
+	}
+	if opErr != nil {
+		msg := opErr.Error()
+		entry.ErrorMessage = &msg
+	}
+
+	if _, createErr := s.factorySet.AuditLogRegistry.Create(ctx, entry); createErr != nil {
+		slog.Error("Failed to write backup-key audit log entry",
+			"action", action, "fingerprint", fingerprint, "error", createErr)
+	}
+}
+
 // workerPausedByCLI is the paused_by marker recorded for CLI-driven
 // pauses (#1308). The CLI has no authenticated operator session, so a
 // coarse "cli" marker distinguishes a CLI pause from an admin-REST pause
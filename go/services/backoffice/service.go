@@ -30,10 +30,11 @@ type Service struct {
 	cleanup    func() error
 }
 
-// NewService constructs a Service backed by the postgres registry.
-// dbConfig.Validate already rejects non-postgres DSNs (memory://,
+// NewService constructs a Service backed by the postgres or sqlite
+// registry. dbConfig.Validate already rejects other DSNs (memory://,
 // file://, etc.), so by the time control reaches the registry lookup
-// the DSN is guaranteed to be postgres. Mirrors services/admin.NewService.
+// the DSN is guaranteed to be a persistent one. Mirrors
+// services/admin.NewService.
 //
 // Error messages NEVER include the raw DSN — a back-office service that
 // shells up the DSN on a "unknown scheme" surface would leak any
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/internal/backupsign"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// TrustedBackupKeyLabelMaxLen caps the operator-supplied label of a trusted
+// backup-signing key.
+const TrustedBackupKeyLabelMaxLen = 200
+
+// NewTrustedBackupKey validates an operator's request to trust a key and
+// builds the row to store. publicKey may be in any form
+// backupsign.ParsePublicKey accepts; the fingerprint and the canonical base64
+// form are derived from the parsed key, so a PEM and a hex submission of the
+// same key collide as they should. Shared by the admin API and the CLI.
+func NewTrustedBackupKey(publicKey, label string, expiresAt *time.Time, now time.Time) (models.TrustedBackupKey, error) {
+	pub, err := backupsign.ParsePublicKey([]byte(publicKey))
+	if err != nil {
+		return models.TrustedBackupKey{}, err
+	}
+	label = strings.TrimSpace(label)
+	switch {
+	case label == "":
+		return models.TrustedBackupKey{}, errors.New("label is required")
+	case utf8.RuneCountInString(label) > TrustedBackupKeyLabelMaxLen:
+		return models.TrustedBackupKey{}, errors.New("label is too long")
+	case expiresAt != nil && !expiresAt.After(now):
+		return models.TrustedBackupKey{}, errors.New("expiry must be in the future")
+	}
+	key := models.TrustedBackupKey{
+		Fingerprint: backupsign.Fingerprint(pub),
+		PublicKey:   base64.StdEncoding.EncodeToString(pub),
+		Label:       label,
+	}
+	if expiresAt != nil {
+		exp := expiresAt.UTC()
+		key.ExpiresAt = &exp
+	}
+	return key, nil
+}
+
+// BackupKeyring builds the set of keys an imported or restored `.inb`
+// archive may be signed with: the server's own signer (nil when signing is
+// not configured) plus every trusted key that is neither revoked nor expired.
+// It is rebuilt per operation so an add or a revoke applies to the next
+// import/restore without a restart.
+//
+// A FactorySet without a TrustedBackupKeyRegistry yields a keyring holding
+// only the server's own key.
+func BackupKeyring(ctx context.Context, factorySet *registry.FactorySet, signer *backupsign.Signer) (*backupsign.Keyring, error) {
+	if factorySet == nil || factorySet.TrustedBackupKeyRegistry == nil {
+		return backupsign.NewKeyring(signer), nil
+	}
+
+	keys, err := factorySet.TrustedBackupKeyRegistry.List(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list trusted backup keys", err)
+	}
+
+	now := time.Now()
+	trusted := make([]ed25519.PublicKey, 0, len(keys))
+	for _, k := range keys {
+		if !k.IsActive(now) {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(k.PublicKey)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			// Add validates the key, so this is a hand-edited row. Skip it
+			// rather than failing every import over one bad entry.
+			slog.Warn("Skipping malformed trusted backup key", "fingerprint", k.Fingerprint)
+			continue
+		}
+		trusted = append(trusted, raw)
+	}
+	return backupsign.NewKeyring(signer, trusted...), nil
+}
@@ -0,0 +1,451 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/internal/ical"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services/notifications"
+)
+
+// ErrCalendarFeedUnknownCategory is returned when a feed is created or
+// updated with a category outside CalendarFeedCategories.
+var ErrCalendarFeedUnknownCategory = errx.NewSentinel("unknown calendar feed category")
+
+// ErrCalendarFeedGroupAccess is returned when a feed is scoped to a group
+// the user is not a member of.
+var ErrCalendarFeedGroupAccess = errx.NewSentinel("not a member of the calendar feed group")
+
+// CalendarFeedCategories are the notification categories a calendar feed
+// can publish, in the order their events are documented. Each maps to one
+// date field: warranty expiry, loan due-back, service return and the next
+// maintenance due date.
+var CalendarFeedCategories = []notifications.Category{
+	notifications.CategoryWarrantyExpiry,
+	notifications.CategoryLoanReminder,
+	notifications.CategoryServiceReminder,
+	notifications.CategoryMaintenanceReminder,
+}
+
+// calendarFeedWarrantyLookback keeps recently expired warranties in the
+// feed so a subscriber still sees the event for a while after the date.
+const calendarFeedWarrantyLookback = 365 * 24 * time.Hour
+
+const calendarFeedProdID = "-//Inventario//Calendar Feed//EN"
+
+// CalendarFeedInput carries the user-settable fields of a calendar feed.
+type CalendarFeedInput struct {
+	// GroupID scopes the feed to one group; nil means all of the user's
+	// groups. Ignored on update — a feed's scope is fixed at creation.
+	GroupID    *string
+	Name       string
+	Categories []string
+}
+
+// CalendarFeedService manages per-user iCalendar subscription feeds and
+// renders them. Management calls take the authenticated user and only
+// ever touch that user's feeds; Render is driven by the URL token alone
+// and re-checks the owner's group memberships on every fetch, so a user
+// who leaves a group stops seeing its dates without touching the feed.
+type CalendarFeedService struct {
+	factorySet *registry.FactorySet
+	// commodityURLBuilder builds the deep link attached to each event.
+	// Optional — when nil, events carry no URL.
+	commodityURLBuilder func(groupSlug, commodityID string) string
+}
+
+func NewCalendarFeedService(factorySet *registry.FactorySet, urlBuilder func(groupSlug, commodityID string) string) *CalendarFeedService {
+	return &CalendarFeedService{
+		factorySet:          factorySet,
+		commodityURLBuilder: urlBuilder,
+	}
+}
+
+// List returns the user's feeds, oldest first.
+func (s *CalendarFeedService) List(ctx context.Context, user *models.User) ([]*models.CalendarFeed, error) {
+	feeds, err := s.factorySet.CalendarFeedRegistry.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list calendar feeds", err)
+	}
+	return feeds, nil
+}
+
+// Get returns one of the user's feeds. Another user's feed is reported
+// as registry.ErrNotFound so feed IDs cannot be probed.
+func (s *CalendarFeedService) Get(ctx context.Context, user *models.User, id string) (*models.CalendarFeed, error) {
+	feed, err := s.factorySet.CalendarFeedRegistry.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if feed.UserID != user.ID || feed.TenantID != user.TenantID {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "CalendarFeed", "entity_id", id))
+	}
+	return feed, nil
+}
+
+// Create stores a new feed and returns it together with the raw token.
+// The token is not recoverable afterwards; only its hash is stored.
+func (s *CalendarFeedService) Create(ctx context.Context, user *models.User, input CalendarFeedInput) (*models.CalendarFeed, string, error) {
+	if err := validateCalendarFeedCategories(input.Categories); err != nil {
+		return nil, "", err
+	}
+	if input.GroupID != nil {
+		if err := s.checkMembership(ctx, user, *input.GroupID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	token, hash, err := models.GenerateCalendarFeedToken()
+	if err != nil {
+		return nil, "", errxtrace.Wrap("failed to generate calendar feed token", err)
+	}
+
+	feed := models.CalendarFeed{
+		GroupID:    input.GroupID,
+		Name:       input.Name,
+		TokenHash:  hash,
+		Categories: input.Categories,
+	}
+	feed.TenantID = user.TenantID
+	feed.UserID = user.ID
+	if err := feed.ValidateWithContext(ctx); err != nil {
+		return nil, "", err
+	}
+
+	created, err := s.factorySet.CalendarFeedRegistry.Create(ctx, feed)
+	if err != nil {
+		return nil, "", errxtrace.Wrap("failed to create calendar feed", err)
+	}
+	return created, token, nil
+}
+
+// Update changes a feed's name and categories. The token is unchanged.
+func (s *CalendarFeedService) Update(ctx context.Context, user *models.User, id string, input CalendarFeedInput) (*models.CalendarFeed, error) {
+	if err := validateCalendarFeedCategories(input.Categories); err != nil {
+		return nil, err
+	}
+	feed, err := s.Get(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	feed.Name = input.Name
+	feed.Categories = input.Categories
+	if err := feed.ValidateWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.factorySet.CalendarFeedRegistry.Update(ctx, *feed)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update calendar feed", err)
+	}
+	return updated, nil
+}
+
+// Rotate replaces the feed's token, invalidating the old URL, and returns
+// the new raw token. Subscribers must re-subscribe with the new URL.
+func (s *CalendarFeedService) Rotate(ctx context.Context, user *models.User, id string, now time.Time) (*models.CalendarFeed, string, error) {
+	feed, err := s.Get(ctx, user, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, hash, err := models.GenerateCalendarFeedToken()
+	if err != nil {
+		return nil, "", errxtrace.Wrap("failed to generate calendar feed token", err)
+	}
+	feed.TokenHash = hash
+	feed.RotatedAt = &now
+
+	updated, err := s.factorySet.CalendarFeedRegistry.Update(ctx, *feed)
+	if err != nil {
+		return nil, "", errxtrace.Wrap("failed to rotate calendar feed token", err)
+	}
+	return updated, token, nil
+}
+
+// Revoke deletes the feed; its URL stops resolving immediately.
+func (s *CalendarFeedService) Revoke(ctx context.Context, user *models.User, id string) error {
+	if _, err := s.Get(ctx, user, id); err != nil {
+		return err
+	}
+	if err := s.factorySet.CalendarFeedRegistry.Delete(ctx, id); err != nil {
+		return errxtrace.Wrap("failed to revoke calendar feed", err)
+	}
+	return nil
+}
+
+// Render resolves a raw feed token and builds the calendar it publishes.
+// An unknown token, or one whose owner is gone or deactivated, yields
+// registry.ErrNotFound. A group-scoped feed whose owner is no longer a
+// member renders as an empty calendar rather than an error, so clients
+// keep polling quietly instead of surfacing sync failures.
+func (s *CalendarFeedService) Render(ctx context.Context, token string, now time.Time) (*ical.Calendar, error) {
+	if token == "" {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "CalendarFeed"))
+	}
+	feed, err := s.factorySet.CalendarFeedRegistry.GetByTokenHash(ctx, models.HashCalendarFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.factorySet.UserRegistry.Get(ctx, feed.UserID)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "CalendarFeed"))
+		}
+		return nil, errxtrace.Wrap("failed to load calendar feed owner", err)
+	}
+	if !user.IsActive {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("entity_type", "CalendarFeed"))
+	}
+
+	groups, err := s.feedGroups(ctx, user, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &ical.Calendar{ProdID: calendarFeedProdID, Name: "Inventario"}
+	if feed.Name != "" {
+		cal.Name = "Inventario — " + feed.Name
+	} else if feed.GroupID != nil && len(groups) == 1 {
+		cal.Name = "Inventario — " + groups[0].Name
+	}
+
+	for _, group := range groups {
+		events, err := s.groupEvents(ctx, user, group, feed, now)
+		if err != nil {
+			return nil, err
+		}
+		cal.Events = append(cal.Events, events...)
+	}
+	sort.SliceStable(cal.Events, func(i, j int) bool {
+		if !cal.Events[i].Date.Equal(cal.Events[j].Date) {
+			return cal.Events[i].Date.Before(cal.Events[j].Date)
+		}
+		return cal.Events[i].UID < cal.Events[j].UID
+	})
+
+	feed.LastUsedAt = &now
+	if _, err := s.factorySet.CalendarFeedRegistry.Update(ctx, *feed); err != nil {
+		// Bookkeeping only; the feed itself is still served.
+		slog.Warn("calendar feed: failed to record last use", "feed_id", feed.ID, "error", err)
+	}
+	return cal, nil
+}
+
+// feedGroups returns the active groups the feed covers that the user is
+// still a member of.
+func (s *CalendarFeedService) feedGroups(ctx context.Context, user *models.User, feed *models.CalendarFeed) ([]*models.LocationGroup, error) {
+	var groupIDs []string
+	if feed.GroupID != nil {
+		groupIDs = []string{*feed.GroupID}
+	} else {
+		memberships, err := s.factorySet.GroupMembershipRegistry.ListByUser(ctx, user.TenantID, user.ID)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list group memberships", err)
+		}
+		for _, m := range memberships {
+			groupIDs = append(groupIDs, m.GroupID)
+		}
+	}
+
+	var groups []*models.LocationGroup
+	for _, groupID := range groupIDs {
+		if err := s.checkMembership(ctx, user, groupID); err != nil {
+			if errors.Is(err, ErrCalendarFeedGroupAccess) {
+				continue
+			}
+			return nil, err
+		}
+		group, err := s.factorySet.LocationGroupRegistry.Get(ctx, groupID)
+		if err != nil {
+			if errors.Is(err, registry.ErrNotFound) {
+				continue
+			}
+			return nil, errxtrace.Wrap("failed to load location group", err)
+		}
+		if group.TenantID != user.TenantID || group.Status == models.LocationGroupStatusPendingDeletion {
+			continue
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (s *CalendarFeedService) checkMembership(ctx context.Context, user *models.User, groupID string) error {
+	membership, err := s.factorySet.GroupMembershipRegistry.GetByGroupAndUser(ctx, groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return errxtrace.Classify(ErrCalendarFeedGroupAccess, errx.Attrs("group_id", groupID))
+		}
+		return errxtrace.Wrap("failed to check group membership", err)
+	}
+	if membership.TenantID != user.TenantID {
+		return errxtrace.Classify(ErrCalendarFeedGroupAccess, errx.Attrs("group_id", groupID))
+	}
+	return nil
+}
+
+// groupEvents collects the feed's events for one group. The registries
+// are opened as the feed owner inside the group, so the same visibility
+// rules apply as in the UI.
+func (s *CalendarFeedService) groupEvents(ctx context.Context, user *models.User, group *models.LocationGroup, feed *models.CalendarFeed, now time.Time) ([]ical.Event, error) {
+	ctx, err := registry.MemberContext(ctx, s.factorySet.GroupMemberLocationScopeRegistry, user, group)
+	if err != nil {
+		return nil, err
+	}
+
+	commodityReg, err := s.factorySet.CommodityRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create commodity registry", err)
+	}
+	commodityList, err := commodityReg.List(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list commodities", err)
+	}
+	commodities := make(map[string]*models.Commodity, len(commodityList))
+	for _, c := range commodityList {
+		if c.Draft {
+			continue
+		}
+		commodities[c.ID] = c
+	}
+
+	b := calendarEventBuilder{stamp: now, group: group, urlBuilder: s.commodityURLBuilder}
+	var events []ical.Event
+
+	if feed.IncludesCategory(string(notifications.CategoryWarrantyExpiry)) {
+		cutoff := now.Add(-calendarFeedWarrantyLookback)
+		for _, c := range commodityList {
+			if c.Draft || c.WarrantyExpiresAt == nil || *c.WarrantyExpiresAt == "" {
+				continue
+			}
+			date := c.WarrantyExpiresAt.ToTime()
+			if date.IsZero() || date.Before(cutoff) {
+				continue
+			}
+			events = append(events, b.event(notifications.CategoryWarrantyExpiry, "warranty-"+c.ID, date,
+				"Warranty expires: "+c.Name, "", c))
+		}
+	}
+
+	if feed.IncludesCategory(string(notifications.CategoryLoanReminder)) {
+		loanReg, err := s.factorySet.CommodityLoanRegistryFactory.CreateUserRegistry(ctx)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to create loan registry", err)
+		}
+		loans, err := loanReg.List(ctx)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list loans", err)
+		}
+		for _, l := range loans {
+			c, ok := commodities[l.CommodityID]
+			if !ok || !l.IsOpen() || l.DueBackAt == nil || *l.DueBackAt == "" {
+				continue
+			}
+			date := l.DueBackAt.ToTime()
+			if date.IsZero() {
+				continue
+			}
+			events = append(events, b.event(notifications.CategoryLoanReminder, "loan-"+l.ID, date,
+				"Loan due back: "+c.Name, "Lent to "+l.BorrowerName, c))
+		}
+	}
+
+	if feed.IncludesCategory(string(notifications.CategoryServiceReminder)) {
+		serviceReg, err := s.factorySet.CommodityServiceRegistryFactory.CreateUserRegistry(ctx)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to create service registry", err)
+		}
+		serviceRows, err := serviceReg.List(ctx)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list services", err)
+		}
+		for _, sv := range serviceRows {
+			c, ok := commodities[sv.CommodityID]
+			if !ok || !sv.IsOpen() || sv.ExpectedReturnAt == nil || *sv.ExpectedReturnAt == "" {
+				continue
+			}
+			date := sv.ExpectedReturnAt.ToTime()
+			if date.IsZero() {
+				continue
+			}
+			events = append(events, b.event(notifications.CategoryServiceReminder, "service-"+sv.ID, date,
+				"Back from service: "+c.Name, "At "+sv.ProviderName, c))
+		}
+	}
+
+	if feed.IncludesCategory(string(notifications.CategoryMaintenanceReminder)) {
+		scheduleReg, err := s.factorySet.MaintenanceScheduleRegistryFactory.CreateUserRegistry(ctx)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to create maintenance schedule registry", err)
+		}
+		schedules, err := scheduleReg.List(ctx)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list maintenance schedules", err)
+		}
+		for _, ms := range schedules {
+			c, ok := commodities[ms.CommodityID]
+			if !ok || !ms.Enabled || ms.NextDueAt == "" {
+				continue
+			}
+			date := ms.NextDueAt.ToTime()
+			if date.IsZero() {
+				continue
+			}
+			events = append(events, b.event(notifications.CategoryMaintenanceReminder, "maintenance-"+ms.ID, date,
+				fmt.Sprintf("Maintenance due: %s (%s)", ms.Title, c.Name), "", c))
+		}
+	}
+
+	return events, nil
+}
+
+// calendarEventBuilder fills in the fields shared by every event of one
+// group.
+type calendarEventBuilder struct {
+	stamp      time.Time
+	group      *models.LocationGroup
+	urlBuilder func(groupSlug, commodityID string) string
+}
+
+// event builds an all-day event. uidPrefix is the kind and entity ID; the
+// UID is stable across fetches so clients update events in place.
+func (b calendarEventBuilder) event(category notifications.Category, uidPrefix string, date time.Time, summary, detail string, c *models.Commodity) ical.Event {
+	description := b.group.Name
+	if detail != "" {
+		description = detail + "\n" + description
+	}
+	e := ical.Event{
+		UID:         uidPrefix + "@inventario",
+		Date:        date,
+		Stamp:       b.stamp,
+		Summary:     summary,
+		Description: description,
+		Categories:  []string{string(category)},
+	}
+	if b.urlBuilder != nil {
+		e.URL = b.urlBuilder(b.group.Slug, c.ID)
+	}
+	return e
+}
+
+func validateCalendarFeedCategories(categories []string) error {
+	for _, category := range categories {
+		if !slices.Contains(CalendarFeedCategories, notifications.Category(category)) {
+			return errxtrace.Classify(ErrCalendarFeedUnknownCategory, errx.Attrs("category", category))
+		}
+	}
+	return nil
+}
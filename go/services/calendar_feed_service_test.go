@@ -0,0 +1,222 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/ical"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+)
+
+type calendarFeedFixture struct {
+	factorySet *registry.FactorySet
+	regSet     *registry.Set
+	user       *models.User
+	group      *models.LocationGroup
+	membership *models.GroupMembership
+	commodity  *models.Commodity
+}
+
+func newCalendarFeedFixture(c *qt.C) calendarFeedFixture {
+	c.Helper()
+	factorySet := memory.NewFactorySet()
+	u, err := factorySet.CreateServiceRegistrySet().UserRegistry.Create(context.Background(), models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "cal-feed-user"},
+			TenantID: "cal-feed-tenant",
+		},
+		Email:    "owner@example.com",
+		Name:     "Feed Owner",
+		IsActive: true,
+	})
+	c.Assert(err, qt.IsNil)
+	group, err := factorySet.LocationGroupRegistry.Create(context.Background(), models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: u.TenantID},
+		Slug:                "home",
+		Name:                "Home",
+		Status:              models.LocationGroupStatusActive,
+	})
+	c.Assert(err, qt.IsNil)
+	membership, err := factorySet.GroupMembershipRegistry.Create(context.Background(), models.GroupMembership{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: u.TenantID},
+		GroupID:             group.ID,
+		MemberUserID:        u.ID,
+		Role:                models.GroupRoleAdmin,
+	})
+	c.Assert(err, qt.IsNil)
+
+	ctx := appctx.WithGroup(appctx.WithUser(context.Background(), u), group)
+	regSet := must.Must(factorySet.CreateUserRegistrySet(ctx))
+	loc, err := regSet.LocationRegistry.Create(ctx, models.Location{Name: "L"})
+	c.Assert(err, qt.IsNil)
+	area, err := regSet.AreaRegistry.Create(ctx, models.Area{Name: "A", LocationID: loc.ID})
+	c.Assert(err, qt.IsNil)
+	warranty := models.Date("2026-12-01")
+	commodity, err := regSet.CommodityRegistry.Create(ctx, models.Commodity{
+		AreaID:            new(area.ID),
+		Name:              "Lawn mower",
+		ShortName:         "mower",
+		Type:              models.CommodityTypeEquipment,
+		Status:            models.CommodityStatusInUse,
+		Count:             1,
+		WarrantyExpiresAt: &warranty,
+	})
+	c.Assert(err, qt.IsNil)
+
+	return calendarFeedFixture{
+		factorySet: factorySet,
+		regSet:     regSet,
+		user:       u,
+		group:      group,
+		membership: membership,
+		commodity:  commodity,
+	}
+}
+
+func eventUIDs(cal *ical.Calendar) []string {
+	uids := make([]string, 0, len(cal.Events))
+	for _, e := range cal.Events {
+		uids = append(uids, e.UID)
+	}
+	return uids
+}
+
+// TestCalendarFeedService_Render checks that a feed publishes one event
+// per due date kind, in date order, and that the category filter and a
+// lost group membership narrow what a later fetch returns.
+func TestCalendarFeedService_Render(t *testing.T) {
+	c := qt.New(t)
+	f := newCalendarFeedFixture(c)
+	ctx := context.Background()
+	userCtx := appctx.WithGroup(appctx.WithUser(ctx, f.user), f.group)
+
+	dueBack := models.Date("2026-11-01")
+	loan, err := f.regSet.CommodityLoanRegistry.Create(userCtx, models.CommodityLoan{
+		CommodityID:  f.commodity.ID,
+		BorrowerName: "Neighbour",
+		LentAt:       "2026-10-01",
+		DueBackAt:    &dueBack,
+	})
+	c.Assert(err, qt.IsNil)
+	schedule, err := f.regSet.MaintenanceScheduleRegistry.Create(userCtx, models.MaintenanceSchedule{
+		CommodityID:  f.commodity.ID,
+		Title:        "Sharpen blade",
+		IntervalDays: 180,
+		NextDueAt:    "2026-10-25",
+		Enabled:      true,
+	})
+	c.Assert(err, qt.IsNil)
+
+	svc := services.NewCalendarFeedService(f.factorySet, func(groupSlug, commodityID string) string {
+		return "https://inventario.example/g/" + groupSlug + "/commodities/" + commodityID
+	})
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	feed, token, err := svc.Create(ctx, f.user, services.CalendarFeedInput{})
+	c.Assert(err, qt.IsNil)
+	cal, err := svc.Render(ctx, token, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(eventUIDs(cal), qt.DeepEquals, []string{
+		"maintenance-" + schedule.ID + "@inventario",
+		"loan-" + loan.ID + "@inventario",
+		"warranty-" + f.commodity.ID + "@inventario",
+	})
+	c.Assert(cal.Events[0].Summary, qt.Equals, "Maintenance due: Sharpen blade (Lawn mower)")
+	c.Assert(cal.Events[1].Summary, qt.Equals, "Loan due back: Lawn mower")
+	c.Assert(cal.Events[1].Description, qt.Equals, "Lent to Neighbour\nHome")
+	c.Assert(cal.Events[2].URL, qt.Equals, "https://inventario.example/g/home/commodities/"+f.commodity.ID)
+	c.Assert(cal.Events[2].Categories, qt.DeepEquals, []string{"warranty_expiry"})
+
+	_, err = svc.Update(ctx, f.user, feed.ID, services.CalendarFeedInput{Categories: []string{"loan_reminder"}})
+	c.Assert(err, qt.IsNil)
+	cal, err = svc.Render(ctx, token, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(eventUIDs(cal), qt.DeepEquals, []string{"loan-" + loan.ID + "@inventario"})
+
+	// Leaving the group empties the feed without invalidating the URL.
+	c.Assert(f.factorySet.GroupMembershipRegistry.Delete(ctx, f.membership.ID), qt.IsNil)
+	cal, err = svc.Render(ctx, token, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cal.Events, qt.HasLen, 0)
+}
+
+// TestCalendarFeedService_AppliesLocationScope checks that the public feed
+// honours the owner's location scope: the feed has no request to resolve
+// it from, so a restricted member must not see other locations' items.
+func TestCalendarFeedService_AppliesLocationScope(t *testing.T) {
+	c := qt.New(t)
+	f := newCalendarFeedFixture(c)
+	ctx := context.Background()
+	userCtx := appctx.WithGroup(appctx.WithUser(ctx, f.user), f.group)
+	other, err := f.regSet.LocationRegistry.Create(userCtx, models.Location{Name: "Elsewhere"})
+	c.Assert(err, qt.IsNil)
+
+	svc := services.NewCalendarFeedService(f.factorySet, nil)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	_, token, err := svc.Create(ctx, f.user, services.CalendarFeedInput{})
+	c.Assert(err, qt.IsNil)
+
+	_, err = f.factorySet.GroupMemberLocationScopeRegistry.ReplaceForMember(ctx, f.user.TenantID, f.group.ID, f.user.ID,
+		[]models.GroupMemberLocationScope{{LocationID: other.ID, Role: models.GroupRoleViewer}})
+	c.Assert(err, qt.IsNil)
+	cal, err := svc.Render(ctx, token, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cal.Events, qt.HasLen, 0)
+
+	_, err = f.factorySet.GroupMemberLocationScopeRegistry.ReplaceForMember(ctx, f.user.TenantID, f.group.ID, f.user.ID, nil)
+	c.Assert(err, qt.IsNil)
+	cal, err = svc.Render(ctx, token, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(eventUIDs(cal), qt.DeepEquals, []string{"warranty-" + f.commodity.ID + "@inventario"})
+}
+
+// TestCalendarFeedService_Management covers the ownership and input
+// checks: unknown categories and foreign groups are rejected, another
+// user's feed is invisible, and revoking makes the token unknown.
+func TestCalendarFeedService_Management(t *testing.T) {
+	c := qt.New(t)
+	f := newCalendarFeedFixture(c)
+	ctx := context.Background()
+	svc := services.NewCalendarFeedService(f.factorySet, nil)
+
+	_, _, err := svc.Create(ctx, f.user, services.CalendarFeedInput{Categories: []string{"weekly_digest"}})
+	c.Assert(err, qt.ErrorIs, services.ErrCalendarFeedUnknownCategory)
+	otherGroup := "some-other-group"
+	_, _, err = svc.Create(ctx, f.user, services.CalendarFeedInput{GroupID: &otherGroup})
+	c.Assert(err, qt.ErrorIs, services.ErrCalendarFeedGroupAccess)
+
+	feed, token, err := svc.Create(ctx, f.user, services.CalendarFeedInput{GroupID: &f.group.ID, Name: "Phone"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(feed.TokenHash, qt.Equals, models.HashCalendarFeedToken(token))
+
+	stranger := &models.User{TenantAwareEntityID: models.TenantAwareEntityID{
+		EntityID: models.EntityID{ID: "someone-else"},
+		TenantID: f.user.TenantID,
+	}}
+	_, err = svc.Get(ctx, stranger, feed.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	c.Assert(svc.Revoke(ctx, stranger, feed.ID), qt.ErrorIs, registry.ErrNotFound)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	rotated, newToken, err := svc.Rotate(ctx, f.user, feed.ID, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(newToken, qt.Not(qt.Equals), token)
+	c.Assert(rotated.RotatedAt, qt.IsNotNil)
+	_, err = svc.Render(ctx, token, now)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	c.Assert(svc.Revoke(ctx, f.user, feed.ID), qt.IsNil)
+	_, err = svc.Render(ctx, newToken, now)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	feeds, err := svc.List(ctx, f.user)
+	c.Assert(err, qt.IsNil)
+	c.Assert(feeds, qt.HasLen, 0)
+}
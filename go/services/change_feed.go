@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/changefeed"
+	changefeedinmemory "github.com/denisvmedia/inventario/changefeed/inmemory"
+	changefeedredis "github.com/denisvmedia/inventario/changefeed/redis"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// NewChangeFeedBroker selects the changefeed.Broker implementation based on
+// configuration. When redisURL is non-empty a Redis-backed broker is used so
+// every apiserver replica sees every change. Otherwise it falls back to an
+// in-memory broker with a warning.
+func NewChangeFeedBroker(redisURL string) changefeed.Broker {
+	if redisURL != "" {
+		broker, err := changefeedredis.NewFromURL(redisURL)
+		if err != nil {
+			slog.Error("Failed to create Redis change feed, falling back to in-memory", "error", err)
+			return newInMemoryChangeFeedWithWarning()
+		}
+		slog.Info("Using Redis change feed")
+		return broker
+	}
+	return newInMemoryChangeFeedWithWarning()
+}
+
+func newInMemoryChangeFeedWithWarning() *changefeedinmemory.Broker {
+	slog.Warn("Using in-memory change feed — live updates only reach clients on the same replica; set --change-feed-redis-url for multi-instance deployments")
+	return changefeedinmemory.New()
+}
+
+// ChangeFeed publishes group change events for the SSE stream. It stamps
+// each event with the group and actor from ctx and resolves the location the
+// row lives in, which the stream handler needs to filter events for
+// location-scoped members.
+//
+// The feed travels on the request context (WithChangeFeed, installed by the
+// apiserver) rather than through every service constructor: the commodity
+// timeline writes happen in half a dozen services, each built with only a
+// FactorySet. Outside an HTTP request (workers, CLI) there is no feed and
+// every method is a no-op, as it is on a nil *ChangeFeed.
+//
+// Publish failures are logged and never propagated, the same discipline as
+// CommodityEventService: a committed write must not fail because the
+// notification could not be sent.
+type ChangeFeed struct {
+	broker     changefeed.Broker
+	factorySet *registry.FactorySet
+}
+
+// NewChangeFeed binds a broker to the FactorySet used for location lookups.
+func NewChangeFeed(broker changefeed.Broker, fs *registry.FactorySet) *ChangeFeed {
+	return &ChangeFeed{broker: broker, factorySet: fs}
+}
+
+type changeFeedCtxKey struct{}
+
+// WithChangeFeed returns a context carrying the feed.
+func WithChangeFeed(ctx context.Context, feed *ChangeFeed) context.Context {
+	return context.WithValue(ctx, changeFeedCtxKey{}, feed)
+}
+
+// ChangeFeedFromContext returns the feed carried by ctx, or nil.
+func ChangeFeedFromContext(ctx context.Context) *ChangeFeed {
+	feed, _ := ctx.Value(changeFeedCtxKey{}).(*ChangeFeed)
+	return feed
+}
+
+// LocationChanged publishes a location event.
+func (f *ChangeFeed) LocationChanged(ctx context.Context, action changefeed.Action, location *models.Location) {
+	if f == nil || location == nil {
+		return
+	}
+	f.publish(ctx, location.GroupID, changefeed.Event{
+		Entity:     changefeed.EntityLocation,
+		Action:     action,
+		EntityID:   location.ID,
+		LocationID: location.ID,
+	})
+}
+
+// AreaChanged publishes an area event.
+func (f *ChangeFeed) AreaChanged(ctx context.Context, action changefeed.Action, area *models.Area) {
+	if f == nil || area == nil {
+		return
+	}
+	f.publish(ctx, area.GroupID, changefeed.Event{
+		Entity:     changefeed.EntityArea,
+		Action:     action,
+		EntityID:   area.ID,
+		LocationID: area.LocationID,
+	})
+}
+
+// CommodityChanged publishes a commodity event. kind is the timeline kind
+// that triggered it, if any.
+func (f *ChangeFeed) CommodityChanged(ctx context.Context, action changefeed.Action, kind models.CommodityEventKind, commodity *models.Commodity) {
+	if f == nil || commodity == nil {
+		return
+	}
+	f.publish(ctx, commodity.GroupID, changefeed.Event{
+		Entity:     changefeed.EntityCommodity,
+		Action:     action,
+		EntityID:   commodity.ID,
+		LocationID: f.areaLocation(ctx, ptrString(commodity.AreaID)),
+		Kind:       string(kind),
+	})
+}
+
+// FileChanged publishes a file event. Files not linked to a location, area
+// or commodity carry no location and are not shown to scoped members.
+func (f *ChangeFeed) FileChanged(ctx context.Context, action changefeed.Action, file *models.FileEntity) {
+	if f == nil || file == nil {
+		return
+	}
+	f.publish(ctx, file.GroupID, changefeed.Event{
+		Entity:     changefeed.EntityFile,
+		Action:     action,
+		EntityID:   file.ID,
+		LocationID: f.linkedLocation(ctx, file.LinkedEntityType, file.LinkedEntityID),
+	})
+}
+
+// LoanChanged publishes a loan event.
+func (f *ChangeFeed) LoanChanged(ctx context.Context, action changefeed.Action, kind models.CommodityEventKind, loan *models.CommodityLoan) {
+	if f == nil || loan == nil {
+		return
+	}
+	f.publish(ctx, loan.GroupID, changefeed.Event{
+		Entity:     changefeed.EntityLoan,
+		Action:     action,
+		EntityID:   loan.ID,
+		LocationID: f.commodityLocation(ctx, loan.CommodityID),
+		Kind:       string(kind),
+	})
+}
+
+// commodityEventChanged publishes the commodity event matching a timeline
+// write. Only the commodity ID is known there, so the row is looked up.
+func (f *ChangeFeed) commodityEventChanged(ctx context.Context, commodityID string, kind models.CommodityEventKind) {
+	if f == nil || commodityID == "" {
+		return
+	}
+	action := changefeed.ActionUpdated
+	if kind == models.CommodityEventKindCreated {
+		action = changefeed.ActionCreated
+	}
+	f.publish(ctx, "", changefeed.Event{
+		Entity:     changefeed.EntityCommodity,
+		Action:     action,
+		EntityID:   commodityID,
+		LocationID: f.commodityLocation(ctx, commodityID),
+		Kind:       string(kind),
+	})
+}
+
+func (f *ChangeFeed) publish(ctx context.Context, groupID string, event changefeed.Event) {
+	if groupID == "" {
+		groupID = appctx.GroupIDFromContext(ctx)
+	}
+	if groupID == "" {
+		return
+	}
+	event.GroupID = groupID
+	event.ActorUserID = appctx.UserIDFromContext(ctx)
+	if _, err := f.broker.Publish(ctx, event); err != nil {
+		slog.WarnContext(ctx, "change feed: failed to publish",
+			"err", err,
+			"entity", event.Entity,
+			"action", event.Action,
+			"entity_id", event.EntityID,
+		)
+	}
+}
+
+// The lookups below go through service registries: the event must carry
+// the row's real location even when the acting member's own registries
+// could not see a parent row.
+
+func (f *ChangeFeed) areaLocation(ctx context.Context, areaID string) string {
+	if areaID == "" {
+		return ""
+	}
+	area, err := f.factorySet.AreaRegistryFactory.CreateServiceRegistry().Get(ctx, areaID)
+	if err != nil {
+		return ""
+	}
+	return area.LocationID
+}
+
+func (f *ChangeFeed) commodityLocation(ctx context.Context, commodityID string) string {
+	if commodityID == "" {
+		return ""
+	}
+	commodity, err := f.factorySet.CommodityRegistryFactory.CreateServiceRegistry().Get(ctx, commodityID)
+	if err != nil {
+		return ""
+	}
+	return f.areaLocation(ctx, ptrString(commodity.AreaID))
+}
+
+func (f *ChangeFeed) linkedLocation(ctx context.Context, entityType, entityID string) string {
+	switch entityType {
+	case "location":
+		return entityID
+	case "area":
+		return f.areaLocation(ctx, entityID)
+	case "commodity":
+		return f.commodityLocation(ctx, entityID)
+	default:
+		return ""
+	}
+}
@@ -8,6 +8,8 @@ import (
 	errxtrace "github.com/go-extras/errx/stacktrace"
 	"github.com/shopspring/decimal"
 
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/changefeed"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -131,6 +133,33 @@ func (s *CommodityEventService) EmitDeleted(ctx context.Context, before *models.
 	s.emit(ctx, before.ID, models.CommodityEventKindDeleted, snapshotCreated(before), nil)
 }
 
+// EmitMerged records a "merged" event on the surviving commodity. before
+// carries the duplicate's identifying snapshot (the row is deleted by the
+// merge, so this is the only place its name survives); after carries the
+// per-kind counts of what moved across.
+func (s *CommodityEventService) EmitMerged(ctx context.Context, survivorID string, merged *models.Commodity, moved models.CommodityEventPayload) {
+	if s == nil || merged == nil {
+		return
+	}
+	before := snapshotCreated(merged)
+	before["id"] = merged.ID
+	s.emit(ctx, survivorID, models.CommodityEventKindMerged, before, moved)
+}
+
+// EmitTransferred records a "transferred" event for a commodity that moved
+// from group `from` to group `to`. It is called twice per commodity: once
+// with a ctx bound to each group, so both timelines show the move. before /
+// after carry the group, area and price fields on either side.
+func (s *CommodityEventService) EmitTransferred(ctx context.Context, before, after *models.Commodity, from, to *models.LocationGroup) {
+	if s == nil || before == nil || after == nil || from == nil || to == nil {
+		return
+	}
+	s.emit(ctx, after.ID, models.CommodityEventKindTransferred,
+		snapshotTransfer(before, from),
+		snapshotTransfer(after, to),
+	)
+}
+
 // EmitLoanStarted records a "lent_out" event when a new loan opens. The
 // after payload carries the borrower-facing fields the timeline UI
 // renders ("Lent out to X on Y, due back Z"); before is null since this
@@ -139,7 +168,7 @@ func (s *CommodityEventService) EmitLoanStarted(ctx context.Context, loan *model
 	if s == nil || loan == nil {
 		return
 	}
-	s.emit(ctx, loan.CommodityID, models.CommodityEventKindLentOut,
+	s.emitLoan(ctx, loan, changefeed.ActionCreated, models.CommodityEventKindLentOut,
 		nil,
 		snapshotLoanLifecycle(loan),
 	)
@@ -155,7 +184,7 @@ func (s *CommodityEventService) EmitLoanReturned(ctx context.Context, loan *mode
 	if s == nil || loan == nil {
 		return
 	}
-	s.emit(ctx, loan.CommodityID, models.CommodityEventKindReturned,
+	s.emitLoan(ctx, loan, changefeed.ActionUpdated, models.CommodityEventKindReturned,
 		nil,
 		snapshotLoanLifecycle(loan),
 	)
@@ -163,9 +192,9 @@ func (s *CommodityEventService) EmitLoanReturned(ctx context.Context, loan *mode
 
 // EmitLoanUpdated records a "loan_updated" event when one or more of
 // the patch-mutable loan fields change (borrower_name /
-// borrower_contact / borrower_note / due_back_at — see LoanUpdate in
-// commodity_loan_service.go). When nothing actually changed the call
-// is a no-op — same gate as EmitUpdated for commodities, so idempotent
+// borrower_contact / borrower_email / borrower_note / due_back_at — see
+// LoanUpdate in commodity_loan_service.go). When nothing actually
+// changed the call is a no-op — same gate as EmitUpdated for commodities, so idempotent
 // PATCHes don't pollute the timeline.
 func (s *CommodityEventService) EmitLoanUpdated(ctx context.Context, before, after *models.CommodityLoan) {
 	if s == nil || before == nil || after == nil {
@@ -174,12 +203,75 @@ func (s *CommodityEventService) EmitLoanUpdated(ctx context.Context, before, aft
 	if !loanFieldsChanged(before, after) {
 		return
 	}
-	s.emit(ctx, after.CommodityID, models.CommodityEventKindLoanUpdated,
+	s.emitLoan(ctx, after, changefeed.ActionUpdated, models.CommodityEventKindLoanUpdated,
 		snapshotLoanDiff(before),
 		snapshotLoanDiff(after),
 	)
 }
 
+// EmitLoanRequestSubmitted records a "loan_request_submitted" event
+// when the borrower responds through the signed loan link. The borrower
+// has no session, so the row is written through the service registry
+// and attributed to the loan's creator (the same actor the reminder
+// worker uses for its loan_reminder_sent rows).
+func (s *CommodityEventService) EmitLoanRequestSubmitted(ctx context.Context, loan *models.CommodityLoan) {
+	if s == nil || loan == nil {
+		return
+	}
+	event := models.CommodityEvent{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
+			TenantID:        loan.TenantID,
+			GroupID:         loan.GroupID,
+			CreatedByUserID: loan.CreatedByUserID,
+		},
+		CommodityID: loan.CommodityID,
+		Kind:        models.CommodityEventKindLoanRequestSubmitted,
+		OccurredAt:  time.Now().UTC(),
+		After:       snapshotLoanRequest(loan),
+	}
+	// The memory backend stamps created_by from the context user even in
+	// service mode; hand it the lender so both backends agree.
+	if appctx.UserIDFromContext(ctx) == "" && loan.CreatedByUserID != "" && s.factorySet.UserRegistry != nil {
+		if lender, err := s.factorySet.UserRegistry.Get(ctx, loan.CreatedByUserID); err == nil {
+			ctx = appctx.WithUser(ctx, lender)
+		}
+	}
+	reg := s.factorySet.CommodityEventRegistryFactory.CreateServiceRegistry()
+	if _, err := reg.Create(ctx, event); err != nil {
+		slog.WarnContext(ctx, "commodity event: failed to write",
+			"err", errxtrace.Wrap("commodity event write", err),
+			"kind", event.Kind,
+			"commodity_id", loan.CommodityID,
+		)
+	}
+	ChangeFeedFromContext(ctx).LoanChanged(ctx, changefeed.ActionUpdated, event.Kind, loan)
+}
+
+// EmitLoanRequestApproved records a "loan_request_approved" event. The
+// loan passed in must still carry the pending_request_* fields — the
+// caller snapshots it before clearing them.
+func (s *CommodityEventService) EmitLoanRequestApproved(ctx context.Context, loan *models.CommodityLoan) {
+	if s == nil || loan == nil {
+		return
+	}
+	s.emitLoan(ctx, loan, changefeed.ActionUpdated, models.CommodityEventKindLoanRequestApproved,
+		nil,
+		snapshotLoanRequest(loan),
+	)
+}
+
+// EmitLoanRequestRejected records a "loan_request_rejected" event. Same
+// pre-clear snapshot contract as EmitLoanRequestApproved.
+func (s *CommodityEventService) EmitLoanRequestRejected(ctx context.Context, loan *models.CommodityLoan) {
+	if s == nil || loan == nil {
+		return
+	}
+	s.emitLoan(ctx, loan, changefeed.ActionUpdated, models.CommodityEventKindLoanRequestRejected,
+		nil,
+		snapshotLoanRequest(loan),
+	)
+}
+
 // EmitServiceStarted records a "sent_for_service" event when a new
 // service row opens. Sibling to EmitLoanStarted — same shape, same
 // "best-effort, never blocks the CRUD" discipline.
@@ -221,10 +313,28 @@ func (s *CommodityEventService) EmitServiceUpdated(ctx context.Context, before,
 	)
 }
 
-// emit is the shared write path. Construction of the registry per-call is
+// emit writes a commodity timeline row and publishes the matching change
+// to the group's live stream. Deletions are the exception: EmitDeleted runs
+// before the row is gone, so the handler publishes those once the delete
+// has succeeded.
+func (s *CommodityEventService) emit(ctx context.Context, commodityID string, kind models.CommodityEventKind, before, after models.CommodityEventPayload) {
+	s.write(ctx, commodityID, kind, before, after)
+	if kind != models.CommodityEventKindDeleted {
+		ChangeFeedFromContext(ctx).commodityEventChanged(ctx, commodityID, kind)
+	}
+}
+
+// emitLoan writes a loan lifecycle row onto the commodity's timeline and
+// publishes it as a loan change.
+func (s *CommodityEventService) emitLoan(ctx context.Context, loan *models.CommodityLoan, action changefeed.Action, kind models.CommodityEventKind, before, after models.CommodityEventPayload) {
+	s.write(ctx, loan.CommodityID, kind, before, after)
+	ChangeFeedFromContext(ctx).LoanChanged(ctx, action, kind, loan)
+}
+
+// write is the shared write path. Construction of the registry per-call is
 // cheap (it's just a wrapper around the shared dbx) and keeps the call
 // fully RLS-scoped to the current request's tenant + group + user.
-func (s *CommodityEventService) emit(ctx context.Context, commodityID string, kind models.CommodityEventKind, before, after models.CommodityEventPayload) {
+func (s *CommodityEventService) write(ctx context.Context, commodityID string, kind models.CommodityEventKind, before, after models.CommodityEventPayload) {
 	reg, err := s.factorySet.CommodityEventRegistryFactory.CreateUserRegistry(ctx)
 	if err != nil {
 		slog.WarnContext(ctx, "commodity event: failed to build registry", "err", err, "kind", kind, "commodity_id", commodityID)
@@ -321,6 +431,19 @@ func snapshotForUpdate(c *models.Commodity) models.CommodityEventPayload {
 	}
 }
 
+// snapshotTransfer captures one side of a "transferred" event.
+func snapshotTransfer(c *models.Commodity, g *models.LocationGroup) models.CommodityEventPayload {
+	return models.CommodityEventPayload{
+		"group_id":                 g.ID,
+		"group_name":               g.Name,
+		"area_id":                  ptrString(c.AreaID),
+		"original_price":           decimalString(c.OriginalPrice),
+		"original_price_currency":  string(c.OriginalPriceCurrency),
+		"converted_original_price": decimalString(c.ConvertedOriginalPrice),
+		"current_price":            decimalString(c.CurrentPrice),
+	}
+}
+
 // decimalString turns a decimal.Decimal into its plain text representation —
 // JSONB doesn't have a native decimal type and float coercion would lose
 // precision (the prices the user is auditing are exact).
@@ -386,6 +509,9 @@ func snapshotLoanLifecycle(l *models.CommodityLoan) models.CommodityEventPayload
 	if l.BorrowerContact != "" {
 		p["borrower_contact"] = l.BorrowerContact
 	}
+	if l.BorrowerEmail != "" {
+		p["borrower_email"] = l.BorrowerEmail
+	}
 	if l.BorrowerNote != "" {
 		p["borrower_note"] = l.BorrowerNote
 	}
@@ -419,6 +545,9 @@ func snapshotLoanDiff(l *models.CommodityLoan) models.CommodityEventPayload {
 	if l.BorrowerContact != "" {
 		p["borrower_contact"] = l.BorrowerContact
 	}
+	if l.BorrowerEmail != "" {
+		p["borrower_email"] = l.BorrowerEmail
+	}
 	if l.BorrowerNote != "" {
 		p["borrower_note"] = l.BorrowerNote
 	}
@@ -434,12 +563,31 @@ func snapshotLoanDiff(l *models.CommodityLoan) models.CommodityEventPayload {
 func loanFieldsChanged(before, after *models.CommodityLoan) bool {
 	if before.BorrowerName != after.BorrowerName ||
 		before.BorrowerContact != after.BorrowerContact ||
+		before.BorrowerEmail != after.BorrowerEmail ||
 		before.BorrowerNote != after.BorrowerNote {
 		return true
 	}
 	return !equalPDate(before.DueBackAt, after.DueBackAt)
 }
 
+// snapshotLoanRequest captures the borrower request fields the timeline
+// renders for the loan_request_* kinds. Sparse like the other loan
+// snapshots — the optional due date and note are dropped when unset.
+func snapshotLoanRequest(l *models.CommodityLoan) models.CommodityEventPayload {
+	p := models.CommodityEventPayload{
+		"loan_id":       l.ID,
+		"borrower_name": l.BorrowerName,
+		"request_kind":  string(l.PendingRequestKind),
+	}
+	if l.PendingRequestDueBackAt != nil && *l.PendingRequestDueBackAt != "" {
+		p["requested_due_back_at"] = string(*l.PendingRequestDueBackAt)
+	}
+	if l.PendingRequestNote != "" {
+		p["request_note"] = l.PendingRequestNote
+	}
+	return p
+}
+
 // snapshotServiceLifecycle captures the service-row fields the timeline
 // renders for `sent_for_service` and `back_from_service` events. Sibling
 // to snapshotLoanLifecycle — same sparse-payload discipline (empty-string
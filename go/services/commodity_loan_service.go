@@ -29,6 +29,16 @@ var (
 // audit trail. Apiserver maps this sentinel to 422.
 var ErrClosedLoanFieldImmutable = errx.NewSentinel("closed loan field is immutable")
 
+// ErrNoPendingLoanRequest signals an approve / reject call against a
+// loan without an outstanding borrower request (already reviewed, or
+// never submitted). Apiserver maps it to 409.
+var ErrNoPendingLoanRequest = errx.NewSentinel("loan has no pending borrower request")
+
+// ErrInvalidLoanRequest signals a borrower request the loan cannot
+// accept: an unknown kind, or an extension without a due date later
+// than both today and the current due_back_at. Apiserver maps it to 422.
+var ErrInvalidLoanRequest = errx.NewSentinel("invalid borrower loan request")
+
 // CommodityLoanService coordinates the lend-out lifecycle on top of the
 // per-row CommodityLoanRegistry. Invariants enforced here (rather than
 // at the SQL layer) so that the FE always sees a domain 409 instead of
@@ -138,6 +148,9 @@ func (s *CommodityLoanService) StartLoan(ctx context.Context, loan models.Commod
 	loan.ReturnedAt = nil
 	loan.ReminderSentOverdue = false
 	loan.ReminderSentDueSoon = false
+	loan.BorrowerReminderSentOverdue = false
+	loan.BorrowerReminderSentDueSoon = false
+	loan.ClearPendingRequest()
 
 	created, err = loanReg.Create(ctx, loan)
 	if err != nil {
@@ -162,6 +175,7 @@ func (s *CommodityLoanService) StartLoan(ctx context.Context, loan models.Commod
 type LoanUpdate struct {
 	BorrowerName    *string
 	BorrowerContact *string
+	BorrowerEmail   *string
 	BorrowerNote    *string
 	// DueBackAt: non-nil sets, nil + ClearDueBackAt=false leaves
 	// unchanged, nil + ClearDueBackAt=true clears the column.
@@ -173,7 +187,8 @@ type LoanUpdate struct {
 // LoanUpdate for per-field semantics.
 //
 // Mutability matrix:
-//   - borrower_name / borrower_contact / borrower_note: mutable on
+//   - borrower_name / borrower_contact / borrower_email /
+//     borrower_note: mutable on
 //     both open and closed loans. The mid-loan ambiguity "if you
 //     change the borrower name on an open loan, who actually has the
 //     item now?" was raised when this surface was first designed, but
@@ -221,6 +236,9 @@ func (s *CommodityLoanService) UpdateLoan(ctx context.Context, id string, patch
 	if patch.BorrowerContact != nil {
 		updated.BorrowerContact = *patch.BorrowerContact
 	}
+	if patch.BorrowerEmail != nil {
+		updated.BorrowerEmail = *patch.BorrowerEmail
+	}
 	if patch.BorrowerNote != nil {
 		updated.BorrowerNote = *patch.BorrowerNote
 	}
@@ -263,6 +281,10 @@ func (s *CommodityLoanService) MarkReturned(ctx context.Context, id string, retu
 
 	updated := *current
 	updated.ReturnedAt = returnedAt
+	// A borrower request still pending when the owner closes the loan
+	// by hand is moot either way — drop it so the FE stops offering the
+	// approve / reject affordance on a returned loan.
+	updated.ClearPendingRequest()
 
 	final, err := loanReg.Update(ctx, updated)
 	if err != nil {
@@ -271,3 +293,175 @@ func (s *CommodityLoanService) MarkReturned(ctx context.Context, id string, retu
 	s.eventService.EmitLoanReturned(ctx, final)
 	return final, nil
 }
+
+// BorrowerLoanRequest is the payload a borrower submits through the
+// signed loan link. DueBackAt is required for extension requests and
+// ignored for return confirmations.
+type BorrowerLoanRequest struct {
+	Kind      models.LoanRequestKind
+	DueBackAt models.PDate
+	Note      string
+}
+
+// GetLoanForBorrower loads a loan for the public, link-authenticated
+// borrower surface. The caller has already validated the link
+// signature; the lookup runs in service mode because the borrower has
+// no session (and so no RLS context). Returns the loan together with
+// the commodity name the borrower page shows.
+func (s *CommodityLoanService) GetLoanForBorrower(ctx context.Context, loanID string) (loan *models.CommodityLoan, commodityName string, err error) {
+	loan, err = s.factorySet.CommodityLoanRegistryFactory.CreateServiceRegistry().Get(ctx, loanID)
+	if err != nil {
+		return nil, "", errxtrace.Wrap("failed to look up loan", err)
+	}
+	commodity, err := s.factorySet.CommodityRegistryFactory.CreateServiceRegistry().Get(ctx, loan.CommodityID)
+	if err != nil {
+		return nil, "", errxtrace.Wrap("failed to look up loaned commodity", err)
+	}
+	return loan, commodity.Name, nil
+}
+
+// SubmitBorrowerRequest records a borrower's return confirmation or
+// extension request on an open loan. Nothing about the loan itself
+// changes until the owner approves; a newer submission replaces an
+// unreviewed one. Runs in service mode for the same reason as
+// GetLoanForBorrower.
+func (s *CommodityLoanService) SubmitBorrowerRequest(ctx context.Context, loanID string, req BorrowerLoanRequest) (*models.CommodityLoan, error) {
+	loanReg := s.factorySet.CommodityLoanRegistryFactory.CreateServiceRegistry()
+	current, err := loanReg.Get(ctx, loanID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to look up loan", err)
+	}
+	if !current.IsOpen() {
+		return nil, errxtrace.Wrap("loan already returned", ErrLoanAlreadyReturned)
+	}
+
+	now := time.Now().UTC()
+	var dueBackAt models.PDate
+	switch req.Kind {
+	case models.LoanRequestKindReturn:
+	case models.LoanRequestKindExtension:
+		if err := validateExtensionDate(current, req.DueBackAt, now); err != nil {
+			return nil, err
+		}
+		dueBackAt = req.DueBackAt
+	default:
+		return nil, errxtrace.Wrap("unknown request kind", ErrInvalidLoanRequest)
+	}
+
+	recorded, err := loanReg.RecordPendingRequest(ctx, loanID, req.Kind, dueBackAt, req.Note, now)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to record borrower request", err)
+	}
+	if !recorded {
+		// Returned (or deleted) between the open check and the write.
+		return nil, errxtrace.Wrap("loan already returned", ErrLoanAlreadyReturned)
+	}
+	final, err := loanReg.Get(ctx, loanID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to reload loan", err)
+	}
+	s.eventService.EmitLoanRequestSubmitted(ctx, final)
+	return final, nil
+}
+
+// ApproveBorrowerRequest applies the loan's pending borrower request:
+//   - return: closes the loan with returned_at set to the day the
+//     borrower confirmed (not the day the owner got round to
+//     approving);
+//   - extension: moves due_back_at to the requested date and re-arms
+//     every reminder flag so the new date gets its own due-soon /
+//     overdue reminders.
+//
+// Either way the pending_request_* fields are cleared and a
+// loan_request_approved event lands next to the regular returned /
+// loan_updated event.
+func (s *CommodityLoanService) ApproveBorrowerRequest(ctx context.Context, id string) (*models.CommodityLoan, error) {
+	loanReg, current, err := s.loadPendingRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *current
+	updated.ClearPendingRequest()
+	switch current.PendingRequestKind {
+	case models.LoanRequestKindReturn:
+		returnedAt := models.Date(current.PendingRequestAt.UTC().Format("2006-01-02"))
+		updated.ReturnedAt = &returnedAt
+	case models.LoanRequestKindExtension:
+		updated.DueBackAt = current.PendingRequestDueBackAt
+		updated.ReminderSentOverdue = false
+		updated.ReminderSentDueSoon = false
+		updated.BorrowerReminderSentOverdue = false
+		updated.BorrowerReminderSentDueSoon = false
+	}
+
+	final, err := loanReg.Update(ctx, updated)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to apply borrower request", err)
+	}
+	s.eventService.EmitLoanRequestApproved(ctx, current)
+	if current.PendingRequestKind == models.LoanRequestKindReturn {
+		s.eventService.EmitLoanReturned(ctx, final)
+	} else {
+		s.eventService.EmitLoanUpdated(ctx, current, final)
+	}
+	return final, nil
+}
+
+// RejectBorrowerRequest dismisses the loan's pending borrower request
+// without touching the loan itself.
+func (s *CommodityLoanService) RejectBorrowerRequest(ctx context.Context, id string) (*models.CommodityLoan, error) {
+	loanReg, current, err := s.loadPendingRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *current
+	updated.ClearPendingRequest()
+	final, err := loanReg.Update(ctx, updated)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to reject borrower request", err)
+	}
+	s.eventService.EmitLoanRequestRejected(ctx, current)
+	return final, nil
+}
+
+// loadPendingRequest fetches a loan through the caller's RLS-scoped
+// registry and checks it carries a reviewable borrower request.
+func (s *CommodityLoanService) loadPendingRequest(ctx context.Context, id string) (registry.CommodityLoanRegistry, *models.CommodityLoan, error) {
+	loanReg, err := s.factorySet.CommodityLoanRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, nil, errxtrace.Wrap("failed to create loan registry", err)
+	}
+	current, err := loanReg.Get(ctx, id)
+	if err != nil {
+		return nil, nil, errxtrace.Wrap("failed to look up loan", err)
+	}
+	if !current.HasPendingRequest() {
+		return nil, nil, errxtrace.Wrap("nothing to review", ErrNoPendingLoanRequest)
+	}
+	if !current.IsOpen() {
+		return nil, nil, errxtrace.Wrap("loan already returned", ErrLoanAlreadyReturned)
+	}
+	return loanReg, current, nil
+}
+
+// validateExtensionDate checks an extension request asks for a real
+// date later than both today and the loan's current due date.
+func validateExtensionDate(loan *models.CommodityLoan, requested models.PDate, now time.Time) error {
+	if requested == nil || *requested == "" {
+		return errxtrace.Wrap("extension requires due_back_at", ErrInvalidLoanRequest)
+	}
+	want := requested.ToTime()
+	if want.IsZero() {
+		return errxtrace.Wrap("invalid due_back_at", ErrInvalidLoanRequest)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if want.Before(today) {
+		return errxtrace.Wrap("due_back_at is in the past", ErrInvalidLoanRequest)
+	}
+	if loan.DueBackAt != nil && *loan.DueBackAt != "" && !want.After(loan.DueBackAt.ToTime()) {
+		return errxtrace.Wrap("due_back_at must be later than the current due date", ErrInvalidLoanRequest)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 
@@ -37,6 +38,10 @@ func newLoanServiceFixture(c *qt.C) *loanServiceFixture {
 		Email: "u@example.com",
 		Name:  "Tester",
 	}
+	// Registered so the link-authenticated borrower paths, which run
+	// without a user context, can resolve the lender.
+	user, err := factorySet.UserRegistry.Create(context.Background(), *user)
+	c.Assert(err, qt.IsNil)
 	ctx := appctx.WithUser(context.Background(), user)
 	ctx = appctx.WithGroup(ctx, &models.LocationGroup{
 		TenantAwareEntityID: models.TenantAwareEntityID{
@@ -380,3 +385,105 @@ func TestCommodityLoanService_UpdateLoan_ClearDueBackAt_NoOpOnAlreadyNil(t *test
 	c.Assert(events, qt.HasLen, 1)
 	c.Assert(events[0].Kind, qt.Equals, models.CommodityEventKindLentOut)
 }
+
+func (f *loanServiceFixture) eventKinds(c *qt.C) []models.CommodityEventKind {
+	c.Helper()
+	events, err := f.events.List(f.ctx)
+	c.Assert(err, qt.IsNil)
+	kinds := make([]models.CommodityEventKind, 0, len(events))
+	for _, ev := range events {
+		kinds = append(kinds, ev.Kind)
+	}
+	return kinds
+}
+
+// TestCommodityLoanService_BorrowerRequest_ApproveExtension walks the
+// extension flow: the borrower's request only parks on the loan, and
+// approval moves due_back_at, re-arms the reminder flags and records
+// both steps in the timeline.
+func TestCommodityLoanService_BorrowerRequest_ApproveExtension(t *testing.T) {
+	c := qt.New(t)
+	fx := newLoanServiceFixture(c)
+
+	created := fx.startLoan(c)
+	requested := models.Date(time.Now().UTC().AddDate(0, 0, 14).Format("2006-01-02"))
+
+	pending, err := fx.loanSvc.SubmitBorrowerRequest(context.Background(), created.ID, services.BorrowerLoanRequest{
+		Kind:      models.LoanRequestKindExtension,
+		DueBackAt: models.PDate(&requested),
+		Note:      "Need it for one more weekend",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(pending.HasPendingRequest(), qt.IsTrue)
+	c.Assert(pending.DueBackAt, qt.IsNil)
+	c.Assert(pending.PendingRequestAt, qt.IsNotNil)
+
+	approved, err := fx.loanSvc.ApproveBorrowerRequest(fx.ctx, created.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(approved.HasPendingRequest(), qt.IsFalse)
+	c.Assert(approved.DueBackAt, qt.IsNotNil)
+	c.Assert(*approved.DueBackAt, qt.Equals, requested)
+	c.Assert(approved.IsOpen(), qt.IsTrue)
+
+	kinds := fx.eventKinds(c)
+	c.Assert(kinds, qt.Contains, models.CommodityEventKindLoanRequestSubmitted)
+	c.Assert(kinds, qt.Contains, models.CommodityEventKindLoanRequestApproved)
+	c.Assert(kinds, qt.Contains, models.CommodityEventKindLoanUpdated)
+
+	_, err = fx.loanSvc.ApproveBorrowerRequest(fx.ctx, created.ID)
+	c.Assert(err, qt.ErrorIs, services.ErrNoPendingLoanRequest)
+}
+
+// TestCommodityLoanService_BorrowerRequest_ApproveReturn pins that a
+// confirmed return closes the loan as of the day the borrower said so.
+func TestCommodityLoanService_BorrowerRequest_ApproveReturn(t *testing.T) {
+	c := qt.New(t)
+	fx := newLoanServiceFixture(c)
+
+	created := fx.startLoan(c)
+	pending, err := fx.loanSvc.SubmitBorrowerRequest(context.Background(), created.ID, services.BorrowerLoanRequest{
+		Kind: models.LoanRequestKindReturn,
+	})
+	c.Assert(err, qt.IsNil)
+
+	approved, err := fx.loanSvc.ApproveBorrowerRequest(fx.ctx, created.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(approved.IsOpen(), qt.IsFalse)
+	c.Assert(string(*approved.ReturnedAt), qt.Equals, pending.PendingRequestAt.Format("2006-01-02"))
+	c.Assert(fx.eventKinds(c), qt.Contains, models.CommodityEventKindReturned)
+
+	_, err = fx.loanSvc.SubmitBorrowerRequest(context.Background(), created.ID, services.BorrowerLoanRequest{
+		Kind: models.LoanRequestKindReturn,
+	})
+	c.Assert(err, qt.ErrorIs, services.ErrLoanAlreadyReturned)
+}
+
+func TestCommodityLoanService_BorrowerRequest_Reject(t *testing.T) {
+	c := qt.New(t)
+	fx := newLoanServiceFixture(c)
+
+	created := fx.startLoan(c)
+	_, err := fx.loanSvc.SubmitBorrowerRequest(context.Background(), created.ID, services.BorrowerLoanRequest{
+		Kind: models.LoanRequestKindReturn,
+	})
+	c.Assert(err, qt.IsNil)
+
+	rejected, err := fx.loanSvc.RejectBorrowerRequest(fx.ctx, created.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rejected.HasPendingRequest(), qt.IsFalse)
+	c.Assert(rejected.IsOpen(), qt.IsTrue)
+	c.Assert(fx.eventKinds(c), qt.Contains, models.CommodityEventKindLoanRequestRejected)
+}
+
+func TestCommodityLoanService_BorrowerRequest_RejectsPastExtension(t *testing.T) {
+	c := qt.New(t)
+	fx := newLoanServiceFixture(c)
+
+	created := fx.startLoan(c)
+	past := models.Date("2020-01-01")
+	_, err := fx.loanSvc.SubmitBorrowerRequest(context.Background(), created.ID, services.BorrowerLoanRequest{
+		Kind:      models.LoanRequestKindExtension,
+		DueBackAt: models.PDate(&past),
+	})
+	c.Assert(err, qt.ErrorIs, services.ErrInvalidLoanRequest)
+}
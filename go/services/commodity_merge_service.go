@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// ErrMergeSameCommodity is returned when the survivor and the duplicate of
+// a merge are the same row. Apiserver maps it to 422.
+var ErrMergeSameCommodity = errx.NewSentinel("cannot merge a commodity into itself")
+
+// ErrMergeHoldingConflict is returned when both commodities of a merge are
+// currently out (open loan or open service). Moving both holdings onto the
+// survivor would break the "out for at most one reason" invariant enforced
+// by OpenHoldingChecker, so the user has to close one first. Apiserver maps
+// it to 409.
+var ErrMergeHoldingConflict = errx.NewSentinel("both commodities have an open loan or service")
+
+// CommodityMergeResult summarises what CommodityMergeService.Merge moved
+// onto the survivor. The counts are also persisted as the "merged" event
+// payload.
+type CommodityMergeResult struct {
+	Survivor             *models.Commodity
+	MergedID             string
+	Files                int
+	Events               int
+	Loans                int
+	Services             int
+	SupplyLinks          int
+	MaintenanceSchedules int
+	MeterReadings        int
+	InvoiceExtractions   int
+	TagsAdded            []string
+}
+
+// CommodityMergeService folds a duplicate commodity into a surviving one:
+// linked files, tags, the event timeline, loans, service rows, supply links,
+// maintenance schedules (with their completion logs), meter readings and
+// invoice extractions all move onto the survivor, then the duplicate row is deleted. The survivor's
+// own scalar fields (name, prices, serials, area, ...) are never
+// overwritten — the user picks the survivor precisely because its data is
+// the one to keep. The one exception is the cover photo: a survivor without
+// a cover adopts the duplicate's, since the file it points at moves across
+// anyway.
+//
+// The move is not a single transaction: each registry writes on its own
+// (and opens its own RLS-scoped transaction on postgres), so there is no
+// shared transaction to run the steps in. Instead every step is idempotent
+// and the duplicate is deleted last:
+//
+//   - children are selected by "still points at the duplicate", so a re-run
+//     only sees the ones a failed attempt did not get to;
+//   - a maintenance schedule's logs move before the schedule itself, so a
+//     schedule is never left on the survivor with logs behind on the
+//     duplicate;
+//   - the tag / cover update is a union that a re-run turns into a no-op;
+//   - the "merged" event is emitted only after the delete succeeds.
+//
+// A failure part-way therefore leaves both rows in place with the children
+// split between them and nothing lost. Retrying the same merge resumes it;
+// its result counts only what that attempt moved.
+type CommodityMergeService struct {
+	factorySet     *registry.FactorySet
+	eventService   *CommodityEventService
+	holdingChecker *OpenHoldingChecker
+}
+
+func NewCommodityMergeService(factorySet *registry.FactorySet) *CommodityMergeService {
+	return &CommodityMergeService{
+		factorySet:     factorySet,
+		eventService:   NewCommodityEventService(factorySet),
+		holdingChecker: NewOpenHoldingChecker(factorySet),
+	}
+}
+
+// Merge moves everything attached to duplicateID onto survivorID and
+// deletes duplicateID. Both commodities must be visible to the caller;
+// registry.ErrNotFound is returned otherwise.
+func (s *CommodityMergeService) Merge(ctx context.Context, survivorID, duplicateID string) (*CommodityMergeResult, error) {
+	if survivorID == duplicateID {
+		return nil, errxtrace.Wrap("merge survivor and duplicate are the same commodity", ErrMergeSameCommodity)
+	}
+
+	comReg, err := s.factorySet.CommodityRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create commodity registry", err)
+	}
+	survivor, err := comReg.Get(ctx, survivorID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get surviving commodity", err)
+	}
+	duplicate, err := comReg.Get(ctx, duplicateID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get duplicate commodity", err)
+	}
+
+	if err := s.checkHoldings(ctx, survivor, duplicate); err != nil {
+		return nil, err
+	}
+
+	result := &CommodityMergeResult{MergedID: duplicate.ID}
+	if err := s.moveChildren(ctx, duplicate.ID, survivor.ID, result); err != nil {
+		return nil, err
+	}
+
+	updated := *survivor
+	// Clone so appending cannot write into survivor.Tags' backing array,
+	// which is the "before" side of the emitted event.
+	updated.Tags = slices.Clone(survivor.Tags)
+	for _, tag := range duplicate.Tags {
+		if !slices.Contains(updated.Tags, tag) {
+			updated.Tags = append(updated.Tags, tag)
+			result.TagsAdded = append(result.TagsAdded, tag)
+		}
+	}
+	if updated.CoverFileID == nil && duplicate.CoverFileID != nil {
+		updated.CoverFileID = duplicate.CoverFileID
+	}
+	if len(result.TagsAdded) > 0 || !ptrEq(survivor.CoverFileID, updated.CoverFileID) {
+		saved, err := comReg.Update(ctx, updated)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to update surviving commodity", err)
+		}
+		survivor = saved
+	}
+
+	if err := comReg.Delete(ctx, duplicate.ID); err != nil {
+		return nil, errxtrace.Wrap("failed to delete duplicate commodity", err)
+	}
+
+	result.Survivor = survivor
+	s.eventService.EmitMerged(ctx, survivor.ID, duplicate, models.CommodityEventPayload{
+		"files":                 result.Files,
+		"events":                result.Events,
+		"loans":                 result.Loans,
+		"services":              result.Services,
+		"supply_links":          result.SupplyLinks,
+		"maintenance_schedules": result.MaintenanceSchedules,
+		"meter_readings":        result.MeterReadings,
+		"invoice_extractions":   result.InvoiceExtractions,
+		"tags_added":            result.TagsAdded,
+	})
+	return result, nil
+}
+
+// checkHoldings rejects merges that would leave the survivor out for two
+// reasons at once, or would hand a bundle (Count > 1) survivor the
+// duplicate's open loan / service (#1554).
+func (s *CommodityMergeService) checkHoldings(ctx context.Context, survivor, duplicate *models.Commodity) error {
+	survivorHold, err := s.holdingChecker.CheckCommodityFree(ctx, survivor.ID, "")
+	if err != nil && !errors.Is(err, ErrCommodityAlreadyOut) {
+		return errxtrace.Wrap("failed to check surviving commodity holdings", err)
+	}
+	duplicateHold, err := s.holdingChecker.CheckCommodityFree(ctx, duplicate.ID, "")
+	if err != nil && !errors.Is(err, ErrCommodityAlreadyOut) {
+		return errxtrace.Wrap("failed to check duplicate commodity holdings", err)
+	}
+	if survivorHold != nil && duplicateHold != nil {
+		return errxtrace.Wrap("both commodities are out", ErrMergeHoldingConflict)
+	}
+	if duplicateHold != nil && survivor.Count > 1 {
+		return errxtrace.Wrap("surviving commodity has count > 1", ErrCommodityNotTrackable)
+	}
+	return nil
+}
+
+// moveChildren re-points every row that references fromID at toID,
+// recording the per-kind counts on result.
+func (s *CommodityMergeService) moveChildren(ctx context.Context, fromID, toID string, result *CommodityMergeResult) error {
+	fileReg, err := s.factorySet.FileRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create file registry", err)
+	}
+	files, err := fileReg.ListByLinkedEntity(ctx, "commodity", fromID)
+	if err != nil {
+		return errxtrace.Wrap("failed to list linked files", err)
+	}
+	for _, file := range files {
+		file.LinkedEntityID = toID
+		if _, err := fileReg.Update(ctx, *file); err != nil {
+			return errxtrace.Wrap("failed to relink file", err, errx.Attrs("file_id", file.ID))
+		}
+		result.Files++
+	}
+
+	loanReg, err := s.factorySet.CommodityLoanRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create loan registry", err)
+	}
+	loans, err := loanReg.ListByCommodity(ctx, fromID)
+	if err != nil {
+		return errxtrace.Wrap("failed to list loans", err)
+	}
+	for _, loan := range loans {
+		loan.CommodityID = toID
+		if _, err := loanReg.Update(ctx, *loan); err != nil {
+			return errxtrace.Wrap("failed to move loan", err, errx.Attrs("loan_id", loan.ID))
+		}
+		result.Loans++
+	}
+
+	svcReg, err := s.factorySet.CommodityServiceRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create service registry", err)
+	}
+	svcs, err := svcReg.ListByCommodity(ctx, fromID)
+	if err != nil {
+		return errxtrace.Wrap("failed to list services", err)
+	}
+	for _, svc := range svcs {
+		svc.CommodityID = toID
+		if _, err := svcReg.Update(ctx, *svc); err != nil {
+			return errxtrace.Wrap("failed to move service", err, errx.Attrs("service_id", svc.ID))
+		}
+		result.Services++
+	}
+
+	supplyReg, err := s.factorySet.SupplyLinkRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create supply link registry", err)
+	}
+	links, err := supplyReg.ListByCommodity(ctx, fromID)
+	if err != nil {
+		return errxtrace.Wrap("failed to list supply links", err)
+	}
+	for _, link := range links {
+		link.CommodityID = toID
+		if _, err := supplyReg.Update(ctx, *link); err != nil {
+			return errxtrace.Wrap("failed to move supply link", err, errx.Attrs("supply_link_id", link.ID))
+		}
+		result.SupplyLinks++
+	}
+
+	scheduleReg, err := s.factorySet.MaintenanceScheduleRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create maintenance schedule registry", err)
+	}
+	schedules, err := scheduleReg.ListByCommodity(ctx, fromID)
+	if err != nil {
+		return errxtrace.Wrap("failed to list maintenance schedules", err)
+	}
+	logReg, err := s.factorySet.MaintenanceLogRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create maintenance log registry", err)
+	}
+	for _, schedule := range schedules {
+		// Logs first: the schedule is what a retry finds by commodity, so
+		// it must stay on the duplicate until its logs have moved.
+		logs, err := logReg.ListBySchedule(ctx, schedule.ID)
+		if err != nil {
+			return errxtrace.Wrap("failed to list maintenance logs", err, errx.Attrs("schedule_id", schedule.ID))
+		}
+		for _, log := range logs {
+			if log.CommodityID == toID {
+				continue
+			}
+			log.CommodityID = toID
+			if _, err := logReg.Update(ctx, *log); err != nil {
+				return errxtrace.Wrap("failed to move maintenance log", err, errx.Attrs("log_id", log.ID))
+			}
+		}
+
+		schedule.CommodityID = toID
+		if _, err := scheduleReg.Update(ctx, *schedule); err != nil {
+			return errxtrace.Wrap("failed to move maintenance schedule", err, errx.Attrs("schedule_id", schedule.ID))
+		}
+		result.MaintenanceSchedules++
+	}
+
+	readingReg, err := s.factorySet.CommodityMeterReadingRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create meter reading registry", err)
+	}
+	readings, err := readingReg.ListByCommodity(ctx, fromID, "")
+	if err != nil {
+		return errxtrace.Wrap("failed to list meter readings", err)
+	}
+	for _, reading := range readings {
+		reading.CommodityID = toID
+		if _, err := readingReg.Update(ctx, *reading); err != nil {
+			return errxtrace.Wrap("failed to move meter reading", err, errx.Attrs("reading_id", reading.ID))
+		}
+		result.MeterReadings++
+	}
+
+	extractionReg, err := s.factorySet.InvoiceExtractionRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create invoice extraction registry", err)
+	}
+	extractions, err := extractionReg.ListByCommodity(ctx, fromID)
+	if err != nil {
+		return errxtrace.Wrap("failed to list invoice extractions", err)
+	}
+	for _, extraction := range extractions {
+		extraction.CommodityID = toID
+		if _, err := extractionReg.Update(ctx, *extraction); err != nil {
+			return errxtrace.Wrap("failed to move invoice extraction", err, errx.Attrs("extraction_id", extraction.ID))
+		}
+		result.InvoiceExtractions++
+	}
+
+	eventReg, err := s.factorySet.CommodityEventRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create commodity event registry", err)
+	}
+	result.Events, err = eventReg.ReassignCommodity(ctx, fromID, toID)
+	if err != nil {
+		return errxtrace.Wrap("failed to move commodity events", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/internal/aivision"
+	"github.com/denisvmedia/inventario/internal/aivision/mock"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// pricedMockResult is a mock scan result that reports a token split the
+// test price table turns into exactly $0.01.
+func pricedMockResult() aivision.ScanResult {
+	r := mock.DefaultResult()
+	r.InputTokens = 2000
+	r.OutputTokens = 400
+	r.UsedTokens = 2400
+	return r
+}
+
+// mockPrices prices the mock model at $3/M input and $10/M output.
+func mockPrices() aivision.PriceTable {
+	return aivision.PriceTable{
+		"mock": {Input: decimal.RequireFromString("3"), Output: decimal.RequireFromString("10")},
+	}
+}
+
+func monthStart() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCommodityScanService_RecordsCost(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	audit := memory.NewCommodityScanAuditRegistry()
+	svc := services.NewCommodityScanService(mock.New(mock.WithDefaultResult(pricedMockResult())), audit, services.CommodityScanConfig{
+		MaxPhotos:        5,
+		RateLimitPerHour: 100,
+		Prices:           mockPrices(),
+	})
+
+	in := newScanInput(jpegPhoto("a.jpg", 64))
+	in.GroupID = "group-1"
+	_, err := svc.Scan(ctx, "tenant-1", "user-1", in)
+	c.Assert(err, qt.IsNil)
+
+	spent, err := audit.SumCostSince(ctx, "tenant-1", "group-1", monthStart())
+	c.Assert(err, qt.IsNil)
+	c.Assert(spent.String(), qt.Equals, "0.01")
+
+	usage, err := audit.Usage(ctx, monthStart(), time.Now().Add(time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(usage, qt.HasLen, 1)
+	c.Assert(usage[0].GroupID, qt.Equals, "group-1")
+	c.Assert(usage[0].Scans, qt.Equals, 1)
+	c.Assert(usage[0].TokensUsed, qt.Equals, int64(2400))
+}
+
+func TestCommodityScanService_AttributesFallbackProvider(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	audit := memory.NewCommodityScanAuditRegistry()
+	chain := aivision.NewChain(0,
+		mock.New(mock.WithDefaultError(aivision.ErrProviderUnavailable)),
+		mock.New(mock.WithDefaultResult(pricedMockResult())),
+	)
+	svc := services.NewCommodityScanService(chain, audit, services.CommodityScanConfig{
+		MaxPhotos:        5,
+		RateLimitPerHour: 100,
+		Prices:           mockPrices(),
+	})
+
+	_, err := svc.Scan(ctx, "tenant-1", "user-1", newScanInput(jpegPhoto("a.jpg", 64)))
+	c.Assert(err, qt.IsNil)
+
+	usage, err := audit.Usage(ctx, monthStart(), time.Now().Add(time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Assert(usage, qt.HasLen, 1)
+	c.Assert(usage[0].Provider, qt.Equals, mock.Name)
+	c.Assert(usage[0].CostUSD.String(), qt.Equals, "0.01")
+}
+
+func TestCommodityScanService_BudgetExceeded(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	audit := memory.NewCommodityScanAuditRegistry()
+	budgets := memory.NewCommodityScanBudgetRegistry()
+	provider := mock.New(mock.WithDefaultResult(pricedMockResult()))
+	svc := services.NewCommodityScanService(provider, audit, services.CommodityScanConfig{
+		MaxPhotos:        5,
+		RateLimitPerHour: 100,
+		Prices:           mockPrices(),
+	}).WithBudgets(budgets)
+
+	_, err := budgets.Set(ctx, models.CommodityScanBudget{
+		TenantID:        "tenant-1",
+		GroupID:         "group-1",
+		MonthlyLimitUSD: decimal.RequireFromString("0.01"),
+	})
+	c.Assert(err, qt.IsNil)
+
+	in := newScanInput(jpegPhoto("a.jpg", 64))
+	in.GroupID = "group-1"
+
+	// The first scan fits the budget and spends it.
+	_, err = svc.Scan(ctx, "tenant-1", "user-1", in)
+	c.Assert(err, qt.IsNil)
+
+	// The second is refused before the provider is called.
+	_, err = svc.Scan(ctx, "tenant-1", "user-1", in)
+	c.Assert(err, qt.ErrorIs, services.ErrScanBudgetExceeded)
+
+	// Another group of the same tenant has no budget and still scans.
+	other := newScanInput(jpegPhoto("b.jpg", 64))
+	other.GroupID = "group-2"
+	_, err = svc.Scan(ctx, "tenant-1", "user-1", other)
+	c.Assert(err, qt.IsNil)
+
+	// A tenant-wide budget covers every group.
+	_, err = budgets.Set(ctx, models.CommodityScanBudget{
+		TenantID:        "tenant-1",
+		MonthlyLimitUSD: decimal.RequireFromString("0.02"),
+	})
+	c.Assert(err, qt.IsNil)
+	_, err = svc.Scan(ctx, "tenant-1", "user-1", other)
+	c.Assert(err, qt.ErrorIs, services.ErrScanBudgetExceeded)
+
+	// Refused scans are not provider calls and cost nothing.
+	count, err := audit.CountRecentForUser(ctx, "tenant-1", "user-1", time.Now().Add(-1*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 2)
+}
+
+// failingBudgetRegistry fails the budget lookup the way a dropped
+// database connection would.
+type failingBudgetRegistry struct {
+	registry.CommodityScanBudgetRegistry
+	err error
+}
+
+func (r *failingBudgetRegistry) ListForTenant(context.Context, string) ([]*models.CommodityScanBudget, error) {
+	return nil, r.err
+}
+
+// failingSpendAuditRegistry fails only the monthly spend sum.
+type failingSpendAuditRegistry struct {
+	registry.CommodityScanAuditRegistry
+	err error
+}
+
+func (r *failingSpendAuditRegistry) SumCostSince(context.Context, string, string, time.Time) (decimal.Decimal, error) {
+	return decimal.Zero, r.err
+}
+
+func TestCommodityScanService_BudgetLookupFailsClosed(t *testing.T) {
+	boom := errors.New("boom")
+
+	t.Run("budget lookup", func(t *testing.T) {
+		c := qt.New(t)
+		ctx := context.Background()
+
+		audit := memory.NewCommodityScanAuditRegistry()
+		svc := services.NewCommodityScanService(mock.New(mock.WithDefaultResult(pricedMockResult())), audit, services.CommodityScanConfig{
+			MaxPhotos:        5,
+			RateLimitPerHour: 100,
+			Prices:           mockPrices(),
+		}).WithBudgets(&failingBudgetRegistry{err: boom})
+
+		_, err := svc.Scan(ctx, "tenant-1", "user-1", newScanInput(jpegPhoto("a.jpg", 64)))
+		c.Assert(err, qt.ErrorIs, boom)
+
+		// The provider was never called, so nothing was spent.
+		spent, err := audit.SumCostSince(ctx, "tenant-1", "", monthStart())
+		c.Assert(err, qt.IsNil)
+		c.Assert(spent.IsZero(), qt.IsTrue)
+	})
+
+	t.Run("spend lookup", func(t *testing.T) {
+		c := qt.New(t)
+		ctx := context.Background()
+
+		audit := memory.NewCommodityScanAuditRegistry()
+		budgets := memory.NewCommodityScanBudgetRegistry()
+		_, err := budgets.Set(ctx, models.CommodityScanBudget{
+			TenantID:        "tenant-1",
+			MonthlyLimitUSD: decimal.RequireFromString("1"),
+		})
+		c.Assert(err, qt.IsNil)
+		svc := services.NewCommodityScanService(mock.New(mock.WithDefaultResult(pricedMockResult())), &failingSpendAuditRegistry{CommodityScanAuditRegistry: audit, err: boom}, services.CommodityScanConfig{
+			MaxPhotos:        5,
+			RateLimitPerHour: 100,
+			Prices:           mockPrices(),
+		}).WithBudgets(budgets)
+
+		_, err = svc.Scan(ctx, "tenant-1", "user-1", newScanInput(jpegPhoto("a.jpg", 64)))
+		c.Assert(err, qt.ErrorIs, boom)
+
+		spent, err := audit.SumCostSince(ctx, "tenant-1", "", monthStart())
+		c.Assert(err, qt.IsNil)
+		c.Assert(spent.IsZero(), qt.IsTrue)
+	})
+}
@@ -111,6 +111,11 @@ var (
 	// or env-var mishap. The aivision package doc explicitly classifies
 	// auth failures as server-side misconfig for this reason.
 	ErrScanProviderMisconfigured = errx.NewSentinel("commodity scan provider is misconfigured")
+
+	// ErrScanBudgetExceeded fires when the tenant's or the group's
+	// monthly AI vision budget is spent. Checked before the provider is
+	// called, after the rate limiter.
+	ErrScanBudgetExceeded = errx.NewSentinel("commodity scan monthly budget exceeded")
 )
 
 // CommodityScanConfig carries the runtime tunables read from
@@ -132,7 +137,13 @@ type CommodityScanConfig struct {
 	// Timeout is the upstream provider deadline. The service enforces it
 	// by wrapping the incoming context; callers may also set their own
 	// deadline, in which case whichever deadline expires first wins.
+	// With a fallback chain it covers every attempt.
 	Timeout time.Duration
+
+	// Prices turns the provider-reported token usage into the CostUSD
+	// recorded on the audit row. Nil records zero cost, which also
+	// leaves every budget unspent.
+	Prices aivision.PriceTable
 }
 
 // AllowedMIMETypes is the closed list of source MIME types accepted by
@@ -163,12 +174,14 @@ var AllowedMIMETypes = map[string]bool{
 }
 
 // CommodityScanService coordinates the photo-scan flow: rate limit, →
-// validate, → provider call, → audit row. The provider is the boundary
-// to a specific vendor; the audit registry is the source of truth for
-// the rate limiter and operations dashboards.
+// validate, → budget, → provider call, → audit row. The provider is the
+// boundary to a specific vendor (or a fallback chain of them); the audit
+// registry is the source of truth for the rate limiter, the monthly
+// budgets and operations dashboards.
 type CommodityScanService struct {
 	provider aivision.Provider
 	audit    registry.CommodityScanAuditRegistry
+	budgets  registry.CommodityScanBudgetRegistry
 	cfg      CommodityScanConfig
 	now      func() time.Time
 }
@@ -186,6 +199,19 @@ func NewCommodityScanService(provider aivision.Provider, audit registry.Commodit
 	}
 }
 
+// WithBudgets enables the monthly spend check against the tenant and
+// group budgets in reg. Without it no budget is enforced.
+func (s *CommodityScanService) WithBudgets(reg registry.CommodityScanBudgetRegistry) *CommodityScanService {
+	s.budgets = reg
+	return s
+}
+
+// Enabled reports whether a real provider is configured. Background
+// callers check it to avoid writing a "disabled" audit row per file.
+func (s *CommodityScanService) Enabled() bool {
+	return s != nil && s.provider != nil
+}
+
 // SetClock overrides the time source. Used by tests to drive the rate
 // limiter and CreatedAt deterministically.
 func (s *CommodityScanService) SetClock(now func() time.Time) {
@@ -201,6 +227,10 @@ type ScanInput struct {
 	Photos                []ScanPhotoInput
 	HintFromUser          string
 	PreferredCurrencyCode string
+	// GroupID is the location group the scan is made for. It is
+	// recorded on the audit row and selects the group budget; empty
+	// checks the tenant budget only.
+	GroupID string
 }
 
 // ScanPhotoInput is a single photo plus its detected MIME type.
@@ -264,6 +294,7 @@ func (s *CommodityScanService) Scan(ctx context.Context, tenantID, userID string
 	if s.provider == nil {
 		s.writeAudit(ctx, models.CommodityScanAudit{
 			TenantUserAwareEntityID: models.TenantUserAwareEntityID{TenantID: tenantID, UserID: userID},
+			GroupID:                 in.GroupID,
 			Provider:                "none",
 			PhotoCount:              clampInt16(len(in.Photos)),
 			TotalPhotoBytes:         clampInt32(totalBytes),
@@ -282,11 +313,16 @@ func (s *CommodityScanService) Scan(ctx context.Context, tenantID, userID string
 
 	// 3) Rate limit. Counted *after* validation so a malformed request
 	// doesn't burn budget.
-	if err := s.checkRateLimit(ctx, tenantID, userID, len(in.Photos), totalBytes); err != nil {
+	if err := s.checkRateLimit(ctx, tenantID, userID, in.GroupID, len(in.Photos), totalBytes); err != nil {
+		return nil, err
+	}
+
+	// 4) Monthly spend budgets, tenant-wide then per group.
+	if err := s.checkBudget(ctx, tenantID, userID, in.GroupID, len(in.Photos), totalBytes); err != nil {
 		return nil, err
 	}
 
-	// 4) Provider call. The provider already applies its own deadline
+	// 5) Provider call. The provider already applies its own deadline
 	// via the context the handler injected; we re-apply the configured
 	// timeout as a server-side guard.
 	callCtx := ctx
@@ -315,6 +351,7 @@ func (s *CommodityScanService) Scan(ctx context.Context, tenantID, userID string
 
 	audit := models.CommodityScanAudit{
 		TenantUserAwareEntityID: models.TenantUserAwareEntityID{TenantID: tenantID, UserID: userID},
+		GroupID:                 in.GroupID,
 		Provider:                s.provider.Name(),
 		Model:                   s.provider.Model(),
 		PhotoCount:              clampInt16(len(in.Photos)),
@@ -364,6 +401,13 @@ func (s *CommodityScanService) Scan(ctx context.Context, tenantID, userID string
 
 	audit.Status = models.CommodityScanStatusOK
 	audit.TokensUsed = clampInt32(result.UsedTokens)
+	// A fallback chain names the provider that actually answered; bill
+	// and record that one rather than the primary.
+	if result.Provider != "" {
+		audit.Provider = result.Provider
+		audit.Model = result.Model
+	}
+	audit.CostUSD = s.cfg.Prices.Cost(audit.Model, result)
 	// The provider may have a more accurate latency reading (e.g. it
 	// excludes JSON marshalling). Prefer it when present.
 	if result.LatencyMS > 0 {
@@ -510,6 +554,7 @@ func (s *CommodityScanService) validateInput(ctx context.Context, tenantID, user
 
 	audit := models.CommodityScanAudit{
 		TenantUserAwareEntityID: models.TenantUserAwareEntityID{TenantID: tenantID, UserID: userID},
+		GroupID:                 in.GroupID,
 		Provider:                s.providerName(),
 		Model:                   s.providerModel(),
 		Status:                  models.CommodityScanStatusValidation,
@@ -529,7 +574,7 @@ func (s *CommodityScanService) validateInput(ctx context.Context, tenantID, user
 // checkRateLimit counts recent audit rows and rejects when the per-user
 // hourly cap is hit. The cap is read from CommodityScanConfig; zero
 // disables the limiter.
-func (s *CommodityScanService) checkRateLimit(ctx context.Context, tenantID, userID string, photoCount, totalBytes int) error {
+func (s *CommodityScanService) checkRateLimit(ctx context.Context, tenantID, userID, groupID string, photoCount, totalBytes int) error {
 	if s.cfg.RateLimitPerHour <= 0 {
 		return nil
 	}
@@ -545,6 +590,7 @@ func (s *CommodityScanService) checkRateLimit(ctx context.Context, tenantID, use
 	if count >= s.cfg.RateLimitPerHour {
 		s.writeAudit(ctx, models.CommodityScanAudit{
 			TenantUserAwareEntityID: models.TenantUserAwareEntityID{TenantID: tenantID, UserID: userID},
+			GroupID:                 groupID,
 			Provider:                s.providerName(),
 			Model:                   s.providerModel(),
 			PhotoCount:              clampInt16(photoCount),
@@ -557,6 +603,48 @@ func (s *CommodityScanService) checkRateLimit(ctx context.Context, tenantID, use
 	return nil
 }
 
+// checkBudget compares the calendar month's spend (UTC) with the
+// tenant-wide budget and, when the scan is made for a group, the group
+// budget, and rejects once either is used up. Tenants without a budget
+// row are unlimited. Unlike the rate limiter it fails closed: a failed
+// budget or spend lookup is returned, so an unknown spend never lets a
+// scan through.
+func (s *CommodityScanService) checkBudget(ctx context.Context, tenantID, userID, groupID string, photoCount, totalBytes int) error {
+	if s.budgets == nil {
+		return nil
+	}
+	budgets, err := s.budgets.ListForTenant(ctx, tenantID)
+	if err != nil {
+		return errxtrace.Wrap("failed to load commodity scan budgets", err, errx.Attrs("tenant_id", tenantID))
+	}
+	now := s.now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, b := range budgets {
+		if b.GroupID != "" && b.GroupID != groupID {
+			continue
+		}
+		spent, err := s.audit.SumCostSince(ctx, tenantID, b.GroupID, monthStart)
+		if err != nil {
+			return errxtrace.Wrap("failed to sum commodity scan spend", err, errx.Attrs("tenant_id", tenantID, "group_id", b.GroupID))
+		}
+		if spent.LessThan(b.MonthlyLimitUSD) {
+			continue
+		}
+		s.writeAudit(ctx, models.CommodityScanAudit{
+			TenantUserAwareEntityID: models.TenantUserAwareEntityID{TenantID: tenantID, UserID: userID},
+			GroupID:                 groupID,
+			Provider:                s.providerName(),
+			Model:                   s.providerModel(),
+			PhotoCount:              clampInt16(photoCount),
+			TotalPhotoBytes:         clampInt32(totalBytes),
+			Status:                  models.CommodityScanStatusBudgetExceeded,
+			ErrorCode:               "commodity_scan.budget_exceeded",
+		})
+		return errxtrace.Classify(ErrScanBudgetExceeded, errx.Attrs("tenant_id", tenantID, "group_id", b.GroupID))
+	}
+	return nil
+}
+
 // providerName returns the configured provider's Name() or "none" when
 // no provider is wired.
 func (s *CommodityScanService) providerName() string {
@@ -18,6 +18,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/shopspring/decimal"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -349,6 +350,9 @@ func (w *CurrencyMigrationWorker) run(ctx context.Context) {
 // true iff the claim picked up a row (regardless of TX2 outcome) — the
 // run loop uses this signal to switch to active cadence.
 func (w *CurrencyMigrationWorker) tick(ctx context.Context) bool {
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeCurrencyMigration))
+	defer span.End()
+
 	w.runSweep(ctx)
 
 	// Soft-pause (#1308): the recovery sweep above MUST still run while
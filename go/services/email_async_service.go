@@ -11,11 +11,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/denisvmedia/inventario/appctx"
 	emailqueue "github.com/denisvmedia/inventario/email/queue"
 	mailsender "github.com/denisvmedia/inventario/email/sender"
 	"github.com/denisvmedia/inventario/internal/metrics"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 )
 
 // AsyncEmailService is the orchestration layer between business flows and provider
@@ -245,6 +247,59 @@ func (s *AsyncEmailService) SendLoanReminderEmail(ctx context.Context, to, name,
 	})
 }
 
+// SendServiceReminderEmail enqueues a service reminder for an open
+// commodity_services row. Same validation as SendLoanReminderEmail:
+// unknown kinds and negative deltas hard-fail at enqueue time.
+func (s *AsyncEmailService) SendServiceReminderEmail(ctx context.Context, to, name, commodityName, providerName, sentAt, expectedReturnAt, commodityURL, kind string, daysDelta int) error {
+	kind = strings.TrimSpace(kind)
+	switch kind {
+	case "overdue", "due_soon":
+	default:
+		return fmt.Errorf("unsupported service reminder kind: %q", kind)
+	}
+	if daysDelta < 0 {
+		return fmt.Errorf("service reminder daysDelta must be >= 0: %d", daysDelta)
+	}
+	return s.enqueue(ctx, emailJob{
+		TemplateType:     emailTemplateServiceReminder,
+		To:               to,
+		Name:             name,
+		CommodityName:    commodityName,
+		CommodityURL:     commodityURL,
+		ProviderName:     providerName,
+		ServiceSentAt:    sentAt,
+		ExpectedReturnAt: expectedReturnAt,
+		ServiceKind:      kind,
+		ServiceDaysDelta: daysDelta,
+	})
+}
+
+// SendLoanBorrowerReminderEmail enqueues the borrower-facing courtesy
+// reminder. Same validation as SendLoanReminderEmail.
+func (s *AsyncEmailService) SendLoanBorrowerReminderEmail(ctx context.Context, to, borrowerName, lenderName, commodityName, dueBackAt, responseURL, kind string, daysDelta int) error {
+	kind = strings.TrimSpace(kind)
+	switch kind {
+	case "overdue", "due_soon":
+	default:
+		return fmt.Errorf("unsupported loan borrower reminder kind: %q", kind)
+	}
+	if daysDelta < 0 {
+		return fmt.Errorf("loan borrower reminder daysDelta must be >= 0: %d", daysDelta)
+	}
+	return s.enqueue(ctx, emailJob{
+		TemplateType:  emailTemplateLoanBorrowerReminder,
+		To:            to,
+		Name:          borrowerName,
+		URL:           responseURL,
+		CommodityName: commodityName,
+		BorrowerName:  borrowerName,
+		LenderName:    lenderName,
+		DueBackAt:     dueBackAt,
+		LoanKind:      kind,
+		LoanDaysDelta: daysDelta,
+	})
+}
+
 // SendStorageQuotaWarningEmail enqueues a "your group is approaching
 // its storage quota" email (#1585).
 func (s *AsyncEmailService) SendStorageQuotaWarningEmail(ctx context.Context, to, name, groupName string, thresholdPercent, usagePercent int, usedHuman, quotaHuman string, breakdownLines []string, filesURL, settingsURL string) error {
@@ -279,6 +334,48 @@ func (s *AsyncEmailService) SendMaintenanceReminderEmail(ctx context.Context, to
 	})
 }
 
+// SendBackupFailureEmail enqueues the scheduled-backup failure notice.
+// The group's exports page travels in URL.
+func (s *AsyncEmailService) SendBackupFailureEmail(ctx context.Context, to, name, groupName, reason, exportsURL string) error {
+	return s.enqueue(ctx, emailJob{
+		TemplateType:        emailTemplateBackupFailure,
+		To:                  to,
+		Name:                name,
+		URL:                 exportsURL,
+		GroupName:           groupName,
+		BackupFailureReason: reason,
+	})
+}
+
+// SendRegistrationPendingEmail enqueues the new-registration notice for
+// a back-office operator.
+func (s *AsyncEmailService) SendRegistrationPendingEmail(ctx context.Context, to, name, applicantName, applicantEmail, tenantName string) error {
+	return s.enqueue(ctx, emailJob{
+		TemplateType:   emailTemplateRegistrationPending,
+		To:             to,
+		Name:           name,
+		ApplicantName:  applicantName,
+		ApplicantEmail: applicantEmail,
+		TenantName:     tenantName,
+	})
+}
+
+// SendRegistrationDecisionEmail enqueues the approved or rejected notice
+// for the applicant. The sign-in page travels in URL.
+func (s *AsyncEmailService) SendRegistrationDecisionEmail(ctx context.Context, to, name string, approved bool, reason, loginURL string) error {
+	tt := emailTemplateRegistrationRejected
+	if approved {
+		tt = emailTemplateRegistrationApproved
+	}
+	return s.enqueue(ctx, emailJob{
+		TemplateType:   tt,
+		To:             to,
+		Name:           name,
+		URL:            loginURL,
+		DecisionReason: reason,
+	})
+}
+
 func (s *AsyncEmailService) enqueue(ctx context.Context, job emailJob) error {
 	job.ID = uuid.NewString()
 	job.To = strings.TrimSpace(job.To)
@@ -291,6 +388,9 @@ func (s *AsyncEmailService) enqueue(ctx context.Context, job emailJob) error {
 	if job.Language == "" {
 		job.Language = appctx.EmailLanguageFromContext(ctx)
 	}
+	if job.TraceParent == "" {
+		job.TraceParent = tracing.TraceParent(ctx)
+	}
 
 	if job.To == "" {
 		return errors.New("email recipient is required")
@@ -363,6 +463,11 @@ func (s *AsyncEmailService) processJob(ctx context.Context, job emailJob, worker
 
 	sendCtx, cancel := context.WithTimeout(ctx, s.sendTimeout)
 	defer cancel()
+	// The span never names the recipient: addresses are PII.
+	sendCtx, span := tracing.StartJob(sendCtx, "email.send", job.TraceParent,
+		attribute.String("email.template", string(job.TemplateType)),
+		attribute.Int("email.attempt", job.Attempt),
+	)
 
 	// Feedback (#1387) is the one template where the per-message
 	// Reply-To wins: the submitter's address (validated and sanitised
@@ -387,6 +492,7 @@ func (s *AsyncEmailService) processJob(ctx context.Context, job emailJob, worker
 	})
 	// Send-attempt latency is recorded regardless of outcome.
 	metrics.ObserveEmailSend(time.Since(sendStart))
+	tracing.End(span, err)
 	if err == nil {
 		metrics.RecordEmailProcessed(metrics.EmailStatusSent)
 		slog.Debug("Email sent",
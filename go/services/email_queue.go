@@ -61,6 +61,10 @@ type emailJob struct {
 	DueBackAt     string `json:"due_back_at,omitempty"`
 	LoanKind      string `json:"loan_kind,omitempty"`
 	LoanDaysDelta int    `json:"loan_days_delta,omitempty"`
+	// LenderName is populated only by
+	// AsyncEmailService.SendLoanBorrowerReminderEmail, which reuses the
+	// loan fields above for the borrower-facing courtesy reminder.
+	LenderName string `json:"lender_name,omitempty"`
 	// Maintenance-reminder fields. Optional and only populated by
 	// AsyncEmailService.SendMaintenanceReminderEmail (#1368).
 	// CommodityName / CommodityURL / ThresholdDays piggyback on the
@@ -68,6 +72,19 @@ type emailJob struct {
 	// maintenance.
 	MaintenanceTitle   string `json:"maintenance_title,omitempty"`
 	MaintenanceDueDate string `json:"maintenance_due_date,omitempty"`
+	// Service-reminder fields. Populated only by
+	// AsyncEmailService.SendServiceReminderEmail. ServiceKind is the
+	// ServiceReminderKind ("overdue"|"due_soon"); ServiceDaysDelta is
+	// the positive magnitude, same as LoanDaysDelta.
+	ProviderName     string `json:"provider_name,omitempty"`
+	ServiceSentAt    string `json:"service_sent_at,omitempty"`
+	ExpectedReturnAt string `json:"expected_return_at,omitempty"`
+	ServiceKind      string `json:"service_kind,omitempty"`
+	ServiceDaysDelta int    `json:"service_days_delta,omitempty"`
+	// Backup-failure field. Populated only by
+	// AsyncEmailService.SendBackupFailureEmail, which also reuses Name,
+	// GroupName and URL (the group's exports page).
+	BackupFailureReason string `json:"backup_failure_reason,omitempty"`
 	// Feedback fields (#1387). Populated only by
 	// AsyncEmailService.SendFeedbackEmail. FeedbackType is the human
 	// label ("Bug", "Feature request", etc.); FromName/FromEmail/FromUserID
@@ -83,6 +100,18 @@ type emailJob struct {
 	ReplyToEmail     string   `json:"reply_to_email,omitempty"`
 	FeedbackMessage  string   `json:"feedback_message,omitempty"`
 	DiagnosticsLines []string `json:"diagnostics_lines,omitempty"`
+	// Registration-approval fields. ApplicantName / ApplicantEmail /
+	// TenantName are populated only by
+	// AsyncEmailService.SendRegistrationPendingEmail; DecisionReason only
+	// by SendRegistrationDecisionEmail, which also reuses URL. Both reuse
+	// Name.
+	ApplicantName  string `json:"applicant_name,omitempty"`
+	ApplicantEmail string `json:"applicant_email,omitempty"`
+	TenantName     string `json:"tenant_name,omitempty"`
+	DecisionReason string `json:"decision_reason,omitempty"`
+	// TraceParent is the W3C traceparent of the request that enqueued the
+	// job, so the send span joins that request's trace. Retries keep it.
+	TraceParent string `json:"trace_parent,omitempty"`
 }
 
 // newEmailQueue selects Redis-backed queueing when configured; otherwise it
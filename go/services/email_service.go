@@ -85,6 +85,27 @@ type EmailService interface {
 	// template suppresses the link block.
 	SendMaintenanceReminderEmail(ctx context.Context, to, name, commodityName, title, dueDate, commodityURL string, thresholdDays int) error
 
+	// SendServiceReminderEmail requests delivery of a "your item is due
+	// back from service / is overdue from service" notification. Same
+	// contract as SendLoanReminderEmail: `kind` is "overdue" /
+	// "due_soon", `daysDelta` the positive magnitude, and an empty
+	// `commodityURL` suppresses the link block.
+	SendServiceReminderEmail(ctx context.Context, to, name, commodityName, providerName, sentAt, expectedReturnAt, commodityURL, kind string, daysDelta int) error
+
+	// SendLoanBorrowerReminderEmail requests delivery of a courtesy
+	// "please return this item" note to a loan's borrower. Same `kind` /
+	// `daysDelta` contract as SendLoanReminderEmail. `responseURL` is
+	// the signed loan link where the borrower can confirm the return or
+	// ask for an extension; empty suppresses the link block.
+	SendLoanBorrowerReminderEmail(ctx context.Context, to, borrowerName, lenderName, commodityName, dueBackAt, responseURL, kind string, daysDelta int) error
+
+	// SendBackupFailureEmail requests delivery of a "your scheduled backup
+	// failed" notification to the member who owns the group's backup
+	// schedule. `reason` is the export error surfaced verbatim;
+	// `exportsURL` links to the group's exports page and, when empty,
+	// suppresses the link block.
+	SendBackupFailureEmail(ctx context.Context, to, name, groupName, reason, exportsURL string) error
+
 	// SendFeedbackEmail requests delivery of an in-app feedback /
 	// support submission (#1387) to the configured support address.
 	// `to` is the operator-configured support inbox; `fromEmail` /
@@ -99,6 +120,17 @@ type EmailService interface {
 	// rendered into a bullet list; the FE opts in via the diagnostics
 	// checkbox and is responsible for choosing which fields to include.
 	SendFeedbackEmail(ctx context.Context, to, fromEmail, fromName, fromUserID, feedbackType, message, replyToEmail string, diagnosticsLines []string) error
+
+	// SendRegistrationPendingEmail requests delivery of a "new
+	// registration awaits approval" notice to a back-office operator.
+	// Operator-facing, so it is English only like the feedback email.
+	SendRegistrationPendingEmail(ctx context.Context, to, name, applicantName, applicantEmail, tenantName string) error
+
+	// SendRegistrationDecisionEmail requests delivery of the outcome of
+	// a registration review to the applicant. `approved` picks the
+	// template; `reason` is the operator's note (always present for a
+	// rejection) and `loginURL` is the sign-in page, shown on approval.
+	SendRegistrationDecisionEmail(ctx context.Context, to, name string, approved bool, reason, loginURL string) error
 }
 
 // EmailProvider identifies which transport backend should be instantiated by
@@ -319,6 +351,9 @@ func (s *StubEmailService) SendMagicLinkEmail(_ context.Context, to, name, signI
 	return nil
 }
 
+// redactTokenFromURLForLogs strips the credential-bearing query
+// parameters — `token` on the auth links, `sig` on signed loan links —
+// so the remaining URL can be logged.
 func redactTokenFromURLForLogs(raw string) string {
 	parsed, err := url.Parse(raw)
 	if err != nil {
@@ -328,6 +363,7 @@ func redactTokenFromURLForLogs(raw string) string {
 
 	q := parsed.Query()
 	q.Del("token")
+	q.Del("sig")
 	parsed.RawQuery = q.Encode()
 
 	return parsed.String()
@@ -395,6 +431,91 @@ func (s *StubEmailService) SendLoanReminderEmail(_ context.Context, to, name, co
 	return nil
 }
 
+// SendServiceReminderEmail logs the service reminder event without
+// dispatching anything externally. Redacts the deep-link the same way
+// SendLoanReminderEmail does.
+func (s *StubEmailService) SendServiceReminderEmail(_ context.Context, to, name, commodityName, providerName, sentAt, expectedReturnAt, commodityURL, kind string, daysDelta int) error {
+	attrs := []any{
+		"to", to,
+		"name", name,
+		"commodity_name", commodityName,
+		"provider_name", providerName,
+		"sent_at", sentAt,
+		"expected_return_at", expectedReturnAt,
+		"kind", kind,
+		"days_delta", daysDelta,
+	}
+	if s.logEmailURLs {
+		attrs = append(attrs, "commodity_url", commodityURL)
+	} else {
+		attrs = append(attrs, "commodity_url_redacted", redactTokenFromURLForLogs(commodityURL))
+	}
+	//nolint:sloglint // structured fields are constructed dynamically.
+	slog.Info("STUB email: service reminder", attrs...)
+	return nil
+}
+
+// SendLoanBorrowerReminderEmail logs the borrower courtesy reminder
+// without dispatching anything externally. The response link carries a
+// signature, so it is redacted unless LogEmailURLs is enabled.
+func (s *StubEmailService) SendLoanBorrowerReminderEmail(_ context.Context, to, borrowerName, lenderName, commodityName, dueBackAt, responseURL, kind string, daysDelta int) error {
+	attrs := []any{
+		"to", to,
+		"borrower_name", borrowerName,
+		"lender_name", lenderName,
+		"commodity_name", commodityName,
+		"due_back_at", dueBackAt,
+		"kind", kind,
+		"days_delta", daysDelta,
+	}
+	if s.logEmailURLs {
+		attrs = append(attrs, "response_url", responseURL)
+	} else {
+		attrs = append(attrs, "response_url_redacted", redactTokenFromURLForLogs(responseURL))
+	}
+	//nolint:sloglint // structured fields are constructed dynamically.
+	slog.Info("STUB email: loan borrower reminder", attrs...)
+	return nil
+}
+
+// SendBackupFailureEmail logs the scheduled-backup failure without
+// dispatching anything externally. The exports link carries no token, so
+// it is logged as-is.
+func (s *StubEmailService) SendBackupFailureEmail(_ context.Context, to, name, groupName, reason, exportsURL string) error {
+	slog.Info("STUB email: backup failure",
+		"to", to,
+		"name", name,
+		"group_name", groupName,
+		"reason", reason,
+		"exports_url", exportsURL,
+	)
+	return nil
+}
+
+// SendRegistrationPendingEmail logs the new-registration notice without
+// dispatching anything externally. The applicant's name and address are
+// left out, as in SendFeedbackEmail: they are PII.
+func (s *StubEmailService) SendRegistrationPendingEmail(_ context.Context, to, _, _, _, tenantName string) error {
+	slog.Info("STUB email: registration pending approval",
+		"to", to,
+		"tenant_name", tenantName,
+	)
+	return nil
+}
+
+// SendRegistrationDecisionEmail logs the registration decision without
+// dispatching anything externally.
+func (s *StubEmailService) SendRegistrationDecisionEmail(_ context.Context, to, name string, approved bool, reason, loginURL string) error {
+	slog.Info("STUB email: registration decision",
+		"to", to,
+		"name", name,
+		"approved", approved,
+		"reason", reason,
+		"login_url", loginURL,
+	)
+	return nil
+}
+
 // SendStorageQuotaWarningEmail logs the storage quota warning event
 // without dispatching anything externally — useful in tests and the
 // "stub" provider profile.
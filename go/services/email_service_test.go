@@ -28,4 +28,14 @@ func TestRedactTokenFromURLForLogs_RemovesTokenQuery(t *testing.T) {
 		c.Assert(parsed.Query().Has("token"), qt.IsFalse)
 		c.Assert(parsed.Path, qt.Equals, "/reset-password")
 	})
+
+	t.Run("signed loan link", func(t *testing.T) {
+		c := qt.New(t)
+
+		redacted := redactTokenFromURLForLogs("https://example.com/loan-response/loan-1?exp=1700000000&sig=secret")
+		parsed, err := url.Parse(redacted)
+		c.Assert(err, qt.IsNil)
+		c.Assert(parsed.Query().Has("sig"), qt.IsFalse)
+		c.Assert(parsed.Query().Get("exp"), qt.Equals, "1700000000")
+	})
 }
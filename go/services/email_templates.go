@@ -21,17 +21,23 @@ var emailTemplatesFS embed.FS
 type emailTemplateType string
 
 const (
-	emailTemplateVerification        emailTemplateType = "verification"
-	emailTemplatePasswordReset       emailTemplateType = "password_reset"
-	emailTemplateMagicLink           emailTemplateType = "magic_link"
-	emailTemplatePasswordChange      emailTemplateType = "password_changed"
-	emailTemplateWelcome             emailTemplateType = "welcome"
-	emailTemplateWarrantyReminder    emailTemplateType = "warranty_reminder"
-	emailTemplateGroupInvite         emailTemplateType = "group_invite"
-	emailTemplateStorageQuotaWarning emailTemplateType = "storage_quota_warning"
-	emailTemplateLoanReminder        emailTemplateType = "loan_reminder"
-	emailTemplateMaintenanceReminder emailTemplateType = "maintenance_reminder"
-	emailTemplateFeedback            emailTemplateType = "feedback"
+	emailTemplateVerification         emailTemplateType = "verification"
+	emailTemplatePasswordReset        emailTemplateType = "password_reset"
+	emailTemplateMagicLink            emailTemplateType = "magic_link"
+	emailTemplatePasswordChange       emailTemplateType = "password_changed"
+	emailTemplateWelcome              emailTemplateType = "welcome"
+	emailTemplateWarrantyReminder     emailTemplateType = "warranty_reminder"
+	emailTemplateGroupInvite          emailTemplateType = "group_invite"
+	emailTemplateStorageQuotaWarning  emailTemplateType = "storage_quota_warning"
+	emailTemplateLoanReminder         emailTemplateType = "loan_reminder"
+	emailTemplateMaintenanceReminder  emailTemplateType = "maintenance_reminder"
+	emailTemplateServiceReminder      emailTemplateType = "service_reminder"
+	emailTemplateLoanBorrowerReminder emailTemplateType = "loan_borrower_reminder"
+	emailTemplateBackupFailure        emailTemplateType = "backup_failure"
+	emailTemplateFeedback             emailTemplateType = "feedback"
+	emailTemplateRegistrationPending  emailTemplateType = "registration_pending"
+	emailTemplateRegistrationApproved emailTemplateType = "registration_approved"
+	emailTemplateRegistrationRejected emailTemplateType = "registration_rejected"
 )
 
 type renderedEmail struct {
@@ -44,8 +50,8 @@ type renderedEmail struct {
 //
 // Parsing happens once at startup; rendering executes templates per job.
 // Keyed by language ("en"/"cs"/"ru") then template type. The "en" maps are
-// always fully populated; cs/ru hold the localized subset (feedback is
-// operator-facing and stays English). A missing (lang, type) entry falls
+// always fully populated; cs/ru hold the localized subset (feedback and
+// registration_pending are operator-facing and stay English). A missing (lang, type) entry falls
 // back to en at render time. #2090
 type emailTemplateRenderer struct {
 	htmlTemplates map[string]map[emailTemplateType]*htemplate.Template
@@ -95,12 +101,30 @@ type emailTemplateData struct {
 	// equality check.
 	LoanIsOverdue bool
 	LoanIsDueSoon bool
+	// LenderName is the owner's display name in the borrower-facing
+	// courtesy reminder, which reuses the loan fields above and carries
+	// the signed loan link in URL.
+	LenderName string
 	// Maintenance-reminder fields. Empty for every other template type.
 	// CommodityName / CommodityURL / ThresholdDays are shared with the
 	// warranty template; Title is the user-supplied schedule label and
 	// DueDate is the next_due_at formatted as YYYY-MM-DD.
 	MaintenanceTitle   string
 	MaintenanceDueDate string
+	// Service-reminder fields. Same shape as the loan-reminder block:
+	// ServiceKind is "overdue" | "due_soon" and ServiceDaysDelta is the
+	// positive magnitude; the Is* flags drive the template branches.
+	// CommodityName / CommodityURL are shared with the warranty template.
+	ProviderName     string
+	ServiceSentAt    string
+	ExpectedReturnAt string
+	ServiceKind      string
+	ServiceDaysDelta int
+	ServiceIsOverdue bool
+	ServiceIsDueSoon bool
+	// Backup-failure field. GroupName and URL are shared with the other
+	// templates; FailureReason is the export error message.
+	FailureReason string
 	// Feedback fields (#1387). Populated only by
 	// AsyncEmailService.SendFeedbackEmail. FeedbackType is the human
 	// label ("Bug", "Feature request", etc.) the renderer surfaces in
@@ -115,6 +139,13 @@ type emailTemplateData struct {
 	ReplyToEmail     string
 	Message          string
 	DiagnosticsLines []string
+	// Registration-approval fields. The pending notice (operator-facing)
+	// names the applicant and tenant; the decision emails carry the
+	// operator's DecisionReason. URL is shared with the other templates.
+	ApplicantName  string
+	ApplicantEmail string
+	TenantName     string
+	DecisionReason string
 }
 
 // emailTemplateLanguages lists the locales we ship templates + subjects
@@ -126,17 +157,23 @@ var emailTemplateLanguages = []string{"en", "cs", "ru"}
 // (shared across languages); the ".html.tmpl"/".txt.tmpl" suffix is
 // appended by the loader.
 var emailTemplateBasenames = map[emailTemplateType]string{
-	emailTemplateVerification:        "verification",
-	emailTemplatePasswordReset:       "password_reset",
-	emailTemplateMagicLink:           "magic_link",
-	emailTemplatePasswordChange:      "password_changed",
-	emailTemplateWelcome:             "welcome",
-	emailTemplateWarrantyReminder:    "warranty_reminder",
-	emailTemplateGroupInvite:         "group_invite",
-	emailTemplateStorageQuotaWarning: "storage_quota_warning",
-	emailTemplateLoanReminder:        "loan_reminder",
-	emailTemplateMaintenanceReminder: "maintenance_reminder",
-	emailTemplateFeedback:            "feedback",
+	emailTemplateVerification:         "verification",
+	emailTemplatePasswordReset:        "password_reset",
+	emailTemplateMagicLink:            "magic_link",
+	emailTemplatePasswordChange:       "password_changed",
+	emailTemplateWelcome:              "welcome",
+	emailTemplateWarrantyReminder:     "warranty_reminder",
+	emailTemplateGroupInvite:          "group_invite",
+	emailTemplateStorageQuotaWarning:  "storage_quota_warning",
+	emailTemplateLoanReminder:         "loan_reminder",
+	emailTemplateMaintenanceReminder:  "maintenance_reminder",
+	emailTemplateServiceReminder:      "service_reminder",
+	emailTemplateLoanBorrowerReminder: "loan_borrower_reminder",
+	emailTemplateBackupFailure:        "backup_failure",
+	emailTemplateFeedback:             "feedback",
+	emailTemplateRegistrationPending:  "registration_pending",
+	emailTemplateRegistrationApproved: "registration_approved",
+	emailTemplateRegistrationRejected: "registration_rejected",
 }
 
 // emailTemplatePath resolves the embedded path for (lang, basename, suffix).
@@ -253,8 +290,17 @@ func (r *emailTemplateRenderer) render(job emailJob) (renderedEmail, error) {
 		LoanDaysDelta:      job.LoanDaysDelta,
 		LoanIsOverdue:      job.LoanKind == "overdue",
 		LoanIsDueSoon:      job.LoanKind == "due_soon",
+		LenderName:         strings.TrimSpace(job.LenderName),
 		MaintenanceTitle:   strings.TrimSpace(job.MaintenanceTitle),
 		MaintenanceDueDate: strings.TrimSpace(job.MaintenanceDueDate),
+		ProviderName:       strings.TrimSpace(job.ProviderName),
+		ServiceSentAt:      strings.TrimSpace(job.ServiceSentAt),
+		ExpectedReturnAt:   strings.TrimSpace(job.ExpectedReturnAt),
+		ServiceKind:        strings.TrimSpace(job.ServiceKind),
+		ServiceDaysDelta:   job.ServiceDaysDelta,
+		ServiceIsOverdue:   job.ServiceKind == "overdue",
+		ServiceIsDueSoon:   job.ServiceKind == "due_soon",
+		FailureReason:      strings.TrimSpace(job.BackupFailureReason),
 		FeedbackType:       strings.TrimSpace(job.FeedbackType),
 		FromName:           strings.TrimSpace(job.FromName),
 		FromEmail:          strings.TrimSpace(job.FromEmail),
@@ -262,6 +308,10 @@ func (r *emailTemplateRenderer) render(job emailJob) (renderedEmail, error) {
 		ReplyToEmail:       strings.TrimSpace(job.ReplyToEmail),
 		Message:            job.FeedbackMessage,
 		DiagnosticsLines:   job.DiagnosticsLines,
+		ApplicantName:      strings.TrimSpace(job.ApplicantName),
+		ApplicantEmail:     strings.TrimSpace(job.ApplicantEmail),
+		TenantName:         strings.TrimSpace(job.TenantName),
+		DecisionReason:     strings.TrimSpace(job.DecisionReason),
 	}
 	if data.Name == "" {
 		data.Name = "there"
@@ -314,45 +364,62 @@ func normalizeEmailLang(lang string) string {
 }
 
 // emailSubjects holds the fixed per-type subject line for each language.
-// cs/ru omit feedback (operator-facing, English only); subjectByTemplateType
-// falls back to en for any missing (lang, type). #2090
+// cs/ru omit feedback and registration_pending (operator-facing, English
+// only); subjectByTemplateType falls back to en for any missing (lang,
+// type). #2090
 var emailSubjects = map[string]map[emailTemplateType]string{
 	"en": { // #nosec G101 -- email subject lines, not credentials
-		emailTemplateVerification:        "Verify your Inventario account",
-		emailTemplatePasswordReset:       "Reset your Inventario password",
-		emailTemplateMagicLink:           "Sign in to Inventario",
-		emailTemplatePasswordChange:      "Your Inventario password was changed",
-		emailTemplateWelcome:             "Welcome to Inventario",
-		emailTemplateWarrantyReminder:    "Inventario warranty reminder",
-		emailTemplateGroupInvite:         "You're invited to a group on Inventario",
-		emailTemplateStorageQuotaWarning: "Your group is approaching its storage quota",
-		emailTemplateLoanReminder:        "Inventario loan reminder",
-		emailTemplateMaintenanceReminder: "Inventario maintenance reminder",
-		emailTemplateFeedback:            "Inventario feedback",
+		emailTemplateVerification:         "Verify your Inventario account",
+		emailTemplatePasswordReset:        "Reset your Inventario password",
+		emailTemplateMagicLink:            "Sign in to Inventario",
+		emailTemplatePasswordChange:       "Your Inventario password was changed",
+		emailTemplateWelcome:              "Welcome to Inventario",
+		emailTemplateWarrantyReminder:     "Inventario warranty reminder",
+		emailTemplateGroupInvite:          "You're invited to a group on Inventario",
+		emailTemplateStorageQuotaWarning:  "Your group is approaching its storage quota",
+		emailTemplateLoanReminder:         "Inventario loan reminder",
+		emailTemplateMaintenanceReminder:  "Inventario maintenance reminder",
+		emailTemplateServiceReminder:      "Inventario service reminder",
+		emailTemplateLoanBorrowerReminder: "A friendly reminder about a borrowed item",
+		emailTemplateBackupFailure:        "Your scheduled Inventario backup failed",
+		emailTemplateFeedback:             "Inventario feedback",
+		emailTemplateRegistrationPending:  "New Inventario registration awaiting approval",
+		emailTemplateRegistrationApproved: "Your Inventario account has been approved",
+		emailTemplateRegistrationRejected: "Your Inventario registration was declined",
 	},
 	"cs": { // #nosec G101 -- email subject lines, not credentials
-		emailTemplateVerification:        "Ověřte svůj účet Inventario",
-		emailTemplatePasswordReset:       "Obnovení hesla pro Inventario",
-		emailTemplateMagicLink:           "Přihlášení do Inventaria",
-		emailTemplatePasswordChange:      "Vaše heslo k Inventario bylo změněno",
-		emailTemplateWelcome:             "Vítejte v Inventario",
-		emailTemplateWarrantyReminder:    "Připomenutí záruky Inventario",
-		emailTemplateGroupInvite:         "Máte pozvánku do skupiny v Inventariu",
-		emailTemplateStorageQuotaWarning: "Vaše skupina se blíží svému úložnému limitu",
-		emailTemplateLoanReminder:        "Připomenutí zápůjčky Inventario",
-		emailTemplateMaintenanceReminder: "Připomenutí údržby v Inventariu",
+		emailTemplateVerification:         "Ověřte svůj účet Inventario",
+		emailTemplatePasswordReset:        "Obnovení hesla pro Inventario",
+		emailTemplateMagicLink:            "Přihlášení do Inventaria",
+		emailTemplatePasswordChange:       "Vaše heslo k Inventario bylo změněno",
+		emailTemplateWelcome:              "Vítejte v Inventario",
+		emailTemplateWarrantyReminder:     "Připomenutí záruky Inventario",
+		emailTemplateGroupInvite:          "Máte pozvánku do skupiny v Inventariu",
+		emailTemplateStorageQuotaWarning:  "Vaše skupina se blíží svému úložnému limitu",
+		emailTemplateLoanReminder:         "Připomenutí zápůjčky Inventario",
+		emailTemplateMaintenanceReminder:  "Připomenutí údržby v Inventariu",
+		emailTemplateServiceReminder:      "Připomenutí servisu v Inventariu",
+		emailTemplateLoanBorrowerReminder: "Přátelské připomenutí vypůjčené věci",
+		emailTemplateBackupFailure:        "Plánovaná záloha Inventaria selhala",
+		emailTemplateRegistrationApproved: "Váš účet Inventario byl schválen",
+		emailTemplateRegistrationRejected: "Vaše registrace do Inventaria byla zamítnuta",
 	},
 	"ru": { // #nosec G101 -- email subject lines, not credentials
-		emailTemplateVerification:        "Подтвердите свою учётную запись Inventario",
-		emailTemplatePasswordReset:       "Сброс пароля в Inventario",
-		emailTemplateMagicLink:           "Вход в Inventario",
-		emailTemplatePasswordChange:      "Ваш пароль Inventario был изменён",
-		emailTemplateWelcome:             "Добро пожаловать в Inventario",
-		emailTemplateWarrantyReminder:    "Напоминание о гарантии Inventario",
-		emailTemplateGroupInvite:         "Вас пригласили в группу в Inventario",
-		emailTemplateStorageQuotaWarning: "Ваша группа приближается к лимиту квоты хранилища",
-		emailTemplateLoanReminder:        "Напоминание о займе Inventario",
-		emailTemplateMaintenanceReminder: "Напоминание об обслуживании в Inventario",
+		emailTemplateVerification:         "Подтвердите свою учётную запись Inventario",
+		emailTemplatePasswordReset:        "Сброс пароля в Inventario",
+		emailTemplateMagicLink:            "Вход в Inventario",
+		emailTemplatePasswordChange:       "Ваш пароль Inventario был изменён",
+		emailTemplateWelcome:              "Добро пожаловать в Inventario",
+		emailTemplateWarrantyReminder:     "Напоминание о гарантии Inventario",
+		emailTemplateGroupInvite:          "Вас пригласили в группу в Inventario",
+		emailTemplateStorageQuotaWarning:  "Ваша группа приближается к лимиту квоты хранилища",
+		emailTemplateLoanReminder:         "Напоминание о займе Inventario",
+		emailTemplateMaintenanceReminder:  "Напоминание об обслуживании в Inventario",
+		emailTemplateServiceReminder:      "Напоминание о сервисе в Inventario",
+		emailTemplateLoanBorrowerReminder: "Дружеское напоминание о взятой вещи",
+		emailTemplateBackupFailure:        "Плановое резервное копирование Inventario не удалось",
+		emailTemplateRegistrationApproved: "Ваша учётная запись Inventario одобрена",
+		emailTemplateRegistrationRejected: "Ваша регистрация в Inventario отклонена",
 	},
 }
 
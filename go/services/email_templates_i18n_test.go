@@ -45,19 +45,32 @@ func TestEmailTemplateRenderer_AllTypesAllLangsRender(t *testing.T) {
 		DueBackAt:             "2026-02-01",
 		LoanKind:              "overdue",
 		LoanDaysDelta:         5,
+		LenderName:            "Sam",
 		MaintenanceTitle:      "Oil change",
 		MaintenanceDueDate:    "2026-03-01",
+		ProviderName:          "Bob's Repair",
+		ServiceSentAt:         "2026-01-10",
+		ExpectedReturnAt:      "2026-01-20",
+		ServiceKind:           "due_soon",
+		ServiceDaysDelta:      3,
+		BackupFailureReason:   "disk full",
 		FeedbackType:          "Bug",
 		FromName:              "Alex",
 		FromEmail:             "alex@example.com",
 		FeedbackMessage:       "hello",
 		DiagnosticsLines:      []string{"x: y"},
+		ApplicantName:         "Jordan",
+		ApplicantEmail:        "jordan@example.com",
+		TenantName:            "Acme",
+		DecisionReason:        "duplicate account",
 	}
 	types := []emailTemplateType{
 		emailTemplateVerification, emailTemplatePasswordReset, emailTemplateMagicLink,
 		emailTemplatePasswordChange, emailTemplateWelcome, emailTemplateWarrantyReminder,
 		emailTemplateGroupInvite, emailTemplateStorageQuotaWarning, emailTemplateLoanReminder,
-		emailTemplateMaintenanceReminder, emailTemplateFeedback,
+		emailTemplateMaintenanceReminder, emailTemplateServiceReminder, emailTemplateLoanBorrowerReminder,
+		emailTemplateBackupFailure, emailTemplateFeedback, emailTemplateRegistrationPending,
+		emailTemplateRegistrationApproved, emailTemplateRegistrationRejected,
 	}
 	for _, lang := range []string{"en", "cs", "ru"} {
 		for _, tt := range types {
@@ -96,6 +109,8 @@ func TestEmailTemplateRenderer_SubjectLocalization(t *testing.T) {
 		{"welcome unknown -> en", "xx", emailTemplateWelcome, "Welcome to Inventario"},
 		{"verification ru", "ru", emailTemplateVerification, "Подтвердите свою учётную запись Inventario"},
 		{"magic_link cs", "cs", emailTemplateMagicLink, "Přihlášení do Inventaria"},
+		{"registration_rejected ru", "ru", emailTemplateRegistrationRejected, "Ваша регистрация в Inventario отклонена"},
+		{"registration_pending cs -> en", "cs", emailTemplateRegistrationPending, "New Inventario registration awaiting approval"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
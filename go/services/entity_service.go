@@ -86,7 +86,7 @@ func (s *EntityService) DeleteAreaRecursive(ctx context.Context, id string) erro
 	}
 
 	// Check if area exists first - if it's already deleted, that's fine
-	_, err = areaReg.Get(ctx, id)
+	area, err := areaReg.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, registry.ErrNotFound) {
 			// Area is already deleted. Still sweep files linked to it so a
@@ -100,6 +100,11 @@ func (s *EntityService) DeleteAreaRecursive(ctx context.Context, id string) erro
 		}
 		return errxtrace.Wrap("failed to get area", err)
 	}
+	// A conditional delete (registry.WithExpectedVersion) of an area
+	// that changed since is refused before any child is touched.
+	if err := registry.CheckExpectedVersion(ctx, id, area); err != nil {
+		return err
+	}
 
 	// Get all commodities in this area first
 	commodities, err := areaReg.GetCommodities(ctx, id)
@@ -147,7 +152,7 @@ func (s *EntityService) DeleteLocationRecursive(ctx context.Context, id string)
 
 	// Check if location exists first - if it's already deleted, that's fine
 	// (idempotency parity with DeleteAreaRecursive).
-	_, err = locReg.Get(ctx, id)
+	location, err := locReg.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, registry.ErrNotFound) {
 			// Location is already deleted. Still sweep files linked to it so a
@@ -162,6 +167,11 @@ func (s *EntityService) DeleteLocationRecursive(ctx context.Context, id string)
 		}
 		return errxtrace.Wrap("failed to get location", err)
 	}
+	// A conditional delete (registry.WithExpectedVersion) of a location
+	// that changed since is refused before any child is touched.
+	if err := registry.CheckExpectedVersion(ctx, id, location); err != nil {
+		return err
+	}
 
 	// Get all areas in this location
 	areas, err := locReg.GetAreas(ctx, id)
@@ -274,7 +284,7 @@ func (s *EntityService) UnlinkAndDeleteArea(ctx context.Context, id string) erro
 	// If the area is already gone there is nothing to unlink — treat as
 	// success, after sweeping any files still linked to it so a retry
 	// self-heals an interrupted earlier delete (see DeleteAreaRecursive).
-	_, err = areaReg.Get(ctx, id)
+	area, err := areaReg.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, registry.ErrNotFound) {
 			if err := s.fileService.DeleteLinkedFiles(ctx, "area", id); err != nil && !errors.Is(err, registry.ErrNotFound) {
@@ -284,6 +294,11 @@ func (s *EntityService) UnlinkAndDeleteArea(ctx context.Context, id string) erro
 		}
 		return errxtrace.Wrap("failed to get area", err)
 	}
+	// A conditional delete (registry.WithExpectedVersion) of an area
+	// that changed since is refused before any child is touched.
+	if err := registry.CheckExpectedVersion(ctx, id, area); err != nil {
+		return err
+	}
 
 	commodities, err := areaReg.GetCommodities(ctx, id)
 	if err != nil {
@@ -350,7 +365,7 @@ func (s *EntityService) UnlinkAndDeleteLocation(ctx context.Context, id string)
 	// If the location is already gone there is nothing to unlink — success,
 	// after sweeping any files still linked to it so a retry self-heals an
 	// interrupted earlier delete (see DeleteLocationRecursive).
-	_, err = locReg.Get(ctx, id)
+	location, err := locReg.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, registry.ErrNotFound) {
 			if err := s.fileService.DeleteLinkedFiles(ctx, "location", id); err != nil && !errors.Is(err, registry.ErrNotFound) {
@@ -360,6 +375,11 @@ func (s *EntityService) UnlinkAndDeleteLocation(ctx context.Context, id string)
 		}
 		return errxtrace.Wrap("failed to get location", err)
 	}
+	// A conditional delete (registry.WithExpectedVersion) of a location
+	// that changed since is refused before any child is touched.
+	if err := registry.CheckExpectedVersion(ctx, id, location); err != nil {
+		return err
+	}
 
 	areas, err := locReg.GetAreas(ctx, id)
 	if err != nil {
@@ -1543,3 +1543,37 @@ func TestEntityService_MissingEntityNotFound(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteAreaRecursive_StaleVersionTouchesNothing asserts that an
+// If-Match delete (registry.WithExpectedVersion) of an area that changed
+// since is refused before any of its commodities is deleted.
+func TestDeleteAreaRecursive_StaleVersionTouchesNothing(t *testing.T) {
+	c := qt.New(t)
+
+	factorySet := memory.NewFactorySet()
+	ctx := newTestContext(factorySet)
+	registrySet := must.Must(factorySet.CreateUserRegistrySet(ctx))
+
+	service := services.NewEntityService(factorySet, uploadLocationForTempDir(c.TempDir()))
+
+	location := must.Must(registrySet.LocationRegistry.Create(ctx, models.Location{Name: "Loc"}))
+	area := must.Must(registrySet.AreaRegistry.Create(ctx, models.Area{Name: "Area", LocationID: location.ID}))
+	commodity := must.Must(registrySet.CommodityRegistry.Create(ctx, models.Commodity{
+		Name:   "Commodity",
+		AreaID: new(area.ID),
+	}))
+
+	renamed := *area
+	renamed.Name = "Renamed"
+	must.Must(registrySet.AreaRegistry.Update(ctx, renamed))
+
+	err := service.DeleteAreaRecursive(registry.WithExpectedVersion(ctx, area.ID, area.Version), area.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrVersionConflict)
+	c.Assert(must.Must(registrySet.AreaRegistry.Get(ctx, area.ID)), qt.IsNotNil)
+	c.Assert(must.Must(registrySet.CommodityRegistry.Get(ctx, commodity.ID)), qt.IsNotNil)
+
+	err = service.DeleteAreaRecursive(registry.WithExpectedVersion(ctx, area.ID, area.Version+1), area.ID)
+	c.Assert(err, qt.IsNil)
+	_, err = registrySet.CommodityRegistry.Get(ctx, commodity.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+}
@@ -154,6 +154,11 @@ func (s *FileService) DeleteFileWithPhysical(ctx context.Context, fileID string)
 	if err != nil {
 		return errxtrace.Wrap("failed to get file entity", err)
 	}
+	// A conditional delete (registry.WithExpectedVersion) of a file that
+	// changed since is refused before the thumbnail chain is touched.
+	if err := registry.CheckExpectedVersion(ctx, fileID, file); err != nil {
+		return err
+	}
 
 	// Break the thumbnail-generation chain before the file row is removed so
 	// the NO ACTION FKs (slots -> jobs -> files) don't block the delete.
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+var (
+	// ErrScopeRoleNotAllowed is returned when an admin or owner would be
+	// restricted to locations. Those roles manage the group as a whole
+	// (members, invites, exports), which a location scope cannot express,
+	// so only viewer and user members can be scoped.
+	ErrScopeRoleNotAllowed = errx.NewSentinel("only viewer and user members can be restricted to locations")
+	// ErrScopeLocationNotInGroup is returned when a scope names a location
+	// that does not exist in the group.
+	ErrScopeLocationNotInGroup = errx.NewSentinel("location does not belong to this group")
+	// ErrScopeRoleAboveMembership is returned when a scope grants a role
+	// above the member's group role. A scope only narrows access: the
+	// group-level role gate still applies to every request, so a higher
+	// per-location role would promise writes that are never allowed.
+	ErrScopeRoleAboveMembership = errx.NewSentinel("location scope role exceeds the member's group role")
+)
+
+// SetLocationScopeRegistries enables per-location member restrictions.
+// Without it the service reports every member as unrestricted and the
+// scope management methods fail with ErrFieldRequired. The location
+// factory is used in service mode to check that scoped locations belong
+// to the group.
+func (s *GroupService) SetLocationScopeRegistries(scopes registry.GroupMemberLocationScopeRegistry, locations registry.LocationRegistryFactory) {
+	s.scopeRegistry = scopes
+	s.locationFactory = locations
+}
+
+// GetMemberLocationScope returns the member's effective location scope in
+// the group. A nil scope means the member is unrestricted. Called once per
+// group request by the slug resolver.
+func (s *GroupService) GetMemberLocationScope(ctx context.Context, tenantID, groupID, userID string) (models.LocationScope, error) {
+	if s.scopeRegistry == nil {
+		return nil, nil
+	}
+	scopes, err := s.scopeRegistry.ListByMember(ctx, tenantID, groupID, userID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to load member location scope", err)
+	}
+	return models.NewLocationScope(scopes), nil
+}
+
+// ListMemberLocationScopes returns the member's scope rows. Returns
+// ErrNotGroupMember when the user is not a member of the group.
+func (s *GroupService) ListMemberLocationScopes(ctx context.Context, groupID, userID string) ([]*models.GroupMemberLocationScope, error) {
+	membership, err := s.scopedMembership(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.scopeRegistry.ListByMember(ctx, membership.TenantID, groupID, userID)
+}
+
+// SetMemberLocationScopes replaces the member's location restrictions.
+// An empty scopes slice lifts the restriction. Each location must belong
+// to the group and each role must not exceed the member's group role; a
+// location listed twice keeps its last role.
+func (s *GroupService) SetMemberLocationScopes(ctx context.Context, groupID, userID string, scopes []models.GroupMemberLocationScope) ([]*models.GroupMemberLocationScope, error) {
+	membership, err := s.scopedMembership(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) > 0 && membership.Role.AtLeast(models.GroupRoleAdmin) {
+		return nil, errxtrace.Classify(ErrScopeRoleNotAllowed, errx.Attrs("role", membership.Role))
+	}
+
+	byLocation := make(map[string]int, len(scopes))
+	rows := make([]models.GroupMemberLocationScope, 0, len(scopes))
+	for _, scope := range scopes {
+		scope.TenantID = membership.TenantID
+		scope.GroupID = groupID
+		scope.MemberUserID = userID
+		if err := scope.ValidateWithContext(ctx); err != nil {
+			return nil, err
+		}
+		if !membership.Role.AtLeast(scope.Role) {
+			return nil, errxtrace.Classify(ErrScopeRoleAboveMembership,
+				errx.Attrs("location_id", scope.LocationID, "scope_role", scope.Role, "member_role", membership.Role))
+		}
+		if err := s.checkLocationInGroup(ctx, groupID, scope.LocationID); err != nil {
+			return nil, err
+		}
+		if i, ok := byLocation[scope.LocationID]; ok {
+			rows[i] = scope
+			continue
+		}
+		byLocation[scope.LocationID] = len(rows)
+		rows = append(rows, scope)
+	}
+
+	return s.scopeRegistry.ReplaceForMember(ctx, membership.TenantID, groupID, userID, rows)
+}
+
+// ClearMemberLocationScopes lifts the member's location restriction.
+func (s *GroupService) ClearMemberLocationScopes(ctx context.Context, groupID, userID string) error {
+	_, err := s.SetMemberLocationScopes(ctx, groupID, userID, nil)
+	return err
+}
+
+func (s *GroupService) scopedMembership(ctx context.Context, groupID, userID string) (*models.GroupMembership, error) {
+	if s.scopeRegistry == nil || s.locationFactory == nil {
+		return nil, errxtrace.Classify(registry.ErrFieldRequired, errx.Attrs("field_name", "scopeRegistry"))
+	}
+	membership, err := s.membershipRegistry.GetByGroupAndUser(ctx, groupID, userID)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return nil, errxtrace.Classify(ErrNotGroupMember)
+		}
+		return nil, errxtrace.Wrap("failed to look up membership", err)
+	}
+	return membership, nil
+}
+
+func (s *GroupService) checkLocationInGroup(ctx context.Context, groupID, locationID string) error {
+	location, err := s.locationFactory.CreateServiceRegistry().Get(ctx, locationID)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return errxtrace.Classify(ErrScopeLocationNotInGroup, errx.Attrs("location_id", locationID))
+		}
+		return errxtrace.Wrap("failed to look up location", err)
+	}
+	if location.GroupID != groupID {
+		return errxtrace.Classify(ErrScopeLocationNotInGroup, errx.Attrs("location_id", locationID))
+	}
+	return nil
+}
+
+// dropLocationScopes removes a member's scope rows after they were
+// promoted to admin or removed from the group.
+func (s *GroupService) dropLocationScopes(ctx context.Context, tenantID, groupID, userID string) error {
+	if s.scopeRegistry == nil {
+		return nil
+	}
+	if _, err := s.scopeRegistry.ReplaceForMember(ctx, tenantID, groupID, userID, nil); err != nil {
+		return errxtrace.Wrap("failed to drop member location scopes", err)
+	}
+	return nil
+}
+
+// capLocationScopes lowers the member's scope roles that exceed role after
+// a demotion, so the stored scope never promises more than the group-level
+// role gate allows.
+func (s *GroupService) capLocationScopes(ctx context.Context, tenantID, groupID, userID string, role models.GroupRole) error {
+	if s.scopeRegistry == nil {
+		return nil
+	}
+	scopes, err := s.scopeRegistry.ListByMember(ctx, tenantID, groupID, userID)
+	if err != nil {
+		return errxtrace.Wrap("failed to load member location scopes", err)
+	}
+	capped := false
+	rows := make([]models.GroupMemberLocationScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !role.AtLeast(scope.Role) {
+			scope.Role = role
+			capped = true
+		}
+		rows = append(rows, *scope)
+	}
+	if !capped {
+		return nil
+	}
+	if _, err := s.scopeRegistry.ReplaceForMember(ctx, tenantID, groupID, userID, rows); err != nil {
+		return errxtrace.Wrap("failed to cap member location scopes", err)
+	}
+	return nil
+}
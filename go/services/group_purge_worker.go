@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 )
 
@@ -140,6 +141,9 @@ func (w *GroupPurgeWorker) tick(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeGroupPurge))
+	defer span.End()
+
 	if purged, failed, err := w.service.PurgeOnce(ctx); err != nil {
 		slog.Error("Group purge sweep failed", "error", err)
 	} else {
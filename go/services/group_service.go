@@ -121,6 +121,10 @@ type GroupService struct {
 	// the affected user (#1592). Tests that don't care about the default-group
 	// invariant can construct the service without it via NewGroupService.
 	userRegistry registry.UserRegistry
+	// scopeRegistry and locationFactory are optional; see
+	// SetLocationScopeRegistries.
+	scopeRegistry   registry.GroupMemberLocationScopeRegistry
+	locationFactory registry.LocationRegistryFactory
 }
 
 // NewGroupService creates a new GroupService without default-group auto-promotion.
@@ -574,6 +578,14 @@ func (s *GroupService) RemoveMember(ctx context.Context, groupID, userID string)
 		}
 	}
 
+	// A location restriction only qualifies a membership; drop it with the
+	// membership so a later re-invite starts unrestricted. Best-effort: a
+	// leftover row can only narrow access, never widen it.
+	if err := s.dropLocationScopes(ctx, membership.TenantID, groupID, userID); err != nil {
+		slog.Warn("failed to drop location scopes of removed member",
+			"group_id", groupID, "user_id", userID, "error", err)
+	}
+
 	// Auto-promote a remaining membership to default if the user lost the one
 	// they pointed at (#1592). Best-effort — see ensureDefaultGroupBestEffort.
 	s.ensureDefaultGroupBestEffort(ctx, userID)
@@ -607,6 +619,16 @@ func (s *GroupService) UpdateMemberRole(ctx context.Context, groupID, userID str
 			return nil, err
 		}
 	}
+	// Admins and owners cannot be location-scoped (ErrScopeRoleNotAllowed),
+	// so a promotion lifts any restriction the member had. A demotion caps
+	// the per-location roles at the new role (ErrScopeRoleAboveMembership).
+	if newRole.AtLeast(models.GroupRoleAdmin) {
+		if err := s.dropLocationScopes(ctx, membership.TenantID, groupID, userID); err != nil {
+			return nil, err
+		}
+	} else if err := s.capLocationScopes(ctx, membership.TenantID, groupID, userID, newRole); err != nil {
+		return nil, err
+	}
 	return updated, nil
 }
 
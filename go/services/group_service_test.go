@@ -1307,3 +1307,45 @@ func TestGroupService_AttachCurrentUserRole_SingleGroup(t *testing.T) {
 	c.Assert(svc.AttachCurrentUserRole(ctx, group, "tenant-1", outsider.ID), qt.IsNil)
 	c.Assert(group.CurrentUserRole, qt.IsNil)
 }
+
+// TestGroupService_LocationScopeRolesNeverExceedMembership pins that a
+// scope cannot grant more than the member's group role: setting a higher
+// per-location role is rejected, and demoting a scoped member caps the
+// roles already stored.
+func TestGroupService_LocationScopeRolesNeverExceedMembership(t *testing.T) {
+	c := qt.New(t)
+	svc := newTestGroupService()
+	scopes := memory.NewGroupMemberLocationScopeRegistry()
+	locations := memory.NewLocationRegistryFactory()
+	svc.SetLocationScopeRegistries(scopes, locations)
+	ctx := context.Background()
+
+	group, err := svc.CreateGroup(ctx, "tenant-1", "user-1", "Group", "", "", "")
+	c.Assert(err, qt.IsNil)
+	_, err = svc.AddMember(ctx, "tenant-1", group.ID, "user-2", models.GroupRoleUser)
+	c.Assert(err, qt.IsNil)
+
+	location, err := locations.CreateServiceRegistry().Create(ctx, models.Location{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{TenantID: "tenant-1", GroupID: group.ID},
+		Name:                     "Garage",
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = svc.SetMemberLocationScopes(ctx, group.ID, "user-2", []models.GroupMemberLocationScope{
+		{LocationID: location.ID, Role: models.GroupRoleAdmin},
+	})
+	c.Assert(err, qt.ErrorIs, services.ErrScopeRoleAboveMembership)
+
+	_, err = svc.SetMemberLocationScopes(ctx, group.ID, "user-2", []models.GroupMemberLocationScope{
+		{LocationID: location.ID, Role: models.GroupRoleUser},
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = svc.UpdateMemberRole(ctx, group.ID, "user-2", models.GroupRoleViewer)
+	c.Assert(err, qt.IsNil)
+
+	rows, err := svc.ListMemberLocationScopes(ctx, group.ID, "user-2")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.HasLen, 1)
+	c.Assert(rows[0].Role, qt.Equals, models.GroupRoleViewer)
+}
@@ -0,0 +1,480 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/currency"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// ErrTransferSameGroup is returned when the target group of a transfer is
+// the group the items already live in. Apiserver maps it to 422.
+var ErrTransferSameGroup = errx.NewSentinel("cannot transfer into the same group")
+
+// ErrTransferNothingSelected is returned when a transfer names no
+// location, area or commodity. Apiserver maps it to 422.
+var ErrTransferNothingSelected = errx.NewSentinel("nothing selected to transfer")
+
+// ErrTransferTargetAreaRequired is returned when commodities are selected
+// on their own (not through a moved area or location) without an area of
+// the target group to put them in. Apiserver maps it to 422.
+var ErrTransferTargetAreaRequired = errx.NewSentinel("a target area is required to transfer commodities")
+
+// ErrTransferTargetLocationRequired is returned when areas are selected on
+// their own (not through a moved location) without a location of the
+// target group to put them under. Apiserver maps it to 422.
+var ErrTransferTargetLocationRequired = errx.NewSentinel("a target location is required to transfer areas")
+
+// ErrTransferExchangeRateRequired is returned when the two groups value
+// their inventory in different currencies and the request carries no
+// rate. The rate is typed by the user, exactly like a currency migration.
+// Apiserver maps it to 422.
+var ErrTransferExchangeRateRequired = errx.NewSentinel("an exchange rate is required when the groups use different currencies")
+
+// ErrTransferForbidden is returned when the caller is not at least a
+// `user` in both groups. Apiserver maps it to 403.
+var ErrTransferForbidden = errx.NewSentinel("transferring requires at least the user role in both groups")
+
+// GroupTransferRequest selects what to move out of the group in ctx.
+//
+// Locations move with all their areas and commodities. Areas move with
+// their commodities and land under TargetLocationID. Commodities land in
+// TargetAreaID. A selection already covered by a wider one (an area of a
+// selected location, a commodity of a selected area) keeps its place and
+// needs no target.
+type GroupTransferRequest struct {
+	TargetGroupID    string
+	LocationIDs      []string
+	AreaIDs          []string
+	TargetLocationID string
+	CommodityIDs     []string
+	TargetAreaID     string
+	// ExchangeRate converts prices from the source group currency to the
+	// target group currency. Required only when the two differ.
+	ExchangeRate *decimal.Decimal
+}
+
+// GroupTransferResult summarises a completed transfer.
+type GroupTransferResult struct {
+	TargetGroup *models.LocationGroup
+	Locations   int
+	Areas       int
+	Commodities int
+	Files       int
+	// TagsCreated lists the slugs provisioned in the target group, commodity
+	// and file tags together.
+	TagsCreated []string
+	// Converted reports whether prices were converted with ExchangeRate.
+	Converted    bool
+	ExchangeRate decimal.Decimal
+}
+
+// GroupTransferService moves inventory from one location group to
+// another group of the same tenant — when a family member moves out or a
+// team splits, the items keep their files, tags, loans, service records,
+// maintenance history and timeline instead of being recreated by hand.
+//
+// The caller needs at least the `user` role in both groups. Prices are
+// converted with currency.ApplyConversion when the group currencies
+// differ, so a transferred commodity reads exactly as if its old group had
+// run a currency migration first, acquisition capture included.
+//
+// Tags are provisioned in the target group before the move (copying label
+// and color from the source group); the move itself is one
+// registry.GroupTransferrer call. A "transferred" event is then written in
+// both groups. Tag provisioning and events are outside the move: a failure
+// there leaves at worst an unused tag or a missing timeline row.
+type GroupTransferService struct {
+	factorySet   *registry.FactorySet
+	eventService *CommodityEventService
+}
+
+func NewGroupTransferService(factorySet *registry.FactorySet) *GroupTransferService {
+	return &GroupTransferService{
+		factorySet:   factorySet,
+		eventService: NewCommodityEventService(factorySet),
+	}
+}
+
+// transferPlan is the resolved selection before it is handed to the
+// registry.
+type transferPlan struct {
+	transfer registry.GroupTransfer
+	// before holds the commodities as they read in the source group,
+	// parallel to transfer.Commodities.
+	before []*models.Commodity
+	files  []*models.FileEntity
+}
+
+// Transfer moves the selection out of the group in ctx into
+// req.TargetGroupID. Every selected id must belong to the source group and
+// the targets to the target group; registry.ErrNotFound is returned
+// otherwise. A group locked by an in-flight currency migration fails with
+// registry.ErrMigrationInFlight.
+func (s *GroupTransferService) Transfer(ctx context.Context, req GroupTransferRequest) (*GroupTransferResult, error) {
+	user, err := appctx.RequireUserFromContext(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get user from context", err)
+	}
+	source := appctx.GroupFromContext(ctx)
+	if source == nil {
+		return nil, errxtrace.Wrap("group context is required", registry.ErrFieldRequired)
+	}
+	if req.TargetGroupID == source.ID {
+		return nil, errxtrace.Classify(ErrTransferSameGroup, errx.Attrs("group_id", source.ID))
+	}
+	if len(req.LocationIDs) == 0 && len(req.AreaIDs) == 0 && len(req.CommodityIDs) == 0 {
+		return nil, errxtrace.Classify(ErrTransferNothingSelected)
+	}
+
+	target, err := s.factorySet.LocationGroupRegistry.Get(ctx, req.TargetGroupID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get target group", err)
+	}
+	if target.TenantID != source.TenantID || target.Status != models.LocationGroupStatusActive {
+		return nil, errxtrace.Classify(registry.ErrNotFound, errx.Attrs("group_id", req.TargetGroupID))
+	}
+	migrations := s.factorySet.CurrencyMigrationRegistryFactory.CreateServiceRegistry()
+	for _, g := range []*models.LocationGroup{source, target} {
+		if err := s.checkRole(ctx, g.ID, user.ID); err != nil {
+			return nil, err
+		}
+		inFlight, err := migrations.InFlightForGroup(ctx, g.ID)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to check currency migration lock", err, errx.Attrs("group_id", g.ID))
+		}
+		if inFlight != nil {
+			return nil, errxtrace.Classify(registry.ErrMigrationInFlight,
+				errx.Attrs("group_id", g.ID, "migration_id", inFlight.ID))
+		}
+	}
+
+	result := &GroupTransferResult{TargetGroup: target}
+	convert := source.GroupCurrency != "" && target.GroupCurrency != "" && source.GroupCurrency != target.GroupCurrency
+	if convert {
+		if req.ExchangeRate == nil {
+			return nil, errxtrace.Classify(ErrTransferExchangeRateRequired,
+				errx.Attrs("from_currency", source.GroupCurrency, "to_currency", target.GroupCurrency))
+		}
+		if err := currency.ValidateRate(*req.ExchangeRate); err != nil {
+			return nil, errxtrace.Wrap("invalid exchange rate", err)
+		}
+		result.Converted = true
+		result.ExchangeRate = *req.ExchangeRate
+	}
+
+	targetCtx := appctx.WithGroup(ctx, target)
+	plan, err := s.resolve(ctx, targetCtx, req)
+	if err != nil {
+		return nil, err
+	}
+	plan.transfer.TenantID = source.TenantID
+	plan.transfer.FromGroupID = source.ID
+	plan.transfer.ToGroupID = target.ID
+
+	if convert {
+		for i := range plan.transfer.Commodities {
+			tc := &plan.transfer.Commodities[i]
+			applied := currency.ApplyConversion(*plan.before[i], source.GroupCurrency, target.GroupCurrency, *req.ExchangeRate)
+			tc.Commodity.OriginalPrice = applied.After.OriginalPrice
+			tc.Commodity.OriginalPriceCurrency = applied.After.OriginalPriceCurrency
+			tc.Commodity.ConvertedOriginalPrice = applied.After.ConvertedOriginalPrice
+			tc.Commodity.CurrentPrice = applied.After.CurrentPrice
+			tc.FillAcquisition = applied.FillAcquisition
+			tc.AcquisitionPrice = applied.AcquisitionPrice
+			tc.AcquisitionCurrency = applied.AcquisitionCurrency
+		}
+	}
+
+	created, err := s.provisionTags(ctx, targetCtx, plan)
+	if err != nil {
+		return nil, err
+	}
+	result.TagsCreated = created
+
+	if err := s.factorySet.GroupTransferrer.TransferToGroup(ctx, plan.transfer); err != nil {
+		return nil, errxtrace.Wrap("failed to transfer to group", err)
+	}
+
+	for i := range plan.transfer.Commodities {
+		after := &plan.transfer.Commodities[i].Commodity
+		s.eventService.EmitTransferred(ctx, plan.before[i], after, source, target)
+		s.eventService.EmitTransferred(targetCtx, plan.before[i], after, source, target)
+	}
+
+	result.Locations = len(plan.transfer.LocationIDs)
+	result.Areas = len(plan.transfer.Areas)
+	result.Commodities = len(plan.transfer.Commodities)
+	result.Files = len(plan.files)
+	return result, nil
+}
+
+// checkRole fails with ErrTransferForbidden unless the user is at least a
+// `user` in the group.
+func (s *GroupTransferService) checkRole(ctx context.Context, groupID, userID string) error {
+	m, err := s.factorySet.GroupMembershipRegistry.GetByGroupAndUser(ctx, groupID, userID)
+	if errors.Is(err, registry.ErrNotFound) {
+		return errxtrace.Classify(ErrTransferForbidden, errx.Attrs("group_id", groupID))
+	}
+	if err != nil {
+		return errxtrace.Wrap("failed to look up membership", err, errx.Attrs("group_id", groupID))
+	}
+	if !m.Role.AtLeast(models.GroupRoleUser) {
+		return errxtrace.Classify(ErrTransferForbidden, errx.Attrs("group_id", groupID, "role", m.Role))
+	}
+	return nil
+}
+
+// resolve expands the request into the rows to move. Source rows are read
+// through the caller's group-scoped registries, so an id of another group
+// is simply not found; targets are read the same way in targetCtx.
+//
+//nolint:gocognit,funlen // one linear walk down locations → areas → commodities
+func (s *GroupTransferService) resolve(ctx, targetCtx context.Context, req GroupTransferRequest) (*transferPlan, error) {
+	locReg, err := s.factorySet.LocationRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create location registry", err)
+	}
+	areaReg, err := s.factorySet.AreaRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create area registry", err)
+	}
+	comReg, err := s.factorySet.CommodityRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create commodity registry", err)
+	}
+
+	plan := &transferPlan{}
+	movedLocations := make(map[string]bool)
+	movedAreas := make(map[string]bool)
+	movedCommodities := make(map[string]bool)
+
+	addArea := func(area *models.Area, locationID string) error {
+		movedAreas[area.ID] = true
+		plan.transfer.Areas = append(plan.transfer.Areas, registry.GroupTransferArea{ID: area.ID, LocationID: locationID})
+		commodityIDs, err := areaReg.GetCommodities(ctx, area.ID)
+		if err != nil {
+			return errxtrace.Wrap("failed to list area commodities", err, errx.Attrs("area_id", area.ID))
+		}
+		for _, id := range commodityIDs {
+			if movedCommodities[id] {
+				continue
+			}
+			c, err := comReg.Get(ctx, id)
+			if err != nil {
+				return errxtrace.Wrap("failed to get commodity", err, errx.Attrs("commodity_id", id))
+			}
+			plan.addCommodity(c, c.AreaID)
+			movedCommodities[id] = true
+		}
+		return nil
+	}
+
+	for _, id := range req.LocationIDs {
+		if movedLocations[id] {
+			continue
+		}
+		if _, err := locReg.Get(ctx, id); err != nil {
+			return nil, errxtrace.Wrap("failed to get location", err, errx.Attrs("location_id", id))
+		}
+		movedLocations[id] = true
+		plan.transfer.LocationIDs = append(plan.transfer.LocationIDs, id)
+		areaIDs, err := locReg.GetAreas(ctx, id)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to list location areas", err, errx.Attrs("location_id", id))
+		}
+		for _, areaID := range areaIDs {
+			area, err := areaReg.Get(ctx, areaID)
+			if err != nil {
+				return nil, errxtrace.Wrap("failed to get area", err, errx.Attrs("area_id", areaID))
+			}
+			if err := addArea(area, area.LocationID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, id := range req.AreaIDs {
+		if movedAreas[id] {
+			continue
+		}
+		area, err := areaReg.Get(ctx, id)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to get area", err, errx.Attrs("area_id", id))
+		}
+		if movedLocations[area.LocationID] {
+			continue
+		}
+		if req.TargetLocationID == "" {
+			return nil, errxtrace.Classify(ErrTransferTargetLocationRequired, errx.Attrs("area_id", id))
+		}
+		if err := s.requireTargetLocation(targetCtx, req.TargetLocationID); err != nil {
+			return nil, err
+		}
+		if err := addArea(area, req.TargetLocationID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range req.CommodityIDs {
+		if movedCommodities[id] {
+			continue
+		}
+		c, err := comReg.Get(ctx, id)
+		if err != nil {
+			return nil, errxtrace.Wrap("failed to get commodity", err, errx.Attrs("commodity_id", id))
+		}
+		if req.TargetAreaID == "" {
+			return nil, errxtrace.Classify(ErrTransferTargetAreaRequired, errx.Attrs("commodity_id", id))
+		}
+		if err := s.requireTargetArea(targetCtx, req.TargetAreaID); err != nil {
+			return nil, err
+		}
+		plan.addCommodity(c, &req.TargetAreaID)
+		movedCommodities[id] = true
+	}
+
+	files, err := s.linkedFiles(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	plan.files = files
+	return plan, nil
+}
+
+// addCommodity appends c to the plan, placed in areaID.
+func (p *transferPlan) addCommodity(c *models.Commodity, areaID *string) {
+	moved := *c
+	moved.AreaID = areaID
+	p.before = append(p.before, c)
+	p.transfer.Commodities = append(p.transfer.Commodities, registry.GroupTransferCommodity{Commodity: moved})
+}
+
+func (s *GroupTransferService) requireTargetLocation(targetCtx context.Context, id string) error {
+	reg, err := s.factorySet.LocationRegistryFactory.CreateUserRegistry(targetCtx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create target location registry", err)
+	}
+	if _, err := reg.Get(targetCtx, id); err != nil {
+		return errxtrace.Wrap("failed to get target location", err, errx.Attrs("location_id", id))
+	}
+	return nil
+}
+
+func (s *GroupTransferService) requireTargetArea(targetCtx context.Context, id string) error {
+	reg, err := s.factorySet.AreaRegistryFactory.CreateUserRegistry(targetCtx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create target area registry", err)
+	}
+	if _, err := reg.Get(targetCtx, id); err != nil {
+		return errxtrace.Wrap("failed to get target area", err, errx.Attrs("area_id", id))
+	}
+	return nil
+}
+
+// linkedFiles lists the files attached to every moved location, area and
+// commodity. The registry moves them by link on its own; the service only
+// needs them for their tags and the result count.
+func (s *GroupTransferService) linkedFiles(ctx context.Context, plan *transferPlan) ([]*models.FileEntity, error) {
+	fileReg, err := s.factorySet.FileRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create file registry", err)
+	}
+
+	var files []*models.FileEntity
+	list := func(entityType, id string) error {
+		linked, err := fileReg.ListByLinkedEntity(ctx, entityType, id)
+		if err != nil {
+			return errxtrace.Wrap("failed to list linked files", err, errx.Attrs("entity_type", entityType, "entity_id", id))
+		}
+		files = append(files, linked...)
+		return nil
+	}
+	for _, id := range plan.transfer.LocationIDs {
+		if err := list("location", id); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range plan.transfer.Areas {
+		if err := list("area", a.ID); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range plan.before {
+		if err := list("commodity", c.ID); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// provisionTags makes sure every commodity and file tag of the plan exists
+// in the target group, copying label and color from the source group's tag
+// (or falling back to the auto-create defaults when the source has no row
+// for the slug). Returns the created slugs.
+func (s *GroupTransferService) provisionTags(ctx, targetCtx context.Context, plan *transferPlan) ([]string, error) {
+	var commoditySlugs, fileSlugs []string
+	for _, c := range plan.before {
+		commoditySlugs = append(commoditySlugs, c.Tags...)
+	}
+	for _, f := range plan.files {
+		fileSlugs = append(fileSlugs, f.Tags...)
+	}
+
+	srcReg, err := s.factorySet.TagRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create tag registry", err)
+	}
+	dstReg, err := s.factorySet.TagRegistryFactory.CreateUserRegistry(targetCtx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create target tag registry", err)
+	}
+
+	var created []string
+	for _, kind := range []models.TagKind{models.TagKindCommodity, models.TagKindFile} {
+		slugs := commoditySlugs
+		if kind == models.TagKindFile {
+			slugs = fileSlugs
+		}
+		slices.Sort(slugs)
+		for _, slug := range slices.Compact(slugs) {
+			_, err := dstReg.GetBySlug(targetCtx, kind, slug)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, registry.ErrNotFound) {
+				return nil, errxtrace.Wrap("failed to look up target tag", err, errx.Attrs("slug", slug))
+			}
+
+			now := time.Now()
+			tag := models.Tag{
+				Kind:      kind,
+				Slug:      slug,
+				Label:     defaultLabelFromSlug(slug),
+				Color:     models.DefaultTagColor,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if src, err := srcReg.GetBySlug(ctx, kind, slug); err == nil {
+				tag.Label = src.Label
+				tag.Color = src.Color
+			}
+			if _, err := dstReg.Create(targetCtx, tag); err != nil {
+				return nil, errxtrace.Wrap("failed to create target tag", err, errx.Attrs("slug", slug))
+			}
+			if !slices.Contains(created, slug) {
+				created = append(created, slug)
+			}
+		}
+	}
+	return created, nil
+}
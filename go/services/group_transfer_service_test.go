@@ -0,0 +1,298 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// transferFixture seeds two groups of one tenant — "home" in USD and
+// "flat" in EUR — with a user who is a member of both, plus a location →
+// area → commodity chain in home carrying a file, a loan and a tag.
+type transferFixture struct {
+	fs        *registry.FactorySet
+	user      *models.User
+	source    *models.LocationGroup
+	target    *models.LocationGroup
+	sourceCtx context.Context
+	targetCtx context.Context
+
+	location  *models.Location
+	area      *models.Area
+	commodity *models.Commodity
+	file      *models.FileEntity
+	loan      *models.CommodityLoan
+	// targetArea is an area of the target group, the landing spot for
+	// standalone commodity transfers.
+	targetArea *models.Area
+}
+
+func newTransferFixture(c *qt.C, targetRole models.GroupRole) *transferFixture {
+	c.Helper()
+	ctx := context.Background()
+	fs := memory.NewFactorySet()
+
+	user, err := fs.UserRegistry.Create(ctx, models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: "tenant-1"},
+		Email:               "mover@example.com",
+		Name:                "Mover",
+	})
+	c.Assert(err, qt.IsNil)
+
+	newGroup := func(slug string, cur models.Currency, role models.GroupRole) *models.LocationGroup {
+		g, err := fs.LocationGroupRegistry.Create(ctx, models.LocationGroup{
+			TenantAwareEntityID: models.TenantAwareEntityID{TenantID: "tenant-1"},
+			Slug:                slug,
+			Name:                slug,
+			Status:              models.LocationGroupStatusActive,
+			CreatedBy:           user.ID,
+			GroupCurrency:       cur,
+		})
+		c.Assert(err, qt.IsNil)
+		_, err = fs.GroupMembershipRegistry.Create(ctx, models.GroupMembership{
+			TenantAwareEntityID: models.TenantAwareEntityID{TenantID: "tenant-1"},
+			GroupID:             g.ID,
+			MemberUserID:        user.ID,
+			Role:                role,
+		})
+		c.Assert(err, qt.IsNil)
+		return g
+	}
+
+	fx := &transferFixture{fs: fs, user: user}
+	fx.source = newGroup("home", "USD", models.GroupRoleAdmin)
+	fx.target = newGroup("flat", "EUR", targetRole)
+	fx.sourceCtx = appctx.WithGroup(appctx.WithUser(ctx, user), fx.source)
+	fx.targetCtx = appctx.WithGroup(appctx.WithUser(ctx, user), fx.target)
+
+	locReg, err := fs.LocationRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	fx.location, err = locReg.Create(fx.sourceCtx, models.Location{Name: "Garage"})
+	c.Assert(err, qt.IsNil)
+	areaReg, err := fs.AreaRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	fx.area, err = areaReg.Create(fx.sourceCtx, models.Area{Name: "Shelf", LocationID: fx.location.ID})
+	c.Assert(err, qt.IsNil)
+
+	comReg, err := fs.CommodityRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	fx.commodity, err = comReg.Create(fx.sourceCtx, models.Commodity{
+		Name:                  "Drill",
+		ShortName:             "drill",
+		Type:                  models.CommodityTypeEquipment,
+		Status:                models.CommodityStatusInUse,
+		Count:                 1,
+		AreaID:                &fx.area.ID,
+		OriginalPrice:         decimal.RequireFromString("100"),
+		OriginalPriceCurrency: "USD",
+		Tags:                  []string{"power-tools"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	tagReg, err := fs.TagRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	_, err = tagReg.Create(fx.sourceCtx, models.Tag{
+		Kind:  models.TagKindCommodity,
+		Slug:  "power-tools",
+		Label: "Power tools",
+		Color: models.TagColorRed,
+	})
+	c.Assert(err, qt.IsNil)
+
+	fileReg, err := fs.FileRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	fx.file, err = fileReg.Create(fx.sourceCtx, models.FileEntity{
+		Title:            "manual",
+		Type:             models.FileTypeDocument,
+		LinkedEntityType: "commodity",
+		LinkedEntityID:   fx.commodity.ID,
+		Tags:             []string{"manuals"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	loanReg, err := fs.CommodityLoanRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	fx.loan, err = loanReg.Create(fx.sourceCtx, models.CommodityLoan{
+		CommodityID:  fx.commodity.ID,
+		BorrowerName: "Alice",
+		LentAt:       models.Date("2026-05-01"),
+	})
+	c.Assert(err, qt.IsNil)
+
+	targetLocReg, err := fs.LocationRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	targetLoc, err := targetLocReg.Create(fx.targetCtx, models.Location{Name: "Flat"})
+	c.Assert(err, qt.IsNil)
+	targetAreaReg, err := fs.AreaRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	fx.targetArea, err = targetAreaReg.Create(fx.targetCtx, models.Area{Name: "Closet", LocationID: targetLoc.ID})
+	c.Assert(err, qt.IsNil)
+
+	return fx
+}
+
+func rate(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
+}
+
+func TestGroupTransferService_Transfer_Location(t *testing.T) {
+	c := qt.New(t)
+	fx := newTransferFixture(c, models.GroupRoleUser)
+	svc := services.NewGroupTransferService(fx.fs)
+
+	result, err := svc.Transfer(fx.sourceCtx, services.GroupTransferRequest{
+		TargetGroupID: fx.target.ID,
+		LocationIDs:   []string{fx.location.ID},
+		ExchangeRate:  rate("0.5"),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Locations, qt.Equals, 1)
+	c.Assert(result.Areas, qt.Equals, 1)
+	c.Assert(result.Commodities, qt.Equals, 1)
+	c.Assert(result.Files, qt.Equals, 1)
+	c.Assert(result.Converted, qt.IsTrue)
+	c.Assert(result.TagsCreated, qt.DeepEquals, []string{"power-tools", "manuals"})
+
+	// Gone from the source group…
+	srcComReg, err := fx.fs.CommodityRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	_, err = srcComReg.Get(fx.sourceCtx, fx.commodity.ID)
+	c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+
+	// …and visible in the target, in its old area, priced in EUR.
+	dstComReg, err := fx.fs.CommodityRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	moved, err := dstComReg.Get(fx.targetCtx, fx.commodity.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(*moved.AreaID, qt.Equals, fx.area.ID)
+	c.Assert(string(moved.OriginalPriceCurrency), qt.Equals, "EUR")
+	c.Assert(moved.OriginalPrice.String(), qt.Equals, "50")
+	c.Assert(moved.AcquisitionPrice, qt.IsNotNil)
+	c.Assert(moved.AcquisitionPrice.String(), qt.Equals, "100")
+
+	dstAreaReg, err := fx.fs.AreaRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	commodityIDs, err := dstAreaReg.GetCommodities(fx.targetCtx, fx.area.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(commodityIDs, qt.DeepEquals, []string{fx.commodity.ID})
+
+	dstFileReg, err := fx.fs.FileRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	_, err = dstFileReg.Get(fx.targetCtx, fx.file.ID)
+	c.Assert(err, qt.IsNil)
+	dstLoanReg, err := fx.fs.CommodityLoanRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	_, err = dstLoanReg.Get(fx.targetCtx, fx.loan.ID)
+	c.Assert(err, qt.IsNil)
+
+	// The tag keeps its label and color in the new group.
+	dstTagReg, err := fx.fs.TagRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	tag, err := dstTagReg.GetBySlug(fx.targetCtx, models.TagKindCommodity, "power-tools")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.Label, qt.Equals, "Power tools")
+	c.Assert(tag.Color, qt.Equals, models.TagColorRed)
+
+	// A transferred event lands in each group.
+	events, err := fx.fs.CommodityEventRegistryFactory.CreateServiceRegistry().List(context.Background())
+	c.Assert(err, qt.IsNil)
+	groups := map[string]bool{}
+	for _, e := range events {
+		if e.Kind == models.CommodityEventKindTransferred {
+			groups[e.GroupID] = true
+		}
+	}
+	c.Assert(groups, qt.DeepEquals, map[string]bool{fx.source.ID: true, fx.target.ID: true})
+}
+
+func TestGroupTransferService_Transfer_CommodityToTargetArea(t *testing.T) {
+	c := qt.New(t)
+	fx := newTransferFixture(c, models.GroupRoleUser)
+	svc := services.NewGroupTransferService(fx.fs)
+
+	result, err := svc.Transfer(fx.sourceCtx, services.GroupTransferRequest{
+		TargetGroupID: fx.target.ID,
+		CommodityIDs:  []string{fx.commodity.ID},
+		TargetAreaID:  fx.targetArea.ID,
+		ExchangeRate:  rate("0.5"),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Locations, qt.Equals, 0)
+	c.Assert(result.Areas, qt.Equals, 0)
+	c.Assert(result.Commodities, qt.Equals, 1)
+
+	dstComReg, err := fx.fs.CommodityRegistryFactory.CreateUserRegistry(fx.targetCtx)
+	c.Assert(err, qt.IsNil)
+	moved, err := dstComReg.Get(fx.targetCtx, fx.commodity.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(*moved.AreaID, qt.Equals, fx.targetArea.ID)
+
+	// The old area stays behind, empty.
+	srcAreaReg, err := fx.fs.AreaRegistryFactory.CreateUserRegistry(fx.sourceCtx)
+	c.Assert(err, qt.IsNil)
+	left, err := srcAreaReg.GetCommodities(fx.sourceCtx, fx.area.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(left, qt.HasLen, 0)
+}
+
+func TestGroupTransferService_Transfer_Rejections(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("viewer in target group", func(c *qt.C) {
+		fx := newTransferFixture(c, models.GroupRoleViewer)
+		_, err := services.NewGroupTransferService(fx.fs).Transfer(fx.sourceCtx, services.GroupTransferRequest{
+			TargetGroupID: fx.target.ID,
+			LocationIDs:   []string{fx.location.ID},
+			ExchangeRate:  rate("0.5"),
+		})
+		c.Assert(err, qt.ErrorIs, services.ErrTransferForbidden)
+	})
+
+	c.Run("same group", func(c *qt.C) {
+		fx := newTransferFixture(c, models.GroupRoleUser)
+		_, err := services.NewGroupTransferService(fx.fs).Transfer(fx.sourceCtx, services.GroupTransferRequest{
+			TargetGroupID: fx.source.ID,
+			LocationIDs:   []string{fx.location.ID},
+		})
+		c.Assert(err, qt.ErrorIs, services.ErrTransferSameGroup)
+	})
+
+	c.Run("missing exchange rate", func(c *qt.C) {
+		fx := newTransferFixture(c, models.GroupRoleUser)
+		_, err := services.NewGroupTransferService(fx.fs).Transfer(fx.sourceCtx, services.GroupTransferRequest{
+			TargetGroupID: fx.target.ID,
+			LocationIDs:   []string{fx.location.ID},
+		})
+		c.Assert(err, qt.ErrorIs, services.ErrTransferExchangeRateRequired)
+	})
+
+	c.Run("commodity without target area", func(c *qt.C) {
+		fx := newTransferFixture(c, models.GroupRoleUser)
+		_, err := services.NewGroupTransferService(fx.fs).Transfer(fx.sourceCtx, services.GroupTransferRequest{
+			TargetGroupID: fx.target.ID,
+			CommodityIDs:  []string{fx.commodity.ID},
+			ExchangeRate:  rate("0.5"),
+		})
+		c.Assert(err, qt.ErrorIs, services.ErrTransferTargetAreaRequired)
+	})
+
+	c.Run("target area of the source group", func(c *qt.C) {
+		fx := newTransferFixture(c, models.GroupRoleUser)
+		_, err := services.NewGroupTransferService(fx.fs).Transfer(fx.sourceCtx, services.GroupTransferRequest{
+			TargetGroupID: fx.target.ID,
+			CommodityIDs:  []string{fx.commodity.ID},
+			TargetAreaID:  fx.area.ID,
+			ExchangeRate:  rate("0.5"),
+		})
+		c.Assert(err, qt.ErrorIs, registry.ErrNotFound)
+	})
+}
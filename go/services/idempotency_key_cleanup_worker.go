@@ -0,0 +1,136 @@
+// IdempotencyKeyCleanupWorker mirrors MagicLinkTokenCleanupWorker by
+// design — same Start/Stop/runCleanup/cleanupOnce lifecycle and the same
+// soft-pause skip (#1308). Only the registry type, the worker-type pause
+// key, and the log wording differ.
+//
+//nolint:dupl // intentional symmetry with the magic-link token cleanup worker
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+const defaultIdempotencyKeyCleanupInterval = 1 * time.Hour
+
+// IdempotencyKeyCleanupWorker periodically deletes expired Idempotency-Key
+// records (stored responses past their TTL and abandoned in-flight
+// reservations). For PostgreSQL this keeps the idempotency_keys table from
+// growing unbounded; for the in-memory registry it reclaims memory during
+// long-running test servers.
+type IdempotencyKeyCleanupWorker struct {
+	registry        registry.IdempotencyKeyRegistry
+	cleanupInterval time.Duration
+	pause           PauseChecker
+	stopCh          chan struct{}
+	stopOnce        sync.Once
+	wg              sync.WaitGroup
+}
+
+// IdempotencyKeyCleanupOption customizes a IdempotencyKeyCleanupWorker created by NewIdempotencyKeyCleanupWorker.
+type IdempotencyKeyCleanupOption func(*idempotencyKeyCleanupOptions)
+
+type idempotencyKeyCleanupOptions struct {
+	cleanupInterval time.Duration
+	pause           PauseChecker
+}
+
+// WithIdempotencyKeyCleanupInterval overrides the default cleanup interval.
+// Non-positive values are ignored.
+func WithIdempotencyKeyCleanupInterval(d time.Duration) IdempotencyKeyCleanupOption {
+	return func(o *idempotencyKeyCleanupOptions) {
+		if d > 0 {
+			o.cleanupInterval = d
+		}
+	}
+}
+
+// WithIdempotencyKeyCleanupPauseController wires the soft-pause controller
+// so the worker skips its cleanup while the idempotency-key-cleanup worker
+// type is paused (#1308). A nil checker leaves the worker unpaused.
+func WithIdempotencyKeyCleanupPauseController(pc PauseChecker) IdempotencyKeyCleanupOption {
+	return func(o *idempotencyKeyCleanupOptions) {
+		if pc != nil {
+			o.pause = pc
+		}
+	}
+}
+
+// NewIdempotencyKeyCleanupWorker creates a cleanup worker with the default one-hour interval,
+// overridable via IdempotencyKeyCleanupOption values (e.g., WithIdempotencyKeyCleanupInterval).
+func NewIdempotencyKeyCleanupWorker(r registry.IdempotencyKeyRegistry, opts ...IdempotencyKeyCleanupOption) *IdempotencyKeyCleanupWorker {
+	options := idempotencyKeyCleanupOptions{
+		cleanupInterval: defaultIdempotencyKeyCleanupInterval,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &IdempotencyKeyCleanupWorker{
+		registry:        r,
+		cleanupInterval: options.cleanupInterval,
+		pause:           options.pause,
+		stopCh:          make(chan struct{}),
+	}
+}
+
+// Start launches the background cleanup goroutine. It is a no-op if the registry is nil.
+func (w *IdempotencyKeyCleanupWorker) Start(ctx context.Context) {
+	if w.registry == nil {
+		slog.Warn("IdempotencyKeyCleanupWorker: no registry configured, skipping startup")
+		return
+	}
+	w.wg.Go(func() {
+		w.runCleanup(ctx)
+	})
+	slog.Info("Idempotency key cleanup worker started", "interval", w.cleanupInterval)
+}
+
+// Stop signals the worker to stop and waits for it to finish.
+func (w *IdempotencyKeyCleanupWorker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+	})
+	w.wg.Wait()
+	slog.Info("Idempotency key cleanup worker stopped")
+}
+
+func (w *IdempotencyKeyCleanupWorker) runCleanup(ctx context.Context) {
+	ticker := time.NewTicker(w.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			w.cleanupOnce(ctx)
+		}
+	}
+}
+
+// cleanupOnce runs a single expired-key sweep.
+func (w *IdempotencyKeyCleanupWorker) cleanupOnce(ctx context.Context) {
+	// Soft-pause (#1308): skip the cleanup while paused. The ticker keeps
+	// running so resuming takes effect on the next tick without a restart.
+	if w.pause != nil && w.pause.IsPaused(models.WorkerTypeIdempotencyKeyCleanup) {
+		return
+	}
+
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeIdempotencyKeyCleanup))
+	defer span.End()
+
+	deleted, err := w.registry.DeleteExpired(ctx)
+	if err != nil {
+		slog.Error("Failed to delete expired idempotency keys", "error", err)
+		return
+	}
+	slog.Debug("Expired idempotency keys cleaned up", "deleted", deleted)
+}
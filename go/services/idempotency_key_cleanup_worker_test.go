@@ -0,0 +1,76 @@
+package services_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// fakeIdempotencyKeyRegistry stubs registry.IdempotencyKeyRegistry for the
+// cleanup worker run-loop tests. Only DeleteExpired is exercised; every other
+// method panics to surface accidental wide usage by the worker.
+type fakeIdempotencyKeyRegistry struct {
+	registry.IdempotencyKeyRegistry
+
+	deleteCalls atomic.Int32
+}
+
+func (f *fakeIdempotencyKeyRegistry) DeleteExpired(_ context.Context) (int, error) {
+	f.deleteCalls.Add(1)
+	return 0, nil
+}
+
+func TestIdempotencyKeyCleanupWorker_CallsDeleteExpired(t *testing.T) {
+	c := qt.New(t)
+
+	reg := &fakeIdempotencyKeyRegistry{}
+	worker := services.NewIdempotencyKeyCleanupWorker(reg,
+		services.WithIdempotencyKeyCleanupInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	worker.Start(ctx)
+
+	c.Assert(eventually(c, 500*time.Millisecond, func() bool {
+		return reg.deleteCalls.Load() >= 1
+	}), qt.IsTrue)
+
+	worker.Stop()
+}
+
+// TestIdempotencyKeyCleanupWorker_SkipsWhilePaused asserts the worker makes
+// no DeleteExpired calls while its worker type is paused and resumes on the
+// next tick once unpaused.
+func TestIdempotencyKeyCleanupWorker_SkipsWhilePaused(t *testing.T) {
+	c := qt.New(t)
+
+	reg := &fakeIdempotencyKeyRegistry{}
+	pause := &fakePauseChecker{}
+	pause.paused.Store(true)
+	worker := services.NewIdempotencyKeyCleanupWorker(reg,
+		services.WithIdempotencyKeyCleanupInterval(10*time.Millisecond),
+		services.WithIdempotencyKeyCleanupPauseController(pause),
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	worker.Start(ctx)
+	defer worker.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	c.Assert(reg.deleteCalls.Load(), qt.Equals, int32(0))
+
+	pause.paused.Store(false)
+	c.Assert(eventually(c, 500*time.Millisecond, func() bool {
+		return reg.deleteCalls.Load() >= 1
+	}), qt.IsTrue)
+}
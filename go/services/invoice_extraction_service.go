@@ -0,0 +1,638 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/shopspring/decimal"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/aivision"
+	"github.com/denisvmedia/inventario/internal/validationctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// defaultInvoiceExtractionBatchSize bounds how many invoice files one
+// sweep hands to the provider. Every call costs vendor tokens, so the
+// backlog of existing invoices drains over many ticks instead of in one
+// burst; the per-user hourly scan cap still applies on top.
+const defaultInvoiceExtractionBatchSize = 20
+
+// Error codes stored on extractions that failed for a reason other than
+// the provider: the file will never be readable by the job, so it gets a
+// failed row instead of being retried (and counted against the batch) on
+// every sweep.
+const (
+	invoiceExtractionFileMissingCode     = "invoice_extraction.file_missing"
+	invoiceExtractionUploaderMissingCode = "invoice_extraction.uploader_missing"
+)
+
+var (
+	// ErrInvoiceExtractionNotPending fires when an extraction that was
+	// already applied, rejected or never queued is reviewed again.
+	ErrInvoiceExtractionNotPending = errx.NewSentinel("invoice extraction is not pending review")
+
+	// ErrInvoiceExtractionUnknownField fires when an approval names a
+	// field the extraction proposes no change for.
+	ErrInvoiceExtractionUnknownField = errx.NewSentinel("invoice extraction proposes no change for the field")
+)
+
+// InvoiceExtractionConfig carries the runtime tunables of the
+// extraction job.
+type InvoiceExtractionConfig struct {
+	// BatchSize is the number of files read per sweep. Zero means
+	// defaultInvoiceExtractionBatchSize.
+	BatchSize int
+
+	// MaxFileBytes caps how much of a blob is read into memory. A larger
+	// file is still handed to the scan service truncated to one byte
+	// over the cap, so it is rejected (and audited) as too large. Zero
+	// means no cap.
+	MaxFileBytes int
+}
+
+// InvoiceExtractionService runs the configured AI vision provider over
+// invoices and receipts already attached to commodities, and manages
+// the review queue of the resulting proposals.
+//
+// The provider call goes through CommodityScanService, so the job is
+// subject to the same MIME/size validation and per-user hourly rate
+// limit as an interactive scan, and every call leaves a row in
+// commodity_scan_audits attributed to the user who uploaded the file.
+type InvoiceExtractionService struct {
+	factorySet     *registry.FactorySet
+	scan           *CommodityScanService
+	events         *CommodityEventService
+	uploadLocation string
+	cfg            InvoiceExtractionConfig
+}
+
+func NewInvoiceExtractionService(factorySet *registry.FactorySet, scan *CommodityScanService, uploadLocation string, cfg InvoiceExtractionConfig) *InvoiceExtractionService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultInvoiceExtractionBatchSize
+	}
+	return &InvoiceExtractionService{
+		factorySet:     factorySet,
+		scan:           scan,
+		events:         NewCommodityEventService(factorySet),
+		uploadLocation: uploadLocation,
+		cfg:            cfg,
+	}
+}
+
+// InvoiceExtractionStats summarises the outcome of one sweep.
+type InvoiceExtractionStats struct {
+	// Proposed counts new rows queued for review.
+	Proposed int
+	// NoChanges counts documents that matched the commodity already.
+	NoChanges int
+	// Failed counts documents the provider could not read, or whose blob
+	// or uploader is gone; they get a failed row and are not retried.
+	Failed int
+	// Deferred counts files left for a later sweep: the uploader hit
+	// the hourly scan cap, the tenant or group spent its monthly AI
+	// budget, or the provider timed out or was unavailable.
+	Deferred int
+	// Errors counts files that could not be processed for a local
+	// reason (blob read, registry write); retried next sweep.
+	Errors int
+}
+
+// IsInvoiceFile reports whether f is an invoice or receipt attached to
+// a commodity: either tagged with models.FileTagInvoice or uploaded
+// into the legacy commodity `invoices` bucket.
+func IsInvoiceFile(f *models.FileEntity) bool {
+	if f == nil || f.LinkedEntityType != "commodity" || f.LinkedEntityID == "" {
+		return false
+	}
+	if f.LinkedEntityMeta == "invoices" {
+		return true
+	}
+	for _, tag := range f.Tags {
+		if strings.EqualFold(tag, models.FileTagInvoice) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractOnce runs one sweep: it reads up to BatchSize invoice files
+// that have no extraction yet, oldest first, and stores one extraction
+// row per file. Files of a user who hits the hourly scan cap are skipped
+// for the rest of the sweep. A disabled or misconfigured provider aborts
+// the sweep with the scan sentinel.
+func (s *InvoiceExtractionService) ExtractOnce(ctx context.Context) (InvoiceExtractionStats, error) {
+	var stats InvoiceExtractionStats
+	if s.factorySet == nil || s.scan == nil {
+		return stats, errxtrace.Wrap("invoice extraction service: factorySet and scan service are required", registry.ErrFieldRequired)
+	}
+
+	candidates, err := s.pendingInvoiceFiles(ctx)
+	if err != nil {
+		return stats, err
+	}
+	if len(candidates) == 0 {
+		return stats, nil
+	}
+
+	b, err := blob.OpenBucket(ctx, s.uploadLocation)
+	if err != nil {
+		return stats, errxtrace.Wrap("failed to open bucket", err)
+	}
+	defer b.Close()
+
+	rateLimited := make(map[string]bool)
+	// overBudget holds the tenant/group pairs whose monthly AI budget ran
+	// out this sweep; their remaining files wait for the next month.
+	overBudget := make(map[string]bool)
+	processed := 0
+	for _, f := range candidates {
+		if processed >= s.cfg.BatchSize {
+			break
+		}
+		if rateLimited[f.CreatedByUserID] || overBudget[f.TenantID+"/"+f.GroupID] {
+			continue
+		}
+		processed++
+
+		status, err := s.extractFile(ctx, b, f)
+		switch {
+		case err == nil:
+			switch status {
+			case models.InvoiceExtractionStatusPending:
+				stats.Proposed++
+			case models.InvoiceExtractionStatusNoChanges:
+				stats.NoChanges++
+			default:
+				stats.Failed++
+			}
+		case errors.Is(err, ErrScanRateLimited):
+			rateLimited[f.CreatedByUserID] = true
+			stats.Deferred++
+		case errors.Is(err, ErrScanBudgetExceeded):
+			overBudget[f.TenantID+"/"+f.GroupID] = true
+			stats.Deferred++
+		case errors.Is(err, ErrScanProviderTimeout), errors.Is(err, ErrScanProviderUnavailable):
+			stats.Deferred++
+		case errors.Is(err, ErrScanProviderDisabled), errors.Is(err, ErrScanProviderMisconfigured):
+			return stats, err
+		default:
+			stats.Errors++
+			slog.Error("invoice extraction failed", "file_id", f.ID, "error", err)
+		}
+	}
+	return stats, nil
+}
+
+// pendingInvoiceFiles returns the invoice files without an extraction
+// row whose MIME type the scan pipeline accepts, oldest first. Files
+// whose linked commodity no longer exists are left out: there is nothing
+// to propose changes to, and the extraction row could not reference it.
+func (s *InvoiceExtractionService) pendingInvoiceFiles(ctx context.Context) ([]*models.FileEntity, error) {
+	files, err := s.factorySet.FileRegistryFactory.CreateServiceRegistry().List(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list files", err)
+	}
+	commodities, err := s.factorySet.CommodityRegistryFactory.CreateServiceRegistry().List(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list commodities", err)
+	}
+	liveCommodity := make(map[string]bool, len(commodities))
+	for _, c := range commodities {
+		liveCommodity[c.ID] = true
+	}
+	extractions, err := s.factorySet.InvoiceExtractionRegistryFactory.CreateServiceRegistry().ListByStatus(ctx, "")
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list invoice extractions", err)
+	}
+	done := make(map[string]bool, len(extractions))
+	for _, e := range extractions {
+		done[e.FileID] = true
+	}
+
+	out := make([]*models.FileEntity, 0)
+	for _, f := range files {
+		if !IsInvoiceFile(f) || f.File == nil || done[f.ID] || !AllowedMIMETypes[f.MIMEType] || !liveCommodity[f.LinkedEntityID] {
+			continue
+		}
+		out = append(out, f)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+// extractFile reads one file through the scan pipeline and stores its
+// extraction. Scan sentinels that mean "try again later" are returned
+// as errors without a row; documents the provider cannot read, and
+// files whose blob or uploader is gone, get a failed row.
+func (s *InvoiceExtractionService) extractFile(ctx context.Context, b *blob.Bucket, f *models.FileEntity) (models.InvoiceExtractionStatus, error) {
+	group, err := s.factorySet.LocationGroupRegistry.Get(ctx, f.GroupID)
+	if err != nil {
+		return "", errxtrace.Wrap("failed to get file group", err)
+	}
+	failCode := ""
+	uploader, err := s.factorySet.UserRegistry.Get(ctx, f.CreatedByUserID)
+	switch {
+	case errors.Is(err, registry.ErrNotFound), errors.Is(err, registry.ErrDeleted):
+		// The failed row is still attributed to the uploader's ID, which
+		// the file itself keeps referencing.
+		uploader = &models.User{TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: f.CreatedByUserID},
+			TenantID: f.TenantID,
+		}}
+		failCode = invoiceExtractionUploaderMissingCode
+	case err != nil:
+		return "", errxtrace.Wrap("failed to get file uploader", err)
+	}
+	// The extraction row is written as the uploader, inside the file's
+	// group, exactly as if they had uploaded it through the API.
+	userCtx, err := registry.MemberContext(ctx, s.factorySet.GroupMemberLocationScopeRegistry, uploader, group)
+	if err != nil {
+		return "", err
+	}
+
+	commodity, err := s.factorySet.CommodityRegistryFactory.CreateServiceRegistry().Get(ctx, f.LinkedEntityID)
+	if err != nil {
+		return "", errxtrace.Wrap("failed to get linked commodity", err)
+	}
+
+	extraction := models.InvoiceExtraction{
+		FileID:      f.ID,
+		CommodityID: commodity.ID,
+	}
+	var data []byte
+	if failCode == "" {
+		data, err = s.readBlob(ctx, b, f.OriginalPath)
+		switch {
+		case gcerrors.Code(err) == gcerrors.NotFound:
+			failCode = invoiceExtractionFileMissingCode
+		case err != nil:
+			return "", err
+		}
+	}
+	if failCode != "" {
+		extraction.Status = models.InvoiceExtractionStatusFailed
+		extraction.ErrorCode = failCode
+		return s.storeExtraction(userCtx, extraction)
+	}
+
+	result, scanErr := s.scan.Scan(ctx, f.TenantID, f.CreatedByUserID, ScanInput{
+		Photos: []ScanPhotoInput{{
+			Filename:    f.Path + f.Ext,
+			ContentType: f.MIMEType,
+			Data:        data,
+		}},
+		HintFromUser:          "This is an invoice or receipt for: " + commodity.Name,
+		PreferredCurrencyCode: string(group.GroupCurrency),
+		GroupID:               f.GroupID,
+	})
+	switch {
+	case scanErr == nil:
+		extraction = buildInvoiceExtraction(extraction, commodity, result)
+	case errors.Is(scanErr, ErrScanUnsupportedMIME),
+		errors.Is(scanErr, ErrScanPhotoTooLarge),
+		errors.Is(scanErr, ErrScanNoPhotos),
+		errors.Is(scanErr, ErrScanProviderError):
+		extraction.Status = models.InvoiceExtractionStatusFailed
+		extraction.ErrorCode = invoiceExtractionErrorCode(scanErr)
+	default:
+		return "", scanErr
+	}
+	return s.storeExtraction(userCtx, extraction)
+}
+
+// storeExtraction writes the extraction row as the user on userCtx.
+func (s *InvoiceExtractionService) storeExtraction(userCtx context.Context, extraction models.InvoiceExtraction) (models.InvoiceExtractionStatus, error) {
+	extReg, err := s.factorySet.InvoiceExtractionRegistryFactory.CreateUserRegistry(userCtx)
+	if err != nil {
+		return "", errxtrace.Wrap("failed to create invoice extraction registry", err)
+	}
+	if _, err := extReg.Create(userCtx, extraction); err != nil {
+		return "", errxtrace.Wrap("failed to store invoice extraction", err)
+	}
+	return extraction.Status, nil
+}
+
+// readBlob reads the file body, stopping one byte past MaxFileBytes so
+// an oversized document is still rejected by the scan size check.
+func (s *InvoiceExtractionService) readBlob(ctx context.Context, b *blob.Bucket, key string) ([]byte, error) {
+	reader, err := b.NewReader(ctx, key, nil)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to open invoice file", err)
+	}
+	defer reader.Close()
+
+	var src io.Reader = reader
+	if s.cfg.MaxFileBytes > 0 {
+		src = io.LimitReader(reader, int64(s.cfg.MaxFileBytes)+1)
+	}
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to read invoice file", err)
+	}
+	return data, nil
+}
+
+// invoiceExtractionErrorCode maps a terminal scan sentinel to the error
+// code stored on the failed extraction; it matches the code of the
+// audit row the scan service wrote for the same call.
+func invoiceExtractionErrorCode(err error) string {
+	if errors.Is(err, ErrScanProviderError) {
+		return "commodity_scan.provider_error"
+	}
+	return errorCodeForScanSentinel(err)
+}
+
+// buildInvoiceExtraction turns a scan result into a proposal against
+// commodity. When the document lists several products, the line item
+// whose name matches the commodity is used, falling back to the most
+// prominent one. Only fields that differ from the commodity become
+// changes; the vendor is proposed as a comment only when the commodity
+// has none.
+func buildInvoiceExtraction(extraction models.InvoiceExtraction, commodity *models.Commodity, result *aivision.ScanResult) models.InvoiceExtraction {
+	items := result.Items
+	if len(items) == 0 {
+		items = []aivision.ScanItem{{Fields: result.Fields}}
+	}
+	lineItems := make([]models.InvoiceLineItem, 0, len(items))
+	for _, it := range items {
+		lineItems = append(lineItems, models.InvoiceLineItem{
+			Name:     guessString(it.Fields, aivision.FieldNameName),
+			Price:    guessPrice(it.Fields),
+			Currency: models.Currency(strings.ToUpper(guessString(it.Fields, aivision.FieldNameOriginalPriceCurrency))),
+		})
+	}
+	chosen := items[matchLineItem(lineItems, commodity.Name)].Fields
+
+	extraction.LineItems = lineItems
+	extraction.Vendor = guessString(chosen, aivision.FieldNameVendor)
+	if extraction.Vendor == "" {
+		extraction.Vendor = guessString(result.Fields, aivision.FieldNameVendor)
+	}
+	if d := guessString(chosen, aivision.FieldNamePurchaseDate); d != "" {
+		if _, err := time.Parse("2006-01-02", d); err == nil {
+			extraction.PurchaseDate = models.Date(d)
+		}
+	}
+	extraction.Price = guessPrice(chosen)
+	extraction.Currency = models.Currency(strings.ToUpper(guessString(chosen, aivision.FieldNameOriginalPriceCurrency)))
+
+	var changes []models.InvoiceExtractionChange
+	if extraction.PurchaseDate != "" && (commodity.PurchaseDate == nil || *commodity.PurchaseDate != extraction.PurchaseDate) {
+		current := ""
+		if commodity.PurchaseDate != nil {
+			current = string(*commodity.PurchaseDate)
+		}
+		changes = append(changes, models.InvoiceExtractionChange{
+			Field:    models.InvoiceExtractionFieldPurchaseDate,
+			Current:  current,
+			Proposed: string(extraction.PurchaseDate),
+		})
+	}
+	if extraction.Price != nil && !extraction.Price.Equal(commodity.OriginalPrice) {
+		changes = append(changes, models.InvoiceExtractionChange{
+			Field:    models.InvoiceExtractionFieldOriginalPrice,
+			Current:  commodity.OriginalPrice.StringFixed(2),
+			Proposed: extraction.Price.StringFixed(2),
+		})
+	}
+	if extraction.Currency != "" && extraction.Currency != commodity.OriginalPriceCurrency {
+		changes = append(changes, models.InvoiceExtractionChange{
+			Field:    models.InvoiceExtractionFieldOriginalPriceCurrency,
+			Current:  string(commodity.OriginalPriceCurrency),
+			Proposed: string(extraction.Currency),
+		})
+	}
+	if extraction.Vendor != "" && strings.TrimSpace(commodity.Comments) == "" {
+		changes = append(changes, models.InvoiceExtractionChange{
+			Field:    models.InvoiceExtractionFieldComments,
+			Proposed: "Purchased from " + extraction.Vendor,
+		})
+	}
+
+	extraction.Changes = changes
+	extraction.Status = models.InvoiceExtractionStatusNoChanges
+	if len(changes) > 0 {
+		extraction.Status = models.InvoiceExtractionStatusPending
+	}
+	return extraction
+}
+
+// matchLineItem returns the index of the line item that names the
+// commodity: an exact (case-insensitive) match first, then a line that
+// contains the commodity name or is contained in it, else 0.
+func matchLineItem(items []models.InvoiceLineItem, commodityName string) int {
+	name := strings.ToLower(strings.TrimSpace(commodityName))
+	if name == "" {
+		return 0
+	}
+	for i, it := range items {
+		if strings.ToLower(strings.TrimSpace(it.Name)) == name {
+			return i
+		}
+	}
+	for i, it := range items {
+		line := strings.ToLower(strings.TrimSpace(it.Name))
+		if line != "" && (strings.Contains(line, name) || strings.Contains(name, line)) {
+			return i
+		}
+	}
+	return 0
+}
+
+func guessString(fields map[string]aivision.FieldGuess, key string) string {
+	if g, ok := fields[key]; ok {
+		if v, ok := g.Value.(string); ok {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// guessPrice reads the original_price guess, which providers return as
+// a number but which may also arrive as a numeric string.
+func guessPrice(fields map[string]aivision.FieldGuess) *decimal.Decimal {
+	g, ok := fields[aivision.FieldNameOriginalPrice]
+	if !ok {
+		return nil
+	}
+	var d decimal.Decimal
+	switch v := g.Value.(type) {
+	case float64:
+		d = decimal.NewFromFloat(v)
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil
+		}
+		d = decimal.NewFromFloat(f)
+	default:
+		return nil
+	}
+	if d.IsNegative() {
+		return nil
+	}
+	d = d.Round(2)
+	return &d
+}
+
+// List returns the current group's extractions in the given status
+// (every status when empty), oldest first.
+func (s *InvoiceExtractionService) List(ctx context.Context, status models.InvoiceExtractionStatus) ([]*models.InvoiceExtraction, error) {
+	extReg, err := s.factorySet.InvoiceExtractionRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create invoice extraction registry", err)
+	}
+	extractions, err := extReg.ListByStatus(ctx, status)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list invoice extractions", err)
+	}
+	return extractions, nil
+}
+
+// Get returns one extraction of the current group.
+func (s *InvoiceExtractionService) Get(ctx context.Context, id string) (*models.InvoiceExtraction, error) {
+	extReg, err := s.factorySet.InvoiceExtractionRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create invoice extraction registry", err)
+	}
+	extraction, err := extReg.Get(ctx, id)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get invoice extraction", err)
+	}
+	return extraction, nil
+}
+
+// Approve writes the proposed changes to the commodity and marks the
+// extraction applied. fields narrows the write to a subset of the
+// proposed changes (all of them when empty); the extraction keeps only
+// the changes that were applied. The updated commodity is validated
+// like an API edit, so a foreign currency without a converted price is
+// rejected with a validation error.
+func (s *InvoiceExtractionService) Approve(ctx context.Context, id string, fields []string, now time.Time) (*models.InvoiceExtraction, error) {
+	extReg, extraction, err := s.loadPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := extraction.Changes
+	if len(fields) > 0 {
+		changes = make([]models.InvoiceExtractionChange, 0, len(fields))
+		for _, field := range fields {
+			change := extraction.Change(field)
+			if change == nil {
+				return nil, errxtrace.Classify(ErrInvoiceExtractionUnknownField, errx.Attrs("field", field))
+			}
+			changes = append(changes, *change)
+		}
+	}
+
+	group := appctx.GroupFromContext(ctx)
+	if group == nil || group.GroupCurrency == "" {
+		return nil, errxtrace.Classify(registry.ErrGroupCurrencyNotSet)
+	}
+	commodityReg, err := s.factorySet.CommodityRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create commodity registry", err)
+	}
+	before, err := commodityReg.Get(ctx, extraction.CommodityID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to get commodity", err)
+	}
+
+	updated := *before
+	for _, change := range changes {
+		if err := applyInvoiceChange(&updated, change, group.GroupCurrency); err != nil {
+			return nil, err
+		}
+	}
+	if err := updated.ValidateWithContext(validationctx.WithGroupCurrency(ctx, string(group.GroupCurrency))); err != nil {
+		return nil, err
+	}
+	after, err := commodityReg.Update(ctx, updated)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update commodity", err)
+	}
+	s.events.EmitUpdated(ctx, before, after)
+
+	extraction.Changes = changes
+	return s.markReviewed(ctx, extReg, extraction, models.InvoiceExtractionStatusApplied, now)
+}
+
+// Reject dismisses a pending extraction without touching the commodity.
+func (s *InvoiceExtractionService) Reject(ctx context.Context, id string, now time.Time) (*models.InvoiceExtraction, error) {
+	extReg, extraction, err := s.loadPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.markReviewed(ctx, extReg, extraction, models.InvoiceExtractionStatusRejected, now)
+}
+
+func (s *InvoiceExtractionService) loadPending(ctx context.Context, id string) (registry.InvoiceExtractionRegistry, *models.InvoiceExtraction, error) {
+	extReg, err := s.factorySet.InvoiceExtractionRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, nil, errxtrace.Wrap("failed to create invoice extraction registry", err)
+	}
+	extraction, err := extReg.Get(ctx, id)
+	if err != nil {
+		return nil, nil, errxtrace.Wrap("failed to get invoice extraction", err)
+	}
+	if extraction.Status != models.InvoiceExtractionStatusPending {
+		return nil, nil, errxtrace.Classify(ErrInvoiceExtractionNotPending, errx.Attrs("status", extraction.Status))
+	}
+	return extReg, extraction, nil
+}
+
+func (*InvoiceExtractionService) markReviewed(ctx context.Context, extReg registry.InvoiceExtractionRegistry, extraction *models.InvoiceExtraction, status models.InvoiceExtractionStatus, now time.Time) (*models.InvoiceExtraction, error) {
+	reviewedAt := now.UTC()
+	extraction.Status = status
+	extraction.ReviewedAt = &reviewedAt
+	if user := appctx.UserFromContext(ctx); user != nil {
+		extraction.ReviewedByUserID = user.ID
+	}
+	updated, err := extReg.Update(ctx, *extraction)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to update invoice extraction", err)
+	}
+	return updated, nil
+}
+
+// applyInvoiceChange writes one proposed change onto c. Switching the
+// price to the group currency clears the converted price, which the
+// price rule requires to be zero in that case.
+func applyInvoiceChange(c *models.Commodity, change models.InvoiceExtractionChange, groupCurrency models.Currency) error {
+	switch change.Field {
+	case models.InvoiceExtractionFieldPurchaseDate:
+		d := models.Date(change.Proposed)
+		c.PurchaseDate = &d
+	case models.InvoiceExtractionFieldOriginalPrice:
+		price, err := decimal.NewFromString(change.Proposed)
+		if err != nil {
+			return errxtrace.Wrap("invalid proposed price", err)
+		}
+		c.OriginalPrice = price
+	case models.InvoiceExtractionFieldOriginalPriceCurrency:
+		c.OriginalPriceCurrency = models.Currency(change.Proposed)
+		if c.OriginalPriceCurrency == groupCurrency {
+			c.ConvertedOriginalPrice = decimal.Zero
+		}
+	case models.InvoiceExtractionFieldComments:
+		c.Comments = change.Proposed
+	default:
+		return errxtrace.Classify(ErrInvoiceExtractionUnknownField, errx.Attrs("field", change.Field))
+	}
+	return nil
+}
@@ -0,0 +1,347 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+	"github.com/shopspring/decimal"
+	"gocloud.dev/blob"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/aivision"
+	"github.com/denisvmedia/inventario/internal/aivision/mock"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+)
+
+type invoiceExtractionFixture struct {
+	ctx            context.Context
+	user           *models.User
+	regSet         *registry.Set
+	factorySet     *registry.FactorySet
+	audit          *memory.CommodityScanAuditRegistry
+	uploadLocation string
+	commodity      *models.Commodity
+}
+
+// newInvoiceExtractionFixture wires a memory-backed factory with a EUR
+// group, one commodity bought in EUR and a file:// upload location.
+func newInvoiceExtractionFixture(c *qt.C) *invoiceExtractionFixture {
+	c.Helper()
+	factorySet := memory.NewFactorySet()
+	audit := memory.NewCommodityScanAuditRegistry()
+	factorySet.CommodityScanAuditRegistry = audit
+	u, err := factorySet.CreateServiceRegistrySet().UserRegistry.Create(context.Background(), models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "invoice-user"},
+			TenantID: "invoice-tenant",
+		},
+		Email: "owner@example.com",
+		Name:  "Invoice Owner",
+	})
+	c.Assert(err, qt.IsNil)
+	group, err := factorySet.LocationGroupRegistry.Create(context.Background(), models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: u.TenantID},
+		Slug:                "invoice-group",
+		Name:                "Invoice group",
+		GroupCurrency:       "EUR",
+	})
+	c.Assert(err, qt.IsNil)
+	ctx := appctx.WithGroup(appctx.WithUser(context.Background(), u), group)
+	regSet := must.Must(factorySet.CreateUserRegistrySet(ctx))
+	loc, err := regSet.LocationRegistry.Create(ctx, models.Location{Name: "L"})
+	c.Assert(err, qt.IsNil)
+	area, err := regSet.AreaRegistry.Create(ctx, models.Area{Name: "A", LocationID: loc.ID})
+	c.Assert(err, qt.IsNil)
+	commodity, err := regSet.CommodityRegistry.Create(ctx, models.Commodity{
+		AreaID:                 new(area.ID),
+		Name:                   "Espresso Machine",
+		ShortName:              "espresso",
+		Type:                   models.CommodityTypeWhiteGoods,
+		Status:                 models.CommodityStatusInUse,
+		Count:                  1,
+		OriginalPrice:          decimal.NewFromInt(500),
+		OriginalPriceCurrency:  "EUR",
+		ConvertedOriginalPrice: decimal.Zero,
+	})
+	c.Assert(err, qt.IsNil)
+	return &invoiceExtractionFixture{
+		ctx:            ctx,
+		user:           u,
+		regSet:         regSet,
+		factorySet:     factorySet,
+		audit:          audit,
+		uploadLocation: newFileUploadLocation(c),
+		commodity:      commodity,
+	}
+}
+
+// addInvoice stores a PDF blob and a file row linked to the commodity.
+// Names avoid "invoice"/"receipt": the mock provider answers those with
+// its canned multi-item result.
+func (f *invoiceExtractionFixture) addInvoice(c *qt.C, name string, tags ...string) *models.FileEntity {
+	c.Helper()
+	key := "invoices/" + name + ".pdf"
+	b, err := blob.OpenBucket(f.ctx, f.uploadLocation)
+	c.Assert(err, qt.IsNil)
+	defer b.Close()
+	c.Assert(b.WriteAll(f.ctx, key, []byte("%PDF-1.7\n"), nil), qt.IsNil)
+
+	file, err := f.regSet.FileRegistry.Create(f.ctx, models.FileEntity{
+		Title:            name,
+		Type:             models.FileTypeDocument,
+		Category:         models.FileCategoryDocuments,
+		Tags:             tags,
+		LinkedEntityType: "commodity",
+		LinkedEntityID:   f.commodity.ID,
+		File: &models.File{
+			Path:         name,
+			OriginalPath: key,
+			Ext:          ".pdf",
+			MIMEType:     aivision.PDFMediaType,
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	return file
+}
+
+func (f *invoiceExtractionFixture) service(provider aivision.Provider, rateLimit int) *services.InvoiceExtractionService {
+	scan := services.NewCommodityScanService(provider, f.audit, services.CommodityScanConfig{
+		MaxPhotos:        1,
+		MaxPhotoBytes:    1 << 20,
+		RateLimitPerHour: rateLimit,
+	})
+	return services.NewInvoiceExtractionService(f.factorySet, scan, f.uploadLocation, services.InvoiceExtractionConfig{MaxFileBytes: 1 << 20})
+}
+
+func invoiceResult(fields map[string]any, items ...map[string]any) aivision.ScanResult {
+	toGuesses := func(m map[string]any) map[string]aivision.FieldGuess {
+		out := make(map[string]aivision.FieldGuess, len(m))
+		for k, v := range m {
+			out[k] = aivision.FieldGuess{Value: v, Confidence: 0.9}
+		}
+		return out
+	}
+	result := aivision.ScanResult{Fields: toGuesses(fields)}
+	for _, it := range items {
+		result.Items = append(result.Items, aivision.ScanItem{Fields: toGuesses(it)})
+	}
+	return result
+}
+
+// TestInvoiceExtractionService_ProposeAndApprove runs the job over a
+// multi-line receipt: the line naming the commodity is picked, only the
+// differing fields are proposed, and approving a subset writes just
+// those fields to the commodity.
+func TestInvoiceExtractionService_ProposeAndApprove(t *testing.T) {
+	c := qt.New(t)
+	f := newInvoiceExtractionFixture(c)
+	file := f.addInvoice(c, "doc-2026", models.FileTagInvoice)
+
+	provider := mock.New(mock.WithDefaultResult(invoiceResult(
+		map[string]any{"vendor": "Coffee Shop s.r.o."},
+		map[string]any{"name": "Milk jug", "original_price": 25.0, "original_price_currency": "eur"},
+		map[string]any{"name": "espresso machine", "original_price": "489.90", "original_price_currency": "eur", "purchase_date": "2026-03-14"},
+	)))
+	svc := f.service(provider, 10)
+
+	stats, err := svc.ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{Proposed: 1})
+
+	// A second sweep leaves the file alone.
+	stats, err = svc.ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{})
+
+	queue, err := svc.List(f.ctx, models.InvoiceExtractionStatusPending)
+	c.Assert(err, qt.IsNil)
+	c.Assert(queue, qt.HasLen, 1)
+	extraction := queue[0]
+	c.Assert(extraction.FileID, qt.Equals, file.ID)
+	c.Assert(extraction.CommodityID, qt.Equals, f.commodity.ID)
+	c.Assert(extraction.Vendor, qt.Equals, "Coffee Shop s.r.o.")
+	c.Assert(extraction.LineItems, qt.HasLen, 2)
+	c.Assert(extraction.Changes, qt.DeepEquals, models.ValuerSlice[models.InvoiceExtractionChange]{
+		{Field: models.InvoiceExtractionFieldPurchaseDate, Current: "", Proposed: "2026-03-14"},
+		{Field: models.InvoiceExtractionFieldOriginalPrice, Current: "500.00", Proposed: "489.90"},
+		{Field: models.InvoiceExtractionFieldComments, Current: "", Proposed: "Purchased from Coffee Shop s.r.o."},
+	})
+
+	// Every provider call is audited against the uploader.
+	count, err := f.audit.CountRecentForUser(context.Background(), f.user.TenantID, f.user.ID, time.Now().Add(-time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 1)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	_, err = svc.Approve(f.ctx, extraction.ID, []string{models.InvoiceExtractionFieldOriginalPriceCurrency}, now)
+	c.Assert(err, qt.ErrorIs, services.ErrInvoiceExtractionUnknownField)
+
+	applied, err := svc.Approve(f.ctx, extraction.ID, []string{models.InvoiceExtractionFieldPurchaseDate, models.InvoiceExtractionFieldOriginalPrice}, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(applied.Status, qt.Equals, models.InvoiceExtractionStatusApplied)
+	c.Assert(applied.Changes, qt.HasLen, 2)
+	c.Assert(applied.ReviewedByUserID, qt.Equals, f.user.ID)
+	c.Assert(*applied.ReviewedAt, qt.Equals, now)
+
+	commodity, err := f.regSet.CommodityRegistry.Get(f.ctx, f.commodity.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(*commodity.PurchaseDate), qt.Equals, "2026-03-14")
+	c.Assert(commodity.OriginalPrice.StringFixed(2), qt.Equals, "489.90")
+	c.Assert(commodity.Comments, qt.Equals, "")
+
+	_, err = svc.Reject(f.ctx, extraction.ID, now)
+	c.Assert(err, qt.ErrorIs, services.ErrInvoiceExtractionNotPending)
+}
+
+// TestInvoiceExtractionService_NoChangesAndReject covers a document
+// that matches the commodity (never queued) and a rejected proposal
+// that leaves the commodity untouched. Files that are not invoices are
+// ignored.
+func TestInvoiceExtractionService_NoChangesAndReject(t *testing.T) {
+	c := qt.New(t)
+	f := newInvoiceExtractionFixture(c)
+	f.addInvoice(c, "manual")
+	f.addInvoice(c, "doc", models.FileTagInvoice)
+
+	provider := mock.New(mock.WithDefaultResult(invoiceResult(map[string]any{
+		"name":                    "Espresso Machine",
+		"original_price":          500.0,
+		"original_price_currency": "EUR",
+	})))
+	svc := f.service(provider, 10)
+
+	stats, err := svc.ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{NoChanges: 1})
+
+	queue, err := svc.List(f.ctx, models.InvoiceExtractionStatusPending)
+	c.Assert(err, qt.IsNil)
+	c.Assert(queue, qt.HasLen, 0)
+
+	f.addInvoice(c, "second-doc", models.FileTagInvoice)
+	svc = f.service(mock.New(mock.WithDefaultResult(invoiceResult(map[string]any{
+		"original_price":          450.0,
+		"original_price_currency": "EUR",
+	}))), 10)
+	stats, err = svc.ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{Proposed: 1})
+
+	queue, err = svc.List(f.ctx, models.InvoiceExtractionStatusPending)
+	c.Assert(err, qt.IsNil)
+	c.Assert(queue, qt.HasLen, 1)
+	rejected, err := svc.Reject(f.ctx, queue[0].ID, time.Now())
+	c.Assert(err, qt.IsNil)
+	c.Assert(rejected.Status, qt.Equals, models.InvoiceExtractionStatusRejected)
+
+	commodity, err := f.regSet.CommodityRegistry.Get(f.ctx, f.commodity.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(commodity.OriginalPrice.StringFixed(2), qt.Equals, "500.00")
+}
+
+// TestInvoiceExtractionService_RateLimited defers the remaining files of
+// a user who exhausted the hourly scan budget; they are picked up once
+// the budget allows it.
+func TestInvoiceExtractionService_RateLimited(t *testing.T) {
+	c := qt.New(t)
+	f := newInvoiceExtractionFixture(c)
+	for _, name := range []string{"a", "b", "c"} {
+		f.addInvoice(c, name, models.FileTagInvoice)
+	}
+
+	provider := mock.New(mock.WithDefaultResult(invoiceResult(map[string]any{"original_price": 10.0})))
+	stats, err := f.service(provider, 1).ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{Proposed: 1, Deferred: 1})
+
+	stats, err = f.service(provider, 10).ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{Proposed: 2})
+}
+
+// TestInvoiceExtractionService_ProviderErrors records unreadable
+// documents as failed and aborts the sweep when no provider is set.
+func TestInvoiceExtractionService_ProviderErrors(t *testing.T) {
+	c := qt.New(t)
+	f := newInvoiceExtractionFixture(c)
+	f.addInvoice(c, "scan", models.FileTagInvoice)
+
+	_, err := f.service(nil, 10).ExtractOnce(context.Background())
+	c.Assert(err, qt.ErrorIs, services.ErrScanProviderDisabled)
+
+	stats, err := f.service(mock.New(mock.WithDefaultError(aivision.ErrProviderBadResponse)), 10).ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{Failed: 1})
+
+	failed, err := f.service(nil, 10).List(f.ctx, models.InvoiceExtractionStatusFailed)
+	c.Assert(err, qt.IsNil)
+	c.Assert(failed, qt.HasLen, 1)
+	c.Assert(failed[0].ErrorCode, qt.Equals, "commodity_scan.provider_error")
+}
+
+// TestInvoiceExtractionService_PermanentLocalFailures gives a file whose
+// blob is gone and a file whose uploader was deleted a failed row, so
+// neither is retried on later sweeps, and skips files whose commodity
+// was deleted.
+func TestInvoiceExtractionService_PermanentLocalFailures(t *testing.T) {
+	c := qt.New(t)
+	f := newInvoiceExtractionFixture(c)
+
+	missing := f.addInvoice(c, "missing-blob", models.FileTagInvoice)
+	b, err := blob.OpenBucket(f.ctx, f.uploadLocation)
+	c.Assert(err, qt.IsNil)
+	c.Assert(b.Delete(f.ctx, missing.OriginalPath), qt.IsNil)
+	c.Assert(b.Close(), qt.IsNil)
+
+	orphaned := f.addInvoice(c, "orphaned", models.FileTagInvoice)
+	orphaned.CreatedByUserID = "deleted-user"
+	_, err = f.factorySet.FileRegistryFactory.CreateServiceRegistry().Update(context.Background(), *orphaned)
+	c.Assert(err, qt.IsNil)
+
+	gone, err := f.regSet.CommodityRegistry.Create(f.ctx, models.Commodity{
+		AreaID:                 f.commodity.AreaID,
+		Name:                   "Gone",
+		ShortName:              "gone",
+		Type:                   models.CommodityTypeOther,
+		Status:                 models.CommodityStatusInUse,
+		Count:                  1,
+		OriginalPrice:          decimal.Zero,
+		ConvertedOriginalPrice: decimal.Zero,
+	})
+	c.Assert(err, qt.IsNil)
+	stale := f.addInvoice(c, "stale", models.FileTagInvoice)
+	stale.LinkedEntityID = gone.ID
+	_, err = f.factorySet.FileRegistryFactory.CreateServiceRegistry().Update(context.Background(), *stale)
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.regSet.CommodityRegistry.Delete(f.ctx, gone.ID), qt.IsNil)
+
+	provider := mock.New(mock.WithDefaultResult(invoiceResult(map[string]any{"original_price": 10.0})))
+	svc := services.NewInvoiceExtractionService(f.factorySet,
+		services.NewCommodityScanService(provider, f.audit, services.CommodityScanConfig{MaxPhotos: 1, MaxPhotoBytes: 1 << 20, RateLimitPerHour: 10}),
+		f.uploadLocation, services.InvoiceExtractionConfig{BatchSize: 2})
+	stats, err := svc.ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{Failed: 2})
+
+	failed, err := svc.List(f.ctx, models.InvoiceExtractionStatusFailed)
+	c.Assert(err, qt.IsNil)
+	codes := make(map[string]string, len(failed))
+	for _, e := range failed {
+		codes[e.FileID] = e.ErrorCode
+	}
+	c.Assert(codes, qt.DeepEquals, map[string]string{
+		missing.ID:  "invoice_extraction.file_missing",
+		orphaned.ID: "invoice_extraction.uploader_missing",
+	})
+
+	// Nothing is left to sweep: the stale file is not a candidate.
+	stats, err = svc.ExtractOnce(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.Equals, services.InvoiceExtractionStats{})
+}
@@ -0,0 +1,162 @@
+// InvoiceExtractionWorker mirrors MaintenanceReminderWorker by design —
+// same Start/Stop/run/tick lifecycle, its own Prometheus counters and
+// pause knob.
+//
+//nolint:dupl // intentional symmetry with the reminder workers
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+	"github.com/denisvmedia/inventario/models"
+)
+
+const defaultInvoiceExtractionInterval = 15 * time.Minute
+
+// Prometheus counters for the invoice extraction worker.
+// Labels:
+//   - outcome: "proposed", "no_changes", "failed", "deferred", "error" —
+//     matches the InvoiceExtractionStats fields.
+var invoiceExtractionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "inventario_invoice_extractions_total",
+	Help: "Number of invoice files processed by the extraction job, partitioned by outcome.",
+}, []string{"outcome"})
+
+// InvoiceExtractionWorker periodically runs InvoiceExtractionService.
+type InvoiceExtractionWorker struct {
+	service  *InvoiceExtractionService
+	interval time.Duration
+	pause    PauseChecker
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// InvoiceExtractionOption customizes an InvoiceExtractionWorker.
+type InvoiceExtractionOption func(*invoiceExtractionOptions)
+
+type invoiceExtractionOptions struct {
+	interval time.Duration
+	pause    PauseChecker
+}
+
+// WithInvoiceExtractionInterval overrides the default tick cadence.
+func WithInvoiceExtractionInterval(d time.Duration) InvoiceExtractionOption {
+	return func(o *invoiceExtractionOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+// WithInvoiceExtractionPauseController wires the soft-pause controller
+// so the worker skips its sweep while the invoice-extraction worker type
+// is paused. A nil checker leaves the worker unpaused.
+func WithInvoiceExtractionPauseController(pc PauseChecker) InvoiceExtractionOption {
+	return func(o *invoiceExtractionOptions) {
+		if pc != nil {
+			o.pause = pc
+		}
+	}
+}
+
+func NewInvoiceExtractionWorker(service *InvoiceExtractionService, opts ...InvoiceExtractionOption) *InvoiceExtractionWorker {
+	options := invoiceExtractionOptions{
+		interval: defaultInvoiceExtractionInterval,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &InvoiceExtractionWorker{
+		service:  service,
+		interval: options.interval,
+		pause:    options.pause,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine. No-op if no service is configured.
+func (w *InvoiceExtractionWorker) Start(ctx context.Context) {
+	if w.service == nil {
+		slog.Warn("InvoiceExtractionWorker: no service configured, skipping startup")
+		return
+	}
+	w.wg.Go(func() {
+		w.run(ctx)
+	})
+	slog.Info("Invoice extraction worker started", "interval", w.interval)
+}
+
+// Stop signals the worker and waits for the goroutine to exit.
+func (w *InvoiceExtractionWorker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+	})
+	w.wg.Wait()
+	slog.Info("Invoice extraction worker stopped")
+}
+
+func (w *InvoiceExtractionWorker) run(ctx context.Context) {
+	w.tick(ctx)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *InvoiceExtractionWorker) tick(ctx context.Context) {
+	// Soft-pause: skip the sweep while paused. The ticker keeps running
+	// so resuming takes effect on the next tick without a restart.
+	if w.pause != nil && w.pause.IsPaused(models.WorkerTypeInvoiceExtraction) {
+		return
+	}
+
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeInvoiceExtraction))
+	defer span.End()
+
+	stats, err := w.service.ExtractOnce(ctx)
+	for outcome, count := range map[string]int{
+		"proposed":   stats.Proposed,
+		"no_changes": stats.NoChanges,
+		"failed":     stats.Failed,
+		"deferred":   stats.Deferred,
+		"error":      stats.Errors,
+	} {
+		if count > 0 {
+			invoiceExtractionsTotal.WithLabelValues(outcome).Add(float64(count))
+		}
+	}
+	if err != nil {
+		slog.Error("Invoice extraction sweep failed", "error", err)
+		return
+	}
+
+	processed := stats.Proposed + stats.NoChanges + stats.Failed
+	if processed > 0 || stats.Deferred > 0 || stats.Errors > 0 {
+		slog.Info("Invoice extraction sweep completed",
+			"proposed", stats.Proposed,
+			"no_changes", stats.NoChanges,
+			"failed", stats.Failed,
+			"deferred", stats.Deferred,
+			"errors", stats.Errors,
+		)
+	} else {
+		slog.Debug("Invoice extraction sweep completed", "processed", 0)
+	}
+}
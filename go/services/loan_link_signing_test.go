@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/services"
+)
+
+var loanLinkKey = []byte("test-signing-key-32-bytes-long!!")
+
+func TestLoanLinkSigner_BuildURL(t *testing.T) {
+	c := qt.New(t)
+
+	now := time.Date(2026, 5, 17, 12, 0, 0, 0, time.UTC)
+	signer := services.NewLoanLinkSigner(loanLinkKey, time.Hour).WithClock(func() time.Time { return now })
+
+	link, expiresAt, err := signer.BuildURL("https://inventario.example/", "loan-1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(expiresAt, qt.Equals, now.Add(time.Hour))
+
+	parsed, err := url.Parse(link)
+	c.Assert(err, qt.IsNil)
+	c.Assert(parsed.Scheme+"://"+parsed.Host+parsed.Path, qt.Equals, "https://inventario.example/loan-response/loan-1")
+	c.Assert(parsed.Query().Get("exp"), qt.Equals, strconv.FormatInt(expiresAt.Unix(), 10))
+	c.Assert(signer.Validate("loan-1", parsed.Query().Get("sig"), parsed.Query().Get("exp")), qt.IsNil)
+}
+
+func TestLoanLinkSigner_Validate_Rejects(t *testing.T) {
+	now := time.Date(2026, 5, 17, 12, 0, 0, 0, time.UTC)
+	signer := services.NewLoanLinkSigner(loanLinkKey, time.Hour).WithClock(func() time.Time { return now })
+	sig, expiresAt, err := signer.Sign("loan-1")
+	qt.Assert(t, err, qt.IsNil)
+	exp := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	tests := []struct {
+		name    string
+		signer  *services.LoanLinkSigner
+		loanID  string
+		sig     string
+		exp     string
+		wantErr error
+	}{
+		{
+			name:    "different loan",
+			signer:  signer,
+			loanID:  "loan-2",
+			sig:     sig,
+			exp:     exp,
+			wantErr: services.ErrLoanLinkInvalid,
+		},
+		{
+			name:    "tampered expiry",
+			signer:  signer,
+			loanID:  "loan-1",
+			sig:     sig,
+			exp:     strconv.FormatInt(expiresAt.Add(time.Hour).Unix(), 10),
+			wantErr: services.ErrLoanLinkInvalid,
+		},
+		{
+			name:    "malformed expiry",
+			signer:  signer,
+			loanID:  "loan-1",
+			sig:     sig,
+			exp:     "tomorrow",
+			wantErr: services.ErrLoanLinkInvalid,
+		},
+		{
+			name:    "missing signature",
+			signer:  signer,
+			loanID:  "loan-1",
+			exp:     exp,
+			wantErr: services.ErrLoanLinkInvalid,
+		},
+		{
+			name:    "different key",
+			signer:  services.NewLoanLinkSigner([]byte("another-signing-key-32-bytes!!!!"), time.Hour).WithClock(func() time.Time { return now }),
+			loanID:  "loan-1",
+			sig:     sig,
+			exp:     exp,
+			wantErr: services.ErrLoanLinkInvalid,
+		},
+		{
+			name:    "expired",
+			signer:  services.NewLoanLinkSigner(loanLinkKey, time.Hour).WithClock(func() time.Time { return now.Add(2 * time.Hour) }),
+			loanID:  "loan-1",
+			sig:     sig,
+			exp:     exp,
+			wantErr: services.ErrLoanLinkExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			err := tt.signer.Validate(tt.loanID, tt.sig, tt.exp)
+			c.Assert(err, qt.ErrorIs, tt.wantErr)
+		})
+	}
+}
+
+// TestLoanLinkSigner_NotAFileSignature pins the domain separation: a
+// file URL signature minted with the same key never validates as a
+// loan link.
+func TestLoanLinkSigner_NotAFileSignature(t *testing.T) {
+	c := qt.New(t)
+
+	fileSigner := services.NewFileSigningService(loanLinkKey, time.Hour)
+	fileURL, err := fileSigner.GenerateSignedURL("loan-1", "jpg", "user-1", "")
+	c.Assert(err, qt.IsNil)
+	parsed, err := url.Parse(fileURL)
+	c.Assert(err, qt.IsNil)
+
+	signer := services.NewLoanLinkSigner(loanLinkKey, time.Hour)
+	err = signer.Validate("loan-1", parsed.Query().Get("sig"), parsed.Query().Get("exp"))
+	c.Assert(err, qt.ErrorIs, services.ErrLoanLinkInvalid)
+}
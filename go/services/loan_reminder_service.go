@@ -39,6 +39,10 @@ const defaultLoanReminderDueSoonDays = 7
 //     loan_reminder_sent against the loan's commodity so the per-item
 //     timeline carries the audit trail.
 //
+// Loans carrying a borrower_email additionally get the borrower kinds:
+// a courtesy reminder addressed to the borrower, with a signed link to
+// confirm the return or ask for an extension (see processBorrower).
+//
 // The service is stateless — RemindOnce takes `now` as a parameter so
 // unit tests can pin the clock without injecting a global ticker.
 type LoanReminderService struct {
@@ -54,6 +58,11 @@ type LoanReminderService struct {
 	// dueSoonDays is the forward-looking window for LoanReminderKindDueSoon.
 	// Zero falls back to defaultLoanReminderDueSoonDays.
 	dueSoonDays int
+	// linkSigner + publicBaseURL mint the signed loan link printed in
+	// borrower reminders. Optional — when either is unset the borrower
+	// email goes out without the link block.
+	linkSigner    *LoanLinkSigner
+	publicBaseURL string
 }
 
 // NewLoanReminderService constructs the service. emailSvc may be nil in
@@ -86,6 +95,14 @@ func (s *LoanReminderService) WithDueSoonDays(d int) *LoanReminderService {
 	return s
 }
 
+// WithBorrowerLinks enables the signed loan link in borrower reminders.
+// baseURL is the deployment's public URL the SPA is served from.
+func (s *LoanReminderService) WithBorrowerLinks(signer *LoanLinkSigner, baseURL string) *LoanReminderService {
+	s.linkSigner = signer
+	s.publicBaseURL = baseURL
+	return s
+}
+
 // LoanReminderStats summarises one RemindOnce sweep. Sent partitions
 // the count by kind so the worker can emit one Prometheus series per
 // kind label. Failed is the cross-kind count of per-loan failures
@@ -104,7 +121,7 @@ func (s LoanReminderStats) Total() int {
 	return total
 }
 
-// RemindOnce runs one sweep pinned to `now` for both kinds in sequence.
+// RemindOnce runs one sweep pinned to `now` for every kind in sequence.
 // A non-nil error is only returned when the initial listing fails for
 // either kind — per-loan failures bump stats.Failed and are logged.
 func (s *LoanReminderService) RemindOnce(ctx context.Context, now time.Time) (LoanReminderStats, error) {
@@ -116,6 +133,8 @@ func (s *LoanReminderService) RemindOnce(ctx context.Context, now time.Time) (Lo
 	for _, kind := range []registry.LoanReminderKind{
 		registry.LoanReminderKindOverdue,
 		registry.LoanReminderKindDueSoon,
+		registry.LoanReminderKindBorrowerOverdue,
+		registry.LoanReminderKindBorrowerDueSoon,
 	} {
 		sent, failed, err := s.sweepKind(ctx, kind, now, prefsCache)
 		if err != nil {
@@ -183,6 +202,9 @@ func (s *LoanReminderService) sweepKind(ctx context.Context, kind registry.LoanR
 //     (flag NOT flipped) OR concurrent worker won the flip.
 //   - (false, err) — enqueue or flip-side failure that should retry.
 func (s *LoanReminderService) processOne(ctx context.Context, loanReg registry.CommodityLoanRegistry, l *models.CommodityLoan, kind registry.LoanReminderKind, now time.Time, prefsCache *notifications.Cache) (bool, error) {
+	if kind.IsBorrower() {
+		return s.processBorrower(ctx, loanReg, l, kind, now, prefsCache)
+	}
 	lender, recipientEmail, recipientName := s.lookupLender(ctx, l)
 	if recipientEmail == "" {
 		// No lender / no email on file. Flip the flag anyway so this row
@@ -289,6 +311,67 @@ func (s *LoanReminderService) processOne(ctx context.Context, loanReg registry.C
 	return true, nil
 }
 
+// processBorrower handles one (loan, borrower kind) pair. Same
+// send-then-flip ordering as processOne, with two differences:
+//
+//   - the recipient is the loan's borrower_email (the registry only
+//     lists loans that have one), so there is no no-recipient branch;
+//   - the lender's notification preferences are NOT consulted. They
+//     govern mail to the lender; the borrower's opt-in is the lender
+//     entering their address on the loan, and clearing it stops the
+//     courtesy reminders.
+//
+// The email is localized to the lender's UI language — the borrower has
+// no account, and sharing a language with the lender is the best guess.
+func (s *LoanReminderService) processBorrower(ctx context.Context, loanReg registry.CommodityLoanRegistry, l *models.CommodityLoan, kind registry.LoanReminderKind, now time.Time, prefsCache *notifications.Cache) (bool, error) {
+	recipientEmail := strings.TrimSpace(l.BorrowerEmail)
+	if s.emailSvc != nil {
+		lender, _, lenderName := s.lookupLender(ctx, l)
+		responseURL := ""
+		if s.linkSigner != nil && s.publicBaseURL != "" {
+			link, _, err := s.linkSigner.BuildURL(s.publicBaseURL, l.ID)
+			if err != nil {
+				return false, errxtrace.Wrap("loan reminder: sign borrower link", err)
+			}
+			responseURL = link
+		}
+		emailKind := registry.LoanReminderKindDueSoon
+		if kind.IsOverdue() {
+			emailKind = registry.LoanReminderKindOverdue
+		}
+		sendErr := s.emailSvc.SendLoanBorrowerReminderEmail(
+			withReminderLanguage(ctx, prefsCache, lender),
+			recipientEmail,
+			l.BorrowerName,
+			lenderName,
+			s.lookupCommodityName(ctx, l.CommodityID),
+			string(*l.DueBackAt),
+			responseURL,
+			string(emailKind),
+			computeDaysDelta(l.DueBackAt, now),
+		)
+		if sendErr != nil {
+			return false, errxtrace.Wrap("loan reminder: enqueue borrower reminder failed", sendErr)
+		}
+	}
+
+	flipped, err := loanReg.MarkReminderSent(ctx, l.ID, kind)
+	if err != nil {
+		return false, errxtrace.Wrap("loan reminder: flip borrower flag", err)
+	}
+	if !flipped {
+		return false, nil
+	}
+	if emitErr := s.emitAuditEvent(ctx, l, kind, recipientEmail); emitErr != nil {
+		slog.Warn("loan reminder: emit audit event failed",
+			"loan_id", l.ID,
+			"kind", string(kind),
+			"error", emitErr,
+		)
+	}
+	return true, nil
+}
+
 // lookupLender returns the resolved lender User + its email + display
 // name. Empty email return signals "no recipient on file" so the caller
 // can short-circuit. The lender is the user who created the loan row;
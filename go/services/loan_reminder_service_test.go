@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"errors"
+	"net/url"
 	"sync"
 	"testing"
 	"time"
@@ -24,8 +25,20 @@ import (
 // EmailService method as a no-op — the loan service only calls
 // SendLoanReminderEmail.
 type recordingLoanEmailService struct {
-	mu    sync.Mutex
-	calls []recordedLoanEmail
+	mu            sync.Mutex
+	calls         []recordedLoanEmail
+	borrowerCalls []recordedBorrowerEmail
+}
+
+type recordedBorrowerEmail struct {
+	to            string
+	borrowerName  string
+	lenderName    string
+	commodityName string
+	dueBackAt     string
+	responseURL   string
+	kind          string
+	daysDelta     int
 }
 
 type recordedLoanEmail struct {
@@ -73,6 +86,10 @@ func (r *recordingLoanEmailService) SendMaintenanceReminderEmail(_ context.Conte
 	return nil
 }
 
+func (r *recordingLoanEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+
 func (r *recordingLoanEmailService) SendLoanReminderEmail(_ context.Context, to, name, commodityName, borrowerName, lentAt, dueBackAt, commodityURL, kind string, daysDelta int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -90,6 +107,33 @@ func (r *recordingLoanEmailService) SendLoanReminderEmail(_ context.Context, to,
 	return nil
 }
 
+func (r *recordingLoanEmailService) SendLoanBorrowerReminderEmail(_ context.Context, to, borrowerName, lenderName, commodityName, dueBackAt, responseURL, kind string, daysDelta int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.borrowerCalls = append(r.borrowerCalls, recordedBorrowerEmail{
+		to:            to,
+		borrowerName:  borrowerName,
+		lenderName:    lenderName,
+		commodityName: commodityName,
+		dueBackAt:     dueBackAt,
+		responseURL:   responseURL,
+		kind:          kind,
+		daysDelta:     daysDelta,
+	})
+	return nil
+}
+func (*recordingLoanEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingLoanEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingLoanEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return nil
+}
+
 func (r *recordingLoanEmailService) snapshot() []recordedLoanEmail {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -98,6 +142,14 @@ func (r *recordingLoanEmailService) snapshot() []recordedLoanEmail {
 	return out
 }
 
+func (r *recordingLoanEmailService) borrowerSnapshot() []recordedBorrowerEmail {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]recordedBorrowerEmail, len(r.borrowerCalls))
+	copy(out, r.borrowerCalls)
+	return out
+}
+
 // failingLoanEmailService stand-in returns errors from
 // SendLoanReminderEmail. Lets the retry test simulate a queue outage
 // without touching the recording mock. Implemented as a standalone
@@ -133,6 +185,25 @@ func (failingLoanEmailService) SendLoanReminderEmail(_ context.Context, _, _, _,
 func (failingLoanEmailService) SendMaintenanceReminderEmail(_ context.Context, _, _, _, _, _, _ string, _ int) error {
 	return errors.New("queue down")
 }
+func (failingLoanEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return errors.New("queue down")
+}
+
+func (failingLoanEmailService) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return errors.New("queue down")
+}
+func (failingLoanEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return errors.New("queue down")
+}
+
+func (failingLoanEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return errors.New("queue down")
+}
+
+func (failingLoanEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return errors.New("queue down")
+}
+
 func (failingLoanEmailService) SendFeedbackEmail(_ context.Context, _, _, _, _, _, _, _ string, _ []string) error {
 	return nil
 }
@@ -448,6 +519,90 @@ func TestLoanReminderService_DueSoonWindow(t *testing.T) {
 		qt.Commentf("loan due in 8 days must remain a non-candidate until the clock catches up"))
 }
 
+// TestLoanReminderService_BorrowerReminders covers the courtesy
+// reminders: a loan with a borrower email gets one borrower due-soon
+// and one borrower overdue email alongside the lender's, each carrying
+// a signed loan link that validates against the same signer.
+func TestLoanReminderService_BorrowerReminders(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, commodityID, factorySet := newLoanReminderServiceFixture(c)
+
+	dueDate := models.Date("2026-05-17")
+	loan, err := regSet.CommodityLoanRegistry.Create(ctx, models.CommodityLoan{
+		CommodityID:   commodityID,
+		BorrowerName:  "Pavel",
+		BorrowerEmail: "pavel@example.com",
+		LentAt:        "2026-05-10",
+		DueBackAt:     models.PDate(&dueDate),
+	})
+	c.Assert(err, qt.IsNil)
+
+	signer := services.NewLoanLinkSigner([]byte("0123456789abcdef0123456789abcdef"), 0)
+	emailSvc := &recordingLoanEmailService{}
+	svc := services.NewLoanReminderService(factorySet, emailSvc, nil).
+		WithBorrowerLinks(signer, "https://example.test/")
+
+	tick1 := time.Date(2026, 5, 16, 12, 0, 0, 0, time.UTC)
+	stats, err := svc.RemindOnce(ctx, tick1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Sent[registry.LoanReminderKindDueSoon], qt.Equals, 1)
+	c.Assert(stats.Sent[registry.LoanReminderKindBorrowerDueSoon], qt.Equals, 1)
+	borrowerCalls := emailSvc.borrowerSnapshot()
+	c.Assert(borrowerCalls, qt.HasLen, 1)
+	c.Assert(borrowerCalls[0].to, qt.Equals, "pavel@example.com")
+	c.Assert(borrowerCalls[0].borrowerName, qt.Equals, "Pavel")
+	c.Assert(borrowerCalls[0].lenderName, qt.Equals, "Loan Owner")
+	c.Assert(borrowerCalls[0].commodityName, qt.Equals, "drill")
+	c.Assert(borrowerCalls[0].kind, qt.Equals, "due_soon")
+	c.Assert(borrowerCalls[0].daysDelta, qt.Equals, 1)
+
+	link, err := url.Parse(borrowerCalls[0].responseURL)
+	c.Assert(err, qt.IsNil)
+	c.Assert(link.Host, qt.Equals, "example.test")
+	c.Assert(link.Path, qt.Equals, "/loan-response/"+loan.ID)
+	c.Assert(signer.Validate(loan.ID, link.Query().Get("sig"), link.Query().Get("exp")), qt.IsNil)
+
+	// Re-run: both flags flipped, nothing new goes out.
+	stats, err = svc.RemindOnce(ctx, tick1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Total(), qt.Equals, 0)
+
+	tick2 := tick1.AddDate(0, 0, 3)
+	stats, err = svc.RemindOnce(ctx, tick2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Sent[registry.LoanReminderKindOverdue], qt.Equals, 1)
+	c.Assert(stats.Sent[registry.LoanReminderKindBorrowerOverdue], qt.Equals, 1)
+	borrowerCalls = emailSvc.borrowerSnapshot()
+	c.Assert(borrowerCalls, qt.HasLen, 2)
+	c.Assert(borrowerCalls[1].kind, qt.Equals, "overdue")
+	c.Assert(borrowerCalls[1].daysDelta, qt.Equals, 2)
+	c.Assert(emailSvc.snapshot(), qt.HasLen, 2)
+}
+
+// TestLoanReminderService_NoBorrowerEmailNoBorrowerReminder pins that
+// a loan without a borrower email only ever reminds the lender.
+func TestLoanReminderService_NoBorrowerEmailNoBorrowerReminder(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, commodityID, factorySet := newLoanReminderServiceFixture(c)
+
+	dueDate := models.Date("2026-05-01")
+	_, err := regSet.CommodityLoanRegistry.Create(ctx, models.CommodityLoan{
+		CommodityID:  commodityID,
+		BorrowerName: "Ivan",
+		LentAt:       "2026-04-20",
+		DueBackAt:    models.PDate(&dueDate),
+	})
+	c.Assert(err, qt.IsNil)
+
+	emailSvc := &recordingLoanEmailService{}
+	svc := services.NewLoanReminderService(factorySet, emailSvc, nil)
+	stats, err := svc.RemindOnce(ctx, time.Date(2026, 5, 17, 12, 0, 0, 0, time.UTC))
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Sent[registry.LoanReminderKindOverdue], qt.Equals, 1)
+	c.Assert(stats.Sent[registry.LoanReminderKindBorrowerOverdue], qt.Equals, 0)
+	c.Assert(emailSvc.borrowerSnapshot(), qt.HasLen, 0)
+}
+
 // newLoanReminderServiceFixture wires a memory-backed factory + commodity +
 // area + location the loan tests can populate. The returned commodity
 // ID is the parent for every loan the test creates.
@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -16,7 +17,8 @@ import (
 const defaultLoanReminderInterval = 1 * time.Hour
 
 // Prometheus counters for the loan reminder worker (#1509). Labels:
-//   - kind: "overdue" or "due_soon" — matches LoanReminderKind.
+//   - kind: "overdue", "due_soon", "borrower_overdue" or
+//     "borrower_due_soon" — matches LoanReminderKind.
 //
 // `_failures_total` is unlabelled because every failure is logged with
 // the kind tag and slicing the counter by reason adds no value (the
@@ -24,7 +26,7 @@ const defaultLoanReminderInterval = 1 * time.Hour
 var (
 	loanRemindersSentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "inventario_loan_reminders_sent_total",
-		Help: "Number of loan reminder emails enqueued, partitioned by kind (overdue|due_soon).",
+		Help: "Number of loan reminder emails enqueued, partitioned by kind (overdue|due_soon|borrower_overdue|borrower_due_soon).",
 	}, []string{"kind"})
 	loanReminderFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "inventario_loan_reminder_failures_total",
@@ -156,6 +158,9 @@ func (w *LoanReminderWorker) tick(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeLoanReminder))
+	defer span.End()
+
 	stats, err := w.service.RemindOnce(ctx, w.clock())
 	if err != nil {
 		slog.Error("Loan reminder sweep failed", "error", err)
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -159,6 +160,9 @@ func (w *LoginEventRetentionWorker) sweep(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeLoginEventRetention))
+	defer span.End()
+
 	now := time.Now
 	if w.clock != nil {
 		now = w.clock
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -123,6 +124,9 @@ func (w *MagicLinkTokenCleanupWorker) cleanupOnce(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeMagicLinkTokenCleanup))
+	defer span.End()
+
 	if err := w.registry.DeleteExpired(ctx); err != nil {
 		slog.Error("Failed to delete expired magic-link tokens", "error", err)
 	} else {
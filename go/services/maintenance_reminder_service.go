@@ -29,7 +29,9 @@ import (
 //
 // Mirrors WarrantyReminderService one-to-one — the only differences
 // are the threshold semantics (matchedMaintenanceThresholds adds the
-// overdue sentinel) and the email shape.
+// overdue sentinel), the email shape, and usage-based schedules, whose
+// due date is re-estimated from the latest meter readings on every
+// sweep (see withUsageEstimate).
 type MaintenanceReminderService struct {
 	factorySet *registry.FactorySet
 	emailSvc   EmailService
@@ -99,7 +101,16 @@ func (s *MaintenanceReminderService) RemindOnce(ctx context.Context, now time.Ti
 		if m == nil || !m.Enabled {
 			continue
 		}
-		for _, threshold := range matchedMaintenanceThresholds(m, now) {
+		m, thresholds, estErr := s.withUsageEstimate(ctx, m, now)
+		if estErr != nil {
+			stats.Failed++
+			slog.Error("maintenance reminder: usage estimate failed",
+				"schedule_id", m.ID,
+				"error", estErr,
+			)
+			continue
+		}
+		for _, threshold := range thresholds {
 			ok, processErr := s.processOne(ctx, m, threshold, now, prefsCache)
 			if processErr != nil {
 				stats.Failed++
@@ -118,6 +129,31 @@ func (s *MaintenanceReminderService) RemindOnce(ctx context.Context, now time.Ti
 	return stats, nil
 }
 
+// withUsageEstimate returns the schedule as the sweep should see it
+// together with its matched thresholds. Interval and calendar
+// schedules pass through unchanged. A usage schedule gets a copy whose
+// NextDueAt is the fresh estimate from its meter readings — readings
+// recorded since the last write may have moved it — and is overdue
+// outright once the latest reading has reached NextDueMeter,
+// whatever the date says.
+func (s *MaintenanceReminderService) withUsageEstimate(ctx context.Context, m *models.MaintenanceSchedule, now time.Time) (*models.MaintenanceSchedule, []models.MaintenanceReminderThreshold, error) {
+	if m.Kind() != models.MaintenanceRecurrenceUsage {
+		return m, matchedMaintenanceThresholds(m, now), nil
+	}
+	readingReg := s.factorySet.CommodityMeterReadingRegistryFactory.CreateServiceRegistry()
+	readings, err := readingReg.ListByCommodity(ctx, m.CommodityID, m.MeterUnit)
+	if err != nil {
+		return m, nil, errxtrace.Wrap("failed to list meter readings", err)
+	}
+	est := m.EstimateUsage(readings, now)
+	estimated := *m
+	estimated.NextDueAt = est.DueAt
+	if est.MeterReached {
+		return &estimated, []models.MaintenanceReminderThreshold{models.MaintenanceReminderOverdue}, nil
+	}
+	return &estimated, matchedMaintenanceThresholds(&estimated, now), nil
+}
+
 // matchedMaintenanceThresholds returns every threshold whose
 // "days remaining" window contains the schedule at the given clock,
 // plus MaintenanceReminderOverdue when the schedule is past due. A
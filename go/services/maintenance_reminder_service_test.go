@@ -78,6 +78,25 @@ func (r *recordingMaintenanceEmailService) SendMaintenanceReminderEmail(_ contex
 	return nil
 }
 
+func (r *recordingMaintenanceEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+
+func (r *recordingMaintenanceEmailService) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+func (*recordingMaintenanceEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingMaintenanceEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingMaintenanceEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return nil
+}
+
 func (r *recordingMaintenanceEmailService) snapshot() []recordedMaintenanceEmail {
 	r.mu.Lock()
 	defer r.mu.Unlock()
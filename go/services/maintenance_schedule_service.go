@@ -2,9 +2,13 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	errxtrace "github.com/go-extras/errx/stacktrace"
+	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
 
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
@@ -23,17 +27,36 @@ func NewMaintenanceScheduleService(factorySet *registry.FactorySet) *Maintenance
 	return &MaintenanceScheduleService{factorySet: factorySet}
 }
 
-// Create persists a new schedule, defaulting NextDueAt to
-// `today + interval_days` when the caller passes an empty value. The
-// commodity must be trackable (count == 1, #1554) — bundles cannot
-// carry per-instance maintenance.
+// Create persists a new schedule. An empty NextDueAt defaults to
+// `today + interval_days` for interval schedules and to the first rule
+// occurrence on or after today for calendar schedules. Usage schedules
+// default NextDueMeter to the latest reading plus MeterInterval and
+// always derive NextDueAt from the meter readings. The commodity must
+// be trackable (count == 1, #1554) — bundles cannot carry per-instance
+// maintenance.
 func (s *MaintenanceScheduleService) Create(ctx context.Context, schedule models.MaintenanceSchedule, now time.Time) (*models.MaintenanceSchedule, error) {
 	if err := EnsureCommodityTrackable(ctx, s.factorySet, schedule.CommodityID); err != nil {
 		return nil, err
 	}
 
-	if string(schedule.NextDueAt) == "" {
-		schedule.NextDueAt = schedule.AdvanceFromDone(now)
+	schedule.Recurrence = schedule.Kind()
+	switch schedule.Recurrence {
+	case models.MaintenanceRecurrenceUsage:
+		if err := s.refreshUsageDue(ctx, &schedule, now); err != nil {
+			return nil, err
+		}
+	case models.MaintenanceRecurrenceCalendar:
+		if string(schedule.NextDueAt) == "" && schedule.CalendarRule != nil {
+			schedule.NextDueAt = firstCalendarOccurrence(schedule.CalendarRule, now)
+		}
+	default:
+		if string(schedule.NextDueAt) == "" {
+			schedule.NextDueAt = schedule.AdvanceFromDone(now)
+		}
+	}
+
+	if err := schedule.ValidateWithContext(ctx); err != nil {
+		return nil, errxtrace.Wrap("failed to validate maintenance schedule", err)
 	}
 
 	reg, err := s.factorySet.MaintenanceScheduleRegistryFactory.CreateUserRegistry(ctx)
@@ -54,7 +77,12 @@ func (s *MaintenanceScheduleService) Create(ctx context.Context, schedule models
 // LoanUpdate.ClearDueBackAt.
 type MaintenanceScheduleUpdate struct {
 	Title         *string
+	Recurrence    *models.MaintenanceRecurrence
 	IntervalDays  *int
+	CalendarRule  *models.MaintenanceCalendarRule
+	MeterUnit     *models.MeterUnit
+	MeterInterval *decimal.Decimal
+	NextDueMeter  *decimal.Decimal
 	NextDueAt     *models.Date
 	LastDoneAt    models.PDate
 	ClearLastDone bool
@@ -62,8 +90,12 @@ type MaintenanceScheduleUpdate struct {
 	Enabled       *bool
 }
 
-// Update applies partial updates to an existing schedule.
-func (s *MaintenanceScheduleService) Update(ctx context.Context, id string, patch MaintenanceScheduleUpdate) (*models.MaintenanceSchedule, error) {
+// Update applies partial updates to an existing schedule. Switching the
+// recurrence drops the settings of the previous kind. A calendar
+// schedule whose rule changed without an explicit NextDueAt moves to
+// the new rule's first occurrence on or after today; usage schedules
+// always re-derive NextDueAt from the meter readings.
+func (s *MaintenanceScheduleService) Update(ctx context.Context, id string, patch MaintenanceScheduleUpdate, now time.Time) (*models.MaintenanceSchedule, error) {
 	reg, err := s.factorySet.MaintenanceScheduleRegistryFactory.CreateUserRegistry(ctx)
 	if err != nil {
 		return nil, errxtrace.Wrap("failed to create maintenance schedule registry", err)
@@ -74,12 +106,39 @@ func (s *MaintenanceScheduleService) Update(ctx context.Context, id string, patc
 		return nil, errxtrace.Wrap("failed to fetch maintenance schedule", err)
 	}
 
+	previousKind := current.Kind()
 	if patch.Title != nil {
 		current.Title = *patch.Title
 	}
+	if patch.Recurrence != nil {
+		current.Recurrence = *patch.Recurrence
+	}
+	if kind := current.Kind(); kind != previousKind {
+		if kind != models.MaintenanceRecurrenceCalendar {
+			current.CalendarRule = nil
+		}
+		if kind != models.MaintenanceRecurrenceUsage {
+			current.MeterUnit = ""
+			current.MeterInterval = decimal.Zero
+			current.NextDueMeter = decimal.Zero
+		}
+	}
 	if patch.IntervalDays != nil {
 		current.IntervalDays = *patch.IntervalDays
 	}
+	if patch.CalendarRule != nil {
+		rule := *patch.CalendarRule
+		current.CalendarRule = &rule
+	}
+	if patch.MeterUnit != nil {
+		current.MeterUnit = *patch.MeterUnit
+	}
+	if patch.MeterInterval != nil {
+		current.MeterInterval = *patch.MeterInterval
+	}
+	if patch.NextDueMeter != nil {
+		current.NextDueMeter = *patch.NextDueMeter
+	}
 	if patch.NextDueAt != nil {
 		current.NextDueAt = *patch.NextDueAt
 	}
@@ -96,6 +155,22 @@ func (s *MaintenanceScheduleService) Update(ctx context.Context, id string, patc
 		current.Enabled = *patch.Enabled
 	}
 
+	switch current.Kind() {
+	case models.MaintenanceRecurrenceUsage:
+		if err := s.refreshUsageDue(ctx, current, now); err != nil {
+			return nil, err
+		}
+	case models.MaintenanceRecurrenceCalendar:
+		ruleChanged := patch.CalendarRule != nil || previousKind != models.MaintenanceRecurrenceCalendar
+		if ruleChanged && patch.NextDueAt == nil && current.CalendarRule != nil {
+			current.NextDueAt = firstCalendarOccurrence(current.CalendarRule, now)
+		}
+	}
+
+	if err := current.ValidateWithContext(ctx); err != nil {
+		return nil, errxtrace.Wrap("failed to validate maintenance schedule patch", err)
+	}
+
 	updated, err := reg.Update(ctx, *current)
 	if err != nil {
 		return nil, errxtrace.Wrap("failed to update maintenance schedule", err)
@@ -111,48 +186,321 @@ func (s *MaintenanceScheduleService) Update(ctx context.Context, id string, patc
 	return updated, nil
 }
 
-// MarkDone advances NextDueAt by IntervalDays from the supplied done
-// date (or `now` when zero), records LastDoneAt, and clears the
-// idempotency rows so the next cycle's reminders fire fresh.
-//
-// `doneDate` is the user-supplied "I did this on …" date — typically
-// today, but may be a recent past date (the user logging a maintenance
-// they performed earlier and forgot to tick off).
+// MaintenanceCompletion describes one completion of a schedule for
+// Complete. Everything but the schedule itself is optional.
+type MaintenanceCompletion struct {
+	// DoneAt is the user-supplied "I did this on …" date — typically
+	// today, but may be a recent past date (the user logging a
+	// maintenance they performed earlier and forgot to tick off).
+	// Empty means the server clock.
+	DoneAt models.PDate
+	// MeterValue is the meter reading at completion. For usage
+	// schedules it is also recorded as a meter reading and becomes the
+	// base of the next NextDueMeter; other kinds just keep it on the log.
+	MeterValue   *decimal.Decimal
+	Notes        string
+	CostAmount   decimal.Decimal
+	CostCurrency string
+	// FileIDs attaches already-uploaded files of the group.
+	FileIDs []string
+}
+
+// MarkDone completes a schedule on the given date without any log
+// details. See Complete.
 func (s *MaintenanceScheduleService) MarkDone(ctx context.Context, id string, doneDate models.PDate, now time.Time) (*models.MaintenanceSchedule, error) {
+	updated, _, err := s.Complete(ctx, id, MaintenanceCompletion{DoneAt: doneDate}, now)
+	return updated, err
+}
+
+// Complete records a completion of the schedule: it writes a
+// MaintenanceLog row, records LastDoneAt, advances NextDueAt according
+// to the recurrence (and NextDueMeter for usage schedules), and clears
+// the idempotency rows so the next cycle's reminders fire fresh.
+//
+// The log row is written before the schedule is advanced: a failure in
+// between leaves a logged completion on a not-yet-advanced schedule,
+// which the user can fix by editing the due date, rather than an
+// advanced schedule with no history.
+func (s *MaintenanceScheduleService) Complete(ctx context.Context, id string, completion MaintenanceCompletion, now time.Time) (*models.MaintenanceSchedule, *models.MaintenanceLog, error) {
 	reg, err := s.factorySet.MaintenanceScheduleRegistryFactory.CreateUserRegistry(ctx)
 	if err != nil {
-		return nil, errxtrace.Wrap("failed to create maintenance schedule registry", err)
+		return nil, nil, errxtrace.Wrap("failed to create maintenance schedule registry", err)
 	}
 
 	current, err := reg.Get(ctx, id)
 	if err != nil {
-		return nil, errxtrace.Wrap("failed to fetch maintenance schedule", err)
+		return nil, nil, errxtrace.Wrap("failed to fetch maintenance schedule", err)
 	}
 
 	// Resolve done date: explicit PDate wins, otherwise the server
 	// clock. Stored alongside the recomputed NextDueAt.
 	var doneTime time.Time
-	if doneDate != nil && string(*doneDate) != "" {
-		doneTime = doneDate.ToTime()
+	if completion.DoneAt != nil && string(*completion.DoneAt) != "" {
+		doneTime = completion.DoneAt.ToTime()
 	}
 	if doneTime.IsZero() {
 		doneTime = now
 	}
-
-	current.NextDueAt = current.AdvanceFromDone(doneTime)
 	doneDateValue := models.Date(doneTime.UTC().Format("2006-01-02"))
+
+	log := models.MaintenanceLog{
+		ScheduleID:   current.ID,
+		CommodityID:  current.CommodityID,
+		DoneAt:       doneDateValue,
+		MeterValue:   completion.MeterValue,
+		Notes:        completion.Notes,
+		CostAmount:   completion.CostAmount,
+		CostCurrency: completion.CostCurrency,
+		FileIDs:      completion.FileIDs,
+	}
+	if err := log.ValidateWithContext(ctx); err != nil {
+		return nil, nil, errxtrace.Wrap("failed to validate maintenance log", err)
+	}
+	if err := s.ensureFilesExist(ctx, completion.FileIDs); err != nil {
+		return nil, nil, err
+	}
+
+	if current.Kind() == models.MaintenanceRecurrenceUsage && completion.MeterValue != nil {
+		if _, err := s.recordReading(ctx, models.CommodityMeterReading{
+			CommodityID: current.CommodityID,
+			Unit:        current.MeterUnit,
+			Value:       *completion.MeterValue,
+			ReadAt:      doneDateValue,
+			Notes:       current.Title,
+		}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	logReg, err := s.factorySet.MaintenanceLogRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, nil, errxtrace.Wrap("failed to create maintenance log registry", err)
+	}
+	createdLog, err := logReg.Create(ctx, log)
+	if err != nil {
+		return nil, nil, errxtrace.Wrap("failed to create maintenance log", err)
+	}
+
 	current.LastDoneAt = &doneDateValue
+	if current.Kind() == models.MaintenanceRecurrenceUsage {
+		base := current.NextDueMeter
+		readings, err := s.usageReadings(ctx, current)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case completion.MeterValue != nil:
+			base = *completion.MeterValue
+		default:
+			if latest := current.EstimateUsage(readings, now).LatestMeter; latest != nil {
+				base = *latest
+			}
+		}
+		current.NextDueMeter = base.Add(current.MeterInterval)
+		current.NextDueAt = current.EstimateUsage(readings, now).DueAt
+	} else {
+		current.NextDueAt = current.AdvanceFromDone(doneTime)
+	}
 
 	updated, err := reg.Update(ctx, *current)
 	if err != nil {
-		return nil, errxtrace.Wrap("failed to update maintenance schedule", err)
+		return nil, createdLog, errxtrace.Wrap("failed to update maintenance schedule", err)
 	}
 
 	// Reset idempotency rows — the new NextDueAt opens a fresh
 	// 14/7/1/overdue cycle.
 	if _, derr := s.factorySet.MaintenanceReminderRegistry.DeleteBySchedule(ctx, updated.ID); derr != nil {
-		return updated, errxtrace.Wrap("failed to reset maintenance reminders", derr)
+		return updated, createdLog, errxtrace.Wrap("failed to reset maintenance reminders", derr)
 	}
 
-	return updated, nil
+	return updated, createdLog, nil
+}
+
+// ListLogs returns the completion history of a schedule, newest first.
+func (s *MaintenanceScheduleService) ListLogs(ctx context.Context, scheduleID string) ([]*models.MaintenanceLog, error) {
+	logReg, err := s.factorySet.MaintenanceLogRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create maintenance log registry", err)
+	}
+	logs, err := logReg.ListBySchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list maintenance logs", err)
+	}
+	return logs, nil
+}
+
+// DeleteLog removes one completion from a schedule's history. The
+// schedule itself is left as is — deleting history does not rewind
+// the due date.
+func (s *MaintenanceScheduleService) DeleteLog(ctx context.Context, scheduleID, logID string) error {
+	logReg, err := s.factorySet.MaintenanceLogRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create maintenance log registry", err)
+	}
+	log, err := logReg.Get(ctx, logID)
+	if err != nil {
+		return errxtrace.Wrap("failed to fetch maintenance log", err)
+	}
+	if log.ScheduleID != scheduleID {
+		return errxtrace.Wrap("maintenance log belongs to another schedule", registry.ErrNotFound)
+	}
+	if err := logReg.Delete(ctx, logID); err != nil {
+		return errxtrace.Wrap("failed to delete maintenance log", err)
+	}
+	return nil
+}
+
+// ListMeterReadings returns a commodity's meter readings in date order,
+// optionally narrowed to one unit.
+func (s *MaintenanceScheduleService) ListMeterReadings(ctx context.Context, commodityID string, unit models.MeterUnit) ([]*models.CommodityMeterReading, error) {
+	readingReg, err := s.factorySet.CommodityMeterReadingRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create meter reading registry", err)
+	}
+	readings, err := readingReg.ListByCommodity(ctx, commodityID, unit)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to list meter readings", err)
+	}
+	return readings, nil
+}
+
+// RecordMeterReading stores a new meter reading for a commodity and
+// re-estimates the due dates of the commodity's usage schedules in the
+// reading's unit. Same trackability rule as schedules.
+func (s *MaintenanceScheduleService) RecordMeterReading(ctx context.Context, reading models.CommodityMeterReading, now time.Time) (*models.CommodityMeterReading, error) {
+	if err := EnsureCommodityTrackable(ctx, s.factorySet, reading.CommodityID); err != nil {
+		return nil, err
+	}
+	created, err := s.recordReading(ctx, reading)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshCommodityUsageSchedules(ctx, created.CommodityID, created.Unit, now); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// DeleteMeterReading removes a mistyped reading of the given commodity
+// and re-estimates the affected usage schedules.
+func (s *MaintenanceScheduleService) DeleteMeterReading(ctx context.Context, commodityID, readingID string, now time.Time) error {
+	readingReg, err := s.factorySet.CommodityMeterReadingRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create meter reading registry", err)
+	}
+	reading, err := readingReg.Get(ctx, readingID)
+	if err != nil {
+		return errxtrace.Wrap("failed to fetch meter reading", err)
+	}
+	if reading.CommodityID != commodityID {
+		return errxtrace.Wrap("meter reading belongs to another commodity", registry.ErrNotFound)
+	}
+	if err := readingReg.Delete(ctx, readingID); err != nil {
+		return errxtrace.Wrap("failed to delete meter reading", err)
+	}
+	return s.refreshCommodityUsageSchedules(ctx, commodityID, reading.Unit, now)
+}
+
+func (s *MaintenanceScheduleService) recordReading(ctx context.Context, reading models.CommodityMeterReading) (*models.CommodityMeterReading, error) {
+	if err := reading.ValidateWithContext(ctx); err != nil {
+		return nil, errxtrace.Wrap("failed to validate meter reading", err)
+	}
+	readingReg, err := s.factorySet.CommodityMeterReadingRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create meter reading registry", err)
+	}
+	created, err := readingReg.Create(ctx, reading)
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to create meter reading", err)
+	}
+	return created, nil
+}
+
+// refreshCommodityUsageSchedules re-estimates NextDueAt of every usage
+// schedule of the commodity that counts in `unit`. Reminder rows are
+// left alone: a moved estimate is not a new cycle, and the worker
+// re-evaluates the thresholds against the new date on its next tick.
+func (s *MaintenanceScheduleService) refreshCommodityUsageSchedules(ctx context.Context, commodityID string, unit models.MeterUnit, now time.Time) error {
+	reg, err := s.factorySet.MaintenanceScheduleRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create maintenance schedule registry", err)
+	}
+	schedules, err := reg.ListByCommodity(ctx, commodityID)
+	if err != nil {
+		return errxtrace.Wrap("failed to list maintenance schedules", err)
+	}
+	for _, schedule := range schedules {
+		if schedule.Kind() != models.MaintenanceRecurrenceUsage || schedule.MeterUnit != unit {
+			continue
+		}
+		previous := schedule.NextDueAt
+		if err := s.refreshUsageDue(ctx, schedule, now); err != nil {
+			return err
+		}
+		if schedule.NextDueAt == previous {
+			continue
+		}
+		if _, err := reg.Update(ctx, *schedule); err != nil {
+			return errxtrace.Wrap("failed to update maintenance schedule", err)
+		}
+	}
+	return nil
+}
+
+// refreshUsageDue fills in the derived state of a usage schedule:
+// NextDueMeter defaults to the latest reading (or zero) plus
+// MeterInterval, and NextDueAt is the meter-based estimate.
+func (s *MaintenanceScheduleService) refreshUsageDue(ctx context.Context, schedule *models.MaintenanceSchedule, now time.Time) error {
+	readings, err := s.usageReadings(ctx, schedule)
+	if err != nil {
+		return err
+	}
+	est := schedule.EstimateUsage(readings, now)
+	if schedule.NextDueMeter.IsZero() {
+		base := decimal.Zero
+		if est.LatestMeter != nil {
+			base = *est.LatestMeter
+		}
+		schedule.NextDueMeter = base.Add(schedule.MeterInterval)
+		est = schedule.EstimateUsage(readings, now)
+	}
+	schedule.NextDueAt = est.DueAt
+	return nil
+}
+
+func (s *MaintenanceScheduleService) usageReadings(ctx context.Context, schedule *models.MaintenanceSchedule) ([]*models.CommodityMeterReading, error) {
+	if schedule.MeterUnit == "" {
+		return nil, nil
+	}
+	return s.ListMeterReadings(ctx, schedule.CommodityID, schedule.MeterUnit)
+}
+
+// ensureFilesExist rejects attachments that do not name a file of the
+// current group. Reported as a field validation error on file_ids so
+// the API answers 422 like any other bad attribute.
+func (s *MaintenanceScheduleService) ensureFilesExist(ctx context.Context, fileIDs []string) error {
+	if len(fileIDs) == 0 {
+		return nil
+	}
+	fileReg, err := s.factorySet.FileRegistryFactory.CreateUserRegistry(ctx)
+	if err != nil {
+		return errxtrace.Wrap("failed to create file registry", err)
+	}
+	for _, id := range fileIDs {
+		if _, err := fileReg.Get(ctx, id); err != nil {
+			if errors.Is(err, registry.ErrNotFound) {
+				return validation.Errors{
+					"file_ids": validation.NewError("file_not_found", fmt.Sprintf("file %s does not exist", id)),
+				}
+			}
+			return errxtrace.Wrap("failed to look up attached file", err)
+		}
+	}
+	return nil
+}
+
+// firstCalendarOccurrence is the first occurrence of the rule on or
+// after today.
+func firstCalendarOccurrence(rule *models.MaintenanceCalendarRule, now time.Time) models.Date {
+	return models.Date(rule.Next(now.AddDate(0, 0, -1)).Format("2006-01-02"))
 }
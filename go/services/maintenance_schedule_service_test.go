@@ -0,0 +1,235 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/jellydator/validation"
+	"github.com/shopspring/decimal"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services"
+)
+
+func newMaintenanceCommodity(c *qt.C, ctx context.Context, regSet *registry.Set, areaID, name string) *models.Commodity {
+	c.Helper()
+	commodity, err := regSet.CommodityRegistry.Create(ctx, models.Commodity{
+		AreaID: new(areaID), Name: name, ShortName: name,
+		Type: models.CommodityTypeEquipment, Status: models.CommodityStatusInUse, Count: 1,
+	})
+	c.Assert(err, qt.IsNil)
+	return commodity
+}
+
+// TestMaintenanceScheduleService_CompleteWritesLog checks that a
+// completion is kept as a log entry with its details and that the
+// history lists newest first.
+func TestMaintenanceScheduleService_CompleteWritesLog(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID, factorySet := newMaintenanceScheduleFixture(c)
+	commodity := newMaintenanceCommodity(c, ctx, regSet, areaID, "boiler")
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	svc := services.NewMaintenanceScheduleService(factorySet)
+	schedule, err := svc.Create(ctx, models.MaintenanceSchedule{
+		CommodityID:  commodity.ID,
+		Title:        "Annual service",
+		IntervalDays: 365,
+		Enabled:      true,
+	}, now)
+	c.Assert(err, qt.IsNil)
+
+	earlier := models.Date("2026-10-01")
+	_, first, err := svc.Complete(ctx, schedule.ID, services.MaintenanceCompletion{DoneAt: &earlier}, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(first.DoneAt), qt.Equals, "2026-10-01")
+
+	updated, second, err := svc.Complete(ctx, schedule.ID, services.MaintenanceCompletion{
+		Notes:        "Replaced the gasket",
+		CostAmount:   decimal.RequireFromString("120.50"),
+		CostCurrency: "EUR",
+	}, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(updated.NextDueAt), qt.Equals, "2027-10-18")
+	c.Assert(second.ScheduleID, qt.Equals, schedule.ID)
+	c.Assert(second.CommodityID, qt.Equals, commodity.ID)
+	c.Assert(second.Notes, qt.Equals, "Replaced the gasket")
+	c.Assert(second.CostAmount.String(), qt.Equals, "120.5")
+
+	logs, err := svc.ListLogs(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(logs, qt.HasLen, 2)
+	c.Assert(logs[0].ID, qt.Equals, second.ID)
+	c.Assert(logs[1].ID, qt.Equals, first.ID)
+
+	c.Assert(svc.DeleteLog(ctx, "other-schedule", first.ID), qt.ErrorIs, registry.ErrNotFound)
+	c.Assert(svc.DeleteLog(ctx, schedule.ID, first.ID), qt.IsNil)
+	logs, err = svc.ListLogs(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(logs, qt.HasLen, 1)
+}
+
+// TestMaintenanceScheduleService_CompleteRejectsBadDetails covers the
+// 422 paths of a completion: unknown attachments and a half-set cost.
+// Nothing is logged and the schedule is left alone.
+func TestMaintenanceScheduleService_CompleteRejectsBadDetails(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID, factorySet := newMaintenanceScheduleFixture(c)
+	commodity := newMaintenanceCommodity(c, ctx, regSet, areaID, "heat-pump")
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	svc := services.NewMaintenanceScheduleService(factorySet)
+	schedule, err := svc.Create(ctx, models.MaintenanceSchedule{
+		CommodityID:  commodity.ID,
+		Title:        "Clean filters",
+		IntervalDays: 30,
+		Enabled:      true,
+	}, now)
+	c.Assert(err, qt.IsNil)
+
+	for _, completion := range []services.MaintenanceCompletion{
+		{FileIDs: []string{"missing-file"}},
+		{CostAmount: decimal.NewFromInt(10)},
+	} {
+		_, _, err := svc.Complete(ctx, schedule.ID, completion, now)
+		var verrs validation.Errors
+		c.Assert(err, qt.ErrorAs, &verrs)
+	}
+
+	logs, err := svc.ListLogs(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(logs, qt.HasLen, 0)
+	current, err := regSet.MaintenanceScheduleRegistry.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(current.NextDueAt, qt.Equals, schedule.NextDueAt)
+}
+
+// TestMaintenanceScheduleService_CalendarSchedule checks that a
+// calendar schedule starts on the rule's first occurrence and moves to
+// the following one when completed.
+func TestMaintenanceScheduleService_CalendarSchedule(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID, factorySet := newMaintenanceScheduleFixture(c)
+	commodity := newMaintenanceCommodity(c, ctx, regSet, areaID, "smoke-alarm")
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	svc := services.NewMaintenanceScheduleService(factorySet)
+	schedule, err := svc.Create(ctx, models.MaintenanceSchedule{
+		CommodityID: commodity.ID,
+		Title:       "Test alarm",
+		Recurrence:  models.MaintenanceRecurrenceCalendar,
+		CalendarRule: &models.MaintenanceCalendarRule{
+			Frequency: models.MaintenanceCalendarMonthly,
+			Weekday:   "monday",
+			Week:      1,
+		},
+		Enabled: true,
+	}, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(schedule.NextDueAt), qt.Equals, "2026-11-02")
+
+	updated, err := svc.MarkDone(ctx, schedule.ID, nil, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(updated.NextDueAt), qt.Equals, "2026-12-07")
+
+	_, err = svc.Create(ctx, models.MaintenanceSchedule{
+		CommodityID: commodity.ID,
+		Title:       "No rule",
+		Recurrence:  models.MaintenanceRecurrenceCalendar,
+		Enabled:     true,
+	}, now)
+	var verrs validation.Errors
+	c.Assert(err, qt.ErrorAs, &verrs)
+}
+
+// TestMaintenanceScheduleService_UsageSchedule walks a usage schedule
+// through readings and a completion: the due meter is derived from the
+// latest reading, new readings move the estimated date, and completing
+// with a meter value records it and re-bases the next threshold.
+func TestMaintenanceScheduleService_UsageSchedule(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID, factorySet := newMaintenanceScheduleFixture(c)
+	commodity := newMaintenanceCommodity(c, ctx, regSet, areaID, "car")
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	svc := services.NewMaintenanceScheduleService(factorySet)
+	_, err := svc.RecordMeterReading(ctx, models.CommodityMeterReading{
+		CommodityID: commodity.ID, Unit: models.MeterUnitKm,
+		Value: decimal.NewFromInt(10000), ReadAt: "2026-01-01",
+	}, now)
+	c.Assert(err, qt.IsNil)
+
+	schedule, err := svc.Create(ctx, models.MaintenanceSchedule{
+		CommodityID:   commodity.ID,
+		Title:         "Oil change",
+		Recurrence:    models.MaintenanceRecurrenceUsage,
+		MeterUnit:     models.MeterUnitKm,
+		MeterInterval: decimal.NewFromInt(5000),
+		Enabled:       true,
+	}, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schedule.NextDueMeter.String(), qt.Equals, "15000")
+	// One reading gives no rate and there is no time cap.
+	c.Assert(string(schedule.NextDueAt), qt.Equals, "")
+
+	_, err = svc.RecordMeterReading(ctx, models.CommodityMeterReading{
+		CommodityID: commodity.ID, Unit: models.MeterUnitKm,
+		Value: decimal.NewFromInt(12000), ReadAt: "2026-07-01",
+	}, now)
+	c.Assert(err, qt.IsNil)
+	refreshed, err := regSet.MaintenanceScheduleRegistry.Get(ctx, schedule.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(refreshed.NextDueAt), qt.Equals, "2027-03-30")
+
+	meter := decimal.NewFromInt(14800)
+	updated, log, err := svc.Complete(ctx, schedule.ID, services.MaintenanceCompletion{MeterValue: &meter}, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(log.MeterValue.String(), qt.Equals, "14800")
+	c.Assert(updated.NextDueMeter.String(), qt.Equals, "19800")
+
+	readings, err := svc.ListMeterReadings(ctx, commodity.ID, models.MeterUnitKm)
+	c.Assert(err, qt.IsNil)
+	c.Assert(readings, qt.HasLen, 3)
+	c.Assert(readings[2].Value.String(), qt.Equals, "14800")
+	c.Assert(string(readings[2].ReadAt), qt.Equals, "2026-10-18")
+}
+
+// TestMaintenanceReminderService_UsageMeterReached checks that the
+// sweep treats a usage schedule whose meter threshold has been reached
+// as overdue, even though its estimated date is still in the future.
+func TestMaintenanceReminderService_UsageMeterReached(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, areaID, factorySet := newMaintenanceScheduleFixture(c)
+	commodity := newMaintenanceCommodity(c, ctx, regSet, areaID, "generator")
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	_, err := regSet.MaintenanceScheduleRegistry.Create(ctx, models.MaintenanceSchedule{
+		CommodityID:   commodity.ID,
+		Title:         "Replace spark plug",
+		Recurrence:    models.MaintenanceRecurrenceUsage,
+		MeterUnit:     models.MeterUnitHours,
+		MeterInterval: decimal.NewFromInt(100),
+		NextDueMeter:  decimal.NewFromInt(100),
+		NextDueAt:     dateOffset(now, 60),
+		Enabled:       true,
+	})
+	c.Assert(err, qt.IsNil)
+	// Recorded straight into the registry so the stored NextDueAt stays
+	// stale — the sweep must not rely on it.
+	_, err = regSet.CommodityMeterReadingRegistry.Create(ctx, models.CommodityMeterReading{
+		CommodityID: commodity.ID, Unit: models.MeterUnitHours,
+		Value: decimal.NewFromInt(104), ReadAt: dateOffset(now, -1),
+	})
+	c.Assert(err, qt.IsNil)
+
+	email := &recordingMaintenanceEmailService{}
+	reminder := services.NewMaintenanceReminderService(factorySet, email, nil)
+	stats, err := reminder.RemindOnce(ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Failed, qt.Equals, 0)
+	c.Assert(stats.SentByThreshold[models.MaintenanceReminderOverdue], qt.Equals, 1)
+	c.Assert(stats.Sent(), qt.Equals, 1)
+}
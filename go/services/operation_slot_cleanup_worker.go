@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -125,6 +126,9 @@ func (w *OperationSlotCleanupWorker) cleanupOnce(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeOperationSlotCleanup))
+	defer span.End()
+
 	deleted, err := w.registry.CleanupExpiredSlots(ctx)
 	if err != nil {
 		slog.Error("Failed to delete expired operation slots", "error", err)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/denisvmedia/inventario/appctx"
 	"github.com/denisvmedia/inventario/internal/blobkeys"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -570,6 +571,10 @@ func (w *OrphanFileGCWorker) RunOnce(ctx context.Context) {
 		orphanGCBlockedTenants.Set(0)
 		return
 	}
+
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeOrphanFileGC))
+	defer span.End()
+
 	if w.mode == OrphanFileGCModeOff {
 		orphanGCRunsTotal.WithLabelValues("skipped_disabled").Inc()
 		orphanGCBlockedTenants.Set(0)
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -122,6 +123,9 @@ func (w *RefreshTokenCleanupWorker) cleanupOnce(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeRefreshTokenCleanup))
+	defer span.End()
+
 	if err := w.registry.DeleteExpired(ctx); err != nil {
 		slog.Error("Failed to delete expired refresh tokens", "error", err)
 	} else {
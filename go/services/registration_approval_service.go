@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/go-extras/errx"
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+)
+
+// ErrRegistrationAlreadyDecided aliases the registry sentinel so apiserver
+// and CLI code can compare against the services package. Mirrors the
+// ErrLoanAlreadyReturned pattern.
+var ErrRegistrationAlreadyDecided = registry.ErrRegistrationAlreadyDecided
+
+// ErrRegistrationReasonRequired signals a rejection without a reason. The
+// reason is mailed to the applicant, so an empty one is refused rather
+// than sending a bare "no". Apiserver maps it to 422.
+var ErrRegistrationReasonRequired = errx.NewSentinel("a reason is required to reject a registration")
+
+// RegistrationApprovalService runs the approval queue for tenants in
+// RegistrationModeApproval. POST /register submits a request next to the
+// inactive user; a back-office operator (API) or a CLI operator approves
+// or rejects it. Approval activates the user.
+//
+// Emails are best-effort, like the other notifications: a failed send is
+// logged and never fails the decision. A nil EmailService (the CLI has no
+// transport) skips them.
+//
+// Audit logging stays with the callers, which know the actor: the admin
+// API logs with the back-office operator and request, the CLI through
+// services/admin.
+type RegistrationApprovalService struct {
+	factorySet *registry.FactorySet
+	emailSvc   EmailService
+	// loginURL is linked from the approval email. Empty omits the link.
+	loginURL string
+}
+
+func NewRegistrationApprovalService(factorySet *registry.FactorySet, emailSvc EmailService, loginURL string) *RegistrationApprovalService {
+	return &RegistrationApprovalService{
+		factorySet: factorySet,
+		emailSvc:   emailSvc,
+		loginURL:   strings.TrimSpace(loginURL),
+	}
+}
+
+// Submit queues the user's registration and notifies every active
+// back-office operator. The user must already exist (inactive).
+func (s *RegistrationApprovalService) Submit(ctx context.Context, user *models.User) (*models.RegistrationRequest, error) {
+	req, err := s.factorySet.RegistrationRequestRegistry.Create(ctx, models.RegistrationRequest{
+		TenantID: user.TenantID,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Name:     user.Name,
+	})
+	if err != nil {
+		return nil, errxtrace.Wrap("failed to queue registration request", err, errx.Attrs("user_id", user.ID))
+	}
+	s.notifyOperators(ctx, req)
+	return req, nil
+}
+
+// List returns the queue entries matching filter, oldest first.
+func (s *RegistrationApprovalService) List(ctx context.Context, filter registry.RegistrationRequestFilter) ([]*models.RegistrationRequest, error) {
+	return s.factorySet.RegistrationRequestRegistry.List(ctx, filter)
+}
+
+// Approve decides the request as approved and activates the user.
+// decidedBy is the operator id, nil for the CLI.
+//
+// The decision is recorded first: it is the step that settles a race
+// between two operators. If the activation then fails, the request stays
+// approved and the error is returned; unblocking the user
+// (POST /admin/users/{id}/unblock) activates it by hand.
+func (s *RegistrationApprovalService) Approve(ctx context.Context, id string, reason, decidedBy *string) (*models.RegistrationRequest, error) {
+	req, err := s.factorySet.RegistrationRequestRegistry.Decide(ctx, id, models.RegistrationRequestStatusApproved, trimmedOrNil(reason), decidedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.factorySet.UserRegistry.Get(ctx, req.UserID)
+	if err != nil {
+		return req, errxtrace.Wrap("registration approved but the user could not be loaded", err, errx.Attrs("user_id", req.UserID))
+	}
+	if !user.IsActive {
+		user.IsActive = true
+		if _, err := s.factorySet.UserRegistry.Update(ctx, *user); err != nil {
+			return req, errxtrace.Wrap("registration approved but the user could not be activated", err, errx.Attrs("user_id", req.UserID))
+		}
+	}
+
+	s.notifyApplicant(ctx, req, true)
+	return req, nil
+}
+
+// Reject decides the request as rejected. reason is required and is sent
+// to the applicant. The user row stays inactive, so a new registration
+// with the same email is still ignored; delete the user to free it.
+func (s *RegistrationApprovalService) Reject(ctx context.Context, id, reason string, decidedBy *string) (*models.RegistrationRequest, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errxtrace.Classify(ErrRegistrationReasonRequired, errx.Attrs("id", id))
+	}
+
+	req, err := s.factorySet.RegistrationRequestRegistry.Decide(ctx, id, models.RegistrationRequestStatusRejected, &reason, decidedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyApplicant(ctx, req, false)
+	return req, nil
+}
+
+// notifyOperators mails every active back-office operator about a new
+// request. The tenant name falls back to its id when the lookup fails.
+func (s *RegistrationApprovalService) notifyOperators(ctx context.Context, req *models.RegistrationRequest) {
+	if s.emailSvc == nil || s.factorySet.BackofficeUserRegistry == nil {
+		return
+	}
+
+	tenantName := req.TenantID
+	if tenant, err := s.factorySet.TenantRegistry.Get(ctx, req.TenantID); err == nil && tenant.Name != "" {
+		tenantName = tenant.Name
+	}
+
+	operators, err := s.factorySet.BackofficeUserRegistry.List(ctx)
+	if err != nil {
+		slog.Error("Failed to list back-office users for registration notice", "request_id", req.ID, "error", err)
+		return
+	}
+	notified := 0
+	for _, op := range operators {
+		if !op.IsActive {
+			continue
+		}
+		if err := s.emailSvc.SendRegistrationPendingEmail(ctx, op.Email, op.Name, req.Name, req.Email, tenantName); err != nil {
+			slog.Error("Failed to send registration notice", "request_id", req.ID, "operator_id", op.ID, "error", err)
+			continue
+		}
+		notified++
+	}
+	if notified == 0 {
+		slog.Warn("Registration is pending approval but no back-office operator was notified",
+			"request_id", req.ID, "tenant_id", req.TenantID)
+	}
+}
+
+// notifyApplicant mails the decision to the applicant.
+//
+//revive:disable-next-line:flag-parameter
+func (s *RegistrationApprovalService) notifyApplicant(ctx context.Context, req *models.RegistrationRequest, approved bool) {
+	if s.emailSvc == nil {
+		return
+	}
+	var reason string
+	if req.Reason != nil {
+		reason = *req.Reason
+	}
+	if err := s.emailSvc.SendRegistrationDecisionEmail(ctx, req.Email, req.Name, approved, reason, s.loginURL); err != nil {
+		slog.Error("Failed to send registration decision email", "request_id", req.ID, "approved", approved, "error", err)
+	}
+}
+
+// trimmedOrNil returns nil for a nil or blank string, else the trimmed
+// value.
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,175 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+)
+
+// registrationEmailRecorder captures the two registration emails; every
+// other Send* falls through to the embedded stub.
+type registrationEmailRecorder struct {
+	*services.StubEmailService
+
+	mu        sync.Mutex
+	pending   []string // operator addresses
+	decisions []registrationDecisionEmail
+}
+
+type registrationDecisionEmail struct {
+	to       string
+	approved bool
+	reason   string
+	loginURL string
+}
+
+func newRegistrationEmailRecorder() *registrationEmailRecorder {
+	return &registrationEmailRecorder{StubEmailService: services.NewStubEmailService()}
+}
+
+func (r *registrationEmailRecorder) SendRegistrationPendingEmail(_ context.Context, to, _, _, _, _ string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = append(r.pending, to)
+	return nil
+}
+
+func (r *registrationEmailRecorder) SendRegistrationDecisionEmail(_ context.Context, to, _ string, approved bool, reason, loginURL string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decisions = append(r.decisions, registrationDecisionEmail{to: to, approved: approved, reason: reason, loginURL: loginURL})
+	return nil
+}
+
+type registrationApprovalFixture struct {
+	factorySet *registry.FactorySet
+	email      *registrationEmailRecorder
+	svc        *services.RegistrationApprovalService
+	user       *models.User
+}
+
+// newRegistrationApprovalFixture seeds an inactive applicant plus one
+// active and one inactive back-office operator.
+func newRegistrationApprovalFixture(c *qt.C) *registrationApprovalFixture {
+	c.Helper()
+	ctx := context.Background()
+
+	factorySet := memory.NewFactorySet()
+	for _, op := range []models.BackofficeUser{
+		{Email: "ops@example.com", Name: "Ops", PasswordHash: "x", Role: models.BackofficeRolePlatformAdmin, IsActive: true},
+		{Email: "former@example.com", Name: "Former", PasswordHash: "x", Role: models.BackofficeRoleSupportAgent, IsActive: false},
+	} {
+		_, err := factorySet.BackofficeUserRegistry.Create(ctx, op)
+		c.Assert(err, qt.IsNil)
+	}
+
+	user, err := factorySet.UserRegistry.Create(ctx, models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: "test-tenant-id"},
+		Email:               "applicant@example.com",
+		Name:                "Applicant",
+		IsActive:            false,
+	})
+	c.Assert(err, qt.IsNil)
+
+	email := newRegistrationEmailRecorder()
+	return &registrationApprovalFixture{
+		factorySet: factorySet,
+		email:      email,
+		svc:        services.NewRegistrationApprovalService(factorySet, email, "https://inventario.example.com/login"),
+		user:       user,
+	}
+}
+
+func TestRegistrationApprovalService_Submit_NotifiesActiveOperators(t *testing.T) {
+	c := qt.New(t)
+	fx := newRegistrationApprovalFixture(c)
+
+	req, err := fx.svc.Submit(context.Background(), fx.user)
+	c.Assert(err, qt.IsNil)
+	c.Assert(req.Status, qt.Equals, models.RegistrationRequestStatusPending)
+	c.Assert(req.UserID, qt.Equals, fx.user.ID)
+	c.Assert(req.Email, qt.Equals, "applicant@example.com")
+	c.Assert(fx.email.pending, qt.DeepEquals, []string{"ops@example.com"})
+
+	// A second submission for the same user is refused.
+	_, err = fx.svc.Submit(context.Background(), fx.user)
+	c.Assert(err, qt.ErrorIs, registry.ErrAlreadyExists)
+}
+
+func TestRegistrationApprovalService_Approve_ActivatesUser(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	fx := newRegistrationApprovalFixture(c)
+
+	req, err := fx.svc.Submit(ctx, fx.user)
+	c.Assert(err, qt.IsNil)
+
+	decided, err := fx.svc.Approve(ctx, req.ID, new("  "), new("op-1"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(decided.Status, qt.Equals, models.RegistrationRequestStatusApproved)
+	c.Assert(decided.Reason, qt.IsNil) // blank reason is dropped
+	c.Assert(*decided.DecidedBy, qt.Equals, "op-1")
+
+	user, err := fx.factorySet.UserRegistry.Get(ctx, fx.user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.IsActive, qt.IsTrue)
+
+	c.Assert(fx.email.decisions, qt.HasLen, 1)
+	c.Assert(fx.email.decisions[0] == registrationDecisionEmail{
+		to:       "applicant@example.com",
+		approved: true,
+		loginURL: "https://inventario.example.com/login",
+	}, qt.IsTrue, qt.Commentf("got %+v", fx.email.decisions[0]))
+
+	_, err = fx.svc.Approve(ctx, req.ID, nil, nil)
+	c.Assert(err, qt.ErrorIs, services.ErrRegistrationAlreadyDecided)
+}
+
+func TestRegistrationApprovalService_Reject(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	fx := newRegistrationApprovalFixture(c)
+
+	req, err := fx.svc.Submit(ctx, fx.user)
+	c.Assert(err, qt.IsNil)
+
+	_, err = fx.svc.Reject(ctx, req.ID, "   ", nil)
+	c.Assert(err, qt.ErrorIs, services.ErrRegistrationReasonRequired)
+
+	decided, err := fx.svc.Reject(ctx, req.ID, " unknown applicant ", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(decided.Status, qt.Equals, models.RegistrationRequestStatusRejected)
+	c.Assert(*decided.Reason, qt.Equals, "unknown applicant")
+
+	user, err := fx.factorySet.UserRegistry.Get(ctx, fx.user.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.IsActive, qt.IsFalse)
+
+	c.Assert(fx.email.decisions, qt.HasLen, 1)
+	c.Assert(fx.email.decisions[0].approved, qt.IsFalse)
+	c.Assert(fx.email.decisions[0].reason, qt.Equals, "unknown applicant")
+
+	_, err = fx.svc.Approve(ctx, req.ID, nil, nil)
+	c.Assert(err, qt.ErrorIs, services.ErrRegistrationAlreadyDecided)
+}
+
+func TestRegistrationApprovalService_NilEmailService(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	fx := newRegistrationApprovalFixture(c)
+	svc := services.NewRegistrationApprovalService(fx.factorySet, nil, "")
+
+	req, err := svc.Submit(ctx, fx.user)
+	c.Assert(err, qt.IsNil)
+	_, err = svc.Approve(ctx, req.ID, nil, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(fx.email.pending, qt.HasLen, 0)
+	c.Assert(fx.email.decisions, qt.HasLen, 0)
+}
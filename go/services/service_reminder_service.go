@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	errxtrace "github.com/go-extras/errx/stacktrace"
+
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/services/notifications"
+)
+
+// defaultServiceReminderDueSoonDays is the inclusive forward-looking
+// window the worker scans for ServiceReminderKindDueSoon. Configurable
+// via --service-reminder-due-soon-days; 7 matches the loan reminder.
+const defaultServiceReminderDueSoonDays = 7
+
+// ServiceReminderService runs one service-reminder sweep at a time
+// across every group (service-mode). It is the commodity_services twin
+// of LoanReminderService: for each kind (overdue / due-soon) it lists
+// the open service rows whose expected_return_at falls into the window
+// and whose reminder_sent_* flag is still false, then for every row:
+//
+//  1. resolves the owner (the user who logged the service row);
+//  2. checks the owner's notifications.service_reminder × channel.email
+//     preference, including the per-group override — if disabled, SKIP
+//     both the email AND the flag flip so re-enabling resumes naturally;
+//  3. enqueues SendServiceReminderEmail through the AsyncEmailService;
+//  4. only on a successful enqueue, flips the reminder_sent_* flag
+//     atomically; a failed enqueue leaves the flag false so the next
+//     sweep retries;
+//  5. on a successful flip, emits a CommodityEvent of kind
+//     service_reminder_sent against the service row's commodity.
+type ServiceReminderService struct {
+	factorySet *registry.FactorySet
+	emailSvc   EmailService
+	// commodityURLBuilder builds the deep-link printed in the reminder
+	// email. Optional — when nil, the email suppresses the link block.
+	commodityURLBuilder func(groupSlug, commodityID string) string
+	// prefs is the per-user notification preferences service. Optional
+	// — when nil, every recipient is treated as opted-in (test path).
+	prefs *notifications.Service
+	// dueSoonDays is the forward-looking window for
+	// ServiceReminderKindDueSoon.
+	dueSoonDays int
+}
+
+// NewServiceReminderService constructs the service. emailSvc may be nil
+// in tests that only assert the flag-flip / event-emission side.
+func NewServiceReminderService(factorySet *registry.FactorySet, emailSvc EmailService, urlBuilder func(groupSlug, commodityID string) string) *ServiceReminderService {
+	return &ServiceReminderService{
+		factorySet:          factorySet,
+		emailSvc:            emailSvc,
+		commodityURLBuilder: urlBuilder,
+		dueSoonDays:         defaultServiceReminderDueSoonDays,
+	}
+}
+
+// WithPreferences attaches a notifications.Service so the worker gates
+// each recipient on their `notifications.service_reminder` ×
+// `channel.email` toggle (and the per-group override when the service
+// has group prefs wired).
+func (s *ServiceReminderService) WithPreferences(prefs *notifications.Service) *ServiceReminderService {
+	s.prefs = prefs
+	return s
+}
+
+// WithDueSoonDays overrides the default 7-day window for the due-soon
+// kind. Non-positive values are ignored.
+func (s *ServiceReminderService) WithDueSoonDays(d int) *ServiceReminderService {
+	if d > 0 {
+		s.dueSoonDays = d
+	}
+	return s
+}
+
+// ServiceReminderStats summarises one RemindOnce sweep. Same shape as
+// LoanReminderStats.
+type ServiceReminderStats struct {
+	Sent   map[registry.ServiceReminderKind]int
+	Failed int
+}
+
+// Total returns the cross-kind count of newly-sent reminders.
+func (s ServiceReminderStats) Total() int {
+	total := 0
+	for _, v := range s.Sent {
+		total += v
+	}
+	return total
+}
+
+// RemindOnce runs one sweep pinned to `now` for both kinds in sequence.
+// A non-nil error is only returned when the initial listing fails for
+// either kind — per-row failures bump stats.Failed and are logged.
+func (s *ServiceReminderService) RemindOnce(ctx context.Context, now time.Time) (ServiceReminderStats, error) {
+	stats := ServiceReminderStats{Sent: map[registry.ServiceReminderKind]int{}}
+	if s.factorySet == nil {
+		return stats, errxtrace.Wrap("service reminder service: factorySet is required", registry.ErrFieldRequired)
+	}
+	prefsCache := s.prefsForSweep()
+	for _, kind := range []registry.ServiceReminderKind{
+		registry.ServiceReminderKindOverdue,
+		registry.ServiceReminderKindDueSoon,
+	} {
+		sent, failed, err := s.sweepKind(ctx, kind, now, prefsCache)
+		if err != nil {
+			return stats, err
+		}
+		stats.Sent[kind] = sent
+		stats.Failed += failed
+	}
+	return stats, nil
+}
+
+func (s *ServiceReminderService) sweepKind(ctx context.Context, kind registry.ServiceReminderKind, now time.Time, prefsCache *notifications.Cache) (sent, failed int, listErr error) {
+	if s.factorySet.CommodityServiceRegistryFactory == nil {
+		return 0, 0, errxtrace.Wrap("service reminder: missing CommodityServiceRegistryFactory", registry.ErrFieldRequired)
+	}
+	svcReg := s.factorySet.CommodityServiceRegistryFactory.CreateServiceRegistry()
+	rows, err := svcReg.ListPendingReminders(ctx, kind, now, s.dueSoonDays)
+	if err != nil {
+		return 0, 0, errxtrace.Wrap("service reminder: list pending", err)
+	}
+	for _, row := range rows {
+		ok, processErr := s.processOne(ctx, svcReg, row, kind, now, prefsCache)
+		if processErr != nil {
+			failed++
+			slog.Error("service reminder failed",
+				"service_id", row.ID,
+				"commodity_id", row.CommodityID,
+				"kind", string(kind),
+				"error", processErr,
+			)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, failed, nil
+}
+
+// processOne handles one (service row, kind) pair. Same ordering and
+// return contract as LoanReminderService.processOne:
+//   - (true,  nil) — email enqueued AND flag flipped by us.
+//   - (false, nil) — no recipient (flag still flipped) OR opted out
+//     (flag NOT flipped) OR concurrent worker won the flip.
+//   - (false, err) — enqueue or flip-side failure that should retry.
+func (s *ServiceReminderService) processOne(ctx context.Context, svcReg registry.CommodityServiceRegistry, row *models.CommodityService, kind registry.ServiceReminderKind, now time.Time, prefsCache *notifications.Cache) (bool, error) {
+	owner, recipientEmail, recipientName := s.lookupOwner(ctx, row)
+	if recipientEmail == "" {
+		// No one to email. Flip the flag so the row stops matching on
+		// every tick — a structural skip, not a user opt-out.
+		flipped, err := svcReg.MarkReminderSent(ctx, row.ID, kind)
+		if err != nil {
+			return false, errxtrace.Wrap("service reminder: flip flag without recipient", err)
+		}
+		if flipped {
+			slog.Warn("service reminder: no recipient on file; flag flipped",
+				"service_id", row.ID,
+				"kind", string(kind),
+			)
+			if emitErr := s.emitAuditEvent(ctx, row, kind, ""); emitErr != nil {
+				slog.Warn("service reminder: emit audit event failed (no recipient)",
+					"service_id", row.ID,
+					"error", emitErr,
+				)
+			}
+		}
+		return false, nil
+	}
+
+	// Per-recipient opt-out (user-global or per-group): skip BOTH the
+	// send AND the flag flip. The flag is the only idempotency record,
+	// so flipping it here would silence the owner for this row even
+	// after they re-enable the category.
+	if prefsCache != nil && owner != nil && !prefsCache.IsEnabledForGroup(ctx, owner, row.TenantID, row.GroupID, notifications.CategoryServiceReminder, notifications.ChannelEmail) {
+		slog.Debug("service reminder: owner opted out; skipping send and flag flip",
+			"service_id", row.ID,
+			"group_id", row.GroupID,
+			"kind", string(kind),
+			"user_id", owner.ID,
+		)
+		return false, nil
+	}
+
+	if s.emailSvc == nil {
+		flipped, err := svcReg.MarkReminderSent(ctx, row.ID, kind)
+		if err != nil {
+			return false, errxtrace.Wrap("service reminder: flip flag (stub mode)", err)
+		}
+		if flipped {
+			if emitErr := s.emitAuditEvent(ctx, row, kind, recipientEmail); emitErr != nil {
+				slog.Warn("service reminder: emit audit event failed (stub mode)",
+					"service_id", row.ID,
+					"error", emitErr,
+				)
+			}
+		}
+		return flipped, nil
+	}
+
+	url := s.buildCommodityURL(ctx, row)
+	commodityName := s.lookupCommodityName(ctx, row.CommodityID)
+	daysDelta := computeDaysDelta(row.ExpectedReturnAt, now)
+	sendErr := s.emailSvc.SendServiceReminderEmail(
+		withReminderLanguage(ctx, prefsCache, owner),
+		recipientEmail,
+		recipientName,
+		commodityName,
+		row.ProviderName,
+		string(row.SentAt),
+		string(*row.ExpectedReturnAt),
+		url,
+		string(kind),
+		daysDelta,
+	)
+	if sendErr != nil {
+		return false, errxtrace.Wrap("service reminder: enqueue failed", sendErr)
+	}
+
+	flipped, flipErr := svcReg.MarkReminderSent(ctx, row.ID, kind)
+	if flipErr != nil {
+		return false, errxtrace.Wrap("service reminder: flip flag after send", flipErr)
+	}
+	if !flipped {
+		// Concurrent worker won the row; it owns the audit event.
+		return false, nil
+	}
+	if emitErr := s.emitAuditEvent(ctx, row, kind, recipientEmail); emitErr != nil {
+		slog.Warn("service reminder: emit audit event failed",
+			"service_id", row.ID,
+			"kind", string(kind),
+			"error", emitErr,
+		)
+	}
+	return true, nil
+}
+
+// lookupOwner returns the user who logged the service row plus their
+// email and display name. Empty email signals "no recipient on file".
+func (s *ServiceReminderService) lookupOwner(ctx context.Context, row *models.CommodityService) (owner *models.User, email, name string) {
+	if row == nil || row.CreatedByUserID == "" {
+		return nil, "", ""
+	}
+	if s.factorySet.UserRegistry == nil {
+		return nil, "", ""
+	}
+	u, err := s.factorySet.UserRegistry.Get(ctx, row.CreatedByUserID)
+	if err != nil || u == nil {
+		return nil, "", ""
+	}
+	email = strings.TrimSpace(u.Email)
+	if email == "" {
+		return u, "", ""
+	}
+	return u, email, u.Name
+}
+
+// emitAuditEvent writes a commodity_events row of kind
+// service_reminder_sent. `recipient` is empty when the flag flipped
+// without a send (no-recipient path).
+func (s *ServiceReminderService) emitAuditEvent(ctx context.Context, row *models.CommodityService, kind registry.ServiceReminderKind, recipient string) error {
+	if s.factorySet.CommodityEventRegistryFactory == nil {
+		return nil
+	}
+	payload := models.CommodityEventPayload{
+		"kind":       string(kind),
+		"service_id": row.ID,
+	}
+	if recipient != "" {
+		payload["recipient"] = recipient
+	}
+	event := models.CommodityEvent{
+		TenantGroupAwareEntityID: models.TenantGroupAwareEntityID{
+			TenantID:        row.TenantID,
+			GroupID:         row.GroupID,
+			CreatedByUserID: row.CreatedByUserID,
+		},
+		CommodityID: row.CommodityID,
+		Kind:        models.CommodityEventKindServiceReminderSent,
+		OccurredAt:  time.Now().UTC(),
+		After:       payload,
+	}
+	reg := s.factorySet.CommodityEventRegistryFactory.CreateServiceRegistry()
+	if _, err := reg.Create(ctx, event); err != nil {
+		return errxtrace.Wrap("emit service_reminder_sent event", err)
+	}
+	return nil
+}
+
+// lookupCommodityName resolves the commodity name for the email body.
+// Returns "" when the commodity can't be read; the template falls back
+// to "your item".
+func (s *ServiceReminderService) lookupCommodityName(ctx context.Context, commodityID string) string {
+	if s.factorySet.CommodityRegistryFactory == nil || commodityID == "" {
+		return ""
+	}
+	reg := s.factorySet.CommodityRegistryFactory.CreateServiceRegistry()
+	c, err := reg.Get(ctx, commodityID)
+	if err != nil || c == nil {
+		return ""
+	}
+	return c.Name
+}
+
+// buildCommodityURL composes the deep-link printed in the reminder
+// email. Falls back to "" when no PublicURL is configured or the row's
+// group can't be resolved.
+func (s *ServiceReminderService) buildCommodityURL(ctx context.Context, row *models.CommodityService) string {
+	if s.commodityURLBuilder == nil {
+		return ""
+	}
+	if s.factorySet.LocationGroupRegistry == nil {
+		return ""
+	}
+	group, err := s.factorySet.LocationGroupRegistry.Get(ctx, row.GroupID)
+	if err != nil || group == nil {
+		return ""
+	}
+	return s.commodityURLBuilder(group.Slug, row.CommodityID)
+}
+
+// prefsForSweep returns a per-sweep preferences cache when the service
+// is wired with a notifications.Service, or nil otherwise.
+func (s *ServiceReminderService) prefsForSweep() *notifications.Cache {
+	if s.prefs == nil {
+		return nil
+	}
+	return s.prefs.NewCache()
+}
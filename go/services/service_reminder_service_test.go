@@ -0,0 +1,272 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-extras/go-kit/must"
+
+	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/models"
+	"github.com/denisvmedia/inventario/registry"
+	"github.com/denisvmedia/inventario/registry/memory"
+	"github.com/denisvmedia/inventario/services"
+	"github.com/denisvmedia/inventario/services/notifications"
+)
+
+// recordingServiceEmailService captures SendServiceReminderEmail
+// invocations. Every other EmailService method is inherited as a no-op
+// from recordingLoanEmailService.
+type recordingServiceEmailService struct {
+	recordingLoanEmailService
+
+	serviceMu    sync.Mutex
+	serviceCalls []recordedServiceEmail
+}
+
+type recordedServiceEmail struct {
+	to               string
+	commodityName    string
+	providerName     string
+	expectedReturnAt string
+	commodityURL     string
+	kind             string
+	daysDelta        int
+}
+
+func (r *recordingServiceEmailService) SendServiceReminderEmail(_ context.Context, to, _, commodityName, providerName, _, expectedReturnAt, commodityURL, kind string, daysDelta int) error {
+	r.serviceMu.Lock()
+	defer r.serviceMu.Unlock()
+	r.serviceCalls = append(r.serviceCalls, recordedServiceEmail{
+		to:               to,
+		commodityName:    commodityName,
+		providerName:     providerName,
+		expectedReturnAt: expectedReturnAt,
+		commodityURL:     commodityURL,
+		kind:             kind,
+		daysDelta:        daysDelta,
+	})
+	return nil
+}
+
+func (r *recordingServiceEmailService) snapshot() []recordedServiceEmail {
+	r.serviceMu.Lock()
+	defer r.serviceMu.Unlock()
+	out := make([]recordedServiceEmail, len(r.serviceCalls))
+	copy(out, r.serviceCalls)
+	return out
+}
+
+// TestServiceReminderService_RemindOnce_TickClock mirrors the loan
+// reminder acceptance test: a service row expected back today gets one
+// due-soon reminder, a repeat sweep is a no-op, and the next day flips
+// it to a single overdue reminder with an audit event per send.
+func TestServiceReminderService_RemindOnce_TickClock(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, commodityID, factorySet := newServiceReminderServiceFixture(c)
+
+	expectedDate := models.Date("2026-05-17")
+	svcRow, err := regSet.CommodityServiceRegistry.Create(ctx, models.CommodityService{
+		CommodityID:      commodityID,
+		ProviderName:     "Bob's Repair",
+		SentAt:           "2026-05-10",
+		ExpectedReturnAt: &expectedDate,
+	})
+	c.Assert(err, qt.IsNil)
+
+	emailSvc := &recordingServiceEmailService{}
+	svc := services.NewServiceReminderService(factorySet, emailSvc, func(slug, id string) string {
+		return "https://example.test/g/" + slug + "/commodities/" + id
+	})
+
+	tick1 := time.Date(2026, 5, 17, 12, 0, 0, 0, time.UTC)
+	stats, err := svc.RemindOnce(ctx, tick1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Failed, qt.Equals, 0)
+	c.Assert(stats.Sent[registry.ServiceReminderKindDueSoon], qt.Equals, 1)
+	calls := emailSvc.snapshot()
+	c.Assert(calls, qt.HasLen, 1)
+	c.Assert(calls[0].kind, qt.Equals, "due_soon")
+	c.Assert(calls[0].to, qt.Equals, "owner@example.com")
+	c.Assert(calls[0].commodityName, qt.Equals, "laptop")
+	c.Assert(calls[0].providerName, qt.Equals, "Bob's Repair")
+	c.Assert(calls[0].expectedReturnAt, qt.Equals, "2026-05-17")
+	c.Assert(calls[0].commodityURL, qt.Equals, "https://example.test/g/svc-group/commodities/"+commodityID)
+
+	stats, err = svc.RemindOnce(ctx, tick1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Total(), qt.Equals, 0)
+
+	tick2 := tick1.AddDate(0, 0, 2)
+	stats, err = svc.RemindOnce(ctx, tick2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Sent[registry.ServiceReminderKindOverdue], qt.Equals, 1)
+	calls = emailSvc.snapshot()
+	c.Assert(calls, qt.HasLen, 2)
+	c.Assert(calls[1].kind, qt.Equals, "overdue")
+	c.Assert(calls[1].daysDelta, qt.Equals, 2)
+
+	events, _, err := regSet.CommodityEventRegistry.ListByCommodity(ctx, commodityID, 0, 50, registry.CommodityEventListOptions{})
+	c.Assert(err, qt.IsNil)
+	var reminderEvents int
+	for _, e := range events {
+		if e.Kind == models.CommodityEventKindServiceReminderSent {
+			reminderEvents++
+			c.Assert(e.After["service_id"], qt.Equals, svcRow.ID)
+		}
+	}
+	c.Assert(reminderEvents, qt.Equals, 2)
+}
+
+// TestServiceReminderService_CompletedNeverReminds pins that a row with
+// returned_at set is never a candidate, even when its ETA is long past.
+func TestServiceReminderService_CompletedNeverReminds(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, commodityID, factorySet := newServiceReminderServiceFixture(c)
+
+	expectedDate := models.Date("2026-05-01")
+	returnedDate := models.Date("2026-05-03")
+	_, err := regSet.CommodityServiceRegistry.Create(ctx, models.CommodityService{
+		CommodityID:      commodityID,
+		ProviderName:     "Bob's Repair",
+		SentAt:           "2026-04-20",
+		ExpectedReturnAt: &expectedDate,
+		ReturnedAt:       &returnedDate,
+	})
+	c.Assert(err, qt.IsNil)
+
+	emailSvc := &recordingServiceEmailService{}
+	svc := services.NewServiceReminderService(factorySet, emailSvc, nil)
+
+	stats, err := svc.RemindOnce(ctx, time.Date(2026, 5, 17, 12, 0, 0, 0, time.UTC))
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Total(), qt.Equals, 0)
+	c.Assert(emailSvc.snapshot(), qt.HasLen, 0)
+}
+
+// TestServiceReminderService_GroupOptOutSkipsFlagFlip covers the
+// per-group override: opting out of service reminders for the group
+// suppresses the send without flipping the flag, and opting back in
+// delivers the deferred reminder on the next sweep.
+func TestServiceReminderService_GroupOptOutSkipsFlagFlip(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, commodityID, factorySet := newServiceReminderServiceFixture(c)
+
+	expectedDate := models.Date("2026-05-01")
+	svcRow, err := regSet.CommodityServiceRegistry.Create(ctx, models.CommodityService{
+		CommodityID:      commodityID,
+		ProviderName:     "Bob's Repair",
+		SentAt:           "2026-04-20",
+		ExpectedReturnAt: &expectedDate,
+	})
+	c.Assert(err, qt.IsNil)
+
+	setGroupPref := func(enabled bool) {
+		_, err := factorySet.GroupNotificationPrefRegistry.Upsert(ctx, models.GroupNotificationPref{
+			TenantAwareEntityID: models.TenantAwareEntityID{TenantID: svcRow.TenantID},
+			GroupID:             svcRow.GroupID,
+			UserID:              svcRow.CreatedByUserID,
+			Category:            string(notifications.CategoryServiceReminder),
+			Enabled:             enabled,
+		})
+		c.Assert(err, qt.IsNil)
+	}
+	setGroupPref(false)
+
+	prefs := notifications.NewService(factorySet.SettingsRegistryFactory)
+	prefs.SetGroupPrefs(factorySet.GroupNotificationPrefRegistry)
+	emailSvc := &recordingServiceEmailService{}
+	svc := services.NewServiceReminderService(factorySet, emailSvc, nil).WithPreferences(prefs)
+
+	tick := time.Date(2026, 5, 17, 12, 0, 0, 0, time.UTC)
+	stats, err := svc.RemindOnce(ctx, tick)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Total(), qt.Equals, 0)
+	c.Assert(stats.Failed, qt.Equals, 0)
+	c.Assert(emailSvc.snapshot(), qt.HasLen, 0)
+	reloaded, err := regSet.CommodityServiceRegistry.Get(ctx, svcRow.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(reloaded.ReminderSentOverdue, qt.IsFalse)
+
+	setGroupPref(true)
+	stats, err = svc.RemindOnce(ctx, tick)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Sent[registry.ServiceReminderKindOverdue], qt.Equals, 1)
+	c.Assert(emailSvc.snapshot(), qt.HasLen, 1)
+}
+
+// TestServiceReminderWorker_Paused pins that the worker skips its sweep
+// while the service-reminder worker type is paused.
+func TestServiceReminderWorker_Paused(t *testing.T) {
+	c := qt.New(t)
+	ctx, regSet, commodityID, factorySet := newServiceReminderServiceFixture(c)
+
+	expectedDate := models.Date("2026-05-01")
+	_, err := regSet.CommodityServiceRegistry.Create(ctx, models.CommodityService{
+		CommodityID:      commodityID,
+		ProviderName:     "Bob's Repair",
+		SentAt:           "2026-04-20",
+		ExpectedReturnAt: &expectedDate,
+	})
+	c.Assert(err, qt.IsNil)
+
+	emailSvc := &recordingServiceEmailService{}
+	svc := services.NewServiceReminderService(factorySet, emailSvc, nil)
+	worker := services.NewServiceReminderWorker(svc,
+		services.WithServiceReminderInterval(time.Hour),
+		services.WithServiceReminderClock(func() time.Time { return time.Date(2026, 5, 17, 12, 0, 0, 0, time.UTC) }),
+		services.WithServiceReminderPauseController(pausedFor(models.WorkerTypeServiceReminder)),
+	)
+	worker.Start(ctx)
+	worker.Stop()
+	c.Assert(emailSvc.snapshot(), qt.HasLen, 0)
+}
+
+type pausedFor models.WorkerType
+
+func (p pausedFor) IsPaused(wt models.WorkerType) bool {
+	return wt == models.WorkerType(p)
+}
+
+// newServiceReminderServiceFixture wires a memory-backed factory with a
+// user, a group and one commodity the service-row tests can hang
+// records off.
+func newServiceReminderServiceFixture(c *qt.C) (context.Context, *registry.Set, string, *registry.FactorySet) {
+	c.Helper()
+	factorySet := memory.NewFactorySet()
+	u, err := factorySet.CreateServiceRegistrySet().UserRegistry.Create(context.Background(), models.User{
+		TenantAwareEntityID: models.TenantAwareEntityID{
+			EntityID: models.EntityID{ID: "svc-reminder-user"},
+			TenantID: "svc-reminder-tenant",
+		},
+		Email: "owner@example.com",
+		Name:  "Service Owner",
+	})
+	c.Assert(err, qt.IsNil)
+	group, err := factorySet.LocationGroupRegistry.Create(context.Background(), models.LocationGroup{
+		TenantAwareEntityID: models.TenantAwareEntityID{TenantID: u.TenantID},
+		Slug:                "svc-group",
+		Name:                "Service group",
+	})
+	c.Assert(err, qt.IsNil)
+	ctx := appctx.WithUser(context.Background(), u)
+	ctx = appctx.WithGroup(ctx, group)
+	regSet := must.Must(factorySet.CreateUserRegistrySet(ctx))
+	loc, err := regSet.LocationRegistry.Create(ctx, models.Location{Name: "L"})
+	c.Assert(err, qt.IsNil)
+	area, err := regSet.AreaRegistry.Create(ctx, models.Area{Name: "A", LocationID: loc.ID})
+	c.Assert(err, qt.IsNil)
+	commodity, err := regSet.CommodityRegistry.Create(ctx, models.Commodity{
+		AreaID:    new(area.ID),
+		Name:      "laptop",
+		ShortName: "laptop",
+		Type:      models.CommodityTypeElectronics,
+		Status:    models.CommodityStatusInUse,
+		Count:     1,
+	})
+	c.Assert(err, qt.IsNil)
+	return ctx, regSet, commodity.ID, factorySet
+}
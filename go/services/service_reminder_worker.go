@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
+	"github.com/denisvmedia/inventario/models"
+)
+
+const defaultServiceReminderInterval = 1 * time.Hour
+
+// Prometheus counters for the service reminder worker. Labels:
+//   - kind: "overdue" or "due_soon" — matches ServiceReminderKind.
+//
+// `_failures_total` is unlabelled for the same reason as the loan
+// reminder counter: every failure is logged with its kind.
+var (
+	serviceRemindersSentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "inventario_service_reminders_sent_total",
+		Help: "Number of service reminder emails enqueued, partitioned by kind (overdue|due_soon).",
+	}, []string{"kind"})
+	serviceReminderFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inventario_service_reminder_failures_total",
+		Help: "Number of per-service reminder failures (logged; will be retried next tick).",
+	})
+)
+
+// ServiceReminderWorker periodically runs ServiceReminderService.RemindOnce.
+// Same shape as LoanReminderWorker: single goroutine driven by a
+// ticker, best-effort graceful stop on Stop().
+type ServiceReminderWorker struct {
+	service  *ServiceReminderService
+	interval time.Duration
+	clock    func() time.Time
+	pause    PauseChecker
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// ServiceReminderOption customises a ServiceReminderWorker.
+type ServiceReminderOption func(*serviceReminderOptions)
+
+type serviceReminderOptions struct {
+	interval time.Duration
+	clock    func() time.Time
+	pause    PauseChecker
+}
+
+// WithServiceReminderInterval overrides the default tick cadence. Non-
+// positive values are ignored.
+func WithServiceReminderInterval(d time.Duration) ServiceReminderOption {
+	return func(o *serviceReminderOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+// WithServiceReminderClock overrides the now-source the worker hands to
+// RemindOnce. Tests pin time.Time{} via a closure; production passes
+// time.Now indirectly via the default.
+func WithServiceReminderClock(now func() time.Time) ServiceReminderOption {
+	return func(o *serviceReminderOptions) {
+		if now != nil {
+			o.clock = now
+		}
+	}
+}
+
+// WithServiceReminderPauseController wires the soft-pause controller so the
+// worker skips its sweep while the service-reminder worker type is paused
+// A nil checker leaves the worker unpaused.
+func WithServiceReminderPauseController(pc PauseChecker) ServiceReminderOption {
+	return func(o *serviceReminderOptions) {
+		if pc != nil {
+			o.pause = pc
+		}
+	}
+}
+
+// NewServiceReminderWorker constructs the worker. Default cadence: one
+// hour, matching the loan reminder worker.
+func NewServiceReminderWorker(service *ServiceReminderService, opts ...ServiceReminderOption) *ServiceReminderWorker {
+	options := serviceReminderOptions{
+		interval: defaultServiceReminderInterval,
+		clock:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &ServiceReminderWorker{
+		service:  service,
+		interval: options.interval,
+		clock:    options.clock,
+		pause:    options.pause,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine. No-op when no service is configured.
+func (w *ServiceReminderWorker) Start(ctx context.Context) {
+	if w.service == nil {
+		slog.Warn("ServiceReminderWorker: no service configured, skipping startup")
+		return
+	}
+	w.wg.Go(func() {
+		w.run(ctx)
+	})
+	slog.Info("Service reminder worker started", "interval", w.interval)
+}
+
+// Stop signals the worker and waits for the goroutine to exit.
+func (w *ServiceReminderWorker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+	})
+	w.wg.Wait()
+	slog.Info("Service reminder worker stopped")
+}
+
+func (w *ServiceReminderWorker) run(ctx context.Context) {
+	// Run once at startup so the first tick doesn't have to wait the
+	// full interval after a deploy. Matches the loan worker.
+	w.tick(ctx)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+// tick runs a single sweep. The service returns ServiceReminderStats with
+// a per-kind breakdown so the worker emits one Prometheus series per
+// kind label.
+func (w *ServiceReminderWorker) tick(ctx context.Context) {
+	// Soft-pause: skip the sweep while paused. The ticker keeps
+	// running so resuming takes effect on the next tick without a restart.
+	if w.pause != nil && w.pause.IsPaused(models.WorkerTypeServiceReminder) {
+		return
+	}
+
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeServiceReminder))
+	defer span.End()
+
+	stats, err := w.service.RemindOnce(ctx, w.clock())
+	if err != nil {
+		slog.Error("Service reminder sweep failed", "error", err)
+		return
+	}
+	if stats.Failed > 0 {
+		serviceReminderFailuresTotal.Add(float64(stats.Failed))
+	}
+	for kind, count := range stats.Sent {
+		if count > 0 {
+			serviceRemindersSentTotal.
+				WithLabelValues(string(kind)).
+				Add(float64(count))
+		}
+	}
+	if total := stats.Total(); total > 0 {
+		slog.Info("Service reminder sweep completed",
+			"reminders_sent", total,
+			"failed", stats.Failed,
+		)
+	} else {
+		slog.Debug("Service reminder sweep completed",
+			"reminders_sent", 0,
+			"failed", stats.Failed,
+		)
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	errxtrace "github.com/go-extras/errx/stacktrace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/denisvmedia/inventario/appctx"
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -97,6 +99,7 @@ func (s *ThumbnailGenerationService) RequestThumbnailGeneration(ctx context.Cont
 		Status:       models.ThumbnailStatusPending,
 		AttemptCount: 0,
 		MaxAttempts:  3,
+		TraceParent:  tracing.TraceParent(ctx),
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
@@ -126,7 +129,7 @@ func (s *ThumbnailGenerationService) Stop() {
 }
 
 // ProcessThumbnailGeneration processes a single thumbnail generation job with resource limiting
-func (s *ThumbnailGenerationService) ProcessThumbnailGeneration(ctx context.Context, jobID string) error {
+func (s *ThumbnailGenerationService) ProcessThumbnailGeneration(ctx context.Context, jobID string) (err error) {
 	jobRegistry := s.factorySet.ThumbnailGenerationJobRegistryFactory.CreateServiceRegistry()
 
 	// Get the job
@@ -135,6 +138,9 @@ func (s *ThumbnailGenerationService) ProcessThumbnailGeneration(ctx context.Cont
 		return errxtrace.Wrap("failed to get thumbnail generation job", err)
 	}
 
+	ctx, span := tracing.StartJob(ctx, "thumbnail.generate", job.TraceParent, attribute.String("inventario.thumbnail_job.id", job.ID))
+	defer func() { tracing.End(span, err) }()
+
 	// Skip if job is not pending
 	if job.Status != models.ThumbnailStatusPending {
 		slog.Debug("Skipping non-pending thumbnail job", "job_id", jobID, "status", job.Status)
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/denisvmedia/inventario/internal/observability/tracing"
 	"github.com/denisvmedia/inventario/models"
 	"github.com/denisvmedia/inventario/registry"
 )
@@ -261,6 +262,9 @@ func (w *ThumbnailGenerationWorker) processPendingJobs(ctx context.Context) {
 		return
 	}
 
+	ctx, span := tracing.StartWorkerIteration(ctx, string(models.WorkerTypeThumbnail))
+	defer span.End()
+
 	jobs, err := w.thumbnailService.GetPendingJobs(ctx, w.jobBatchSize)
 	if err != nil {
 		slog.Error("Failed to get pending thumbnail jobs", "error", err)
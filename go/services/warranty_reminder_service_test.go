@@ -83,6 +83,25 @@ func (*recordingEmailService) SendMaintenanceReminderEmail(_ context.Context, _,
 	return nil
 }
 
+func (*recordingEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+
+func (*recordingEmailService) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return nil
+}
+func (*recordingEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return nil
+}
+
+func (*recordingEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return nil
+}
+
 func (*recordingEmailService) SendFeedbackEmail(_ context.Context, _, _, _, _, _, _, _ string, _ []string) error {
 	return nil
 }
@@ -130,6 +149,24 @@ func (failingEmailService) SendLoanReminderEmail(_ context.Context, _, _, _, _,
 func (failingEmailService) SendMaintenanceReminderEmail(_ context.Context, _, _, _, _, _, _ string, _ int) error {
 	return errors.New("queue down")
 }
+func (failingEmailService) SendServiceReminderEmail(_ context.Context, _, _, _, _, _, _, _, _ string, _ int) error {
+	return errors.New("queue down")
+}
+
+func (failingEmailService) SendLoanBorrowerReminderEmail(_ context.Context, _, _, _, _, _, _, _ string, _ int) error {
+	return errors.New("queue down")
+}
+func (failingEmailService) SendBackupFailureEmail(_ context.Context, _, _, _, _, _ string) error {
+	return errors.New("queue down")
+}
+
+func (failingEmailService) SendRegistrationPendingEmail(_ context.Context, _, _, _, _, _ string) error {
+	return errors.New("queue down")
+}
+
+func (failingEmailService) SendRegistrationDecisionEmail(_ context.Context, _, _ string, _ bool, _, _ string) error {
+	return errors.New("queue down")
+}
 func (failingEmailService) SendFeedbackEmail(_ context.Context, _, _, _, _, _, _, _ string, _ []string) error {
 	return errors.New("queue down")
 }